@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// expandAlias replaces a user-defined alias in the first argument with
+// the arguments it expands to. Built-in commands and their aliases always
+// take precedence, so an alias can never shadow them.
+func expandAlias(root *cobra.Command, args []string, aliases map[string]string) []string {
+	if len(args) == 0 {
+		return args
+	}
+	expansion, ok := aliases[args[0]]
+	if !ok || isBuiltin(root, args[0]) {
+		return args
+	}
+	return append(strings.Fields(expansion), args[1:]...)
+}
+
+// isBuiltin reports whether name is a top-level command of root or one of
+// its aliases. help and completion are added by cobra at execution time.
+func isBuiltin(root *cobra.Command, name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	c, _, err := root.Find([]string{name})
+	return err == nil && c != root
+}
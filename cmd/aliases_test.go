@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpandAlias(t *testing.T) {
+	root := rootCmd()
+	aliases := map[string]string{
+		"web":    "server ssh web-1",
+		"server": "find tag:env=prod",
+		"s":      "stats",
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"web", "--user", "ubuntu"}, []string{"server", "ssh", "web-1", "--user", "ubuntu"}},
+		{[]string{"server", "list"}, []string{"server", "list"}},
+		{[]string{"s", "ls"}, []string{"s", "ls"}},
+		{[]string{"other"}, []string{"other"}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := expandAlias(root, tt.args, aliases); !slices.Equal(got, tt.want) {
+			t.Errorf("expandAlias(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRootCmd_ShortDNSAliases(t *testing.T) {
+	root := rootCmd()
+	cmd, _, err := root.Find([]string{"d", "rec", "add"})
+	if err != nil {
+		t.Fatalf("Find() error: %v", err)
+	}
+	if got := cmd.CommandPath(); got != "vpsm dns record create" {
+		t.Errorf("'d rec add' resolved to %q, want vpsm dns record create", got)
+	}
+}
@@ -14,6 +14,7 @@ Use this command group to log in and store API tokens securely.`,
 	}
 
 	cmd.AddCommand(LoginCommand())
+	cmd.AddCommand(ProjectCommand())
 	cmd.AddCommand(StatusCommand())
 
 	return cmd
@@ -1,11 +1,14 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/services/tokencheck"
 	"nathanbeddoewebdev/vpsm/internal/tui"
 
 	"github.com/spf13/cobra"
@@ -18,8 +21,25 @@ func LoginCommand() *cobra.Command {
 		Short: "Store an API token for a provider",
 		Long: `Store an API token for a provider using the local keychain.
 
-Example:
-  vpsm auth login hetzner`,
+The token is first checked with one read request (listing servers, or
+zones for DNS providers) and only stored if the provider accepts it.
+The check shows that the token can read; it cannot tell a read-only
+token from a read/write one, or which zones a scoped Cloudflare token may
+edit, since vpsm does not read a token's permissions. Missing ones show
+up as permission errors on the first change.
+--no-verify stores a --token without the check.
+
+Providers such as Hetzner scope tokens to a project. Use --project to
+store one token per project, then pick one with --project on other
+commands or make it the default with 'vpsm auth project'. A profile name
+such as "hetzner:work" is the same as --project work: keep several
+accounts at one provider and pick one with --profile hetzner:work or a
+.vpsm.json file.
+
+Examples:
+  vpsm auth login hetzner
+  vpsm auth login hetzner --project staging
+  vpsm auth login hetzner:work`,
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			provider := strings.TrimSpace(args[0])
@@ -35,7 +55,17 @@ Example:
 			}
 
 			token = strings.TrimSpace(token)
-			store := auth.DefaultStore()
+			project, _ := cmd.Flags().GetString("project")
+			project = strings.TrimSpace(project)
+			provider, profile := auth.ParseProfile(provider)
+			if profile != "" {
+				if project != "" && auth.NormalizeProvider(project) != profile {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Error: profile %s conflicts with --project %s\n", profile, project)
+					return
+				}
+				project = profile
+			}
+			store := auth.WithProject(auth.DefaultStore(), project)
 
 			if token == "" {
 				// Interactive mode: use TUI if running in a terminal.
@@ -46,7 +76,10 @@ Example:
 						return
 					}
 					if result != nil && result.Saved {
-						fmt.Fprintf(cmd.OutOrStdout(), "Saved token for provider %s\n", provider)
+						if result.Verified != nil {
+							fmt.Fprintf(cmd.OutOrStdout(), "Token accepted by %s\n", result.Verified)
+						}
+						recordProject(cmd, provider, project)
 					} else {
 						fmt.Fprintln(cmd.ErrOrStderr(), "Login cancelled.")
 					}
@@ -62,16 +95,53 @@ Example:
 				return
 			}
 
+			if noVerify, _ := cmd.Flags().GetBool("no-verify"); !noVerify {
+				result, err := verifyToken(cmd.Context(), provider, token)
+				switch {
+				case errors.Is(err, tokencheck.ErrUnsupported):
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: cannot verify %s tokens; saving it unchecked\n", provider)
+				case err != nil:
+					fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+					return
+				default:
+					fmt.Fprintf(cmd.OutOrStdout(), "Token accepted by %s\n", result)
+				}
+			}
+
 			if err := store.SetToken(provider, token); err != nil {
 				fmt.Fprintln(cmd.ErrOrStderr(), err)
 				return
 			}
 
-			fmt.Fprintf(cmd.OutOrStdout(), "Saved token for provider %s\n", provider)
+			recordProject(cmd, provider, project)
 		},
 	}
 
 	cmd.Flags().String("token", "", "API token (optional, overrides prompt)")
+	cmd.Flags().String("project", "", "Project the token belongs to (stores it alongside other projects' tokens)")
+	cmd.Flags().Bool("no-verify", false, "Store the --token without checking it with the provider")
 
 	return cmd
 }
+
+// verifyToken checks a token with the provider. Tests replace it.
+var verifyToken = tokencheck.Verify
+
+// recordProject reports a saved token and, for a project token, adds the
+// project to the config so it can be listed and selected.
+func recordProject(cmd *cobra.Command, provider, project string) {
+	if project == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Saved token for provider %s\n", provider)
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Saved token for provider %s (project %s)\n", provider, project)
+
+	cfg, err := config.Load()
+	if err == nil {
+		cfg.AddProject(provider, project)
+		err = cfg.Save()
+	}
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to record project: %v\n", err)
+	}
+}
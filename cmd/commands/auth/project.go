@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/util"
+
+	"github.com/spf13/cobra"
+)
+
+func ProjectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project <provider> [project]",
+		Short: "List or select a provider's projects",
+		Long: `List the projects with a stored token for a provider, or select the
+project commands use when --project is not given.
+
+Examples:
+  vpsm auth project hetzner             # List projects
+  vpsm auth project hetzner staging     # Use the staging project by default
+  vpsm auth project hetzner --unset     # Use the token stored without a project`,
+		Args: cobra.RangeArgs(1, 2),
+		Run:  runProject,
+	}
+
+	cmd.Flags().Bool("unset", false, "Stop using a project and fall back to the provider's default token")
+
+	return cmd
+}
+
+func runProject(cmd *cobra.Command, args []string) {
+	provider := util.NormalizeKey(args[0])
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	unset, _ := cmd.Flags().GetBool("unset")
+	switch {
+	case unset:
+		cfg.SetActiveProject(provider, "")
+	case len(args) == 2:
+		project := util.NormalizeKey(args[1])
+		if !slices.Contains(cfg.ProjectsFor(provider), project) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: unknown project %q for %s: log in with 'vpsm auth login %s --project %s'\n", project, provider, provider, project)
+			return
+		}
+		cfg.SetActiveProject(provider, project)
+	default:
+		listProjects(cmd, cfg, provider)
+		return
+	}
+
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if saved := cfg.SavedProject(provider); saved != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Using project %s for %s\n", saved, provider)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Using the default token for %s\n", provider)
+	}
+	if profile, ok := config.SelectedProfile(provider); ok && profile != cfg.SavedProject(provider) {
+		fmt.Fprintf(cmd.OutOrStdout(), "Note: the profile selected for this directory (%s) takes precedence here.\n", profile)
+	}
+}
+
+func listProjects(cmd *cobra.Command, cfg *config.Config, provider string) {
+	projects := cfg.ProjectsFor(provider)
+	if len(projects) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No projects for %s. Add one with 'vpsm auth login %s --project <name>'.\n", provider, provider)
+		return
+	}
+	saved := cfg.SavedProject(provider)
+	profile, _ := config.SelectedProfile(provider)
+	for _, project := range projects {
+		marker := "  "
+		if project == saved {
+			marker = "* "
+		}
+		line := marker + project
+		if project == profile {
+			line += " (the profile selected for this directory)"
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+)
+
+func setupProjects(t *testing.T, projects ...string) {
+	t.Helper()
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+
+	cfg := &config.Config{}
+	for _, p := range projects {
+		cfg.AddProject("hetzner", p)
+	}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+}
+
+func execProject(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"project"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestProject_SelectAndList(t *testing.T) {
+	setupProjects(t, "prod", "staging")
+
+	stdout, stderr := execProject(t, "hetzner", "staging")
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	if !strings.Contains(stdout, "Using project staging for hetzner") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+
+	stdout, _ = execProject(t, "hetzner")
+	if !strings.Contains(stdout, "* staging") || !strings.Contains(stdout, "  prod") {
+		t.Errorf("expected staging marked active, got:\n%s", stdout)
+	}
+
+	execProject(t, "hetzner", "--unset")
+	cfg, _ := config.Load()
+	if got := cfg.ActiveProject("hetzner"); got != "" {
+		t.Errorf("expected no active project after --unset, got %q", got)
+	}
+}
+
+func TestProject_UnknownProject(t *testing.T) {
+	setupProjects(t, "prod")
+
+	_, stderr := execProject(t, "hetzner", "staging")
+
+	if !strings.Contains(stderr, "unknown project") {
+		t.Errorf("expected unknown project error, got: %s", stderr)
+	}
+}
+
+func TestProject_ListShowsDirectoryProfileSeparately(t *testing.T) {
+	setupProjects(t, "prod", "staging")
+	t.Cleanup(config.ResetProfiles)
+	execProject(t, "hetzner", "prod")
+	config.UseProfile("hetzner", "staging")
+
+	stdout, _ := execProject(t, "hetzner")
+	if !strings.Contains(stdout, "* prod\n") {
+		t.Errorf("expected the saved project marked active, got:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "  staging (the profile selected for this directory)") {
+		t.Errorf("expected staging shown as the directory's profile, got:\n%s", stdout)
+	}
+
+	stdout, _ = execProject(t, "hetzner", "prod")
+	if !strings.Contains(stdout, "Using project prod for hetzner") || !strings.Contains(stdout, "profile selected for this directory (staging)") {
+		t.Errorf("unexpected output: %s", stdout)
+	}
+}
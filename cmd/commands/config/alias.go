@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// AliasCommand returns the "config alias" command.
+func AliasCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage command aliases",
+		Long: `Define shortcuts that expand to a longer command line before it is parsed.
+
+Built-in commands and their short forms (s, g, key, ls, rm) always take
+precedence, so an alias cannot shadow them.
+
+Examples:
+  vpsm config alias set web server ssh web-1   # vpsm web == vpsm server ssh web-1
+  vpsm config alias list
+  vpsm config alias unset web`,
+		Run: runAliasList,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set <name> <command...>",
+		Short: "Define an alias",
+		Args:  cobra.MinimumNArgs(2),
+		Run:   runAliasSet,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unset <name>",
+		Short: "Remove an alias",
+		Args:  cobra.ExactArgs(1),
+		Run:   runAliasUnset,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List aliases",
+		Args:  cobra.ExactArgs(0),
+		Run:   runAliasList,
+	})
+
+	return cmd
+}
+
+func runAliasSet(cmd *cobra.Command, args []string) {
+	name, expansion := args[0], strings.Join(args[1:], " ")
+	if strings.HasPrefix(name, "-") || strings.ContainsAny(name, " \t") {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: invalid alias name %q\n", name)
+		return
+	}
+	if c, _, err := cmd.Root().Find([]string{name}); err == nil && c != cmd.Root() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %q is a built-in command and cannot be an alias\n", name)
+		return
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	cfg.SetAlias(name, expansion)
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s now runs %q\n", name, expansion)
+}
+
+func runAliasUnset(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if _, ok := cfg.Aliases[args[0]]; !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: no alias named %q\n", args[0])
+		return
+	}
+	cfg.SetAlias(args[0], "")
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed alias %s\n", args[0])
+}
+
+func runAliasList(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if len(cfg.Aliases) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No aliases. Add one with 'vpsm config alias set <name> <command...>'.")
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Aliases))
+	for name := range cfg.Aliases {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s = %s\n", name, cfg.Aliases[name])
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+)
+
+func TestAlias_SetListUnset(t *testing.T) {
+	setupTestConfig(t)
+
+	if _, stderr := execConfig(t, "alias", "set", "web", "server", "ssh", "web-1"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if got := cfg.Aliases["web"]; got != "server ssh web-1" {
+		t.Errorf("alias web = %q, want %q", got, "server ssh web-1")
+	}
+
+	stdout, _ := execConfig(t, "alias", "list")
+	if !strings.Contains(stdout, "web = server ssh web-1") {
+		t.Errorf("expected the alias in the list, got: %s", stdout)
+	}
+
+	if _, stderr := execConfig(t, "alias", "unset", "web"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, _ = config.Load()
+	if len(cfg.Aliases) != 0 {
+		t.Errorf("expected no aliases after unset, got %v", cfg.Aliases)
+	}
+}
+
+func TestAlias_RejectsBuiltin(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "alias", "set", "get", "server", "list")
+
+	if !strings.Contains(stderr, "built-in command") {
+		t.Errorf("expected a built-in command error, got: %s", stderr)
+	}
+}
+
+func TestAlias_UnsetUnknown(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "alias", "unset", "nope")
+
+	if !strings.Contains(stderr, `no alias named "nope"`) {
+		t.Errorf("expected an unknown alias error, got: %s", stderr)
+	}
+}
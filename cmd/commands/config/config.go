@@ -18,6 +18,8 @@ func NewCommand() *cobra.Command {
 
 	cmd.AddCommand(SetCommand())
 	cmd.AddCommand(GetCommand())
+	cmd.AddCommand(AliasCommand())
+	cmd.AddCommand(EndpointCommand())
 
 	return cmd
 }
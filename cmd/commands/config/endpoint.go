@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/util"
+
+	"github.com/spf13/cobra"
+)
+
+// EndpointCommand returns the "config endpoint" command.
+func EndpointCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "endpoint",
+		Short: "Point a provider at an alternate API endpoint",
+		Long: `Send a provider's API requests to another base URL, such as an
+API-compatible gateway, a proxy or a mock server.
+
+--ca-cert adds the certificate authorities in a PEM file to the ones the
+system trusts, for endpoints served with a private CA.
+
+Examples:
+  vpsm config endpoint set hetzner https://hcloud-gw.internal/v1 --ca-cert ~/gw-ca.pem
+  vpsm config endpoint set digitalocean http://localhost:8080/v2
+  vpsm config endpoint list
+  vpsm config endpoint unset hetzner`,
+		Run: runEndpointList,
+	}
+
+	set := &cobra.Command{
+		Use:   "set <provider> <url>",
+		Short: "Override a provider's API endpoint",
+		Args:  cobra.ExactArgs(2),
+		Run:   runEndpointSet,
+	}
+	set.Flags().String("ca-cert", "", "PEM file of extra certificate authorities to trust for the endpoint")
+	cmd.AddCommand(set)
+	cmd.AddCommand(&cobra.Command{
+		Use:   "unset <provider>",
+		Short: "Return a provider to its default endpoint",
+		Args:  cobra.ExactArgs(1),
+		Run:   runEndpointUnset,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List endpoint overrides",
+		Args:  cobra.ExactArgs(0),
+		Run:   runEndpointList,
+	})
+
+	return cmd
+}
+
+func runEndpointSet(cmd *cobra.Command, args []string) {
+	provider, rawURL := args[0], args[1]
+	if err := validateProvider(cmd, provider); err != nil {
+		return
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: invalid endpoint %q: expected an http or https URL\n", rawURL)
+		return
+	}
+
+	e := config.Endpoint{URL: rawURL}
+	if caCert, _ := cmd.Flags().GetString("ca-cert"); caCert != "" {
+		if e.CACert, err = filepath.Abs(caCert); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		if _, err := providers.LoadCAPool(e.CACert); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	cfg.SetEndpoint(provider, e)
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s now uses %s\n", util.NormalizeKey(provider), rawURL)
+	if u.Scheme == "http" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Warning: the endpoint is not encrypted; your API token is sent in plain text.")
+	}
+}
+
+func runEndpointUnset(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if _, ok := cfg.EndpointFor(args[0]); !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: no endpoint override for %q\n", args[0])
+		return
+	}
+	cfg.SetEndpoint(args[0], config.Endpoint{})
+	if err := cfg.Save(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s uses its default endpoint again\n", util.NormalizeKey(args[0]))
+}
+
+func runEndpointList(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if len(cfg.Endpoints) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No endpoint overrides. Add one with 'vpsm config endpoint set <provider> <url>'.")
+		return
+	}
+
+	names := make([]string, 0, len(cfg.Endpoints))
+	for name := range cfg.Endpoints {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		e := cfg.Endpoints[name]
+		line := fmt.Sprintf("%s = %s", name, e.URL)
+		if e.CACert != "" {
+			line += " (CA " + e.CACert + ")"
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+}
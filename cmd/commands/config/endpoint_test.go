@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+)
+
+func TestEndpoint_SetListUnset(t *testing.T) {
+	setupTestConfig(t)
+	registerTestProvider(t, "hetzner")
+
+	if _, stderr := execConfig(t, "endpoint", "set", "Hetzner", "https://gw.example.com/v1"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if e, ok := cfg.EndpointFor("hetzner"); !ok || e.URL != "https://gw.example.com/v1" {
+		t.Errorf("endpoint = %+v, %v", e, ok)
+	}
+
+	stdout, _ := execConfig(t, "endpoint", "list")
+	if !strings.Contains(stdout, "hetzner = https://gw.example.com/v1") {
+		t.Errorf("expected the endpoint in the list, got: %s", stdout)
+	}
+
+	if _, stderr := execConfig(t, "endpoint", "unset", "hetzner"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, _ = config.Load()
+	if len(cfg.Endpoints) != 0 {
+		t.Errorf("expected no endpoints after unset, got %v", cfg.Endpoints)
+	}
+}
+
+func TestEndpoint_RejectsInvalidURL(t *testing.T) {
+	setupTestConfig(t)
+	registerTestProvider(t, "hetzner")
+
+	_, stderr := execConfig(t, "endpoint", "set", "hetzner", "gw.example.com")
+
+	if !strings.Contains(stderr, "expected an http or https URL") {
+		t.Errorf("expected an invalid URL error, got: %s", stderr)
+	}
+}
+
+func TestEndpoint_RejectsCAWithoutCertificates(t *testing.T) {
+	setupTestConfig(t)
+	registerTestProvider(t, "hetzner")
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(ca, []byte("not a certificate"), 0o600)
+
+	_, stderr := execConfig(t, "endpoint", "set", "hetzner", "https://gw.example.com", "--ca-cert", ca)
+
+	if !strings.Contains(stderr, "no PEM certificates") {
+		t.Errorf("expected a CA error, got: %s", stderr)
+	}
+	if cfg, _ := config.Load(); len(cfg.Endpoints) != 0 {
+		t.Errorf("expected nothing saved, got %v", cfg.Endpoints)
+	}
+}
+
+func TestEndpoint_WarnsAboutPlainHTTP(t *testing.T) {
+	setupTestConfig(t)
+	registerTestProvider(t, "hetzner")
+
+	_, stderr := execConfig(t, "endpoint", "set", "hetzner", "http://localhost:8080")
+
+	if !strings.Contains(stderr, "not encrypted") {
+		t.Errorf("expected a plain HTTP warning, got: %s", stderr)
+	}
+}
@@ -2,10 +2,19 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
 	providernames "nathanbeddoewebdev/vpsm/internal/platform/providers/names"
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
+	"nathanbeddoewebdev/vpsm/internal/server/services/idle"
+	"nathanbeddoewebdev/vpsm/internal/server/services/nativessh"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
 	"nathanbeddoewebdev/vpsm/internal/util"
 
 	"github.com/spf13/cobra"
@@ -24,13 +33,28 @@ func SetCommand() *cobra.Command {
 		Run:  runSet,
 	}
 
+	// Stop flag parsing at the key so values such as ssh options
+	// ("-o ForwardAgent=yes") are not taken for flags.
+	cmd.Flags().SetInterspersed(false)
+
 	return cmd
 }
 
 // validators maps key names to optional pre-save validation functions.
 // Keys not present in this map have no extra validation.
 var validators = map[string]func(cmd *cobra.Command, value string) error{
-	"default-provider": validateProvider,
+	"default-provider":       validateProvider,
+	"locale":                 validateLocale,
+	"idle-window":            validateIdleWindow,
+	"usage-stats":            validateOnOff,
+	"delete-require-stopped": validateOnOff,
+	"ssh-launch":             validateSSHLaunch,
+	"ssh-options":            validateSSHOptions,
+	"ssh-client":             validateSSHClient,
+	"ops-concurrency":        validatePositiveInt,
+	"metrics-refresh":        validateMetricsRefresh,
+	"proxy":                  validateProxy,
+	"dns-provider":           validateDNSProvider,
 }
 
 func runSet(cmd *cobra.Command, args []string) {
@@ -57,6 +81,9 @@ func runSet(cmd *cobra.Command, args []string) {
 	}
 
 	normalized := util.NormalizeKey(value)
+	if spec.Raw {
+		normalized = strings.TrimSpace(value)
+	}
 	spec.Set(cfg, normalized)
 	if err := cfg.Save(); err != nil {
 		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
@@ -79,3 +106,106 @@ func validateProvider(cmd *cobra.Command, name string) error {
 	fmt.Fprintf(cmd.ErrOrStderr(), "Registered providers: %v\n", known)
 	return fmt.Errorf("unknown provider %q", name)
 }
+
+// validateDNSProvider checks that the given name is a registered DNS
+// provider.
+func validateDNSProvider(cmd *cobra.Command, name string) error {
+	normalized := util.NormalizeKey(name)
+	known := dnsproviders.List()
+	if slices.Contains(known, normalized) {
+		return nil
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: unknown DNS provider %q\n", name)
+	fmt.Fprintf(cmd.ErrOrStderr(), "Registered DNS providers: %v\n", known)
+	return fmt.Errorf("unknown DNS provider %q", name)
+}
+
+// validateLocale checks that the given value is a recognisable locale.
+func validateLocale(cmd *cobra.Command, locale string) error {
+	if err := money.ValidateLocale(locale); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateIdleWindow checks that the given value is a parseable window.
+func validateIdleWindow(cmd *cobra.Command, window string) error {
+	if _, err := idle.ParseWindow(window); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateSSHLaunch checks that the given value is a known launch mode.
+func validateSSHLaunch(cmd *cobra.Command, value string) error {
+	if _, err := tmux.ParseMode(util.NormalizeKey(value)); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateSSHOptions checks that the given value splits into ssh arguments.
+func validateSSHOptions(cmd *cobra.Command, value string) error {
+	if _, err := remote.SplitOptions(value); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateSSHClient checks that the given value is a known SSH client.
+func validateSSHClient(cmd *cobra.Command, value string) error {
+	if _, err := nativessh.ParseMode(util.NormalizeKey(value)); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateMetricsRefresh checks that the given value is "off" or a long
+// enough duration.
+func validateMetricsRefresh(cmd *cobra.Command, value string) error {
+	if _, err := config.ParseMetricsRefresh(util.NormalizeKey(value)); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateProxy checks that the given value is a supported proxy URL. An
+// empty value clears the proxy.
+func validateProxy(cmd *cobra.Command, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	if _, err := proxy.Parse(strings.TrimSpace(value)); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validatePositiveInt checks that the given value is a whole number of at
+// least one.
+func validatePositiveInt(cmd *cobra.Command, value string) error {
+	if n, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || n < 1 {
+		err := fmt.Errorf("invalid value %q: expected a positive whole number", value)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// validateOnOff checks that the given value is "on" or "off".
+func validateOnOff(cmd *cobra.Command, value string) error {
+	switch util.NormalizeKey(value) {
+	case "on", "off":
+		return nil
+	}
+	err := fmt.Errorf("invalid value %q: expected on or off", value)
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	return err
+}
@@ -7,7 +7,10 @@ import (
 	"testing"
 
 	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
 	providernames "nathanbeddoewebdev/vpsm/internal/platform/providers/names"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
 )
 
 // setupTestConfig points the config package at a temp file and returns cleanup.
@@ -97,3 +100,216 @@ func TestSet_DefaultProvider_CaseInsensitive(t *testing.T) {
 		t.Errorf("expected normalized provider name, got: %s", stdout)
 	}
 }
+
+func TestSet_Locale(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "locale", "de-DE")
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Locale != "de-de" {
+		t.Errorf("expected Locale %q, got %q", "de-de", cfg.Locale)
+	}
+}
+
+func TestSet_Locale_Invalid(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "locale", "not a locale!")
+
+	if !strings.Contains(stderr, "invalid locale") {
+		t.Errorf("expected 'invalid locale' error, got: %s", stderr)
+	}
+}
+
+func TestSet_IdleWindow(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "idle-window", "14d")
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.IdleWindow != "14d" {
+		t.Errorf("expected IdleWindow %q, got %q", "14d", cfg.IdleWindow)
+	}
+}
+
+func TestSet_IdleWindow_Invalid(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "idle-window", "soon")
+
+	if !strings.Contains(stderr, "invalid window") {
+		t.Errorf("expected 'invalid window' error, got: %s", stderr)
+	}
+}
+
+func TestSet_UsageStats(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "usage-stats", "OFF")
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.UsageStatsEnabled() {
+		t.Error("expected usage stats to be disabled")
+	}
+}
+
+func TestSet_UsageStats_Invalid(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "usage-stats", "maybe")
+
+	if !strings.Contains(stderr, "expected on or off") {
+		t.Errorf("expected on/off error, got: %s", stderr)
+	}
+}
+
+func TestSet_SSHLaunch(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "ssh-launch", "tmux-window")
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.SSHLaunch != "tmux-window" {
+		t.Errorf("expected SSHLaunch %q, got %q", "tmux-window", cfg.SSHLaunch)
+	}
+}
+
+func TestSet_SSHLaunch_Invalid(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "ssh-launch", "screen")
+
+	if !strings.Contains(stderr, "invalid launch mode") {
+		t.Errorf("expected launch mode error, got: %s", stderr)
+	}
+}
+
+func TestSet_SSHClient(t *testing.T) {
+	setupTestConfig(t)
+
+	if _, stderr := execConfig(t, "set", "ssh-client", "Native"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.SSHClient != "native" {
+		t.Errorf("expected SSHClient %q, got %q", "native", cfg.SSHClient)
+	}
+
+	if _, stderr := execConfig(t, "set", "ssh-client", "putty"); !strings.Contains(stderr, "invalid ssh client") {
+		t.Errorf("expected ssh client error, got: %s", stderr)
+	}
+}
+
+func TestSet_MetricsRefresh(t *testing.T) {
+	setupTestConfig(t)
+
+	if _, stderr := execConfig(t, "set", "metrics-refresh", "30s"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.MetricsRefresh != "30s" {
+		t.Errorf("expected MetricsRefresh %q, got %q", "30s", cfg.MetricsRefresh)
+	}
+
+	if _, stderr := execConfig(t, "set", "metrics-refresh", "2s"); !strings.Contains(stderr, "at least 10s") {
+		t.Errorf("expected minimum interval error, got: %s", stderr)
+	}
+}
+
+func TestSet_SSHOptionsKeepsCase(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "ssh-options", "-J Jump@203.0.113.1 -o ForwardAgent=yes")
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.SSHOptions != "-J Jump@203.0.113.1 -o ForwardAgent=yes" {
+		t.Errorf("expected options stored verbatim, got %q", cfg.SSHOptions)
+	}
+}
+
+func TestSet_Proxy(t *testing.T) {
+	setupTestConfig(t)
+
+	if _, stderr := execConfig(t, "set", "proxy", "socks5://User:pw@127.0.0.1:1080"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.Proxy != "socks5://User:pw@127.0.0.1:1080" {
+		t.Errorf("expected the proxy stored verbatim, got %q", cfg.Proxy)
+	}
+}
+
+func TestSet_Proxy_Invalid(t *testing.T) {
+	setupTestConfig(t)
+
+	_, stderr := execConfig(t, "set", "proxy", "ftp://proxy")
+
+	if !strings.Contains(stderr, "scheme must be") {
+		t.Errorf("expected a scheme error, got: %s", stderr)
+	}
+}
+
+func TestSet_DNSProvider(t *testing.T) {
+	setupTestConfig(t)
+	dnsproviders.Reset()
+	t.Cleanup(dnsproviders.Reset)
+	dnsproviders.Register("desec", func(auth.Store) (dnsdomain.Provider, error) { return nil, nil })
+
+	if _, stderr := execConfig(t, "set", "dns-provider", "deSEC"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if cfg.DNSProvider != "desec" {
+		t.Errorf("expected DNSProvider %q, got %q", "desec", cfg.DNSProvider)
+	}
+
+	_, stderr := execConfig(t, "set", "dns-provider", "nonexistent")
+	if !strings.Contains(stderr, "unknown DNS provider") {
+		t.Errorf("expected 'unknown DNS provider' error, got: %s", stderr)
+	}
+}
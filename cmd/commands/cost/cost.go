@@ -0,0 +1,211 @@
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	costsvc "nathanbeddoewebdev/vpsm/internal/server/services/cost"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Estimate monthly spend on servers",
+		Long: `Estimate what your servers cost, from the list price of each server's type.
+
+Every provider you are logged in to is included, or only the one given with
+--provider. Only running servers are counted unless --all is given; most
+providers bill stopped servers too. Volumes, backups, traffic and other
+extras are not included.
+
+Server type prices are cached for a day; use --refresh to fetch them again.
+
+Examples:
+  vpsm cost
+  vpsm cost --provider hetzner --all
+  vpsm cost -o json`,
+		Args:         cobra.NoArgs,
+		RunE:         runCost,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("provider", "", "Only include this provider")
+	cmd.Flags().Bool("all", false, "Include servers that are not running")
+	cmd.Flags().Bool("refresh", false, "Fetch server type prices again instead of using cached ones")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runCost(cmd *cobra.Command, args []string) error {
+	only, _ := cmd.Flags().GetString("provider")
+	includeStopped, _ := cmd.Flags().GetBool("all")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		cfg = &config.Config{}
+	}
+
+	names := providers.List()
+	sort.Strings(names)
+	if only != "" {
+		if !slices.Contains(names, only) {
+			return fmt.Errorf("unknown provider %q", only)
+		}
+		names = []string{only}
+	}
+
+	c := cache.NewDefault()
+	var overviews []costsvc.Overview
+	for _, name := range names {
+		provider, err := providers.Get(name, auth.WithProject(auth.DefaultStore(), cfg.ActiveProject(name)))
+		if errors.Is(err, auth.ErrTokenNotFound) && only == "" {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", name, err)
+		}
+		o, err := overview(cmd.Context(), c, name, provider, includeStopped, refresh)
+		if err != nil {
+			if only != "" {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping %s: %v\n", name, err)
+			continue
+		}
+		overviews = append(overviews, o)
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		return printJSON(cmd, overviews)
+	}
+	if len(overviews) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No providers to estimate. Log in with 'vpsm auth login <provider>'.")
+		return nil
+	}
+	printTable(cmd, overviews, priceFormatter(cfg))
+	return nil
+}
+
+// overview lists a provider's servers and prices them.
+func overview(ctx context.Context, c *cache.Cache, name string, provider domain.Provider, includeStopped, refresh bool) (costsvc.Overview, error) {
+	catalog, ok := provider.(domain.CatalogProvider)
+	if !ok {
+		return costsvc.Overview{}, fmt.Errorf("provider %q does not list server type prices", name)
+	}
+	servers, err := provider.ListServers(ctx)
+	if err != nil {
+		return costsvc.Overview{}, fmt.Errorf("failed to list servers: %w", err)
+	}
+	prices, err := costsvc.LoadPrices(ctx, c, catalog, name, refresh)
+	if err != nil {
+		return costsvc.Overview{}, err
+	}
+	return costsvc.Estimate(name, servers, prices, includeStopped), nil
+}
+
+func priceFormatter(cfg *config.Config) *money.Formatter {
+	return money.NewFormatter(money.ResolveLocale(cfg.Locale))
+}
+
+func printTable(cmd *cobra.Command, overviews []costsvc.Overview, f *money.Formatter) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tSERVER\tTYPE\tSTATUS\tHOURLY\tMONTHLY")
+	fmt.Fprintln(w, "--------\t------\t----\t------\t------\t-------")
+	for _, o := range overviews {
+		for _, line := range o.Lines {
+			hourly, monthly := "-", "unknown"
+			if line.Priced {
+				hourly, monthly = f.Format(line.Hourly), f.Format(line.Monthly)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				o.Provider, line.Server.Name, line.Server.ServerType, line.Server.Status, hourly, monthly)
+		}
+	}
+	w.Flush()
+
+	fmt.Fprintln(cmd.OutOrStdout(), "\nEstimated monthly spend:")
+	w = tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	for _, o := range overviews {
+		total := "-"
+		if !o.Monthly.IsZero() {
+			total = f.FormatMonthly(o.Monthly)
+		}
+		var notes []string
+		notes = append(notes, fmt.Sprintf("%d server(s)", len(o.Lines)))
+		if o.Unpriced > 0 {
+			notes = append(notes, fmt.Sprintf("%d without a list price", o.Unpriced))
+		}
+		if o.Stopped > 0 {
+			notes = append(notes, fmt.Sprintf("%d not running, not counted", o.Stopped))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", o.Provider, total, strings.Join(notes, ", "))
+	}
+	w.Flush()
+}
+
+// overviewJSON is the JSON shape of a provider's estimate.
+type overviewJSON struct {
+	Provider string       `json:"provider"`
+	Monthly  money.Money  `json:"monthly"`
+	Hourly   money.Money  `json:"hourly"`
+	Servers  []serverJSON `json:"servers"`
+	Unpriced int          `json:"unpriced"`
+	Stopped  int          `json:"stopped_not_counted"`
+}
+
+type serverJSON struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	ServerType string       `json:"server_type"`
+	Status     string       `json:"status"`
+	Monthly    *money.Money `json:"monthly"`
+	Hourly     *money.Money `json:"hourly"`
+}
+
+func printJSON(cmd *cobra.Command, overviews []costsvc.Overview) error {
+	out := make([]overviewJSON, len(overviews))
+	for i, o := range overviews {
+		servers := make([]serverJSON, len(o.Lines))
+		for j, line := range o.Lines {
+			s := serverJSON{
+				ID:         line.Server.ID,
+				Name:       line.Server.Name,
+				ServerType: line.Server.ServerType,
+				Status:     line.Server.Status,
+			}
+			if line.Priced {
+				s.Monthly, s.Hourly = &line.Monthly, &line.Hourly
+			}
+			servers[j] = s
+		}
+		out[i] = overviewJSON{
+			Provider: o.Provider,
+			Monthly:  o.Monthly,
+			Hourly:   o.Hourly,
+			Servers:  servers,
+			Unpriced: o.Unpriced,
+			Stopped:  o.Stopped,
+		}
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,128 @@
+package cost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// mockProvider implements domain.CatalogProvider for cost tests.
+type mockProvider struct {
+	servers     []domain.Server
+	serverTypes []domain.ServerTypeSpec
+}
+
+func (m *mockProvider) GetDisplayName() string { return "Mock" }
+func (m *mockProvider) CreateServer(context.Context, domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *mockProvider) DeleteServer(context.Context, string) error { return nil }
+func (m *mockProvider) GetServer(context.Context, string) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *mockProvider) ListServers(context.Context) ([]domain.Server, error) {
+	return m.servers, nil
+}
+func (m *mockProvider) StartServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (m *mockProvider) StopServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (m *mockProvider) ListLocations(context.Context) ([]domain.Location, error) { return nil, nil }
+func (m *mockProvider) ListServerTypes(context.Context) ([]domain.ServerTypeSpec, error) {
+	return m.serverTypes, nil
+}
+func (m *mockProvider) ListImages(context.Context) ([]domain.ImageSpec, error)   { return nil, nil }
+func (m *mockProvider) ListSSHKeys(context.Context) ([]domain.SSHKeySpec, error) { return nil, nil }
+
+func setupCost(t *testing.T, mocks map[string]*mockProvider) {
+	t.Helper()
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("LANG", "en_US.UTF-8")
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+
+	providers.Reset()
+	t.Cleanup(providers.Reset)
+	for name, mock := range mocks {
+		providers.Register(name, func(auth.Store) (domain.Provider, error) {
+			if mock == nil {
+				return nil, auth.ErrTokenNotFound
+			}
+			return mock, nil
+		})
+	}
+}
+
+func execCost(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
+func TestCost_SumsRunningServersPerProvider(t *testing.T) {
+	eur := func(v float64) money.Money { return money.Money{Amount: v, Currency: "EUR"} }
+	setupCost(t, map[string]*mockProvider{
+		"alpha": {
+			servers: []domain.Server{
+				{ID: "1", Name: "web-1", Status: "running", ServerType: "small"},
+				{ID: "2", Name: "web-2", Status: "running", ServerType: "large"},
+				{ID: "3", Name: "db-1", Status: "off", ServerType: "large"},
+			},
+			serverTypes: []domain.ServerTypeSpec{
+				{Name: "small", PriceMonthly: eur(5)},
+				{Name: "large", PriceMonthly: eur(20)},
+			},
+		},
+		"beta": nil, // not logged in
+	})
+
+	stdout, stderr, err := execCost(t)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, want := range []string{"web-1", "web-2", "25.00", "2 server(s)", "1 not running, not counted"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "db-1") || strings.Contains(stdout, "beta") {
+		t.Errorf("expected only alpha's running servers:\n%s", stdout)
+	}
+
+	stdout, _, _ = execCost(t, "--all", "-o", "json")
+	var got []overviewJSON
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got) != 1 || len(got[0].Servers) != 3 || got[0].Monthly.Amount != 45 {
+		t.Errorf("expected all three alpha servers totalling 45, got %+v", got)
+	}
+}
+
+func TestCost_UnknownProvider(t *testing.T) {
+	setupCost(t, nil)
+
+	_, _, err := execCost(t, "--provider", "nope")
+	if err == nil || !strings.Contains(err.Error(), "unknown provider") {
+		t.Errorf("err = %v, want unknown provider", err)
+	}
+}
@@ -0,0 +1,208 @@
+package dns
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v2"
+)
+
+// desiredState is the file read by 'vpsm dns apply': the complete record
+// set of each listed domain.
+type desiredState struct {
+	Domains map[string]desiredZone `json:"domains" yaml:"domains"`
+}
+
+type desiredZone struct {
+	// Provider hosts the domain; empty means --provider.
+	Provider string             `json:"provider,omitempty" yaml:"provider,omitempty"`
+	Records  []dnsdomain.Record `json:"records" yaml:"records"`
+}
+
+// ApplyCommand returns the "apply" command, which converges zones to a
+// declarative file.
+func ApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Converge zones to the records declared in a YAML or JSON file",
+		Long: `Read the desired records of one or more domains from a YAML or JSON file
+(by extension; YAML unless it ends in .json), compare them with each
+zone's current records and create, update and delete records until the
+zones match. The plan is printed first, like a diff: "+" creates, "~"
+updates and "-" deletes. Records the provider manages itself, such as its
+apex NS records, are left alone.
+
+The file lists every record of each domain; records it does not list are
+deleted. A domain's provider defaults to --provider.
+
+  domains:
+    example.com:
+      provider: hetzner
+      records:
+        - {name: "@", type: A, value: 203.0.113.10, ttl: 300}
+        - {name: "@", type: MX, value: mail.example.com, priority: 10}
+        - {name: www, type: CNAME, value: example.com}
+
+Examples:
+  vpsm dns apply --file records.yaml --dry-run
+  vpsm dns apply --file records.yaml`,
+		Args:         cobra.NoArgs,
+		RunE:         runApply,
+		SilenceUsage: true,
+		// The file may name each domain's provider, so --provider and a
+		// default provider are optional here.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return resolveProviderFlag(cmd, false)
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "YAML or JSON file of desired records (required)")
+	cmd.Flags().Bool("dry-run", false, "Show the plan without applying it")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// zonePlan is the plan for one domain of the file.
+type zonePlan struct {
+	zone     string
+	provider dnsdomain.Provider
+	plan     dnsdomain.MirrorPlan
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	state, err := loadDesiredState(path)
+	if err != nil {
+		return err
+	}
+
+	zones := make([]string, 0, len(state.Domains))
+	for zone := range state.Domains {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	var plans []zonePlan
+	var creates, updates, deletes int
+	for _, zone := range zones {
+		desired := state.Domains[zone]
+		provider, err := applyProvider(cmd, desired.Provider)
+		if err != nil {
+			return fmt.Errorf("%s: %w", zone, err)
+		}
+		existing, err := provider.ListRecords(cmd.Context(), zone)
+		if err != nil {
+			return fmt.Errorf("%s: %w", zone, err)
+		}
+		plan := dnsdomain.PlanImport(desired.Records, existing, provider.Quirks(), true)
+		plans = append(plans, zonePlan{zone: zone, provider: provider, plan: plan})
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s (%s):\n", zone, provider.GetDisplayName())
+		if plan.InSync() && len(plan.Skipped) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "  no changes")
+			continue
+		}
+		printPlan(cmd.OutOrStdout(), plan, "  ")
+		for _, c := range plan.Changes {
+			switch c.Action {
+			case dnsdomain.MirrorCreate:
+				creates++
+			case dnsdomain.MirrorUpdate:
+				updates++
+			case dnsdomain.MirrorDelete:
+				deletes++
+			}
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\nPlan: %d to create, %d to update, %d to delete.\n", creates, updates, deletes)
+	total := creates + updates + deletes
+	if total == 0 || dryRun {
+		return nil
+	}
+
+	applied := 0
+	for _, p := range plans {
+		n, err := dnsdomain.ApplyChanges(cmd.Context(), p.provider, p.zone, p.plan.Changes)
+		applied += n
+		if err != nil {
+			return fmt.Errorf("%s: %w (%d of %d change(s) applied)", p.zone, err, applied, total)
+		}
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Applied %d change(s).\n", applied)
+	return nil
+}
+
+// loadDesiredState reads and validates the desired-state file.
+func loadDesiredState(path string) (*desiredState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var state desiredState
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&state)
+	} else {
+		err = yaml.UnmarshalStrict(data, &state)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(state.Domains) == 0 {
+		return nil, fmt.Errorf("%s lists no domains", path)
+	}
+
+	for zone, desired := range state.Domains {
+		for i, r := range desired.Records {
+			r.Type = dnsdomain.ParseRecordType(string(r.Type))
+			if r.Name == "" {
+				r.Name = "@"
+			}
+			if err := dnsdomain.ValidateRecord(r); err != nil {
+				return nil, fmt.Errorf("%s: invalid %s %s record: %w", zone, r.Name, r.Type, err)
+			}
+			desired.Records[i] = r
+		}
+	}
+	return &state, nil
+}
+
+// applyProvider returns the DNS provider called name, or the one selected
+// by --provider when name is empty.
+func applyProvider(cmd *cobra.Command, name string) (dnsdomain.Provider, error) {
+	flag := cmd.Flag("provider").Value.String()
+	if name == "" || name == flag {
+		if flag == "" {
+			return nil, fmt.Errorf("no provider specified: set one in the file, use --provider or set a default with 'vpsm config set default-provider <name>'")
+		}
+		return dnsProvider(cmd)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	project := cfg.ActiveProject(name)
+	provider, err := providers.Get(name, auth.WithProject(auth.NewKeyringStore(auth.ServiceName), project))
+	if err != nil {
+		return nil, err
+	}
+	return logChanges(cmd, name, project, provider), nil
+}
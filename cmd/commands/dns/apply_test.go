@@ -0,0 +1,119 @@
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func writeDesiredState(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestApply_PlansThenConverges(t *testing.T) {
+	mock := &mockProvider{nextID: 10, records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1", TTL: 300},
+		{ID: "2", Name: "old", Type: dnsdomain.RecordTXT, Value: "stale"},
+	}}
+	registerDNSMock(t, mock)
+	path := writeDesiredState(t, "records.yaml", `domains:
+  example.com:
+    records:
+      - {name: www, type: a, value: 203.0.113.10, ttl: 300}
+      - {name: "@", type: MX, value: mail.example.com, priority: 10}
+`)
+
+	stdout, err := execDNS(t, "apply", "--file", path, "--dry-run")
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	for _, want := range []string{
+		"example.com (Mock):",
+		"  ~ update www A 203.0.113.10 (ttl 300)",
+		"  + create @ MX mail.example.com (priority 10)",
+		"  - delete old TXT stale",
+		"Plan: 1 to create, 1 to update, 1 to delete.",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in plan:\n%s", want, stdout)
+		}
+	}
+	if len(mock.records) != 2 || mock.records[0].Value != "203.0.113.1" {
+		t.Fatalf("dry run changed the zone: %+v", mock.records)
+	}
+
+	if _, err := execDNS(t, "apply", "--file", path); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	priority := 10
+	want := []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "11", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", Priority: &priority},
+	}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after apply mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout, err = execDNS(t, "apply", "--file", path)
+	if err != nil || !strings.Contains(stdout, "no changes") {
+		t.Errorf("second apply: err = %v, output:\n%s", err, stdout)
+	}
+}
+
+func TestApply_JSONWithPerDomainProvider(t *testing.T) {
+	mock := &mockProvider{}
+	registerDNSMock(t, mock)
+	other := &mockProvider{}
+	providers.Register("other", func(auth.Store) (dnsdomain.Provider, error) { return other, nil })
+
+	path := writeDesiredState(t, "records.json", `{"domains": {
+  "example.com": {"records": [{"name": "www", "type": "A", "value": "203.0.113.10"}]},
+  "example.org": {"provider": "other", "records": [{"name": "www", "type": "AAAA", "value": "2001:db8::10"}]}
+}}`)
+
+	if _, err := execDNS(t, "apply", "--file", path); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(mock.records) != 1 || mock.records[0].Type != dnsdomain.RecordA {
+		t.Errorf("example.com records = %+v, want the A record", mock.records)
+	}
+	if len(other.records) != 1 || other.records[0].Type != dnsdomain.RecordAAAA {
+		t.Errorf("example.org records = %+v, want the AAAA record", other.records)
+	}
+}
+
+func TestApply_RejectsInvalidFiles(t *testing.T) {
+	registerDNSMock(t, &mockProvider{})
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"unknown field", "r.yaml", "domains:\n  example.com:\n    recrods: []\n", "failed to parse"},
+		{"invalid record", "r.yaml", "domains:\n  example.com:\n    records:\n      - {name: www, type: A, value: nope}\n", "example.com: invalid www A record"},
+		{"no domains", "r.json", `{"domains": {}}`, "lists no domains"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeDesiredState(t, tt.file, tt.content)
+			_, err := execDNS(t, "apply", "--file", path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package dns
+
+import (
+	"fmt"
+	"io"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// changePrefix marks a change in a printed plan like a diff.
+var changePrefix = map[dnsdomain.MirrorAction]string{
+	dnsdomain.MirrorCreate: "+",
+	dnsdomain.MirrorUpdate: "~",
+	dnsdomain.MirrorDelete: "-",
+}
+
+// printPlan lists a plan's changes and skipped records, each line
+// prefixed with indent.
+func printPlan(w io.Writer, plan dnsdomain.MirrorPlan, indent string) {
+	for _, c := range plan.Changes {
+		fmt.Fprintf(w, "%s%s %s\n", indent, changePrefix[c.Action], c)
+	}
+	for _, s := range plan.Skipped {
+		fmt.Fprintf(w, "%s! skip %s %s %s: %s\n", indent, s.Record.Name, s.Record.Type, s.Record.Value, s.Reason)
+	}
+}
@@ -0,0 +1,97 @@
+package dns
+
+import (
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "dns",
+		Aliases: []string{"d"},
+		Short:   "Manage DNS zones and records",
+		Long: `Manage the zones and records hosted by a DNS provider.
+
+Supported providers are Hetzner DNS ("hetzner"), deSEC ("desec") and
+Cloudflare ("cloudflare", with an API token from 'vpsm auth login
+cloudflare').
+Hetzner DNS uses the token stored with 'vpsm auth login hetzner-dns' and
+falls back to your Hetzner Cloud token; the DNS Console issues its own
+API tokens, so log in with one if the Cloud token is rejected.`,
+		PersistentPreRunE: resolveProvider,
+	}
+
+	cmd.AddCommand(ApplyCommand())
+	cmd.AddCommand(ApplyTemplateCommand())
+	cmd.AddCommand(DNSSECCommand())
+	cmd.AddCommand(DomainCommand())
+	cmd.AddCommand(ExportCommand())
+	cmd.AddCommand(HistoryCommand())
+	cmd.AddCommand(ImportCommand())
+	cmd.AddCommand(MirrorCommand())
+	cmd.AddCommand(RecordCommand())
+	cmd.AddCommand(RenameCommand())
+	cmd.AddCommand(RollbackCommand())
+	cmd.AddCommand(SyncCommand())
+
+	cmd.PersistentFlags().String("provider", "", "DNS provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
+
+	return cmd
+}
+
+// resolveProvider ensures the --provider flag has a value, falling back to the
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
+func resolveProvider(cmd *cobra.Command, args []string) error {
+	return resolveProviderFlag(cmd, true)
+}
+
+// resolveProviderFlag is resolveProvider; unless required, a missing
+// default leaves --provider empty instead of failing.
+func resolveProviderFlag(cmd *cobra.Command, required bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			if !required {
+				return nil
+			}
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
+		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
+	}
+
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
+}
+
+// dnsProvider returns the DNS provider selected by --provider, logging
+// the record changes made through it for 'vpsm dns rollback'.
+func dnsProvider(cmd *cobra.Command) (dnsdomain.Provider, error) {
+	provider, err := selectedProvider(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return logChanges(cmd, cmd.Flag("provider").Value.String(), auth.Project(), provider), nil
+}
+
+// selectedProvider is dnsProvider without the change log, for the
+// provider's optional extensions (such as DNSSECProvider), which the
+// logging wrapper hides.
+func selectedProvider(cmd *cobra.Command) (dnsdomain.Provider, error) {
+	return providers.Get(cmd.Flag("provider").Value.String(), auth.DefaultStore())
+}
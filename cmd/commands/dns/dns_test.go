@@ -0,0 +1,320 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/zonestats"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// mockProvider is an in-memory dnsdomain.Provider.
+type mockProvider struct {
+	zones   []dnsdomain.Zone
+	records []dnsdomain.Record
+	nextID  int
+}
+
+func (m *mockProvider) GetDisplayName() string   { return "Mock" }
+func (m *mockProvider) Quirks() dnsdomain.Quirks { return dnsdomain.Quirks{} }
+func (m *mockProvider) ListZones(context.Context) ([]dnsdomain.Zone, error) {
+	return m.zones, nil
+}
+func (m *mockProvider) ListRecords(context.Context, string) ([]dnsdomain.Record, error) {
+	return append([]dnsdomain.Record(nil), m.records...), nil
+}
+
+func (m *mockProvider) CreateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	m.nextID++
+	r.ID = fmt.Sprint(m.nextID)
+	m.records = append(m.records, r)
+	return &r, nil
+}
+
+func (m *mockProvider) UpdateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	for i := range m.records {
+		if m.records[i].ID == r.ID {
+			m.records[i] = r
+			return &r, nil
+		}
+	}
+	return nil, dnsdomain.ErrNotFound
+}
+
+func (m *mockProvider) DeleteRecord(_ context.Context, _, id string) error {
+	for i := range m.records {
+		if m.records[i].ID == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return dnsdomain.ErrNotFound
+}
+
+func registerDNSMock(t *testing.T, mock *mockProvider) {
+	t.Helper()
+	dir := t.TempDir()
+	config.SetPath(filepath.Join(dir, "config.json"))
+	t.Cleanup(config.ResetPath)
+	changelog.SetPath(filepath.Join(dir, "vpsm.db"))
+	t.Cleanup(changelog.ResetPath)
+	tagindex.SetPath(filepath.Join(dir, "vpsm.db"))
+	t.Cleanup(tagindex.ResetPath)
+	zoneStatsCache = func() *cache.Cache { return cache.New(filepath.Join(dir, "cache")) }
+	lookupNS = func(context.Context, string) ([]string, error) { return nil, errors.New("no lookups in tests") }
+	t.Cleanup(func() { zoneStatsCache, lookupNS = cache.NewDefault, zonestats.LookupDelegation })
+
+	providers.Reset()
+	t.Cleanup(providers.Reset)
+	providers.Register("mock", func(auth.Store) (dnsdomain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func execDNS(t *testing.T, args ...string) (stdout string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append(args, "--provider", "mock"))
+	err = cmd.Execute()
+	return outBuf.String(), err
+}
+
+func TestDomainList_ShowsNameservers(t *testing.T) {
+	registerDNSMock(t, &mockProvider{zones: []dnsdomain.Zone{
+		{ID: "z1", Name: "example.com", TTL: 3600, Nameservers: []string{"ns1.example.net", "ns2.example.net"}},
+	}})
+
+	stdout, err := execDNS(t, "domain", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, "example.com") || !strings.Contains(stdout, "ns1.example.net, ns2.example.net") {
+		t.Errorf("expected the zone and its nameservers:\n%s", stdout)
+	}
+}
+
+func TestDomainList_ShowsStatsAndFlagsProblems(t *testing.T) {
+	mock := &mockProvider{
+		zones: []dnsdomain.Zone{
+			{ID: "z1", Name: "example.com", Plan: "Free Website", Nameservers: []string{"ns1.example.net", "ns2.example.net"}},
+		},
+		records: []dnsdomain.Record{
+			{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1"},
+			{ID: "2", Name: "@", Type: dnsdomain.RecordA, Value: "203.0.113.1"},
+			{ID: "3", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all"},
+		},
+	}
+	registerDNSMock(t, mock)
+	lookupNS = func(context.Context, string) ([]string, error) {
+		return []string{"ns1.registrar.example", "ns2.registrar.example"}, nil
+	}
+
+	stdout, err := execDNS(t, "domain", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"3 (2 A, 1 TXT)", "Free Website", "mismatch", "! example.com: nameservers are ns1.registrar.example"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+
+	// The counts are cached until --refresh.
+	mock.records = mock.records[:1]
+	if stdout, _ := execDNS(t, "domain", "list"); !strings.Contains(stdout, "3 (2 A, 1 TXT)") {
+		t.Errorf("expected cached counts:\n%s", stdout)
+	}
+	if stdout, _ := execDNS(t, "domain", "list", "--refresh"); !strings.Contains(stdout, "1 (1 A)") {
+		t.Errorf("expected refreshed counts with --refresh:\n%s", stdout)
+	}
+}
+
+func TestRecordCreateUpdateDelete(t *testing.T) {
+	mock := &mockProvider{}
+	registerDNSMock(t, mock)
+
+	if _, err := execDNS(t, "record", "create", "--domain", "example.com", "--name", "@", "--type", "mx", "--value", "mail.example.com", "--priority", "10"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	priority := 10
+	want := []dnsdomain.Record{{ID: "1", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", Priority: &priority}}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after create mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := execDNS(t, "record", "update", "--domain", "example.com", "--id", "1", "--ttl", "300"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	want[0].TTL = 300
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after update mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout, err := execDNS(t, "record", "list", "--domain", "example.com")
+	if err != nil || !strings.Contains(stdout, "mail.example.com") {
+		t.Errorf("list: err = %v, output:\n%s", err, stdout)
+	}
+
+	if _, err := execDNS(t, "record", "delete", "--domain", "example.com", "--id", "1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(mock.records) != 0 {
+		t.Errorf("expected no records after delete, got %+v", mock.records)
+	}
+}
+
+func TestRecordList_Format(t *testing.T) {
+	mock := &mockProvider{records: []dnsdomain.Record{
+		{ID: "2", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "1", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all", TTL: 300},
+	}}
+	registerDNSMock(t, mock)
+
+	stdout, err := execDNS(t, "record", "list", "--domain", "example.com", "--format", `{{.Name}} {{.Type}} {{.Value}}`)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if want := "@ TXT v=spf1 -all\nwww A 203.0.113.7\n"; stdout != want {
+		t.Errorf("output = %q, want %q", stdout, want)
+	}
+
+	if _, err := execDNS(t, "record", "list", "--domain", "example.com", "--format", "{{.Name}}", "-o", "json"); err == nil {
+		t.Error("expected --format and --output to be rejected together")
+	}
+}
+
+func TestRecordList_IndexesZoneForSearch(t *testing.T) {
+	registerDNSMock(t, &mockProvider{records: []dnsdomain.Record{
+		{ID: "2", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+	}})
+
+	if _, err := execDNS(t, "record", "list", "--domain", "example.com", "-o", "json"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+
+	repo, err := tagindex.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := tagindexsvc.NewService(repo)
+	defer svc.Close()
+	got, err := svc.Search("203.0.113.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Kind != tagindex.KindDNSRecord || got[0].Name != "www.example.com" || got[0].Parent != "example.com" {
+		t.Errorf("expected www.example.com to be indexed, got %+v", got)
+	}
+}
+
+func TestRecordCreate_ValidatesBeforeSubmitting(t *testing.T) {
+	mock := &mockProvider{}
+	registerDNSMock(t, mock)
+
+	_, err := execDNS(t, "record", "create", "--domain", "example.com", "--name", "www", "--type", "A", "--value", "not-an-ip")
+	if err == nil || !strings.Contains(err.Error(), "IPv4") {
+		t.Errorf("err = %v, want an IPv4 validation error", err)
+	}
+	if len(mock.records) != 0 {
+		t.Errorf("expected nothing to be created, got %+v", mock.records)
+	}
+}
+
+func TestRecordCreate_Upsert(t *testing.T) {
+	mock := &mockProvider{nextID: 1, records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+	}}
+	registerDNSMock(t, mock)
+
+	args := []string{"record", "create", "--domain", "example.com", "--name", "www", "--type", "A", "--value", "203.0.113.8", "--ttl", "300", "--upsert"}
+	stdout, err := execDNS(t, args...)
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if !strings.Contains(stdout, "Updated www A record in example.com (ID 1)") {
+		t.Errorf("expected an update:\n%s", stdout)
+	}
+	want := []dnsdomain.Record{{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.8", TTL: 300}}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after upsert mismatch (-want +got):\n%s", diff)
+	}
+
+	// Running it again changes nothing.
+	stdout, err = execDNS(t, args...)
+	if err != nil || !strings.Contains(stdout, "is up to date") {
+		t.Errorf("second upsert: err = %v, output:\n%s", err, stdout)
+	}
+
+	stdout, err = execDNS(t, "record", "create", "--domain", "example.com", "--name", "api", "--type", "A", "--value", "203.0.113.9", "--upsert")
+	if err != nil || !strings.Contains(stdout, "Created api A record") {
+		t.Errorf("upsert of a new name: err = %v, output:\n%s", err, stdout)
+	}
+	if len(mock.records) != 2 {
+		t.Errorf("expected the api record to be created, got %+v", mock.records)
+	}
+}
+
+func TestRename_MovesSetKeepingContent(t *testing.T) {
+	priority := 10
+	mock := &mockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "2", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.8", TTL: 300},
+		{ID: "3", Name: "www", Type: dnsdomain.RecordAAAA, Value: "2001:db8::1"},
+		{ID: "4", Name: "mail", Type: dnsdomain.RecordMX, Value: "mx.example.com", TTL: 600, Priority: &priority},
+	}}
+	registerDNSMock(t, mock)
+
+	stdout, err := execDNS(t, "rename", "--domain", "example.com", "www", "a", "web")
+	if err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if !strings.Contains(stdout, "Renamed 2 A record(s) in example.com from www to web") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+	if _, err := execDNS(t, "rename", "--domain", "example.com", "mail", "MX", "mx"); err != nil {
+		t.Fatalf("rename MX: %v", err)
+	}
+
+	want := []dnsdomain.Record{
+		{ID: "1", Name: "web", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "2", Name: "web", Type: dnsdomain.RecordA, Value: "203.0.113.8", TTL: 300},
+		{ID: "3", Name: "www", Type: dnsdomain.RecordAAAA, Value: "2001:db8::1"},
+		{ID: "4", Name: "mx", Type: dnsdomain.RecordMX, Value: "mx.example.com", TTL: 600, Priority: &priority},
+	}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after rename mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRename_RejectsCollision(t *testing.T) {
+	mock := &mockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordCNAME, Value: "example.com"},
+		{ID: "2", Name: "api", Type: dnsdomain.RecordA, Value: "203.0.113.7"},
+	}}
+	registerDNSMock(t, mock)
+
+	_, err := execDNS(t, "rename", "--domain", "example.com", "www", "CNAME", "api")
+	if err == nil || !strings.Contains(err.Error(), "CNAME cannot share its name") {
+		t.Errorf("err = %v, want a CNAME collision", err)
+	}
+	if mock.records[0].Name != "www" {
+		t.Errorf("the record was renamed despite the collision: %+v", mock.records[0])
+	}
+}
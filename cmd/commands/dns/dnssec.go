@@ -0,0 +1,244 @@
+package dns
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// DNSSECCommand returns the "dnssec" command group.
+func DNSSECCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dnssec",
+		Short: "Show and toggle DNSSEC signing of a zone",
+		Long: `Show whether a zone is signed with DNSSEC, turn signing on or off, and
+print the DS record to publish at the registrar.
+
+Signing takes two steps: enable it here, then add the DS record shown at
+your registrar. Turning it off takes the same steps in reverse: remove the
+DS record at the registrar first, or resolvers that validate will stop
+resolving the zone.
+
+Supported by Cloudflare.
+
+Examples:
+  vpsm dns dnssec status example.com --provider cloudflare
+  vpsm dns dnssec enable example.com --provider cloudflare`,
+	}
+
+	cmd.AddCommand(dnssecStatusCommand())
+	cmd.AddCommand(dnssecEnableCommand())
+	cmd.AddCommand(dnssecDisableCommand())
+
+	return cmd
+}
+
+func dnssecStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status <domain>",
+		Short: "Show a zone's DNSSEC status and DS record",
+		Long: `Show whether the zone is signed, and the DS record (and DNSKEY) the
+registrar needs, ready to paste.
+
+Examples:
+  vpsm dns dnssec status example.com
+  vpsm dns dnssec status example.com -o json`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runDNSSECStatus,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func dnssecEnableCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable <domain>",
+		Short: "Sign a zone and print the DS record for the registrar",
+		Long: `Turn on DNSSEC signing of the zone and print the DS record to publish
+at the registrar. The zone validates once the registrar publishes it.
+
+Examples:
+  vpsm dns dnssec enable example.com`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runDNSSECEnable,
+		SilenceUsage: true,
+	}
+}
+
+func dnssecDisableCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "disable <domain>",
+		Short: "Stop signing a zone",
+		Long: `Turn off DNSSEC signing of the zone. Remove the DS record at the
+registrar first: while it is published, validating resolvers treat the
+unsigned zone as forged and stop resolving it.
+
+Examples:
+  vpsm dns dnssec disable example.com
+  vpsm dns dnssec disable example.com --yes`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runDNSSECDisable,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolP("yes", "y", false, "Disable without asking")
+
+	return cmd
+}
+
+// dnssecProvider returns the DNS provider selected by --provider, or an
+// error when it cannot sign zones.
+func dnssecProvider(cmd *cobra.Command) (dnsdomain.DNSSECProvider, error) {
+	provider, err := selectedProvider(cmd)
+	if err != nil {
+		return nil, err
+	}
+	dp, ok := provider.(dnsdomain.DNSSECProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s does not support DNSSEC", provider.GetDisplayName())
+	}
+	return dp, nil
+}
+
+func runDNSSECStatus(cmd *cobra.Command, args []string) error {
+	provider, err := dnssecProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone := normalizeZone(args[0])
+
+	status, err := provider.GetDNSSEC(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(status)
+	}
+	printDNSSEC(cmd.OutOrStdout(), zone, provider.GetDisplayName(), status)
+	return nil
+}
+
+func runDNSSECEnable(cmd *cobra.Command, args []string) error {
+	provider, err := dnssecProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone := normalizeZone(args[0])
+
+	status, err := provider.EnableDNSSEC(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+	printDNSSEC(cmd.OutOrStdout(), zone, provider.GetDisplayName(), status)
+	return nil
+}
+
+func runDNSSECDisable(cmd *cobra.Command, args []string) error {
+	provider, err := dnssecProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone := normalizeZone(args[0])
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Remove the DS record of %s at your registrar before disabling DNSSEC, or the zone will stop resolving.\n", zone)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Disable DNSSEC for %s? [y/N]: ", zone)
+		response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled.")
+			return nil
+		}
+	}
+
+	status, err := provider.DisableDNSSEC(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+	printDNSSEC(cmd.OutOrStdout(), zone, provider.GetDisplayName(), status)
+	return nil
+}
+
+// normalizeZone lowercases a zone name and drops its trailing dot.
+func normalizeZone(zone string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(zone)), ".")
+}
+
+// dnssecHints explains what each status asks of the user.
+var dnssecHints = map[dnsdomain.DNSSECStatus]string{
+	dnsdomain.DNSSECActive:         "The zone is signed and the registrar publishes its DS record.",
+	dnsdomain.DNSSECPending:        "The zone is signed. Publish the DS record below at your registrar to complete the chain of trust.",
+	dnsdomain.DNSSECPendingDisable: "Signing stops once the DS record is gone from the registrar.",
+	dnsdomain.DNSSECDisabled:       "The zone is not signed. Turn signing on with 'vpsm dns dnssec enable <domain>'.",
+}
+
+// dnssecAlgorithms names the DNSSEC algorithm numbers registrars list.
+var dnssecAlgorithms = map[int]string{
+	8:  "RSASHA256",
+	10: "RSASHA512",
+	13: "ECDSAP256SHA256",
+	14: "ECDSAP384SHA384",
+	15: "ED25519",
+	16: "ED448",
+}
+
+// dsDigestTypes names the DS digest type numbers registrars list.
+var dsDigestTypes = map[int]string{
+	1: "SHA-1",
+	2: "SHA-256",
+	4: "SHA-384",
+}
+
+// named renders a registry number with its name, e.g. "13 (ECDSAP256SHA256)".
+func named(n int, names map[int]string) string {
+	if name, ok := names[n]; ok {
+		return fmt.Sprintf("%d (%s)", n, name)
+	}
+	return fmt.Sprint(n)
+}
+
+// printDNSSEC prints a zone's DNSSEC status and the records to publish at
+// the registrar, both field by field (as registrar forms ask for them)
+// and as zone file lines.
+func printDNSSEC(w io.Writer, zone, providerName string, status *dnsdomain.DNSSEC) {
+	fmt.Fprintf(w, "%s (%s): DNSSEC %s\n", zone, providerName, status.Status)
+	if hint, ok := dnssecHints[status.Status]; ok {
+		fmt.Fprintln(w, hint)
+	}
+	if len(status.DS) == 0 {
+		return
+	}
+
+	for _, ds := range status.DS {
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "  Key tag:\t%d\n", ds.KeyTag)
+		fmt.Fprintf(tw, "  Algorithm:\t%s\n", named(ds.Algorithm, dnssecAlgorithms))
+		fmt.Fprintf(tw, "  Digest type:\t%s\n", named(ds.DigestType, dsDigestTypes))
+		fmt.Fprintf(tw, "  Digest:\t%s\n", strings.ToUpper(ds.Digest))
+		tw.Flush()
+	}
+
+	fmt.Fprintln(w)
+	for _, ds := range status.DS {
+		fmt.Fprintf(w, "%s. IN DS %s\n", zone, ds)
+	}
+	for _, key := range status.Keys {
+		fmt.Fprintf(w, "%s. IN DNSKEY %s\n", zone, key)
+	}
+}
@@ -0,0 +1,101 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// dnssecMock adds zone signing to mockProvider.
+type dnssecMock struct {
+	mockProvider
+	state dnsdomain.DNSSEC
+}
+
+func (m *dnssecMock) GetDNSSEC(context.Context, string) (*dnsdomain.DNSSEC, error) {
+	state := m.state
+	return &state, nil
+}
+
+func (m *dnssecMock) EnableDNSSEC(ctx context.Context, zone string) (*dnsdomain.DNSSEC, error) {
+	m.state = dnsdomain.DNSSEC{
+		Status: dnsdomain.DNSSECPending,
+		DS:     []dnsdomain.DSRecord{{KeyTag: 2371, Algorithm: 13, DigestType: 2, Digest: "c988ec423e3880eb8dd8a46fe06ca230ee23f35b578d64e6e1f3a3b1bd2e4fb7"}},
+		Keys:   []dnsdomain.DNSKEY{{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0d"}},
+	}
+	return m.GetDNSSEC(ctx, zone)
+}
+
+func (m *dnssecMock) DisableDNSSEC(ctx context.Context, zone string) (*dnsdomain.DNSSEC, error) {
+	m.state = dnsdomain.DNSSEC{Status: dnsdomain.DNSSECDisabled}
+	return m.GetDNSSEC(ctx, zone)
+}
+
+func registerDNSSECMock(t *testing.T, mock *dnssecMock) {
+	t.Helper()
+	registerDNSMock(t, &mock.mockProvider)
+	providers.Reset()
+	providers.Register("mock", func(auth.Store) (dnsdomain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func TestDNSSECEnable_PrintsDSForRegistrar(t *testing.T) {
+	mock := &dnssecMock{state: dnsdomain.DNSSEC{Status: dnsdomain.DNSSECDisabled}}
+	registerDNSSECMock(t, mock)
+
+	stdout, err := execDNS(t, "dnssec", "enable", "Example.com.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"example.com (Mock): DNSSEC pending",
+		"Key tag:      2371",
+		"Algorithm:    13 (ECDSAP256SHA256)",
+		"Digest type:  2 (SHA-256)",
+		"example.com. IN DS 2371 13 2 C988EC423E3880EB8DD8A46FE06CA230EE23F35B578D64E6E1F3A3B1BD2E4FB7",
+		"example.com. IN DNSKEY 257 3 13 mdsswUyr3DPW132mOi8V9xESWE8jTo0d",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestDNSSECDisable_RequiresConfirmation(t *testing.T) {
+	mock := &dnssecMock{state: dnsdomain.DNSSEC{Status: dnsdomain.DNSSECActive}}
+	registerDNSSECMock(t, mock)
+
+	cmd := NewCommand()
+	var out strings.Builder
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetArgs([]string{"dnssec", "disable", "example.com", "--provider", "mock"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.state.Status != dnsdomain.DNSSECActive {
+		t.Errorf("status = %s, want DNSSEC left active after declining", mock.state.Status)
+	}
+
+	if _, err := execDNS(t, "dnssec", "disable", "example.com", "--yes"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.state.Status != dnsdomain.DNSSECDisabled {
+		t.Errorf("status = %s, want disabled", mock.state.Status)
+	}
+}
+
+func TestDNSSECStatus_UnsupportedProvider(t *testing.T) {
+	registerDNSMock(t, &mockProvider{})
+
+	_, err := execDNS(t, "dnssec", "status", "example.com")
+	if err == nil || !strings.Contains(err.Error(), "Mock does not support DNSSEC") {
+		t.Errorf("err = %v, want unsupported error", err)
+	}
+}
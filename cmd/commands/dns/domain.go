@@ -0,0 +1,145 @@
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/zonestats"
+
+	"github.com/spf13/cobra"
+)
+
+// zoneStatsCache and lookupNS are replaced by tests to keep the domain
+// list off the user's cache and the network.
+var (
+	zoneStatsCache                    = cache.NewDefault
+	lookupNS       zonestats.LookupNS = zonestats.LookupDelegation
+)
+
+// DomainCommand returns the "domain" command group.
+func DomainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "domain",
+		Short: "List the zones hosted by a DNS provider",
+	}
+
+	cmd.AddCommand(domainListCommand())
+
+	return cmd
+}
+
+func domainListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List zones with their record counts, plan and delegation",
+		Long: `List the zones hosted by the provider, with the nameservers the
+registrar should point each one at and, for each zone, its record count
+by type, its plan (Cloudflare) and whether the zone is actually delegated
+to those nameservers. Empty and misdelegated zones are flagged below the
+table.
+
+Counting records takes one request per zone, so the statistics are
+cached for five minutes; --refresh fetches them again.
+
+Examples:
+  vpsm dns domain list --provider desec
+  vpsm dns domain list --refresh
+  vpsm dns domain list -o json`,
+		Args:         cobra.NoArgs,
+		RunE:         runDomainList,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().Bool("refresh", false, "Fetch the zone statistics again instead of using the cache")
+
+	return cmd
+}
+
+func runDomainList(cmd *cobra.Command, args []string) error {
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+
+	zones, err := provider.ListZones(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	names := make([]string, len(zones))
+	for i, z := range zones {
+		names[i] = z.Name
+	}
+	results := zonestats.Collect(cmd.Context(), names, zonestats.ProviderFetcher(provider, zones, lookupNS), zonestats.Options{
+		Provider: cmd.Flag("provider").Value.String(),
+		Cache:    zoneStatsCache(),
+		Refresh:  refresh,
+	})
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		type zoneJSON struct {
+			dnsdomain.Zone
+			Stats *dnsdomain.ZoneStats `json:"stats,omitempty"`
+		}
+		out := make([]zoneJSON, len(zones))
+		for i, z := range zones {
+			out[i].Zone = z
+			if results[i].Err == nil {
+				out[i].Stats = &results[i].Stats
+			}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(zones) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No zones found at %s.\n", provider.GetDisplayName())
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTTL\tRECORDS\tPLAN\tNAMESERVERS\tDELEGATION")
+	fmt.Fprintln(w, "----\t---\t-------\t----\t-----------\t----------")
+	var problems []string
+	for i, z := range zones {
+		ttl := "-"
+		if z.TTL > 0 {
+			ttl = fmt.Sprint(z.TTL)
+		}
+		records, plan, delegation := "-", "-", "-"
+		if r := results[i]; r.Err != nil {
+			problems = append(problems, fmt.Sprintf("%s: failed to fetch records: %v", z.Name, r.Err))
+		} else {
+			records = fmt.Sprint(r.Stats.Records)
+			if summary := r.Stats.TypeSummary(); summary != "" {
+				records += " (" + summary + ")"
+			}
+			if r.Stats.Plan != "" {
+				plan = r.Stats.Plan
+			}
+			delegation = string(r.Stats.NameserverStatus())
+			for _, p := range r.Stats.Problems() {
+				problems = append(problems, z.Name+": "+p)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", z.Name, ttl, records, plan, strings.Join(z.Nameservers, ", "), delegation)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		fmt.Fprintln(cmd.OutOrStdout())
+		for _, p := range problems {
+			fmt.Fprintf(cmd.OutOrStdout(), "! %s\n", p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package dns
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// logChanges wraps provider so the record changes made through it are
+// logged. The provider name is logged with the project whose token was
+// used (as a "<provider>:<project>" profile), so a rollback uses the same
+// token.
+func logChanges(cmd *cobra.Command, name, project string, provider dnsdomain.Provider) dnsdomain.Provider {
+	if _, profile := auth.ParseProfile(name); profile == "" && project != "" {
+		name += ":" + project
+	}
+	logged := changelog.Wrap(provider, name, changelog.OpenDefault)
+	logged.OnLogError = func(err error) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the change was made but not logged, so it cannot be rolled back: %v\n", err)
+	}
+	return logged
+}
+
+// HistoryCommand returns the "history" command, which lists the logged
+// record changes.
+func HistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the record changes made with vpsm",
+		Long: `List the record changes made with vpsm (by record create, update and
+delete, apply, import and rollback), newest first, with the ID to pass
+to 'vpsm dns rollback'.
+
+Examples:
+  vpsm dns history
+  vpsm dns history --domain example.com --limit 50
+  vpsm dns history -o json`,
+		Args:         cobra.NoArgs,
+		RunE:         runHistory,
+		SilenceUsage: true,
+		// The log covers every provider.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	}
+
+	cmd.Flags().String("domain", "", "Only list changes to this zone")
+	cmd.Flags().Int("limit", 20, "Number of changes to list (0 for all)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// RollbackCommand returns the "rollback" command, which undoes a logged
+// record change.
+func RollbackCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback <change-id>",
+		Short: "Undo a record change listed by 'vpsm dns history'",
+		Long: `Undo a record change listed by 'vpsm dns history', with the provider
+and token it was made with: a created record is deleted, an updated one
+gets its previous name, value, TTL and priority back, and a deleted one
+is created again (with a new ID).
+
+A record changed again since is left alone unless --force is given. The
+rollback is logged too, so it can be rolled back in turn.
+
+Examples:
+  vpsm dns rollback 42
+  vpsm dns rollback 42 --yes`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runRollback,
+		SilenceUsage: true,
+		// The change names its provider.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	}
+
+	cmd.Flags().Bool("force", false, "Roll back even if the record was changed again since")
+	cmd.Flags().BoolP("yes", "y", false, "Roll back without asking")
+
+	return cmd
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	zone, _ := cmd.Flags().GetString("domain")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	repo, err := changelog.Open()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+
+	changes, err := repo.List(normalizeZone(zone), limit)
+	if err != nil {
+		return err
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		if changes == nil {
+			changes = []changelog.Change{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No record changes logged.")
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tPROVIDER\tZONE\tCHANGE\tNOTE")
+	fmt.Fprintln(w, "--\t----\t--------\t----\t------\t----")
+	for _, c := range changes {
+		note := ""
+		if c.Reverts != 0 {
+			note = fmt.Sprintf("rolls back #%d", c.Reverts)
+		}
+		if by, err := repo.RevertedBy(c.ID); err == nil && by != 0 {
+			note = fmt.Sprintf("rolled back by #%d", by)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", c.ID, c.CreatedAt.Local().Format(time.DateTime), c.Provider, c.Zone, c, note)
+	}
+	return w.Flush()
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(strings.TrimPrefix(args[0], "#"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid change ID %q", args[0])
+	}
+	force, _ := cmd.Flags().GetBool("force")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	repo, err := changelog.Open()
+	if err != nil {
+		return err
+	}
+	change, err := repo.Get(id)
+	if err == nil {
+		var by int64
+		if by, err = repo.RevertedBy(id); err == nil && by != 0 && !force {
+			err = fmt.Errorf("change %d was already rolled back by change %d (use --force to roll it back again)", id, by)
+		}
+	}
+	repo.Close()
+	if err != nil {
+		return err
+	}
+
+	provider, err := providers.Get(change.Provider, auth.NewKeyringStore(auth.ServiceName))
+	if err != nil {
+		return err
+	}
+	logged := changelog.Wrap(provider, change.Provider, changelog.OpenDefault)
+	logged.OnLogError = func(err error) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the rollback was made but not logged: %v\n", err)
+	}
+
+	if !yes {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Roll back change %d in %s (%s)? [y/N]: ", change.ID, change.Zone, change)
+		response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled.")
+			return nil
+		}
+	}
+
+	revert, err := logged.Revert(cmd.Context(), *change, force)
+	if errors.Is(err, changelog.ErrChangedSince) {
+		return fmt.Errorf("%w (use --force to roll back anyway)", err)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Rolled back change %d: %s.\n", change.ID, revert)
+	return nil
+}
@@ -0,0 +1,80 @@
+package dns
+
+import (
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRollback_UndoesRecordUpdate(t *testing.T) {
+	mock := &mockProvider{records: []dnsdomain.Record{{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 3600}}, nextID: 1}
+	registerDNSMock(t, mock)
+
+	if _, err := execDNS(t, "record", "update", "--domain", "example.com", "--id", "1", "--value", "203.0.113.8", "--ttl", "60"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	stdout, err := execDNS(t, "history")
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	if !strings.Contains(stdout, "update www A 203.0.113.7 -> 203.0.113.8, ttl 3600 -> 60") {
+		t.Errorf("expected the update in the history:\n%s", stdout)
+	}
+
+	if _, err := execDNS(t, "rollback", "1", "--yes"); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	want := []dnsdomain.Record{{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 3600}}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after rollback mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout, _ = execDNS(t, "history", "--domain", "example.com")
+	if !strings.Contains(stdout, "rolled back by #2") {
+		t.Errorf("expected the update to be marked rolled back:\n%s", stdout)
+	}
+	if _, err := execDNS(t, "rollback", "1", "--yes"); err == nil || !strings.Contains(err.Error(), "already rolled back") {
+		t.Errorf("second rollback err = %v, want already rolled back", err)
+	}
+}
+
+func TestRollback_RecreatesDeletedRecord(t *testing.T) {
+	priority := 10
+	mx := dnsdomain.Record{ID: "1", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", TTL: 300, Priority: &priority}
+	mock := &mockProvider{records: []dnsdomain.Record{mx}, nextID: 1}
+	registerDNSMock(t, mock)
+
+	if _, err := execDNS(t, "record", "delete", "--domain", "example.com", "--id", "1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := execDNS(t, "rollback", "1", "--yes"); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	mx.ID = "2"
+	if diff := cmp.Diff([]dnsdomain.Record{mx}, mock.records); diff != "" {
+		t.Errorf("records after rollback mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRollback_RefusesRecordChangedSince(t *testing.T) {
+	mock := &mockProvider{records: []dnsdomain.Record{{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7"}}, nextID: 1}
+	registerDNSMock(t, mock)
+
+	for _, value := range []string{"203.0.113.8", "203.0.113.9"} {
+		if _, err := execDNS(t, "record", "update", "--domain", "example.com", "--id", "1", "--value", value); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	}
+
+	_, err := execDNS(t, "rollback", "1", "--yes")
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("err = %v, want a changed-since error suggesting --force", err)
+	}
+	if mock.records[0].Value != "203.0.113.9" {
+		t.Errorf("value = %s, want the record left alone", mock.records[0].Value)
+	}
+}
@@ -0,0 +1,129 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// driftExitCode is the status 'vpsm dns mirror --check' exits with when
+// the secondary has drifted, leaving 1 for failures.
+const driftExitCode = 2
+
+// driftError reports drift found by 'vpsm dns mirror --check'.
+type driftError struct {
+	zone    string
+	changes int
+}
+
+func (e *driftError) Error() string {
+	return fmt.Sprintf("%s has drifted: %d change(s) needed", e.zone, e.changes)
+}
+
+// ExitCode is the status vpsm exits with.
+func (e *driftError) ExitCode() int { return driftExitCode }
+
+// MirrorCommand returns the "mirror" command, which copies a zone's
+// records from its primary provider onto a secondary one.
+func MirrorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Copy a zone's records from its primary provider onto a secondary one",
+		Long: `Compare a zone on its primary provider (--from) with the same zone on a
+secondary provider (--to) and make the secondary match: missing records
+are created, records whose TTL or priority differ are updated, and
+records only the secondary has are deleted. The plan is printed first.
+Records are adjusted for what the secondary accepts (its minimum TTL,
+record types and own apex NS records); those it cannot take are listed
+as skipped.
+
+With --check nothing is changed: the drift is printed and vpsm exits
+with status 2 when there is any (1 on failure), so the command can run
+from cron or a systemd timer to alert on drift.
+
+Examples:
+  vpsm dns mirror --domain example.com --from cloudflare --to desec
+  vpsm dns mirror --domain example.com --from cloudflare --to desec --yes
+  vpsm dns mirror --domain example.com --from cloudflare --to desec --check`,
+		Args:         cobra.NoArgs,
+		RunE:         runMirror,
+		SilenceUsage: true,
+		// --from and --to name the providers.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return resolveProviderFlag(cmd, false)
+		},
+	}
+
+	cmd.Flags().String("domain", "", "Zone to mirror (required)")
+	cmd.Flags().String("from", "", "Primary provider (required)")
+	cmd.Flags().String("to", "", "Secondary provider (required)")
+	cmd.Flags().Bool("check", false, "Only report drift, exiting with status 2 when there is any")
+	cmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+	for _, name := range []string{"domain", "from", "to"} {
+		cmd.MarkFlagRequired(name)
+	}
+
+	return cmd
+}
+
+func runMirror(cmd *cobra.Command, args []string) error {
+	zone, _ := cmd.Flags().GetString("domain")
+	zone = normalizeZone(zone)
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	check, _ := cmd.Flags().GetBool("check")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	if strings.EqualFold(from, to) {
+		return fmt.Errorf("--from and --to name the same provider")
+	}
+	primary, err := applyProvider(cmd, from)
+	if err != nil {
+		return fmt.Errorf("%s: %w", from, err)
+	}
+	secondary, err := applyProvider(cmd, to)
+	if err != nil {
+		return fmt.Errorf("%s: %w", to, err)
+	}
+
+	want, err := primary.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return fmt.Errorf("%s: %w", primary.GetDisplayName(), err)
+	}
+	have, err := secondary.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return fmt.Errorf("%s: %w", secondary.GetDisplayName(), err)
+	}
+	plan := dnsdomain.PlanMirror(want, have, secondary.Quirks())
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s: %s -> %s\n", zone, primary.GetDisplayName(), secondary.GetDisplayName())
+	printPlan(cmd.OutOrStdout(), plan, "  ")
+	if plan.InSync() {
+		fmt.Fprintln(cmd.OutOrStdout(), "  in sync")
+		return nil
+	}
+	if check {
+		return &driftError{zone: zone, changes: len(plan.Changes)}
+	}
+
+	if !yes {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Apply %d change(s) to %s? [y/N]: ", len(plan.Changes), secondary.GetDisplayName())
+		response, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled.")
+			return nil
+		}
+	}
+
+	applied, err := dnsdomain.ApplyChanges(cmd.Context(), secondary, zone, plan.Changes)
+	if err != nil {
+		return fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(plan.Changes))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Applied %d change(s).\n", applied)
+	return nil
+}
@@ -0,0 +1,70 @@
+package dns
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func registerMirrorPair(t *testing.T) (primary, secondary *mockProvider) {
+	t.Helper()
+	primary = &mockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "2", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all", TTL: 3600},
+	}}
+	secondary = &mockProvider{nextID: 20, records: []dnsdomain.Record{
+		{ID: "11", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "12", Name: "old", Type: dnsdomain.RecordCNAME, Value: "www.example.com", TTL: 300},
+	}}
+	registerDNSMock(t, primary)
+	providers.Register("secondary", func(auth.Store) (dnsdomain.Provider, error) { return secondary, nil })
+	return primary, secondary
+}
+
+func TestMirror_CheckExitsNonZeroOnDrift(t *testing.T) {
+	_, secondary := registerMirrorPair(t)
+	before := append([]dnsdomain.Record(nil), secondary.records...)
+
+	stdout, err := execDNS(t, "mirror", "--domain", "example.com", "--from", "mock", "--to", "secondary", "--check")
+	var drift *driftError
+	if !errors.As(err, &drift) {
+		t.Fatalf("expected a drift error, got %v", err)
+	}
+	if drift.ExitCode() != driftExitCode || drift.changes != 2 {
+		t.Errorf("drift = %+v, exit code %d", drift, drift.ExitCode())
+	}
+	for _, want := range []string{"+ create @ TXT", "- delete old CNAME"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if diff := cmp.Diff(before, secondary.records); diff != "" {
+		t.Errorf("--check changed the secondary (-want +got):\n%s", diff)
+	}
+}
+
+func TestMirror_CopiesThenReportsInSync(t *testing.T) {
+	_, secondary := registerMirrorPair(t)
+
+	if _, err := execDNS(t, "mirror", "--domain", "example.com", "--from", "mock", "--to", "secondary", "--yes"); err != nil {
+		t.Fatalf("mirror: %v", err)
+	}
+	want := []dnsdomain.Record{
+		{ID: "11", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "21", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all", TTL: 3600},
+	}
+	if diff := cmp.Diff(want, secondary.records); diff != "" {
+		t.Errorf("secondary after mirror mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout, err := execDNS(t, "mirror", "--domain", "example.com", "--from", "mock", "--to", "secondary", "--check")
+	if err != nil || !strings.Contains(stdout, "in sync") {
+		t.Errorf("check after mirror: err = %v, output:\n%s", err, stdout)
+	}
+}
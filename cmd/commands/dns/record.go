@@ -0,0 +1,334 @@
+package dns
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/tui"
+	"nathanbeddoewebdev/vpsm/internal/platform/format"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// RecordCommand returns the "record" command group.
+func RecordCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "record",
+		Aliases: []string{"rec"},
+		Short:   "List, create, update and delete records in a zone",
+	}
+
+	cmd.AddCommand(recordListCommand())
+	cmd.AddCommand(recordCreateCommand())
+	cmd.AddCommand(recordUpdateCommand())
+	cmd.AddCommand(recordDeleteCommand())
+
+	return cmd
+}
+
+func recordListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the records in a zone",
+		Long: `List the records in a zone, sorted by name and type. The ID column
+identifies a record for update and delete.
+
+In a terminal, opens an interactive list where "r" renames the selected
+record. Use --output table or json, or a --format Go template, for
+non-interactive output.
+
+Examples:
+  vpsm dns record list --domain example.com
+  vpsm dns record list --domain example.com -o json
+  vpsm dns record list --domain example.com --format '{{.Name}}\t{{.Type}}\t{{.Value}}'`,
+		Args:         cobra.NoArgs,
+		RunE:         runRecordList,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("domain", "", "Zone to list (required)")
+	cmd.MarkFlagRequired("domain")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().String("format", "", "Format each record with a Go template, e.g. '{{.Name}}\\t{{.Value}}'")
+	cmd.MarkFlagsMutuallyExclusive("output", "format")
+
+	return cmd
+}
+
+func recordCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "create",
+		Aliases: []string{"add"},
+		Short:   "Create a record",
+		Long: `Create a record in a zone. The name is relative to the zone, with "@"
+for the apex. MX and SRV records need a --priority; SRV values are
+"<weight> <port> <target>" and CAA values '<flags> <tag> "<value>"'.
+TXT values longer than 255 characters are split into several strings
+unless given already quoted. Values are checked before anything is sent.
+
+With --upsert, a record with the same name and type is updated instead,
+and nothing is sent when it already matches, so deploy scripts can run
+the command again safely. Several records sharing the name and type are
+ambiguous, and the command fails rather than overwrite one of them.
+
+Examples:
+  vpsm dns record create --domain example.com --name www --type A --value 203.0.113.7
+  vpsm dns record create --domain example.com --name @ --type MX --value mail.example.com --priority 10
+  vpsm dns record create --domain example.com --name www --type A --value 203.0.113.8 --upsert`,
+		Args:         cobra.NoArgs,
+		RunE:         runRecordCreate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("domain", "", "Zone to create the record in (required)")
+	cmd.Flags().String("name", "", `Record name relative to the zone, "@" for the apex (required)`)
+	cmd.Flags().String("type", "", "Record type, e.g. A, AAAA, CNAME, MX, TXT (required)")
+	cmd.Flags().String("value", "", "Record value (required)")
+	cmd.Flags().Int("ttl", 0, "TTL in seconds (default: the provider's)")
+	cmd.Flags().Int("priority", 0, "Priority of MX and SRV records")
+	cmd.Flags().Bool("upsert", false, "Update the record with the same name and type if there is one")
+	for _, name := range []string{"domain", "name", "type", "value"} {
+		cmd.MarkFlagRequired(name)
+	}
+
+	return cmd
+}
+
+func recordUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "Update a record",
+		Long: `Update a record, identified by the ID shown by 'vpsm dns record list'.
+Only the given flags are changed.
+
+Examples:
+  vpsm dns record update --domain example.com --id 1a2b3c --value 203.0.113.8
+  vpsm dns record update --domain example.com --id 1a2b3c --ttl 300`,
+		Args:         cobra.NoArgs,
+		RunE:         runRecordUpdate,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("domain", "", "Zone the record is in (required)")
+	cmd.Flags().String("id", "", "Record ID (required)")
+	cmd.Flags().String("name", "", `New record name, "@" for the apex`)
+	cmd.Flags().String("type", "", "New record type")
+	cmd.Flags().String("value", "", "New record value")
+	cmd.Flags().Int("ttl", 0, "New TTL in seconds")
+	cmd.Flags().Int("priority", 0, "New priority of an MX or SRV record")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagsOneRequired("name", "type", "value", "ttl", "priority")
+
+	return cmd
+}
+
+func recordDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete",
+		Aliases: []string{"rm"},
+		Short:   "Delete a record",
+		Long: `Delete a record, identified by the ID shown by 'vpsm dns record list'.
+
+Examples:
+  vpsm dns record delete --domain example.com --id 1a2b3c`,
+		Args:         cobra.NoArgs,
+		RunE:         runRecordDelete,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("domain", "", "Zone the record is in (required)")
+	cmd.Flags().String("id", "", "Record ID (required)")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func runRecordList(cmd *cobra.Command, args []string) error {
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone, _ := cmd.Flags().GetString("domain")
+	// The records feed the server TUI's search palette.
+	indexKey := auth.ProjectKey(cmd.Flag("provider").Value.String(), auth.Project())
+
+	if !cmd.Flags().Changed("output") && !cmd.Flags().Changed("format") && term.IsTerminal(int(os.Stdout.Fd())) {
+		return tui.RunRecordList(provider, provider.GetDisplayName(), indexKey, zone)
+	}
+
+	records, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+	tagindexsvc.IndexZone(indexKey, zone, records)
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Name != records[j].Name {
+			return records[i].Name < records[j].Name
+		}
+		return records[i].Type < records[j].Type
+	})
+
+	if text, _ := cmd.Flags().GetString("format"); text != "" {
+		tmpl, err := format.Parse(text)
+		if err != nil {
+			return err
+		}
+		return format.Lines(cmd.OutOrStdout(), tmpl, records)
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		if records == nil {
+			records = []dnsdomain.Record{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No records in %s.\n", zone)
+		return nil
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tTTL\tPRIORITY\tVALUE")
+	fmt.Fprintln(w, "--\t----\t----\t---\t--------\t-----")
+	for _, r := range records {
+		ttl, priority := "-", "-"
+		if r.TTL > 0 {
+			ttl = fmt.Sprint(r.TTL)
+		}
+		if r.Priority != nil {
+			priority = fmt.Sprint(*r.Priority)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.ID, r.Name, r.Type, ttl, priority, r.Value)
+	}
+	return w.Flush()
+}
+
+func runRecordCreate(cmd *cobra.Command, args []string) error {
+	zone, _ := cmd.Flags().GetString("domain")
+	name, _ := cmd.Flags().GetString("name")
+	recordType, _ := cmd.Flags().GetString("type")
+	value, _ := cmd.Flags().GetString("value")
+	ttl, _ := cmd.Flags().GetInt("ttl")
+
+	r := dnsdomain.Record{
+		Name:  name,
+		Type:  dnsdomain.ParseRecordType(recordType),
+		Value: value,
+		TTL:   ttl,
+	}
+	if cmd.Flags().Changed("priority") {
+		priority, _ := cmd.Flags().GetInt("priority")
+		r.Priority = &priority
+	}
+	if err := dnsdomain.ValidateRecord(r); err != nil {
+		return err
+	}
+
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+
+	if upsert, _ := cmd.Flags().GetBool("upsert"); upsert {
+		result, action, err := dnsdomain.Upsert(cmd.Context(), provider, zone, r)
+		if err != nil {
+			return err
+		}
+		switch action {
+		case dnsdomain.UpsertCreate:
+			fmt.Fprintf(cmd.OutOrStdout(), "Created %s %s record in %s (ID %s).\n", result.Name, result.Type, zone, result.ID)
+		case dnsdomain.UpsertUpdate:
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated %s %s record in %s (ID %s).\n", result.Name, result.Type, zone, result.ID)
+		default:
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s record in %s is up to date (ID %s).\n", result.Name, result.Type, zone, result.ID)
+		}
+		return nil
+	}
+
+	created, err := provider.CreateRecord(cmd.Context(), zone, r)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Created %s %s record in %s (ID %s).\n", created.Name, created.Type, zone, created.ID)
+	return nil
+}
+
+func runRecordUpdate(cmd *cobra.Command, args []string) error {
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone, _ := cmd.Flags().GetString("domain")
+	id, _ := cmd.Flags().GetString("id")
+
+	records, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+	var r *dnsdomain.Record
+	for i := range records {
+		if records[i].ID == id {
+			r = &records[i]
+			break
+		}
+	}
+	if r == nil {
+		return fmt.Errorf("record %s not found in %s", id, zone)
+	}
+
+	if cmd.Flags().Changed("name") {
+		r.Name, _ = cmd.Flags().GetString("name")
+	}
+	if cmd.Flags().Changed("type") {
+		recordType, _ := cmd.Flags().GetString("type")
+		r.Type = dnsdomain.ParseRecordType(recordType)
+	}
+	if cmd.Flags().Changed("value") {
+		r.Value, _ = cmd.Flags().GetString("value")
+	}
+	if cmd.Flags().Changed("ttl") {
+		r.TTL, _ = cmd.Flags().GetInt("ttl")
+	}
+	if cmd.Flags().Changed("priority") {
+		priority, _ := cmd.Flags().GetInt("priority")
+		r.Priority = &priority
+	}
+	if err := dnsdomain.ValidateRecord(*r); err != nil {
+		return err
+	}
+
+	updated, err := provider.UpdateRecord(cmd.Context(), zone, *r)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Updated %s %s record in %s (ID %s).\n", updated.Name, updated.Type, zone, updated.ID)
+	return nil
+}
+
+func runRecordDelete(cmd *cobra.Command, args []string) error {
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	zone, _ := cmd.Flags().GetString("domain")
+	id, _ := cmd.Flags().GetString("id")
+
+	if err := provider.DeleteRecord(cmd.Context(), zone, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted record %s from %s.\n", id, zone)
+	return nil
+}
@@ -0,0 +1,82 @@
+package dns
+
+import (
+	"fmt"
+	"strings"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// RenameCommand returns the "rename" command, which moves records to
+// another name in the zone.
+func RenameCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <name> <type> <new-name>",
+		Short: "Move a record to another name, keeping its content, TTL and priority",
+		Long: `Move the records with a name and type to another name in the zone
+("@" for the apex), keeping their content, TTL and priority. Every
+record of the set moves (round-robin A records, several TXT values)
+unless --value picks one.
+
+The new name is checked before anything is sent: it must be a valid
+hostname, and must not already hold an identical record, or any record
+at all when a CNAME is involved.
+
+Examples:
+  vpsm dns rename --domain example.com www A web
+  vpsm dns rename --domain example.com @ TXT _old --value "v=spf1 -all"`,
+		Args:         cobra.ExactArgs(3),
+		RunE:         runRename,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("domain", "", "Zone the records are in (required)")
+	cmd.Flags().String("value", "", "Only move the record with this value")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	zone, _ := cmd.Flags().GetString("domain")
+	name, recordType, newName := args[0], dnsdomain.ParseRecordType(args[1]), args[2]
+
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	existing, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+
+	var renamed []dnsdomain.Record
+	for _, r := range existing {
+		if r.Type != recordType || !strings.EqualFold(r.Name, name) {
+			continue
+		}
+		if cmd.Flags().Changed("value") {
+			if value, _ := cmd.Flags().GetString("value"); r.Value != value {
+				continue
+			}
+		}
+		moved, err := dnsdomain.Rename(r, newName, existing)
+		if err != nil {
+			return err
+		}
+		renamed = append(renamed, moved)
+	}
+	if len(renamed) == 0 {
+		return fmt.Errorf("no %s record named %q in %s: %w", recordType, name, zone, dnsdomain.ErrNotFound)
+	}
+
+	for i, r := range renamed {
+		if _, err := provider.UpdateRecord(cmd.Context(), zone, r); err != nil {
+			return fmt.Errorf("%w (%d of %d record(s) renamed)", err, i, len(renamed))
+		}
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Renamed %d %s record(s) in %s from %s to %s.\n", len(renamed), recordType, zone, name, renamed[0].Name)
+	return nil
+}
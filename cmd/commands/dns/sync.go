@@ -0,0 +1,136 @@
+package dns
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// syncRoundDone is called after each round of 'vpsm dns sync --watch'.
+// Tests replace it to step through the rounds.
+var syncRoundDone = func() {}
+
+// SyncCommand returns the "sync" command, which converges one zone to a
+// declarative file, once or whenever the file or the zone drifts.
+func SyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Keep a zone converged to a YAML or JSON file",
+		Long: `Converge one domain of a desired-state file (the format read by
+'vpsm dns apply') to the records it lists, printing the diff first.
+
+With --watch, the file and the zone are compared again every --interval
+until interrupted, and any drift is printed and applied as it appears:
+an edit to the file, or a record changed behind vpsm's back. A file that
+fails to parse (say, half-saved) is reported and retried on the next
+round; the zone is left as it is meanwhile.
+
+Examples:
+  vpsm dns sync --domain example.com -f zone.yaml
+  vpsm dns sync --domain example.com -f zone.yaml --watch
+  vpsm dns sync --domain example.com -f zone.yaml --watch --interval 1m --dry-run`,
+		Args:         cobra.NoArgs,
+		RunE:         runSync,
+		SilenceUsage: true,
+		// The file may name the domain's provider.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return resolveProviderFlag(cmd, false)
+		},
+	}
+
+	cmd.Flags().String("domain", "", "Domain of the file to sync (required)")
+	cmd.Flags().StringP("file", "f", "", "YAML or JSON file of desired records (required)")
+	cmd.Flags().Bool("watch", false, "Keep syncing until interrupted")
+	cmd.Flags().Duration("interval", 30*time.Second, "How often --watch compares the file and the zone")
+	cmd.Flags().Bool("dry-run", false, "Show the drift without applying it")
+	cmd.MarkFlagRequired("domain")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	zone, _ := cmd.Flags().GetString("domain")
+	zone = normalizeZone(zone)
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+
+	if !watch {
+		_, err := syncZone(cmd, zone)
+		return err
+	}
+	if interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	cmd.SetContext(ctx)
+
+	path, _ := cmd.Flags().GetString("file")
+	fmt.Fprintf(cmd.ErrOrStderr(), "Watching %s for %s every %s (Ctrl+C to stop).\n", path, zone, interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changed, err := syncZone(cmd, zone)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s %v\n", time.Now().Format(time.TimeOnly), err)
+		} else if !changed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "%s %s is in sync\n", time.Now().Format(time.TimeOnly), zone)
+		}
+		syncRoundDone()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncZone converges zone to the file once, printing the plan, and
+// reports whether there was drift.
+func syncZone(cmd *cobra.Command, zone string) (bool, error) {
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	state, err := loadDesiredState(path)
+	if err != nil {
+		return false, err
+	}
+	desired, ok := state.Domains[zone]
+	if !ok {
+		return false, fmt.Errorf("%s does not list %s", path, zone)
+	}
+	provider, err := applyProvider(cmd, desired.Provider)
+	if err != nil {
+		return false, err
+	}
+
+	ctx := cmd.Context()
+	existing, err := provider.ListRecords(ctx, zone)
+	if err != nil {
+		return false, err
+	}
+	plan := dnsdomain.PlanImport(desired.Records, existing, provider.Quirks(), true)
+	if plan.InSync() {
+		return false, nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s %s (%s) drifted:\n", time.Now().Format(time.TimeOnly), zone, provider.GetDisplayName())
+	printPlan(cmd.OutOrStdout(), plan, "  ")
+	if dryRun {
+		return true, nil
+	}
+	applied, err := dnsdomain.ApplyChanges(ctx, provider, zone, plan.Changes)
+	if err != nil {
+		return true, fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(plan.Changes))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Applied %d change(s).\n", applied)
+	return true, nil
+}
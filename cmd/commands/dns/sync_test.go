@@ -0,0 +1,94 @@
+package dns
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSync_ConvergesOneDomain(t *testing.T) {
+	mock := &mockProvider{nextID: 10, records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1"},
+	}}
+	registerDNSMock(t, mock)
+	path := writeDesiredState(t, "zone.yaml", `domains:
+  example.com:
+    records:
+      - {name: www, type: A, value: 203.0.113.10}
+  example.org:
+    records: []
+`)
+
+	stdout, err := execDNS(t, "sync", "--domain", "example.com", "-f", path)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if !strings.Contains(stdout, "~ update www A 203.0.113.10") {
+		t.Errorf("expected the drift in the output:\n%s", stdout)
+	}
+	want := []dnsdomain.Record{{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10"}}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := execDNS(t, "sync", "--domain", "example.net", "-f", path); err == nil || !strings.Contains(err.Error(), "does not list example.net") {
+		t.Errorf("err = %v, want the domain to be missing from the file", err)
+	}
+}
+
+func TestSync_WatchAppliesFileEdits(t *testing.T) {
+	mock := &mockProvider{nextID: 10}
+	registerDNSMock(t, mock)
+	path := writeDesiredState(t, "zone.yaml", `domains:
+  example.com:
+    records:
+      - {name: www, type: A, value: 203.0.113.10}
+`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Each round is followed by the next edit; the last one stops the watch.
+	edits := []func(){
+		// A half-saved file is reported and leaves the zone alone.
+		func() { os.WriteFile(path, []byte("domains: [\n"), 0o644) },
+		func() {
+			os.WriteFile(path, []byte(`domains:
+  example.com:
+    records:
+      - {name: api, type: A, value: 203.0.113.20}
+`), 0o644)
+		},
+		cancel,
+	}
+	orig := syncRoundDone
+	syncRoundDone = func() {
+		if len(edits) > 0 {
+			edits[0]()
+			edits = edits[1:]
+		}
+	}
+	t.Cleanup(func() { syncRoundDone = orig })
+
+	var out bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"sync", "--domain", "example.com", "-f", path, "--watch", "--interval", "1ms", "--provider", "mock"})
+	if err := cmd.ExecuteContext(ctx); err != nil {
+		t.Fatalf("sync --watch: %v", err)
+	}
+
+	want := []dnsdomain.Record{{ID: "12", Name: "api", Type: dnsdomain.RecordA, Value: "203.0.113.20"}}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+	if !strings.Contains(out.String(), "failed to parse") {
+		t.Errorf("expected the parse error to be reported:\n%s", out.String())
+	}
+}
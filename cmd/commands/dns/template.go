@@ -0,0 +1,164 @@
+package dns
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// ApplyTemplateCommand returns the "apply-template" command, which adds a
+// built-in template's records to a zone.
+func ApplyTemplateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply-template <template>",
+		Short: "Add the records of a common setup, such as GitHub Pages, to a zone",
+		Long: `Add the records of a built-in template to a zone. The template's
+variables come from --var or, when missing, are asked for. The records
+the zone lacks are then shown as a diff and created once confirmed;
+records already there (whatever their TTL) and the zone's other records
+are left alone.
+
+Templates (--list shows them with their variables):
+  github-pages         GitHub Pages apex A/AAAA set and www CNAME
+  google-verification  Google site verification TXT record
+  fastmail             Fastmail MX set and SPF record
+
+Examples:
+  vpsm dns apply-template --list
+  vpsm dns apply-template github-pages --domain example.com
+  vpsm dns apply-template google-verification --domain example.com --var token=abc123 --yes`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         runApplyTemplate,
+		SilenceUsage: true,
+		// Listing the templates needs no provider.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			list, _ := cmd.Flags().GetBool("list")
+			return resolveProviderFlag(cmd, !list)
+		},
+	}
+
+	cmd.Flags().String("domain", "", "Zone to add the records to (required unless --list)")
+	cmd.Flags().StringArray("var", nil, "Template variable as name=value (repeatable)")
+	cmd.Flags().Bool("list", false, "List the templates and their variables")
+	cmd.Flags().Bool("dry-run", false, "Show the diff without creating anything")
+	cmd.Flags().BoolP("yes", "y", false, "Create the records without asking")
+
+	return cmd
+}
+
+func runApplyTemplate(cmd *cobra.Command, args []string) error {
+	if list, _ := cmd.Flags().GetBool("list"); list {
+		printTemplates(cmd)
+		return nil
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("name a template (see --list)")
+	}
+	tmpl := dnsdomain.LookupTemplate(args[0])
+	if tmpl == nil {
+		return fmt.Errorf("unknown template %q (see --list)", args[0])
+	}
+	zone, _ := cmd.Flags().GetString("domain")
+	if zone == "" {
+		return fmt.Errorf("--domain is required")
+	}
+	zone = normalizeZone(zone)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	vars := map[string]string{}
+	pairs, _ := cmd.Flags().GetStringArray("var")
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid --var %q: want name=value", pair)
+		}
+		vars[strings.TrimSpace(name)] = value
+	}
+
+	// Prompts and the confirmation share one reader, so piped answers
+	// are not lost to buffering.
+	stdin := bufio.NewReader(cmd.InOrStdin())
+	for _, v := range tmpl.Vars {
+		if strings.TrimSpace(vars[v.Name]) != "" {
+			continue
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s: ", v.Prompt)
+		answer, _ := stdin.ReadString('\n')
+		vars[v.Name] = strings.TrimSpace(answer)
+	}
+	records, err := tmpl.Render(vars)
+	if err != nil {
+		return err
+	}
+
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	existing, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+
+	// Only the missing records are created: a mirror plan's updates and
+	// deletes would touch records the template does not own.
+	mirror := dnsdomain.PlanMirror(records, existing, provider.Quirks())
+	plan := dnsdomain.MirrorPlan{Skipped: mirror.Skipped}
+	for _, c := range mirror.Changes {
+		if c.Action == dnsdomain.MirrorCreate {
+			plan.Changes = append(plan.Changes, c)
+		}
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "%s in %s (%s):\n", tmpl.Name, zone, provider.GetDisplayName())
+	if plan.InSync() {
+		printPlan(cmd.OutOrStdout(), plan, "  ")
+		fmt.Fprintln(cmd.OutOrStdout(), "  every record is already there")
+		return nil
+	}
+	printPlan(cmd.OutOrStdout(), plan, "  ")
+	if dryRun {
+		return nil
+	}
+	if !yes {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Create %d record(s)? [y/N]: ", len(plan.Changes))
+		response, _ := stdin.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled.")
+			return nil
+		}
+	}
+
+	applied, err := dnsdomain.ApplyChanges(cmd.Context(), provider, zone, plan.Changes)
+	if err != nil {
+		return fmt.Errorf("%w (%d of %d record(s) created)", err, applied, len(plan.Changes))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Created %d record(s).\n", applied)
+	return nil
+}
+
+// printTemplates lists the built-in templates and their variables.
+func printTemplates(cmd *cobra.Command) {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "TEMPLATE\tVARIABLES\tDESCRIPTION")
+	fmt.Fprintln(w, "--------\t---------\t-----------")
+	for _, t := range dnsdomain.Templates {
+		names := make([]string, len(t.Vars))
+		for i, v := range t.Vars {
+			names[i] = v.Name
+		}
+		vars := strings.Join(names, ", ")
+		if vars == "" {
+			vars = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name, vars, t.Description)
+	}
+	w.Flush()
+}
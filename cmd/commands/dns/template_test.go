@@ -0,0 +1,69 @@
+package dns
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+func TestApplyTemplate_PromptsPreviewsAndCreatesMissing(t *testing.T) {
+	mock := &mockProvider{nextID: 10, records: []dnsdomain.Record{
+		{ID: "1", Name: "@", Type: dnsdomain.RecordA, Value: "185.199.108.153", TTL: 3600},
+		{ID: "2", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all"},
+	}}
+	registerDNSMock(t, mock)
+
+	var out, errOut bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetIn(strings.NewReader("octocat\ny\n"))
+	cmd.SetArgs([]string{"apply-template", "github-pages", "--domain", "example.com", "--provider", "mock"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("apply-template: %v", err)
+	}
+
+	if !strings.Contains(errOut.String(), "GitHub user or organisation: ") {
+		t.Errorf("expected a prompt for the user variable:\n%s", errOut.String())
+	}
+	if !strings.Contains(out.String(), "+ create www CNAME octocat.github.io.") {
+		t.Errorf("expected the CNAME in the preview:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "185.199.108.153") {
+		t.Errorf("the existing A record should not be in the preview:\n%s", out.String())
+	}
+	// 3 A, 4 AAAA and the CNAME are added; the SPF record is kept.
+	if len(mock.records) != 10 || mock.records[1].Value != "v=spf1 -all" {
+		t.Errorf("expected 8 records added next to the existing ones, got %+v", mock.records)
+	}
+}
+
+func TestApplyTemplate_DryRunWithVars(t *testing.T) {
+	mock := &mockProvider{}
+	registerDNSMock(t, mock)
+
+	stdout, err := execDNS(t, "apply-template", "google-verification", "--domain", "example.com", "--var", "token=abc123", "--dry-run")
+	if err != nil {
+		t.Fatalf("apply-template: %v", err)
+	}
+	if !strings.Contains(stdout, "+ create @ TXT google-site-verification=abc123") {
+		t.Errorf("expected the TXT record in the preview:\n%s", stdout)
+	}
+	if len(mock.records) != 0 {
+		t.Errorf("dry run created records: %+v", mock.records)
+	}
+}
+
+func TestApplyTemplate_List(t *testing.T) {
+	registerDNSMock(t, &mockProvider{})
+
+	stdout, err := execDNS(t, "apply-template", "--list")
+	if err != nil {
+		t.Fatalf("apply-template --list: %v", err)
+	}
+	if !strings.Contains(stdout, "github-pages") || !strings.Contains(stdout, "token") {
+		t.Errorf("expected the templates and their variables:\n%s", stdout)
+	}
+}
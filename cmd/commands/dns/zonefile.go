@@ -0,0 +1,146 @@
+package dns
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/spf13/cobra"
+)
+
+// ExportCommand returns the "export" command, which writes a zone's
+// records as a BIND zone file.
+func ExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <domain>",
+		Short: "Export a zone's records as a BIND zone file",
+		Long: `Write the records of a zone in BIND zone file format, to standard output
+or to --file. The SOA record is left out, since every provider generates
+its own; the file can be imported into another provider with
+'vpsm dns import'.
+
+Examples:
+  vpsm dns export example.com
+  vpsm dns export example.com --file example.com.zone`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runExport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP("file", "f", "", "Write to this file instead of standard output")
+
+	return cmd
+}
+
+// ImportCommand returns the "import" command, which converges a zone to
+// the records of a BIND zone file.
+func ImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <domain>",
+		Short: "Create and update records from a BIND zone file",
+		Long: `Read a BIND zone file and create or update records so the zone holds
+them. The changes are listed first, like a diff: "+" creates, "~" updates
+and "-" deletes. A record whose value differs from the file's replaces it
+in place; records the file does not mention are kept unless --prune is
+given. SOA records are ignored, and records the provider cannot hold
+(such as its own apex NS records) are skipped.
+
+Use --dry-run to only show the changes.
+
+Examples:
+  vpsm dns import example.com --file example.com.zone --dry-run
+  vpsm dns import example.com --file example.com.zone
+  vpsm dns export example.com --provider hetzner | vpsm dns import example.com --provider desec --file -`,
+		Args:         cobra.ExactArgs(1),
+		RunE:         runImport,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP("file", "f", "", `Zone file to import, "-" for standard input (required)`)
+	cmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	cmd.Flags().Bool("prune", false, "Delete records the zone file does not list")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	zone := args[0]
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+
+	records, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+	out := fmt.Sprintf("; %s at %s, exported by vpsm\n", zone, provider.GetDisplayName()) +
+		dnsdomain.FormatZoneFile(zone, records)
+
+	path, _ := cmd.Flags().GetString("file")
+	if path == "" {
+		_, err := io.WriteString(cmd.OutOrStdout(), out)
+		return err
+	}
+	if err := os.WriteFile(path, []byte(out), 0o644); err != nil {
+		return fmt.Errorf("failed to write zone file: %w", err)
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d record(s) to %s.\n", len(records), path)
+	return nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	zone := args[0]
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	in := cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open zone file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	imported, err := dnsdomain.ParseZoneFile(in, zone)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, r := range imported {
+		if err := dnsdomain.ValidateRecord(r); err != nil {
+			return fmt.Errorf("invalid %s %s record: %w", r.Name, r.Type, err)
+		}
+	}
+
+	provider, err := dnsProvider(cmd)
+	if err != nil {
+		return err
+	}
+	existing, err := provider.ListRecords(cmd.Context(), zone)
+	if err != nil {
+		return err
+	}
+
+	plan := dnsdomain.PlanImport(imported, existing, provider.Quirks(), prune)
+	printPlan(cmd.OutOrStdout(), plan, "")
+	if plan.InSync() {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s already matches the zone file.\n", zone)
+		return nil
+	}
+	if dryRun {
+		fmt.Fprintf(cmd.OutOrStdout(), "Dry run: %d change(s) not applied.\n", len(plan.Changes))
+		return nil
+	}
+
+	applied, err := dnsdomain.ApplyChanges(cmd.Context(), provider, zone, plan.Changes)
+	if err != nil {
+		return fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(plan.Changes))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Applied %d change(s) to %s.\n", applied, zone)
+	return nil
+}
@@ -0,0 +1,81 @@
+package dns
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExport_WritesZoneFile(t *testing.T) {
+	registerDNSMock(t, &mockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+	}})
+
+	stdout, err := execDNS(t, "export", "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"$ORIGIN example.com.", "www  300     IN  A      203.0.113.10"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestImport_DryRunThenApply(t *testing.T) {
+	mock := &mockProvider{nextID: 10, records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1", TTL: 300},
+		{ID: "2", Name: "old", Type: dnsdomain.RecordA, Value: "203.0.113.99", TTL: 300},
+	}}
+	registerDNSMock(t, mock)
+
+	path := filepath.Join(t.TempDir(), "example.com.zone")
+	zoneFile := "$TTL 300\nwww IN A 203.0.113.10\n@ IN MX 10 mail\n"
+	if err := os.WriteFile(path, []byte(zoneFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, err := execDNS(t, "import", "example.com", "--file", path, "--dry-run")
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	for _, want := range []string{"~ update www A 203.0.113.10", "+ create @ MX mail.example.com", "Dry run: 2 change(s)"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in dry run output:\n%s", want, stdout)
+		}
+	}
+	if mock.nextID != 10 || mock.records[0].Value != "203.0.113.1" {
+		t.Fatalf("dry run changed the zone: %+v", mock.records)
+	}
+
+	if _, err := execDNS(t, "import", "example.com", "--file", path); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	priority := 10
+	want := []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "2", Name: "old", Type: dnsdomain.RecordA, Value: "203.0.113.99", TTL: 300},
+		{ID: "11", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", TTL: 300, Priority: &priority},
+	}
+	if diff := cmp.Diff(want, mock.records); diff != "" {
+		t.Errorf("records after import mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestImport_RejectsInvalidRecords(t *testing.T) {
+	registerDNSMock(t, &mockProvider{})
+
+	path := filepath.Join(t.TempDir(), "bad.zone")
+	if err := os.WriteFile(path, []byte("www IN A 2001:db8::1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := execDNS(t, "import", "example.com", "--file", path)
+	if err == nil || !strings.Contains(err.Error(), "invalid www A record") {
+		t.Errorf("err = %v, want an invalid record error", err)
+	}
+}
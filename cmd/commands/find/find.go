@@ -0,0 +1,163 @@
+package find
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "find <term>...",
+		Short: "Find resources by tag across providers",
+		Long: `Find resources across every provider you are logged in to.
+
+Provider labels are kept in a local tag index, along with the backups
+and DNS records vpsm has listed. Before searching, the servers and
+volumes in the index are refreshed from each provider; a provider that
+cannot be reached is searched using what was last indexed. Use --offline to search the index
+without contacting any provider.
+
+Search terms:
+  tag:<selector>     label selector, e.g. tag:env=prod or tag:env!=dev,role
+  provider:<name>    only resources from this provider
+  kind:<kind>        only resources of this kind (server, volume, backup or dns-record)
+  <word>             resource name contains word
+
+Examples:
+  vpsm find tag:env=prod
+  vpsm find tag:env=prod tag:role=web
+  vpsm find provider:hetzner web
+  vpsm find tag:env=prod -o json`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runFind,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().Bool("offline", false, "Search the local index without refreshing it from providers")
+
+	return cmd
+}
+
+func runFind(cmd *cobra.Command, args []string) {
+	q, err := tagindexsvc.ParseQuery(args)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	repo, err := tagindex.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	svc := tagindexsvc.NewService(repo)
+	defer svc.Close()
+
+	if offline, _ := cmd.Flags().GetBool("offline"); !offline {
+		refresh(cmd, svc, q.Provider)
+	}
+
+	resources, err := svc.Find(q)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		printJSON(cmd, resources)
+		return
+	}
+
+	if len(resources) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No matching resources found.")
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tKIND\tID\tNAME\tLABELS")
+	fmt.Fprintln(w, "--------\t----\t--\t----\t------")
+	for _, res := range resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", res.Provider, res.Kind, res.ID, res.Name, formatLabels(res.Labels))
+	}
+	w.Flush()
+}
+
+// refresh re-indexes the active project of every provider with stored
+// credentials, or only onlyProvider when set. Providers the user is not
+// logged in to are skipped; other failures leave the provider's previous
+// entries in place.
+func refresh(cmd *cobra.Command, svc *tagindexsvc.Service, onlyProvider string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		cfg = &config.Config{}
+	}
+
+	names := providers.List()
+	sort.Strings(names)
+	for _, name := range names {
+		if provider, _, _ := strings.Cut(onlyProvider, "/"); provider != "" && name != provider {
+			continue
+		}
+		project := cfg.ActiveProject(name)
+		provider, err := providers.Get(name, auth.WithProject(auth.DefaultStore(), project))
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			continue
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping %s: %v\n", name, err)
+			continue
+		}
+		servers, err := provider.ListServers(context.Background())
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: could not refresh %s, using indexed results: %v\n", name, err)
+			continue
+		}
+		if err := svc.RecordServers(auth.ProjectKey(name, project), servers); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to index %s: %v\n", name, err)
+		}
+	}
+}
+
+// resourceJSON is the JSON shape of a search result.
+type resourceJSON struct {
+	Provider string            `json:"provider"`
+	Kind     string            `json:"kind"`
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+func printJSON(cmd *cobra.Command, resources []tagindex.Resource) {
+	out := make([]resourceJSON, len(resources))
+	for i, res := range resources {
+		out[i] = resourceJSON{Provider: res.Provider, Kind: res.Kind, ID: res.ID, Name: res.Name, Labels: res.Labels}
+	}
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	enc.Encode(out)
+}
+
+// formatLabels renders labels as sorted key=value pairs.
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,128 @@
+package find
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+)
+
+// mockProvider implements domain.Provider for find tests.
+type mockProvider struct {
+	servers []domain.Server
+	listErr error
+}
+
+func (m *mockProvider) GetDisplayName() string { return "Mock" }
+func (m *mockProvider) CreateServer(context.Context, domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *mockProvider) DeleteServer(context.Context, string) error { return nil }
+func (m *mockProvider) GetServer(context.Context, string) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *mockProvider) ListServers(context.Context) ([]domain.Server, error) {
+	return m.servers, m.listErr
+}
+func (m *mockProvider) StartServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (m *mockProvider) StopServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func setupFind(t *testing.T, mocks map[string]*mockProvider) {
+	t.Helper()
+	tagindex.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(tagindex.ResetPath)
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+
+	providers.Reset()
+	t.Cleanup(providers.Reset)
+	for name, mock := range mocks {
+		providers.Register(name, func(auth.Store) (domain.Provider, error) {
+			if mock == nil {
+				return nil, auth.ErrTokenNotFound
+			}
+			return mock, nil
+		})
+	}
+}
+
+func execFind(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(args)
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestFind_MatchesAcrossProviders(t *testing.T) {
+	setupFind(t, map[string]*mockProvider{
+		"alpha": {servers: []domain.Server{
+			{ID: "1", Name: "web-1", Labels: map[string]string{"env": "prod"}},
+			{ID: "2", Name: "web-2", Labels: map[string]string{"env": "dev"}},
+		}},
+		"beta": {servers: []domain.Server{
+			{ID: "9", Name: "db-1", Labels: map[string]string{"env": "prod", "role": "db"}},
+		}},
+		"gamma": nil, // not logged in
+	})
+
+	stdout, stderr := execFind(t, "tag:env=prod")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, want := range []string{"alpha", "web-1", "beta", "db-1", "env=prod,role=db"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+	if strings.Contains(stdout, "web-2") {
+		t.Errorf("expected web-2 to be filtered out:\n%s", stdout)
+	}
+}
+
+func TestFind_FallsBackToIndexWhenProviderFails(t *testing.T) {
+	mock := &mockProvider{servers: []domain.Server{{ID: "1", Name: "web-1", Labels: map[string]string{"env": "prod"}}}}
+	setupFind(t, map[string]*mockProvider{"alpha": mock})
+	execFind(t, "tag:env=prod")
+
+	mock.listErr = errors.New("boom")
+	stdout, stderr := execFind(t, "tag:env=prod", "-o", "json")
+
+	if !strings.Contains(stderr, "using indexed results") {
+		t.Errorf("expected a refresh warning, got: %s", stderr)
+	}
+	var got []resourceJSON
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got) != 1 || got[0].Name != "web-1" {
+		t.Errorf("expected indexed web-1, got %+v", got)
+	}
+}
+
+func TestFind_InvalidTerm(t *testing.T) {
+	setupFind(t, nil)
+
+	_, stderr := execFind(t, "colour:red")
+
+	if !strings.Contains(stderr, "unknown search field") {
+		t.Errorf("expected unknown field error, got: %s", stderr)
+	}
+}
@@ -0,0 +1,212 @@
+package group
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/group"
+	"nathanbeddoewebdev/vpsm/internal/server/tui"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func StartCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "start <name>",
+		Short: "Start every server in a group",
+		Long: `Power on every server in the group and wait for each to reach "running".
+Servers that are already running are skipped.
+
+Examples:
+  vpsm group start web`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runGroupAction(cmd, args[0], group.OpStart, group.Options{})
+		},
+	}
+	addConcurrencyFlag(cmd)
+	return cmd
+}
+
+func StopCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stop <name>",
+		Short: "Stop every server in a group",
+		Long: `Shut down every server in the group and wait for each to reach "off".
+Servers that are already off are skipped.
+
+Examples:
+  vpsm group stop web`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runGroupAction(cmd, args[0], group.OpStop, group.Options{})
+		},
+	}
+	addConcurrencyFlag(cmd)
+	return cmd
+}
+
+func UpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update <name>",
+		Short: "Upgrade packages on every server in a group",
+		Long: `Upgrade installed packages over SSH on every server in the group, using
+whichever of apt-get, dnf, yum, or apk the server has. Non-root users must
+be able to run sudo without a password.
+
+Examples:
+  vpsm group update web
+  vpsm group update web --user deploy`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			user, _ := cmd.Flags().GetString("user")
+			runGroupAction(cmd, args[0], group.OpUpdate, group.Options{Username: user})
+		},
+	}
+	addConcurrencyFlag(cmd)
+	addUserFlag(cmd)
+	return cmd
+}
+
+func RunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <name> -- <command...>",
+		Short: "Run a command on every server in a group",
+		Long: `Run a shell command over SSH on every server in the group and show each
+server's output.
+
+SSH runs in batch mode, so key-based authentication must already be set up.
+
+Examples:
+  vpsm group run web -- uptime
+  vpsm group run web --user deploy --concurrency 10 -- systemctl restart app`,
+		Args: cobra.MinimumNArgs(2),
+		Run:  runRun,
+	}
+	addConcurrencyFlag(cmd)
+	addUserFlag(cmd)
+	return cmd
+}
+
+func addConcurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("concurrency", group.DefaultConcurrency, "Maximum number of servers acted on at once")
+}
+
+func addUserFlag(cmd *cobra.Command) {
+	cmd.Flags().String("user", "root", "SSH username")
+}
+
+func runRun(cmd *cobra.Command, args []string) {
+	if dash := cmd.ArgsLenAtDash(); dash != -1 && dash != 1 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: expected exactly one group name before --")
+		return
+	}
+
+	user, _ := cmd.Flags().GetString("user")
+	runGroupAction(cmd, args[0], group.OpExec, group.Options{
+		Command:  strings.Join(args[1:], " "),
+		Username: user,
+	})
+}
+
+// runGroupAction resolves the group's members and runs op across them,
+// showing the combined progress view in a terminal or streaming plain
+// progress lines otherwise.
+func runGroupAction(cmd *cobra.Command, name string, op group.Op, opts group.Options) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	g, servers, err := group.Load(ctx, provider, providerName, name)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if len(servers) == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Group %q has no matching servers.\n", name)
+		return
+	}
+
+	if op == group.OpExec || op == group.OpUpdate {
+		opts.Bastions = group.Bastions(providerName, g, servers)
+	}
+
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		results, err := tui.RunGroupProgress(provider, providerName, name, servers, op, opts)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		printSummary(cmd, name, op, results, len(servers))
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Running %s on %d server(s) in group %q...\n", op, len(servers), name)
+
+	var mu sync.Mutex
+	results := group.Run(ctx, provider, providerName, servers, op, opts, func(e group.Event) {
+		if e.Result == nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		printProgressLine(cmd, e.Result)
+	})
+
+	if op == group.OpExec || op == group.OpUpdate {
+		printOutputs(cmd, results)
+	}
+	printSummary(cmd, name, op, results, len(servers))
+}
+
+func printProgressLine(cmd *cobra.Command, r *group.Result) {
+	switch {
+	case r.Err != nil:
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s %s: %v\n", styles.Cross(), r.Server.Name, r.Err)
+	case r.Skipped:
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s %s (already %s)\n", styles.Check(), r.Server.Name, r.Server.Status)
+	default:
+		fmt.Fprintf(cmd.ErrOrStderr(), "  %s %s\n", styles.Check(), r.Server.Name)
+	}
+}
+
+// printOutputs writes each server's command output to stdout under a
+// per-server heading, in group order.
+func printOutputs(cmd *cobra.Command, results []group.Result) {
+	for _, r := range results {
+		if r.Output == "" {
+			continue
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "==> %s <==\n", r.Server.Name)
+		fmt.Fprint(cmd.OutOrStdout(), r.Output)
+		if !strings.HasSuffix(r.Output, "\n") {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+	}
+}
+
+func printSummary(cmd *cobra.Command, name string, op group.Op, results []group.Result, total int) {
+	succeeded, failed := group.Summary(results)
+	if unfinished := total - len(results); unfinished > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Group %q %s: %d succeeded, %d failed, %d cancelled.\n", name, op, succeeded, failed, unfinished)
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Group %q %s: %d succeeded, %d failed.\n", name, op, succeeded, failed)
+}
@@ -0,0 +1,91 @@
+package group
+
+import (
+	"fmt"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+
+	"github.com/spf13/cobra"
+)
+
+func CreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create or replace a server group",
+		Long: `Create a named server group, or replace an existing group's definition.
+
+Members are given explicitly with --server (repeatable) and/or by label
+with --selector. Selector expressions are comma-separated requirements:
+"key=value", "key!=value", "key" (label present), or "!key" (label absent).
+
+With --bastion, SSH connections to members (vpsm server ssh, group exec
+and update) are proxied through the given jump host unless the server has
+its own bastion set with 'vpsm server bastion'.
+
+Examples:
+  vpsm group create web --selector role=web,env=prod
+  vpsm group create db --server 12345 --server 67890
+  vpsm group create private --selector net=private --bastion jump@203.0.113.10`,
+		Args: cobra.ExactArgs(1),
+		Run:  runCreate,
+	}
+
+	cmd.Flags().StringSlice("server", nil, "Server ID to include (repeatable)")
+	cmd.Flags().String("selector", "", "Label selector matching member servers")
+	cmd.Flags().String("bastion", "", "Jump host for SSH to members ([user@]host[:port])")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+	name := strings.TrimSpace(args[0])
+	serverIDs, _ := cmd.Flags().GetStringSlice("server")
+	selector, _ := cmd.Flags().GetString("selector")
+	jumpHost, _ := cmd.Flags().GetString("bastion")
+
+	if name == "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: group name must not be empty")
+		return
+	}
+	if len(serverIDs) == 0 && selector == "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: provide at least one --server or a --selector")
+		return
+	}
+	if selector != "" {
+		parsed, err := domain.ParseLabelSelector(selector)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		selector = parsed.String()
+	}
+
+	if jumpHost != "" {
+		if err := bastion.Validate(jumpHost); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+	}
+
+	repo, err := servergroups.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer repo.Close()
+
+	g := &servergroups.Group{Provider: providerName, Name: name, Selector: selector, ServerIDs: serverIDs, Bastion: jumpHost}
+	if existing, err := repo.Get(providerName, name); err == nil && existing != nil {
+		g.CreatedAt = existing.CreatedAt
+	}
+	if err := repo.Save(g); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Group %q saved.\n", name)
+}
@@ -0,0 +1,46 @@
+package group
+
+import (
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+
+	"github.com/spf13/cobra"
+)
+
+func DeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a server group",
+		Long: `Delete a server group definition. The member servers are not affected.
+
+Examples:
+  vpsm group delete web`,
+		Args: cobra.ExactArgs(1),
+		Run:  runDelete,
+	}
+}
+
+func runDelete(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	repo, err := servergroups.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer repo.Close()
+
+	deleted, err := repo.Delete(providerName, args[0])
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if !deleted {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: group %q not found\n", args[0])
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Group %q deleted.\n", args[0])
+}
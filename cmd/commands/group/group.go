@@ -0,0 +1,66 @@
+package group
+
+import (
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "group",
+		Aliases: []string{"g"},
+		Short:   "Manage server groups and run actions across them",
+		Long: `Define named groups of servers and act on every member at once.
+
+A group's members are its explicitly listed server IDs plus any servers
+whose labels match its selector. Membership is resolved against the
+provider each time an action runs, so selector groups pick up new servers
+automatically.
+
+In the server list (vpsm server list), press o to pick a group and run
+start, stop, update, or a command across it.`,
+		PersistentPreRunE: resolveProvider,
+		Run:               runList,
+	}
+
+	cmd.AddCommand(CreateCommand())
+	cmd.AddCommand(DeleteCommand())
+	cmd.AddCommand(ListCommand())
+	cmd.AddCommand(RunCommand())
+	cmd.AddCommand(StartCommand())
+	cmd.AddCommand(StopCommand())
+	cmd.AddCommand(UpdateCommand())
+
+	cmd.PersistentFlags().String("provider", "", "Cloud provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
+
+	return cmd
+}
+
+// resolveProvider ensures the --provider flag has a value, falling back to the
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
+func resolveProvider(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
+		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
+	}
+
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
+}
@@ -0,0 +1,192 @@
+package group
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// mockProvider implements domain.Provider for CLI testing.
+type mockProvider struct {
+	servers []domain.Server
+	started []string
+}
+
+func (m *mockProvider) GetDisplayName() string { return "Mock" }
+func (m *mockProvider) CreateServer(_ context.Context, _ domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (m *mockProvider) DeleteServer(_ context.Context, _ string) error {
+	return fmt.Errorf("not implemented")
+}
+func (m *mockProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
+	return &domain.Server{ID: id, Status: "running"}, nil
+}
+func (m *mockProvider) ListServers(_ context.Context) ([]domain.Server, error) {
+	return m.servers, nil
+}
+func (m *mockProvider) StartServer(_ context.Context, id string) (*domain.ActionStatus, error) {
+	m.started = append(m.started, id)
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+func (m *mockProvider) StopServer(_ context.Context, _ string) (*domain.ActionStatus, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// setup points the group store at a temp database and registers mock as
+// the "mock" provider.
+func setup(t *testing.T, mock *mockProvider) {
+	t.Helper()
+	servergroups.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(servergroups.ResetPath)
+
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func execGroup(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"--provider", "mock"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func testServers() []domain.Server {
+	return []domain.Server{
+		{ID: "1", Name: "web-1", Status: "off", PublicIPv4: "10.0.0.1", Labels: map[string]string{"role": "web"}},
+		{ID: "2", Name: "web-2", Status: "running", PublicIPv4: "10.0.0.2", Labels: map[string]string{"role": "web"}},
+		{ID: "3", Name: "db-1", Status: "running", PublicIPv4: "10.0.0.3", Labels: map[string]string{"role": "db"}},
+	}
+}
+
+func TestCreateAndList(t *testing.T) {
+	setup(t, &mockProvider{})
+
+	_, stderr := execGroup(t, "create", "web", "--selector", "role=web")
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	execGroup(t, "create", "db", "--server", "3")
+
+	stdout, _ := execGroup(t, "list")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected header + separator + 2 rows, got:\n%s", stdout)
+	}
+	if !strings.Contains(lines[2], "db") || !strings.Contains(lines[2], "3") {
+		t.Errorf("unexpected db row: %q", lines[2])
+	}
+	if !strings.Contains(lines[3], "web") || !strings.Contains(lines[3], "role=web") {
+		t.Errorf("unexpected web row: %q", lines[3])
+	}
+}
+
+func TestCreate_RequiresMembers(t *testing.T) {
+	setup(t, &mockProvider{})
+
+	_, stderr := execGroup(t, "create", "web")
+	if !strings.Contains(stderr, "--server") {
+		t.Errorf("expected membership error, got: %s", stderr)
+	}
+}
+
+func TestCreate_InvalidSelector(t *testing.T) {
+	setup(t, &mockProvider{})
+
+	_, stderr := execGroup(t, "create", "web", "--selector", "=web")
+	if !strings.Contains(stderr, "Error") {
+		t.Errorf("expected selector error, got: %s", stderr)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	setup(t, &mockProvider{})
+
+	execGroup(t, "create", "web", "--selector", "role=web")
+
+	stdout, _ := execGroup(t, "delete", "web")
+	if !strings.Contains(stdout, `Group "web" deleted`) {
+		t.Errorf("expected deletion confirmation, got: %s", stdout)
+	}
+
+	_, stderr := execGroup(t, "delete", "web")
+	if !strings.Contains(stderr, "not found") {
+		t.Errorf("expected not found error, got: %s", stderr)
+	}
+}
+
+func TestRun_ExecutesOnMembers(t *testing.T) {
+	setup(t, &mockProvider{servers: testServers()})
+
+	orig := remote.Runner
+	remote.Runner = func(_ context.Context, args ...string) ([]byte, error) {
+		if args[len(args)-1] != "uptime -p" {
+			t.Errorf("unexpected remote command %q", args[len(args)-1])
+		}
+		return []byte("up on " + args[len(args)-2] + "\n"), nil
+	}
+	t.Cleanup(func() { remote.Runner = orig })
+
+	execGroup(t, "create", "web", "--selector", "role=web")
+
+	stdout, stderr := execGroup(t, "run", "web", "--", "uptime", "-p")
+
+	assertContainsAll(t, stdout, "stdout", []string{
+		"==> web-1 <==", "up on root@10.0.0.1",
+		"==> web-2 <==", "up on root@10.0.0.2",
+	})
+	if strings.Contains(stdout, "db-1") {
+		t.Errorf("expected non-members to be excluded, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "2 succeeded, 0 failed") {
+		t.Errorf("expected summary on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestRun_UnknownGroup(t *testing.T) {
+	setup(t, &mockProvider{servers: testServers()})
+
+	_, stderr := execGroup(t, "run", "nope", "--", "uptime")
+	if !strings.Contains(stderr, `group "nope" not found`) {
+		t.Errorf("expected not found error, got: %s", stderr)
+	}
+}
+
+func TestStart_SkipsRunningMembers(t *testing.T) {
+	mock := &mockProvider{servers: testServers()}
+	setup(t, mock)
+
+	execGroup(t, "create", "web", "--selector", "role=web")
+
+	_, stderr := execGroup(t, "start", "web")
+
+	if len(mock.started) != 1 || mock.started[0] != "1" {
+		t.Errorf("expected only server 1 to be started, got %v", mock.started)
+	}
+	assertContainsAll(t, stderr, "stderr", []string{"✓ web-1", "web-2 (already running)", "2 succeeded, 0 failed"})
+}
+
+func assertContainsAll(t *testing.T, output string, label string, expected []string) {
+	t.Helper()
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in %s output:\n%s", want, label, output)
+		}
+	}
+}
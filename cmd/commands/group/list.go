@@ -0,0 +1,62 @@
+package group
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+
+	"github.com/spf13/cobra"
+)
+
+func ListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List server groups",
+		Long: `List the server groups defined for the provider.
+
+Examples:
+  vpsm group list`,
+		Args: cobra.ExactArgs(0),
+		Run:  runList,
+	}
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	repo, err := servergroups.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer repo.Close()
+
+	groups, err := repo.List(providerName)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	if len(groups) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No server groups defined. Create one with 'vpsm group create'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSELECTOR\tSERVERS\tBASTION")
+	fmt.Fprintln(w, "----\t--------\t-------\t-------")
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.Name, orDash(g.Selector), orDash(strings.Join(g.ServerIDs, ",")), orDash(g.Bastion))
+	}
+	w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+
+	"github.com/spf13/cobra"
+)
+
+func AssignCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assign <floating-ip>",
+		Short: "Assign a floating IP to a server",
+		Long: `Route a floating IP to a server, moving it away from the server it
+is currently assigned to. The floating IP can be given by ID, name or
+address. The server must be in the address's home location.
+
+Examples:
+  vpsm ip assign web-vip --server 12345
+  vpsm ip assign 203.0.113.7 --server 12345`,
+		Args: cobra.ExactArgs(1),
+		Run:  runAssign,
+	}
+
+	cmd.Flags().String("server", "", "ID of the server to assign the address to (required)")
+	cmd.MarkFlagRequired("server")
+
+	return cmd
+}
+
+func UnassignCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unassign <floating-ip>",
+		Short: "Remove a floating IP from its server",
+		Long: `Remove a floating IP from the server it is assigned to. The address
+is kept and can be assigned again later.
+
+Examples:
+  vpsm ip unassign web-vip`,
+		Args: cobra.ExactArgs(1),
+		Run:  runUnassign,
+	}
+}
+
+func runAssign(cmd *cobra.Command, args []string) {
+	provider := floatingIPProvider(cmd)
+	if provider == nil {
+		return
+	}
+	serverID, _ := cmd.Flags().GetString("server")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	ip, server, ok := lookup(ctx, cmd, provider, args[0], serverID)
+	if !ok {
+		return
+	}
+	if ip.ServerID == server.ID {
+		fmt.Fprintf(cmd.OutOrStdout(), "Floating IP %s is already assigned to server %q.\n", ip.IP, server.Name)
+		return
+	}
+	if server.Region != "" && ip.HomeLocation != "" && server.Region != ip.HomeLocation {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: floating IP %s is homed in %s, but server %q is in %s\n", ip.IP, ip.HomeLocation, server.Name, server.Region)
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Assigning floating IP %s to server %q...\n", ip.IP, server.Name)
+	actionStatus, err := provider.AssignFloatingIP(ctx, ip.ID, server.ID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error assigning floating IP: %v\n", err)
+		return
+	}
+	if !wait(ctx, cmd, provider, server, actionStatus, "assign_floating_ip") {
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Floating IP %s assigned to server %q.\n", ip.IP, server.Name)
+}
+
+func runUnassign(cmd *cobra.Command, args []string) {
+	provider := floatingIPProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	ip, server, ok := lookup(ctx, cmd, provider, args[0], "")
+	if !ok {
+		return
+	}
+	if server == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "Floating IP %s is not assigned.\n", ip.IP)
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Unassigning floating IP %s from server %q...\n", ip.IP, server.Name)
+	actionStatus, err := provider.UnassignFloatingIP(ctx, ip.ID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error unassigning floating IP: %v\n", err)
+		return
+	}
+	if !wait(ctx, cmd, provider, server, actionStatus, "unassign_floating_ip") {
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Floating IP %s unassigned from server %q.\n", ip.IP, server.Name)
+}
+
+// lookup resolves the floating IP ref and the server it is to be assigned
+// to, or its current server when serverID is empty. The server is nil
+// when the address is unassigned.
+func lookup(ctx context.Context, cmd *cobra.Command, provider domain.FloatingIPProvider, ref, serverID string) (*domain.FloatingIP, *domain.Server, bool) {
+	ips, err := provider.ListFloatingIPs(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+	ip, err := findFloatingIP(ips, ref)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+
+	if serverID == "" {
+		serverID = ip.ServerID
+	}
+	if serverID == "" {
+		return ip, nil, true
+	}
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+	if server == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %s not found\n", serverID)
+		return nil, nil, false
+	}
+	return ip, server, true
+}
+
+// wait tracks a floating IP action until it completes. The server keeps
+// its power state, so its current status is the target.
+func wait(ctx context.Context, cmd *cobra.Command, provider domain.Provider, server *domain.Server, actionStatus *domain.ActionStatus, command string) bool {
+	// Open the action repository. If unavailable, repo is set to nil
+	// and the service degrades gracefully (no persistence, but operation continues).
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, cmd.Flag("provider").Value.String(), repo)
+	defer svc.Close()
+
+	record := svc.TrackAction(server.ID, server.Name, actionStatus, command, server.Status)
+	if err := svc.WaitForAction(ctx, actionStatus, server.ID, server.Status, cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error waiting for floating IP action: %v\n", err)
+		return false
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	return true
+}
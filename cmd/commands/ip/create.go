@@ -0,0 +1,67 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/spf13/cobra"
+)
+
+func CreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a floating IP",
+		Long: `Create a floating IP in a location, or for a server.
+
+With --server the address is created in the server's location and
+assigned to it straight away. Floating IPs are billed whether or not
+they are assigned.
+
+Examples:
+  vpsm ip create --location fsn1 --name web-vip
+  vpsm ip create --type ipv6 --server 12345`,
+		Args: cobra.ExactArgs(0),
+		Run:  runCreate,
+	}
+
+	cmd.Flags().String("type", "ipv4", "Address family: ipv4 or ipv6")
+	cmd.Flags().String("location", "", "Home location, e.g. fsn1")
+	cmd.Flags().String("server", "", "ID of a server to assign the address to")
+	cmd.Flags().String("name", "", "Name for the floating IP")
+	cmd.Flags().String("description", "", "Description for the floating IP")
+	cmd.MarkFlagsOneRequired("location", "server")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) {
+	provider := floatingIPProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	opts := domain.CreateFloatingIPOpts{}
+	opts.Type, _ = cmd.Flags().GetString("type")
+	opts.HomeLocation, _ = cmd.Flags().GetString("location")
+	opts.ServerID, _ = cmd.Flags().GetString("server")
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.Description, _ = cmd.Flags().GetString("description")
+
+	if opts.Type != "ipv4" && opts.Type != "ipv6" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: --type must be ipv4 or ipv6, got %q\n", opts.Type)
+		return
+	}
+
+	ip, err := provider.CreateFloatingIP(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Floating IP %s created (ID: %s, location: %s).\n", ip.IP, ip.ID, ip.HomeLocation)
+	if ip.ServerID != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Assigned to server %s.\n", ip.ServerID)
+	}
+}
@@ -0,0 +1,91 @@
+package ip
+
+import (
+	"fmt"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ip",
+		Aliases: []string{"floating-ip"},
+		Short:   "Manage floating IPs",
+		Long: `Create floating IPs and move them between servers.
+
+A floating IP is a public address that can be reassigned to another server
+in its home location, e.g. to fail over without changing DNS. The server
+must also configure the address on a network interface to answer on it.`,
+		PersistentPreRunE: resolveProvider,
+	}
+
+	cmd.AddCommand(AssignCommand())
+	cmd.AddCommand(CreateCommand())
+	cmd.AddCommand(ListCommand())
+	cmd.AddCommand(UnassignCommand())
+
+	cmd.PersistentFlags().String("provider", "", "Cloud provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
+
+	return cmd
+}
+
+// resolveProvider ensures the --provider flag has a value, falling back to the
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
+func resolveProvider(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
+		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
+	}
+
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
+}
+
+// floatingIPProvider returns the provider selected by --provider, or
+// prints an error and returns nil when it has no floating IPs.
+func floatingIPProvider(cmd *cobra.Command) domain.FloatingIPProvider {
+	provider, err := providers.Get(cmd.Flag("provider").Value.String(), auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil
+	}
+	fp, ok := provider.(domain.FloatingIPProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support floating IPs\n", provider.GetDisplayName())
+		return nil
+	}
+	return fp
+}
+
+// findFloatingIP returns the floating IP whose ID, name or address is ref.
+func findFloatingIP(ips []domain.FloatingIP, ref string) (*domain.FloatingIP, error) {
+	for i, f := range ips {
+		if f.ID == ref || (f.Name != "" && f.Name == ref) || f.IP == ref {
+			return &ips[i], nil
+		}
+		// An IPv6 floating IP is a /64; accept the network without the suffix.
+		if f.Type == "ipv6" && strings.TrimSuffix(f.IP, "/64") == ref {
+			return &ips[i], nil
+		}
+	}
+	return nil, fmt.Errorf("floating IP %q not found", ref)
+}
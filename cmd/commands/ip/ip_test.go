@@ -0,0 +1,225 @@
+package ip
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// ipMockProvider implements domain.FloatingIPProvider.
+type ipMockProvider struct {
+	servers []domain.Server
+	ips     []domain.FloatingIP
+
+	createdOpts  *domain.CreateFloatingIPOpts
+	assigned     [2]string // floating IP ID, server ID
+	unassignedID string
+}
+
+func (m *ipMockProvider) GetDisplayName() string { return "Mock" }
+func (m *ipMockProvider) CreateServer(context.Context, domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *ipMockProvider) DeleteServer(context.Context, string) error { return nil }
+func (m *ipMockProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
+	for i := range m.servers {
+		if m.servers[i].ID == id {
+			return &m.servers[i], nil
+		}
+	}
+	return nil, nil
+}
+func (m *ipMockProvider) ListServers(context.Context) ([]domain.Server, error) { return m.servers, nil }
+func (m *ipMockProvider) StartServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (m *ipMockProvider) StopServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func (m *ipMockProvider) ListFloatingIPs(context.Context) ([]domain.FloatingIP, error) {
+	return m.ips, nil
+}
+
+func (m *ipMockProvider) CreateFloatingIP(_ context.Context, opts domain.CreateFloatingIPOpts) (*domain.FloatingIP, error) {
+	m.createdOpts = &opts
+	return &domain.FloatingIP{ID: "9", IP: "203.0.113.9", Type: opts.Type, HomeLocation: opts.HomeLocation, ServerID: opts.ServerID}, nil
+}
+
+func (m *ipMockProvider) AssignFloatingIP(_ context.Context, floatingIPID, serverID string) (*domain.ActionStatus, error) {
+	m.assigned = [2]string{floatingIPID, serverID}
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func (m *ipMockProvider) UnassignFloatingIP(_ context.Context, floatingIPID string) (*domain.ActionStatus, error) {
+	m.unassignedID = floatingIPID
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func registerIPMock(t *testing.T, mock domain.Provider) {
+	t.Helper()
+	orig := action.PollInterval
+	action.PollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { action.PollInterval = orig })
+
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func execIP(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append(args, "--provider", "mock"))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func newIPMock() *ipMockProvider {
+	return &ipMockProvider{
+		servers: []domain.Server{
+			{ID: "1", Name: "web-1", Status: "running", Region: "fsn1"},
+			{ID: "2", Name: "web-2", Status: "running", Region: "fsn1"},
+			{ID: "3", Name: "db-1", Status: "off", Region: "nbg1"},
+		},
+		ips: []domain.FloatingIP{
+			{ID: "7", Name: "web-vip", IP: "203.0.113.7", Type: "ipv4", HomeLocation: "fsn1", ServerID: "1"},
+			{ID: "8", IP: "2001:db8::/64", Type: "ipv6", HomeLocation: "fsn1"},
+		},
+	}
+}
+
+func TestListCommand_ShowsServerNames(t *testing.T) {
+	registerIPMock(t, newIPMock())
+
+	stdout, stderr := execIP(t, "list")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		switch {
+		case strings.HasPrefix(line, "7 ") && !strings.HasSuffix(strings.TrimSpace(line), "web-1"):
+			t.Errorf("expected web-vip to show server web-1, got %q", line)
+		case strings.HasPrefix(line, "8 ") && !strings.HasSuffix(strings.TrimSpace(line), "-"):
+			t.Errorf("expected unassigned address to show -, got %q", line)
+		}
+	}
+}
+
+func TestListCommand_UnsupportedProvider(t *testing.T) {
+	registerIPMock(t, &struct{ domain.Provider }{&ipMockProvider{}})
+
+	_, stderr := execIP(t, "list")
+
+	if !strings.Contains(stderr, "does not support floating IPs") {
+		t.Errorf("expected unsupported error, got %q", stderr)
+	}
+}
+
+func TestCreateCommand_PassesOptions(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	stdout, stderr := execIP(t, "create", "--location", "fsn1", "--name", "api-vip")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	if mock.createdOpts == nil || mock.createdOpts.Type != "ipv4" || mock.createdOpts.HomeLocation != "fsn1" || mock.createdOpts.Name != "api-vip" {
+		t.Errorf("created opts = %+v", mock.createdOpts)
+	}
+	if !strings.Contains(stdout, "203.0.113.9") {
+		t.Errorf("expected created address in output, got %q", stdout)
+	}
+}
+
+func TestCreateCommand_RejectsUnknownType(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	_, stderr := execIP(t, "create", "--location", "fsn1", "--type", "ipv5")
+
+	if !strings.Contains(stderr, "--type must be ipv4 or ipv6") || mock.createdOpts != nil {
+		t.Errorf("expected type error, got %q", stderr)
+	}
+}
+
+func TestAssignCommand_ByName(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	stdout, stderr := execIP(t, "assign", "web-vip", "--server", "2")
+
+	if mock.assigned != [2]string{"7", "2"} {
+		t.Errorf("assigned = %v, stderr %q", mock.assigned, stderr)
+	}
+	if !strings.Contains(stdout, `assigned to server "web-2"`) {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestAssignCommand_IPv6WithoutPrefix(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	execIP(t, "assign", "2001:db8::", "--server", "1")
+
+	if mock.assigned != [2]string{"8", "1"} {
+		t.Errorf("assigned = %v", mock.assigned)
+	}
+}
+
+func TestAssignCommand_RejectsOtherLocation(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	_, stderr := execIP(t, "assign", "web-vip", "--server", "3")
+
+	if mock.assigned != [2]string{} {
+		t.Errorf("expected no assignment, got %v", mock.assigned)
+	}
+	if !strings.Contains(stderr, "homed in fsn1") {
+		t.Errorf("expected location error, got %q", stderr)
+	}
+}
+
+func TestUnassignCommand(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	stdout, _ := execIP(t, "unassign", "203.0.113.7")
+
+	if mock.unassignedID != "7" {
+		t.Errorf("unassigned = %q", mock.unassignedID)
+	}
+	if !strings.Contains(stdout, `unassigned from server "web-1"`) {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestUnassignCommand_NotAssigned(t *testing.T) {
+	mock := newIPMock()
+	registerIPMock(t, mock)
+
+	stdout, _ := execIP(t, "unassign", "8")
+
+	if mock.unassignedID != "" {
+		t.Errorf("expected no unassign call, got %q", mock.unassignedID)
+	}
+	if !strings.Contains(stdout, "not assigned") {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
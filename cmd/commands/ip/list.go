@@ -0,0 +1,85 @@
+package ip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func ListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List floating IPs and the servers they are assigned to",
+		Long: `List the project's floating IPs with their home location and the
+server each one is assigned to.
+
+Examples:
+  vpsm ip list
+  vpsm ip list -o json`,
+		Args: cobra.ExactArgs(0),
+		Run:  runList,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	provider := floatingIPProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	ctx := context.Background()
+	ips, err := provider.ListFloatingIPs(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(ips)
+		return
+	}
+
+	if len(ips) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No floating IPs found. Create one with 'vpsm ip create'.")
+		return
+	}
+
+	// Show server names rather than IDs; fall back to the ID if the
+	// server list is unavailable.
+	names := map[string]string{}
+	if servers, err := provider.ListServers(ctx); err == nil {
+		for _, s := range servers {
+			names[s.ID] = s.Name
+		}
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tIP\tLOCATION\tSERVER")
+	fmt.Fprintln(w, "--\t----\t--\t--------\t------")
+	for _, f := range ips {
+		name := f.Name
+		if name == "" {
+			name = "-"
+		}
+		server := "-"
+		if f.ServerID != "" {
+			server = f.ServerID
+			if n := names[f.ServerID]; n != "" {
+				server = n
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.ID, name, f.IP, f.HomeLocation, server)
+	}
+	w.Flush()
+}
@@ -0,0 +1,163 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+
+	"github.com/spf13/cobra"
+)
+
+func AttachCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach <network>",
+		Short: "Attach a server to a private network",
+		Long: `Attach a server to a private network given by ID or name. The server
+gets an address from the network's subnet unless --ip is given.
+
+Examples:
+  vpsm network attach internal --server 12345
+  vpsm network attach internal --server 12345 --ip 10.0.0.10`,
+		Args: cobra.ExactArgs(1),
+		Run:  runAttach,
+	}
+
+	cmd.Flags().String("server", "", "ID of the server to attach (required)")
+	cmd.Flags().String("ip", "", "Address for the server inside the network")
+	cmd.MarkFlagRequired("server")
+
+	return cmd
+}
+
+func DetachCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "detach <network>",
+		Short: "Detach a server from a private network",
+		Long: `Detach a server from a private network given by ID or name.
+
+Examples:
+  vpsm network detach internal --server 12345`,
+		Args: cobra.ExactArgs(1),
+		Run:  runDetach,
+	}
+
+	cmd.Flags().String("server", "", "ID of the server to detach (required)")
+	cmd.MarkFlagRequired("server")
+
+	return cmd
+}
+
+func runAttach(cmd *cobra.Command, args []string) {
+	provider := networkProvider(cmd)
+	if provider == nil {
+		return
+	}
+	serverID, _ := cmd.Flags().GetString("server")
+	ip, _ := cmd.Flags().GetString("ip")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	network, server, ok := lookup(ctx, cmd, provider, args[0], serverID)
+	if !ok {
+		return
+	}
+	if slices.Contains(network.ServerIDs, server.ID) {
+		fmt.Fprintf(cmd.OutOrStdout(), "Server %q is already attached to network %q.\n", server.Name, network.Name)
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Attaching server %q to network %q...\n", server.Name, network.Name)
+	actionStatus, err := provider.AttachServer(ctx, network.ID, server.ID, ip)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if !wait(ctx, cmd, provider, server, actionStatus, "attach_to_network") {
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Server %q attached to network %q.\n", server.Name, network.Name)
+}
+
+func runDetach(cmd *cobra.Command, args []string) {
+	provider := networkProvider(cmd)
+	if provider == nil {
+		return
+	}
+	serverID, _ := cmd.Flags().GetString("server")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	network, server, ok := lookup(ctx, cmd, provider, args[0], serverID)
+	if !ok {
+		return
+	}
+	if !slices.Contains(network.ServerIDs, server.ID) {
+		fmt.Fprintf(cmd.OutOrStdout(), "Server %q is not attached to network %q.\n", server.Name, network.Name)
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Detaching server %q from network %q...\n", server.Name, network.Name)
+	actionStatus, err := provider.DetachServer(ctx, network.ID, server.ID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if !wait(ctx, cmd, provider, server, actionStatus, "detach_from_network") {
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Server %q detached from network %q.\n", server.Name, network.Name)
+}
+
+// lookup resolves the network ref and the server with the given ID.
+func lookup(ctx context.Context, cmd *cobra.Command, provider domain.NetworkProvider, ref, serverID string) (*domain.NetworkSpec, *domain.Server, bool) {
+	networks, err := provider.ListNetworks(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+	network, err := findNetwork(networks, ref)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil, nil, false
+	}
+	if server == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %s not found\n", serverID)
+		return nil, nil, false
+	}
+	return network, server, true
+}
+
+// wait tracks a network action until it completes. The server keeps its
+// power state, so its current status is the target.
+func wait(ctx context.Context, cmd *cobra.Command, provider domain.Provider, server *domain.Server, actionStatus *domain.ActionStatus, command string) bool {
+	// Open the action repository. If unavailable, repo is set to nil
+	// and the service degrades gracefully (no persistence, but operation continues).
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, cmd.Flag("provider").Value.String(), repo)
+	defer svc.Close()
+
+	record := svc.TrackAction(server.ID, server.Name, actionStatus, command, server.Status)
+	if err := svc.WaitForAction(ctx, actionStatus, server.ID, server.Status, cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error waiting for network action: %v\n", err)
+		return false
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	return true
+}
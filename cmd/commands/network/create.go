@@ -0,0 +1,59 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/spf13/cobra"
+)
+
+func CreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a private network",
+		Long: `Create a private network with one subnet.
+
+The subnet covers the whole IP range unless --subnet is given, and lives
+in the --zone network zone; only servers in locations of that zone can
+attach to it.
+
+Examples:
+  vpsm network create internal
+  vpsm network create backend --ip-range 10.1.0.0/16 --subnet 10.1.1.0/24 --zone us-east`,
+		Args: cobra.ExactArgs(1),
+		Run:  runCreate,
+	}
+
+	cmd.Flags().String("ip-range", "10.0.0.0/16", "Private IPv4 range of the network")
+	cmd.Flags().String("subnet", "", "Range of the subnet inside --ip-range (default: the whole range)")
+	cmd.Flags().String("zone", "eu-central", "Network zone of the subnet, e.g. eu-central or us-east")
+
+	return cmd
+}
+
+func runCreate(cmd *cobra.Command, args []string) {
+	provider := networkProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	opts := domain.CreateNetworkOpts{Name: args[0]}
+	opts.IPRange, _ = cmd.Flags().GetString("ip-range")
+	opts.SubnetRange, _ = cmd.Flags().GetString("subnet")
+	opts.NetworkZone, _ = cmd.Flags().GetString("zone")
+
+	if err := opts.Validate(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	network, err := provider.CreateNetwork(context.Background(), opts)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Network %q created (ID: %s, range: %s).\n", network.Name, network.ID, network.IPRange)
+}
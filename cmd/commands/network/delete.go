@@ -0,0 +1,52 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func DeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete <network>",
+		Aliases: []string{"rm"},
+		Short:   "Delete a private network",
+		Long: `Delete a private network by ID or name. Detach its servers first with
+'vpsm network detach'.
+
+Examples:
+  vpsm network delete internal`,
+		Args: cobra.ExactArgs(1),
+		Run:  runDelete,
+	}
+}
+
+func runDelete(cmd *cobra.Command, args []string) {
+	provider := networkProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	ctx := context.Background()
+	networks, err := provider.ListNetworks(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	network, err := findNetwork(networks, args[0])
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if n := len(network.ServerIDs); n > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: network %q still has %d attached server(s); detach them first with 'vpsm network detach'\n", network.Name, n)
+		return
+	}
+
+	if err := provider.DeleteNetwork(ctx, network.ID); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Network %q deleted.\n", network.Name)
+}
@@ -0,0 +1,89 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func ListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List private networks and their attached servers",
+		Long: `List the project's private networks with their IP range, the network
+zones their subnets cover, and the servers attached to them.
+
+Examples:
+  vpsm network list
+  vpsm network list -o json`,
+		Args: cobra.ExactArgs(0),
+		Run:  runList,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	provider := networkProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	ctx := context.Background()
+	networks, err := provider.ListNetworks(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(networks)
+		return
+	}
+
+	if len(networks) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No private networks found. Create one with 'vpsm network create'.")
+		return
+	}
+
+	// Show server names rather than IDs; fall back to the ID if the
+	// server list is unavailable.
+	names := map[string]string{}
+	if servers, err := provider.ListServers(ctx); err == nil {
+		for _, s := range servers {
+			names[s.ID] = s.Name
+		}
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tIP RANGE\tZONES\tSERVERS")
+	fmt.Fprintln(w, "--\t----\t--------\t-----\t-------")
+	for _, n := range networks {
+		servers := make([]string, 0, len(n.ServerIDs))
+		for _, id := range n.ServerIDs {
+			if name := names[id]; name != "" {
+				id = name
+			}
+			servers = append(servers, id)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", n.ID, n.Name, n.IPRange, orDash(strings.Join(n.NetworkZones, ",")), orDash(strings.Join(servers, ",")))
+	}
+	w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
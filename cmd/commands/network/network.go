@@ -0,0 +1,87 @@
+package network
+
+import (
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "network",
+		Aliases: []string{"net"},
+		Short:   "Manage private networks",
+		Long: `Create private networks and attach servers to them.
+
+Servers in the same private network reach each other on internal
+addresses without going over the public internet. A server can only
+join a network that has a subnet in its location's network zone.`,
+		PersistentPreRunE: resolveProvider,
+	}
+
+	cmd.AddCommand(AttachCommand())
+	cmd.AddCommand(CreateCommand())
+	cmd.AddCommand(DeleteCommand())
+	cmd.AddCommand(DetachCommand())
+	cmd.AddCommand(ListCommand())
+
+	cmd.PersistentFlags().String("provider", "", "Cloud provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
+
+	return cmd
+}
+
+// resolveProvider ensures the --provider flag has a value, falling back to the
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
+func resolveProvider(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
+		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
+	}
+
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
+}
+
+// networkProvider returns the provider selected by --provider, or prints
+// an error and returns nil when it has no private networks.
+func networkProvider(cmd *cobra.Command) domain.NetworkProvider {
+	provider, err := providers.Get(cmd.Flag("provider").Value.String(), auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil
+	}
+	np, ok := provider.(domain.NetworkProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support private networks\n", provider.GetDisplayName())
+		return nil
+	}
+	return np
+}
+
+// findNetwork returns the network whose ID or name is ref.
+func findNetwork(networks []domain.NetworkSpec, ref string) (*domain.NetworkSpec, error) {
+	for i, n := range networks {
+		if n.ID == ref || n.Name == ref {
+			return &networks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("network %q not found", ref)
+}
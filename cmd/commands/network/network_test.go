@@ -0,0 +1,232 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// networkMockProvider implements domain.NetworkProvider.
+type networkMockProvider struct {
+	servers  []domain.Server
+	networks []domain.NetworkSpec
+
+	createdOpts *domain.CreateNetworkOpts
+	deletedID   string
+	attached    []string // network ID, server ID, IP
+	detached    []string // network ID, server ID
+}
+
+func (m *networkMockProvider) GetDisplayName() string { return "Mock" }
+func (m *networkMockProvider) CreateServer(context.Context, domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, nil
+}
+func (m *networkMockProvider) DeleteServer(context.Context, string) error { return nil }
+func (m *networkMockProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
+	for i := range m.servers {
+		if m.servers[i].ID == id {
+			return &m.servers[i], nil
+		}
+	}
+	return nil, nil
+}
+func (m *networkMockProvider) ListServers(context.Context) ([]domain.Server, error) {
+	return m.servers, nil
+}
+func (m *networkMockProvider) StartServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (m *networkMockProvider) StopServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func (m *networkMockProvider) ListNetworks(context.Context) ([]domain.NetworkSpec, error) {
+	return m.networks, nil
+}
+
+func (m *networkMockProvider) CreateNetwork(_ context.Context, opts domain.CreateNetworkOpts) (*domain.NetworkSpec, error) {
+	m.createdOpts = &opts
+	return &domain.NetworkSpec{ID: "9", Name: opts.Name, IPRange: opts.IPRange}, nil
+}
+
+func (m *networkMockProvider) DeleteNetwork(_ context.Context, id string) error {
+	m.deletedID = id
+	return nil
+}
+
+func (m *networkMockProvider) AttachServer(_ context.Context, networkID, serverID, ip string) (*domain.ActionStatus, error) {
+	m.attached = []string{networkID, serverID, ip}
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func (m *networkMockProvider) DetachServer(_ context.Context, networkID, serverID string) (*domain.ActionStatus, error) {
+	m.detached = []string{networkID, serverID}
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func registerNetworkMock(t *testing.T, mock domain.Provider) {
+	t.Helper()
+	orig := action.PollInterval
+	action.PollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { action.PollInterval = orig })
+
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func execNetwork(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append(args, "--provider", "mock"))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func newNetworkMock() *networkMockProvider {
+	return &networkMockProvider{
+		servers: []domain.Server{
+			{ID: "1", Name: "web-1", Status: "running"},
+			{ID: "2", Name: "db-1", Status: "off"},
+		},
+		networks: []domain.NetworkSpec{
+			{ID: "7", Name: "internal", IPRange: "10.0.0.0/16", NetworkZones: []string{"eu-central"}, ServerIDs: []string{"1"}},
+			{ID: "8", Name: "empty", IPRange: "10.1.0.0/16"},
+		},
+	}
+}
+
+func TestListCommand_ShowsServerNames(t *testing.T) {
+	registerNetworkMock(t, newNetworkMock())
+
+	stdout, stderr := execNetwork(t, "list")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		switch {
+		case strings.HasPrefix(line, "7 ") && !strings.HasSuffix(strings.TrimSpace(line), "web-1"):
+			t.Errorf("expected internal to list web-1, got %q", line)
+		case strings.HasPrefix(line, "8 ") && !strings.HasSuffix(strings.TrimSpace(line), "-"):
+			t.Errorf("expected empty network to show -, got %q", line)
+		}
+	}
+}
+
+func TestListCommand_UnsupportedProvider(t *testing.T) {
+	registerNetworkMock(t, &struct{ domain.Provider }{&networkMockProvider{}})
+
+	_, stderr := execNetwork(t, "list")
+
+	if !strings.Contains(stderr, "does not support private networks") {
+		t.Errorf("expected unsupported error, got %q", stderr)
+	}
+}
+
+func TestCreateCommand_Defaults(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	stdout, stderr := execNetwork(t, "create", "backend")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	want := domain.CreateNetworkOpts{Name: "backend", IPRange: "10.0.0.0/16", NetworkZone: "eu-central"}
+	if mock.createdOpts == nil || *mock.createdOpts != want {
+		t.Errorf("created opts = %+v, want %+v", mock.createdOpts, want)
+	}
+	if !strings.Contains(stdout, `Network "backend" created`) {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestCreateCommand_RejectsInvalidSubnet(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	_, stderr := execNetwork(t, "create", "backend", "--subnet", "192.168.0.0/24")
+
+	if mock.createdOpts != nil {
+		t.Error("expected no create call")
+	}
+	if !strings.Contains(stderr, "not inside") {
+		t.Errorf("expected subnet error, got %q", stderr)
+	}
+}
+
+func TestDeleteCommand_RefusesWithAttachedServers(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	_, stderr := execNetwork(t, "delete", "internal")
+
+	if mock.deletedID != "" {
+		t.Error("expected no delete call")
+	}
+	if !strings.Contains(stderr, "1 attached server(s)") {
+		t.Errorf("unexpected stderr %q", stderr)
+	}
+
+	execNetwork(t, "delete", "empty")
+	if mock.deletedID != "8" {
+		t.Errorf("deleted = %q, want 8", mock.deletedID)
+	}
+}
+
+func TestAttachCommand(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	stdout, stderr := execNetwork(t, "attach", "internal", "--server", "2", "--ip", "10.0.0.10")
+
+	if strings.Join(mock.attached, ",") != "7,2,10.0.0.10" {
+		t.Errorf("attached = %v, stderr %q", mock.attached, stderr)
+	}
+	if !strings.Contains(stdout, `Server "db-1" attached to network "internal"`) {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestAttachCommand_AlreadyAttached(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	stdout, _ := execNetwork(t, "attach", "7", "--server", "1")
+
+	if mock.attached != nil {
+		t.Errorf("expected no attach call, got %v", mock.attached)
+	}
+	if !strings.Contains(stdout, "already attached") {
+		t.Errorf("unexpected stdout %q", stdout)
+	}
+}
+
+func TestDetachCommand(t *testing.T) {
+	mock := newNetworkMock()
+	registerNetworkMock(t, mock)
+
+	execNetwork(t, "detach", "internal", "--server", "1")
+	if strings.Join(mock.detached, ",") != "7,1" {
+		t.Errorf("detached = %v", mock.detached)
+	}
+
+	mock.detached = nil
+	stdout, _ := execNetwork(t, "detach", "internal", "--server", "2")
+	if mock.detached != nil || !strings.Contains(stdout, "not attached") {
+		t.Errorf("expected no detach for an unattached server, got %v / %q", mock.detached, stdout)
+	}
+}
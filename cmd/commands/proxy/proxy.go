@@ -0,0 +1,61 @@
+// Package proxy implements the hidden proxy-connect command ssh runs as its
+// ProxyCommand to tunnel through the configured proxy.
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCommand returns the hidden "proxy-connect" command.
+func NewCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:    "proxy-connect <host> <port>",
+		Short:  "Tunnel stdin/stdout to host:port through the configured proxy",
+		Hidden: true,
+		Args:   cobra.ExactArgs(2),
+		RunE:   runConnect,
+		// ssh reads stdout as the connection; nothing else may be written.
+		SilenceUsage: true,
+	}
+}
+
+func runConnect(cmd *cobra.Command, args []string) error {
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Proxy
+	}
+	proxyURL, err := proxy.ForSSH(configured)
+	if err != nil {
+		return err
+	}
+	if proxyURL == nil {
+		return fmt.Errorf("no proxy configured")
+	}
+
+	conn, err := proxy.Dial(context.Background(), proxyURL, net.JoinHostPort(args[0], args[1]))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return pipe(conn, cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// pipe copies in to conn and conn to out until the remote side closes.
+func pipe(conn net.Conn, in io.Reader, out io.Writer) error {
+	go func() {
+		io.Copy(conn, in)
+		if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+			cw.CloseWrite()
+		}
+	}()
+	_, err := io.Copy(out, conn)
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/server/services/action"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
@@ -153,10 +154,36 @@ func resumeAction(ctx context.Context, cmd *cobra.Command, repo actionstore.Acti
 		verb = "started"
 	} else if record.Command == "stop_server" {
 		verb = "stopped"
+	} else if record.Command == "reboot_server" {
+		verb = "rebooted"
+	} else if record.Command == "resize_server" {
+		verb = "resized"
+	} else if record.Command == "restore_backup" {
+		verb = "restored"
 	}
 	fmt.Fprintf(cmd.OutOrStdout(), "Server %s %s successfully.\n", record.ServerID, verb)
 }
 
+// lockServer claims serverID in the action store for command, so that a
+// conflicting operation started on the same server from another vpsm
+// process is refused until unlock is called. When the store cannot be
+// opened the operation goes ahead unguarded.
+func lockServer(provider domain.Provider, providerName, serverID, command string) (unlock func(), err error) {
+	repo, err := actionstore.Open()
+	if err != nil {
+		return func() {}, nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	if err := svc.Lock(serverID, command); err != nil {
+		svc.Close()
+		return nil, err
+	}
+	return func() {
+		svc.Unlock(serverID)
+		svc.Close()
+	}, nil
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
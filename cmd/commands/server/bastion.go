@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	"github.com/spf13/cobra"
+)
+
+// BastionCommand returns a cobra.Command that configures the SSH jump host
+// used to reach servers.
+func BastionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bastion",
+		Short: "Configure the SSH jump host used to reach servers",
+		Long: `Show or configure the bastion (jump host) that SSH connections are
+proxied through, for fleets where only a jump host is publicly reachable.
+
+A bastion can be set for a single server (--id) or as the default for every
+server of the provider (no --id). Groups can set one with
+'vpsm group create --bastion'. The most specific setting wins: server, then
+group, then provider.
+
+The bastion is used by 'vpsm server ssh', boot logs, and group exec/update.
+When connecting through a bastion, the server's private IP is preferred.
+
+Examples:
+  # Route every server of the provider through a jump host
+  vpsm server bastion --set jump@203.0.113.10
+
+  # Override for one server, with a custom port
+  vpsm server bastion --id 12345 --set admin@bastion.example.com:2222
+
+  # Show the bastion in effect for a server
+  vpsm server bastion --id 12345
+
+  # Remove the provider-wide default
+  vpsm server bastion --clear`,
+		Args: cobra.ExactArgs(0),
+		Run:  runBastion,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (omit to configure the provider-wide default)")
+	cmd.Flags().String("set", "", "Jump host to use ([user@]host[:port])")
+	cmd.Flags().Bool("clear", false, "Remove the configured jump host")
+	cmd.MarkFlagsMutuallyExclusive("set", "clear")
+
+	return cmd
+}
+
+func runBastion(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+	serverID, _ := cmd.Flags().GetString("id")
+	jumpHost, _ := cmd.Flags().GetString("set")
+	unset, _ := cmd.Flags().GetBool("clear")
+
+	repo, err := serverprefs.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	svc := prefssvc.NewService(repo)
+	defer svc.Close()
+
+	scope := serverID
+	target := fmt.Sprintf("server %s", serverID)
+	if serverID == "" {
+		scope = serverprefs.AllServers
+		target = fmt.Sprintf("all %s servers", providerName)
+	}
+
+	switch {
+	case jumpHost != "":
+		if err := bastion.Validate(jumpHost); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		if err := svc.SetBastion(providerName, scope, jumpHost); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "SSH to %s will go through %s.\n", target, jumpHost)
+
+	case unset:
+		if err := svc.SetBastion(providerName, scope, ""); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Bastion cleared for %s.\n", target)
+
+	case serverID == "":
+		if b := svc.GetBastion(providerName, serverprefs.AllServers); b != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Bastion for %s: %s\n", target, b)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "No bastion configured for %s.\n", target)
+		}
+
+	default:
+		// The effective bastion may come from a group, which needs the
+		// server's labels to resolve.
+		provider, err := providers.Get(providerName, auth.DefaultStore())
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		server, err := provider.GetServer(context.Background(), serverID)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error fetching server: %v\n", err)
+			return
+		}
+		if b := bastion.Lookup(svc, providerName, *server); b != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Bastion for %s: %s\n", target, b)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "No bastion configured for %s.\n", target)
+		}
+	}
+}
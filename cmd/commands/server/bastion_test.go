@@ -0,0 +1,81 @@
+package server
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+)
+
+func execBastion(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"bastion", "--provider", "mock"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func setupBastionStores(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	serverprefs.SetPath(filepath.Join(dir, "vpsm.db"))
+	servergroups.SetPath(filepath.Join(dir, "vpsm.db"))
+	t.Cleanup(func() {
+		serverprefs.ResetPath()
+		servergroups.ResetPath()
+	})
+}
+
+func TestBastionCommand_ProviderDefault(t *testing.T) {
+	setupBastionStores(t)
+	registerShowMockProvider(t, "mock", &showMockProvider{getServer: &domain.Server{ID: "42", Name: "web"}})
+
+	stdout, stderr := execBastion(t, "--set", "jump@203.0.113.10")
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	if !strings.Contains(stdout, "all mock servers") {
+		t.Errorf("expected provider-wide confirmation, got: %s", stdout)
+	}
+
+	stdout, _ = execBastion(t, "--id", "42")
+	if !strings.Contains(stdout, "jump@203.0.113.10") {
+		t.Errorf("expected server to inherit provider bastion, got: %s", stdout)
+	}
+}
+
+func TestBastionCommand_ServerOverrideAndClear(t *testing.T) {
+	setupBastionStores(t)
+	registerShowMockProvider(t, "mock", &showMockProvider{getServer: &domain.Server{ID: "42", Name: "web"}})
+
+	execBastion(t, "--set", "provider-jump")
+	execBastion(t, "--id", "42", "--set", "admin@bastion.example.com:2222")
+
+	stdout, _ := execBastion(t, "--id", "42")
+	if !strings.Contains(stdout, "admin@bastion.example.com:2222") {
+		t.Errorf("expected server bastion, got: %s", stdout)
+	}
+
+	execBastion(t, "--id", "42", "--clear")
+	stdout, _ = execBastion(t, "--id", "42")
+	if !strings.Contains(stdout, "provider-jump") {
+		t.Errorf("expected fallback to provider bastion after clear, got: %s", stdout)
+	}
+}
+
+func TestBastionCommand_InvalidHost(t *testing.T) {
+	setupBastionStores(t)
+
+	_, stderr := execBastion(t, "--set", "-oProxyCommand=evil")
+
+	if !strings.Contains(stderr, "invalid bastion") {
+		t.Errorf("expected validation error, got: %s", stderr)
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/broadcast"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	"github.com/spf13/cobra"
+)
+
+// BroadcastCommand returns a cobra.Command that opens synchronized SSH
+// sessions to several servers.
+func BroadcastCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "broadcast <server> <server>...",
+		Short: "Type into SSH sessions on several servers at once",
+		Long: `Open an SSH session to each server in its own pane of a tmux window,
+with synchronize-panes on: everything you type is sent to every server,
+and each pane shows that server's output. Useful for quick, identical
+changes across a handful of machines.
+
+Servers are given by name or ID. Servers that are not running or have no
+public IP address are skipped. The login, ssh options and bastion are
+resolved per server as for 'vpsm server ssh'; --user overrides the login
+for all of them.
+
+tmux must be installed. Inside tmux the session opens as a new session
+and the client switches to it; otherwise vpsm attaches to it. Toggle
+synchronization with ':setw synchronize-panes' and detach with the tmux
+prefix followed by d.
+
+Examples:
+  vpsm server broadcast web-1 web-2 web-3
+  vpsm server broadcast --user deploy web-1 web-2`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runBroadcast,
+	}
+
+	cmd.Flags().String("user", "", "SSH username for every server (defaults to each server's saved preference or 'root')")
+
+	return cmd
+}
+
+func runBroadcast(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+	username, _ := cmd.Flags().GetString("user")
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	ctx := context.Background()
+	servers := make([]domain.Server, 0, len(args))
+	for _, arg := range args {
+		server, err := findServer(ctx, provider, arg)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		servers = append(servers, *server)
+	}
+
+	var svc *prefssvc.Service
+	if repo, err := serverprefs.Open(); err == nil {
+		svc = prefssvc.NewService(repo)
+		defer svc.Close()
+	}
+	var defaultOptions string
+	if cfg, err := config.Load(); err == nil {
+		defaultOptions = cfg.SSHOptions
+	}
+
+	panes, skipped := broadcast.Panes(svc, providerName, servers, username, defaultOptions)
+	for _, s := range skipped {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %q: %s\n", s.Server.Name, s.Reason)
+	}
+	if len(panes) == 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: none of the servers can be reached over SSH\n")
+		return
+	}
+
+	session := broadcast.SessionName(time.Now())
+	if err := broadcast.Open(session, panes); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	attach := tmux.Attach(session)
+	attach.Stdin = os.Stdin
+	attach.Stdout = os.Stdout
+	attach.Stderr = cmd.ErrOrStderr()
+	if err := attach.Run(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to attach to tmux session %s: %v\n", session, err)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Attach manually with: tmux attach -t %s\n", session)
+	}
+}
@@ -0,0 +1,55 @@
+package server
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func execBroadcast(t *testing.T, providerName string, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"broadcast", "--provider", providerName}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestBroadcastCommand_UnknownServer(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	registerSSHMockProvider(t, "mock", &sshMockProvider{
+		displayName: "Mock",
+		servers:     []domain.Server{{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"}},
+	})
+
+	_, stderr := execBroadcast(t, "mock", "web-1", "web-9")
+	if !strings.Contains(stderr, `no server named "web-9"`) {
+		t.Errorf("expected unknown server error, got %q", stderr)
+	}
+}
+
+func TestBroadcastCommand_SkipsUnreachableServers(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	registerSSHMockProvider(t, "mock", &sshMockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{ID: "1", Name: "web-1", Status: "off", PublicIPv4: "192.0.2.1"},
+			{ID: "2", Name: "web-2", Status: "running"},
+		},
+	})
+
+	_, stderr := execBroadcast(t, "mock", "web-1", "web-2")
+	for _, want := range []string{
+		`Skipping "web-1": not running (status: off)`,
+		`Skipping "web-2": no public IP address`,
+		"none of the servers can be reached",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected %q in stderr, got %q", want, stderr)
+		}
+	}
+}
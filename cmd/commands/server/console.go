@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/browser"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/console"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// ConsoleCommand returns a cobra.Command that opens a server's VNC console.
+func ConsoleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console",
+		Short: "Open a server's VNC console",
+		Long: `Open a server's remote VNC console, which works even when its network
+or SSH setup is broken.
+
+By default the console is proxied to a local port, so any VNC viewer can
+connect to it with the printed password. --open also launches the
+system's VNC viewer on it. The proxy runs until interrupted with Ctrl+C.
+
+--url prints the console's WebSocket URL and password instead, for noVNC
+and other browser-based clients; -o json and -o yaml print them as data.
+The URL is short-lived, so connect within a minute.
+
+Examples:
+  vpsm server console --id 12345
+  vpsm server console --id 12345 --open
+  vpsm server console --id 12345 --listen 127.0.0.1:5901
+  vpsm server console --id 12345 --url`,
+		Run: runConsole,
+	}
+
+	cmd.Flags().String("id", "", "Server ID whose console to open (required)")
+	cmd.Flags().String("listen", "127.0.0.1:5900", "Local address VNC viewers connect to")
+	cmd.Flags().Bool("open", false, "Launch the system VNC viewer on the local proxy")
+	cmd.Flags().Bool("url", false, "Print the console URL and password instead of proxying it")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagsMutuallyExclusive("url", "open")
+
+	return cmd
+}
+
+func runConsole(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	consoler, ok := provider.(domain.ConsoleProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support server consoles\n", provider.GetDisplayName())
+		return
+	}
+
+	serverID, _ := cmd.Flags().GetString("id")
+	output, _ := cmd.Flags().GetString("output")
+	printURL, _ := cmd.Flags().GetBool("url")
+	open, _ := cmd.Flags().GetBool("open")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	c, err := consoler.RequestConsole(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	switch {
+	case output == "json" || output == "yaml":
+		if err := printStructured(cmd, output, c); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		}
+		return
+	case printURL:
+		fmt.Fprintf(cmd.OutOrStdout(), "URL:      %s\n", c.URL)
+		fmt.Fprintf(cmd.OutOrStdout(), "Password: %s\n", c.Password)
+		return
+	}
+
+	listen, _ := cmd.Flags().GetString("listen")
+	ln, err := net.Listen("tcp", listen)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: failed to listen on %s: %v\n", listen, err)
+		return
+	}
+
+	viewerURL := console.ViewerURL(ln.Addr().String())
+	fmt.Fprintf(cmd.OutOrStdout(), "Console for server %s: %s\n", serverID, viewerURL)
+	fmt.Fprintf(cmd.OutOrStdout(), "Password: %s\n", c.Password)
+	fmt.Fprintln(cmd.ErrOrStderr(), "Connect a VNC viewer within a minute. Press Ctrl+C to stop.")
+
+	if open {
+		if err := browser.Open(viewerURL); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to open a VNC viewer: %v\n", err)
+		}
+	}
+
+	var configuredProxy string
+	if cfg, err := config.Load(); err == nil {
+		configuredProxy = cfg.Proxy
+	}
+	if err := console.Serve(ctx, ln, c, configuredProxy); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	}
+}
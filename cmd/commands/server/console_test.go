@@ -0,0 +1,79 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// consoleMockProvider extends mockProvider with domain.ConsoleProvider.
+type consoleMockProvider struct {
+	mockProvider
+	requestedID string
+}
+
+func (m *consoleMockProvider) RequestConsole(_ context.Context, id string) (*domain.Console, error) {
+	m.requestedID = id
+	return &domain.Console{URL: "wss://console.example/?token=abc", Password: "s3cret"}, nil
+}
+
+// execConsole registers provider as "mock" and runs "console --provider
+// mock [flags...]", returning what was written to stdout and stderr.
+func execConsole(t *testing.T, provider domain.Provider, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return provider, nil
+	})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"console", "--provider", "mock"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestConsoleCommand_PrintURL(t *testing.T) {
+	mock := &consoleMockProvider{mockProvider: mockProvider{displayName: "Mock"}}
+
+	stdout, stderr := execConsole(t, mock, "--id", "42", "--url")
+
+	if mock.requestedID != "42" {
+		t.Errorf("expected a console for server 42, got %q", mock.requestedID)
+	}
+	if stderr != "" {
+		t.Errorf("unexpected stderr:\n%s", stderr)
+	}
+	assertContainsAll(t, stdout, "console", []string{"wss://console.example/?token=abc", "s3cret"})
+}
+
+func TestConsoleCommand_JSON(t *testing.T) {
+	mock := &consoleMockProvider{mockProvider: mockProvider{displayName: "Mock"}}
+
+	stdout, _ := execConsole(t, mock, "--id", "42", "-o", "json")
+
+	var got domain.Console
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, stdout)
+	}
+	if got.Password != "s3cret" || got.URL == "" {
+		t.Errorf("console = %+v", got)
+	}
+}
+
+func TestConsoleCommand_Unsupported(t *testing.T) {
+	_, stderr := execConsole(t, &mockProvider{displayName: "Mock"}, "--id", "42")
+
+	if !strings.Contains(stderr, "does not support server consoles") {
+		t.Errorf("expected unsupported error, got:\n%s", stderr)
+	}
+}
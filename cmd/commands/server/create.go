@@ -5,13 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"text/tabwriter"
 
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
 	"nathanbeddoewebdev/vpsm/internal/server/tui"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	archivesvc "nathanbeddoewebdev/vpsm/internal/services/serverarchive"
 	"nathanbeddoewebdev/vpsm/internal/util"
 
 	"github.com/spf13/cobra"
@@ -27,6 +31,9 @@ func CreateCommand() *cobra.Command {
 All three of --name, --image, and --type are required unless you use
 interactive mode. If any are missing and the provider supports catalog
 listing, a TUI wizard will guide you through the required choices.
+When all three are given, the wizard never opens: invalid flags, a
+failed create, or a failed --wait exit with a non-zero status, so the
+command can be used from scripts.
 
 Examples:
   # Minimal
@@ -44,35 +51,67 @@ Examples:
   # JSON output for scripting
   vpsm server create --provider hetzner \
     --name web-1 --image ubuntu-24.04 --type cpx11 \
-    -o json`,
-		Run: runCreate,
+    -o json
+
+  # Attached to a private network (the location must be in its zone)
+  vpsm server create --provider hetzner \
+    --name web-1 --image ubuntu-24.04 --type cpx11 \
+    --location fsn1 --network internal
+
+  # IPv6-only server
+  vpsm server create --provider hetzner \
+    --name web-1 --image ubuntu-24.04 --type cpx11 --ipv4=false
+
+  # Labelled, with cloud-init user data from a file, waiting until it runs
+  vpsm server create --name web-1 --image ubuntu-24.04 --type cpx11 \
+    --label env=prod --label role=web \
+    --user-data-file cloud-init.yaml --wait
+
+  # Cloud-init user data from stdin or a URL
+  vpsm server create --name web-1 --image ubuntu-24.04 --type cpx11 \
+    --user-data - < cloud-init.yaml
+  vpsm server create --name web-1 --image ubuntu-24.04 --type cpx11 \
+    --user-data-url https://example.com/cloud-init.yaml
+
+User data larger than the provider accepts (32 KiB on Hetzner) is
+gzip-compressed and base64-encoded, which cloud-init decodes on boot.`,
+		RunE:         runCreate,
+		SilenceUsage: true,
 	}
 
 	// Required for flag mode
 	cmd.Flags().String("name", "", "Server name (must be a valid hostname)")
-	cmd.Flags().String("image", "", "Image name or ID (e.g. ubuntu-24.04)")
+	cmd.Flags().String("image", "", "Image name or ID (e.g. ubuntu-24.04, or the ID of a snapshot or backup)")
 	cmd.Flags().String("type", "", "Server type name or ID (e.g. cpx11)")
 
 	// Optional
 	cmd.Flags().String("location", "", "Location name or ID (e.g. fsn1)")
 	cmd.Flags().StringArray("ssh-key", nil, "SSH key name or ID (can be specified multiple times)")
+	cmd.Flags().StringArray("network", nil, "Private network name or ID to attach (can be specified multiple times)")
 	cmd.Flags().StringArray("label", nil, "Label in key=value format (can be specified multiple times)")
-	cmd.Flags().String("user-data", "", "Cloud-init user data string")
+	cmd.Flags().String("user-data", "", "Cloud-init user data string, or - to read it from stdin")
+	cmd.Flags().String("user-data-file", "", "Read cloud-init user data from a file")
+	cmd.Flags().String("user-data-url", "", "Fetch cloud-init user data from an http(s) URL")
+	cmd.Flags().Bool("start-after-create", true, "Start server after creation")
 	cmd.Flags().Bool("start", true, "Start server after creation")
+	cmd.Flags().MarkDeprecated("start", "use --start-after-create instead")
+	cmd.Flags().Bool("wait", false, "Wait until the server is running (or off with --start-after-create=false)")
+	cmd.Flags().Bool("ipv4", true, "Assign a public IPv4 address")
+	cmd.Flags().Bool("ipv6", true, "Assign a public IPv6 /64 subnet")
+	cmd.Flags().Bool("interruptible", false, "Create a discounted spot instance the provider may reclaim at any time (where offered)")
 
-	// Output
-	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.MarkFlagsMutuallyExclusive("user-data", "user-data-file", "user-data-url")
+	cmd.MarkFlagsMutuallyExclusive("start-after-create", "start")
 
 	return cmd
 }
 
-func runCreate(cmd *cobra.Command, args []string) {
+func runCreate(cmd *cobra.Command, args []string) error {
 	providerName := cmd.Flag("provider").Value.String()
 
 	provider, err := providers.Get(providerName, auth.DefaultStore())
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-		return
+		return err
 	}
 
 	name, _ := cmd.Flags().GetString("name")
@@ -80,8 +119,12 @@ func runCreate(cmd *cobra.Command, args []string) {
 	serverType, _ := cmd.Flags().GetString("type")
 	location, _ := cmd.Flags().GetString("location")
 	sshKeys, _ := cmd.Flags().GetStringArray("ssh-key")
+	networks, _ := cmd.Flags().GetStringArray("network")
 	labels, _ := cmd.Flags().GetStringArray("label")
 	userData, _ := cmd.Flags().GetString("user-data")
+	userDataFile, _ := cmd.Flags().GetString("user-data-file")
+	userDataURL, _ := cmd.Flags().GetString("user-data-url")
+	wait, _ := cmd.Flags().GetBool("wait")
 
 	var missing []string
 	if name == "" {
@@ -96,8 +139,7 @@ func runCreate(cmd *cobra.Command, args []string) {
 
 	if name != "" {
 		if err := util.ValidateServerName(name); err != nil {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-			return
+			return err
 		}
 	}
 
@@ -113,65 +155,165 @@ func runCreate(cmd *cobra.Command, args []string) {
 	if len(sshKeys) > 0 {
 		opts.SSHKeyIdentifiers = sshKeys
 	}
+	if len(networks) > 0 {
+		opts.Networks = networks
+	}
 	if len(labels) > 0 {
-		opts.Labels = parseLabels(labels)
+		opts.Labels, err = parseLabels(labels)
+		if err != nil {
+			return err
+		}
 	}
-	if userData != "" {
-		opts.UserData = userData
+	if userData != "" || userDataFile != "" || userDataURL != "" {
+		data, err := readUserData(cmd, userData, userDataFile, userDataURL)
+		if err != nil {
+			return err
+		}
+		opts.UserData, err = domain.EncodeUserData(data, domain.UserDataLimit(provider))
+		if err != nil {
+			return err
+		}
+		if len(opts.UserData) != len(data) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "User data compressed and encoded from %d to %d bytes for %s\n",
+				len(data), len(opts.UserData), provider.GetDisplayName())
+		}
+	}
+	for _, flag := range []string{"start-after-create", "start"} {
+		if cmd.Flags().Changed(flag) {
+			start, _ := cmd.Flags().GetBool(flag)
+			opts.StartAfterCreate = &start
+			break
+		}
+	}
+	if cmd.Flags().Changed("ipv4") {
+		enable, _ := cmd.Flags().GetBool("ipv4")
+		opts.EnableIPv4 = &enable
 	}
-	if cmd.Flags().Changed("start") {
-		start, _ := cmd.Flags().GetBool("start")
-		opts.StartAfterCreate = &start
+	if cmd.Flags().Changed("ipv6") {
+		enable, _ := cmd.Flags().GetBool("ipv6")
+		opts.EnableIPv6 = &enable
+	}
+	opts.Interruptible, _ = cmd.Flags().GetBool("interruptible")
+	if opts.Interruptible && !domain.SupportsInterruptible(provider) {
+		return fmt.Errorf("%s does not offer interruptible servers", provider.GetDisplayName())
 	}
 
 	useInteractive := len(missing) > 0
 	if useInteractive {
 		// Interactive mode requires a terminal.
 		if !term.IsTerminal(int(os.Stdout.Fd())) {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: missing required flag(s): %s\n", strings.Join(missing, ", "))
-			fmt.Fprintln(cmd.ErrOrStderr(), "Interactive mode requires a terminal. Provide all flags for non-interactive use.")
-			return
+			return fmt.Errorf("missing required flag(s): %s (interactive mode requires a terminal; provide all flags for non-interactive use)",
+				strings.Join(missing, ", "))
 		}
 
 		catalogProvider, ok := provider.(domain.CatalogProvider)
 		if !ok {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: missing required flag(s): %s\n", strings.Join(missing, ", "))
-			fmt.Fprintln(cmd.ErrOrStderr(), "Interactive mode is not supported for this provider.")
-			return
+			return fmt.Errorf("missing required flag(s): %s (interactive mode is not supported for this provider)",
+				strings.Join(missing, ", "))
 		}
 
 		finalOpts, err := tui.RunServerCreate(catalogProvider, providerName, opts)
 		if err != nil {
 			if errors.Is(err, tui.ErrAborted) {
 				fmt.Fprintln(cmd.ErrOrStderr(), "Server creation cancelled.")
-				return
+				return nil
 			}
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-			return
+			return err
 		}
 		if finalOpts == nil {
-			return
+			return nil
 		}
 		opts = *finalOpts
 	}
 
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// The wizard only offers images matching the server type; flags are
+	// checked here so a mismatch fails before the create request.
+	if !useInteractive {
+		if err := checkImageArchitecture(ctx, provider, opts); err != nil {
+			return err
+		}
+	}
+
 	logCreateOpts(cmd, opts)
 
-	ctx := context.Background()
 	server, err := provider.CreateServer(ctx, opts)
 	if err != nil {
 		logCreateOptsFull(cmd, opts)
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error creating server: %v\n", err)
-		return
+		return fmt.Errorf("failed to create server: %w", err)
+	}
+	archivesvc.RememberCreated(providerName, *server, opts)
+
+	if wait {
+		if server, err = waitForCreate(ctx, cmd, provider, providerName, server, opts); err != nil {
+			return err
+		}
 	}
 
 	output, _ := cmd.Flags().GetString("output")
 	switch output {
-	case "json":
-		printServerJSON(cmd, server)
+	case "json", "yaml":
+		printStructured(cmd, output, server)
 	default:
 		printCreateTable(cmd, server)
 	}
+	return nil
+}
+
+// waitForCreate polls a new server until it is running, or off when it
+// was created without starting, and returns its final state. The wait is
+// tracked like a start action so it can be resumed and timed.
+func waitForCreate(ctx context.Context, cmd *cobra.Command, provider domain.Provider, providerName string, server *domain.Server, opts domain.CreateServerOpts) (*domain.Server, error) {
+	target := "running"
+	if opts.StartAfterCreate != nil && !*opts.StartAfterCreate {
+		target = "off"
+	}
+
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	defer svc.Close()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for server %q to be %s...\n", server.Name, target)
+	pending := &domain.ActionStatus{Status: domain.ActionStatusRunning, Command: "create_server"}
+	record := svc.TrackAction(server.ID, server.Name, pending, "create_server", target)
+	if err := svc.WaitForAction(ctx, pending, server.ID, target, cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		return nil, fmt.Errorf("server %q was created but did not become %s: %w", server.Name, target, err)
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+
+	current, err := provider.GetServer(ctx, server.ID)
+	if err != nil || current == nil {
+		return server, nil
+	}
+	// The create response carries the only copy of some fields, such as
+	// the root password; keep them.
+	current.Metadata = server.Metadata
+	return current, nil
+}
+
+// checkImageArchitecture returns an error if opts.Image targets another CPU
+// architecture than opts.ServerType. The check is best-effort: providers
+// without a catalog, or a catalog that fails to load, skip it.
+func checkImageArchitecture(ctx context.Context, provider domain.Provider, opts domain.CreateServerOpts) error {
+	catalog, ok := provider.(domain.CatalogProvider)
+	if !ok {
+		return nil
+	}
+	types, err := catalog.ListServerTypes(ctx)
+	if err != nil {
+		return nil
+	}
+	images, err := catalog.ListImages(ctx)
+	if err != nil {
+		return nil
+	}
+	return domain.ValidateImageArchitecture(opts.Image, opts.ServerType, types, images)
 }
 
 func logCreateOpts(cmd *cobra.Command, opts domain.CreateServerOpts) {
@@ -197,6 +339,9 @@ func logCreateOptsFull(cmd *cobra.Command, opts domain.CreateServerOpts) {
 	if len(opts.SSHKeyIdentifiers) > 0 {
 		fmt.Fprintf(w, "  SSH keys:    %s\n", strings.Join(opts.SSHKeyIdentifiers, ", "))
 	}
+	if len(opts.Networks) > 0 {
+		fmt.Fprintf(w, "  Networks:    %s\n", strings.Join(opts.Networks, ", "))
+	}
 	if len(opts.Labels) > 0 {
 		parts := make([]string, 0, len(opts.Labels))
 		for k, v := range opts.Labels {
@@ -210,17 +355,28 @@ func logCreateOptsFull(cmd *cobra.Command, opts domain.CreateServerOpts) {
 	if opts.StartAfterCreate != nil {
 		fmt.Fprintf(w, "  Start after: %t\n", *opts.StartAfterCreate)
 	}
+	if opts.EnableIPv4 != nil {
+		fmt.Fprintf(w, "  IPv4:        %t\n", *opts.EnableIPv4)
+	}
+	if opts.EnableIPv6 != nil {
+		fmt.Fprintf(w, "  IPv6:        %t\n", *opts.EnableIPv6)
+	}
+	if opts.Interruptible {
+		fmt.Fprintf(w, "  Interruptible: true\n")
+	}
 }
 
-func parseLabels(labels []string) map[string]string {
+// parseLabels parses --label values, failing on the first invalid one.
+func parseLabels(labels []string) (map[string]string, error) {
 	result := make(map[string]string, len(labels))
 	for _, l := range labels {
-		k, v, ok := strings.Cut(l, "=")
-		if ok {
-			result[k] = v
+		k, v, err := domain.ParseLabel(l)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --label %q: %w", l, err)
 		}
+		result[k] = v
 	}
-	return result
+	return result, nil
 }
 
 func printCreateTable(cmd *cobra.Command, server *domain.Server) {
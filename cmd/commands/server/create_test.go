@@ -0,0 +1,206 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// createMockProvider records the options passed to CreateServer.
+type createMockProvider struct {
+	sshMockProvider
+	opts domain.CreateServerOpts
+}
+
+func (m *createMockProvider) CreateServer(_ context.Context, opts domain.CreateServerOpts) (*domain.Server, error) {
+	m.opts = opts
+	return &domain.Server{ID: "1", Name: opts.Name, Status: "running"}, nil
+}
+
+func (m *createMockProvider) UserDataLimit() int { return 128 }
+
+func registerCreateMock(t *testing.T, mock *createMockProvider) {
+	t.Helper()
+	serverarchive.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(serverarchive.ResetPath)
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+}
+
+func execCreate(t *testing.T, stdin string, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"create", "--provider", "mock", "--name", "web-1", "--image", "ubuntu-24.04", "--type", "cpx11"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+// catalogCreateMock adds a catalog to createMockProvider.
+type catalogCreateMock struct {
+	createMockProvider
+	types  []domain.ServerTypeSpec
+	images []domain.ImageSpec
+}
+
+func (m *catalogCreateMock) ListLocations(context.Context) ([]domain.Location, error) {
+	return nil, nil
+}
+func (m *catalogCreateMock) ListServerTypes(context.Context) ([]domain.ServerTypeSpec, error) {
+	return m.types, nil
+}
+func (m *catalogCreateMock) ListImages(context.Context) ([]domain.ImageSpec, error) {
+	return m.images, nil
+}
+func (m *catalogCreateMock) ListSSHKeys(context.Context) ([]domain.SSHKeySpec, error) {
+	return nil, nil
+}
+
+func TestCreateCommand_RejectsImageForOtherArchitecture(t *testing.T) {
+	mock := &catalogCreateMock{
+		createMockProvider: createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}},
+		types:              []domain.ServerTypeSpec{{ID: "1", Name: "cpx11", Architecture: "arm"}},
+		images:             []domain.ImageSpec{{ID: "100", Name: "ubuntu-24.04", Architecture: "x86"}},
+	}
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+
+	_, stderr := execCreate(t, "")
+	if !strings.Contains(stderr, `image "ubuntu-24.04" is built for x86, but server type "cpx11" is arm`) {
+		t.Errorf("expected an architecture error, got %q", stderr)
+	}
+	if mock.opts.Name != "" {
+		t.Error("expected no create request to be sent")
+	}
+}
+
+// execCreateArgs runs "create --provider mock" with args and returns the
+// output and the command's error, which sets the exit status.
+func execCreateArgs(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"create", "--provider", "mock"}, args...))
+	err = cmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
+func TestCreateCommand_AllFlags(t *testing.T) {
+	mock := &createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}}
+	registerCreateMock(t, mock)
+
+	path := filepath.Join(t.TempDir(), "cloud-init.yaml")
+	if err := os.WriteFile(path, []byte("#cloud-config\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, err := execCreateArgs(t,
+		"--name", "web-1", "--image", "ubuntu-24.04", "--type", "cpx11", "--location", "fsn1",
+		"--ssh-key", "me", "--ssh-key", "deploy",
+		"--label", "env=prod", "--label", "role=web",
+		"--user-data-file", path, "--start-after-create=false")
+	if err != nil {
+		t.Fatalf("create failed: %v\n%s", err, stderr)
+	}
+
+	start := false
+	want := domain.CreateServerOpts{
+		Name:              "web-1",
+		Image:             "ubuntu-24.04",
+		ServerType:        "cpx11",
+		Location:          "fsn1",
+		SSHKeyIdentifiers: []string{"me", "deploy"},
+		Labels:            map[string]string{"env": "prod", "role": "web"},
+		UserData:          "#cloud-config\n",
+		StartAfterCreate:  &start,
+	}
+	if diff := cmp.Diff(want, mock.opts); diff != "" {
+		t.Errorf("CreateServer opts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateCommand_ValidationErrorsExitNonZero(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"invalid label", []string{"--label", "env"}, `invalid --label "env"`},
+		{"invalid name", []string{"--name", "web_1!"}, "web_1!"},
+		{"missing user data file", []string{"--user-data-file", "/nonexistent/cloud-init.yaml"}, "failed to read user data file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}}
+			registerCreateMock(t, mock)
+
+			args := append([]string{"--name", "web-1", "--image", "ubuntu-24.04", "--type", "cpx11"}, tt.args...)
+			_, stderr, err := execCreateArgs(t, args...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(stderr, tt.want) {
+				t.Errorf("expected %q on stderr, got:\n%s", tt.want, stderr)
+			}
+			if mock.opts.Name != "" {
+				t.Error("expected no create request to be sent")
+			}
+		})
+	}
+}
+
+func TestCreateCommand_MissingFlagsWithoutTerminal(t *testing.T) {
+	mock := &createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}}
+	registerCreateMock(t, mock)
+
+	_, stderr, err := execCreateArgs(t, "--name", "web-1")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(stderr, "missing required flag(s): --image, --type") {
+		t.Errorf("expected missing flags on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestCreateCommand_Wait(t *testing.T) {
+	withTestStore(t)
+	withFastPolling(t)
+
+	mock := &createMockProvider{sshMockProvider: sshMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "203.0.113.10"},
+	}}
+	registerCreateMock(t, mock)
+
+	stdout, stderr, err := execCreateArgs(t, "--name", "web-1", "--image", "ubuntu-24.04", "--type", "cpx11", "--wait")
+	if err != nil {
+		t.Fatalf("create failed: %v\n%s", err, stderr)
+	}
+	if !strings.Contains(stderr, `Waiting for server "web-1" to be running`) {
+		t.Errorf("expected a wait message, got:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, "203.0.113.10") {
+		t.Errorf("expected the running server's details, got:\n%s", stdout)
+	}
+}
@@ -2,12 +2,20 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
 	"nathanbeddoewebdev/vpsm/internal/server/tui"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	archivesvc "nathanbeddoewebdev/vpsm/internal/services/serverarchive"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -15,67 +23,198 @@ import (
 
 func DeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete",
-		Short: "Delete a server",
+		Use:     "delete",
+		Aliases: []string{"rm"},
+		Short:   "Delete a server",
 		Long: `Delete a server instance from the specified provider.
 
 If --id is not provided, an interactive TUI will let you select a server
 from the current list. The TUI shows a summary and asks for confirmation
 before deleting. Requires a terminal; use --id for scripting.
 
+With --require-stopped (or 'vpsm config set delete-require-stopped on'),
+deleting a running server is refused unless --force is given. The TUI
+instead offers to stop the server and then delete it.
+
+With --wait, the command blocks until the provider no longer reports the
+server, printing progress to stderr, so scripts can rely on it being gone.
+
+With -o json or -o yaml, the server is printed as it was just before it
+was deleted.
+
 Examples:
   # Interactive mode (TUI)
   vpsm server delete --provider hetzner
 
   # Non-interactive (scripting)
-  vpsm server delete --provider hetzner --id 12345`,
-		Run: runDelete,
+  vpsm server delete --provider hetzner --id 12345
+
+  # Only delete the server if it is already stopped
+  vpsm server delete --id 12345 --require-stopped
+
+  # Block until the server is gone (CI pipelines)
+  vpsm server delete --id 12345 --wait`,
+		RunE:         runDelete,
+		SilenceUsage: true,
 	}
 
 	cmd.Flags().String("id", "", "Server ID to delete (skips interactive selection)")
+	cmd.Flags().Bool("require-stopped", false, "Refuse to delete the server while it is running")
+	cmd.Flags().Bool("force", false, "Delete even if the server is running and stopping is required")
+	cmd.Flags().Bool("wait", false, "Wait until the server is deleted")
 
 	return cmd
 }
 
-func runDelete(cmd *cobra.Command, args []string) {
+func runDelete(cmd *cobra.Command, args []string) error {
 	providerName := cmd.Flag("provider").Value.String()
 
 	provider, err := providers.Get(providerName, auth.DefaultStore())
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-		return
+		return err
 	}
 
 	serverID, _ := cmd.Flags().GetString("id")
+	force, _ := cmd.Flags().GetBool("force")
+	requireStopped, _ := cmd.Flags().GetBool("require-stopped")
+	if !cmd.Flags().Changed("require-stopped") {
+		if cfg, err := config.Load(); err == nil {
+			requireStopped = cfg.DeleteRequiresStopped()
+		}
+	}
+	requireStopped = requireStopped && !force
 
 	if serverID == "" {
 		// Interactive mode requires a terminal.
 		if !term.IsTerminal(int(os.Stdout.Fd())) {
-			fmt.Fprintln(cmd.ErrOrStderr(), "Error: --id is required when not running in a terminal")
-			return
+			return errors.New(i18n.T("--id is required when not running in a terminal"))
 		}
 
-		result, err := tui.RunServerDelete(provider, providerName, nil)
+		result, err := tui.RunServerDelete(provider, providerName, nil, requireStopped)
 		if err != nil {
-			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
-			return
+			return err
 		}
 		if result == nil || !result.Confirmed {
-			fmt.Fprintln(cmd.ErrOrStderr(), "Server deletion cancelled.")
-			return
+			fmt.Fprintln(cmd.ErrOrStderr(), i18n.T("Server deletion cancelled."))
+			return nil
 		}
 
 		serverID = result.Server.ID
-		fmt.Fprintf(cmd.ErrOrStderr(), "Deleting server %q (ID: %s)...\n", result.Server.Name, serverID)
+		unlock, err := lockServer(provider, providerName, serverID, "delete_server")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if result.StopFirst {
+			if err := stopAndWait(cmd, provider, providerName, *result.Server); err != nil {
+				return fmt.Errorf("failed to stop server: %w", err)
+			}
+		}
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Deleting server %q (ID: %s)...\n", result.Server.Name, serverID))
 	} else {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Deleting server %s...\n", serverID)
+		unlock, err := lockServer(provider, providerName, serverID, "delete_server")
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if requireStopped {
+			server, err := provider.GetServer(context.Background(), serverID)
+			if err != nil {
+				return err
+			}
+			if server == nil {
+				return errors.New(i18n.T("server %s not found", serverID))
+			}
+			if !server.IsStopped() {
+				return errors.New(i18n.T("server %q is %s; stop it first with 'vpsm server stop --id %s' or pass --force", server.Name, server.Status, serverID))
+			}
+		}
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Deleting server %s...\n", serverID))
 	}
 
-	ctx := context.Background()
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// Fetch the server's final state for the archive before it is gone.
+	archived, _ := provider.GetServer(ctx, serverID)
 	if err := provider.DeleteServer(ctx, serverID); err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error deleting server: %v\n", err)
-		return
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+	if archived != nil {
+		// The DNS records pointing at the server go into the archive too,
+		// so restore-config can point them at its replacement.
+		dns, _ := attachDNSProvider(cmd, providerName)
+		archivesvc.ArchiveDeleted(ctx, providerName, *archived, dns)
+	}
+
+	if wait, _ := cmd.Flags().GetBool("wait"); wait {
+		name := serverID
+		if archived != nil {
+			name = archived.Name
+		}
+		if err := waitForDelete(ctx, cmd, provider, providerName, serverID, name); err != nil {
+			return err
+		}
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "Server %s deleted successfully.\n", serverID)
+	if output, _ := cmd.Flags().GetString("output"); isStructured(output) {
+		if archived == nil {
+			archived = &domain.Server{ID: serverID}
+		}
+		printStructured(cmd, output, archived)
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), i18n.T("Server %s deleted successfully.\n", serverID))
+	return nil
+}
+
+// waitForDelete polls until the provider no longer reports the server. The
+// wait is tracked like other actions so its usual duration drives the
+// progress estimate.
+func waitForDelete(ctx context.Context, cmd *cobra.Command, provider domain.Provider, providerName, serverID, serverName string) error {
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	defer svc.Close()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for server %q to be deleted...\n", serverName)
+	pending := &domain.ActionStatus{Status: domain.ActionStatusRunning, Command: "delete_server"}
+	record := svc.TrackAction(serverID, serverName, pending, "delete_server", action.StatusDeleted)
+	if err := svc.WaitForAction(ctx, pending, serverID, action.StatusDeleted, cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		return fmt.Errorf("server %q was deleted but did not go away: %w", serverName, err)
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	return nil
+}
+
+// stopAndWait stops server and waits for it to power off, tracking the
+// action like 'vpsm server stop' does.
+func stopAndWait(cmd *cobra.Command, provider domain.Provider, providerName string, server domain.Server) error {
+	fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Stopping server %q (ID: %s)...\n", server.Name, server.ID))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	actionStatus, err := provider.StopServer(ctx, server.ID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	defer svc.Close()
+
+	record := svc.TrackAction(server.ID, server.Name, actionStatus, "stop_server", "off")
+	if err := svc.WaitForAction(ctx, actionStatus, server.ID, "off", cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		return err
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	return nil
 }
@@ -3,12 +3,17 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
 )
 
@@ -19,6 +24,11 @@ type deleteMockProvider struct {
 	listErr     error
 	deleteErr   error
 	deletedID   string
+	server      *domain.Server
+
+	// lingers is how many lookups still find the server after it was
+	// deleted, as it shuts down.
+	lingers int
 }
 
 func (m *deleteMockProvider) GetDisplayName() string { return m.displayName }
@@ -30,7 +40,17 @@ func (m *deleteMockProvider) DeleteServer(_ context.Context, id string) error {
 	return m.deleteErr
 }
 func (m *deleteMockProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
-	return nil, fmt.Errorf("not implemented")
+	if m.deletedID != "" {
+		if m.lingers == 0 {
+			return nil, fmt.Errorf("server %q: %w", id, domain.ErrNotFound)
+		}
+		m.lingers--
+		return &domain.Server{ID: id, Name: m.server.Name, Status: "deleting"}, nil
+	}
+	if m.server == nil {
+		return nil, fmt.Errorf("not implemented")
+	}
+	return m.server, nil
 }
 func (m *deleteMockProvider) ListServers(_ context.Context) ([]domain.Server, error) {
 	return m.servers, m.listErr
@@ -45,6 +65,8 @@ func (m *deleteMockProvider) StopServer(_ context.Context, _ string) (*domain.Ac
 // registerDeleteMockProvider resets the global registry and registers a delete mock.
 func registerDeleteMockProvider(t *testing.T, name string, mock *deleteMockProvider) {
 	t.Helper()
+	serverarchive.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(serverarchive.ResetPath)
 	providers.Reset()
 	t.Cleanup(func() { providers.Reset() })
 	providers.Register(name, func(store auth.Store) (domain.Provider, error) {
@@ -66,6 +88,18 @@ func execDelete(t *testing.T, providerName string, extraArgs ...string) (stdout,
 	return outBuf.String(), errBuf.String()
 }
 
+// execDeleteErr is execDelete for tests that check the command's error.
+func execDeleteErr(t *testing.T, extraArgs ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"delete", "--provider", "mock"}, extraArgs...))
+	err = cmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
 func TestDeleteCommand_WithIDFlag(t *testing.T) {
 	mock := &deleteMockProvider{
 		displayName: "Mock",
@@ -114,3 +148,135 @@ func TestDeleteCommand_UnknownProvider(t *testing.T) {
 		t.Errorf("expected 'unknown provider' error on stderr, got:\n%s", stderr)
 	}
 }
+
+func TestDeleteCommand_RequireStopped_RefusesRunning(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "running"},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	_, stderr := execDelete(t, "mock", "--id", "42", "--require-stopped")
+
+	if mock.deletedID != "" {
+		t.Errorf("expected DeleteServer not to be called, got %q", mock.deletedID)
+	}
+	if !strings.Contains(stderr, `server "web-1" is running`) {
+		t.Errorf("expected running server error on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestDeleteCommand_RequireStopped_AllowsStopped(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "off"},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	stdout, _ := execDelete(t, "mock", "--id", "42", "--require-stopped")
+
+	if mock.deletedID != "42" {
+		t.Errorf("expected DeleteServer called with ID '42', got %q", mock.deletedID)
+	}
+	if !strings.Contains(stdout, "deleted successfully") {
+		t.Errorf("expected success message on stdout, got:\n%s", stdout)
+	}
+}
+
+func TestDeleteCommand_RequireStopped_FromConfig(t *testing.T) {
+	config.SetPath(t.TempDir() + "/config.json")
+	t.Cleanup(config.ResetPath)
+	cfg := &config.Config{DeleteRequireStopped: "on"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "running"},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	_, stderr := execDelete(t, "mock", "--id", "42")
+	if mock.deletedID != "" {
+		t.Errorf("expected policy from config to block delete, got %q", mock.deletedID)
+	}
+	if !strings.Contains(stderr, "--force") {
+		t.Errorf("expected hint about --force, got:\n%s", stderr)
+	}
+
+	execDelete(t, "mock", "--id", "42", "--force")
+	if mock.deletedID != "42" {
+		t.Errorf("expected --force to delete, got %q", mock.deletedID)
+	}
+}
+
+func TestDeleteCommand_JSONOutput(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "off", Provider: "mock"},
+	}
+
+	registerDeleteMockProvider(t, "mock", mock)
+
+	stdout, _ := execDelete(t, "mock", "--id", "42", "-o", "json")
+
+	var got domain.Server
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\noutput:\n%s", err, stdout)
+	}
+	if got.ID != "42" || got.Name != "web-1" {
+		t.Errorf("expected the deleted server, got %+v", got)
+	}
+}
+
+func TestDeleteCommand_Wait(t *testing.T) {
+	withTestStore(t)
+	withFastPolling(t)
+
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "off"},
+		lingers:     2,
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	stdout, stderr, err := execDeleteErr(t, "--id", "42", "--wait")
+	if err != nil {
+		t.Fatalf("delete --wait failed: %v\nstderr:\n%s", err, stderr)
+	}
+	for _, want := range []string{`Waiting for server "web-1" to be deleted`, "Status: deleting"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected %q on stderr, got:\n%s", want, stderr)
+		}
+	}
+	if mock.lingers != 0 {
+		t.Errorf("expected the command to wait until the server was gone, %d lookups left", mock.lingers)
+	}
+	if !strings.Contains(stdout, "deleted successfully") {
+		t.Errorf("expected success message on stdout, got:\n%s", stdout)
+	}
+}
+
+func TestDeleteCommand_WaitTimeout(t *testing.T) {
+	withTestStore(t)
+	withFastPolling(t)
+	orig := action.MaxPollAttempts
+	action.MaxPollAttempts = 3
+	t.Cleanup(func() { action.MaxPollAttempts = orig })
+
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", Status: "off"},
+		lingers:     10,
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	stdout, _, err := execDeleteErr(t, "--id", "42", "--wait")
+	if err == nil || !strings.Contains(err.Error(), "did not go away") {
+		t.Fatalf("expected a wait error, got %v", err)
+	}
+	if strings.Contains(stdout, "deleted successfully") {
+		t.Errorf("expected no success message, got:\n%s", stdout)
+	}
+}
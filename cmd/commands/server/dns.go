@@ -0,0 +1,260 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// dnsLookup resolves names for the propagation check. Tests replace it.
+var dnsLookup attach.Lookup = attach.LookupAt
+
+// dnsPollInterval is how often the propagation check asks again.
+var dnsPollInterval = 5 * time.Second
+
+// DNSCommand returns a cobra.Command that manages the DNS records
+// pointing at a server.
+func DNSCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dns",
+		Short: "Point DNS names at a server",
+		Long: `Manage the forward DNS records pointing at a server. The records are
+written to the DNS provider set with 'vpsm config set dns-provider <name>',
+or to the server's own provider when none is set.
+
+Examples:
+  vpsm server dns attach --id 12345 --domain example.com --name www`,
+	}
+
+	cmd.AddCommand(dnsAttachCommand())
+
+	return cmd
+}
+
+func dnsAttachCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach",
+		Short: "Point a name at a server's public addresses",
+		Long: `Create or update the A record of a name in a DNS zone so it points at
+the server's public IPv4 address, and its AAAA record for the server's
+IPv6 address. The changes are shown and confirmed before they are made.
+A name holding a CNAME record, or several A or AAAA records, is left
+alone.
+
+Afterwards vpsm asks the zone's nameservers for the name until they all
+answer with the server's addresses, for up to --wait.
+
+Examples:
+  vpsm server dns attach --id 12345 --domain example.com --name www
+  vpsm server dns attach --id 12345 --domain example.com --name @ --no-ipv6
+  vpsm server dns attach --id 12345 --domain example.com --name www --dns-provider desec --yes`,
+		Args:         cobra.NoArgs,
+		RunE:         runDNSAttach,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.Flags().String("domain", "", "Zone to add the records to, e.g. example.com (required)")
+	cmd.Flags().String("name", "@", "Name within the zone, or @ for the zone apex")
+	cmd.Flags().Int("ttl", 0, "Record TTL in seconds (defaults to the provider's, or the replaced record's)")
+	cmd.Flags().String("dns-provider", "", "DNS provider hosting the zone (defaults to the dns-provider setting)")
+	cmd.Flags().Bool("no-ipv6", false, "Only point the A record at the server, not the AAAA record")
+	cmd.Flags().BoolP("yes", "y", false, "Apply the changes without asking")
+	cmd.Flags().Duration("wait", 2*time.Minute, "How long to wait for the nameservers to answer with the new addresses (0 skips the check)")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("domain")
+
+	return cmd
+}
+
+func runDNSAttach(cmd *cobra.Command, args []string) error {
+	serverID, _ := cmd.Flags().GetString("id")
+	zone, _ := cmd.Flags().GetString("domain")
+	name, _ := cmd.Flags().GetString("name")
+	ttl, _ := cmd.Flags().GetInt("ttl")
+	noIPv6, _ := cmd.Flags().GetBool("no-ipv6")
+	yes, _ := cmd.Flags().GetBool("yes")
+	wait, _ := cmd.Flags().GetDuration("wait")
+
+	zone = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(zone)), ".")
+	if name = strings.TrimSpace(name); name == "" {
+		name = "@"
+	}
+	if name != "@" {
+		if err := dnsdomain.ValidateHostname(name); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	providerName := cmd.Flag("provider").Value.String()
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		return err
+	}
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		return fmt.Errorf("failed to get server: %w", err)
+	}
+	if server == nil {
+		return fmt.Errorf("server %s not found", serverID)
+	}
+
+	ipv6 := server.ReachableIPv6()
+	if noIPv6 {
+		ipv6 = ""
+	}
+	records := attach.Records(name, server.PublicIPv4, ipv6, ttl)
+	if len(records) == 0 {
+		return fmt.Errorf("server %q has no public address", server.Name)
+	}
+
+	dns, err := attachDNSProvider(cmd, providerName)
+	if err != nil {
+		return err
+	}
+	existing, err := dns.ListRecords(ctx, zone)
+	if err != nil {
+		return fmt.Errorf("failed to list records of %s: %w", zone, err)
+	}
+	changes, err := attach.Plan(records, existing, dns.Quirks())
+	if err != nil {
+		return err
+	}
+
+	hostname := attach.Hostname(name, zone)
+	want := make([]string, len(records))
+	for i, r := range records {
+		want[i] = r.Value
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s already points at %s.\n", hostname, strings.Join(want, ", "))
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "Pointing %s at server %q in %s (%s):\n", hostname, server.Name, zone, dns.GetDisplayName())
+		for _, c := range changes {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", c)
+		}
+		if !yes && !confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), "Apply these changes? [y/N]: ") {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cancelled.")
+			return nil
+		}
+		applied, err := dnsdomain.ApplyChanges(ctx, dns, zone, changes)
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d change(s) applied)", err, applied, len(changes))
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Applied %d change(s).\n", applied)
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+	return waitForPropagation(ctx, cmd, dns, zone, hostname, want, wait)
+}
+
+// attachDNSProvider returns the DNS provider named by --dns-provider, or
+// the one configured for servers on serverProvider.
+func attachDNSProvider(cmd *cobra.Command, serverProvider string) (dnsdomain.Provider, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	name := cfg.DNSProviderFor(serverProvider)
+	if cmd.Flags().Changed("dns-provider") {
+		name, _ = cmd.Flags().GetString("dns-provider")
+	}
+
+	// The server's provider keeps the project selected for it.
+	project := auth.Project()
+	if name != serverProvider {
+		project = cfg.ActiveProject(name)
+	}
+	store := auth.WithProject(auth.NewKeyringStore(auth.ServiceName), project)
+	dns, err := dnsproviders.Get(name, store)
+	if err != nil {
+		return nil, fmt.Errorf("%w: pass --dns-provider or set one with 'vpsm config set dns-provider <name>'", err)
+	}
+
+	// Log the record changes so 'vpsm dns rollback' can undo them.
+	if _, profile := auth.ParseProfile(name); profile == "" && project != "" {
+		name += ":" + project
+	}
+	logged := changelog.Wrap(dns, name, changelog.OpenDefault)
+	logged.OnLogError = func(err error) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the change was made but not logged, so it cannot be rolled back: %v\n", err)
+	}
+	return logged, nil
+}
+
+// waitForPropagation asks the zone's nameservers for hostname until each
+// answers with every address in want, or until wait has passed. A
+// nameserver that lags behind is reported, not an error: the records are
+// in place and will be served once it catches up.
+func waitForPropagation(ctx context.Context, cmd *cobra.Command, dns dnsdomain.Provider, zone, hostname string, want []string, wait time.Duration) error {
+	var nameservers []string
+	zones, err := dns.ListZones(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list zones: %w", err)
+	}
+	for _, z := range zones {
+		if strings.EqualFold(strings.TrimSuffix(z.Name, "."), zone) {
+			nameservers = z.Nameservers
+		}
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Waiting for the nameservers to answer with the new addresses...\n")
+	deadline := time.Now().Add(wait)
+	for {
+		statuses := attach.Check(ctx, dnsLookup, nameservers, hostname, want)
+		if attach.Propagated(statuses) {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s resolves to %s at %d nameserver(s).\n", hostname, strings.Join(want, ", "), len(statuses))
+			return nil
+		}
+		if ctx.Err() != nil || !time.Now().Add(dnsPollInterval).Before(deadline) {
+			fmt.Fprintf(cmd.OutOrStdout(), "Not every nameserver answers with the new addresses yet:\n")
+			for _, s := range statuses {
+				mark := "✗"
+				if s.Propagated {
+					mark = "✓"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s %s\n", mark, s)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "The records are saved; the rest should follow within the zone's refresh time.\n")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(dnsPollInterval):
+		}
+	}
+}
+
+// confirm asks a yes/no question and reports whether the answer was yes.
+func confirm(in io.Reader, out io.Writer, prompt string) bool {
+	fmt.Fprint(out, prompt)
+	response, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes"
+}
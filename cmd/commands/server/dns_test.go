@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/netip"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// dnsMockProvider is an in-memory DNS provider holding one zone.
+type dnsMockProvider struct {
+	records []dnsdomain.Record
+	nextID  int
+}
+
+func (m *dnsMockProvider) GetDisplayName() string   { return "Mock DNS" }
+func (m *dnsMockProvider) Quirks() dnsdomain.Quirks { return dnsdomain.Quirks{} }
+func (m *dnsMockProvider) ListZones(context.Context) ([]dnsdomain.Zone, error) {
+	return []dnsdomain.Zone{{ID: "z1", Name: "example.com", Nameservers: []string{"ns1.example.net", "ns2.example.net"}}}, nil
+}
+func (m *dnsMockProvider) ListRecords(context.Context, string) ([]dnsdomain.Record, error) {
+	return m.records, nil
+}
+func (m *dnsMockProvider) CreateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	m.nextID++
+	r.ID = fmt.Sprint(m.nextID)
+	m.records = append(m.records, r)
+	return &r, nil
+}
+func (m *dnsMockProvider) UpdateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	for i := range m.records {
+		if m.records[i].ID == r.ID {
+			m.records[i] = r
+			return &r, nil
+		}
+	}
+	return nil, dnsdomain.ErrNotFound
+}
+func (m *dnsMockProvider) DeleteRecord(context.Context, string, string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// stubDNSLookup answers every propagation query with addrs.
+func stubDNSLookup(t *testing.T, addrs ...string) {
+	t.Helper()
+	origLookup, origInterval := dnsLookup, dnsPollInterval
+	dnsLookup = func(context.Context, string, string) ([]netip.Addr, error) {
+		parsed := make([]netip.Addr, len(addrs))
+		for i, a := range addrs {
+			parsed[i] = netip.MustParseAddr(a)
+		}
+		return parsed, nil
+	}
+	dnsPollInterval = 0
+	t.Cleanup(func() { dnsLookup, dnsPollInterval = origLookup, origInterval })
+}
+
+func execDNSAttach(t *testing.T, dns *dnsMockProvider, stdin string, extraArgs ...string) (stdout string, err error) {
+	t.Helper()
+	dir := t.TempDir()
+	config.SetPath(filepath.Join(dir, "config.json"))
+	t.Cleanup(config.ResetPath)
+	changelog.SetPath(filepath.Join(dir, "vpsm.db"))
+	t.Cleanup(changelog.ResetPath)
+
+	server := &domain.Server{ID: "42", Name: "web-1", Status: "running", PublicIPv4: "203.0.113.7", PublicIPv6Network: "2001:db8::/64"}
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(auth.Store) (domain.Provider, error) { return &stopMockProvider{getServer: server}, nil })
+	dnsproviders.Reset()
+	t.Cleanup(dnsproviders.Reset)
+	dnsproviders.Register("mock", func(auth.Store) (dnsdomain.Provider, error) { return dns, nil })
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetArgs(append([]string{"dns", "attach", "--provider", "mock", "--id", "42", "--domain", "example.com"}, extraArgs...))
+	err = cmd.Execute()
+	return outBuf.String(), err
+}
+
+func TestDNSAttach_ConfirmsAppliesAndChecks(t *testing.T) {
+	stubDNSLookup(t, "203.0.113.7", "2001:db8::1")
+	dns := &dnsMockProvider{nextID: 10, records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1", TTL: 300},
+	}}
+
+	stdout, err := execDNSAttach(t, dns, "y\n", "--name", "www")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`Pointing www.example.com at server "web-1" in example.com (Mock DNS):`,
+		"  update www A 203.0.113.7 (ttl 300)",
+		"  create www AAAA 2001:db8::1",
+		"Applied 2 change(s).",
+		"www.example.com resolves to 203.0.113.7, 2001:db8::1 at 2 nameserver(s).",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+
+	want := []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "11", Name: "www", Type: dnsdomain.RecordAAAA, Value: "2001:db8::1"},
+	}
+	if diff := cmp.Diff(want, dns.records); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDNSAttach_DeclinedLeavesZone(t *testing.T) {
+	dns := &dnsMockProvider{}
+
+	stdout, err := execDNSAttach(t, dns, "n\n", "--name", "www")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(stdout, "Cancelled.") || len(dns.records) != 0 {
+		t.Errorf("records = %v, output:\n%s", dns.records, stdout)
+	}
+}
+
+func TestDNSAttach_ReportsLaggingNameservers(t *testing.T) {
+	stubDNSLookup(t, "203.0.113.1")
+	dns := &dnsMockProvider{}
+
+	stdout, err := execDNSAttach(t, dns, "", "--no-ipv6", "--yes", "--wait", "1ms")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"create @ A 203.0.113.7", "Not every nameserver", "✗ ns1.example.net: 203.0.113.1"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestDNSAttach_UnknownDNSProvider(t *testing.T) {
+	_, err := execDNSAttach(t, &dnsMockProvider{}, "", "--dns-provider", "nope", "--yes")
+	if err == nil || !strings.Contains(err.Error(), "dns-provider") {
+		t.Errorf("err = %v, want a hint to set a DNS provider", err)
+	}
+}
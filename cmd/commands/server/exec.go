@@ -0,0 +1,275 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/server/services/group"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// ExecCommand returns a cobra.Command that runs a command on one or more
+// servers over SSH.
+func ExecCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [server] -- <command...>",
+		Short: "Run a command on a server over SSH",
+		Long: `Run a shell command on a server over a non-interactive SSH connection,
+streaming its output as it is produced. vpsm exits with the command's exit
+status, so it can be used in scripts; ssh exits with 255 when it cannot
+connect.
+
+With --all the command runs on every running server, or with --label only
+on those matching the label selector (e.g. env=prod,role!=db), at most
+--concurrency at a time. Each output line is prefixed with the server's
+name, and vpsm exits with the highest exit status of any server.
+
+The login and bastion are resolved per server as for 'vpsm server ssh';
+--user overrides the login. SSH runs in batch mode, so key-based
+authentication must already be set up.
+
+Examples:
+  vpsm server exec web-1 -- uptime
+  vpsm server exec --id 12345 --user deploy -- systemctl restart app
+  vpsm server exec --all --label env=prod -- df -h /`,
+		Args:         cobra.MinimumNArgs(1),
+		RunE:         runExec,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("id", "", "Server ID to run the command on (or pass the server name before --)")
+	cmd.Flags().String("user", "", "SSH username (defaults to each server's saved preference or 'root')")
+	cmd.Flags().Bool("all", false, "Run the command on every running server")
+	cmd.Flags().String("label", "", "Only run on servers matching this label selector (implies --all)")
+	cmd.Flags().Int("concurrency", group.DefaultConcurrency, "Maximum number of servers the command runs on at once")
+
+	return cmd
+}
+
+// exitStatusError makes vpsm exit with a remote command's status without
+// printing anything further; the command's own output explains it.
+type exitStatusError struct {
+	code int
+}
+
+func (e *exitStatusError) Error() string { return fmt.Sprintf("exit status %d", e.code) }
+
+// ExitCode is the status vpsm exits with.
+func (e *exitStatusError) ExitCode() int { return e.code }
+
+func runExec(cmd *cobra.Command, args []string) error {
+	dash := cmd.ArgsLenAtDash()
+	if dash == -1 || dash == len(args) {
+		return errors.New("no command given: pass it after --, e.g. vpsm server exec web-1 -- uptime")
+	}
+	targets, command := args[:dash], strings.Join(args[dash:], " ")
+
+	serverID, _ := cmd.Flags().GetString("id")
+	all, _ := cmd.Flags().GetBool("all")
+	labelExpr, _ := cmd.Flags().GetString("label")
+	fanOut := all || cmd.Flags().Changed("label")
+
+	switch {
+	case fanOut && (serverID != "" || len(targets) > 0):
+		return errors.New("--all and --label cannot be combined with a server")
+	case !fanOut && serverID == "" && len(targets) == 0:
+		return errors.New(`required flag "id" not set (or pass the server name, or --all)`)
+	case len(targets) > 1 || (serverID != "" && len(targets) > 0):
+		return errors.New("expected a single server before --")
+	}
+
+	var selector domain.LabelSelector
+	if labelExpr != "" {
+		var err error
+		if selector, err = domain.ParseLabelSelector(labelExpr); err != nil {
+			return err
+		}
+	}
+
+	providerName := cmd.Flag("provider").Value.String()
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var svc *prefssvc.Service
+	if repo, err := serverprefs.Open(); err == nil {
+		svc = prefssvc.NewService(repo)
+		defer svc.Close()
+	}
+	userFlag, _ := cmd.Flags().GetString("user")
+	conn := func(server domain.Server) remote.Conn {
+		c := remote.Conn{Username: userFlag, Bastion: bastion.Lookup(svc, providerName, server)}
+		if c.Username == "" && svc != nil {
+			c.Username = svc.GetSSHUser(providerName, server.ID)
+		}
+		return c
+	}
+
+	if !fanOut {
+		server, err := execTarget(ctx, provider, serverID, targets)
+		if err != nil {
+			return err
+		}
+		if server.Status != "running" {
+			return fmt.Errorf("server %s is not running (status: %s)", server.Name, server.Status)
+		}
+		err = remote.Stream(ctx, *server, conn(*server), command, cmd.OutOrStdout(), cmd.ErrOrStderr())
+		return execResult(cmd, err)
+	}
+
+	var servers []domain.Server
+	if selector != nil {
+		servers, err = domain.ListServersMatching(ctx, provider, selector)
+	} else {
+		servers, err = provider.ListServers(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	running := servers[:0:0]
+	for _, s := range servers {
+		if s.Status != "running" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Skipping %q: not running (status: %s)\n", s.Name, s.Status)
+			continue
+		}
+		running = append(running, s)
+	}
+	if len(running) == 0 {
+		return errors.New("no running servers match")
+	}
+
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+	if concurrency <= 0 {
+		concurrency = group.DefaultConcurrency
+	}
+	return execResult(cmd, execFanOut(ctx, cmd, running, command, conn, concurrency))
+}
+
+// execTarget fetches the server named by --id or the positional argument.
+func execTarget(ctx context.Context, provider domain.Provider, serverID string, targets []string) (*domain.Server, error) {
+	if serverID == "" {
+		return findServer(ctx, provider, targets[0])
+	}
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch server: %w", err)
+	}
+	return server, nil
+}
+
+// execFanOut runs command on every server, at most concurrency at once,
+// prefixing each output line with the server's name. Failures are
+// reported as they happen; the returned error carries the highest exit
+// status, or is nil when the command succeeded everywhere.
+func execFanOut(ctx context.Context, cmd *cobra.Command, servers []domain.Server, command string, conn func(domain.Server) remote.Conn, concurrency int) error {
+	width := 0
+	for _, s := range servers {
+		width = max(width, len(s.Name))
+	}
+
+	var mu sync.Mutex
+	codes := make([]int, len(servers))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, server := range servers {
+		g.Go(func() error {
+			prefix := fmt.Sprintf("%-*s | ", width, server.Name)
+			stdout := &prefixWriter{mu: &mu, w: cmd.OutOrStdout(), prefix: prefix}
+			stderr := &prefixWriter{mu: &mu, w: cmd.ErrOrStderr(), prefix: prefix}
+			err := remote.Stream(ctx, server, conn(server), command, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+
+			codes[i] = remote.ExitCode(err)
+			if err != nil {
+				mu.Lock()
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s: %v\n", server.Name, err)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	failed, highest := 0, 0
+	for _, code := range codes {
+		if code != 0 {
+			failed++
+			highest = max(highest, code)
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Command failed on %d of %d server(s).\n", failed, len(servers))
+	return &exitStatusError{code: highest}
+}
+
+// execResult turns the outcome of a remote command into the command's
+// error: exit statuses are passed on silently, anything else (such as a
+// server without a public IP) is reported.
+func execResult(cmd *cobra.Command, err error) error {
+	var coded interface{ ExitCode() int }
+	if err == nil || !errors.As(err, &coded) {
+		return err
+	}
+	cmd.SilenceErrors = true
+	return &exitStatusError{code: remote.ExitCode(err)}
+}
+
+// prefixWriter writes whole lines to w, each starting with prefix. Lines
+// from concurrent writers sharing mu are not interleaved.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i == -1 {
+			return len(b), nil
+		}
+		p.writeLine(p.buf[:i+1])
+		p.buf = p.buf[i+1:]
+	}
+}
+
+// Flush writes a trailing partial line, ending it with a newline.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		p.writeLine(append(p.buf, '\n'))
+		p.buf = nil
+	}
+}
+
+func (p *prefixWriter) writeLine(line []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	io.WriteString(p.w, p.prefix)
+	p.w.Write(line)
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+)
+
+// fakeExitError carries an exit status like *exec.ExitError.
+type fakeExitError int
+
+func (e fakeExitError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e fakeExitError) ExitCode() int { return int(e) }
+
+// stubStream replaces remote.StreamRunner with run, called with the
+// target ("user@host") of each ssh invocation.
+func stubStream(t *testing.T, run func(target string, stdout, stderr io.Writer) error) {
+	t.Helper()
+	orig := remote.StreamRunner
+	remote.StreamRunner = func(_ context.Context, stdout, stderr io.Writer, args ...string) error {
+		return run(args[len(args)-2], stdout, stderr)
+	}
+	t.Cleanup(func() { remote.StreamRunner = orig })
+}
+
+func execExec(t *testing.T, mock *sshMockProvider, extraArgs ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	registerSSHMockProvider(t, "mock", mock)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"exec", "--provider", "mock"}, extraArgs...))
+	err = cmd.Execute()
+	return outBuf.String(), errBuf.String(), err
+}
+
+func TestExecCommand_PassesOnExitStatus(t *testing.T) {
+	var target string
+	stubStream(t, func(tgt string, stdout, stderr io.Writer) error {
+		target = tgt
+		io.WriteString(stdout, "migrating...\n")
+		return fakeExitError(3)
+	})
+
+	stdout, stderr, err := execExec(t, &sshMockProvider{
+		servers: []domain.Server{{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"}},
+	}, "web-1", "--user", "deploy", "--", "make", "migrate")
+
+	var coded interface{ ExitCode() int }
+	if !errors.As(err, &coded) || coded.ExitCode() != 3 {
+		t.Fatalf("err = %v, want exit status 3", err)
+	}
+	if target != "deploy@192.0.2.1" {
+		t.Errorf("target = %q, want deploy@192.0.2.1", target)
+	}
+	if stdout != "migrating...\n" {
+		t.Errorf("stdout = %q", stdout)
+	}
+	if stderr != "" {
+		t.Errorf("expected no stderr, got %q", stderr)
+	}
+}
+
+func TestExecCommand_FansOutByLabel(t *testing.T) {
+	var mu sync.Mutex
+	var targets []string
+	stubStream(t, func(target string, stdout, stderr io.Writer) error {
+		mu.Lock()
+		targets = append(targets, target)
+		mu.Unlock()
+		io.WriteString(stdout, "up 3 days\npartial")
+		if target == "root@192.0.2.3" {
+			return fakeExitError(2)
+		}
+		return nil
+	})
+
+	stdout, stderr, err := execExec(t, &sshMockProvider{
+		servers: []domain.Server{
+			{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1", Labels: map[string]string{"env": "prod"}},
+			{ID: "2", Name: "web-2", Status: "off", PublicIPv4: "192.0.2.2", Labels: map[string]string{"env": "prod"}},
+			{ID: "3", Name: "api", Status: "running", PublicIPv4: "192.0.2.3", Labels: map[string]string{"env": "prod"}},
+			{ID: "4", Name: "db-1", Status: "running", PublicIPv4: "192.0.2.4", Labels: map[string]string{"env": "dev"}},
+		},
+	}, "--label", "env=prod", "--", "uptime")
+
+	var coded interface{ ExitCode() int }
+	if !errors.As(err, &coded) || coded.ExitCode() != 2 {
+		t.Fatalf("err = %v, want exit status 2", err)
+	}
+	if len(targets) != 2 {
+		t.Errorf("ran on %q, want web-1 and api only", targets)
+	}
+	assertContainsAll(t, stdout, "stdout", []string{
+		"web-1 | up 3 days\n",
+		"web-1 | partial\n",
+		"api   | up 3 days\n",
+	})
+	assertContainsAll(t, stderr, "stderr", []string{
+		`Skipping "web-2": not running (status: off)`,
+		"api: exit status 2",
+		"Command failed on 1 of 2 server(s).",
+	})
+	if strings.Contains(stdout, "db-1") {
+		t.Errorf("db-1 should not match the selector: %q", stdout)
+	}
+}
+
+func TestExecCommand_RequiresCommand(t *testing.T) {
+	_, _, err := execExec(t, &sshMockProvider{}, "web-1")
+	if err == nil || !strings.Contains(err.Error(), "no command given") {
+		t.Errorf("err = %v, want missing command error", err)
+	}
+}
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/idle"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// idleCheckConcurrency bounds parallel metrics requests.
+const idleCheckConcurrency = 4
+
+func IdleCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "idle",
+		Short: "Find idle servers worth stopping or downsizing",
+		Long: `Analyse metrics history for every running server and list those whose
+CPU and network usage stayed near zero for the whole window.
+
+The window defaults to the "idle-window" config key (7d if unset).
+
+Examples:
+  vpsm server idle
+  vpsm server idle --window 48h
+  vpsm server idle -o json`,
+		Run: runIdle,
+	}
+
+	cmd.Flags().String("window", "", "Metrics look-back window, e.g. 7d or 48h (overrides config)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// idleServer pairs a server with its idle report for output.
+type idleServer struct {
+	Server         domain.Server `json:"server"`
+	CPUP95         float64       `json:"cpu_p95_percent"`
+	NetworkP95     float64       `json:"network_p95_bytes_per_sec"`
+	Recommendation string        `json:"recommendation"`
+}
+
+func runIdle(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	mp, ok := provider.(domain.MetricsProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: provider %q does not support metrics\n", providerName)
+		return
+	}
+
+	window, err := resolveIdleWindow(cmd)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	ctx := context.Background()
+	servers, err := provider.ListServers(ctx)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error listing servers: %v\n", err)
+		return
+	}
+
+	var running []domain.Server
+	for _, s := range servers {
+		if s.Status == "running" {
+			running = append(running, s)
+		}
+	}
+
+	reports := make([]*idle.Report, len(running))
+	var g errgroup.Group
+	g.SetLimit(idleCheckConcurrency)
+	for i, s := range running {
+		g.Go(func() error {
+			report, err := idle.Check(ctx, mp, s.ID, window, idle.DefaultThresholds())
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping %s: %v\n", s.Name, err)
+				return nil
+			}
+			reports[i] = report
+			return nil
+		})
+	}
+	g.Wait()
+
+	var found []idleServer
+	for i, r := range reports {
+		if r != nil && r.Idle {
+			found = append(found, idleServer{
+				Server:         running[i],
+				CPUP95:         r.CPUP95,
+				NetworkP95:     r.NetworkP95,
+				Recommendation: r.Recommendation(),
+			})
+		}
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		if found == nil {
+			found = []idleServer{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(found)
+		return
+	}
+
+	if len(found) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No idle servers found over the last %s.\n", idle.FormatWindow(window))
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tTYPE\tCPU P95\tNETWORK P95")
+	fmt.Fprintln(w, "--\t----\t----\t-------\t-----------")
+	for _, s := range found {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1f%%\t%s\n",
+			s.Server.ID, s.Server.Name, s.Server.ServerType, s.CPUP95, formatMetric(s.NetworkP95, "B/s"))
+	}
+	w.Flush()
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d server(s) idle over the last %s — consider stopping or downsizing.\n", len(found), idle.FormatWindow(window))
+	fmt.Fprintln(cmd.OutOrStdout(), "Stop one with: vpsm server stop --id <id>")
+}
+
+// resolveIdleWindow returns the --window flag value, falling back to the
+// configured idle-window and then the default.
+func resolveIdleWindow(cmd *cobra.Command) (time.Duration, error) {
+	if flag, _ := cmd.Flags().GetString("window"); flag != "" {
+		return idle.ParseWindow(flag)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return idle.DefaultWindow, nil
+	}
+	return idle.ResolveWindow(cfg.IdleWindow), nil
+}
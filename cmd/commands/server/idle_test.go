@@ -0,0 +1,131 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// idleMockProvider lists servers and returns per-server metrics.
+type idleMockProvider struct {
+	mockProvider
+	metrics map[string]*domain.ServerMetrics
+
+	mu      sync.Mutex
+	windows []time.Duration
+}
+
+func (m *idleMockProvider) GetServerMetrics(_ context.Context, id string, _ []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.windows = append(m.windows, end.Sub(start))
+	return m.metrics[id], nil
+}
+
+func flatMetrics(cpu, network float64) *domain.ServerMetrics {
+	series := func(v float64) domain.MetricsTimeSeries {
+		return domain.MetricsTimeSeries{Values: []domain.MetricsPoint{{Value: v}, {Value: v}, {Value: v}}}
+	}
+	return &domain.ServerMetrics{TimeSeries: map[string]domain.MetricsTimeSeries{
+		"cpu":                     series(cpu),
+		"network.0.bandwidth.in":  series(network),
+		"network.0.bandwidth.out": series(network),
+	}}
+}
+
+func execIdle(t *testing.T, mock *idleMockProvider, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"idle", "--provider", "mock"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestIdleCommand_ListsIdleRunningServers(t *testing.T) {
+	mock := &idleMockProvider{
+		mockProvider: mockProvider{servers: []domain.Server{
+			{ID: "1", Name: "quiet", Status: "running", ServerType: "cpx11"},
+			{ID: "2", Name: "busy", Status: "running", ServerType: "cpx21"},
+			{ID: "3", Name: "stopped", Status: "off", ServerType: "cpx11"},
+		}},
+		metrics: map[string]*domain.ServerMetrics{
+			"1": flatMetrics(0.2, 50),
+			"2": flatMetrics(40, 50_000),
+		},
+	}
+
+	stdout, stderr := execIdle(t, mock)
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	assertContainsAll(t, stdout, "stdout", []string{"quiet", "cpx11", "0.2%", "1 server(s) idle over the last 7d"})
+	if strings.Contains(stdout, "busy") || strings.Contains(stdout, "stopped") {
+		t.Errorf("expected only the idle running server, got:\n%s", stdout)
+	}
+	if len(mock.windows) != 2 {
+		t.Errorf("expected metrics to be fetched for the 2 running servers, got %d", len(mock.windows))
+	}
+}
+
+func TestIdleCommand_WindowFlag(t *testing.T) {
+	mock := &idleMockProvider{
+		mockProvider: mockProvider{servers: []domain.Server{{ID: "1", Name: "quiet", Status: "running"}}},
+		metrics:      map[string]*domain.ServerMetrics{"1": flatMetrics(10, 0)},
+	}
+
+	stdout, _ := execIdle(t, mock, "--window", "48h")
+
+	if len(mock.windows) != 1 || mock.windows[0] != 48*time.Hour {
+		t.Errorf("expected a 48h metrics window, got %v", mock.windows)
+	}
+	if !strings.Contains(stdout, "No idle servers found over the last 2d") {
+		t.Errorf("unexpected output:\n%s", stdout)
+	}
+}
+
+func TestIdleCommand_InvalidWindow(t *testing.T) {
+	mock := &idleMockProvider{}
+
+	_, stderr := execIdle(t, mock, "--window", "soon")
+
+	if !strings.Contains(stderr, "invalid window") {
+		t.Errorf("expected invalid window error, got: %s", stderr)
+	}
+}
+
+func TestIdleCommand_ProviderWithoutMetrics(t *testing.T) {
+	registerMockProvider(t, "mock", &mockProvider{})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"idle", "--provider", "mock"})
+	cmd.Execute()
+
+	if !strings.Contains(errBuf.String(), "does not support metrics") {
+		t.Errorf("expected unsupported error, got: %s", errBuf.String())
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/ipv6"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// IPv6Command returns a cobra.Command that shows a server's IPv6 subnet and
+// resolves addresses inside it, e.g. for DNS AAAA records.
+func IPv6Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ipv6",
+		Short: "Show a server's IPv6 subnet and pick addresses in it",
+		Long: `Show the IPv6 subnet routed to a server and resolve specific addresses
+inside it.
+
+Each --host is either an interface identifier written as an IPv6 suffix
+(e.g. ::1, ::dead:beef) or a decimal host number (e.g. 2). Without --host,
+the ::1 address is shown, which is what most images configure by default.
+The resulting addresses can be used directly in DNS AAAA records.
+
+Examples:
+  vpsm server ipv6 --id 12345
+  vpsm server ipv6 --id 12345 --host ::1 --host ::2
+  vpsm server ipv6 --id 12345 --host 10 -o json`,
+		Run: runIPv6,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringArray("host", nil, "Host part within the subnet (repeatable, default ::1)")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// ipv6Address is a resolved address within a server's subnet.
+type ipv6Address struct {
+	Host    string `json:"host"`
+	Address string `json:"address"`
+}
+
+func runIPv6(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	serverID, _ := cmd.Flags().GetString("id")
+	server, err := provider.GetServer(context.Background(), serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	if server.PublicIPv6Network == "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %q has no IPv6 subnet assigned\n", server.Name)
+		return
+	}
+
+	hosts, _ := cmd.Flags().GetStringArray("host")
+	if len(hosts) == 0 {
+		hosts = []string{ipv6.DefaultHost}
+	}
+
+	addresses := make([]ipv6Address, 0, len(hosts))
+	for _, host := range hosts {
+		addr, err := ipv6.Address(server.PublicIPv6Network, host)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		addresses = append(addresses, ipv6Address{Host: host, Address: addr.String()})
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(struct {
+			Subnet    string        `json:"subnet"`
+			Addresses []ipv6Address `json:"addresses"`
+		}{server.PublicIPv6Network, addresses})
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  Subnet:\t%s\n", server.PublicIPv6Network)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  HOST\tADDRESS")
+	for _, a := range addresses {
+		fmt.Fprintf(w, "  %s\t%s\n", a.Host, a.Address)
+	}
+	w.Flush()
+}
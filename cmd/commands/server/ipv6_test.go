@@ -0,0 +1,77 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func execIPv6(t *testing.T, server *domain.Server, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	registerShowMockProvider(t, "mock", &showMockProvider{getServer: server})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"ipv6", "--provider", "mock", "--id", "42"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestIPv6Command_DefaultHost(t *testing.T) {
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv6: "2001:db8::", PublicIPv6Network: "2001:db8::/64"}
+
+	stdout, stderr := execIPv6(t, server)
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	assertContainsAll(t, stdout, "stdout", []string{"2001:db8::/64", "::1", "2001:db8::1"})
+}
+
+func TestIPv6Command_JSONHosts(t *testing.T) {
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv6Network: "2001:db8::/64"}
+
+	stdout, _ := execIPv6(t, server, "--host", "::2", "--host", "16", "-o", "json")
+
+	var got struct {
+		Subnet    string `json:"subnet"`
+		Addresses []struct {
+			Host    string `json:"host"`
+			Address string `json:"address"`
+		} `json:"addresses"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if got.Subnet != "2001:db8::/64" || len(got.Addresses) != 2 {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+	if got.Addresses[0].Address != "2001:db8::2" || got.Addresses[1].Address != "2001:db8::10" {
+		t.Errorf("unexpected addresses: %+v", got.Addresses)
+	}
+}
+
+func TestIPv6Command_NoSubnet(t *testing.T) {
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv4: "1.2.3.4"}
+
+	_, stderr := execIPv6(t, server)
+
+	if !strings.Contains(stderr, "no IPv6 subnet") {
+		t.Errorf("expected no subnet error, got: %s", stderr)
+	}
+}
+
+func TestIPv6Command_InvalidHost(t *testing.T) {
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv6Network: "2001:db8::/64"}
+
+	_, stderr := execIPv6(t, server, "--host", "nope")
+
+	if !strings.Contains(stderr, "invalid host") {
+		t.Errorf("expected invalid host error, got: %s", stderr)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/export"
 	"nathanbeddoewebdev/vpsm/internal/server/tui"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
 
@@ -17,12 +21,15 @@ import (
 
 func ListCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List all servers",
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List all servers",
 		Long: `List all servers from the specified provider.
 
 In interactive mode (default), opens a full-window TUI with keyboard
-navigation. Use --output json or --output table for non-interactive output.
+navigation. Use --output table, json, yaml, or csv for non-interactive
+output. JSON and YAML use the same field names. --format prints each
+server through a Go template instead.
 
 Examples:
   # Interactive TUI
@@ -31,12 +38,28 @@ Examples:
   # Non-interactive table
   vpsm server list -o table
 
-  # JSON output for scripting
-  vpsm server list -o json`,
+  # JSON or YAML output for scripting
+  vpsm server list -o json
+  vpsm server list -o yaml
+
+  # CSV export for spreadsheets
+  vpsm server list -o csv > servers.csv
+
+  # Only servers whose labels match a selector (implies non-interactive output)
+  vpsm server list --selector env=prod,role=web
+
+  # Surface labels as extra columns (defaults to the label-columns config key)
+  vpsm server list -o table --label-columns env,role
+
+  # Shape each line with a Go template (fields as in -o json, Go names)
+  vpsm server list --format '{{.Name}}\t{{.PublicIPv4}}'
+  vpsm server list --format '{{.Name}} {{index .Labels "env"}}'`,
 		Run: runList,
 	}
 
-	cmd.Flags().StringP("output", "o", "", "Output format: table or json (omit for interactive TUI)")
+	cmd.Flags().StringSlice("label-columns", nil, "Label keys to show as extra table columns (overrides the label-columns config key)")
+	cmd.Flags().String("format", "", formatUsage)
+	cmd.Flags().String("selector", "", "Only list servers whose labels match, e.g. env=prod,role!=db,backup,!temp")
 
 	return cmd
 }
@@ -46,62 +69,122 @@ func runList(cmd *cobra.Command, args []string) {
 
 	provider, err := providers.Get(providerName, auth.DefaultStore())
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error: %v\n", err))
 		return
 	}
 
-	output, _ := cmd.Flags().GetString("output")
+	if err := checkFormatFlags(cmd); err != nil {
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error: %v\n", err))
+		return
+	}
 
-	// Non-interactive mode for scripting, or when no TTY is available.
-	if output == "json" || output == "table" || !term.IsTerminal(int(os.Stdout.Fd())) {
-		if output == "" {
-			output = "table"
+	var selector domain.LabelSelector
+	if cmd.Flags().Changed("selector") {
+		expr, _ := cmd.Flags().GetString("selector")
+		selector, err = domain.ParseLabelSelector(expr)
+		if err != nil {
+			fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error: %v\n", err))
+			return
 		}
-		runListNonInteractive(cmd, provider, output)
+	}
+
+	// Non-interactive mode for scripting, or when no TTY is available.
+	if cmd.Flags().Changed("output") || cmd.Flags().Changed("format") || selector != nil || !term.IsTerminal(int(os.Stdout.Fd())) {
+		output, _ := cmd.Flags().GetString("output")
+		runListNonInteractive(cmd, provider, output, selector)
 		return
 	}
 
 	// Interactive full-window TUI. Runs a single Bubbletea program that
 	// manages all view transitions (list, show, delete, create) internally,
 	// eliminating screen flicker between views.
-	if _, err := tui.RunServerApp(provider, providerName); err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	if _, err := tui.RunServerApp(provider, providerName, tui.AppOptions{}); err != nil {
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error: %v\n", err))
 	}
 }
 
-func runListNonInteractive(cmd *cobra.Command, provider domain.Provider, output string) {
+// runListNonInteractive prints the servers as text. A non-nil selector
+// restricts the listing to matching servers, filtered by the provider's
+// API where it supports that.
+func runListNonInteractive(cmd *cobra.Command, provider domain.Provider, output string, selector domain.LabelSelector) {
 	ctx := context.Background()
-	servers, err := provider.ListServers(ctx)
+	var servers []domain.Server
+	var err error
+	if selector != nil {
+		servers, err = domain.ListServersMatching(ctx, provider, selector)
+	} else {
+		servers, err = provider.ListServers(ctx)
+	}
 	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "Error listing servers: %v\n", err)
+		fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error listing servers: %v\n", err))
 		return
 	}
 
-	if output == "json" {
-		printServersJSON(cmd, servers)
+	if printFormatted(cmd, servers) {
+		return
+	}
+
+	switch output {
+	case "json", "yaml":
+		printStructured(cmd, output, servers)
+		return
+	case "csv":
+		if err := export.WriteCSV(cmd.OutOrStdout(), servers); err != nil {
+			fmt.Fprint(cmd.ErrOrStderr(), i18n.T("Error: %v\n", err))
+		}
 		return
 	}
 
 	if len(servers) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No servers found.")
+		fmt.Fprintln(cmd.OutOrStdout(), i18n.T("No servers found."))
 		return
 	}
 
+	labelKeys := resolveLabelColumns(cmd)
+
+	headers := []string{"ID", "NAME"}
+	for _, key := range labelKeys {
+		headers = append(headers, strings.ToUpper(key))
+	}
+	headers = append(headers, "STATUS", "REGION", "TYPE", "PUBLIC IPv4", "IMAGE")
+
+	underlines := make([]string, len(headers))
+	for i, h := range headers {
+		underlines[i] = strings.Repeat("-", len(h))
+	}
+
 	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
-	fmt.Fprintln(w, "ID\tNAME\tSTATUS\tREGION\tTYPE\tPUBLIC IPv4\tIMAGE")
-	fmt.Fprintln(w, "--\t----\t------\t------\t----\t-----------\t-----")
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(underlines, "\t"))
 
 	for _, server := range servers {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			server.ID,
-			server.Name,
+		row := []string{server.ID, server.Name}
+		for _, key := range labelKeys {
+			row = append(row, server.Labels[key])
+		}
+		row = append(row,
 			server.Status,
 			server.Region,
 			server.ServerType,
 			server.PublicIPv4,
 			server.Image,
 		)
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 
 	w.Flush()
 }
+
+// resolveLabelColumns returns the label keys to render as extra table
+// columns. The --label-columns flag takes precedence over the config value.
+func resolveLabelColumns(cmd *cobra.Command) []string {
+	if f := cmd.Flags().Lookup("label-columns"); f != nil && f.Changed {
+		keys, _ := cmd.Flags().GetStringSlice("label-columns")
+		return keys
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.LabelColumns
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
@@ -163,3 +165,186 @@ func TestListCommand_UnknownProvider(t *testing.T) {
 		t.Errorf("expected 'unknown provider' error on stderr, got:\n%s", stderr)
 	}
 }
+
+func TestListCommand_CSVOutput(t *testing.T) {
+	mock := &mockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{
+				ID:          "42",
+				Name:        "web-server",
+				Status:      "running",
+				PublicIPv4:  "1.2.3.4",
+				PublicIPv6:  "2001:db8::1",
+				PrivateIPv4: "10.0.0.2",
+				Region:      "fsn1",
+				ServerType:  "cpx11",
+				Image:       "ubuntu-24.04",
+				Provider:    "mock",
+			},
+		},
+	}
+
+	registerMockProvider(t, "mock", mock)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "-o", "csv"})
+	cmd.Execute()
+
+	lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header + 1 row, got %d lines:\n%s", len(lines), outBuf.String())
+	}
+	if !strings.HasPrefix(lines[0], "id,name,status") {
+		t.Errorf("unexpected CSV header: %s", lines[0])
+	}
+
+	// Columns hidden in the TUI (IPv6, private IP) must still be exported.
+	assertContainsAll(t, lines[1], "csv row", []string{"42", "web-server", "2001:db8::1", "10.0.0.2"})
+}
+
+func TestListCommand_LabelColumns(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(func() { config.ResetPath() })
+
+	mock := &mockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{ID: "42", Name: "web-server", Status: "running", Provider: "mock", Labels: map[string]string{"env": "prod", "role": "web"}},
+			{ID: "99", Name: "db-server", Status: "stopped", Provider: "mock", Labels: map[string]string{"env": "staging"}},
+		},
+	}
+
+	registerMockProvider(t, "mock", mock)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "-o", "table", "--label-columns", "env,role"})
+	cmd.Execute()
+
+	lines := strings.Split(strings.TrimSpace(outBuf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %d:\n%s", len(lines), outBuf.String())
+	}
+	if fields := strings.Fields(lines[0]); fields[2] != "ENV" || fields[3] != "ROLE" || fields[4] != "STATUS" {
+		t.Errorf("expected label columns after NAME, got header %q", lines[0])
+	}
+	assertContainsAll(t, lines[2], "first row", []string{"prod", "web"})
+	assertContainsAll(t, lines[3], "second row", []string{"staging"})
+}
+
+func TestListCommand_LabelColumnsFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config.SetPath(path)
+	t.Cleanup(func() { config.ResetPath() })
+
+	cfg := &config.Config{LabelColumns: []string{"env"}}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("failed to save config: %v", err)
+	}
+
+	mock := &mockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{ID: "42", Name: "web-server", Status: "running", Provider: "mock", Labels: map[string]string{"env": "prod"}},
+		},
+	}
+
+	registerMockProvider(t, "mock", mock)
+
+	stdout, _ := execList(t, "mock")
+
+	assertContainsAll(t, stdout, "stdout", []string{"ENV", "prod"})
+}
+
+func TestListCommand_FormatTemplate(t *testing.T) {
+	mock := &mockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{ID: "1", Name: "web", PublicIPv4: "1.2.3.4", Labels: map[string]string{"env": "prod"}},
+			{ID: "2", Name: "db", PublicIPv4: "5.6.7.8"},
+		},
+	}
+	registerMockProvider(t, "mock", mock)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "--format", `{{.Name}}\t{{.PublicIPv4}}\t{{index .Labels "env"}}`})
+	cmd.Execute()
+
+	if errBuf.Len() != 0 {
+		t.Fatalf("unexpected stderr: %s", errBuf.String())
+	}
+	want := "web\t1.2.3.4\tprod\ndb\t5.6.7.8\t\n"
+	if outBuf.String() != want {
+		t.Errorf("stdout = %q, want %q", outBuf.String(), want)
+	}
+}
+
+func TestListCommand_FormatWithOutputRejected(t *testing.T) {
+	registerMockProvider(t, "mock", &mockProvider{displayName: "Mock"})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "-o", "json", "--format", "{{.Name}}"})
+	cmd.Execute()
+
+	if !strings.Contains(errBuf.String(), "--format cannot be combined with --output") {
+		t.Errorf("expected a conflict error, got stderr:\n%s", errBuf.String())
+	}
+	if outBuf.Len() != 0 {
+		t.Errorf("expected no stdout, got:\n%s", outBuf.String())
+	}
+}
+
+func TestListCommand_Selector(t *testing.T) {
+	mock := &mockProvider{
+		displayName: "Mock",
+		servers: []domain.Server{
+			{ID: "42", Name: "web-server", Status: "running", Provider: "mock", Labels: map[string]string{"env": "prod", "role": "web"}},
+			{ID: "99", Name: "db-server", Status: "stopped", Provider: "mock", Labels: map[string]string{"env": "prod", "role": "db"}},
+		},
+	}
+	registerMockProvider(t, "mock", mock)
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "--selector", "env=prod,role=web"})
+	cmd.Execute()
+
+	if errBuf.Len() > 0 {
+		t.Fatalf("unexpected stderr: %s", errBuf.String())
+	}
+	if !strings.Contains(outBuf.String(), "web-server") {
+		t.Errorf("expected web-server in output:\n%s", outBuf.String())
+	}
+	if strings.Contains(outBuf.String(), "db-server") {
+		t.Errorf("expected db-server to be filtered out:\n%s", outBuf.String())
+	}
+}
+
+func TestListCommand_InvalidSelector(t *testing.T) {
+	registerMockProvider(t, "mock", &mockProvider{displayName: "Mock"})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"list", "--provider", "mock", "--selector", "=prod"})
+	cmd.Execute()
+
+	if !strings.Contains(errBuf.String(), "missing key") {
+		t.Errorf("expected missing key error, got %q", errBuf.String())
+	}
+}
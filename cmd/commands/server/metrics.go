@@ -16,10 +16,12 @@ func MetricsCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "metrics",
 		Short: "Show server metrics",
-		Long: `Display CPU, disk IOPS, and network bandwidth metrics for a server.
+		Long: `Display CPU, disk IOPS, and network bandwidth metrics for a server,
+plus memory usage where the provider collects it (Hetzner does not).
 
 Fetches metrics from the last hour and prints a summary with current,
-minimum, maximum, and average values for each time series.
+minimum, maximum, and average values for each time series, including
+every disk and network interface the server has.
 
 Examples:
   # Table output (default)
@@ -62,6 +64,7 @@ func runMetrics(cmd *cobra.Command, args []string) {
 		domain.MetricCPU,
 		domain.MetricDisk,
 		domain.MetricNetwork,
+		domain.MetricMemory,
 	}, start, end)
 	if err != nil {
 		fmt.Fprintf(cmd.ErrOrStderr(), "Error fetching metrics: %v\n", err)
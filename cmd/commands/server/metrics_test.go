@@ -214,7 +214,7 @@ func TestMetricsCommand_RequestsAllMetricTypes(t *testing.T) {
 
 	_, _ = execMetrics(t, "mock", "--id", "42")
 
-	expectedTypes := []domain.MetricType{domain.MetricCPU, domain.MetricDisk, domain.MetricNetwork}
+	expectedTypes := []domain.MetricType{domain.MetricCPU, domain.MetricDisk, domain.MetricNetwork, domain.MetricMemory}
 	if len(mock.gotTypes) != len(expectedTypes) {
 		t.Errorf("expected %d metric types, got %d", len(expectedTypes), len(mock.gotTypes))
 	}
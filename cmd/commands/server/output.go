@@ -1,30 +1,142 @@
 package server
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/platform/format"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 
 	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v2"
 )
 
-// printServerJSON encodes a server as indented JSON to the command's stdout.
-func printServerJSON(cmd *cobra.Command, server *domain.Server) {
-	enc := json.NewEncoder(cmd.OutOrStdout())
-	enc.SetIndent("", "  ")
-	enc.Encode(server)
+// isStructured reports whether output is a machine-readable format
+// handled by printStructured.
+func isStructured(output string) bool {
+	return output == "json" || output == "yaml"
 }
 
-// printServersJSON encodes a slice of servers as indented JSON to stdout.
-func printServersJSON(cmd *cobra.Command, servers []domain.Server) {
-	enc := json.NewEncoder(cmd.OutOrStdout())
-	enc.SetIndent("", "  ")
-	enc.Encode(servers)
+// printStructured encodes v to the command's stdout as indented JSON or,
+// when format is "yaml", as YAML. YAML uses the JSON field names and
+// order, so scripts see the same fields in either format.
+func printStructured(cmd *cobra.Command, format string, v any) error {
+	if format != "yaml" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	ordered, err := decodeOrdered(dec)
+	if err != nil {
+		return err
+	}
+	out, err := yaml.Marshal(ordered)
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(out)
+	return err
+}
+
+// formatUsage is the help text of the --format flag.
+const formatUsage = "Format each server with a Go template, e.g. '{{.Name}}\\t{{.PublicIPv4}}'"
+
+// checkFormatFlags rejects --format combined with an explicit --output,
+// since the template replaces the output format.
+func checkFormatFlags(cmd *cobra.Command) error {
+	if cmd.Flags().Changed("format") && cmd.Flags().Changed("output") {
+		return fmt.Errorf("--format cannot be combined with --output")
+	}
+	return nil
+}
+
+// printFormatted renders items with the --format template, if one was
+// given, and reports whether it did. Template errors go to stderr.
+func printFormatted[T any](cmd *cobra.Command, items []T) bool {
+	text, _ := cmd.Flags().GetString("format")
+	if text == "" {
+		return false
+	}
+	tmpl, err := format.Parse(text)
+	if err == nil {
+		err = format.Lines(cmd.OutOrStdout(), tmpl, items)
+	}
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	}
+	return true
+}
+
+// printServerState fetches a server after an action completed and prints
+// it in a structured output format, so scripts see its new status.
+func printServerState(cmd *cobra.Command, provider domain.Provider, serverID, format string) {
+	server, err := provider.GetServer(context.Background(), serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error fetching server: %v\n", err)
+		return
+	}
+	printStructured(cmd, format, server)
+}
+
+// decodeOrdered decodes the next JSON value from dec, keeping object keys
+// in their encoded order.
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			obj := yaml.MapSlice{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				obj = append(obj, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err := dec.Token()
+			return obj, err
+		}
+		list := []any{}
+		for dec.More() {
+			value, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err := dec.Token()
+		return list, err
+	case json.Number:
+		if n, err := tok.Int64(); err == nil {
+			return n, nil
+		}
+		return tok.Float64()
+	}
+	return tok, nil
 }
 
 // printServerDetail prints a vertical key-value table of all server fields.
@@ -49,6 +161,9 @@ func printServerDetail(cmd *cobra.Command, server *domain.Server) {
 	if server.PublicIPv6 != "" {
 		fmt.Fprintf(w, "  IPv6:\t%s\n", server.PublicIPv6)
 	}
+	if server.PublicIPv6Network != "" {
+		fmt.Fprintf(w, "  IPv6 Subnet:\t%s\n", server.PublicIPv6Network)
+	}
 	if server.PrivateIPv4 != "" {
 		fmt.Fprintf(w, "  Private IP:\t%s\n", server.PrivateIPv4)
 	}
@@ -60,6 +175,56 @@ func printServerDetail(cmd *cobra.Command, server *domain.Server) {
 	w.Flush()
 }
 
+// printPreviousIPs prints public IPs the server used to have.
+func printPreviousIPs(cmd *cobra.Command, records []iphistory.IPRecord) {
+	if len(records) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "  Previous IPs:")
+	for _, rec := range records {
+		fmt.Fprintf(w, "    %s\tlast seen %s\n", rec.IP, rec.LastSeen.UTC().Format("2006-01-02 15:04:05 UTC"))
+	}
+	w.Flush()
+}
+
+// printStaleDNS warns that the server's public IP has changed, naming the
+// records at the DNS provider that still point at the previous IPs. When
+// the provider cannot be asked, the warning stays generic.
+func printStaleDNS(cmd *cobra.Command, serverProvider string, records []iphistory.IPRecord) {
+	if len(records) == 0 {
+		return
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out)
+
+	ips := make([]string, len(records))
+	for i, rec := range records {
+		ips[i] = rec.IP
+	}
+	var stale []attach.Pointer
+	dns, err := attachDNSProvider(cmd, serverProvider)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+		stale, err = attach.Pointing(ctx, dns, ips)
+		cancel()
+	}
+	switch {
+	case err != nil:
+		fmt.Fprintln(out, "  Warning: this server's public IP has changed. Check that DNS records no longer point at the addresses above.")
+	case len(stale) == 0:
+		fmt.Fprintf(out, "  This server's public IP has changed; no records at %s point at the addresses above.\n", dns.GetDisplayName())
+	default:
+		fmt.Fprintf(out, "  Warning: this server's public IP has changed. %d record(s) at %s still point at the addresses above:\n", len(stale), dns.GetDisplayName())
+		for _, p := range stale {
+			fmt.Fprintf(out, "    %s\n", p)
+		}
+	}
+}
+
 // printMetricsJSON encodes server metrics as indented JSON to stdout.
 func printMetricsJSON(cmd *cobra.Command, metrics *domain.ServerMetrics) {
 	enc := json.NewEncoder(cmd.OutOrStdout())
@@ -77,6 +242,7 @@ func printMetricsSummary(cmd *cobra.Command, metrics *domain.ServerMetrics) {
 	// Well-known keys printed first in a stable order.
 	orderedKeys := []string{
 		"cpu",
+		"memory",
 		"disk.0.iops.read",
 		"disk.0.iops.write",
 		"network.0.bandwidth.in",
@@ -129,7 +295,7 @@ func printMetricsSummary(cmd *cobra.Command, metrics *domain.ServerMetrics) {
 // metricSuffix returns the unit suffix for a metric key.
 func metricSuffix(key string) string {
 	switch {
-	case key == "cpu":
+	case key == "cpu", key == "memory":
 		return "%"
 	case strings.HasPrefix(key, "network."):
 		return "B/s"
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/tui"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// pickFields maps the values accepted by --field to the server field they
+// print. Labels are handled separately as "label:<key>".
+var pickFields = map[string]func(domain.Server) string{
+	"id":          func(s domain.Server) string { return s.ID },
+	"name":        func(s domain.Server) string { return s.Name },
+	"status":      func(s domain.Server) string { return s.Status },
+	"ipv4":        func(s domain.Server) string { return s.PublicIPv4 },
+	"ipv6":        func(s domain.Server) string { return s.PublicIPv6 },
+	"private-ip":  func(s domain.Server) string { return s.PrivateIPv4 },
+	"region":      func(s domain.Server) string { return s.Region },
+	"server-type": func(s domain.Server) string { return s.ServerType },
+	"image":       func(s domain.Server) string { return s.Image },
+}
+
+// errNoServerPicked is returned when the picker is closed without a
+// selection, so shell compositions fail instead of using an empty value.
+var errNoServerPicked = errors.New("no server selected")
+
+// PickCommand returns the "server pick" command.
+func PickCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pick",
+		Short: "Choose a server interactively and print its ID",
+		Long: `Open the server list, and print the chosen server's ID (or another
+field) to stdout when enter is pressed.
+
+The list is drawn on stderr, so the command can be used inside shell
+substitutions. It exits with an error if the list is closed without a
+selection, or if the chosen field is empty.
+
+Fields: id, name, status, ipv4, ipv6, private-ip, region, server-type,
+image, or label:<key> for a label value.
+
+Examples:
+  ssh root@$(vpsm server pick --field ipv4)
+  vpsm server stop --id "$(vpsm server pick)"
+  vpsm server pick --field label:env`,
+		RunE:         runPick,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().String("field", "id", "Server field to print: "+strings.Join(pickFieldNames(), ", ")+", or label:<key>")
+
+	return cmd
+}
+
+func runPick(cmd *cobra.Command, args []string) error {
+	field, _ := cmd.Flags().GetString("field")
+	if _, err := pickField(domain.Server{}, field); err != nil {
+		return err
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return fmt.Errorf("server pick needs an interactive terminal")
+	}
+
+	providerName := cmd.Flag("provider").Value.String()
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		return err
+	}
+
+	server, err := tui.RunServerPicker(provider, providerName)
+	if err != nil {
+		return err
+	}
+	if server == nil {
+		return errNoServerPicked
+	}
+
+	value, err := pickField(*server, field)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return fmt.Errorf("server %q has no %s", server.Name, field)
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+// pickField returns the value of the named field on server.
+func pickField(server domain.Server, field string) (string, error) {
+	if key, ok := strings.CutPrefix(field, "label:"); ok {
+		if key == "" {
+			return "", fmt.Errorf("invalid field %q: expected label:<key>", field)
+		}
+		return server.Labels[key], nil
+	}
+
+	get, ok := pickFields[field]
+	if !ok {
+		return "", fmt.Errorf("unknown field %q: expected one of %s, or label:<key>", field, strings.Join(pickFieldNames(), ", "))
+	}
+	return get(server), nil
+}
+
+// pickFieldNames lists the --field values in a stable order.
+func pickFieldNames() []string {
+	return slices.Sorted(maps.Keys(pickFields))
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func TestPickField(t *testing.T) {
+	server := domain.Server{
+		ID:         "42",
+		Name:       "web-1",
+		PublicIPv4: "1.2.3.4",
+		Labels:     map[string]string{"env": "prod"},
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"id", "42"},
+		{"name", "web-1"},
+		{"ipv4", "1.2.3.4"},
+		{"ipv6", ""},
+		{"label:env", "prod"},
+		{"label:missing", ""},
+	}
+	for _, tt := range tests {
+		got, err := pickField(server, tt.field)
+		if err != nil {
+			t.Errorf("pickField(%q): unexpected error %v", tt.field, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("pickField(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+
+	for _, field := range []string{"ip", "label:"} {
+		if _, err := pickField(server, field); err == nil {
+			t.Errorf("pickField(%q): expected an error", field)
+		}
+	}
+}
+
+func TestPickCommand_RejectsUnknownField(t *testing.T) {
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"pick", "--provider", "mock", "--field", "bogus"})
+
+	if err := cmd.Execute(); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if !strings.Contains(errBuf.String(), `unknown field "bogus"`) {
+		t.Errorf("expected unknown field error on stderr, got:\n%s", errBuf.String())
+	}
+	if outBuf.Len() != 0 {
+		t.Errorf("expected nothing on stdout, got %q", outBuf.String())
+	}
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/ping"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// PingCommand returns a cobra.Command that checks whether a server's public
+// addresses answer and how quickly.
+func PingCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ping",
+		Short: "Check that a server's public IPs are reachable",
+		Long: `Send ICMP echo requests to a server's public IPv4 and IPv6 addresses and
+report round-trip latency and packet loss.
+
+When ICMP sockets are not permitted (vpsm is running unprivileged), each
+address is probed with TCP connects instead, using the first of --port
+that answers. A refused connection still counts as an answer.
+
+Examples:
+  vpsm server ping --id 12345
+  vpsm server ping --id 12345 --count 10
+  vpsm server ping --id 12345 --port 8080 -o json`,
+		Run: runPing,
+	}
+
+	defaults := ping.DefaultOptions()
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().IntP("count", "c", defaults.Count, "Number of probes per address")
+	cmd.Flags().IntSlice("port", defaults.Ports, "TCP ports to try when ICMP is unavailable")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// pingResult is the JSON form of a ping.Result.
+type pingResult struct {
+	Address  string  `json:"address"`
+	Method   string  `json:"method"`
+	Sent     int     `json:"sent"`
+	Received int     `json:"received"`
+	Loss     float64 `json:"loss_percent"`
+	MinMs    float64 `json:"min_ms,omitempty"`
+	AvgMs    float64 `json:"avg_ms,omitempty"`
+	MaxMs    float64 `json:"max_ms,omitempty"`
+	Error    string  `json:"error,omitempty"`
+}
+
+func runPing(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	ctx := context.Background()
+	serverID, _ := cmd.Flags().GetString("id")
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	opts := ping.DefaultOptions()
+	opts.Count, _ = cmd.Flags().GetInt("count")
+	opts.Ports, _ = cmd.Flags().GetIntSlice("port")
+	if opts.Count < 1 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: --count must be at least 1")
+		return
+	}
+
+	results, err := ping.Server(ctx, *server, opts)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		out := make([]pingResult, 0, len(results))
+		for _, r := range results {
+			out = append(out, pingResult{
+				Address:  r.Address,
+				Method:   r.MethodLabel(),
+				Sent:     r.Sent,
+				Received: r.Received,
+				Loss:     r.Loss(),
+				MinMs:    float64(r.Min.Microseconds()) / 1000,
+				AvgMs:    float64(r.Avg.Microseconds()) / 1000,
+				MaxMs:    float64(r.Max.Microseconds()) / 1000,
+				Error:    r.Err,
+			})
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(out)
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ADDRESS\tMETHOD\tRESULT")
+	for _, r := range results {
+		method := r.MethodLabel()
+		if method == "" {
+			method = "-"
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", r.Address, method, r.Summary())
+	}
+	w.Flush()
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/ping"
+
+	"golang.org/x/net/icmp"
+)
+
+func execPing(t *testing.T, server *domain.Server, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	registerShowMockProvider(t, "mock", &showMockProvider{getServer: server})
+
+	// Force the TCP fallback so the tests do not depend on ICMP permissions.
+	orig := ping.ListenICMP
+	ping.ListenICMP = func(string, string) (*icmp.PacketConn, error) {
+		return nil, errors.New("operation not permitted")
+	}
+	t.Cleanup(func() { ping.ListenICMP = orig })
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"ping", "--provider", "mock", "--id", "42", "--count", "2"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+// listenLoopback accepts and closes TCP connections on a loopback port.
+func listenLoopback(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return strconv.Itoa(ln.Addr().(*net.TCPAddr).Port)
+}
+
+func TestPingCommand_TCPFallback(t *testing.T) {
+	port := listenLoopback(t)
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv4: "127.0.0.1"}
+
+	stdout, stderr := execPing(t, server, "--port", port)
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	assertContainsAll(t, stdout, "stdout", []string{"127.0.0.1", "tcp/" + port, "2/2 answered", "0% loss"})
+}
+
+func TestPingCommand_JSON(t *testing.T) {
+	port := listenLoopback(t)
+	server := &domain.Server{ID: "42", Name: "web", PublicIPv4: "127.0.0.1"}
+
+	stdout, _ := execPing(t, server, "--port", port, "-o", "json")
+
+	var got []struct {
+		Address  string  `json:"address"`
+		Method   string  `json:"method"`
+		Received int     `json:"received"`
+		Loss     float64 `json:"loss_percent"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got) != 1 || got[0].Method != "tcp/"+port || got[0].Received != 2 || got[0].Loss != 0 {
+		t.Errorf("unexpected output: %+v", got)
+	}
+}
+
+func TestPingCommand_NoPublicIP(t *testing.T) {
+	server := &domain.Server{ID: "42", Name: "web"}
+
+	_, stderr := execPing(t, server)
+
+	if !strings.Contains(stderr, "no public IP") {
+		t.Errorf("expected no public IP error, got: %s", stderr)
+	}
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// RDNSCommand returns a cobra.Command that manages the reverse DNS (PTR)
+// records of a server's public addresses.
+func RDNSCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rdns",
+		Short: "Show and set reverse DNS records of a server's addresses",
+		Long: `Show and set the reverse DNS (PTR) records of a server's public
+addresses. Mail servers in particular need a PTR record that matches the
+name they introduce themselves with.
+
+Examples:
+  vpsm server rdns list --id 12345
+  vpsm server rdns set --id 12345 --ip 203.0.113.7 --hostname mail.example.com`,
+	}
+
+	cmd.AddCommand(rdnsListCommand())
+	cmd.AddCommand(rdnsSetCommand())
+
+	return cmd
+}
+
+func rdnsListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the reverse DNS records of a server's addresses",
+		Long: `List the reverse DNS records of a server's public IPv4 address and of
+the IPv6 addresses in its subnet that have one. The subnet's ::1 address is
+always listed, so it can be given a record.
+
+Examples:
+  vpsm server rdns list --id 12345
+  vpsm server rdns list --id 12345 -o json`,
+		Run: runRDNSList,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+func rdnsSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Set the reverse DNS record of one of a server's addresses",
+		Long: `Set the reverse DNS record of one of a server's public addresses, or
+of any address in its IPv6 subnet. Pass --reset instead of --hostname to
+restore the provider's default record.
+
+The forward record (A or AAAA) of the hostname should point back at the
+address; many mail servers check both.
+
+Examples:
+  vpsm server rdns set --id 12345 --ip 203.0.113.7 --hostname mail.example.com
+  vpsm server rdns set --id 12345 --ip 2001:db8::1 --hostname mail.example.com
+  vpsm server rdns set --id 12345 --ip 203.0.113.7 --reset`,
+		Run: runRDNSSet,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.Flags().String("ip", "", "Address whose record to set (required)")
+	cmd.Flags().String("hostname", "", "Hostname the address should resolve to")
+	cmd.Flags().Bool("reset", false, "Restore the provider's default record")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("ip")
+	cmd.MarkFlagsMutuallyExclusive("hostname", "reset")
+	cmd.MarkFlagsOneRequired("hostname", "reset")
+
+	return cmd
+}
+
+// reverseDNSProvider returns the provider selected by --provider, or
+// prints an error and returns nil when it has no reverse DNS.
+func reverseDNSProvider(cmd *cobra.Command) domain.ReverseDNSProvider {
+	provider, err := providers.Get(cmd.Flag("provider").Value.String(), auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return nil
+	}
+	rp, ok := provider.(domain.ReverseDNSProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support reverse DNS\n", provider.GetDisplayName())
+		return nil
+	}
+	return rp
+}
+
+func runRDNSList(cmd *cobra.Command, args []string) {
+	provider := reverseDNSProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	serverID, _ := cmd.Flags().GetString("id")
+	records, err := provider.GetReverseDNS(context.Background(), serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		if records == nil {
+			records = []domain.ReverseDNS{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(records)
+		return
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "Server has no public addresses.")
+		return
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "IP\tHOSTNAME")
+	fmt.Fprintln(w, "--\t--------")
+	for _, r := range records {
+		hostname := r.Hostname
+		if hostname == "" {
+			hostname = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", r.IP, hostname)
+	}
+	w.Flush()
+}
+
+func runRDNSSet(cmd *cobra.Command, args []string) {
+	serverID, _ := cmd.Flags().GetString("id")
+	ip, _ := cmd.Flags().GetString("ip")
+	hostname, _ := cmd.Flags().GetString("hostname")
+
+	if _, err := netip.ParseAddr(ip); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: invalid IP address %q\n", ip)
+		return
+	}
+	if hostname != "" {
+		if err := dnsdomain.ValidateHostname(hostname); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+	}
+
+	provider := reverseDNSProvider(cmd)
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if server == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %s not found\n", serverID)
+		return
+	}
+
+	if hostname == "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Resetting reverse DNS of %s...\n", ip)
+	} else {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Pointing %s at %s...\n", ip, hostname)
+	}
+	actionStatus, err := provider.SetReverseDNS(ctx, serverID, ip, hostname)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error setting reverse DNS: %v\n", err)
+		return
+	}
+
+	// Open the action repository. If unavailable, repo is set to nil
+	// and the service degrades gracefully (no persistence, but operation continues).
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, cmd.Flag("provider").Value.String(), repo)
+	defer svc.Close()
+
+	// The server keeps its power state, so its current status is the target.
+	record := svc.TrackAction(server.ID, server.Name, actionStatus, "change_dns_ptr", server.Status)
+	if err := svc.WaitForAction(ctx, actionStatus, server.ID, server.Status, cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error waiting for reverse DNS change: %v\n", err)
+		return
+	}
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+
+	if hostname == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Reverse DNS of %s reset.\n", ip)
+		return
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Reverse DNS of %s set to %s.\n", ip, hostname)
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// rdnsMockProvider extends stopMockProvider with domain.ReverseDNSProvider.
+type rdnsMockProvider struct {
+	stopMockProvider
+	records     []domain.ReverseDNS
+	setIP       string
+	setHostname string
+}
+
+func (m *rdnsMockProvider) GetReverseDNS(context.Context, string) ([]domain.ReverseDNS, error) {
+	return m.records, nil
+}
+
+func (m *rdnsMockProvider) SetReverseDNS(_ context.Context, _, ip, hostname string) (*domain.ActionStatus, error) {
+	m.setIP, m.setHostname = ip, hostname
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func execRDNS(t *testing.T, mock domain.Provider, args ...string) (stdout, stderr string) {
+	t.Helper()
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(auth.Store) (domain.Provider, error) { return mock, nil })
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"rdns", "--provider", "mock"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestRDNSList_Table(t *testing.T) {
+	mock := &rdnsMockProvider{records: []domain.ReverseDNS{
+		{IP: "203.0.113.7", Hostname: "mail.example.com"},
+		{IP: "2001:db8::1"},
+	}}
+
+	stdout, stderr := execRDNS(t, mock, "list", "--id", "42")
+
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	for _, want := range []string{"203.0.113.7", "mail.example.com", "2001:db8::1"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRDNSList_JSON(t *testing.T) {
+	want := []domain.ReverseDNS{{IP: "203.0.113.7", Hostname: "mail.example.com"}}
+	stdout, _ := execRDNS(t, &rdnsMockProvider{records: want}, "list", "--id", "42", "-o", "json")
+
+	var got []domain.ReverseDNS
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRDNSSet(t *testing.T) {
+	withFastPolling(t)
+
+	tests := []struct {
+		name         string
+		args         []string
+		wantHostname string
+		wantOut      string
+	}{
+		{
+			name:         "hostname",
+			args:         []string{"--hostname", "mail.example.com"},
+			wantHostname: "mail.example.com",
+			wantOut:      "Reverse DNS of 203.0.113.7 set to mail.example.com.",
+		},
+		{
+			name:    "reset",
+			args:    []string{"--reset"},
+			wantOut: "Reverse DNS of 203.0.113.7 reset.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &rdnsMockProvider{stopMockProvider: stopMockProvider{
+				getServer: &domain.Server{ID: "42", Name: "mail", Status: "running"},
+			}}
+
+			args := append([]string{"set", "--id", "42", "--ip", "203.0.113.7"}, tt.args...)
+			stdout, stderr := execRDNS(t, mock, args...)
+
+			if mock.setIP != "203.0.113.7" || mock.setHostname != tt.wantHostname {
+				t.Errorf("set (%q, %q), want (203.0.113.7, %q)", mock.setIP, mock.setHostname, tt.wantHostname)
+			}
+			if !strings.Contains(stdout, tt.wantOut) {
+				t.Errorf("expected %q on stdout, got:\n%s\nstderr:\n%s", tt.wantOut, stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestRDNSSet_RejectsInvalidHostname(t *testing.T) {
+	mock := &rdnsMockProvider{}
+
+	_, stderr := execRDNS(t, mock, "set", "--id", "42", "--ip", "203.0.113.7", "--hostname", "not a host")
+
+	if mock.setIP != "" {
+		t.Error("expected no reverse DNS change")
+	}
+	if !strings.Contains(stderr, "not a valid hostname") {
+		t.Errorf("expected a hostname error, got:\n%s", stderr)
+	}
+}
+
+func TestRDNS_UnsupportedProvider(t *testing.T) {
+	_, stderr := execRDNS(t, &stopMockProvider{displayName: "Mock"}, "list", "--id", "42")
+
+	if !strings.Contains(stderr, "Mock does not support reverse DNS") {
+		t.Errorf("expected unsupported error, got:\n%s", stderr)
+	}
+}
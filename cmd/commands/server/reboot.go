@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// RebootCommand returns a cobra.Command that reboots a running server.
+func RebootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reboot",
+		Short: "Reboot a running server",
+		Long: `Reboot a running server instance.
+
+By default the operating system is asked to restart (ACPI reboot). Pass
+--hard to reset the server instead, which power-cycles it without warning
+and may lose unsaved data; use it when the server no longer responds.
+
+The command waits for the operation to complete by polling the provider,
+and the action is persisted locally so it can be resumed with
+"vpsm server actions --resume".
+
+Examples:
+  vpsm server reboot --provider hetzner --id 12345
+  vpsm server reboot --id 12345 --hard`,
+		Run: runReboot,
+	}
+
+	cmd.Flags().String("id", "", "Server ID to reboot (required)")
+	cmd.Flags().Bool("hard", false, "Hard-reset the server instead of rebooting it gracefully")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func runReboot(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	rebooter, ok := provider.(domain.RebootProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support rebooting servers\n", provider.GetDisplayName())
+		return
+	}
+
+	serverID, _ := cmd.Flags().GetString("id")
+	hard, _ := cmd.Flags().GetBool("hard")
+
+	unlock, err := lockServer(provider, providerName, serverID, "reboot_server")
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer unlock()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	var actionStatus *domain.ActionStatus
+	if hard {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Resetting server %s...\n", serverID)
+		actionStatus, err = rebooter.ResetServer(ctx, serverID)
+	} else {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Rebooting server %s...\n", serverID)
+		actionStatus, err = rebooter.RebootServer(ctx, serverID)
+	}
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error rebooting server: %v\n", err)
+		return
+	}
+
+	// Open the action repository. If unavailable, repo is set to nil
+	// and the service degrades gracefully (no persistence, but operation continues).
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	defer svc.Close()
+
+	record := svc.TrackAction(serverID, "", actionStatus, "reboot_server", "running")
+
+	if err := svc.WaitForAction(ctx, actionStatus, serverID, "running", cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error waiting for server to reboot: %v\n", err)
+		return
+	}
+
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	fmt.Fprintf(cmd.OutOrStdout(), "Server %s rebooted successfully.\n", serverID)
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// rebootMockProvider extends stopMockProvider with domain.RebootProvider.
+type rebootMockProvider struct {
+	stopMockProvider
+	rebootedID string
+	resetID    string
+}
+
+func (m *rebootMockProvider) RebootServer(_ context.Context, id string) (*domain.ActionStatus, error) {
+	m.rebootedID = id
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func (m *rebootMockProvider) ResetServer(_ context.Context, id string) (*domain.ActionStatus, error) {
+	m.resetID = id
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+// registerRebootMockProvider resets the global registry and registers a
+// reboot mock.
+func registerRebootMockProvider(t *testing.T, name string, mock *rebootMockProvider) {
+	t.Helper()
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register(name, func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+}
+
+// execReboot runs "reboot --provider <provider> [flags...]" and returns
+// what was written to stdout and stderr.
+func execReboot(t *testing.T, providerName string, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"reboot", "--provider", providerName}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestRebootCommand_Graceful(t *testing.T) {
+	withFastPolling(t)
+
+	mock := &rebootMockProvider{stopMockProvider: stopMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "running"},
+	}}
+	registerRebootMockProvider(t, "mock", mock)
+
+	stdout, stderr := execReboot(t, "mock", "--id", "42")
+
+	if mock.rebootedID != "42" || mock.resetID != "" {
+		t.Errorf("expected a graceful reboot of 42, got reboot=%q reset=%q", mock.rebootedID, mock.resetID)
+	}
+	if !strings.Contains(stderr, "Rebooting server 42") {
+		t.Errorf("expected progress message on stderr, got:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, "rebooted successfully") {
+		t.Errorf("expected success message on stdout, got:\n%s", stdout)
+	}
+}
+
+func TestRebootCommand_Hard(t *testing.T) {
+	withFastPolling(t)
+
+	mock := &rebootMockProvider{stopMockProvider: stopMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "running"},
+	}}
+	registerRebootMockProvider(t, "mock", mock)
+
+	_, stderr := execReboot(t, "mock", "--id", "42", "--hard")
+
+	if mock.resetID != "42" || mock.rebootedID != "" {
+		t.Errorf("expected a reset of 42, got reboot=%q reset=%q", mock.rebootedID, mock.resetID)
+	}
+	if !strings.Contains(stderr, "Resetting server 42") {
+		t.Errorf("expected reset message on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestRebootCommand_Unsupported(t *testing.T) {
+	registerStopMockProvider(t, "mock", &stopMockProvider{displayName: "Mock"})
+
+	_, stderr := execReboot(t, "mock", "--id", "42")
+
+	if !strings.Contains(stderr, "does not support rebooting") {
+		t.Errorf("expected unsupported error on stderr, got:\n%s", stderr)
+	}
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/spf13/cobra"
+)
+
+// ResizeCommand returns a cobra.Command that changes a server's type.
+func ResizeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resize",
+		Short: "Change a stopped server's type",
+		Long: `Change the server type (CPU and memory) of a stopped server.
+
+The server must be powered off first; stop it with "vpsm server stop".
+By default the disk keeps its size so the server can be resized back down
+later. Pass --upgrade-disk to grow the disk to the new type's size, which
+cannot be undone.
+
+The command waits for the operation to complete by polling the provider,
+and the action is persisted locally so it can be resumed with
+"vpsm server actions --resume".
+
+Examples:
+  vpsm server resize --id 12345 --type cpx21
+  vpsm server resize --id 12345 --type cpx31 --upgrade-disk`,
+		Run: runResize,
+	}
+
+	cmd.Flags().String("id", "", "Server ID to resize (required)")
+	cmd.Flags().String("type", "", "Server type to resize to (required)")
+	cmd.Flags().Bool("upgrade-disk", false, "Grow the disk to the new type's size (cannot be undone)")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagRequired("type")
+
+	return cmd
+}
+
+func runResize(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	resizer, ok := provider.(domain.ResizeProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support resizing servers\n", provider.GetDisplayName())
+		return
+	}
+
+	serverID, _ := cmd.Flags().GetString("id")
+	serverType, _ := cmd.Flags().GetString("type")
+	upgradeDisk, _ := cmd.Flags().GetBool("upgrade-disk")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	server, err := provider.GetServer(ctx, serverID)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	if server == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %s not found\n", serverID)
+		return
+	}
+	if !server.IsStopped() {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %q is %s; stop it first with 'vpsm server stop --id %s'\n", server.Name, server.Status, server.ID)
+		return
+	}
+	if server.ServerType == serverType {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: server %q is already of type %s\n", server.Name, serverType)
+		return
+	}
+
+	// Without a disk upgrade the new type must fit the current disk;
+	// check against the catalog so the user sees the options up front.
+	if catalog, ok := provider.(domain.CatalogProvider); ok && !upgradeDisk {
+		if types, err := catalog.ListServerTypes(ctx); err == nil {
+			targets := domain.ResizeTargets(server.ServerType, server.Region, types)
+			if !containsServerType(targets, serverType) {
+				names := make([]string, len(targets))
+				for i, t := range targets {
+					names[i] = t.Name
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s cannot be used for server %q without --upgrade-disk; compatible types: %s\n",
+					serverType, server.Name, strings.Join(names, ", "))
+				return
+			}
+		}
+	}
+
+	unlock, err := lockServer(provider, providerName, serverID, "resize_server")
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer unlock()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Resizing server %q to %s...\n", server.Name, serverType)
+
+	actionStatus, err := resizer.ResizeServer(ctx, serverID, serverType, upgradeDisk)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error resizing server: %v\n", err)
+		return
+	}
+
+	// Open the action repository. If unavailable, repo is set to nil
+	// and the service degrades gracefully (no persistence, but operation continues).
+	repo, err := actionstore.Open()
+	if err != nil {
+		repo = nil
+	}
+	svc := action.NewService(provider, providerName, repo)
+	defer svc.Close()
+
+	record := svc.TrackAction(serverID, server.Name, actionStatus, "resize_server", "off")
+
+	if err := svc.WaitForAction(ctx, actionStatus, serverID, "off", cmd.ErrOrStderr()); err != nil {
+		svc.FinalizeAction(record, domain.ActionStatusError, err.Error())
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error waiting for resize: %v\n", err)
+		return
+	}
+
+	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	fmt.Fprintf(cmd.OutOrStdout(), "Server %s resized to %s successfully.\n", serverID, serverType)
+}
+
+func containsServerType(types []domain.ServerTypeSpec, name string) bool {
+	for _, t := range types {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// resizeMockProvider extends stopMockProvider with domain.ResizeProvider.
+type resizeMockProvider struct {
+	stopMockProvider
+	resizedID   string
+	resizedTo   string
+	upgradeDisk bool
+}
+
+func (m *resizeMockProvider) ResizeServer(_ context.Context, id string, serverType string, upgradeDisk bool) (*domain.ActionStatus, error) {
+	m.resizedID = id
+	m.resizedTo = serverType
+	m.upgradeDisk = upgradeDisk
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+// execResize registers mock and runs "resize --provider mock [flags...]",
+// returning what was written to stdout and stderr.
+func execResize(t *testing.T, mock *resizeMockProvider, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	providers.Reset()
+	t.Cleanup(func() { providers.Reset() })
+	providers.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		return mock, nil
+	})
+
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"resize", "--provider", "mock"}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestResizeCommand_HappyPath(t *testing.T) {
+	withFastPolling(t)
+
+	mock := &resizeMockProvider{stopMockProvider: stopMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "off", ServerType: "cx22"},
+	}}
+
+	stdout, stderr := execResize(t, mock, "--id", "42", "--type", "cx32")
+
+	if mock.resizedID != "42" || mock.resizedTo != "cx32" || mock.upgradeDisk {
+		t.Errorf("expected 42 resized to cx32 keeping its disk, got id=%q type=%q upgradeDisk=%v", mock.resizedID, mock.resizedTo, mock.upgradeDisk)
+	}
+	if !strings.Contains(stderr, `Resizing server "test" to cx32`) {
+		t.Errorf("expected progress message on stderr, got:\n%s", stderr)
+	}
+	if !strings.Contains(stdout, "resized to cx32 successfully") {
+		t.Errorf("expected success message on stdout, got:\n%s", stdout)
+	}
+}
+
+func TestResizeCommand_RejectsRunningServer(t *testing.T) {
+	mock := &resizeMockProvider{stopMockProvider: stopMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "running", ServerType: "cx22"},
+	}}
+
+	_, stderr := execResize(t, mock, "--id", "42", "--type", "cx32")
+
+	if mock.resizedID != "" {
+		t.Error("expected no resize for a running server")
+	}
+	if !strings.Contains(stderr, "stop it first") {
+		t.Errorf("expected stop hint on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestResizeCommand_Unsupported(t *testing.T) {
+	registerStopMockProvider(t, "mock", &stopMockProvider{displayName: "Mock"})
+
+	var errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs([]string{"resize", "--provider", "mock", "--id", "42", "--type", "cx32"})
+	cmd.Execute()
+
+	if !strings.Contains(errBuf.String(), "does not support resizing") {
+		t.Errorf("expected unsupported error on stderr, got:\n%s", errBuf.String())
+	}
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/tui"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	archivesvc "nathanbeddoewebdev/vpsm/internal/services/serverarchive"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// RestoreConfigCommand returns a cobra.Command that recreates a deleted
+// server from its archived configuration.
+func RestoreConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore-config [archived]",
+		Short: "Recreate a deleted server from its archived config",
+		Long: `Recreate a server that was deleted with vpsm.
+
+Deleting a server archives its configuration locally: type, image,
+location, labels, networks, and the user data and SSH keys it was
+created with, along with the A and AAAA records that pointed at it.
+Without an argument, the archived configurations are listed. With an
+archive ID or server name, the create wizard opens prefilled with that
+configuration so you can review it before creating an equivalent
+server. vpsm then offers to point the archived DNS records at the new
+server.
+
+The archived server's provider is used unless --provider is given.
+
+Examples:
+  vpsm server restore-config           # List archived configs
+  vpsm server restore-config web-1     # Recreate the last deleted web-1
+  vpsm server restore-config 3         # Recreate archive entry 3`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  runRestoreConfig,
+	}
+
+	return cmd
+}
+
+func runRestoreConfig(cmd *cobra.Command, args []string) {
+	repo, err := serverarchive.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error opening server archive: %v\n", err)
+		return
+	}
+	svc := archivesvc.NewService(repo)
+	defer svc.Close()
+
+	if len(args) == 0 {
+		archived, err := svc.List()
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error listing archived configs: %v\n", err)
+			return
+		}
+		printArchivedConfigs(cmd, archived)
+		return
+	}
+
+	cfg, err := svc.Find(args[0])
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	providerName := cfg.Provider
+	if cmd.Flag("provider").Changed || providerName == "" {
+		providerName = cmd.Flag("provider").Value.String()
+	}
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: restore-config requires a terminal to review the configuration.")
+		return
+	}
+	catalogProvider, ok := provider.(domain.CatalogProvider)
+	if !ok {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s does not support the create wizard\n", provider.GetDisplayName())
+		return
+	}
+
+	opts, err := tui.RunServerCreate(catalogProvider, providerName, archivesvc.CreateOpts(*cfg))
+	if err != nil {
+		if errors.Is(err, tui.ErrAborted) {
+			fmt.Fprintln(cmd.ErrOrStderr(), "Server creation cancelled.")
+			return
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	logCreateOpts(cmd, *opts)
+
+	server, err := provider.CreateServer(context.Background(), *opts)
+	if err != nil {
+		logCreateOptsFull(cmd, *opts)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error creating server: %v\n", err)
+		return
+	}
+	archivesvc.RememberCreated(providerName, *server, *opts)
+
+	printCreateTable(cmd, server)
+	offerDNSRepoint(cmd, providerName, cfg.DNSRecords, server)
+}
+
+// offerDNSRepoint lists the DNS records that pointed at an archived
+// server and offers to point them at server, its replacement.
+func offerDNSRepoint(cmd *cobra.Command, providerName string, records []serverarchive.DNSRecord, server *domain.Server) {
+	if len(records) == 0 {
+		return
+	}
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "\n%d DNS record(s) pointed at the deleted server:\n", len(records))
+	for _, r := range records {
+		fmt.Fprintf(out, "  %s %s %s\n", attach.Hostname(r.Name, r.Zone), r.Type, r.Value)
+	}
+
+	dns, err := attachDNSProvider(cmd, providerName)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		return
+	}
+	if !confirm(cmd.InOrStdin(), cmd.ErrOrStderr(), "Point them at the new server? [y/N]: ") {
+		fmt.Fprintf(out, "Left unchanged. Use 'vpsm server dns attach --id %s' to point names at the new server.\n", server.ID)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	var zones []string
+	byZone := make(map[string][]serverarchive.DNSRecord)
+	for _, r := range records {
+		if _, ok := byZone[r.Zone]; !ok {
+			zones = append(zones, r.Zone)
+		}
+		byZone[r.Zone] = append(byZone[r.Zone], r)
+	}
+
+	ipv4, ipv6 := server.PublicIPv4, server.ReachableIPv6()
+	for _, zone := range zones {
+		existing, err := dns.ListRecords(ctx, zone)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to list records of %s: %v\n", zone, err)
+			continue
+		}
+		changes, skipped := archivesvc.Repoint(byZone[zone], existing, ipv4, ipv6)
+		for _, r := range skipped {
+			reason := "it was changed after the server was deleted"
+			switch {
+			case dnsdomain.RecordType(r.Type) == dnsdomain.RecordA && ipv4 == "":
+				reason = "the new server has no public IPv4 address"
+			case dnsdomain.RecordType(r.Type) == dnsdomain.RecordAAAA && ipv6 == "":
+				reason = "the new server has no public IPv6 address"
+			}
+			fmt.Fprintf(out, "  Skipped %s %s: %s.\n", attach.Hostname(r.Name, r.Zone), r.Type, reason)
+		}
+		applied, err := dnsdomain.ApplyChanges(ctx, dns, zone, changes)
+		for _, c := range changes[:applied] {
+			fmt.Fprintf(out, "  Pointed %s %s at %s.\n", attach.Hostname(c.Record.Name, zone), c.Record.Type, c.Record.Value)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		}
+	}
+}
+
+func printArchivedConfigs(cmd *cobra.Command, archived []serverarchive.Config) {
+	if len(archived) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No archived server configs.")
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tPROVIDER\tTYPE\tLOCATION\tIMAGE\tDNS RECORDS\tDELETED")
+	for _, cfg := range archived {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			cfg.ID, cfg.Name, cfg.Provider, cfg.ServerType, cfg.Location, cfg.Image,
+			len(cfg.DNSRecords), cfg.ArchivedAt.Local().Format(time.DateTime))
+	}
+	w.Flush()
+}
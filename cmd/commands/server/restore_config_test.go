@@ -0,0 +1,177 @@
+package server
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
+)
+
+// registerArchiveDNS makes dns the DNS provider for servers on "mock".
+func registerArchiveDNS(t *testing.T, dns *dnsMockProvider) {
+	t.Helper()
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	dnsproviders.Reset()
+	t.Cleanup(dnsproviders.Reset)
+	dnsproviders.Register("mock", func(auth.Store) (dnsdomain.Provider, error) { return dns, nil })
+}
+
+func execRestoreConfig(t *testing.T, providerName string, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	args := append([]string{"restore-config", "--provider", providerName}, extraArgs...)
+	cmd.SetArgs(args)
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestRestoreConfigCommand_NoArchive(t *testing.T) {
+	registerDeleteMockProvider(t, "mock", &deleteMockProvider{displayName: "Mock"})
+
+	stdout, stderr := execRestoreConfig(t, "mock")
+
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+	if !strings.Contains(stdout, "No archived server configs") {
+		t.Errorf("expected empty message, got:\n%s", stdout)
+	}
+}
+
+func TestRestoreConfigCommand_ListsDeletedServers(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server: &domain.Server{
+			ID:         "42",
+			Name:       "web-1",
+			ServerType: "cpx11",
+			Image:      "ubuntu-24.04",
+			Region:     "fsn1",
+		},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+
+	if _, stderr := execDelete(t, "mock", "--id", "42"); strings.Contains(stderr, "Error") {
+		t.Fatalf("delete failed: %s", stderr)
+	}
+
+	stdout, _ := execRestoreConfig(t, "mock")
+
+	for _, want := range []string{"NAME", "web-1", "mock", "cpx11", "fsn1", "ubuntu-24.04"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestRestoreConfigCommand_UnknownArchive(t *testing.T) {
+	registerDeleteMockProvider(t, "mock", &deleteMockProvider{displayName: "Mock"})
+
+	_, stderr := execRestoreConfig(t, "mock", "missing")
+
+	if !strings.Contains(stderr, `no archived server config "missing"`) {
+		t.Errorf("expected not-found error, got:\n%s", stderr)
+	}
+}
+
+func TestRestoreConfigCommand_RequiresTerminal(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", ServerType: "cpx11", Image: "ubuntu-24.04"},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+	execDelete(t, "mock", "--id", "42")
+
+	_, stderr := execRestoreConfig(t, "mock", "web-1")
+
+	if !strings.Contains(stderr, "requires a terminal") {
+		t.Errorf("expected terminal error, got:\n%s", stderr)
+	}
+}
+
+func TestRestoreConfigCommand_ArchivesDNSRecords(t *testing.T) {
+	mock := &deleteMockProvider{
+		displayName: "Mock",
+		server:      &domain.Server{ID: "42", Name: "web-1", PublicIPv4: "203.0.113.7"},
+	}
+	registerDeleteMockProvider(t, "mock", mock)
+	registerArchiveDNS(t, &dnsMockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "2", Name: "mail", Type: dnsdomain.RecordA, Value: "198.51.100.9"},
+	}})
+
+	if _, stderr := execDelete(t, "mock", "--id", "42"); strings.Contains(stderr, "Error") {
+		t.Fatalf("delete failed: %s", stderr)
+	}
+
+	repo, err := serverarchive.Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer repo.Close()
+	cfg, err := repo.Get("mock", "42")
+	if err != nil || cfg == nil {
+		t.Fatalf("Get() = %+v, %v", cfg, err)
+	}
+	want := []serverarchive.DNSRecord{{Zone: "example.com", Name: "www", Type: "A", Value: "203.0.113.7", TTL: 300}}
+	if diff := cmp.Diff(want, cfg.DNSRecords); diff != "" {
+		t.Errorf("archived DNS records mismatch (-want +got):\n%s", diff)
+	}
+
+	stdout, _ := execRestoreConfig(t, "mock")
+	if !strings.Contains(stdout, "DNS RECORDS") {
+		t.Errorf("expected a DNS records column, got:\n%s", stdout)
+	}
+}
+
+func TestOfferDNSRepoint_PointsRecordsAtNewServer(t *testing.T) {
+	dns := &dnsMockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "2", Name: "api", Type: dnsdomain.RecordA, Value: "198.51.100.9"},
+	}}
+	registerArchiveDNS(t, dns)
+	archived := []serverarchive.DNSRecord{
+		{Zone: "example.com", Name: "www", Type: "A", Value: "203.0.113.7", TTL: 300},
+		{Zone: "example.com", Name: "api", Type: "A", Value: "203.0.113.7"},
+	}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.Flags().String("dns-provider", "", "")
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader("y\n"))
+	offerDNSRepoint(cmd, "mock", archived, &domain.Server{ID: "43", PublicIPv4: "203.0.113.20"})
+
+	for _, want := range []string{
+		"2 DNS record(s) pointed at the deleted server:",
+		"  www.example.com A 203.0.113.7",
+		"  Skipped api.example.com A: it was changed after the server was deleted.",
+		"  Pointed www.example.com A at 203.0.113.20.",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output:\n%s", want, out.String())
+		}
+	}
+	wantRecords := []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.20", TTL: 300},
+		{ID: "2", Name: "api", Type: dnsdomain.RecordA, Value: "198.51.100.9"},
+	}
+	if diff := cmp.Diff(wantRecords, dns.records); diff != "" {
+		t.Errorf("records mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
 
 	"github.com/spf13/cobra"
 )
@@ -11,6 +12,7 @@ import (
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "server",
+		Aliases:           []string{"s"},
 		Short:             "Manage servers across cloud providers",
 		Long:              `Create, list, show, and delete servers from your configured cloud providers.`,
 		PersistentPreRunE: resolveProvider,
@@ -18,36 +20,57 @@ func NewCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(ActionsCommand())
+	cmd.AddCommand(BastionCommand())
+	cmd.AddCommand(BroadcastCommand())
+	cmd.AddCommand(ConsoleCommand())
 	cmd.AddCommand(CreateCommand())
 	cmd.AddCommand(DeleteCommand())
+	cmd.AddCommand(DNSCommand())
+	cmd.AddCommand(ExecCommand())
+	cmd.AddCommand(IdleCommand())
+	cmd.AddCommand(IPv6Command())
 	cmd.AddCommand(ListCommand())
 	cmd.AddCommand(MetricsCommand())
+	cmd.AddCommand(PickCommand())
+	cmd.AddCommand(PingCommand())
+	cmd.AddCommand(RDNSCommand())
+	cmd.AddCommand(RebootCommand())
+	cmd.AddCommand(ResizeCommand())
+	cmd.AddCommand(RestoreConfigCommand())
 	cmd.AddCommand(ShowCommand())
 	cmd.AddCommand(SSHCommand())
+	cmd.AddCommand(SSHOptionsCommand())
 	cmd.AddCommand(StartCommand())
 	cmd.AddCommand(StopCommand())
+	cmd.AddCommand(TUICommand())
 
 	cmd.PersistentFlags().String("provider", "", "Cloud provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
+	cmd.PersistentFlags().StringP("output", "o", "table", "Output format: table, json, or yaml (list also accepts csv)")
 
 	return cmd
 }
 
 // resolveProvider ensures the --provider flag has a value, falling back to the
-// configured default when the flag was not explicitly passed.
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
 func resolveProvider(cmd *cobra.Command, args []string) error {
-	if cmd.Flag("provider").Changed {
-		return nil // explicitly provided -- nothing to do
-	}
-
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if cfg.DefaultProvider != "" {
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
 		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
-		return nil
 	}
 
-	return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
 }
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/server/tui"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	iphistorysvc "nathanbeddoewebdev/vpsm/internal/services/iphistory"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -31,12 +34,15 @@ Examples:
   vpsm server show --provider hetzner --id 12345
 
   # JSON output for scripting
-  vpsm server show --provider hetzner --id 12345 -o json`,
+  vpsm server show --provider hetzner --id 12345 -o json
+
+  # Pick fields with a Go template
+  vpsm server show --id 12345 --format '{{.PublicIPv4}}'`,
 		Run: runShow,
 	}
 
 	cmd.Flags().String("id", "", "Server ID to show (skips interactive selection)")
-	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().String("format", "", formatUsage)
 
 	return cmd
 }
@@ -50,21 +56,22 @@ func runShow(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if err := checkFormatFlags(cmd); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
 	serverID, _ := cmd.Flags().GetString("id")
 
 	if serverID == "" {
-		output, _ := cmd.Flags().GetString("output")
-		outputChanged := cmd.Flags().Changed("output")
-		if outputChanged || !term.IsTerminal(int(os.Stdout.Fd())) {
-			if output == "" {
-				output = "table"
-			}
-			runListNonInteractive(cmd, provider, output)
+		if cmd.Flags().Changed("output") || cmd.Flags().Changed("format") || !term.IsTerminal(int(os.Stdout.Fd())) {
+			output, _ := cmd.Flags().GetString("output")
+			runListNonInteractive(cmd, provider, output, nil)
 			return
 		}
 
 		// Interactive full-window TUI with seamless view transitions.
-		if _, err := tui.RunServerApp(provider, providerName); err != nil {
+		if _, err := tui.RunServerApp(provider, providerName, tui.AppOptions{}); err != nil {
 			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
 		}
 		return
@@ -78,11 +85,27 @@ func runShow(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Record the current public IPs and look up any previous ones
+	// (best-effort, like the actionstore pattern).
+	var previousIPs []iphistory.IPRecord
+	if repo, err := iphistory.Open(); err == nil {
+		svc := iphistorysvc.NewService(repo)
+		svc.RecordServers([]domain.Server{*server})
+		previousIPs = svc.PreviousIPs(*server)
+		svc.Close()
+	}
+
+	if printFormatted(cmd, []*domain.Server{server}) {
+		return
+	}
+
 	output, _ := cmd.Flags().GetString("output")
 	switch output {
-	case "json":
-		printServerJSON(cmd, server)
+	case "json", "yaml":
+		printStructured(cmd, output, server)
 	default:
 		printServerDetail(cmd, server)
+		printPreviousIPs(cmd, previousIPs)
+		printStaleDNS(cmd, providerName, previousIPs)
 	}
 }
@@ -5,10 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
@@ -53,6 +58,9 @@ func registerShowMockProvider(t *testing.T, name string, mock *showMockProvider)
 	providers.Register(name, func(store auth.Store) (domain.Provider, error) {
 		return mock, nil
 	})
+
+	iphistory.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(func() { iphistory.ResetPath() })
 }
 
 // execShow creates the server command, wires up output buffers, runs "show --provider <provider> [flags...]",
@@ -105,6 +113,52 @@ func TestShowCommand_WithIDFlag_TableOutput(t *testing.T) {
 	})
 }
 
+func TestShowCommand_WithIDFlag_PreviousIPs(t *testing.T) {
+	mock := &showMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "web-server", Provider: "mock", PublicIPv4: "1.2.3.4"},
+	}
+	registerShowMockProvider(t, "mock", mock)
+
+	stdout, _ := execShow(t, "mock", "--id", "42")
+	if strings.Contains(stdout, "Previous IPs") {
+		t.Errorf("expected no previous IPs on first fetch, got:\n%s", stdout)
+	}
+
+	// Simulate a rebuild that assigns a new IP.
+	mock.getServer = &domain.Server{ID: "42", Name: "web-server", Provider: "mock", PublicIPv4: "5.6.7.8"}
+	stdout, _ = execShow(t, "mock", "--id", "42")
+
+	assertContainsAll(t, stdout, "stdout", []string{"5.6.7.8", "Previous IPs", "1.2.3.4", "DNS"})
+}
+
+func TestShowCommand_WithIDFlag_PreviousIPsNamesStaleDNS(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	dns := &dnsMockProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "1.2.3.4"},
+		{ID: "2", Name: "mail", Type: dnsdomain.RecordA, Value: "9.9.9.9"},
+	}}
+	dnsproviders.Reset()
+	t.Cleanup(dnsproviders.Reset)
+	dnsproviders.Register("mock", func(auth.Store) (dnsdomain.Provider, error) { return dns, nil })
+
+	mock := &showMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "web-server", Provider: "mock", PublicIPv4: "1.2.3.4"},
+	}
+	registerShowMockProvider(t, "mock", mock)
+	execShow(t, "mock", "--id", "42")
+
+	mock.getServer = &domain.Server{ID: "42", Name: "web-server", Provider: "mock", PublicIPv4: "5.6.7.8"}
+	stdout, _ := execShow(t, "mock", "--id", "42")
+
+	assertContainsAll(t, stdout, "stdout", []string{"1 record(s) at Mock DNS", "www.example.com A 1.2.3.4"})
+	if strings.Contains(stdout, "mail.example.com") {
+		t.Errorf("expected only records on the old IP, got:\n%s", stdout)
+	}
+}
+
 func TestShowCommand_WithIDFlag_JSONOutput(t *testing.T) {
 	server := &domain.Server{
 		ID:         "42",
@@ -143,6 +197,42 @@ func TestShowCommand_WithIDFlag_JSONOutput(t *testing.T) {
 	}
 }
 
+func TestShowCommand_WithIDFlag_YAMLOutput(t *testing.T) {
+	mock := &showMockProvider{
+		displayName: "Mock",
+		getServer: &domain.Server{
+			ID:         "42",
+			Name:       "web-server",
+			Status:     "running",
+			CreatedAt:  time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+			PublicIPv4: "1.2.3.4",
+			Region:     "fsn1",
+			ServerType: "cpx11",
+			Provider:   "mock",
+			Labels:     map[string]string{"env": "prod"},
+		},
+	}
+
+	registerShowMockProvider(t, "mock", mock)
+
+	stdout, _ := execShow(t, "mock", "--id", "42", "-o", "yaml")
+
+	want := `id: "42"
+name: web-server
+status: running
+created_at: "2024-06-15T12:00:00Z"
+public_ipv4: 1.2.3.4
+region: fsn1
+server_type: cpx11
+provider: mock
+labels:
+  env: prod
+`
+	if stdout != want {
+		t.Errorf("YAML output mismatch:\ngot:\n%s\nwant:\n%s", stdout, want)
+	}
+}
+
 func TestShowCommand_WithIDFlag_GetError(t *testing.T) {
 	mock := &showMockProvider{
 		displayName: "Mock",
@@ -4,12 +4,17 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/server/services/nativessh"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
 	"nathanbeddoewebdev/vpsm/internal/serverprefs"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
 	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
@@ -20,7 +25,7 @@ import (
 // SSHCommand returns a cobra.Command that connects to a server via SSH.
 func SSHCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "ssh",
+		Use:   "ssh [server]",
 		Short: "Connect to a server via SSH",
 		Long: `Connect to a running server instance via SSH.
 
@@ -30,14 +35,32 @@ IPv4 is preferred; if unavailable, IPv6 will be used.
 The username can be specified via --user, or will default to the last-used
 username for this server (stored locally), or "root" if never set.
 
+If a bastion is configured for the server, its group, or the provider (see
+'vpsm server bastion'), the connection is proxied through it with ssh -J
+and the server's private IP is used when it has one.
+
+The server can be given by name instead of --id.
+
+Extra ssh arguments (e.g. -o ForwardAgent=yes, -i, -J) are added from the
+server's stored options ('vpsm server ssh-options') and the ssh-options
+config key, in that order of precedence.
+
+The ssh-client config key selects the system ssh binary (exec, the
+default) or a built-in client (native), which is also used when ssh is
+not installed. The built-in client keeps its host keys in vpsm's own
+known_hosts file, authenticates with ssh-agent and ~/.ssh/id_* keys, and
+understands the -A, -i, -J, -p and -o ForwardAgent/Port/User/IdentityFile
+options.
+
 Examples:
   vpsm server ssh --provider hetzner --id 12345
-  vpsm server ssh --provider hetzner --id 12345 --user ubuntu`,
-		Run: runSSH,
+  vpsm server ssh --provider hetzner --id 12345 --user ubuntu
+  vpsm s ssh web-1`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  runSSH,
 	}
 
-	cmd.Flags().String("id", "", "Server ID to connect to (required)")
-	cmd.MarkFlagRequired("id")
+	cmd.Flags().String("id", "", "Server ID to connect to (or pass the server name as an argument)")
 	cmd.Flags().String("user", "", "SSH username (optional, defaults to saved preference or 'root')")
 
 	return cmd
@@ -57,6 +80,19 @@ func runSSH(cmd *cobra.Command, args []string) {
 
 	ctx := context.Background()
 
+	if serverID == "" {
+		if len(args) == 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: required flag \"id\" not set (or pass the server name)\n")
+			return
+		}
+		found, err := findServer(ctx, provider, args[0])
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		serverID = found.ID
+	}
+
 	// Fetch the server.
 	server, err := provider.GetServer(ctx, serverID)
 	if err != nil {
@@ -71,23 +107,24 @@ func runSSH(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	// Resolve IP address (IPv4 preferred, IPv6 fallback).
-	ipAddress := server.PublicIPv4
-	if ipAddress == "" {
-		ipAddress = server.PublicIPv6
+	// Open serverprefs repository (best-effort, like actionstore pattern).
+	var svc *prefssvc.Service
+	if repo, err := serverprefs.Open(); err == nil {
+		svc = prefssvc.NewService(repo)
+		defer svc.Close()
 	}
+
+	// Resolve IP address (IPv4 preferred, IPv6 fallback; private IPv4
+	// when connecting through a bastion).
+	via := bastion.Lookup(svc, providerName, *server)
+	ipAddress, _ := remote.HostVia(*server, via)
 	if ipAddress == "" {
 		fmt.Fprintf(cmd.ErrOrStderr(), "Error: Server %s has no public IP address\n", serverID)
 		return
 	}
 
-	// Open serverprefs repository (best-effort, like actionstore pattern).
 	var username string
-	repo, err := serverprefs.Open()
-	if err == nil {
-		svc := prefssvc.NewService(repo)
-		defer svc.Close()
-
+	if svc != nil {
 		// Determine username: --user flag > saved pref > "root".
 		if userFlag != "" {
 			username = userFlag
@@ -109,20 +146,39 @@ func runSSH(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// Extra options: the server's, then the configured default.
+	var serverOptions, defaultOptions string
+	if svc != nil {
+		serverOptions = svc.GetSSHOptions(providerName, serverID)
+	}
+	if cfg, err := config.Load(); err == nil {
+		defaultOptions = cfg.SSHOptions
+	}
+	userArgs := remote.UserArgs(serverOptions, defaultOptions)
+
 	// Attempt SSH connection with retry on host key conflict.
-	connectSSH(cmd, providerName, serverID, username, ipAddress)
+	connectSSH(cmd, providerName, serverID, username, ipAddress, via, userArgs)
 }
 
 // connectSSH attempts to SSH into the server, handling host key conflicts.
-func connectSSH(cmd *cobra.Command, providerName, serverID, username, ipAddress string) {
+// userArgs come before the built-in options so they take precedence.
+func connectSSH(cmd *cobra.Command, providerName, serverID, username, ipAddress, via string, userArgs []string) {
+	if nativessh.Configured() == nativessh.ModeNative {
+		connectNative(cmd, username, ipAddress, via, userArgs)
+		return
+	}
+
 	// Build SSH command.
-	sshCmd := exec.Command("ssh",
+	args := append([]string(nil), userArgs...)
+	args = append(args,
 		"-o", "StrictHostKeyChecking=accept-new",
 		"-o", "ConnectTimeout=10",
 		"-o", "ServerAliveInterval=60",
 		"-o", "ServerAliveCountMax=3",
-		fmt.Sprintf("%s@%s", username, ipAddress),
 	)
+	args = append(args, remote.RouteArgs(via)...)
+	args = append(args, fmt.Sprintf("%s@%s", username, ipAddress))
+	sshCmd := exec.Command("ssh", args...)
 
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
@@ -165,7 +221,7 @@ func connectSSH(cmd *cobra.Command, providerName, serverID, username, ipAddress
 
 			// Retry SSH connection.
 			fmt.Fprintf(cmd.ErrOrStderr(), "Retrying SSH connection...\n")
-			connectSSH(cmd, providerName, serverID, username, ipAddress)
+			connectSSH(cmd, providerName, serverID, username, ipAddress, via, userArgs)
 		}
 		return
 	}
@@ -173,3 +229,52 @@ func connectSSH(cmd *cobra.Command, providerName, serverID, username, ipAddress
 	// Other SSH errors — just print a generic message.
 	fmt.Fprintf(cmd.ErrOrStderr(), "\nSSH connection failed.\n")
 }
+
+// connectNative logs in with the built-in SSH client, offering to forget
+// a changed host key like connectSSH does for ssh.
+func connectNative(cmd *cobra.Command, username, ipAddress, via string, userArgs []string) {
+	session := &nativessh.Session{User: username, Host: ipAddress}
+	if ignored := session.ApplyArgs(userArgs); len(ignored) > 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Ignoring ssh options the built-in client does not support: %s\n", strings.Join(ignored, " "))
+	}
+	// Like ssh, the first -J wins over the configured bastion.
+	if session.Jump == "" {
+		session.Jump = via
+	}
+	session.SetStdin(os.Stdin)
+	session.SetStdout(os.Stdout)
+	session.SetStderr(cmd.ErrOrStderr())
+
+	err := session.Run()
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+
+	if errors.Is(err, nativessh.ErrHostKeyChanged) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "\nHost key has changed (IP may have been reused by a new server).\n")
+		fmt.Fprintf(cmd.ErrOrStderr(), "Clear the old key and retry? [Y/n]: ")
+
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+
+		if response == "" || response == "y" || response == "yes" {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Clearing old host key for %s...\n", ipAddress)
+			path, err := nativessh.KnownHostsPath()
+			if err == nil {
+				err = nativessh.Forget(path, ipAddress)
+			}
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Error clearing host key: %v\n", err)
+				return
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "Retrying SSH connection...\n")
+			connectNative(cmd, username, ipAddress, via, userArgs)
+		}
+		return
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "\nSSH connection failed.\n")
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	"github.com/spf13/cobra"
+)
+
+// SSHOptionsCommand returns a cobra.Command that configures extra ssh
+// arguments for a server.
+func SSHOptionsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh-options",
+		Short: "Configure extra ssh arguments for a server",
+		Long: `Show or configure extra arguments passed to ssh when logging in to a
+server, from 'vpsm server ssh' and the TUI.
+
+They take precedence over the default set with
+'vpsm config set ssh-options', which in turn overrides vpsm's built-in
+options. Quote values containing spaces.
+
+Examples:
+  vpsm server ssh-options --id 12345 --set "-o ForwardAgent=yes -i ~/.ssh/deploy"
+  vpsm server ssh-options --id 12345
+  vpsm server ssh-options --id 12345 --clear`,
+		Args: cobra.ExactArgs(0),
+		Run:  runSSHOptions,
+	}
+
+	cmd.Flags().String("id", "", "Server ID (required)")
+	cmd.Flags().String("set", "", "ssh arguments to add")
+	cmd.Flags().Bool("clear", false, "Remove the server's ssh arguments")
+	cmd.MarkFlagRequired("id")
+	cmd.MarkFlagsMutuallyExclusive("set", "clear")
+
+	return cmd
+}
+
+func runSSHOptions(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+	serverID, _ := cmd.Flags().GetString("id")
+	options, _ := cmd.Flags().GetString("set")
+	clear, _ := cmd.Flags().GetBool("clear")
+
+	if options != "" {
+		if _, err := remote.SplitOptions(options); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+	}
+
+	repo, err := serverprefs.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	svc := prefssvc.NewService(repo)
+	defer svc.Close()
+
+	switch {
+	case options != "":
+		if err := svc.SetSSHOptions(providerName, serverID, options); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "SSH to server %s will use: %s\n", serverID, options)
+
+	case clear:
+		if err := svc.SetSSHOptions(providerName, serverID, ""); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "SSH options cleared for server %s.\n", serverID)
+
+	default:
+		if o := svc.GetSSHOptions(providerName, serverID); o != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "SSH options for server %s: %s\n", serverID, o)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "No SSH options configured for server %s.\n", serverID)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+)
+
+func execSSHOptions(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"ssh-options", "--provider", "mock"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestSSHOptionsCommand_SetShowClear(t *testing.T) {
+	serverprefs.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(serverprefs.ResetPath)
+
+	if _, stderr := execSSHOptions(t, "--id", "42", "--set", "-o ForwardAgent=yes"); stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	stdout, _ := execSSHOptions(t, "--id", "42")
+	if !strings.Contains(stdout, "-o ForwardAgent=yes") {
+		t.Errorf("expected the stored options, got: %s", stdout)
+	}
+
+	execSSHOptions(t, "--id", "42", "--clear")
+	stdout, _ = execSSHOptions(t, "--id", "42")
+	if !strings.Contains(stdout, "No SSH options") {
+		t.Errorf("expected options to be cleared, got: %s", stdout)
+	}
+}
+
+func TestSSHOptionsCommand_RejectsInvalid(t *testing.T) {
+	serverprefs.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(serverprefs.ResetPath)
+
+	_, stderr := execSSHOptions(t, "--id", "42", "--set", `-i "unterminated`)
+	if !strings.Contains(stderr, "unterminated") {
+		t.Errorf("expected a parse error, got: %s", stderr)
+	}
+}
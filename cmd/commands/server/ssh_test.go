@@ -18,6 +18,7 @@ type sshMockProvider struct {
 	displayName  string
 	getServer    *domain.Server
 	getServerErr error
+	servers      []domain.Server
 }
 
 func (m *sshMockProvider) GetDisplayName() string { return m.displayName }
@@ -34,7 +35,7 @@ func (m *sshMockProvider) GetServer(_ context.Context, id string) (*domain.Serve
 	return m.getServer, nil
 }
 func (m *sshMockProvider) ListServers(_ context.Context) ([]domain.Server, error) {
-	return nil, fmt.Errorf("not implemented")
+	return m.servers, nil
 }
 func (m *sshMockProvider) StartServer(_ context.Context, _ string) (*domain.ActionStatus, error) {
 	return nil, fmt.Errorf("not implemented")
@@ -86,6 +87,35 @@ func TestSSHCommand_MissingID(t *testing.T) {
 	}
 }
 
+func TestSSHCommand_ServerByName(t *testing.T) {
+	server := domain.Server{ID: "42", Name: "web-1", Status: "off"}
+	mock := &sshMockProvider{
+		displayName: "Mock",
+		getServer:   &server,
+		servers:     []domain.Server{{ID: "7", Name: "db-1"}, server},
+	}
+
+	registerSSHMockProvider(t, "mock", mock)
+
+	_, stderr := execSSH(t, "mock", "web-1")
+
+	if !strings.Contains(stderr, "Server 42 is not running") {
+		t.Errorf("expected the name to resolve to server 42, got:\n%s", stderr)
+	}
+}
+
+func TestSSHCommand_UnknownName(t *testing.T) {
+	mock := &sshMockProvider{displayName: "Mock", servers: []domain.Server{{ID: "7", Name: "db-1"}}}
+
+	registerSSHMockProvider(t, "mock", mock)
+
+	_, stderr := execSSH(t, "mock", "web-1")
+
+	if !strings.Contains(stderr, `no server named "web-1"`) {
+		t.Errorf("expected an unknown-name error, got:\n%s", stderr)
+	}
+}
+
 func TestSSHCommand_ServerNotRunning(t *testing.T) {
 	mock := &sshMockProvider{
 		displayName: "Mock",
@@ -28,8 +28,11 @@ for action progress (or falling back to server-status polling).
 The action is persisted locally so that if the CLI is interrupted, the
 action can be resumed with "vpsm server actions --resume".
 
+With -o json or -o yaml, the server is printed once it is running.
+
 Examples:
-  vpsm server start --provider hetzner --id 12345`,
+  vpsm server start --provider hetzner --id 12345
+  vpsm server start --provider hetzner --id 12345 -o json`,
 		Run: runStart,
 	}
 
@@ -50,6 +53,13 @@ func runStart(cmd *cobra.Command, args []string) {
 
 	serverID, _ := cmd.Flags().GetString("id")
 
+	unlock, err := lockServer(provider, providerName, serverID, "start_server")
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer unlock()
+
 	fmt.Fprintf(cmd.ErrOrStderr(), "Starting server %s...\n", serverID)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -80,5 +90,9 @@ func runStart(cmd *cobra.Command, args []string) {
 	}
 
 	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	if output, _ := cmd.Flags().GetString("output"); isStructured(output) {
+		printServerState(cmd, provider, serverID, output)
+		return
+	}
 	fmt.Fprintf(cmd.OutOrStdout(), "Server %s started successfully.\n", serverID)
 }
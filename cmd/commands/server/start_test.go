@@ -3,6 +3,7 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -163,6 +164,27 @@ func TestStartCommand_WithIDFlag(t *testing.T) {
 	}
 }
 
+func TestStartCommand_JSONOutput(t *testing.T) {
+	withFastPolling(t)
+
+	mock := &startMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "running"},
+	}
+
+	registerStartMockProvider(t, "mock", mock)
+
+	stdout, _ := execStart(t, "mock", "--id", "42", "-o", "json")
+
+	var got domain.Server
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("failed to parse JSON output: %v\noutput:\n%s", err, stdout)
+	}
+	if got.ID != "42" || got.Status != "running" {
+		t.Errorf("expected the running server, got %+v", got)
+	}
+}
+
 func TestStartCommand_WithIDFlag_StartError(t *testing.T) {
 	mock := &startMockProvider{
 		displayName: "Mock",
@@ -30,8 +30,11 @@ until it reaches "off".
 The action is persisted locally so that if the CLI is interrupted, the
 action can be resumed with "vpsm server actions --resume".
 
+With -o json or -o yaml, the server is printed once it is off.
+
 Examples:
-  vpsm server stop --provider hetzner --id 12345`,
+  vpsm server stop --provider hetzner --id 12345
+  vpsm server stop --provider hetzner --id 12345 -o yaml`,
 		Run: runStop,
 	}
 
@@ -52,6 +55,13 @@ func runStop(cmd *cobra.Command, args []string) {
 
 	serverID, _ := cmd.Flags().GetString("id")
 
+	unlock, err := lockServer(provider, providerName, serverID, "stop_server")
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer unlock()
+
 	fmt.Fprintf(cmd.ErrOrStderr(), "Stopping server %s...\n", serverID)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -82,5 +92,9 @@ func runStop(cmd *cobra.Command, args []string) {
 	}
 
 	svc.FinalizeAction(record, domain.ActionStatusSuccess, "")
+	if output, _ := cmd.Flags().GetString("output"); isStructured(output) {
+		printServerState(cmd, provider, serverID, output)
+		return
+	}
 	fmt.Fprintf(cmd.OutOrStdout(), "Server %s stop initiated successfully.\n", serverID)
 }
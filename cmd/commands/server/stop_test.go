@@ -3,10 +3,14 @@ package server
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/server/services/action"
@@ -170,6 +174,63 @@ func TestStopCommand_WithIDFlag_StopError(t *testing.T) {
 	}
 }
 
+func TestStopCommand_ServerBusyInAnotherProcess(t *testing.T) {
+	withTestStore(t)
+	path, _ := actionstore.DefaultPath()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	// The test binary's parent process is alive for the whole test.
+	if _, err := db.Exec(`INSERT INTO server_locks (provider, server_id, command, pid, acquired_at) VALUES (?, ?, ?, ?, ?)`,
+		"mock", "42", "delete_server", os.Getppid(), time.Now().UTC().Format(time.RFC3339Nano)); err != nil {
+		t.Fatalf("insert lock failed: %v", err)
+	}
+
+	mock := &stopMockProvider{displayName: "Mock"}
+	registerStopMockProvider(t, "mock", mock)
+
+	stdout, stderr := execStop(t, "mock", "--id", "42")
+
+	if mock.stoppedID != "" {
+		t.Errorf("expected StopServer not to be called, got ID %q", mock.stoppedID)
+	}
+	if !strings.Contains(stderr, "server 42 is busy") || !strings.Contains(stderr, "delete_server") {
+		t.Errorf("expected busy error naming delete_server on stderr, got:\n%s", stderr)
+	}
+	if stdout != "" {
+		t.Errorf("expected no stdout, got:\n%s", stdout)
+	}
+}
+
+func TestStopCommand_ReleasesLock(t *testing.T) {
+	withFastPolling(t)
+	withTestStore(t)
+
+	mock := &stopMockProvider{
+		displayName: "Mock",
+		getServer:   &domain.Server{ID: "42", Name: "test", Status: "off"},
+	}
+	registerStopMockProvider(t, "mock", mock)
+
+	execStop(t, "mock", "--id", "42")
+
+	path, _ := actionstore.DefaultPath()
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM server_locks`).Scan(&n); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected no locks after stop, got %d", n)
+	}
+}
+
 func TestStopCommand_UnknownProvider(t *testing.T) {
 	providers.Reset()
 	t.Cleanup(func() { providers.Reset() })
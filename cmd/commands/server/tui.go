@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/server/tui"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"golang.org/x/term"
+
+	"github.com/spf13/cobra"
+)
+
+// TUICommand returns a cobra.Command that opens the interactive server app,
+// optionally already on one server's detail view.
+func TUICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tui",
+		Short: "Open the interactive server app",
+		Long: `Open the full-window server app.
+
+With --id or --name the app opens directly on that server's detail view,
+skipping the list. Going back still shows the list.
+
+Examples:
+  vpsm server tui
+  vpsm server tui --id 42
+  vpsm s tui --name web-1`,
+		Args: cobra.NoArgs,
+		Run:  runTUI,
+	}
+
+	cmd.Flags().String("id", "", "Open on the server with this ID")
+	cmd.Flags().String("name", "", "Open on the server with this name")
+	cmd.MarkFlagsMutuallyExclusive("id", "name")
+
+	return cmd
+}
+
+func runTUI(cmd *cobra.Command, args []string) {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: the server app needs a terminal\n")
+		return
+	}
+
+	providerName := cmd.Flag("provider").Value.String()
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	server, err := startServer(cmd, provider)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	if _, err := tui.RunServerApp(provider, providerName, tui.AppOptions{Server: server}); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+	}
+}
+
+// startServer resolves the server selected with --id or --name, or nil
+// when neither was given.
+func startServer(cmd *cobra.Command, provider domain.Provider) (*domain.Server, error) {
+	ctx := context.Background()
+	if id, _ := cmd.Flags().GetString("id"); id != "" {
+		server, err := provider.GetServer(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch server: %w", err)
+		}
+		return server, nil
+	}
+	if name, _ := cmd.Flags().GetString("name"); name != "" {
+		return findServer(ctx, provider, name)
+	}
+	return nil, nil
+}
+
+// findServer returns the server named nameOrID, or whose ID it is.
+func findServer(ctx context.Context, provider domain.Provider, nameOrID string) (*domain.Server, error) {
+	servers, err := provider.ListServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	for _, s := range servers {
+		if s.Name == nameOrID || s.ID == nameOrID {
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("no server named %q", nameOrID)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/spf13/cobra"
+)
+
+func tuiFlags(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := TUICommand()
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatalf("ParseFlags failed: %v", err)
+	}
+	return cmd
+}
+
+func TestStartServer_ByName(t *testing.T) {
+	mock := &sshMockProvider{servers: []domain.Server{{ID: "7", Name: "db-1"}, {ID: "42", Name: "web-1"}}}
+
+	server, err := startServer(tuiFlags(t, "--name", "web-1"), mock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server == nil || server.ID != "42" {
+		t.Errorf("expected server 42, got %+v", server)
+	}
+}
+
+func TestStartServer_ByID(t *testing.T) {
+	mock := &sshMockProvider{getServer: &domain.Server{ID: "42", Name: "web-1"}}
+
+	server, err := startServer(tuiFlags(t, "--id", "42"), mock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if server == nil || server.Name != "web-1" {
+		t.Errorf("expected web-1, got %+v", server)
+	}
+}
+
+func TestStartServer_UnknownName(t *testing.T) {
+	mock := &sshMockProvider{}
+
+	_, err := startServer(tuiFlags(t, "--name", "web-1"), mock)
+	if err == nil || !strings.Contains(err.Error(), `no server named "web-1"`) {
+		t.Errorf("expected an unknown-name error, got %v", err)
+	}
+}
+
+func TestStartServer_NoFlags(t *testing.T) {
+	server, err := startServer(tuiFlags(t), &sshMockProvider{})
+	if err != nil || server != nil {
+		t.Errorf("expected no server and no error, got %+v, %v", server, err)
+	}
+}
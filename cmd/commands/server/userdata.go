@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// maxUserDataSource caps how much user data is read from stdin or a URL.
+// It is well above any provider's limit; compression brings the data
+// down to size afterwards.
+const maxUserDataSource = 1 << 20
+
+// userDataHTTPClient fetches --user-data-url. Tests replace it.
+var userDataHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// readUserData returns the user data given by --user-data, which may be
+// "-" to read stdin, read from --user-data-file, or fetched from
+// --user-data-url.
+func readUserData(cmd *cobra.Command, value, path, rawURL string) ([]byte, error) {
+	switch {
+	case rawURL != "":
+		return fetchUserData(cmd.Context(), rawURL)
+	case path != "":
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user data file: %w", err)
+		}
+		defer f.Close()
+		data, err := readLimited(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user data file: %w", err)
+		}
+		return data, nil
+	case value == "-":
+		data, err := readLimited(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user data from stdin: %w", err)
+		}
+		return data, nil
+	default:
+		return []byte(value), nil
+	}
+}
+
+// fetchUserData downloads user data from an http(s) URL.
+func fetchUserData(ctx context.Context, rawURL string) ([]byte, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("invalid user data URL %q: expected an http(s) URL", rawURL)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user data: %w", err)
+	}
+	resp, err := userDataHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("failed to fetch user data: %s returned %s", u.Host, resp.Status)
+	}
+
+	data, err := readLimited(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user data: %w", err)
+	}
+	return data, nil
+}
+
+// readLimited reads r to the end, failing past maxUserDataSource bytes.
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxUserDataSource+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUserDataSource {
+		return nil, fmt.Errorf("user data is larger than %d bytes", maxUserDataSource)
+	}
+	return data, nil
+}
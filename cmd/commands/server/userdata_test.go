@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateCommand_UserDataFromStdin(t *testing.T) {
+	mock := &createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}}
+	registerCreateMock(t, mock)
+
+	_, stderr := execCreate(t, "#cloud-config\n", "--user-data", "-")
+	if mock.opts.UserData != "#cloud-config\n" {
+		t.Errorf("UserData = %q, stderr = %q", mock.opts.UserData, stderr)
+	}
+}
+
+func TestCreateCommand_UserDataFromURLIsCompressedOverLimit(t *testing.T) {
+	body := "#cloud-config\n" + strings.Repeat("# padding\n", 40)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	mock := &createMockProvider{sshMockProvider: sshMockProvider{displayName: "Mock"}}
+	registerCreateMock(t, mock)
+
+	_, stderr := execCreate(t, "", "--user-data-url", srv.URL)
+	if mock.opts.UserData == "" || mock.opts.UserData == body || len(mock.opts.UserData) > 128 {
+		t.Errorf("expected compressed user data within 128 bytes, got %q (stderr %q)", mock.opts.UserData, stderr)
+	}
+	if !strings.Contains(stderr, "compressed and encoded") {
+		t.Errorf("expected a compression note, got %q", stderr)
+	}
+}
+
+func TestFetchUserData_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+
+	if _, err := fetchUserData(context.Background(), srv.URL); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+	if _, err := fetchUserData(context.Background(), "file:///etc/passwd"); err == nil {
+		t.Error("expected non-http URLs to be rejected")
+	}
+}
+
+func TestReadLimited_RejectsOversizedInput(t *testing.T) {
+	if _, err := readLimited(strings.NewReader(strings.Repeat("x", maxUserDataSource+1))); err == nil {
+		t.Error("expected an error for oversized input")
+	}
+}
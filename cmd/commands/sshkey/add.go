@@ -13,6 +13,7 @@ import (
 	"nathanbeddoewebdev/vpsm/internal/sshkey/providers"
 	"nathanbeddoewebdev/vpsm/internal/sshkey/tui"
 	"nathanbeddoewebdev/vpsm/internal/sshkeys"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -24,11 +25,15 @@ func AddCommand() *cobra.Command {
 		Short: "Upload an SSH key to the cloud provider",
 		Long: `Upload a local SSH public key to the cloud provider's account.
 
-Provide a path argument or use --public-key to paste the key directly.
+Provide a path argument, use --public-key to paste the key directly, or use
+--github to import every public key a GitHub user has published at
+https://github.com/<username>.keys.
 If no path argument is provided, you will be prompted with the default path (~/.ssh/id_ed25519.pub) prefilled.
 If the selected file does not exist, you will be asked to provide another path.
 
 The key name will be prompted interactively unless --name is specified.
+With --github the name defaults to github-<username>; when the user has
+several keys they are uploaded as <name>-1, <name>-2, ...
 
 Examples:
   # Upload default key (interactive name prompt)
@@ -40,6 +45,9 @@ Examples:
   # Paste public key directly
   vpsm ssh-key add --public-key "ssh-ed25519 AAAA..." --name laptop
 
+  # Give a teammate access with the keys from their GitHub account
+  vpsm ssh-key add --github octocat
+
   # Upload with provider override
   vpsm ssh-key add --provider hetzner --name my-key`,
 		Run: runAdd,
@@ -47,6 +55,7 @@ Examples:
 
 	cmd.Flags().String("name", "", "Name for the SSH key (interactive prompt if not provided)")
 	cmd.Flags().String("public-key", "", "Public SSH key content (paste instead of providing a path)")
+	cmd.Flags().String("github", "", "Import the public keys of this GitHub user")
 
 	return cmd
 }
@@ -67,12 +76,18 @@ func runAdd(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	githubUser, _ := cmd.Flags().GetString("github")
+	githubUser = strings.TrimSpace(githubUser)
+	if githubUser != "" && (publicKeyProvided || len(args) > 0) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: --github cannot be combined with a path or --public-key\n")
+		return
+	}
+
 	keyName, _ := cmd.Flags().GetString("name")
 	keyName = strings.TrimSpace(keyName)
 
-	needsInteractive := keyName == "" || (!publicKeyProvided && len(args) == 0)
-	var publicKey string
-	var keyPath string
+	needsInteractive := githubUser == "" && (keyName == "" || (!publicKeyProvided && len(args) == 0))
+	var uploads []tui.SSHKeyUpload
 
 	if needsInteractive {
 		if !term.IsTerminal(int(os.Stdin.Fd())) {
@@ -91,7 +106,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 
 		var result *tui.SSHKeyAddResult
 		var err error
-		if os.Getenv("ACCESSIBLE") != "" {
+		if styles.Accessible() {
 			result, err = tui.RunSSHKeyAddAccessible(providerName, prefill)
 		} else {
 			result, err = tui.RunSSHKeyAdd(providerName, prefill)
@@ -109,9 +124,24 @@ func runAdd(cmd *cobra.Command, args []string) {
 			return
 		}
 
-		publicKey = result.PublicKey
-		keyName = result.Name
+		uploads = result.Keys
+	} else if githubUser != "" {
+		if keyName == "" {
+			keyName = sshkeys.DefaultGitHubKeyName(githubUser)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Fetching keys from %s\n", sshkeys.GitHubKeysURL(githubUser))
+
+		keys, err := sshkeys.FetchGitHubKeys(context.Background(), nil, githubUser)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		names := sshkeys.GitHubKeyNames(keyName, len(keys))
+		for i, key := range keys {
+			uploads = append(uploads, tui.SSHKeyUpload{Name: names[i], PublicKey: key})
+		}
 	} else {
+		var publicKey string
 		if publicKeyProvided {
 			publicKey, err = sshkeys.ValidatePublicKey(publicKeyInput)
 			if err != nil {
@@ -119,8 +149,7 @@ func runAdd(cmd *cobra.Command, args []string) {
 				return
 			}
 		} else {
-			keyPath = args[0]
-			keyPath, err = sshkeys.ExpandHomePath(keyPath)
+			keyPath, err := sshkeys.ExpandHomePath(args[0])
 			if err != nil {
 				fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
 				return
@@ -139,23 +168,29 @@ func runAdd(cmd *cobra.Command, args []string) {
 				return
 			}
 		}
+		uploads = []tui.SSHKeyUpload{{Name: keyName, PublicKey: publicKey}}
 	}
 
-	// Upload the key
-	fmt.Fprintf(cmd.ErrOrStderr(), "Uploading SSH key %q to %s...", keyName, provider.GetDisplayName())
-
+	// Upload the keys, carrying on past failures so one rejected key
+	// (e.g. a duplicate) does not block the rest of an import.
 	ctx := context.Background()
-	keySpec, err := provider.CreateSSHKey(ctx, keyName, publicKey)
-	if err != nil {
-		fmt.Fprintf(cmd.ErrOrStderr(), "\nError: %v\n", err)
-		return
-	}
+	for i, upload := range uploads {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Uploading SSH key %q to %s...", upload.Name, provider.GetDisplayName())
 
-	fmt.Fprintln(cmd.ErrOrStderr(), " done")
-	fmt.Fprintln(cmd.ErrOrStderr())
+		keySpec, err := provider.CreateSSHKey(ctx, upload.Name, upload.PublicKey)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "\nError: %v\n", err)
+			continue
+		}
+
+		fmt.Fprintln(cmd.ErrOrStderr(), " done")
+		fmt.Fprintln(cmd.ErrOrStderr())
 
-	// Print the result
-	printKeyDetails(cmd, keySpec)
+		if i > 0 {
+			fmt.Fprintln(cmd.OutOrStdout())
+		}
+		printKeyDetails(cmd, keySpec)
+	}
 }
 
 func printKeyDetails(cmd *cobra.Command, key *platformsshkey.Spec) {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -23,13 +25,21 @@ type sshKeyMockProvider struct {
 	createdKey        *platformsshkey.Spec
 	capturedName      string
 	capturedPublicKey string
+	capturedNames     []string
+	keys              []platformsshkey.Spec
+	listErr           error
 }
 
 func (m *sshKeyMockProvider) GetDisplayName() string { return m.displayName }
 
+func (m *sshKeyMockProvider) ListSSHKeys(_ context.Context) ([]platformsshkey.Spec, error) {
+	return m.keys, m.listErr
+}
+
 func (m *sshKeyMockProvider) CreateSSHKey(_ context.Context, name, publicKey string) (*platformsshkey.Spec, error) {
 	m.capturedName = name
 	m.capturedPublicKey = publicKey
+	m.capturedNames = append(m.capturedNames, name)
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
@@ -157,6 +167,80 @@ func TestAddCommand_PathAndPublicKeyConflict(t *testing.T) {
 	}
 }
 
+// stubGitHubKeys serves body as every user's .keys file.
+func stubGitHubKeys(t *testing.T, body string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	sshkeys.SetGitHubBaseURL(srv.URL)
+	t.Cleanup(sshkeys.ResetGitHubBaseURL)
+}
+
+func TestAddCommand_GitHub(t *testing.T) {
+	stubGitHubKeys(t, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFirst\nssh-rsa AAAAB3NzaC1yc2EAAAADSecond\n")
+
+	mock := &sshKeyMockProvider{displayName: "Mock"}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	stdout, stderr := execAdd(t, "mock", "--github", "Octocat")
+
+	want := []string{"github-octocat-1", "github-octocat-2"}
+	if strings.Join(mock.capturedNames, ",") != strings.Join(want, ",") {
+		t.Errorf("expected uploads %v, got %v", want, mock.capturedNames)
+	}
+	if !strings.Contains(mock.capturedPublicKey, "ssh-rsa") {
+		t.Errorf("expected second key to be uploaded last, got %q", mock.capturedPublicKey)
+	}
+	if strings.Count(stdout, "SSH key added") != 2 {
+		t.Errorf("expected two keys reported on stdout, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Fetching keys from") {
+		t.Errorf("expected fetch notice on stderr, got:\n%s", stderr)
+	}
+}
+
+func TestAddCommand_GitHubSingleKeyUsesName(t *testing.T) {
+	stubGitHubKeys(t, "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOnly\n")
+
+	mock := &sshKeyMockProvider{displayName: "Mock"}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	execAdd(t, "mock", "--github", "octocat", "--name", "alice")
+
+	if mock.capturedName != "alice" {
+		t.Errorf("expected CreateSSHKey called with name 'alice', got %q", mock.capturedName)
+	}
+}
+
+func TestAddCommand_GitHubNoKeys(t *testing.T) {
+	stubGitHubKeys(t, "")
+
+	mock := &sshKeyMockProvider{displayName: "Mock"}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	_, stderr := execAdd(t, "mock", "--github", "octocat")
+
+	if !strings.Contains(stderr, "no public SSH keys") {
+		t.Errorf("expected no keys error on stderr, got:\n%s", stderr)
+	}
+	if len(mock.capturedNames) != 0 {
+		t.Errorf("expected CreateSSHKey not to be called, got %v", mock.capturedNames)
+	}
+}
+
+func TestAddCommand_GitHubConflict(t *testing.T) {
+	mock := &sshKeyMockProvider{displayName: "Mock"}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	_, stderr := execAdd(t, "mock", "--github", "octocat", "--public-key", "ssh-ed25519 AAAA")
+
+	if !strings.Contains(stderr, "--github cannot be combined") {
+		t.Errorf("expected conflict error on stderr, got:\n%s", stderr)
+	}
+}
+
 func TestAddCommand_RSAKey(t *testing.T) {
 	keyContent := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQC... user@host"
 	keyPath := createTempSSHKey(t, keyContent)
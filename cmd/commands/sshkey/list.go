@@ -0,0 +1,119 @@
+package sshkey
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/format"
+	platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/sshkey/providers"
+	"nathanbeddoewebdev/vpsm/internal/sshkeys"
+
+	"github.com/spf13/cobra"
+)
+
+func ListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List SSH keys and whether they are available locally",
+		Long: `List the SSH keys uploaded to the cloud provider.
+
+Each key's fingerprint is matched against keys loaded in ssh-agent
+(via SSH_AUTH_SOCK) and public keys in ~/.ssh, so you can see which
+uploaded keys you can actually log in with from this machine.
+
+Examples:
+  vpsm ssh-key list
+  vpsm ssh-key list -o json
+
+  # Shape each line with a Go template
+  vpsm ssh-key list --format '{{.Name}}\t{{.Fingerprint}}\t{{.Local}}'`,
+		Args: cobra.ExactArgs(0),
+		Run:  runList,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().String("format", "", "Format each key with a Go template, e.g. '{{.Name}}\\t{{.Fingerprint}}'")
+	cmd.MarkFlagsMutuallyExclusive("output", "format")
+
+	return cmd
+}
+
+// listedKey is a provider key annotated with its local availability.
+type listedKey struct {
+	platformsshkey.Spec
+	Local string `json:"local,omitempty"`
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	providerName := cmd.Flag("provider").Value.String()
+
+	provider, err := providers.Get(providerName, auth.DefaultStore())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	keys, err := provider.ListSSHKeys(context.Background())
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	local := sshkeys.LocalKeys()
+	listed := make([]listedKey, 0, len(keys))
+	for _, k := range keys {
+		entry := listedKey{Spec: k}
+		if found := sshkeys.FindByFingerprint(local, k.Fingerprint); found != nil {
+			entry.Local = found.Source
+		}
+		listed = append(listed, entry)
+	}
+
+	if text, _ := cmd.Flags().GetString("format"); text != "" {
+		tmpl, err := format.Parse(text)
+		if err == nil {
+			err = format.Lines(cmd.OutOrStdout(), tmpl, listed)
+		}
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		}
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(listed)
+		return
+	}
+
+	if len(listed) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No SSH keys found. Upload one with 'vpsm ssh-key add'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tFINGERPRINT\tLOCAL")
+	fmt.Fprintln(w, "--\t----\t-----------\t-----")
+	available := 0
+	for _, k := range listed {
+		local := k.Local
+		if local == "" {
+			local = "-"
+		} else {
+			available++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Fingerprint, local)
+	}
+	w.Flush()
+
+	if available == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "\nWarning: none of these keys are loaded in ssh-agent or present in ~/.ssh.")
+	}
+}
@@ -0,0 +1,97 @@
+package sshkey
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+	"nathanbeddoewebdev/vpsm/internal/sshkeys"
+)
+
+// withLocalKeys points local key discovery at a temp dir containing the
+// given public keys and disables the ssh-agent lookup.
+func withLocalKeys(t *testing.T, pubKeys ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	for i, k := range pubKeys {
+		path := filepath.Join(dir, "id_"+string(rune('a'+i))+".pub")
+		if err := os.WriteFile(path, []byte(k+"\n"), 0o644); err != nil {
+			t.Fatalf("failed to write key: %v", err)
+		}
+	}
+	t.Setenv("SSH_AUTH_SOCK", "")
+	sshkeys.SetSSHDir(dir)
+	t.Cleanup(sshkeys.ResetSSHDir)
+}
+
+func execList(t *testing.T, providerName string, extraArgs ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"list", "--provider", providerName}, extraArgs...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+const listTestKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIAABAgMEBQYHCAkKCwwNDg8QERITFBUWFxgZGhscHR4f test@laptop"
+
+func TestListCommand_MarksLocalKeys(t *testing.T) {
+	withLocalKeys(t, listTestKey)
+	registerSSHKeyMockProvider(t, "mock", &sshKeyMockProvider{
+		displayName: "Mock",
+		keys: []platformsshkey.Spec{
+			{ID: "1", Name: "laptop", Fingerprint: "0f:a2:0a:d7:38:3e:65:45:08:6b:63:84:1c:ff:dc:ba"},
+			{ID: "2", Name: "old", Fingerprint: "aa:bb:cc:dd:ee:ff:00:11:22:33:44:55:66:77:88:99"},
+		},
+	})
+
+	stdout, stderr := execList(t, "mock")
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		switch {
+		case strings.Contains(line, "laptop") && !strings.Contains(line, "id_a.pub"):
+			t.Errorf("expected laptop key to be marked local, got %q", line)
+		case strings.Contains(line, "old") && !strings.HasSuffix(strings.TrimSpace(line), "-"):
+			t.Errorf("expected old key to be marked unavailable, got %q", line)
+		}
+	}
+}
+
+func TestListCommand_WarnsWhenNoneLocal(t *testing.T) {
+	withLocalKeys(t)
+	registerSSHKeyMockProvider(t, "mock", &sshKeyMockProvider{
+		displayName: "Mock",
+		keys: []platformsshkey.Spec{
+			{ID: "2", Name: "old", Fingerprint: "aa:bb:cc:dd:ee:ff:00:11:22:33:44:55:66:77:88:99"},
+		},
+	})
+
+	_, stderr := execList(t, "mock")
+
+	if !strings.Contains(stderr, "none of these keys") {
+		t.Errorf("expected warning, got stderr %q", stderr)
+	}
+}
+
+func TestListCommand_Error(t *testing.T) {
+	withLocalKeys(t)
+	registerSSHKeyMockProvider(t, "mock", &sshKeyMockProvider{
+		displayName: "Mock",
+		listErr:     errors.New("boom"),
+	})
+
+	_, stderr := execList(t, "mock")
+
+	if !strings.Contains(stderr, "Error: boom") {
+		t.Errorf("expected error, got %q", stderr)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
 
 	"github.com/spf13/cobra"
 )
@@ -11,34 +12,42 @@ import (
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "ssh-key",
+		Aliases:           []string{"key"},
 		Short:             "Manage SSH keys across cloud providers",
 		Long:              `Upload, list, and delete SSH keys from your configured cloud providers.`,
 		PersistentPreRunE: resolveProvider,
 	}
 
 	cmd.AddCommand(AddCommand())
+	cmd.AddCommand(ListCommand())
+	cmd.AddCommand(SyncCommand())
 
 	cmd.PersistentFlags().String("provider", "", "Cloud provider to use (overrides default)")
+	cmd.PersistentFlags().String("project", "", "Provider project whose token to use (overrides the active project)")
 
 	return cmd
 }
 
 // resolveProvider ensures the --provider flag has a value, falling back to the
-// configured default when the flag was not explicitly passed.
+// configured default when the flag was not explicitly passed, and selects
+// the project whose token is used: --project, or the provider's active one.
 func resolveProvider(cmd *cobra.Command, args []string) error {
-	if cmd.Flag("provider").Changed {
-		return nil
-	}
-
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if cfg.DefaultProvider != "" {
+	if !cmd.Flag("provider").Changed {
+		if cfg.DefaultProvider == "" {
+			return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+		}
 		cmd.Flag("provider").Value.Set(cfg.DefaultProvider)
-		return nil
 	}
 
-	return fmt.Errorf("no provider specified: use --provider flag or set a default with 'vpsm config set default-provider <name>'")
+	project := cfg.ActiveProject(cmd.Flag("provider").Value.String())
+	if cmd.Flag("project").Changed {
+		project = cmd.Flag("project").Value.String()
+	}
+	auth.UseProject(project)
+	return nil
 }
@@ -0,0 +1,231 @@
+package sshkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/sshkey/providers"
+	"nathanbeddoewebdev/vpsm/internal/sshkeys"
+
+	"github.com/spf13/cobra"
+)
+
+// Sync statuses reported per provider and key.
+const (
+	syncPresent    = "present"
+	syncCreated    = "created"
+	syncMissing    = "missing"
+	syncExtraneous = "extraneous"
+	syncFailed     = "failed"
+)
+
+func SyncCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync [path...]",
+		Short: "Ensure local public keys exist on every authenticated provider",
+		Long: `Upload a chosen set of local SSH public keys to every provider you are
+logged in to, so the same keys work across clouds.
+
+Keys are matched by fingerprint. Keys missing from a provider are created
+(named after the file, like 'vpsm ssh-key add'); keys on a provider that
+are not in the chosen set are reported as extraneous but never deleted.
+
+Without path arguments the default key (~/.ssh/id_ed25519.pub) is synced.
+Use --provider to sync a single provider instead of all of them.
+
+Examples:
+  vpsm ssh-key sync
+  vpsm ssh-key sync ~/.ssh/id_ed25519.pub ~/.ssh/work.pub
+  vpsm ssh-key sync --dry-run -o json`,
+		// Sync spans providers, so it does not need a default provider.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+		Run:               runSync,
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Report what would change without uploading keys")
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+
+	return cmd
+}
+
+// syncKey is a local public key selected for syncing.
+type syncKey struct {
+	Name      string
+	PublicKey string
+	local     sshkeys.LocalKey
+}
+
+// syncResult is the outcome for one key on one provider.
+type syncResult struct {
+	Provider    string `json:"provider"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{sshkeys.DefaultPath()}
+	}
+
+	keys, err := readSyncKeys(paths)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	providerNames := providers.List()
+	if cmd.Flag("provider").Changed {
+		providerNames = []string{cmd.Flag("provider").Value.String()}
+	}
+	sort.Strings(providerNames)
+
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	store := auth.DefaultStore()
+	ctx := context.Background()
+
+	var results []syncResult
+	synced := 0
+	for _, name := range providerNames {
+		provider, err := providers.Get(name, store)
+		if err != nil {
+			if errors.Is(err, auth.ErrTokenNotFound) && !cmd.Flag("provider").Changed {
+				continue
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s: %v\n", name, err)
+			continue
+		}
+		synced++
+
+		existing, err := provider.ListSSHKeys(ctx)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %s: %v\n", name, err)
+			continue
+		}
+
+		results = append(results, syncProvider(name, existing, keys, dryRun, func(key syncKey) (*platformsshkey.Spec, error) {
+			return provider.CreateSSHKey(ctx, key.Name, key.PublicKey)
+		})...)
+	}
+
+	if synced == 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "Error: no authenticated providers. Log in with 'vpsm auth login <provider>'.")
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+		return
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PROVIDER\tNAME\tFINGERPRINT\tSTATUS")
+	fmt.Fprintln(w, "--------\t----\t-----------\t------")
+	for _, r := range results {
+		status := r.Status
+		if r.Error != "" {
+			status += ": " + r.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Provider, r.Name, r.Fingerprint, status)
+	}
+	w.Flush()
+
+	if dryRun {
+		fmt.Fprintln(cmd.ErrOrStderr(), "\nDry run: no keys were uploaded.")
+	}
+}
+
+// readSyncKeys reads and validates the public key at each path.
+func readSyncKeys(paths []string) ([]syncKey, error) {
+	keys := make([]syncKey, 0, len(paths))
+	for _, path := range paths {
+		expanded, err := sshkeys.ExpandHomePath(path)
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := sshkeys.ReadAndValidatePublicKey(expanded)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		local, err := sshkeys.ParsePublicKey(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, syncKey{
+			Name:      sshkeys.SuggestKeyName(expanded),
+			PublicKey: publicKey,
+			local:     local,
+		})
+	}
+	return keys, nil
+}
+
+// syncProvider compares a provider's keys with the chosen local keys,
+// creating missing ones through create unless dryRun is set.
+func syncProvider(providerName string, existing []platformsshkey.Spec, keys []syncKey, dryRun bool, create func(syncKey) (*platformsshkey.Spec, error)) []syncResult {
+	var results []syncResult
+	matched := make(map[int]bool, len(existing))
+
+	for _, key := range keys {
+		found := -1
+		for i, spec := range existing {
+			if key.local.MatchesFingerprint(spec.Fingerprint) {
+				found = i
+				break
+			}
+		}
+		if found >= 0 {
+			matched[found] = true
+			results = append(results, syncResult{
+				Provider:    providerName,
+				Name:        existing[found].Name,
+				Fingerprint: existing[found].Fingerprint,
+				Status:      syncPresent,
+			})
+			continue
+		}
+
+		result := syncResult{
+			Provider:    providerName,
+			Name:        key.Name,
+			Fingerprint: key.local.FingerprintMD5(),
+			Status:      syncMissing,
+		}
+		if !dryRun {
+			if spec, err := create(key); err != nil {
+				result.Status, result.Error = syncFailed, err.Error()
+			} else {
+				result.Status = syncCreated
+				result.Name = spec.Name
+				if spec.Fingerprint != "" {
+					result.Fingerprint = spec.Fingerprint
+				}
+			}
+		}
+		results = append(results, result)
+	}
+
+	for i, spec := range existing {
+		if matched[i] {
+			continue
+		}
+		results = append(results, syncResult{
+			Provider:    providerName,
+			Name:        spec.Name,
+			Fingerprint: spec.Fingerprint,
+			Status:      syncExtraneous,
+		})
+	}
+	return results
+}
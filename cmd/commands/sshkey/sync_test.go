@@ -0,0 +1,123 @@
+package sshkey
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+)
+
+// listTestKeyMD5 is the MD5 fingerprint of listTestKey.
+const listTestKeyMD5 = "0f:a2:0a:d7:38:3e:65:45:08:6b:63:84:1c:ff:dc:ba"
+
+func writeSyncKey(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return path
+}
+
+func execSync(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(append([]string{"sync"}, args...))
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func decodeSyncResults(t *testing.T, stdout string) []syncResult {
+	t.Helper()
+	var results []syncResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("failed to decode output: %v\n%s", err, stdout)
+	}
+	return results
+}
+
+func TestSyncCommand_CreatesMissingAndReportsExtraneous(t *testing.T) {
+	path := writeSyncKey(t, "work.pub", listTestKey)
+	mock := &sshKeyMockProvider{
+		displayName: "Mock",
+		keys: []platformsshkey.Spec{
+			{ID: "2", Name: "old", Fingerprint: "aa:bb:cc:dd:ee:ff:00:11:22:33:44:55:66:77:88:99"},
+		},
+	}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	stdout, stderr := execSync(t, path, "-o", "json")
+	if stderr != "" {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+
+	if mock.capturedName != "work" || mock.capturedPublicKey != listTestKey {
+		t.Errorf("expected key 'work' to be uploaded, got name=%q key=%q", mock.capturedName, mock.capturedPublicKey)
+	}
+
+	results := decodeSyncResults(t, stdout)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+	if results[0].Status != syncCreated || results[0].Name != "work" {
+		t.Errorf("expected work to be created, got %+v", results[0])
+	}
+	if results[1].Status != syncExtraneous || results[1].Name != "old" {
+		t.Errorf("expected old to be extraneous, got %+v", results[1])
+	}
+}
+
+func TestSyncCommand_PresentKeyNotUploaded(t *testing.T) {
+	path := writeSyncKey(t, "work.pub", listTestKey)
+	mock := &sshKeyMockProvider{
+		displayName: "Mock",
+		keys:        []platformsshkey.Spec{{ID: "1", Name: "laptop", Fingerprint: listTestKeyMD5}},
+	}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	stdout, _ := execSync(t, path, "-o", "json")
+
+	if len(mock.capturedNames) != 0 {
+		t.Errorf("expected no uploads, got %v", mock.capturedNames)
+	}
+	results := decodeSyncResults(t, stdout)
+	if len(results) != 1 || results[0].Status != syncPresent || results[0].Name != "laptop" {
+		t.Errorf("expected laptop to be present, got %+v", results)
+	}
+}
+
+func TestSyncCommand_DryRun(t *testing.T) {
+	path := writeSyncKey(t, "work.pub", listTestKey)
+	mock := &sshKeyMockProvider{displayName: "Mock"}
+	registerSSHKeyMockProvider(t, "mock", mock)
+
+	stdout, stderr := execSync(t, path, "--dry-run")
+
+	if len(mock.capturedNames) != 0 {
+		t.Errorf("expected no uploads in dry run, got %v", mock.capturedNames)
+	}
+	if !strings.Contains(stdout, syncMissing) {
+		t.Errorf("expected missing status, got:\n%s", stdout)
+	}
+	if !strings.Contains(stderr, "Dry run") {
+		t.Errorf("expected dry run notice, got:\n%s", stderr)
+	}
+}
+
+func TestSyncCommand_InvalidKey(t *testing.T) {
+	path := writeSyncKey(t, "bad.pub", "not a key")
+	registerSSHKeyMockProvider(t, "mock", &sshKeyMockProvider{displayName: "Mock"})
+
+	_, stderr := execSync(t, path)
+
+	if !strings.Contains(stderr, "bad.pub") {
+		t.Errorf("expected error naming the file, got:\n%s", stderr)
+	}
+}
@@ -0,0 +1,138 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
+
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show local usage statistics",
+		Long: `Show how often each command has run and how long provider API calls take.
+
+Statistics are recorded only on this machine and are never sent anywhere.
+They help spot slow providers and can be shared when reporting performance
+problems. Turn recording off with 'vpsm config set usage-stats off'.
+
+Examples:
+  vpsm stats
+  vpsm stats -o json
+  vpsm stats --reset`,
+		Args: cobra.ExactArgs(0),
+		Run:  runStats,
+	}
+
+	cmd.Flags().StringP("output", "o", "table", "Output format: table or json")
+	cmd.Flags().Bool("reset", false, "Delete all recorded statistics")
+
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	repo, err := usagestats.Open()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+	defer repo.Close()
+
+	if reset, _ := cmd.Flags().GetBool("reset"); reset {
+		if err := repo.Reset(); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+			return
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "Usage statistics cleared.")
+		return
+	}
+
+	stats, err := repo.Summary()
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Error: %v\n", err)
+		return
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "json" {
+		if stats == nil {
+			stats = []usagestats.Stat{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.Encode(stats)
+		return
+	}
+
+	if len(stats) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No usage recorded yet.")
+		return
+	}
+
+	var commands, calls []usagestats.Stat
+	for _, s := range stats {
+		switch s.Kind {
+		case usagestats.KindCommand:
+			commands = append(commands, s)
+		case usagestats.KindProviderCall:
+			calls = append(calls, s)
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if len(commands) > 0 {
+		fmt.Fprintln(out, "Commands")
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "COMMAND\tRUNS\tAVG TIME\tLAST RUN")
+		fmt.Fprintln(w, "-------\t----\t--------\t--------")
+		for _, s := range commands {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", s.Name, s.Count, formatDuration(s.Avg), s.LastSeen.Local().Format("2006-01-02 15:04"))
+		}
+		w.Flush()
+	}
+
+	if len(calls) > 0 {
+		if len(commands) > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintln(out, "Provider calls")
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "PROVIDER\tCALL\tCOUNT\tERRORS\tAVG\tMAX")
+		fmt.Fprintln(w, "--------\t----\t-----\t------\t---\t---")
+		for _, s := range calls {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", s.Provider, s.Name, s.Count, s.Failures, formatDuration(s.Avg), formatDuration(s.Max))
+		}
+		w.Flush()
+	}
+
+	var failed []usagestats.Stat
+	for _, s := range calls {
+		if s.LastFailedRequestID != "" {
+			failed = append(failed, s)
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Last failed requests (quote the ID in provider support tickets)")
+		w := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "PROVIDER\tCALL\tREQUEST ID")
+		fmt.Fprintln(w, "--------\t----\t----------")
+		for _, s := range failed {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Provider, s.Name, s.LastFailedRequestID)
+		}
+		w.Flush()
+	}
+}
+
+// formatDuration rounds d to a precision that reads well in a table.
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
@@ -0,0 +1,109 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
+)
+
+func setupStats(t *testing.T, events ...usagestats.Event) {
+	t.Helper()
+	usagestats.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(usagestats.ResetPath)
+
+	repo, err := usagestats.Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repo.Close()
+	if err := repo.Record(events); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+}
+
+func execStats(t *testing.T, args ...string) (stdout, stderr string) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	cmd := NewCommand()
+	cmd.SetOut(&outBuf)
+	cmd.SetErr(&errBuf)
+	cmd.SetArgs(args)
+	cmd.Execute()
+	return outBuf.String(), errBuf.String()
+}
+
+func TestStats_Empty(t *testing.T) {
+	setupStats(t)
+
+	stdout, _ := execStats(t)
+
+	if !strings.Contains(stdout, "No usage recorded yet.") {
+		t.Errorf("expected empty message, got: %s", stdout)
+	}
+}
+
+func TestStats_Table(t *testing.T) {
+	setupStats(t,
+		usagestats.Event{Kind: usagestats.KindCommand, Name: "server list", Duration: 1500 * time.Millisecond},
+		usagestats.Event{Kind: usagestats.KindProviderCall, Provider: "hetzner", Name: "GET /v1/servers", Duration: 250 * time.Millisecond},
+	)
+
+	stdout, stderr := execStats(t)
+
+	if stderr != "" {
+		t.Errorf("unexpected stderr: %s", stderr)
+	}
+	for _, want := range []string{"Commands", "server list", "1.5s", "Provider calls", "hetzner", "GET /v1/servers", "250ms"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestStats_JSON(t *testing.T) {
+	setupStats(t, usagestats.Event{Kind: usagestats.KindCommand, Name: "server list"})
+
+	stdout, _ := execStats(t, "-o", "json")
+
+	var got []usagestats.Stat
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(got) != 1 || got[0].Name != "server list" {
+		t.Errorf("unexpected stats: %+v", got)
+	}
+}
+
+func TestStats_Reset(t *testing.T) {
+	setupStats(t, usagestats.Event{Kind: usagestats.KindCommand, Name: "server list"})
+
+	stdout, _ := execStats(t, "--reset")
+	if !strings.Contains(stdout, "cleared") {
+		t.Errorf("expected confirmation, got: %s", stdout)
+	}
+
+	stdout, _ = execStats(t)
+	if !strings.Contains(stdout, "No usage recorded yet.") {
+		t.Errorf("expected stats to be empty after reset, got: %s", stdout)
+	}
+}
+
+func TestStats_Table_LastFailedRequestIDs(t *testing.T) {
+	setupStats(t,
+		usagestats.Event{Kind: usagestats.KindProviderCall, Provider: "hetzner", Name: "GET /v1/servers", Duration: 250 * time.Millisecond},
+		usagestats.Event{Kind: usagestats.KindProviderCall, Provider: "hetzner", Name: "POST /v1/servers", Duration: 300 * time.Millisecond, Failed: true, RequestID: "d5064a1f0bb9de4b"},
+	)
+
+	stdout, _ := execStats(t)
+
+	for _, want := range []string{"Last failed requests", "POST /v1/servers", "d5064a1f0bb9de4b"} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, stdout)
+		}
+	}
+}
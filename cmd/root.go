@@ -1,16 +1,36 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"nathanbeddoewebdev/vpsm/cmd/commands/auth"
 	cfgcmd "nathanbeddoewebdev/vpsm/cmd/commands/config"
+	"nathanbeddoewebdev/vpsm/cmd/commands/cost"
+	"nathanbeddoewebdev/vpsm/cmd/commands/dns"
+	"nathanbeddoewebdev/vpsm/cmd/commands/find"
+	"nathanbeddoewebdev/vpsm/cmd/commands/group"
+	"nathanbeddoewebdev/vpsm/cmd/commands/ip"
+	"nathanbeddoewebdev/vpsm/cmd/commands/network"
+	proxycmd "nathanbeddoewebdev/vpsm/cmd/commands/proxy"
 	"nathanbeddoewebdev/vpsm/cmd/commands/server"
 	"nathanbeddoewebdev/vpsm/cmd/commands/sshkey"
+	"nathanbeddoewebdev/vpsm/cmd/commands/stats"
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
 	serverproviders "nathanbeddoewebdev/vpsm/internal/server/providers"
+	authsvc "nathanbeddoewebdev/vpsm/internal/services/auth"
 	sshkeyproviders "nathanbeddoewebdev/vpsm/internal/sshkey/providers"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -22,19 +42,48 @@ func rootCmd() *cobra.Command {
 multiple cloud providers. It supports creating, listing, and deleting
 servers, with interactive TUI wizards for guided workflows.
 
-Supported providers: Hetzner (more coming soon).
+Supported providers: Hetzner, DigitalOcean.
 
 Quick start:
   vpsm auth login hetzner          # Store your API token
   vpsm server list                 # List all servers
   vpsm server create               # Interactive server creation
-  vpsm server delete               # Interactive server deletion`,
+  vpsm server delete               # Interactive server deletion
+  vpsm group run web -- uptime     # Run a command on a server group
+  vpsm find tag:env=prod           # Find resources by label across providers
+  vpsm cost                        # Estimate monthly spend on servers
+
+Several accounts at one provider are kept apart as profiles: log in with
+'vpsm auth login hetzner:work', then pick one with --profile hetzner:work,
+or for a directory tree with a .vpsm.json file such as
+{"profiles": ["hetzner:work"]}. --project takes precedence over both.
+
+Short aliases: s (server), g (group), key (ssh-key), ls (list), rm (delete).
+Define your own with 'vpsm config alias set <name> <command...>'.
+
+Set ACCESSIBLE=1 for screen-reader friendly TUIs: no box drawing, content
+laid out line by line, and state changes announced as plain sentences.
+TUIs draw inline (as with --inline) on terminals without an alternate
+screen, and switch to that renderer on terminals smaller than 60x16.`,
 	}
 
+	cmd.PersistentFlags().Bool("no-color", false, "Disable colored output (NO_COLOR is also honored)")
+	cmd.PersistentFlags().Bool("ascii", false, "Use ASCII-only output: no unicode symbols, rounded borders or charts")
+	cmd.PersistentFlags().Bool("inline", false, "Draw TUIs below the prompt instead of on the terminal's alternate screen")
+	cmd.PersistentFlags().StringSlice("profile", nil, "Token profile to use, as <provider>:<profile> (repeatable; overrides .vpsm.json)")
+
 	cmd.AddCommand(auth.NewCommand())
 	cmd.AddCommand(cfgcmd.NewCommand())
+	cmd.AddCommand(cost.NewCommand())
+	cmd.AddCommand(dns.NewCommand())
+	cmd.AddCommand(find.NewCommand())
+	cmd.AddCommand(group.NewCommand())
+	cmd.AddCommand(ip.NewCommand())
+	cmd.AddCommand(network.NewCommand())
+	cmd.AddCommand(proxycmd.NewCommand())
 	cmd.AddCommand(server.NewCommand())
 	cmd.AddCommand(sshkey.NewCommand())
+	cmd.AddCommand(stats.NewCommand())
 
 	return cmd
 }
@@ -43,11 +92,142 @@ Quick start:
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	serverproviders.RegisterHetzner()
+	serverproviders.RegisterDigitalOcean()
 	sshkeyproviders.RegisterHetzner()
+	dnsproviders.RegisterHetzner()
+	dnsproviders.RegisterDeSEC()
+	dnsproviders.RegisterCloudflare()
+	setLocale()
+	installProxy()
 
 	var root = rootCmd()
-	err := root.Execute()
+	cobra.OnInitialize(func() {
+		applyOutputMode(root)
+		cobra.CheckErr(applyProfiles(root))
+	})
+	if cfg, err := config.Load(); err == nil {
+		root.SetArgs(expandAlias(root, os.Args[1:], cfg.Aliases))
+	}
+	start := time.Now()
+	executed, err := root.ExecuteC()
+	recordUsage(executed, time.Since(start), err)
 	if err != nil {
+		// Commands that pass on a remote exit status (server exec) return
+		// an error carrying it.
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) && coded.ExitCode() > 0 {
+			os.Exit(coded.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
+
+// setLocale selects the message language from the config or environment.
+func setLocale() {
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Locale
+	}
+	i18n.SetLocale(i18n.ResolveLocale(configured))
+}
+
+// installProxy routes HTTP clients through the configured proxy, or the
+// *_PROXY environment variables when none is set.
+func installProxy() {
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Proxy
+	}
+	proxy.Install(configured)
+}
+
+// applyProfiles selects the token profiles listed in the nearest
+// .vpsm.json and by --profile, which wins for the providers it names.
+// Problems with .vpsm.json are only warned about, so they don't stop
+// commands that need no token; a malformed --profile is an error.
+func applyProfiles(root *cobra.Command) error {
+	if wd, err := os.Getwd(); err == nil {
+		local, path, err := config.FindLocal(wd)
+		if err != nil {
+			fmt.Fprintf(root.ErrOrStderr(), "Warning: ignoring profiles: %v\n", err)
+		}
+		if local != nil {
+			for _, name := range local.Profiles {
+				if err := useProfile(name); err != nil {
+					fmt.Fprintf(root.ErrOrStderr(), "Warning: ignoring %s: %v\n", path, err)
+				}
+			}
+		}
+	}
+
+	flagNames, _ := root.PersistentFlags().GetStringSlice("profile")
+	for _, name := range flagNames {
+		if err := useProfile(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// useProfile selects a "<provider>:<profile>" token profile.
+func useProfile(name string) error {
+	provider, profile := authsvc.ParseProfile(name)
+	if provider == "" || profile == "" {
+		return fmt.Errorf("invalid profile %q: use <provider>:<profile>, e.g. hetzner:work", name)
+	}
+	config.UseProfile(provider, profile)
+	return nil
+}
+
+// applyOutputMode turns off color and unicode output when asked to by
+// flags or the environment. NO_COLOR (https://no-color.org) disables
+// color; TERM=dumb disables both. ACCESSIBLE switches the TUIs to the
+// screen-reader friendly renderer. Terminals without an alternate screen
+// get inline TUIs, as with --inline, and terminals too small for the
+// full-window layouts get the accessible renderer.
+func applyOutputMode(root *cobra.Command) {
+	noColor, _ := root.PersistentFlags().GetBool("no-color")
+	ascii, _ := root.PersistentFlags().GetBool("ascii")
+	inline, _ := root.PersistentFlags().GetBool("inline")
+	dumb := os.Getenv("TERM") == "dumb"
+
+	var limits styles.TerminalLimits
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		width, height, _ := term.GetSize(int(os.Stdout.Fd()))
+		limits = styles.DetectLimits(os.Getenv("TERM"), width, height)
+	}
+
+	if noColor || os.Getenv("NO_COLOR") != "" || dumb {
+		styles.DisableColor()
+	}
+	if ascii || dumb {
+		styles.SetASCII(true)
+	}
+	if inline || limits.NoAltScreen {
+		styles.SetInline(true)
+	}
+	if os.Getenv("ACCESSIBLE") != "" || limits.TooSmall {
+		styles.SetAccessible(true)
+	}
+}
+
+// recordUsage stores the command run and any provider calls it made in
+// the local usage statistics, unless the user has turned them off.
+// Failures are ignored: statistics must never get in the way of a command.
+func recordUsage(executed *cobra.Command, elapsed time.Duration, err error) {
+	if executed == nil {
+		return
+	}
+	if cfg, cfgErr := config.Load(); cfgErr == nil && !cfg.UsageStatsEnabled() {
+		usagestats.Drain()
+		return
+	}
+
+	usagestats.Observe(usagestats.Event{
+		Kind:     usagestats.KindCommand,
+		Name:     strings.TrimPrefix(executed.CommandPath(), executed.Root().Name()+" "),
+		Duration: elapsed,
+		Failed:   err != nil,
+	})
+	usagestats.Flush()
+}
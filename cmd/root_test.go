@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+)
+
+func TestApplyProfiles_FlagOverridesLocalFile(t *testing.T) {
+	t.Cleanup(config.ResetProfiles)
+	dir := t.TempDir()
+	local := `{"profiles": ["hetzner:work", "desec:personal"]}`
+	if err := os.WriteFile(filepath.Join(dir, config.LocalFileName), []byte(local), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	root := rootCmd()
+	root.PersistentFlags().Set("profile", "hetzner:client")
+	if err := applyProfiles(root); err != nil {
+		t.Fatalf("applyProfiles() error: %v", err)
+	}
+
+	cfg := &config.Config{}
+	if got := cfg.ActiveProject("hetzner"); got != "client" {
+		t.Errorf("hetzner profile = %q, want the --profile one", got)
+	}
+	if got := cfg.ActiveProject("desec"); got != "personal" {
+		t.Errorf("desec profile = %q, want the .vpsm.json one", got)
+	}
+}
+
+func TestApplyProfiles_RejectsNameWithoutProfile(t *testing.T) {
+	t.Cleanup(config.ResetProfiles)
+	t.Chdir(t.TempDir())
+
+	root := rootCmd()
+	root.PersistentFlags().Set("profile", "hetzner")
+	if err := applyProfiles(root); err == nil {
+		t.Error("expected an error for a profile without a name")
+	}
+}
+
+func TestApplyProfiles_WarnsAboutMalformedLocalFile(t *testing.T) {
+	t.Cleanup(config.ResetProfiles)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, config.LocalFileName), []byte(`{"profiles": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	root := rootCmd()
+	var stderr bytes.Buffer
+	root.SetErr(&stderr)
+	if err := applyProfiles(root); err != nil {
+		t.Fatalf("applyProfiles() error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Warning: ignoring profiles") {
+		t.Errorf("stderr = %q, want a warning", stderr.String())
+	}
+}
+
+func TestApplyProfiles_SkipsMalformedLocalProfile(t *testing.T) {
+	t.Cleanup(config.ResetProfiles)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, config.LocalFileName), []byte(`{"profiles": ["hetzner", "desec:personal"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	root := rootCmd()
+	var stderr bytes.Buffer
+	root.SetErr(&stderr)
+	if err := applyProfiles(root); err != nil {
+		t.Fatalf("applyProfiles() error: %v", err)
+	}
+	if !strings.Contains(stderr.String(), `invalid profile "hetzner"`) {
+		t.Errorf("stderr = %q, want a warning about the malformed profile", stderr.String())
+	}
+	cfg := &config.Config{}
+	if got := cfg.ActiveProject("desec"); got != "personal" {
+		t.Errorf("desec profile = %q, want the valid entry applied", got)
+	}
+}
@@ -0,0 +1,111 @@
+package actionstore
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// LockTTL is how long a server lock is honoured. A lock older than this
+// is treated as abandoned even if its process ID is in use again.
+const LockTTL = time.Hour
+
+// ErrServerBusy is matched by the error AcquireLock returns when another
+// vpsm process holds the server.
+var ErrServerBusy = errors.New("server is busy")
+
+// LockedError reports the process that holds a server lock.
+type LockedError struct {
+	ServerID   string
+	Command    string
+	PID        int
+	AcquiredAt time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("server %s is busy: another vpsm process (pid %d) started %s on it at %s",
+		e.ServerID, e.PID, e.Command, e.AcquiredAt.Local().Format("15:04:05"))
+}
+
+// Is makes errors.Is(err, ErrServerBusy) match a *LockedError.
+func (e *LockedError) Is(target error) bool { return target == ErrServerBusy }
+
+// currentPID and processAlive are variables so tests can simulate other
+// processes.
+var (
+	currentPID   = os.Getpid
+	processAlive = isProcessAlive
+)
+
+// isProcessAlive reports whether a process with the given ID is running.
+func isProcessAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Windows FindProcess only succeeds for running processes.
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// AcquireLock claims serverID on provider for command on behalf of this
+// process. It returns a *LockedError when another live process holds the
+// server. Locks left behind by exited processes, or older than LockTTL,
+// are taken over. Acquiring a lock this process already holds succeeds.
+func (r *SQLiteRepository) AcquireLock(provider, serverID, command string) error {
+	pid := currentPID()
+	now := time.Now().UTC()
+
+	var holderPID int
+	var holderCommand, acquiredStr string
+	err := r.db.QueryRow(`
+		SELECT pid, command, acquired_at FROM server_locks
+		WHERE provider = ? AND server_id = ?`, provider, serverID).Scan(&holderPID, &holderCommand, &acquiredStr)
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return fmt.Errorf("actions: query failed: %w", err)
+	default:
+		acquired, _ := time.Parse(time.RFC3339Nano, acquiredStr)
+		if holderPID != pid && processAlive(holderPID) && now.Sub(acquired) < LockTTL {
+			return &LockedError{ServerID: serverID, Command: holderCommand, PID: holderPID, AcquiredAt: acquired}
+		}
+		// Only remove the lock that was inspected, so a process that
+		// took it over in the meantime keeps it.
+		if _, err := r.db.Exec(`
+			DELETE FROM server_locks WHERE provider = ? AND server_id = ? AND pid = ? AND acquired_at = ?`,
+			provider, serverID, holderPID, acquiredStr); err != nil {
+			return fmt.Errorf("actions: delete failed: %w", err)
+		}
+	}
+
+	result, err := r.db.Exec(`
+		INSERT INTO server_locks (provider, server_id, command, pid, acquired_at)
+		VALUES (?, ?, ?, ?, ?) ON CONFLICT (provider, server_id) DO NOTHING`,
+		provider, serverID, command, pid, now.Format(time.RFC3339Nano))
+	if err != nil {
+		return fmt.Errorf("actions: insert failed: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		// Another process won the race for the lock.
+		return r.AcquireLock(provider, serverID, command)
+	}
+	return nil
+}
+
+// ReleaseLock drops this process's lock on serverID, if it holds one.
+func (r *SQLiteRepository) ReleaseLock(provider, serverID string) error {
+	if _, err := r.db.Exec(`
+		DELETE FROM server_locks WHERE provider = ? AND server_id = ? AND pid = ?`,
+		provider, serverID, currentPID()); err != nil {
+		return fmt.Errorf("actions: delete failed: %w", err)
+	}
+	return nil
+}
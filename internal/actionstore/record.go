@@ -21,7 +21,8 @@ type ActionRecord struct {
 	// ServerName is the human-readable server name (for display).
 	ServerName string
 
-	// Command describes the operation, e.g. "start_server", "stop_server".
+	// Command describes the operation, e.g. "start_server", "stop_server",
+	// "reboot_server", "resize_server", "restore_backup".
 	Command string
 
 	// TargetStatus is the expected server status when the action completes
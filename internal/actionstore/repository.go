@@ -2,7 +2,9 @@
 //
 // When a user starts or stops a server, the CLI tracks the action locally
 // so that if the process is interrupted (Ctrl+C, crash, etc.) the action
-// can be resumed on the next invocation.
+// can be resumed on the next invocation. It also keeps advisory per-server
+// locks so that two vpsm processes don't run conflicting operations on the
+// same server at once.
 //
 // Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
 // (or the platform-equivalent path returned by os.UserConfigDir).
@@ -15,6 +17,8 @@ import (
 	"path/filepath"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -54,6 +58,18 @@ type ActionRepository interface {
 	// Returns the number of records removed.
 	DeleteOlderThan(d time.Duration) (int64, error)
 
+	// TypicalDuration returns the median duration of recent successful
+	// runs of command on provider, or 0 if none have been recorded.
+	TypicalDuration(provider, command string) (time.Duration, error)
+
+	// AcquireLock claims a server for command so other vpsm processes
+	// don't start conflicting operations on it. It fails with an error
+	// matching ErrServerBusy while another live process holds the server.
+	AcquireLock(provider, serverID, command string) error
+
+	// ReleaseLock drops this process's claim on a server.
+	ReleaseLock(provider, serverID string) error
+
 	// Close releases database resources.
 	Close() error
 }
@@ -106,8 +122,26 @@ func OpenAt(path string) (*SQLiteRepository, error) {
 	return r, nil
 }
 
-// migrate creates the actions table if it doesn't exist.
+// maxDurationSamples is how many recent durations are kept per provider
+// and command. Older samples are pruned as new ones are recorded.
+const maxDurationSamples = 20
+
+// migrations are the schema versions of the action store's tables,
+// oldest first. Add changes as new migrations; never edit one that has
+// shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createActionTables},
+}
+
+// migrate brings the action store's tables up to the latest schema.
 func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "actions", migrations)
+}
+
+// createActionTables creates the actions, action_durations and
+// server_locks tables if they don't exist. Durations live in their own
+// table so they outlive the action records that DeleteOlderThan prunes.
+func createActionTables(tx *sql.Tx) error {
 	const ddl = `
 		CREATE TABLE IF NOT EXISTS actions (
 			id            INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -124,11 +158,25 @@ func (r *SQLiteRepository) migrate() error {
 			updated_at    TEXT    NOT NULL DEFAULT (datetime('now'))
 		);
 		CREATE INDEX IF NOT EXISTS idx_actions_status ON actions(status);
+		CREATE TABLE IF NOT EXISTS action_durations (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider    TEXT    NOT NULL,
+			command     TEXT    NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			finished_at TEXT    NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_action_durations_command ON action_durations(provider, command);
+		CREATE TABLE IF NOT EXISTS server_locks (
+			provider    TEXT    NOT NULL,
+			server_id   TEXT    NOT NULL,
+			command     TEXT    NOT NULL DEFAULT '',
+			pid         INTEGER NOT NULL,
+			acquired_at TEXT    NOT NULL,
+			PRIMARY KEY (provider, server_id)
+		);
 	`
-	if _, err := r.db.Exec(ddl); err != nil {
-		return fmt.Errorf("actions: migration failed: %w", err)
-	}
-	return nil
+	_, err := tx.Exec(ddl)
+	return err
 }
 
 // Save inserts a new record (ID == 0) or updates an existing one.
@@ -159,6 +207,11 @@ func (r *SQLiteRepository) Save(record *ActionRecord) error {
 	}
 
 	// Update
+	if record.Status == "success" {
+		if err := r.recordDuration(record); err != nil {
+			return err
+		}
+	}
 	result, err := r.db.Exec(`
 		UPDATE actions SET action_id=?, provider=?, server_id=?, server_name=?,
 		       command=?, target_status=?, status=?, progress=?, error_message=?,
@@ -232,6 +285,73 @@ func (r *SQLiteRepository) DeleteOlderThan(d time.Duration) (int64, error) {
 	return result.RowsAffected()
 }
 
+// recordDuration stores how long record took when it first turns
+// successful, measured from its stored creation time.
+func (r *SQLiteRepository) recordDuration(record *ActionRecord) error {
+	var status, createdStr string
+	err := r.db.QueryRow(`SELECT status, created_at FROM actions WHERE id = ?`, record.ID).Scan(&status, &createdStr)
+	if err == sql.ErrNoRows || status == "success" {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("actions: query failed: %w", err)
+	}
+	created, err := time.Parse(time.RFC3339Nano, createdStr)
+	if err != nil || record.Command == "" {
+		return nil
+	}
+
+	d := record.UpdatedAt.Sub(created)
+	if d <= 0 {
+		return nil
+	}
+	if _, err := r.db.Exec(`
+		INSERT INTO action_durations (provider, command, duration_ms, finished_at)
+		VALUES (?, ?, ?, ?)`,
+		record.Provider, record.Command, d.Milliseconds(), record.UpdatedAt.Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("actions: insert failed: %w", err)
+	}
+	if _, err := r.db.Exec(`
+		DELETE FROM action_durations
+		WHERE provider = ? AND command = ? AND id NOT IN (
+			SELECT id FROM action_durations WHERE provider = ? AND command = ?
+			ORDER BY id DESC LIMIT ?)`,
+		record.Provider, record.Command, record.Provider, record.Command, maxDurationSamples,
+	); err != nil {
+		return fmt.Errorf("actions: prune failed: %w", err)
+	}
+	return nil
+}
+
+// TypicalDuration returns the median of the recorded durations for
+// command on provider, or 0 if there are none.
+func (r *SQLiteRepository) TypicalDuration(provider, command string) (time.Duration, error) {
+	rows, err := r.db.Query(`
+		SELECT duration_ms FROM action_durations
+		WHERE provider = ? AND command = ? ORDER BY duration_ms`, provider, command)
+	if err != nil {
+		return 0, fmt.Errorf("actions: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var samples []int64
+	for rows.Next() {
+		var ms int64
+		if err := rows.Scan(&ms); err != nil {
+			return 0, fmt.Errorf("actions: scan failed: %w", err)
+		}
+		samples = append(samples, ms)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("actions: query failed: %w", err)
+	}
+	if len(samples) == 0 {
+		return 0, nil
+	}
+	return time.Duration(samples[len(samples)/2]) * time.Millisecond, nil
+}
+
 // Close releases database resources.
 func (r *SQLiteRepository) Close() error {
 	return r.db.Close()
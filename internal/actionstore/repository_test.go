@@ -1,6 +1,7 @@
 package actionstore
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -81,6 +82,81 @@ func TestSave_Update(t *testing.T) {
 	}
 }
 
+// completeAction saves a stop_server action that started took ago and
+// then marks it successful.
+func completeAction(t *testing.T, r *SQLiteRepository, took time.Duration) {
+	t.Helper()
+	record := &ActionRecord{
+		Provider:  "hetzner",
+		ServerID:  "42",
+		Command:   "stop_server",
+		Status:    "running",
+		CreatedAt: time.Now().UTC().Add(-took),
+	}
+	if err := r.Save(record); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	record.Status = "success"
+	if err := r.Save(record); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	// Saving a finished record again must not count it twice.
+	if err := r.Save(record); err != nil {
+		t.Fatalf("second update failed: %v", err)
+	}
+}
+
+func TestTypicalDuration_MedianOfSuccessfulRuns(t *testing.T) {
+	r := tempRepo(t)
+
+	if d, err := r.TypicalDuration("hetzner", "stop_server"); err != nil || d != 0 {
+		t.Fatalf("expected no history, got %v (err %v)", d, err)
+	}
+
+	completeAction(t, r, 20*time.Second)
+	completeAction(t, r, 25*time.Second)
+	completeAction(t, r, 90*time.Second)
+
+	d, err := r.TypicalDuration("hetzner", "stop_server")
+	if err != nil {
+		t.Fatalf("TypicalDuration failed: %v", err)
+	}
+	if d < 25*time.Second || d > 26*time.Second {
+		t.Errorf("expected the median of ~25s, got %v", d)
+	}
+	if d, _ := r.TypicalDuration("hetzner", "start_server"); d != 0 {
+		t.Errorf("expected no history for another command, got %v", d)
+	}
+}
+
+func TestTypicalDuration_IgnoresFailuresAndKeepsRecentSamples(t *testing.T) {
+	r := tempRepo(t)
+
+	failed := &ActionRecord{Provider: "hetzner", Command: "stop_server", Status: "running", CreatedAt: time.Now().UTC().Add(-time.Hour)}
+	if err := r.Save(failed); err != nil {
+		t.Fatalf("insert failed: %v", err)
+	}
+	failed.Status = "error"
+	if err := r.Save(failed); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	for i := 0; i < maxDurationSamples+5; i++ {
+		completeAction(t, r, 10*time.Second)
+	}
+
+	var n int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM action_durations`).Scan(&n); err != nil {
+		t.Fatalf("count failed: %v", err)
+	}
+	if n != maxDurationSamples {
+		t.Errorf("expected %d samples kept, got %d", maxDurationSamples, n)
+	}
+	if d, _ := r.TypicalDuration("hetzner", "stop_server"); d > 11*time.Second {
+		t.Errorf("expected the failed run to be ignored, got %v", d)
+	}
+}
+
 func TestSave_UpdateNotFound(t *testing.T) {
 	r := tempRepo(t)
 
@@ -309,3 +385,89 @@ func TestSQLiteRepository_CreatesDirectory(t *testing.T) {
 		t.Errorf("expected file to exist at %s, got error: %v", path, err)
 	}
 }
+
+func TestAcquireLock_HeldByOtherProcess(t *testing.T) {
+	r := tempRepo(t)
+
+	origPID, origAlive := currentPID, processAlive
+	t.Cleanup(func() { currentPID, processAlive = origPID, origAlive })
+	processAlive = func(int) bool { return true }
+
+	currentPID = func() int { return 100 }
+	if err := r.AcquireLock("hetzner", "42", "stop_server"); err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	currentPID = func() int { return 200 }
+	err := r.AcquireLock("hetzner", "42", "delete_server")
+	if !errors.Is(err, ErrServerBusy) {
+		t.Fatalf("expected ErrServerBusy, got %v", err)
+	}
+	var locked *LockedError
+	if !errors.As(err, &locked) {
+		t.Fatalf("expected *LockedError, got %T", err)
+	}
+	if locked.PID != 100 || locked.Command != "stop_server" {
+		t.Errorf("expected holder pid 100 running stop_server, got pid %d running %q", locked.PID, locked.Command)
+	}
+
+	// Other servers and providers are unaffected.
+	if err := r.AcquireLock("hetzner", "43", "delete_server"); err != nil {
+		t.Errorf("expected lock on another server, got %v", err)
+	}
+	if err := r.AcquireLock("other", "42", "delete_server"); err != nil {
+		t.Errorf("expected lock on another provider, got %v", err)
+	}
+}
+
+func TestAcquireLock_ReleasedAndReacquired(t *testing.T) {
+	r := tempRepo(t)
+
+	origPID, origAlive := currentPID, processAlive
+	t.Cleanup(func() { currentPID, processAlive = origPID, origAlive })
+	processAlive = func(int) bool { return true }
+
+	currentPID = func() int { return 100 }
+	if err := r.AcquireLock("hetzner", "42", "stop_server"); err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+	if err := r.AcquireLock("hetzner", "42", "delete_server"); err != nil {
+		t.Fatalf("expected the holder to reacquire its own lock, got %v", err)
+	}
+
+	// Releasing from another process leaves the lock in place.
+	currentPID = func() int { return 200 }
+	if err := r.ReleaseLock("hetzner", "42"); err != nil {
+		t.Fatalf("ReleaseLock failed: %v", err)
+	}
+	if err := r.AcquireLock("hetzner", "42", "start_server"); !errors.Is(err, ErrServerBusy) {
+		t.Fatalf("expected ErrServerBusy, got %v", err)
+	}
+
+	currentPID = func() int { return 100 }
+	if err := r.ReleaseLock("hetzner", "42"); err != nil {
+		t.Fatalf("ReleaseLock failed: %v", err)
+	}
+	currentPID = func() int { return 200 }
+	if err := r.AcquireLock("hetzner", "42", "start_server"); err != nil {
+		t.Errorf("expected lock after release, got %v", err)
+	}
+}
+
+func TestAcquireLock_TakesOverFromExitedProcess(t *testing.T) {
+	r := tempRepo(t)
+
+	origPID, origAlive := currentPID, processAlive
+	t.Cleanup(func() { currentPID, processAlive = origPID, origAlive })
+
+	currentPID = func() int { return 100 }
+	if err := r.AcquireLock("hetzner", "42", "stop_server"); err != nil {
+		t.Fatalf("AcquireLock failed: %v", err)
+	}
+
+	processAlive = func(pid int) bool { return pid != 100 }
+	currentPID = func() int { return 200 }
+	if err := r.AcquireLock("hetzner", "42", "delete_server"); err != nil {
+		t.Errorf("expected to take over an abandoned lock, got %v", err)
+	}
+}
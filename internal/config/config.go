@@ -11,6 +11,11 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/util"
 )
 
 const (
@@ -31,6 +36,233 @@ func ResetPath() { pathOverride = "" }
 // Config holds user preferences that persist across invocations.
 type Config struct {
 	DefaultProvider string `json:"default_provider,omitempty"`
+
+	// DNSProvider hosts the zones that server hostnames are added to
+	// (e.g. "desec"). When empty, a server's own provider is used.
+	DNSProvider string `json:"dns_provider,omitempty"`
+
+	// LabelColumns lists server label keys shown as extra columns in
+	// server list output (e.g. ["env", "role"]).
+	LabelColumns []string `json:"label_columns,omitempty"`
+
+	// Locale controls the message language and number and currency
+	// formatting (e.g. "de-DE").
+	// When empty, the LC_ALL/LC_MONETARY/LANG environment is used.
+	Locale string `json:"locale,omitempty"`
+
+	// IdleWindow is the metrics look-back used to detect idle servers
+	// (e.g. "7d" or "48h"). When empty, seven days are used.
+	IdleWindow string `json:"idle_window,omitempty"`
+
+	// UsageStats controls local usage statistics ("on" or "off").
+	// When empty, statistics are recorded.
+	UsageStats string `json:"usage_stats,omitempty"`
+
+	// DeleteRequireStopped ("on" or "off") refuses to delete running
+	// servers unless forced. When empty, running servers can be deleted.
+	DeleteRequireStopped string `json:"delete_require_stopped,omitempty"`
+
+	// SSHLaunch controls where the TUI opens SSH sessions: "exec" (the
+	// current terminal), "tmux-window" or "tmux-pane". The tmux modes only
+	// apply inside tmux. When empty, sessions use the current terminal.
+	SSHLaunch string `json:"ssh_launch,omitempty"`
+
+	// SSHOptions are extra ssh arguments added to every interactive login
+	// (e.g. "-o ForwardAgent=yes"). Options stored for a server take
+	// precedence.
+	SSHOptions string `json:"ssh_options,omitempty"`
+
+	// SSHClient selects the SSH client for interactive logins: "exec"
+	// (the system ssh binary) or "native" (built in). When empty, ssh is
+	// used if installed and the built-in client otherwise.
+	SSHClient string `json:"ssh_client,omitempty"`
+
+	// OpsConcurrency caps how many start/stop/delete operations the TUI
+	// runs against a provider at once; the rest wait in a queue. When
+	// empty, DefaultOpsConcurrency is used.
+	OpsConcurrency string `json:"ops_concurrency,omitempty"`
+
+	// MetricsRefresh is how often the server detail view refreshes its
+	// metrics card while open (e.g. "60s" or "5m"), or "off". When empty,
+	// DefaultMetricsRefresh is used.
+	MetricsRefresh string `json:"metrics_refresh,omitempty"`
+
+	// Projects lists, per provider, the projects with a stored token. The
+	// keychain cannot be enumerated, so the names are kept here.
+	Projects map[string][]string `json:"projects,omitempty"`
+
+	// ActiveProjects maps a provider to the project used when --project
+	// is not given. A missing entry uses the provider's unscoped token.
+	// Profiles selected with --profile or a .vpsm.json file override it.
+	ActiveProjects map[string]string `json:"active_projects,omitempty"`
+
+	// Aliases maps user-defined command names to the arguments they expand
+	// to (e.g. "web": "server ssh web-1"). They are expanded before the
+	// command line is parsed; built-in commands take precedence.
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Endpoints maps a provider to an alternate API endpoint, e.g. an
+	// API-compatible gateway, proxy or mock server.
+	Endpoints map[string]Endpoint `json:"endpoints,omitempty"`
+
+	// Proxy routes provider API requests and SSH connections through an
+	// HTTP(S) or SOCKS5 proxy (e.g. "socks5://127.0.0.1:1080"). When
+	// empty, HTTP_PROXY/HTTPS_PROXY/NO_PROXY apply to API requests and
+	// ALL_PROXY to SSH.
+	Proxy string `json:"proxy,omitempty"`
+}
+
+// Endpoint overrides where a provider's API is reached.
+type Endpoint struct {
+	// URL is the API base URL used instead of the provider's default.
+	URL string `json:"url"`
+
+	// CACert is a PEM file of extra certificate authorities trusted for
+	// the endpoint, for gateways with a private CA.
+	CACert string `json:"ca_cert,omitempty"`
+}
+
+// UsageStatsEnabled reports whether local usage statistics are recorded.
+func (c *Config) UsageStatsEnabled() bool {
+	return c.UsageStats != "off"
+}
+
+// DeleteRequiresStopped reports whether servers must be stopped before
+// they can be deleted.
+func (c *Config) DeleteRequiresStopped() bool {
+	return c.DeleteRequireStopped == "on"
+}
+
+// DefaultOpsConcurrency is the operation cap used when none is configured.
+const DefaultOpsConcurrency = 3
+
+// OperationConcurrency returns the configured operation cap, or
+// DefaultOpsConcurrency when it is unset or not a positive number.
+func (c *Config) OperationConcurrency() int {
+	n, err := strconv.Atoi(c.OpsConcurrency)
+	if err != nil || n < 1 {
+		return DefaultOpsConcurrency
+	}
+	return n
+}
+
+// DefaultMetricsRefresh is the metrics refresh interval used when none is
+// configured.
+const DefaultMetricsRefresh = time.Minute
+
+// MinMetricsRefresh is the shortest metrics refresh interval accepted.
+const MinMetricsRefresh = 10 * time.Second
+
+// ParseMetricsRefresh parses a metrics refresh setting. "off" yields zero.
+func ParseMetricsRefresh(value string) (time.Duration, error) {
+	if value == "off" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < MinMetricsRefresh {
+		return 0, fmt.Errorf("invalid metrics refresh %q: expected off or a duration of at least %s, e.g. 60s or 5m", value, MinMetricsRefresh)
+	}
+	return d, nil
+}
+
+// MetricsRefreshInterval returns how often open detail views refresh
+// their metrics, zero when refreshing is off, or DefaultMetricsRefresh
+// when the setting is unset or invalid.
+func (c *Config) MetricsRefreshInterval() time.Duration {
+	d, err := ParseMetricsRefresh(c.MetricsRefresh)
+	if err != nil {
+		return DefaultMetricsRefresh
+	}
+	return d
+}
+
+// DNSProviderFor returns the DNS provider that hosts hostnames of servers
+// on serverProvider: the configured one, or serverProvider itself.
+func (c *Config) DNSProviderFor(serverProvider string) string {
+	if c.DNSProvider != "" {
+		return c.DNSProvider
+	}
+	return serverProvider
+}
+
+// ProjectsFor returns the projects with a stored token for provider.
+func (c *Config) ProjectsFor(provider string) []string {
+	return c.Projects[util.NormalizeKey(provider)]
+}
+
+// AddProject records that project has a stored token for provider.
+func (c *Config) AddProject(provider, project string) {
+	provider, project = util.NormalizeKey(provider), util.NormalizeKey(project)
+	if slices.Contains(c.Projects[provider], project) {
+		return
+	}
+	if c.Projects == nil {
+		c.Projects = make(map[string][]string)
+	}
+	c.Projects[provider] = append(c.Projects[provider], project)
+	slices.Sort(c.Projects[provider])
+}
+
+// ActiveProject returns the project selected for provider, or "". A
+// profile selected with UseProfile takes precedence over ActiveProjects.
+func (c *Config) ActiveProject(provider string) string {
+	if profile, ok := SelectedProfile(provider); ok {
+		return profile
+	}
+	return c.SavedProject(provider)
+}
+
+// SavedProject returns the active project saved for provider, ignoring
+// any profile selected with UseProfile.
+func (c *Config) SavedProject(provider string) string {
+	return c.ActiveProjects[util.NormalizeKey(provider)]
+}
+
+// SetActiveProject selects project for provider. An empty project
+// returns the provider to its unscoped token.
+func (c *Config) SetActiveProject(provider, project string) {
+	provider = util.NormalizeKey(provider)
+	if project == "" {
+		delete(c.ActiveProjects, provider)
+		return
+	}
+	if c.ActiveProjects == nil {
+		c.ActiveProjects = make(map[string]string)
+	}
+	c.ActiveProjects[provider] = util.NormalizeKey(project)
+}
+
+// SetAlias defines name to expand to expansion. An empty expansion
+// removes the alias.
+func (c *Config) SetAlias(name, expansion string) {
+	if expansion == "" {
+		delete(c.Aliases, name)
+		return
+	}
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[name] = expansion
+}
+
+// EndpointFor returns the endpoint override for provider, if any.
+func (c *Config) EndpointFor(provider string) (Endpoint, bool) {
+	e, ok := c.Endpoints[util.NormalizeKey(provider)]
+	return e, ok
+}
+
+// SetEndpoint overrides provider's API endpoint. An empty URL removes the
+// override.
+func (c *Config) SetEndpoint(provider string, e Endpoint) {
+	provider = util.NormalizeKey(provider)
+	if e.URL == "" {
+		delete(c.Endpoints, provider)
+		return
+	}
+	if c.Endpoints == nil {
+		c.Endpoints = make(map[string]Endpoint)
+	}
+	c.Endpoints[provider] = e
 }
 
 // Path returns the absolute path to the config file.
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 )
@@ -102,3 +103,76 @@ func TestLoad_EmptyDefaultProvider(t *testing.T) {
 		t.Errorf("expected empty DefaultProvider, got %q", cfg.DefaultProvider)
 	}
 }
+
+func TestProjects(t *testing.T) {
+	var cfg Config
+	cfg.AddProject("Hetzner", "staging")
+	cfg.AddProject("hetzner", "Prod")
+	cfg.AddProject("hetzner", "staging")
+
+	if got := cfg.ProjectsFor("hetzner"); len(got) != 2 || got[0] != "prod" || got[1] != "staging" {
+		t.Errorf("ProjectsFor = %v, want [prod staging]", got)
+	}
+
+	cfg.SetActiveProject("hetzner", "Staging")
+	if got := cfg.ActiveProject("HETZNER"); got != "staging" {
+		t.Errorf("ActiveProject = %q, want %q", got, "staging")
+	}
+	cfg.SetActiveProject("hetzner", "")
+	if got := cfg.ActiveProject("hetzner"); got != "" {
+		t.Errorf("expected no active project after clearing, got %q", got)
+	}
+}
+
+func TestOperationConcurrency(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"", DefaultOpsConcurrency},
+		{"5", 5},
+		{"0", DefaultOpsConcurrency},
+		{"lots", DefaultOpsConcurrency},
+	}
+	for _, tt := range tests {
+		cfg := Config{OpsConcurrency: tt.value}
+		if got := cfg.OperationConcurrency(); got != tt.want {
+			t.Errorf("OperationConcurrency(%q) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMetricsRefreshInterval(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultMetricsRefresh},
+		{"30s", 30 * time.Second},
+		{"5m", 5 * time.Minute},
+		{"off", 0},
+		{"1s", DefaultMetricsRefresh},
+		{"often", DefaultMetricsRefresh},
+	}
+	for _, tt := range tests {
+		cfg := Config{MetricsRefresh: tt.value}
+		if got := cfg.MetricsRefreshInterval(); got != tt.want {
+			t.Errorf("MetricsRefreshInterval(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	cfg := &Config{}
+	cfg.SetEndpoint("Hetzner", Endpoint{URL: "https://gw.example.com/v1", CACert: "/etc/ca.pem"})
+
+	e, ok := cfg.EndpointFor("hetzner")
+	if !ok || e.URL != "https://gw.example.com/v1" || e.CACert != "/etc/ca.pem" {
+		t.Errorf("EndpointFor = %+v, %v", e, ok)
+	}
+
+	cfg.SetEndpoint("hetzner", Endpoint{})
+	if _, ok := cfg.EndpointFor("hetzner"); ok {
+		t.Error("expected no endpoint after clearing")
+	}
+}
@@ -19,6 +19,10 @@ type KeySpec struct {
 	// Set applies a value for this key to the given Config (in memory only;
 	// the caller is responsible for calling Save).
 	Set func(cfg *Config, value string)
+
+	// Raw keeps the value exactly as given. Other values are lowercased
+	// and trimmed before they are stored.
+	Raw bool
 }
 
 // Keys is the authoritative list of all supported configuration keys.
@@ -30,6 +34,91 @@ var Keys = []KeySpec{
 		Get:         func(cfg *Config) string { return cfg.DefaultProvider },
 		Set:         func(cfg *Config, v string) { cfg.DefaultProvider = v },
 	},
+	{
+		Name:        "label-columns",
+		Description: "Comma-separated label keys shown as extra columns in server list",
+		Get:         func(cfg *Config) string { return strings.Join(cfg.LabelColumns, ",") },
+		Set:         func(cfg *Config, v string) { cfg.LabelColumns = SplitList(v) },
+	},
+	{
+		Name:        "locale",
+		Description: "Locale for messages, numbers and prices, e.g. en-US or de-DE (defaults to $LANG)",
+		Get:         func(cfg *Config) string { return cfg.Locale },
+		Set:         func(cfg *Config, v string) { cfg.Locale = v },
+	},
+	{
+		Name:        "idle-window",
+		Description: "Metrics window for idle server detection, e.g. 7d or 48h (default 7d)",
+		Get:         func(cfg *Config) string { return cfg.IdleWindow },
+		Set:         func(cfg *Config, v string) { cfg.IdleWindow = v },
+	},
+	{
+		Name:        "usage-stats",
+		Description: "Record local-only usage statistics shown by 'vpsm stats': on or off (default on)",
+		Get:         func(cfg *Config) string { return cfg.UsageStats },
+		Set:         func(cfg *Config, v string) { cfg.UsageStats = v },
+	},
+	{
+		Name:        "delete-require-stopped",
+		Description: "Refuse to delete running servers unless forced: on or off (default off)",
+		Get:         func(cfg *Config) string { return cfg.DeleteRequireStopped },
+		Set:         func(cfg *Config, v string) { cfg.DeleteRequireStopped = v },
+	},
+	{
+		Name:        "ssh-launch",
+		Description: "Where the TUI opens SSH sessions: exec, tmux-window or tmux-pane (default exec)",
+		Get:         func(cfg *Config) string { return cfg.SSHLaunch },
+		Set:         func(cfg *Config, v string) { cfg.SSHLaunch = v },
+	},
+	{
+		Name:        "ssh-options",
+		Description: "Extra ssh arguments for interactive logins, e.g. \"-o ForwardAgent=yes\"",
+		Get:         func(cfg *Config) string { return cfg.SSHOptions },
+		Set:         func(cfg *Config, v string) { cfg.SSHOptions = v },
+		Raw:         true,
+	},
+	{
+		Name:        "ssh-client",
+		Description: "SSH client for interactive logins: exec (the ssh binary) or native (built in) (default exec)",
+		Get:         func(cfg *Config) string { return cfg.SSHClient },
+		Set:         func(cfg *Config, v string) { cfg.SSHClient = v },
+	},
+	{
+		Name:        "ops-concurrency",
+		Description: "How many start/stop/delete operations the TUI runs at once per provider (default 3)",
+		Get:         func(cfg *Config) string { return cfg.OpsConcurrency },
+		Set:         func(cfg *Config, v string) { cfg.OpsConcurrency = v },
+	},
+	{
+		Name:        "metrics-refresh",
+		Description: "How often the server detail view refreshes metrics, e.g. 30s or 5m, or off (default 60s)",
+		Get:         func(cfg *Config) string { return cfg.MetricsRefresh },
+		Set:         func(cfg *Config, v string) { cfg.MetricsRefresh = v },
+	},
+	{
+		Name:        "proxy",
+		Description: "Proxy for API requests and SSH, e.g. http://proxy:3128 or socks5://host:1080 (defaults to *_PROXY)",
+		Get:         func(cfg *Config) string { return cfg.Proxy },
+		Set:         func(cfg *Config, v string) { cfg.Proxy = v },
+		Raw:         true,
+	},
+	{
+		Name:        "dns-provider",
+		Description: "DNS provider for server hostnames, e.g. desec (defaults to the server's provider)",
+		Get:         func(cfg *Config) string { return cfg.DNSProvider },
+		Set:         func(cfg *Config, v string) { cfg.DNSProvider = v },
+	},
+}
+
+// SplitList parses a comma-separated value into its trimmed, non-empty parts.
+func SplitList(v string) []string {
+	var parts []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
 }
 
 // Lookup returns the KeySpec for the given name, or nil if not found.
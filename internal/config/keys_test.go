@@ -83,3 +83,15 @@ func TestKeysHelp_ContainsAllKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitList(t *testing.T) {
+	got := SplitList(" env, role ,,tier ")
+	want := []string{"env", "role", "tier"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("SplitList = %q, want %q", got, want)
+	}
+
+	if got := SplitList(""); len(got) != 0 {
+		t.Errorf("SplitList(\"\") = %q, want empty", got)
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"nathanbeddoewebdev/vpsm/internal/util"
+)
+
+// LocalFileName is the per-directory config file. It applies to commands
+// run in its directory or below it.
+const LocalFileName = ".vpsm.json"
+
+// Local is the per-directory config, e.g. {"profiles": ["hetzner:work"]}.
+type Local struct {
+	// Profiles select which token each provider uses, as
+	// "<provider>:<profile>".
+	Profiles []string `json:"profiles,omitempty"`
+}
+
+// FindLocal returns the LocalFileName nearest to dir, searching dir and
+// then its parents, and its path. It returns nil when there is none.
+func FindLocal(dir string) (*Local, string, error) {
+	for {
+		path := filepath.Join(dir, LocalFileName)
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var local Local
+			if err := json.Unmarshal(data, &local); err != nil {
+				return nil, "", fmt.Errorf("config: failed to parse %s: %w", path, err)
+			}
+			return &local, path, nil
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, "", fmt.Errorf("config: failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, "", nil
+		}
+		dir = parent
+	}
+}
+
+var (
+	profileMu sync.RWMutex
+	profiles  = map[string]string{}
+)
+
+// UseProfile selects profile as provider's project for the rest of the
+// process, taking precedence over the active project saved in the config.
+func UseProfile(provider, profile string) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	profiles[util.NormalizeKey(provider)] = util.NormalizeKey(profile)
+}
+
+// ResetProfiles clears the profiles selected with UseProfile.
+func ResetProfiles() {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	profiles = map[string]string{}
+}
+
+// SelectedProfile returns the profile selected with UseProfile for
+// provider, by .vpsm.json or --profile.
+func SelectedProfile(provider string) (string, bool) {
+	profileMu.RLock()
+	defer profileMu.RUnlock()
+	profile, ok := profiles[util.NormalizeKey(provider)]
+	return profile, ok
+}
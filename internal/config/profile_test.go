@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindLocal_SearchesParents(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, LocalFileName), []byte(`{"profiles": ["hetzner:work"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(root, "infra", "prod")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	local, path, err := FindLocal(dir)
+	if err != nil {
+		t.Fatalf("FindLocal() error: %v", err)
+	}
+	if path != filepath.Join(root, LocalFileName) {
+		t.Errorf("path = %q", path)
+	}
+	if diff := cmp.Diff(&Local{Profiles: []string{"hetzner:work"}}, local); diff != "" {
+		t.Errorf("FindLocal() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindLocal_NoneFound(t *testing.T) {
+	local, _, err := FindLocal(t.TempDir())
+	if err != nil || local != nil {
+		t.Errorf("FindLocal() = %v, %v; want nil, nil", local, err)
+	}
+}
+
+func TestActiveProject_ProfileOverridesConfig(t *testing.T) {
+	t.Cleanup(ResetProfiles)
+	cfg := &Config{}
+	cfg.SetActiveProject("hetzner", "staging")
+	cfg.SetActiveProject("digitalocean", "team")
+
+	UseProfile("Hetzner", "work")
+	if got := cfg.ActiveProject("hetzner"); got != "work" {
+		t.Errorf("ActiveProject(hetzner) = %q, want the selected profile", got)
+	}
+	if got := cfg.ActiveProject("digitalocean"); got != "team" {
+		t.Errorf("ActiveProject(digitalocean) = %q, want the saved project", got)
+	}
+}
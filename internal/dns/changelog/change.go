@@ -0,0 +1,86 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// Action is the kind of record mutation a change made.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is one record mutation made through vpsm.
+type Change struct {
+	ID int64 `json:"id"`
+	// Provider is the DNS provider name the change was made with (e.g.
+	// "desec" or "hetzner:work"), so it can be rolled back with it.
+	Provider string `json:"provider"`
+	Zone     string `json:"zone"`
+	Action   Action `json:"action"`
+	// Before is the record as it was; nil for a create.
+	Before *dnsdomain.Record `json:"before,omitempty"`
+	// After is the record as it became; nil for a delete.
+	After *dnsdomain.Record `json:"after,omitempty"`
+	// Reverts is the ID of the change this one rolled back, or 0.
+	Reverts   int64     `json:"reverts,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Record returns the record the change is about: After, or Before for a
+// delete.
+func (c Change) Record() dnsdomain.Record {
+	if c.After != nil {
+		return *c.After
+	}
+	if c.Before != nil {
+		return *c.Before
+	}
+	return dnsdomain.Record{}
+}
+
+// String describes the change, e.g. "update www A 192.0.2.1 -> 192.0.2.2".
+func (c Change) String() string {
+	r := c.Record()
+	switch {
+	case c.Action == ActionUpdate && c.Before != nil && c.After != nil:
+		return fmt.Sprintf("update %s %s %s", r.Name, r.Type, describeUpdate(*c.Before, *c.After))
+	default:
+		return fmt.Sprintf("%s %s %s %s", c.Action, r.Name, r.Type, r.Value)
+	}
+}
+
+// describeUpdate lists what an update changed, e.g. "ttl 300 -> 60".
+func describeUpdate(before, after dnsdomain.Record) string {
+	var parts []string
+	if before.Name != after.Name {
+		parts = append(parts, fmt.Sprintf("name %s -> %s", before.Name, after.Name))
+	}
+	if before.Value != after.Value {
+		parts = append(parts, fmt.Sprintf("%s -> %s", before.Value, after.Value))
+	}
+	if before.TTL != after.TTL {
+		parts = append(parts, fmt.Sprintf("ttl %d -> %d", before.TTL, after.TTL))
+	}
+	if p, q := priority(before), priority(after); p != q {
+		parts = append(parts, fmt.Sprintf("priority %s -> %s", p, q))
+	}
+	if len(parts) == 0 {
+		return after.Value
+	}
+	return strings.Join(parts, ", ")
+}
+
+func priority(r dnsdomain.Record) string {
+	if r.Priority == nil {
+		return "-"
+	}
+	return fmt.Sprint(*r.Priority)
+}
@@ -0,0 +1,213 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// ErrChangedSince is returned when rolling back a change to a record that
+// has been changed again since.
+var ErrChangedSince = errors.New("record changed since")
+
+// Provider wraps a DNS provider and logs the record changes made through
+// it. The records returned by ListRecords are kept as the "before" side of
+// later updates and deletes; records not seen are looked up first.
+//
+// Only the methods of dnsdomain.Provider are wrapped: use Unwrap to reach
+// optional extensions such as DNSSECProvider.
+type Provider struct {
+	dnsdomain.Provider
+
+	name string
+	open func() (Repository, error)
+	// OnLogError receives failures to write the log. The record change
+	// itself has been made by then, so they are not returned.
+	OnLogError func(error)
+
+	listed map[string][]dnsdomain.Record
+	// reverts is stored on the next logged change.
+	reverts int64
+	last    *Change
+}
+
+// Wrap returns provider, registered as name, logging to the repository
+// returned by open. The repository is opened for each change and closed
+// again, so the log is never held open between requests. A nil open logs
+// nothing.
+func Wrap(provider dnsdomain.Provider, name string, open func() (Repository, error)) *Provider {
+	return &Provider{Provider: provider, name: name, open: open, listed: map[string][]dnsdomain.Record{}}
+}
+
+// OpenDefault opens the repository at the default path; pass it to Wrap.
+func OpenDefault() (Repository, error) {
+	return Open()
+}
+
+// Unwrap returns the wrapped provider.
+func (p *Provider) Unwrap() dnsdomain.Provider {
+	return p.Provider
+}
+
+// LastChange returns the change most recently logged through p, or nil.
+func (p *Provider) LastChange() *Change {
+	return p.last
+}
+
+func (p *Provider) ListRecords(ctx context.Context, zone string) ([]dnsdomain.Record, error) {
+	records, err := p.Provider.ListRecords(ctx, zone)
+	if err == nil {
+		// Callers edit the records they list before updating them.
+		p.listed[zone] = slices.Clone(records)
+	}
+	return records, err
+}
+
+func (p *Provider) CreateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	created, err := p.Provider.CreateRecord(ctx, zone, r)
+	if err != nil {
+		return nil, err
+	}
+	after := *created
+	p.log(zone, ActionCreate, nil, &after)
+	return created, nil
+}
+
+func (p *Provider) UpdateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	before, err := p.lookup(ctx, zone, r.ID)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := p.Provider.UpdateRecord(ctx, zone, r)
+	if err != nil {
+		return nil, err
+	}
+	after := *updated
+	p.log(zone, ActionUpdate, before, &after)
+	return updated, nil
+}
+
+func (p *Provider) DeleteRecord(ctx context.Context, zone, id string) error {
+	before, err := p.lookup(ctx, zone, id)
+	if err != nil {
+		return err
+	}
+	if err := p.Provider.DeleteRecord(ctx, zone, id); err != nil {
+		return err
+	}
+	if before == nil {
+		before = &dnsdomain.Record{ID: id}
+	}
+	p.log(zone, ActionDelete, before, nil)
+	return nil
+}
+
+// lookup returns the record with id as last listed, listing the zone when
+// it has not been listed or id was not in it. It returns nil without an
+// error when the record cannot be found, leaving the provider to fail.
+func (p *Provider) lookup(ctx context.Context, zone, id string) (*dnsdomain.Record, error) {
+	if r := findRecord(p.listed[zone], id); r != nil {
+		return r, nil
+	}
+	records, err := p.ListRecords(ctx, zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the record before changing it: %w", err)
+	}
+	return findRecord(records, id), nil
+}
+
+func findRecord(records []dnsdomain.Record, id string) *dnsdomain.Record {
+	for _, r := range records {
+		if r.ID == id {
+			found := r
+			return &found
+		}
+	}
+	return nil
+}
+
+func (p *Provider) log(zone string, action Action, before, after *dnsdomain.Record) {
+	c := &Change{Provider: p.name, Zone: zone, Action: action, Before: before, After: after, Reverts: p.reverts}
+	p.reverts = 0
+	p.last = c
+	if p.open == nil {
+		return
+	}
+	if err := p.record(c); err != nil && p.OnLogError != nil {
+		p.OnLogError(err)
+	}
+}
+
+func (p *Provider) record(c *Change) error {
+	repo, err := p.open()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+	return repo.Record(c)
+}
+
+// Revert applies the inverse of c: it deletes a created record, restores
+// an updated one to its previous value and recreates a deleted one. The
+// rollback is logged as a change of its own. Unless force is set, a record
+// that was changed again after c is left alone and ErrChangedSince is
+// returned.
+func (p *Provider) Revert(ctx context.Context, c Change, force bool) (*Change, error) {
+	if c.Action != ActionCreate && (c.Before == nil || c.Before.Type == "") {
+		return nil, fmt.Errorf("change %d did not record the previous record, so it cannot be rolled back", c.ID)
+	}
+	records, err := p.ListRecords(ctx, c.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *dnsdomain.Record
+	if c.After != nil {
+		current = findRecord(records, c.After.ID)
+		if current == nil {
+			return nil, fmt.Errorf("the %s %s record is no longer in %s: %w", c.After.Name, c.After.Type, c.Zone, dnsdomain.ErrNotFound)
+		}
+		if !force && !sameRecord(*current, *c.After) {
+			return nil, fmt.Errorf("%s %s in %s: %w", c.After.Name, c.After.Type, c.Zone, ErrChangedSince)
+		}
+	}
+
+	p.reverts = c.ID
+	defer func() { p.reverts = 0 }()
+	p.last = nil
+	switch c.Action {
+	case ActionCreate:
+		err = p.DeleteRecord(ctx, c.Zone, c.After.ID)
+	case ActionUpdate:
+		// Providers that derive IDs from the content (deSEC) gave the
+		// record a new ID when it changed, so address it by the current one.
+		restored := *c.Before
+		restored.ID = current.ID
+		_, err = p.UpdateRecord(ctx, c.Zone, restored)
+	case ActionDelete:
+		restored := *c.Before
+		restored.ID = ""
+		_, err = p.CreateRecord(ctx, c.Zone, restored)
+	default:
+		err = fmt.Errorf("unknown change action %q", c.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p.last, nil
+}
+
+// sameRecord reports whether a and b have the same name, type and
+// content.
+func sameRecord(a, b dnsdomain.Record) bool {
+	if a.Name != b.Name || a.Type != b.Type || a.Value != b.Value || a.TTL != b.TTL {
+		return false
+	}
+	if a.Priority == nil || b.Priority == nil {
+		return a.Priority == b.Priority
+	}
+	return *a.Priority == *b.Priority
+}
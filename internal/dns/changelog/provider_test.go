@@ -0,0 +1,170 @@
+package changelog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// memProvider is an in-memory dnsdomain.Provider.
+type memProvider struct {
+	records []dnsdomain.Record
+	nextID  int
+}
+
+func (m *memProvider) GetDisplayName() string   { return "Mem" }
+func (m *memProvider) Quirks() dnsdomain.Quirks { return dnsdomain.Quirks{} }
+func (m *memProvider) ListZones(context.Context) ([]dnsdomain.Zone, error) {
+	return nil, nil
+}
+func (m *memProvider) ListRecords(context.Context, string) ([]dnsdomain.Record, error) {
+	return append([]dnsdomain.Record(nil), m.records...), nil
+}
+
+func (m *memProvider) CreateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	m.nextID++
+	r.ID = fmt.Sprint(m.nextID)
+	m.records = append(m.records, r)
+	return &r, nil
+}
+
+func (m *memProvider) UpdateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	for i := range m.records {
+		if m.records[i].ID == r.ID {
+			m.records[i] = r
+			return &r, nil
+		}
+	}
+	return nil, dnsdomain.ErrNotFound
+}
+
+func (m *memProvider) DeleteRecord(_ context.Context, _, id string) error {
+	for i := range m.records {
+		if m.records[i].ID == id {
+			m.records = append(m.records[:i], m.records[i+1:]...)
+			return nil
+		}
+	}
+	return dnsdomain.ErrNotFound
+}
+
+func wrapTemp(t *testing.T, mem *memProvider) (*Provider, *SQLiteRepository) {
+	t.Helper()
+	repo := tempRepo(t)
+	p := Wrap(mem, "mem", func() (Repository, error) { return noClose{repo}, nil })
+	p.OnLogError = func(err error) { t.Errorf("log error: %v", err) }
+	return p, repo
+}
+
+// noClose keeps the test repository open across changes.
+type noClose struct{ Repository }
+
+func (noClose) Close() error { return nil }
+
+func TestProvider_LogsBeforeAndAfter(t *testing.T) {
+	mem := &memProvider{records: []dnsdomain.Record{{ID: "a", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 300}}, nextID: 1}
+	p, repo := wrapTemp(t, mem)
+	ctx := context.Background()
+
+	updated := mem.records[0]
+	updated.TTL = 60
+	if _, err := p.UpdateRecord(ctx, "example.com", updated); err != nil {
+		t.Fatalf("UpdateRecord failed: %v", err)
+	}
+	if err := p.DeleteRecord(ctx, "example.com", "a"); err != nil {
+		t.Fatalf("DeleteRecord failed: %v", err)
+	}
+
+	changes, err := repo.List("example.com", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+	if changes[1].Before.TTL != 300 || changes[1].After.TTL != 60 {
+		t.Errorf("update logged %+v -> %+v, want ttl 300 -> 60", changes[1].Before, changes[1].After)
+	}
+	if changes[0].Action != ActionDelete || changes[0].Before.Value != "192.0.2.1" {
+		t.Errorf("delete logged %+v, want the deleted record", changes[0])
+	}
+}
+
+func TestRevert_RestoresEachAction(t *testing.T) {
+	original := dnsdomain.Record{ID: "a", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 300}
+	mem := &memProvider{records: []dnsdomain.Record{original}}
+	p, repo := wrapTemp(t, mem)
+	ctx := context.Background()
+
+	changed := original
+	changed.Value = "192.0.2.2"
+	if _, err := p.UpdateRecord(ctx, "example.com", changed); err != nil {
+		t.Fatalf("UpdateRecord failed: %v", err)
+	}
+	created, err := p.CreateRecord(ctx, "example.com", dnsdomain.Record{Name: "api", Type: dnsdomain.RecordA, Value: "192.0.2.9"})
+	if err != nil {
+		t.Fatalf("CreateRecord failed: %v", err)
+	}
+
+	changes, _ := repo.List("example.com", 0)
+	for _, c := range changes {
+		revert, err := p.Revert(ctx, c, false)
+		if err != nil {
+			t.Fatalf("Revert(%s) failed: %v", c, err)
+		}
+		if revert.Reverts != c.ID {
+			t.Errorf("revert of %d logged Reverts = %d", c.ID, revert.Reverts)
+		}
+	}
+	if diff := cmp.Diff([]dnsdomain.Record{original}, mem.records); diff != "" {
+		t.Errorf("records after revert mismatch (-want +got):\n%s", diff)
+	}
+	if by, _ := repo.RevertedBy(changes[0].ID); by == 0 {
+		t.Errorf("expected the create of %s to be marked reverted", created.ID)
+	}
+
+	// Rolling back the rollback of a delete recreates the record.
+	deleteChange, err := repo.Get(3)
+	if err != nil || deleteChange.Action != ActionDelete {
+		t.Fatalf("Get(3) = %+v, %v; want the delete of the api record", deleteChange, err)
+	}
+	if _, err := p.Revert(ctx, *deleteChange, false); err != nil {
+		t.Fatalf("Revert of the rollback failed: %v", err)
+	}
+	if len(mem.records) != 2 {
+		t.Errorf("expected the api record back, got %+v", mem.records)
+	}
+}
+
+func TestRevert_RefusesRecordChangedSince(t *testing.T) {
+	mem := &memProvider{records: []dnsdomain.Record{{ID: "a", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1"}}}
+	p, repo := wrapTemp(t, mem)
+	ctx := context.Background()
+
+	for _, value := range []string{"192.0.2.2", "192.0.2.3"} {
+		r := mem.records[0]
+		r.Value = value
+		if _, err := p.UpdateRecord(ctx, "example.com", r); err != nil {
+			t.Fatalf("UpdateRecord failed: %v", err)
+		}
+	}
+
+	first, err := repo.Get(1)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := p.Revert(ctx, *first, false); !errors.Is(err, ErrChangedSince) {
+		t.Fatalf("err = %v, want ErrChangedSince", err)
+	}
+	if _, err := p.Revert(ctx, *first, true); err != nil {
+		t.Fatalf("forced Revert failed: %v", err)
+	}
+	if mem.records[0].Value != "192.0.2.1" {
+		t.Errorf("value = %s, want 192.0.2.1", mem.records[0].Value)
+	}
+}
@@ -0,0 +1,253 @@
+// Package changelog records the DNS record changes made through vpsm, with
+// the record's value before and after each one, so a bad edit can be
+// rolled back.
+//
+// Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
+// (shared with actionstore and serverprefs, separate table).
+package changelog
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// ErrNotFound is returned for a change ID that is not in the log.
+var ErrNotFound = errors.New("change not found")
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for the change log.
+type Repository interface {
+	// Record appends c to the log, setting its ID and CreatedAt.
+	Record(c *Change) error
+
+	// Get returns the change with id, or ErrNotFound.
+	Get(id int64) (*Change, error)
+
+	// List returns the latest changes, newest first: at most limit of
+	// them (all when limit <= 0), only zone's when zone is not empty.
+	List(zone string, limit int) ([]Change, error)
+
+	// RevertedBy returns the ID of the change that rolled back id, or 0.
+	RevertedBy(id int64) (int64, error)
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("changelog: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("changelog: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("changelog: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := schema.Migrate(db, "dnschanges", migrations); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// migrations are the schema versions of the dns_changes table, oldest
+// first. Add changes as new migrations; never edit one that has shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createChangesTable},
+}
+
+func createChangesTable(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS dns_changes (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider    TEXT NOT NULL,
+			zone        TEXT NOT NULL,
+			action      TEXT NOT NULL,
+			record_name TEXT NOT NULL,
+			record_type TEXT NOT NULL,
+			before      TEXT NOT NULL DEFAULT '',
+			after       TEXT NOT NULL DEFAULT '',
+			reverts     INTEGER NOT NULL DEFAULT 0,
+			created_at  TEXT NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS dns_changes_zone ON dns_changes (zone, id);
+	`)
+	return err
+}
+
+// Record appends c to the log.
+func (r *SQLiteRepository) Record(c *Change) error {
+	before, err := encodeRecord(c.Before)
+	if err != nil {
+		return err
+	}
+	after, err := encodeRecord(c.After)
+	if err != nil {
+		return err
+	}
+	c.CreatedAt = time.Now().UTC()
+	rec := c.Record()
+
+	result, err := r.db.Exec(`
+		INSERT INTO dns_changes (provider, zone, action, record_name, record_type, before, after, reverts, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.Provider, c.Zone, string(c.Action), rec.Name, string(rec.Type), before, after, c.Reverts, c.CreatedAt.Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("changelog: insert failed: %w", err)
+	}
+	c.ID, err = result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("changelog: insert failed: %w", err)
+	}
+	return nil
+}
+
+const selectChanges = `SELECT id, provider, zone, action, before, after, reverts, created_at FROM dns_changes`
+
+// Get returns the change with id.
+func (r *SQLiteRepository) Get(id int64) (*Change, error) {
+	rows, err := r.db.Query(selectChanges+` WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("changelog: query failed: %w", err)
+	}
+	changes, err := scanChanges(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("change %d: %w", id, ErrNotFound)
+	}
+	return &changes[0], nil
+}
+
+// List returns the latest changes, newest first.
+func (r *SQLiteRepository) List(zone string, limit int) ([]Change, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := r.db.Query(selectChanges+` WHERE ? = '' OR zone = ? ORDER BY id DESC LIMIT ?`, zone, zone, limit)
+	if err != nil {
+		return nil, fmt.Errorf("changelog: query failed: %w", err)
+	}
+	return scanChanges(rows)
+}
+
+// RevertedBy returns the ID of the change that rolled back id, or 0.
+func (r *SQLiteRepository) RevertedBy(id int64) (int64, error) {
+	var by int64
+	err := r.db.QueryRow(`SELECT id FROM dns_changes WHERE reverts = ? ORDER BY id DESC LIMIT 1`, id).Scan(&by)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("changelog: query failed: %w", err)
+	}
+	return by, nil
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func scanChanges(rows *sql.Rows) ([]Change, error) {
+	defer rows.Close()
+
+	var changes []Change
+	for rows.Next() {
+		var c Change
+		var action, before, after, created string
+		if err := rows.Scan(&c.ID, &c.Provider, &c.Zone, &action, &before, &after, &c.Reverts, &created); err != nil {
+			return nil, fmt.Errorf("changelog: scan failed: %w", err)
+		}
+		c.Action = Action(action)
+		var err error
+		if c.Before, err = decodeRecord(before); err != nil {
+			return nil, err
+		}
+		if c.After, err = decodeRecord(after); err != nil {
+			return nil, err
+		}
+		c.CreatedAt, _ = time.Parse(time.RFC3339Nano, created)
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
+
+func encodeRecord(r *dnsdomain.Record) (string, error) {
+	if r == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("changelog: failed to encode record: %w", err)
+	}
+	return string(data), nil
+}
+
+func decodeRecord(s string) (*dnsdomain.Record, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var r dnsdomain.Record
+	if err := json.Unmarshal([]byte(s), &r); err != nil {
+		return nil, fmt.Errorf("changelog: failed to decode record: %w", err)
+	}
+	return &r, nil
+}
@@ -0,0 +1,124 @@
+package changelog
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestRecord_GetRoundTrip(t *testing.T) {
+	r := tempRepo(t)
+
+	c := &Change{
+		Provider: "hetzner:work",
+		Zone:     "example.com",
+		Action:   ActionUpdate,
+		Before:   &dnsdomain.Record{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 300},
+		After:    &dnsdomain.Record{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.2", TTL: 300},
+	}
+	if err := r.Record(c); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if c.ID == 0 || c.CreatedAt.IsZero() {
+		t.Fatalf("Record did not set ID and CreatedAt: %+v", c)
+	}
+
+	got, err := r.Get(c.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if diff := cmp.Diff(c, got, cmpopts.EquateApproxTime(0)); diff != "" {
+		t.Errorf("Get mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGet_NotFound(t *testing.T) {
+	r := tempRepo(t)
+
+	if _, err := r.Get(7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestList_NewestFirstByZone(t *testing.T) {
+	r := tempRepo(t)
+
+	for _, zone := range []string{"example.com", "example.org", "example.com"} {
+		c := &Change{Provider: "desec", Zone: zone, Action: ActionCreate, After: &dnsdomain.Record{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1"}}
+		if err := r.Record(c); err != nil {
+			t.Fatalf("Record failed: %v", err)
+		}
+	}
+
+	got, err := r.List("example.com", 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var ids []int64
+	for _, c := range got {
+		ids = append(ids, c.ID)
+	}
+	if diff := cmp.Diff([]int64{3, 1}, ids); diff != "" {
+		t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+	}
+
+	all, err := r.List("", 2)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != 3 {
+		t.Errorf("List(\"\", 2) = %+v, want the two newest changes", all)
+	}
+}
+
+func TestRevertedBy(t *testing.T) {
+	r := tempRepo(t)
+
+	c := &Change{Provider: "desec", Zone: "example.com", Action: ActionCreate, After: &dnsdomain.Record{ID: "1", Name: "www", Type: dnsdomain.RecordA}}
+	if err := r.Record(c); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if by, err := r.RevertedBy(c.ID); err != nil || by != 0 {
+		t.Fatalf("RevertedBy = %d, %v; want 0", by, err)
+	}
+
+	revert := &Change{Provider: "desec", Zone: "example.com", Action: ActionDelete, Before: c.After, Reverts: c.ID}
+	if err := r.Record(revert); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if by, err := r.RevertedBy(c.ID); err != nil || by != revert.ID {
+		t.Errorf("RevertedBy = %d, %v; want %d", by, err, revert.ID)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	ttl := &Change{
+		Action: ActionUpdate,
+		Before: &dnsdomain.Record{Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 300},
+		After:  &dnsdomain.Record{Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 60},
+	}
+	if got, want := ttl.String(), "update www A ttl 300 -> 60"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	deleted := &Change{Action: ActionDelete, Before: &dnsdomain.Record{Name: "mail", Type: dnsdomain.RecordMX, Value: "mx.example.com"}}
+	if got, want := deleted.String(), "delete mail MX mx.example.com"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DNSSECStatus is the signing state of a zone.
+type DNSSECStatus string
+
+const (
+	// DNSSECActive zones are signed and the registrar publishes their DS
+	// record.
+	DNSSECActive DNSSECStatus = "active"
+	// DNSSECPending zones are signed, but the provider has not yet seen
+	// the DS record at the registrar.
+	DNSSECPending DNSSECStatus = "pending"
+	// DNSSECPendingDisable zones are being unsigned; they keep serving
+	// signatures until the DS record is gone from the registrar.
+	DNSSECPendingDisable DNSSECStatus = "pending-disable"
+	DNSSECDisabled       DNSSECStatus = "disabled"
+)
+
+// DSRecord is a delegation signer record: the digest of a zone's key that
+// the registrar publishes in the parent zone to establish the chain of
+// trust.
+type DSRecord struct {
+	KeyTag     int    `json:"key_tag"`
+	Algorithm  int    `json:"algorithm"`
+	DigestType int    `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+// String renders the record's data as registrars accept it, e.g.
+// "2371 13 2 C988EC...".
+func (d DSRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", d.KeyTag, d.Algorithm, d.DigestType, strings.ToUpper(d.Digest))
+}
+
+// DNSKEY is a zone's public signing key, for registrars that take the key
+// instead of (or as well as) its DS record.
+type DNSKEY struct {
+	Flags     int    `json:"flags"`
+	Protocol  int    `json:"protocol"`
+	Algorithm int    `json:"algorithm"`
+	PublicKey string `json:"public_key"`
+}
+
+// String renders the key's data, e.g. "257 3 13 mdsswUyr3...".
+func (k DNSKEY) String() string {
+	return fmt.Sprintf("%d %d %d %s", k.Flags, k.Protocol, k.Algorithm, k.PublicKey)
+}
+
+// DNSSEC is the signing state of a zone and the records its registrar
+// needs. DS and Keys are empty while the zone is unsigned.
+type DNSSEC struct {
+	Status DNSSECStatus `json:"status"`
+	DS     []DSRecord   `json:"ds,omitempty"`
+	Keys   []DNSKEY     `json:"keys,omitempty"`
+}
+
+// DNSSECProvider extends Provider with zone signing. Enabling signs the
+// zone; it only validates once the DS record is published at the
+// registrar. Disabling while the registrar still publishes a DS record
+// breaks resolution, so the DS record has to be removed first.
+type DNSSECProvider interface {
+	Provider
+
+	GetDNSSEC(ctx context.Context, zone string) (*DNSSEC, error)
+	EnableDNSSEC(ctx context.Context, zone string) (*DNSSEC, error)
+	DisableDNSSEC(ctx context.Context, zone string) (*DNSSEC, error)
+}
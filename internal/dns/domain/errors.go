@@ -0,0 +1,16 @@
+package domain
+
+import shared "nathanbeddoewebdev/vpsm/internal/domain"
+
+var (
+	// ErrNotFound indicates the requested zone or record does not exist.
+	ErrNotFound = shared.ErrNotFound
+	// ErrUnauthorized indicates the request was rejected due to
+	// invalid, expired, or missing credentials.
+	ErrUnauthorized = shared.ErrUnauthorized
+	// ErrRateLimited indicates the provider throttled the request.
+	ErrRateLimited = shared.ErrRateLimited
+	// ErrConflict indicates the record already exists or clashes with
+	// another one, e.g. a CNAME next to other records.
+	ErrConflict = shared.ErrConflict
+)
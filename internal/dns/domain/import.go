@@ -0,0 +1,51 @@
+package domain
+
+// PlanImport returns the changes that bring a zone's existing records in
+// line with imported ones, adjusted for the provider's quirks like
+// PlanMirror. An imported value that is missing from its record set
+// replaces one of the set's values the import does not list, so a changed
+// address updates the record rather than adding a second one; any other
+// missing value is created. Existing records the import does not mention
+// are kept unless prune is set, in which case they are deleted.
+func PlanImport(imported, existing []Record, quirks Quirks, prune bool) MirrorPlan {
+	mirror := PlanMirror(imported, existing, quirks)
+
+	// Unmatched existing values per set, in the order PlanMirror listed
+	// them, to be reused by creates in the same set.
+	spare := make(map[string][]Record)
+	for _, c := range mirror.Changes {
+		if c.Action == MirrorDelete {
+			spare[setKey(c.Record)] = append(spare[setKey(c.Record)], c.Record)
+		}
+	}
+
+	plan := MirrorPlan{Skipped: mirror.Skipped}
+	for _, c := range mirror.Changes {
+		switch c.Action {
+		case MirrorCreate:
+			key := setKey(c.Record)
+			if set := spare[key]; len(set) > 0 {
+				c.Action = MirrorUpdate
+				c.Record.ID = set[0].ID
+				spare[key] = set[1:]
+			}
+			plan.Changes = append(plan.Changes, c)
+		case MirrorUpdate:
+			plan.Changes = append(plan.Changes, c)
+		}
+	}
+	if prune {
+		for _, c := range mirror.Changes {
+			if c.Action != MirrorDelete {
+				continue
+			}
+			for _, r := range spare[setKey(c.Record)] {
+				if r.ID == c.Record.ID {
+					plan.Changes = append(plan.Changes, c)
+					break
+				}
+			}
+		}
+	}
+	return plan
+}
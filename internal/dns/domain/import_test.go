@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlanImport(t *testing.T) {
+	imported := []Record{
+		{Name: "@", Type: RecordA, Value: "203.0.113.20", TTL: 300},
+		{Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{Name: "www", Type: RecordA, Value: "203.0.113.11", TTL: 300},
+		{Name: "@", Type: RecordTXT, Value: "v=spf1 -all"},
+	}
+	existing := []Record{
+		{ID: "1", Name: "@", Type: RecordA, Value: "203.0.113.1", TTL: 300},
+		{ID: "2", Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "3", Name: "old", Type: RecordA, Value: "203.0.113.99"},
+	}
+
+	changes := func(plan MirrorPlan) []string {
+		var s []string
+		for _, c := range plan.Changes {
+			s = append(s, c.Record.ID+" "+c.String())
+		}
+		return s
+	}
+
+	plan := PlanImport(imported, existing, Quirks{}, false)
+	want := []string{
+		"1 update @ A 203.0.113.20 (ttl 300)",
+		" create www A 203.0.113.11 (ttl 300)",
+		" create @ TXT v=spf1 -all",
+	}
+	if diff := cmp.Diff(want, changes(plan)); diff != "" {
+		t.Errorf("PlanImport() mismatch (-want +got):\n%s", diff)
+	}
+
+	plan = PlanImport(imported, existing, Quirks{}, true)
+	want = append(want, "3 delete old A 203.0.113.99")
+	if diff := cmp.Diff(want, changes(plan)); diff != "" {
+		t.Errorf("PlanImport() with prune mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Quirks describes what a DNS provider accepts, so records mirrored onto
+// it can be adjusted or skipped instead of failing on submit.
+type Quirks struct {
+	// MinTTL is the lowest TTL the provider accepts. Lower TTLs are
+	// raised to it. Zero means no minimum.
+	MinTTL int
+
+	// Types lists the record types the provider supports. Nil means all.
+	Types []RecordType
+
+	// ManagesApexNS is set for providers that own the zone's apex NS
+	// records. Those records are neither mirrored onto nor deleted from
+	// the provider.
+	ManagesApexNS bool
+}
+
+// supports reports whether records of type t can be written.
+func (q Quirks) supports(t RecordType) bool {
+	return q.Types == nil || slices.Contains(q.Types, t)
+}
+
+// MirrorAction is what a mirror does to one secondary record.
+type MirrorAction string
+
+const (
+	MirrorCreate MirrorAction = "create"
+	MirrorUpdate MirrorAction = "update"
+	MirrorDelete MirrorAction = "delete"
+)
+
+// MirrorChange is one change needed to bring the secondary in line with
+// the primary. For updates and deletes, Record carries the secondary
+// record's ID.
+type MirrorChange struct {
+	Action MirrorAction
+	Record Record
+}
+
+// String describes the change, e.g. "create www A 192.0.2.1 (ttl 300)".
+func (c MirrorChange) String() string {
+	s := fmt.Sprintf("%s %s %s %s", c.Action, c.Record.Name, c.Record.Type, c.Record.Value)
+	if c.Record.Priority != nil {
+		s += fmt.Sprintf(" (priority %d)", *c.Record.Priority)
+	}
+	if c.Record.TTL > 0 && c.Action != MirrorDelete {
+		s += fmt.Sprintf(" (ttl %d)", c.Record.TTL)
+	}
+	return s
+}
+
+// SkippedRecord is a primary record that cannot be mirrored.
+type SkippedRecord struct {
+	Record Record
+	Reason string
+}
+
+// MirrorPlan lists the changes that mirror a primary zone onto a
+// secondary provider. An empty plan means the zones are in sync.
+type MirrorPlan struct {
+	Changes []MirrorChange
+	Skipped []SkippedRecord
+}
+
+// InSync reports whether the secondary already matches the primary.
+func (p MirrorPlan) InSync() bool {
+	return len(p.Changes) == 0
+}
+
+// PlanMirror compares the records of a zone on its primary provider with
+// the same zone on a secondary provider and returns the changes that make
+// the secondary match, adjusted for the secondary's quirks. The changes
+// double as a drift report.
+//
+// Records are grouped into sets by name and type and matched by value
+// within a set, so round-robin A records and multiple TXT values are
+// mirrored individually. A matched record whose TTL or priority differs
+// is updated; secondary records without a match are deleted.
+func PlanMirror(primary, secondary []Record, quirks Quirks) MirrorPlan {
+	var plan MirrorPlan
+
+	existing := make(map[string][]Record)
+	for _, r := range secondary {
+		if quirks.ManagesApexNS && isApexNS(r) {
+			continue
+		}
+		existing[setKey(r)] = append(existing[setKey(r)], r)
+	}
+
+	for _, r := range primary {
+		if quirks.ManagesApexNS && isApexNS(r) {
+			continue
+		}
+		if !quirks.supports(r.Type) {
+			plan.Skipped = append(plan.Skipped, SkippedRecord{Record: r, Reason: fmt.Sprintf("%s records are not supported", r.Type)})
+			continue
+		}
+
+		want := r
+		want.ID = ""
+		if quirks.MinTTL > 0 && want.TTL > 0 && want.TTL < quirks.MinTTL {
+			want.TTL = quirks.MinTTL
+		}
+
+		key := setKey(want)
+		set := existing[key]
+		i := slices.IndexFunc(set, func(other Record) bool { return sameValue(want.Type, other.Value, want.Value) })
+		if i < 0 {
+			plan.Changes = append(plan.Changes, MirrorChange{Action: MirrorCreate, Record: want})
+			continue
+		}
+
+		match := set[i]
+		existing[key] = slices.Delete(set, i, i+1)
+		want.ID = match.ID
+		want.Value = match.Value
+		if !sameContent(match, want) {
+			plan.Changes = append(plan.Changes, MirrorChange{Action: MirrorUpdate, Record: want})
+		}
+	}
+
+	keys := make([]string, 0, len(existing))
+	for key := range existing {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, r := range existing[key] {
+			plan.Changes = append(plan.Changes, MirrorChange{Action: MirrorDelete, Record: r})
+		}
+	}
+
+	return plan
+}
+
+// setKey identifies the record set r belongs to.
+func setKey(r Record) string {
+	return strings.ToLower(r.Name) + " " + string(r.Type)
+}
+
+func isApexNS(r Record) bool {
+	return r.Type == RecordNS && (r.Name == "@" || r.Name == "")
+}
+
+// sameValue compares record values the way DNS does: hostnames are
+// case-insensitive and the trailing dot some providers store is ignored.
+func sameValue(t RecordType, a, b string) bool {
+	switch t {
+	case RecordCNAME, RecordMX, RecordNS, RecordSRV:
+		return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+	}
+	return a == b
+}
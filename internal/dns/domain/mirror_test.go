@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestPlanMirror(t *testing.T) {
+	primary := []Record{
+		{ID: "p1", Name: "@", Type: RecordNS, Value: "ns1.primary.example."},
+		{ID: "p2", Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "p3", Name: "www", Type: RecordA, Value: "203.0.113.11", TTL: 300},
+		{ID: "p4", Name: "api", Type: RecordCNAME, Value: "www.example.com.", TTL: 30},
+		{ID: "p5", Name: "mail", Type: RecordMX, Value: "mx.example.com", Priority: intPtr(10)},
+		{ID: "p6", Name: "@", Type: RecordCAA, Value: `0 issue "letsencrypt.org"`},
+	}
+	secondary := []Record{
+		{ID: "s1", Name: "@", Type: RecordNS, Value: "ns1.secondary.example."},
+		{ID: "s2", Name: "WWW", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "s3", Name: "api", Type: RecordCNAME, Value: "WWW.example.com", TTL: 60},
+		{ID: "s4", Name: "mail", Type: RecordMX, Value: "mx.example.com", Priority: intPtr(20)},
+		{ID: "s5", Name: "old", Type: RecordA, Value: "203.0.113.99"},
+	}
+	quirks := Quirks{
+		MinTTL:        60,
+		Types:         []RecordType{RecordA, RecordAAAA, RecordCNAME, RecordMX, RecordTXT, RecordNS},
+		ManagesApexNS: true,
+	}
+
+	plan := PlanMirror(primary, secondary, quirks)
+
+	want := []string{
+		"create www A 203.0.113.11 (ttl 300)",
+		"update mail MX mx.example.com (priority 10)",
+		"delete old A 203.0.113.99",
+	}
+	if len(plan.Changes) != len(want) {
+		t.Fatalf("got %d changes %v, want %v", len(plan.Changes), plan.Changes, want)
+	}
+	for i, change := range plan.Changes {
+		if got := change.String(); got != want[i] {
+			t.Errorf("change %d = %q, want %q", i, got, want[i])
+		}
+	}
+	if id := plan.Changes[1].Record.ID; id != "s4" {
+		t.Errorf("update targets %q, want s4", id)
+	}
+
+	if len(plan.Skipped) != 1 || plan.Skipped[0].Record.Type != RecordCAA {
+		t.Errorf("Skipped = %+v, want the CAA record", plan.Skipped)
+	}
+	if plan.InSync() {
+		t.Error("expected drift")
+	}
+}
+
+func TestPlanMirror_InSync(t *testing.T) {
+	records := []Record{
+		{ID: "1", Name: "@", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "2", Name: "@", Type: RecordTXT, Value: "v=spf1 -all"},
+	}
+	if plan := PlanMirror(records, records, Quirks{}); !plan.InSync() {
+		t.Errorf("expected no changes, got %v", plan.Changes)
+	}
+}
+
+func TestPlanMirror_RaisesTTLToMinimum(t *testing.T) {
+	primary := []Record{{Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 30}}
+	secondary := []Record{{ID: "s1", Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 3600}}
+
+	plan := PlanMirror(primary, secondary, Quirks{MinTTL: 3600})
+	if !plan.InSync() {
+		t.Errorf("expected the raised TTL to match, got %v", plan.Changes)
+	}
+}
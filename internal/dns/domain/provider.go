@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+// Zone is a DNS zone (domain) hosted by a provider.
+type Zone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// TTL is the zone's default record TTL, if the provider has one.
+	TTL int `json:"ttl,omitempty"`
+	// Nameservers are the provider's nameservers the registrar should
+	// delegate the zone to.
+	Nameservers []string `json:"nameservers,omitempty"`
+	// Plan is the provider's plan for the zone, if it has plans.
+	Plan string `json:"plan,omitempty"`
+}
+
+// Provider defines DNS zone and record operations for a DNS provider.
+//
+// Zones are addressed by name ("example.com"). Record names are relative
+// to the zone, with "@" for the apex; MX and SRV priorities are carried in
+// Record.Priority rather than the value, and hostname values have no
+// trailing dot, whatever the provider's wire format.
+type Provider interface {
+	GetDisplayName() string
+
+	// Quirks describes what the provider accepts.
+	Quirks() Quirks
+
+	ListZones(ctx context.Context) ([]Zone, error)
+	ListRecords(ctx context.Context, zone string) ([]Record, error)
+
+	// CreateRecord adds r to the zone and returns it with its ID set.
+	CreateRecord(ctx context.Context, zone string, r Record) (*Record, error)
+
+	// UpdateRecord replaces the record with r.ID by r.
+	UpdateRecord(ctx context.Context, zone string, r Record) (*Record, error)
+
+	DeleteRecord(ctx context.Context, zone, id string) error
+}
+
+// ApplyChanges submits changes to the provider in order, stopping at the
+// first failure. It returns how many were applied.
+func ApplyChanges(ctx context.Context, provider Provider, zone string, changes []MirrorChange) (int, error) {
+	for i, c := range changes {
+		var err error
+		switch c.Action {
+		case MirrorCreate:
+			_, err = provider.CreateRecord(ctx, zone, c.Record)
+		case MirrorUpdate:
+			_, err = provider.UpdateRecord(ctx, zone, c.Record)
+		case MirrorDelete:
+			err = provider.DeleteRecord(ctx, zone, c.Record.ID)
+		}
+		if err != nil {
+			return i, fmt.Errorf("failed to %s: %w", c, err)
+		}
+	}
+	return len(changes), nil
+}
+
+// Upsert creates r in the zone, or updates the record with its name and
+// type when there is exactly one (see PlanUpsert). It returns the record
+// as it now stands and what was done; a record that already matches is
+// not submitted.
+func Upsert(ctx context.Context, provider Provider, zone string, r Record) (*Record, UpsertAction, error) {
+	existing, err := provider.ListRecords(ctx, zone)
+	if err != nil {
+		return nil, "", err
+	}
+	planned, action, err := PlanUpsert(r, existing)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var result *Record
+	switch action {
+	case UpsertCreate:
+		result, err = provider.CreateRecord(ctx, zone, planned)
+	case UpsertUpdate:
+		result, err = provider.UpdateRecord(ctx, zone, planned)
+	default:
+		result = &planned
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return result, action, nil
+}
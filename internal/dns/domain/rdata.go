@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// txtChunkSize is the longest character-string a TXT record can hold;
+// longer values are split into several quoted strings.
+const txtChunkSize = 255
+
+// ParseRData converts a record value in zone file syntax, as zone files and
+// the Hetzner DNS and deSEC APIs use, to Record form: the priority of MX
+// and SRV records is split off, hostname targets lose their trailing dot
+// and TXT strings are unquoted and joined. Relative hostnames must already
+// have been qualified.
+func ParseRData(t RecordType, rdata string) (value string, priority *int, err error) {
+	rdata = strings.TrimSpace(rdata)
+	switch t {
+	case RecordMX, RecordSRV:
+		prio, rest, ok := strings.Cut(rdata, " ")
+		p, convErr := strconv.Atoi(prio)
+		if !ok || convErr != nil {
+			return "", nil, fmt.Errorf("invalid %s record %q", t, rdata)
+		}
+		rest = strings.TrimSpace(rest)
+		if t == RecordMX {
+			rest = strings.TrimSuffix(rest, ".")
+		} else if fields := strings.Fields(rest); len(fields) == 3 {
+			fields[2] = strings.TrimSuffix(fields[2], ".")
+			rest = strings.Join(fields, " ")
+		}
+		return rest, &p, nil
+	case RecordCNAME, RecordNS:
+		return strings.TrimSuffix(rdata, "."), nil, nil
+	case RecordTXT:
+		return unquoteTXT(rdata), nil, nil
+	}
+	return rdata, nil, nil
+}
+
+// FormatRData is the inverse of ParseRData: it renders r's value in zone
+// file syntax, with the priority prepended and hostnames fully qualified.
+func FormatRData(r Record) string {
+	value := strings.TrimSpace(r.Value)
+	switch r.Type {
+	case RecordMX, RecordSRV:
+		prio := 0
+		if r.Priority != nil {
+			prio = *r.Priority
+		}
+		if r.Type == RecordMX {
+			value = fqdn(value)
+		} else if fields := strings.Fields(value); len(fields) == 3 {
+			fields[2] = fqdn(fields[2])
+			value = strings.Join(fields, " ")
+		}
+		return fmt.Sprintf("%d %s", prio, value)
+	case RecordCNAME, RecordNS:
+		return fqdn(value)
+	case RecordTXT:
+		return quoteTXT(value)
+	}
+	return value
+}
+
+// fqdn adds the trailing dot that marks name as fully qualified.
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+// quoteTXT renders s as one or more quoted character-strings of at most
+// txtChunkSize bytes, escaping quotes and backslashes.
+func quoteTXT(s string) string {
+	if len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`) {
+		return s // already quoted
+	}
+	var chunks []string
+	for len(s) > txtChunkSize {
+		chunks = append(chunks, s[:txtChunkSize])
+		s = s[txtChunkSize:]
+	}
+	chunks = append(chunks, s)
+
+	quoted := make([]string, len(chunks))
+	for i, c := range chunks {
+		c = strings.ReplaceAll(c, `\`, `\\`)
+		quoted[i] = `"` + strings.ReplaceAll(c, `"`, `\"`) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+// unquoteTXT joins the quoted character-strings of a TXT record into one
+// value. Unquoted input is returned as is.
+func unquoteTXT(s string) string {
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	var b strings.Builder
+	inQuotes, escaped := false, false
+	for _, c := range s {
+		switch {
+		case escaped:
+			b.WriteRune(c)
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormatRData_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		record Record
+		rdata  string
+	}{
+		{"a", Record{Type: RecordA, Value: "192.0.2.1"}, "192.0.2.1"},
+		{"cname", Record{Type: RecordCNAME, Value: "example.net"}, "example.net."},
+		{"mx", Record{Type: RecordMX, Value: "mail.example.com", Priority: intPtr(10)}, "10 mail.example.com."},
+		{"srv", Record{Type: RecordSRV, Value: "5 5060 sip.example.com", Priority: intPtr(0)}, "0 5 5060 sip.example.com."},
+		{"txt", Record{Type: RecordTXT, Value: `v=spf1 include:"x" -all`}, `"v=spf1 include:\"x\" -all"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatRData(tt.record); got != tt.rdata {
+				t.Errorf("FormatRData() = %q, want %q", got, tt.rdata)
+			}
+			value, priority, err := ParseRData(tt.record.Type, tt.rdata)
+			if err != nil {
+				t.Fatalf("ParseRData() error: %v", err)
+			}
+			got := Record{Type: tt.record.Type, Value: value, Priority: priority}
+			if diff := cmp.Diff(tt.record, got); diff != "" {
+				t.Errorf("ParseRData() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuoteTXT_SplitsLongValues(t *testing.T) {
+	value := strings.Repeat("a", 300)
+	quoted := quoteTXT(value)
+
+	want := `"` + strings.Repeat("a", 255) + `" "` + strings.Repeat("a", 45) + `"`
+	if quoted != want {
+		t.Errorf("quoteTXT() = %q, want two strings of 255 and 45", quoted)
+	}
+	if got := unquoteTXT(quoted); got != value {
+		t.Errorf("unquoteTXT() did not restore the value, got %d characters", len(got))
+	}
+}
+
+func TestParseRData_InvalidMX(t *testing.T) {
+	if _, _, err := ParseRData(RecordMX, "mail.example.com."); err == nil {
+		t.Error("expected an error for an MX record without a priority")
+	}
+}
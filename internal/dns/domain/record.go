@@ -0,0 +1,37 @@
+// Package domain defines provider-agnostic DNS types and validation.
+package domain
+
+import "strings"
+
+// RecordType is a DNS resource record type such as "A" or "MX".
+type RecordType string
+
+const (
+	RecordA     RecordType = "A"
+	RecordAAAA  RecordType = "AAAA"
+	RecordCNAME RecordType = "CNAME"
+	RecordMX    RecordType = "MX"
+	RecordTXT   RecordType = "TXT"
+	RecordNS    RecordType = "NS"
+	RecordSRV   RecordType = "SRV"
+	RecordCAA   RecordType = "CAA"
+)
+
+// ParseRecordType normalises a record type name (e.g. "aaaa" -> AAAA).
+func ParseRecordType(s string) RecordType {
+	return RecordType(strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// Record is a single DNS record within a zone.
+type Record struct {
+	ID   string     `json:"id,omitempty"`
+	Name string     `json:"name"` // relative to the zone, "@" for the apex
+	Type RecordType `json:"type"`
+	// Value is the record content: an address for A/AAAA, a hostname for
+	// CNAME/MX/NS, free text for TXT, "<weight> <port> <target>" for SRV
+	// and `<flags> <tag> "<value>"` for CAA.
+	Value string `json:"value"`
+	TTL   int    `json:"ttl,omitempty"`
+	// Priority is required for MX (and SRV) records and ignored otherwise.
+	Priority *int `json:"priority,omitempty"`
+}
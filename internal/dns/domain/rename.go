@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rename returns r moved to newName with its content, TTL and priority
+// unchanged. newName is relative to the zone ("@" for the apex). existing
+// holds the zone's current records; renaming fails when the result would
+// collide with one of them: an identical record already at newName, or a
+// CNAME sharing its name with any other record.
+func Rename(r Record, newName string, existing []Record) (Record, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return Record{}, fmt.Errorf("new record name must not be empty")
+	}
+	if newName != "@" {
+		if err := ValidateHostname(newName); err != nil {
+			return Record{}, fmt.Errorf("invalid record name: %w", err)
+		}
+	}
+	if strings.EqualFold(newName, r.Name) {
+		return Record{}, fmt.Errorf("record is already named %q", r.Name)
+	}
+
+	renamed := r
+	renamed.Name = newName
+	for _, other := range existing {
+		if other.ID != "" && other.ID == r.ID {
+			continue
+		}
+		if !strings.EqualFold(other.Name, newName) {
+			continue
+		}
+		switch {
+		case other.Type == renamed.Type && other.Value == renamed.Value:
+			return Record{}, fmt.Errorf("an identical %s record already exists at %q", other.Type, newName)
+		case other.Type == RecordCNAME || renamed.Type == RecordCNAME:
+			return Record{}, fmt.Errorf("%q already has a %s record; a CNAME cannot share its name with other records", newName, other.Type)
+		}
+	}
+	return renamed, nil
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRename(t *testing.T) {
+	mx := Record{ID: "1", Name: "mail", Type: RecordMX, Value: "mx.example.com", TTL: 600, Priority: intPtr(10)}
+	existing := []Record{
+		mx,
+		{ID: "2", Name: "www", Type: RecordCNAME, Value: "example.com."},
+		{ID: "3", Name: "mx", Type: RecordMX, Value: "mx.example.com", Priority: intPtr(20)},
+		{ID: "4", Name: "api", Type: RecordA, Value: "203.0.113.10"},
+	}
+
+	renamed, err := Rename(mx, "smtp", existing)
+	if err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+	if renamed.Name != "smtp" || renamed.Value != mx.Value || renamed.TTL != 600 || *renamed.Priority != 10 {
+		t.Errorf("expected only the name to change, got %+v", renamed)
+	}
+
+	tests := []struct {
+		record  Record
+		newName string
+		wantErr string
+	}{
+		{mx, "", "must not be empty"},
+		{mx, "bad name", "invalid record name"},
+		{mx, "MAIL", "already named"},
+		{mx, "mx", "identical MX record"},
+		{mx, "www", "cannot share its name"},
+		{Record{ID: "5", Name: "blog", Type: RecordCNAME, Value: "example.com."}, "api", "cannot share its name"},
+	}
+	for _, tt := range tests {
+		_, err := Rename(tt.record, tt.newName, existing)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Rename(%q -> %q) error = %v, want %q", tt.record.Name, tt.newName, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NameserverStatus says whether a zone is delegated to its provider.
+type NameserverStatus string
+
+const (
+	// NameserversOK means the zone's registered nameservers are exactly
+	// the ones the provider assigned.
+	NameserversOK NameserverStatus = "ok"
+	// NameserversMismatch means the registrar points elsewhere, so
+	// records edited at the provider are not served.
+	NameserversMismatch NameserverStatus = "mismatch"
+	// NameserversUnknown means the provider did not report both sets.
+	NameserversUnknown NameserverStatus = "unknown"
+)
+
+// ZoneStats summarises one zone for the domain list.
+type ZoneStats struct {
+	Domain string `json:"domain"`
+	// Records counts the zone's records; ByType breaks that down by
+	// record type.
+	Records int                `json:"records"`
+	ByType  map[RecordType]int `json:"by_type,omitempty"`
+	// Plan is the provider's plan for the zone (e.g. Cloudflare's
+	// "Free Website"), empty when the provider has no plans.
+	Plan string `json:"plan,omitempty"`
+	// Nameservers are the nameservers currently delegated for the zone;
+	// Expected are the ones the provider assigned it.
+	Nameservers []string `json:"nameservers,omitempty"`
+	Expected    []string `json:"expected_nameservers,omitempty"`
+}
+
+// CountRecords fills in the record counts of s from the zone's records.
+func (s *ZoneStats) CountRecords(records []Record) {
+	s.Records = len(records)
+	s.ByType = make(map[RecordType]int)
+	for _, r := range records {
+		s.ByType[r.Type]++
+	}
+}
+
+// NameserverStatus compares the delegated nameservers with the expected
+// ones, ignoring case, order and trailing dots.
+func (s ZoneStats) NameserverStatus() NameserverStatus {
+	if len(s.Nameservers) == 0 || len(s.Expected) == 0 {
+		return NameserversUnknown
+	}
+	if slices.Equal(normaliseNameservers(s.Nameservers), normaliseNameservers(s.Expected)) {
+		return NameserversOK
+	}
+	return NameserversMismatch
+}
+
+// TypeSummary lists the record counts by type, most common first, e.g.
+// "3 A, 2 TXT, 1 MX".
+func (s ZoneStats) TypeSummary() string {
+	types := make([]RecordType, 0, len(s.ByType))
+	for t := range s.ByType {
+		types = append(types, t)
+	}
+	slices.SortFunc(types, func(a, b RecordType) int {
+		if s.ByType[a] != s.ByType[b] {
+			return s.ByType[b] - s.ByType[a]
+		}
+		return strings.Compare(string(a), string(b))
+	})
+
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%d %s", s.ByType[t], t)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Problems lists what looks wrong with the zone: no records beyond the
+// provider-managed apex NS and SOA, or nameservers that do not point at
+// the provider. An empty result means the zone looks healthy.
+func (s ZoneStats) Problems() []string {
+	var problems []string
+	if s.Records-s.ByType[RecordNS]-s.ByType["SOA"] <= 0 {
+		problems = append(problems, "zone has no records")
+	}
+	if s.NameserverStatus() == NameserversMismatch {
+		problems = append(problems, fmt.Sprintf("nameservers are %s, expected %s",
+			strings.Join(s.Nameservers, ", "), strings.Join(s.Expected, ", ")))
+	}
+	return problems
+}
+
+func normaliseNameservers(ns []string) []string {
+	out := make([]string, len(ns))
+	for i, n := range ns {
+		out[i] = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(n), "."))
+	}
+	slices.Sort(out)
+	return out
+}
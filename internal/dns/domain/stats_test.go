@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestZoneStats_CountRecords(t *testing.T) {
+	var s ZoneStats
+	s.CountRecords([]Record{
+		{Name: "@", Type: RecordA, Value: "203.0.113.10"},
+		{Name: "www", Type: RecordA, Value: "203.0.113.10"},
+		{Name: "@", Type: RecordTXT, Value: "v=spf1 -all"},
+		{Name: "@", Type: RecordMX, Value: "mx.example.com"},
+		{Name: "@", Type: RecordTXT, Value: "google-site-verification=abc"},
+		{Name: "api", Type: RecordA, Value: "203.0.113.11"},
+	})
+
+	if s.Records != 6 {
+		t.Errorf("Records = %d, want 6", s.Records)
+	}
+	if got, want := s.TypeSummary(), "3 A, 2 TXT, 1 MX"; got != want {
+		t.Errorf("TypeSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestZoneStats_NameserverStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  []string
+		expected []string
+		want     NameserverStatus
+	}{
+		{"match", []string{"b.ns.example.", "A.NS.EXAMPLE"}, []string{"a.ns.example", "b.ns.example"}, NameserversOK},
+		{"mismatch", []string{"ns1.registrar.example"}, []string{"a.ns.example", "b.ns.example"}, NameserversMismatch},
+		{"not reported", nil, []string{"a.ns.example"}, NameserversUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ZoneStats{Nameservers: tt.current, Expected: tt.expected}
+			if got := s.NameserverStatus(); got != tt.want {
+				t.Errorf("NameserverStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZoneStats_Problems(t *testing.T) {
+	s := ZoneStats{
+		Domain:      "example.com",
+		Nameservers: []string{"ns1.registrar.example"},
+		Expected:    []string{"a.ns.example"},
+	}
+	s.CountRecords([]Record{
+		{Name: "@", Type: RecordNS, Value: "a.ns.example"},
+		{Name: "@", Type: "SOA", Value: "a.ns.example hostmaster.example.com 1 7200 3600 1209600 300"},
+	})
+
+	want := []string{
+		"zone has no records",
+		"nameservers are ns1.registrar.example, expected a.ns.example",
+	}
+	if diff := cmp.Diff(want, s.Problems()); diff != "" {
+		t.Errorf("Problems() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Template is a named set of records for a common hosting or verification
+// setup. Record names and values may contain {var} placeholders that are
+// filled in from Vars when the template is rendered.
+type Template struct {
+	Name        string
+	Description string
+	// Vars lists the placeholders the records use, in prompt order.
+	Vars    []TemplateVar
+	Records []Record
+}
+
+// TemplateVar is a value the user supplies when applying a template.
+type TemplateVar struct {
+	Name   string
+	Prompt string
+}
+
+// Templates lists the built-in record templates.
+var Templates = []Template{
+	{
+		Name:        "github-pages",
+		Description: "GitHub Pages apex A/AAAA set and www CNAME",
+		Vars:        []TemplateVar{{Name: "user", Prompt: "GitHub user or organisation"}},
+		Records: []Record{
+			{Name: "@", Type: RecordA, Value: "185.199.108.153"},
+			{Name: "@", Type: RecordA, Value: "185.199.109.153"},
+			{Name: "@", Type: RecordA, Value: "185.199.110.153"},
+			{Name: "@", Type: RecordA, Value: "185.199.111.153"},
+			{Name: "@", Type: RecordAAAA, Value: "2606:50c0:8000::153"},
+			{Name: "@", Type: RecordAAAA, Value: "2606:50c0:8001::153"},
+			{Name: "@", Type: RecordAAAA, Value: "2606:50c0:8002::153"},
+			{Name: "@", Type: RecordAAAA, Value: "2606:50c0:8003::153"},
+			{Name: "www", Type: RecordCNAME, Value: "{user}.github.io."},
+		},
+	},
+	{
+		Name:        "google-verification",
+		Description: "Google site verification TXT record",
+		Vars:        []TemplateVar{{Name: "token", Prompt: "Verification token (after google-site-verification=)"}},
+		Records: []Record{
+			{Name: "@", Type: RecordTXT, Value: "google-site-verification={token}"},
+		},
+	},
+	{
+		Name:        "fastmail",
+		Description: "Fastmail MX set and SPF record",
+		Records: []Record{
+			{Name: "@", Type: RecordMX, Value: "in1-smtp.messagingengine.com.", Priority: intPtr(10)},
+			{Name: "@", Type: RecordMX, Value: "in2-smtp.messagingengine.com.", Priority: intPtr(20)},
+			{Name: "@", Type: RecordTXT, Value: "v=spf1 include:spf.messagingengine.com ?all"},
+		},
+	},
+}
+
+// LookupTemplate returns the built-in template called name, or nil.
+func LookupTemplate(name string) *Template {
+	for i := range Templates {
+		if Templates[i].Name == strings.ToLower(strings.TrimSpace(name)) {
+			return &Templates[i]
+		}
+	}
+	return nil
+}
+
+// Render fills the template's placeholders from vars and validates the
+// resulting records. Every variable must be given a non-empty value.
+func (t Template) Render(vars map[string]string) ([]Record, error) {
+	replacements := make([]string, 0, 2*len(t.Vars))
+	for _, v := range t.Vars {
+		value := strings.TrimSpace(vars[v.Name])
+		if value == "" {
+			return nil, fmt.Errorf("template %s: missing value for %q", t.Name, v.Name)
+		}
+		replacements = append(replacements, "{"+v.Name+"}", value)
+	}
+	r := strings.NewReplacer(replacements...)
+
+	records := make([]Record, len(t.Records))
+	for i, rec := range t.Records {
+		rec.Name = r.Replace(rec.Name)
+		rec.Value = r.Replace(rec.Value)
+		if err := ValidateRecord(rec); err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		records[i] = rec
+	}
+	return records, nil
+}
+
+func intPtr(i int) *int { return &i }
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplates_Valid(t *testing.T) {
+	for _, tmpl := range Templates {
+		vars := make(map[string]string)
+		for _, v := range tmpl.Vars {
+			vars[v.Name] = "example"
+		}
+		if _, err := tmpl.Render(vars); err != nil {
+			t.Errorf("template %s does not render: %v", tmpl.Name, err)
+		}
+	}
+}
+
+func TestTemplate_RenderFillsVars(t *testing.T) {
+	records, err := LookupTemplate("GitHub-Pages").Render(map[string]string{"user": "octocat"})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	last := records[len(records)-1]
+	if last.Name != "www" || last.Value != "octocat.github.io." {
+		t.Errorf("unexpected CNAME record %+v", last)
+	}
+}
+
+func TestTemplate_RenderErrors(t *testing.T) {
+	tmpl := LookupTemplate("github-pages")
+	if _, err := tmpl.Render(nil); err == nil || !strings.Contains(err.Error(), `missing value for "user"`) {
+		t.Errorf("expected a missing value error, got %v", err)
+	}
+	if _, err := tmpl.Render(map[string]string{"user": "bad user"}); err == nil {
+		t.Error("expected an invalid hostname to be rejected")
+	}
+	if LookupTemplate("nope") != nil {
+		t.Error("expected no template for an unknown name")
+	}
+}
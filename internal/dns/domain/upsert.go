@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UpsertAction is what an upsert does to a zone.
+type UpsertAction string
+
+const (
+	UpsertCreate    UpsertAction = "create"
+	UpsertUpdate    UpsertAction = "update"
+	UpsertUnchanged UpsertAction = "unchanged"
+)
+
+// PlanUpsert decides how to apply r to a zone holding existing. With no
+// record of the same name and type, r is created. With exactly one, that
+// record is updated in place: the returned record carries its ID and r's
+// content, TTL and priority. If the existing record already matches, the
+// action is UpsertUnchanged. Several records sharing the name and type
+// (round-robin A records, multiple TXT values) are ambiguous and return
+// an error rather than guessing which one to overwrite.
+func PlanUpsert(r Record, existing []Record) (Record, UpsertAction, error) {
+	var matches []Record
+	for _, other := range existing {
+		if other.Type == r.Type && strings.EqualFold(other.Name, r.Name) {
+			matches = append(matches, other)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return r, UpsertCreate, nil
+	case 1:
+		updated := r
+		updated.ID = matches[0].ID
+		if sameContent(matches[0], updated) {
+			return updated, UpsertUnchanged, nil
+		}
+		return updated, UpsertUpdate, nil
+	default:
+		return Record{}, "", fmt.Errorf("%d %s records exist at %q; delete the ones to replace before upserting", len(matches), r.Type, r.Name)
+	}
+}
+
+// sameContent reports whether a and b hold the same value, TTL and
+// priority.
+func sameContent(a, b Record) bool {
+	if a.Value != b.Value || a.TTL != b.TTL {
+		return false
+	}
+	if a.Priority == nil || b.Priority == nil {
+		return a.Priority == b.Priority
+	}
+	return *a.Priority == *b.Priority
+}
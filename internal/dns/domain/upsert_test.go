@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlanUpsert(t *testing.T) {
+	existing := []Record{
+		{ID: "1", Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "2", Name: "www", Type: RecordAAAA, Value: "2001:db8::1"},
+		{ID: "3", Name: "@", Type: RecordTXT, Value: "v=spf1 -all"},
+		{ID: "4", Name: "@", Type: RecordTXT, Value: "google-site-verification=abc"},
+		{ID: "5", Name: "mail", Type: RecordMX, Value: "mx.example.com", Priority: intPtr(10)},
+	}
+
+	tests := []struct {
+		name       string
+		record     Record
+		wantAction UpsertAction
+		wantID     string
+		wantErr    string
+	}{
+		{"new name", Record{Name: "api", Type: RecordA, Value: "203.0.113.20"}, UpsertCreate, "", ""},
+		{"new type at existing name", Record{Name: "www", Type: RecordCNAME, Value: "example.com."}, UpsertCreate, "", ""},
+		{"changed value", Record{Name: "WWW", Type: RecordA, Value: "203.0.113.11", TTL: 300}, UpsertUpdate, "1", ""},
+		{"changed TTL", Record{Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 60}, UpsertUpdate, "1", ""},
+		{"changed priority", Record{Name: "mail", Type: RecordMX, Value: "mx.example.com", Priority: intPtr(20)}, UpsertUpdate, "5", ""},
+		{"identical", Record{Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300}, UpsertUnchanged, "1", ""},
+		{"ambiguous", Record{Name: "@", Type: RecordTXT, Value: "v=spf1 mx -all"}, "", "", "2 TXT records exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, action, err := PlanUpsert(tt.record, existing)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if action != tt.wantAction {
+				t.Errorf("action = %q, want %q", action, tt.wantAction)
+			}
+			if got.ID != tt.wantID {
+				t.Errorf("ID = %q, want %q", got.ID, tt.wantID)
+			}
+			if got.Value != tt.record.Value {
+				t.Errorf("Value = %q, want %q", got.Value, tt.record.Value)
+			}
+		})
+	}
+}
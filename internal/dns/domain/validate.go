@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ValidateRecord checks that a record's value (and priority, where needed)
+// is valid for its type. It is provider-agnostic and meant to run before
+// any create or update call, in both the CLI and the TUI.
+func ValidateRecord(r Record) error {
+	if err := ValidateValue(r.Type, r.Value); err != nil {
+		return err
+	}
+	if r.Type == RecordMX || r.Type == RecordSRV {
+		if r.Priority == nil {
+			return fmt.Errorf("%s records require a priority", r.Type)
+		}
+		if *r.Priority < 0 || *r.Priority > 65535 {
+			return fmt.Errorf("priority must be between 0 and 65535, got %d", *r.Priority)
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks that value is valid content for a record of type t.
+// Types without specific rules only need a non-empty value.
+func ValidateValue(t RecordType, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fmt.Errorf("%s record value must not be empty", t)
+	}
+
+	switch t {
+	case RecordA:
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("A records require an IPv4 address, got %q", value)
+		}
+	case RecordAAAA:
+		addr, err := netip.ParseAddr(value)
+		if err != nil || !addr.Is6() || addr.Is4In6() {
+			return fmt.Errorf("AAAA records require an IPv6 address, got %q", value)
+		}
+	case RecordCNAME, RecordMX, RecordNS:
+		if err := ValidateHostname(value); err != nil {
+			return fmt.Errorf("%s records require a hostname: %w", t, err)
+		}
+	case RecordTXT:
+		return validateTXT(value)
+	case RecordSRV:
+		return validateSRV(value)
+	case RecordCAA:
+		return validateCAA(value)
+	}
+	return nil
+}
+
+// validateTXT checks TXT content. Unquoted text is split into
+// character-strings automatically; quoted text is taken as given, so each
+// quoted string has to fit in one.
+func validateTXT(value string) error {
+	for _, c := range value {
+		if c != '\t' && unicode.IsControl(c) {
+			return fmt.Errorf("TXT records must not contain line breaks or control characters; put each line in its own quoted string")
+		}
+	}
+	if !strings.HasPrefix(value, `"`) {
+		return nil
+	}
+
+	rest := value
+	for rest != "" {
+		if rest[0] != '"' {
+			return fmt.Errorf("TXT value %q mixes quoted and unquoted text; quote every part or none", value)
+		}
+		end, escaped := -1, false
+		for i := 1; i < len(rest); i++ {
+			switch {
+			case escaped:
+				escaped = false
+			case rest[i] == '\\':
+				escaped = true
+			case rest[i] == '"':
+				end = i
+			}
+			if end >= 0 {
+				break
+			}
+		}
+		if end < 0 {
+			return fmt.Errorf("TXT value %q has an unterminated quoted string", value)
+		}
+		if n := len(unquoteTXT(rest[:end+1])); n > txtChunkSize {
+			return fmt.Errorf("TXT strings hold at most %d characters, one has %d; split it into several quoted strings, or pass the value unquoted to have it split automatically", txtChunkSize, n)
+		}
+		rest = strings.TrimLeft(rest[end+1:], " \t")
+	}
+	return nil
+}
+
+// validateSRV checks SRV content in the form "<weight> <port> <target>";
+// the priority is kept separately.
+func validateSRV(value string) error {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return fmt.Errorf(`SRV records take "<weight> <port> <target>" with the priority set separately, e.g. "5 5060 sip.example.com"; got %q`, value)
+	}
+	for i, field := range []string{"weight", "port"} {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n < 0 || n > 65535 {
+			return fmt.Errorf("SRV %s must be a number between 0 and 65535, got %q", field, fields[i])
+		}
+	}
+	if fields[2] == "." {
+		return nil // the service is decidedly not available
+	}
+	if err := ValidateHostname(fields[2]); err != nil {
+		return fmt.Errorf("SRV target must be a hostname: %w", err)
+	}
+	return nil
+}
+
+// validateCAA checks CAA content in the form `<flags> <tag> "<value>"`.
+func validateCAA(value string) error {
+	flags, rest, _ := strings.Cut(value, " ")
+	tag, tagValue, _ := strings.Cut(strings.TrimSpace(rest), " ")
+	tagValue = strings.TrimSpace(tagValue)
+	if n, err := strconv.Atoi(flags); err != nil || n < 0 || n > 255 || tag == "" || tagValue == "" {
+		return fmt.Errorf(`CAA records take "<flags> <tag> <value>", e.g. '0 issue "letsencrypt.org"'; got %q`, value)
+	}
+	for _, c := range tag {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return fmt.Errorf("CAA tag %q must be letters and digits only, e.g. issue, issuewild or iodef", tag)
+		}
+	}
+	return nil
+}
+
+// ValidateHostname checks that s is a syntactically valid DNS hostname.
+// A trailing dot (fully qualified form) is allowed. IP addresses are
+// rejected, since CNAME/MX/NS targets must be names.
+func ValidateHostname(s string) error {
+	if _, err := netip.ParseAddr(s); err == nil {
+		return fmt.Errorf("%q is an IP address, not a hostname", s)
+	}
+
+	name := strings.TrimSuffix(s, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("%q is not a valid hostname", s)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if err := validateLabel(label); err != nil {
+			return fmt.Errorf("%q is not a valid hostname: %w", s, err)
+		}
+	}
+	return nil
+}
+
+// validateLabel checks a single hostname label: 1-63 letters, digits,
+// hyphens or underscores, not starting or ending with a hyphen.
+func validateLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("empty label")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("label %q is longer than 63 characters", label)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("label %q must not start or end with a hyphen", label)
+	}
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_':
+		default:
+			return fmt.Errorf("label %q contains invalid character %q", label, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		typ     RecordType
+		value   string
+		wantErr string
+	}{
+		{RecordA, "203.0.113.10", ""},
+		{RecordA, "2001:db8::1", "IPv4"},
+		{RecordA, "example.com", "IPv4"},
+		{RecordAAAA, "2001:db8::1", ""},
+		{RecordAAAA, "203.0.113.10", "IPv6"},
+		{RecordAAAA, "::ffff:203.0.113.10", "IPv6"},
+		{RecordCNAME, "target.example.com.", ""},
+		{RecordCNAME, "203.0.113.10", "IP address"},
+		{RecordCNAME, "bad_host-.example.com", "hyphen"},
+		{RecordMX, "mail.example.com", ""},
+		{RecordMX, "mail..example.com", "empty label"},
+		{RecordNS, "ns1.example.com", ""},
+		{RecordTXT, "v=spf1 include:_spf.example.com ~all", ""},
+		{RecordTXT, "  ", "must not be empty"},
+		{RecordCNAME, "_acme-challenge.example.com", ""},
+		{RecordTXT, strings.Repeat("a", 600), ""},
+		{RecordTXT, `"v=DKIM1; k=rsa;" "p=MIIB"`, ""},
+		{RecordTXT, `"` + strings.Repeat("a", 256) + `"`, "at most 255"},
+		{RecordTXT, `"v=spf1 -all`, "unterminated"},
+		{RecordTXT, `"v=spf1" -all`, "mixes quoted"},
+		{RecordTXT, "line one\nline two", "line breaks"},
+		{RecordSRV, "5 5060 sip.example.com", ""},
+		{RecordSRV, "0 0 .", ""},
+		{RecordSRV, "10 5 5060 sip.example.com", "<weight> <port> <target>"},
+		{RecordSRV, "5 70000 sip.example.com", "port"},
+		{RecordSRV, "5 5060 203.0.113.10", "IP address"},
+		{RecordCAA, `0 issue "letsencrypt.org"`, ""},
+		{RecordCAA, "issue letsencrypt.org", "<flags> <tag> <value>"},
+		{RecordCAA, `0 is-sue "letsencrypt.org"`, "letters and digits"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.typ)+" "+tt.value, func(t *testing.T) {
+			err := ValidateValue(tt.typ, tt.value)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRecord_MXRequiresPriority(t *testing.T) {
+	r := Record{Name: "@", Type: RecordMX, Value: "mail.example.com"}
+	if err := ValidateRecord(r); err == nil || !strings.Contains(err.Error(), "priority") {
+		t.Errorf("expected priority error, got %v", err)
+	}
+
+	r.Priority = intPtr(10)
+	if err := ValidateRecord(r); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	r.Priority = intPtr(70000)
+	if err := ValidateRecord(r); err == nil {
+		t.Error("expected out-of-range priority error")
+	}
+}
+
+func TestValidateRecord_PriorityIgnoredForA(t *testing.T) {
+	r := Record{Name: "www", Type: RecordA, Value: "203.0.113.10"}
+	if err := ValidateRecord(r); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseRecordType(t *testing.T) {
+	if got := ParseRecordType(" aaaa "); got != RecordAAAA {
+		t.Errorf("ParseRecordType = %q, want %q", got, RecordAAAA)
+	}
+}
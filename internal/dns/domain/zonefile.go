@@ -0,0 +1,323 @@
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FormatZoneFile renders records as a BIND zone file for zone. Names are
+// written relative to a $ORIGIN of the zone, records without a TTL get
+// none (the provider's default applies on import) and the output is
+// sorted by name and type so exports diff cleanly.
+func FormatZoneFile(zone string, records []Record) string {
+	sorted := append([]Record(nil), records...)
+	sortRecords(sorted)
+
+	nameWidth := 1
+	for _, r := range sorted {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s\n", fqdn(zone))
+	for _, r := range sorted {
+		ttl := ""
+		if r.TTL > 0 {
+			ttl = strconv.Itoa(r.TTL)
+		}
+		fmt.Fprintf(&b, "%-*s  %-6s  IN  %-5s  %s\n", nameWidth, zoneFileName(r.Name), ttl, r.Type, FormatRData(r))
+	}
+	return b.String()
+}
+
+// sortRecords orders records by name (apex first), type and value.
+func sortRecords(records []Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if a.Name != b.Name {
+			if a.Name == "@" || b.Name == "@" {
+				return a.Name == "@"
+			}
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Value < b.Value
+	})
+}
+
+func zoneFileName(name string) string {
+	if name == "" {
+		return "@"
+	}
+	return name
+}
+
+// ParseZoneFile reads the records of zone from a BIND zone file. It
+// understands $ORIGIN and $TTL, comments, parenthesised multi-line
+// records, blank owners (repeating the previous one) and relative names.
+// SOA records are skipped, since providers generate their own; $INCLUDE
+// and classes other than IN are rejected. Records without a TTL or $TTL
+// get none, leaving the provider's default.
+func ParseZoneFile(r io.Reader, zone string) ([]Record, error) {
+	zone = strings.ToLower(fqdn(zone))
+	p := zoneParser{origin: zone, zone: zone}
+
+	lines, err := logicalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, line := range lines {
+		record, ok, err := p.parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line.number, err)
+		}
+		if ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// zoneLine is a logical zone file line: the tokens of a record, which may
+// span physical lines inside parentheses.
+type zoneLine struct {
+	number int
+	// indented is set when the line starts with whitespace, i.e. reuses
+	// the previous owner.
+	indented bool
+	tokens   []string
+}
+
+// logicalLines splits a zone file into logical lines of tokens, dropping
+// comments and joining parenthesised continuations. Quoted strings are
+// kept as single tokens, quotes included.
+func logicalLines(r io.Reader) ([]zoneLine, error) {
+	var lines []zoneLine
+	var current *zoneLine
+	depth := 0
+
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := scanner.Text()
+		if current == nil {
+			current = &zoneLine{number: number, indented: text != "" && (text[0] == ' ' || text[0] == '\t')}
+		}
+
+		var tok strings.Builder
+		inQuotes, escaped := false, false
+		flush := func() {
+			if tok.Len() > 0 {
+				current.tokens = append(current.tokens, tok.String())
+				tok.Reset()
+			}
+		}
+	scan:
+		for _, c := range text {
+			switch {
+			case escaped:
+				tok.WriteRune(c)
+				escaped = false
+			case c == '\\':
+				tok.WriteRune(c)
+				escaped = true
+			case c == '"':
+				tok.WriteRune(c)
+				inQuotes = !inQuotes
+				if !inQuotes {
+					flush()
+				}
+			case inQuotes:
+				tok.WriteRune(c)
+			case c == ';':
+				break scan
+			case c == '(':
+				flush()
+				depth++
+			case c == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parenthesis", number)
+				}
+				depth--
+			case c == ' ' || c == '\t':
+				flush()
+			default:
+				tok.WriteRune(c)
+			}
+		}
+		if inQuotes {
+			return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+		}
+		flush()
+
+		if depth == 0 {
+			if len(current.tokens) > 0 {
+				lines = append(lines, *current)
+			}
+			current = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read zone file: %w", err)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", current.number)
+	}
+	return lines, nil
+}
+
+// zoneParser holds the state carried between zone file lines.
+type zoneParser struct {
+	zone       string // fully qualified, lower case
+	origin     string // fully qualified
+	defaultTTL int
+	lastOwner  string
+}
+
+// parseLine parses one logical line. ok is false for directives and
+// skipped records.
+func (p *zoneParser) parseLine(line zoneLine) (record Record, ok bool, err error) {
+	tokens := line.tokens
+	switch strings.ToUpper(tokens[0]) {
+	case "$ORIGIN":
+		if len(tokens) != 2 {
+			return Record{}, false, fmt.Errorf("$ORIGIN needs one name")
+		}
+		p.origin = p.qualify(tokens[1])
+		return Record{}, false, nil
+	case "$TTL":
+		if len(tokens) != 2 {
+			return Record{}, false, fmt.Errorf("$TTL needs one value")
+		}
+		ttl, err := parseTTL(tokens[1])
+		if err != nil {
+			return Record{}, false, err
+		}
+		p.defaultTTL = ttl
+		return Record{}, false, nil
+	case "$INCLUDE", "$GENERATE":
+		return Record{}, false, fmt.Errorf("%s is not supported", tokens[0])
+	}
+
+	owner := p.lastOwner
+	if !line.indented {
+		owner = p.qualify(tokens[0])
+		tokens = tokens[1:]
+	}
+	if owner == "" {
+		return Record{}, false, fmt.Errorf("record has no owner name")
+	}
+	p.lastOwner = owner
+
+	ttl := p.defaultTTL
+	for len(tokens) > 0 {
+		if class := strings.ToUpper(tokens[0]); class == "IN" {
+			tokens = tokens[1:]
+			continue
+		} else if class == "CH" || class == "HS" || class == "CS" {
+			return Record{}, false, fmt.Errorf("class %s is not supported", class)
+		}
+		if t, err := parseTTL(tokens[0]); err == nil {
+			ttl = t
+			tokens = tokens[1:]
+			continue
+		}
+		break
+	}
+	if len(tokens) < 2 {
+		return Record{}, false, fmt.Errorf("expected a record type and value")
+	}
+
+	t := ParseRecordType(tokens[0])
+	if t == "SOA" {
+		return Record{}, false, nil
+	}
+	rdata := tokens[1:]
+	if i := targetIndex(t); i >= 0 && i < len(rdata) {
+		rdata[i] = p.qualify(rdata[i])
+	}
+
+	name, err := p.relative(owner)
+	if err != nil {
+		return Record{}, false, err
+	}
+	value, priority, err := ParseRData(t, strings.Join(rdata, " "))
+	if err != nil {
+		return Record{}, false, err
+	}
+	return Record{Name: name, Type: t, Value: value, TTL: ttl, Priority: priority}, true, nil
+}
+
+// targetIndex is the position of the hostname in a record's rdata
+// tokens, or -1 for types without one.
+func targetIndex(t RecordType) int {
+	switch t {
+	case RecordCNAME, RecordNS:
+		return 0
+	case RecordMX:
+		return 1
+	case RecordSRV:
+		return 3
+	}
+	return -1
+}
+
+// qualify makes name fully qualified relative to the current origin.
+func (p *zoneParser) qualify(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + p.origin
+}
+
+// relative converts a fully qualified owner to a name relative to the
+// zone, "@" for the apex.
+func (p *zoneParser) relative(owner string) (string, error) {
+	lower := strings.ToLower(owner)
+	if lower == p.zone {
+		return "@", nil
+	}
+	if !strings.HasSuffix(lower, "."+p.zone) {
+		return "", fmt.Errorf("%s is outside the zone %s", owner, p.zone)
+	}
+	return owner[:len(owner)-len(p.zone)-1], nil
+}
+
+// parseTTL parses a TTL in seconds or with BIND's unit suffixes, e.g.
+// "1h30m".
+func parseTTL(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		return n, nil
+	}
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, digits := 0, ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			digits += string(c)
+			continue
+		}
+		unit, ok := units[c|0x20] // lower case
+		if !ok || digits == "" {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		n, _ := strconv.Atoi(digits)
+		total += n * unit
+		digits = ""
+	}
+	if digits != "" || s == "" {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
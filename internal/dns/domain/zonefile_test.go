@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	zoneFile := `$ORIGIN example.com.
+$TTL 1h
+@   IN  SOA ns1.example.com. hostmaster.example.com. (
+            2024010101 ; serial
+            7200 3600 1209600 3600 )
+@       IN  A     203.0.113.10
+        IN  MX    10 mail          ; relative target
+www 300 IN  CNAME @
+_sip._tcp   SRV   0 5 5060 sip.example.net.
+mail.example.com.  IN  TXT "v=spf1 -all" ; fully qualified owner
+long    TXT ( "part one "
+              "part two" )
+`
+	records, err := ParseZoneFile(strings.NewReader(zoneFile), "example.com")
+	if err != nil {
+		t.Fatalf("ParseZoneFile() error: %v", err)
+	}
+
+	want := []Record{
+		{Name: "@", Type: RecordA, Value: "203.0.113.10", TTL: 3600},
+		{Name: "@", Type: RecordMX, Value: "mail.example.com", TTL: 3600, Priority: intPtr(10)},
+		{Name: "www", Type: RecordCNAME, Value: "example.com", TTL: 300},
+		{Name: "_sip._tcp", Type: RecordSRV, Value: "5 5060 sip.example.net", TTL: 3600, Priority: intPtr(0)},
+		{Name: "mail", Type: RecordTXT, Value: "v=spf1 -all", TTL: 3600},
+		{Name: "long", Type: RecordTXT, Value: "part one part two", TTL: 3600},
+	}
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("ParseZoneFile() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseZoneFile_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneFile string
+		want     string
+	}{
+		{"outside zone", "www.example.net. IN A 203.0.113.1\n", "line 1: www.example.net. is outside the zone"},
+		{"unterminated quote", "@ TXT \"open\n", "line 1: unterminated quoted string"},
+		{"unbalanced parenthesis", "@ TXT ( \"a\"\n", "unbalanced parenthesis"},
+		{"missing value", "www IN A\n", "line 1: expected a record type and value"},
+		{"include", "$INCLUDE other.zone\n", "$INCLUDE is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseZoneFile(strings.NewReader(tt.zoneFile), "example.com")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatZoneFile_RoundTrips(t *testing.T) {
+	records := []Record{
+		{ID: "3", Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+		{ID: "1", Name: "@", Type: RecordMX, Value: "mail.example.com", Priority: intPtr(10)},
+		{ID: "2", Name: "@", Type: RecordTXT, Value: `say "hi"`, TTL: 3600},
+	}
+
+	out := FormatZoneFile("example.com", records)
+	wantOut := `$ORIGIN example.com.
+@            IN  MX     10 mail.example.com.
+@    3600    IN  TXT    "say \"hi\""
+www  300     IN  A      203.0.113.10
+`
+	if diff := cmp.Diff(wantOut, out); diff != "" {
+		t.Errorf("FormatZoneFile() mismatch (-want +got):\n%s", diff)
+	}
+
+	parsed, err := ParseZoneFile(strings.NewReader(out), "example.com")
+	if err != nil {
+		t.Fatalf("ParseZoneFile() error: %v", err)
+	}
+	want := []Record{
+		{Name: "@", Type: RecordMX, Value: "mail.example.com", Priority: intPtr(10)},
+		{Name: "@", Type: RecordTXT, Value: `say "hi"`, TTL: 3600},
+		{Name: "www", Type: RecordA, Value: "203.0.113.10", TTL: 300},
+	}
+	if diff := cmp.Diff(want, parsed); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
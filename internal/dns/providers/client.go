@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
+)
+
+// requestTimeout bounds each attempt of an API request.
+const requestTimeout = 30 * time.Second
+
+// apiError is an error response from a DNS provider's API. It unwraps to
+// the matching domain sentinel error, if any.
+type apiError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: HTTP %d", e.Provider, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s (HTTP %d)", e.Provider, e.Message, e.StatusCode)
+}
+
+func (e *apiError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return dnsdomain.ErrUnauthorized
+	case http.StatusNotFound:
+		return dnsdomain.ErrNotFound
+	case http.StatusTooManyRequests:
+		return dnsdomain.ErrRateLimited
+	case http.StatusConflict, http.StatusUnprocessableEntity:
+		return dnsdomain.ErrConflict
+	}
+	return nil
+}
+
+func isRetryable(err error) bool {
+	if retry.IsRetryable(err) {
+		return true
+	}
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return false
+}
+
+// client is a minimal JSON API client shared by the DNS providers.
+type client struct {
+	name        string
+	endpoint    string
+	httpClient  *http.Client
+	retryConfig retry.Config
+
+	// authorize adds the provider's credentials to a request.
+	authorize func(req *http.Request)
+	// errorMessage extracts a human-readable message from an error
+	// response body, or returns "".
+	errorMessage func(body []byte) string
+}
+
+func newClient(name, endpoint string, authorize func(*http.Request), errorMessage func([]byte) string) *client {
+	return &client{
+		name:         name,
+		endpoint:     endpoint,
+		httpClient:   &http.Client{Transport: usagestats.Transport(name, nil)},
+		retryConfig:  retry.DefaultConfig(),
+		authorize:    authorize,
+		errorMessage: errorMessage,
+	}
+}
+
+// do sends a request to the API, retrying transient failures, and decodes
+// the JSON response into out (which may be nil). It returns the headers of
+// the successful response.
+func (c *client) do(ctx context.Context, method, path string, body, out interface{}) (http.Header, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	var header http.Header
+	err := retry.Do(ctx, c.retryConfig, isRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(reqCtx, method, c.endpoint+path, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		c.authorize(req)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			apiErr := &apiError{Provider: c.name, StatusCode: resp.StatusCode}
+			if raw, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil {
+				apiErr.Message = c.errorMessage(raw)
+			}
+			return apiErr
+		}
+		header = resp.Header
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	})
+	return header, err
+}
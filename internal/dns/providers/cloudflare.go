@@ -0,0 +1,443 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+var (
+	_ dnsdomain.Provider       = (*CloudflareProvider)(nil)
+	_ dnsdomain.DNSSECProvider = (*CloudflareProvider)(nil)
+)
+
+const (
+	cloudflareEndpoint = "https://api.cloudflare.com/client/v4"
+
+	// Cloudflare pages zones by at most 50 and records by at most 100.
+	cloudflareZonePageSize   = 50
+	cloudflareRecordPageSize = 100
+
+	// cloudflareAutoTTL is the TTL Cloudflare reports for records whose
+	// TTL it chooses itself.
+	cloudflareAutoTTL = 1
+)
+
+// CloudflareProvider implements dnsdomain.Provider and
+// dnsdomain.DNSSECProvider using the Cloudflare API. Records of proxied
+// hostnames are managed like any other; their proxy setting is kept.
+type CloudflareProvider struct {
+	client *client
+}
+
+// NewCloudflareProvider creates a CloudflareProvider authenticated with
+// the given API token, which needs the Zone:Read, DNS:Edit and (for
+// DNSSEC) Zone Settings:Edit permissions.
+func NewCloudflareProvider(token string) *CloudflareProvider {
+	return &CloudflareProvider{
+		client: newClient("cloudflare", cloudflareEndpoint,
+			func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) },
+			cloudflareErrorMessage),
+	}
+}
+
+// RegisterCloudflare registers the Cloudflare DNS provider factory.
+func RegisterCloudflare() {
+	Register("cloudflare", func(store auth.Store) (dnsdomain.Provider, error) {
+		token, err := store.GetToken("cloudflare")
+		if err != nil {
+			return nil, fmt.Errorf("cloudflare auth: %w", err)
+		}
+		return NewCloudflareProvider(token), nil
+	})
+}
+
+func (c *CloudflareProvider) GetDisplayName() string {
+	return "Cloudflare"
+}
+
+// Quirks reports that Cloudflare serves the zone's apex NS records itself
+// and accepts TTLs down to a minute (lower TTLs mean "automatic").
+func (c *CloudflareProvider) Quirks() dnsdomain.Quirks {
+	return dnsdomain.Quirks{MinTTL: 60, ManagesApexNS: true}
+}
+
+// cloudflareErrorMessage joins the messages of an error body:
+// {"errors": [{"code": 1003, "message": ...}]}.
+func cloudflareErrorMessage(body []byte) string {
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	messages := make([]string, 0, len(resp.Errors))
+	for _, e := range resp.Errors {
+		messages = append(messages, e.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// --- API types ---
+
+type cloudflareZone struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	NameServers []string `json:"name_servers"`
+	Plan        struct {
+		Name string `json:"name"`
+	} `json:"plan"`
+}
+
+type cloudflareRecord struct {
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name"`
+	Type     string          `json:"type"`
+	Content  string          `json:"content,omitempty"`
+	TTL      int             `json:"ttl"`
+	Priority *int            `json:"priority,omitempty"`
+	Proxied  *bool           `json:"proxied,omitempty"`
+	Data     *cloudflareData `json:"data,omitempty"`
+}
+
+// cloudflareData holds the fields of SRV and CAA records, which Cloudflare
+// takes apart instead of as content.
+type cloudflareData struct {
+	// SRV
+	Priority *int   `json:"priority,omitempty"`
+	Weight   *int   `json:"weight,omitempty"`
+	Port     *int   `json:"port,omitempty"`
+	Target   string `json:"target,omitempty"`
+
+	// CAA
+	Flags *int   `json:"flags,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+type cloudflareResultInfo struct {
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+}
+
+type cloudflareDNSSEC struct {
+	Status     string `json:"status"`
+	Algorithm  string `json:"algorithm"`
+	DigestType string `json:"digest_type"`
+	Digest     string `json:"digest"`
+	KeyTag     int    `json:"key_tag"`
+	Flags      int    `json:"flags"`
+	PublicKey  string `json:"public_key"`
+}
+
+// --- Provider implementation ---
+
+func (c *CloudflareProvider) ListZones(ctx context.Context) ([]dnsdomain.Zone, error) {
+	var zones []dnsdomain.Zone
+	for page := 1; ; page++ {
+		var resp struct {
+			Result     []cloudflareZone     `json:"result"`
+			ResultInfo cloudflareResultInfo `json:"result_info"`
+		}
+		path := fmt.Sprintf("/zones?page=%d&per_page=%d", page, cloudflareZonePageSize)
+		if _, err := c.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list zones: %w", err)
+		}
+		for _, z := range resp.Result {
+			zones = append(zones, dnsdomain.Zone{ID: z.ID, Name: z.Name, Nameservers: z.NameServers, Plan: z.Plan.Name})
+		}
+		if page >= resp.ResultInfo.TotalPages {
+			return zones, nil
+		}
+	}
+}
+
+// zoneID looks up the ID of the zone called name.
+func (c *CloudflareProvider) zoneID(ctx context.Context, name string) (string, error) {
+	var resp struct {
+		Result []cloudflareZone `json:"result"`
+	}
+	if _, err := c.client.do(ctx, http.MethodGet, "/zones?name="+url.QueryEscape(name), nil, &resp); err != nil {
+		return "", fmt.Errorf("failed to find zone %s: %w", name, err)
+	}
+	for _, z := range resp.Result {
+		if z.Name == name {
+			return z.ID, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find zone %s: %w", name, dnsdomain.ErrNotFound)
+}
+
+func (c *CloudflareProvider) ListRecords(ctx context.Context, zone string) ([]dnsdomain.Record, error) {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dnsdomain.Record
+	for page := 1; ; page++ {
+		var resp struct {
+			Result     []cloudflareRecord   `json:"result"`
+			ResultInfo cloudflareResultInfo `json:"result_info"`
+		}
+		path := fmt.Sprintf("/zones/%s/dns_records?page=%d&per_page=%d", url.PathEscape(zoneID), page, cloudflareRecordPageSize)
+		if _, err := c.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list records: %w", err)
+		}
+		for _, r := range resp.Result {
+			record, err := toDomainCloudflareRecord(zone, r)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		if page >= resp.ResultInfo.TotalPages {
+			return records, nil
+		}
+	}
+}
+
+func (c *CloudflareProvider) CreateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	body, err := toCloudflareRecord(zone, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result cloudflareRecord `json:"result"`
+	}
+	path := fmt.Sprintf("/zones/%s/dns_records", url.PathEscape(zoneID))
+	if _, err := c.client.do(ctx, http.MethodPost, path, body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	created, err := toDomainCloudflareRecord(zone, resp.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateRecord replaces the record with r.ID. PATCH keeps fields the
+// domain record does not carry, such as whether the hostname is proxied.
+func (c *CloudflareProvider) UpdateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	body, err := toCloudflareRecord(zone, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Result cloudflareRecord `json:"result"`
+	}
+	path := fmt.Sprintf("/zones/%s/dns_records/%s", url.PathEscape(zoneID), url.PathEscape(r.ID))
+	if _, err := c.client.do(ctx, http.MethodPatch, path, body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+	updated, err := toDomainCloudflareRecord(zone, resp.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *CloudflareProvider) DeleteRecord(ctx context.Context, zone, id string) error {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return err
+	}
+	path := fmt.Sprintf("/zones/%s/dns_records/%s", url.PathEscape(zoneID), url.PathEscape(id))
+	if _, err := c.client.do(ctx, http.MethodDelete, path, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	return nil
+}
+
+// --- DNSSEC ---
+
+func (c *CloudflareProvider) GetDNSSEC(ctx context.Context, zone string) (*dnsdomain.DNSSEC, error) {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Result cloudflareDNSSEC `json:"result"`
+	}
+	path := fmt.Sprintf("/zones/%s/dnssec", url.PathEscape(zoneID))
+	if _, err := c.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get DNSSEC status: %w", err)
+	}
+	return toDomainCloudflareDNSSEC(resp.Result), nil
+}
+
+func (c *CloudflareProvider) EnableDNSSEC(ctx context.Context, zone string) (*dnsdomain.DNSSEC, error) {
+	return c.setDNSSEC(ctx, zone, "active")
+}
+
+func (c *CloudflareProvider) DisableDNSSEC(ctx context.Context, zone string) (*dnsdomain.DNSSEC, error) {
+	return c.setDNSSEC(ctx, zone, "disabled")
+}
+
+// setDNSSEC asks Cloudflare to sign ("active") or unsign ("disabled") the
+// zone.
+func (c *CloudflareProvider) setDNSSEC(ctx context.Context, zone, status string) (*dnsdomain.DNSSEC, error) {
+	zoneID, err := c.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Result cloudflareDNSSEC `json:"result"`
+	}
+	path := fmt.Sprintf("/zones/%s/dnssec", url.PathEscape(zoneID))
+	if _, err := c.client.do(ctx, http.MethodPatch, path, map[string]string{"status": status}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to set DNSSEC status: %w", err)
+	}
+	return toDomainCloudflareDNSSEC(resp.Result), nil
+}
+
+// --- Conversion ---
+
+// cloudflareRelativeName turns Cloudflare's fully qualified record name
+// into one relative to zone.
+func cloudflareRelativeName(zone, name string) string {
+	name = strings.TrimSuffix(name, ".")
+	if strings.EqualFold(name, zone) {
+		return "@"
+	}
+	if trimmed, ok := strings.CutSuffix(name, "."+zone); ok {
+		return trimmed
+	}
+	return name
+}
+
+// cloudflareFullName is the inverse of cloudflareRelativeName.
+func cloudflareFullName(zone, name string) string {
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+func toDomainCloudflareRecord(zone string, r cloudflareRecord) (dnsdomain.Record, error) {
+	record := dnsdomain.Record{
+		ID:   r.ID,
+		Name: cloudflareRelativeName(zone, r.Name),
+		Type: dnsdomain.ParseRecordType(r.Type),
+		TTL:  r.TTL,
+	}
+	if record.TTL == cloudflareAutoTTL {
+		record.TTL = 0
+	}
+
+	switch record.Type {
+	case dnsdomain.RecordSRV:
+		if r.Data == nil || r.Data.Priority == nil || r.Data.Weight == nil || r.Data.Port == nil {
+			return dnsdomain.Record{}, fmt.Errorf("invalid SRV record %s: missing data", r.Name)
+		}
+		priority := *r.Data.Priority
+		record.Priority = &priority
+		record.Value = fmt.Sprintf("%d %d %s", *r.Data.Weight, *r.Data.Port, strings.TrimSuffix(r.Data.Target, "."))
+	case dnsdomain.RecordCAA:
+		if r.Data == nil || r.Data.Flags == nil {
+			return dnsdomain.Record{}, fmt.Errorf("invalid CAA record %s: missing data", r.Name)
+		}
+		record.Value = fmt.Sprintf("%d %s %q", *r.Data.Flags, r.Data.Tag, r.Data.Value)
+	case dnsdomain.RecordMX:
+		record.Value = strings.TrimSuffix(r.Content, ".")
+		record.Priority = r.Priority
+	default:
+		value, _, err := dnsdomain.ParseRData(record.Type, r.Content)
+		if err != nil {
+			return dnsdomain.Record{}, err
+		}
+		record.Value = value
+	}
+	return record, nil
+}
+
+func toCloudflareRecord(zone string, r dnsdomain.Record) (cloudflareRecord, error) {
+	out := cloudflareRecord{
+		Name: cloudflareFullName(zone, r.Name),
+		Type: string(r.Type),
+		TTL:  r.TTL,
+	}
+	if out.TTL == 0 {
+		out.TTL = cloudflareAutoTTL
+	}
+
+	switch r.Type {
+	case dnsdomain.RecordSRV:
+		fields := strings.Fields(r.Value)
+		if len(fields) != 3 {
+			return cloudflareRecord{}, fmt.Errorf("invalid SRV value %q: expected weight, port and target", r.Value)
+		}
+		weight, werr := strconv.Atoi(fields[0])
+		port, perr := strconv.Atoi(fields[1])
+		if werr != nil || perr != nil {
+			return cloudflareRecord{}, fmt.Errorf("invalid SRV value %q: weight and port must be numbers", r.Value)
+		}
+		priority := 0
+		if r.Priority != nil {
+			priority = *r.Priority
+		}
+		out.Data = &cloudflareData{Priority: &priority, Weight: &weight, Port: &port, Target: strings.TrimSuffix(fields[2], ".")}
+	case dnsdomain.RecordCAA:
+		fields := strings.SplitN(r.Value, " ", 3)
+		if len(fields) != 3 {
+			return cloudflareRecord{}, fmt.Errorf("invalid CAA value %q: expected flags, tag and value", r.Value)
+		}
+		flags, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return cloudflareRecord{}, fmt.Errorf("invalid CAA value %q: flags must be a number", r.Value)
+		}
+		out.Data = &cloudflareData{Flags: &flags, Tag: fields[1], Value: strings.Trim(fields[2], `"`)}
+	case dnsdomain.RecordMX:
+		out.Content = r.Value
+		out.Priority = r.Priority
+	default:
+		out.Content = r.Value
+	}
+	return out, nil
+}
+
+// cloudflareDNSSECStatus maps Cloudflare's DNSSEC states onto the domain's.
+var cloudflareDNSSECStatus = map[string]dnsdomain.DNSSECStatus{
+	"active":           dnsdomain.DNSSECActive,
+	"pending":          dnsdomain.DNSSECPending,
+	"pending-disabled": dnsdomain.DNSSECPendingDisable,
+	"disabled":         dnsdomain.DNSSECDisabled,
+}
+
+func toDomainCloudflareDNSSEC(d cloudflareDNSSEC) *dnsdomain.DNSSEC {
+	status, ok := cloudflareDNSSECStatus[d.Status]
+	if !ok {
+		status = dnsdomain.DNSSECStatus(d.Status)
+	}
+	result := &dnsdomain.DNSSEC{Status: status}
+	if d.Digest == "" {
+		return result
+	}
+
+	algorithm, _ := strconv.Atoi(d.Algorithm)
+	digestType, _ := strconv.Atoi(d.DigestType)
+	result.DS = []dnsdomain.DSRecord{{KeyTag: d.KeyTag, Algorithm: algorithm, DigestType: digestType, Digest: d.Digest}}
+	if d.PublicKey != "" {
+		result.Keys = []dnsdomain.DNSKEY{{Flags: d.Flags, Protocol: 3, Algorithm: algorithm, PublicKey: d.PublicKey}}
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+//go:build e2e
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// End-to-end tests against a real Cloudflare zone. They only build with
+// -tags e2e (see make e2e) and skip unless VPSM_E2E_CLOUDFLARE_TOKEN and
+// VPSM_E2E_CLOUDFLARE_ZONE are set. Use a test zone: every record the
+// suite creates is named e2ePrefix<unix time> and deleted when the test
+// ends, and leftovers from aborted runs are swept before the suite
+// starts.
+
+const (
+	e2ePrefix      = "vpsm-e2e-"
+	e2eStaleAfter  = time.Hour
+	e2eStepTimeout = 2 * time.Minute
+)
+
+// newE2EProvider returns a provider for the test zone, or skips.
+func newE2EProvider(t *testing.T) (*CloudflareProvider, string) {
+	t.Helper()
+	token, zone := os.Getenv("VPSM_E2E_CLOUDFLARE_TOKEN"), os.Getenv("VPSM_E2E_CLOUDFLARE_ZONE")
+	if token == "" || zone == "" {
+		t.Skip("VPSM_E2E_CLOUDFLARE_TOKEN or VPSM_E2E_CLOUDFLARE_ZONE not set")
+	}
+	c := NewCloudflareProvider(token)
+	sweepE2ERecords(t, c, zone)
+	return c, zone
+}
+
+// sweepE2ERecords deletes test records left behind by aborted runs.
+// Recent ones are kept in case another run is still using them.
+func sweepE2ERecords(t *testing.T, c *CloudflareProvider, zone string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	records, err := c.ListRecords(ctx, zone)
+	if err != nil {
+		t.Fatalf("failed to list records: %v", err)
+	}
+	for _, r := range records {
+		created, err := strconv.ParseInt(strings.TrimPrefix(r.Name, e2ePrefix), 10, 64)
+		if !strings.HasPrefix(r.Name, e2ePrefix) || err != nil || time.Since(time.Unix(created, 0)) < e2eStaleAfter {
+			continue
+		}
+		t.Logf("deleting stale test record %s %s (%s)", r.Name, r.Type, r.ID)
+		if err := c.DeleteRecord(ctx, zone, r.ID); err != nil && !errors.Is(err, dnsdomain.ErrNotFound) {
+			t.Errorf("failed to delete stale record %s: %v", r.ID, err)
+		}
+	}
+}
+
+// createE2ERecord creates a throwaway record and registers its deletion.
+func createE2ERecord(t *testing.T, c *CloudflareProvider, zone string, r dnsdomain.Record) *dnsdomain.Record {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	created, err := c.CreateRecord(ctx, zone, r)
+	if err != nil {
+		t.Fatalf("CreateRecord failed: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+		defer cancel()
+		if err := c.DeleteRecord(ctx, zone, created.ID); err != nil && !errors.Is(err, dnsdomain.ErrNotFound) {
+			t.Errorf("failed to delete test record %s, remove it by hand: %v", created.ID, err)
+		}
+	})
+	return created
+}
+
+// findE2ERecord returns the record with id in zone, or nil.
+func findE2ERecord(t *testing.T, c *CloudflareProvider, zone, id string) *dnsdomain.Record {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	records, err := c.ListRecords(ctx, zone)
+	if err != nil {
+		t.Fatalf("ListRecords failed: %v", err)
+	}
+	for _, r := range records {
+		if r.ID == id {
+			return &r
+		}
+	}
+	return nil
+}
+
+func TestE2ECloudflare_RecordLifecycle(t *testing.T) {
+	c, zone := newE2EProvider(t)
+	name := fmt.Sprintf("%s%d", e2ePrefix, time.Now().Unix())
+
+	created := createE2ERecord(t, c, zone, dnsdomain.Record{Name: name, Type: dnsdomain.RecordA, Value: "192.0.2.10", TTL: 300})
+	got := findE2ERecord(t, c, zone, created.ID)
+	if got == nil {
+		t.Fatalf("created record %s missing from ListRecords", created.ID)
+	}
+	if got.Name != name || got.Type != dnsdomain.RecordA || got.Value != "192.0.2.10" || got.TTL != 300 {
+		t.Errorf("record did not round-trip, got %+v", got)
+	}
+
+	ctx := context.Background()
+	update := *got
+	update.Value = "192.0.2.20"
+	update.TTL = 600
+	if _, err := c.UpdateRecord(ctx, zone, update); err != nil {
+		t.Fatalf("UpdateRecord failed: %v", err)
+	}
+	if got := findE2ERecord(t, c, zone, created.ID); got == nil || got.Value != "192.0.2.20" || got.TTL != 600 {
+		t.Errorf("expected the update to be listed, got %+v", got)
+	}
+
+	if err := c.DeleteRecord(ctx, zone, created.ID); err != nil {
+		t.Fatalf("DeleteRecord failed: %v", err)
+	}
+	if got := findE2ERecord(t, c, zone, created.ID); got != nil {
+		t.Errorf("record %s still listed after delete", created.ID)
+	}
+}
+
+func TestE2ECloudflare_TXTAndMXRecords(t *testing.T) {
+	c, zone := newE2EProvider(t)
+	name := fmt.Sprintf("%s%d", e2ePrefix, time.Now().Unix())
+	priority := 10
+
+	for _, r := range []dnsdomain.Record{
+		{Name: name, Type: dnsdomain.RecordTXT, Value: "v=spf1 -all", TTL: 300},
+		{Name: name, Type: dnsdomain.RecordMX, Value: "mail." + zone, TTL: 300, Priority: &priority},
+	} {
+		created := createE2ERecord(t, c, zone, r)
+		got := findE2ERecord(t, c, zone, created.ID)
+		if got == nil {
+			t.Fatalf("created %s record missing from ListRecords", r.Type)
+		}
+		if got.Value != r.Value {
+			t.Errorf("%s value = %q, want %q", r.Type, got.Value, r.Value)
+		}
+		if r.Priority != nil && (got.Priority == nil || *got.Priority != *r.Priority) {
+			t.Errorf("%s priority = %v, want %d", r.Type, got.Priority, *r.Priority)
+		}
+	}
+}
+
+func TestE2ECloudflare_ListZones(t *testing.T) {
+	c, zone := newE2EProvider(t)
+
+	zones, err := c.ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("ListZones failed: %v", err)
+	}
+	for _, z := range zones {
+		if z.Name == zone {
+			if len(z.Nameservers) == 0 {
+				t.Errorf("expected %s to list its nameservers", zone)
+			}
+			return
+		}
+	}
+	t.Errorf("test zone %s missing from ListZones", zone)
+}
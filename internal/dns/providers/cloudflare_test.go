@@ -0,0 +1,200 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestCloudflareProvider creates a CloudflareProvider that talks to a
+// test server.
+func newTestCloudflareProvider(t *testing.T, serverURL string) *CloudflareProvider {
+	t.Helper()
+	provider := NewCloudflareProvider("test-token")
+	provider.client.endpoint = serverURL
+	provider.client.httpClient = http.DefaultClient
+	provider.client.retryConfig = retry.Config{MaxAttempts: 1}
+	return provider
+}
+
+// cloudflareZoneHandler answers zone lookups for example.com with zone
+// "z1".
+func cloudflareZoneHandler(t *testing.T, w http.ResponseWriter, r *http.Request) bool {
+	t.Helper()
+	if r.URL.Path != "/zones" {
+		return false
+	}
+	if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+		t.Errorf("Authorization = %q, want Bearer test-token", got)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"result": []interface{}{map[string]interface{}{
+			"id": "z1", "name": "example.com", "name_servers": []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"},
+			"plan": map[string]interface{}{"name": "Free Website"},
+		}},
+		"result_info": map[string]interface{}{"page": 1, "total_pages": 1},
+	})
+	return true
+}
+
+func TestCloudflareListRecords_ConvertsNamesAndData(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cloudflareZoneHandler(t, w, r) {
+			return
+		}
+		if r.URL.Path != "/zones/z1/dns_records" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		records := []interface{}{
+			map[string]interface{}{"id": "r1", "name": "www.example.com", "type": "A", "content": "192.0.2.1", "ttl": 1, "proxied": true},
+			map[string]interface{}{"id": "r2", "name": "example.com", "type": "MX", "content": "mail.example.com", "ttl": 300, "priority": 10},
+			map[string]interface{}{"id": "r3", "name": "example.com", "type": "TXT", "content": `"v=spf1 -all"`, "ttl": 300},
+		}
+		if r.URL.Query().Get("page") == "2" {
+			records = []interface{}{
+				map[string]interface{}{"id": "r4", "name": "_sip._tcp.example.com", "type": "SRV", "ttl": 300,
+					"data": map[string]interface{}{"priority": 10, "weight": 5, "port": 5060, "target": "sip.example.com"}},
+				map[string]interface{}{"id": "r5", "name": "example.com", "type": "CAA", "ttl": 300,
+					"data": map[string]interface{}{"flags": 0, "tag": "issue", "value": "letsencrypt.org"}},
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"result":      records,
+			"result_info": map[string]interface{}{"page": 1, "total_pages": 2},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	records, err := newTestCloudflareProvider(t, srv.URL).ListRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ListRecords() error: %v", err)
+	}
+	want := []dnsdomain.Record{
+		{ID: "r1", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1"},
+		{ID: "r2", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", TTL: 300, Priority: intPtr(10)},
+		{ID: "r3", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all", TTL: 300},
+		{ID: "r4", Name: "_sip._tcp", Type: dnsdomain.RecordSRV, Value: "5 5060 sip.example.com", TTL: 300, Priority: intPtr(10)},
+		{ID: "r5", Name: "@", Type: dnsdomain.RecordCAA, Value: `0 issue "letsencrypt.org"`, TTL: 300},
+	}
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("ListRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCloudflareCreateRecord_SendsFullNameAndData(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cloudflareZoneHandler(t, w, r) {
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/zones/z1/dns_records" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": map[string]interface{}{
+			"id": "r9", "name": "_sip._tcp.example.com", "type": "SRV", "ttl": 1, "data": got["data"],
+		}})
+	}))
+	t.Cleanup(srv.Close)
+
+	created, err := newTestCloudflareProvider(t, srv.URL).CreateRecord(context.Background(), "example.com", dnsdomain.Record{
+		Name: "_sip._tcp", Type: dnsdomain.RecordSRV, Value: "5 5060 sip.example.com", Priority: intPtr(10),
+	})
+	if err != nil {
+		t.Fatalf("CreateRecord() error: %v", err)
+	}
+
+	wantBody := map[string]interface{}{
+		"name": "_sip._tcp.example.com", "type": "SRV", "ttl": float64(1),
+		"data": map[string]interface{}{"priority": float64(10), "weight": float64(5), "port": float64(5060), "target": "sip.example.com"},
+	}
+	if diff := cmp.Diff(wantBody, got); diff != "" {
+		t.Errorf("request body mismatch (-want +got):\n%s", diff)
+	}
+	if created.ID != "r9" || created.Value != "5 5060 sip.example.com" {
+		t.Errorf("created = %+v", created)
+	}
+}
+
+func TestCloudflareDNSSEC_EnableReturnsDS(t *testing.T) {
+	var gotStatus string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cloudflareZoneHandler(t, w, r) {
+			return
+		}
+		if r.URL.Path != "/zones/z1/dnssec" || r.Method != http.MethodPatch {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		gotStatus = body["status"]
+		json.NewEncoder(w).Encode(map[string]interface{}{"result": map[string]interface{}{
+			"status": "pending", "algorithm": "13", "digest_type": "2", "digest": "48e939042e82c22542cb377b580dfdc52a361cefdc72e7f9107e2b6bd9306a45",
+			"key_tag": 42, "flags": 257, "public_key": "oXiGYrSTO+LSCJ3mohc8EP+CzF9KxBj8/ydXJ22pKuZP3VAC3/Md/k7xZfz470CoRyZJ6gV6vml07IC3d8xqhA==",
+		}})
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := newTestCloudflareProvider(t, srv.URL).EnableDNSSEC(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("EnableDNSSEC() error: %v", err)
+	}
+	if gotStatus != "active" {
+		t.Errorf("requested status %q, want active", gotStatus)
+	}
+	want := &dnsdomain.DNSSEC{
+		Status: dnsdomain.DNSSECPending,
+		DS:     []dnsdomain.DSRecord{{KeyTag: 42, Algorithm: 13, DigestType: 2, Digest: "48e939042e82c22542cb377b580dfdc52a361cefdc72e7f9107e2b6bd9306a45"}},
+		Keys:   []dnsdomain.DNSKEY{{Flags: 257, Protocol: 3, Algorithm: 13, PublicKey: "oXiGYrSTO+LSCJ3mohc8EP+CzF9KxBj8/ydXJ22pKuZP3VAC3/Md/k7xZfz470CoRyZJ6gV6vml07IC3d8xqhA=="}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EnableDNSSEC() mismatch (-want +got):\n%s", diff)
+	}
+	if s := got.DS[0].String(); s != "42 13 2 48E939042E82C22542CB377B580DFDC52A361CEFDC72E7F9107E2B6BD9306A45" {
+		t.Errorf("DS String() = %q", s)
+	}
+}
+
+func TestCloudflareListZones_IncludesPlan(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cloudflareZoneHandler(t, w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := newTestCloudflareProvider(t, srv.URL).ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []dnsdomain.Zone{{
+		ID: "z1", Name: "example.com", Plan: "Free Website",
+		Nameservers: []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ListZones() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCloudflareErrors_UnwrapToSentinels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"success": false, "errors": [{"code": 10000, "message": "Authentication error"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestCloudflareProvider(t, srv.URL).ListZones(context.Background())
+	if !errors.Is(err, dnsdomain.ErrUnauthorized) {
+		t.Fatalf("err = %v, want ErrUnauthorized", err)
+	}
+	if want := "cloudflare: Authentication error (HTTP 403)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %q, want it to contain %q", err, want)
+	}
+}
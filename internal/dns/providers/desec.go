@@ -0,0 +1,374 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+var _ dnsdomain.Provider = (*DeSECProvider)(nil)
+
+const (
+	deSECEndpoint = "https://desec.io/api/v1"
+
+	// deSECMinTTL is the lowest TTL deSEC accepts on new domains.
+	deSECMinTTL = 3600
+)
+
+// deSECNameservers are the nameservers every deSEC domain is served from.
+var deSECNameservers = []string{"ns1.desec.io", "ns2.desec.org"}
+
+// deSECNextLink matches the rel="next" URL of a paginated response's
+// Link header.
+var deSECNextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// DeSECProvider implements dnsdomain.Provider using the deSEC API.
+//
+// deSEC stores records as RRsets: all values of a name and type share one
+// set and one TTL. Each value is surfaced as its own Record, with an ID of
+// the form "<name>/<type>/<hash of the value>", and writes rewrite the set
+// it belongs to. A record's TTL is its set's; changing it changes the TTL
+// of every value in the set.
+type DeSECProvider struct {
+	client *client
+}
+
+// NewDeSECProvider creates a DeSECProvider authenticated with the given
+// API token.
+func NewDeSECProvider(token string) *DeSECProvider {
+	return &DeSECProvider{
+		client: newClient("desec", deSECEndpoint,
+			func(req *http.Request) { req.Header.Set("Authorization", "Token "+token) },
+			deSECErrorMessage),
+	}
+}
+
+// RegisterDeSEC registers the deSEC provider factory.
+func RegisterDeSEC() {
+	Register("desec", func(store auth.Store) (dnsdomain.Provider, error) {
+		token, err := store.GetToken("desec")
+		if err != nil {
+			return nil, fmt.Errorf("desec auth: %w", err)
+		}
+		return NewDeSECProvider(token), nil
+	})
+}
+
+func (d *DeSECProvider) GetDisplayName() string {
+	return "deSEC"
+}
+
+// Quirks reports deSEC's one-hour minimum TTL and that it serves the
+// zone's apex NS records itself.
+func (d *DeSECProvider) Quirks() dnsdomain.Quirks {
+	return dnsdomain.Quirks{MinTTL: deSECMinTTL, ManagesApexNS: true}
+}
+
+// deSECErrorMessage extracts the message from an error body, which is
+// either {"detail": ...} or a map of field names to lists of problems.
+func deSECErrorMessage(body []byte) string {
+	var detail struct {
+		Detail string `json:"detail"`
+	}
+	if json.Unmarshal(body, &detail) == nil && detail.Detail != "" {
+		return detail.Detail
+	}
+	var fields map[string][]string
+	if json.Unmarshal(body, &fields) != nil {
+		return ""
+	}
+	var problems []string
+	for field, msgs := range fields {
+		problems = append(problems, field+": "+strings.Join(msgs, " "))
+	}
+	sort.Strings(problems)
+	return strings.Join(problems, "; ")
+}
+
+// --- API types ---
+
+type deSECDomain struct {
+	Name       string `json:"name"`
+	MinimumTTL int    `json:"minimum_ttl"`
+}
+
+type deSECRRset struct {
+	Subname string   `json:"subname"`
+	Type    string   `json:"type"`
+	TTL     int      `json:"ttl,omitempty"`
+	Records []string `json:"records"`
+}
+
+// --- Provider implementation ---
+
+func (d *DeSECProvider) ListZones(ctx context.Context) ([]dnsdomain.Zone, error) {
+	var domains []deSECDomain
+	if err := d.listAll(ctx, "/domains/", func(raw json.RawMessage) error {
+		var page []deSECDomain
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		domains = append(domains, page...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	zones := make([]dnsdomain.Zone, len(domains))
+	for i, dom := range domains {
+		zones[i] = dnsdomain.Zone{
+			ID:          dom.Name,
+			Name:        dom.Name,
+			TTL:         dom.MinimumTTL,
+			Nameservers: slices.Clone(deSECNameservers),
+		}
+	}
+	return zones, nil
+}
+
+func (d *DeSECProvider) ListRecords(ctx context.Context, zone string) ([]dnsdomain.Record, error) {
+	var rrsets []deSECRRset
+	if err := d.listAll(ctx, "/domains/"+url.PathEscape(zone)+"/rrsets/", func(raw json.RawMessage) error {
+		var page []deSECRRset
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		rrsets = append(rrsets, page...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list records: %w", err)
+	}
+
+	var records []dnsdomain.Record
+	for _, set := range rrsets {
+		setRecords, err := toDomainDeSECRecords(set)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, setRecords...)
+	}
+	return records, nil
+}
+
+// CreateRecord adds r's value to the set of its name and type, creating
+// the set if needed. The set's TTL becomes r's.
+func (d *DeSECProvider) CreateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	set, err := d.getRRset(ctx, zone, r.Name, r.Type)
+	if errors.Is(err, dnsdomain.ErrNotFound) {
+		set = &deSECRRset{Subname: deSECSubname(r.Name), Type: string(r.Type)}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+
+	rdata := dnsdomain.FormatRData(r)
+	if slices.Contains(set.Records, rdata) {
+		return nil, fmt.Errorf("failed to create record: %s %s %s already exists: %w", r.Name, r.Type, r.Value, dnsdomain.ErrConflict)
+	}
+	set.Records = append(set.Records, rdata)
+	set.TTL = deSECTTL(r.TTL, set.TTL)
+
+	if err := d.writeRRset(ctx, zone, *set); err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	created := deSECRecord(r, rdata, set.TTL)
+	return &created, nil
+}
+
+// UpdateRecord replaces the value r.ID refers to by r's. If r moves the
+// record to another name or type, the value is removed from its old set
+// and added to the new one.
+func (d *DeSECProvider) UpdateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	name, t, hash, err := parseDeSECRecordID(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	if name != r.Name || t != r.Type {
+		if err := d.DeleteRecord(ctx, zone, r.ID); err != nil {
+			return nil, err
+		}
+		return d.CreateRecord(ctx, zone, r)
+	}
+
+	set, err := d.getRRset(ctx, zone, name, t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+	i := slices.IndexFunc(set.Records, func(v string) bool { return deSECValueHash(v) == hash })
+	if i < 0 {
+		return nil, fmt.Errorf("failed to update record %s: %w", r.ID, dnsdomain.ErrNotFound)
+	}
+	rdata := dnsdomain.FormatRData(r)
+	set.Records[i] = rdata
+	set.TTL = deSECTTL(r.TTL, set.TTL)
+
+	if err := d.writeRRset(ctx, zone, *set); err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+	updated := deSECRecord(r, rdata, set.TTL)
+	return &updated, nil
+}
+
+// DeleteRecord removes the value id refers to from its set, deleting the
+// set when it was the last value.
+func (d *DeSECProvider) DeleteRecord(ctx context.Context, zone, id string) error {
+	name, t, hash, err := parseDeSECRecordID(id)
+	if err != nil {
+		return err
+	}
+	set, err := d.getRRset(ctx, zone, name, t)
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	i := slices.IndexFunc(set.Records, func(v string) bool { return deSECValueHash(v) == hash })
+	if i < 0 {
+		return fmt.Errorf("failed to delete record %s: %w", id, dnsdomain.ErrNotFound)
+	}
+	set.Records = slices.Delete(set.Records, i, i+1)
+
+	if len(set.Records) == 0 {
+		_, err = d.client.do(ctx, http.MethodDelete, rrsetPath(zone, name, t), nil, nil)
+	} else {
+		err = d.writeRRset(ctx, zone, *set)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	return nil
+}
+
+// --- RRsets ---
+
+func rrsetPath(zone, name string, t dnsdomain.RecordType) string {
+	subname := "@"
+	if s := deSECSubname(name); s != "" {
+		subname = s
+	}
+	return fmt.Sprintf("/domains/%s/rrsets/%s/%s/", url.PathEscape(zone), url.PathEscape(subname), t)
+}
+
+func (d *DeSECProvider) getRRset(ctx context.Context, zone, name string, t dnsdomain.RecordType) (*deSECRRset, error) {
+	var set deSECRRset
+	if _, err := d.client.do(ctx, http.MethodGet, rrsetPath(zone, name, t), nil, &set); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// writeRRset writes a whole set with a bulk PATCH, which creates the set if
+// it does not exist yet and leaves other sets alone.
+func (d *DeSECProvider) writeRRset(ctx context.Context, zone string, set deSECRRset) error {
+	path := "/domains/" + url.PathEscape(zone) + "/rrsets/"
+	_, err := d.client.do(ctx, http.MethodPatch, path, []deSECRRset{set}, nil)
+	return err
+}
+
+// listAll fetches every page of a list endpoint. deSEC only paginates
+// when asked to with an empty cursor, and links the next page in the Link
+// header.
+func (d *DeSECProvider) listAll(ctx context.Context, path string, decode func(json.RawMessage) error) error {
+	next := path + "?cursor="
+	for next != "" {
+		var raw json.RawMessage
+		header, err := d.client.do(ctx, http.MethodGet, next, nil, &raw)
+		if err != nil {
+			return err
+		}
+		if err := decode(raw); err != nil {
+			return err
+		}
+		next = ""
+		if m := deSECNextLink.FindStringSubmatch(header.Get("Link")); m != nil {
+			u, err := url.Parse(m[1])
+			if err != nil {
+				return fmt.Errorf("failed to parse next page link: %w", err)
+			}
+			next = path + "?" + u.RawQuery
+		}
+	}
+	return nil
+}
+
+// --- Conversion ---
+
+// deSECSubname converts a record name to a deSEC subname, which is empty
+// for the apex.
+func deSECSubname(name string) string {
+	if name == "@" {
+		return ""
+	}
+	return name
+}
+
+// deSECTTL is the TTL to write: the record's, else the set's current one,
+// else the minimum.
+func deSECTTL(recordTTL, setTTL int) int {
+	switch {
+	case recordTTL > 0:
+		return recordTTL
+	case setTTL > 0:
+		return setTTL
+	}
+	return deSECMinTTL
+}
+
+func deSECValueHash(rdata string) string {
+	sum := sha256.Sum256([]byte(rdata))
+	return hex.EncodeToString(sum[:4])
+}
+
+func deSECRecordID(name string, t dnsdomain.RecordType, rdata string) string {
+	return fmt.Sprintf("%s/%s/%s", name, t, deSECValueHash(rdata))
+}
+
+func parseDeSECRecordID(id string) (name string, t dnsdomain.RecordType, hash string, err error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid deSEC record ID %q: want <name>/<type>/<hash>", id)
+	}
+	return parts[0], dnsdomain.ParseRecordType(parts[1]), parts[2], nil
+}
+
+// deSECRecord is r as stored: with the ID of its value and its set's TTL.
+func deSECRecord(r dnsdomain.Record, rdata string, ttl int) dnsdomain.Record {
+	r.ID = deSECRecordID(r.Name, r.Type, rdata)
+	r.TTL = ttl
+	return r
+}
+
+// toDomainDeSECRecords flattens a set into one record per value.
+func toDomainDeSECRecords(set deSECRRset) ([]dnsdomain.Record, error) {
+	name := set.Subname
+	if name == "" {
+		name = "@"
+	}
+	t := dnsdomain.ParseRecordType(set.Type)
+
+	records := make([]dnsdomain.Record, 0, len(set.Records))
+	for _, rdata := range set.Records {
+		value, priority, err := dnsdomain.ParseRData(t, rdata)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, dnsdomain.Record{
+			ID:       deSECRecordID(name, t, rdata),
+			Name:     name,
+			Type:     t,
+			Value:    value,
+			TTL:      set.TTL,
+			Priority: priority,
+		})
+	}
+	return records, nil
+}
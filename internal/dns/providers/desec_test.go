@@ -0,0 +1,232 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// fakeDeSEC is an in-memory deSEC API serving the RRsets of example.com.
+type fakeDeSEC struct {
+	t      *testing.T
+	mu     sync.Mutex
+	rrsets map[string]deSECRRset // by "subname/type"
+}
+
+func newFakeDeSEC(t *testing.T, rrsets ...deSECRRset) (*fakeDeSEC, *DeSECProvider) {
+	t.Helper()
+	f := &fakeDeSEC{t: t, rrsets: map[string]deSECRRset{}}
+	for _, set := range rrsets {
+		f.rrsets[set.Subname+"/"+set.Type] = set
+	}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	provider := NewDeSECProvider("test-token")
+	provider.client.endpoint = srv.URL
+	provider.client.httpClient = http.DefaultClient
+	provider.client.retryConfig = retry.Config{MaxAttempts: 1}
+	return f, provider
+}
+
+func (f *fakeDeSEC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if got := r.Header.Get("Authorization"); got != "Token test-token" {
+		f.t.Errorf("Authorization = %q, want Token test-token", got)
+	}
+
+	const prefix = "/domains/example.com/rrsets/"
+	switch {
+	case r.URL.Path == "/domains/":
+		json.NewEncoder(w).Encode([]deSECDomain{{Name: "example.com", MinimumTTL: 3600}})
+
+	case r.URL.Path == prefix && r.Method == http.MethodGet:
+		sets := []deSECRRset{}
+		for _, key := range slices.Sorted(maps.Keys(f.rrsets)) {
+			sets = append(sets, f.rrsets[key])
+		}
+		json.NewEncoder(w).Encode(sets)
+
+	case r.URL.Path == prefix && r.Method == http.MethodPatch:
+		var sets []deSECRRset
+		json.NewDecoder(r.Body).Decode(&sets)
+		for _, set := range sets {
+			f.rrsets[set.Subname+"/"+set.Type] = set
+		}
+		json.NewEncoder(w).Encode(sets)
+
+	case strings.HasPrefix(r.URL.Path, prefix):
+		subname, t, _ := strings.Cut(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+		if subname == "@" {
+			subname = ""
+		}
+		key := subname + "/" + t
+		set, ok := f.rrsets[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]string{"detail": "Not found."})
+			return
+		}
+		if r.Method == http.MethodDelete {
+			delete(f.rrsets, key)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(set)
+
+	default:
+		f.t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestDeSECListZones(t *testing.T) {
+	_, provider := newFakeDeSEC(t)
+
+	zones, err := provider.ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("ListZones() error: %v", err)
+	}
+	want := []dnsdomain.Zone{{
+		ID: "example.com", Name: "example.com", TTL: 3600,
+		Nameservers: []string{"ns1.desec.io", "ns2.desec.org"},
+	}}
+	if diff := cmp.Diff(want, zones); diff != "" {
+		t.Errorf("ListZones() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeSECListRecords_FlattensRRsets(t *testing.T) {
+	_, provider := newFakeDeSEC(t,
+		deSECRRset{Subname: "", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com."}},
+		deSECRRset{Subname: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+	)
+
+	records, err := provider.ListRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ListRecords() error: %v", err)
+	}
+	want := []dnsdomain.Record{
+		{ID: deSECRecordID("@", "MX", "10 mail.example.com."), Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", TTL: 3600, Priority: intPtr(10)},
+		{ID: deSECRecordID("www", "A", "192.0.2.1"), Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 3600},
+		{ID: deSECRecordID("www", "A", "192.0.2.2"), Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.2", TTL: 3600},
+	}
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("ListRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeSECCreateRecord_AppendsToRRset(t *testing.T) {
+	f, provider := newFakeDeSEC(t,
+		deSECRRset{Subname: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1"}},
+	)
+	ctx := context.Background()
+
+	created, err := provider.CreateRecord(ctx, "example.com", dnsdomain.Record{Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.2"})
+	if err != nil {
+		t.Fatalf("CreateRecord() error: %v", err)
+	}
+	if created.ID != deSECRecordID("www", "A", "192.0.2.2") || created.TTL != 3600 {
+		t.Errorf("created = %+v, want the new value's ID and the set's TTL", created)
+	}
+	if diff := cmp.Diff([]string{"192.0.2.1", "192.0.2.2"}, f.rrsets["www/A"].Records); diff != "" {
+		t.Errorf("rrset mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = provider.CreateRecord(ctx, "example.com", dnsdomain.Record{Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.2"})
+	if !errors.Is(err, dnsdomain.ErrConflict) {
+		t.Errorf("creating a duplicate: err = %v, want ErrConflict", err)
+	}
+
+	if _, err := provider.CreateRecord(ctx, "example.com", dnsdomain.Record{Name: "@", Type: dnsdomain.RecordTXT, Value: "hello"}); err != nil {
+		t.Fatalf("CreateRecord() for a new set error: %v", err)
+	}
+	want := deSECRRset{Subname: "", Type: "TXT", TTL: 3600, Records: []string{`"hello"`}}
+	if diff := cmp.Diff(want, f.rrsets["/TXT"]); diff != "" {
+		t.Errorf("new rrset mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeSECUpdateAndDeleteRecord(t *testing.T) {
+	f, provider := newFakeDeSEC(t,
+		deSECRRset{Subname: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+	)
+	ctx := context.Background()
+	id := deSECRecordID("www", "A", "192.0.2.1")
+
+	updated, err := provider.UpdateRecord(ctx, "example.com", dnsdomain.Record{ID: id, Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.9", TTL: 7200})
+	if err != nil {
+		t.Fatalf("UpdateRecord() error: %v", err)
+	}
+	want := deSECRRset{Subname: "www", Type: "A", TTL: 7200, Records: []string{"192.0.2.9", "192.0.2.2"}}
+	if diff := cmp.Diff(want, f.rrsets["www/A"]); diff != "" {
+		t.Errorf("rrset after update mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := provider.DeleteRecord(ctx, "example.com", updated.ID); err != nil {
+		t.Fatalf("DeleteRecord() error: %v", err)
+	}
+	if err := provider.DeleteRecord(ctx, "example.com", deSECRecordID("www", "A", "192.0.2.2")); err != nil {
+		t.Fatalf("DeleteRecord() of the last value error: %v", err)
+	}
+	if _, ok := f.rrsets["www/A"]; ok {
+		t.Error("expected the emptied rrset to be deleted")
+	}
+
+	if err := provider.DeleteRecord(ctx, "example.com", id); !errors.Is(err, dnsdomain.ErrNotFound) {
+		t.Errorf("deleting a missing record: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDeSECRevert_ChangedValueAndRename(t *testing.T) {
+	changelog.SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(changelog.ResetPath)
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		change func(dnsdomain.Record) dnsdomain.Record
+	}{
+		{"value", func(r dnsdomain.Record) dnsdomain.Record { r.Value = "192.0.2.9"; return r }},
+		{"rename", func(r dnsdomain.Record) dnsdomain.Record { r.Name = "web"; return r }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := deSECRRset{Subname: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1"}}
+			f, provider := newFakeDeSEC(t, original)
+			logged := changelog.Wrap(provider, "desec", changelog.OpenDefault)
+
+			record := dnsdomain.Record{ID: deSECRecordID("www", "A", "192.0.2.1"), Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 3600}
+			if _, err := logged.UpdateRecord(ctx, "example.com", tt.change(record)); err != nil {
+				t.Fatalf("UpdateRecord() error: %v", err)
+			}
+			change := logged.LastChange()
+			if change == nil {
+				t.Fatal("expected the update to be logged")
+			}
+
+			if _, err := logged.Revert(ctx, *change, false); err != nil {
+				t.Fatalf("Revert() error: %v", err)
+			}
+			want := map[string]deSECRRset{"www/A": original}
+			if diff := cmp.Diff(want, f.rrsets); diff != "" {
+				t.Errorf("rrsets after revert mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
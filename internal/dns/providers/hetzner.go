@@ -0,0 +1,268 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/platform/providers/names"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+var _ dnsdomain.Provider = (*HetznerProvider)(nil)
+
+const (
+	hetznerDNSEndpoint = "https://dns.hetzner.com/api/v1"
+	hetznerDNSPageSize = 100
+
+	// hetznerDNSTokenKey is the auth store key of a DNS Console API token.
+	// The DNS Console issues its own tokens, separate from Hetzner Cloud
+	// project tokens.
+	hetznerDNSTokenKey = "hetzner-dns"
+)
+
+// HetznerProvider implements dnsdomain.Provider using the Hetzner DNS
+// Console API (dns.hetzner.com).
+type HetznerProvider struct {
+	client *client
+}
+
+// NewHetznerProvider creates a HetznerProvider authenticated with the
+// given DNS API token.
+func NewHetznerProvider(token string) *HetznerProvider {
+	return &HetznerProvider{
+		client: newClient("hetzner-dns", hetznerDNSEndpoint,
+			func(req *http.Request) { req.Header.Set("Auth-API-Token", token) },
+			hetznerDNSErrorMessage),
+	}
+}
+
+// RegisterHetzner registers the Hetzner DNS provider factory. It uses the
+// token stored for "hetzner-dns" and falls back to the "hetzner" token, so
+// accounts whose Cloud token is also allowed to manage DNS need no second
+// login.
+func RegisterHetzner() {
+	names.Register(hetznerDNSTokenKey)
+	Register("hetzner", func(store auth.Store) (dnsdomain.Provider, error) {
+		token, err := store.GetToken(hetznerDNSTokenKey)
+		if errors.Is(err, auth.ErrTokenNotFound) {
+			token, err = store.GetToken("hetzner")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("hetzner dns auth: %w", err)
+		}
+		return NewHetznerProvider(token), nil
+	})
+}
+
+func (h *HetznerProvider) GetDisplayName() string {
+	return "Hetzner DNS"
+}
+
+// Quirks reports that Hetzner DNS serves the zone's apex NS records itself
+// and accepts TTLs down to a minute.
+func (h *HetznerProvider) Quirks() dnsdomain.Quirks {
+	return dnsdomain.Quirks{MinTTL: 60, ManagesApexNS: true}
+}
+
+// hetznerDNSErrorMessage extracts the message from an error body, which
+// is either {"error": {"message": ...}} or {"message": ...}.
+func hetznerDNSErrorMessage(body []byte) string {
+	var resp struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &resp) != nil {
+		return ""
+	}
+	if resp.Error.Message != "" {
+		return resp.Error.Message
+	}
+	return resp.Message
+}
+
+// --- API types ---
+
+type hetznerDNSZone struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	TTL  int      `json:"ttl"`
+	NS   []string `json:"ns"`
+}
+
+type hetznerDNSRecord struct {
+	ID     string `json:"id,omitempty"`
+	ZoneID string `json:"zone_id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	TTL    int    `json:"ttl,omitempty"`
+}
+
+type hetznerDNSMeta struct {
+	Pagination struct {
+		LastPage int `json:"last_page"`
+	} `json:"pagination"`
+}
+
+// --- Provider implementation ---
+
+func (h *HetznerProvider) ListZones(ctx context.Context) ([]dnsdomain.Zone, error) {
+	var zones []dnsdomain.Zone
+	for page := 1; ; page++ {
+		var resp struct {
+			Zones []hetznerDNSZone `json:"zones"`
+			Meta  hetznerDNSMeta   `json:"meta"`
+		}
+		path := fmt.Sprintf("/zones?page=%d&per_page=%d", page, hetznerDNSPageSize)
+		if _, err := h.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list zones: %w", err)
+		}
+		for _, z := range resp.Zones {
+			zones = append(zones, toDomainHetznerZone(z))
+		}
+		if page >= resp.Meta.Pagination.LastPage {
+			return zones, nil
+		}
+	}
+}
+
+// zoneID looks up the ID of the zone called name.
+func (h *HetznerProvider) zoneID(ctx context.Context, name string) (string, error) {
+	var resp struct {
+		Zones []hetznerDNSZone `json:"zones"`
+	}
+	if _, err := h.client.do(ctx, http.MethodGet, "/zones?name="+url.QueryEscape(name), nil, &resp); err != nil {
+		return "", fmt.Errorf("failed to find zone %s: %w", name, err)
+	}
+	for _, z := range resp.Zones {
+		if z.Name == name {
+			return z.ID, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find zone %s: %w", name, dnsdomain.ErrNotFound)
+}
+
+func (h *HetznerProvider) ListRecords(ctx context.Context, zone string) ([]dnsdomain.Record, error) {
+	zoneID, err := h.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []dnsdomain.Record
+	for page := 1; ; page++ {
+		var resp struct {
+			Records []hetznerDNSRecord `json:"records"`
+			Meta    hetznerDNSMeta     `json:"meta"`
+		}
+		path := fmt.Sprintf("/records?zone_id=%s&page=%d&per_page=%d", url.QueryEscape(zoneID), page, hetznerDNSPageSize)
+		if _, err := h.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list records: %w", err)
+		}
+		for _, r := range resp.Records {
+			// The SOA record is generated by Hetzner and cannot be edited.
+			if r.Type == "SOA" {
+				continue
+			}
+			record, err := toDomainHetznerRecord(r)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+		if page >= resp.Meta.Pagination.LastPage {
+			return records, nil
+		}
+	}
+}
+
+func (h *HetznerProvider) CreateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	zoneID, err := h.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Record hetznerDNSRecord `json:"record"`
+	}
+	if _, err := h.client.do(ctx, http.MethodPost, "/records", toHetznerDNSRecord(zoneID, r), &resp); err != nil {
+		return nil, fmt.Errorf("failed to create record: %w", err)
+	}
+	created, err := toDomainHetznerRecord(resp.Record)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (h *HetznerProvider) UpdateRecord(ctx context.Context, zone string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	zoneID, err := h.zoneID(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Record hetznerDNSRecord `json:"record"`
+	}
+	path := "/records/" + url.PathEscape(r.ID)
+	if _, err := h.client.do(ctx, http.MethodPut, path, toHetznerDNSRecord(zoneID, r), &resp); err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
+	updated, err := toDomainHetznerRecord(resp.Record)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteRecord deletes the record with the given ID. Record IDs are unique
+// across zones, so zone is not needed.
+func (h *HetznerProvider) DeleteRecord(ctx context.Context, zone, id string) error {
+	if _, err := h.client.do(ctx, http.MethodDelete, "/records/"+url.PathEscape(id), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	return nil
+}
+
+// --- Conversion ---
+
+func toDomainHetznerZone(z hetznerDNSZone) dnsdomain.Zone {
+	nameservers := make([]string, len(z.NS))
+	for i, ns := range z.NS {
+		nameservers[i] = strings.TrimSuffix(ns, ".")
+	}
+	return dnsdomain.Zone{ID: z.ID, Name: z.Name, TTL: z.TTL, Nameservers: nameservers}
+}
+
+func toDomainHetznerRecord(r hetznerDNSRecord) (dnsdomain.Record, error) {
+	t := dnsdomain.ParseRecordType(r.Type)
+	value, priority, err := dnsdomain.ParseRData(t, r.Value)
+	if err != nil {
+		return dnsdomain.Record{}, err
+	}
+	return dnsdomain.Record{
+		ID:       r.ID,
+		Name:     r.Name,
+		Type:     t,
+		Value:    value,
+		TTL:      r.TTL,
+		Priority: priority,
+	}, nil
+}
+
+func toHetznerDNSRecord(zoneID string, r dnsdomain.Record) hetznerDNSRecord {
+	return hetznerDNSRecord{
+		ZoneID: zoneID,
+		Name:   r.Name,
+		Type:   string(r.Type),
+		Value:  dnsdomain.FormatRData(r),
+		TTL:    r.TTL,
+	}
+}
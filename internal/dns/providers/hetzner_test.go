@@ -0,0 +1,194 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func intPtr(v int) *int { return &v }
+
+// newTestHetznerProvider creates a HetznerProvider that talks to a test
+// server.
+func newTestHetznerProvider(t *testing.T, serverURL string) *HetznerProvider {
+	t.Helper()
+	provider := NewHetznerProvider("test-token")
+	provider.client.endpoint = serverURL
+	provider.client.httpClient = http.DefaultClient
+	provider.client.retryConfig = retry.Config{MaxAttempts: 1}
+	return provider
+}
+
+// hetznerZoneHandler answers zone lookups for example.com with zone "z1".
+func hetznerZoneHandler(t *testing.T, w http.ResponseWriter, r *http.Request) bool {
+	t.Helper()
+	if r.URL.Path != "/zones" {
+		return false
+	}
+	if got := r.Header.Get("Auth-API-Token"); got != "test-token" {
+		t.Errorf("Auth-API-Token = %q, want test-token", got)
+	}
+	var zones []interface{}
+	if name := r.URL.Query().Get("name"); name == "" || name == "example.com" {
+		zones = append(zones, map[string]interface{}{
+			"id": "z1", "name": "example.com", "ttl": 86400,
+			"ns": []string{"hydrogen.ns.hetzner.com.", "oxygen.ns.hetzner.com."},
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"zones": zones,
+		"meta":  map[string]interface{}{"pagination": map[string]interface{}{"last_page": 1}},
+	})
+	return true
+}
+
+func TestHetznerListZones(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hetznerZoneHandler(t, w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	zones, err := newTestHetznerProvider(t, srv.URL).ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("ListZones() error: %v", err)
+	}
+	want := []dnsdomain.Zone{{
+		ID: "z1", Name: "example.com", TTL: 86400,
+		Nameservers: []string{"hydrogen.ns.hetzner.com", "oxygen.ns.hetzner.com"},
+	}}
+	if diff := cmp.Diff(want, zones); diff != "" {
+		t.Errorf("ListZones() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHetznerListRecords_PaginatesAndSkipsSOA(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hetznerZoneHandler(t, w, r) {
+			return
+		}
+		if got := r.URL.Query().Get("zone_id"); got != "z1" {
+			t.Errorf("zone_id = %q, want z1", got)
+		}
+		records := []interface{}{
+			map[string]interface{}{"id": "r1", "zone_id": "z1", "name": "@", "type": "SOA", "value": "hydrogen.ns.hetzner.com. dns.hetzner.com. 1 86400 10800 3600000 3600"},
+			map[string]interface{}{"id": "r2", "zone_id": "z1", "name": "www", "type": "A", "value": "192.0.2.1", "ttl": 300},
+		}
+		if r.URL.Query().Get("page") == "2" {
+			records = []interface{}{
+				map[string]interface{}{"id": "r3", "zone_id": "z1", "name": "@", "type": "MX", "value": "10 mail.example.com."},
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"records": records,
+			"meta":    map[string]interface{}{"pagination": map[string]interface{}{"last_page": 2}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	records, err := newTestHetznerProvider(t, srv.URL).ListRecords(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ListRecords() error: %v", err)
+	}
+	want := []dnsdomain.Record{
+		{ID: "r2", Name: "www", Type: dnsdomain.RecordA, Value: "192.0.2.1", TTL: 300},
+		{ID: "r3", Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", Priority: intPtr(10)},
+	}
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("ListRecords() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHetznerCreateRecord_SendsZoneFileValue(t *testing.T) {
+	var sent hetznerDNSRecord
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hetznerZoneHandler(t, w, r) {
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/records" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		sent.ID = "r9"
+		json.NewEncoder(w).Encode(map[string]interface{}{"record": sent})
+	}))
+	t.Cleanup(srv.Close)
+
+	created, err := newTestHetznerProvider(t, srv.URL).CreateRecord(context.Background(), "example.com",
+		dnsdomain.Record{Name: "@", Type: dnsdomain.RecordMX, Value: "mail.example.com", Priority: intPtr(10), TTL: 3600})
+	if err != nil {
+		t.Fatalf("CreateRecord() error: %v", err)
+	}
+	wantSent := hetznerDNSRecord{ID: "r9", ZoneID: "z1", Name: "@", Type: "MX", Value: "10 mail.example.com.", TTL: 3600}
+	if diff := cmp.Diff(wantSent, sent); diff != "" {
+		t.Errorf("request mismatch (-want +got):\n%s", diff)
+	}
+	if created.ID != "r9" || created.Value != "mail.example.com" {
+		t.Errorf("created = %+v, want ID r9 and value mail.example.com", created)
+	}
+}
+
+func TestHetznerListRecords_UnknownZone(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"message": "zone not found", "code": 404}})
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestHetznerProvider(t, srv.URL).ListRecords(context.Background(), "nope.example")
+	if !errors.Is(err, dnsdomain.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRegisterHetzner_PrefersDNSToken(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+	RegisterHetzner()
+
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Auth-API-Token")
+		json.NewEncoder(w).Encode(map[string]interface{}{"zones": []interface{}{}})
+	}))
+	t.Cleanup(srv.Close)
+
+	// tokenUsed lists zones with the provider the registry builds and
+	// returns the token it sent.
+	tokenUsed := func(store auth.Store) string {
+		t.Helper()
+		provider, err := Get("hetzner", store)
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		h := provider.(*HetznerProvider)
+		h.client.endpoint = srv.URL
+		h.client.httpClient = http.DefaultClient
+		if _, err := h.ListZones(context.Background()); err != nil {
+			t.Fatalf("ListZones() error: %v", err)
+		}
+		return token
+	}
+
+	store := auth.NewMockStore()
+	store.SetToken("hetzner", "cloud-token")
+	if got := tokenUsed(store); got != "cloud-token" {
+		t.Errorf("without a DNS token, sent %q, want the Cloud token", got)
+	}
+	store.SetToken("hetzner-dns", "dns-token")
+	if got := tokenUsed(store); got != "dns-token" {
+		t.Errorf("with a DNS token, sent %q, want it", got)
+	}
+
+	if _, err := Get("hetzner", auth.NewMockStore()); !errors.Is(err, auth.ErrTokenNotFound) {
+		t.Errorf("without tokens, err = %v, want ErrTokenNotFound", err)
+	}
+}
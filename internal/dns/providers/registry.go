@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"fmt"
+	"sync"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/platform/providers/names"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/util"
+)
+
+// Factory creates a DNS provider implementation.
+type Factory func(store auth.Store) (dnsdomain.Provider, error)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{}
+)
+
+// Register registers a DNS provider factory by name.
+func Register(name string, factory Factory) {
+	normalizedName := util.NormalizeKey(name)
+	if normalizedName == "" {
+		panic("dns providers: empty provider name")
+	}
+	if factory == nil {
+		panic("dns providers: nil factory")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, exists := registry[normalizedName]; exists {
+		panic(fmt.Sprintf("dns providers: provider %q already registered", name))
+	}
+
+	registry[normalizedName] = factory
+	names.Register(normalizedName)
+}
+
+// Get resolves and constructs a DNS provider by name.
+// A profile name such as "hetzner:work" uses that profile's token.
+func Get(name string, store auth.Store) (dnsdomain.Provider, error) {
+	baseName, profile := auth.ParseProfile(name)
+	normalizedName := util.NormalizeKey(baseName)
+
+	mu.RLock()
+	factory, ok := registry[normalizedName]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("dns providers: unknown provider %q", name)
+	}
+
+	provider, err := factory(auth.WithProject(store, profile))
+	if err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// Reset clears the DNS provider registry. Intended for tests only.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	registry = map[string]Factory{}
+}
+
+// List returns all registered DNS provider names.
+func List() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,244 @@
+// Package attach points a hostname at a server: it plans and applies the
+// A and AAAA records for the server's public addresses, then checks that
+// the zone's nameservers answer with them.
+package attach
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// Records returns the records pointing name at the given addresses: an A
+// record for ipv4 and an AAAA record for ipv6, each only when set. A zero
+// ttl leaves the TTL to the provider, or to the record being replaced.
+func Records(name, ipv4, ipv6 string, ttl int) []domain.Record {
+	if name == "" {
+		name = "@"
+	}
+	var records []domain.Record
+	if ipv4 != "" {
+		records = append(records, domain.Record{Name: name, Type: domain.RecordA, Value: ipv4, TTL: ttl})
+	}
+	if ipv6 != "" {
+		records = append(records, domain.Record{Name: name, Type: domain.RecordAAAA, Value: ipv6, TTL: ttl})
+	}
+	return records
+}
+
+// Plan returns the creates and updates that make existing hold records.
+// Records already in place are left out, so an empty plan means the name
+// already points at the addresses. A CNAME at the name, or several
+// records of one type there, is an error: neither can be overwritten
+// safely.
+func Plan(records, existing []domain.Record, quirks domain.Quirks) ([]domain.MirrorChange, error) {
+	var changes []domain.MirrorChange
+	for _, r := range records {
+		for _, other := range existing {
+			if other.Type == domain.RecordCNAME && strings.EqualFold(other.Name, r.Name) {
+				return nil, fmt.Errorf("%s has a CNAME record; delete it before pointing the name at a server", r.Name)
+			}
+		}
+		if r.TTL > 0 && r.TTL < quirks.MinTTL {
+			r.TTL = quirks.MinTTL
+		}
+		if r.TTL == 0 {
+			r.TTL = currentTTL(r, existing)
+		}
+		if err := domain.ValidateRecord(r); err != nil {
+			return nil, fmt.Errorf("invalid %s %s record: %w", r.Name, r.Type, err)
+		}
+
+		planned, action, err := domain.PlanUpsert(r, existing)
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case domain.UpsertCreate:
+			changes = append(changes, domain.MirrorChange{Action: domain.MirrorCreate, Record: planned})
+		case domain.UpsertUpdate:
+			changes = append(changes, domain.MirrorChange{Action: domain.MirrorUpdate, Record: planned})
+		}
+	}
+	return changes, nil
+}
+
+// currentTTL returns the TTL of the one existing record r would replace,
+// so an update without a TTL keeps it. It is zero when there is none.
+func currentTTL(r domain.Record, existing []domain.Record) int {
+	ttl, found := 0, 0
+	for _, other := range existing {
+		if other.Type == r.Type && strings.EqualFold(other.Name, r.Name) {
+			ttl = other.TTL
+			found++
+		}
+	}
+	if found != 1 {
+		return 0
+	}
+	return ttl
+}
+
+// Hostname returns the fully qualified name of a record in zone, e.g.
+// "www.example.com" for "www", or "example.com" for "@".
+func Hostname(name, zone string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	if name == "" || name == "@" {
+		return zone
+	}
+	return name + "." + zone
+}
+
+// Pointer is a record still pointing a name at an address.
+type Pointer struct {
+	Zone   string
+	Record domain.Record
+}
+
+// String describes the record, e.g. "www.example.com A 203.0.113.7".
+func (p Pointer) String() string {
+	return fmt.Sprintf("%s %s %s", Hostname(p.Record.Name, p.Zone), p.Record.Type, p.Record.Value)
+}
+
+// Pointing returns the A and AAAA records in the provider's zones whose
+// value is one of ips, so a server's old addresses can be traced to the
+// names still using them. An IPv6 address stands for its /64, since
+// servers are given a whole network.
+func Pointing(ctx context.Context, provider domain.Provider, ips []string) ([]Pointer, error) {
+	var v4 []netip.Addr
+	var v6 []netip.Prefix
+	for _, ip := range ips {
+		addr, err := netip.ParseAddr(strings.SplitN(ip, "/", 2)[0])
+		if err != nil {
+			continue
+		}
+		if addr.Is4() {
+			v4 = append(v4, addr)
+		} else if prefix, err := addr.Prefix(64); err == nil {
+			v6 = append(v6, prefix)
+		}
+	}
+	if len(v4) == 0 && len(v6) == 0 {
+		return nil, nil
+	}
+
+	zones, err := provider.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pointers []Pointer
+	for _, z := range zones {
+		records, err := provider.ListRecords(ctx, z.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", z.Name, err)
+		}
+		for _, r := range records {
+			addr, err := netip.ParseAddr(r.Value)
+			if err != nil {
+				continue
+			}
+			switch {
+			case r.Type == domain.RecordA && slices.Contains(v4, addr.Unmap()),
+				r.Type == domain.RecordAAAA && slices.ContainsFunc(v6, func(p netip.Prefix) bool { return p.Contains(addr) }):
+				pointers = append(pointers, Pointer{Zone: z.Name, Record: r})
+			}
+		}
+	}
+	return pointers, nil
+}
+
+// Lookup resolves host to its addresses by asking nameserver. An empty
+// nameserver means the system resolver.
+type Lookup func(ctx context.Context, nameserver, host string) ([]netip.Addr, error)
+
+// LookupAt is the Lookup that queries nameservers over the network.
+func LookupAt(ctx context.Context, nameserver, host string) ([]netip.Addr, error) {
+	resolver := net.DefaultResolver
+	if nameserver != "" {
+		address := net.JoinHostPort(nameserver, "53")
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, address)
+			},
+		}
+	}
+	return resolver.LookupNetIP(ctx, "ip", host)
+}
+
+// Status is what one nameserver answers for the hostname.
+type Status struct {
+	// Nameserver is the server asked, or "" for the system resolver.
+	Nameserver string
+	Addrs      []netip.Addr
+	Err        error
+	// Propagated is set once every wanted address is in the answer.
+	Propagated bool
+}
+
+// lookupTimeout bounds each nameserver query.
+const lookupTimeout = 5 * time.Second
+
+// Check asks each nameserver for host and reports whether it answers with
+// every address in want. Without nameservers, the system resolver is
+// asked instead.
+func Check(ctx context.Context, lookup Lookup, nameservers []string, host string, want []string) []Status {
+	if len(nameservers) == 0 {
+		nameservers = []string{""}
+	}
+	statuses := make([]Status, len(nameservers))
+	for i, ns := range nameservers {
+		lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+		addrs, err := lookup(lookupCtx, ns, host)
+		cancel()
+		statuses[i] = Status{Nameserver: ns, Addrs: addrs, Err: err, Propagated: err == nil && answers(addrs, want)}
+	}
+	return statuses
+}
+
+// answers reports whether addrs holds every address in want.
+func answers(addrs []netip.Addr, want []string) bool {
+	for _, w := range want {
+		addr, err := netip.ParseAddr(w)
+		if err != nil || !slices.ContainsFunc(addrs, func(a netip.Addr) bool { return a.Unmap() == addr }) {
+			return false
+		}
+	}
+	return true
+}
+
+// Propagated reports whether every nameserver answers with the addresses.
+func Propagated(statuses []Status) bool {
+	for _, s := range statuses {
+		if !s.Propagated {
+			return false
+		}
+	}
+	return len(statuses) > 0
+}
+
+// String describes the status, e.g. "ns1.example.net: 203.0.113.7".
+func (s Status) String() string {
+	ns := s.Nameserver
+	if ns == "" {
+		ns = "system resolver"
+	}
+	if s.Err != nil {
+		return fmt.Sprintf("%s: %v", ns, s.Err)
+	}
+	if len(s.Addrs) == 0 {
+		return ns + ": no addresses"
+	}
+	addrs := make([]string, len(s.Addrs))
+	for i, a := range s.Addrs {
+		addrs[i] = a.String()
+	}
+	return ns + ": " + strings.Join(addrs, ", ")
+}
@@ -0,0 +1,164 @@
+package attach
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlan_CreatesAndUpdates(t *testing.T) {
+	existing := []domain.Record{
+		{ID: "1", Name: "www", Type: domain.RecordA, Value: "203.0.113.1", TTL: 300},
+		{ID: "2", Name: "mail", Type: domain.RecordA, Value: "203.0.113.9"},
+	}
+
+	changes, err := Plan(Records("www", "203.0.113.7", "2001:db8::1", 0), existing, domain.Quirks{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []domain.MirrorChange{
+		{Action: domain.MirrorUpdate, Record: domain.Record{ID: "1", Name: "www", Type: domain.RecordA, Value: "203.0.113.7", TTL: 300}},
+		{Action: domain.MirrorCreate, Record: domain.Record{Name: "www", Type: domain.RecordAAAA, Value: "2001:db8::1"}},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("changes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPlan_AlreadyPointedIsEmpty(t *testing.T) {
+	existing := []domain.Record{{ID: "1", Name: "@", Type: domain.RecordA, Value: "203.0.113.7", TTL: 300}}
+
+	changes, err := Plan(Records("", "203.0.113.7", "", 0), existing, domain.Quirks{})
+	if err != nil || len(changes) != 0 {
+		t.Errorf("changes = %v, err = %v; want no changes", changes, err)
+	}
+}
+
+func TestPlan_RaisesTTLToMinimum(t *testing.T) {
+	changes, err := Plan(Records("www", "203.0.113.7", "", 60), nil, domain.Quirks{MinTTL: 3600})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Record.TTL != 3600 {
+		t.Errorf("changes = %v, want one create with ttl 3600", changes)
+	}
+}
+
+func TestPlan_RefusesToOverwrite(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []domain.Record
+		want     string
+	}{
+		{"cname", []domain.Record{{ID: "1", Name: "www", Type: domain.RecordCNAME, Value: "example.com"}}, "has a CNAME record"},
+		{"round robin", []domain.Record{
+			{ID: "1", Name: "www", Type: domain.RecordA, Value: "203.0.113.1"},
+			{ID: "2", Name: "www", Type: domain.RecordA, Value: "203.0.113.2"},
+		}, "2 A records exist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Plan(Records("www", "203.0.113.7", "", 0), tt.existing, domain.Quirks{})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostname(t *testing.T) {
+	for name, want := range map[string]string{"@": "example.com", "": "example.com", "www": "www.example.com"} {
+		if got := Hostname(name, "example.com."); got != want {
+			t.Errorf("Hostname(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCheck_ReportsEachNameserver(t *testing.T) {
+	answers := map[string][]netip.Addr{
+		"ns1.example.net": {netip.MustParseAddr("203.0.113.7"), netip.MustParseAddr("2001:db8::1")},
+		"ns2.example.net": {netip.MustParseAddr("203.0.113.1")},
+	}
+	lookup := func(_ context.Context, ns, host string) ([]netip.Addr, error) {
+		if host != "www.example.com" {
+			t.Errorf("looked up %q, want www.example.com", host)
+		}
+		if addrs, ok := answers[ns]; ok {
+			return addrs, nil
+		}
+		return nil, errors.New("no such host")
+	}
+
+	statuses := Check(context.Background(), lookup, []string{"ns1.example.net", "ns2.example.net", "ns3.example.net"},
+		"www.example.com", []string{"203.0.113.7", "2001:db8::1"})
+
+	got := make([]bool, len(statuses))
+	for i, s := range statuses {
+		got[i] = s.Propagated
+	}
+	if diff := cmp.Diff([]bool{true, false, false}, got); diff != "" {
+		t.Errorf("propagated mismatch (-want +got):\n%s", diff)
+	}
+	if Propagated(statuses) {
+		t.Error("Propagated = true, want false while ns2 and ns3 lag")
+	}
+	if !Propagated(statuses[:1]) {
+		t.Error("Propagated = false, want true for ns1 alone")
+	}
+	if got := statuses[2].String(); got != "ns3.example.net: no such host" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// zonesProvider serves fixed zones and their records.
+type zonesProvider struct {
+	domain.Provider
+	records map[string][]domain.Record
+}
+
+func (p zonesProvider) ListZones(context.Context) ([]domain.Zone, error) {
+	var zones []domain.Zone
+	for name := range p.records {
+		zones = append(zones, domain.Zone{Name: name})
+	}
+	slices.SortFunc(zones, func(a, b domain.Zone) int { return strings.Compare(a.Name, b.Name) })
+	return zones, nil
+}
+
+func (p zonesProvider) ListRecords(_ context.Context, zone string) ([]domain.Record, error) {
+	return p.records[zone], nil
+}
+
+func TestPointing_FindsRecordsOnOldAddresses(t *testing.T) {
+	provider := zonesProvider{records: map[string][]domain.Record{
+		"example.com": {
+			{Name: "www", Type: domain.RecordA, Value: "203.0.113.1"},
+			{Name: "api", Type: domain.RecordA, Value: "203.0.113.9"},
+			{Name: "www", Type: domain.RecordAAAA, Value: "2001:db8:1::1"},
+			{Name: "@", Type: domain.RecordTXT, Value: "203.0.113.1"},
+		},
+		"example.org": {{Name: "@", Type: domain.RecordA, Value: "203.0.113.1"}},
+	}}
+
+	got, err := Pointing(context.Background(), provider, []string{"203.0.113.1", "2001:db8:1::"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, p := range got {
+		names = append(names, p.String())
+	}
+	want := []string{"www.example.com A 203.0.113.1", "www.example.com AAAA 2001:db8:1::1", "example.org A 203.0.113.1"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("pointers mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,144 @@
+// Package zonestats gathers per-zone statistics (record counts, plan and
+// nameserver status) for the DNS domain list. Zones are fetched
+// concurrently and the results cached, since listing every zone's records
+// takes one API call per zone.
+package zonestats
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency bounds how many zones are fetched at once, keeping
+// well inside provider rate limits.
+const DefaultConcurrency = 4
+
+// DefaultTTL is how long zone statistics are cached.
+const DefaultTTL = 5 * time.Minute
+
+// lookupTimeout bounds each nameserver lookup.
+const lookupTimeout = 5 * time.Second
+
+// Fetcher fetches the statistics of one zone from a provider.
+type Fetcher func(ctx context.Context, zone string) (domain.ZoneStats, error)
+
+// Options configures a collection.
+type Options struct {
+	// Provider names the DNS provider; it keys the cache.
+	Provider string
+	// Concurrency bounds parallelism; DefaultConcurrency when <= 0.
+	Concurrency int
+	// Cache stores fetched statistics. Nil disables caching.
+	Cache *cache.Cache
+	// TTL is how long cached statistics are used; DefaultTTL when <= 0.
+	TTL time.Duration
+	// Refresh ignores cached statistics and fetches every zone again.
+	Refresh bool
+}
+
+// Result is the outcome for one zone.
+type Result struct {
+	Stats domain.ZoneStats
+	// Cached is set when Stats came from the cache.
+	Cached bool
+	Err    error
+}
+
+// Collect fetches the statistics of every zone. Results are returned in
+// the order of zones; a failure on one zone does not stop the others.
+func Collect(ctx context.Context, zones []string, fetch Fetcher, opts Options) []Result {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	results := make([]Result, len(zones))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, zone := range zones {
+		g.Go(func() error {
+			results[i] = collectOne(ctx, zone, fetch, opts, ttl)
+			return nil
+		})
+	}
+	g.Wait()
+	return results
+}
+
+func collectOne(ctx context.Context, zone string, fetch Fetcher, opts Options, ttl time.Duration) Result {
+	key := cacheKey(opts.Provider, zone)
+	if !opts.Refresh {
+		var cached domain.ZoneStats
+		if hit, _ := opts.Cache.Get(key, ttl, &cached); hit {
+			return Result{Stats: cached, Cached: true}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return Result{Stats: domain.ZoneStats{Domain: zone}, Err: err}
+	}
+	stats, err := fetch(ctx, zone)
+	if err != nil {
+		return Result{Stats: domain.ZoneStats{Domain: zone}, Err: err}
+	}
+	stats.Domain = zone
+	_ = opts.Cache.Set(key, stats)
+	return Result{Stats: stats}
+}
+
+func cacheKey(provider, zone string) string {
+	return "dns_zonestats_" + provider + "_" + zone
+}
+
+// LookupNS returns the nameservers a zone is delegated to.
+type LookupNS func(ctx context.Context, zone string) ([]string, error)
+
+// LookupDelegation is the LookupNS that asks the system resolver.
+func LookupDelegation(ctx context.Context, zone string) ([]string, error) {
+	records, err := net.DefaultResolver.LookupNS(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	nameservers := make([]string, len(records))
+	for i, ns := range records {
+		nameservers[i] = strings.TrimSuffix(ns.Host, ".")
+	}
+	return nameservers, nil
+}
+
+// ProviderFetcher fetches zone statistics from provider: the record
+// counts from its records, the plan and expected nameservers from zones
+// (as listed by the provider) and the delegated nameservers from
+// lookupNS. A failed lookup leaves the nameserver status unknown rather
+// than failing the zone.
+func ProviderFetcher(provider domain.Provider, zones []domain.Zone, lookupNS LookupNS) Fetcher {
+	byName := make(map[string]domain.Zone, len(zones))
+	for _, z := range zones {
+		byName[z.Name] = z
+	}
+	return func(ctx context.Context, zone string) (domain.ZoneStats, error) {
+		records, err := provider.ListRecords(ctx, zone)
+		if err != nil {
+			return domain.ZoneStats{}, err
+		}
+		stats := domain.ZoneStats{Domain: zone, Plan: byName[zone].Plan, Expected: byName[zone].Nameservers}
+		stats.CountRecords(records)
+		if len(stats.Expected) > 0 && lookupNS != nil {
+			lookupCtx, cancel := context.WithTimeout(ctx, lookupTimeout)
+			defer cancel()
+			stats.Nameservers, _ = lookupNS(lookupCtx, zone)
+		}
+		return stats, nil
+	}
+}
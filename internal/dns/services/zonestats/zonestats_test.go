@@ -0,0 +1,119 @@
+package zonestats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+func TestCollect_BoundsConcurrencyAndKeepsOrder(t *testing.T) {
+	zones := []string{"a.example", "b.example", "c.example", "d.example", "e.example"}
+
+	var running, peak atomic.Int32
+	fetch := func(ctx context.Context, zone string) (domain.ZoneStats, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if zone == "c.example" {
+			return domain.ZoneStats{}, errors.New("rate limited")
+		}
+		return domain.ZoneStats{Records: len(zone)}, nil
+	}
+
+	results := Collect(context.Background(), zones, fetch, Options{Concurrency: 2})
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrency = %d, want <= 2", p)
+	}
+	for i, r := range results {
+		if r.Stats.Domain != zones[i] {
+			t.Errorf("results[%d].Domain = %q, want %q", i, r.Stats.Domain, zones[i])
+		}
+	}
+	if results[2].Err == nil {
+		t.Error("expected an error for c.example")
+	}
+	if results[3].Err != nil || results[3].Stats.Records != len("d.example") {
+		t.Errorf("results[3] = %+v, want stats for d.example", results[3])
+	}
+}
+
+func TestCollect_UsesCache(t *testing.T) {
+	c := cache.New(t.TempDir())
+	var mu sync.Mutex
+	calls := map[string]int{}
+	fetch := func(ctx context.Context, zone string) (domain.ZoneStats, error) {
+		mu.Lock()
+		calls[zone]++
+		mu.Unlock()
+		return domain.ZoneStats{Records: 3, Plan: "Free Website"}, nil
+	}
+	opts := Options{Provider: "cloudflare", Cache: c}
+
+	Collect(context.Background(), []string{"example.com"}, fetch, opts)
+	results := Collect(context.Background(), []string{"example.com"}, fetch, opts)
+
+	if calls["example.com"] != 1 {
+		t.Errorf("fetch called %d times, want 1", calls["example.com"])
+	}
+	if !results[0].Cached || results[0].Stats.Plan != "Free Website" {
+		t.Errorf("results[0] = %+v, want cached stats", results[0])
+	}
+
+	opts.Refresh = true
+	results = Collect(context.Background(), []string{"example.com"}, fetch, opts)
+	if calls["example.com"] != 2 || results[0].Cached {
+		t.Errorf("Refresh: fetch called %d times, cached = %v; want 2, false", calls["example.com"], results[0].Cached)
+	}
+}
+
+// recordsProvider answers ListRecords with a fixed zone.
+type recordsProvider struct {
+	domain.Provider
+	records []domain.Record
+}
+
+func (p recordsProvider) ListRecords(context.Context, string) ([]domain.Record, error) {
+	return p.records, nil
+}
+
+func TestProviderFetcher_CountsRecordsAndChecksDelegation(t *testing.T) {
+	provider := recordsProvider{records: []domain.Record{
+		{Name: "@", Type: domain.RecordA, Value: "192.0.2.1"},
+		{Name: "www", Type: domain.RecordA, Value: "192.0.2.1"},
+		{Name: "@", Type: domain.RecordMX, Value: "mail.example.com"},
+	}}
+	zones := []domain.Zone{{Name: "example.com", Plan: "Free Website", Nameservers: []string{"ada.ns.cloudflare.com", "bob.ns.cloudflare.com"}}}
+	lookupNS := func(ctx context.Context, zone string) ([]string, error) {
+		return []string{"ns1.registrar.example", "ns2.registrar.example"}, nil
+	}
+
+	stats, err := ProviderFetcher(provider, zones, lookupNS)(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Records != 3 || stats.ByType[domain.RecordA] != 2 || stats.Plan != "Free Website" {
+		t.Errorf("stats = %+v, want 3 records (2 A) on Free Website", stats)
+	}
+	if got := stats.NameserverStatus(); got != domain.NameserversMismatch {
+		t.Errorf("NameserverStatus() = %q, want %q", got, domain.NameserversMismatch)
+	}
+
+	failing := func(ctx context.Context, zone string) ([]string, error) { return nil, errors.New("no such host") }
+	stats, err = ProviderFetcher(provider, zones, failing)(context.Background(), "example.com")
+	if err != nil || stats.NameserverStatus() != domain.NameserversUnknown {
+		t.Errorf("failed lookup: err = %v, status = %q, want nil, unknown", err, stats.NameserverStatus())
+	}
+}
@@ -0,0 +1,560 @@
+// Package tui holds the interactive DNS views.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// requestTimeout bounds each provider request the view makes.
+const requestTimeout = 30 * time.Second
+
+// undoWindow is how long the toast offers to undo a change after it is
+// saved.
+const undoWindow = 10 * time.Second
+
+// reverter is implemented by providers that log their changes, such as
+// *changelog.Provider; with one, saved changes can be undone.
+type reverter interface {
+	LastChange() *changelog.Change
+	Revert(ctx context.Context, c changelog.Change, force bool) (*changelog.Change, error)
+}
+
+// editField is the part of the selected record being typed.
+type editField int
+
+const (
+	editNone editField = iota
+	editName
+	editTTL
+)
+
+// undoToast offers to revert the last change saved from the view.
+type undoToast struct {
+	id      int
+	summary string
+	change  changelog.Change
+}
+
+// --- Messages ---
+
+type recordsLoadedMsg struct {
+	records []dnsdomain.Record
+}
+
+type recordsErrorMsg struct {
+	err error
+}
+
+// recordSavedMsg reports a record change the provider accepted. change
+// is how it was logged, when the provider logs changes.
+type recordSavedMsg struct {
+	summary string // e.g. `www A renamed to "web"`
+	change  *changelog.Change
+}
+
+// recordRevertedMsg reports an undone change.
+type recordRevertedMsg struct {
+	summary string
+}
+
+// undoExpiredMsg hides the toast with the given id once its window has
+// passed. A newer toast is left alone.
+type undoExpiredMsg struct {
+	id int
+}
+
+type recordSaveErrorMsg struct {
+	err error
+}
+
+// --- Record list model ---
+
+// recordListModel lists a zone's records and renames the selected one or
+// changes its TTL. Through a logging provider, each change can be undone
+// for a few seconds after it is saved.
+type recordListModel struct {
+	provider     dnsdomain.Provider
+	providerName string
+	zone         string
+	// indexKey, when set, names the provider in the local search index,
+	// which is updated with the zone's records each time they load.
+	indexKey string
+
+	records []dnsdomain.Record
+	cursor  int
+	// editing is the field of the selected record being typed, if any.
+	editing  editField
+	input    textinput.Model
+	inputErr string
+	loading  bool
+	saving   bool
+	err      error
+	// status reports the last change, or why it failed.
+	status  string
+	spinner spinner.Model
+	// toast offers to undo the last change for undoWindow.
+	toast   *undoToast
+	toastID int
+
+	width  int
+	height int
+}
+
+// RunRecordList opens the record list of zone. Changes go through
+// provider, so a logging provider records them. The loaded records are
+// added to the local search index under indexKey.
+func RunRecordList(provider dnsdomain.Provider, providerName, indexKey, zone string) error {
+	m := newRecordListModel(provider, providerName, zone)
+	m.indexKey = indexKey
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("failed to run record list: %w", err)
+	}
+	return nil
+}
+
+func newRecordListModel(provider dnsdomain.Provider, providerName, zone string) recordListModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	input := textinput.New()
+	input.Placeholder = "www"
+	input.CharLimit = 253
+	input.Width = 40
+
+	return recordListModel{
+		provider:     provider,
+		providerName: providerName,
+		zone:         zone,
+		input:        input,
+		loading:      true,
+		spinner:      s,
+	}
+}
+
+func (m recordListModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchRecords())
+}
+
+func (m recordListModel) fetchRecords() tea.Cmd {
+	provider, zone, indexKey := m.provider, m.zone, m.indexKey
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		records, err := provider.ListRecords(ctx, zone)
+		if err != nil {
+			return recordsErrorMsg{err: err}
+		}
+		if indexKey != "" {
+			tagindexsvc.IndexZone(indexKey, zone, records)
+		}
+		sort.SliceStable(records, func(i, j int) bool {
+			if records[i].Name != records[j].Name {
+				return records[i].Name < records[j].Name
+			}
+			return records[i].Type < records[j].Type
+		})
+		return recordsLoadedMsg{records: records}
+	}
+}
+
+// updateRecord submits r and reports the change as summary.
+func (m recordListModel) updateRecord(r dnsdomain.Record, summary string) tea.Cmd {
+	provider, zone := m.provider, m.zone
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		if _, err := provider.UpdateRecord(ctx, zone, r); err != nil {
+			return recordSaveErrorMsg{err: err}
+		}
+		msg := recordSavedMsg{summary: summary}
+		if logged, ok := provider.(reverter); ok {
+			msg.change = logged.LastChange()
+		}
+		return msg
+	}
+}
+
+// revert undoes the change the toast offers. A record changed again since
+// is left alone.
+func (m recordListModel) revert(toast undoToast) tea.Cmd {
+	logged, ok := m.provider.(reverter)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		if _, err := logged.Revert(ctx, toast.change, false); err != nil {
+			return recordSaveErrorMsg{err: fmt.Errorf("failed to undo: %w", err)}
+		}
+		return recordRevertedMsg{summary: "Undone: " + toast.summary}
+	}
+}
+
+// --- Update ---
+
+func (m recordListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing != editNone {
+			return m.handleEditKey(msg)
+		}
+		return m.handleKey(msg)
+
+	case recordsLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.records = msg.records
+		if m.cursor >= len(m.records) {
+			m.cursor = max(len(m.records)-1, 0)
+		}
+		return m, nil
+
+	case recordsErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case recordSavedMsg:
+		m.saving = false
+		m.status = msg.summary
+		m.loading = true
+		cmds := []tea.Cmd{m.spinner.Tick, m.fetchRecords()}
+		if msg.change != nil {
+			m.toastID++
+			id := m.toastID
+			m.toast = &undoToast{id: id, summary: msg.summary, change: *msg.change}
+			cmds = append(cmds, tea.Tick(undoWindow, func(time.Time) tea.Msg { return undoExpiredMsg{id: id} }))
+		}
+		return m, tea.Batch(cmds...)
+
+	case recordRevertedMsg:
+		m.saving = false
+		m.status = msg.summary
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.fetchRecords())
+
+	case undoExpiredMsg:
+		if m.toast != nil && m.toast.id == msg.id {
+			m.toast = nil
+		}
+		return m, nil
+
+	case recordSaveErrorMsg:
+		m.saving = false
+		m.status = "Error: " + msg.err.Error()
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading || m.saving {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.editing != editNone {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m recordListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q", "esc":
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.records)-1 {
+			m.cursor++
+		}
+
+	case "ctrl+r":
+		if !m.loading {
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.fetchRecords())
+		}
+
+	case "r":
+		if m.loading || m.saving || len(m.records) == 0 {
+			return m, nil
+		}
+		return m.startEdit(editName, "www", m.records[m.cursor].Name)
+
+	case "t":
+		if m.loading || m.saving || len(m.records) == 0 {
+			return m, nil
+		}
+		ttl := ""
+		if current := m.records[m.cursor].TTL; current > 0 {
+			ttl = strconv.Itoa(current)
+		}
+		return m.startEdit(editTTL, "3600", ttl)
+
+	case "u":
+		if m.toast != nil && !m.saving {
+			toast := *m.toast
+			m.toast = nil
+			m.saving = true
+			m.status = ""
+			return m, tea.Batch(m.spinner.Tick, m.revert(toast))
+		}
+	}
+
+	return m, nil
+}
+
+// startEdit opens the input on field of the selected record, holding
+// value.
+func (m recordListModel) startEdit(field editField, placeholder, value string) (tea.Model, tea.Cmd) {
+	m.editing = field
+	m.inputErr = ""
+	m.input.Placeholder = placeholder
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	return m, m.input.Focus()
+}
+
+func (m recordListModel) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.editing = editNone
+		m.input.Blur()
+		return m, nil
+
+	case "enter":
+		record := m.records[m.cursor]
+		var (
+			changed dnsdomain.Record
+			summary string
+		)
+		switch m.editing {
+		case editName:
+			renamed, err := dnsdomain.Rename(record, m.input.Value(), m.records)
+			if err != nil {
+				m.inputErr = err.Error()
+				return m, nil
+			}
+			changed = renamed
+			summary = fmt.Sprintf("%s %s renamed to %q", record.Name, record.Type, renamed.Name)
+		case editTTL:
+			ttl, err := m.parseTTL(m.input.Value())
+			if err != nil {
+				m.inputErr = err.Error()
+				return m, nil
+			}
+			if ttl == record.TTL {
+				m.editing = editNone
+				m.input.Blur()
+				return m, nil
+			}
+			changed = record
+			changed.TTL = ttl
+			summary = fmt.Sprintf("%s %s TTL set to %d", record.Name, record.Type, ttl)
+		}
+		m.editing = editNone
+		m.input.Blur()
+		m.saving = true
+		m.status = ""
+		return m, tea.Batch(m.spinner.Tick, m.updateRecord(changed, summary))
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.inputErr = ""
+	return m, cmd
+}
+
+// parseTTL checks a typed TTL against the provider's minimum.
+func (m recordListModel) parseTTL(s string) (int, error) {
+	ttl, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("TTL must be a positive number of seconds")
+	}
+	if minTTL := m.provider.Quirks().MinTTL; ttl < minTTL {
+		return 0, fmt.Errorf("%s accepts TTLs of %d seconds or more", m.providerName, minTTL)
+	}
+	return ttl, nil
+}
+
+// --- View ---
+
+func (m recordListModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, []string{"dns", m.zone}, m.providerName)
+
+	bindings := []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "r", Desc: "rename"},
+		{Key: "t", Desc: "ttl"},
+		{Key: "ctrl+r", Desc: "refresh"},
+		{Key: "q", Desc: "quit"},
+	}
+	if m.editing != editNone {
+		bindings = []components.KeyBinding{
+			{Key: "enter", Desc: "save"},
+			{Key: "esc", Desc: "cancel"},
+		}
+	}
+	footer := components.Footer(m.width, bindings)
+	// The undo toast takes over the footer's top border.
+	if m.toast != nil && m.editing == editNone {
+		if divider := components.UndoDivider(m.width, m.toast.summary); divider != "" {
+			if _, rest, ok := strings.Cut(footer, "\n"); ok {
+				footer = divider + "\n" + rest
+			}
+		}
+	}
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m recordListModel) renderContent(height int) string {
+	if m.loading && m.records == nil {
+		loadingText := m.spinner.View() + "  Fetching records" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press ctrl+r to retry or q to quit.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			errText,
+		)
+	}
+
+	if len(m.records) == 0 {
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(fmt.Sprintf("No records in %s.", m.zone)),
+		)
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Records in %s", m.zone))
+
+	var bottom string
+	switch {
+	case m.editing != editNone:
+		r := m.records[m.cursor]
+		hint := styles.MutedText.Render(fmt.Sprintf("New name for %s %s, \"@\" for the apex:", r.Name, r.Type))
+		if m.editing == editTTL {
+			hint = styles.MutedText.Render(fmt.Sprintf("TTL in seconds for %s %s:", r.Name, r.Type))
+		}
+		bottom = lipgloss.JoinVertical(lipgloss.Left, hint, m.input.View())
+		if m.inputErr != "" {
+			bottom = lipgloss.JoinVertical(lipgloss.Left, bottom, styles.ErrorText.Render(m.inputErr))
+		}
+	case m.saving:
+		bottom = styles.MutedText.Render(m.spinner.View() + "  Saving" + styles.Ellipsis())
+	case strings.HasPrefix(m.status, "Error: "):
+		bottom = styles.ErrorText.Render(m.status)
+	case m.status != "":
+		bottom = styles.SuccessText.Render(m.status)
+	default:
+		bottom = styles.MutedText.Render("Renaming keeps the record's content, TTL and priority.")
+		if _, ok := m.provider.(reverter); ok {
+			bottom = styles.MutedText.Render("Renames and TTL changes can be undone with u for a few seconds.")
+		}
+	}
+
+	combined := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		m.renderRecords(height-6),
+		"",
+		bottom,
+	)
+
+	return lipgloss.Place(
+		m.width, height,
+		lipgloss.Center, lipgloss.Center,
+		combined,
+	)
+}
+
+// renderRecords lists the records, one per line, scrolled to keep the
+// cursor among the at most maxRows shown.
+func (m recordListModel) renderRecords(maxRows int) string {
+	nameWidth, typeWidth := 0, 0
+	for _, r := range m.records {
+		nameWidth = max(nameWidth, lipgloss.Width(r.Name))
+		typeWidth = max(typeWidth, lipgloss.Width(string(r.Type)))
+	}
+
+	first, last := 0, len(m.records)
+	if maxRows > 0 && last > maxRows {
+		first = min(max(m.cursor-maxRows/2, 0), last-maxRows)
+		last = first + maxRows
+	}
+
+	rows := make([]string, 0, last-first)
+	for i := first; i < last; i++ {
+		r := m.records[i]
+		ttl := "-"
+		if r.TTL > 0 {
+			ttl = fmt.Sprint(r.TTL)
+		}
+		value := r.Value
+		if r.Priority != nil {
+			value = fmt.Sprintf("%d %s", *r.Priority, value)
+		}
+		label := fmt.Sprintf("%-*s  %-*s  %6s  %s", nameWidth, r.Name, typeWidth, r.Type, ttl, value)
+		if i == m.cursor {
+			rows = append(rows, styles.AccentText.Render("> ")+styles.Value.Bold(true).Render(label))
+		} else {
+			rows = append(rows, "  "+styles.MutedText.Render(label))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
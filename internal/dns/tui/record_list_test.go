@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/changelog"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// memProvider is an in-memory dnsdomain.Provider.
+type memProvider struct {
+	records []dnsdomain.Record
+	updated []dnsdomain.Record
+}
+
+func (p *memProvider) GetDisplayName() string   { return "Mem" }
+func (p *memProvider) Quirks() dnsdomain.Quirks { return dnsdomain.Quirks{} }
+func (p *memProvider) ListZones(context.Context) ([]dnsdomain.Zone, error) {
+	return nil, nil
+}
+func (p *memProvider) ListRecords(context.Context, string) ([]dnsdomain.Record, error) {
+	return append([]dnsdomain.Record(nil), p.records...), nil
+}
+func (p *memProvider) CreateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	return &r, nil
+}
+func (p *memProvider) UpdateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	p.updated = append(p.updated, r)
+	for i := range p.records {
+		if p.records[i].ID == r.ID {
+			p.records[i] = r
+		}
+	}
+	return &r, nil
+}
+func (p *memProvider) DeleteRecord(context.Context, string, string) error { return nil }
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// loadedModel returns a record list with provider's records loaded.
+func loadedModel(t *testing.T, provider *memProvider) recordListModel {
+	t.Helper()
+	m := newRecordListModel(provider, "Mem", "example.com")
+	updated, _ := m.Update(m.fetchRecords()())
+	return updated.(recordListModel)
+}
+
+// typeInput replaces the input's value with s.
+func typeInput(m recordListModel, s string) recordListModel {
+	m.input.SetValue(s)
+	return m
+}
+
+func TestRecordList_RenameKeepsContent(t *testing.T) {
+	priority := 10
+	provider := &memProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "mail", Type: dnsdomain.RecordMX, Value: "mx.example.com", TTL: 600, Priority: &priority},
+		{ID: "2", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7"},
+	}}
+	m := loadedModel(t, provider)
+
+	updated, _ := m.Update(runeKey('r'))
+	m = updated.(recordListModel)
+	if m.editing != editName || m.input.Value() != "mail" {
+		t.Fatalf("expected r to start renaming mail, got editing=%v value=%q", m.editing, m.input.Value())
+	}
+
+	m = typeInput(m, "mx")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(recordListModel)
+	if m.editing != editNone || !m.saving || cmd == nil {
+		t.Fatalf("expected enter to submit the rename")
+	}
+	for _, msg := range collect(cmd) {
+		if saved, ok := msg.(recordSavedMsg); ok {
+			updated, _ = m.Update(saved)
+			m = updated.(recordListModel)
+		}
+	}
+
+	if len(provider.updated) != 1 {
+		t.Fatalf("expected one update, got %+v", provider.updated)
+	}
+	got := provider.updated[0]
+	if got.ID != "1" || got.Name != "mx" || got.Value != "mx.example.com" || got.TTL != 600 || got.Priority == nil || *got.Priority != 10 {
+		t.Errorf("update = %+v, want mail moved to mx with its content, TTL and priority", got)
+	}
+	if !strings.Contains(m.status, `mail MX renamed to "mx"`) {
+		t.Errorf("status = %q", m.status)
+	}
+}
+
+func TestRecordList_RenameRejectsCollision(t *testing.T) {
+	provider := &memProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "api", Type: dnsdomain.RecordA, Value: "203.0.113.7"},
+		{ID: "2", Name: "www", Type: dnsdomain.RecordCNAME, Value: "example.com"},
+	}}
+	m := loadedModel(t, provider)
+	m.cursor = 1
+
+	updated, _ := m.Update(runeKey('r'))
+	m = typeInput(updated.(recordListModel), "api")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(recordListModel)
+	if m.editing != editName || !strings.Contains(m.inputErr, "CNAME") {
+		t.Errorf("expected the collision to keep the input open with an error, got editing=%v err=%q", m.editing, m.inputErr)
+	}
+	if len(provider.updated) != 0 {
+		t.Errorf("expected nothing submitted, got %+v", provider.updated)
+	}
+}
+
+func TestRecordList_TTLChangeCanBeUndone(t *testing.T) {
+	provider := &memProvider{records: []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 3600},
+	}}
+	m := newRecordListModel(changelog.Wrap(provider, "mem", nil), "Mem", "example.com")
+	updated, _ := m.Update(m.fetchRecords()())
+	m = updated.(recordListModel)
+
+	updated, _ = m.Update(runeKey('t'))
+	m = updated.(recordListModel)
+	if m.editing != editTTL || m.input.Value() != "3600" {
+		t.Fatalf("expected t to edit the TTL, got editing=%v value=%q", m.editing, m.input.Value())
+	}
+	m = typeInput(m, "soon")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(recordListModel)
+	if m.inputErr == "" {
+		t.Fatal("expected an error for a TTL that is not a number")
+	}
+
+	m = typeInput(m, "60")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(recordListModel)
+	for _, msg := range collect(cmd) {
+		if saved, ok := msg.(recordSavedMsg); ok {
+			updated, _ = m.Update(saved)
+			m = updated.(recordListModel)
+		}
+	}
+	if provider.records[0].TTL != 60 {
+		t.Fatalf("expected the TTL to be 60, got %+v", provider.records[0])
+	}
+	if m.toast == nil || m.toast.summary != "www A TTL set to 60" {
+		t.Fatalf("expected an undo toast, got %+v", m.toast)
+	}
+
+	updated, cmd = m.Update(runeKey('u'))
+	m = updated.(recordListModel)
+	if m.toast != nil || cmd == nil {
+		t.Fatal("expected u to undo the change")
+	}
+	for _, msg := range collect(cmd) {
+		if reverted, ok := msg.(recordRevertedMsg); ok {
+			updated, _ = m.Update(reverted)
+			m = updated.(recordListModel)
+		}
+	}
+	if provider.records[0].TTL != 3600 {
+		t.Errorf("expected undo to restore the TTL of 3600, got %+v", provider.records[0])
+	}
+	if m.status != "Undone: www A TTL set to 60" {
+		t.Errorf("status = %q", m.status)
+	}
+}
+
+func TestRecordList_UndoToastExpires(t *testing.T) {
+	m := loadedModel(t, &memProvider{})
+	updated, _ := m.Update(recordSavedMsg{summary: "www A TTL set to 60", change: &changelog.Change{}})
+	m = updated.(recordListModel)
+	first := m.toast.id
+
+	updated, _ = m.Update(recordSavedMsg{summary: "www A TTL set to 300", change: &changelog.Change{}})
+	m = updated.(recordListModel)
+	updated, _ = m.Update(undoExpiredMsg{id: first})
+	if m = updated.(recordListModel); m.toast == nil {
+		t.Fatal("expected the newer toast to stay")
+	}
+	updated, _ = m.Update(undoExpiredMsg{id: m.toast.id})
+	if m = updated.(recordListModel); m.toast != nil {
+		t.Error("expected the toast to expire")
+	}
+}
+
+// collect runs cmd and any batched commands it returns and returns
+// their messages.
+func collect(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var msgs []tea.Msg
+		for _, c := range batch {
+			msgs = append(msgs, collect(c)...)
+		}
+		return msgs
+	}
+	return []tea.Msg{msg}
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Sentinel errors for cross-provider error classification.
 // Providers should wrap these so the CLI can handle error categories
@@ -23,3 +26,43 @@ var (
 	// transitional state.
 	ErrConflict = errors.New("conflict")
 )
+
+// RequestIDError annotates a failed provider call with the identifier the
+// provider assigned to the request (Hetzner's correlation ID, DigitalOcean's
+// request ID, Cloudflare's ray ID), so support tickets can reference the
+// exact call. It unwraps to the underlying error, so sentinel checks with
+// errors.Is keep working.
+type RequestIDError struct {
+	Err       error
+	RequestID string
+}
+
+func (e *RequestIDError) Error() string {
+	return fmt.Sprintf("%v (request ID %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestIDError) Unwrap() error { return e.Err }
+
+// WithRequestID wraps err with the provider's request ID. It returns err
+// unchanged when either is empty.
+func WithRequestID(err error, requestID string) error {
+	if err == nil || requestID == "" {
+		return err
+	}
+	return &RequestIDError{Err: err, RequestID: requestID}
+}
+
+// RequestID returns the provider request ID carried anywhere in err's
+// chain, or "" if there is none. Errors can carry one either as a
+// RequestIDError or by implementing RequestID() string.
+func RequestID(err error) string {
+	var carrier interface{ RequestID() string }
+	if errors.As(err, &carrier) {
+		return carrier.RequestID()
+	}
+	var rid *RequestIDError
+	if errors.As(err, &rid) {
+		return rid.RequestID
+	}
+	return ""
+}
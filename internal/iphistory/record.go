@@ -0,0 +1,13 @@
+package iphistory
+
+import "time"
+
+// IPRecord is a single public IP address observed on a server.
+type IPRecord struct {
+	ID        int64
+	Provider  string
+	ServerID  string
+	IP        string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
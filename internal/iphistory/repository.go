@@ -0,0 +1,181 @@
+// Package iphistory provides persistent storage for the public IP addresses
+// observed on servers over time.
+//
+// Each time server data is fetched, the current public IPs are recorded
+// keyed by (provider, server_id, ip). Addresses that are no longer assigned
+// to a server remain in the history so callers can surface stale references
+// (e.g. DNS records still pointing at a server's old IP after a rebuild).
+//
+// Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
+// (shared with actionstore and serverprefs, separate table).
+package iphistory
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for server IP history.
+type Repository interface {
+	// Record upserts the given IPs for a server, setting last_seen to seenAt.
+	// IPs seen for the first time also get first_seen set to seenAt.
+	Record(provider, serverID string, ips []string, seenAt time.Time) error
+
+	// List returns all IPs ever recorded for a server, most recently seen first.
+	List(provider, serverID string) ([]IPRecord, error)
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("iphistory: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("iphistory: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("iphistory: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// migrations are the schema versions of the server_ip_history table,
+// oldest first. Add changes as new migrations; never edit one that has
+// shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createHistoryTable},
+}
+
+// migrate brings the server_ip_history table up to the latest schema.
+func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "iphistory", migrations)
+}
+
+// createHistoryTable creates the server_ip_history table, which
+// databases from before schema versioning already hold.
+func createHistoryTable(tx *sql.Tx) error {
+	const ddl = `
+		CREATE TABLE IF NOT EXISTS server_ip_history (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider   TEXT NOT NULL,
+			server_id  TEXT NOT NULL,
+			ip         TEXT NOT NULL,
+			first_seen TEXT NOT NULL,
+			last_seen  TEXT NOT NULL,
+			UNIQUE(provider, server_id, ip)
+		);
+	`
+	_, err := tx.Exec(ddl)
+	return err
+}
+
+// Record upserts the given IPs for a server.
+func (r *SQLiteRepository) Record(provider, serverID string, ips []string, seenAt time.Time) error {
+	seen := seenAt.UTC().Format(time.RFC3339Nano)
+	for _, ip := range ips {
+		if ip == "" {
+			continue
+		}
+		_, err := r.db.Exec(`
+			INSERT INTO server_ip_history (provider, server_id, ip, first_seen, last_seen)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(provider, server_id, ip) DO UPDATE SET
+				last_seen = excluded.last_seen`,
+			provider, serverID, ip, seen, seen,
+		)
+		if err != nil {
+			return fmt.Errorf("iphistory: upsert failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// List returns all IPs ever recorded for a server, most recently seen first.
+func (r *SQLiteRepository) List(provider, serverID string) ([]IPRecord, error) {
+	rows, err := r.db.Query(`
+		SELECT id, provider, server_id, ip, first_seen, last_seen
+		FROM server_ip_history WHERE provider = ? AND server_id = ?
+		ORDER BY last_seen DESC, id DESC`,
+		provider, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("iphistory: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var records []IPRecord
+	for rows.Next() {
+		var rec IPRecord
+		var firstStr, lastStr string
+		if err := rows.Scan(&rec.ID, &rec.Provider, &rec.ServerID, &rec.IP, &firstStr, &lastStr); err != nil {
+			return nil, fmt.Errorf("iphistory: scan failed: %w", err)
+		}
+		rec.FirstSeen, _ = time.Parse(time.RFC3339Nano, firstStr)
+		rec.LastSeen, _ = time.Parse(time.RFC3339Nano, lastStr)
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
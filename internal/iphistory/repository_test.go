@@ -0,0 +1,112 @@
+package iphistory
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestList_Empty(t *testing.T) {
+	r := tempRepo(t)
+
+	got, err := r.List("hetzner", "1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no records, got %d", len(got))
+	}
+}
+
+func TestRecord_InsertAndUpdateLastSeen(t *testing.T) {
+	r := tempRepo(t)
+
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	if err := r.Record("hetzner", "1", []string{"1.2.3.4"}, t1); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if err := r.Record("hetzner", "1", []string{"1.2.3.4"}, t2); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	got, err := r.List("hetzner", "1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(got))
+	}
+	if !got[0].FirstSeen.Equal(t1) {
+		t.Errorf("FirstSeen = %v, want %v", got[0].FirstSeen, t1)
+	}
+	if !got[0].LastSeen.Equal(t2) {
+		t.Errorf("LastSeen = %v, want %v", got[0].LastSeen, t2)
+	}
+}
+
+func TestRecord_IPChangeKeepsHistory(t *testing.T) {
+	r := tempRepo(t)
+
+	t1 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	r.Record("hetzner", "1", []string{"1.2.3.4"}, t1)
+	r.Record("hetzner", "1", []string{"5.6.7.8", ""}, t2)
+
+	got, err := r.List("hetzner", "1")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(got))
+	}
+	if got[0].IP != "5.6.7.8" || got[1].IP != "1.2.3.4" {
+		t.Errorf("unexpected order: %q, %q", got[0].IP, got[1].IP)
+	}
+}
+
+func TestRecord_ScopedByServer(t *testing.T) {
+	r := tempRepo(t)
+
+	now := time.Now()
+	r.Record("hetzner", "1", []string{"1.2.3.4"}, now)
+	r.Record("hetzner", "2", []string{"5.6.7.8"}, now)
+
+	got, _ := r.List("hetzner", "2")
+	if len(got) != 1 || got[0].IP != "5.6.7.8" {
+		t.Errorf("expected only server 2's IP, got %+v", got)
+	}
+}
+
+func TestOpenAt_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	_, err = r.db.Exec(`UPDATE schema_versions SET version = version + 1 WHERE store = 'iphistory'`)
+	r.Close()
+	if err != nil {
+		t.Fatalf("failed to bump version: %v", err)
+	}
+
+	if _, err := OpenAt(path); !errors.Is(err, schema.ErrTooNew) {
+		t.Errorf("err = %v, want ErrTooNew", err)
+	}
+}
@@ -0,0 +1,27 @@
+// Package browser opens URLs with the desktop's default handler: the web
+// browser for http(s) URLs, or the registered application for schemes
+// such as vnc://.
+package browser
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+// Open hands url to the system's URL handler without waiting for it.
+func Open(url string) error {
+	name, args := command(runtime.GOOS, url)
+	return exec.Command(name, args...).Start()
+}
+
+// command returns the program and arguments that open url on goos.
+func command(goos, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
@@ -0,0 +1,30 @@
+package browser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		wantName string
+		wantArgs []string
+	}{
+		{"darwin", "open", []string{"vnc://127.0.0.1:5900"}},
+		{"windows", "rundll32", []string{"url.dll,FileProtocolHandler", "vnc://127.0.0.1:5900"}},
+		{"linux", "xdg-open", []string{"vnc://127.0.0.1:5900"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := command(tt.goos, "vnc://127.0.0.1:5900")
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+			if diff := cmp.Diff(tt.wantArgs, args); diff != "" {
+				t.Errorf("args mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+// Package format renders command output through user-supplied Go
+// templates, as given to the --format flag, so output can be shaped
+// precisely without piping JSON through jq:
+//
+//	vpsm server list --format '{{.Name}}\t{{.PublicIPv4}}'
+//
+// Templates see the same structs that -o json encodes, with Go field
+// names (.PublicIPv4 rather than public_ipv4).
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// funcs are available to templates on top of text/template's builtins.
+var funcs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// escapes turns the \t and \n a user typed in a quoted shell argument into
+// the tab and newline they meant.
+var escapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// Parse compiles a --format template.
+func Parse(text string) (*template.Template, error) {
+	t, err := template.New("format").Funcs(funcs).Option("missingkey=zero").Parse(escapes.Replace(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return t, nil
+}
+
+// Lines executes t for each item and writes the results to w, one per
+// line. Nothing is written if the template fails for any item.
+func Lines[T any](w io.Writer, t *template.Template, items []T) error {
+	var buf bytes.Buffer
+	for _, item := range items {
+		if err := t.Execute(&buf, item); err != nil {
+			return fmt.Errorf("failed to apply --format template: %w", err)
+		}
+		buf.WriteByte('\n')
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,68 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type item struct {
+	Name   string
+	IPs    []string
+	Labels map[string]string
+}
+
+func TestLines(t *testing.T) {
+	tmpl, err := Parse(`{{.Name | upper}}\t{{join .IPs ","}}\t{{index .Labels "env"}}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	items := []item{
+		{Name: "web", IPs: []string{"192.0.2.1", "192.0.2.2"}, Labels: map[string]string{"env": "prod"}},
+		{Name: "db"},
+	}
+	if err := Lines(&buf, tmpl, items); err != nil {
+		t.Fatalf("Lines failed: %v", err)
+	}
+
+	want := "WEB\t192.0.2.1,192.0.2.2\tprod\nDB\t\t\n"
+	if buf.String() != want {
+		t.Errorf("Lines() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestLines_JSON(t *testing.T) {
+	tmpl, err := Parse(`{{json .Labels}}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Lines(&buf, tmpl, []item{{Labels: map[string]string{"env": "prod"}}}); err != nil {
+		t.Fatalf("Lines failed: %v", err)
+	}
+	if buf.String() != `{"env":"prod"}`+"\n" {
+		t.Errorf("Lines() = %q", buf.String())
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("{{.Name"); err == nil || !strings.Contains(err.Error(), "invalid --format template") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
+
+func TestLines_UnknownFieldWritesNothing(t *testing.T) {
+	tmpl, err := Parse("{{.Nmae}}")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := Lines(&buf, tmpl, []item{{Name: "web"}}); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, got %q", buf.String())
+	}
+}
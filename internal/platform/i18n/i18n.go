@@ -0,0 +1,145 @@
+// Package i18n translates user-facing strings.
+//
+// Messages are keyed by their English text, so English needs no catalog
+// and an untranslated message falls back to it. Translations live in
+// locales/<language>.json, one file per language mapping English text to
+// the translation; adding a language does not require touching view code.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+// DefaultLocale is used when no locale is configured or detected.
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+var (
+	messages  = mustLoadCatalog()
+	supported = append([]language.Tag{language.English}, messages.Languages()...)
+	matcher   = language.NewMatcher(supported)
+
+	current atomic.Pointer[localizer]
+)
+
+// localizer pairs the selected language with its printer.
+type localizer struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+func init() {
+	SetLocale(DefaultLocale)
+}
+
+// mustLoadCatalog builds the message catalog from the embedded locale
+// files. The files ship with the binary, so a malformed one is a bug.
+func mustLoadCatalog() *catalog.Builder {
+	b := catalog.NewBuilder(catalog.Fallback(language.English))
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: failed to read locales: %v", err))
+	}
+	for _, entry := range entries {
+		tag, translations, err := loadLocale(entry.Name())
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+		for key, text := range translations {
+			if err := b.SetString(tag, key, text); err != nil {
+				panic(fmt.Sprintf("i18n: failed to add %q to %s: %v", key, tag, err))
+			}
+		}
+	}
+	return b
+}
+
+// loadLocale reads one locale file, named after its BCP 47 language tag.
+func loadLocale(name string) (language.Tag, map[string]string, error) {
+	tag, err := language.Parse(strings.TrimSuffix(name, path.Ext(name)))
+	if err != nil {
+		return language.Und, nil, fmt.Errorf("invalid locale file name %q: %w", name, err)
+	}
+	data, err := localeFiles.ReadFile(path.Join("locales", name))
+	if err != nil {
+		return language.Und, nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	var translations map[string]string
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return language.Und, nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return tag, translations, nil
+}
+
+// SetLocale selects the language for subsequent calls to T. It accepts
+// BCP 47 or POSIX locale names (e.g. "de-AT" or "de_DE.UTF-8") and falls
+// back to English for unknown or unsupported languages.
+func SetLocale(locale string) {
+	tag := language.English
+	if parsed, err := language.Parse(normalizeLocale(locale)); err == nil {
+		_, i, conf := matcher.Match(parsed)
+		if conf != language.No {
+			tag = supported[i]
+		}
+	}
+	current.Store(&localizer{tag: tag, printer: message.NewPrinter(tag, message.Catalog(messages))})
+}
+
+// Locale returns the language tag messages are currently translated to.
+func Locale() string {
+	return current.Load().tag.String()
+}
+
+// Languages returns the languages with a translation catalog, English
+// first.
+func Languages() []string {
+	names := make([]string, len(supported))
+	for i, tag := range supported {
+		names[i] = tag.String()
+	}
+	return names
+}
+
+// ResolveLocale picks the locale for messages: the configured value if
+// set, otherwise the LC_ALL, LC_MESSAGES, or LANG environment variables,
+// otherwise DefaultLocale.
+func ResolveLocale(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(env)
+		if v != "" && v != "C" && v != "POSIX" && !strings.HasPrefix(v, "C.") {
+			return v
+		}
+	}
+	return DefaultLocale
+}
+
+// T translates the English message key into the current locale and
+// formats it with args like fmt.Sprintf.
+func T(key string, args ...any) string {
+	return current.Load().printer.Sprintf(key, args...)
+}
+
+// normalizeLocale converts POSIX locale names such as "de_DE.UTF-8" into
+// BCP 47 form.
+func normalizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
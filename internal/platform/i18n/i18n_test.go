@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"en", "quit", nil, "quit"},
+		{"de", "quit", nil, "beenden"},
+		{"de_DE.UTF-8", "quit", nil, "beenden"},
+		{"de-AT", "Deleting server %s...\n", []any{"42"}, "Server 42 wird gelöscht...\n"},
+		{"de", "not translated %s", []any{"x"}, "not translated x"},
+		{"fr-FR", "quit", nil, "quit"},
+		{"not a locale!", "quit", nil, "quit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.key, func(t *testing.T) {
+			SetLocale(tt.locale)
+			t.Cleanup(func() { SetLocale(DefaultLocale) })
+			if got := T(tt.key, tt.args...); got != tt.want {
+				t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetLocale_FallsBackToEnglish(t *testing.T) {
+	SetLocale("ja-JP")
+	t.Cleanup(func() { SetLocale(DefaultLocale) })
+	if got := Locale(); got != "en" {
+		t.Errorf("Locale() = %q, want %q", got, "en")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	langs := Languages()
+	if len(langs) == 0 || langs[0] != "en" {
+		t.Fatalf("Languages() = %v, want English first", langs)
+	}
+	if !slices.Contains(langs, "de") {
+		t.Errorf("Languages() = %v, want de included", langs)
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "de_DE.UTF-8")
+
+	if got := ResolveLocale("en-GB"); got != "en-GB" {
+		t.Errorf("configured locale: got %q", got)
+	}
+	if got := ResolveLocale(""); got != "de_DE.UTF-8" {
+		t.Errorf("LANG fallback: got %q", got)
+	}
+
+	t.Setenv("LC_MESSAGES", "C")
+	t.Setenv("LANG", "")
+	if got := ResolveLocale(""); got != DefaultLocale {
+		t.Errorf("C locale: got %q, want %q", got, DefaultLocale)
+	}
+}
+
+var verbPattern = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+
+// TestLocales_KeepFormatVerbs catches translations that drop, add, or
+// reorder format verbs, which would garble the output at runtime.
+func TestLocales_KeepFormatVerbs(t *testing.T) {
+	entries, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, entry := range entries {
+		tag, translations, err := loadLocale(entry.Name())
+		if err != nil {
+			t.Fatalf("loadLocale(%s): %v", entry.Name(), err)
+		}
+		for key, text := range translations {
+			if text == "" {
+				t.Errorf("%s: empty translation for %q", tag, key)
+				continue
+			}
+			want := verbPattern.FindAllString(key, -1)
+			got := verbPattern.FindAllString(text, -1)
+			if !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", tag, text, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package ipv6 provides helpers for working with the IPv6 subnets that
+// providers assign to servers, e.g. picking a specific address inside a
+// /64 for a DNS AAAA record.
+package ipv6
+
+import (
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// DefaultHost is the interface identifier most provider images configure
+// on the primary interface (the "::1" address of the subnet).
+const DefaultHost = "::1"
+
+// ParseSubnet parses a CIDR such as "2001:db8:1:2::/64" and returns the
+// masked prefix.
+func ParseSubnet(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IPv6 subnet %q: %w", cidr, err)
+	}
+	if !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return netip.Prefix{}, fmt.Errorf("invalid IPv6 subnet %q: not an IPv6 prefix", cidr)
+	}
+	return prefix.Masked(), nil
+}
+
+// Address returns the address inside subnet with the given host part.
+//
+// host is either an interface identifier written as an IPv6 suffix
+// ("::1", "::dead:beef") or a decimal host index ("1", "42"). The host
+// part must fit in the subnet's host bits.
+func Address(subnet, host string) (netip.Addr, error) {
+	prefix, err := ParseSubnet(subnet)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	hostBits := 128 - prefix.Bits()
+	hostValue, err := parseHost(host)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if hostValue.BitLen() > hostBits {
+		return netip.Addr{}, fmt.Errorf("host %q does not fit in %s", host, prefix)
+	}
+
+	base := prefix.Addr().As16()
+	value := new(big.Int).SetBytes(base[:])
+	value.Or(value, hostValue)
+
+	var out [16]byte
+	value.FillBytes(out[:])
+	return netip.AddrFrom16(out), nil
+}
+
+// DefaultAddress returns the subnet's DefaultHost address.
+func DefaultAddress(subnet string) (netip.Addr, error) {
+	return Address(subnet, DefaultHost)
+}
+
+// Contains reports whether addr lies inside subnet.
+func Contains(subnet, addr string) bool {
+	prefix, err := ParseSubnet(subnet)
+	if err != nil {
+		return false
+	}
+	a, err := netip.ParseAddr(strings.TrimSpace(addr))
+	if err != nil {
+		return false
+	}
+	return prefix.Contains(a)
+}
+
+func parseHost(host string) (*big.Int, error) {
+	host = strings.TrimSpace(host)
+	if host == "" {
+		return nil, fmt.Errorf("host must not be empty")
+	}
+
+	if strings.Contains(host, ":") {
+		addr, err := netip.ParseAddr(host)
+		if err != nil || !addr.Is6() {
+			return nil, fmt.Errorf("invalid host %q: expected an IPv6 suffix like ::1", host)
+		}
+		b := addr.As16()
+		return new(big.Int).SetBytes(b[:]), nil
+	}
+
+	n, err := strconv.ParseUint(host, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host %q: expected an IPv6 suffix like ::1 or a number", host)
+	}
+	return new(big.Int).SetUint64(n), nil
+}
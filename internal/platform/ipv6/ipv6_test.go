@@ -0,0 +1,57 @@
+package ipv6
+
+import "testing"
+
+func TestAddress(t *testing.T) {
+	tests := []struct {
+		subnet  string
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{"2001:db8:1:2::/64", "::1", "2001:db8:1:2::1", false},
+		{"2001:db8:1:2::/64", "1", "2001:db8:1:2::1", false},
+		{"2001:db8:1:2::/64", "255", "2001:db8:1:2::ff", false},
+		{"2001:db8:1:2::/64", "::dead:beef", "2001:db8:1:2::dead:beef", false},
+		{"2001:db8:1:2::/64", "::1:0:0:0:1", "", true}, // exceeds host bits
+		{"2001:db8:1:2::5/64", "::1", "2001:db8:1:2::1", false},
+		{"2001:db8::/120", "256", "", true},
+		{"10.0.0.0/8", "1", "", true},
+		{"not-a-subnet", "1", "", true},
+		{"2001:db8::/64", "abc", "", true},
+		{"2001:db8::/64", "", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := Address(tt.subnet, tt.host)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Address(%q, %q) error = %v, wantErr %v", tt.subnet, tt.host, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("Address(%q, %q) = %s, want %s", tt.subnet, tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultAddress(t *testing.T) {
+	got, err := DefaultAddress("2001:db8::/64")
+	if err != nil {
+		t.Fatalf("DefaultAddress failed: %v", err)
+	}
+	if got.String() != "2001:db8::1" {
+		t.Errorf("DefaultAddress = %s, want 2001:db8::1", got)
+	}
+}
+
+func TestContains(t *testing.T) {
+	if !Contains("2001:db8::/64", "2001:db8::abcd") {
+		t.Error("expected address inside subnet")
+	}
+	if Contains("2001:db8::/64", "2001:db8:0:1::1") {
+		t.Error("expected address outside subnet")
+	}
+	if Contains("2001:db8::/64", "garbage") {
+		t.Error("expected invalid address to be rejected")
+	}
+}
@@ -0,0 +1,106 @@
+package money
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// DefaultLocale is used when no locale is configured or detected.
+const DefaultLocale = "en-US"
+
+// Formatter formats amounts according to a locale's number conventions.
+type Formatter struct {
+	tag     language.Tag
+	printer *message.Printer
+}
+
+// NewFormatter returns a Formatter for the given BCP 47 or POSIX locale
+// (e.g. "de-DE" or "de_DE.UTF-8"). Unparseable locales fall back to
+// DefaultLocale.
+func NewFormatter(locale string) *Formatter {
+	tag, err := language.Parse(normalizeLocale(locale))
+	if err != nil || tag == language.Und {
+		tag = language.MustParse(DefaultLocale)
+	}
+	return &Formatter{tag: tag, printer: message.NewPrinter(tag)}
+}
+
+// ValidateLocale returns an error if locale is not a recognisable BCP 47
+// or POSIX locale name.
+func ValidateLocale(locale string) error {
+	tag, err := language.Parse(normalizeLocale(locale))
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+	if tag == language.Und {
+		return fmt.Errorf("invalid locale %q", locale)
+	}
+	return nil
+}
+
+// ResolveLocale picks the locale to format with: the configured value if
+// set, otherwise the LC_ALL, LC_MONETARY, or LANG environment variables,
+// otherwise DefaultLocale.
+func ResolveLocale(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	for _, env := range []string{"LC_ALL", "LC_MONETARY", "LANG"} {
+		v := os.Getenv(env)
+		if v != "" && v != "C" && v != "POSIX" && !strings.HasPrefix(v, "C.") {
+			return v
+		}
+	}
+	return DefaultLocale
+}
+
+// Locale returns the BCP 47 tag the formatter uses.
+func (f *Formatter) Locale() string {
+	return f.tag.String()
+}
+
+// Format renders an amount with its currency symbol, e.g. "€ 4.76" in
+// en-US or "€ 4,76" in de-DE. Small amounts (such as hourly prices) keep
+// up to four fraction digits so they don't round to zero.
+func (f *Formatter) Format(m Money) string {
+	opts := []number.Option{number.MinFractionDigits(2), number.MaxFractionDigits(2)}
+	if m.Amount != 0 && m.Amount < 1 && m.Amount > -1 {
+		opts = []number.Option{number.MinFractionDigits(2), number.MaxFractionDigits(4)}
+	}
+	amount := f.printer.Sprint(number.Decimal(m.Amount, opts...))
+
+	unit, err := currency.ParseISO(m.Currency)
+	if err != nil {
+		if m.Currency == "" {
+			return amount
+		}
+		return amount + " " + m.Currency
+	}
+	return f.printer.Sprint(currency.Symbol(unit)) + " " + amount
+}
+
+// FormatMonthly renders a monthly price, e.g. "€ 4.76/mo".
+func (f *Formatter) FormatMonthly(m Money) string {
+	return f.Format(m) + "/mo"
+}
+
+// FormatHourly renders an hourly price, e.g. "€ 0.0065/hr".
+func (f *Formatter) FormatHourly(m Money) string {
+	return f.Format(m) + "/hr"
+}
+
+// normalizeLocale converts POSIX locale names such as "de_DE.UTF-8" or
+// "en_US@euro" into BCP 47 form.
+func normalizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ReplaceAll(locale, "_", "-")
+}
@@ -0,0 +1,69 @@
+package money
+
+import "testing"
+
+func TestFormatter_Format(t *testing.T) {
+	tests := []struct {
+		locale string
+		money  Money
+		want   string
+	}{
+		{"en-US", Money{Amount: 1234.5, Currency: "EUR"}, "€ 1,234.50"},
+		{"de-DE", Money{Amount: 1234.5, Currency: "EUR"}, "€ 1.234,50"},
+		{"de_DE.UTF-8", Money{Amount: 4.76, Currency: "EUR"}, "€ 4,76"},
+		{"en-US", Money{Amount: 0.0064, Currency: "EUR"}, "€ 0.0064"},
+		{"en-US", Money{Amount: 5, Currency: "USD"}, "$ 5.00"},
+		{"en-US", Money{Amount: 5}, "5.00"},
+		{"not a locale!", Money{Amount: 5, Currency: "EUR"}, "€ 5.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := NewFormatter(tt.locale).Format(tt.money); got != tt.want {
+				t.Errorf("Format(%+v) = %q, want %q", tt.money, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_Suffixes(t *testing.T) {
+	f := NewFormatter("en-US")
+	m := Money{Amount: 4.76, Currency: "EUR"}
+	if got := f.FormatMonthly(m); got != "€ 4.76/mo" {
+		t.Errorf("FormatMonthly = %q", got)
+	}
+	if got := f.FormatHourly(m); got != "€ 4.76/hr" {
+		t.Errorf("FormatHourly = %q", got)
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MONETARY", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+
+	if got := ResolveLocale("de-DE"); got != "de-DE" {
+		t.Errorf("configured locale should win, got %q", got)
+	}
+	if got := ResolveLocale(""); got != "fr_FR.UTF-8" {
+		t.Errorf("expected LANG fallback, got %q", got)
+	}
+
+	t.Setenv("LANG", "C.UTF-8")
+	if got := ResolveLocale(""); got != DefaultLocale {
+		t.Errorf("expected default for C locale, got %q", got)
+	}
+}
+
+func TestValidateLocale(t *testing.T) {
+	for _, valid := range []string{"en-US", "de_DE.UTF-8", "fr"} {
+		if err := ValidateLocale(valid); err != nil {
+			t.Errorf("ValidateLocale(%q) returned error: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{"", "not a locale!"} {
+		if err := ValidateLocale(invalid); err == nil {
+			t.Errorf("ValidateLocale(%q) expected error", invalid)
+		}
+	}
+}
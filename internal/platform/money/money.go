@@ -0,0 +1,53 @@
+// Package money provides typed monetary amounts and locale-aware formatting
+// for provider prices.
+package money
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// HoursPerMonth is the number of hours used to convert between hourly and
+// monthly prices (365 days * 24 hours / 12 months).
+const HoursPerMonth = 730
+
+// Money is an amount in a specific currency.
+type Money struct {
+	Amount   float64 `json:"amount"`
+	Currency string  `json:"currency"` // ISO 4217 code, e.g. "EUR"
+}
+
+// Parse converts a decimal string (as returned by provider APIs, e.g.
+// "4.7600000000") into Money. An empty amount yields the zero value.
+func Parse(amount, currency string) (Money, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return Money{}, nil
+	}
+	v, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	return Money{Amount: v, Currency: strings.ToUpper(strings.TrimSpace(currency))}, nil
+}
+
+// IsZero reports whether no amount is set.
+func (m Money) IsZero() bool {
+	return m.Amount == 0 && m.Currency == ""
+}
+
+// Mul returns the amount multiplied by n, in the same currency.
+func (m Money) Mul(n float64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// MonthlyFromHourly estimates a monthly price from an hourly price.
+func MonthlyFromHourly(hourly Money) Money {
+	return hourly.Mul(HoursPerMonth)
+}
+
+// HourlyFromMonthly estimates an hourly price from a monthly price.
+func HourlyFromMonthly(monthly Money) Money {
+	return monthly.Mul(1.0 / HoursPerMonth)
+}
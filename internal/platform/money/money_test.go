@@ -0,0 +1,45 @@
+package money
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	got, err := Parse("4.7600000000", "eur")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got.Amount != 4.76 || got.Currency != "EUR" {
+		t.Errorf("Parse = %+v, want 4.76 EUR", got)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	got, err := Parse("", "EUR")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if !got.IsZero() {
+		t.Errorf("expected zero Money, got %+v", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	if _, err := Parse("abc", "EUR"); err == nil {
+		t.Fatal("expected error for invalid amount")
+	}
+}
+
+func TestHourlyMonthlyConversion(t *testing.T) {
+	hourly := Money{Amount: 0.0065, Currency: "EUR"}
+	monthly := MonthlyFromHourly(hourly)
+	if math.Abs(monthly.Amount-4.745) > 1e-9 || monthly.Currency != "EUR" {
+		t.Errorf("MonthlyFromHourly = %+v", monthly)
+	}
+
+	back := HourlyFromMonthly(monthly)
+	if math.Abs(back.Amount-hourly.Amount) > 1e-9 {
+		t.Errorf("round trip = %v, want %v", back.Amount, hourly.Amount)
+	}
+}
@@ -0,0 +1,182 @@
+// Package proxy routes vpsm's network connections through an HTTP(S) or
+// SOCKS5 proxy. The configured proxy takes precedence; otherwise HTTP
+// clients honor HTTP_PROXY, HTTPS_PROXY and NO_PROXY, and SSH connections
+// honor ALL_PROXY.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	xproxy "golang.org/x/net/proxy"
+)
+
+// Parse validates a proxy URL such as "http://proxy:3128" or
+// "socks5://127.0.0.1:1080".
+func Parse(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid proxy %q: expected a URL such as http://proxy:3128 or socks5://host:1080", raw)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return u, nil
+	}
+	return nil, fmt.Errorf("invalid proxy %q: scheme must be http, https, socks5 or socks5h", raw)
+}
+
+// HTTPProxy returns the proxy selector for HTTP clients: configured when
+// set, or else the standard environment variables.
+func HTTPProxy(configured string) func(*http.Request) (*url.URL, error) {
+	if configured == "" {
+		return http.ProxyFromEnvironment
+	}
+	u, err := Parse(configured)
+	if err != nil {
+		return func(*http.Request) (*url.URL, error) { return nil, err }
+	}
+	return http.ProxyURL(u)
+}
+
+// Install makes every HTTP client built on http.DefaultTransport use
+// configured, or the environment when it is empty.
+func Install(configured string) {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = HTTPProxy(configured)
+	}
+}
+
+// ForSSH returns the proxy SSH connections go through: configured, or
+// else ALL_PROXY. It returns nil when connections are direct.
+func ForSSH(configured string) (*url.URL, error) {
+	raw := configured
+	if raw == "" {
+		raw = firstEnv("ALL_PROXY", "all_proxy")
+	}
+	if raw == "" {
+		return nil, nil
+	}
+	return Parse(raw)
+}
+
+func firstEnv(names ...string) string {
+	for _, n := range names {
+		if v := os.Getenv(n); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// SSHArgs returns the ssh arguments that tunnel a connection through
+// proxyURL by running "<self> proxy-connect %h %p" as the ProxyCommand.
+// self is the path of the vpsm executable. It returns nil when proxyURL
+// is nil.
+func SSHArgs(proxyURL *url.URL, self string) []string {
+	if proxyURL == nil {
+		return nil
+	}
+	return []string{"-o", "ProxyCommand=" + shellQuote(self) + " proxy-connect %h %p"}
+}
+
+// shellQuote quotes s for the shell ssh runs ProxyCommand with.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("/._-+", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Dial connects to addr through proxyURL: a SOCKS5 handshake for socks5
+// proxies, or an HTTP CONNECT request for http(s) ones.
+func Dial(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		dialer, err := xproxy.FromURL(proxyURL, xproxy.Direct)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure proxy: %w", err)
+		}
+		if cd, ok := dialer.(xproxy.ContextDialer); ok {
+			return cd.DialContext(ctx, "tcp", addr)
+		}
+		return dialer.Dial("tcp", addr)
+	default:
+		return dialConnect(ctx, proxyURL, addr)
+	}
+}
+
+// dialConnect opens a tunnel to addr with an HTTP CONNECT request.
+func dialConnect(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyHostPort(proxyURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach proxy: %w", err)
+	}
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u := proxyURL.User; u != nil {
+		pass, _ := u.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + pass))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send CONNECT: %w", err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy refused tunnel to %s: %s", addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// proxyHostPort returns the proxy's address, defaulting the port by scheme.
+func proxyHostPort(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// bufferedConn returns bytes the CONNECT response reader read ahead
+// before reading from the connection again.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -0,0 +1,126 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	for _, raw := range []string{"http://proxy:3128", "https://user:pw@proxy", "socks5://127.0.0.1:1080", "socks5h://gw:1080"} {
+		if _, err := Parse(raw); err != nil {
+			t.Errorf("Parse(%q) = %v", raw, err)
+		}
+	}
+	for _, raw := range []string{"proxy:3128", "ftp://proxy", "http://"} {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) expected an error", raw)
+		}
+	}
+}
+
+func TestHTTPProxy_ConfiguredWinsOverEnvironment(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://env-proxy:8080")
+	req, _ := http.NewRequest(http.MethodGet, "https://api.hetzner.cloud/v1/servers", nil)
+
+	got, err := HTTPProxy("socks5://127.0.0.1:1080")(req)
+	if err != nil || got.String() != "socks5://127.0.0.1:1080" {
+		t.Errorf("configured proxy = %v, %v", got, err)
+	}
+}
+
+func TestForSSH(t *testing.T) {
+	t.Setenv("ALL_PROXY", "")
+	t.Setenv("all_proxy", "")
+	if u, err := ForSSH(""); u != nil || err != nil {
+		t.Errorf("expected a direct connection, got %v, %v", u, err)
+	}
+
+	t.Setenv("ALL_PROXY", "socks5://env:1080")
+	if u, _ := ForSSH(""); u == nil || u.Host != "env:1080" {
+		t.Errorf("expected ALL_PROXY, got %v", u)
+	}
+	if u, _ := ForSSH("http://configured:3128"); u == nil || u.Host != "configured:3128" {
+		t.Errorf("expected the configured proxy, got %v", u)
+	}
+}
+
+func TestSSHArgs(t *testing.T) {
+	u, _ := url.Parse("socks5://127.0.0.1:1080")
+	if got := SSHArgs(nil, "/usr/bin/vpsm"); got != nil {
+		t.Errorf("expected no args without a proxy, got %v", got)
+	}
+	got := SSHArgs(u, "/Users/me/My Tools/vpsm")
+	want := "ProxyCommand='/Users/me/My Tools/vpsm' proxy-connect %h %p"
+	if len(got) != 2 || got[0] != "-o" || got[1] != want {
+		t.Errorf("SSHArgs = %q, want [-o %q]", got, want)
+	}
+}
+
+// connectProxy runs a minimal HTTP CONNECT proxy that answers status and,
+// on success, echoes the tunnelled bytes back.
+func connectProxy(t *testing.T, status int) *url.URL {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				req, err := http.ReadRequest(br)
+				if err != nil || req.Method != http.MethodConnect || req.Host != "server:22" {
+					io.WriteString(conn, "HTTP/1.1 400 Bad Request\r\n\r\n")
+					return
+				}
+				if req.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwdw==" {
+					io.WriteString(conn, "HTTP/1.1 407 Proxy Authentication Required\r\n\r\n")
+					return
+				}
+				fmt.Fprintf(conn, "HTTP/1.1 %d %s\r\n\r\n", status, http.StatusText(status))
+				if status == http.StatusOK {
+					io.Copy(conn, br)
+				}
+			}()
+		}
+	}()
+	return &url.URL{Scheme: "http", User: url.UserPassword("user", "pw"), Host: ln.Addr().String()}
+}
+
+func TestDial_HTTPConnect(t *testing.T) {
+	proxyURL := connectProxy(t, http.StatusOK)
+
+	conn, err := Dial(context.Background(), proxyURL, "server:22")
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	io.WriteString(conn, "SSH-2.0-test\n")
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil || line != "SSH-2.0-test\n" {
+		t.Errorf("expected the tunnel to echo, got %q, %v", line, err)
+	}
+}
+
+func TestDial_HTTPConnectRefused(t *testing.T) {
+	proxyURL := connectProxy(t, http.StatusForbidden)
+
+	_, err := Dial(context.Background(), proxyURL, "server:22")
+	if err == nil || !strings.Contains(err.Error(), "proxy refused tunnel") {
+		t.Errorf("expected a refused tunnel error, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Package schema versions the tables vpsm keeps in its SQLite database.
+//
+// Several stores (actionstore, serverprefs, ...) share one database file,
+// so each store's tables are versioned on their own: the schema_versions
+// table records, per store, how many of its migrations have been applied.
+// Opening a store applies the migrations it has not seen yet, each in a
+// transaction with its version bump, and refuses a database written by a
+// newer vpsm whose schema it does not know.
+package schema
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrTooNew is returned when a database's schema is newer than this build
+// of vpsm knows how to use, i.e. it was last opened by a later version.
+var ErrTooNew = errors.New("database schema is newer than this version of vpsm supports")
+
+// Migration brings a store's tables from the previous version to Version.
+type Migration struct {
+	Version int
+	// Up applies the change. It runs in the transaction that records
+	// Version, so a failure leaves the schema at the previous version.
+	Up func(tx *sql.Tx) error
+}
+
+const versionsDDL = `
+	CREATE TABLE IF NOT EXISTS schema_versions (
+		store      TEXT PRIMARY KEY,
+		version    INTEGER NOT NULL,
+		updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+	);
+`
+
+// Version returns the schema version recorded for store, or 0 if none is.
+func Version(db *sql.DB, store string) (int, error) {
+	if _, err := db.Exec(versionsDDL); err != nil {
+		return 0, fmt.Errorf("failed to create schema_versions table: %w", err)
+	}
+	return version(db, store)
+}
+
+// querier is satisfied by *sql.DB and *sql.Tx.
+type querier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func version(q querier, store string) (int, error) {
+	var v int
+	err := q.QueryRow(`SELECT version FROM schema_versions WHERE store = ?`, store).Scan(&v)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return v, nil
+}
+
+// Migrate applies the migrations of store newer than its recorded
+// version. migrations must be numbered 1, 2, 3, ... in order; the last
+// one's Version is the schema this build expects. A recorded version
+// beyond that is an error matching ErrTooNew.
+func Migrate(db *sql.DB, store string, migrations []Migration) error {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return fmt.Errorf("%s: migration %d is numbered %d", store, i+1, m.Version)
+		}
+	}
+	latest := len(migrations)
+
+	current, err := Version(db, store)
+	if err != nil {
+		return fmt.Errorf("%s: %w", store, err)
+	}
+	if current > latest {
+		return fmt.Errorf("%s: %w (version %d, this vpsm supports up to %d); upgrade vpsm to use it",
+			store, ErrTooNew, current, latest)
+	}
+
+	for _, m := range migrations[current:] {
+		if err := apply(db, store, m); err != nil {
+			return fmt.Errorf("%s: migration to version %d failed: %w", store, m.Version, err)
+		}
+	}
+	return nil
+}
+
+// apply runs one migration and records its version. If another process
+// applied it first, it is skipped.
+func apply(db *sql.DB, store string, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := version(tx, store)
+	if err != nil {
+		return err
+	}
+	if current >= m.Version {
+		return nil
+	}
+	if err := m.Up(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+		INSERT INTO schema_versions (store, version, updated_at) VALUES (?, ?, datetime('now'))
+		ON CONFLICT(store) DO UPDATE SET version = excluded.version, updated_at = excluded.updated_at`,
+		store, m.Version)
+	if err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	return tx.Commit()
+}
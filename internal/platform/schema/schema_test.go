@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func exec(query string) func(*sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		_, err := tx.Exec(query)
+		return err
+	}
+}
+
+func TestMigrate_AppliesPendingMigrationsOnce(t *testing.T) {
+	db := openDB(t)
+	runs := 0
+	migrations := []Migration{
+		{Version: 1, Up: func(tx *sql.Tx) error {
+			runs++
+			_, err := tx.Exec(`CREATE TABLE notes (id INTEGER PRIMARY KEY)`)
+			return err
+		}},
+	}
+	if err := Migrate(db, "notes", migrations); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	migrations = append(migrations, Migration{Version: 2, Up: exec(`ALTER TABLE notes ADD COLUMN body TEXT NOT NULL DEFAULT ''`)})
+	if err := Migrate(db, "notes", migrations); err != nil {
+		t.Fatalf("Migrate to version 2 failed: %v", err)
+	}
+	if runs != 1 {
+		t.Errorf("migration 1 ran %d times, want 1", runs)
+	}
+	if v, _ := Version(db, "notes"); v != 2 {
+		t.Errorf("version = %d, want 2", v)
+	}
+	if _, err := db.Exec(`INSERT INTO notes (body) VALUES ('hi')`); err != nil {
+		t.Errorf("expected the body column: %v", err)
+	}
+}
+
+func TestMigrate_RejectsNewerSchema(t *testing.T) {
+	db := openDB(t)
+	migrations := []Migration{
+		{Version: 1, Up: exec(`CREATE TABLE a (id INTEGER)`)},
+		{Version: 2, Up: exec(`CREATE TABLE b (id INTEGER)`)},
+	}
+	if err := Migrate(db, "store", migrations); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+
+	err := Migrate(db, "store", migrations[:1])
+	if !errors.Is(err, ErrTooNew) {
+		t.Fatalf("err = %v, want ErrTooNew", err)
+	}
+}
+
+func TestMigrate_FailedMigrationRollsBack(t *testing.T) {
+	db := openDB(t)
+	migrations := []Migration{
+		{Version: 1, Up: exec(`CREATE TABLE a (id INTEGER)`)},
+		{Version: 2, Up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec(`CREATE TABLE b (id INTEGER)`); err != nil {
+				return err
+			}
+			return errors.New("boom")
+		}},
+	}
+	if err := Migrate(db, "store", migrations); err == nil {
+		t.Fatal("expected the failing migration to fail")
+	}
+	if v, _ := Version(db, "store"); v != 1 {
+		t.Errorf("version = %d, want 1", v)
+	}
+	if _, err := db.Exec(`SELECT * FROM b`); err == nil {
+		t.Error("expected table b to be rolled back")
+	}
+}
+
+func TestMigrate_StoresAreVersionedSeparately(t *testing.T) {
+	db := openDB(t)
+	if err := Migrate(db, "one", []Migration{
+		{Version: 1, Up: exec(`CREATE TABLE a (id INTEGER)`)},
+		{Version: 2, Up: exec(`CREATE TABLE b (id INTEGER)`)},
+	}); err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if err := Migrate(db, "two", []Migration{{Version: 1, Up: exec(`CREATE TABLE c (id INTEGER)`)}}); err != nil {
+		t.Fatalf("Migrate of a second store failed: %v", err)
+	}
+	if v, _ := Version(db, "two"); v != 1 {
+		t.Errorf("version of two = %d, want 1", v)
+	}
+}
+
+func TestMigrate_RejectsMisnumberedMigrations(t *testing.T) {
+	db := openDB(t)
+	err := Migrate(db, "store", []Migration{{Version: 2, Up: exec(`SELECT 1`)}})
+	if err == nil {
+		t.Error("expected an error for a migration list not starting at 1")
+	}
+}
@@ -0,0 +1,103 @@
+// Package tmux opens commands in new tmux windows or panes, so interactive
+// sessions can run alongside the TUI instead of taking over its terminal.
+package tmux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Mode selects where a command is launched.
+type Mode string
+
+const (
+	// ModeExec runs the command in the current terminal.
+	ModeExec Mode = "exec"
+	// ModeWindow opens the command in a new tmux window.
+	ModeWindow Mode = "tmux-window"
+	// ModePane opens the command in a new pane split beside the current one.
+	ModePane Mode = "tmux-pane"
+)
+
+// Modes lists the valid launch modes.
+var Modes = []Mode{ModeExec, ModeWindow, ModePane}
+
+// ParseMode returns the Mode named by s. An empty value is ModeExec.
+func ParseMode(s string) (Mode, error) {
+	if s == "" {
+		return ModeExec, nil
+	}
+	for _, m := range Modes {
+		if string(m) == s {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid launch mode %q: expected exec, tmux-window or tmux-pane", s)
+}
+
+// Active reports whether vpsm is running inside a tmux session.
+func Active() bool {
+	return os.Getenv("TMUX") != ""
+}
+
+// Command returns the tmux command that opens argv in a new window or pane
+// named name. mode must be ModeWindow or ModePane.
+func Command(mode Mode, name string, argv ...string) (*exec.Cmd, error) {
+	var args []string
+	switch mode {
+	case ModeWindow:
+		args = []string{"new-window", "-n", name, "--"}
+	case ModePane:
+		args = []string{"split-window", "-h", "--"}
+	default:
+		return nil, fmt.Errorf("launch mode %q does not use tmux", mode)
+	}
+	return exec.Command("tmux", append(args, argv...)...), nil
+}
+
+// Pane is one command in a broadcast session, labelled with title.
+type Pane struct {
+	Title string
+	Argv  []string
+}
+
+// Broadcast returns the tmux commands that create the detached session
+// named session with one tiled pane per entry in panes, each titled, and
+// with synchronize-panes on so typed input reaches every pane. Run them in
+// order, then Attach to the session.
+func Broadcast(session string, panes []Pane) ([]*exec.Cmd, error) {
+	if len(panes) == 0 {
+		return nil, fmt.Errorf("no panes to broadcast to")
+	}
+	target := session + ":0"
+
+	cmds := []*exec.Cmd{
+		exec.Command("tmux", append([]string{"new-session", "-d", "-s", session, "-n", "broadcast", "--"}, panes[0].Argv...)...),
+	}
+	for _, p := range panes[1:] {
+		// Re-tile after every split so later splits still have room.
+		cmds = append(cmds,
+			exec.Command("tmux", append([]string{"split-window", "-t", target, "--"}, p.Argv...)...),
+			exec.Command("tmux", "select-layout", "-t", target, "tiled"),
+		)
+	}
+	for i, p := range panes {
+		cmds = append(cmds, exec.Command("tmux", "select-pane", "-t", fmt.Sprintf("%s.%d", target, i), "-T", p.Title))
+	}
+	cmds = append(cmds,
+		exec.Command("tmux", "set-option", "-t", session, "pane-border-status", "top"),
+		exec.Command("tmux", "set-window-option", "-t", target, "synchronize-panes", "on"),
+	)
+	return cmds, nil
+}
+
+// Attach returns the command that brings session to the foreground:
+// switch-client from inside tmux, attach-session otherwise. Attaching
+// takes over the terminal until the session is detached or ends.
+func Attach(session string) *exec.Cmd {
+	if Active() {
+		return exec.Command("tmux", "switch-client", "-t", session)
+	}
+	return exec.Command("tmux", "attach-session", "-t", session)
+}
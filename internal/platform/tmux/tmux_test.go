@@ -0,0 +1,114 @@
+package tmux
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Mode
+		wantErr bool
+	}{
+		{"", ModeExec, false},
+		{"exec", ModeExec, false},
+		{"tmux-window", ModeWindow, false},
+		{"tmux-pane", ModePane, false},
+		{"screen", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMode(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMode(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	cmd, err := Command(ModeWindow, "web-1", "ssh", "root@192.0.2.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"tmux", "new-window", "-n", "web-1", "--", "ssh", "root@192.0.2.1"}
+	if !slices.Equal(cmd.Args, want) {
+		t.Errorf("Args = %v, want %v", cmd.Args, want)
+	}
+
+	cmd, err = Command(ModePane, "web-1", "ssh", "root@192.0.2.1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []string{"tmux", "split-window", "-h", "--", "ssh", "root@192.0.2.1"}
+	if !slices.Equal(cmd.Args, want) {
+		t.Errorf("Args = %v, want %v", cmd.Args, want)
+	}
+
+	if _, err := Command(ModeExec, "web-1", "ssh"); err == nil {
+		t.Error("expected an error for exec mode")
+	}
+}
+
+func TestActive(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if Active() {
+		t.Error("expected Active() false without $TMUX")
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if !Active() {
+		t.Error("expected Active() true with $TMUX")
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	cmds, err := Broadcast("vpsm-broadcast", []Pane{
+		{Title: "web-1", Argv: []string{"ssh", "root@192.0.2.1"}},
+		{Title: "web-2", Argv: []string{"ssh", "root@192.0.2.2"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got [][]string
+	for _, c := range cmds {
+		got = append(got, c.Args[1:])
+	}
+	want := [][]string{
+		{"new-session", "-d", "-s", "vpsm-broadcast", "-n", "broadcast", "--", "ssh", "root@192.0.2.1"},
+		{"split-window", "-t", "vpsm-broadcast:0", "--", "ssh", "root@192.0.2.2"},
+		{"select-layout", "-t", "vpsm-broadcast:0", "tiled"},
+		{"select-pane", "-t", "vpsm-broadcast:0.0", "-T", "web-1"},
+		{"select-pane", "-t", "vpsm-broadcast:0.1", "-T", "web-2"},
+		{"set-option", "-t", "vpsm-broadcast", "pane-border-status", "top"},
+		{"set-window-option", "-t", "vpsm-broadcast:0", "synchronize-panes", "on"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d commands, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("command %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := Broadcast("vpsm-broadcast", nil); err == nil {
+		t.Error("expected an error without panes")
+	}
+}
+
+func TestAttach(t *testing.T) {
+	t.Setenv("TMUX", "")
+	if got := Attach("s").Args; !slices.Equal(got, []string{"tmux", "attach-session", "-t", "s"}) {
+		t.Errorf("Args = %v", got)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	if got := Attach("s").Args; !slices.Equal(got, []string{"tmux", "switch-client", "-t", "s"}) {
+		t.Errorf("Args = %v", got)
+	}
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// Backup is an automatic backup of a server's disk taken by the provider.
+type Backup struct {
+	ID          string    `json:"id"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	// SizeGB is the compressed size of the backup, when known.
+	SizeGB float64 `json:"size_gb,omitempty"`
+}
@@ -1,28 +1,61 @@
 package domain
 
-import platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	platformsshkey "nathanbeddoewebdev/vpsm/internal/platform/sshkey"
+)
 
 // Location represents an available deployment region/location from a provider.
 type Location struct {
 	ID          string `json:"id"`
-	Name        string `json:"name"`        // e.g. "fsn1"
-	Description string `json:"description"` // e.g. "Falkenstein"
-	Country     string `json:"country"`     // e.g. "DE"
-	City        string `json:"city"`        // e.g. "Falkenstein"
+	Name        string `json:"name"`                   // e.g. "fsn1"
+	Description string `json:"description"`            // e.g. "Falkenstein"
+	Country     string `json:"country"`                // e.g. "DE"
+	City        string `json:"city"`                   // e.g. "Falkenstein"
+	NetworkZone string `json:"network_zone,omitempty"` // e.g. "eu-central"
 }
 
 // ServerTypeSpec describes an available server configuration from a provider.
 type ServerTypeSpec struct {
-	ID           string   `json:"id"`
-	Name         string   `json:"name"`        // e.g. "cpx11"
-	Description  string   `json:"description"` // e.g. "CPX 11"
-	Cores        int      `json:"cores"`
-	Memory       float64  `json:"memory"`       // in GB
-	Disk         int      `json:"disk"`         // in GB
-	Architecture string   `json:"architecture"` // e.g. "x86", "arm"
-	PriceMonthly string   `json:"price_monthly"`
-	PriceHourly  string   `json:"price_hourly"`
-	Locations    []string `json:"locations"` // location names where available
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`        // e.g. "cpx11"
+	Description  string      `json:"description"` // e.g. "CPX 11"
+	Cores        int         `json:"cores"`
+	Memory       float64     `json:"memory"`       // in GB
+	Disk         int         `json:"disk"`         // in GB
+	Architecture string      `json:"architecture"` // e.g. "x86", "arm"
+	PriceMonthly money.Money `json:"price_monthly"`
+	PriceHourly  money.Money `json:"price_hourly"`
+	Locations    []string    `json:"locations"` // location names where available
+}
+
+// MonthlyPrice returns the monthly price, estimated from the hourly price
+// when the provider only reports hourly pricing.
+func (s ServerTypeSpec) MonthlyPrice() money.Money {
+	if s.PriceMonthly.IsZero() && !s.PriceHourly.IsZero() {
+		return money.MonthlyFromHourly(s.PriceHourly)
+	}
+	return s.PriceMonthly
+}
+
+// HourlyPrice returns the hourly price, estimated from the monthly price
+// when the provider only reports monthly pricing.
+func (s ServerTypeSpec) HourlyPrice() money.Money {
+	if s.PriceHourly.IsZero() && !s.PriceMonthly.IsZero() {
+		return money.HourlyFromMonthly(s.PriceMonthly)
+	}
+	return s.PriceHourly
+}
+
+// AddonPricing holds prices for extras billed on top of the server type.
+type AddonPricing struct {
+	// IPv4Monthly is the monthly price of a public IPv4 address, keyed by
+	// location name.
+	IPv4Monthly map[string]money.Money `json:"ipv4_monthly,omitempty"`
 }
 
 // ImageSpec describes an available OS image from a provider.
@@ -33,7 +66,87 @@ type ImageSpec struct {
 	Type         string `json:"type"`         // e.g. "system", "snapshot", "backup"
 	OSFlavor     string `json:"os_flavor"`    // e.g. "ubuntu", "debian", "fedora"
 	Architecture string `json:"architecture"` // e.g. "x86", "arm"
+
+	// SizeGB, CreatedFrom and CreatedAt describe snapshots and backups:
+	// the compressed image size, the name of the server it was taken
+	// from, and when. They are unset for system images.
+	SizeGB      float64   `json:"size_gb,omitempty"`
+	CreatedFrom string    `json:"created_from,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitzero"`
 }
 
 // SSHKeySpec describes an SSH key registered with the provider.
 type SSHKeySpec = platformsshkey.Spec
+
+// NetworkSpec describes a private network registered with the provider.
+type NetworkSpec struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	IPRange string `json:"ip_range"` // e.g. "10.0.0.0/16"
+
+	// NetworkZones lists the zones the network's subnets cover. A server
+	// can only attach to the network from a location in one of them.
+	NetworkZones []string `json:"network_zones,omitempty"`
+
+	// ServerIDs lists the servers attached to the network.
+	ServerIDs []string `json:"server_ids,omitempty"`
+}
+
+// CoversLocation reports whether a server at loc can attach to the
+// network. Unknown zones on either side are assumed compatible and left
+// for the provider to check.
+func (n NetworkSpec) CoversLocation(loc Location) bool {
+	if loc.NetworkZone == "" || len(n.NetworkZones) == 0 {
+		return true
+	}
+	for _, zone := range n.NetworkZones {
+		if strings.EqualFold(zone, loc.NetworkZone) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateNetworkLocation returns an error if a server at loc cannot
+// attach to network because they are in different network zones.
+func ValidateNetworkLocation(network NetworkSpec, loc Location) error {
+	if network.CoversLocation(loc) {
+		return nil
+	}
+	return fmt.Errorf("location %q is in network zone %q, but network %q only covers %s",
+		loc.Name, loc.NetworkZone, network.Name, strings.Join(network.NetworkZones, ", "))
+}
+
+// ValidateImageArchitecture returns an error if the image named or
+// identified by image cannot boot on serverType because every catalog
+// image of that name targets another CPU architecture. Images and server
+// types missing from the catalog, or with an unknown architecture, are
+// left for the provider to check.
+func ValidateImageArchitecture(image, serverType string, types []ServerTypeSpec, images []ImageSpec) error {
+	var arch string
+	for _, st := range types {
+		if st.Name == serverType || st.ID == serverType {
+			arch = st.Architecture
+			break
+		}
+	}
+	if arch == "" {
+		return nil
+	}
+
+	var others []string
+	for _, img := range images {
+		if img.Name != image && img.ID != image {
+			continue
+		}
+		if img.Architecture == "" || strings.EqualFold(img.Architecture, arch) {
+			return nil
+		}
+		others = append(others, img.Architecture)
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return fmt.Errorf("image %q is built for %s, but server type %q is %s; choose an %s image or a %s server type",
+		image, strings.Join(others, ", "), serverType, arch, arch, others[0])
+}
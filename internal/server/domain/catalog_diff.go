@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"sort"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+)
+
+// CatalogSnapshot is the part of a provider's catalog compared between
+// sessions to tell users what changed.
+type CatalogSnapshot struct {
+	ServerTypes []ServerTypeSpec `json:"server_types"`
+	Locations   []Location       `json:"locations"`
+}
+
+// PriceChange records a server type whose monthly price changed.
+type PriceChange struct {
+	ServerType string
+	Old        money.Money
+	New        money.Money
+}
+
+// CatalogChanges lists the differences between two catalog snapshots.
+// Server types and locations are identified by name.
+type CatalogChanges struct {
+	AddedServerTypes   []ServerTypeSpec
+	RemovedServerTypes []string
+	PriceChanges       []PriceChange
+	AddedLocations     []Location
+	RemovedLocations   []string
+}
+
+// Empty reports whether nothing changed.
+func (c CatalogChanges) Empty() bool {
+	return len(c.AddedServerTypes) == 0 && len(c.RemovedServerTypes) == 0 &&
+		len(c.PriceChanges) == 0 && len(c.AddedLocations) == 0 && len(c.RemovedLocations) == 0
+}
+
+// DiffCatalog returns what changed from before to after. Each list is sorted
+// by name so the result is stable.
+func DiffCatalog(before, after CatalogSnapshot) CatalogChanges {
+	var changes CatalogChanges
+
+	oldTypes := make(map[string]ServerTypeSpec, len(before.ServerTypes))
+	for _, st := range before.ServerTypes {
+		oldTypes[st.Name] = st
+	}
+	newTypes := make(map[string]bool, len(after.ServerTypes))
+	for _, st := range after.ServerTypes {
+		newTypes[st.Name] = true
+		prev, ok := oldTypes[st.Name]
+		if !ok {
+			changes.AddedServerTypes = append(changes.AddedServerTypes, st)
+			continue
+		}
+		if prev.MonthlyPrice() != st.MonthlyPrice() {
+			changes.PriceChanges = append(changes.PriceChanges, PriceChange{
+				ServerType: st.Name,
+				Old:        prev.MonthlyPrice(),
+				New:        st.MonthlyPrice(),
+			})
+		}
+	}
+	for name := range oldTypes {
+		if !newTypes[name] {
+			changes.RemovedServerTypes = append(changes.RemovedServerTypes, name)
+		}
+	}
+
+	oldLocations := make(map[string]bool, len(before.Locations))
+	for _, loc := range before.Locations {
+		oldLocations[loc.Name] = true
+	}
+	newLocations := make(map[string]bool, len(after.Locations))
+	for _, loc := range after.Locations {
+		newLocations[loc.Name] = true
+		if !oldLocations[loc.Name] {
+			changes.AddedLocations = append(changes.AddedLocations, loc)
+		}
+	}
+	for name := range oldLocations {
+		if !newLocations[name] {
+			changes.RemovedLocations = append(changes.RemovedLocations, name)
+		}
+	}
+
+	sort.Slice(changes.AddedServerTypes, func(i, j int) bool {
+		return changes.AddedServerTypes[i].Name < changes.AddedServerTypes[j].Name
+	})
+	sort.Strings(changes.RemovedServerTypes)
+	sort.Slice(changes.PriceChanges, func(i, j int) bool {
+		return changes.PriceChanges[i].ServerType < changes.PriceChanges[j].ServerType
+	})
+	sort.Slice(changes.AddedLocations, func(i, j int) bool {
+		return changes.AddedLocations[i].Name < changes.AddedLocations[j].Name
+	})
+	sort.Strings(changes.RemovedLocations)
+
+	return changes
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func eur(amount float64) money.Money {
+	return money.Money{Amount: amount, Currency: "EUR"}
+}
+
+func TestDiffCatalog(t *testing.T) {
+	before := CatalogSnapshot{
+		ServerTypes: []ServerTypeSpec{
+			{Name: "cpx11", PriceMonthly: eur(4.5)},
+			{Name: "cpx21", PriceMonthly: eur(8)},
+			{Name: "cx11", PriceMonthly: eur(3)},
+		},
+		Locations: []Location{{Name: "fsn1"}, {Name: "hil"}},
+	}
+	after := CatalogSnapshot{
+		ServerTypes: []ServerTypeSpec{
+			{Name: "cpx11", PriceMonthly: eur(4.99)},
+			{Name: "cpx21", PriceMonthly: eur(8)},
+			{Name: "cax11", PriceMonthly: eur(3.79)},
+		},
+		Locations: []Location{{Name: "fsn1"}, {Name: "sin", City: "Singapore"}},
+	}
+
+	got := DiffCatalog(before, after)
+	want := CatalogChanges{
+		AddedServerTypes:   []ServerTypeSpec{{Name: "cax11", PriceMonthly: eur(3.79)}},
+		RemovedServerTypes: []string{"cx11"},
+		PriceChanges:       []PriceChange{{ServerType: "cpx11", Old: eur(4.5), New: eur(4.99)}},
+		AddedLocations:     []Location{{Name: "sin", City: "Singapore"}},
+		RemovedLocations:   []string{"hil"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffCatalog() mismatch (-want +got):\n%s", diff)
+	}
+	if got.Empty() {
+		t.Error("expected changes to be non-empty")
+	}
+}
+
+func TestDiffCatalog_Unchanged(t *testing.T) {
+	snap := CatalogSnapshot{
+		ServerTypes: []ServerTypeSpec{{Name: "cpx11", PriceHourly: eur(0.01)}},
+		Locations:   []Location{{Name: "fsn1"}},
+	}
+	if got := DiffCatalog(snap, snap); !got.Empty() {
+		t.Errorf("expected no changes, got %+v", got)
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNetworkSpec_CoversLocation(t *testing.T) {
+	network := NetworkSpec{Name: "internal", NetworkZones: []string{"eu-central"}}
+
+	tests := []struct {
+		name string
+		loc  Location
+		want bool
+	}{
+		{"same zone", Location{Name: "fsn1", NetworkZone: "eu-central"}, true},
+		{"other zone", Location{Name: "ash", NetworkZone: "us-east"}, false},
+		{"unknown zone", Location{Name: "fsn1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := network.CoversLocation(tt.loc); got != tt.want {
+				t.Errorf("CoversLocation(%+v) = %v, want %v", tt.loc, got, tt.want)
+			}
+		})
+	}
+
+	if !(NetworkSpec{Name: "bare"}).CoversLocation(Location{NetworkZone: "us-east"}) {
+		t.Error("expected a network without known zones to cover any location")
+	}
+}
+
+func TestValidateNetworkLocation(t *testing.T) {
+	network := NetworkSpec{Name: "internal", NetworkZones: []string{"eu-central"}}
+
+	if err := ValidateNetworkLocation(network, Location{Name: "fsn1", NetworkZone: "eu-central"}); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	err := ValidateNetworkLocation(network, Location{Name: "ash", NetworkZone: "us-east"})
+	if err == nil {
+		t.Fatal("expected an error for mismatched zones")
+	}
+	for _, want := range []string{"ash", "us-east", "internal", "eu-central"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error, got: %v", want, err)
+		}
+	}
+}
+
+func TestValidateImageArchitecture(t *testing.T) {
+	types := []ServerTypeSpec{
+		{ID: "1", Name: "cpx11", Architecture: "x86"},
+		{ID: "45", Name: "cax11", Architecture: "arm"},
+	}
+	images := []ImageSpec{
+		{ID: "100", Name: "ubuntu-24.04", Architecture: "x86"},
+		{ID: "101", Name: "ubuntu-24.04", Architecture: "arm"},
+		{ID: "200", Name: "windows-x86", Architecture: "x86"},
+		{ID: "300", Name: "custom"},
+	}
+
+	tests := []struct {
+		name       string
+		image      string
+		serverType string
+		wantErr    string
+	}{
+		{"name with both architectures", "ubuntu-24.04", "cax11", ""},
+		{"matching ID", "101", "cax11", ""},
+		{"mismatched ID", "100", "cax11", `image "100" is built for x86, but server type "cax11" is arm`},
+		{"mismatched name", "windows-x86", "45", `server type "45" is arm`},
+		{"unknown architecture", "custom", "cax11", ""},
+		{"image not in catalog", "snapshot-1", "cax11", ""},
+		{"server type not in catalog", "windows-x86", "cax99", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateImageArchitecture(tt.image, tt.serverType, types, images)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
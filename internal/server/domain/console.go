@@ -0,0 +1,9 @@
+package domain
+
+// Console is a remote console session on a server, independent of its
+// network configuration. URL is a WebSocket endpoint speaking the VNC
+// protocol; Password authenticates the VNC session. Both are short-lived.
+type Console struct {
+	URL      string `json:"url"`
+	Password string `json:"password"`
+}
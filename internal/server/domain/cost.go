@@ -0,0 +1,42 @@
+package domain
+
+import "nathanbeddoewebdev/vpsm/internal/platform/money"
+
+// CostLine is one billed component of a monthly cost estimate.
+type CostLine struct {
+	Label   string
+	Monthly money.Money
+}
+
+// CostEstimate is an itemised monthly cost for a set of create options.
+type CostEstimate struct {
+	Lines []CostLine
+	Total money.Money
+}
+
+// EstimateMonthlyCost itemises the monthly cost of creating a server of
+// type st with opts. Add-ons whose price is unknown (no pricing, or no
+// price for the chosen location) are left out rather than guessed.
+func EstimateMonthlyCost(st ServerTypeSpec, opts CreateServerOpts, pricing *AddonPricing) CostEstimate {
+	var est CostEstimate
+	est.add("Server "+st.Name, st.MonthlyPrice())
+
+	ipv4 := opts.EnableIPv4 == nil || *opts.EnableIPv4
+	if ipv4 && pricing != nil {
+		if price, ok := pricing.IPv4Monthly[opts.Location]; ok {
+			est.add("Public IPv4", price)
+		}
+	}
+	return est
+}
+
+func (e *CostEstimate) add(label string, price money.Money) {
+	if price.IsZero() {
+		return
+	}
+	e.Lines = append(e.Lines, CostLine{Label: label, Monthly: price})
+	e.Total.Amount += price.Amount
+	if e.Total.Currency == "" {
+		e.Total.Currency = price.Currency
+	}
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEstimateMonthlyCost(t *testing.T) {
+	st := ServerTypeSpec{Name: "cpx11", PriceMonthly: money.Money{Amount: 4.50, Currency: "EUR"}}
+	pricing := &AddonPricing{IPv4Monthly: map[string]money.Money{
+		"fsn1": {Amount: 0.60, Currency: "EUR"},
+	}}
+	off := false
+
+	tests := []struct {
+		name    string
+		opts    CreateServerOpts
+		pricing *AddonPricing
+		want    []string
+		total   float64
+	}{
+		{"type and ipv4", CreateServerOpts{Location: "fsn1"}, pricing, []string{"Server cpx11", "Public IPv4"}, 5.10},
+		{"ipv4 disabled", CreateServerOpts{Location: "fsn1", EnableIPv4: &off}, pricing, []string{"Server cpx11"}, 4.50},
+		{"unpriced location", CreateServerOpts{Location: "ash"}, pricing, []string{"Server cpx11"}, 4.50},
+		{"no pricing", CreateServerOpts{Location: "fsn1"}, nil, []string{"Server cpx11"}, 4.50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			est := EstimateMonthlyCost(st, tt.opts, tt.pricing)
+			var labels []string
+			for _, line := range est.Lines {
+				labels = append(labels, line.Label)
+			}
+			if diff := cmp.Diff(tt.want, labels); diff != "" {
+				t.Errorf("lines mismatch (-want +got):\n%s", diff)
+			}
+			if d := est.Total.Amount - tt.total; d > 1e-9 || d < -1e-9 {
+				t.Errorf("Total = %v, want %v", est.Total.Amount, tt.total)
+			}
+			if est.Total.Currency != "EUR" {
+				t.Errorf("Total currency = %q, want EUR", est.Total.Currency)
+			}
+		})
+	}
+}
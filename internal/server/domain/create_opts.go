@@ -15,8 +15,19 @@ type CreateServerOpts struct {
 	Labels            map[string]string
 	UserData          string
 	StartAfterCreate  *bool // nil = provider default (usually true)
+	EnableIPv4        *bool // nil = provider default (usually true)
+	EnableIPv6        *bool // nil = provider default (usually true)
 
-	// Provider-specific extensions (e.g. firewalls, networks, volumes).
+	// Networks are private networks (names or IDs) to attach the server
+	// to. Each must cover the location's network zone.
+	Networks []string
+
+	// Interruptible requests a discounted spot instance that the provider
+	// may reclaim at any time. Only providers implementing
+	// InterruptibleProvider accept it.
+	Interruptible bool
+
+	// Provider-specific extensions (e.g. firewalls, volumes).
 	// Keyed by provider-defined strings; see each provider for details.
 	Extra map[string]interface{}
 }
@@ -13,3 +13,17 @@ var (
 	// ErrConflict indicates a state or uniqueness conflict.
 	ErrConflict = shared.ErrConflict
 )
+
+// RequestIDError annotates a failed provider call with the provider's ID
+// for the request.
+type RequestIDError = shared.RequestIDError
+
+// WithRequestID wraps err with the provider's request ID, if any.
+func WithRequestID(err error, requestID string) error {
+	return shared.WithRequestID(err, requestID)
+}
+
+// RequestID returns the provider request ID carried in err, or "".
+func RequestID(err error) string {
+	return shared.RequestID(err)
+}
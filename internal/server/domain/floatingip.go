@@ -0,0 +1,36 @@
+package domain
+
+// FloatingIP is a public address that can be moved between servers in
+// its home location, e.g. to fail over without changing DNS.
+type FloatingIP struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	// IP is the address, or the network ("2001:db8::/64") for IPv6.
+	IP   string `json:"ip"`
+	Type string `json:"type"` // "ipv4" or "ipv6"
+	// HomeLocation is where the address is routed, e.g. "fsn1". It can
+	// only be assigned to servers there.
+	HomeLocation string `json:"home_location"`
+	// ServerID is the server the address is assigned to, "" if none.
+	ServerID string `json:"server_id,omitempty"`
+}
+
+// Label returns the name if the address has one, else the address.
+func (f FloatingIP) Label() string {
+	if f.Name != "" {
+		return f.Name
+	}
+	return f.IP
+}
+
+// CreateFloatingIPOpts holds the parameters for creating a floating IP.
+// Either HomeLocation or ServerID must be set; with ServerID the address
+// is created in the server's location and assigned to it.
+type CreateFloatingIPOpts struct {
+	Type         string // "ipv4" or "ipv6"
+	Name         string
+	Description  string
+	HomeLocation string
+	ServerID     string
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// maxLabelLength is the longest label key or value accepted. Providers
+// enforce similar limits (Hetzner: 63 characters per name and value).
+const maxLabelLength = 63
+
+// ParseLabel splits a "key=value" label and checks that both parts are
+// usable as provider labels: a non-empty key, at most 63 characters each,
+// and only letters, digits and "-_./" (a key may carry a "prefix/").
+// The value may be empty.
+func ParseLabel(s string) (key, value string, err error) {
+	key, value, ok := strings.Cut(strings.TrimSpace(s), "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid label %q: expected key=value", s)
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if key == "" {
+		return "", "", fmt.Errorf("invalid label %q: missing key", s)
+	}
+	if err := checkLabelPart("key", key); err != nil {
+		return "", "", err
+	}
+	if err := checkLabelPart("value", value); err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
+
+// ParseLabels parses a comma-separated list of "key=value" labels, as
+// edited in the TUI. An empty list gives an empty, non-nil map.
+func ParseLabels(s string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, part := range strings.Split(s, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		key, value, err := ParseLabel(part)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := labels[key]; dup {
+			return nil, fmt.Errorf("label %q is given twice", key)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// FormatLabels is the inverse of ParseLabels, with the keys sorted.
+func FormatLabels(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		parts = append(parts, key+"="+labels[key])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func checkLabelPart(part, s string) error {
+	if len(s) > maxLabelLength {
+		return fmt.Errorf("label %s %q is longer than %d characters", part, s, maxLabelLength)
+	}
+	for _, r := range s {
+		ok := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./", r)
+		if !ok {
+			return fmt.Errorf("label %s %q may only contain letters, digits and -_./", part, s)
+		}
+	}
+	return nil
+}
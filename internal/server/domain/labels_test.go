@@ -0,0 +1,50 @@
+package domain
+
+import "testing"
+
+func TestParseLabel(t *testing.T) {
+	tests := []struct {
+		in         string
+		key, value string
+		wantErr    bool
+	}{
+		{in: "env=prod", key: "env", value: "prod"},
+		{in: " team = web ", key: "team", value: "web"},
+		{in: "example.com/role=db", key: "example.com/role", value: "db"},
+		{in: "flag=", key: "flag", value: ""},
+		{in: "env", wantErr: true},
+		{in: "=prod", wantErr: true},
+		{in: "env=pro d", wantErr: true},
+		{in: "e nv=prod", wantErr: true},
+		{in: "env=" + string(make([]byte, 64)), wantErr: true},
+	}
+	for _, tt := range tests {
+		key, value, err := ParseLabel(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLabel(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if key != tt.key || value != tt.value {
+			t.Errorf("ParseLabel(%q) = %q, %q, want %q, %q", tt.in, key, value, tt.key, tt.value)
+		}
+	}
+}
+
+func TestParseLabels_RoundTripsFormatLabels(t *testing.T) {
+	labels, err := ParseLabels(" role=web,env=prod, ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := FormatLabels(labels), "env=prod, role=web"; got != want {
+		t.Errorf("FormatLabels() = %q, want %q", got, want)
+	}
+
+	if labels, err := ParseLabels(""); err != nil || labels == nil || len(labels) != 0 {
+		t.Errorf("ParseLabels(\"\") = %v, %v, want an empty map", labels, err)
+	}
+	for _, in := range []string{"env=prod,env=dev", "env=prod,role"} {
+		if _, err := ParseLabels(in); err == nil {
+			t.Errorf("ParseLabels(%q): expected an error", in)
+		}
+	}
+}
@@ -12,6 +12,10 @@ const (
 	MetricDisk MetricType = "disk"
 	// MetricNetwork represents network bandwidth metrics.
 	MetricNetwork MetricType = "network"
+	// MetricMemory represents memory usage, as the percentage of memory in
+	// use in the "memory" series. Not every provider collects it; those
+	// that don't leave the series out rather than failing the request.
+	MetricMemory MetricType = "memory"
 )
 
 // MetricsPoint is a single data point in a time series.
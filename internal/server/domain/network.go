@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// CreateNetworkOpts holds the parameters for creating a private network
+// with one subnet. Servers attach through the subnet, so it must lie in
+// the network zone of the locations they run in.
+type CreateNetworkOpts struct {
+	Name        string
+	IPRange     string // e.g. "10.0.0.0/16"
+	NetworkZone string // e.g. "eu-central"
+	// SubnetRange is the subnet's range inside IPRange. Empty means the
+	// whole of IPRange.
+	SubnetRange string
+}
+
+// Validate checks that the ranges are private IPv4 prefixes and that the
+// subnet lies inside the network's range.
+func (o CreateNetworkOpts) Validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("network name is required")
+	}
+	if o.NetworkZone == "" {
+		return fmt.Errorf("network zone is required")
+	}
+	network, err := parsePrivatePrefix(o.IPRange)
+	if err != nil {
+		return fmt.Errorf("invalid IP range: %w", err)
+	}
+	if o.SubnetRange == "" {
+		return nil
+	}
+	subnet, err := parsePrivatePrefix(o.SubnetRange)
+	if err != nil {
+		return fmt.Errorf("invalid subnet range: %w", err)
+	}
+	if subnet.Bits() < network.Bits() || !network.Contains(subnet.Addr()) {
+		return fmt.Errorf("subnet %s is not inside %s", subnet, network)
+	}
+	return nil
+}
+
+// parsePrivatePrefix parses s as a private IPv4 CIDR such as "10.0.0.0/16"
+// whose address is the start of the range.
+func parsePrivatePrefix(s string) (netip.Prefix, error) {
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	if !p.Addr().Is4() || !p.Addr().IsPrivate() {
+		return netip.Prefix{}, fmt.Errorf("%s is not a private IPv4 range", s)
+	}
+	if p.Masked() != p {
+		return netip.Prefix{}, fmt.Errorf("%s is not the start of its range; use %s", s, p.Masked())
+	}
+	return p, nil
+}
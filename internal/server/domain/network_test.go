@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateNetworkOpts_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CreateNetworkOpts
+		wantErr string
+	}{
+		{"whole range", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0/16", NetworkZone: "eu-central"}, ""},
+		{"subnet", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0/16", NetworkZone: "eu-central", SubnetRange: "10.0.1.0/24"}, ""},
+		{"missing name", CreateNetworkOpts{IPRange: "10.0.0.0/16", NetworkZone: "eu-central"}, "name is required"},
+		{"missing zone", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0/16"}, "zone is required"},
+		{"public range", CreateNetworkOpts{Name: "n", IPRange: "8.8.0.0/16", NetworkZone: "eu-central"}, "not a private IPv4 range"},
+		{"host bits set", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.1/16", NetworkZone: "eu-central"}, "use 10.0.0.0/16"},
+		{"not a CIDR", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0", NetworkZone: "eu-central"}, "invalid IP range"},
+		{"subnet outside", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0/16", NetworkZone: "eu-central", SubnetRange: "10.1.0.0/24"}, "not inside"},
+		{"subnet larger", CreateNetworkOpts{Name: "n", IPRange: "10.0.0.0/16", NetworkZone: "eu-central", SubnetRange: "10.0.0.0/8"}, "not inside"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -29,6 +29,146 @@ type CatalogProvider interface {
 	ListSSHKeys(ctx context.Context) ([]SSHKeySpec, error)
 }
 
+// NetworkProvider extends Provider with private networks. The create
+// wizard lists them to attach new servers; existing servers are attached
+// and detached with AttachServer and DetachServer. A network can only be
+// deleted once no servers are attached to it.
+type NetworkProvider interface {
+	Provider
+
+	ListNetworks(ctx context.Context) ([]NetworkSpec, error)
+	CreateNetwork(ctx context.Context, opts CreateNetworkOpts) (*NetworkSpec, error)
+	DeleteNetwork(ctx context.Context, id string) error
+	// AttachServer attaches a server to a network. ip is the server's
+	// address inside the network; "" lets the provider pick one.
+	AttachServer(ctx context.Context, networkID, serverID, ip string) (*ActionStatus, error)
+	DetachServer(ctx context.Context, networkID, serverID string) (*ActionStatus, error)
+}
+
+// PricingProvider extends Provider with prices for add-ons that are billed
+// on top of the server type, so the create wizard can estimate the total
+// monthly cost of a selection.
+type PricingProvider interface {
+	Provider
+
+	GetAddonPricing(ctx context.Context) (*AddonPricing, error)
+}
+
+// CustomImageProvider extends Provider with the account's own images:
+// snapshots and automatic backups, with Type "snapshot" or "backup". A
+// server created from one starts with a copy of its disk. Unlike the
+// catalog, they are listed fresh each time, since they come and go.
+type CustomImageProvider interface {
+	Provider
+
+	ListCustomImages(ctx context.Context) ([]ImageSpec, error)
+}
+
+// InterruptibleProvider extends Provider for providers that offer
+// discounted interruptible (spot) servers. CreateServer honours
+// CreateServerOpts.Interruptible only when SupportsInterruptible is true.
+type InterruptibleProvider interface {
+	Provider
+
+	SupportsInterruptible() bool
+}
+
+// SupportsInterruptible reports whether p can create interruptible servers.
+func SupportsInterruptible(p Provider) bool {
+	ip, ok := p.(InterruptibleProvider)
+	return ok && ip.SupportsInterruptible()
+}
+
+// UserDataLimiter extends Provider for providers that cap the size of the
+// cloud-init user data sent with CreateServer.
+type UserDataLimiter interface {
+	Provider
+
+	// UserDataLimit returns the largest accepted user data in bytes.
+	UserDataLimit() int
+}
+
+// UserDataLimit returns p's user data size limit in bytes, or 0 when p
+// does not declare one.
+func UserDataLimit(p Provider) int {
+	if l, ok := p.(UserDataLimiter); ok {
+		return l.UserDataLimit()
+	}
+	return 0
+}
+
+// RebootProvider extends Provider with restarting a running server.
+// RebootServer asks the operating system to restart (ACPI reboot);
+// ResetServer power-cycles the server without warning, like pressing
+// its reset button.
+type RebootProvider interface {
+	Provider
+
+	RebootServer(ctx context.Context, id string) (*ActionStatus, error)
+	ResetServer(ctx context.Context, id string) (*ActionStatus, error)
+}
+
+// ResizeProvider extends Provider with changing a server's type. The
+// server must be powered off. Unless upgradeDisk is set the disk keeps
+// its size, so the server can later be resized back down.
+type ResizeProvider interface {
+	Provider
+
+	ResizeServer(ctx context.Context, id, serverType string, upgradeDisk bool) (*ActionStatus, error)
+}
+
+// BackupProvider extends Provider with the provider's automatic backups.
+// RestoreBackup overwrites the server's disk with the given backup; data
+// written since the backup was taken is lost.
+type BackupProvider interface {
+	Provider
+
+	EnableBackups(ctx context.Context, serverID string) (*ActionStatus, error)
+	DisableBackups(ctx context.Context, serverID string) (*ActionStatus, error)
+	ListBackups(ctx context.Context, serverID string) ([]Backup, error)
+	RestoreBackup(ctx context.Context, serverID, backupID string) (*ActionStatus, error)
+}
+
+// FloatingIPProvider extends Provider with floating IPs. Assigning an
+// address that is assigned elsewhere moves it; the server must be in the
+// address's home location.
+type FloatingIPProvider interface {
+	Provider
+
+	ListFloatingIPs(ctx context.Context) ([]FloatingIP, error)
+	CreateFloatingIP(ctx context.Context, opts CreateFloatingIPOpts) (*FloatingIP, error)
+	AssignFloatingIP(ctx context.Context, floatingIPID, serverID string) (*ActionStatus, error)
+	UnassignFloatingIP(ctx context.Context, floatingIPID string) (*ActionStatus, error)
+}
+
+// ReverseDNSProvider extends Provider with the reverse DNS (PTR) records
+// of a server's public addresses. GetReverseDNS lists the server's IPv4
+// address and the IPv6 addresses with a record, plus the subnet's ::1
+// address. SetReverseDNS accepts any address in the server's IPv6 subnet;
+// an empty hostname resets the record to the provider's default.
+type ReverseDNSProvider interface {
+	Provider
+
+	GetReverseDNS(ctx context.Context, serverID string) ([]ReverseDNS, error)
+	SetReverseDNS(ctx context.Context, serverID, ip, hostname string) (*ActionStatus, error)
+}
+
+// LabelProvider extends Provider with editing a server's labels. SetLabels
+// replaces the whole set; an empty map removes every label.
+type LabelProvider interface {
+	Provider
+
+	SetLabels(ctx context.Context, serverID string, labels map[string]string) error
+}
+
+// ConsoleProvider extends Provider with remote console access, which works
+// even when the server's network or SSH setup is broken.
+type ConsoleProvider interface {
+	Provider
+
+	RequestConsole(ctx context.Context, serverID string) (*Console, error)
+}
+
 // SSHKeyManager extends Provider with SSH key management operations.
 type SSHKeyManager interface {
 	Provider
@@ -54,3 +194,42 @@ type MetricsProvider interface {
 
 	GetServerMetrics(ctx context.Context, serverID string, types []MetricType, start, end time.Time) (*ServerMetrics, error)
 }
+
+// ConsoleOutputProvider extends Provider with retrieval of a server's
+// serial console / boot output. Providers that expose it implement this so
+// the TUI can show boot logs without an SSH connection.
+type ConsoleOutputProvider interface {
+	Provider
+
+	GetConsoleOutput(ctx context.Context, serverID string) (string, error)
+}
+
+// ServerFilterProvider extends Provider with server listing filtered by
+// the provider's API, so large accounts do not have to transfer every
+// server to match a few.
+type ServerFilterProvider interface {
+	Provider
+
+	ListServersFiltered(ctx context.Context, selector LabelSelector) ([]Server, error)
+}
+
+// ListServersMatching returns the servers whose labels satisfy selector.
+// Filtering happens server-side when p implements ServerFilterProvider and
+// client-side otherwise.
+func ListServersMatching(ctx context.Context, p Provider, selector LabelSelector) ([]Server, error) {
+	if f, ok := p.(ServerFilterProvider); ok {
+		return f.ListServersFiltered(ctx, selector)
+	}
+
+	servers, err := p.ListServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]Server, 0, len(servers))
+	for _, s := range servers {
+		if selector.Matches(s.Labels) {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type listOnlyProvider struct {
+	Provider
+	servers []Server
+}
+
+func (p listOnlyProvider) ListServers(context.Context) ([]Server, error) {
+	return p.servers, nil
+}
+
+type filteringProvider struct {
+	listOnlyProvider
+	gotSelector string
+}
+
+func (p *filteringProvider) ListServersFiltered(_ context.Context, selector LabelSelector) ([]Server, error) {
+	p.gotSelector = selector.String()
+	return p.servers[:1], nil
+}
+
+func TestListServersMatching_FiltersClientSide(t *testing.T) {
+	p := listOnlyProvider{servers: []Server{
+		{ID: "1", Labels: map[string]string{"env": "prod"}},
+		{ID: "2", Labels: map[string]string{"env": "dev"}},
+		{ID: "3"},
+	}}
+	sel, _ := ParseLabelSelector("env=prod")
+
+	got, err := ListServersMatching(context.Background(), p, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Server{{ID: "1", Labels: map[string]string{"env": "prod"}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("servers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListServersMatching_UsesProviderFilter(t *testing.T) {
+	p := &filteringProvider{listOnlyProvider: listOnlyProvider{servers: []Server{{ID: "1"}, {ID: "2"}}}}
+	sel, _ := ParseLabelSelector("role=web,env=prod")
+
+	got, err := ListServersMatching(context.Background(), p, sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.gotSelector != "env=prod,role=web" {
+		t.Errorf("selector = %q, want %q", p.gotSelector, "env=prod,role=web")
+	}
+	if len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("servers = %+v, want the provider's result", got)
+	}
+}
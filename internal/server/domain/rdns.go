@@ -0,0 +1,9 @@
+package domain
+
+// ReverseDNS is the reverse DNS (PTR) record of one of a server's public
+// addresses.
+type ReverseDNS struct {
+	IP string `json:"ip"`
+	// Hostname is the PTR value, "" when the provider's default applies.
+	Hostname string `json:"hostname,omitempty"`
+}
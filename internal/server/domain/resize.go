@@ -0,0 +1,33 @@
+package domain
+
+import "slices"
+
+// ResizeTargets returns the server types a server of type current in
+// region can be resized to while keeping its disk: the same architecture,
+// offered in the region, and at least as much disk, since disks cannot
+// shrink. The current type itself is excluded. When current is not in
+// types, only the region filter applies.
+func ResizeTargets(current, region string, types []ServerTypeSpec) []ServerTypeSpec {
+	var from *ServerTypeSpec
+	for i := range types {
+		if types[i].Name == current {
+			from = &types[i]
+			break
+		}
+	}
+
+	var targets []ServerTypeSpec
+	for _, st := range types {
+		if st.Name == current {
+			continue
+		}
+		if region != "" && len(st.Locations) > 0 && !slices.Contains(st.Locations, region) {
+			continue
+		}
+		if from != nil && (st.Architecture != from.Architecture || st.Disk < from.Disk) {
+			continue
+		}
+		targets = append(targets, st)
+	}
+	return targets
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResizeTargets(t *testing.T) {
+	types := []ServerTypeSpec{
+		{Name: "cx22", Architecture: "x86", Disk: 40, Locations: []string{"fsn1", "nbg1"}},
+		{Name: "cx32", Architecture: "x86", Disk: 80, Locations: []string{"fsn1", "nbg1"}},
+		{Name: "cx42", Architecture: "x86", Disk: 160, Locations: []string{"nbg1"}},
+		{Name: "cx12", Architecture: "x86", Disk: 20, Locations: []string{"fsn1"}},
+		{Name: "cax21", Architecture: "arm", Disk: 80, Locations: []string{"fsn1"}},
+	}
+
+	names := func(specs []ServerTypeSpec) []string {
+		var out []string
+		for _, s := range specs {
+			out = append(out, s.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		current string
+		region  string
+		want    []string
+	}{
+		{"same arch, larger disk, in region", "cx22", "fsn1", []string{"cx32"}},
+		{"other region", "cx22", "nbg1", []string{"cx32", "cx42"}},
+		{"unknown current type", "gone", "fsn1", []string{"cx22", "cx32", "cx12", "cax21"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(ResizeTargets(tt.current, tt.region, types))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ResizeTargets(%q, %q) = %v, want %v", tt.current, tt.region, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// selectorOp is the comparison performed by a LabelRequirement.
+type selectorOp int
+
+const (
+	selectorEquals selectorOp = iota
+	selectorNotEquals
+	selectorExists
+	selectorNotExists
+)
+
+// LabelRequirement is a single clause of a LabelSelector.
+type LabelRequirement struct {
+	Key   string
+	Value string
+	op    selectorOp
+}
+
+// LabelSelector matches servers by their labels. All requirements must
+// match (logical AND).
+type LabelSelector []LabelRequirement
+
+// ParseLabelSelector parses a comma-separated selector expression.
+// Supported clauses:
+//
+//	env=prod     label "env" equals "prod"
+//	env!=prod    label "env" is missing or not "prod"
+//	env          label "env" is present
+//	!env         label "env" is absent
+func ParseLabelSelector(expr string) (LabelSelector, error) {
+	var sel LabelSelector
+	for _, clause := range strings.Split(expr, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+
+		var req LabelRequirement
+		switch {
+		case strings.Contains(clause, "!="):
+			k, v, _ := strings.Cut(clause, "!=")
+			req = LabelRequirement{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v), op: selectorNotEquals}
+		case strings.Contains(clause, "="):
+			k, v, _ := strings.Cut(clause, "=")
+			req = LabelRequirement{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v), op: selectorEquals}
+		case strings.HasPrefix(clause, "!"):
+			req = LabelRequirement{Key: strings.TrimSpace(clause[1:]), op: selectorNotExists}
+		default:
+			req = LabelRequirement{Key: clause, op: selectorExists}
+		}
+
+		if req.Key == "" {
+			return nil, fmt.Errorf("invalid label selector clause %q: missing key", clause)
+		}
+		sel = append(sel, req)
+	}
+
+	if len(sel) == 0 {
+		return nil, fmt.Errorf("label selector %q is empty", expr)
+	}
+	return sel, nil
+}
+
+// Matches reports whether labels satisfy every requirement.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		v, ok := labels[req.Key]
+		switch req.op {
+		case selectorEquals:
+			if !ok || v != req.Value {
+				return false
+			}
+		case selectorNotEquals:
+			if ok && v == req.Value {
+				return false
+			}
+		case selectorExists:
+			if !ok {
+				return false
+			}
+		case selectorNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// String renders the selector in canonical form (clauses sorted by key).
+func (s LabelSelector) String() string {
+	clauses := make([]string, len(s))
+	for i, req := range s {
+		switch req.op {
+		case selectorEquals:
+			clauses[i] = req.Key + "=" + req.Value
+		case selectorNotEquals:
+			clauses[i] = req.Key + "!=" + req.Value
+		case selectorExists:
+			clauses[i] = req.Key
+		case selectorNotExists:
+			clauses[i] = "!" + req.Key
+		}
+	}
+	sort.Strings(clauses)
+	return strings.Join(clauses, ",")
+}
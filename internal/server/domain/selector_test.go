@@ -0,0 +1,55 @@
+package domain
+
+import "testing"
+
+func TestParseLabelSelector_Matches(t *testing.T) {
+	labels := map[string]string{"env": "prod", "role": "web"}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"env=prod", true},
+		{"env=staging", false},
+		{"env=prod,role=web", true},
+		{"env=prod,role=db", false},
+		{"env!=staging", true},
+		{"env!=prod", false},
+		{"missing!=x", true},
+		{"role", true},
+		{"tier", false},
+		{"!tier", true},
+		{"!env", false},
+		{" env = prod , role ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			sel, err := ParseLabelSelector(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseLabelSelector(%q) failed: %v", tt.expr, err)
+			}
+			if got := sel.Matches(labels); got != tt.want {
+				t.Errorf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLabelSelector_Invalid(t *testing.T) {
+	for _, expr := range []string{"", " , ", "=prod", "!", "!=x"} {
+		if _, err := ParseLabelSelector(expr); err == nil {
+			t.Errorf("ParseLabelSelector(%q) expected error", expr)
+		}
+	}
+}
+
+func TestLabelSelector_String(t *testing.T) {
+	sel, err := ParseLabelSelector("role=web, !tier, env!=dev, team")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector failed: %v", err)
+	}
+	if got, want := sel.String(), "!tier,env!=dev,role=web,team"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
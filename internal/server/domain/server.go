@@ -1,23 +1,99 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/ipv6"
+)
 
 // Server represents a virtual server instance across providers
 type Server struct {
 	// Core fields (common across all providers)
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	PublicIPv4  string    `json:"public_ipv4,omitempty"`
-	PublicIPv6  string    `json:"public_ipv6,omitempty"`
-	PrivateIPv4 string    `json:"private_ipv4,omitempty"`
-	Region      string    `json:"region"`
-	ServerType  string    `json:"server_type"`
-	Image       string    `json:"image,omitempty"`
-	Provider    string    `json:"provider"`
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+	PublicIPv4 string    `json:"public_ipv4,omitempty"`
+	PublicIPv6 string    `json:"public_ipv6,omitempty"`
+	// PublicIPv6Network is the full IPv6 subnet routed to the server in
+	// CIDR notation (e.g. "2001:db8:1:2::/64"), when the provider assigns one.
+	PublicIPv6Network string `json:"public_ipv6_network,omitempty"`
+	PrivateIPv4       string `json:"private_ipv4,omitempty"`
+	Region            string `json:"region"`
+	ServerType        string `json:"server_type"`
+	Image             string `json:"image,omitempty"`
+	Provider          string `json:"provider"`
+
+	// Labels are user-defined key/value pairs attached to the server.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interruptible is true for spot instances the provider may reclaim
+	// at any time.
+	Interruptible bool `json:"interruptible,omitempty"`
+
+	// Backups is true when the provider takes automatic backups of the
+	// server.
+	Backups bool `json:"backups,omitempty"`
+
+	// Attached lists resources bound to the server (volumes, floating IPs,
+	// firewalls, load balancers, private networks), when the provider
+	// reports them.
+	Attached []AttachedResource `json:"attached,omitempty"`
 
 	// Metadata holds provider-specific fields
 	// Examples: floating_ips, firewalls, volumes, tags, etc.
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
+
+// Kinds of resources that can be attached to a server.
+const (
+	AttachedVolume       = "volume"
+	AttachedFloatingIP   = "floating_ip"
+	AttachedFirewall     = "firewall"
+	AttachedLoadBalancer = "load_balancer"
+	AttachedNetwork      = "network"
+)
+
+// AttachedResource is a provider resource bound to a server. Name is empty
+// when the provider only reports the resource by ID; Detail carries a short
+// extra value such as the server's address inside a private network.
+type AttachedResource struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// ReachableIPv6 returns the IPv6 address to connect to. When the provider
+// reports a subnet, this is its ::1 address (the one images configure by
+// default); otherwise it is PublicIPv6 as reported.
+func (s Server) ReachableIPv6() string {
+	if s.PublicIPv6Network != "" {
+		if addr, err := ipv6.DefaultAddress(s.PublicIPv6Network); err == nil {
+			return addr.String()
+		}
+	}
+	return s.PublicIPv6
+}
+
+// AttachedOfKind returns the attached resources of the given kind.
+func (s Server) AttachedOfKind(kind string) []AttachedResource {
+	var out []AttachedResource
+	for _, r := range s.Attached {
+		if r.Kind == kind {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// IsStopped reports whether the server is powered off.
+func (s Server) IsStopped() bool {
+	return s.Status == "off" || s.Status == "stopped"
+}
+
+// IsDeleting reports whether the provider is in the middle of deleting
+// the server.
+func (s Server) IsDeleting() bool {
+	return s.Status == "deleting"
+}
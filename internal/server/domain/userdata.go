@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+)
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// EncodeUserData prepares data for CreateServerOpts.UserData on a provider
+// that accepts at most limit bytes (0 means no limit). Text that fits is
+// sent unchanged. Anything larger, or binary, is gzip-compressed and
+// base64-encoded, which cloud-init decodes before use. Data that is
+// already gzip-compressed is only base64-encoded. It is an error if the
+// encoded form still exceeds limit.
+func EncodeUserData(data []byte, limit int) (string, error) {
+	fits := limit <= 0 || len(data) <= limit
+	if fits && utf8.Valid(data) {
+		return string(data), nil
+	}
+
+	compressed := data
+	if !bytes.HasPrefix(data, gzipMagic) {
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if _, err := zw.Write(data); err != nil {
+			return "", fmt.Errorf("failed to compress user data: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return "", fmt.Errorf("failed to compress user data: %w", err)
+		}
+		compressed = buf.Bytes()
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(compressed)
+	if limit > 0 && len(encoded) > limit {
+		return "", fmt.Errorf("user data is %d bytes (%d compressed and encoded), over the provider's limit of %d bytes",
+			len(data), len(encoded), limit)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func decodeUserData(t *testing.T, encoded string) []byte {
+	t.Helper()
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("expected base64, got error: %v", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("expected gzip, got error: %v", err)
+	}
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("failed to decompress: %v", err)
+	}
+	return out
+}
+
+func TestEncodeUserData_SmallTextUnchanged(t *testing.T) {
+	data := "#cloud-config\npackages: [nginx]\n"
+	got, err := EncodeUserData([]byte(data), 32*1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != data {
+		t.Errorf("expected data unchanged, got %q", got)
+	}
+}
+
+func TestEncodeUserData_CompressesWhenOverLimit(t *testing.T) {
+	data := []byte("#cloud-config\n" + strings.Repeat("runcmd: [echo hello]\n", 2000))
+	got, err := EncodeUserData(data, 32*1024)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) > 32*1024 {
+		t.Errorf("encoded size %d exceeds the limit", len(got))
+	}
+	if !bytes.Equal(decodeUserData(t, got), data) {
+		t.Error("round trip did not return the original data")
+	}
+}
+
+func TestEncodeUserData_EncodesBinary(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte("#!/bin/sh\necho hi\n"))
+	zw.Close()
+
+	got, err := EncodeUserData(buf.Bytes(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Already compressed data is not compressed twice.
+	if string(decodeUserData(t, got)) != "#!/bin/sh\necho hi\n" {
+		t.Errorf("unexpected round trip for pre-compressed data")
+	}
+}
+
+func TestEncodeUserData_TooLarge(t *testing.T) {
+	// Random data does not compress.
+	rng := rand.New(rand.NewPCG(1, 2))
+	data := make([]byte, 4096)
+	for i := range data {
+		data[i] = byte(rng.UintN(256))
+	}
+	if _, err := EncodeUserData(data, 1024); err == nil || !strings.Contains(err.Error(), "limit of 1024 bytes") {
+		t.Errorf("expected a size error, got %v", err)
+	}
+}
@@ -0,0 +1,589 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
+)
+
+// Compile-time checks that DigitalOceanProvider satisfies the required interfaces.
+var _ domain.CatalogProvider = (*DigitalOceanProvider)(nil)
+var _ domain.SSHKeyManager = (*DigitalOceanProvider)(nil)
+var _ domain.ActionPoller = (*DigitalOceanProvider)(nil)
+var _ domain.RebootProvider = (*DigitalOceanProvider)(nil)
+var _ domain.CustomImageProvider = (*DigitalOceanProvider)(nil)
+var _ domain.ResizeProvider = (*DigitalOceanProvider)(nil)
+var _ domain.UserDataLimiter = (*DigitalOceanProvider)(nil)
+
+const (
+	digitalOceanEndpoint = "https://api.digitalocean.com/v2"
+	digitalOceanPageSize = 200
+
+	// digitalOceanUserDataLimit is the largest user data a droplet accepts.
+	digitalOceanUserDataLimit = 64 * 1024
+)
+
+// DigitalOceanProvider implements domain.Provider using the DigitalOcean
+// API v2. Droplets map to servers; droplet tags of the form "key:value"
+// map to labels.
+type DigitalOceanProvider struct {
+	endpoint    string
+	token       string
+	httpClient  *http.Client
+	cache       *cache.Cache
+	retryConfig retry.Config
+}
+
+// NewDigitalOceanProvider creates a DigitalOceanProvider authenticated
+// with the given API token.
+func NewDigitalOceanProvider(token string) *DigitalOceanProvider {
+	return &DigitalOceanProvider{
+		endpoint:    digitalOceanEndpoint,
+		token:       token,
+		httpClient:  &http.Client{Transport: usagestats.Transport("digitalocean", nil)},
+		cache:       cache.NewDefault(),
+		retryConfig: retry.DefaultConfig(),
+	}
+}
+
+// RegisterDigitalOcean registers the DigitalOcean provider factory with the global registry.
+func RegisterDigitalOcean() {
+	Register("digitalocean", func(store auth.Store) (domain.Provider, error) {
+		token, err := store.GetToken("digitalocean")
+		if err != nil {
+			return nil, fmt.Errorf("digitalocean auth: %w", err)
+		}
+
+		provider := NewDigitalOceanProvider(token)
+		url, client, ok, err := endpointOverride("digitalocean")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			provider.endpoint = strings.TrimSuffix(url, "/")
+			provider.httpClient = client
+		}
+		return provider, nil
+	})
+}
+
+func (d *DigitalOceanProvider) GetDisplayName() string {
+	return "DigitalOcean"
+}
+
+// UserDataLimit returns the 64 KiB DigitalOcean allows for user data.
+func (d *DigitalOceanProvider) UserDataLimit() int {
+	return digitalOceanUserDataLimit
+}
+
+// --- API client ---
+
+// doAPIError is an error response from the DigitalOcean API. It unwraps
+// to the matching domain sentinel error, if any.
+type doAPIError struct {
+	StatusCode int
+	ID         string `json:"id"`
+	Message    string `json:"message"`
+	// Request is the ID DigitalOcean assigned to the failed request, taken
+	// from the body or the X-Request-Id header.
+	Request string `json:"request_id"`
+}
+
+func (e *doAPIError) Error() string {
+	msg := fmt.Sprintf("digitalocean: HTTP %d", e.StatusCode)
+	if e.Message != "" {
+		msg = fmt.Sprintf("digitalocean: %s (HTTP %d)", e.Message, e.StatusCode)
+	}
+	if e.Request != "" {
+		msg += " (request ID " + e.Request + ")"
+	}
+	return msg
+}
+
+// RequestID reports the request ID for domain.RequestID.
+func (e *doAPIError) RequestID() string { return e.Request }
+
+func (e *doAPIError) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return domain.ErrUnauthorized
+	case http.StatusNotFound:
+		return domain.ErrNotFound
+	case http.StatusTooManyRequests:
+		return domain.ErrRateLimited
+	case http.StatusConflict, http.StatusUnprocessableEntity:
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func isDigitalOceanRetryable(err error) bool {
+	if retry.IsRetryable(err) {
+		return true
+	}
+	var apiErr *doAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return false
+}
+
+// do sends a request to the API, retrying transient failures, and decodes
+// the JSON response into out (which may be nil).
+func (d *DigitalOceanProvider) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	return retry.Do(ctx, d.retryConfig, isDigitalOceanRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(reqCtx, method, d.endpoint+path, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+d.token)
+		req.Header.Set("Accept", "application/json")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := d.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= 400 {
+			apiErr := &doAPIError{StatusCode: resp.StatusCode}
+			_ = json.NewDecoder(resp.Body).Decode(apiErr)
+			if apiErr.Request == "" {
+				apiErr.Request = resp.Header.Get("X-Request-Id")
+			}
+			return apiErr
+		}
+		if out == nil || resp.StatusCode == http.StatusNoContent {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(out)
+	})
+}
+
+// doLinks is the pagination block of a list response.
+type doLinks struct {
+	Pages struct {
+		Next string `json:"next"`
+	} `json:"pages"`
+}
+
+// listAll fetches every page of a list endpoint. decode receives each
+// page's raw body and returns its links.
+func (d *DigitalOceanProvider) listAll(ctx context.Context, path string, decode func(json.RawMessage) (doLinks, error)) error {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	for page := 1; ; page++ {
+		var raw json.RawMessage
+		pagePath := fmt.Sprintf("%s%sper_page=%d&page=%d", path, sep, digitalOceanPageSize, page)
+		if err := d.do(ctx, http.MethodGet, pagePath, nil, &raw); err != nil {
+			return err
+		}
+		links, err := decode(raw)
+		if err != nil {
+			return err
+		}
+		if links.Pages.Next == "" {
+			return nil
+		}
+	}
+}
+
+// --- Provider implementation ---
+
+type doDroplet struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	SizeSlug  string    `json:"size_slug"`
+	Region    struct {
+		Slug string `json:"slug"`
+	} `json:"region"`
+	Image struct {
+		Slug         string `json:"slug"`
+		Name         string `json:"name"`
+		Distribution string `json:"distribution"`
+	} `json:"image"`
+	Size struct {
+		Slug string `json:"slug"`
+	} `json:"size"`
+	Networks struct {
+		V4 []doNetworkAddress `json:"v4"`
+		V6 []doNetworkAddress `json:"v6"`
+	} `json:"networks"`
+	Tags      []string `json:"tags"`
+	VolumeIDs []string `json:"volume_ids"`
+	VPCUUID   string   `json:"vpc_uuid"`
+}
+
+type doNetworkAddress struct {
+	IPAddress string `json:"ip_address"`
+	Type      string `json:"type"` // "public" or "private"
+}
+
+type doAction struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"` // "in-progress", "completed" or "errored"
+	Type   string `json:"type"`
+}
+
+// ListServers retrieves all droplets from the DigitalOcean API.
+func (d *DigitalOceanProvider) ListServers(ctx context.Context) ([]domain.Server, error) {
+	var servers []domain.Server
+	err := d.listAll(ctx, "/droplets", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			Droplets []doDroplet `json:"droplets"`
+			Links    doLinks     `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		for _, droplet := range page.Droplets {
+			servers = append(servers, toDomainDroplet(droplet))
+		}
+		return page.Links, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+	if servers == nil {
+		servers = []domain.Server{}
+	}
+
+	return servers, nil
+}
+
+// GetServer retrieves a single droplet by its ID.
+func (d *DigitalOceanProvider) GetServer(ctx context.Context, id string) (*domain.Server, error) {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	var resp struct {
+		Droplet doDroplet `json:"droplet"`
+	}
+	if err := d.do(ctx, http.MethodGet, "/droplets/"+id, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+
+	server := toDomainDroplet(resp.Droplet)
+	return &server, nil
+}
+
+// CreateServer creates a droplet. SSH keys may be given by name, ID or
+// fingerprint; labels become "key:value" tags. DigitalOcean droplets
+// always get a public IPv4 address, and at most one VPC can be attached.
+func (d *DigitalOceanProvider) CreateServer(ctx context.Context, opts domain.CreateServerOpts) (*domain.Server, error) {
+	if opts.EnableIPv4 != nil && !*opts.EnableIPv4 {
+		return nil, fmt.Errorf("failed to create server: digitalocean droplets always have a public IPv4 address")
+	}
+	if len(opts.Networks) > 0 {
+		return nil, fmt.Errorf("failed to create server: private networks are not supported for digitalocean")
+	}
+	if opts.Interruptible {
+		return nil, fmt.Errorf("failed to create server: digitalocean does not offer interruptible servers")
+	}
+
+	req := map[string]interface{}{
+		"name":  opts.Name,
+		"size":  opts.ServerType,
+		"image": opts.Image,
+	}
+	// Snapshots and backups are referred to by their numeric ID.
+	if id, err := strconv.ParseInt(opts.Image, 10, 64); err == nil {
+		req["image"] = id
+	}
+	if opts.Location != "" {
+		req["region"] = opts.Location
+	}
+	if opts.UserData != "" {
+		req["user_data"] = opts.UserData
+	}
+	if opts.EnableIPv6 != nil {
+		req["ipv6"] = *opts.EnableIPv6
+	}
+	if tags := labelsToTags(opts.Labels); len(tags) > 0 {
+		req["tags"] = tags
+	}
+	if len(opts.SSHKeyIdentifiers) > 0 {
+		keys, err := d.resolveSSHKeys(ctx, opts.SSHKeyIdentifiers)
+		if err != nil {
+			return nil, err
+		}
+		req["ssh_keys"] = keys
+	}
+
+	var resp struct {
+		Droplet doDroplet `json:"droplet"`
+	}
+	if err := d.do(ctx, http.MethodPost, "/droplets", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create server: %w", err)
+	}
+
+	server := toDomainDroplet(resp.Droplet)
+	return &server, nil
+}
+
+// resolveSSHKeys maps key names, IDs or fingerprints to the IDs the
+// create endpoint accepts.
+func (d *DigitalOceanProvider) resolveSSHKeys(ctx context.Context, identifiers []string) ([]int64, error) {
+	keys, err := d.listSSHKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve SSH keys: %w", err)
+	}
+
+	ids := make([]int64, 0, len(identifiers))
+	for _, ident := range identifiers {
+		found := false
+		for _, key := range keys {
+			if strconv.FormatInt(key.ID, 10) == ident || key.Fingerprint == ident || strings.EqualFold(key.Name, ident) {
+				ids = append(ids, key.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("SSH key %q not found", ident)
+		}
+	}
+	return ids, nil
+}
+
+// DeleteServer removes a droplet by its ID.
+func (d *DigitalOceanProvider) DeleteServer(ctx context.Context, id string) error {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	if err := d.do(ctx, http.MethodDelete, "/droplets/"+id, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete server: %w", err)
+	}
+	return nil
+}
+
+// StartServer powers on a droplet and returns the initial action status
+// so callers can poll for completion.
+func (d *DigitalOceanProvider) StartServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := d.dropletAction(ctx, id, "power_on")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start server: %w", err)
+	}
+	return action, nil
+}
+
+// StopServer gracefully shuts down a droplet and returns the initial
+// action status so callers can poll for completion.
+func (d *DigitalOceanProvider) StopServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := d.dropletAction(ctx, id, "shutdown")
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop server: %w", err)
+	}
+	return action, nil
+}
+
+// RebootServer gracefully reboots a droplet and returns the initial
+// action status so callers can poll for completion.
+func (d *DigitalOceanProvider) RebootServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := d.dropletAction(ctx, id, "reboot")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reboot server: %w", err)
+	}
+	return action, nil
+}
+
+// ResetServer power-cycles a droplet and returns the initial action
+// status so callers can poll for completion.
+func (d *DigitalOceanProvider) ResetServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := d.dropletAction(ctx, id, "power_cycle")
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset server: %w", err)
+	}
+	return action, nil
+}
+
+// ResizeServer changes the size of a powered-off droplet and returns the
+// initial action status so callers can poll for completion.
+func (d *DigitalOceanProvider) ResizeServer(ctx context.Context, id, serverType string, upgradeDisk bool) (*domain.ActionStatus, error) {
+	action, err := d.postDropletAction(ctx, id, map[string]any{"type": "resize", "size": serverType, "disk": upgradeDisk})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize server: %w", err)
+	}
+	return action, nil
+}
+
+func (d *DigitalOceanProvider) dropletAction(ctx context.Context, id, actionType string) (*domain.ActionStatus, error) {
+	return d.postDropletAction(ctx, id, map[string]any{"type": actionType})
+}
+
+func (d *DigitalOceanProvider) postDropletAction(ctx context.Context, id string, req map[string]any) (*domain.ActionStatus, error) {
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	var resp struct {
+		Action doAction `json:"action"`
+	}
+	if err := d.do(ctx, http.MethodPost, "/droplets/"+id+"/actions", req, &resp); err != nil {
+		return nil, err
+	}
+	return toDomainDropletAction(resp.Action), nil
+}
+
+// PollAction retrieves the current status of an in-flight action.
+func (d *DigitalOceanProvider) PollAction(ctx context.Context, actionID string) (*domain.ActionStatus, error) {
+	if _, err := strconv.ParseInt(actionID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid action ID %q: %w", actionID, err)
+	}
+
+	var resp struct {
+		Action doAction `json:"action"`
+	}
+	if err := d.do(ctx, http.MethodGet, "/actions/"+url.PathEscape(actionID), nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to poll action: %w", err)
+	}
+	return toDomainDropletAction(resp.Action), nil
+}
+
+// --- Conversion ---
+
+// dropletStatuses maps droplet statuses to the values the rest of vpsm
+// uses for Hetzner servers.
+var dropletStatuses = map[string]string{
+	"new":     "initializing",
+	"active":  "running",
+	"off":     "off",
+	"archive": "archived",
+}
+
+func toDomainDroplet(d doDroplet) domain.Server {
+	status, ok := dropletStatuses[d.Status]
+	if !ok {
+		status = d.Status
+	}
+
+	server := domain.Server{
+		ID:         strconv.FormatInt(d.ID, 10),
+		Name:       d.Name,
+		Status:     status,
+		CreatedAt:  d.CreatedAt,
+		Region:     d.Region.Slug,
+		ServerType: d.SizeSlug,
+		Provider:   "digitalocean",
+		Labels:     tagsToLabels(d.Tags),
+		Metadata:   map[string]interface{}{"digitalocean_id": d.ID},
+	}
+	if server.ServerType == "" {
+		server.ServerType = d.Size.Slug
+	}
+
+	server.Image = d.Image.Slug
+	if server.Image == "" && d.Image.Name != "" {
+		server.Image = strings.TrimSpace(d.Image.Distribution + " " + d.Image.Name)
+	}
+
+	for _, addr := range d.Networks.V4 {
+		switch addr.Type {
+		case "public":
+			if server.PublicIPv4 == "" {
+				server.PublicIPv4 = addr.IPAddress
+			}
+		case "private":
+			if server.PrivateIPv4 == "" {
+				server.PrivateIPv4 = addr.IPAddress
+			}
+		}
+	}
+	for _, addr := range d.Networks.V6 {
+		if addr.Type == "public" && server.PublicIPv6 == "" {
+			server.PublicIPv6 = addr.IPAddress
+		}
+	}
+
+	for _, id := range d.VolumeIDs {
+		server.Attached = append(server.Attached, domain.AttachedResource{Kind: domain.AttachedVolume, ID: id})
+	}
+	if d.VPCUUID != "" {
+		server.Attached = append(server.Attached, domain.AttachedResource{Kind: domain.AttachedNetwork, ID: d.VPCUUID, Detail: server.PrivateIPv4})
+	}
+
+	return server
+}
+
+func toDomainDropletAction(a doAction) *domain.ActionStatus {
+	status := &domain.ActionStatus{
+		ID:      strconv.FormatInt(a.ID, 10),
+		Command: a.Type,
+	}
+	switch a.Status {
+	case "completed":
+		status.Status = domain.ActionStatusSuccess
+		status.Progress = 100
+	case "errored":
+		status.Status = domain.ActionStatusError
+		status.ErrorMessage = fmt.Sprintf("%s failed", a.Type)
+	default:
+		status.Status = domain.ActionStatusRunning
+	}
+	return status
+}
+
+// tagsToLabels turns "key:value" tags into labels. Tags without a colon
+// become labels with an empty value.
+func tagsToLabels(tags []string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	labels := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		key, value, _ := strings.Cut(tag, ":")
+		labels[key] = value
+	}
+	return labels
+}
+
+// labelsToTags is the inverse of tagsToLabels.
+func labelsToTags(labels map[string]string) []string {
+	tags := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if value == "" {
+			tags = append(tags, key)
+			continue
+		}
+		tags = append(tags, key+":"+value)
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,299 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// --- CatalogProvider implementation ---
+
+type doRegion struct {
+	Slug      string `json:"slug"`
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+}
+
+type doSize struct {
+	Slug         string   `json:"slug"`
+	Description  string   `json:"description"`
+	Memory       int      `json:"memory"` // in MB
+	VCPUs        int      `json:"vcpus"`
+	Disk         int      `json:"disk"` // in GB
+	PriceMonthly float64  `json:"price_monthly"`
+	PriceHourly  float64  `json:"price_hourly"`
+	Regions      []string `json:"regions"`
+	Available    bool     `json:"available"`
+}
+
+type doImage struct {
+	ID            int64     `json:"id"`
+	Name          string    `json:"name"`
+	Slug          string    `json:"slug"`
+	Distribution  string    `json:"distribution"`
+	Status        string    `json:"status"`
+	Type          string    `json:"type"`
+	SizeGigabytes float64   `json:"size_gigabytes"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type doSSHKey struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ListLocations retrieves the regions droplets can currently be created in.
+func (d *DigitalOceanProvider) ListLocations(ctx context.Context) ([]domain.Location, error) {
+	if d.cache != nil {
+		var cached []domain.Location
+		hit, err := d.cache.Get(doCatalogCacheKey("locations"), defaultCatalogCacheTTL, &cached)
+		if err == nil && hit {
+			return cached, nil
+		}
+	}
+
+	locations := []domain.Location{}
+	err := d.listAll(ctx, "/regions", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			Regions []doRegion `json:"regions"`
+			Links   doLinks    `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		for _, r := range page.Regions {
+			if r.Available {
+				locations = append(locations, domain.Location{
+					ID:          r.Slug,
+					Name:        r.Slug,
+					Description: r.Name,
+					City:        r.Name,
+				})
+			}
+		}
+		return page.Links, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	if d.cache != nil {
+		_ = d.cache.Set(doCatalogCacheKey("locations"), locations)
+	}
+
+	return locations, nil
+}
+
+// ListServerTypes retrieves the available droplet sizes.
+func (d *DigitalOceanProvider) ListServerTypes(ctx context.Context) ([]domain.ServerTypeSpec, error) {
+	if d.cache != nil {
+		var cached []domain.ServerTypeSpec
+		hit, err := d.cache.Get(doCatalogCacheKey("server_types"), defaultCatalogCacheTTL, &cached)
+		if err == nil && hit {
+			return cached, nil
+		}
+	}
+
+	serverTypes := []domain.ServerTypeSpec{}
+	err := d.listAll(ctx, "/sizes", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			Sizes []doSize `json:"sizes"`
+			Links doLinks  `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		for _, s := range page.Sizes {
+			if s.Available {
+				serverTypes = append(serverTypes, toDomainSize(s))
+			}
+		}
+		return page.Links, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server types: %w", err)
+	}
+
+	if d.cache != nil {
+		_ = d.cache.Set(doCatalogCacheKey("server_types"), serverTypes)
+	}
+
+	return serverTypes, nil
+}
+
+// ListImages retrieves the public distribution images.
+func (d *DigitalOceanProvider) ListImages(ctx context.Context) ([]domain.ImageSpec, error) {
+	if d.cache != nil {
+		var cached []domain.ImageSpec
+		hit, err := d.cache.Get(doCatalogCacheKey("images"), defaultCatalogCacheTTL, &cached)
+		if err == nil && hit {
+			return cached, nil
+		}
+	}
+
+	images := []domain.ImageSpec{}
+	err := d.listAll(ctx, "/images?type=distribution", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			Images []doImage `json:"images"`
+			Links  doLinks   `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		for _, img := range page.Images {
+			if img.Status == "" || img.Status == "available" {
+				images = append(images, toDomainDOImage(img))
+			}
+		}
+		return page.Links, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	if d.cache != nil {
+		_ = d.cache.Set(doCatalogCacheKey("images"), images)
+	}
+
+	return images, nil
+}
+
+// ListCustomImages retrieves the account's droplet snapshots and
+// backups, newest first. DigitalOcean does not report which droplet an
+// image was taken from.
+func (d *DigitalOceanProvider) ListCustomImages(ctx context.Context) ([]domain.ImageSpec, error) {
+	images := []domain.ImageSpec{}
+	err := d.listAll(ctx, "/images?private=true", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			Images []doImage `json:"images"`
+			Links  doLinks   `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		for _, img := range page.Images {
+			if (img.Type == "snapshot" || img.Type == "backup") && (img.Status == "" || img.Status == "available") {
+				images = append(images, toDomainDOCustomImage(img))
+			}
+		}
+		return page.Links, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots and backups: %w", err)
+	}
+
+	sort.SliceStable(images, func(i, j int) bool { return images[i].CreatedAt.After(images[j].CreatedAt) })
+	return images, nil
+}
+
+// ListSSHKeys retrieves all SSH keys on the account.
+func (d *DigitalOceanProvider) ListSSHKeys(ctx context.Context) ([]domain.SSHKeySpec, error) {
+	doKeys, err := d.listSSHKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys: %w", err)
+	}
+
+	keys := make([]domain.SSHKeySpec, 0, len(doKeys))
+	for _, k := range doKeys {
+		keys = append(keys, toDomainDOSSHKey(k))
+	}
+
+	return keys, nil
+}
+
+func (d *DigitalOceanProvider) listSSHKeys(ctx context.Context) ([]doSSHKey, error) {
+	var keys []doSSHKey
+	err := d.listAll(ctx, "/account/keys", func(raw json.RawMessage) (doLinks, error) {
+		var page struct {
+			SSHKeys []doSSHKey `json:"ssh_keys"`
+			Links   doLinks    `json:"links"`
+		}
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return doLinks{}, err
+		}
+		keys = append(keys, page.SSHKeys...)
+		return page.Links, nil
+	})
+	return keys, err
+}
+
+// --- SSHKeyManager implementation ---
+
+// CreateSSHKey uploads a new SSH key to the account.
+func (d *DigitalOceanProvider) CreateSSHKey(ctx context.Context, name, publicKey string) (*domain.SSHKeySpec, error) {
+	var resp struct {
+		SSHKey doSSHKey `json:"ssh_key"`
+	}
+	req := map[string]string{"name": name, "public_key": publicKey}
+	if err := d.do(ctx, http.MethodPost, "/account/keys", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to create SSH key: %w", err)
+	}
+
+	keySpec := toDomainDOSSHKey(resp.SSHKey)
+	return &keySpec, nil
+}
+
+// --- Conversion ---
+
+func toDomainSize(s doSize) domain.ServerTypeSpec {
+	return domain.ServerTypeSpec{
+		ID:           s.Slug,
+		Name:         s.Slug,
+		Description:  s.Description,
+		Cores:        s.VCPUs,
+		Memory:       float64(s.Memory) / 1024,
+		Disk:         s.Disk,
+		Architecture: "x86",
+		PriceMonthly: money.Money{Amount: s.PriceMonthly, Currency: "USD"},
+		PriceHourly:  money.Money{Amount: s.PriceHourly, Currency: "USD"},
+		Locations:    uniqueStrings(s.Regions),
+	}
+}
+
+func toDomainDOImage(img doImage) domain.ImageSpec {
+	name := img.Slug
+	if name == "" {
+		name = strconv.FormatInt(img.ID, 10)
+	}
+	return domain.ImageSpec{
+		ID:           strconv.FormatInt(img.ID, 10),
+		Name:         name,
+		Description:  strings.TrimSpace(img.Distribution + " " + img.Name),
+		Type:         "system",
+		OSFlavor:     strings.ToLower(img.Distribution),
+		Architecture: "x86",
+	}
+}
+
+func toDomainDOCustomImage(img doImage) domain.ImageSpec {
+	return domain.ImageSpec{
+		ID:           strconv.FormatInt(img.ID, 10),
+		Description:  img.Name,
+		Type:         img.Type,
+		OSFlavor:     strings.ToLower(img.Distribution),
+		Architecture: "x86",
+		SizeGB:       img.SizeGigabytes,
+		CreatedAt:    img.CreatedAt,
+	}
+}
+
+func toDomainDOSSHKey(k doSSHKey) domain.SSHKeySpec {
+	return domain.SSHKeySpec{
+		ID:          strconv.FormatInt(k.ID, 10),
+		Name:        k.Name,
+		Fingerprint: k.Fingerprint,
+	}
+}
+
+func doCatalogCacheKey(resource string) string {
+	return "catalog_digitalocean_" + resource
+}
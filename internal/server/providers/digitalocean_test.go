@@ -0,0 +1,450 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/retry"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestDigitalOceanProvider creates a DigitalOceanProvider that talks
+// to a test server and caches into a temp dir.
+func newTestDigitalOceanProvider(t *testing.T, serverURL string) *DigitalOceanProvider {
+	t.Helper()
+	provider := NewDigitalOceanProvider("test-token")
+	provider.endpoint = serverURL
+	provider.httpClient = http.DefaultClient
+	provider.cache = cache.New(t.TempDir())
+	provider.retryConfig = retry.Config{MaxAttempts: 3}
+	return provider
+}
+
+// testDropletJSON builds a DigitalOcean API droplet object.
+func testDropletJSON(id int, name, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         id,
+		"name":       name,
+		"status":     status,
+		"created_at": "2024-06-15T12:00:00Z",
+		"size_slug":  "s-1vcpu-1gb",
+		"region":     map[string]interface{}{"slug": "fra1", "name": "Frankfurt 1"},
+		"image":      map[string]interface{}{"slug": "ubuntu-24-04-x64", "name": "24.04 (LTS) x64", "distribution": "Ubuntu"},
+		"networks": map[string]interface{}{
+			"v4": []interface{}{
+				map[string]interface{}{"ip_address": "10.110.0.2", "type": "private"},
+				map[string]interface{}{"ip_address": "203.0.113.7", "type": "public"},
+			},
+			"v6": []interface{}{
+				map[string]interface{}{"ip_address": "2a03:b0c0:3:d0::1", "type": "public"},
+			},
+		},
+		"tags":       []interface{}{"env:prod", "web"},
+		"volume_ids": []interface{}{"506f78a4-e098-11e5-ad9f-000f53306ae1"},
+		"vpc_uuid":   "5a4981aa-9653-4bd1-bef5-d6bff52042e4",
+	}
+}
+
+func TestDigitalOceanListServers_Paginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/droplets" {
+			t.Errorf("expected path /droplets, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want Bearer test-token", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "1" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"droplets": []interface{}{testDropletJSON(1, "web", "active")},
+				"links":    map[string]interface{}{"pages": map[string]interface{}{"next": "https://api.digitalocean.com/v2/droplets?page=2"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"droplets": []interface{}{testDropletJSON(2, "db", "off")},
+			"links":    map[string]interface{}{},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	servers, err := newTestDigitalOceanProvider(t, srv.URL).ListServers(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(servers) != 2 {
+		t.Fatalf("expected 2 servers across pages, got %d", len(servers))
+	}
+
+	want := domain.Server{
+		ID:          "1",
+		Name:        "web",
+		Status:      "running",
+		CreatedAt:   time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+		PublicIPv4:  "203.0.113.7",
+		PublicIPv6:  "2a03:b0c0:3:d0::1",
+		PrivateIPv4: "10.110.0.2",
+		Region:      "fra1",
+		ServerType:  "s-1vcpu-1gb",
+		Image:       "ubuntu-24-04-x64",
+		Provider:    "digitalocean",
+		Labels:      map[string]string{"env": "prod", "web": ""},
+		Attached: []domain.AttachedResource{
+			{Kind: domain.AttachedVolume, ID: "506f78a4-e098-11e5-ad9f-000f53306ae1"},
+			{Kind: domain.AttachedNetwork, ID: "5a4981aa-9653-4bd1-bef5-d6bff52042e4", Detail: "10.110.0.2"},
+		},
+		Metadata: map[string]interface{}{"digitalocean_id": int64(1)},
+	}
+	if diff := cmp.Diff(want, servers[0]); diff != "" {
+		t.Errorf("server[0] mismatch (-want +got):\n%s", diff)
+	}
+	if servers[1].Status != "off" || !servers[1].IsStopped() {
+		t.Errorf("server[1].Status = %q, want off", servers[1].Status)
+	}
+}
+
+func TestDigitalOceanGetServer_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "not_found", "message": "The resource you requested could not be found."})
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestDigitalOceanProvider(t, srv.URL).GetServer(context.Background(), "42")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDigitalOceanListServers_Unauthorized(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "Unauthorized", "message": "Unable to authenticate you"})
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestDigitalOceanProvider(t, srv.URL).ListServers(context.Background())
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestDigitalOceanListServers_ErrorIncludesRequestID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "4c1a7e2b-0d9f-4a55-9d1e-5f2c3b6a7d80")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "not_found", "message": "The resource you were accessing could not be found."})
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestDigitalOceanProvider(t, srv.URL).ListServers(context.Background())
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if got := domain.RequestID(err); got != "4c1a7e2b-0d9f-4a55-9d1e-5f2c3b6a7d80" {
+		t.Errorf("RequestID = %q", got)
+	}
+	if !strings.Contains(err.Error(), "request ID 4c1a7e2b") {
+		t.Errorf("expected the rendered error to include the request ID, got %v", err)
+	}
+}
+
+func TestDigitalOceanListServers_RetriesOnServerError(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"droplets": []interface{}{}})
+	}))
+	t.Cleanup(srv.Close)
+
+	if _, err := newTestDigitalOceanProvider(t, srv.URL).ListServers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 API calls, got %d", calls)
+	}
+}
+
+func TestDigitalOceanCreateServer_RequestBody(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/account/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"ssh_keys": []interface{}{map[string]interface{}{"id": 512, "name": "laptop", "fingerprint": "aa:bb"}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/droplets":
+			json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(map[string]interface{}{"droplet": testDropletJSON(3, "app", "new")})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	server, err := newTestDigitalOceanProvider(t, srv.URL).CreateServer(context.Background(), domain.CreateServerOpts{
+		Name:              "app",
+		Image:             "ubuntu-24-04-x64",
+		ServerType:        "s-1vcpu-1gb",
+		Location:          "fra1",
+		SSHKeyIdentifiers: []string{"laptop"},
+		Labels:            map[string]string{"env": "prod"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if server.Status != "initializing" {
+		t.Errorf("Status = %q, want initializing", server.Status)
+	}
+
+	want := map[string]interface{}{
+		"name":     "app",
+		"image":    "ubuntu-24-04-x64",
+		"size":     "s-1vcpu-1gb",
+		"region":   "fra1",
+		"ssh_keys": []interface{}{float64(512)},
+		"tags":     []interface{}{"env:prod"},
+	}
+	if diff := cmp.Diff(want, body); diff != "" {
+		t.Errorf("request body mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDigitalOceanCreateServer_RejectsDisabledIPv4(t *testing.T) {
+	off := false
+	provider := newTestDigitalOceanProvider(t, "http://unused.invalid")
+	if _, err := provider.CreateServer(context.Background(), domain.CreateServerOpts{Name: "a", EnableIPv4: &off}); err == nil {
+		t.Fatal("expected an error when IPv4 is disabled")
+	}
+}
+
+func TestDigitalOceanStopServer_PollsAction(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/droplets/7/actions":
+			var req map[string]string
+			json.NewDecoder(r.Body).Decode(&req)
+			if req["type"] != "shutdown" {
+				t.Errorf("action type = %q, want shutdown", req["type"])
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"action": map[string]interface{}{"id": 99, "status": "in-progress", "type": "shutdown"}})
+		case r.URL.Path == "/actions/99":
+			json.NewEncoder(w).Encode(map[string]interface{}{"action": map[string]interface{}{"id": 99, "status": "completed", "type": "shutdown"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestDigitalOceanProvider(t, srv.URL)
+	action, err := provider.StopServer(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("StopServer: %v", err)
+	}
+	if action.ID != "99" || action.Status != domain.ActionStatusRunning {
+		t.Errorf("initial action = %+v, want running action 99", action)
+	}
+
+	action, err = provider.PollAction(context.Background(), action.ID)
+	if err != nil {
+		t.Fatalf("PollAction: %v", err)
+	}
+	if !action.IsComplete() || action.Status != domain.ActionStatusSuccess {
+		t.Errorf("polled action = %+v, want success", action)
+	}
+}
+
+func TestDigitalOceanResetServer_PowerCycles(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]string
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/droplets/7/actions" || req["type"] != "power_cycle" {
+			t.Errorf("unexpected request %s %s type=%q", r.Method, r.URL.Path, req["type"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"action": map[string]interface{}{"id": 5, "status": "in-progress", "type": "power_cycle"}})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestDigitalOceanProvider(t, srv.URL)
+	action, err := provider.ResetServer(context.Background(), "7")
+	if err != nil {
+		t.Fatalf("ResetServer: %v", err)
+	}
+	if action.ID != "5" {
+		t.Errorf("action ID = %q, want 5", action.ID)
+	}
+}
+
+func TestDigitalOceanResizeServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/droplets/7/actions" || req["type"] != "resize" || req["size"] != "s-2vcpu-4gb" || req["disk"] != true {
+			t.Errorf("unexpected request %s %s body=%v", r.Method, r.URL.Path, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"action": map[string]interface{}{"id": 6, "status": "in-progress", "type": "resize"}})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestDigitalOceanProvider(t, srv.URL)
+	action, err := provider.ResizeServer(context.Background(), "7", "s-2vcpu-4gb", true)
+	if err != nil {
+		t.Fatalf("ResizeServer: %v", err)
+	}
+	if action.ID != "6" {
+		t.Errorf("action ID = %q, want 6", action.ID)
+	}
+}
+
+func TestDigitalOceanDeleteServer_InvalidID(t *testing.T) {
+	provider := newTestDigitalOceanProvider(t, "http://unused.invalid")
+	if err := provider.DeleteServer(context.Background(), "abc"); err == nil {
+		t.Fatal("expected error for non-numeric ID")
+	}
+}
+
+// --- Catalog tests ---
+
+func TestDigitalOceanListServerTypes(t *testing.T) {
+	srv := newTestAPI(t, map[string]interface{}{
+		"sizes": []interface{}{
+			map[string]interface{}{
+				"slug": "s-1vcpu-1gb", "description": "Basic", "memory": 1024, "vcpus": 1, "disk": 25,
+				"price_monthly": 6.0, "price_hourly": 0.00893, "regions": []interface{}{"nyc1", "fra1"}, "available": true,
+			},
+			map[string]interface{}{"slug": "retired", "available": false},
+		},
+	})
+
+	types, err := newTestDigitalOceanProvider(t, srv.URL).ListServerTypes(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []domain.ServerTypeSpec{{
+		ID:           "s-1vcpu-1gb",
+		Name:         "s-1vcpu-1gb",
+		Description:  "Basic",
+		Cores:        1,
+		Memory:       1,
+		Disk:         25,
+		Architecture: "x86",
+		PriceMonthly: money.Money{Amount: 6.0, Currency: "USD"},
+		PriceHourly:  money.Money{Amount: 0.00893, Currency: "USD"},
+		Locations:    []string{"fra1", "nyc1"},
+	}}
+	if diff := cmp.Diff(want, types); diff != "" {
+		t.Errorf("server types mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDigitalOceanListLocations_OnlyAvailable(t *testing.T) {
+	srv := newTestAPI(t, map[string]interface{}{
+		"regions": []interface{}{
+			map[string]interface{}{"slug": "fra1", "name": "Frankfurt 1", "available": true},
+			map[string]interface{}{"slug": "ams2", "name": "Amsterdam 2", "available": false},
+		},
+	})
+
+	locations, err := newTestDigitalOceanProvider(t, srv.URL).ListLocations(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(locations) != 1 || locations[0].Name != "fra1" {
+		t.Errorf("locations = %+v, want only fra1", locations)
+	}
+}
+
+func TestDigitalOceanListImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("type"); got != "distribution" {
+			t.Errorf("type = %q, want distribution", got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"images": []interface{}{
+				map[string]interface{}{"id": 1, "name": "24.04 (LTS) x64", "slug": "ubuntu-24-04-x64", "distribution": "Ubuntu", "status": "available"},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	images, err := newTestDigitalOceanProvider(t, srv.URL).ListImages(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []domain.ImageSpec{{
+		ID: "1", Name: "ubuntu-24-04-x64", Description: "Ubuntu 24.04 (LTS) x64",
+		Type: "system", OSFlavor: "ubuntu", Architecture: "x86",
+	}}
+	if diff := cmp.Diff(want, images); diff != "" {
+		t.Errorf("images mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDigitalOceanListCustomImages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("private"); got != "true" {
+			t.Errorf("private = %q, want true", got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"images": []interface{}{
+				map[string]interface{}{"id": 7, "name": "web-1 weekly", "distribution": "Ubuntu", "type": "backup", "size_gigabytes": 1.2, "created_at": "2026-09-01T00:00:00Z"},
+				map[string]interface{}{"id": 8, "name": "my-custom", "distribution": "Unknown", "type": "custom", "created_at": "2026-09-15T00:00:00Z"},
+				map[string]interface{}{"id": 9, "name": "pre-deploy", "distribution": "Ubuntu", "type": "snapshot", "size_gigabytes": 3.4, "created_at": "2026-10-01T00:00:00Z"},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	images, err := newTestDigitalOceanProvider(t, srv.URL).ListCustomImages(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := []domain.ImageSpec{
+		{ID: "9", Description: "pre-deploy", Type: "snapshot", OSFlavor: "ubuntu", Architecture: "x86", SizeGB: 3.4, CreatedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "7", Description: "web-1 weekly", Type: "backup", OSFlavor: "ubuntu", Architecture: "x86", SizeGB: 1.2, CreatedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(want, images); diff != "" {
+		t.Errorf("images mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDigitalOceanFactoryViaRegistry(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	RegisterDigitalOcean()
+	store := auth.NewMockStore()
+	if err := store.SetToken("digitalocean", "do-token"); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+
+	provider, err := Get("digitalocean", store)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if provider.GetDisplayName() != "DigitalOcean" {
+		t.Errorf("GetDisplayName() = %q, want DigitalOcean", provider.GetDisplayName())
+	}
+}
@@ -0,0 +1,63 @@
+package providers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
+)
+
+// endpointOverride returns the API base URL and HTTP client configured for
+// provider with 'vpsm config endpoint set', or ok=false when the provider
+// uses its default endpoint.
+func endpointOverride(provider string) (url string, client *http.Client, ok bool, err error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", nil, false, nil // best-effort: fall back to the default endpoint
+	}
+	e, ok := cfg.EndpointFor(provider)
+	if !ok {
+		return "", nil, false, nil
+	}
+	transport, err := endpointTransport(e)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("%s endpoint: %w", provider, err)
+	}
+	return e.URL, &http.Client{Transport: usagestats.Transport(provider, transport)}, true, nil
+}
+
+// endpointTransport returns the transport for e: the default one, or a
+// clone that also trusts the CAs in e.CACert.
+func endpointTransport(e config.Endpoint) (http.RoundTripper, error) {
+	if e.CACert == "" {
+		return nil, nil
+	}
+	pool, err := LoadCAPool(e.CACert)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return transport, nil
+}
+
+// LoadCAPool returns the system certificate pool extended with the PEM
+// certificates in path.
+func LoadCAPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
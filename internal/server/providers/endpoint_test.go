@@ -0,0 +1,108 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// useEndpoint points provider at url in a temporary config, trusting the
+// certificate of srv when it is a TLS server.
+func useEndpoint(t *testing.T, provider, url string, srv *httptest.Server) {
+	t.Helper()
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+
+	e := config.Endpoint{URL: url}
+	if srv != nil && srv.TLS != nil {
+		e.CACert = filepath.Join(t.TempDir(), "ca.pem")
+		block := &pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}
+		if err := os.WriteFile(e.CACert, pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{}
+	cfg.SetEndpoint(provider, e)
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegisterHetzner_EndpointOverrideWithCA(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/gw/servers") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"servers": []interface{}{}})
+	}))
+	t.Cleanup(srv.Close)
+	useEndpoint(t, "hetzner", srv.URL+"/gw", srv)
+
+	Reset()
+	t.Cleanup(Reset)
+	RegisterHetzner()
+	store := auth.NewMockStore()
+	store.SetToken("hetzner", "token")
+
+	provider, err := Get("hetzner", store)
+	if err != nil {
+		t.Fatalf("expected no error from Get, got %v", err)
+	}
+	if _, err := provider.ListServers(context.Background()); err != nil {
+		t.Fatalf("expected the overridden endpoint to be trusted, got %v", err)
+	}
+}
+
+func TestRegisterDigitalOcean_EndpointOverride(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mock/droplets" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"droplets": []interface{}{}})
+	}))
+	t.Cleanup(srv.Close)
+	useEndpoint(t, "digitalocean", srv.URL+"/mock/", nil)
+
+	Reset()
+	t.Cleanup(Reset)
+	RegisterDigitalOcean()
+	store := auth.NewMockStore()
+	store.SetToken("digitalocean", "token")
+
+	provider, err := Get("digitalocean", store)
+	if err != nil {
+		t.Fatalf("expected no error from Get, got %v", err)
+	}
+	if _, err := provider.ListServers(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestRegisterHetzner_BadCACert(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	cfg := &config.Config{}
+	cfg.SetEndpoint("hetzner", config.Endpoint{URL: "https://gw.example.com", CACert: filepath.Join(t.TempDir(), "missing.pem")})
+	cfg.Save()
+
+	Reset()
+	t.Cleanup(Reset)
+	RegisterHetzner()
+	store := auth.NewMockStore()
+	store.SetToken("hetzner", "token")
+
+	if _, err := Get("hetzner", store); err == nil || !strings.Contains(err.Error(), "hetzner endpoint") {
+		t.Errorf("expected a CA certificate error, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/services"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/usagestats"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
@@ -20,6 +23,18 @@ var _ domain.CatalogProvider = (*HetznerProvider)(nil)
 var _ domain.SSHKeyManager = (*HetznerProvider)(nil)
 var _ domain.ActionPoller = (*HetznerProvider)(nil)
 var _ domain.MetricsProvider = (*HetznerProvider)(nil)
+var _ domain.NetworkProvider = (*HetznerProvider)(nil)
+var _ domain.PricingProvider = (*HetznerProvider)(nil)
+var _ domain.CustomImageProvider = (*HetznerProvider)(nil)
+var _ domain.RebootProvider = (*HetznerProvider)(nil)
+var _ domain.ResizeProvider = (*HetznerProvider)(nil)
+var _ domain.BackupProvider = (*HetznerProvider)(nil)
+var _ domain.UserDataLimiter = (*HetznerProvider)(nil)
+var _ domain.FloatingIPProvider = (*HetznerProvider)(nil)
+var _ domain.ReverseDNSProvider = (*HetznerProvider)(nil)
+var _ domain.ConsoleProvider = (*HetznerProvider)(nil)
+var _ domain.ServerFilterProvider = (*HetznerProvider)(nil)
+var _ domain.LabelProvider = (*HetznerProvider)(nil)
 
 // HetznerProvider implements domain.Provider using the Hetzner Cloud API.
 type HetznerProvider struct {
@@ -32,6 +47,10 @@ type HetznerProvider struct {
 const (
 	requestTimeout         = 30 * time.Second
 	defaultCatalogCacheTTL = time.Hour
+
+	// hetznerUserDataLimit is the largest cloud-init user data Hetzner
+	// accepts at server creation.
+	hetznerUserDataLimit = 32 * 1024
 )
 
 // NewHetznerProvider creates a HetznerProvider with the given hcloud client options.
@@ -39,6 +58,7 @@ const (
 func NewHetznerProvider(opts ...hcloud.ClientOption) *HetznerProvider {
 	defaults := []hcloud.ClientOption{
 		hcloud.WithApplication("vpsm", "0.1.0"),
+		hcloud.WithHTTPClient(&http.Client{Transport: usagestats.Transport("hetzner", nil)}),
 	}
 	allOpts := append(defaults, opts...)
 	client := hcloud.NewClient(allOpts...)
@@ -59,15 +79,43 @@ func RegisterHetzner() {
 			return nil, fmt.Errorf("hetzner auth: %w", err)
 		}
 
-		return NewHetznerProvider(hcloud.WithToken(token)), nil
+		opts, err := HetznerClientOptions(token)
+		if err != nil {
+			return nil, err
+		}
+		return NewHetznerProvider(opts...), nil
 	})
 }
 
+// HetznerClientOptions returns the hcloud options for a client using
+// token, pointed at the endpoint set with 'vpsm config endpoint set
+// hetzner' when there is one.
+func HetznerClientOptions(token string) ([]hcloud.ClientOption, error) {
+	opts := []hcloud.ClientOption{hcloud.WithToken(token)}
+	url, client, ok, err := endpointOverride("hetzner")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, hcloud.WithEndpoint(url), hcloud.WithHTTPClient(client))
+	}
+	return opts, nil
+}
+
 func (h *HetznerProvider) GetDisplayName() string {
 	return "Hetzner"
 }
 
+// UserDataLimit returns the 32 KiB Hetzner allows for user data.
+func (h *HetznerProvider) UserDataLimit() int {
+	return hetznerUserDataLimit
+}
+
 func (h *HetznerProvider) CreateServer(ctx context.Context, opts domain.CreateServerOpts) (*domain.Server, error) {
+	if opts.Interruptible {
+		return nil, fmt.Errorf("failed to create server: hetzner does not offer interruptible servers")
+	}
+
 	server, err := h.hcloudService.CreateServer(ctx, &opts)
 	if err != nil {
 		return nil, err
@@ -92,13 +140,13 @@ func (h *HetznerProvider) DeleteServer(ctx context.Context, id string) error {
 	})
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return fmt.Errorf("failed to delete server: %w", domain.ErrNotFound)
+			return fmt.Errorf("failed to delete server: %w", hetznerSentinel(err, domain.ErrNotFound))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return fmt.Errorf("failed to delete server: %w", domain.ErrUnauthorized)
+			return fmt.Errorf("failed to delete server: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return fmt.Errorf("failed to delete server: %w", domain.ErrRateLimited)
+			return fmt.Errorf("failed to delete server: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		return fmt.Errorf("failed to delete server: %w", err)
 	}
@@ -112,16 +160,16 @@ func (h *HetznerProvider) StartServer(ctx context.Context, id string) (*domain.A
 	action, err := h.hcloudService.StartServer(ctx, id)
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return nil, fmt.Errorf("failed to start server: %w", domain.ErrNotFound)
+			return nil, fmt.Errorf("failed to start server: %w", hetznerSentinel(err, domain.ErrNotFound))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to start server: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to start server: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to start server: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to start server: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeConflict) {
-			return nil, fmt.Errorf("failed to start server: %w", domain.ErrConflict)
+			return nil, fmt.Errorf("failed to start server: %w", hetznerSentinel(err, domain.ErrConflict))
 		}
 		return nil, fmt.Errorf("failed to start server: %w", err)
 	}
@@ -135,16 +183,16 @@ func (h *HetznerProvider) StopServer(ctx context.Context, id string) (*domain.Ac
 	action, err := h.hcloudService.StopServer(ctx, id)
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return nil, fmt.Errorf("failed to stop server: %w", domain.ErrNotFound)
+			return nil, fmt.Errorf("failed to stop server: %w", hetznerSentinel(err, domain.ErrNotFound))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to stop server: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to stop server: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to stop server: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to stop server: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeConflict) {
-			return nil, fmt.Errorf("failed to stop server: %w", domain.ErrConflict)
+			return nil, fmt.Errorf("failed to stop server: %w", hetznerSentinel(err, domain.ErrConflict))
 		}
 		return nil, fmt.Errorf("failed to stop server: %w", err)
 	}
@@ -152,6 +200,63 @@ func (h *HetznerProvider) StopServer(ctx context.Context, id string) (*domain.Ac
 	return action, nil
 }
 
+// RebootServer soft-reboots a server by its ID and returns the initial
+// action status so callers can poll for completion.
+func (h *HetznerProvider) RebootServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.RebootServer(ctx, id)
+	if err != nil {
+		return nil, hetznerPowerActionError("reboot", err)
+	}
+	return action, nil
+}
+
+// ResetServer hard-resets a server by its ID and returns the initial
+// action status so callers can poll for completion.
+func (h *HetznerProvider) ResetServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.ResetServer(ctx, id)
+	if err != nil {
+		return nil, hetznerPowerActionError("reset", err)
+	}
+	return action, nil
+}
+
+// RequestConsole requests a VNC console for a server. Hetzner's console
+// URL accepts a connection for one minute after it is issued.
+func (h *HetznerProvider) RequestConsole(ctx context.Context, id string) (*domain.Console, error) {
+	console, err := h.hcloudService.RequestConsole(ctx, id)
+	if err != nil {
+		return nil, hetznerPowerActionError("open a console for", err)
+	}
+	return console, nil
+}
+
+// ResizeServer changes the type of a powered-off server and returns the
+// initial action status so callers can poll for completion.
+func (h *HetznerProvider) ResizeServer(ctx context.Context, id, serverType string, upgradeDisk bool) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.ResizeServer(ctx, id, serverType, upgradeDisk)
+	if err != nil {
+		return nil, hetznerPowerActionError("resize", err)
+	}
+	return action, nil
+}
+
+// hetznerPowerActionError wraps a failed server action, mapping hcloud
+// errors to domain sentinels.
+func hetznerPowerActionError(verb string, err error) error {
+	switch {
+	case hcloud.IsError(err, hcloud.ErrorCodeNotFound):
+		return fmt.Errorf("failed to %s server: %w", verb, hetznerSentinel(err, domain.ErrNotFound))
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return fmt.Errorf("failed to %s server: %w", verb, hetznerSentinel(err, domain.ErrUnauthorized))
+	case hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded):
+		return fmt.Errorf("failed to %s server: %w", verb, hetznerSentinel(err, domain.ErrRateLimited))
+	case hcloud.IsError(err, hcloud.ErrorCodeConflict):
+		return fmt.Errorf("failed to %s server: %w", verb, hetznerSentinel(err, domain.ErrConflict))
+	default:
+		return fmt.Errorf("failed to %s server: %w", verb, err)
+	}
+}
+
 // PollAction retrieves the current status of an in-flight action.
 // It maps provider-specific errors to domain sentinel errors so callers
 // can react to rate limiting without importing the hcloud SDK.
@@ -159,13 +264,13 @@ func (h *HetznerProvider) PollAction(ctx context.Context, actionID string) (*dom
 	action, err := h.hcloudService.PollAction(ctx, actionID)
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return nil, fmt.Errorf("action not found: %w", domain.ErrNotFound)
+			return nil, fmt.Errorf("action not found: %w", hetznerSentinel(err, domain.ErrNotFound))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("rate limited while polling action: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("rate limited while polling action: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to poll action: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to poll action: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		return nil, fmt.Errorf("failed to poll action: %w", err)
 	}
@@ -190,10 +295,10 @@ func (h *HetznerProvider) GetServer(ctx context.Context, id string) (*domain.Ser
 	})
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to get server: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to get server: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to get server: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to get server: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		return nil, fmt.Errorf("failed to get server: %w", err)
 	}
@@ -208,20 +313,31 @@ func (h *HetznerProvider) GetServer(ctx context.Context, id string) (*domain.Ser
 
 // ListServers retrieves all servers from the Hetzner Cloud API.
 func (h *HetznerProvider) ListServers(ctx context.Context) ([]domain.Server, error) {
+	return h.listServers(ctx, hcloud.ServerListOpts{})
+}
+
+// ListServersFiltered retrieves the servers matching selector, letting
+// the Hetzner API evaluate it through its label_selector parameter.
+func (h *HetznerProvider) ListServersFiltered(ctx context.Context, selector domain.LabelSelector) ([]domain.Server, error) {
+	opts := hcloud.ServerListOpts{ListOpts: hcloud.ListOpts{LabelSelector: selector.String()}}
+	return h.listServers(ctx, opts)
+}
+
+func (h *HetznerProvider) listServers(ctx context.Context, opts hcloud.ServerListOpts) ([]domain.Server, error) {
 	var hzServers []*hcloud.Server
 	err := retry.Do(ctx, h.retryConfig, isHetznerRetryable, func() error {
 		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
 		defer cancel()
 		var apiErr error
-		hzServers, apiErr = h.client.Server.All(reqCtx)
+		hzServers, apiErr = h.client.Server.AllWithOpts(reqCtx, opts)
 		return apiErr
 	})
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to list servers: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to list servers: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to list servers: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to list servers: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		return nil, fmt.Errorf("failed to list servers: %w", err)
 	}
@@ -251,6 +367,9 @@ func toDomainServer(s *hcloud.Server) domain.Server {
 
 	if !s.PublicNet.IPv6.IsUnspecified() {
 		server.PublicIPv6 = s.PublicNet.IPv6.IP.String()
+		if s.PublicNet.IPv6.Network != nil {
+			server.PublicIPv6Network = s.PublicNet.IPv6.Network.String()
+		}
 	}
 
 	if len(s.PrivateNet) > 0 && s.PrivateNet[0].IP != nil {
@@ -270,12 +389,91 @@ func toDomainServer(s *hcloud.Server) domain.Server {
 		server.Region = s.Location.Name
 	}
 
+	if len(s.Labels) > 0 {
+		server.Labels = s.Labels
+	}
+
+	server.Backups = s.BackupWindow != ""
+
+	server.Attached = attachedResources(s)
+
 	// Store Hetzner-specific metadata
 	server.Metadata["hetzner_id"] = s.ID
 
 	return server
 }
 
+// attachedResources collects the resources bound to s. The server payload
+// only embeds IDs for most of them, so names are filled in where available.
+func attachedResources(s *hcloud.Server) []domain.AttachedResource {
+	var attached []domain.AttachedResource
+	add := func(kind string, id int64, name, detail string) {
+		attached = append(attached, domain.AttachedResource{
+			Kind:   kind,
+			ID:     strconv.FormatInt(id, 10),
+			Name:   name,
+			Detail: detail,
+		})
+	}
+
+	for _, v := range s.Volumes {
+		if v != nil {
+			add(domain.AttachedVolume, v.ID, v.Name, "")
+		}
+	}
+	for _, ip := range s.PublicNet.FloatingIPs {
+		if ip != nil {
+			detail := ""
+			if ip.IP != nil {
+				detail = ip.IP.String()
+			}
+			add(domain.AttachedFloatingIP, ip.ID, ip.Name, detail)
+		}
+	}
+	for _, fw := range s.PublicNet.Firewalls {
+		if fw != nil {
+			add(domain.AttachedFirewall, fw.Firewall.ID, fw.Firewall.Name, string(fw.Status))
+		}
+	}
+	for _, lb := range s.LoadBalancers {
+		if lb != nil {
+			add(domain.AttachedLoadBalancer, lb.ID, lb.Name, "")
+		}
+	}
+	for _, pn := range s.PrivateNet {
+		if pn.Network == nil {
+			continue
+		}
+		detail := ""
+		if pn.IP != nil {
+			detail = pn.IP.String()
+		}
+		add(domain.AttachedNetwork, pn.Network.ID, pn.Network.Name, detail)
+	}
+	return attached
+}
+
+// hetznerSentinel returns sentinel annotated with the correlation ID of
+// the failed hcloud call in err. Mapping to a sentinel would otherwise drop
+// the ID that hcloud includes in its own error message.
+func hetznerSentinel(err, sentinel error) error {
+	return domain.WithRequestID(sentinel, hetznerCorrelationID(err))
+}
+
+// hetznerCorrelationID returns the X-Correlation-Id header of the response
+// behind an hcloud API error, or "" if there is none.
+func hetznerCorrelationID(err error) string {
+	var apiErr hcloud.Error
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	resp := apiErr.Response()
+	if resp == nil || resp.Response == nil {
+		return ""
+	}
+	return resp.Header.Get("X-Correlation-Id")
+}
+
 func isHetznerRetryable(err error) bool {
 	if retry.IsRetryable(err) {
 		return true
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// --- BackupProvider implementation ---
+
+// EnableBackups turns on daily backups for a server. Hetzner keeps the
+// seven most recent backups and charges 20% of the server price.
+func (h *HetznerProvider) EnableBackups(ctx context.Context, serverID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.EnableBackups(ctx, serverID)
+	if err != nil {
+		return nil, hetznerPowerActionError("enable backups for", err)
+	}
+	return action, nil
+}
+
+// DisableBackups turns off backups for a server. Hetzner deletes the
+// server's existing backups when they are disabled.
+func (h *HetznerProvider) DisableBackups(ctx context.Context, serverID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.DisableBackups(ctx, serverID)
+	if err != nil {
+		return nil, hetznerPowerActionError("disable backups for", err)
+	}
+	return action, nil
+}
+
+// RestoreBackup rebuilds a server from one of its backups.
+func (h *HetznerProvider) RestoreBackup(ctx context.Context, serverID, backupID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.RestoreBackup(ctx, serverID, backupID)
+	if err != nil {
+		return nil, hetznerPowerActionError("restore", err)
+	}
+	return action, nil
+}
+
+// ListBackups returns a server's backups, newest first.
+func (h *HetznerProvider) ListBackups(ctx context.Context, serverID string) ([]domain.Backup, error) {
+	images, err := h.hcloudService.ListBackups(ctx, serverID)
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
+			return nil, fmt.Errorf("failed to list backups: %w", hetznerSentinel(err, domain.ErrUnauthorized))
+		}
+		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
+			return nil, fmt.Errorf("failed to list backups: %w", hetznerSentinel(err, domain.ErrRateLimited))
+		}
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	backups := make([]domain.Backup, 0, len(images))
+	for _, img := range images {
+		backups = append(backups, domain.Backup{
+			ID:          strconv.FormatInt(img.ID, 10),
+			Description: img.Description,
+			CreatedAt:   img.Created,
+			SizeGB:      float64(img.ImageSize),
+		})
+	}
+	return backups, nil
+}
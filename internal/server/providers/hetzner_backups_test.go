@@ -0,0 +1,107 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func TestHetznerListBackups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/images" || q.Get("type") != "backup" || q.Get("bound_to") != "42" {
+			t.Errorf("unexpected request %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"images": []interface{}{
+				map[string]interface{}{
+					"id": 900, "type": "backup", "status": "available",
+					"description": "web-1 2026-10-15", "image_size": 1.5,
+					"created": "2026-10-15T03:00:00+00:00", "architecture": "x86",
+				},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	backups, err := provider.ListBackups(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup, got %d", len(backups))
+	}
+	b := backups[0]
+	if b.ID != "900" || b.Description != "web-1 2026-10-15" || b.SizeGB != 1.5 || b.CreatedAt.Day() != 15 {
+		t.Errorf("backup = %+v", b)
+	}
+}
+
+func TestHetznerEnableBackups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/servers/42/actions/enable_backup" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{"id": 10, "status": "running", "command": "enable_backup"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.EnableBackups(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.ID != "10" {
+		t.Errorf("action ID = %q, want 10", action.ID)
+	}
+}
+
+func TestHetznerRestoreBackup_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/servers/42/actions/rebuild" || req["image"] != float64(900) {
+			t.Errorf("unexpected request %s body=%v", r.URL.Path, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"code": "not_found", "message": "image not found"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	_, err := provider.RestoreBackup(context.Background(), "42", "900")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestToDomainServer_Backups(t *testing.T) {
+	srv := newTestAPI(t, map[string]interface{}{
+		"server": map[string]interface{}{
+			"id": 42, "name": "web-1", "status": "running", "backup_window": "22-02",
+			"created": "2026-01-01T00:00:00+00:00",
+		},
+	})
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	server, err := provider.GetServer(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !server.Backups {
+		t.Error("expected Backups to be true when a backup window is set")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
 	"nathanbeddoewebdev/vpsm/internal/retry"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 
@@ -119,6 +120,32 @@ func (h *HetznerProvider) ListImages(ctx context.Context) ([]domain.ImageSpec, e
 	return images, nil
 }
 
+// ListCustomImages retrieves the project's snapshots and backups, newest
+// first. Unlike ListImages the result is not cached.
+func (h *HetznerProvider) ListCustomImages(ctx context.Context) ([]domain.ImageSpec, error) {
+	var hzImages []*hcloud.Image
+	err := retry.Do(ctx, h.retryConfig, isHetznerRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		var apiErr error
+		hzImages, apiErr = h.client.Image.AllWithOpts(reqCtx, hcloud.ImageListOpts{
+			Type:   []hcloud.ImageType{hcloud.ImageTypeSnapshot, hcloud.ImageTypeBackup},
+			Status: []hcloud.ImageStatus{hcloud.ImageStatusAvailable},
+			Sort:   []string{"created:desc"},
+		})
+		return apiErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots and backups: %w", err)
+	}
+
+	images := make([]domain.ImageSpec, 0, len(hzImages))
+	for _, img := range hzImages {
+		images = append(images, toDomainImage(img))
+	}
+	return images, nil
+}
+
 // ListSSHKeys retrieves all SSH keys from the Hetzner Cloud API.
 func (h *HetznerProvider) ListSSHKeys(ctx context.Context) ([]domain.SSHKeySpec, error) {
 	var hzKeys []*hcloud.SSHKey
@@ -141,6 +168,72 @@ func (h *HetznerProvider) ListSSHKeys(ctx context.Context) ([]domain.SSHKeySpec,
 	return keys, nil
 }
 
+// --- NetworkProvider implementation ---
+//
+// Creating, deleting and attaching networks lives in hetzner_networks.go.
+
+// ListNetworks retrieves all private networks from the Hetzner Cloud API.
+func (h *HetznerProvider) ListNetworks(ctx context.Context) ([]domain.NetworkSpec, error) {
+	var hzNetworks []*hcloud.Network
+	err := retry.Do(ctx, h.retryConfig, isHetznerRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		var apiErr error
+		hzNetworks, apiErr = h.client.Network.All(reqCtx)
+		return apiErr
+	})
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
+			return nil, fmt.Errorf("failed to list networks: %w", hetznerSentinel(err, domain.ErrUnauthorized))
+		}
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+
+	networks := make([]domain.NetworkSpec, 0, len(hzNetworks))
+	for _, n := range hzNetworks {
+		networks = append(networks, toDomainNetwork(n))
+	}
+
+	return networks, nil
+}
+
+// --- PricingProvider implementation ---
+
+// GetAddonPricing retrieves add-on prices from the Hetzner Cloud pricing
+// API. Public IPv4 is billed as a primary IP, priced per location.
+func (h *HetznerProvider) GetAddonPricing(ctx context.Context) (*domain.AddonPricing, error) {
+	if h.cache != nil {
+		var cached domain.AddonPricing
+		hit, err := h.cache.Get(catalogCacheKey("addon_pricing"), defaultCatalogCacheTTL, &cached)
+		if err == nil && hit {
+			return &cached, nil
+		}
+	}
+
+	var hzPricing hcloud.Pricing
+	err := retry.Do(ctx, h.retryConfig, isHetznerRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+		var apiErr error
+		hzPricing, _, apiErr = h.client.Pricing.Get(reqCtx)
+		return apiErr
+	})
+	if err != nil {
+		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
+			return nil, fmt.Errorf("failed to get pricing: %w", hetznerSentinel(err, domain.ErrUnauthorized))
+		}
+		return nil, fmt.Errorf("failed to get pricing: %w", err)
+	}
+
+	pricing := toDomainAddonPricing(hzPricing)
+
+	if h.cache != nil {
+		_ = h.cache.Set(catalogCacheKey("addon_pricing"), pricing)
+	}
+
+	return &pricing, nil
+}
+
 // --- SSHKeyManager implementation ---
 
 // CreateSSHKey uploads a new SSH key to the Hetzner Cloud API.
@@ -158,13 +251,13 @@ func (h *HetznerProvider) CreateSSHKey(ctx context.Context, name, publicKey stri
 	})
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to create SSH key: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to create SSH key: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to create SSH key: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to create SSH key: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeConflict) {
-			return nil, fmt.Errorf("failed to create SSH key: %w", domain.ErrConflict)
+			return nil, fmt.Errorf("failed to create SSH key: %w", hetznerSentinel(err, domain.ErrConflict))
 		}
 		return nil, fmt.Errorf("failed to create SSH key: %w", err)
 	}
@@ -182,9 +275,31 @@ func toDomainLocation(loc *hcloud.Location) domain.Location {
 		Description: loc.Description,
 		Country:     loc.Country,
 		City:        loc.City,
+		NetworkZone: string(loc.NetworkZone),
 	}
 }
 
+func toDomainNetwork(n *hcloud.Network) domain.NetworkSpec {
+	spec := domain.NetworkSpec{
+		ID:   strconv.FormatInt(n.ID, 10),
+		Name: n.Name,
+	}
+	if n.IPRange != nil {
+		spec.IPRange = n.IPRange.String()
+	}
+	zones := make([]string, 0, len(n.Subnets))
+	for _, subnet := range n.Subnets {
+		zones = append(zones, string(subnet.NetworkZone))
+	}
+	spec.NetworkZones = uniqueStrings(zones)
+	for _, server := range n.Servers {
+		if server != nil {
+			spec.ServerIDs = append(spec.ServerIDs, strconv.FormatInt(server.ID, 10))
+		}
+	}
+	return spec
+}
+
 func toDomainServerType(st *hcloud.ServerType) domain.ServerTypeSpec {
 	spec := domain.ServerTypeSpec{
 		ID:           strconv.FormatInt(st.ID, 10),
@@ -218,9 +333,10 @@ func toDomainServerType(st *hcloud.ServerType) domain.ServerTypeSpec {
 	}
 
 	// Use the first available price entry as the representative price.
+	// Unparseable amounts are left as zero so the UI omits them.
 	if len(st.Pricings) > 0 {
-		spec.PriceMonthly = st.Pricings[0].Monthly.Gross
-		spec.PriceHourly = st.Pricings[0].Hourly.Gross
+		spec.PriceMonthly = hetznerPrice(st.Pricings[0].Monthly)
+		spec.PriceHourly = hetznerPrice(st.Pricings[0].Hourly)
 	}
 
 	return spec
@@ -265,7 +381,7 @@ func uniqueStrings(values []string) []string {
 }
 
 func toDomainImage(img *hcloud.Image) domain.ImageSpec {
-	return domain.ImageSpec{
+	spec := domain.ImageSpec{
 		ID:           strconv.FormatInt(img.ID, 10),
 		Name:         img.Name,
 		Description:  img.Description,
@@ -273,6 +389,14 @@ func toDomainImage(img *hcloud.Image) domain.ImageSpec {
 		OSFlavor:     img.OSFlavor,
 		Architecture: string(img.Architecture),
 	}
+	if img.Type != hcloud.ImageTypeSystem {
+		spec.SizeGB = float64(img.ImageSize)
+		spec.CreatedAt = img.Created
+		if img.CreatedFrom != nil {
+			spec.CreatedFrom = img.CreatedFrom.Name
+		}
+	}
+	return spec
 }
 
 func toDomainSSHKey(k *hcloud.SSHKey) domain.SSHKeySpec {
@@ -283,6 +407,39 @@ func toDomainSSHKey(k *hcloud.SSHKey) domain.SSHKeySpec {
 	}
 }
 
+// hetznerPrice converts a Hetzner gross price into Money. Hetzner bills in
+// EUR and omits the currency on per-server-type prices.
+func hetznerPrice(p hcloud.Price) money.Money {
+	currency := p.Currency
+	if currency == "" {
+		currency = "EUR"
+	}
+	m, err := money.Parse(p.Gross, currency)
+	if err != nil {
+		return money.Money{}
+	}
+	return m
+}
+
+func toDomainAddonPricing(p hcloud.Pricing) domain.AddonPricing {
+	pricing := domain.AddonPricing{IPv4Monthly: make(map[string]money.Money)}
+	for _, ip := range p.PrimaryIPs {
+		if ip.Type != string(hcloud.PrimaryIPTypeIPv4) {
+			continue
+		}
+		for _, lp := range ip.Pricings {
+			if lp.Location == "" {
+				continue
+			}
+			pricing.IPv4Monthly[lp.Location] = hetznerPrice(hcloud.Price{
+				Currency: p.Currency,
+				Gross:    lp.Monthly.Gross,
+			})
+		}
+	}
+	return pricing
+}
+
 func catalogCacheKey(resource string) string {
 	return "catalog_hetzner_" + resource
 }
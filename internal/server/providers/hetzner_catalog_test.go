@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 
 	"github.com/google/go-cmp/cmp"
@@ -36,9 +38,9 @@ func TestListLocations_HappyPath(t *testing.T) {
 	}
 
 	want := []domain.Location{
-		{ID: "1", Name: "fsn1", Description: "fsn1", Country: "DE", City: "Falkenstein"},
-		{ID: "2", Name: "nbg1", Description: "nbg1", Country: "DE", City: "Nuremberg"},
-		{ID: "3", Name: "ash", Description: "ash", Country: "US", City: "Ashburn"},
+		{ID: "1", Name: "fsn1", Description: "fsn1", Country: "DE", City: "Falkenstein", NetworkZone: "eu-central"},
+		{ID: "2", Name: "nbg1", Description: "nbg1", Country: "DE", City: "Nuremberg", NetworkZone: "eu-central"},
+		{ID: "3", Name: "ash", Description: "ash", Country: "US", City: "Ashburn", NetworkZone: "eu-central"},
 	}
 
 	if diff := cmp.Diff(want, locations); diff != "" {
@@ -169,13 +171,13 @@ func TestListServerTypes_HappyPath(t *testing.T) {
 		{
 			ID: "1", Name: "cpx11", Description: "cpx11",
 			Cores: 2, Memory: 2.0, Disk: 40, Architecture: "x86",
-			PriceMonthly: "3.92", PriceHourly: "0.0064",
+			PriceMonthly: money.Money{Amount: 3.92, Currency: "EUR"}, PriceHourly: money.Money{Amount: 0.0064, Currency: "EUR"},
 			Locations: []string{"fsn1", "nbg1"},
 		},
 		{
 			ID: "2", Name: "cax11", Description: "cax11",
 			Cores: 2, Memory: 4.0, Disk: 40, Architecture: "arm",
-			PriceMonthly: "3.29", PriceHourly: "0.0055",
+			PriceMonthly: money.Money{Amount: 3.29, Currency: "EUR"}, PriceHourly: money.Money{Amount: 0.0055, Currency: "EUR"},
 			Locations: []string{"fsn1"},
 		},
 	}
@@ -283,11 +285,11 @@ func TestListServerTypes_NoPrices(t *testing.T) {
 		t.Fatalf("expected 1 server type, got %d", len(serverTypes))
 	}
 
-	if serverTypes[0].PriceMonthly != "" {
-		t.Errorf("PriceMonthly = %q, want empty", serverTypes[0].PriceMonthly)
+	if !serverTypes[0].PriceMonthly.IsZero() {
+		t.Errorf("PriceMonthly = %+v, want zero", serverTypes[0].PriceMonthly)
 	}
-	if serverTypes[0].PriceHourly != "" {
-		t.Errorf("PriceHourly = %q, want empty", serverTypes[0].PriceHourly)
+	if !serverTypes[0].PriceHourly.IsZero() {
+		t.Errorf("PriceHourly = %+v, want zero", serverTypes[0].PriceHourly)
 	}
 }
 
@@ -393,6 +395,40 @@ func TestListImages_Non200(t *testing.T) {
 	}
 }
 
+func TestListCustomImages_SnapshotsAndBackups(t *testing.T) {
+	snapshot := testImageJSON(200, "", "ubuntu", "24.04", "x86")
+	snapshot["type"] = "snapshot"
+	snapshot["description"] = "before upgrade"
+	snapshot["image_size"] = 2.5
+	snapshot["created"] = "2026-10-01T09:00:00+00:00"
+	snapshot["created_from"] = map[string]interface{}{"id": 42, "name": "web-1"}
+
+	var gotTypes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTypes = r.URL.Query()["type"]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"images": []interface{}{snapshot}})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	images, err := provider.ListCustomImages(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"snapshot", "backup"}, gotTypes); diff != "" {
+		t.Errorf("type filter mismatch (-want +got):\n%s", diff)
+	}
+	want := []domain.ImageSpec{{
+		ID: "200", Description: "before upgrade", Type: "snapshot", OSFlavor: "ubuntu", Architecture: "x86",
+		SizeGB: 2.5, CreatedFrom: "web-1", CreatedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}}
+	if diff := cmp.Diff(want, images); diff != "" {
+		t.Errorf("images mismatch (-want +got):\n%s", diff)
+	}
+}
+
 // --- ListSSHKeys tests ---
 
 func testSSHKeyJSON(id int, name, fingerprint, publicKey string) map[string]interface{} {
@@ -616,3 +652,95 @@ func TestCatalogMethods_RequestPaths(t *testing.T) {
 		})
 	}
 }
+
+// --- ListNetworks tests ---
+
+func testNetworkJSON(id int, name, networkZone string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       id,
+		"name":     name,
+		"ip_range": "10.0.0.0/16",
+		"subnets": []interface{}{
+			map[string]interface{}{"type": "cloud", "ip_range": "10.0.1.0/24", "network_zone": networkZone, "gateway": "10.0.0.1"},
+		},
+		"routes":                   []interface{}{},
+		"servers":                  []interface{}{},
+		"labels":                   map[string]interface{}{},
+		"protection":               map[string]interface{}{"delete": false},
+		"created":                  "2024-01-01T00:00:00+00:00",
+		"expose_routes_to_vswitch": false,
+	}
+}
+
+func TestListNetworks_HappyPath(t *testing.T) {
+	srv := newTestAPI(t, map[string]interface{}{
+		"networks": []interface{}{
+			testNetworkJSON(7, "internal", "eu-central"),
+			testNetworkJSON(8, "us-backend", "us-east"),
+		},
+	})
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	networks, err := provider.ListNetworks(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []domain.NetworkSpec{
+		{ID: "7", Name: "internal", IPRange: "10.0.0.0/16", NetworkZones: []string{"eu-central"}},
+		{ID: "8", Name: "us-backend", IPRange: "10.0.0.0/16", NetworkZones: []string{"us-east"}},
+	}
+	if diff := cmp.Diff(want, networks); diff != "" {
+		t.Errorf("networks mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// --- GetAddonPricing tests ---
+
+func TestGetAddonPricing_IPv4PerLocation(t *testing.T) {
+	srv := newTestAPI(t, map[string]interface{}{
+		"pricing": map[string]interface{}{
+			"currency": "EUR",
+			"vat_rate": "19.00",
+			// hcloud-go sizes the primary IP slice by the floating IP
+			// count, so mirror the real API and list both types.
+			"floating_ips": []interface{}{
+				map[string]interface{}{"type": "ipv4", "prices": []interface{}{}},
+				map[string]interface{}{"type": "ipv6", "prices": []interface{}{}},
+			},
+			"primary_ips": []interface{}{
+				map[string]interface{}{
+					"type": "ipv4",
+					"prices": []interface{}{
+						map[string]interface{}{
+							"location":      "fsn1",
+							"price_hourly":  map[string]interface{}{"net": "0.0008", "gross": "0.00095"},
+							"price_monthly": map[string]interface{}{"net": "0.50", "gross": "0.5950"},
+						},
+					},
+				},
+				map[string]interface{}{
+					"type": "ipv6",
+					"prices": []interface{}{
+						map[string]interface{}{
+							"location":      "fsn1",
+							"price_hourly":  map[string]interface{}{"net": "0", "gross": "0"},
+							"price_monthly": map[string]interface{}{"net": "0", "gross": "0"},
+						},
+					},
+				},
+			},
+		},
+	})
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	pricing, err := provider.GetAddonPricing(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := map[string]money.Money{"fsn1": {Amount: 0.595, Currency: "EUR"}}
+	if diff := cmp.Diff(want, pricing.IPv4Monthly); diff != "" {
+		t.Errorf("IPv4Monthly mismatch (-want +got):\n%s", diff)
+	}
+}
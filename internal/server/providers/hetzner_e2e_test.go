@@ -0,0 +1,214 @@
+//go:build e2e
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// End-to-end tests against a real Hetzner Cloud project. They only build
+// with -tags e2e (see make e2e) and skip unless VPSM_E2E_HETZNER_TOKEN is
+// set. Use a dedicated, empty test project: every server the suite
+// creates carries e2eLabel and is deleted when the test ends, and
+// leftovers from aborted runs are swept before the suite starts.
+
+const (
+	e2eLabel       = "vpsm-e2e"
+	e2eStaleAfter  = time.Hour
+	e2eStepTimeout = 5 * time.Minute
+)
+
+// newE2EProvider returns a provider for the test project, or skips.
+func newE2EProvider(t *testing.T) *HetznerProvider {
+	t.Helper()
+	token := os.Getenv("VPSM_E2E_HETZNER_TOKEN")
+	if token == "" {
+		t.Skip("VPSM_E2E_HETZNER_TOKEN not set")
+	}
+	h := NewHetznerProvider(hcloud.WithToken(token))
+	sweepE2EServers(t, h)
+	return h
+}
+
+// e2eEnv returns the environment variable key, or fallback when unset.
+func e2eEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// sweepE2EServers deletes test servers left behind by aborted runs.
+// Recent ones are kept in case another run is still using them.
+func sweepE2EServers(t *testing.T, h *HetznerProvider) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	servers, err := h.ListServers(ctx)
+	if err != nil {
+		t.Fatalf("failed to list servers: %v", err)
+	}
+	for _, s := range servers {
+		if _, ok := s.Labels[e2eLabel]; !ok || time.Since(s.CreatedAt) < e2eStaleAfter {
+			continue
+		}
+		t.Logf("deleting stale test server %s (%s)", s.Name, s.ID)
+		if err := h.DeleteServer(ctx, s.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("failed to delete stale server %s: %v", s.ID, err)
+		}
+	}
+}
+
+// createE2EServer creates a throwaway server and registers its deletion.
+func createE2EServer(t *testing.T, h *HetznerProvider) *domain.Server {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	server, err := h.CreateServer(ctx, domain.CreateServerOpts{
+		Name:       fmt.Sprintf("%s-%d", e2eLabel, time.Now().Unix()),
+		Image:      e2eEnv("VPSM_E2E_HETZNER_IMAGE", "ubuntu-24.04"),
+		ServerType: e2eEnv("VPSM_E2E_HETZNER_TYPE", "cx22"),
+		Location:   e2eEnv("VPSM_E2E_HETZNER_LOCATION", "fsn1"),
+		Labels:     map[string]string{e2eLabel: "true"},
+	})
+	if err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+		defer cancel()
+		if err := h.DeleteServer(ctx, server.ID); err != nil && !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("failed to delete test server %s, remove it by hand: %v", server.ID, err)
+		}
+	})
+	return server
+}
+
+// waitForStatus polls until the server reaches status.
+func waitForStatus(t *testing.T, h *HetznerProvider, id, status string) *domain.Server {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	for {
+		server, err := h.GetServer(ctx, id)
+		if err != nil {
+			t.Fatalf("GetServer failed: %v", err)
+		}
+		if server.Status == status {
+			return server
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("server %s still %q, want %q", id, server.Status, status)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// waitForAction polls an action until it completes successfully.
+func waitForAction(t *testing.T, h *HetznerProvider, action *domain.ActionStatus) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), e2eStepTimeout)
+	defer cancel()
+
+	for !action.IsComplete() {
+		select {
+		case <-ctx.Done():
+			t.Fatalf("action %s did not complete", action.ID)
+		case <-time.After(2 * time.Second):
+		}
+		var err error
+		if action, err = h.PollAction(ctx, action.ID); err != nil {
+			t.Fatalf("PollAction failed: %v", err)
+		}
+	}
+	if action.Status != domain.ActionStatusSuccess {
+		t.Fatalf("action %s failed: %s", action.ID, action.ErrorMessage)
+	}
+}
+
+func TestE2EHetzner_ServerLifecycle(t *testing.T) {
+	h := newE2EProvider(t)
+
+	created := createE2EServer(t, h)
+	server := waitForStatus(t, h, created.ID, "running")
+	if server.PublicIPv4 == "" && server.PublicIPv6 == "" {
+		t.Errorf("expected the server to have a public address, got %+v", server)
+	}
+	if server.Labels[e2eLabel] != "true" {
+		t.Errorf("expected the test label to round-trip, got %v", server.Labels)
+	}
+
+	ctx := context.Background()
+	servers, err := h.ListServers(ctx)
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+	found := false
+	for _, s := range servers {
+		found = found || s.ID == server.ID
+	}
+	if !found {
+		t.Errorf("created server %s missing from ListServers", server.ID)
+	}
+
+	action, err := h.StopServer(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("StopServer failed: %v", err)
+	}
+	waitForAction(t, h, action)
+	waitForStatus(t, h, server.ID, "off")
+
+	action, err = h.StartServer(ctx, server.ID)
+	if err != nil {
+		t.Fatalf("StartServer failed: %v", err)
+	}
+	waitForAction(t, h, action)
+	waitForStatus(t, h, server.ID, "running")
+
+	if err := h.DeleteServer(ctx, server.ID); err != nil {
+		t.Fatalf("DeleteServer failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, e2eStepTimeout)
+	defer cancel()
+	for {
+		if _, err := h.GetServer(ctx, server.ID); errors.Is(err, domain.ErrNotFound) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("server %s still exists after delete", server.ID)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func TestE2EHetzner_Catalog(t *testing.T) {
+	h := newE2EProvider(t)
+	ctx := context.Background()
+
+	locations, err := h.ListLocations(ctx)
+	if err != nil || len(locations) == 0 {
+		t.Fatalf("ListLocations returned %d locations, err %v", len(locations), err)
+	}
+	types, err := h.ListServerTypes(ctx)
+	if err != nil || len(types) == 0 {
+		t.Fatalf("ListServerTypes returned %d types, err %v", len(types), err)
+	}
+	images, err := h.ListImages(ctx)
+	if err != nil || len(images) == 0 {
+		t.Fatalf("ListImages returned %d images, err %v", len(images), err)
+	}
+}
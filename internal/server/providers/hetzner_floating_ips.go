@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// --- FloatingIPProvider implementation ---
+
+// ListFloatingIPs returns the project's floating IPs.
+func (h *HetznerProvider) ListFloatingIPs(ctx context.Context) ([]domain.FloatingIP, error) {
+	hzIPs, err := h.hcloudService.ListFloatingIPs(ctx)
+	if err != nil {
+		return nil, hetznerFloatingIPError("list", err)
+	}
+
+	ips := make([]domain.FloatingIP, 0, len(hzIPs))
+	for _, ip := range hzIPs {
+		ips = append(ips, toDomainFloatingIP(ip))
+	}
+	return ips, nil
+}
+
+// CreateFloatingIP creates a floating IP. Hetzner bills it monthly
+// whether or not it is assigned.
+func (h *HetznerProvider) CreateFloatingIP(ctx context.Context, opts domain.CreateFloatingIPOpts) (*domain.FloatingIP, error) {
+	hzIP, err := h.hcloudService.CreateFloatingIP(ctx, opts)
+	if err != nil {
+		return nil, hetznerFloatingIPError("create", err)
+	}
+	ip := toDomainFloatingIP(hzIP)
+	return &ip, nil
+}
+
+// AssignFloatingIP routes a floating IP to a server, moving it away from
+// any server it was assigned to before. The server still has to configure
+// the address on an interface to answer on it.
+func (h *HetznerProvider) AssignFloatingIP(ctx context.Context, floatingIPID, serverID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.AssignFloatingIP(ctx, floatingIPID, serverID)
+	if err != nil {
+		return nil, hetznerFloatingIPError("assign", err)
+	}
+	return action, nil
+}
+
+// UnassignFloatingIP removes a floating IP from its server.
+func (h *HetznerProvider) UnassignFloatingIP(ctx context.Context, floatingIPID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.UnassignFloatingIP(ctx, floatingIPID)
+	if err != nil {
+		return nil, hetznerFloatingIPError("unassign", err)
+	}
+	return action, nil
+}
+
+// hetznerFloatingIPError wraps a failed floating IP request, mapping
+// hcloud errors to domain sentinels.
+func hetznerFloatingIPError(verb string, err error) error {
+	switch {
+	case hcloud.IsError(err, hcloud.ErrorCodeNotFound):
+		return fmt.Errorf("failed to %s floating IP: %w", verb, hetznerSentinel(err, domain.ErrNotFound))
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return fmt.Errorf("failed to %s floating IP: %w", verb, hetznerSentinel(err, domain.ErrUnauthorized))
+	case hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded):
+		return fmt.Errorf("failed to %s floating IP: %w", verb, hetznerSentinel(err, domain.ErrRateLimited))
+	case hcloud.IsError(err, hcloud.ErrorCodeConflict):
+		return fmt.Errorf("failed to %s floating IP: %w", verb, hetznerSentinel(err, domain.ErrConflict))
+	default:
+		return fmt.Errorf("failed to %s floating IP: %w", verb, err)
+	}
+}
+
+// toDomainFloatingIP converts an hcloud.FloatingIP to a domain.FloatingIP.
+// IPv6 addresses are reported as their /64 network.
+func toDomainFloatingIP(ip *hcloud.FloatingIP) domain.FloatingIP {
+	f := domain.FloatingIP{
+		ID:          strconv.FormatInt(ip.ID, 10),
+		Name:        ip.Name,
+		Description: ip.Description,
+		Type:        string(ip.Type),
+	}
+	switch {
+	case ip.Type == hcloud.FloatingIPTypeIPv6 && ip.Network != nil:
+		f.IP = ip.Network.String()
+	case ip.IP != nil:
+		f.IP = ip.IP.String()
+	}
+	if ip.HomeLocation != nil {
+		f.HomeLocation = ip.HomeLocation.Name
+	}
+	if ip.Server != nil {
+		f.ServerID = strconv.FormatInt(ip.Server.ID, 10)
+	}
+	return f
+}
@@ -0,0 +1,131 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func TestHetznerListFloatingIPs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/floating_ips" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"floating_ips": []interface{}{
+				map[string]interface{}{
+					"id": 7, "name": "web-vip", "type": "ipv4", "ip": "203.0.113.7",
+					"server": 42, "home_location": testLocationJSON(1, "fsn1", "DE", "Falkenstein"),
+				},
+				map[string]interface{}{
+					"id": 8, "type": "ipv6", "ip": "2001:db8::/64",
+					"home_location": testLocationJSON(2, "nbg1", "DE", "Nuremberg"),
+				},
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	ips, err := provider.ListFloatingIPs(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(ips) != 2 {
+		t.Fatalf("expected 2 floating IPs, got %d", len(ips))
+	}
+	want := domain.FloatingIP{ID: "7", Name: "web-vip", IP: "203.0.113.7", Type: "ipv4", HomeLocation: "fsn1", ServerID: "42"}
+	if ips[0] != want {
+		t.Errorf("ips[0] = %+v, want %+v", ips[0], want)
+	}
+	if ips[1].IP != "2001:db8::/64" || ips[1].ServerID != "" || ips[1].Label() != "2001:db8::/64" {
+		t.Errorf("ips[1] = %+v", ips[1])
+	}
+}
+
+func TestHetznerCreateFloatingIP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.Method != http.MethodPost || r.URL.Path != "/floating_ips" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if req["type"] != "ipv4" || req["home_location"] != "fsn1" || req["name"] != "web-vip" {
+			t.Errorf("unexpected body %v", req)
+		}
+		if _, ok := req["server"]; ok {
+			t.Errorf("server should be omitted, body %v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"floating_ip": map[string]interface{}{
+				"id": 7, "name": "web-vip", "type": "ipv4", "ip": "203.0.113.7",
+				"home_location": testLocationJSON(1, "fsn1", "DE", "Falkenstein"),
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	ip, err := provider.CreateFloatingIP(context.Background(), domain.CreateFloatingIPOpts{
+		Type: "ipv4", Name: "web-vip", HomeLocation: "fsn1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ip.ID != "7" || ip.IP != "203.0.113.7" {
+		t.Errorf("ip = %+v", ip)
+	}
+}
+
+func TestHetznerAssignFloatingIP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/floating_ips/7/actions/assign" || req["server"] != float64(42) {
+			t.Errorf("unexpected request %s body=%v", r.URL.Path, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{"id": 11, "status": "running", "command": "assign_floating_ip"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.AssignFloatingIP(context.Background(), "7", "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.ID != "11" || action.Command != "assign_floating_ip" {
+		t.Errorf("action = %+v", action)
+	}
+}
+
+func TestHetznerUnassignFloatingIP_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/floating_ips/7/actions/unassign" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"code": "not_found", "message": "floating IP not found"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	_, err := provider.UnassignFloatingIP(context.Background(), "7")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
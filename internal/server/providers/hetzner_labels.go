@@ -0,0 +1,30 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// --- LabelProvider implementation ---
+
+// SetLabels replaces a server's labels. Hetzner applies the change
+// immediately, without an action to wait for.
+func (h *HetznerProvider) SetLabels(ctx context.Context, serverID string, labels map[string]string) error {
+	err := h.hcloudService.SetLabels(ctx, serverID, labels)
+	switch {
+	case err == nil:
+		return nil
+	case hcloud.IsError(err, hcloud.ErrorCodeNotFound):
+		return fmt.Errorf("failed to set labels: %w", hetznerSentinel(err, domain.ErrNotFound))
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return fmt.Errorf("failed to set labels: %w", hetznerSentinel(err, domain.ErrUnauthorized))
+	case hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded):
+		return fmt.Errorf("failed to set labels: %w", hetznerSentinel(err, domain.ErrRateLimited))
+	default:
+		return fmt.Errorf("failed to set labels: %w", err)
+	}
+}
@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHetznerSetLabels(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   map[string]string
+	}{
+		{name: "replace", labels: map[string]string{"env": "prod", "role": "web"}, want: map[string]string{"env": "prod", "role": "web"}},
+		{name: "clear", labels: nil, want: map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/servers/42" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				var body struct {
+					Labels map[string]string `json:"labels"`
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				got = body.Labels
+				server := testServerJSON(42, "web", "running", "2024-06-15T12:00:00+00:00",
+					testLocationJSON(1, "fsn1", "DE", "Falkenstein"), testServerTypeJSON(1, "cx22", "x86"))
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(map[string]interface{}{"server": server})
+			}))
+			t.Cleanup(srv.Close)
+
+			provider := newTestHetznerProvider(t, srv.URL, "test-token")
+			if err := provider.SetLabels(context.Background(), "42", tt.labels); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("labels sent mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,6 +16,10 @@ import (
 // GetServerMetrics fetches time-series metrics for a server over the given
 // time range. The step is calculated automatically based on the duration
 // to produce approximately 60 data points.
+//
+// Series are returned for every disk and network interface the server
+// has (disk.0, disk.1, ...). Hetzner Cloud does not collect memory usage,
+// so MetricMemory is accepted but yields no series.
 func (h *HetznerProvider) GetServerMetrics(ctx context.Context, serverID string, types []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
 	hcloudTypes := make([]hcloud.ServerMetricType, 0, len(types))
 	for _, t := range types {
@@ -26,11 +30,21 @@ func (h *HetznerProvider) GetServerMetrics(ctx context.Context, serverID string,
 			hcloudTypes = append(hcloudTypes, hcloud.ServerMetricDisk)
 		case domain.MetricNetwork:
 			hcloudTypes = append(hcloudTypes, hcloud.ServerMetricNetwork)
+		case domain.MetricMemory:
+			// Not collected by Hetzner Cloud.
 		default:
 			return nil, fmt.Errorf("unsupported metric type: %q", t)
 		}
 	}
 
+	if len(hcloudTypes) == 0 {
+		return &domain.ServerMetrics{
+			Start:      start,
+			End:        end,
+			TimeSeries: make(map[string]domain.MetricsTimeSeries),
+		}, nil
+	}
+
 	// Calculate step to produce ~60 data points.
 	duration := end.Sub(start)
 	step := int(duration.Seconds() / 60)
@@ -48,13 +62,13 @@ func (h *HetznerProvider) GetServerMetrics(ctx context.Context, serverID string,
 	hzMetrics, err := h.hcloudService.GetServerMetrics(ctx, serverID, opts)
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
-			return nil, fmt.Errorf("failed to get server metrics: %w", domain.ErrNotFound)
+			return nil, fmt.Errorf("failed to get server metrics: %w", hetznerSentinel(err, domain.ErrNotFound))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeUnauthorized) {
-			return nil, fmt.Errorf("failed to get server metrics: %w", domain.ErrUnauthorized)
+			return nil, fmt.Errorf("failed to get server metrics: %w", hetznerSentinel(err, domain.ErrUnauthorized))
 		}
 		if hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded) {
-			return nil, fmt.Errorf("failed to get server metrics: %w", domain.ErrRateLimited)
+			return nil, fmt.Errorf("failed to get server metrics: %w", hetznerSentinel(err, domain.ErrRateLimited))
 		}
 		return nil, fmt.Errorf("failed to get server metrics: %w", err)
 	}
@@ -272,6 +272,60 @@ func TestGetServerMetrics_EmptySeries(t *testing.T) {
 	}
 }
 
+func TestGetServerMetrics_MemoryAndExtraDevices(t *testing.T) {
+	response := testMetricsResponse(
+		"2024-01-15T12:00:00Z",
+		"2024-01-15T13:00:00Z",
+		60,
+		map[string][][2]interface{}{
+			"disk.0.iops.read": {{1705320000.0, "10"}},
+			"disk.1.iops.read": {{1705320000.0, "20"}},
+		},
+	)
+
+	var gotTypes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTypes = r.URL.Query()["type"]
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	metrics, err := provider.GetServerMetrics(context.Background(), "42",
+		[]domain.MetricType{domain.MetricDisk, domain.MetricMemory},
+		time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if diff := cmp.Diff([]string{"disk"}, gotTypes); diff != "" {
+		t.Errorf("query types mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := metrics.TimeSeries["disk.1.iops.read"]; !ok {
+		t.Error("expected the second disk's series")
+	}
+	if _, ok := metrics.TimeSeries["memory"]; ok {
+		t.Error("expected no memory series from Hetzner")
+	}
+}
+
+func TestGetServerMetrics_MemoryOnlySkipsAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected API call to %s", r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	metrics, err := provider.GetServerMetrics(context.Background(), "42",
+		[]domain.MetricType{domain.MetricMemory}, time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(metrics.TimeSeries) != 0 {
+		t.Errorf("expected no series, got %v", metrics.TimeSeries)
+	}
+}
+
 // containsString is a small helper for substring matching in test assertions.
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && containsSubstr(s, substr)
@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// CreateNetwork creates a private network with one subnet in the given
+// network zone. Hetzner networks are free of charge.
+func (h *HetznerProvider) CreateNetwork(ctx context.Context, opts domain.CreateNetworkOpts) (*domain.NetworkSpec, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to create network: %w", err)
+	}
+	network, err := h.hcloudService.CreateNetwork(ctx, opts)
+	if err != nil {
+		return nil, hetznerNetworkError("create network", err)
+	}
+	spec := toDomainNetwork(network)
+	return &spec, nil
+}
+
+// DeleteNetwork deletes a private network. Hetzner refuses while servers
+// are still attached to it.
+func (h *HetznerProvider) DeleteNetwork(ctx context.Context, id string) error {
+	if err := h.hcloudService.DeleteNetwork(ctx, id); err != nil {
+		return hetznerNetworkError("delete network", err)
+	}
+	return nil
+}
+
+// AttachServer attaches a server to a private network. The server's
+// location must be in a network zone covered by one of its subnets.
+func (h *HetznerProvider) AttachServer(ctx context.Context, networkID, serverID, ip string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.AttachServerToNetwork(ctx, serverID, networkID, ip)
+	if err != nil {
+		return nil, hetznerNetworkError("attach server to network", err)
+	}
+	return action, nil
+}
+
+// DetachServer detaches a server from a private network.
+func (h *HetznerProvider) DetachServer(ctx context.Context, networkID, serverID string) (*domain.ActionStatus, error) {
+	action, err := h.hcloudService.DetachServerFromNetwork(ctx, serverID, networkID)
+	if err != nil {
+		return nil, hetznerNetworkError("detach server from network", err)
+	}
+	return action, nil
+}
+
+// hetznerNetworkError wraps a failed network request, mapping hcloud
+// errors to domain sentinels.
+func hetznerNetworkError(what string, err error) error {
+	switch {
+	case hcloud.IsError(err, hcloud.ErrorCodeNotFound):
+		return fmt.Errorf("failed to %s: %w", what, hetznerSentinel(err, domain.ErrNotFound))
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return fmt.Errorf("failed to %s: %w", what, hetznerSentinel(err, domain.ErrUnauthorized))
+	case hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded):
+		return fmt.Errorf("failed to %s: %w", what, hetznerSentinel(err, domain.ErrRateLimited))
+	case hcloud.IsError(err, hcloud.ErrorCodeConflict, hcloud.ErrorCodeResourceInUse, hcloud.ErrorCodeServerAlreadyAttached):
+		return fmt.Errorf("failed to %s: %w: %v", what, domain.ErrConflict, err)
+	default:
+		return fmt.Errorf("failed to %s: %w", what, err)
+	}
+}
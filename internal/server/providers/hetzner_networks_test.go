@@ -0,0 +1,134 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func TestHetznerCreateNetwork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Name    string `json:"name"`
+			IPRange string `json:"ip_range"`
+			Subnets []struct {
+				Type        string `json:"type"`
+				IPRange     string `json:"ip_range"`
+				NetworkZone string `json:"network_zone"`
+			} `json:"subnets"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.Method != http.MethodPost || r.URL.Path != "/networks" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if req.Name != "internal" || req.IPRange != "10.0.0.0/16" || len(req.Subnets) != 1 ||
+			req.Subnets[0].IPRange != "10.0.0.0/16" || req.Subnets[0].NetworkZone != "eu-central" || req.Subnets[0].Type != "cloud" {
+			t.Errorf("unexpected body %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"network": testNetworkJSON(7, "internal", "eu-central")})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	network, err := provider.CreateNetwork(context.Background(), domain.CreateNetworkOpts{
+		Name: "internal", IPRange: "10.0.0.0/16", NetworkZone: "eu-central",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if network.ID != "7" || network.IPRange != "10.0.0.0/16" {
+		t.Errorf("network = %+v", network)
+	}
+}
+
+func TestHetznerCreateNetwork_ValidatesBeforeRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	_, err := provider.CreateNetwork(context.Background(), domain.CreateNetworkOpts{
+		Name: "internal", IPRange: "10.0.0.0/16", NetworkZone: "eu-central", SubnetRange: "192.168.0.0/24",
+	})
+	if err == nil {
+		t.Fatal("expected an error for a subnet outside the range")
+	}
+}
+
+func TestHetznerDeleteNetwork_InUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/networks/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"code": "resource_in_use", "message": "network has attached servers"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	err := provider.DeleteNetwork(context.Background(), "7")
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+}
+
+func TestHetznerAttachServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/servers/42/actions/attach_to_network" || req["network"] != float64(7) || req["ip"] != "10.0.0.5" {
+			t.Errorf("unexpected request %s body=%v", r.URL.Path, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{"id": 31, "status": "running", "command": "attach_to_network"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.AttachServer(context.Background(), "7", "42", "10.0.0.5")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.ID != "31" {
+		t.Errorf("action ID = %q, want 31", action.ID)
+	}
+}
+
+func TestHetznerDetachServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if r.URL.Path != "/servers/42/actions/detach_from_network" || req["network"] != float64(7) {
+			t.Errorf("unexpected request %s body=%v", r.URL.Path, req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{"id": 32, "status": "success", "command": "detach_from_network"},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.DetachServer(context.Background(), "7", "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.Status != domain.ActionStatusSuccess {
+		t.Errorf("action = %+v", action)
+	}
+}
@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/ipv6"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// --- ReverseDNSProvider implementation ---
+
+// GetReverseDNS returns the PTR records of a server's primary IPs: its
+// IPv4 address and the addresses of its IPv6 subnet that have a record,
+// plus the subnet's ::1 address so it can be given one.
+func (h *HetznerProvider) GetReverseDNS(ctx context.Context, serverID string) ([]domain.ReverseDNS, error) {
+	hzServer, err := h.hcloudService.GetServer(ctx, serverID)
+	if err != nil {
+		return nil, hetznerReverseDNSError("get", err)
+	}
+	if hzServer == nil {
+		return nil, fmt.Errorf("server %q: %w", serverID, domain.ErrNotFound)
+	}
+	return toDomainReverseDNS(hzServer.PublicNet), nil
+}
+
+// SetReverseDNS sets the PTR record of one of a server's primary IPs, or
+// of any address in its IPv6 subnet. An empty hostname resets it to
+// Hetzner's default, e.g. static.7.113.0.203.clients.your-server.de.
+func (h *HetznerProvider) SetReverseDNS(ctx context.Context, serverID, ip, hostname string) (*domain.ActionStatus, error) {
+	if _, err := netip.ParseAddr(ip); err != nil {
+		return nil, fmt.Errorf("invalid IP address %q: %w", ip, err)
+	}
+	var ptr *string
+	if hostname != "" {
+		ptr = hcloud.Ptr(hostname)
+	}
+	action, err := h.hcloudService.ChangeDNSPtr(ctx, serverID, ip, ptr)
+	if err != nil {
+		return nil, hetznerReverseDNSError("set", err)
+	}
+	return action, nil
+}
+
+// hetznerReverseDNSError wraps a failed reverse DNS request, mapping
+// hcloud errors to domain sentinels.
+func hetznerReverseDNSError(verb string, err error) error {
+	switch {
+	case hcloud.IsError(err, hcloud.ErrorCodeNotFound):
+		return fmt.Errorf("failed to %s reverse DNS: %w", verb, hetznerSentinel(err, domain.ErrNotFound))
+	case hcloud.IsError(err, hcloud.ErrorCodeUnauthorized):
+		return fmt.Errorf("failed to %s reverse DNS: %w", verb, hetznerSentinel(err, domain.ErrUnauthorized))
+	case hcloud.IsError(err, hcloud.ErrorCodeRateLimitExceeded):
+		return fmt.Errorf("failed to %s reverse DNS: %w", verb, hetznerSentinel(err, domain.ErrRateLimited))
+	case hcloud.IsError(err, hcloud.ErrorCodeConflict):
+		return fmt.Errorf("failed to %s reverse DNS: %w", verb, hetznerSentinel(err, domain.ErrConflict))
+	default:
+		return fmt.Errorf("failed to %s reverse DNS: %w", verb, err)
+	}
+}
+
+// toDomainReverseDNS lists the PTR records of a server's public network,
+// IPv4 first and IPv6 addresses in order.
+func toDomainReverseDNS(net hcloud.ServerPublicNet) []domain.ReverseDNS {
+	var records []domain.ReverseDNS
+	if !net.IPv4.IsUnspecified() {
+		records = append(records, domain.ReverseDNS{IP: net.IPv4.IP.String(), Hostname: net.IPv4.DNSPtr})
+	}
+
+	var v6 []domain.ReverseDNS
+	for ip, ptr := range net.IPv6.DNSPtr {
+		v6 = append(v6, domain.ReverseDNS{IP: ip, Hostname: ptr})
+	}
+	if !net.IPv6.IsUnspecified() && net.IPv6.Network != nil {
+		if addr, err := ipv6.DefaultAddress(net.IPv6.Network.String()); err == nil {
+			if _, ok := net.IPv6.DNSPtr[addr.String()]; !ok {
+				v6 = append(v6, domain.ReverseDNS{IP: addr.String()})
+			}
+		}
+	}
+	slices.SortFunc(v6, func(a, b domain.ReverseDNS) int {
+		addrA, errA := netip.ParseAddr(a.IP)
+		addrB, errB := netip.ParseAddr(b.IP)
+		if errA != nil || errB != nil {
+			return strings.Compare(a.IP, b.IP)
+		}
+		return addrA.Compare(addrB)
+	})
+	return append(records, v6...)
+}
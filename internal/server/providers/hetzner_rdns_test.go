@@ -0,0 +1,99 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHetznerGetReverseDNS(t *testing.T) {
+	server := testServerJSON(42, "web", "running", "2024-06-15T12:00:00+00:00",
+		testLocationJSON(1, "fsn1", "DE", "Falkenstein"), testServerTypeJSON(1, "cx22", "x86"))
+	server["public_net"] = map[string]interface{}{
+		"ipv4": map[string]interface{}{"ip": "203.0.113.7", "dns_ptr": "web.example.com"},
+		"ipv6": map[string]interface{}{
+			"ip": "2001:db8::/64",
+			"dns_ptr": []interface{}{
+				map[string]interface{}{"ip": "2001:db8::2", "dns_ptr": "mail.example.com"},
+			},
+		},
+		"floating_ips": []interface{}{},
+		"firewalls":    []interface{}{},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/42" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"server": server})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	got, err := provider.GetReverseDNS(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := []domain.ReverseDNS{
+		{IP: "203.0.113.7", Hostname: "web.example.com"},
+		{IP: "2001:db8::1"},
+		{IP: "2001:db8::2", Hostname: "mail.example.com"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetReverseDNS mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHetznerSetReverseDNS(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostname string
+		wantPtr  interface{}
+	}{
+		{name: "set", hostname: "web.example.com", wantPtr: "web.example.com"},
+		{name: "reset", hostname: "", wantPtr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&req)
+				if r.Method != http.MethodPost || r.URL.Path != "/servers/42/actions/change_dns_ptr" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				if req["ip"] != "203.0.113.7" || req["dns_ptr"] != tt.wantPtr {
+					t.Errorf("unexpected body %v", req)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"action": map[string]interface{}{"id": 10, "status": "running", "command": "change_dns_ptr"},
+				})
+			}))
+			t.Cleanup(srv.Close)
+
+			provider := newTestHetznerProvider(t, srv.URL, "test-token")
+			action, err := provider.SetReverseDNS(context.Background(), "42", "203.0.113.7", tt.hostname)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if action.ID != "10" {
+				t.Errorf("action ID = %q, want 10", action.ID)
+			}
+		})
+	}
+}
+
+func TestHetznerSetReverseDNS_InvalidIP(t *testing.T) {
+	provider := newTestHetznerProvider(t, "http://127.0.0.1:0", "test-token")
+	if _, err := provider.SetReverseDNS(context.Background(), "42", "not-an-ip", "web.example.com"); err == nil {
+		t.Error("expected an error for an invalid IP")
+	}
+}
@@ -140,6 +140,7 @@ func TestListServers_HappyPath(t *testing.T) {
 		map[string]interface{}{"ip": "10.0.0.2", "alias_ips": []interface{}{}, "network": 1, "mac_address": ""},
 	}
 	server1["image"] = testImageJSON(1, "ubuntu-24.04", "ubuntu", "24.04", "x86")
+	server1["labels"] = map[string]interface{}{"env": "prod", "role": "web"}
 
 	server2 := testServerJSON(99, "db-server", "stopped", createdStr, nbg1, testServerTypeJSON(2, "cpx22", "arm"))
 	server2["public_net"] = map[string]interface{}{
@@ -178,17 +179,22 @@ func TestListServers_HappyPath(t *testing.T) {
 	}
 
 	wantFirst := domain.Server{
-		ID:          "42",
-		Name:        "web-server",
-		Status:      "running",
-		CreatedAt:   created,
-		PublicIPv4:  "1.2.3.4",
-		PublicIPv6:  "2001:db8::",
-		PrivateIPv4: "10.0.0.2",
-		Region:      "fsn1",
-		ServerType:  "cpx11",
-		Image:       "ubuntu-24.04",
-		Provider:    "hetzner",
+		ID:                "42",
+		Name:              "web-server",
+		Status:            "running",
+		CreatedAt:         created,
+		PublicIPv4:        "1.2.3.4",
+		PublicIPv6:        "2001:db8::",
+		PublicIPv6Network: "2001:db8::/64",
+		PrivateIPv4:       "10.0.0.2",
+		Region:            "fsn1",
+		ServerType:        "cpx11",
+		Image:             "ubuntu-24.04",
+		Provider:          "hetzner",
+		Labels:            map[string]string{"env": "prod", "role": "web"},
+		Attached: []domain.AttachedResource{
+			{Kind: domain.AttachedNetwork, ID: "1", Detail: "10.0.0.2"},
+		},
 		Metadata: map[string]interface{}{
 			"hetzner_id":   int64(42),
 			"architecture": "x86",
@@ -235,6 +241,38 @@ func TestListServers_EmptyList(t *testing.T) {
 	}
 }
 
+func TestListServersFiltered_SendsLabelSelector(t *testing.T) {
+	const createdStr = "2024-06-15T12:00:00+00:00"
+	fsn1 := testLocationJSON(1, "fsn1", "DE", "Falkenstein")
+	web := testServerJSON(42, "web-server", "running", createdStr, fsn1, testServerTypeJSON(1, "cpx11", "x86"))
+	web["labels"] = map[string]interface{}{"env": "prod", "role": "web"}
+
+	var gotSelector string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSelector = r.URL.Query().Get("label_selector")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"servers": []interface{}{web}})
+	}))
+	t.Cleanup(srv.Close)
+
+	selector, err := domain.ParseLabelSelector("role=web,env=prod")
+	if err != nil {
+		t.Fatalf("ParseLabelSelector: %v", err)
+	}
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	servers, err := provider.ListServersFiltered(context.Background(), selector)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotSelector != "env=prod,role=web" {
+		t.Errorf("label_selector = %q, want %q", gotSelector, "env=prod,role=web")
+	}
+	if len(servers) != 1 || servers[0].Name != "web-server" {
+		t.Errorf("servers = %+v, want only web-server", servers)
+	}
+}
+
 func TestListServers_RetriesOnTransientError(t *testing.T) {
 	createdStr := "2024-06-15T12:00:00+00:00"
 	loc := testLocationJSON(1, "fsn1", "DE", "Falkenstein")
@@ -311,6 +349,47 @@ func TestListServers_NilOptionalFields(t *testing.T) {
 	}
 }
 
+func TestListServers_AttachedResources(t *testing.T) {
+	loc := testLocationJSON(1, "fsn1", "DE", "Falkenstein")
+	server := testServerJSON(7, "app", "running", "2024-06-15T12:00:00+00:00", loc, testServerTypeJSON(1, "cpx11", "x86"))
+	server["public_net"] = map[string]interface{}{
+		"floating_ips": []interface{}{11},
+		"firewalls": []interface{}{
+			map[string]interface{}{"id": 21, "status": "applied"},
+		},
+	}
+	server["volumes"] = []interface{}{31, 32}
+	server["load_balancers"] = []interface{}{41}
+	server["private_net"] = []interface{}{
+		map[string]interface{}{"ip": "10.0.0.5", "alias_ips": []interface{}{}, "network": 51, "mac_address": ""},
+	}
+
+	srv := newTestAPI(t, map[string]interface{}{
+		"servers": []interface{}{server},
+	})
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	servers, err := provider.ListServers(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(servers))
+	}
+
+	want := []domain.AttachedResource{
+		{Kind: domain.AttachedVolume, ID: "31"},
+		{Kind: domain.AttachedVolume, ID: "32"},
+		{Kind: domain.AttachedFloatingIP, ID: "11"},
+		{Kind: domain.AttachedFirewall, ID: "21", Detail: "applied"},
+		{Kind: domain.AttachedLoadBalancer, ID: "41"},
+		{Kind: domain.AttachedNetwork, ID: "51", Detail: "10.0.0.5"},
+	}
+	if diff := cmp.Diff(want, servers[0].Attached); diff != "" {
+		t.Errorf("Attached mismatch (-want +got):\n%s", diff)
+	}
+}
+
 func TestListServers_Non200StatusCode(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -463,16 +542,17 @@ func TestGetServer_HappyPath(t *testing.T) {
 	}
 
 	want := &domain.Server{
-		ID:         "42",
-		Name:       "web-server",
-		Status:     "running",
-		CreatedAt:  created,
-		PublicIPv4: "1.2.3.4",
-		PublicIPv6: "2001:db8::",
-		Region:     "fsn1",
-		ServerType: "cpx11",
-		Image:      "ubuntu-24.04",
-		Provider:   "hetzner",
+		ID:                "42",
+		Name:              "web-server",
+		Status:            "running",
+		CreatedAt:         created,
+		PublicIPv4:        "1.2.3.4",
+		PublicIPv6:        "2001:db8::",
+		PublicIPv6Network: "2001:db8::/64",
+		Region:            "fsn1",
+		ServerType:        "cpx11",
+		Image:             "ubuntu-24.04",
+		Provider:          "hetzner",
 		Metadata: map[string]interface{}{
 			"hetzner_id":   int64(42),
 			"architecture": "x86",
@@ -544,6 +624,32 @@ func TestGetServer_Unauthorized(t *testing.T) {
 	}
 }
 
+func TestGetServer_UnauthorizedKeepsCorrelationID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Correlation-Id", "d5064a1f0bb9de4b")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "unauthorized",
+				"message": "unable to authenticate",
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := newTestHetznerProvider(t, srv.URL, "bad-token").GetServer(context.Background(), "42")
+	if !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got: %v", err)
+	}
+	if got := domain.RequestID(err); got != "d5064a1f0bb9de4b" {
+		t.Errorf("RequestID = %q, want the correlation ID", got)
+	}
+	if !strings.Contains(err.Error(), "request ID d5064a1f0bb9de4b") {
+		t.Errorf("expected the rendered error to include the correlation ID, got: %v", err)
+	}
+}
+
 // --- DeleteServer tests ---
 
 func TestDeleteServer_HappyPath(t *testing.T) {
@@ -637,3 +743,267 @@ func TestDeleteServer_APIError(t *testing.T) {
 		t.Errorf("expected 'failed to delete server' in error, got: %v", err)
 	}
 }
+
+// --- RebootServer / ResetServer tests ---
+
+func TestRebootServer_HappyPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/servers/42/actions/reboot" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{
+				"id":       7,
+				"status":   "running",
+				"command":  "reboot_server",
+				"progress": 0,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.RebootServer(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.ID != "7" || action.Status != domain.ActionStatusRunning {
+		t.Errorf("action = %+v, want running action 7", action)
+	}
+}
+
+func TestResizeServer_KeepsDisk(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/servers/42/actions/change_type" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var req map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req["server_type"] != "cx32" || req["upgrade_disk"] != false {
+			t.Errorf("unexpected body %v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"action": map[string]interface{}{
+				"id":       8,
+				"status":   "running",
+				"command":  "change_server_type",
+				"progress": 0,
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	action, err := provider.ResizeServer(context.Background(), "42", "cx32", false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if action.ID != "8" || action.Status != domain.ActionStatusRunning {
+		t.Errorf("action = %+v, want running action 8", action)
+	}
+}
+
+func TestResetServer_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/999/actions/reset" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    "not_found",
+				"message": "server with ID '999' not found",
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	_, err := provider.ResetServer(context.Background(), "999")
+	if !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "failed to reset server") {
+		t.Errorf("expected 'failed to reset server' in error, got: %v", err)
+	}
+}
+
+func TestRequestConsole_HappyPath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/servers/42/actions/request_console" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"wss_url":  "wss://console.hetzner.cloud/?server_id=42&token=abc",
+			"password": "s3cret",
+			"action": map[string]interface{}{
+				"id":      9,
+				"status":  "success",
+				"command": "request_console",
+			},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+	console, err := provider.RequestConsole(context.Background(), "42")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := domain.Console{URL: "wss://console.hetzner.cloud/?server_id=42&token=abc", Password: "s3cret"}
+	if *console != want {
+		t.Errorf("console = %+v, want %+v", *console, want)
+	}
+}
+
+// --- CreateServer tests ---
+
+func TestCreateServer_PublicNet(t *testing.T) {
+	const createdStr = "2024-06-15T12:00:00+00:00"
+	fsn1 := testLocationJSON(1, "fsn1", "DE", "Falkenstein")
+
+	disabled := false
+	tests := []struct {
+		name string
+		opts domain.CreateServerOpts
+		want map[string]interface{} // nil means public_net must be omitted
+	}{
+		{
+			name: "provider default",
+			opts: domain.CreateServerOpts{Name: "web", Image: "ubuntu-24.04", ServerType: "cpx11"},
+			want: nil,
+		},
+		{
+			name: "ipv6 disabled",
+			opts: domain.CreateServerOpts{Name: "web", Image: "ubuntu-24.04", ServerType: "cpx11", EnableIPv6: &disabled},
+			want: map[string]interface{}{"enable_ipv4": true, "enable_ipv6": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/servers" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"server": testServerJSON(42, "web", "initializing", createdStr, fsn1, testServerTypeJSON(1, "cpx11", "x86")),
+					"action": map[string]interface{}{"id": 1, "command": "create_server", "status": "running", "progress": 0, "started": createdStr, "resources": []interface{}{}},
+				})
+			}))
+			t.Cleanup(srv.Close)
+
+			provider := newTestHetznerProvider(t, srv.URL, "test-token")
+			if _, err := provider.CreateServer(context.Background(), tt.opts); err != nil {
+				t.Fatalf("CreateServer failed: %v", err)
+			}
+
+			got, _ := body["public_net"].(map[string]interface{})
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("expected public_net to be omitted, got %v", got)
+				}
+				return
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("public_net[%q] = %v, want %v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
+
+// networkAPI serves the location and network lookups CreateServer makes
+// before attaching networks, recording the create request body.
+func networkAPI(t *testing.T, networkZone string, body *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	const createdStr = "2024-06-15T12:00:00+00:00"
+	fsn1 := testLocationJSON(1, "fsn1", "DE", "Falkenstein")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/locations":
+			json.NewEncoder(w).Encode(map[string]interface{}{"locations": []interface{}{fsn1}})
+		case r.Method == http.MethodGet && r.URL.Path == "/networks":
+			json.NewEncoder(w).Encode(map[string]interface{}{"networks": []interface{}{
+				testNetworkJSON(7, "internal", networkZone),
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/servers":
+			json.NewDecoder(r.Body).Decode(body)
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"server": testServerJSON(42, "web", "initializing", createdStr, fsn1, testServerTypeJSON(1, "cpx11", "x86")),
+				"action": map[string]interface{}{"id": 1, "command": "create_server", "status": "running", "progress": 0, "started": createdStr, "resources": []interface{}{}},
+			})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCreateServer_AttachesNetworks(t *testing.T) {
+	var body map[string]interface{}
+	srv := networkAPI(t, "eu-central", &body)
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	opts := domain.CreateServerOpts{Name: "web", Image: "ubuntu-24.04", ServerType: "cpx11", Location: "fsn1", Networks: []string{"internal"}}
+	if _, err := provider.CreateServer(context.Background(), opts); err != nil {
+		t.Fatalf("CreateServer failed: %v", err)
+	}
+
+	networks, _ := body["networks"].([]interface{})
+	if len(networks) != 1 || networks[0] != float64(7) {
+		t.Errorf("expected networks [7] in request, got %v", body["networks"])
+	}
+}
+
+func TestCreateServer_RejectsNetworkInOtherZone(t *testing.T) {
+	var body map[string]interface{}
+	srv := networkAPI(t, "us-east", &body)
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	opts := domain.CreateServerOpts{Name: "web", Image: "ubuntu-24.04", ServerType: "cpx11", Location: "fsn1", Networks: []string{"internal"}}
+	_, err := provider.CreateServer(context.Background(), opts)
+	if err == nil {
+		t.Fatal("expected an error for a network in another zone")
+	}
+	if !strings.Contains(err.Error(), "network zone") {
+		t.Errorf("expected a network zone error, got: %v", err)
+	}
+	if body != nil {
+		t.Error("expected no create request to be sent")
+	}
+}
+
+func TestCreateServer_RejectsInterruptible(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+	provider := newTestHetznerProvider(t, srv.URL, "test-token")
+
+	opts := domain.CreateServerOpts{Name: "web", Image: "ubuntu-24.04", ServerType: "cpx11", Interruptible: true}
+	if _, err := provider.CreateServer(context.Background(), opts); err == nil {
+		t.Fatal("expected an error: Hetzner has no interruptible servers")
+	}
+	if domain.SupportsInterruptible(provider) {
+		t.Error("expected Hetzner not to advertise interruptible servers")
+	}
+}
@@ -36,8 +36,11 @@ func Register(name string, factory Factory) {
 	names.Register(normalizedName)
 }
 
+// Get resolves and constructs a provider by name. A profile name such as
+// "hetzner:work" uses that profile's token.
 func Get(name string, store auth.Store) (domain.Provider, error) {
-	normalizedName := util.NormalizeKey(name)
+	baseName, profile := auth.ParseProfile(name)
+	normalizedName := util.NormalizeKey(baseName)
 	mu.RLock()
 	factory, ok := registry[normalizedName]
 	mu.RUnlock()
@@ -46,7 +49,7 @@ func Get(name string, store auth.Store) (domain.Provider, error) {
 		return nil, fmt.Errorf("providers: unknown provider %q", name)
 	}
 
-	provider, err := factory(store)
+	provider, err := factory(auth.WithProject(store, profile))
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/actionstore"
@@ -27,12 +28,20 @@ var MaxPollAttempts = 100
 // Exported as a variable for test flexibility and consistency with PollInterval.
 var MaxTransientErrors = 3
 
+// StatusDeleted is the target status for waiting until a server is gone.
+// Polling for it succeeds once the provider reports the server not found.
+const StatusDeleted = "deleted"
+
 // Service encapsulates action tracking logic, including persistence
 // and polling across providers.
 type Service struct {
 	repo         actionstore.ActionRepository
 	provider     domain.Provider
 	providerName string
+
+	// typical is how long the action passed to TrackAction usually takes,
+	// shown by WaitForAction. Zero when there is no history.
+	typical time.Duration
 }
 
 // NewService creates a new action service.
@@ -60,12 +69,37 @@ func (s *Service) SaveRecord(record *actionstore.ActionRecord) error {
 	return s.repo.Save(record)
 }
 
+// Lock claims serverID for command so that another vpsm process starting
+// a conflicting operation on the same server is refused. It returns an
+// error matching actionstore.ErrServerBusy when another process holds the
+// server. Without a repository, or when the store fails, it allows the
+// operation.
+func (s *Service) Lock(serverID, command string) error {
+	if s == nil || s.repo == nil {
+		return nil
+	}
+	err := s.repo.AcquireLock(s.providerName, serverID, command)
+	if errors.Is(err, actionstore.ErrServerBusy) {
+		return err
+	}
+	return nil
+}
+
+// Unlock releases the claim taken by Lock.
+func (s *Service) Unlock(serverID string) {
+	if s == nil || s.repo == nil {
+		return
+	}
+	_ = s.repo.ReleaseLock(s.providerName, serverID)
+}
+
 // TrackAction persists a new action record for the given action.
 // If persistence fails, it returns nil so callers can proceed without tracking.
 func (s *Service) TrackAction(serverID, serverName string, action *domain.ActionStatus, command, targetStatus string) *actionstore.ActionRecord {
 	if s.repo == nil || action == nil {
 		return nil
 	}
+	s.typical = s.TypicalDuration(command)
 
 	record := &actionstore.ActionRecord{
 		ActionID:     action.ID,
@@ -104,6 +138,61 @@ func (s *Service) FinalizeAction(record *actionstore.ActionRecord, status, errMs
 	_ = s.repo.Save(record)
 }
 
+// TypicalDuration returns how long command usually takes on this
+// service's provider, or 0 when there is no history to go by.
+func (s *Service) TypicalDuration(command string) time.Duration {
+	if s == nil || s.repo == nil {
+		return 0
+	}
+	d, err := s.repo.TypicalDuration(s.providerName, command)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// ETA describes typical for a progress line, e.g. "usually ~25s", noting
+// when elapsed has already run past it. It returns "" when typical is 0.
+func ETA(typical, elapsed time.Duration) string {
+	if typical <= 0 {
+		return ""
+	}
+	eta := "usually ~" + formatETA(typical)
+	if elapsed > typical {
+		eta += ", taking longer than usual"
+	}
+	return eta
+}
+
+// EstimateProgress guesses how far along an action is from how long it
+// usually takes, for providers that report no progress of their own. It
+// returns 0 when typical is 0 and never claims more than 99%.
+func EstimateProgress(typical, elapsed time.Duration) int {
+	if typical <= 0 {
+		return 0
+	}
+	pct := int(elapsed * 100 / typical)
+	return max(1, min(pct, 99))
+}
+
+// formatETA rounds d to whole seconds, or whole minutes past ten minutes,
+// dropping a trailing "0s" ("2m" rather than "2m0s").
+func formatETA(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "1s"
+	case d >= 10*time.Minute:
+		d = d.Round(time.Minute)
+	default:
+		d = d.Round(time.Second)
+	}
+	str := d.String()
+	if strings.HasSuffix(str, "m0s") {
+		str = strings.TrimSuffix(str, "0s")
+	}
+	return str
+}
+
 // ListPending returns all pending action records.
 func (s *Service) ListPending() ([]actionstore.ActionRecord, error) {
 	if s.repo == nil {
@@ -193,6 +282,9 @@ func (s *Service) WaitForAction(
 	if action == nil {
 		return nil
 	}
+	if s.typical > 0 && !action.IsComplete() {
+		fmt.Fprintf(w, "  Usually takes ~%s.\n", formatETA(s.typical))
+	}
 
 	// Handle action errors immediately — no need to check server status.
 	if action.Status == domain.ActionStatusError {
@@ -259,6 +351,7 @@ func (s *Service) pollByAction(
 	w io.Writer,
 ) error {
 	var consecutiveErrors int
+	start := time.Now()
 
 	for i := 0; i < MaxPollAttempts; i++ {
 		select {
@@ -293,7 +386,12 @@ func (s *Service) pollByAction(
 			return fmt.Errorf("action failed")
 		default:
 			// Still running -- log progress and continue.
-			if status.Progress > 0 {
+			elapsed := time.Since(start)
+			if eta := ETA(s.typical, elapsed); eta != "" {
+				// Hetzner often reports 0% until the very end, so the
+				// elapsed time against the usual duration says more.
+				fmt.Fprintf(w, "  Progress: %d%% (%s elapsed, %s)\n", status.Progress, formatETA(elapsed), eta)
+			} else if status.Progress > 0 {
 				fmt.Fprintf(w, "  Progress: %d%%\n", status.Progress)
 			}
 		}
@@ -303,7 +401,8 @@ func (s *Service) pollByAction(
 }
 
 // pollByServerStatus repeatedly calls [domain.Provider.GetServer] until the
-// server's Status matches targetStatus.
+// server's Status matches targetStatus, or, for [StatusDeleted], until the
+// server no longer exists.
 //
 // This is the generic fallback for providers that do not expose an action
 // polling API. It works for any provider since GetServer is part of the
@@ -315,6 +414,7 @@ func (s *Service) pollByServerStatus(
 	w io.Writer,
 ) error {
 	var consecutiveErrors int
+	start := time.Now()
 
 	for i := 0; i < MaxPollAttempts; i++ {
 		select {
@@ -324,6 +424,9 @@ func (s *Service) pollByServerStatus(
 		}
 
 		server, err := s.provider.GetServer(ctx, serverID)
+		if targetStatus == StatusDeleted && ((server == nil && err == nil) || errors.Is(err, domain.ErrNotFound)) {
+			return nil
+		}
 		if err != nil {
 			if errors.Is(err, domain.ErrRateLimited) {
 				return fmt.Errorf("polling stopped: %w", err)
@@ -345,8 +448,13 @@ func (s *Service) pollByServerStatus(
 			return nil
 		}
 
-		// Show the server's transitional status (e.g. "starting", "stopping").
-		fmt.Fprintf(w, "  Status: %s\n", server.Status)
+		// Show the server's transitional status (e.g. "starting", "stopping"),
+		// with an estimate of how far along it is when there is history.
+		if pct := EstimateProgress(s.typical, time.Since(start)); pct > 0 {
+			fmt.Fprintf(w, "  Status: %s (~%d%%)\n", server.Status, pct)
+		} else {
+			fmt.Fprintf(w, "  Status: %s\n", server.Status)
+		}
 	}
 
 	return fmt.Errorf("timed out waiting for server to reach %q status (%d polls)", targetStatus, MaxPollAttempts)
@@ -1,8 +1,12 @@
 package action
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +22,9 @@ type mockRepository struct {
 	listRecentErr      error
 	deleteOlderThanErr error
 	deletedCount       int64
+	typical            time.Duration
+	lockErr            error
+	released           []string
 }
 
 func (m *mockRepository) Save(record *actionstore.ActionRecord) error {
@@ -72,6 +79,19 @@ func (m *mockRepository) DeleteOlderThan(d time.Duration) (int64, error) {
 	return m.deletedCount, nil
 }
 
+func (m *mockRepository) TypicalDuration(provider, command string) (time.Duration, error) {
+	return m.typical, nil
+}
+
+func (m *mockRepository) AcquireLock(provider, serverID, command string) error {
+	return m.lockErr
+}
+
+func (m *mockRepository) ReleaseLock(provider, serverID string) error {
+	m.released = append(m.released, serverID)
+	return nil
+}
+
 func (m *mockRepository) Close() error {
 	return nil
 }
@@ -113,6 +133,35 @@ func (m *mockProvider) StopServer(ctx context.Context, id string) (*domain.Actio
 	return nil, nil
 }
 
+func TestLock_Busy(t *testing.T) {
+	busy := &actionstore.LockedError{ServerID: "42", Command: "stop_server", PID: 4242}
+	svc := NewService(nil, "test", &mockRepository{lockErr: busy})
+
+	err := svc.Lock("42", "delete_server")
+	if !errors.Is(err, actionstore.ErrServerBusy) {
+		t.Fatalf("expected ErrServerBusy, got %v", err)
+	}
+}
+
+func TestLock_StoreErrorAllowsOperation(t *testing.T) {
+	svc := NewService(nil, "test", &mockRepository{lockErr: errors.New("disk I/O error")})
+
+	if err := svc.Lock("42", "delete_server"); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+}
+
+func TestUnlock_ReleasesServer(t *testing.T) {
+	repo := &mockRepository{}
+	svc := NewService(nil, "test", repo)
+
+	svc.Unlock("42")
+
+	if len(repo.released) != 1 || repo.released[0] != "42" {
+		t.Errorf("expected server 42 to be released, got %v", repo.released)
+	}
+}
+
 func TestNewService(t *testing.T) {
 	repo := &mockRepository{}
 	provider := &mockProvider{}
@@ -380,3 +429,140 @@ func TestService_Cleanup(t *testing.T) {
 		}
 	})
 }
+
+func TestETA(t *testing.T) {
+	tests := []struct {
+		typical, elapsed time.Duration
+		want             string
+	}{
+		{0, 5 * time.Second, ""},
+		{25 * time.Second, 0, "usually ~25s"},
+		{25400 * time.Millisecond, 10 * time.Second, "usually ~25s"},
+		{90 * time.Second, 0, "usually ~1m30s"},
+		{2 * time.Minute, 0, "usually ~2m"},
+		{25 * time.Second, 40 * time.Second, "usually ~25s, taking longer than usual"},
+		{12*time.Minute + 20*time.Second, 0, "usually ~12m"},
+	}
+	for _, tt := range tests {
+		if got := ETA(tt.typical, tt.elapsed); got != tt.want {
+			t.Errorf("ETA(%v, %v) = %q, want %q", tt.typical, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+// pollingProvider reports a running action a fixed number of times before
+// it succeeds.
+type pollingProvider struct {
+	mockProvider
+	polls int
+}
+
+func (p *pollingProvider) PollAction(ctx context.Context, actionID string) (*domain.ActionStatus, error) {
+	p.polls--
+	if p.polls > 0 {
+		return &domain.ActionStatus{ID: actionID, Status: domain.ActionStatusRunning}, nil
+	}
+	return &domain.ActionStatus{ID: actionID, Status: domain.ActionStatusSuccess}, nil
+}
+
+func TestService_WaitForAction_ShowsTypicalDuration(t *testing.T) {
+	orig := PollInterval
+	PollInterval = time.Millisecond
+	t.Cleanup(func() { PollInterval = orig })
+
+	provider := &pollingProvider{mockProvider: mockProvider{server: &domain.Server{Status: "off"}}, polls: 2}
+	svc := NewService(provider, "test", &mockRepository{typical: 25 * time.Second})
+	action := &domain.ActionStatus{ID: "act-1", Status: domain.ActionStatusRunning}
+	svc.TrackAction("server-1", "web-1", action, "stop_server", "off")
+
+	var out bytes.Buffer
+	if err := svc.WaitForAction(context.Background(), action, "server-1", "off", &out); err != nil {
+		t.Fatalf("WaitForAction failed: %v", err)
+	}
+	for _, want := range []string{"Usually takes ~25s.", "Progress: 0% (", "usually ~25s)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestService_WaitForAction_NoHistoryKeepsPercentOnly(t *testing.T) {
+	orig := PollInterval
+	PollInterval = time.Millisecond
+	t.Cleanup(func() { PollInterval = orig })
+
+	provider := &pollingProvider{mockProvider: mockProvider{server: &domain.Server{Status: "off"}}, polls: 2}
+	svc := NewService(provider, "test", &mockRepository{})
+	action := &domain.ActionStatus{ID: "act-1", Status: domain.ActionStatusRunning}
+	svc.TrackAction("server-1", "web-1", action, "stop_server", "off")
+
+	var out bytes.Buffer
+	if err := svc.WaitForAction(context.Background(), action, "server-1", "off", &out); err != nil {
+		t.Fatalf("WaitForAction failed: %v", err)
+	}
+	if strings.Contains(out.String(), "usually") || strings.Contains(out.String(), "Progress: 0%") {
+		t.Errorf("expected no ETA without history, got:\n%s", out.String())
+	}
+}
+
+func TestEstimateProgress(t *testing.T) {
+	tests := []struct {
+		typical, elapsed time.Duration
+		want             int
+	}{
+		{0, 10 * time.Second, 0},
+		{20 * time.Second, 0, 1},
+		{20 * time.Second, 5 * time.Second, 25},
+		{20 * time.Second, time.Minute, 99},
+	}
+	for _, tt := range tests {
+		if got := EstimateProgress(tt.typical, tt.elapsed); got != tt.want {
+			t.Errorf("EstimateProgress(%s, %s) = %d, want %d", tt.typical, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+// vanishingProvider reports the server as deleting for a few polls and
+// then as not found.
+type vanishingProvider struct {
+	mockProvider
+	polls int
+}
+
+func (p *vanishingProvider) GetServer(ctx context.Context, id string) (*domain.Server, error) {
+	if p.polls == 0 {
+		return nil, fmt.Errorf("server %q: %w", id, domain.ErrNotFound)
+	}
+	p.polls--
+	return &domain.Server{ID: id, Status: "deleting"}, nil
+}
+
+func TestService_WaitForAction_Deleted(t *testing.T) {
+	orig := PollInterval
+	PollInterval = time.Millisecond
+	t.Cleanup(func() { PollInterval = orig })
+
+	svc := NewService(&vanishingProvider{polls: 2}, "test", &mockRepository{typical: time.Hour})
+	action := &domain.ActionStatus{Status: domain.ActionStatusRunning}
+	svc.TrackAction("server-1", "web-1", action, "delete_server", StatusDeleted)
+
+	var out bytes.Buffer
+	if err := svc.WaitForAction(context.Background(), action, "server-1", StatusDeleted, &out); err != nil {
+		t.Fatalf("WaitForAction failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Status: deleting (~1%)") {
+		t.Errorf("expected estimated progress in output, got:\n%s", out.String())
+	}
+}
+
+func TestService_WaitForAction_NotFoundFailsOtherTargets(t *testing.T) {
+	orig := PollInterval
+	PollInterval = time.Millisecond
+	t.Cleanup(func() { PollInterval = orig })
+
+	svc := NewService(&vanishingProvider{}, "test", nil)
+	action := &domain.ActionStatus{Status: domain.ActionStatusRunning}
+	if err := svc.WaitForAction(context.Background(), action, "server-1", "running", io.Discard); err == nil {
+		t.Fatal("expected a missing server to fail a wait for running")
+	}
+}
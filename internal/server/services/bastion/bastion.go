@@ -0,0 +1,84 @@
+// Package bastion resolves the SSH jump host used to reach a server.
+//
+// A bastion can be configured per server, per server group or as a
+// provider-wide default. The most specific setting wins.
+package bastion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+)
+
+// Validate checks that bastion is a usable ssh -J destination of the form
+// "[user@]host[:port]". Comma-separated chains of jump hosts are accepted.
+func Validate(bastion string) error {
+	if bastion == "" {
+		return fmt.Errorf("bastion must not be empty")
+	}
+	for _, hop := range strings.Split(bastion, ",") {
+		if hop == "" || strings.HasPrefix(hop, "-") || strings.ContainsAny(hop, " \t\n'\"") {
+			return fmt.Errorf("invalid bastion %q: expected [user@]host[:port]", bastion)
+		}
+		host := hop
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+		if host == "" || strings.HasPrefix(host, ":") {
+			return fmt.Errorf("invalid bastion %q: missing host", bastion)
+		}
+	}
+	return nil
+}
+
+// Resolve returns the jump host for server, or "" to connect directly.
+// The server's own preference wins, then the first group in groups that
+// contains the server, then the provider-wide default. prefs may be nil.
+func Resolve(prefs *prefssvc.Service, groups []servergroups.Group, providerName string, server domain.Server) string {
+	if prefs != nil {
+		if b := prefs.GetBastion(providerName, server.ID); b != "" {
+			return b
+		}
+	}
+	for _, g := range groups {
+		if g.Bastion != "" && contains(g, server) {
+			return g.Bastion
+		}
+	}
+	if prefs != nil {
+		return prefs.GetBastion(providerName, serverprefs.AllServers)
+	}
+	return ""
+}
+
+// Lookup is Resolve with the provider's groups loaded from the local
+// store. Failing to open the store is not an error; groups are skipped.
+func Lookup(prefs *prefssvc.Service, providerName string, server domain.Server) string {
+	var groups []servergroups.Group
+	if repo, err := servergroups.Open(); err == nil {
+		groups, _ = repo.List(providerName)
+		repo.Close()
+	}
+	return Resolve(prefs, groups, providerName, server)
+}
+
+// contains reports whether server is a member of g, either explicitly or
+// through its label selector.
+func contains(g servergroups.Group, server domain.Server) bool {
+	if slices.Contains(g.ServerIDs, server.ID) {
+		return true
+	}
+	if g.Selector == "" {
+		return false
+	}
+	sel, err := domain.ParseLabelSelector(g.Selector)
+	if err != nil {
+		return false
+	}
+	return sel.Matches(server.Labels)
+}
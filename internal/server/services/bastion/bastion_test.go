@@ -0,0 +1,74 @@
+package bastion
+
+import (
+	"path/filepath"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+)
+
+func tempPrefs(t *testing.T) *prefssvc.Service {
+	t.Helper()
+	repo, err := serverprefs.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	svc := prefssvc.NewService(repo)
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"bastion", "jump@203.0.113.10", "admin@bastion.example.com:2222", "a@one,b@two:22"}
+	for _, b := range valid {
+		if err := Validate(b); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", b, err)
+		}
+	}
+
+	invalid := []string{"", "-oProxyCommand=x", "user@", "jump host", "a,,b", ":22"}
+	for _, b := range invalid {
+		if err := Validate(b); err == nil {
+			t.Errorf("Validate(%q) = nil, want error", b)
+		}
+	}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	prefs := tempPrefs(t)
+	prefs.SetBastion("hetzner", serverprefs.AllServers, "provider-jump")
+	prefs.SetBastion("hetzner", "1", "server-jump")
+
+	groups := []servergroups.Group{
+		{Name: "private", Selector: "net=private", Bastion: "group-jump"},
+		{Name: "explicit", ServerIDs: []string{"3"}, Bastion: "explicit-jump"},
+	}
+
+	tests := []struct {
+		name   string
+		server domain.Server
+		want   string
+	}{
+		{"server pref wins", domain.Server{ID: "1", Labels: map[string]string{"net": "private"}}, "server-jump"},
+		{"selector group", domain.Server{ID: "2", Labels: map[string]string{"net": "private"}}, "group-jump"},
+		{"explicit group", domain.Server{ID: "3"}, "explicit-jump"},
+		{"provider default", domain.Server{ID: "4"}, "provider-jump"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resolve(prefs, groups, "hetzner", tt.server); got != tt.want {
+				t.Errorf("Resolve = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_NilPrefs(t *testing.T) {
+	if got := Resolve(nil, nil, "hetzner", domain.Server{ID: "1"}); got != "" {
+		t.Errorf("Resolve = %q, want empty", got)
+	}
+}
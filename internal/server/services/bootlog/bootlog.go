@@ -0,0 +1,64 @@
+// Package bootlog fetches a server's boot output: the provider's console
+// output when available, otherwise the cloud-init output log over SSH.
+package bootlog
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+)
+
+// CloudInitLogPath is the cloud-init output log on the remote server.
+const CloudInitLogPath = "/var/log/cloud-init-output.log"
+
+// DefaultLines is the number of trailing log lines fetched over SSH.
+const DefaultLines = 500
+
+// Source describes where a log was fetched from.
+type Source string
+
+const (
+	SourceConsole Source = "console"
+	SourceSSH     Source = "ssh"
+)
+
+// Log is the fetched boot output.
+type Log struct {
+	Source  Source
+	Content string
+}
+
+// Fetch returns the boot output for a server. Provider console output is
+// preferred when the provider supports it; otherwise the cloud-init log is
+// read over SSH using conn.
+func Fetch(ctx context.Context, provider domain.Provider, server domain.Server, conn remote.Conn) (*Log, error) {
+	if cp, ok := provider.(domain.ConsoleOutputProvider); ok {
+		out, err := cp.GetConsoleOutput(ctx, server.ID)
+		if err == nil {
+			return &Log{Source: SourceConsole, Content: out}, nil
+		}
+		// Fall through to SSH if the console is unavailable.
+	}
+
+	content, err := FetchCloudInitLog(ctx, server, conn, DefaultLines)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{Source: SourceSSH, Content: content}, nil
+}
+
+// FetchCloudInitLog reads the last n lines of the cloud-init output log over
+// a non-interactive SSH connection.
+func FetchCloudInitLog(ctx context.Context, server domain.Server, conn remote.Conn, n int) (string, error) {
+	if _, err := remote.HostVia(server, conn.Bastion); err != nil {
+		return "", err
+	}
+
+	out, err := remote.Exec(ctx, server, conn, fmt.Sprintf("tail -n %d %s", n, CloudInitLogPath))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s over SSH: %w", CloudInitLogPath, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,101 @@
+package bootlog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+)
+
+type stubProvider struct {
+	domain.Provider
+}
+
+type consoleProvider struct {
+	stubProvider
+	output string
+	err    error
+}
+
+func (p *consoleProvider) GetConsoleOutput(_ context.Context, _ string) (string, error) {
+	return p.output, p.err
+}
+
+func stubSSH(t *testing.T, out string, err error) *[]string {
+	t.Helper()
+	var got []string
+	orig := remote.Runner
+	remote.Runner = func(_ context.Context, args ...string) ([]byte, error) {
+		got = args
+		return []byte(out), err
+	}
+	t.Cleanup(func() { remote.Runner = orig })
+	return &got
+}
+
+func TestFetch_PrefersConsoleOutput(t *testing.T) {
+	args := stubSSH(t, "ssh output", nil)
+
+	log, err := Fetch(context.Background(), &consoleProvider{output: "console output"}, domain.Server{PublicIPv4: "1.2.3.4"}, remote.Conn{Username: "root"})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if log.Source != SourceConsole || log.Content != "console output" {
+		t.Errorf("unexpected log: %+v", log)
+	}
+	if *args != nil {
+		t.Errorf("expected SSH not to be used, got args %v", *args)
+	}
+}
+
+func TestFetch_FallsBackToSSH(t *testing.T) {
+	args := stubSSH(t, "Cloud-init finished", nil)
+
+	provider := &consoleProvider{err: errors.New("unavailable")}
+	log, err := Fetch(context.Background(), provider, domain.Server{PublicIPv4: "1.2.3.4"}, remote.Conn{Username: "ubuntu"})
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if log.Source != SourceSSH || log.Content != "Cloud-init finished" {
+		t.Errorf("unexpected log: %+v", log)
+	}
+
+	joined := strings.Join(*args, " ")
+	for _, want := range []string{"BatchMode=yes", "ubuntu@1.2.3.4", "tail -n 500 " + CloudInitLogPath} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("expected %q in ssh args: %s", want, joined)
+		}
+	}
+}
+
+func TestFetchCloudInitLog_IPv6Fallback(t *testing.T) {
+	args := stubSSH(t, "", nil)
+
+	if _, err := FetchCloudInitLog(context.Background(), domain.Server{PublicIPv6: "2001:db8::1"}, remote.Conn{}, 10); err != nil {
+		t.Fatalf("FetchCloudInitLog failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(*args, " "), "root@2001:db8::1") {
+		t.Errorf("expected default user and IPv6 host, got %v", *args)
+	}
+}
+
+func TestFetchCloudInitLog_NoIP(t *testing.T) {
+	stubSSH(t, "", nil)
+
+	_, err := FetchCloudInitLog(context.Background(), domain.Server{Name: "web"}, remote.Conn{Username: "root"}, 10)
+	if err == nil || !strings.Contains(err.Error(), "no public IP") {
+		t.Errorf("expected no public IP error, got %v", err)
+	}
+}
+
+func TestFetchCloudInitLog_SSHError(t *testing.T) {
+	stubSSH(t, "", errors.New("exit status 255"))
+
+	_, err := FetchCloudInitLog(context.Background(), domain.Server{PublicIPv4: "1.2.3.4"}, remote.Conn{Username: "root"}, 10)
+	if err == nil || !strings.Contains(err.Error(), "failed to read") {
+		t.Errorf("expected wrapped SSH error, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Package broadcast opens interactive SSH sessions to several servers at
+// once in a tmux window with synchronized panes, so typed input reaches
+// every server while each keeps its own output pane.
+package broadcast
+
+import (
+	"fmt"
+	"os/exec"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+)
+
+// Skipped is a server left out of a broadcast and why.
+type Skipped struct {
+	Server domain.Server
+	Reason string
+}
+
+// Panes returns one ssh pane per running, reachable server. The login is
+// username when set, else the server's saved SSH user, else root; ssh
+// options and bastions are resolved as for 'vpsm server ssh'. prefs may
+// be nil. Servers that cannot be reached are returned in skipped.
+func Panes(prefs *prefssvc.Service, providerName string, servers []domain.Server, username, defaultOptions string) (panes []tmux.Pane, skipped []Skipped) {
+	for _, s := range servers {
+		if s.Status != "running" {
+			skipped = append(skipped, Skipped{Server: s, Reason: fmt.Sprintf("not running (status: %s)", s.Status)})
+			continue
+		}
+		via := bastion.Lookup(prefs, providerName, s)
+		host, err := remote.HostVia(s, via)
+		if err != nil {
+			skipped = append(skipped, Skipped{Server: s, Reason: "no public IP address"})
+			continue
+		}
+
+		login := username
+		var serverOptions string
+		if prefs != nil {
+			if login == "" {
+				login = prefs.GetSSHUser(providerName, s.ID)
+			}
+			serverOptions = prefs.GetSSHOptions(providerName, s.ID)
+		}
+		if login == "" {
+			login = remote.DefaultUser
+		}
+
+		// User options first: ssh keeps the first value it sees.
+		argv := append([]string{"ssh"}, remote.UserArgs(serverOptions, defaultOptions)...)
+		argv = append(argv,
+			"-o", "StrictHostKeyChecking=accept-new",
+			"-o", "ConnectTimeout=10",
+			"-o", "ServerAliveInterval=60",
+			"-o", "ServerAliveCountMax=3",
+		)
+		argv = append(argv, remote.RouteArgs(via)...)
+		argv = append(argv, fmt.Sprintf("%s@%s", login, host))
+
+		panes = append(panes, tmux.Pane{Title: s.Name, Argv: argv})
+	}
+	return panes, skipped
+}
+
+// SessionName returns a tmux session name for a broadcast started at now.
+func SessionName(now time.Time) string {
+	return fmt.Sprintf("vpsm-broadcast-%d", now.Unix())
+}
+
+// Run runs a tmux command. Tests replace it.
+var Run = func(cmd *exec.Cmd) error {
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if len(out) > 0 {
+			return fmt.Errorf("%w: %s", err, out)
+		}
+		return err
+	}
+	return nil
+}
+
+// Open creates the broadcast session with one pane per entry in panes.
+// It does not attach; use tmux.Attach(session) for that.
+func Open(session string, panes []tmux.Pane) error {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return fmt.Errorf("broadcast sessions need tmux installed: %w", err)
+	}
+	cmds, err := tmux.Broadcast(session, panes)
+	if err != nil {
+		return err
+	}
+	for _, cmd := range cmds {
+		if err := Run(cmd); err != nil {
+			return fmt.Errorf("failed to set up tmux session: %w", err)
+		}
+	}
+	return nil
+}
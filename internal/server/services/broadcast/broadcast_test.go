@@ -0,0 +1,54 @@
+package broadcast
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func TestPanes(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	servers := []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"},
+		{ID: "2", Name: "web-2", Status: "off", PublicIPv4: "192.0.2.2"},
+		{ID: "3", Name: "web-3", Status: "running"},
+		{ID: "4", Name: "web-4", Status: "running", PublicIPv4: "192.0.2.4"},
+	}
+
+	panes, skipped := Panes(nil, "hetzner", servers, "deploy", "-o ForwardAgent=yes")
+
+	if len(panes) != 2 || panes[0].Title != "web-1" || panes[1].Title != "web-4" {
+		t.Fatalf("panes = %+v, want web-1 and web-4", panes)
+	}
+	argv := panes[0].Argv
+	if argv[0] != "ssh" || argv[len(argv)-1] != "deploy@192.0.2.1" {
+		t.Errorf("argv = %v, want ssh ... deploy@192.0.2.1", argv)
+	}
+	if !slices.Equal(argv[1:3], []string{"-o", "ForwardAgent=yes"}) {
+		t.Errorf("expected user options before built-in ones, got %v", argv)
+	}
+
+	if len(skipped) != 2 || skipped[0].Server.ID != "2" || skipped[1].Server.ID != "3" {
+		t.Errorf("skipped = %+v, want servers 2 and 3", skipped)
+	}
+}
+
+func TestPanes_DefaultsToRoot(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	panes, _ := Panes(nil, "hetzner", []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"},
+	}, "", "")
+	if got := panes[0].Argv[len(panes[0].Argv)-1]; got != "root@192.0.2.1" {
+		t.Errorf("login = %q, want root@192.0.2.1", got)
+	}
+}
+
+func TestSessionName(t *testing.T) {
+	if got := SessionName(time.Unix(1700000000, 0)); got != "vpsm-broadcast-1700000000" {
+		t.Errorf("SessionName() = %q", got)
+	}
+}
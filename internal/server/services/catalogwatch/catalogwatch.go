@@ -0,0 +1,59 @@
+// Package catalogwatch detects changes to a provider's catalog between
+// sessions (new server types, price changes, new locations) so they can
+// be shown once as a "what's new" notice.
+//
+// The catalog seen last is kept as a snapshot in the file cache next to
+// the providers' cached catalog listings.
+package catalogwatch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// snapshotTTL is how long a snapshot is kept. It only bounds how stale a
+// comparison can get for a provider that has not been used in a while.
+const snapshotTTL = 365 * 24 * time.Hour
+
+// Catalog is the part of a domain.CatalogProvider that Check compares.
+type Catalog interface {
+	ListServerTypes(ctx context.Context) ([]domain.ServerTypeSpec, error)
+	ListLocations(ctx context.Context) ([]domain.Location, error)
+}
+
+func snapshotKey(providerName string) string {
+	return "catalog_snapshot_" + providerName
+}
+
+// Check fetches provider's server types and locations, compares them with
+// the snapshot stored for providerName, and stores the current catalog as
+// the new snapshot. The first check for a provider only stores a snapshot
+// and reports no changes, and a change is reported by one check only.
+func Check(ctx context.Context, c *cache.Cache, provider Catalog, providerName string) (domain.CatalogChanges, error) {
+	serverTypes, err := provider.ListServerTypes(ctx)
+	if err != nil {
+		return domain.CatalogChanges{}, fmt.Errorf("failed to list server types: %w", err)
+	}
+	locations, err := provider.ListLocations(ctx)
+	if err != nil {
+		return domain.CatalogChanges{}, fmt.Errorf("failed to list locations: %w", err)
+	}
+	current := domain.CatalogSnapshot{ServerTypes: serverTypes, Locations: locations}
+
+	var previous domain.CatalogSnapshot
+	hit, err := c.Get(snapshotKey(providerName), snapshotTTL, &previous)
+	if err != nil {
+		return domain.CatalogChanges{}, fmt.Errorf("failed to read catalog snapshot: %w", err)
+	}
+	if err := c.Set(snapshotKey(providerName), current); err != nil {
+		return domain.CatalogChanges{}, fmt.Errorf("failed to save catalog snapshot: %w", err)
+	}
+	if !hit {
+		return domain.CatalogChanges{}, nil
+	}
+	return domain.DiffCatalog(previous, current), nil
+}
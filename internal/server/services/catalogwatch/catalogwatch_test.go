@@ -0,0 +1,89 @@
+package catalogwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+type fakeCatalog struct {
+	serverTypes []domain.ServerTypeSpec
+	locations   []domain.Location
+	err         error
+}
+
+func (f *fakeCatalog) ListServerTypes(context.Context) ([]domain.ServerTypeSpec, error) {
+	return f.serverTypes, f.err
+}
+
+func (f *fakeCatalog) ListLocations(context.Context) ([]domain.Location, error) {
+	return f.locations, f.err
+}
+
+func TestCheck_ReportsChangesOnce(t *testing.T) {
+	c := cache.New(t.TempDir())
+	catalog := &fakeCatalog{
+		serverTypes: []domain.ServerTypeSpec{{Name: "cpx11"}},
+		locations:   []domain.Location{{Name: "fsn1"}},
+	}
+
+	changes, err := Check(context.Background(), c, catalog, "hetzner")
+	if err != nil {
+		t.Fatalf("first Check: %v", err)
+	}
+	if !changes.Empty() {
+		t.Errorf("expected no changes on first check, got %+v", changes)
+	}
+
+	catalog.serverTypes = append(catalog.serverTypes, domain.ServerTypeSpec{Name: "cax11"})
+	catalog.locations = append(catalog.locations, domain.Location{Name: "sin"})
+
+	changes, err = Check(context.Background(), c, catalog, "hetzner")
+	if err != nil {
+		t.Fatalf("second Check: %v", err)
+	}
+	if len(changes.AddedServerTypes) != 1 || changes.AddedServerTypes[0].Name != "cax11" {
+		t.Errorf("AddedServerTypes = %+v, want cax11", changes.AddedServerTypes)
+	}
+	if len(changes.AddedLocations) != 1 || changes.AddedLocations[0].Name != "sin" {
+		t.Errorf("AddedLocations = %+v, want sin", changes.AddedLocations)
+	}
+
+	changes, err = Check(context.Background(), c, catalog, "hetzner")
+	if err != nil {
+		t.Fatalf("third Check: %v", err)
+	}
+	if !changes.Empty() {
+		t.Errorf("expected changes to be reported once, got %+v", changes)
+	}
+}
+
+func TestCheck_SnapshotsArePerProvider(t *testing.T) {
+	c := cache.New(t.TempDir())
+	hetzner := &fakeCatalog{serverTypes: []domain.ServerTypeSpec{{Name: "cpx11"}}}
+	do := &fakeCatalog{serverTypes: []domain.ServerTypeSpec{{Name: "s-1vcpu-1gb"}}}
+
+	for _, tc := range []struct {
+		name    string
+		catalog *fakeCatalog
+	}{{"hetzner", hetzner}, {"digitalocean", do}, {"hetzner", hetzner}} {
+		changes, err := Check(context.Background(), c, tc.catalog, tc.name)
+		if err != nil {
+			t.Fatalf("Check(%s): %v", tc.name, err)
+		}
+		if !changes.Empty() {
+			t.Errorf("Check(%s) reported changes across providers: %+v", tc.name, changes)
+		}
+	}
+}
+
+func TestCheck_ListError(t *testing.T) {
+	c := cache.New(t.TempDir())
+	_, err := Check(context.Background(), c, &fakeCatalog{err: errors.New("boom")}, "hetzner")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,122 @@
+// Package console bridges a provider's WebSocket VNC console to a local
+// TCP port, so any VNC viewer can connect to a server's console without
+// a noVNC client in the browser.
+package console
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"golang.org/x/net/websocket"
+)
+
+// ViewerURL returns the vnc:// URL a VNC viewer opens to connect to a
+// bridge listening on addr.
+func ViewerURL(addr string) string {
+	return "vnc://" + addr
+}
+
+// Dial opens a console's WebSocket through the configured proxy, or the
+// HTTPS_PROXY environment when it is empty. The returned connection
+// carries raw VNC traffic.
+func Dial(ctx context.Context, rawURL, configuredProxy string) (net.Conn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "wss" && u.Scheme != "ws") {
+		return nil, fmt.Errorf("invalid console URL %q", rawURL)
+	}
+
+	origin := &url.URL{Scheme: "https", Host: u.Host}
+	if u.Scheme == "ws" {
+		origin.Scheme = "http"
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), map[string]string{"ws": "80", "wss": "443"}[u.Scheme])
+	}
+
+	proxyURL, err := proxy.HTTPProxy(configuredProxy)(&http.Request{URL: origin})
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	if proxyURL != nil {
+		conn, err = proxy.Dial(ctx, proxyURL, addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to console: %w", err)
+	}
+
+	if u.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to connect to console: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	cfg := &websocket.Config{Location: u, Origin: origin, Version: websocket.ProtocolVersionHybi13}
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open console: %w", err)
+	}
+	ws.PayloadType = websocket.BinaryFrame
+	return ws, nil
+}
+
+// Serve accepts VNC viewers on ln and bridges each one to its own
+// connection to the console until ctx is done. It closes ln on return.
+func Serve(ctx context.Context, ln net.Listener, c *domain.Console, configuredProxy string) error {
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		viewer, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept VNC viewer: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer viewer.Close()
+			remote, err := Dial(ctx, c.URL, configuredProxy)
+			if err != nil {
+				return
+			}
+			defer remote.Close()
+			pipe(ctx, viewer, remote)
+		}()
+	}
+}
+
+// pipe copies between a and b until either side closes or ctx is done.
+func pipe(ctx context.Context, a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(a, b); done <- struct{}{} }()
+	go func() { io.Copy(b, a); done <- struct{}{} }()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,66 @@
+package console
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"golang.org/x/net/websocket"
+)
+
+func TestServe_BridgesViewerToConsole(t *testing.T) {
+	srv := httptest.NewServer(websocket.Handler(func(ws *websocket.Conn) {
+		ws.PayloadType = websocket.BinaryFrame
+		io.Copy(ws, ws)
+	}))
+	t.Cleanup(srv.Close)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- Serve(ctx, ln, &domain.Console{URL: "ws" + strings.TrimPrefix(srv.URL, "http")}, "")
+	}()
+
+	viewer, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatalf("dial bridge: %v", err)
+	}
+	t.Cleanup(func() { viewer.Close() })
+
+	if _, err := viewer.Write([]byte("RFB 003.008\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	buf := make([]byte, 12)
+	if _, err := io.ReadFull(viewer, buf); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(buf) != "RFB 003.008\n" {
+		t.Errorf("echoed %q", buf)
+	}
+
+	cancel()
+	if err := <-served; err != nil {
+		t.Errorf("Serve returned %v", err)
+	}
+}
+
+func TestDial_InvalidURL(t *testing.T) {
+	if _, err := Dial(context.Background(), "https://example.com", ""); err == nil {
+		t.Fatal("expected an error for a non-WebSocket URL")
+	}
+}
+
+func TestViewerURL(t *testing.T) {
+	if got := ViewerURL("127.0.0.1:5900"); got != "vnc://127.0.0.1:5900" {
+		t.Errorf("ViewerURL() = %q", got)
+	}
+}
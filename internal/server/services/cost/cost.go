@@ -0,0 +1,112 @@
+// Package cost estimates what a provider's servers cost from the list
+// prices of their server types.
+//
+// Server type prices are cached per provider, since they change rarely
+// and listing the catalog is the slowest part of an estimate.
+package cost
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// DefaultTTL is how long cached prices are used.
+const DefaultTTL = 24 * time.Hour
+
+// Catalog is the part of a domain.CatalogProvider that prices come from.
+type Catalog interface {
+	ListServerTypes(ctx context.Context) ([]domain.ServerTypeSpec, error)
+}
+
+// Prices maps server type names to their specs.
+type Prices map[string]domain.ServerTypeSpec
+
+func pricesKey(providerName string) string {
+	return "cost_prices_" + providerName
+}
+
+// LoadPrices returns the server type prices of providerName, from the
+// cache when they were fetched within DefaultTTL unless refresh is set.
+// A nil cache always fetches.
+func LoadPrices(ctx context.Context, c *cache.Cache, catalog Catalog, providerName string, refresh bool) (Prices, error) {
+	if !refresh {
+		var cached Prices
+		if hit, _ := c.Get(pricesKey(providerName), DefaultTTL, &cached); hit {
+			return cached, nil
+		}
+	}
+
+	serverTypes, err := catalog.ListServerTypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list server types: %w", err)
+	}
+	prices := make(Prices, len(serverTypes))
+	for _, st := range serverTypes {
+		prices[st.Name] = st
+	}
+	_ = c.Set(pricesKey(providerName), prices)
+	return prices, nil
+}
+
+// Line is the cost of one server.
+type Line struct {
+	Server  domain.Server
+	Monthly money.Money
+	Hourly  money.Money
+	// Priced is false when the server's type has no list price, e.g. a
+	// type the provider no longer offers.
+	Priced bool
+}
+
+// Overview is the estimated cost of a provider's servers.
+type Overview struct {
+	Provider string
+	Lines    []Line
+	// Monthly and Hourly total the priced lines.
+	Monthly money.Money
+	Hourly  money.Money
+	// Unpriced counts lines without a price.
+	Unpriced int
+	// Stopped counts servers left out because they are not running.
+	Stopped int
+}
+
+// Estimate prices servers at their server type's list price. Only running
+// servers are counted unless includeStopped is set; note that most
+// providers bill stopped servers too.
+func Estimate(providerName string, servers []domain.Server, prices Prices, includeStopped bool) Overview {
+	o := Overview{Provider: providerName}
+	for _, s := range servers {
+		if s.Status != "running" && !includeStopped {
+			o.Stopped++
+			continue
+		}
+		line := Line{Server: s}
+		if st, ok := prices[s.ServerType]; ok && !st.MonthlyPrice().IsZero() {
+			line.Monthly = st.MonthlyPrice()
+			line.Hourly = st.HourlyPrice()
+			line.Priced = true
+			o.Monthly = add(o.Monthly, line.Monthly)
+			o.Hourly = add(o.Hourly, line.Hourly)
+		} else {
+			o.Unpriced++
+		}
+		o.Lines = append(o.Lines, line)
+	}
+	return o
+}
+
+// add sums two amounts of the same currency; a provider prices its whole
+// catalog in one currency.
+func add(total, m money.Money) money.Money {
+	total.Amount += m.Amount
+	if total.Currency == "" {
+		total.Currency = m.Currency
+	}
+	return total
+}
@@ -0,0 +1,76 @@
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+type fakeCatalog struct {
+	calls       int
+	serverTypes []domain.ServerTypeSpec
+}
+
+func (f *fakeCatalog) ListServerTypes(context.Context) ([]domain.ServerTypeSpec, error) {
+	f.calls++
+	return f.serverTypes, nil
+}
+
+func eur(amount float64) money.Money { return money.Money{Amount: amount, Currency: "EUR"} }
+
+func TestEstimate_CountsRunningServers(t *testing.T) {
+	prices := Prices{
+		"cpx11": {Name: "cpx11", PriceMonthly: eur(4.5)},
+		"cpx21": {Name: "cpx21", PriceHourly: eur(0.01)},
+	}
+	servers := []domain.Server{
+		{Name: "web-1", Status: "running", ServerType: "cpx11"},
+		{Name: "web-2", Status: "running", ServerType: "cpx21"},
+		{Name: "old", Status: "running", ServerType: "cx11"},
+		{Name: "db", Status: "off", ServerType: "cpx11"},
+	}
+
+	o := Estimate("hetzner", servers, prices, false)
+
+	if diff := cmp.Diff(eur(4.5+7.3), o.Monthly, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+		t.Errorf("Monthly mismatch (-want +got):\n%s", diff)
+	}
+	if len(o.Lines) != 3 || o.Unpriced != 1 || o.Stopped != 1 {
+		t.Errorf("lines = %d, unpriced = %d, stopped = %d; want 3, 1, 1", len(o.Lines), o.Unpriced, o.Stopped)
+	}
+	if o.Lines[2].Priced {
+		t.Error("expected cx11 to have no list price")
+	}
+
+	if o := Estimate("hetzner", servers, prices, true); len(o.Lines) != 4 || o.Stopped != 0 {
+		t.Errorf("includeStopped: lines = %d, stopped = %d; want 4, 0", len(o.Lines), o.Stopped)
+	}
+}
+
+func TestLoadPrices_UsesCache(t *testing.T) {
+	c := cache.New(t.TempDir())
+	catalog := &fakeCatalog{serverTypes: []domain.ServerTypeSpec{{Name: "cpx11", PriceMonthly: eur(4.5)}}}
+
+	LoadPrices(context.Background(), c, catalog, "hetzner", false)
+	prices, err := LoadPrices(context.Background(), c, catalog, "hetzner", false)
+	if err != nil {
+		t.Fatalf("LoadPrices failed: %v", err)
+	}
+	if catalog.calls != 1 {
+		t.Errorf("catalog listed %d times, want 1", catalog.calls)
+	}
+	if prices["cpx11"].PriceMonthly != eur(4.5) {
+		t.Errorf("cached prices = %+v", prices)
+	}
+
+	LoadPrices(context.Background(), c, catalog, "hetzner", true)
+	if catalog.calls != 2 {
+		t.Errorf("refresh: catalog listed %d times, want 2", catalog.calls)
+	}
+}
@@ -0,0 +1,100 @@
+// Package export writes server inventories to portable file formats.
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// CSVHeader lists the columns written by WriteCSV, in order. Every column is
+// always included, regardless of how much of it the TUI can display.
+var CSVHeader = []string{
+	"id",
+	"name",
+	"status",
+	"provider",
+	"region",
+	"server_type",
+	"image",
+	"public_ipv4",
+	"public_ipv6",
+	"private_ipv4",
+	"created_at",
+	"labels",
+}
+
+// WriteCSV writes servers to w as CSV with a header row.
+func WriteCSV(w io.Writer, servers []domain.Server) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(CSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, s := range servers {
+		if err := cw.Write(csvRecord(s)); err != nil {
+			return fmt.Errorf("failed to write CSV row for server %q: %w", s.ID, err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+	return nil
+}
+
+// WriteCSVFile writes servers to a new CSV file at path, replacing any
+// existing file.
+func WriteCSVFile(path string, servers []domain.Server) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := WriteCSV(f, servers); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
+}
+
+// DefaultCSVPath returns a timestamped file name in dir for an export of
+// the given provider's servers, e.g. "vpsm-servers-hetzner-20260101-150405.csv".
+func DefaultCSVPath(dir, providerName string, now time.Time) string {
+	name := fmt.Sprintf("vpsm-servers-%s-%s.csv", providerName, now.Format("20060102-150405"))
+	return filepath.Join(dir, name)
+}
+
+// csvRecord converts a server to a CSV row matching CSVHeader.
+func csvRecord(s domain.Server) []string {
+	created := ""
+	if !s.CreatedAt.IsZero() {
+		created = s.CreatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		s.ID,
+		s.Name,
+		s.Status,
+		s.Provider,
+		s.Region,
+		s.ServerType,
+		s.Image,
+		s.PublicIPv4,
+		s.PublicIPv6,
+		s.PrivateIPv4,
+		created,
+		domain.FormatLabels(s.Labels),
+	}
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testServers() []domain.Server {
+	return []domain.Server{
+		{
+			ID:          "42",
+			Name:        "web-server",
+			Status:      "running",
+			CreatedAt:   time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+			PublicIPv4:  "1.2.3.4",
+			PublicIPv6:  "2001:db8::1",
+			PrivateIPv4: "10.0.0.2",
+			Region:      "fsn1",
+			ServerType:  "cpx11",
+			Image:       "ubuntu-24.04",
+			Provider:    "hetzner",
+			Labels:      map[string]string{"role": "web", "env": "prod"},
+		},
+		{
+			ID:         "99",
+			Name:       "db, primary",
+			Status:     "off",
+			Region:     "nbg1",
+			ServerType: "cpx22",
+			Provider:   "hetzner",
+		},
+	}
+}
+
+func TestWriteCSV_AllColumns(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, testServers()); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV output: %v", err)
+	}
+
+	want := [][]string{
+		CSVHeader,
+		{"42", "web-server", "running", "hetzner", "fsn1", "cpx11", "ubuntu-24.04", "1.2.3.4", "2001:db8::1", "10.0.0.2", "2024-06-15T12:00:00Z", "env=prod, role=web"},
+		{"99", "db, primary", "off", "hetzner", "nbg1", "cpx22", "", "", "", "", "", ""},
+	}
+
+	if diff := cmp.Diff(want, records); diff != "" {
+		t.Errorf("unexpected CSV records (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteCSV_EmptyWritesHeaderOnly(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, nil); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV output: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected header row only, got %d rows", len(records))
+	}
+}
+
+func TestWriteCSVFile_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "servers.csv")
+
+	if err := WriteCSVFile(path, testServers()); err != nil {
+		t.Fatalf("WriteCSVFile returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read exported file: %v", err)
+	}
+	if !bytes.HasPrefix(data, []byte("id,name,status")) {
+		t.Errorf("expected CSV header at start of file, got:\n%s", data)
+	}
+}
+
+func TestDefaultCSVPath(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	got := DefaultCSVPath("/tmp", "hetzner", now)
+	want := filepath.Join("/tmp", "vpsm-servers-hetzner-20260102-150405.csv")
+	if got != want {
+		t.Errorf("DefaultCSVPath = %q, want %q", got, want)
+	}
+}
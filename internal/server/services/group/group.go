@@ -0,0 +1,261 @@
+// Package group resolves server groups to their member servers and runs
+// actions (start, stop, exec, update) across every member concurrently.
+// It is shared by the CLI and the TUI progress view.
+package group
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency bounds how many servers are acted on at once.
+const DefaultConcurrency = 5
+
+// Op is a group-level action.
+type Op string
+
+const (
+	OpStart  Op = "start"
+	OpStop   Op = "stop"
+	OpExec   Op = "exec"
+	OpUpdate Op = "update"
+)
+
+// Ops lists the supported group actions.
+var Ops = []Op{OpStart, OpStop, OpExec, OpUpdate}
+
+// Options configures a group run.
+type Options struct {
+	// Command is the shell command executed by OpExec.
+	Command string
+	// Username is the SSH login used by OpExec and OpUpdate.
+	Username string
+	// Bastions maps server IDs to the jump host used to reach them over
+	// SSH. Servers without an entry are connected to directly.
+	Bastions map[string]string
+	// Concurrency bounds parallelism; DefaultConcurrency when <= 0.
+	Concurrency int
+}
+
+// State is the progress of a single server within a run.
+type State int
+
+const (
+	StatePending State = iota
+	StateRunning
+	StateDone
+	StateFailed
+)
+
+// Event reports a state change for one server.
+type Event struct {
+	Index  int
+	Server domain.Server
+	State  State
+	Result *Result // set when State is StateDone or StateFailed
+}
+
+// Result is the outcome of an action on one server.
+type Result struct {
+	Server domain.Server
+	Output string
+	// Skipped is true when the server was already in the target state.
+	Skipped bool
+	Err     error
+}
+
+// Summary counts results by outcome.
+func Summary(results []Result) (succeeded, failed int) {
+	for _, r := range results {
+		if r.Err != nil {
+			failed++
+		} else {
+			succeeded++
+		}
+	}
+	return succeeded, failed
+}
+
+// Resolve returns the servers belonging to g: the union of its explicit
+// members and servers matching its label selector, in provider list order.
+func Resolve(ctx context.Context, provider domain.Provider, g *servergroups.Group) ([]domain.Server, error) {
+	var selector domain.LabelSelector
+	if g.Selector != "" {
+		var err error
+		selector, err = domain.ParseLabelSelector(g.Selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	servers, err := provider.ListServers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list servers: %w", err)
+	}
+
+	var members []domain.Server
+	for _, s := range servers {
+		if slices.Contains(g.ServerIDs, s.ID) || (len(selector) > 0 && selector.Matches(s.Labels)) {
+			members = append(members, s)
+		}
+	}
+	return members, nil
+}
+
+// Load reads the group named name from the local group store and returns
+// it with its current members.
+func Load(ctx context.Context, provider domain.Provider, providerName, name string) (*servergroups.Group, []domain.Server, error) {
+	repo, err := servergroups.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer repo.Close()
+
+	g, err := repo.Get(providerName, name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if g == nil {
+		return nil, nil, fmt.Errorf("group %q not found", name)
+	}
+
+	servers, err := Resolve(ctx, provider, g)
+	return g, servers, err
+}
+
+// Bastions returns the jump host for each server that has one, for
+// Options.Bastions. The group being acted on takes precedence over other
+// groups the server belongs to; a server's own bastion still wins.
+func Bastions(providerName string, g *servergroups.Group, servers []domain.Server) map[string]string {
+	var prefs *prefssvc.Service
+	if repo, err := serverprefs.Open(); err == nil {
+		prefs = prefssvc.NewService(repo)
+		defer prefs.Close()
+	}
+
+	groups := []servergroups.Group{*g}
+	if repo, err := servergroups.Open(); err == nil {
+		others, _ := repo.List(providerName)
+		repo.Close()
+		groups = append(groups, others...)
+	}
+
+	bastions := make(map[string]string)
+	for _, s := range servers {
+		if b := bastion.Resolve(prefs, groups, providerName, s); b != "" {
+			bastions[s.ID] = b
+		}
+	}
+	return bastions
+}
+
+// Run performs op on every server, at most opts.Concurrency at a time.
+// progress, when non-nil, is called from worker goroutines as each server
+// starts and finishes. Results are returned in the order of servers; a
+// failure on one server does not stop the others.
+func Run(ctx context.Context, provider domain.Provider, providerName string, servers []domain.Server, op Op, opts Options, progress func(Event)) []Result {
+	if progress == nil {
+		progress = func(Event) {}
+	}
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]Result, len(servers))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, server := range servers {
+		g.Go(func() error {
+			progress(Event{Index: i, Server: server, State: StateRunning})
+			results[i] = runOne(ctx, provider, providerName, server, op, opts)
+			state := StateDone
+			if results[i].Err != nil {
+				state = StateFailed
+			}
+			progress(Event{Index: i, Server: server, State: state, Result: &results[i]})
+			return nil
+		})
+	}
+	g.Wait()
+	return results
+}
+
+func runOne(ctx context.Context, provider domain.Provider, providerName string, server domain.Server, op Op, opts Options) Result {
+	result := Result{Server: server}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	switch op {
+	case OpStart:
+		result.Skipped, result.Err = powerAction(ctx, provider, providerName, server, "running", provider.StartServer)
+	case OpStop:
+		result.Skipped, result.Err = powerAction(ctx, provider, providerName, server, "off", provider.StopServer)
+	case OpExec:
+		result.Output, result.Err = remote.Exec(ctx, server, opts.conn(server), opts.Command)
+	case OpUpdate:
+		result.Output, result.Err = remote.Exec(ctx, server, opts.conn(server), UpdateScript(opts.Username))
+	default:
+		result.Err = fmt.Errorf("unsupported group action %q", op)
+	}
+	return result
+}
+
+// conn returns the SSH connection details for server.
+func (o Options) conn(server domain.Server) remote.Conn {
+	return remote.Conn{Username: o.Username, Bastion: o.Bastions[server.ID]}
+}
+
+// powerAction issues a start/stop call and waits for the server to reach
+// targetStatus. Servers already in the target state are skipped.
+func powerAction(
+	ctx context.Context,
+	provider domain.Provider,
+	providerName string,
+	server domain.Server,
+	targetStatus string,
+	call func(context.Context, string) (*domain.ActionStatus, error),
+) (bool, error) {
+	if server.Status == targetStatus {
+		return true, nil
+	}
+
+	status, err := call(ctx, server.ID)
+	if err != nil {
+		return false, err
+	}
+
+	svc := action.NewService(provider, providerName, nil)
+	return false, svc.WaitForAction(ctx, status, server.ID, targetStatus, io.Discard)
+}
+
+// UpdateScript returns a shell command that upgrades installed packages
+// using whichever package manager the server has. Non-root users run it
+// through sudo without prompting.
+func UpdateScript(username string) string {
+	const script = `if command -v apt-get >/dev/null 2>&1; then ` +
+		`DEBIAN_FRONTEND=noninteractive apt-get update -q && DEBIAN_FRONTEND=noninteractive apt-get upgrade -y -q; ` +
+		`elif command -v dnf >/dev/null 2>&1; then dnf upgrade -y -q; ` +
+		`elif command -v yum >/dev/null 2>&1; then yum update -y -q; ` +
+		`elif command -v apk >/dev/null 2>&1; then apk update && apk upgrade; ` +
+		`else echo "no supported package manager found" >&2; exit 1; fi`
+
+	if username == "" || username == remote.DefaultUser {
+		return "sh -c '" + script + "'"
+	}
+	return "sudo -n sh -c '" + script + "'"
+}
@@ -0,0 +1,194 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+)
+
+type mockProvider struct {
+	domain.Provider
+	servers []domain.Server
+
+	mu      sync.Mutex
+	started []string
+}
+
+func (m *mockProvider) ListServers(_ context.Context) ([]domain.Server, error) {
+	return m.servers, nil
+}
+
+func (m *mockProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
+	return &domain.Server{ID: id, Status: "running"}, nil
+}
+
+func (m *mockProvider) StartServer(_ context.Context, id string) (*domain.ActionStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.started = append(m.started, id)
+	return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+}
+
+func stubRunner(t *testing.T, fn func(host, command string) (string, error)) {
+	t.Helper()
+	orig := remote.Runner
+	remote.Runner = func(_ context.Context, args ...string) ([]byte, error) {
+		out, err := fn(args[len(args)-2], args[len(args)-1])
+		return []byte(out), err
+	}
+	t.Cleanup(func() { remote.Runner = orig })
+}
+
+func testServers() []domain.Server {
+	return []domain.Server{
+		{ID: "1", Name: "web-1", Status: "off", PublicIPv4: "10.0.0.1", Labels: map[string]string{"role": "web"}},
+		{ID: "2", Name: "web-2", Status: "running", PublicIPv4: "10.0.0.2", Labels: map[string]string{"role": "web"}},
+		{ID: "3", Name: "db-1", Status: "off", PublicIPv4: "10.0.0.3", Labels: map[string]string{"role": "db"}},
+	}
+}
+
+func names(servers []domain.Server) string {
+	var out []string
+	for _, s := range servers {
+		out = append(out, s.Name)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestResolve(t *testing.T) {
+	provider := &mockProvider{servers: testServers()}
+
+	tests := []struct {
+		name  string
+		group servergroups.Group
+		want  string
+	}{
+		{"explicit members", servergroups.Group{ServerIDs: []string{"3", "1"}}, "web-1,db-1"},
+		{"selector", servergroups.Group{Selector: "role=web"}, "web-1,web-2"},
+		{"union", servergroups.Group{Selector: "role=db", ServerIDs: []string{"2"}}, "web-2,db-1"},
+		{"no match", servergroups.Group{Selector: "role=cache"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(context.Background(), provider, &tt.group)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			if names(got) != tt.want {
+				t.Errorf("Resolve = %q, want %q", names(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve_InvalidSelector(t *testing.T) {
+	_, err := Resolve(context.Background(), &mockProvider{}, &servergroups.Group{Selector: "=bad"})
+	if err == nil {
+		t.Fatal("expected error for invalid selector")
+	}
+}
+
+func TestRun_Start_SkipsRunningServers(t *testing.T) {
+	provider := &mockProvider{}
+	servers := testServers()[:2]
+
+	results := Run(context.Background(), provider, "mock", servers, OpStart, Options{}, nil)
+
+	if len(provider.started) != 1 || provider.started[0] != "1" {
+		t.Errorf("expected only server 1 to be started, got %v", provider.started)
+	}
+	if results[0].Err != nil || results[0].Skipped {
+		t.Errorf("unexpected result for web-1: %+v", results[0])
+	}
+	if !results[1].Skipped {
+		t.Errorf("expected web-2 to be skipped, got %+v", results[1])
+	}
+}
+
+func TestRun_Exec_CollectsOutputAndErrors(t *testing.T) {
+	stubRunner(t, func(host, command string) (string, error) {
+		if host == "root@10.0.0.2" {
+			return "", errors.New("connection refused")
+		}
+		return "ok from " + host, nil
+	})
+
+	var mu sync.Mutex
+	var events []Event
+	results := Run(context.Background(), &mockProvider{}, "mock", testServers(), OpExec,
+		Options{Command: "uptime", Concurrency: 2},
+		func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		})
+
+	if results[0].Output != "ok from root@10.0.0.1" {
+		t.Errorf("results[0].Output = %q", results[0].Output)
+	}
+	if results[1].Err == nil {
+		t.Error("expected results[1] to fail")
+	}
+	if ok, failed := Summary(results); ok != 2 || failed != 1 {
+		t.Errorf("Summary = (%d, %d), want (2, 1)", ok, failed)
+	}
+	// One running and one finished event per server.
+	if len(events) != 6 {
+		t.Errorf("expected 6 progress events, got %d", len(events))
+	}
+}
+
+func TestRun_Update_UsesSudoForNonRoot(t *testing.T) {
+	var got string
+	stubRunner(t, func(_, command string) (string, error) {
+		got = command
+		return "", nil
+	})
+
+	Run(context.Background(), &mockProvider{}, "mock", testServers()[:1], OpUpdate, Options{Username: "deploy"}, nil)
+
+	if !strings.HasPrefix(got, "sudo -n ") {
+		t.Errorf("expected sudo for non-root user, got %q", got)
+	}
+	if !strings.Contains(got, "apt-get upgrade") {
+		t.Errorf("expected apt-get upgrade in update script, got %q", got)
+	}
+}
+
+func TestRun_Exec_UsesPerServerBastion(t *testing.T) {
+	var mu sync.Mutex
+	var args [][]string
+	orig := remote.Runner
+	remote.Runner = func(_ context.Context, a ...string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		args = append(args, a)
+		return nil, nil
+	}
+	t.Cleanup(func() { remote.Runner = orig })
+
+	servers := []domain.Server{
+		{ID: "1", Name: "web-1", PublicIPv4: "203.0.113.1", PrivateIPv4: "10.0.0.1"},
+		{ID: "2", Name: "web-2", PublicIPv4: "203.0.113.2", PrivateIPv4: "10.0.0.2"},
+	}
+	Run(context.Background(), &mockProvider{}, "mock", servers, OpExec,
+		Options{Command: "true", Concurrency: 1, Bastions: map[string]string{"1": "jump"}}, nil)
+
+	if len(args) != 2 {
+		t.Fatalf("expected 2 ssh invocations, got %d", len(args))
+	}
+	first, second := strings.Join(args[0], " "), strings.Join(args[1], " ")
+	if !strings.Contains(first, "-J jump root@10.0.0.1") {
+		t.Errorf("expected web-1 via bastion on private IP, got %q", first)
+	}
+	if strings.Contains(second, "-J") || !strings.Contains(second, "root@203.0.113.2") {
+		t.Errorf("expected web-2 direct on public IP, got %q", second)
+	}
+}
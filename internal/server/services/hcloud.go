@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"net"
 	"strconv"
 	"time"
 
@@ -49,7 +50,7 @@ func (s *HCloudService) CreateServer(ctx context.Context, opts *domain.CreateSer
 	hcloudOpts := hcloud.ServerCreateOpts{
 		Name:             opts.Name,
 		ServerType:       &hcloud.ServerType{Name: opts.ServerType},
-		Image:            &hcloud.Image{Name: opts.Image},
+		Image:            imageRef(opts.Image),
 		UserData:         opts.UserData,
 		Labels:           opts.Labels,
 		StartAfterCreate: opts.StartAfterCreate,
@@ -59,6 +60,15 @@ func (s *HCloudService) CreateServer(ctx context.Context, opts *domain.CreateSer
 		hcloudOpts.Location = &hcloud.Location{Name: opts.Location}
 	}
 
+	// PublicNet must spell out both families once either is overridden;
+	// unset families keep the Hetzner default (enabled).
+	if opts.EnableIPv4 != nil || opts.EnableIPv6 != nil {
+		hcloudOpts.PublicNet = &hcloud.ServerCreatePublicNet{
+			EnableIPv4: opts.EnableIPv4 == nil || *opts.EnableIPv4,
+			EnableIPv6: opts.EnableIPv6 == nil || *opts.EnableIPv6,
+		}
+	}
+
 	for _, key := range opts.SSHKeyIdentifiers {
 		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
 		defer cancel()
@@ -72,6 +82,10 @@ func (s *HCloudService) CreateServer(ctx context.Context, opts *domain.CreateSer
 		hcloudOpts.SSHKeys = append(hcloudOpts.SSHKeys, sshKey)
 	}
 
+	if err := s.resolveNetworks(ctx, opts, &hcloudOpts); err != nil {
+		return domain.Server{}, err
+	}
+
 	reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
 	defer cancel()
 	res, _, err := s.client.Server.Create(reqCtx, hcloudOpts)
@@ -100,6 +114,70 @@ func (s *HCloudService) CreateServer(ctx context.Context, opts *domain.CreateSer
 	return server, nil
 }
 
+// resolveNetworks looks up the private networks to attach and checks that
+// each covers the location's network zone, which the API would otherwise
+// reject after the request is made.
+func (s *HCloudService) resolveNetworks(ctx context.Context, opts *domain.CreateServerOpts, hcloudOpts *hcloud.ServerCreateOpts) error {
+	if len(opts.Networks) == 0 {
+		return nil
+	}
+
+	var location *domain.Location
+	if opts.Location != "" {
+		var hzLocation *hcloud.Location
+		if err := retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+			reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+			defer cancel()
+			var apiErr error
+			hzLocation, _, apiErr = s.client.Location.Get(reqCtx, opts.Location)
+			return apiErr
+		}); err != nil {
+			return fmt.Errorf("failed to resolve location %q: %w", opts.Location, err)
+		}
+		if hzLocation == nil {
+			return fmt.Errorf("location %q not found", opts.Location)
+		}
+		location = &domain.Location{Name: hzLocation.Name, NetworkZone: string(hzLocation.NetworkZone)}
+	}
+
+	for _, ref := range opts.Networks {
+		network, err := s.GetNetwork(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve network %q: %w", ref, err)
+		}
+		if network == nil {
+			return fmt.Errorf("network %q not found", ref)
+		}
+		if location != nil {
+			spec := domain.NetworkSpec{Name: network.Name}
+			for _, subnet := range network.Subnets {
+				spec.NetworkZones = append(spec.NetworkZones, string(subnet.NetworkZone))
+			}
+			if err := domain.ValidateNetworkLocation(spec, *location); err != nil {
+				return err
+			}
+		}
+		hcloudOpts.Networks = append(hcloudOpts.Networks, network)
+	}
+	return nil
+}
+
+// GetNetwork looks up a private network by ID or name.
+func (s *HCloudService) GetNetwork(ctx context.Context, idOrName string) (*hcloud.Network, error) {
+	var network *hcloud.Network
+	if err := retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		var apiErr error
+		network, _, apiErr = s.client.Network.Get(reqCtx, idOrName)
+		return apiErr
+	}); err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
 func (s *HCloudService) GetSSHKey(ctx context.Context, id string) (*hcloud.SSHKey, error) {
 	var sshKey *hcloud.SSHKey
 	if err := retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
@@ -163,6 +241,316 @@ func (s *HCloudService) StopServer(ctx context.Context, id string) (*domain.Acti
 	return toDomainAction(action), nil
 }
 
+// RebootServer soft-reboots a server by its ID and returns the resulting
+// action status so callers can poll for completion.
+func (s *HCloudService) RebootServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, s.client.Server.Reboot)
+}
+
+// ResetServer hard-resets a server by its ID and returns the resulting
+// action status so callers can poll for completion.
+func (s *HCloudService) ResetServer(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, s.client.Server.Reset)
+}
+
+// ResizeServer changes the type of a powered-off server and returns the
+// resulting action status so callers can poll for completion.
+func (s *HCloudService) ResizeServer(ctx context.Context, id, serverType string, upgradeDisk bool) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
+			ServerType:  &hcloud.ServerType{Name: serverType},
+			UpgradeDisk: upgradeDisk,
+		})
+	})
+}
+
+// EnableBackups turns on automatic backups for a server and returns the
+// resulting action status so callers can poll for completion.
+func (s *HCloudService) EnableBackups(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.EnableBackup(ctx, server, "")
+	})
+}
+
+// DisableBackups turns off automatic backups for a server. Existing
+// backups are deleted by the provider.
+func (s *HCloudService) DisableBackups(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, s.client.Server.DisableBackup)
+}
+
+// RestoreBackup rebuilds a server from one of its backup images and
+// returns the resulting action status so callers can poll for completion.
+func (s *HCloudService) RestoreBackup(ctx context.Context, id, backupID string) (*domain.ActionStatus, error) {
+	imageID, err := strconv.ParseInt(backupID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup ID %q: %w", backupID, err)
+	}
+	return s.serverAction(ctx, id, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.Rebuild(ctx, server, hcloud.ServerRebuildOpts{Image: &hcloud.Image{ID: imageID}})
+	})
+}
+
+// ListBackups returns the backup images bound to a server, retrying
+// transient failures.
+func (s *HCloudService) ListBackups(ctx context.Context, id string) ([]*hcloud.Image, error) {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	var images []*hcloud.Image
+	err = retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		var apiErr error
+		images, apiErr = s.client.Image.AllWithOpts(reqCtx, hcloud.ImageListOpts{
+			Type:    []hcloud.ImageType{hcloud.ImageTypeBackup},
+			BoundTo: &hcloud.Server{ID: numericID},
+			Sort:    []string{"created:desc"},
+		})
+		return apiErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// CreateNetwork creates a private network with one cloud subnet. It is
+// not retried, since a retry after a lost response would fail on the
+// duplicate name.
+func (s *HCloudService) CreateNetwork(ctx context.Context, opts domain.CreateNetworkOpts) (*hcloud.Network, error) {
+	_, ipRange, err := net.ParseCIDR(opts.IPRange)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IP range %q: %w", opts.IPRange, err)
+	}
+	subnetRange := ipRange
+	if opts.SubnetRange != "" {
+		if _, subnetRange, err = net.ParseCIDR(opts.SubnetRange); err != nil {
+			return nil, fmt.Errorf("invalid subnet range %q: %w", opts.SubnetRange, err)
+		}
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	network, _, err := s.client.Network.Create(reqCtx, hcloud.NetworkCreateOpts{
+		Name:    opts.Name,
+		IPRange: ipRange,
+		Subnets: []hcloud.NetworkSubnet{{
+			Type:        hcloud.NetworkSubnetTypeCloud,
+			IPRange:     subnetRange,
+			NetworkZone: hcloud.NetworkZone(opts.NetworkZone),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return network, nil
+}
+
+// DeleteNetwork deletes a private network, retrying transient failures.
+func (s *HCloudService) DeleteNetwork(ctx context.Context, id string) error {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid network ID %q: %w", id, err)
+	}
+	return retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		_, apiErr := s.client.Network.Delete(reqCtx, &hcloud.Network{ID: numericID})
+		return apiErr
+	})
+}
+
+// AttachServerToNetwork attaches a server to a private network, at ip if
+// given, and returns the resulting action status.
+func (s *HCloudService) AttachServerToNetwork(ctx context.Context, serverID, networkID, ip string) (*domain.ActionStatus, error) {
+	numericNetworkID, err := strconv.ParseInt(networkID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network ID %q: %w", networkID, err)
+	}
+	opts := hcloud.ServerAttachToNetworkOpts{Network: &hcloud.Network{ID: numericNetworkID}}
+	if ip != "" {
+		if opts.IP = net.ParseIP(ip); opts.IP == nil {
+			return nil, fmt.Errorf("invalid IP address %q", ip)
+		}
+	}
+	return s.serverAction(ctx, serverID, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.AttachToNetwork(ctx, server, opts)
+	})
+}
+
+// DetachServerFromNetwork detaches a server from a private network.
+func (s *HCloudService) DetachServerFromNetwork(ctx context.Context, serverID, networkID string) (*domain.ActionStatus, error) {
+	numericNetworkID, err := strconv.ParseInt(networkID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network ID %q: %w", networkID, err)
+	}
+	return s.serverAction(ctx, serverID, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.DetachFromNetwork(ctx, server, hcloud.ServerDetachFromNetworkOpts{
+			Network: &hcloud.Network{ID: numericNetworkID},
+		})
+	})
+}
+
+// ListFloatingIPs returns all floating IPs in the project, retrying
+// transient failures.
+func (s *HCloudService) ListFloatingIPs(ctx context.Context) ([]*hcloud.FloatingIP, error) {
+	var ips []*hcloud.FloatingIP
+	err := retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		var apiErr error
+		ips, apiErr = s.client.FloatingIP.All(reqCtx)
+		return apiErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ips, nil
+}
+
+// CreateFloatingIP creates a floating IP. It is not retried, since a
+// retry after a lost response would create a second address.
+func (s *HCloudService) CreateFloatingIP(ctx context.Context, opts domain.CreateFloatingIPOpts) (*hcloud.FloatingIP, error) {
+	hcloudOpts := hcloud.FloatingIPCreateOpts{
+		Type: hcloud.FloatingIPType(opts.Type),
+	}
+	if opts.Name != "" {
+		hcloudOpts.Name = hcloud.Ptr(opts.Name)
+	}
+	if opts.Description != "" {
+		hcloudOpts.Description = hcloud.Ptr(opts.Description)
+	}
+	if opts.HomeLocation != "" {
+		hcloudOpts.HomeLocation = &hcloud.Location{Name: opts.HomeLocation}
+	}
+	if opts.ServerID != "" {
+		serverID, err := strconv.ParseInt(opts.ServerID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server ID %q: %w", opts.ServerID, err)
+		}
+		hcloudOpts.Server = &hcloud.Server{ID: serverID}
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+	result, _, err := s.client.FloatingIP.Create(reqCtx, hcloudOpts)
+	if err != nil {
+		return nil, err
+	}
+	return result.FloatingIP, nil
+}
+
+// AssignFloatingIP routes a floating IP to a server and returns the
+// resulting action status so callers can poll for completion.
+func (s *HCloudService) AssignFloatingIP(ctx context.Context, id, serverID string) (*domain.ActionStatus, error) {
+	numericServerID, err := strconv.ParseInt(serverID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", serverID, err)
+	}
+	return s.floatingIPAction(ctx, id, func(ctx context.Context, ip *hcloud.FloatingIP) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.FloatingIP.Assign(ctx, ip, &hcloud.Server{ID: numericServerID})
+	})
+}
+
+// UnassignFloatingIP removes a floating IP from its server.
+func (s *HCloudService) UnassignFloatingIP(ctx context.Context, id string) (*domain.ActionStatus, error) {
+	return s.floatingIPAction(ctx, id, s.client.FloatingIP.Unassign)
+}
+
+// floatingIPAction runs an action against the floating IP with the given
+// numeric ID, retrying transient failures.
+func (s *HCloudService) floatingIPAction(ctx context.Context, id string, fn func(context.Context, *hcloud.FloatingIP) (*hcloud.Action, *hcloud.Response, error)) (*domain.ActionStatus, error) {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid floating IP ID %q: %w", id, err)
+	}
+
+	var action *hcloud.Action
+	err = retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		var apiErr error
+		action, _, apiErr = fn(reqCtx, &hcloud.FloatingIP{ID: numericID})
+		return apiErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAction(action), nil
+}
+
+// ChangeDNSPtr sets the reverse DNS pointer of one of a server's public
+// addresses, or resets it to Hetzner's default when ptr is nil.
+func (s *HCloudService) ChangeDNSPtr(ctx context.Context, id, ip string, ptr *string) (*domain.ActionStatus, error) {
+	return s.serverAction(ctx, id, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+		return s.client.Server.ChangeDNSPtr(ctx, server, ip, ptr)
+	})
+}
+
+// SetLabels replaces all of a server's labels, retrying transient
+// failures. A nil or empty map removes them.
+func (s *HCloudService) SetLabels(ctx context.Context, id string, labels map[string]string) error {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+	if labels == nil {
+		// A nil map leaves the labels alone; an empty one clears them.
+		labels = map[string]string{}
+	}
+
+	return retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		_, _, apiErr := s.client.Server.Update(reqCtx, &hcloud.Server{ID: numericID}, hcloud.ServerUpdateOpts{Labels: labels})
+		return apiErr
+	})
+}
+
+// RequestConsole requests a WebSocket VNC console for a server. It is not
+// retried: each request invalidates the previous console's password.
+func (s *HCloudService) RequestConsole(ctx context.Context, id string) (*domain.Console, error) {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	result, _, err := s.client.Server.RequestConsole(reqCtx, &hcloud.Server{ID: numericID})
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Console{URL: result.WSSURL, Password: result.Password}, nil
+}
+
+// serverAction runs an action against the server with the given numeric
+// ID, retrying transient failures.
+func (s *HCloudService) serverAction(ctx context.Context, id string, fn func(context.Context, *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)) (*domain.ActionStatus, error) {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server ID %q: %w", id, err)
+	}
+
+	var action *hcloud.Action
+	err = retry.Do(ctx, s.retryConfig, isHCloudRetryable, func() error {
+		reqCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+		defer cancel()
+		var apiErr error
+		action, _, apiErr = fn(reqCtx, &hcloud.Server{ID: numericID})
+		return apiErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainAction(action), nil
+}
+
 // PollAction retrieves the current status of an action by its ID.
 // This is a single, non-retried request — callers are expected to
 // poll in a loop with appropriate intervals, so adding retry logic
@@ -244,3 +632,12 @@ func isHCloudRetryable(err error) bool {
 		hcloud.ErrorCodeLocked,
 	)
 }
+
+// imageRef refers to an image by name, or by ID for snapshots and
+// backups, which have no name.
+func imageRef(image string) *hcloud.Image {
+	if id, err := strconv.ParseInt(image, 10, 64); err == nil {
+		return &hcloud.Image{ID: id}
+	}
+	return &hcloud.Image{Name: image}
+}
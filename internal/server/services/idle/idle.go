@@ -0,0 +1,184 @@
+// Package idle flags servers whose CPU and network usage have stayed near
+// zero over a window of metrics history, so they can be stopped or
+// downsized.
+package idle
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// DefaultWindow is the look-back period used when none is configured.
+const DefaultWindow = 7 * 24 * time.Hour
+
+// Thresholds are the usage levels below which a server counts as idle.
+// They are compared against the 95th percentile of each series so a few
+// short bursts (cron jobs, package updates) don't hide an otherwise idle
+// server.
+type Thresholds struct {
+	CPUPercent         float64
+	NetworkBytesPerSec float64
+}
+
+// DefaultThresholds returns conservative near-zero thresholds.
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		CPUPercent:         2,
+		NetworkBytesPerSec: 2 * 1024,
+	}
+}
+
+// Report is the idle analysis for one server.
+type Report struct {
+	Window     time.Duration
+	CPUP95     float64
+	NetworkP95 float64
+	Samples    int
+	Idle       bool
+}
+
+// Recommendation returns a short human-readable suggestion, or "" when the
+// server is not idle.
+func (r Report) Recommendation() string {
+	if !r.Idle {
+		return ""
+	}
+	return fmt.Sprintf("Idle for %s (CPU p95 %.1f%%, network p95 %s) — consider stopping or downsizing.",
+		FormatWindow(r.Window), r.CPUP95, formatRate(r.NetworkP95))
+}
+
+// Check fetches CPU and network metrics covering window and analyses them.
+func Check(ctx context.Context, provider domain.MetricsProvider, serverID string, window time.Duration, th Thresholds) (*Report, error) {
+	end := time.Now()
+	metrics, err := provider.GetServerMetrics(ctx, serverID, []domain.MetricType{
+		domain.MetricCPU,
+		domain.MetricNetwork,
+	}, end.Add(-window), end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+
+	report := Analyze(metrics, th)
+	report.Window = window
+	return &report, nil
+}
+
+// Analyze computes the idle report for already-fetched metrics. A server
+// with no CPU samples is never reported as idle.
+func Analyze(metrics *domain.ServerMetrics, th Thresholds) Report {
+	var report Report
+	if metrics == nil {
+		return report
+	}
+	report.Window = metrics.End.Sub(metrics.Start)
+
+	cpu := values(metrics.TimeSeries["cpu"].Values)
+	report.Samples = len(cpu)
+	if len(cpu) == 0 {
+		return report
+	}
+	report.CPUP95 = percentile(cpu, 95)
+
+	// Combine inbound and outbound traffic per sample.
+	in := metrics.TimeSeries["network.0.bandwidth.in"].Values
+	out := metrics.TimeSeries["network.0.bandwidth.out"].Values
+	network := make([]float64, max(len(in), len(out)))
+	for i := range network {
+		if i < len(in) {
+			network[i] += in[i].Value
+		}
+		if i < len(out) {
+			network[i] += out[i].Value
+		}
+	}
+	report.NetworkP95 = percentile(network, 95)
+
+	report.Idle = report.CPUP95 < th.CPUPercent && report.NetworkP95 < th.NetworkBytesPerSec
+	return report
+}
+
+// ParseWindow parses a look-back window such as "7d", "36h" or "90m".
+// Whole days ("d") are accepted in addition to time.ParseDuration units.
+func ParseWindow(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: expected e.g. 7d or 48h", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		d, err = time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window %q: expected e.g. 7d or 48h", s)
+		}
+	}
+	if d < time.Hour {
+		return 0, fmt.Errorf("invalid window %q: must be at least 1h", s)
+	}
+	return d, nil
+}
+
+// ResolveWindow returns the configured window, or DefaultWindow when it is
+// empty or invalid.
+func ResolveWindow(configured string) time.Duration {
+	if configured == "" {
+		return DefaultWindow
+	}
+	d, err := ParseWindow(configured)
+	if err != nil {
+		return DefaultWindow
+	}
+	return d
+}
+
+// FormatWindow renders a window compactly, using days when it divides evenly.
+func FormatWindow(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func values(points []domain.MetricsPoint) []float64 {
+	vs := make([]float64, len(points))
+	for i, p := range points {
+		vs[i] = p.Value
+	}
+	return vs
+}
+
+// percentile returns the p-th percentile (nearest rank) of vs.
+func percentile(vs []float64, p float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	rank = min(max(rank, 0), len(sorted)-1)
+	return sorted[rank]
+}
+
+func formatRate(bytesPerSec float64) string {
+	switch {
+	case bytesPerSec >= 1024*1024:
+		return fmt.Sprintf("%.1f MB/s", bytesPerSec/(1024*1024))
+	case bytesPerSec >= 1024:
+		return fmt.Sprintf("%.1f KB/s", bytesPerSec/1024)
+	default:
+		return fmt.Sprintf("%.0f B/s", bytesPerSec)
+	}
+}
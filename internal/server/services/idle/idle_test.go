@@ -0,0 +1,159 @@
+package idle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func series(vs ...float64) domain.MetricsTimeSeries {
+	points := make([]domain.MetricsPoint, len(vs))
+	for i, v := range vs {
+		points[i] = domain.MetricsPoint{Timestamp: float64(i), Value: v}
+	}
+	return domain.MetricsTimeSeries{Values: points}
+}
+
+func metrics(cpu, in, out domain.MetricsTimeSeries) *domain.ServerMetrics {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return &domain.ServerMetrics{
+		Start: start,
+		End:   start.Add(7 * 24 * time.Hour),
+		TimeSeries: map[string]domain.MetricsTimeSeries{
+			"cpu":                     cpu,
+			"network.0.bandwidth.in":  in,
+			"network.0.bandwidth.out": out,
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	th := DefaultThresholds()
+
+	tests := []struct {
+		name    string
+		metrics *domain.ServerMetrics
+		want    bool
+	}{
+		{
+			name:    "idle",
+			metrics: metrics(series(0.2, 0.5, 0.1, 0.3), series(100, 200, 50, 80), series(100, 100, 50, 80)),
+			want:    true,
+		},
+		{
+			name: "single burst is ignored",
+			metrics: metrics(
+				series(0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 90),
+				series(10), series(10)),
+			want: true,
+		},
+		{
+			name:    "busy cpu",
+			metrics: metrics(series(15, 20, 30, 25), series(10), series(10)),
+			want:    false,
+		},
+		{
+			name:    "busy network",
+			metrics: metrics(series(0.1, 0.1), series(50_000, 60_000), series(10, 10)),
+			want:    false,
+		},
+		{
+			name:    "no data",
+			metrics: metrics(series(), series(), series()),
+			want:    false,
+		},
+		{
+			name:    "nil metrics",
+			metrics: nil,
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Analyze(tt.metrics, th); got.Idle != tt.want {
+				t.Errorf("Idle = %v, want %v (report %+v)", got.Idle, tt.want, got)
+			}
+		})
+	}
+}
+
+type stubMetricsProvider struct {
+	domain.Provider
+	metrics    *domain.ServerMetrics
+	err        error
+	start, end time.Time
+}
+
+func (s *stubMetricsProvider) GetServerMetrics(_ context.Context, _ string, _ []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
+	s.start, s.end = start, end
+	return s.metrics, s.err
+}
+
+func TestCheck(t *testing.T) {
+	p := &stubMetricsProvider{metrics: metrics(series(0.1), series(1), series(1))}
+
+	report, err := Check(context.Background(), p, "1", 48*time.Hour, DefaultThresholds())
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if got := p.end.Sub(p.start); got != 48*time.Hour {
+		t.Errorf("requested window = %v, want 48h", got)
+	}
+	if !report.Idle || report.Window != 48*time.Hour {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if !strings.Contains(report.Recommendation(), "Idle for 2d") {
+		t.Errorf("unexpected recommendation: %q", report.Recommendation())
+	}
+}
+
+func TestCheck_Error(t *testing.T) {
+	p := &stubMetricsProvider{err: errors.New("boom")}
+
+	if _, err := Check(context.Background(), p, "1", time.Hour, DefaultThresholds()); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{" 90m ", 90 * time.Minute, false},
+		{"30m", 0, true},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseWindow(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWindow(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseWindow(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFormatWindow(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		7 * 24 * time.Hour: "7d",
+		36 * time.Hour:     "36h",
+		90 * time.Minute:   "1h30m",
+	} {
+		if got := FormatWindow(d); got != want {
+			t.Errorf("FormatWindow(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
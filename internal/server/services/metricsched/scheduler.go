@@ -0,0 +1,404 @@
+// Package metricsched schedules metrics requests so views that fetch
+// metrics for many servers (or for the same server repeatedly) stay
+// within provider rate limits.
+//
+// A Scheduler wraps a domain.MetricsProvider and is one itself. Requests
+// for the same server made within a short window are batched into one
+// provider call, requests covered by an in-flight call or a fresh cached
+// result are answered without a call, and results are cached for the
+// display TTL. After the provider reports a rate limit, calls pause for a
+// cooldown and cached results are served even when stale.
+package metricsched
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+const (
+	// DefaultTTL is how long fetched metrics are reused.
+	DefaultTTL = time.Minute
+
+	// DefaultWindow is how long a request waits for others to join its
+	// batch before the provider is called.
+	DefaultWindow = 25 * time.Millisecond
+
+	// DefaultCooldown is how long provider calls pause after a rate limit.
+	DefaultCooldown = 30 * time.Second
+
+	// targetPoints is the number of points providers aim for per range;
+	// it determines the resolution a cached result offers.
+	targetPoints = 60
+
+	// maxStretch bounds how far a batch's time range may grow beyond a
+	// request's own range before the coarser resolution would show.
+	maxStretch = 1.5
+)
+
+// Options configures a Scheduler. Zero values select the defaults.
+type Options struct {
+	TTL      time.Duration
+	Window   time.Duration
+	Cooldown time.Duration
+
+	// Now returns the current time. Tests replace it.
+	Now func() time.Time
+}
+
+// Scheduler batches, deduplicates, and caches metrics requests.
+type Scheduler struct {
+	domain.MetricsProvider
+
+	ttl      time.Duration
+	window   time.Duration
+	cooldown time.Duration
+	now      func() time.Time
+
+	mu            sync.Mutex
+	cache         map[cacheKey]cacheEntry
+	batches       map[string][]*batch
+	cooldownUntil time.Time
+}
+
+type cacheKey struct {
+	serverID string
+	metric   domain.MetricType
+}
+
+type cacheEntry struct {
+	start, end time.Time
+	step       float64
+	series     map[string]domain.MetricsTimeSeries
+	fetchedAt  time.Time
+}
+
+// batch is one provider call shared by every request that joined it.
+type batch struct {
+	types      map[domain.MetricType]struct{}
+	start, end time.Time
+	dispatched bool
+
+	done   chan struct{}
+	result *domain.ServerMetrics
+	err    error
+}
+
+// New returns a Scheduler in front of provider.
+func New(provider domain.MetricsProvider, opts Options) *Scheduler {
+	s := &Scheduler{
+		MetricsProvider: provider,
+		ttl:             opts.TTL,
+		window:          opts.Window,
+		cooldown:        opts.Cooldown,
+		now:             opts.Now,
+		cache:           make(map[cacheKey]cacheEntry),
+		batches:         make(map[string][]*batch),
+	}
+	if s.ttl <= 0 {
+		s.ttl = DefaultTTL
+	}
+	if s.window < 0 {
+		s.window = 0
+	} else if s.window == 0 {
+		s.window = DefaultWindow
+	}
+	if s.cooldown <= 0 {
+		s.cooldown = DefaultCooldown
+	}
+	if s.now == nil {
+		s.now = time.Now
+	}
+	return s
+}
+
+// GetServerMetrics returns metrics for serverID, from the cache when a
+// fresh result covers the request, otherwise from a batched provider call.
+func (s *Scheduler) GetServerMetrics(ctx context.Context, serverID string, types []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
+	s.mu.Lock()
+	if m := s.fromCache(serverID, types, start, end, false); m != nil {
+		s.mu.Unlock()
+		return m, nil
+	}
+	if s.now().Before(s.cooldownUntil) {
+		m := s.fromCache(serverID, types, start, end, true)
+		s.mu.Unlock()
+		if m != nil {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to fetch metrics: %w", domain.ErrRateLimited)
+	}
+
+	b, owner := s.join(serverID, types, start, end)
+	s.mu.Unlock()
+
+	if owner {
+		s.dispatch(ctx, serverID, b)
+	}
+
+	select {
+	case <-b.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if b.err != nil {
+		if errors.Is(b.err, domain.ErrRateLimited) {
+			s.mu.Lock()
+			m := s.fromCache(serverID, types, start, end, true)
+			s.mu.Unlock()
+			if m != nil {
+				return m, nil
+			}
+		}
+		return nil, b.err
+	}
+	return project(b.result, types, start, end), nil
+}
+
+// join adds the request to a compatible batch for serverID, or starts a
+// new one. owner reports whether the caller must dispatch it. The caller
+// holds s.mu.
+func (s *Scheduler) join(serverID string, types []domain.MetricType, start, end time.Time) (b *batch, owner bool) {
+	for _, b := range s.batches[serverID] {
+		if b.dispatched {
+			if b.covers(types, start, end) {
+				return b, false
+			}
+			continue
+		}
+		if compatible(b.start, b.end, start, end) {
+			for _, t := range types {
+				b.types[t] = struct{}{}
+			}
+			if start.Before(b.start) {
+				b.start = start
+			}
+			if end.After(b.end) {
+				b.end = end
+			}
+			return b, false
+		}
+	}
+
+	b = &batch{
+		types: make(map[domain.MetricType]struct{}, len(types)),
+		start: start,
+		end:   end,
+		done:  make(chan struct{}),
+	}
+	for _, t := range types {
+		b.types[t] = struct{}{}
+	}
+	s.batches[serverID] = append(s.batches[serverID], b)
+	return b, true
+}
+
+// dispatch waits for the batch window, then makes the provider call and
+// publishes its result to every request in the batch.
+func (s *Scheduler) dispatch(ctx context.Context, serverID string, b *batch) {
+	if s.window > 0 {
+		timer := time.NewTimer(s.window)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	s.mu.Lock()
+	b.dispatched = true
+	types := make([]domain.MetricType, 0, len(b.types))
+	for t := range b.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	start, end := b.start, b.end
+	s.mu.Unlock()
+
+	// The call outlives the owner's context when others joined the batch;
+	// a cancelled owner must not fail their requests.
+	callCtx := context.WithoutCancel(ctx)
+	fetchedAt := s.now()
+	result, err := s.MetricsProvider.GetServerMetrics(callCtx, serverID, types, start, end)
+
+	s.mu.Lock()
+	b.result, b.err = result, err
+	if err == nil && result != nil {
+		s.store(serverID, types, start, end, fetchedAt, result)
+	}
+	if errors.Is(err, domain.ErrRateLimited) {
+		s.cooldownUntil = s.now().Add(s.cooldown)
+	}
+	s.removeBatch(serverID, b)
+	s.mu.Unlock()
+	close(b.done)
+}
+
+// store caches result per metric type. The caller holds s.mu.
+func (s *Scheduler) store(serverID string, types []domain.MetricType, start, end, fetchedAt time.Time, result *domain.ServerMetrics) {
+	for _, t := range types {
+		entry := cacheEntry{
+			start:     start,
+			end:       end,
+			step:      result.Step,
+			series:    make(map[string]domain.MetricsTimeSeries),
+			fetchedAt: fetchedAt,
+		}
+		for name, ts := range result.TimeSeries {
+			if seriesType(name) == t {
+				entry.series[name] = ts
+			}
+		}
+		s.cache[cacheKey{serverID: serverID, metric: t}] = entry
+	}
+}
+
+func (s *Scheduler) removeBatch(serverID string, b *batch) {
+	batches := s.batches[serverID]
+	for i, other := range batches {
+		if other == b {
+			s.batches[serverID] = append(batches[:i], batches[i+1:]...)
+			break
+		}
+	}
+	if len(s.batches[serverID]) == 0 {
+		delete(s.batches, serverID)
+	}
+}
+
+// fromCache assembles a result from cached entries, or returns nil if any
+// requested type is missing, stale (unless allowStale), or doesn't cover
+// the range at a fine enough resolution. The caller holds s.mu.
+func (s *Scheduler) fromCache(serverID string, types []domain.MetricType, start, end time.Time, allowStale bool) *domain.ServerMetrics {
+	if len(types) == 0 {
+		return nil
+	}
+	now := s.now()
+	result := &domain.ServerMetrics{
+		Start:      start,
+		End:        end,
+		TimeSeries: make(map[string]domain.MetricsTimeSeries),
+	}
+	for _, t := range types {
+		entry, ok := s.cache[cacheKey{serverID: serverID, metric: t}]
+		if !ok {
+			return nil
+		}
+		if !allowStale && now.Sub(entry.fetchedAt) >= s.ttl {
+			return nil
+		}
+		// A range that ended at fetch time was "up to now"; it keeps
+		// standing in for "up to now" while the entry is in use.
+		haveEnd := entry.end
+		if !entry.end.Before(entry.fetchedAt.Add(-time.Second)) {
+			haveEnd = later(entry.end, now)
+		}
+		if !covers(entry.start, haveEnd, entry.step, start, end) {
+			return nil
+		}
+		if entry.step > result.Step {
+			result.Step = entry.step
+		}
+		for name, ts := range entry.series {
+			result.TimeSeries[name] = trim(ts, start, end)
+		}
+	}
+	return result
+}
+
+// covers reports whether the batch will return everything the request
+// needs.
+func (b *batch) covers(types []domain.MetricType, start, end time.Time) bool {
+	for _, t := range types {
+		if _, ok := b.types[t]; !ok {
+			return false
+		}
+	}
+	return covers(b.start, b.end, stepFor(b.start, b.end), start, end)
+}
+
+// covers reports whether data fetched for [haveStart, haveEnd] at the
+// given step serves a request for [start, end] without visibly coarser
+// resolution than a dedicated call would give.
+func covers(haveStart, haveEnd time.Time, step float64, start, end time.Time) bool {
+	if start.Before(haveStart) || end.After(haveEnd) {
+		return false
+	}
+	return step <= stepFor(start, end)*maxStretch
+}
+
+// compatible reports whether two ranges can share one call: their union
+// must stay close to each range's own length.
+func compatible(aStart, aEnd, bStart, bEnd time.Time) bool {
+	union := later(aEnd, bEnd).Sub(earlier(aStart, bStart))
+	shortest := min(aEnd.Sub(aStart), bEnd.Sub(bStart))
+	return float64(union) <= float64(shortest)*maxStretch
+}
+
+// stepFor is the resolution, in seconds, providers use for a range.
+func stepFor(start, end time.Time) float64 {
+	return max(1, float64(int(end.Sub(start).Seconds()/targetPoints)))
+}
+
+// project narrows result to the requested types and range.
+func project(result *domain.ServerMetrics, types []domain.MetricType, start, end time.Time) *domain.ServerMetrics {
+	if result == nil {
+		return nil
+	}
+	wanted := make(map[domain.MetricType]struct{}, len(types))
+	for _, t := range types {
+		wanted[t] = struct{}{}
+	}
+	out := &domain.ServerMetrics{
+		Start:      start,
+		End:        end,
+		Step:       result.Step,
+		TimeSeries: make(map[string]domain.MetricsTimeSeries),
+	}
+	for name, ts := range result.TimeSeries {
+		if _, ok := wanted[seriesType(name)]; ok {
+			out.TimeSeries[name] = trim(ts, start, end)
+		}
+	}
+	return out
+}
+
+// trim drops points outside [start, end].
+func trim(ts domain.MetricsTimeSeries, start, end time.Time) domain.MetricsTimeSeries {
+	from, to := float64(start.Unix()), float64(end.Unix())
+	values := make([]domain.MetricsPoint, 0, len(ts.Values))
+	for _, p := range ts.Values {
+		if p.Timestamp >= from && p.Timestamp <= to {
+			values = append(values, p)
+		}
+	}
+	return domain.MetricsTimeSeries{Name: ts.Name, Values: values}
+}
+
+// seriesType maps a series name such as "disk.0.iops.read" to the metric
+// type it belongs to.
+func seriesType(name string) domain.MetricType {
+	prefix, _, _ := strings.Cut(name, ".")
+	return domain.MetricType(prefix)
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,235 @@
+package metricsched
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+var base = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type call struct {
+	serverID   string
+	types      []domain.MetricType
+	start, end time.Time
+}
+
+type stubMetricsProvider struct {
+	domain.Provider
+
+	mu    sync.Mutex
+	calls []call
+	err   error
+}
+
+func (s *stubMetricsProvider) GetServerMetrics(_ context.Context, serverID string, types []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call{serverID: serverID, types: types, start: start, end: end})
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	point := func(at time.Time) domain.MetricsPoint {
+		return domain.MetricsPoint{Timestamp: float64(at.Unix()), Value: 1}
+	}
+	series := map[string]domain.MetricsTimeSeries{}
+	for _, t := range types {
+		name := string(t)
+		if t != domain.MetricCPU {
+			name += ".0.bandwidth.in"
+		}
+		series[name] = domain.MetricsTimeSeries{Name: name, Values: []domain.MetricsPoint{point(start), point(end)}}
+	}
+	return &domain.ServerMetrics{Start: start, End: end, Step: stepFor(start, end), TimeSeries: series}, nil
+}
+
+func (s *stubMetricsProvider) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.calls)
+}
+
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestScheduler(window time.Duration) (*Scheduler, *stubMetricsProvider, *clock) {
+	p := &stubMetricsProvider{}
+	c := &clock{now: base}
+	return New(p, Options{TTL: time.Minute, Window: window, Now: c.Now}), p, c
+}
+
+func TestScheduler_BatchesConcurrentRequests(t *testing.T) {
+	s, p, _ := newTestScheduler(50 * time.Millisecond)
+	start := base.Add(-time.Hour)
+
+	var wg sync.WaitGroup
+	results := make([]*domain.ServerMetrics, 2)
+	errs := make([]error, 2)
+	for i, types := range [][]domain.MetricType{{domain.MetricCPU}, {domain.MetricNetwork}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = s.GetServerMetrics(context.Background(), "1", types, start, base)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := p.callCount(); got != 1 {
+		t.Fatalf("expected 1 provider call, got %d", got)
+	}
+	if got := len(p.calls[0].types); got != 2 {
+		t.Errorf("expected the call to request both types, got %v", p.calls[0].types)
+	}
+	if _, ok := results[0].TimeSeries["cpu"]; !ok || len(results[0].TimeSeries) != 1 {
+		t.Errorf("expected only cpu series in first result, got %v", results[0].TimeSeries)
+	}
+	if _, ok := results[1].TimeSeries["network.0.bandwidth.in"]; !ok || len(results[1].TimeSeries) != 1 {
+		t.Errorf("expected only network series in second result, got %v", results[1].TimeSeries)
+	}
+}
+
+func TestScheduler_DoesNotBatchDifferentServers(t *testing.T) {
+	s, p, _ := newTestScheduler(20 * time.Millisecond)
+	start := base.Add(-time.Hour)
+
+	var wg sync.WaitGroup
+	for _, id := range []string{"1", "2"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.GetServerMetrics(context.Background(), id, []domain.MetricType{domain.MetricCPU}, start, base)
+		}()
+	}
+	wg.Wait()
+
+	if got := p.callCount(); got != 2 {
+		t.Errorf("expected 2 provider calls, got %d", got)
+	}
+}
+
+func TestScheduler_ServesOverlappingRangeFromCache(t *testing.T) {
+	s, p, c := newTestScheduler(-1)
+	ctx := context.Background()
+	cpu := []domain.MetricType{domain.MetricCPU}
+
+	if _, err := s.GetServerMetrics(ctx, "1", cpu, base.Add(-time.Hour), base); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The same live window a few seconds later, and a narrower range
+	// inside it, are served from the cache.
+	c.Advance(10 * time.Second)
+	now := c.Now()
+	if _, err := s.GetServerMetrics(ctx, "1", cpu, now.Add(-time.Hour), now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := s.GetServerMetrics(ctx, "1", cpu, base.Add(-50*time.Minute), base.Add(-10*time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := p.callCount(); n != 1 {
+		t.Fatalf("expected 1 provider call, got %d", n)
+	}
+	if points := got.TimeSeries["cpu"].Values; len(points) != 0 {
+		t.Errorf("expected points outside the range to be trimmed, got %v", points)
+	}
+}
+
+func TestScheduler_RefetchesFinerResolution(t *testing.T) {
+	s, p, _ := newTestScheduler(-1)
+	ctx := context.Background()
+	cpu := []domain.MetricType{domain.MetricCPU}
+
+	s.GetServerMetrics(ctx, "1", cpu, base.Add(-7*24*time.Hour), base)
+	s.GetServerMetrics(ctx, "1", cpu, base.Add(-time.Hour), base)
+
+	if n := p.callCount(); n != 2 {
+		t.Errorf("expected a 7-day result not to serve a 1-hour view, got %d calls", n)
+	}
+}
+
+func TestScheduler_ExpiresAfterTTL(t *testing.T) {
+	s, p, c := newTestScheduler(-1)
+	ctx := context.Background()
+	cpu := []domain.MetricType{domain.MetricCPU}
+	start := base.Add(-time.Hour)
+
+	s.GetServerMetrics(ctx, "1", cpu, start, base)
+	c.Advance(2 * time.Minute)
+	s.GetServerMetrics(ctx, "1", cpu, start, base)
+
+	if n := p.callCount(); n != 2 {
+		t.Errorf("expected a refetch after the TTL, got %d calls", n)
+	}
+}
+
+func TestScheduler_RateLimitServesStaleAndCoolsDown(t *testing.T) {
+	s, p, c := newTestScheduler(-1)
+	ctx := context.Background()
+	cpu := []domain.MetricType{domain.MetricCPU}
+	start := base.Add(-time.Hour)
+
+	if _, err := s.GetServerMetrics(ctx, "1", cpu, start, base); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c.Advance(2 * time.Minute)
+	p.err = domain.ErrRateLimited
+	got, err := s.GetServerMetrics(ctx, "1", cpu, start, base)
+	if err != nil {
+		t.Fatalf("expected stale metrics when rate limited, got error %v", err)
+	}
+	if got == nil || len(got.TimeSeries["cpu"].Values) == 0 {
+		t.Errorf("expected stale cpu series, got %+v", got)
+	}
+
+	// During the cooldown the provider isn't called at all.
+	calls := p.callCount()
+	_, err = s.GetServerMetrics(ctx, "2", cpu, start, base)
+	if !errors.Is(err, domain.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited without cached data, got %v", err)
+	}
+	if p.callCount() != calls {
+		t.Error("expected no provider call during the cooldown")
+	}
+
+	c.Advance(DefaultCooldown)
+	p.err = nil
+	if _, err := s.GetServerMetrics(ctx, "2", cpu, start, base); err != nil {
+		t.Errorf("expected calls to resume after the cooldown, got %v", err)
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	hour := base.Add(-time.Hour)
+	if !compatible(hour, base, hour.Add(time.Second), base.Add(time.Second)) {
+		t.Error("expected nearly identical ranges to be compatible")
+	}
+	if compatible(base.Add(-7*24*time.Hour), base, hour, base) {
+		t.Error("expected a 7-day and a 1-hour range not to be compatible")
+	}
+}
@@ -0,0 +1,122 @@
+package nativessh
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// ErrHostKeyChanged is returned when a server presents a different host
+// key than the one recorded for its address, e.g. because the IP was
+// reused by a new server.
+var ErrHostKeyChanged = errors.New("remote host identification has changed")
+
+// KnownHostsPath returns the file the native client records host keys in,
+// ~/.config/vpsm/known_hosts or the platform equivalent. It is separate
+// from OpenSSH's file so vpsm never rewrites ~/.ssh/known_hosts.
+func KnownHostsPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve config directory: %w", err)
+	}
+	return filepath.Join(base, "vpsm", "known_hosts"), nil
+}
+
+// hostKeyCallback checks host keys against the file at path and records
+// keys of hosts seen for the first time, like OpenSSH's
+// StrictHostKeyChecking=accept-new.
+func hostKeyCallback(path string) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if err := ensureFile(path); err != nil {
+			return err
+		}
+		check, err := knownhosts.New(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		err = check(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+		if len(keyErr.Want) > 0 {
+			return fmt.Errorf("%w for %s", ErrHostKeyChanged, hostname)
+		}
+		return appendKnownHost(path, hostname, key)
+	}
+}
+
+// ensureFile creates path and its directory if they do not exist.
+func ensureFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// appendKnownHost records key as the host key of hostname.
+func appendKnownHost(path, hostname string, key ssh.PublicKey) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintln(f, knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return nil
+}
+
+// Forget removes the host keys recorded for host on any port from the
+// file at path, the native equivalent of ssh-keygen -R.
+func Forget(path, host string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	plain := knownhosts.Normalize(host)
+	bracketed := "[" + strings.Trim(plain, "[]") + "]:"
+	matches := func(pattern string) bool {
+		return pattern == plain || strings.HasPrefix(pattern, bracketed)
+	}
+
+	var kept strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		drop := false
+		if len(fields) > 0 {
+			for _, pattern := range strings.Split(fields[0], ",") {
+				if matches(pattern) {
+					drop = true
+					break
+				}
+			}
+		}
+		if !drop {
+			kept.WriteString(line)
+			kept.WriteByte('\n')
+		}
+	}
+	if err := os.WriteFile(path, []byte(kept.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return nil
+}
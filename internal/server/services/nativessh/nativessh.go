@@ -0,0 +1,514 @@
+// Package nativessh is a pure-Go SSH client for interactive logins, used
+// instead of the system ssh binary when the ssh-client config key is
+// "native" or no ssh binary is installed. It keeps its own known_hosts
+// file, authenticates with ssh-agent and the default identity files, and
+// can forward the agent.
+package nativessh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/term"
+)
+
+// Mode selects which SSH client interactive logins use.
+type Mode string
+
+const (
+	// ModeExec runs the system ssh binary.
+	ModeExec Mode = "exec"
+	// ModeNative uses the built-in client.
+	ModeNative Mode = "native"
+)
+
+// ParseMode returns the Mode named by s. An empty value is ModeExec.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", ModeExec:
+		return ModeExec, nil
+	case ModeNative:
+		return ModeNative, nil
+	}
+	return "", fmt.Errorf("invalid ssh client %q: expected exec or native", s)
+}
+
+// lookPath finds the ssh binary. Tests replace it.
+var lookPath = exec.LookPath
+
+// Resolve returns the client to use for mode: ModeExec falls back to
+// ModeNative when no ssh binary is installed.
+func Resolve(mode Mode) Mode {
+	if mode == ModeExec {
+		if _, err := lookPath("ssh"); err != nil {
+			return ModeNative
+		}
+	}
+	return mode
+}
+
+// Configured returns the client selected by the ssh-client config key,
+// resolved with Resolve. An unreadable or invalid config selects ModeExec.
+func Configured() Mode {
+	mode := ModeExec
+	if cfg, err := config.Load(); err == nil {
+		if m, err := ParseMode(cfg.SSHClient); err == nil {
+			mode = m
+		}
+	}
+	return Resolve(mode)
+}
+
+const (
+	connectTimeout    = 10 * time.Second
+	keepaliveInterval = 60 * time.Second
+	keepaliveTimeout  = 15 * time.Second
+	keepaliveMaxMiss  = 3
+	resizePoll        = 250 * time.Millisecond
+)
+
+// Session is one interactive login. It satisfies bubbletea's
+// ExecCommand, so the TUI can hand the terminal over to it like to an
+// ssh process.
+type Session struct {
+	User string
+	Host string
+	// Port defaults to 22.
+	Port int
+	// Jump is an optional bastion ("[user@]host[:port]") the connection
+	// is tunnelled through. Otherwise the configured proxy (or
+	// ALL_PROXY) is used, if any.
+	Jump string
+	// Command runs instead of the login shell when set.
+	Command string
+	// ForwardAgent forwards the local ssh-agent to the server.
+	ForwardAgent bool
+	// Identities are private key files tried after the agent's keys.
+	// Nil means DefaultIdentities; passphrase-protected keys are skipped.
+	Identities []string
+	// KnownHosts is the host key file; empty means KnownHostsPath.
+	KnownHosts string
+
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+// SetStdin sets the session's input.
+func (s *Session) SetStdin(r io.Reader) { s.stdin = r }
+
+// SetStdout sets where the session's output goes.
+func (s *Session) SetStdout(w io.Writer) { s.stdout = w }
+
+// SetStderr sets where the session's error output goes.
+func (s *Session) SetStderr(w io.Writer) { s.stderr = w }
+
+// ApplyArgs applies the ssh arguments the native client understands, as
+// stored in ssh-options: -A, -i, -J, -p and -o ForwardAgent/Port/User.
+// Others are ignored and returned so callers can mention them.
+func (s *Session) ApplyArgs(args []string) (ignored []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		value := func() string {
+			if i+1 < len(args) {
+				i++
+				return args[i]
+			}
+			return ""
+		}
+		switch arg {
+		case "-A":
+			s.ForwardAgent = true
+		case "-a":
+			s.ForwardAgent = false
+		case "-i":
+			s.Identities = append(s.Identities, expandHome(value()))
+		case "-J":
+			s.Jump = value()
+		case "-p":
+			if port, err := strconv.Atoi(value()); err == nil {
+				s.Port = port
+			}
+		case "-o":
+			opt := value()
+			key, val, _ := strings.Cut(strings.Replace(opt, " ", "=", 1), "=")
+			switch strings.ToLower(key) {
+			case "forwardagent":
+				s.ForwardAgent = strings.EqualFold(val, "yes")
+			case "port":
+				if port, err := strconv.Atoi(val); err == nil {
+					s.Port = port
+				}
+			case "user":
+				s.User = val
+			case "identityfile":
+				s.Identities = append(s.Identities, expandHome(val))
+			default:
+				ignored = append(ignored, "-o", opt)
+			}
+		default:
+			ignored = append(ignored, arg)
+		}
+	}
+	return ignored
+}
+
+// DefaultIdentities returns the identity files OpenSSH tries by default.
+func DefaultIdentities() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		paths = append(paths, filepath.Join(home, ".ssh", name))
+	}
+	return paths
+}
+
+// expandHome replaces a leading ~/ with the home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// Run connects, runs the login shell or Command and returns once the
+// session ends. A non-zero exit status is returned as *ssh.ExitError.
+func (s *Session) Run() error {
+	ctx := context.Background()
+	stdin, stdout, stderr := s.streams()
+
+	client, agentClient, closeAgent, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
+	defer client.Close()
+	go keepalive(client, keepaliveInterval, keepaliveTimeout)
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	if s.ForwardAgent && agentClient != nil {
+		if err := agent.ForwardToAgent(client, agentClient); err == nil {
+			agent.RequestAgentForwarding(session)
+		}
+	}
+
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		restore, err := s.attachTerminal(session, int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer restore()
+	}
+
+	if s.Command != "" {
+		err = session.Start(s.Command)
+	} else {
+		err = session.Shell()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	return session.Wait()
+}
+
+// dial connects and authenticates with the agent's keys, if ssh-agent is
+// running, and the identity files. The returned function closes the
+// agent connection; it must outlive any agent forwarding.
+func (s *Session) dial(ctx context.Context) (*ssh.Client, agent.ExtendedAgent, func(), error) {
+	knownHosts := s.KnownHosts
+	if knownHosts == "" {
+		path, err := KnownHostsPath()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		knownHosts = path
+	}
+
+	var agentClient agent.ExtendedAgent
+	closeAgent := func() {}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := net.Dial("unix", sock); err == nil {
+			closeAgent = func() { conn.Close() }
+			agentClient = agent.NewClient(conn)
+		}
+	}
+
+	client, err := s.connect(ctx, agentClient, knownHosts)
+	if err != nil {
+		closeAgent()
+		return nil, nil, nil, err
+	}
+	return client, agentClient, closeAgent, nil
+}
+
+// streams returns the session's input and outputs, defaulting to the
+// process's own.
+func (s *Session) streams() (io.Reader, io.Writer, io.Writer) {
+	stdin, stdout, stderr := s.stdin, s.stdout, s.stderr
+	if stdin == nil {
+		stdin = os.Stdin
+	}
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+	return stdin, stdout, stderr
+}
+
+// connect dials the server, through the bastion or proxy if any, and
+// authenticates.
+func (s *Session) connect(ctx context.Context, agentClient agent.ExtendedAgent, knownHosts string) (*ssh.Client, error) {
+	clientConfig := s.clientConfig(s.User, agentClient, knownHosts)
+	addr := joinHostPort(s.Host, s.Port)
+
+	if s.Jump != "" {
+		jumpUser, jumpHost, jumpPort := parseJump(s.Jump, s.User)
+		jumpAddr := joinHostPort(jumpHost, jumpPort)
+		jump, err := s.dialSSH(ctx, nil, jumpAddr, s.clientConfig(jumpUser, agentClient, knownHosts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to bastion %s: %w", s.Jump, err)
+		}
+		conn, err := jump.Dial("tcp", addr)
+		if err != nil {
+			jump.Close()
+			return nil, fmt.Errorf("failed to reach %s through bastion: %w", addr, err)
+		}
+		c, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+		if err != nil {
+			conn.Close()
+			jump.Close()
+			return nil, err
+		}
+		return ssh.NewClient(&jumpedConn{Conn: c, jump: jump}, chans, reqs), nil
+	}
+
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Proxy
+	}
+	proxyURL, err := proxy.ForSSH(configured)
+	if err != nil {
+		return nil, err
+	}
+	var conn net.Conn
+	if proxyURL != nil {
+		dialCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+		defer cancel()
+		conn, err = proxy.Dial(dialCtx, proxyURL, addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+		}
+	}
+	return s.dialSSH(ctx, conn, addr, clientConfig)
+}
+
+// jumpedConn is a connection made through a bastion; closing it also
+// closes the bastion client.
+type jumpedConn struct {
+	ssh.Conn
+	jump *ssh.Client
+}
+
+func (c *jumpedConn) Close() error {
+	err := c.Conn.Close()
+	c.jump.Close()
+	return err
+}
+
+// dialSSH runs the SSH handshake over conn, dialing addr directly when
+// conn is nil.
+func (s *Session) dialSSH(ctx context.Context, conn net.Conn, addr string, clientConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	if conn == nil {
+		d := net.Dialer{Timeout: connectTimeout}
+		var err error
+		conn, err = d.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to %s: %w", addr, err)
+		}
+	}
+	conn.SetDeadline(time.Now().Add(connectTimeout))
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, clientConfig)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// clientConfig authenticates as user with the agent's keys, then the
+// identity files.
+func (s *Session) clientConfig(user string, agentClient agent.ExtendedAgent, knownHosts string) *ssh.ClientConfig {
+	var auth []ssh.AuthMethod
+	if agentClient != nil {
+		auth = append(auth, ssh.PublicKeysCallback(agentClient.Signers))
+	}
+	identities := s.Identities
+	if identities == nil {
+		identities = DefaultIdentities()
+	}
+	if signers := loadSigners(identities); len(signers) > 0 {
+		auth = append(auth, ssh.PublicKeys(signers...))
+	}
+	return &ssh.ClientConfig{
+		User:            user,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback(knownHosts),
+		Timeout:         connectTimeout,
+	}
+}
+
+// loadSigners parses the unencrypted private keys among paths.
+func loadSigners(paths []string) []ssh.Signer {
+	var signers []ssh.Signer
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+// attachTerminal puts the local terminal in raw mode, requests a remote
+// PTY of the same size and keeps its size in sync. The returned function
+// restores the terminal.
+func (s *Session) attachTerminal(session *ssh.Session, fd int) (func(), error) {
+	width, height, err := term.GetSize(fd)
+	if err != nil {
+		width, height = 80, 24
+	}
+	termType := os.Getenv("TERM")
+	if termType == "" {
+		termType = "xterm-256color"
+	}
+	modes := ssh.TerminalModes{ssh.ECHO: 1, ssh.TTY_OP_ISPEED: 14400, ssh.TTY_OP_OSPEED: 14400}
+	if err := session.RequestPty(termType, height, width, modes); err != nil {
+		return nil, fmt.Errorf("failed to request a terminal: %w", err)
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure terminal: %w", err)
+	}
+
+	// Polling works the same on every platform, unlike SIGWINCH.
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(resizePoll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				w, h, err := term.GetSize(fd)
+				if err == nil && (w != width || h != height) {
+					width, height = w, h
+					session.WindowChange(h, w)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		term.Restore(fd, state)
+	}, nil
+}
+
+// keepalive pings the server every interval like ServerAliveInterval
+// and closes the connection after keepaliveMaxMiss unanswered pings. A
+// ping not answered within timeout counts as unanswered, so a server
+// that stops replying cannot hang the loop.
+func keepalive(client *ssh.Client, interval, timeout time.Duration) {
+	missed := 0
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		// The reply is buffered so a ping that times out does not leave
+		// its goroutine blocked; closing the client ends the request.
+		reply := make(chan error, 1)
+		go func() {
+			_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+			reply <- err
+		}()
+
+		timer := time.NewTimer(timeout)
+		select {
+		case err := <-reply:
+			timer.Stop()
+			if err == nil {
+				missed = 0
+				continue
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+		case <-timer.C:
+		}
+
+		missed++
+		if missed >= keepaliveMaxMiss {
+			client.Close()
+			return
+		}
+	}
+}
+
+// parseJump splits "[user@]host[:port]", defaulting the user to
+// defaultUser and the port to 22.
+func parseJump(jump, defaultUser string) (user, host string, port int) {
+	user = defaultUser
+	if u, rest, ok := strings.Cut(jump, "@"); ok {
+		user, jump = u, rest
+	}
+	host = jump
+	if h, p, err := net.SplitHostPort(jump); err == nil {
+		host = h
+		port, _ = strconv.Atoi(p)
+	}
+	return user, host, port
+}
+
+// joinHostPort joins host and port, defaulting the port to 22.
+func joinHostPort(host string, port int) string {
+	if port == 0 {
+		port = 22
+	}
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
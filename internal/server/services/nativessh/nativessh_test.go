@@ -0,0 +1,324 @@
+package nativessh
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// newTestSigner returns a fresh ed25519 signer and its private key PEM.
+func newTestSigner(t *testing.T) (ssh.Signer, []byte) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, pem.EncodeToMemory(block)
+}
+
+// newTestServer starts an SSH server that accepts clientKey and answers
+// exec requests with "ran: <command>". It returns the server's address.
+func newTestServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey) string {
+	t.Helper()
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown key")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestConn(conn, config)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func serveTestConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer ch.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				req.Reply(true, nil)
+				length := binary.BigEndian.Uint32(req.Payload)
+				ch.Write([]byte("ran: " + string(req.Payload[4:4+length]) + "\n"))
+				ch.SendRequest("exit-status", false, []byte{0, 0, 0, 0})
+				return
+			}
+		}()
+	}
+}
+
+// newTestSession returns a session for addr authenticating with keyPEM.
+func newTestSession(t *testing.T, addr string, keyPEM []byte) (*Session, *bytes.Buffer) {
+	t.Helper()
+	t.Setenv("SSH_AUTH_SOCK", "")
+	t.Setenv("ALL_PROXY", "")
+	dir := t.TempDir()
+	config.SetPath(filepath.Join(dir, "config.json"))
+	t.Cleanup(config.ResetPath)
+	keyPath := filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(keyPath, keyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	host, portStr, _ := net.SplitHostPort(addr)
+	port, _ := strconv.Atoi(portStr)
+
+	var out bytes.Buffer
+	s := &Session{
+		User:       "root",
+		Host:       host,
+		Port:       port,
+		Command:    "uptime",
+		Identities: []string{keyPath},
+		KnownHosts: filepath.Join(dir, "known_hosts"),
+	}
+	s.SetStdin(strings.NewReader(""))
+	s.SetStdout(&out)
+	s.SetStderr(&out)
+	return s, &out
+}
+
+func TestSessionRun_RecordsHostKeyAndRunsCommand(t *testing.T) {
+	hostKey, _ := newTestSigner(t)
+	clientKey, clientPEM := newTestSigner(t)
+	addr := newTestServer(t, hostKey, clientKey.PublicKey())
+
+	s, out := newTestSession(t, addr, clientPEM)
+	for i := 0; i < 2; i++ {
+		out.Reset()
+		if err := s.Run(); err != nil {
+			t.Fatalf("run %d: %v", i, err)
+		}
+		if out.String() != "ran: uptime\n" {
+			t.Errorf("run %d output = %q", i, out.String())
+		}
+	}
+
+	data, err := os.ReadFile(s.KnownHosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := knownhosts.Line([]string{knownhosts.Normalize(addr)}, hostKey.PublicKey()); strings.TrimSpace(string(data)) != want {
+		t.Errorf("known_hosts = %q, want %q", data, want)
+	}
+}
+
+func TestSessionRun_HostKeyChanged(t *testing.T) {
+	hostKey, _ := newTestSigner(t)
+	oldKey, _ := newTestSigner(t)
+	clientKey, clientPEM := newTestSigner(t)
+	addr := newTestServer(t, hostKey, clientKey.PublicKey())
+
+	s, _ := newTestSession(t, addr, clientPEM)
+	line := knownhosts.Line([]string{knownhosts.Normalize(addr)}, oldKey.PublicKey())
+	if err := os.WriteFile(s.KnownHosts, []byte(line+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Run(); !errors.Is(err, ErrHostKeyChanged) {
+		t.Fatalf("expected ErrHostKeyChanged, got %v", err)
+	}
+
+	host, _, _ := net.SplitHostPort(addr)
+	if err := Forget(s.KnownHosts, host); err != nil {
+		t.Fatalf("Forget: %v", err)
+	}
+	if err := s.Run(); err != nil {
+		t.Fatalf("expected the new key to be accepted after Forget, got %v", err)
+	}
+}
+
+func TestKeepalive_ClosesUnresponsiveServer(t *testing.T) {
+	hostKey, _ := newTestSigner(t)
+	config := &ssh.ServerConfig{NoClientAuth: true}
+	config.AddHostKey(hostKey)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, chans, reqs, err := ssh.NewServerConn(conn, config)
+		if err != nil {
+			return
+		}
+		go func() {
+			for newChan := range chans {
+				newChan.Reject(ssh.Prohibited, "no channels")
+			}
+		}()
+		// Take the pings but never answer them.
+		for range reqs {
+		}
+	}()
+
+	client, err := ssh.Dial("tcp", ln.Addr().String(), &ssh.ClientConfig{
+		User:            "root",
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         5 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		keepalive(client, 10*time.Millisecond, 20*time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected keepalive to give up on a server that never replies")
+	}
+	if err := client.Wait(); err == nil {
+		t.Error("expected the connection to be closed")
+	}
+}
+
+func TestForget_KeepsOtherHosts(t *testing.T) {
+	key, _ := newTestSigner(t)
+	path := filepath.Join(t.TempDir(), "known_hosts")
+	lines := []string{
+		knownhosts.Line([]string{"203.0.113.5"}, key.PublicKey()),
+		knownhosts.Line([]string{"[203.0.113.5]:2222"}, key.PublicKey()),
+		knownhosts.Line([]string{"203.0.113.50"}, key.PublicKey()),
+	}
+	os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600)
+
+	if err := Forget(path, "203.0.113.5"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.TrimSpace(string(data)) != lines[2] {
+		t.Errorf("known_hosts after Forget = %q", data)
+	}
+}
+
+func TestApplyArgs(t *testing.T) {
+	s := &Session{User: "root"}
+	ignored := s.ApplyArgs([]string{"-A", "-p", "2222", "-J", "jump@203.0.113.1", "-o", "User=deploy", "-i", "/keys/id", "-o", "Compression=yes", "-v"})
+
+	want := &Session{User: "deploy", Port: 2222, Jump: "jump@203.0.113.1", ForwardAgent: true, Identities: []string{"/keys/id"}}
+	if diff := cmp.Diff(want, s, cmp.AllowUnexported(Session{})); diff != "" {
+		t.Errorf("session mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"-o", "Compression=yes", "-v"}, ignored); diff != "" {
+		t.Errorf("ignored mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseJump(t *testing.T) {
+	user, host, port := parseJump("ops@bastion.example:2200", "root")
+	if user != "ops" || host != "bastion.example" || port != 2200 {
+		t.Errorf("parseJump = %q %q %d", user, host, port)
+	}
+	user, host, port = parseJump("203.0.113.1", "root")
+	if user != "root" || host != "203.0.113.1" || port != 0 {
+		t.Errorf("parseJump = %q %q %d", user, host, port)
+	}
+}
+
+// closeRecorder is an ssh.Conn that records being closed.
+type closeRecorder struct {
+	ssh.Conn
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestJumpedConn_CloseClosesBastion(t *testing.T) {
+	target, bastion := &closeRecorder{}, &closeRecorder{}
+	conn := &jumpedConn{Conn: target, jump: &ssh.Client{Conn: bastion}}
+
+	if err := conn.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !target.closed || !bastion.closed {
+		t.Errorf("closed target=%v bastion=%v, want both", target.closed, bastion.closed)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for in, want := range map[string]Mode{"": ModeExec, "exec": ModeExec, "native": ModeNative} {
+		if got, err := ParseMode(in); err != nil || got != want {
+			t.Errorf("ParseMode(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := ParseMode("putty"); err == nil {
+		t.Error("expected an error for an unknown client")
+	}
+}
+
+func TestResolve_FallsBackWithoutSSHBinary(t *testing.T) {
+	orig := lookPath
+	t.Cleanup(func() { lookPath = orig })
+	lookPath = func(string) (string, error) { return "", errors.New("not found") }
+
+	if got := Resolve(ModeExec); got != ModeNative {
+		t.Errorf("Resolve(exec) without ssh = %q, want native", got)
+	}
+}
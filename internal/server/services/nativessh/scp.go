@@ -0,0 +1,243 @@
+package nativessh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Progress reports that done of total bytes have been copied.
+type Progress func(done, total int64)
+
+// Upload copies the local file at localPath to remotePath on the server
+// over SCP. remotePath may name a directory, which receives the file
+// under its local name; a leading ~/ is relative to the login's home.
+// progress, when non-nil, is called as data is sent.
+func (s *Session) Upload(ctx context.Context, localPath, remotePath string, progress Progress) error {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", localPath, err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", localPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", localPath)
+	}
+
+	return s.scp(ctx, "scp -t "+quoteRemotePath(remotePath), func(w io.Writer, r *bufio.Reader) error {
+		return scpSend(w, r, filepath.Base(localPath), info.Mode(), info.Size(), f, progress)
+	})
+}
+
+// Download copies remotePath on the server to localPath over SCP.
+// localPath may name a directory, which receives the file under its
+// remote name. The file is written next to its destination and only
+// moved into place once complete, so a failed download leaves an
+// existing file untouched.
+func (s *Session) Download(ctx context.Context, remotePath, localPath string, progress Progress) error {
+	if info, err := os.Stat(localPath); err == nil && info.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+	f, err := os.CreateTemp(filepath.Dir(localPath), "."+filepath.Base(localPath)+".part-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", localPath, err)
+	}
+	defer os.Remove(f.Name())
+
+	var mode fs.FileMode
+	err = s.scp(ctx, "scp -f "+quoteRemotePath(remotePath), func(w io.Writer, r *bufio.Reader) error {
+		var err error
+		mode, err = scpReceive(w, r, f, progress)
+		return err
+	})
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write %s: %w", localPath, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", localPath, err)
+	}
+	if err := os.Rename(f.Name(), localPath); err != nil {
+		return fmt.Errorf("failed to write %s: %w", localPath, err)
+	}
+	return nil
+}
+
+// scp runs the remote half of an SCP transfer and drives it with
+// transfer, which writes to the remote's input and reads its output.
+// Cancelling ctx aborts the transfer.
+func (s *Session) scp(ctx context.Context, command string, transfer func(w io.Writer, r *bufio.Reader) error) error {
+	client, _, closeAgent, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeAgent()
+	defer client.Close()
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+	var stderr bytes.Buffer
+	session.Stderr = &stderr
+
+	if err := session.Start(command); err != nil {
+		return fmt.Errorf("failed to start scp: %w", err)
+	}
+	err = transfer(stdin, bufio.NewReader(stdout))
+	stdin.Close()
+	waitErr := session.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return err
+	case waitErr != nil:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("scp failed: %s", msg)
+		}
+		return fmt.Errorf("scp failed: %w", waitErr)
+	}
+	return nil
+}
+
+// scpSend plays the source side of the SCP protocol for one file of size
+// bytes read from src, named name on the remote.
+func scpSend(w io.Writer, r *bufio.Reader, name string, mode fs.FileMode, size int64, src io.Reader, progress Progress) error {
+	if err := readAck(r); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "C%04o %d %s\n", mode.Perm(), size, name); err != nil {
+		return fmt.Errorf("failed to send file header: %w", err)
+	}
+	if err := readAck(r); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(&progressWriter{w: w, total: size, progress: progress}, src, size); err != nil {
+		return fmt.Errorf("failed to send file: %w", err)
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		return fmt.Errorf("failed to send file: %w", err)
+	}
+	return readAck(r)
+}
+
+// scpReceive plays the sink side of the SCP protocol for one file,
+// writing its contents to dst. It returns the file's mode.
+func scpReceive(w io.Writer, r *bufio.Reader, dst io.Writer, progress Progress) (fs.FileMode, error) {
+	if _, err := w.Write([]byte{0}); err != nil {
+		return 0, fmt.Errorf("failed to start transfer: %w", err)
+	}
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return 0, fmt.Errorf("failed to read file header: %w", err)
+	}
+	if line[0] == 1 || line[0] == 2 {
+		return 0, errors.New(strings.TrimSpace(line[1:]))
+	}
+	mode, size, err := parseFileHeader(line)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		return 0, fmt.Errorf("failed to start transfer: %w", err)
+	}
+	if _, err := io.CopyN(&progressWriter{w: dst, total: size, progress: progress}, r, size); err != nil {
+		return 0, fmt.Errorf("failed to receive file: %w", err)
+	}
+	if err := readAck(r); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write([]byte{0}); err != nil {
+		return 0, fmt.Errorf("failed to finish transfer: %w", err)
+	}
+	return mode, nil
+}
+
+// parseFileHeader parses an SCP "C<mode> <size> <name>" line.
+func parseFileHeader(line string) (fs.FileMode, int64, error) {
+	fields := strings.SplitN(strings.TrimSuffix(line, "\n"), " ", 3)
+	if len(fields) != 3 || !strings.HasPrefix(fields[0], "C") {
+		return 0, 0, fmt.Errorf("unexpected scp response %q", strings.TrimSpace(line))
+	}
+	mode, err := strconv.ParseUint(fields[0][1:], 8, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid file mode in %q", strings.TrimSpace(line))
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil || size < 0 {
+		return 0, 0, fmt.Errorf("invalid file size in %q", strings.TrimSpace(line))
+	}
+	return fs.FileMode(mode).Perm(), size, nil
+}
+
+// readAck reads the remote's reply to a protocol step: a zero byte, or 1
+// (warning) or 2 (fatal error) followed by a message line.
+func readAck(r *bufio.Reader) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return fmt.Errorf("failed to read scp response: %w", err)
+	}
+	if b == 0 {
+		return nil
+	}
+	msg, _ := r.ReadString('\n')
+	if b == 1 || b == 2 {
+		return errors.New(strings.TrimSpace(msg))
+	}
+	return fmt.Errorf("unexpected scp response %q", strings.TrimSpace(string(b)+msg))
+}
+
+// quoteRemotePath quotes p for the remote shell. A leading ~/ is dropped
+// instead, since scp resolves relative paths from the home directory.
+func quoteRemotePath(p string) string {
+	p = strings.TrimPrefix(p, "~/")
+	if p == "~" || p == "" {
+		p = "."
+	}
+	return "'" + strings.ReplaceAll(p, "'", `'\''`) + "'"
+}
+
+// progressWriter reports the bytes written through it.
+type progressWriter struct {
+	w        io.Writer
+	done     int64
+	total    int64
+	progress Progress
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.done += int64(n)
+	if p.progress != nil {
+		p.progress(p.done, p.total)
+	}
+	return n, err
+}
@@ -0,0 +1,116 @@
+package nativessh
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// scpSink plays a remote "scp -t": it acknowledges every step and records
+// the header and contents it receives.
+func scpSink(t *testing.T, in io.Reader, out io.Writer) (header string, data []byte) {
+	t.Helper()
+	r := bufio.NewReader(in)
+	out.Write([]byte{0})
+	header, err := r.ReadString('\n')
+	if err != nil {
+		t.Errorf("sink: read header: %v", err)
+		return "", nil
+	}
+	out.Write([]byte{0})
+	mode, size, err := parseFileHeader(header)
+	if err != nil || mode != 0o640 {
+		t.Errorf("sink: parse header %q: mode %o, %v", header, mode, err)
+	}
+	data = make([]byte, size+1)
+	if _, err := io.ReadFull(r, data); err != nil {
+		t.Errorf("sink: read data: %v", err)
+	}
+	out.Write([]byte{0})
+	return header, data[:size]
+}
+
+func TestSCPSend(t *testing.T) {
+	toRemote, remoteIn := io.Pipe()
+	remoteOut, fromRemote := io.Pipe()
+	done := make(chan struct{})
+	var header string
+	var data []byte
+	go func() {
+		defer close(done)
+		header, data = scpSink(t, toRemote, fromRemote)
+	}()
+
+	var reports []int64
+	err := scpSend(remoteIn, bufio.NewReader(remoteOut), "notes.txt", 0o640, 5, strings.NewReader("hello"), func(done, total int64) {
+		reports = append(reports, done)
+		if total != 5 {
+			t.Errorf("total = %d, want 5", total)
+		}
+	})
+	if err != nil {
+		t.Fatalf("scpSend: %v", err)
+	}
+	<-done
+
+	if header != "C0640 5 notes.txt\n" {
+		t.Errorf("header = %q", header)
+	}
+	if string(data) != "hello" {
+		t.Errorf("data = %q, want %q", data, "hello")
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != 5 {
+		t.Errorf("progress reports = %v, want to end at 5", reports)
+	}
+}
+
+func TestSCPSend_RemoteError(t *testing.T) {
+	remote := bufio.NewReader(strings.NewReader("\x02scp: /srv/app: Permission denied\n"))
+	err := scpSend(io.Discard, remote, "notes.txt", 0o644, 5, strings.NewReader("hello"), nil)
+	if err == nil || err.Error() != "scp: /srv/app: Permission denied" {
+		t.Errorf("err = %v, want the remote's message", err)
+	}
+}
+
+func TestSCPReceive(t *testing.T) {
+	remote := bufio.NewReader(strings.NewReader("C0755 5 deploy.sh\nhello\x00"))
+	var sent, got bytes.Buffer
+	mode, err := scpReceive(&sent, remote, &got, nil)
+	if err != nil {
+		t.Fatalf("scpReceive: %v", err)
+	}
+	if got.String() != "hello" {
+		t.Errorf("data = %q, want %q", got.String(), "hello")
+	}
+	if mode != 0o755 {
+		t.Errorf("mode = %o, want 755", mode)
+	}
+	if diff := cmp.Diff([]byte{0, 0, 0}, sent.Bytes()); diff != "" {
+		t.Errorf("acks mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSCPReceive_MissingFile(t *testing.T) {
+	remote := bufio.NewReader(strings.NewReader("\x01scp: /tmp/nope: No such file or directory\n"))
+	_, err := scpReceive(io.Discard, remote, io.Discard, nil)
+	if err == nil || !strings.Contains(err.Error(), "No such file") {
+		t.Errorf("err = %v, want the remote's message", err)
+	}
+}
+
+func TestQuoteRemotePath(t *testing.T) {
+	tests := map[string]string{
+		"~/backups/db.sql": "'backups/db.sql'",
+		"~":                "'.'",
+		"/etc/it's":        `'/etc/it'\''s'`,
+	}
+	for in, want := range tests {
+		if got := quoteRemotePath(in); got != want {
+			t.Errorf("quoteRemotePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
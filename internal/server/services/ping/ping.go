@@ -0,0 +1,306 @@
+// Package ping checks whether a server's public addresses answer. It sends
+// ICMP echo requests where the system allows it and otherwise falls back to
+// timing TCP connects to common ports.
+package ping
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// Methods a result can be measured with.
+const (
+	MethodICMP = "icmp"
+	MethodTCP  = "tcp"
+)
+
+// Options control how many probes are sent and how long to wait for them.
+type Options struct {
+	Count    int
+	Interval time.Duration
+	Timeout  time.Duration
+	// Ports are tried in order when ICMP is unavailable; the first one
+	// that answers is used for every probe.
+	Ports []int
+}
+
+// DefaultOptions sends four probes a quarter second apart, waiting up to
+// two seconds for each.
+func DefaultOptions() Options {
+	return Options{
+		Count:    4,
+		Interval: 250 * time.Millisecond,
+		Timeout:  2 * time.Second,
+		Ports:    []int{22, 80, 443},
+	}
+}
+
+// Result summarises the probes sent to one address.
+type Result struct {
+	Address  string
+	Method   string
+	Port     int
+	Sent     int
+	Received int
+	Min      time.Duration
+	Avg      time.Duration
+	Max      time.Duration
+	// Err is set when the address could not be probed at all, as opposed
+	// to probes that went unanswered.
+	Err string
+}
+
+// Reachable reports whether any probe was answered.
+func (r Result) Reachable() bool {
+	return r.Received > 0
+}
+
+// Loss returns the share of unanswered probes as a percentage.
+func (r Result) Loss() float64 {
+	if r.Sent == 0 {
+		return 100
+	}
+	return float64(r.Sent-r.Received) / float64(r.Sent) * 100
+}
+
+// MethodLabel describes how the address was probed, e.g. "icmp" or
+// "tcp/22".
+func (r Result) MethodLabel() string {
+	if r.Method == MethodTCP && r.Port != 0 {
+		return MethodTCP + "/" + strconv.Itoa(r.Port)
+	}
+	return r.Method
+}
+
+// Summary describes the outcome in one line, e.g. "4/4 answered, 0% loss,
+// rtt 11.2/12.0/13.1 ms (min/avg/max)".
+func (r Result) Summary() string {
+	if r.Err != "" {
+		return r.Err
+	}
+	s := fmt.Sprintf("%d/%d answered, %.0f%% loss", r.Received, r.Sent, r.Loss())
+	if r.Reachable() {
+		s += fmt.Sprintf(", rtt %s/%s/%s ms (min/avg/max)", millis(r.Min), millis(r.Avg), millis(r.Max))
+	}
+	return s
+}
+
+func millis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d.Microseconds())/1000, 'f', 1, 64)
+}
+
+// Addresses returns the public addresses of server worth probing: its IPv4
+// and the IPv6 address images configure by default.
+func Addresses(server domain.Server) []string {
+	var addrs []string
+	if server.PublicIPv4 != "" {
+		addrs = append(addrs, server.PublicIPv4)
+	}
+	if v6 := server.ReachableIPv6(); v6 != "" {
+		addrs = append(addrs, v6)
+	}
+	return addrs
+}
+
+// Server probes each public address of server in turn.
+func Server(ctx context.Context, server domain.Server, opts Options) ([]Result, error) {
+	addrs := Addresses(server)
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("server %q has no public IP address", server.Name)
+	}
+	results := make([]Result, 0, len(addrs))
+	for _, addr := range addrs {
+		results = append(results, Address(ctx, addr, opts))
+	}
+	return results, nil
+}
+
+// Address probes addr with ICMP echo, falling back to TCP connects when an
+// ICMP socket cannot be opened (typically because the process is
+// unprivileged).
+func Address(ctx context.Context, addr string, opts Options) Result {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return Result{Address: addr, Err: fmt.Sprintf("invalid address: %v", err)}
+	}
+	ip = ip.Unmap()
+	if opts.Count < 1 {
+		opts.Count = 1
+	}
+	if res, err := pingICMP(ctx, ip, opts); err == nil {
+		return res
+	}
+	return pingTCP(ctx, ip, opts)
+}
+
+// ListenICMP opens an ICMP socket. Tests replace it to force the TCP
+// fallback.
+var ListenICMP = icmp.ListenPacket
+
+// pingICMP sends echo requests to ip. It returns an error only when no
+// ICMP socket could be opened.
+func pingICMP(ctx context.Context, ip netip.Addr, opts Options) (Result, error) {
+	conn, privileged, err := openICMP(ip)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	proto, echo, reply := 1, icmp.Type(ipv4.ICMPTypeEcho), icmp.Type(ipv4.ICMPTypeEchoReply)
+	if ip.Is6() {
+		proto, echo, reply = 58, ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+	var dst net.Addr = &net.UDPAddr{IP: ip.AsSlice()}
+	if privileged {
+		dst = &net.IPAddr{IP: ip.AsSlice()}
+	}
+	// Unprivileged sockets have their echo ID rewritten by the kernel,
+	// so replies are matched on sequence number alone.
+	id := int(time.Now().UnixNano() & 0xffff)
+
+	res := Result{Address: ip.String(), Method: MethodICMP}
+	var rtts []time.Duration
+	buf := make([]byte, 1500)
+	for seq := range opts.Count {
+		if seq > 0 && !sleep(ctx, opts.Interval) {
+			break
+		}
+		msg := icmp.Message{Type: echo, Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("vpsm")}}
+		b, err := msg.Marshal(nil)
+		if err != nil {
+			return Result{}, err
+		}
+		start := time.Now()
+		res.Sent++
+		if _, err := conn.WriteTo(b, dst); err != nil {
+			continue
+		}
+		deadline := start.Add(opts.Timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		conn.SetReadDeadline(deadline)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				break // timed out: probe lost
+			}
+			got, err := icmp.ParseMessage(proto, buf[:n])
+			if err != nil || got.Type != reply {
+				continue
+			}
+			body, ok := got.Body.(*icmp.Echo)
+			if !ok || body.Seq != seq || (privileged && body.ID != id) {
+				continue
+			}
+			rtts = append(rtts, time.Since(start))
+			break
+		}
+	}
+	res.summarise(rtts)
+	return res, nil
+}
+
+// openICMP tries an unprivileged datagram ICMP socket first, then a raw
+// one.
+func openICMP(ip netip.Addr) (*icmp.PacketConn, bool, error) {
+	network, raw, laddr := "udp4", "ip4:icmp", "0.0.0.0"
+	if ip.Is6() {
+		network, raw, laddr = "udp6", "ip6:ipv6-icmp", "::"
+	}
+	if conn, err := ListenICMP(network, laddr); err == nil {
+		return conn, false, nil
+	}
+	conn, err := ListenICMP(raw, laddr)
+	if err != nil {
+		return nil, false, err
+	}
+	return conn, true, nil
+}
+
+// pingTCP times TCP connects to the first port in opts.Ports that
+// answers. A refused connection still proves the host is up.
+func pingTCP(ctx context.Context, ip netip.Addr, opts Options) Result {
+	res := Result{Address: ip.String(), Method: MethodTCP}
+	var rtts []time.Duration
+	ports := opts.Ports
+	for i := 0; i < opts.Count; i++ {
+		if i > 0 && !sleep(ctx, opts.Interval) {
+			break
+		}
+		res.Sent++
+		// Until a port answers, each probe tries the remaining ports.
+		for len(ports) > 0 {
+			rtt, ok := dialTCP(ctx, netip.AddrPortFrom(ip, uint16(ports[0])), opts.Timeout)
+			if ok {
+				res.Port = ports[0]
+				rtts = append(rtts, rtt)
+				ports = ports[:1]
+				break
+			}
+			if res.Port != 0 {
+				break
+			}
+			ports = ports[1:]
+		}
+		if len(ports) == 0 {
+			break // nothing answered; further probes would only wait again
+		}
+	}
+	res.summarise(rtts)
+	return res
+}
+
+// dialTCP connects to addr and reports how long the handshake took, and
+// whether the host answered at all.
+func dialTCP(ctx context.Context, addr netip.AddrPort, timeout time.Duration) (time.Duration, bool) {
+	dialer := net.Dialer{Timeout: timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", addr.String())
+	rtt := time.Since(start)
+	if err != nil {
+		return rtt, errors.Is(err, syscall.ECONNREFUSED)
+	}
+	conn.Close()
+	return rtt, true
+}
+
+// summarise fills in the received count and round-trip statistics.
+func (r *Result) summarise(rtts []time.Duration) {
+	r.Received = len(rtts)
+	if len(rtts) == 0 {
+		return
+	}
+	var total time.Duration
+	r.Min, r.Max = rtts[0], rtts[0]
+	for _, d := range rtts {
+		total += d
+		r.Min = min(r.Min, d)
+		r.Max = max(r.Max, d)
+	}
+	r.Avg = total / time.Duration(len(rtts))
+}
+
+// sleep waits for d, returning false if ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
@@ -0,0 +1,127 @@
+package ping
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"golang.org/x/net/icmp"
+)
+
+// noICMP forces the TCP fallback for the duration of a test.
+func noICMP(t *testing.T) {
+	t.Helper()
+	orig := ListenICMP
+	ListenICMP = func(string, string) (*icmp.PacketConn, error) {
+		return nil, errors.New("operation not permitted")
+	}
+	t.Cleanup(func() { ListenICMP = orig })
+}
+
+// listenerPort starts a TCP listener on loopback that accepts and closes
+// connections, returning its port.
+func listenerPort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// closedPort returns a loopback port nothing listens on.
+func closedPort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+	return port
+}
+
+func testOptions(ports ...int) Options {
+	return Options{Count: 3, Interval: time.Millisecond, Timeout: time.Second, Ports: ports}
+}
+
+func TestAddress_TCPFallback(t *testing.T) {
+	noICMP(t)
+	port := listenerPort(t)
+
+	res := Address(context.Background(), "127.0.0.1", testOptions(port))
+	if res.Method != MethodTCP || res.Port != port {
+		t.Errorf("method = %s, want tcp/%d", res.MethodLabel(), port)
+	}
+	if res.Sent != 3 || res.Received != 3 || res.Loss() != 0 {
+		t.Errorf("sent %d received %d loss %.0f%%, want 3/3/0%%", res.Sent, res.Received, res.Loss())
+	}
+	if res.Min > res.Avg || res.Avg > res.Max {
+		t.Errorf("expected min <= avg <= max, got %v %v %v", res.Min, res.Avg, res.Max)
+	}
+}
+
+func TestAddress_RefusedPortStillAnswers(t *testing.T) {
+	noICMP(t)
+	port := closedPort(t)
+
+	res := Address(context.Background(), "127.0.0.1", testOptions(port))
+	if !res.Reachable() || res.Port != port {
+		t.Errorf("expected a refused connection to count as reachable, got %+v", res)
+	}
+}
+
+func TestAddress_InvalidAddress(t *testing.T) {
+	res := Address(context.Background(), "not-an-ip", DefaultOptions())
+	if res.Err == "" || res.Reachable() {
+		t.Errorf("expected an error for an invalid address, got %+v", res)
+	}
+}
+
+func TestServer_NoPublicIP(t *testing.T) {
+	if _, err := Server(context.Background(), domain.Server{Name: "web"}, DefaultOptions()); err == nil {
+		t.Error("expected an error for a server without public IPs")
+	}
+}
+
+func TestAddresses(t *testing.T) {
+	server := domain.Server{PublicIPv4: "203.0.113.5", PublicIPv6Network: "2001:db8::/64"}
+	got := Addresses(server)
+	if len(got) != 2 || got[0] != "203.0.113.5" || got[1] != "2001:db8::1" {
+		t.Errorf("Addresses = %v", got)
+	}
+}
+
+func TestResultLoss(t *testing.T) {
+	r := Result{Sent: 4, Received: 3}
+	if r.Loss() != 25 {
+		t.Errorf("Loss = %v, want 25", r.Loss())
+	}
+	if (Result{}).Loss() != 100 {
+		t.Error("expected 100% loss when nothing was sent")
+	}
+}
+
+func TestResultSummary(t *testing.T) {
+	r := Result{Sent: 4, Received: 4, Min: 11200 * time.Microsecond, Avg: 12 * time.Millisecond, Max: 13100 * time.Microsecond}
+	if got, want := r.Summary(), "4/4 answered, 0% loss, rtt 11.2/12.0/13.1 ms (min/avg/max)"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+	if got, want := (Result{Sent: 2}).Summary(), "0/2 answered, 100% loss"; got != want {
+		t.Errorf("Summary = %q, want %q", got, want)
+	}
+}
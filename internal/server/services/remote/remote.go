@@ -0,0 +1,231 @@
+// Package remote runs non-interactive commands on servers over SSH.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/proxy"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// DefaultUser is the login used when no username is configured.
+const DefaultUser = "root"
+
+// Runner executes ssh with the given arguments and returns its stdout.
+// Tests replace it to avoid spawning real processes.
+var Runner = func(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, fmt.Errorf("%w: %s", err, msg)
+		}
+		return out, err
+	}
+	return out, nil
+}
+
+// Conn holds the login details used to reach a server.
+type Conn struct {
+	Username string
+	// Bastion is an optional jump host ("[user@]host[:port]") the
+	// connection is proxied through with ssh -J.
+	Bastion string
+}
+
+// PersistentSession is the remote command that attaches to the tmux
+// session "vpsm", creating it first if needed, so a dropped connection can
+// be resumed by connecting again.
+const PersistentSession = "tmux new -A -s vpsm"
+
+// JumpArgs returns the ssh arguments that route a connection through
+// bastion, or nil when no bastion is configured.
+func JumpArgs(bastion string) []string {
+	if bastion == "" {
+		return nil
+	}
+	return []string{"-J", bastion}
+}
+
+// RouteArgs returns the ssh arguments that route a connection: through
+// bastion when one is set, or else through the configured proxy (or
+// ALL_PROXY), if any. A proxy is not applied to bastion connections.
+func RouteArgs(bastion string) []string {
+	if bastion != "" {
+		return JumpArgs(bastion)
+	}
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Proxy
+	}
+	proxyURL, err := proxy.ForSSH(configured)
+	if err != nil || proxyURL == nil {
+		return nil
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	return proxy.SSHArgs(proxyURL, self)
+}
+
+// SplitOptions splits a user-supplied ssh option string such as
+// `-o ForwardAgent=yes -i "~/my keys/id"` into arguments. Single and
+// double quotes group words; there is no other shell processing.
+func SplitOptions(s string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+	)
+	for _, c := range s {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				current.WriteRune(c)
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			inArg = true
+		case c == ' ' || c == '\t' || c == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(c)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in ssh options", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		return nil, fmt.Errorf("ssh options must start with a flag, got %q", args[0])
+	}
+	return args, nil
+}
+
+// UserArgs returns the ssh arguments for the given user option strings,
+// most specific first (e.g. the server's, then the configured default).
+// ssh keeps the first value it sees for an option, so earlier layers win
+// over later ones and all of them over vpsm's built-in options, which
+// callers append afterwards. Unparseable layers are skipped; they are
+// validated when saved.
+func UserArgs(layers ...string) []string {
+	var args []string
+	for _, layer := range layers {
+		parsed, err := SplitOptions(layer)
+		if err != nil {
+			continue
+		}
+		args = append(args, parsed...)
+	}
+	return args
+}
+
+// HostVia returns the address used to reach a server through bastion.
+// Behind a bastion the private IPv4 is preferred, since it is usually the
+// only address the jump host can route to; otherwise this is Host.
+func HostVia(server domain.Server, bastion string) (string, error) {
+	if bastion != "" && server.PrivateIPv4 != "" {
+		return server.PrivateIPv4, nil
+	}
+	return Host(server)
+}
+
+// Host returns the address used to reach a server, preferring IPv4.
+func Host(server domain.Server) (string, error) {
+	if server.PublicIPv4 != "" {
+		return server.PublicIPv4, nil
+	}
+	if ip := server.ReachableIPv6(); ip != "" {
+		return ip, nil
+	}
+	return "", fmt.Errorf("server %q has no public IP address", server.Name)
+}
+
+// Exec runs command on the server over a non-interactive SSH connection
+// described by conn and returns its stdout. Output produced before a
+// failure is returned alongside the error.
+func Exec(ctx context.Context, server domain.Server, conn Conn, command string) (string, error) {
+	args, err := execArgs(server, conn, command)
+	if err != nil {
+		return "", err
+	}
+	out, err := Runner(ctx, args...)
+	return string(out), err
+}
+
+// StreamRunner executes ssh with the given arguments, copying its output
+// to stdout and stderr as it is produced. Tests replace it.
+var StreamRunner = func(ctx context.Context, stdout, stderr io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// Stream runs command on the server like Exec, but copies its output to
+// stdout and stderr as it arrives instead of collecting it. A non-zero
+// exit status is returned as an error with an ExitCode method; ssh
+// itself exits with 255 when it cannot connect.
+func Stream(ctx context.Context, server domain.Server, conn Conn, command string, stdout, stderr io.Writer) error {
+	args, err := execArgs(server, conn, command)
+	if err != nil {
+		return err
+	}
+	return StreamRunner(ctx, stdout, stderr, args...)
+}
+
+// ExitCode returns the exit status carried by err: 0 for nil, the remote
+// command's status when err has an ExitCode method, and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) && coded.ExitCode() > 0 {
+		return coded.ExitCode()
+	}
+	return 1
+}
+
+// execArgs returns the ssh arguments that run command on the server in
+// batch mode.
+func execArgs(server domain.Server, conn Conn, command string) ([]string, error) {
+	host, err := HostVia(server, conn.Bastion)
+	if err != nil {
+		return nil, err
+	}
+	username := conn.Username
+	if username == "" {
+		username = DefaultUser
+	}
+
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "ConnectTimeout=10",
+	}
+	args = append(args, RouteArgs(conn.Bastion)...)
+	args = append(args, fmt.Sprintf("%s@%s", username, host), command)
+	return args, nil
+}
@@ -0,0 +1,222 @@
+package remote
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func stubRunner(t *testing.T, out string, err error) *[]string {
+	t.Helper()
+	var got []string
+	orig := Runner
+	Runner = func(_ context.Context, args ...string) ([]byte, error) {
+		got = args
+		return []byte(out), err
+	}
+	t.Cleanup(func() { Runner = orig })
+	return &got
+}
+
+func TestExec_BuildsSSHArgs(t *testing.T) {
+	args := stubRunner(t, "up 3 days\n", nil)
+
+	out, err := Exec(context.Background(), domain.Server{PublicIPv4: "1.2.3.4"}, Conn{}, "uptime")
+	if err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if out != "up 3 days\n" {
+		t.Errorf("output = %q", out)
+	}
+
+	want := []string{
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "ConnectTimeout=10",
+		"root@1.2.3.4",
+		"uptime",
+	}
+	if diff := cmp.Diff(want, *args); diff != "" {
+		t.Errorf("ssh args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExec_FallsBackToIPv6(t *testing.T) {
+	args := stubRunner(t, "", nil)
+
+	if _, err := Exec(context.Background(), domain.Server{PublicIPv6: "2001:db8::1"}, Conn{Username: "deploy"}, "true"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if got := (*args)[6]; got != "deploy@2001:db8::1" {
+		t.Errorf("target = %q, want %q", got, "deploy@2001:db8::1")
+	}
+}
+
+func TestExec_NoPublicIP(t *testing.T) {
+	stubRunner(t, "", nil)
+
+	_, err := Exec(context.Background(), domain.Server{Name: "web"}, Conn{Username: "root"}, "true")
+	if err == nil {
+		t.Fatal("expected error for server without public IP")
+	}
+}
+
+func TestExec_ReturnsPartialOutputOnError(t *testing.T) {
+	stubRunner(t, "partial", errors.New("exit status 1"))
+
+	out, err := Exec(context.Background(), domain.Server{PublicIPv4: "1.2.3.4"}, Conn{Username: "root"}, "false")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if out != "partial" {
+		t.Errorf("output = %q, want %q", out, "partial")
+	}
+}
+
+func TestExec_ThroughBastionUsesPrivateIP(t *testing.T) {
+	args := stubRunner(t, "", nil)
+
+	server := domain.Server{PublicIPv4: "1.2.3.4", PrivateIPv4: "10.0.0.5"}
+	if _, err := Exec(context.Background(), server, Conn{Bastion: "jump@bastion.example.com:2222"}, "true"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+
+	want := []string{
+		"-o", "BatchMode=yes",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "ConnectTimeout=10",
+		"-J", "jump@bastion.example.com:2222",
+		"root@10.0.0.5",
+		"true",
+	}
+	if diff := cmp.Diff(want, *args); diff != "" {
+		t.Errorf("ssh args mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestStream_CopiesOutput(t *testing.T) {
+	orig := StreamRunner
+	var gotArgs []string
+	StreamRunner = func(_ context.Context, stdout, stderr io.Writer, args ...string) error {
+		gotArgs = args
+		io.WriteString(stdout, "ok\n")
+		io.WriteString(stderr, "warning\n")
+		return exitStatus(3)
+	}
+	t.Cleanup(func() { StreamRunner = orig })
+
+	var stdout, stderr strings.Builder
+	err := Stream(context.Background(), domain.Server{PublicIPv4: "1.2.3.4"}, Conn{Username: "deploy"}, "make test", &stdout, &stderr)
+
+	if got := ExitCode(err); got != 3 {
+		t.Errorf("ExitCode = %d, want 3", got)
+	}
+	if stdout.String() != "ok\n" || stderr.String() != "warning\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if got := gotArgs[len(gotArgs)-2:]; got[0] != "deploy@1.2.3.4" || got[1] != "make test" {
+		t.Errorf("target args = %q", got)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(nil); got != 0 {
+		t.Errorf("ExitCode(nil) = %d, want 0", got)
+	}
+	if got := ExitCode(errors.New("no public IP")); got != 1 {
+		t.Errorf("ExitCode(plain error) = %d, want 1", got)
+	}
+	if got := ExitCode(fmt.Errorf("web-1: %w", exitStatus(255))); got != 255 {
+		t.Errorf("ExitCode(wrapped) = %d, want 255", got)
+	}
+}
+
+// exitStatus is an error carrying an exit code, like *exec.ExitError.
+type exitStatus int
+
+func (e exitStatus) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitStatus) ExitCode() int { return int(e) }
+
+func TestHostVia_FallsBackToPublicWithoutPrivateIP(t *testing.T) {
+	host, err := HostVia(domain.Server{PublicIPv4: "1.2.3.4"}, "bastion")
+	if err != nil {
+		t.Fatalf("HostVia failed: %v", err)
+	}
+	if host != "1.2.3.4" {
+		t.Errorf("HostVia = %q, want %q", host, "1.2.3.4")
+	}
+}
+
+func TestHost_UsesIPv6SubnetDefaultAddress(t *testing.T) {
+	host, err := Host(domain.Server{PublicIPv6: "2001:db8::", PublicIPv6Network: "2001:db8::/64"})
+	if err != nil {
+		t.Fatalf("Host failed: %v", err)
+	}
+	if host != "2001:db8::1" {
+		t.Errorf("Host = %q, want %q", host, "2001:db8::1")
+	}
+}
+
+func TestSplitOptions(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"", nil, false},
+		{"-o ForwardAgent=yes  -J jump@203.0.113.1", []string{"-o", "ForwardAgent=yes", "-J", "jump@203.0.113.1"}, false},
+		{`-i "~/my keys/id" -o 'User=ops'`, []string{"-i", "~/my keys/id", "-o", "User=ops"}, false},
+		{`-i "~/unterminated`, nil, true},
+		{"ForwardAgent=yes", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := SplitOptions(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("SplitOptions(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("SplitOptions(%q) mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestUserArgs_MostSpecificFirst(t *testing.T) {
+	got := UserArgs("-o ForwardAgent=yes", "bad", "-o ForwardAgent=no -i key")
+	want := []string{"-o", "ForwardAgent=yes", "-o", "ForwardAgent=no", "-i", "key"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UserArgs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRouteArgs(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	t.Setenv("ALL_PROXY", "")
+	t.Setenv("all_proxy", "")
+
+	if got := RouteArgs(""); got != nil {
+		t.Errorf("expected a direct connection without a proxy, got %v", got)
+	}
+
+	cfg := &config.Config{Proxy: "socks5://127.0.0.1:1080"}
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+	got := RouteArgs("")
+	if len(got) != 2 || !strings.HasPrefix(got[1], "ProxyCommand=") || !strings.HasSuffix(got[1], " proxy-connect %h %p") {
+		t.Errorf("expected a ProxyCommand through vpsm, got %v", got)
+	}
+	if diff := cmp.Diff([]string{"-J", "jump@bastion"}, RouteArgs("jump@bastion")); diff != "" {
+		t.Errorf("expected the bastion to take precedence (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,166 @@
+// Package status checks provider status pages for ongoing incidents and
+// maintenance so the TUI can warn users during provider outages.
+//
+// Status pages are read from Statuspage-compatible summary endpoints
+// (the /api/v2/summary.json format used by most hosted status pages).
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Pages maps provider names to their status page summary endpoint.
+// Exported as a variable so tests can point it at a local server. Not
+// every provider's page is a hosted Statuspage (Hetzner runs its own), so
+// an endpoint that does not serve the summary format is reported as
+// unavailable rather than as a failure.
+var Pages = map[string]string{
+	"hetzner":      "https://status.hetzner.com/api/v2/summary.json",
+	"digitalocean": "https://status.digitalocean.com/api/v2/summary.json",
+}
+
+// ErrUnsupported is returned by Fetch when the endpoint does not serve a
+// Statuspage summary: it is missing or answers with something else.
+var ErrUnsupported = errors.New("status page does not serve a Statuspage summary")
+
+// IndicatorUnknown is the Indicator of a provider whose status page could
+// not be read.
+const IndicatorUnknown = "unknown"
+
+// requestTimeout bounds a single status page request. Status checks are
+// best-effort and must never hold up the UI.
+const requestTimeout = 10 * time.Second
+
+// Status summarises a provider's current health.
+type Status struct {
+	// Indicator is the overall status: "none", "minor", "major",
+	// "critical", or "maintenance".
+	Indicator string
+
+	// Description is the page's human-readable overall status,
+	// e.g. "All Systems Operational".
+	Description string
+
+	// Incidents lists the names of unresolved incidents and in-progress
+	// maintenance windows.
+	Incidents []string
+}
+
+// Unavailable returns the status of a provider whose status page
+// exists but cannot be read.
+func Unavailable() *Status {
+	return &Status{Indicator: IndicatorUnknown, Description: "status unavailable"}
+}
+
+// Available reports whether the status was read from the provider's page.
+func (s *Status) Available() bool {
+	return s.Indicator != IndicatorUnknown
+}
+
+// Degraded reports whether the provider is reporting an incident or
+// maintenance.
+func (s *Status) Degraded() bool {
+	return (s.Indicator != "" && s.Indicator != "none" && s.Indicator != IndicatorUnknown) || len(s.Incidents) > 0
+}
+
+// Summary returns a one-line description suitable for a banner.
+func (s *Status) Summary() string {
+	if len(s.Incidents) > 0 {
+		summary := s.Incidents[0]
+		if n := len(s.Incidents) - 1; n > 0 {
+			summary += fmt.Sprintf(" (+%d more)", n)
+		}
+		return summary
+	}
+	return s.Description
+}
+
+type summaryResponse struct {
+	Status struct {
+		Indicator   string `json:"indicator"`
+		Description string `json:"description"`
+	} `json:"status"`
+	Incidents []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"incidents"`
+	ScheduledMaintenances []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"scheduled_maintenances"`
+}
+
+// Check fetches the status page for providerName. It returns nil, nil if
+// no status page is known for the provider, and Unavailable() if the page
+// does not serve a Statuspage summary.
+func Check(ctx context.Context, client *http.Client, providerName string) (*Status, error) {
+	url, ok := Pages[providerName]
+	if !ok {
+		return nil, nil
+	}
+	st, err := Fetch(ctx, client, url)
+	if errors.Is(err, ErrUnsupported) {
+		return Unavailable(), nil
+	}
+	return st, err
+}
+
+// Fetch reads and parses a Statuspage-compatible summary endpoint.
+func Fetch(ctx context.Context, client *http.Client, url string) (*Status, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build status request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch provider status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to fetch provider status: %w", ErrUnsupported)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch provider status: unexpected HTTP status %d", resp.StatusCode)
+	}
+
+	var body summaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse provider status: %w: %v", ErrUnsupported, err)
+	}
+
+	st := &Status{
+		Indicator:   body.Status.Indicator,
+		Description: body.Status.Description,
+	}
+	for _, inc := range body.Incidents {
+		if inc.Status != "resolved" && inc.Status != "postmortem" {
+			st.Incidents = append(st.Incidents, inc.Name)
+		}
+	}
+	for _, mw := range body.ScheduledMaintenances {
+		if mw.Status == "in_progress" || mw.Status == "verifying" {
+			st.Incidents = append(st.Incidents, "Maintenance: "+mw.Name)
+		}
+	}
+	if st.Indicator == "" && len(st.Incidents) == 0 && strings.TrimSpace(st.Description) == "" {
+		return nil, fmt.Errorf("failed to parse provider status: %w: empty response", ErrUnsupported)
+	}
+
+	return st, nil
+}
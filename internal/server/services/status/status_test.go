@@ -0,0 +1,139 @@
+package status
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newStatusServer(t *testing.T, code int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetch_Operational(t *testing.T) {
+	srv := newStatusServer(t, http.StatusOK, `{
+		"status": {"indicator": "none", "description": "All Systems Operational"},
+		"incidents": [],
+		"scheduled_maintenances": [{"name": "Later", "status": "scheduled"}]
+	}`)
+
+	st, err := Fetch(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if st.Degraded() {
+		t.Errorf("expected operational status, got %+v", st)
+	}
+}
+
+func TestFetch_Incident(t *testing.T) {
+	srv := newStatusServer(t, http.StatusOK, `{
+		"status": {"indicator": "major", "description": "Partial System Outage"},
+		"incidents": [
+			{"name": "Cloud API degraded", "status": "investigating"},
+			{"name": "Old issue", "status": "resolved"}
+		],
+		"scheduled_maintenances": [{"name": "fsn1 network", "status": "in_progress"}]
+	}`)
+
+	st, err := Fetch(context.Background(), srv.Client(), srv.URL)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	want := &Status{
+		Indicator:   "major",
+		Description: "Partial System Outage",
+		Incidents:   []string{"Cloud API degraded", "Maintenance: fsn1 network"},
+	}
+	if diff := cmp.Diff(want, st); diff != "" {
+		t.Errorf("status mismatch (-want +got):\n%s", diff)
+	}
+	if !st.Degraded() {
+		t.Error("expected degraded status")
+	}
+	if got := st.Summary(); got != "Cloud API degraded (+1 more)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestFetch_HTTPError(t *testing.T) {
+	srv := newStatusServer(t, http.StatusInternalServerError, ``)
+
+	if _, err := Fetch(context.Background(), srv.Client(), srv.URL); err == nil {
+		t.Fatal("expected error for HTTP 500")
+	}
+}
+
+func TestFetch_InvalidJSON(t *testing.T) {
+	srv := newStatusServer(t, http.StatusOK, `not json`)
+
+	if _, err := Fetch(context.Background(), srv.Client(), srv.URL); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestCheck_UnsupportedPageIsUnavailable(t *testing.T) {
+	for name, srv := range map[string]*httptest.Server{
+		"not found": newStatusServer(t, http.StatusNotFound, `not found`),
+		"html":      newStatusServer(t, http.StatusOK, `<!doctype html><title>Status</title>`),
+		"other":     newStatusServer(t, http.StatusOK, `{"services": []}`),
+	} {
+		orig := Pages
+		Pages = map[string]string{"mock": srv.URL}
+
+		st, err := Check(context.Background(), srv.Client(), "mock")
+		Pages = orig
+		if err != nil {
+			t.Errorf("%s: Check failed: %v", name, err)
+			continue
+		}
+		if st.Available() || st.Degraded() || st.Summary() != "status unavailable" {
+			t.Errorf("%s: got %+v, want status unavailable", name, st)
+		}
+	}
+}
+
+func TestCheck_ServerErrorIsReported(t *testing.T) {
+	srv := newStatusServer(t, http.StatusServiceUnavailable, ``)
+
+	orig := Pages
+	Pages = map[string]string{"mock": srv.URL}
+	t.Cleanup(func() { Pages = orig })
+
+	if _, err := Check(context.Background(), srv.Client(), "mock"); err == nil {
+		t.Fatal("expected an error for HTTP 503")
+	}
+}
+
+func TestCheck_UnknownProvider(t *testing.T) {
+	st, err := Check(context.Background(), nil, "nonexistent")
+	if err != nil || st != nil {
+		t.Errorf("expected nil, nil for unknown provider, got %+v, %v", st, err)
+	}
+}
+
+func TestCheck_UsesRegisteredPage(t *testing.T) {
+	srv := newStatusServer(t, http.StatusOK, `{"status": {"indicator": "minor", "description": "Minor Service Outage"}}`)
+
+	orig := Pages
+	Pages = map[string]string{"mock": srv.URL}
+	t.Cleanup(func() { Pages = orig })
+
+	st, err := Check(context.Background(), srv.Client(), "mock")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if st.Indicator != "minor" || st.Summary() != "Minor Service Outage" {
+		t.Errorf("unexpected status: %+v", st)
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// announce prints text above the view so screen readers read it out. It
+// does nothing outside the accessible renderer, where the overlay and
+// status bars show the same information.
+func announce(text string) tea.Cmd {
+	if !styles.Accessible() || text == "" {
+		return nil
+	}
+	return tea.Println(text)
+}
+
+// stateSentence describes a completed operation on a server as a plain
+// sentence, e.g. "Server web-1 is now running."
+func stateSentence(serverName, verb string) string {
+	switch verb {
+	case "started", "rebooted":
+		return fmt.Sprintf("Server %s is now running.", serverName)
+	case "stopped":
+		return fmt.Sprintf("Server %s is now off.", serverName)
+	case "deleted", "resized", "restored":
+		return fmt.Sprintf("Server %s was %s.", serverName, verb)
+	}
+	return fmt.Sprintf("Server %s: %s.", serverName, verb)
+}
+
+// outcomeSentence announces how an overlay operation ended.
+func outcomeSentence(ev opCompletedEvent) string {
+	if ev.Success {
+		return stateSentence(ev.ServerName, ev.Verb)
+	}
+	return sentence(ev.ErrText)
+}
+
+// sentence ends text with a full stop unless it already has one.
+func sentence(text string) string {
+	if text == "" || strings.HasSuffix(text, ".") {
+		return text
+	}
+	return text + "."
+}
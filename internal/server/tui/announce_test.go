@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func withAccessible(t *testing.T) {
+	t.Helper()
+	styles.SetAccessible(true)
+	t.Cleanup(func() {
+		styles.SetAccessible(false)
+		styles.SetASCII(false)
+	})
+}
+
+func TestOutcomeSentence(t *testing.T) {
+	tests := []struct {
+		ev   opCompletedEvent
+		want string
+	}{
+		{opCompletedEvent{Success: true, ServerName: "web-1", Verb: "started"}, "Server web-1 is now running."},
+		{opCompletedEvent{Success: true, ServerName: "web-1", Verb: "stopped"}, "Server web-1 is now off."},
+		{opCompletedEvent{Success: true, ServerName: "web-1", Verb: "deleted"}, "Server web-1 was deleted."},
+		{opCompletedEvent{Success: true, ServerName: "web-1", Verb: "backups enabled"}, "Server web-1: backups enabled."},
+		{opCompletedEvent{ErrText: `Timed out waiting for server "web-1" to stop`}, `Timed out waiting for server "web-1" to stop.`},
+	}
+	for _, tt := range tests {
+		if got := outcomeSentence(tt.ev); got != tt.want {
+			t.Errorf("outcomeSentence(%+v) = %q, want %q", tt.ev, got, tt.want)
+		}
+	}
+}
+
+func TestAnnounce_OnlyWhenAccessible(t *testing.T) {
+	if announce("Server web-1 is now running.") != nil {
+		t.Error("expected no announcement outside accessible mode")
+	}
+	withAccessible(t)
+	if announce("Server web-1 is now running.") == nil {
+		t.Error("expected an announcement in accessible mode")
+	}
+	if announce("") != nil {
+		t.Error("expected nothing to announce for empty text")
+	}
+}
+
+func TestServerList_AccessibleRendersLines(t *testing.T) {
+	withAccessible(t)
+
+	m := newServerListModel(&reauthProvider{name: "Mock"}, "mock")
+	m.width, m.height = 100, 20
+	m.loading = false
+	m.servers = []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running", ServerType: "cpx11", PublicIPv4: "192.0.2.1", Region: "fsn1"},
+		{ID: "2", Name: "db-1", Status: "off", ServerType: "cpx21", Region: "nbg1"},
+	}
+	m.cursor = 1
+
+	view := ansi.Strip(m.View())
+	for _, want := range []string{
+		"2 servers",
+		"  web-1: running, type cpx11, IPv4 192.0.2.1, region fsn1",
+		"\n  > db-1: off, type cpx21, region nbg1",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, view)
+		}
+	}
+	if strings.ContainsAny(view, "─│╭") || strings.Contains(view, "--") {
+		t.Errorf("expected no box drawing or rules, got:\n%s", view)
+	}
+}
+
+func TestServerApp_AccessibleListsOperationsInline(t *testing.T) {
+	withAccessible(t)
+
+	m := newUndoTestApp()
+	m.overlay, _ = m.overlay.StartBackups(domain.Server{ID: "7", Name: "app", Status: "running"}, true)
+
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, `Operations: Enabling backups for "app"...`) {
+		t.Errorf("expected operations in the footer line, got:\n%s", view)
+	}
+	if strings.Contains(view, "+---") || strings.Contains(view, "╭") {
+		t.Errorf("expected no floating panel, got:\n%s", view)
+	}
+}
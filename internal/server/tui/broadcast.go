@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/broadcast"
+	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestBroadcastMsg asks the app to open a synchronized SSH session to
+// every server.
+type requestBroadcastMsg struct {
+	servers []domain.Server
+}
+
+// broadcastReadyMsg reports that the broadcast's tmux session was set up.
+type broadcastReadyMsg struct {
+	session string
+	panes   int
+	skipped []broadcast.Skipped
+	err     error
+}
+
+// broadcastFinishedMsg reports that the user left the broadcast session,
+// or that switching to it failed.
+type broadcastFinishedMsg struct {
+	session string
+	panes   int
+	skipped []broadcast.Skipped
+	err     error
+}
+
+// openBroadcast sets up a tmux session with one synchronized ssh pane per
+// server. Setting up does not touch the terminal, so it runs in the
+// background; attaching happens once it is ready.
+func openBroadcast(prefs *prefssvc.Service, providerName string, servers []domain.Server) tea.Cmd {
+	defaultOptions := loadSSHOptions()
+	return func() tea.Msg {
+		panes, skipped := broadcast.Panes(prefs, providerName, servers, "", defaultOptions)
+		if len(panes) == 0 {
+			return broadcastReadyMsg{skipped: skipped, err: fmt.Errorf("none of the servers can be reached over SSH")}
+		}
+		session := broadcast.SessionName(time.Now())
+		err := broadcast.Open(session, panes)
+		return broadcastReadyMsg{session: session, panes: len(panes), skipped: skipped, err: err}
+	}
+}
+
+func (m serverAppModel) handleBroadcastReady(msg broadcastReadyMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.list.status = "SSH broadcast failed: " + msg.err.Error()
+		m.list.statusIsError = true
+		return m, nil
+	}
+
+	attach := tmux.Attach(msg.session)
+	done := func(err error) tea.Msg {
+		return broadcastFinishedMsg{session: msg.session, panes: msg.panes, skipped: msg.skipped, err: err}
+	}
+
+	// Inside tmux the client switches sessions and the TUI keeps running
+	// in its own; otherwise attaching takes over the terminal.
+	if tmux.Active() {
+		return m, func() tea.Msg { return done(runTmux(attach)) }
+	}
+	attach.Stdin = os.Stdin
+	attach.Stdout = os.Stdout
+	attach.Stderr = os.Stderr
+	return m, tea.ExecProcess(attach, done)
+}
+
+func (m serverAppModel) handleBroadcastFinished(msg broadcastFinishedMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if !tmux.Active() {
+		cmd = repaintAfterExec(nil)
+	}
+	if msg.err != nil {
+		m.list.status = fmt.Sprintf("Failed to open SSH broadcast: %v (attach with: tmux attach -t %s)", msg.err, msg.session)
+		m.list.statusIsError = true
+		return m, cmd
+	}
+	m.list.status = fmt.Sprintf("SSH broadcast to %d servers in tmux session %s", msg.panes, msg.session)
+	if len(msg.skipped) > 0 {
+		m.list.status += " (skipped " + skippedNames(msg.skipped) + ")"
+	}
+	m.list.statusIsError = false
+	return m, cmd
+}
+
+// skippedNames returns the names of skipped servers, comma-separated.
+func skippedNames(skipped []broadcast.Skipped) string {
+	names := make([]string, len(skipped))
+	for i, s := range skipped {
+		names[i] = s.Server.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/broadcast"
+)
+
+func TestBroadcast_InsideTmuxSwitchesClient(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1234,0")
+	var ran []string
+	orig := runTmux
+	runTmux = func(cmd *exec.Cmd) error {
+		ran = cmd.Args
+		return nil
+	}
+	t.Cleanup(func() { runTmux = orig })
+
+	m := newReauthTestApp()
+	_, cmd := m.handleBroadcastReady(broadcastReadyMsg{
+		session: "vpsm-broadcast-1",
+		panes:   2,
+		skipped: []broadcast.Skipped{{Server: domain.Server{Name: "web-3"}, Reason: "not running"}},
+	})
+	if cmd == nil {
+		t.Fatal("expected a command switching to the session")
+	}
+	updated, _ := m.handleBroadcastFinished(cmd().(broadcastFinishedMsg))
+	if !slices.Equal(ran, []string{"tmux", "switch-client", "-t", "vpsm-broadcast-1"}) {
+		t.Errorf("ran %v", ran)
+	}
+
+	app := updated.(serverAppModel)
+	if app.list.statusIsError || !strings.Contains(app.list.status, "2 servers") || !strings.Contains(app.list.status, "skipped web-3") {
+		t.Errorf("status = %q", app.list.status)
+	}
+}
+
+func TestBroadcast_SetupErrorShowsStatus(t *testing.T) {
+	m := newReauthTestApp()
+	updated, cmd := m.handleBroadcastReady(broadcastReadyMsg{err: errors.New("tmux not found")})
+	if cmd != nil {
+		t.Error("expected no attach after a setup error")
+	}
+	app := updated.(serverAppModel)
+	if !app.list.statusIsError || !strings.Contains(app.list.status, "tmux not found") {
+		t.Errorf("status = %q", app.list.status)
+	}
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// requestBulkMsg asks the app to run verb ("start", "stop" or "delete")
+// on every server, each tracked as its own operation in the overlay.
+type requestBulkMsg struct {
+	verb    string
+	servers []domain.Server
+}
+
+// toggleMark marks or unmarks the server under the cursor and moves to
+// the next row, so holding space marks a run of servers.
+func (m serverListModel) toggleMark() serverListModel {
+	if len(m.servers) == 0 {
+		return m
+	}
+	id := m.servers[m.cursor].ID
+	marked := make(map[string]bool, len(m.marked)+1)
+	for k := range m.marked {
+		marked[k] = true
+	}
+	if marked[id] {
+		delete(marked, id)
+	} else {
+		marked[id] = true
+	}
+	m.marked = marked
+	m.confirmBulkDelete = false
+	if m.cursor < len(m.servers)-1 {
+		m.cursor++
+	}
+	return m
+}
+
+// markedServers returns the marked servers in list order. Marks for
+// servers no longer listed are ignored.
+func (m serverListModel) markedServers() []domain.Server {
+	var servers []domain.Server
+	for _, s := range m.servers {
+		if m.marked[s.ID] {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
+// withStatus returns the servers in one of statuses.
+func withStatus(servers []domain.Server, statuses ...string) []domain.Server {
+	var matched []domain.Server
+	for _, s := range servers {
+		for _, st := range statuses {
+			if s.Status == st {
+				matched = append(matched, s)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// bulkActions returns the actions offered for the marked servers, in strip
+// order. Start and stop only appear when some marked server can take them.
+func (m serverListModel) bulkActions() []quickAction {
+	servers := m.markedServers()
+	var actions []quickAction
+	if toStart := withStatus(servers, "off", "stopped"); len(toStart) > 0 {
+		actions = append(actions, quickAction{
+			label: i18n.T("start %d", len(toStart)),
+			run:   requestBulk("start", "Starting", toStart),
+		})
+	}
+	if toStop := withStatus(servers, "running"); len(toStop) > 0 {
+		actions = append(actions, quickAction{
+			label: i18n.T("stop %d", len(toStop)),
+			run:   requestBulk("stop", "Stopping", toStop),
+		})
+	}
+	actions = append(actions, quickAction{
+		label: i18n.T("delete %d", len(servers)),
+		run: func(m serverListModel, _ domain.Server) (tea.Model, tea.Cmd) {
+			if !m.confirmBulkDelete {
+				m.confirmBulkDelete = true
+				m.status = fmt.Sprintf("Delete %d servers? Running ones are stopped first. Press the key again to confirm.", len(servers))
+				m.statusIsError = true
+				return m, nil
+			}
+			return requestBulk("delete", "Deleting", servers)(m, domain.Server{})
+		},
+	})
+	// Broadcasting to a single server is just ssh, so it needs two. It
+	// comes after delete to keep the other actions' numbers stable.
+	if running := withStatus(servers, "running"); len(running) > 1 {
+		actions = append(actions, quickAction{
+			label: i18n.T("ssh %d", len(running)),
+			run: func(m serverListModel, _ domain.Server) (tea.Model, tea.Cmd) {
+				m.marked = nil
+				m.confirmBulkDelete = false
+				m.status = fmt.Sprintf("Opening SSH broadcast to %d servers...", len(running))
+				m.statusIsError = false
+				return m, func() tea.Msg { return requestBroadcastMsg{servers: running} }
+			},
+		})
+	}
+	return actions
+}
+
+// requestBulk returns a bulk action that hands servers to the app and
+// clears the marks.
+func requestBulk(verb, gerund string, servers []domain.Server) func(serverListModel, domain.Server) (tea.Model, tea.Cmd) {
+	return func(m serverListModel, _ domain.Server) (tea.Model, tea.Cmd) {
+		m.marked = nil
+		m.confirmBulkDelete = false
+		m.status = fmt.Sprintf("%s %d server(s)...", gerund, len(servers))
+		m.statusIsError = false
+		return m, func() tea.Msg { return requestBulkMsg{verb: verb, servers: servers} }
+	}
+}
+
+// runBulkAction runs the marked servers' action numbered key.
+func (m serverListModel) runBulkAction(key string) (tea.Model, tea.Cmd, bool) {
+	n, err := strconv.Atoi(key)
+	actions := m.bulkActions()
+	if err != nil || n < 1 || n > len(actions) {
+		return m, nil, false
+	}
+	updated, cmd := actions[n-1].run(m, domain.Server{})
+	return updated, cmd, true
+}
+
+// startBulk hands each server to the overlay as its own operation.
+func (m serverAppModel) startBulk(msg requestBulkMsg) (tea.Model, tea.Cmd) {
+	cmds := make([]tea.Cmd, 0, len(msg.servers))
+	for _, server := range msg.servers {
+		var cmd tea.Cmd
+		switch msg.verb {
+		case "start", "stop":
+			m.overlay, cmd = m.overlay.StartToggle(server)
+		case "delete":
+			m.overlay, cmd = m.overlay.StartStopThenDelete(server)
+		}
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressKey(t *testing.T, m serverListModel, key string) (serverListModel, tea.Cmd) {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	switch key {
+	case " ":
+		msg = tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(serverListModel), cmd
+}
+
+func bulkTestList() serverListModel {
+	return quickActionList(
+		domain.Server{ID: "1", Name: "web-1", Status: "running"},
+		domain.Server{ID: "2", Name: "web-2", Status: "off"},
+		domain.Server{ID: "3", Name: "web-3", Status: "running"},
+	)
+}
+
+func TestBulkSelect_SpaceMarksAndAdvances(t *testing.T) {
+	m := bulkTestList()
+
+	m, _ = pressKey(t, m, " ")
+	m, _ = pressKey(t, m, " ")
+	if got := len(m.markedServers()); got != 2 {
+		t.Fatalf("expected 2 marked servers, got %d", got)
+	}
+	if m.cursor != 2 {
+		t.Errorf("expected the cursor to advance to row 2, got %d", m.cursor)
+	}
+	if got := quickActionLabels(m.bulkActions()); got != "start 1,stop 1,delete 2" {
+		t.Errorf("bulk actions = %q", got)
+	}
+
+	m, _ = pressKey(t, m, "esc")
+	if len(m.marked) != 0 || m.quitting {
+		t.Errorf("expected esc to clear the marks without quitting")
+	}
+}
+
+func TestBulkSelect_StopRequestsRunningOnly(t *testing.T) {
+	m := bulkTestList()
+	for range 3 {
+		m, _ = pressKey(t, m, " ")
+	}
+
+	// 1: start 1, 2: stop 2, 3: delete 3.
+	m, cmd := pressKey(t, m, "2")
+	if cmd == nil {
+		t.Fatal("expected a bulk request")
+	}
+	msg, ok := cmd().(requestBulkMsg)
+	if !ok || msg.verb != "stop" || len(msg.servers) != 2 {
+		t.Fatalf("unexpected bulk request %+v", msg)
+	}
+	if len(m.marked) != 0 {
+		t.Error("expected the marks to clear after the request")
+	}
+}
+
+func TestBulkSelect_DeleteNeedsConfirmation(t *testing.T) {
+	m := bulkTestList()
+	m, _ = pressKey(t, m, " ")
+
+	// One running server marked: 1 is stop, 2 is delete.
+	m, cmd := pressKey(t, m, "2")
+	if cmd != nil || !m.confirmBulkDelete || !strings.Contains(m.status, "Press the key again") {
+		t.Fatalf("expected a confirmation prompt, got status %q", m.status)
+	}
+
+	m, _ = pressKey(t, m, "j")
+	if m.confirmBulkDelete {
+		t.Fatal("expected another key to cancel the confirmation")
+	}
+
+	m, _ = pressKey(t, m, "2")
+	_, cmd = pressKey(t, m, "2")
+	if cmd == nil {
+		t.Fatal("expected the second press to delete")
+	}
+	if msg, ok := cmd().(requestBulkMsg); !ok || msg.verb != "delete" || len(msg.servers) != 1 {
+		t.Errorf("unexpected bulk request %+v", msg)
+	}
+}
+
+func TestServerApp_BulkStartsOneOperationPerServer(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, _ := m.Update(requestBulkMsg{verb: "stop", servers: []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running"},
+		{ID: "3", Name: "web-3", Status: "running"},
+	}})
+	app := updated.(serverAppModel)
+	if got := len(app.overlay.ops); got != 2 {
+		t.Errorf("expected 2 overlay operations, got %d", got)
+	}
+}
+
+func TestBulkSelect_SSHBroadcastNeedsTwoRunning(t *testing.T) {
+	m := bulkTestList()
+	for range 3 {
+		m, _ = pressKey(t, m, " ")
+	}
+	if got := quickActionLabels(m.bulkActions()); got != "start 1,stop 2,delete 3,ssh 2" {
+		t.Fatalf("bulk actions = %q", got)
+	}
+
+	m, cmd := pressKey(t, m, "4")
+	if cmd == nil {
+		t.Fatal("expected a broadcast request")
+	}
+	msg, ok := cmd().(requestBroadcastMsg)
+	if !ok || len(msg.servers) != 2 || msg.servers[0].ID != "1" || msg.servers[1].ID != "3" {
+		t.Fatalf("unexpected broadcast request %+v", msg)
+	}
+	if len(m.marked) != 0 {
+		t.Error("expected the marks to clear after the request")
+	}
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/cost"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loadPrices returns the provider's server type prices. Tests replace it.
+var loadPrices = func(ctx context.Context, catalog domain.CatalogProvider, providerName string) (cost.Prices, error) {
+	return cost.LoadPrices(ctx, cache.NewDefault(), catalog, providerName, false)
+}
+
+// costPanel is the monthly cost overview shown over the server list.
+type costPanel struct {
+	overview cost.Overview
+	loading  bool
+	err      error
+}
+
+// costPricesMsg carries the prices the cost panel was waiting for.
+type costPricesMsg struct {
+	prices cost.Prices
+	err    error
+}
+
+// canShowCost reports whether the provider lists prices for the panel.
+func (m serverAppModel) canShowCost() bool {
+	_, ok := m.provider.(domain.CatalogProvider)
+	return ok
+}
+
+// openCostPanel shows the cost of the listed servers, once prices load.
+func (m serverAppModel) openCostPanel() (tea.Model, tea.Cmd) {
+	catalog := m.provider.(domain.CatalogProvider)
+	providerName := m.providerName
+	m.cost = &costPanel{loading: true}
+	return m, func() tea.Msg {
+		prices, err := loadPrices(context.Background(), catalog, providerName)
+		return costPricesMsg{prices: prices, err: err}
+	}
+}
+
+// updateCostPanel dismisses the panel on esc, enter, q or $.
+func (m serverAppModel) updateCostPanel(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter", "esc", "q", "$":
+		m.cost = nil
+	}
+	return m, nil
+}
+
+// renderCostPanel renders the cost overview in place of the list.
+func (m serverAppModel) renderCostPanel() string {
+	panel := m.cost
+	header := components.Header(m.width, "cost", m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "esc", Desc: "close"},
+	})
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	var body string
+	switch {
+	case panel.loading:
+		body = styles.MutedText.Render("Loading prices" + styles.Ellipsis())
+	case panel.err != nil:
+		body = styles.ErrorText.Render("Error: " + panel.err.Error())
+	default:
+		body = renderCostOverview(panel.overview, m.provider.GetDisplayName())
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
+
+// renderCostOverview lists each running server's monthly price and the
+// total.
+func renderCostOverview(o cost.Overview, displayName string) string {
+	f := priceFormatter()
+	lines := []string{styles.Title.Render("Estimated monthly cost at " + displayName), ""}
+
+	nameWidth, typeWidth := 0, 0
+	for _, line := range o.Lines {
+		nameWidth = max(nameWidth, lipgloss.Width(line.Server.Name))
+		typeWidth = max(typeWidth, lipgloss.Width(line.Server.ServerType))
+	}
+	for _, line := range o.Lines {
+		price := styles.MutedText.Render("no list price")
+		if line.Priced {
+			price = styles.Value.Render(f.FormatMonthly(line.Monthly))
+		}
+		lines = append(lines, fmt.Sprintf("  %-*s  %s  %s",
+			nameWidth, line.Server.Name,
+			styles.MutedText.Render(fmt.Sprintf("%-*s", typeWidth, line.Server.ServerType)),
+			price))
+	}
+	if len(o.Lines) == 0 {
+		lines = append(lines, styles.MutedText.Render("  No running servers."))
+	}
+
+	total := "-"
+	if !o.Monthly.IsZero() {
+		total = f.FormatMonthly(o.Monthly)
+	}
+	lines = append(lines, "", styles.Label.Render("Total  ")+styles.Value.Render(total))
+	if o.Stopped > 0 {
+		lines = append(lines, styles.MutedText.Render(fmt.Sprintf(
+			"%d stopped server(s) not counted; most providers bill them too.", o.Stopped)))
+	}
+	lines = append(lines, styles.MutedText.Render("List prices only; volumes, backups and traffic are extra."))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
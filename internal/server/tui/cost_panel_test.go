@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/cost"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func stubPrices(t *testing.T, prices cost.Prices, err error) {
+	t.Helper()
+	orig := loadPrices
+	loadPrices = func(context.Context, domain.CatalogProvider, string) (cost.Prices, error) { return prices, err }
+	t.Cleanup(func() { loadPrices = orig })
+}
+
+func newCostTestApp() serverAppModel {
+	m := newReauthTestApp()
+	m.provider = &spotProvider{reauthProvider{name: "Mock"}}
+	m.list = newServerListModel(m.provider, m.providerName)
+	m.list.servers = []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running", ServerType: "cx22"},
+		{ID: "2", Name: "db-1", Status: "off", ServerType: "cx22"},
+	}
+	// Keep Update from batching a window title change with the load.
+	m.title = m.windowTitle()
+	return m
+}
+
+func TestCostPanel_ShowsRunningServersTotal(t *testing.T) {
+	stubPrices(t, cost.Prices{
+		"cx22": {Name: "cx22", PriceMonthly: money.Money{Amount: 4.5, Currency: "EUR"}},
+	}, nil)
+
+	m := newCostTestApp()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("$")})
+	m = updated.(serverAppModel)
+	if m.cost == nil || !m.cost.loading || cmd == nil {
+		t.Fatal("expected $ to open the cost panel and load prices")
+	}
+
+	updated, _ = m.Update(cmd())
+	m = updated.(serverAppModel)
+	view := m.View()
+	for _, want := range []string{"Estimated monthly cost at Mock", "web-1", "4.50", "1 stopped server(s) not counted"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(serverAppModel).cost != nil {
+		t.Error("expected esc to close the cost panel")
+	}
+}
+
+func TestCostPanel_ShowsLoadError(t *testing.T) {
+	stubPrices(t, nil, errors.New("rate limited"))
+
+	m := newCostTestApp()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("$")})
+	updated, _ = updated.(serverAppModel).Update(cmd())
+
+	if view := updated.(serverAppModel).View(); !strings.Contains(view, "rate limited") {
+		t.Errorf("expected the load error in view:\n%s", view)
+	}
+}
+
+func TestCostPanel_NotOfferedWithoutCatalog(t *testing.T) {
+	m := newReauthTestApp()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("$")})
+	if updated.(serverAppModel).cost != nil {
+		t.Error("expected no cost panel for a provider without prices")
+	}
+}
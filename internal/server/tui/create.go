@@ -8,8 +8,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 	"nathanbeddoewebdev/vpsm/internal/util"
 
 	"github.com/charmbracelet/huh"
@@ -25,6 +29,12 @@ type catalogData struct {
 	serverTypes []domain.ServerTypeSpec
 	images      []domain.ImageSpec
 	sshKeys     []domain.SSHKeySpec
+	networks    []domain.NetworkSpec
+	pricing     *domain.AddonPricing
+
+	// customImages are the account's snapshots and backups, when the
+	// provider offers them.
+	customImages []domain.ImageSpec
 }
 
 // CreateServerForm runs an interactive wizard that collects server create options.
@@ -32,7 +42,7 @@ type catalogData struct {
 // Server types are filtered client-side by the chosen location to prevent
 // "unsupported location for server type" errors at creation time.
 func CreateServerForm(provider domain.CatalogProvider, prefill domain.CreateServerOpts) (*domain.CreateServerOpts, error) {
-	accessible := os.Getenv("ACCESSIBLE") != ""
+	accessible := styles.Accessible()
 
 	// Fetch all catalog data concurrently in a single spinner.
 	var data catalogData
@@ -161,6 +171,17 @@ func CreateServerForm(provider domain.CatalogProvider, prefill domain.CreateServ
 		)
 	}
 
+	// IPv6 defaults to enabled; the toggle writes through opts so the
+	// summary reflects the choice.
+	enableIPv6 := opts.EnableIPv6 == nil || *opts.EnableIPv6
+	opts.EnableIPv6 = &enableIPv6
+	ipv6Field := huh.NewConfirm().
+		Title("Public IPv6").
+		Description("Assign a public IPv6 /64 subnet to the server.").
+		Affirmative("Enable").
+		Negative("Disable").
+		Value(&enableIPv6)
+
 	confirm := false
 	summaryNote := huh.NewNote().
 		Title("Summary").
@@ -178,6 +199,7 @@ func CreateServerForm(provider domain.CatalogProvider, prefill domain.CreateServ
 		huh.NewGroup(serverTypeField),
 		huh.NewGroup(imageField),
 		sshKeyGroup,
+		huh.NewGroup(ipv6Field),
 		huh.NewGroup(summaryNote, confirmField),
 	); err != nil {
 		return nil, err
@@ -207,7 +229,9 @@ func runForm(accessible bool, groups ...*huh.Group) error {
 	return nil
 }
 
-// fetchCatalog fetches locations, server types, images, and SSH keys concurrently.
+// fetchCatalog fetches locations, server types, images, SSH keys, and
+// (when the provider supports them) private networks and add-on pricing
+// concurrently.
 func fetchCatalog(ctx context.Context, provider domain.CatalogProvider) (catalogData, error) {
 	var data catalogData
 	g, gctx := errgroup.WithContext(ctx)
@@ -245,6 +269,33 @@ func fetchCatalog(ctx context.Context, provider domain.CatalogProvider) (catalog
 		return nil
 	})
 
+	if np, ok := provider.(domain.NetworkProvider); ok {
+		g.Go(func() error {
+			// Networks are optional, so failing to list them only hides
+			// the network step.
+			data.networks, _ = np.ListNetworks(gctx)
+			return nil
+		})
+	}
+
+	if cp, ok := provider.(domain.CustomImageProvider); ok {
+		g.Go(func() error {
+			// Snapshots and backups are optional, so failing to list
+			// them only leaves their tabs of the image step empty.
+			data.customImages, _ = cp.ListCustomImages(gctx)
+			return nil
+		})
+	}
+
+	if pp, ok := provider.(domain.PricingProvider); ok {
+		g.Go(func() error {
+			// Add-on pricing only feeds the cost estimate, which leaves
+			// out what it cannot price.
+			data.pricing, _ = pp.GetAddonPricing(gctx)
+			return nil
+		})
+	}
+
 	if err := g.Wait(); err != nil {
 		return catalogData{}, err
 	}
@@ -269,6 +320,45 @@ func filterServerTypesByLocation(serverTypes []domain.ServerTypeSpec, location s
 	return filtered
 }
 
+// filterLocationsByNetworks returns only locations every network can be
+// attached from, preventing "server and network in different network
+// zones" errors at creation time.
+func filterLocationsByNetworks(locations []domain.Location, networks []domain.NetworkSpec) []domain.Location {
+	if len(networks) == 0 {
+		return locations
+	}
+
+	filtered := make([]domain.Location, 0, len(locations))
+	for _, loc := range locations {
+		covered := true
+		for _, n := range networks {
+			if !n.CoversLocation(loc) {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			filtered = append(filtered, loc)
+		}
+	}
+	return filtered
+}
+
+// findNetworks returns the specs for the given network names or IDs,
+// skipping any that are not in the catalog.
+func findNetworks(networks []domain.NetworkSpec, refs []string) []domain.NetworkSpec {
+	var found []domain.NetworkSpec
+	for _, ref := range refs {
+		for _, n := range networks {
+			if strings.EqualFold(n.Name, ref) || n.ID == ref {
+				found = append(found, n)
+				break
+			}
+		}
+	}
+	return found
+}
+
 func hasLocation(locations []string, target string) bool {
 	for _, loc := range locations {
 		if strings.EqualFold(loc, target) {
@@ -396,10 +486,23 @@ func buildSummary(opts domain.CreateServerOpts, locationLabels, serverTypeLabels
 	if opts.StartAfterCreate != nil {
 		fmt.Fprintf(&b, "Start after create: %t\n", *opts.StartAfterCreate)
 	}
+	if opts.EnableIPv4 != nil {
+		fmt.Fprintf(&b, "Public IPv4: %s\n", enabledLabel(*opts.EnableIPv4))
+	}
+	if opts.EnableIPv6 != nil {
+		fmt.Fprintf(&b, "Public IPv6: %s\n", enabledLabel(*opts.EnableIPv6))
+	}
 
 	return strings.TrimSpace(b.String())
 }
 
+func enabledLabel(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
 // --- Image filtering ---
 
 func filterImages(images []domain.ImageSpec, arch string) []domain.ImageSpec {
@@ -436,6 +539,23 @@ func filterImages(images []domain.ImageSpec, arch string) []domain.ImageSpec {
 	return filtered
 }
 
+// filterCustomImages returns the snapshots or backups (imageType) that
+// can boot a server of architecture arch. Unlike filterImages there is
+// no fallback: a snapshot of another architecture cannot be used.
+func filterCustomImages(images []domain.ImageSpec, imageType, arch string) []domain.ImageSpec {
+	var filtered []domain.ImageSpec
+	for _, img := range images {
+		if !strings.EqualFold(img.Type, imageType) {
+			continue
+		}
+		if arch != "" && img.Architecture != "" && !strings.EqualFold(img.Architecture, arch) {
+			continue
+		}
+		filtered = append(filtered, img)
+	}
+	return filtered
+}
+
 // --- Label helpers ---
 
 func locationLabel(loc domain.Location) string {
@@ -447,19 +567,38 @@ func locationLabel(loc domain.Location) string {
 	return name + " - " + suffix
 }
 
+func networkLabel(n domain.NetworkSpec) string {
+	label := valueOrID(n.Name, n.ID)
+	if n.IPRange != "" {
+		label += " - " + n.IPRange
+	}
+	if len(n.NetworkZones) > 0 {
+		label += " (" + strings.Join(n.NetworkZones, ", ") + ")"
+	}
+	return label
+}
+
 func serverTypeLabel(st domain.ServerTypeSpec) string {
 	name := valueOrID(st.Name, st.ID)
 	memory := strconv.FormatFloat(st.Memory, 'f', -1, 64)
 	label := fmt.Sprintf("%s - %d vCPU / %s GB / %d GB", name, st.Cores, memory, st.Disk)
-	if st.PriceMonthly != "" {
-		return label + " - " + st.PriceMonthly + "/mo"
-	}
-	if st.PriceHourly != "" {
-		return label + " - " + st.PriceHourly + "/hr"
+	if st.PriceMonthly.IsZero() && st.PriceHourly.IsZero() {
+		return label
 	}
-	return label
+	f := priceFormatter()
+	return label + " - " + f.FormatMonthly(st.MonthlyPrice()) + " (" + f.FormatHourly(st.HourlyPrice()) + ")"
 }
 
+// priceFormatter formats catalog prices using the configured locale,
+// falling back to the environment's locale.
+var priceFormatter = sync.OnceValue(func() *money.Formatter {
+	var configured string
+	if cfg, err := config.Load(); err == nil {
+		configured = cfg.Locale
+	}
+	return money.NewFormatter(money.ResolveLocale(configured))
+})
+
 func imageLabel(img domain.ImageSpec) string {
 	name := valueOrID(img.Name, img.ID)
 	label := name
@@ -472,6 +611,26 @@ func imageLabel(img domain.ImageSpec) string {
 	return label
 }
 
+// customImageLabel describes a snapshot or backup, e.g.
+// "before upgrade - 2.5 GB - from web-1 - 2026-10-01 09:00 (x86)".
+func customImageLabel(img domain.ImageSpec) string {
+	parts := []string{valueOrID(img.Description, img.ID)}
+	if img.SizeGB > 0 {
+		parts = append(parts, strconv.FormatFloat(img.SizeGB, 'f', 1, 64)+" GB")
+	}
+	if img.CreatedFrom != "" {
+		parts = append(parts, "from "+img.CreatedFrom)
+	}
+	if !img.CreatedAt.IsZero() {
+		parts = append(parts, img.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	label := strings.Join(parts, " - ")
+	if img.Architecture != "" {
+		label += " (" + img.Architecture + ")"
+	}
+	return label
+}
+
 func sshKeyLabel(key domain.SSHKeySpec) string {
 	name := valueOrID(key.Name, key.ID)
 	if key.Fingerprint == "" {
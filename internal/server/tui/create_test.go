@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/google/go-cmp/cmp"
 )
@@ -40,7 +43,17 @@ func TestBuildLocationOptions_AddsCustom(t *testing.T) {
 	}
 }
 
+// withPriceLocale pins price formatting to the given locale for a test.
+func withPriceLocale(t *testing.T, locale string) {
+	t.Helper()
+	orig := priceFormatter
+	priceFormatter = func() *money.Formatter { return money.NewFormatter(locale) }
+	t.Cleanup(func() { priceFormatter = orig })
+}
+
 func TestBuildServerTypeOptions_UsesNameAndPrice(t *testing.T) {
+	withPriceLocale(t, "en-US")
+
 	serverTypes := []domain.ServerTypeSpec{
 		{
 			ID:           "1",
@@ -48,18 +61,36 @@ func TestBuildServerTypeOptions_UsesNameAndPrice(t *testing.T) {
 			Cores:        2,
 			Memory:       2,
 			Disk:         40,
-			PriceMonthly: "4.50",
+			PriceMonthly: money.Money{Amount: 4.50, Currency: "EUR"},
+			PriceHourly:  money.Money{Amount: 0.0072, Currency: "EUR"},
 		},
 	}
 
 	_, labels := buildServerTypeOptions(serverTypes, "")
 
-	expected := "cpx11 - 2 vCPU / 2 GB / 40 GB - 4.50/mo"
+	expected := "cpx11 - 2 vCPU / 2 GB / 40 GB - € 4.50/mo (€ 0.0072/hr)"
 	if diff := cmp.Diff(expected, labels["cpx11"]); diff != "" {
 		t.Errorf("unexpected server type label (-want +got):\n%s", diff)
 	}
 }
 
+func TestServerTypeLabel_EstimatesMonthlyFromHourly(t *testing.T) {
+	withPriceLocale(t, "de-DE")
+
+	st := domain.ServerTypeSpec{
+		Name:        "cpx11",
+		Cores:       2,
+		Memory:      2,
+		Disk:        40,
+		PriceHourly: money.Money{Amount: 0.01, Currency: "EUR"},
+	}
+
+	expected := "cpx11 - 2 vCPU / 2 GB / 40 GB - € 7,30/mo (€ 0,01/hr)"
+	if diff := cmp.Diff(expected, serverTypeLabel(st)); diff != "" {
+		t.Errorf("unexpected server type label (-want +got):\n%s", diff)
+	}
+}
+
 func TestFilterServerTypesByLocation(t *testing.T) {
 	serverTypes := []domain.ServerTypeSpec{
 		{Name: "cpx11", Locations: []string{"fsn1", "nbg1"}},
@@ -147,6 +178,7 @@ func TestBuildSSHKeyOptions_AddsCustom(t *testing.T) {
 
 func TestBuildSummary_IncludesOptionalFields(t *testing.T) {
 	start := true
+	ipv6 := false
 	opts := domain.CreateServerOpts{
 		Name:              "web-1",
 		Location:          "fsn1",
@@ -156,6 +188,7 @@ func TestBuildSummary_IncludesOptionalFields(t *testing.T) {
 		Labels:            map[string]string{"env": "prod", "role": "web"},
 		UserData:          "#!/bin/bash\necho hello",
 		StartAfterCreate:  &start,
+		EnableIPv6:        &ipv6,
 	}
 
 	summary := buildSummary(
@@ -175,6 +208,7 @@ func TestBuildSummary_IncludesOptionalFields(t *testing.T) {
 		"Labels: env=prod, role=web",
 		"User data: 22 bytes",
 		"Start after create: true",
+		"Public IPv6: disabled",
 	}
 
 	for _, want := range expected {
@@ -251,6 +285,322 @@ func TestFormatLabels(t *testing.T) {
 	}
 }
 
+func TestFilterLocationsByNetworks(t *testing.T) {
+	locations := []domain.Location{
+		{Name: "fsn1", NetworkZone: "eu-central"},
+		{Name: "ash", NetworkZone: "us-east"},
+		{Name: "hel1", NetworkZone: "eu-central"},
+	}
+	networks := []domain.NetworkSpec{{Name: "internal", NetworkZones: []string{"eu-central"}}}
+
+	filtered := filterLocationsByNetworks(locations, networks)
+	var names []string
+	for _, loc := range filtered {
+		names = append(names, loc.Name)
+	}
+	if diff := cmp.Diff([]string{"fsn1", "hel1"}, names); diff != "" {
+		t.Errorf("filtered locations mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := filterLocationsByNetworks(locations, nil); len(got) != len(locations) {
+		t.Errorf("expected all %d locations without networks, got %d", len(locations), len(got))
+	}
+}
+
+func TestServerCreate_NetworkFiltersLocations(t *testing.T) {
+	m := serverCreateModel{
+		data: catalogData{
+			locations: []domain.Location{
+				{Name: "fsn1", NetworkZone: "eu-central"},
+				{Name: "ash", NetworkZone: "us-east"},
+			},
+			networks: []domain.NetworkSpec{
+				{ID: "1", Name: "internal", NetworkZones: []string{"us-east"}},
+				{ID: "2", Name: "orphan", NetworkZones: []string{"ap-southeast"}},
+			},
+		},
+		sshSelected: make(map[int]struct{}),
+	}
+	m.buildCatalogItems()
+
+	// "orphan" covers no location, so only "None" and "internal" are offered.
+	if len(m.networks) != 2 || m.networks[1].name != "internal" {
+		t.Fatalf("unexpected network items: %+v", m.networks)
+	}
+	if len(m.locations) != 2 {
+		t.Fatalf("expected all locations before choosing a network, got %d", len(m.locations))
+	}
+	if got := m.stepAfterName(); got != stepNetwork {
+		t.Fatalf("stepAfterName() = %v, want stepNetwork", got)
+	}
+
+	m.step = stepNetwork
+	updated, _ := m.handleListKey(tea.KeyMsg{Type: tea.KeyDown})
+	updated, _ = updated.(serverCreateModel).handleListKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverCreateModel)
+
+	if m.step != stepLocation {
+		t.Fatalf("expected location step, got %v", m.step)
+	}
+	if diff := cmp.Diff([]string{"internal"}, m.opts.Networks); diff != "" {
+		t.Errorf("networks mismatch (-want +got):\n%s", diff)
+	}
+	if len(m.locations) != 1 || m.locations[0].name != "ash" {
+		t.Errorf("expected only ash for a us-east network, got %+v", m.locations)
+	}
+}
+
+func TestServerCreate_SelectionErrBlocksMismatchedZone(t *testing.T) {
+	m := serverCreateModel{
+		data: catalogData{
+			locations: []domain.Location{{Name: "fsn1", NetworkZone: "eu-central"}},
+			networks:  []domain.NetworkSpec{{Name: "internal", NetworkZones: []string{"us-east"}}},
+		},
+		opts: domain.CreateServerOpts{Name: "web", Location: "fsn1", Networks: []string{"internal"}},
+		step: stepConfirm,
+	}
+
+	if m.selectionErr() == nil {
+		t.Fatal("expected an error for a network in another zone")
+	}
+	updated, _ := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if updated.(serverCreateModel).result != nil {
+		t.Error("expected create to be blocked")
+	}
+}
+
+func TestServerCreate_CostFooterFollowsCursor(t *testing.T) {
+	withPriceLocale(t, "en-US")
+
+	m := serverCreateModel{
+		data: catalogData{
+			locations: []domain.Location{{Name: "fsn1"}},
+			serverTypes: []domain.ServerTypeSpec{
+				{Name: "cpx11", PriceMonthly: money.Money{Amount: 4.00, Currency: "EUR"}, Locations: []string{"fsn1"}},
+				{Name: "cpx21", PriceMonthly: money.Money{Amount: 8.00, Currency: "EUR"}, Locations: []string{"fsn1"}},
+			},
+			pricing: &domain.AddonPricing{IPv4Monthly: map[string]money.Money{
+				"fsn1": {Amount: 0.50, Currency: "EUR"},
+			}},
+		},
+		sshSelected: make(map[int]struct{}),
+		width:       120,
+	}
+	m.buildCatalogItems()
+
+	m.step = stepLocation
+	if got := m.renderCostFooter(); got != "" {
+		t.Errorf("expected no estimate before the type step, got %q", got)
+	}
+
+	m.step = stepServerType
+	if got := m.renderCostFooter(); !strings.Contains(got, "€ 4.50/mo") {
+		t.Errorf("expected € 4.50/mo for cpx11 + IPv4, got %q", got)
+	}
+
+	updated, _ := m.handleListKey(tea.KeyMsg{Type: tea.KeyDown})
+	if got := updated.(serverCreateModel).renderCostFooter(); !strings.Contains(got, "€ 8.50/mo") {
+		t.Errorf("expected € 8.50/mo after moving to cpx21, got %q", got)
+	}
+}
+
+// spotProvider is a catalog provider that offers interruptible servers.
+type spotProvider struct {
+	reauthProvider
+}
+
+func (p *spotProvider) ListLocations(context.Context) ([]domain.Location, error) { return nil, nil }
+func (p *spotProvider) ListServerTypes(context.Context) ([]domain.ServerTypeSpec, error) {
+	return nil, nil
+}
+func (p *spotProvider) ListImages(context.Context) ([]domain.ImageSpec, error)   { return nil, nil }
+func (p *spotProvider) ListSSHKeys(context.Context) ([]domain.SSHKeySpec, error) { return nil, nil }
+func (p *spotProvider) SupportsInterruptible() bool                              { return true }
+
+func TestServerCreate_ConfirmTogglesInterruptible(t *testing.T) {
+	m := serverCreateModel{
+		provider: &spotProvider{},
+		opts:     domain.CreateServerOpts{Name: "web"},
+		step:     stepConfirm,
+		width:    100,
+	}
+
+	updated, _ := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = updated.(serverCreateModel)
+	if !m.opts.Interruptible {
+		t.Fatal("expected i to request an interruptible server")
+	}
+	if !strings.Contains(m.renderConfirmStep(), "may be reclaimed") {
+		t.Error("expected the summary to warn about reclaimed spot servers")
+	}
+}
+
+func TestServerCreate_InterruptibleNeedsProviderSupport(t *testing.T) {
+	// No provider at all offers nothing beyond the core interface.
+	m := serverCreateModel{step: stepConfirm, width: 100}
+
+	updated, _ := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if updated.(serverCreateModel).opts.Interruptible {
+		t.Error("expected i to be ignored for providers without spot servers")
+	}
+}
+
+// snapshotProvider is a catalog provider with snapshots and backups.
+type snapshotProvider struct {
+	spotProvider
+}
+
+func (p *snapshotProvider) ListCustomImages(context.Context) ([]domain.ImageSpec, error) {
+	return nil, nil
+}
+
+func TestServerCreate_ImageTabsOfferSnapshotsAndBackups(t *testing.T) {
+	m := serverCreateModel{
+		provider: &snapshotProvider{},
+		data: catalogData{
+			locations:   []domain.Location{{Name: "fsn1"}},
+			serverTypes: []domain.ServerTypeSpec{{Name: "cpx11", Architecture: "x86", Locations: []string{"fsn1"}}},
+			images:      []domain.ImageSpec{{ID: "1", Name: "ubuntu-24.04", Type: "system", Architecture: "x86"}},
+			customImages: []domain.ImageSpec{
+				{ID: "200", Description: "before upgrade", Type: "snapshot", Architecture: "x86", SizeGB: 2.5, CreatedFrom: "web-1"},
+				{ID: "201", Description: "arm box", Type: "snapshot", Architecture: "arm"},
+				{ID: "300", Description: "web-1 nightly", Type: "backup", Architecture: "x86"},
+			},
+		},
+		sshSelected: make(map[int]struct{}),
+		step:        stepImage,
+		width:       120,
+	}
+	m.buildCatalogItems()
+
+	if view := m.renderImageStep(20); !strings.Contains(view, "Snapshots (1)") || !strings.Contains(view, "Backups (1)") {
+		t.Errorf("expected tab counts excluding the arm snapshot, got:\n%s", view)
+	}
+
+	updated, _ := m.handleListKey(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(serverCreateModel)
+	if m.imageSource != imageSourceSnapshots {
+		t.Fatalf("expected the snapshots tab after tab, got %v", m.imageSource)
+	}
+	if view := m.renderImageStep(20); !strings.Contains(view, "before upgrade - 2.5 GB - from web-1") {
+		t.Errorf("expected snapshot size and source server, got:\n%s", view)
+	}
+
+	updated, _ = m.handleListKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverCreateModel)
+	if m.opts.Image != "200" {
+		t.Errorf("opts.Image = %q, want the snapshot ID 200", m.opts.Image)
+	}
+	if m.step != stepSSHKeys {
+		t.Errorf("expected the SSH keys step, got %v", m.step)
+	}
+}
+
+func TestServerCreate_PrefilledSnapshotSelectsItsTab(t *testing.T) {
+	m := serverCreateModel{
+		provider: &snapshotProvider{},
+		prefill:  domain.CreateServerOpts{Image: "300"},
+		data: catalogData{
+			customImages: []domain.ImageSpec{{ID: "300", Type: "backup"}},
+		},
+		sshSelected: make(map[int]struct{}),
+	}
+	m.buildCatalogItems()
+
+	if m.imageSource != imageSourceBackups || len(m.images) != 1 || m.images[m.imageIdx].name != "300" {
+		t.Errorf("expected the prefilled backup to be selected, got tab %v, images %+v", m.imageSource, m.images)
+	}
+}
+
+func typeLabel(m serverCreateModel, text string) serverCreateModel {
+	for _, r := range text {
+		updated, _ := m.handleLabelsKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(serverCreateModel)
+	}
+	updated, _ := m.handleLabelsKey(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(serverCreateModel)
+}
+
+func TestServerCreate_SSHKeysAdvanceToLabels(t *testing.T) {
+	m := serverCreateModel{step: stepSSHKeys, sshSelected: make(map[int]struct{}), labelInput: newLabelInput()}
+
+	updated, _ := m.handleSSHKeysKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverCreateModel)
+	if m.step != stepLabels {
+		t.Fatalf("expected labels step, got %v", m.step)
+	}
+	if !m.labelInput.Focused() {
+		t.Error("expected the label input to be focused")
+	}
+}
+
+func TestServerCreate_LabelsAddAndRemove(t *testing.T) {
+	prefill := map[string]string{"team": "web"}
+	m := serverCreateModel{
+		step:       stepLabels,
+		opts:       domain.CreateServerOpts{Name: "web", Labels: prefill},
+		labelInput: newLabelInput(),
+		width:      100,
+	}
+	m.labelInput.Focus()
+
+	m = typeLabel(m, "env=prod")
+	if diff := cmp.Diff(map[string]string{"env": "prod", "team": "web"}, m.opts.Labels); diff != "" {
+		t.Fatalf("labels mismatch (-want +got):\n%s", diff)
+	}
+	if m.labelInput.Value() != "" {
+		t.Errorf("expected the input to be cleared, got %q", m.labelInput.Value())
+	}
+	if len(prefill) != 1 {
+		t.Error("expected the prefill labels to be left untouched")
+	}
+
+	// The cursor follows the added label (env sorts first); move to team and remove it.
+	updated, _ := m.handleLabelsKey(tea.KeyMsg{Type: tea.KeyDown})
+	updated, _ = updated.(serverCreateModel).handleLabelsKey(tea.KeyMsg{Type: tea.KeyCtrlD})
+	m = updated.(serverCreateModel)
+	if diff := cmp.Diff(map[string]string{"env": "prod"}, m.opts.Labels); diff != "" {
+		t.Fatalf("labels mismatch after remove (-want +got):\n%s", diff)
+	}
+	if m.labelIdx != 0 {
+		t.Errorf("expected the cursor to move back onto the remaining label, got %d", m.labelIdx)
+	}
+
+	// An empty entry moves on to the confirm card, which lists the labels.
+	updated, _ = m.handleLabelsKey(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverCreateModel)
+	if m.step != stepConfirm {
+		t.Fatalf("expected confirm step, got %v", m.step)
+	}
+	if !strings.Contains(m.renderConfirmStep(), "env=prod") {
+		t.Error("expected the confirm card to show the labels")
+	}
+
+	updated, _ = m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(serverCreateModel).step != stepLabels {
+		t.Error("expected esc on confirm to return to the labels step")
+	}
+}
+
+func TestServerCreate_LabelsRejectInvalid(t *testing.T) {
+	m := serverCreateModel{step: stepLabels, labelInput: newLabelInput(), width: 100}
+	m.labelInput.Focus()
+
+	m = typeLabel(m, "no value")
+	if m.labelErr == "" {
+		t.Fatal("expected an error for an entry without key=value")
+	}
+	if len(m.opts.Labels) != 0 {
+		t.Errorf("expected no labels, got %v", m.opts.Labels)
+	}
+	if m.step != stepLabels {
+		t.Errorf("expected to stay on the labels step, got %v", m.step)
+	}
+	if !strings.Contains(m.renderLabelsStep(), "key=value") {
+		t.Error("expected the error to be rendered")
+	}
+}
+
 func optionsToPairs(options []huh.Option[string]) []optionPair {
 	pairs := make([]optionPair, 0, len(options))
 	for _, option := range options {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
@@ -20,7 +21,7 @@ var ErrDeleteAborted = errors.New("server deletion aborted by user")
 // to delete. It fetches the current server list, presents a selection, shows a
 // summary, and asks for confirmation before returning the chosen server.
 func DeleteServerForm(provider domain.Provider) (*domain.Server, error) {
-	accessible := os.Getenv("ACCESSIBLE") != ""
+	accessible := styles.Accessible()
 
 	// Fetch existing servers with a spinner.
 	var servers []domain.Server
@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -148,3 +151,161 @@ func TestBuildDeleteSummary_MinimalFields(t *testing.T) {
 		t.Errorf("expected no 'IPv4:' line in summary, got:\n%s", summary)
 	}
 }
+
+func TestServerDeleteModel_RequireStoppedEmitsStopThenDelete(t *testing.T) {
+	server := domain.Server{ID: "1", Name: "web-1", Status: "running"}
+	m := newServerDeleteModel(nil, "mock", &server)
+	m.requireStopped = true
+
+	_, cmd := m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if cmd == nil {
+		t.Fatal("expected a command")
+	}
+	if _, ok := cmd().(stopThenDeleteMsg); !ok {
+		t.Errorf("expected stopThenDeleteMsg for a running server")
+	}
+
+	server.Status = "off"
+	m = newServerDeleteModel(nil, "mock", &server)
+	m.requireStopped = true
+	_, cmd = m.handleConfirmKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if _, ok := cmd().(deleteConfirmedMsg); !ok {
+		t.Errorf("expected deleteConfirmedMsg for a stopped server")
+	}
+}
+
+func TestOpsOverlay_StopThenDeleteDeletesAfterStop(t *testing.T) {
+	o := opsOverlay{providerName: "mock"}
+	o, _ = o.StartStopThenDelete(domain.Server{ID: "1", Name: "web-1", Status: "running"})
+	if len(o.ops) != 1 || !o.ops[0].thenDelete {
+		t.Fatalf("expected one stop operation marked thenDelete, got %+v", o.ops)
+	}
+	o.ops[0].pollMode = opPollModeServer
+
+	o, cmd, events := o.handlePollResult(opPollResultMsg{opID: 0, action: &domain.ActionStatus{Status: domain.ActionStatusSuccess}})
+	if len(events) != 0 || cmd == nil {
+		t.Fatalf("expected delete step instead of completion, got events %+v", events)
+	}
+	if o.ops[0].verb != "deleted" || o.ops[0].status != opStatusActive {
+		t.Errorf("expected active delete step, got %+v", o.ops[0])
+	}
+
+	o, _, events = o.handleDeleteResult(opDeleteResultMsg{opID: 0})
+	if len(events) != 1 || !events[0].Success || events[0].Verb != "deleted" {
+		t.Errorf("expected successful delete event, got %+v", events)
+	}
+	if o.ops[0].status != opStatusSucceeded {
+		t.Errorf("expected succeeded op, got %q", o.ops[0].status)
+	}
+}
+
+func TestOpsOverlay_QueuesBeyondConcurrencyCap(t *testing.T) {
+	o := opsOverlay{providerName: "mock", maxActive: 3}
+	for i := range 4 {
+		o, _ = o.StartStopThenDelete(domain.Server{ID: fmt.Sprint(i), Name: fmt.Sprintf("web-%d", i), Status: "off"})
+	}
+	if o.running() != 3 || !o.ops[3].queued {
+		t.Fatalf("expected 3 running and the fourth queued, got %+v", o.ops)
+	}
+	if !strings.Contains(o.renderOpLine(o.ops[3]), "Queued") {
+		t.Errorf("expected queued line, got %q", o.renderOpLine(o.ops[3]))
+	}
+
+	o, cmd, _ := o.Update(opDeleteResultMsg{opID: 0})
+	if o.ops[3].queued || o.running() != 3 {
+		t.Fatalf("expected queued op to launch once a slot freed, got %+v", o.ops[3])
+	}
+	if cmd == nil {
+		t.Error("expected a command launching the queued op")
+	}
+}
+
+// rebootProvider is a provider that supports rebooting servers.
+type rebootProvider struct {
+	reauthProvider
+	rebooted string
+}
+
+func (p *rebootProvider) RebootServer(_ context.Context, id string) (*domain.ActionStatus, error) {
+	p.rebooted = id
+	return &domain.ActionStatus{ID: "9", Status: domain.ActionStatusRunning}, nil
+}
+
+func (p *rebootProvider) ResetServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func TestOpsOverlay_StartRebootTracksAction(t *testing.T) {
+	provider := &rebootProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+
+	o, cmd := o.StartReboot(domain.Server{ID: "1", Name: "web-1", Status: "off"})
+	if cmd != nil || len(o.ops) != 0 {
+		t.Fatalf("expected no operation for a server that is off")
+	}
+
+	o, cmd = o.StartReboot(domain.Server{ID: "1", Name: "web-1", Status: "running"})
+	if len(o.ops) != 1 || o.ops[0].verb != "rebooted" || o.ops[0].target != "running" {
+		t.Fatalf("expected one reboot operation, got %+v", o.ops)
+	}
+	if !strings.Contains(o.ops[0].statusText, "Rebooting") {
+		t.Errorf("statusText = %q, want Rebooting...", o.ops[0].statusText)
+	}
+
+	var initiated *opToggleInitiatedMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(opToggleInitiatedMsg); ok {
+			initiated = &msg
+		}
+	}
+	if provider.rebooted != "1" {
+		t.Errorf("expected RebootServer(1), got %q", provider.rebooted)
+	}
+	if initiated == nil || initiated.action.ID != "9" {
+		t.Errorf("expected initiated message for action 9, got %+v", initiated)
+	}
+}
+
+// resizeProvider is a provider that supports resizing servers.
+type resizeProvider struct {
+	reauthProvider
+	resizedTo string
+}
+
+func (p *resizeProvider) ResizeServer(_ context.Context, _ string, serverType string, _ bool) (*domain.ActionStatus, error) {
+	p.resizedTo = serverType
+	return &domain.ActionStatus{ID: "10", Status: domain.ActionStatusRunning}, nil
+}
+
+func TestOpsOverlay_StartResizeStopsRunningServerFirst(t *testing.T) {
+	provider := &resizeProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+
+	o, _ = o.StartResize(domain.Server{ID: "1", Name: "web-1", Status: "running"}, "cx32")
+	if len(o.ops) != 1 || o.ops[0].verb != "stopped" || o.ops[0].resizeTo != "cx32" {
+		t.Fatalf("expected a stop operation that resizes next, got %+v", o.ops)
+	}
+	if provider.resizedTo != "" {
+		t.Fatal("expected no resize before the server is off")
+	}
+
+	o.ops[0].pollMode = opPollModeServer
+	o, cmd, events := o.Update(opPollResultMsg{opID: 0, action: &domain.ActionStatus{Status: domain.ActionStatusSuccess}})
+	if len(events) != 0 {
+		t.Errorf("expected no completion event after the stop, got %+v", events)
+	}
+	if o.ops[0].verb != "resized" || o.ops[0].resizeTo != "" || o.ops[0].status != opStatusActive {
+		t.Fatalf("expected the operation to move on to the resize, got %+v", o.ops[0])
+	}
+	if !strings.Contains(o.ops[0].statusText, "cx32") {
+		t.Errorf("statusText = %q, want the new type", o.ops[0].statusText)
+	}
+
+	msg, ok := cmd().(opToggleInitiatedMsg)
+	if !ok || msg.verb != "resized" || msg.target != "off" {
+		t.Fatalf("expected initiated resize message, got %#v", msg)
+	}
+	if provider.resizedTo != "cx32" {
+		t.Errorf("expected ResizeServer(cx32), got %q", provider.resizedTo)
+	}
+}
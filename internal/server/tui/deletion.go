@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// deletionGrace is how long a server whose deletion was accepted is
+	// shown as deleting while the provider still lists it.
+	deletionGrace = 2 * time.Minute
+
+	// deletionRefreshInterval is the delay between quiet list refreshes
+	// while servers are being deleted.
+	deletionRefreshInterval = 3 * time.Second
+)
+
+// deletionRefreshTickMsg asks the list to refetch servers so deleting
+// rows disappear once the provider has removed them.
+type deletionRefreshTickMsg struct{}
+
+// deletionTracker remembers servers whose deletion the provider has
+// accepted. Providers keep listing such servers for a short while, so
+// without it a deleted server would reappear on the next refresh as if
+// nothing happened. The tracker is shared by pointer between the app and
+// the list views it creates; a nil tracker tracks nothing.
+type deletionTracker struct {
+	accepted map[string]time.Time
+	now      func() time.Time
+}
+
+func newDeletionTracker() *deletionTracker {
+	return &deletionTracker{accepted: make(map[string]time.Time), now: time.Now}
+}
+
+// Mark records that the deletion of serverID was accepted.
+func (t *deletionTracker) Mark(serverID string) {
+	if t == nil || serverID == "" {
+		return
+	}
+	t.accepted[serverID] = t.now()
+}
+
+// Observe marks the server in ev when ev reports a deletion.
+func (t *deletionTracker) Observe(ev events.Event) {
+	if ev.Resource == events.ResourceServer && ev.Kind == events.Deleted {
+		t.Mark(ev.ID)
+	}
+}
+
+// Apply returns servers with tracked servers shown as deleting. Servers
+// the provider no longer lists, and those past deletionGrace, are
+// forgotten.
+func (t *deletionTracker) Apply(servers []domain.Server) []domain.Server {
+	if t == nil || len(t.accepted) == 0 {
+		return servers
+	}
+
+	listed := make(map[string]bool, len(servers))
+	for i := range servers {
+		id := servers[i].ID
+		listed[id] = true
+		if at, ok := t.accepted[id]; ok && t.now().Sub(at) < deletionGrace {
+			servers[i].Status = "deleting"
+		}
+	}
+	for id, at := range t.accepted {
+		if !listed[id] || t.now().Sub(at) >= deletionGrace {
+			delete(t.accepted, id)
+		}
+	}
+	return servers
+}
+
+// scheduleDeletionRefresh returns a command that refreshes the list after
+// deletionRefreshInterval.
+func scheduleDeletionRefresh() tea.Cmd {
+	return tea.Tick(deletionRefreshInterval, func(time.Time) tea.Msg { return deletionRefreshTickMsg{} })
+}
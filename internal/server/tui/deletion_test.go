@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
+)
+
+func TestDeletionTracker_Apply(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	tracker := newDeletionTracker()
+	tracker.now = func() time.Time { return now }
+
+	tracker.Observe(events.Event{Resource: events.ResourceServer, Kind: events.Deleted, ID: "1"})
+	tracker.Observe(events.Event{Resource: events.ResourceServer, Kind: events.Changed, ID: "2"})
+
+	servers := tracker.Apply([]domain.Server{
+		{ID: "1", Status: "running"},
+		{ID: "2", Status: "running"},
+	})
+	if !servers[0].IsDeleting() || servers[1].IsDeleting() {
+		t.Fatalf("expected only server 1 to be deleting, got %+v", servers)
+	}
+
+	// Once the provider stops listing the server it is forgotten.
+	tracker.Apply([]domain.Server{{ID: "2", Status: "running"}})
+	if len(tracker.accepted) != 0 {
+		t.Errorf("expected server 1 to be forgotten, got %v", tracker.accepted)
+	}
+
+	// A server that lingers past the grace period is shown as listed.
+	tracker.Mark("2")
+	now = now.Add(deletionGrace)
+	servers = tracker.Apply([]domain.Server{{ID: "2", Status: "running"}})
+	if servers[0].IsDeleting() {
+		t.Error("expected the grace period to expire")
+	}
+}
+
+func TestDeletionTracker_NilIsNoop(t *testing.T) {
+	var tracker *deletionTracker
+	tracker.Mark("1")
+	servers := tracker.Apply([]domain.Server{{ID: "1", Status: "running"}})
+	if servers[0].IsDeleting() {
+		t.Error("expected a nil tracker to leave servers alone")
+	}
+}
+
+func TestServerList_DeletingServerBlocksActions(t *testing.T) {
+	m := newServerListModel(&reauthProvider{}, "mock")
+	m.deletions = newDeletionTracker()
+	m.deletions.Mark("1")
+
+	updated, cmd := m.Update(serversLoadedMsg{servers: []domain.Server{{ID: "1", Name: "web-1", Status: "running"}}})
+	m = updated.(serverListModel)
+	if !m.servers[0].IsDeleting() {
+		t.Fatal("expected the server to be shown as deleting")
+	}
+	if cmd == nil || !m.deletionRefreshPending {
+		t.Error("expected a quiet refresh to be scheduled")
+	}
+
+	for _, key := range []rune{'s', 'd', 'R'} {
+		updated, cmd := m.handleKey(runeKey(key))
+		if cmd != nil {
+			t.Errorf("expected %q to be blocked on a deleting server", key)
+		}
+		if got := updated.(serverListModel); !got.statusIsError {
+			t.Errorf("expected %q to explain why it is blocked", key)
+		}
+	}
+}
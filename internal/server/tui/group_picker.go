@@ -0,0 +1,284 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/group"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// groupUser is the SSH login for group update and run, as for
+// `vpsm group update` without --user.
+const groupUser = "root"
+
+// groupActions are the group actions offered by the picker, in order.
+var groupActions = []group.Op{group.OpStart, group.OpStop, group.OpUpdate, group.OpExec}
+
+// loadGroups returns the server groups defined for providerName. Tests
+// replace it.
+var loadGroups = func(providerName string) ([]servergroups.Group, error) {
+	repo, err := servergroups.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer repo.Close()
+	return repo.List(providerName)
+}
+
+// groupResolvedMsg carries the current members of a group the user chose
+// to act on.
+type groupResolvedMsg struct {
+	group   string
+	op      group.Op
+	opts    group.Options
+	servers []domain.Server
+	err     error
+}
+
+// groupPicker picks a server group, then the action to run across it,
+// then for run the command. The run itself shows in the group view.
+type groupPicker struct {
+	groups []servergroups.Group
+	cursor int
+
+	// chosen is the group whose action is being picked, nil while the
+	// group itself is being picked.
+	chosen *servergroups.Group
+	action int
+
+	// entering is set while the command for run is typed into command.
+	entering bool
+	command  textinput.Model
+
+	// resolving is set while the group's members are listed.
+	resolving bool
+	err       string
+}
+
+// groupActionName is how an action is shown in the picker; exec is the
+// `vpsm group run` command.
+func groupActionName(op group.Op) string {
+	if op == group.OpExec {
+		return "run"
+	}
+	return string(op)
+}
+
+// openGroupPicker shows the group picker over the list.
+func (m serverAppModel) openGroupPicker() (tea.Model, tea.Cmd) {
+	command := textinput.New()
+	command.Placeholder = "uptime"
+	command.CharLimit = 512
+	command.Width = 50
+
+	picker := &groupPicker{command: command}
+	groups, err := loadGroups(m.providerName)
+	switch {
+	case err != nil:
+		picker.err = fmt.Sprintf("Failed to load groups: %v", err)
+	case len(groups) == 0:
+		picker.err = fmt.Sprintf("No groups for %s. Create one with: vpsm group create <name> --provider %s --selector <labels>", m.providerName, m.providerName)
+	}
+	picker.groups = groups
+	m.groups = picker
+	return m, nil
+}
+
+func (m serverAppModel) updateGroupPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	picker := *m.groups
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if picker.resolving {
+		if msg.String() == "esc" {
+			m.groups = nil
+		}
+		return m, nil
+	}
+
+	switch {
+	case picker.entering:
+		switch msg.String() {
+		case "esc":
+			picker.entering = false
+			picker.command.Blur()
+		case "enter":
+			if picker.command.Value() == "" {
+				break
+			}
+			picker.resolving = true
+			m.groups = &picker
+			return m, m.resolveGroup(*picker.chosen, group.OpExec, picker.command.Value())
+		default:
+			var cmd tea.Cmd
+			picker.command, cmd = picker.command.Update(msg)
+			m.groups = &picker
+			return m, cmd
+		}
+
+	case picker.chosen != nil:
+		switch msg.String() {
+		case "esc":
+			picker.chosen = nil
+			picker.err = ""
+		case "up", "k":
+			if picker.action > 0 {
+				picker.action--
+			}
+		case "down", "j":
+			if picker.action < len(groupActions)-1 {
+				picker.action++
+			}
+		case "enter":
+			op := groupActions[picker.action]
+			if op == group.OpExec {
+				picker.entering = true
+				m.groups = &picker
+				return m, picker.command.Focus()
+			}
+			picker.resolving = true
+			m.groups = &picker
+			return m, m.resolveGroup(*picker.chosen, op, "")
+		}
+
+	default:
+		switch msg.String() {
+		case "esc", "o":
+			m.groups = nil
+			return m, nil
+		case "up", "k":
+			if picker.cursor > 0 {
+				picker.cursor--
+			}
+		case "down", "j":
+			if picker.cursor < len(picker.groups)-1 {
+				picker.cursor++
+			}
+		case "enter":
+			if len(picker.groups) == 0 {
+				break
+			}
+			chosen := picker.groups[picker.cursor]
+			picker.chosen = &chosen
+			picker.action = 0
+		}
+	}
+
+	m.groups = &picker
+	return m, nil
+}
+
+// resolveGroup lists g's current members so op can run across them.
+func (m serverAppModel) resolveGroup(g servergroups.Group, op group.Op, command string) tea.Cmd {
+	provider, providerName := m.provider, m.providerName
+	return func() tea.Msg {
+		servers, err := group.Resolve(context.Background(), provider, &g)
+		msg := groupResolvedMsg{group: g.Name, op: op, servers: servers, err: err}
+		if err == nil && (op == group.OpExec || op == group.OpUpdate) {
+			msg.opts = group.Options{
+				Command:  command,
+				Username: groupUser,
+				Bastions: group.Bastions(providerName, &g, servers),
+			}
+		}
+		return msg
+	}
+}
+
+// handleGroupResolved opens the group view for a resolved group, or
+// reports in the picker why it cannot run.
+func (m serverAppModel) handleGroupResolved(msg groupResolvedMsg) (tea.Model, tea.Cmd) {
+	if m.groups == nil || !m.groups.resolving {
+		return m, nil
+	}
+	picker := *m.groups
+	picker.resolving = false
+	switch {
+	case msg.err != nil:
+		picker.err = fmt.Sprintf("Failed to list the members of %q: %v", msg.group, msg.err)
+	case len(msg.servers) == 0:
+		picker.err = fmt.Sprintf("Group %q has no matching servers.", msg.group)
+	default:
+		m.groups = nil
+		return m.switchToGroup(msg)
+	}
+	m.groups = &picker
+	return m, nil
+}
+
+// renderGroupPicker renders the group picker in place of the active view.
+func (m serverAppModel) renderGroupPicker() string {
+	picker := m.groups
+	header := components.Header(m.width, "groups", m.providerName)
+
+	bindings := []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "select"},
+		{Key: "esc", Desc: "back"},
+	}
+	if picker.entering {
+		bindings = []components.KeyBinding{
+			{Key: "enter", Desc: "run"},
+			{Key: "esc", Desc: "back"},
+		}
+	}
+	footer := components.Footer(m.width, bindings)
+
+	var lines []string
+	switch {
+	case picker.entering:
+		lines = []string{
+			styles.Title.Render(fmt.Sprintf("Run on every server in %s", picker.chosen.Name)),
+			"",
+			picker.command.View(),
+			"",
+			styles.MutedText.Render("Runs over SSH as " + groupUser + "."),
+		}
+
+	case picker.chosen != nil:
+		lines = []string{styles.Title.Render(picker.chosen.Name), ""}
+		for i, op := range groupActions {
+			lines = append(lines, pickerLine(groupActionName(op), i == picker.action))
+		}
+
+	default:
+		lines = []string{styles.Title.Render("Act on a group"), ""}
+		for i, g := range picker.groups {
+			line := pickerLine(g.Name, i == picker.cursor)
+			if g.Selector != "" {
+				line += styles.MutedText.Render("  " + g.Selector)
+			}
+			lines = append(lines, line)
+		}
+	}
+	if picker.resolving {
+		lines = append(lines, "", styles.MutedText.Render("Listing members"+styles.Ellipsis()))
+	}
+	if picker.err != "" {
+		lines = append(lines, "", styles.ErrorText.Render(picker.err))
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
+
+// pickerLine renders one picker entry, highlighted when selected.
+func pickerLine(name string, selected bool) string {
+	if selected {
+		return styles.AccentText.Render("> ") + styles.Value.Render(name)
+	}
+	return "  " + name
+}
@@ -0,0 +1,184 @@
+package tui
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/group"
+	"nathanbeddoewebdev/vpsm/internal/servergroups"
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// groupProvider lists fixed servers for group picker tests.
+type groupProvider struct {
+	reauthProvider
+	servers []domain.Server
+}
+
+func (p *groupProvider) ListServers(context.Context) ([]domain.Server, error) {
+	return p.servers, nil
+}
+
+func stubGroups(t *testing.T, groups []servergroups.Group) {
+	t.Helper()
+	orig := loadGroups
+	loadGroups = func(string) ([]servergroups.Group, error) { return groups, nil }
+	t.Cleanup(func() { loadGroups = orig })
+}
+
+func newGroupTestApp() serverAppModel {
+	m := newReauthTestApp()
+	m.provider = &groupProvider{servers: []domain.Server{
+		{ID: "1", Name: "web-1", Status: "running"},
+		{ID: "2", Name: "web-2", Status: "running"},
+		{ID: "3", Name: "db-1", Status: "running"},
+	}}
+	m.list = newServerListModel(m.provider, m.providerName)
+	return m
+}
+
+func pressKeys(t *testing.T, m serverAppModel, keys ...tea.KeyMsg) (serverAppModel, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, key := range keys {
+		var updated tea.Model
+		updated, cmd = m.Update(key)
+		m = updated.(serverAppModel)
+	}
+	return m, cmd
+}
+
+func TestGroupPicker_RunsActionInGroupView(t *testing.T) {
+	stubGroups(t, []servergroups.Group{{Name: "web", ServerIDs: []string{"1", "2"}}})
+
+	m, cmd := pressKeys(t, newGroupTestApp(),
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")},
+		tea.KeyMsg{Type: tea.KeyEnter}, // web
+		tea.KeyMsg{Type: tea.KeyEnter}, // start
+	)
+	if m.groups == nil || !m.groups.resolving || cmd == nil {
+		t.Fatal("expected choosing start to list the group's members")
+	}
+
+	updated, _ := m.Update(cmd())
+	m = updated.(serverAppModel)
+	if m.groups != nil || m.view != appViewGroup {
+		t.Fatalf("expected the picker to close on the group view, view %s", m.ViewName())
+	}
+	if m.group.op != group.OpStart || len(m.group.servers) != 2 {
+		t.Fatalf("expected start across 2 servers, got %s across %d", m.group.op, len(m.group.servers))
+	}
+
+	// Drive the run to completion through the app.
+	msg := m.group.start()
+	for range 10 {
+		updated, cmd = m.Update(msg)
+		m = updated.(serverAppModel)
+		if m.group.done {
+			break
+		}
+		msg = cmd()
+	}
+	if !m.group.done {
+		t.Fatal("expected the run to finish")
+	}
+	for i, r := range m.group.results {
+		if r == nil || !r.Skipped {
+			t.Errorf("expected server %d to be skipped as already running, got %+v", i, r)
+		}
+	}
+
+	_, cmd = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if cmd == nil {
+		t.Fatal("expected q to leave the group view")
+	}
+	if _, ok := cmd().(navigateToListMsg); !ok {
+		t.Error("expected q to return to the server list")
+	}
+}
+
+func TestGroupPicker_RunAsksForCommand(t *testing.T) {
+	dir := t.TempDir()
+	serverprefs.SetPath(filepath.Join(dir, "prefs.db"))
+	servergroups.SetPath(filepath.Join(dir, "groups.db"))
+	t.Cleanup(serverprefs.ResetPath)
+	t.Cleanup(servergroups.ResetPath)
+	stubGroups(t, []servergroups.Group{{Name: "web", ServerIDs: []string{"1"}, Bastion: "jump@203.0.113.10"}})
+
+	m, _ := pressKeys(t, newGroupTestApp(),
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyDown}, // run
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+	if m.groups == nil || !m.groups.entering {
+		t.Fatal("expected run to ask for a command")
+	}
+
+	m, cmd := pressKeys(t, m,
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("uptime")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+	resolved, ok := cmd().(groupResolvedMsg)
+	if !ok {
+		t.Fatal("expected entering a command to list the group's members")
+	}
+	if resolved.op != group.OpExec || resolved.opts.Command != "uptime" || resolved.opts.Username != groupUser {
+		t.Errorf("unexpected run: %s %+v", resolved.op, resolved.opts)
+	}
+	if got := resolved.opts.Bastions["1"]; got != "jump@203.0.113.10" {
+		t.Errorf("expected the group's bastion, got %q", got)
+	}
+}
+
+func TestGroupPicker_EmptyGroupStaysOpen(t *testing.T) {
+	stubGroups(t, []servergroups.Group{{Name: "none", Selector: "role=none"}})
+
+	m, cmd := pressKeys(t, newGroupTestApp(),
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+	updated, _ := m.Update(cmd())
+	app := updated.(serverAppModel)
+
+	if app.view != appViewList || app.groups == nil {
+		t.Fatal("expected the picker to stay open on the list")
+	}
+	if !strings.Contains(app.groups.err, "no matching servers") {
+		t.Errorf("expected an empty group error, got %q", app.groups.err)
+	}
+}
+
+func TestGroupPicker_HintsWithoutGroups(t *testing.T) {
+	stubGroups(t, nil)
+
+	m, _ := pressKeys(t, newGroupTestApp(), tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if m.groups == nil || !strings.Contains(m.groups.err, "vpsm group create") {
+		t.Fatal("expected the picker to explain how to create a group")
+	}
+
+	m, _ = pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.groups != nil {
+		t.Error("expected esc to close the picker")
+	}
+}
+
+func TestGroupProgress_IgnoresOtherRuns(t *testing.T) {
+	servers := []domain.Server{{ID: "1", Name: "web-1"}}
+	m := newGroupProgressModel(&groupProvider{}, "mock", "web", servers, group.OpStart, group.Options{})
+	defer m.cancel()
+
+	stale := groupDoneMsg{updates: make(chan tea.Msg), results: []group.Result{{Server: servers[0]}}}
+	updated, _ := m.Update(stale)
+	if updated.(groupProgressModel).done {
+		t.Error("expected a message from another run to be ignored")
+	}
+}
@@ -0,0 +1,357 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/group"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+// groupEventMsg and groupDoneMsg carry the updates stream they came
+// from, so a run the user already left cannot update a newer one.
+type groupEventMsg struct {
+	updates chan tea.Msg
+	event   group.Event
+}
+
+type groupDoneMsg struct {
+	updates chan tea.Msg
+	results []group.Result
+}
+
+// --- Group progress model ---
+
+// groupProgressModel shows a combined progress view while a group action
+// runs: one row per member server with its state, and the output of the
+// selected server once it has finished.
+type groupProgressModel struct {
+	providerName string
+	groupName    string
+	op           group.Op
+
+	servers []domain.Server
+	states  []group.State
+	results []*group.Result
+	done    bool
+	cursor  int
+
+	spinner  spinner.Model
+	viewport viewport.Model
+	updates  chan tea.Msg
+	start    tea.Cmd
+	cancel   context.CancelFunc
+
+	// embedded is set when the view runs inside the server app, where
+	// q and esc return to the server list instead of quitting.
+	embedded bool
+
+	width  int
+	height int
+}
+
+// newGroupProgressModel returns a progress view for op across servers.
+// Init starts the run; the returned model's cancel stops it.
+func newGroupProgressModel(provider domain.Provider, providerName, groupName string, servers []domain.Server, op group.Op, opts group.Options) groupProgressModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	vp := viewport.New(0, 0)
+	vp.KeyMap = detailViewportKeyMap()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates := make(chan tea.Msg)
+	start := func() tea.Msg {
+		go func() {
+			send := func(msg tea.Msg) {
+				select {
+				case updates <- msg:
+				case <-ctx.Done():
+				}
+			}
+			results := group.Run(ctx, provider, providerName, servers, op, opts, func(e group.Event) {
+				send(groupEventMsg{updates: updates, event: e})
+			})
+			send(groupDoneMsg{updates: updates, results: results})
+		}()
+		return <-updates
+	}
+
+	return groupProgressModel{
+		providerName: providerName,
+		groupName:    groupName,
+		op:           op,
+		servers:      servers,
+		states:       make([]group.State, len(servers)),
+		results:      make([]*group.Result, len(servers)),
+		spinner:      s,
+		viewport:     vp,
+		updates:      updates,
+		start:        start,
+		cancel:       cancel,
+	}
+}
+
+// RunGroupProgress runs op across servers and displays combined progress
+// until the user exits. It returns the per-server results, which are
+// partial if the user quit early.
+func RunGroupProgress(provider domain.Provider, providerName, groupName string, servers []domain.Server, op group.Op, opts group.Options) ([]group.Result, error) {
+	m := newGroupProgressModel(provider, providerName, groupName, servers, op, opts)
+	defer m.cancel()
+
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
+	final, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run group progress: %w", err)
+	}
+
+	fm := final.(groupProgressModel)
+	var results []group.Result
+	for _, r := range fm.results {
+		if r != nil {
+			results = append(results, *r)
+		}
+	}
+	return results, nil
+}
+
+// waitGroupUpdate waits for the next update of a group run.
+func waitGroupUpdate(updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-updates }
+}
+
+func (m groupProgressModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.start)
+}
+
+// --- Update ---
+
+func (m groupProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.syncViewport()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case groupEventMsg:
+		if msg.updates != m.updates {
+			return m, nil
+		}
+		e := msg.event
+		m.states[e.Index] = e.State
+		if e.Result != nil {
+			m.results[e.Index] = e.Result
+		}
+		m.syncViewport()
+		return m, waitGroupUpdate(m.updates)
+
+	case groupDoneMsg:
+		if msg.updates != m.updates {
+			return m, nil
+		}
+		m.done = true
+		for i := range msg.results {
+			m.results[i] = &msg.results[i]
+		}
+		m.syncViewport()
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.done {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m groupProgressModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.cancel()
+		if m.embedded {
+			return m, func() tea.Msg { return navigateToListMsg{} }
+		}
+		return m, tea.Quit
+
+	case "ctrl+c":
+		m.cancel()
+		return m, tea.Quit
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+			m.syncViewport()
+		}
+		return m, nil
+
+	case "down", "j":
+		if m.cursor < len(m.servers)-1 {
+			m.cursor++
+			m.syncViewport()
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// syncViewport sizes the output pane and loads the selected server's output.
+func (m *groupProgressModel) syncViewport() {
+	header, statusBar, footer := m.chrome()
+	height := m.height - lipgloss.Height(header) - lipgloss.Height(statusBar) - lipgloss.Height(footer) - lipgloss.Height(m.renderRows()) - 2
+	if height < 1 {
+		height = 1
+	}
+	width := m.width - 4
+	if width < 1 {
+		width = 1
+	}
+	m.viewport.Width = width
+	m.viewport.Height = height
+
+	content := ""
+	if len(m.results) > 0 {
+		if r := m.results[m.cursor]; r != nil {
+			content = r.Output
+			if r.Err != nil {
+				content = strings.TrimRight(content, "\n") + "\n" + styles.ErrorText.Render("Error: "+r.Err.Error())
+			}
+		}
+	}
+	m.viewport.SetContent(strings.TrimLeft(content, "\n"))
+}
+
+// --- View ---
+
+func (m groupProgressModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header, statusBar, footer := m.chrome()
+	rows := m.renderRows()
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(statusBar) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	divider := styles.MutedText.Render(strings.Repeat(styles.HLine(), max(m.width-4, 1)))
+	body := lipgloss.JoinVertical(lipgloss.Left, rows, divider, m.viewport.View())
+	content := lipgloss.NewStyle().Padding(1, 2, 0, 2).Height(contentH).MaxHeight(contentH).Render(body)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, statusBar, footer)
+}
+
+// chrome renders the header, status bar, and footer around the progress rows.
+func (m groupProgressModel) chrome() (header, statusBar, footer string) {
+	header = components.HeaderTrail(m.width, []string{"groups", m.groupName, string(m.op)}, m.providerName)
+
+	quitDesc := "cancel"
+	switch {
+	case m.done && m.embedded:
+		quitDesc = "back"
+	case m.done:
+		quitDesc = "quit"
+	}
+	footer = components.Footer(m.width, []components.KeyBinding{
+		{Key: styles.Up() + "/" + styles.Down(), Desc: "select server"},
+		{Key: "pgup/pgdn", Desc: "scroll output"},
+		{Key: "q", Desc: quitDesc},
+	})
+
+	statusBar = components.StatusBar(m.width, m.statusText(), m.failed() > 0)
+	return header, statusBar, footer
+}
+
+func (m groupProgressModel) statusText() string {
+	finished := 0
+	for _, r := range m.results {
+		if r != nil {
+			finished++
+		}
+	}
+	text := fmt.Sprintf("%d/%d servers finished", finished, len(m.servers))
+	if failed := m.failed(); failed > 0 {
+		text += fmt.Sprintf(" %s %d failed", styles.Middot(), failed)
+	}
+	if m.done {
+		text += " " + styles.Middot() + " done"
+	}
+	return text
+}
+
+func (m groupProgressModel) failed() int {
+	n := 0
+	for _, r := range m.results {
+		if r != nil && r.Err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+func (m groupProgressModel) renderRows() string {
+	nameWidth := 0
+	for _, s := range m.servers {
+		nameWidth = max(nameWidth, lipgloss.Width(s.Name))
+	}
+
+	var lines []string
+	for i, s := range m.servers {
+		var icon, detail string
+		switch m.states[i] {
+		case group.StatePending:
+			icon, detail = styles.MutedText.Render(styles.Middot()), styles.MutedText.Render("waiting")
+		case group.StateRunning:
+			icon, detail = m.spinner.View(), styles.MutedText.Render("running"+styles.Ellipsis())
+		case group.StateDone:
+			icon, detail = styles.SuccessText.Render(styles.Check()), "done"
+			if r := m.results[i]; r != nil && r.Skipped {
+				detail = styles.MutedText.Render("already " + s.Status)
+			}
+		case group.StateFailed:
+			icon = styles.ErrorText.Render(styles.Cross())
+			if r := m.results[i]; r != nil && r.Err != nil {
+				detail = styles.ErrorText.Render(firstLine(r.Err.Error()))
+			}
+		}
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = styles.AccentText.Render("> ")
+		}
+		name := s.Name + strings.Repeat(" ", nameWidth-lipgloss.Width(s.Name))
+		lines = append(lines, fmt.Sprintf("%s%s %s  %s", cursor, icon, name, detail))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// latencyEntries is how many provider calls the debug line lists per view.
+const latencyEntries = 4
+
+// timing records how long the provider call behind a loaded message took.
+// Messages embed it so the app can collect durations without every view
+// holding a recorder.
+type timing struct {
+	call string
+	took time.Duration
+}
+
+func (t timing) callTiming() timing { return t }
+
+// timedMsg is implemented by messages that embed timing.
+type timedMsg interface {
+	callTiming() timing
+}
+
+// timed returns the timing of call, which started at start.
+func timed(call string, start time.Time) timing {
+	return timing{call: call, took: time.Since(start)}
+}
+
+// latencyRecorder keeps the most recent provider call durations per view
+// for the debug status line. It is shared by pointer and only touched from
+// the app's Update; a nil recorder records nothing.
+type latencyRecorder struct {
+	byView map[appView][]timing
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{byView: map[appView][]timing{}}
+}
+
+// Record notes t against view, replacing an earlier duration of the same
+// call so each call is listed once with its latest value.
+func (r *latencyRecorder) Record(view appView, t timing) {
+	if r == nil || t.call == "" {
+		return
+	}
+	entries := r.byView[view]
+	for i, e := range entries {
+		if e.call == t.call {
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	entries = append(entries, t)
+	if len(entries) > latencyEntries {
+		entries = entries[len(entries)-latencyEntries:]
+	}
+	r.byView[view] = entries
+}
+
+// Summary describes the calls recorded for view, oldest first, e.g.
+// "list fetch 420ms · metrics 1.2s".
+func (r *latencyRecorder) Summary(view appView) string {
+	if r == nil || len(r.byView[view]) == 0 {
+		return "no provider calls yet"
+	}
+	parts := make([]string, 0, len(r.byView[view]))
+	for _, e := range r.byView[view] {
+		parts = append(parts, e.call+" "+formatLatency(e.took))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// formatLatency renders d as "420ms" below a second and "1.2s" above.
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
+
+// latencyDivider renders summary as a divider line, in the style of the
+// project divider, to replace the footer's top border.
+func latencyDivider(width int, summary string) string {
+	label := styles.WarningText.Render(" debug: " + summary + " ")
+	fill := width - lipgloss.Width(label) - 2
+	if fill < 1 {
+		return ""
+	}
+	line := lipgloss.NewStyle().Foreground(styles.DimGray)
+	return line.Render(strings.Repeat(styles.HLine(), 2)) + label + line.Render(strings.Repeat(styles.HLine(), fill))
+}
+
+// composeFooterDivider replaces the footer's top border, the second to
+// last line of a full-height view, with divider.
+func composeFooterDivider(view, divider string) string {
+	if divider == "" {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	if len(lines) < 2 {
+		return view
+	}
+	lines[len(lines)-2] = divider
+	return strings.Join(lines, "\n")
+}
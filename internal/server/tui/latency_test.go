@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatLatency(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{420 * time.Millisecond, "420ms"},
+		{999 * time.Millisecond, "999ms"},
+		{1200 * time.Millisecond, "1.2s"},
+		{12 * time.Second, "12.0s"},
+	}
+	for _, tt := range tests {
+		if got := formatLatency(tt.d); got != tt.want {
+			t.Errorf("formatLatency(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestLatencyRecorder_PerViewLatestFirstSeen(t *testing.T) {
+	r := newLatencyRecorder()
+	r.Record(appViewList, timing{call: "list fetch", took: 300 * time.Millisecond})
+	r.Record(appViewShow, timing{call: "metrics", took: 1200 * time.Millisecond})
+	r.Record(appViewList, timing{call: "list fetch", took: 420 * time.Millisecond})
+
+	if got := r.Summary(appViewList); got != "list fetch 420ms" {
+		t.Errorf("list summary = %q", got)
+	}
+	if got := r.Summary(appViewShow); got != "metrics 1.2s" {
+		t.Errorf("show summary = %q", got)
+	}
+	if got := r.Summary(appViewBackups); got != "no provider calls yet" {
+		t.Errorf("backups summary = %q", got)
+	}
+}
+
+func TestLatencyRecorder_KeepsRecentCalls(t *testing.T) {
+	r := newLatencyRecorder()
+	for _, call := range []string{"a", "b", "c", "d", "e"} {
+		r.Record(appViewShow, timing{call: call, took: time.Millisecond})
+	}
+	if got := r.Summary(appViewShow); strings.Contains(got, "a ") || !strings.HasPrefix(got, "b ") {
+		t.Errorf("expected the oldest call to be dropped, got %q", got)
+	}
+}
+
+func TestServerApp_RecordsTimedMessagesAndTogglesDebugLine(t *testing.T) {
+	m := newReauthTestApp()
+	m.latency = newLatencyRecorder()
+
+	updated, _ := m.update(serversLoadedMsg{
+		timing:  timing{call: "list fetch", took: 420 * time.Millisecond},
+		servers: []domain.Server{{ID: "1", Name: "web-1", Status: "running"}},
+	})
+	m = updated.(serverAppModel)
+	if strings.Contains(m.View(), "debug:") {
+		t.Fatal("expected the debug line to be hidden by default")
+	}
+
+	updated, _ = m.update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = updated.(serverAppModel)
+	lines := strings.Split(m.View(), "\n")
+	if len(lines) != m.height {
+		t.Fatalf("expected %d lines, got %d", m.height, len(lines))
+	}
+	if debug := lines[len(lines)-2]; !strings.Contains(debug, "list fetch 420ms") {
+		t.Errorf("expected the footer border to show the latency, got %q", debug)
+	}
+
+	updated, _ = m.update(tea.KeyMsg{Type: tea.KeyCtrlG})
+	m = updated.(serverAppModel)
+	if strings.Contains(m.View(), "debug:") {
+		t.Error("expected ctrl+g to hide the debug line again")
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+)
+
+// deviceChart describes a dual-series chart drawn once per disk or network
+// interface, from series named "<kind>.<device>.<series>".
+type deviceChart struct {
+	kind             string // "disk" or "network"
+	noun             string // names a device in labels, e.g. "disk 1"
+	label            string
+	series1, series2 string
+	legend1, legend2 string
+	suffix           string
+	colors           components.DualChartColors
+}
+
+var (
+	diskIOPSChart = deviceChart{
+		kind: "disk", noun: "disk", label: "Disk IOPS",
+		series1: "iops.read", series2: "iops.write", legend1: "read", legend2: "write",
+		colors: components.DualChartColors{Color1: styles.Blue, Color2: styles.Yellow},
+	}
+	diskThroughputChart = deviceChart{
+		kind: "disk", noun: "disk", label: "Disk throughput",
+		series1: "bandwidth.read", series2: "bandwidth.write", legend1: "read", legend2: "write", suffix: "B/s",
+		colors: components.DualChartColors{Color1: styles.Blue, Color2: styles.Yellow},
+	}
+	networkBandwidthChart = deviceChart{
+		kind: "network", noun: "interface", label: "Network",
+		series1: "bandwidth.in", series2: "bandwidth.out", legend1: "in", legend2: "out", suffix: "B/s",
+		colors: components.DualChartColors{Color1: styles.Green, Color2: styles.Red},
+	}
+	networkPacketsChart = deviceChart{
+		kind: "network", noun: "interface", label: "Network packets",
+		series1: "pps.in", series2: "pps.out", legend1: "in", legend2: "out", suffix: "/s",
+		colors: components.DualChartColors{Color1: styles.Green, Color2: styles.Red},
+	}
+)
+
+// renderDeviceCharts renders c for every device metrics has series for.
+// With more than one device each chart's label names its device.
+func renderDeviceCharts(metrics *domain.ServerMetrics, c deviceChart, width int, opts components.ChartOptions) []string {
+	devices := metricDevices(metrics, c.kind)
+	var charts []string
+	for _, dev := range devices {
+		prefix := c.kind + "." + dev + "."
+		s1 := extractMetricValues(metrics, prefix+c.series1)
+		s2 := extractMetricValues(metrics, prefix+c.series2)
+		if len(s1) == 0 && len(s2) == 0 {
+			continue
+		}
+		label := c.label
+		if len(devices) > 1 {
+			label += " (" + c.noun + " " + dev + ")"
+		}
+		charts = append(charts, components.MetricsDualChartWith(
+			label, s1, s2, c.legend1, c.legend2, width, c.suffix, c.colors, opts,
+		))
+	}
+	return charts
+}
+
+// metricDevices returns the devices of kind ("disk" or "network") that
+// metrics has series for, in numeric order.
+func metricDevices(metrics *domain.ServerMetrics, kind string) []string {
+	seen := make(map[string]bool)
+	var devices []string
+	for name := range metrics.TimeSeries {
+		rest, ok := strings.CutPrefix(name, kind+".")
+		if !ok {
+			continue
+		}
+		dev, _, ok := strings.Cut(rest, ".")
+		if !ok || seen[dev] {
+			continue
+		}
+		seen[dev] = true
+		devices = append(devices, dev)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		a, errA := strconv.Atoi(devices[i])
+		b, errB := strconv.Atoi(devices[j])
+		if errA != nil || errB != nil {
+			return devices[i] < devices[j]
+		}
+		return a < b
+	})
+	return devices
+}
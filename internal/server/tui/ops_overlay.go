@@ -7,8 +7,11 @@ import (
 	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/config"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+	archivesvc "nathanbeddoewebdev/vpsm/internal/services/serverarchive"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -67,6 +70,12 @@ type requestToggleMsg struct {
 	server domain.Server
 }
 
+// requestRebootMsg is emitted by child models when the user presses "R"
+// to reboot a running server.
+type requestRebootMsg struct {
+	server domain.Server
+}
+
 // All overlay messages carry an opID so the overlay can route them to
 // the correct in-flight operation. Stale messages for already-dismissed
 // operations are silently dropped.
@@ -103,14 +112,56 @@ type opDismissMsg struct {
 	opID int
 }
 
+// opDeleteResultMsg reports the delete step of a stop-then-delete operation.
+type opDeleteResultMsg struct {
+	opID int
+	err  error
+}
+
+// opTransferProgressMsg reports how much of a file transfer has been
+// copied.
+type opTransferProgressMsg struct {
+	opID        int
+	done, total int64
+}
+
+// opTransferResultMsg reports how a file transfer ended.
+type opTransferResultMsg struct {
+	opID int
+	err  error
+}
+
 // opCompletedEvent is returned to the parent model via the outcomes
-// slice so it can take action (e.g. refresh server list). It is not a
-// tea.Msg — it is returned synchronously from Update.
+// slice so it can take action (e.g. publish a resource event). It is not
+// a tea.Msg — it is returned synchronously from Update.
 type opCompletedEvent struct {
 	Success    bool
+	ServerID   string
 	ServerName string
-	Verb       string // "started" or "stopped"
+	Verb       string // operation verb, e.g. "stopped" or "deleted"
 	ErrText    string
+
+	// Unchanged is set when the operation did not change the server
+	// itself (e.g. a file transfer), so views need not refresh it.
+	Unchanged bool
+
+	// Undo reverts a successful change; nil if it cannot be undone.
+	Undo undoFunc
+}
+
+// resourceEvent converts a successful operation into the event that views
+// subscribe to.
+func (ev opCompletedEvent) resourceEvent() events.Event {
+	kind := events.Changed
+	if ev.Verb == "deleted" {
+		kind = events.Deleted
+	}
+	return events.Event{
+		Resource: events.ResourceServer,
+		Kind:     kind,
+		ID:       ev.ServerID,
+		Name:     ev.ServerName,
+	}
 }
 
 // --- Operation ---
@@ -122,7 +173,7 @@ type operation struct {
 	provider   string // provider name for database persistence
 	serverID   string
 	serverName string
-	verb       string // "started" or "stopped"
+	verb       string // e.g. "started", "stopped", "rebooted" or "deleted"
 	target     string // target server status
 
 	pollMode  string // "action" or "server"
@@ -134,6 +185,36 @@ type operation struct {
 	status     string // opStatusActive, opStatusSucceeded, opStatusFailed
 	statusText string
 	progress   int
+
+	// started is when the provider accepted the operation; typical is how
+	// long it usually takes (0 without history), for the ETA in runningText.
+	started time.Time
+	typical time.Duration
+
+	// thenDelete deletes the server once a stop completes.
+	thenDelete bool
+	// resizeTo resizes the server to this type once a stop completes.
+	resizeTo string
+	// undo reverts the change once it has succeeded; nil for changes
+	// that cannot be undone.
+	undo undoFunc
+
+	// queued is set while the operation waits for a free slot; launch
+	// holds the API call to fire once one opens up.
+	queued bool
+	launch tea.Cmd
+
+	// locked is set while the operation holds its server's lock in the
+	// action store, keeping other vpsm processes off the server.
+	locked bool
+
+	// transfer streams the progress and result of a file transfer; nil
+	// for other operations. fileName is the file being copied;
+	// transferred and fileSize count its bytes.
+	transfer    chan tea.Msg
+	fileName    string
+	transferred int64
+	fileSize    int64
 }
 
 // --- Ops overlay ---
@@ -150,6 +231,10 @@ type opsOverlay struct {
 	nextID       int
 	spinner      spinner.Model
 	svc          *action.Service // persistence service (may be nil if DB unavailable)
+
+	// maxActive caps how many operations run against the provider at
+	// once. Further operations are queued. Zero means no cap.
+	maxActive int
 }
 
 // newOpsOverlay creates an overlay bound to the given provider and loads
@@ -157,7 +242,7 @@ type opsOverlay struct {
 // initialization command.
 func newOpsOverlay(provider domain.Provider, providerName string) (opsOverlay, tea.Cmd) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	// Open database connection (best-effort, continue if unavailable).
@@ -174,6 +259,7 @@ func newOpsOverlay(provider domain.Provider, providerName string) (opsOverlay, t
 		providerName: providerName,
 		spinner:      s,
 		svc:          svc,
+		maxActive:    loadOpsConcurrency(),
 	}
 
 	// Load pending actions from database.
@@ -182,6 +268,16 @@ func newOpsOverlay(provider domain.Provider, providerName string) (opsOverlay, t
 	return o, cmd
 }
 
+// loadOpsConcurrency returns the configured operation cap, or the
+// default if the config cannot be read.
+func loadOpsConcurrency() int {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.DefaultOpsConcurrency
+	}
+	return cfg.OperationConcurrency()
+}
+
 // HasActive reports whether any operations are still polling.
 func (o opsOverlay) HasActive() bool {
 	for _, op := range o.ops {
@@ -233,8 +329,27 @@ func (o *opsOverlay) loadPendingActions() tea.Cmd {
 		o.nextID++
 
 		verb := "started"
-		if record.Command == "stop_server" {
+		switch record.Command {
+		case "stop_server":
 			verb = "stopped"
+		case "reboot_server":
+			verb = "rebooted"
+		case "resize_server":
+			verb = "resized"
+		case "enable_backup":
+			verb = "backups enabled"
+		case "disable_backup":
+			verb = "backups disabled"
+		case "restore_backup":
+			verb = "restored"
+		case "assign_floating_ip":
+			verb = "floating IP assigned"
+		case "unassign_floating_ip":
+			verb = "floating IP unassigned"
+		case "change_dns_ptr":
+			verb = "reverse DNS updated"
+		case "update_labels":
+			verb = "labels updated"
 		}
 
 		op := operation{
@@ -250,6 +365,8 @@ func (o *opsOverlay) loadPendingActions() tea.Cmd {
 			status:     opStatusActive,
 			statusText: fmt.Sprintf("Resuming %q...", record.ServerName),
 			progress:   record.Progress,
+			started:    record.CreatedAt,
+			typical:    o.svc.TypicalDuration(record.Command),
 		}
 
 		o.ops = append(o.ops, op)
@@ -300,10 +417,49 @@ func (o *opsOverlay) saveOp(op operation) {
 
 // inferCommand converts a verb to a command name for database storage.
 func inferCommand(verb string) string {
-	if verb == "started" {
+	switch verb {
+	case "started":
 		return "start_server"
+	case "rebooted":
+		return "reboot_server"
+	case "resized":
+		return "resize_server"
+	case "backups enabled":
+		return "enable_backup"
+	case "backups disabled":
+		return "disable_backup"
+	case "restored":
+		return "restore_backup"
+	case "floating IP assigned":
+		return "assign_floating_ip"
+	case "floating IP unassigned":
+		return "unassign_floating_ip"
+	case "reverse DNS updated":
+		return "change_dns_ptr"
+	case "labels updated":
+		return "update_labels"
+	default:
+		return "stop_server"
+	}
+}
+
+// runningText is the status line of an in-flight operation: the progress
+// the provider reports, if any, and how long the operation usually takes.
+// Hetzner often reports 0% until the very end, so the ETA is what tells
+// the user whether to wait.
+func (op operation) runningText() string {
+	text := fmt.Sprintf("%s %q", verbToGerund(op.verb), op.serverName)
+	eta := action.ETA(op.typical, time.Since(op.started))
+	switch {
+	case op.progress > 0 && eta != "":
+		return fmt.Sprintf("%s (%d%%, %s)", text, op.progress, eta)
+	case op.progress > 0:
+		return fmt.Sprintf("%s (%d%%)", text, op.progress)
+	case eta != "":
+		return text + ", " + eta
+	default:
+		return text + "..."
 	}
-	return "stop_server"
 }
 
 // mapOpStatusToDomain converts overlay status to domain status.
@@ -352,10 +508,6 @@ func (o opsOverlay) StartToggle(server domain.Server) (opsOverlay, tea.Cmd) {
 		status:     opStatusActive,
 		statusText: fmt.Sprintf("%s %q...", verbToGerund(verb), server.Name),
 	}
-	o.ops = append(o.ops, op)
-
-	// Persist initial operation state to database.
-	o.saveOp(op)
 
 	provider := o.provider
 	cmd := func() tea.Msg {
@@ -390,15 +542,435 @@ func (o opsOverlay) StartToggle(server domain.Server) (opsOverlay, tea.Cmd) {
 		}
 	}
 
-	return o, tea.Batch(o.spinner.Tick, cmd)
+	return o.enqueue(op, cmd)
+}
+
+// StartReboot creates a new operation that reboots a running server and
+// tracks the reboot action until the server is running again.
+func (o opsOverlay) StartReboot(server domain.Server) (opsOverlay, tea.Cmd) {
+	rebooter, ok := o.provider.(domain.RebootProvider)
+	if !ok || server.Status != "running" {
+		return o, nil
+	}
+	return o.startServerAction(server, "rebooted", "running", nil, func(ctx context.Context) (*domain.ActionStatus, error) {
+		return rebooter.RebootServer(ctx, server.ID)
+	})
+}
+
+// StartBackups turns automatic backups on or off for a server. The
+// server keeps its power state. The change can be undone.
+func (o opsOverlay) StartBackups(server domain.Server, enable bool) (opsOverlay, tea.Cmd) {
+	undo := func(o opsOverlay) (opsOverlay, tea.Cmd) {
+		return o.setBackups(server, !enable, nil)
+	}
+	return o.setBackups(server, enable, undo)
+}
+
+func (o opsOverlay) setBackups(server domain.Server, enable bool, undo undoFunc) (opsOverlay, tea.Cmd) {
+	backups, ok := o.provider.(domain.BackupProvider)
+	if !ok {
+		return o, nil
+	}
+	if enable {
+		return o.startServerAction(server, "backups enabled", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+			return backups.EnableBackups(ctx, server.ID)
+		})
+	}
+	return o.startServerAction(server, "backups disabled", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+		return backups.DisableBackups(ctx, server.ID)
+	})
+}
+
+// StartRestore overwrites a server's disk with one of its backups.
+func (o opsOverlay) StartRestore(server domain.Server, backup domain.Backup) (opsOverlay, tea.Cmd) {
+	backups, ok := o.provider.(domain.BackupProvider)
+	if !ok {
+		return o, nil
+	}
+	return o.startServerAction(server, "restored", server.Status, nil, func(ctx context.Context) (*domain.ActionStatus, error) {
+		return backups.RestoreBackup(ctx, server.ID, backup.ID)
+	})
+}
+
+// StartFloatingIP assigns a floating IP to a server or removes it. The
+// server keeps its power state. The change can be undone, except for an
+// address taken over from another server.
+func (o opsOverlay) StartFloatingIP(server domain.Server, ip domain.FloatingIP, assign bool) (opsOverlay, tea.Cmd) {
+	var undo undoFunc
+	if !assign || ip.ServerID == "" {
+		undo = func(o opsOverlay) (opsOverlay, tea.Cmd) {
+			return o.setFloatingIP(server, ip, !assign, nil)
+		}
+	}
+	return o.setFloatingIP(server, ip, assign, undo)
+}
+
+func (o opsOverlay) setFloatingIP(server domain.Server, ip domain.FloatingIP, assign bool, undo undoFunc) (opsOverlay, tea.Cmd) {
+	fp, ok := o.provider.(domain.FloatingIPProvider)
+	if !ok {
+		return o, nil
+	}
+	if assign {
+		return o.startServerAction(server, "floating IP assigned", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+			return fp.AssignFloatingIP(ctx, ip.ID, server.ID)
+		})
+	}
+	return o.startServerAction(server, "floating IP unassigned", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+		return fp.UnassignFloatingIP(ctx, ip.ID)
+	})
+}
+
+// StartReverseDNS sets the PTR record of one of a server's addresses, or
+// resets it when hostname is empty. The change can be undone by restoring
+// the previous hostname.
+func (o opsOverlay) StartReverseDNS(server domain.Server, ip, hostname, previous string) (opsOverlay, tea.Cmd) {
+	undo := func(o opsOverlay) (opsOverlay, tea.Cmd) {
+		return o.setReverseDNS(server, ip, previous, nil)
+	}
+	return o.setReverseDNS(server, ip, hostname, undo)
+}
+
+func (o opsOverlay) setReverseDNS(server domain.Server, ip, hostname string, undo undoFunc) (opsOverlay, tea.Cmd) {
+	rp, ok := o.provider.(domain.ReverseDNSProvider)
+	if !ok {
+		return o, nil
+	}
+	return o.startServerAction(server, "reverse DNS updated", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+		return rp.SetReverseDNS(ctx, server.ID, ip, hostname)
+	})
+}
+
+// StartLabels replaces a server's labels. The change can be undone by
+// restoring the previous ones.
+func (o opsOverlay) StartLabels(server domain.Server, labels, previous map[string]string) (opsOverlay, tea.Cmd) {
+	undo := func(o opsOverlay) (opsOverlay, tea.Cmd) {
+		return o.setLabels(server, previous, nil)
+	}
+	return o.setLabels(server, labels, undo)
+}
+
+func (o opsOverlay) setLabels(server domain.Server, labels map[string]string, undo undoFunc) (opsOverlay, tea.Cmd) {
+	lp, ok := o.provider.(domain.LabelProvider)
+	if !ok {
+		return o, nil
+	}
+	return o.startServerAction(server, "labels updated", server.Status, undo, func(ctx context.Context) (*domain.ActionStatus, error) {
+		if err := lp.SetLabels(ctx, server.ID, labels); err != nil {
+			return nil, err
+		}
+		// Labels change at once; there is no action to wait for.
+		return &domain.ActionStatus{Status: domain.ActionStatusSuccess}, nil
+	})
+}
+
+// startServerAction creates an operation that runs call against server
+// and tracks the resulting action until the server reaches target. A
+// non-nil undo is offered once the action succeeds.
+func (o opsOverlay) startServerAction(server domain.Server, verb, target string, undo undoFunc, call func(context.Context) (*domain.ActionStatus, error)) (opsOverlay, tea.Cmd) {
+	opID := o.nextID
+	o.nextID++
+
+	op := operation{
+		id:         opID,
+		provider:   o.providerName,
+		serverID:   server.ID,
+		serverName: server.Name,
+		verb:       verb,
+		target:     target,
+		status:     opStatusActive,
+		statusText: fmt.Sprintf("%s %q...", verbToGerund(verb), server.Name),
+		undo:       undo,
+	}
+
+	cmd := func() tea.Msg {
+		action, err := call(context.Background())
+		if err != nil {
+			return opToggleErrorMsg{opID: opID, err: fmt.Errorf("failed to %s server %q: %w", verbToInfinitive(verb), server.Name, err)}
+		}
+		return opToggleInitiatedMsg{
+			opID:       opID,
+			serverID:   server.ID,
+			serverName: server.Name,
+			verb:       verb,
+			target:     target,
+			action:     action,
+		}
+	}
+
+	return o.enqueue(op, cmd)
+}
+
+// StartStopThenDelete stops a running server and deletes it once it is
+// off, as one operation. Servers that are already off are deleted
+// straight away.
+func (o opsOverlay) StartStopThenDelete(server domain.Server) (opsOverlay, tea.Cmd) {
+	if server.IsStopped() {
+		opID := o.nextID
+		o.nextID++
+		return o.enqueue(operation{
+			id:         opID,
+			provider:   o.providerName,
+			serverID:   server.ID,
+			serverName: server.Name,
+			verb:       "deleted",
+			status:     opStatusActive,
+			statusText: fmt.Sprintf("Deleting %q...", server.Name),
+		}, o.deleteServer(opID, server.ID))
+	}
+
+	opID := o.nextID
+	o, cmd := o.StartToggle(server)
+	if idx := o.findOp(opID); idx >= 0 {
+		o.ops[idx].thenDelete = true
+	}
+	return o, cmd
+}
+
+// StartResize changes a server's type, keeping its disk size so the
+// resize can be undone. A running server is stopped first and left off
+// once the resize completes.
+func (o opsOverlay) StartResize(server domain.Server, serverType string) (opsOverlay, tea.Cmd) {
+	if _, ok := o.provider.(domain.ResizeProvider); !ok {
+		return o, nil
+	}
+
+	if server.IsStopped() {
+		opID := o.nextID
+		o.nextID++
+		return o.enqueue(operation{
+			id:         opID,
+			provider:   o.providerName,
+			serverID:   server.ID,
+			serverName: server.Name,
+			verb:       "resized",
+			target:     "off",
+			status:     opStatusActive,
+			statusText: fmt.Sprintf("Resizing %q to %s...", server.Name, serverType),
+		}, o.resizeServer(opID, server, serverType))
+	}
+
+	opID := o.nextID
+	o, cmd := o.StartToggle(server)
+	if idx := o.findOp(opID); idx >= 0 {
+		o.ops[idx].resizeTo = serverType
+	}
+	return o, cmd
+}
+
+// resizeServer fires the resize step of a resize operation.
+func (o opsOverlay) resizeServer(opID int, server domain.Server, serverType string) tea.Cmd {
+	resizer, ok := o.provider.(domain.ResizeProvider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		action, err := resizer.ResizeServer(context.Background(), server.ID, serverType, false)
+		if err != nil {
+			return opToggleErrorMsg{opID: opID, err: fmt.Errorf("failed to resize server %q: %w", server.Name, err)}
+		}
+		return opToggleInitiatedMsg{
+			opID:       opID,
+			serverID:   server.ID,
+			serverName: server.Name,
+			verb:       "resized",
+			target:     "off",
+			action:     action,
+		}
+	}
+}
+
+// StartTransfer copies a file to or from a server over SCP, reporting
+// progress as it goes. Transfers leave the server as it is, so they are
+// neither locked nor persisted.
+func (o opsOverlay) StartTransfer(server domain.Server, t fileTransfer) (opsOverlay, tea.Cmd) {
+	opID := o.nextID
+	o.nextID++
+
+	verb := "uploaded"
+	if t.download {
+		verb = "downloaded"
+	}
+	op := operation{
+		id:         opID,
+		provider:   o.providerName,
+		serverID:   server.ID,
+		serverName: server.Name,
+		verb:       verb,
+		status:     opStatusActive,
+		transfer:   make(chan tea.Msg, 1),
+		fileName:   t.name(),
+	}
+	op.statusText = op.transferText()
+
+	updates := op.transfer
+	launch := func() tea.Msg {
+		go func() {
+			err := runTransfer(context.Background(), t, func(done, total int64) {
+				// Drop updates the view has not caught up with.
+				select {
+				case updates <- opTransferProgressMsg{opID: opID, done: done, total: total}:
+				default:
+				}
+			})
+			updates <- opTransferResultMsg{opID: opID, err: err}
+		}()
+		return <-updates
+	}
+
+	return o.enqueue(op, launch)
+}
+
+// waitTransfer waits for the next update of a file transfer.
+func waitTransfer(updates chan tea.Msg) tea.Cmd {
+	return func() tea.Msg { return <-updates }
+}
+
+// transferText is the status line of a file transfer, e.g.
+// `Uploading app.tar.gz to "web" (40%)`.
+func (op operation) transferText() string {
+	text := fmt.Sprintf("Uploading %s to %q", op.fileName, op.serverName)
+	if op.verb == "downloaded" {
+		text = fmt.Sprintf("Downloading %s from %q", op.fileName, op.serverName)
+	}
+	if op.fileSize > 0 {
+		return fmt.Sprintf("%s (%d%%)", text, op.progress)
+	}
+	return text + "..."
+}
+
+// enqueue adds op to the overlay and fires launch straight away, or
+// holds it back when maxActive operations are already running.
+func (o opsOverlay) enqueue(op operation, launch tea.Cmd) (opsOverlay, tea.Cmd) {
+	if o.maxActive > 0 && o.running() >= o.maxActive {
+		op.queued = true
+		op.launch = launch
+		o.ops = append(o.ops, op)
+		return o, o.spinner.Tick
+	}
+
+	launch = o.claim(&op, launch)
+	o.ops = append(o.ops, op)
+	o.persistLaunch(op)
+	return o, tea.Batch(o.spinner.Tick, launch)
+}
+
+// startQueued launches queued operations, oldest first, while slots are
+// free.
+func (o opsOverlay) startQueued() (opsOverlay, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i := range o.ops {
+		if o.maxActive > 0 && o.running() >= o.maxActive {
+			break
+		}
+		if !o.ops[i].queued {
+			continue
+		}
+		launch := o.claim(&o.ops[i], o.ops[i].launch)
+		o.ops[i].queued = false
+		o.ops[i].launch = nil
+		o.persistLaunch(o.ops[i])
+		cmds = append(cmds, launch)
+	}
+	return o, tea.Batch(cmds...)
+}
+
+// claim locks op's server in the action store before its API call is
+// made. When another vpsm process holds the server, launch is replaced by
+// one that fails the operation with the reason.
+func (o opsOverlay) claim(op *operation, launch tea.Cmd) tea.Cmd {
+	if op.transfer != nil {
+		return launch
+	}
+	command := inferCommand(op.verb)
+	if op.verb == "deleted" {
+		command = "delete_server"
+	}
+	if err := o.svc.Lock(op.serverID, command); err != nil {
+		opID := op.id
+		return func() tea.Msg { return opToggleErrorMsg{opID: opID, err: err} }
+	}
+	op.locked = true
+	return launch
+}
+
+// releaseLocks drops the server locks held by finished operations, unless
+// another operation on the same server is still running.
+func (o opsOverlay) releaseLocks() {
+	for i := range o.ops {
+		op := &o.ops[i]
+		if !op.locked || op.status == opStatusActive {
+			continue
+		}
+		op.locked = false
+		if !o.serverBusy(op.serverID) {
+			o.svc.Unlock(op.serverID)
+		}
+	}
+}
+
+// serverBusy reports whether an operation holding serverID's lock is
+// still running.
+func (o opsOverlay) serverBusy(serverID string) bool {
+	for _, op := range o.ops {
+		if op.locked && op.serverID == serverID && op.status == opStatusActive {
+			return true
+		}
+	}
+	return false
+}
+
+// persistLaunch saves a start/stop operation as it is launched. Queued
+// operations are not persisted: there is nothing to resume until their
+// API call has been made.
+func (o *opsOverlay) persistLaunch(op operation) {
+	if op.verb == "deleted" || op.transfer != nil {
+		return
+	}
+	o.saveOp(op)
+}
+
+// running counts the operations that are in flight, excluding queued ones.
+func (o opsOverlay) running() int {
+	n := 0
+	for _, op := range o.ops {
+		if op.status == opStatusActive && !op.queued {
+			n++
+		}
+	}
+	return n
+}
+
+// deleteServer fires the delete step of a stop-then-delete operation. The
+// server's configuration, and the DNS records pointing at it, are
+// archived once the delete succeeds.
+func (o opsOverlay) deleteServer(opID int, serverID string) tea.Cmd {
+	provider, providerName := o.provider, o.providerName
+	return func() tea.Msg {
+		archived, _ := provider.GetServer(context.Background(), serverID)
+		err := provider.DeleteServer(context.Background(), serverID)
+		if err == nil && archived != nil {
+			dns, _ := dnsProviderFor(providerName)
+			archivesvc.ArchiveDeleted(context.Background(), providerName, *archived, dns)
+		}
+		return opDeleteResultMsg{opID: opID, err: err}
+	}
 }
 
 // --- Update ---
 
 // Update processes overlay-related messages and returns the updated
 // overlay, a tea.Cmd, and a slice of completed outcomes for the parent
-// model to act on (e.g. refresh server list).
+// model to act on (e.g. refresh server list). Queued operations are
+// launched as soon as earlier ones free up a slot, and finished ones give
+// up their server locks.
 func (o opsOverlay) Update(msg tea.Msg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
+	o, cmd, events := o.update(msg)
+	o.releaseLocks()
+	o, launch := o.startQueued()
+	return o, tea.Batch(cmd, launch), events
+}
+
+func (o opsOverlay) update(msg tea.Msg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
 	switch msg := msg.(type) {
 	case opToggleInitiatedMsg:
 		return o.handleInitiated(msg)
@@ -412,6 +984,12 @@ func (o opsOverlay) Update(msg tea.Msg) (opsOverlay, tea.Cmd, []opCompletedEvent
 		return o.handlePollError(msg)
 	case opDismissMsg:
 		return o.handleDismiss(msg)
+	case opDeleteResultMsg:
+		return o.handleDeleteResult(msg)
+	case opTransferProgressMsg:
+		return o.handleTransferProgress(msg)
+	case opTransferResultMsg:
+		return o.handleTransferResult(msg)
 	case spinner.TickMsg:
 		if o.HasActive() {
 			var cmd tea.Cmd
@@ -437,6 +1015,8 @@ func (o opsOverlay) handleInitiated(msg opToggleInitiatedMsg) (opsOverlay, tea.C
 	if action != nil && action.ID != "" {
 		op.actionID = action.ID
 	}
+	op.started = time.Now()
+	op.typical = o.svc.TypicalDuration(inferCommand(op.verb))
 
 	// Fast path: action completed synchronously — verify server status.
 	if action != nil && action.Status == domain.ActionStatusSuccess {
@@ -469,7 +1049,7 @@ func (o opsOverlay) handleInitiated(msg opToggleInitiatedMsg) (opsOverlay, tea.C
 	} else {
 		op.pollMode = opPollModeServer
 	}
-	op.statusText = fmt.Sprintf("%s %q...", verbToGerund(op.verb), op.serverName)
+	op.statusText = op.runningText()
 	o.ops[idx] = op
 	o.saveOp(op)
 	return o, scheduleOpPollTick(op.id), nil
@@ -522,7 +1102,37 @@ func (o opsOverlay) handlePollResult(msg opPollResultMsg) (opsOverlay, tea.Cmd,
 			o.saveOp(op)
 			return o, scheduleOpPollTick(op.id), nil
 		}
-		// Server reached target status — success.
+		// Server reached target status — delete it next if requested.
+		if op.thenDelete {
+			op.thenDelete = false
+			stopped := op
+			stopped.status = opStatusSucceeded
+			o.saveOp(stopped)
+			op.verb = "deleted"
+			op.progress = 0
+			op.statusText = fmt.Sprintf("Deleting %q...", op.serverName)
+			o.ops[idx] = op
+			return o, o.deleteServer(op.id, op.serverID), nil
+		}
+		// Server is off — resize it next if requested.
+		if op.resizeTo != "" {
+			serverType := op.resizeTo
+			op.resizeTo = ""
+			stopped := op
+			stopped.status = opStatusSucceeded
+			o.saveOp(stopped)
+			op.verb = "resized"
+			op.dbID = 0
+			op.actionID = ""
+			op.pollMode = ""
+			op.pollCount = 0
+			op.progress = 0
+			op.statusText = fmt.Sprintf("Resizing %q to %s...", op.serverName, serverType)
+			o.ops[idx] = op
+			o.saveOp(op)
+			server := domain.Server{ID: op.serverID, Name: op.serverName}
+			return o, o.resizeServer(op.id, server, serverType), nil
+		}
 		op.status = opStatusSucceeded
 		op.statusText = fmt.Sprintf("%q %s", op.serverName, op.verb)
 		op.progress = 100
@@ -530,8 +1140,10 @@ func (o opsOverlay) handlePollResult(msg opPollResultMsg) (opsOverlay, tea.Cmd,
 		o.saveOp(op)
 		return o, scheduleDismiss(op.id), []opCompletedEvent{{
 			Success:    true,
+			ServerID:   op.serverID,
 			ServerName: op.serverName,
 			Verb:       op.verb,
+			Undo:       op.undo,
 		}}
 
 	case domain.ActionStatusError:
@@ -562,10 +1174,8 @@ func (o opsOverlay) handlePollResult(msg opPollResultMsg) (opsOverlay, tea.Cmd,
 
 		if status.Progress > 0 {
 			op.progress = status.Progress
-			op.statusText = fmt.Sprintf("%s %q (%d%%)", verbToGerund(op.verb), op.serverName, status.Progress)
-		} else {
-			op.statusText = fmt.Sprintf("%s %q...", verbToGerund(op.verb), op.serverName)
 		}
+		op.statusText = op.runningText()
 		o.ops[idx] = op
 		o.saveOp(op)
 		return o, scheduleOpPollTick(op.id), nil
@@ -608,6 +1218,76 @@ func (o opsOverlay) handlePollError(msg opPollErrorMsg) (opsOverlay, tea.Cmd, []
 	return o, scheduleOpPollTick(op.id), nil
 }
 
+func (o opsOverlay) handleDeleteResult(msg opDeleteResultMsg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
+	idx := o.findOp(msg.opID)
+	if idx < 0 {
+		return o, nil, nil
+	}
+	op := o.ops[idx]
+	if msg.err != nil {
+		op.status = opStatusFailed
+		op.statusText = "Failed: " + msg.err.Error()
+		o.ops[idx] = op
+		return o, scheduleDismiss(op.id), []opCompletedEvent{{
+			ErrText: fmt.Sprintf("Failed to delete server %q: %v", op.serverName, msg.err),
+		}}
+	}
+	op.status = opStatusSucceeded
+	op.statusText = fmt.Sprintf("%q deleted", op.serverName)
+	op.progress = 100
+	o.ops[idx] = op
+	return o, scheduleDismiss(op.id), []opCompletedEvent{{
+		Success:    true,
+		ServerID:   op.serverID,
+		ServerName: op.serverName,
+		Verb:       op.verb,
+	}}
+}
+
+func (o opsOverlay) handleTransferProgress(msg opTransferProgressMsg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
+	idx := o.findOp(msg.opID)
+	if idx < 0 {
+		return o, nil, nil
+	}
+	op := o.ops[idx]
+	op.transferred = msg.done
+	op.fileSize = msg.total
+	if msg.total > 0 {
+		op.progress = int(msg.done * 100 / msg.total)
+	}
+	op.statusText = op.transferText()
+	o.ops[idx] = op
+	return o, waitTransfer(op.transfer), nil
+}
+
+func (o opsOverlay) handleTransferResult(msg opTransferResultMsg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
+	idx := o.findOp(msg.opID)
+	if idx < 0 {
+		return o, nil, nil
+	}
+	op := o.ops[idx]
+	if msg.err != nil {
+		op.status = opStatusFailed
+		op.statusText = "Failed: " + msg.err.Error()
+		o.ops[idx] = op
+		return o, scheduleDismiss(op.id), []opCompletedEvent{{
+			ErrText: fmt.Sprintf("Failed to copy %s: %v", op.fileName, msg.err),
+		}}
+	}
+	op.status = opStatusSucceeded
+	op.statusText = fmt.Sprintf("%s %s", op.fileName, op.verb)
+	op.progress = 100
+	op.transferred = op.fileSize
+	o.ops[idx] = op
+	return o, scheduleDismiss(op.id), []opCompletedEvent{{
+		Success:    true,
+		ServerID:   op.serverID,
+		ServerName: op.serverName,
+		Verb:       op.statusText,
+		Unchanged:  true,
+	}}
+}
+
 func (o opsOverlay) handleDismiss(msg opDismissMsg) (opsOverlay, tea.Cmd, []opCompletedEvent) {
 	idx := o.findOp(msg.opID)
 	if idx < 0 {
@@ -715,7 +1395,7 @@ func (o opsOverlay) View(width, height int) string {
 		Bold(true)
 
 	card := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border).
 		BorderForeground(styles.DimGray).
 		Padding(0, 1).
 		Width(cardWidth).
@@ -724,26 +1404,66 @@ func (o opsOverlay) View(width, height int) string {
 	return card
 }
 
+// PlainView lists the operations on one line for the accessible
+// renderer, which has no floating panel, e.g.
+// `Operations: Stopping "web"...; "db" started`.
+func (o opsOverlay) PlainView() string {
+	texts := make([]string, 0, len(o.ops))
+	for _, op := range o.ops {
+		text := op.statusText
+		if op.queued {
+			text = "Queued: " + text
+		}
+		texts = append(texts, text)
+	}
+	return "Operations: " + strings.Join(texts, "; ")
+}
+
 // renderOpLine renders a single operation line with an appropriate
 // icon/spinner prefix.
 func (o opsOverlay) renderOpLine(op operation) string {
 	// Truncate status text to fit the card.
 	maxTextWidth := overlayMaxWidth - 6 // icon + spacing + border/padding
 	text := op.statusText
+	if op.queued {
+		text = "Queued: " + text
+	}
 	if lipgloss.Width(text) > maxTextWidth {
-		text = ansi.Truncate(text, maxTextWidth-1, "…")
+		text = ansi.Truncate(text, maxTextWidth-1, styles.Ellipsis())
+	}
+
+	if op.queued {
+		return styles.MutedText.Render(styles.Ellipsis() + " " + text)
 	}
 
 	switch op.status {
 	case opStatusSucceeded:
-		icon := lipgloss.NewStyle().Foreground(styles.Green).Render("✓")
+		icon := lipgloss.NewStyle().Foreground(styles.Green).Render(styles.Check())
 		return icon + " " + lipgloss.NewStyle().Foreground(styles.Green).Render(text)
 	case opStatusFailed:
-		icon := lipgloss.NewStyle().Foreground(styles.Red).Render("✗")
+		icon := lipgloss.NewStyle().Foreground(styles.Red).Render(styles.Cross())
 		return icon + " " + lipgloss.NewStyle().Foreground(styles.Red).Render(text)
 	default:
-		return o.spinner.View() + " " + lipgloss.NewStyle().Foreground(styles.White).Render(text)
+		line := o.spinner.View() + " " + lipgloss.NewStyle().Foreground(styles.White).Render(text)
+		if op.transfer != nil && op.fileSize > 0 {
+			line += "\n  " + renderTransferBar(op, maxTextWidth)
+		}
+		return line
+	}
+}
+
+// renderTransferBar renders a file transfer's progress bar followed by
+// the bytes copied, e.g. "████░░░░ 1.2 MB/3.0 MB", in width cells.
+func renderTransferBar(op operation, width int) string {
+	counts := " " + formatBytes(op.transferred) + "/" + formatBytes(op.fileSize)
+	barWidth := width - len(counts)
+	if barWidth < 4 {
+		return styles.MutedText.Render(strings.TrimSpace(counts))
 	}
+	filled := barWidth * op.progress / 100
+	bar := lipgloss.NewStyle().Foreground(styles.Blue).Render(strings.Repeat(styles.BarFilled(), filled)) +
+		styles.MutedText.Render(strings.Repeat(styles.BarEmpty(), barWidth-filled))
+	return bar + styles.MutedText.Render(counts)
 }
 
 // --- Overlay compositing ---
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/actionstore"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/action"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestOperation_RunningText(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		op   operation
+		want string
+	}{
+		{"no history", operation{verb: "stopped", serverName: "web"}, `Stopping "web"...`},
+		{"percent only", operation{verb: "stopped", serverName: "web", progress: 40}, `Stopping "web" (40%)`},
+		{"eta", operation{verb: "stopped", serverName: "web", started: now, typical: 25 * time.Second}, `Stopping "web", usually ~25s`},
+		{"percent and eta", operation{verb: "started", serverName: "web", progress: 40, started: now, typical: 25 * time.Second}, `Starting "web" (40%, usually ~25s)`},
+		{"overdue", operation{verb: "stopped", serverName: "web", started: now.Add(-time.Minute), typical: 25 * time.Second}, `Stopping "web", usually ~25s, taking longer than usual`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.op.runningText(); got != tt.want {
+				t.Errorf("runningText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpsOverlay_PollKeepsETAWhileProgressIsZero(t *testing.T) {
+	o := opsOverlay{providerName: "mock", ops: []operation{{
+		verb:       "stopped",
+		serverName: "web",
+		status:     opStatusActive,
+		pollMode:   opPollModeAction,
+		started:    time.Now(),
+		typical:    25 * time.Second,
+	}}}
+
+	o, _, _ = o.handlePollResult(opPollResultMsg{opID: 0, action: &domain.ActionStatus{Status: domain.ActionStatusRunning}})
+	if got := o.ops[0].statusText; got != `Stopping "web", usually ~25s` {
+		t.Errorf("statusText = %q", got)
+	}
+}
+
+func TestOpsOverlay_ServerLockedByAnotherProcess(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	repo, err := actionstore.OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	// The test binary's parent process is alive for the whole test.
+	if _, err := db.Exec(`INSERT INTO server_locks (provider, server_id, command, pid, acquired_at) VALUES (?, ?, ?, ?, ?)`,
+		"mock", "1", "delete_server", os.Getppid(), time.Now().UTC().Format(time.RFC3339Nano)); err != nil {
+		t.Fatalf("insert lock failed: %v", err)
+	}
+
+	provider := &rebootProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock", svc: action.NewService(provider, "mock", repo)}
+	o, cmd := o.StartReboot(domain.Server{ID: "1", Name: "web-1", Status: "running"})
+	if o.ops[0].locked {
+		t.Fatal("expected the operation not to hold the lock")
+	}
+
+	var msg opToggleErrorMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if m, ok := c().(opToggleErrorMsg); ok {
+			msg = m
+		}
+	}
+	if msg.err == nil || !strings.Contains(msg.err.Error(), "delete_server") {
+		t.Fatalf("expected busy error naming delete_server, got %v", msg.err)
+	}
+	o, _, events := o.Update(msg)
+	if o.ops[0].status != opStatusFailed || len(events) != 1 {
+		t.Errorf("expected failed op with one event, got %+v, %+v", o.ops[0], events)
+	}
+	if provider.rebooted != "" {
+		t.Errorf("expected no reboot, got %q", provider.rebooted)
+	}
+}
+
+func TestOpsOverlay_ReleasesLockWhenDone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	repo, err := actionstore.OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	provider := &rebootProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock", svc: action.NewService(provider, "mock", repo)}
+	o, _ = o.StartReboot(domain.Server{ID: "1", Name: "web-1", Status: "running"})
+	if !o.ops[0].locked {
+		t.Fatal("expected the operation to hold the lock")
+	}
+	o.ops[0].pollMode = opPollModeServer
+
+	o, _, _ = o.Update(opPollResultMsg{opID: 0, action: &domain.ActionStatus{Status: domain.ActionStatusSuccess}})
+	if o.ops[0].locked {
+		t.Error("expected the lock to be released")
+	}
+	var n int
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("sql.Open failed: %v", err)
+	}
+	defer db.Close()
+	if err := db.QueryRow(`SELECT COUNT(*) FROM server_locks`).Scan(&n); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected no locks left, got %d", n)
+	}
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// prefetchDelay is how long a list row must stay selected before its
+	// detail and metrics are prefetched. Scrolling past rows quickly
+	// fetches nothing.
+	prefetchDelay = 300 * time.Millisecond
+
+	// prefetchTTL is how long a prefetched server is used by the show view
+	// and how long before the same server is prefetched again.
+	prefetchTTL = 30 * time.Second
+)
+
+// hoverTickMsg fires prefetchDelay after the list cursor moved to
+// serverID. seq tells stale ticks from the current one.
+type hoverTickMsg struct {
+	serverID string
+	seq      int
+}
+
+// requestPrefetchMsg asks the app to prefetch server's detail and metrics.
+type requestPrefetchMsg struct {
+	server domain.Server
+}
+
+// serverPrefetchedMsg carries a prefetched server detail.
+type serverPrefetchedMsg struct {
+	server *domain.Server
+}
+
+// scheduleHoverPrefetch starts the hover timer when the selected server
+// differs from prevID.
+func (m serverListModel) scheduleHoverPrefetch(prevID string) (serverListModel, tea.Cmd) {
+	if !m.embedded || m.picker || len(m.servers) == 0 {
+		return m, nil
+	}
+	id := m.servers[m.cursor].ID
+	if id == prevID {
+		return m, nil
+	}
+	m.hoverSeq++
+	seq := m.hoverSeq
+	return m, tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return hoverTickMsg{serverID: id, seq: seq}
+	})
+}
+
+// selectedID returns the ID of the server under the cursor, or "".
+func (m serverListModel) selectedID() string {
+	if m.cursor < 0 || m.cursor >= len(m.servers) {
+		return ""
+	}
+	return m.servers[m.cursor].ID
+}
+
+// prefetchCache holds server details prefetched from the list, so the
+// show view can open with fresh data. Like deletionTracker it is shared by
+// pointer; a nil cache holds nothing.
+type prefetchCache struct {
+	entries map[string]prefetchEntry
+	now     func() time.Time
+}
+
+type prefetchEntry struct {
+	server    *domain.Server
+	fetchedAt time.Time
+}
+
+func newPrefetchCache() *prefetchCache {
+	return &prefetchCache{entries: make(map[string]prefetchEntry), now: time.Now}
+}
+
+// Put stores a prefetched server.
+func (c *prefetchCache) Put(server *domain.Server) {
+	if c == nil || server == nil {
+		return
+	}
+	c.entries[server.ID] = prefetchEntry{server: server, fetchedAt: c.now()}
+}
+
+// Get returns the server prefetched within prefetchTTL, if any.
+func (c *prefetchCache) Get(serverID string) (*domain.Server, bool) {
+	if c == nil {
+		return nil, false
+	}
+	e, ok := c.entries[serverID]
+	if !ok || c.now().Sub(e.fetchedAt) >= prefetchTTL {
+		return nil, false
+	}
+	return e.server, true
+}
+
+// Forget drops serverID, e.g. after an operation changed it.
+func (c *prefetchCache) Forget(serverID string) {
+	if c == nil {
+		return
+	}
+	delete(c.entries, serverID)
+}
+
+// prefetchServer fetches server's detail and warms the metrics scheduler's
+// cache with the range the show view requests. Without a scheduler
+// metrics are not cached, so they are left for the show view.
+func prefetchServer(provider domain.Provider, metrics domain.MetricsProvider, server domain.Server) tea.Cmd {
+	detail := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s, err := provider.GetServer(ctx, server.ID)
+		if err != nil {
+			// The show view fetches and reports errors itself.
+			return nil
+		}
+		return serverPrefetchedMsg{server: s}
+	}
+	if metrics == nil {
+		return detail
+	}
+	warm := func() tea.Msg {
+		end := time.Now()
+		metrics.GetServerMetrics(context.Background(), server.ID, []domain.MetricType{
+			domain.MetricCPU,
+			domain.MetricDisk,
+			domain.MetricNetwork,
+			domain.MetricMemory,
+		}, end.Add(-1*time.Hour), end)
+		return nil
+	}
+	return tea.Batch(detail, warm)
+}
+
+// handlePrefetchRequest prefetches msg.server unless a fresh copy is
+// already cached.
+func (m serverAppModel) handlePrefetchRequest(msg requestPrefetchMsg) (tea.Model, tea.Cmd) {
+	if _, ok := m.prefetched.Get(msg.server.ID); ok {
+		return m, nil
+	}
+	return m, prefetchServer(m.provider, m.metrics, msg.server)
+}
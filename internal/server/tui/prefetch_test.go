@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// prefetchProvider counts GetServer calls.
+type prefetchProvider struct {
+	reauthProvider
+	gets int
+}
+
+func (p *prefetchProvider) GetServer(_ context.Context, id string) (*domain.Server, error) {
+	p.gets++
+	return &domain.Server{ID: id, Name: "web-" + id, Status: "running", PublicIPv4: "192.0.2.10"}, nil
+}
+
+func TestServerList_HoverSchedulesPrefetchForLatestRow(t *testing.T) {
+	m := bulkTestList()
+
+	m, cmd := pressKey(t, m, "j")
+	if cmd == nil {
+		t.Fatal("expected a hover timer after moving the cursor")
+	}
+	first := m.hoverSeq
+	m, _ = pressKey(t, m, "j")
+
+	// The tick for the row the cursor already left is ignored.
+	updated, cmd := m.Update(hoverTickMsg{serverID: "2", seq: first})
+	if cmd != nil {
+		t.Error("expected a stale hover tick to be ignored")
+	}
+	m = updated.(serverListModel)
+
+	_, cmd = m.Update(hoverTickMsg{serverID: "3", seq: m.hoverSeq})
+	if cmd == nil {
+		t.Fatal("expected a prefetch request for the resting row")
+	}
+	req, ok := cmd().(requestPrefetchMsg)
+	if !ok || req.server.ID != "3" {
+		t.Errorf("unexpected prefetch request %+v", req)
+	}
+}
+
+func TestServerApp_ShowUsesPrefetchedServer(t *testing.T) {
+	provider := &prefetchProvider{}
+	m := newReauthTestApp()
+	m.provider = provider
+	m.prefetched = newPrefetchCache()
+
+	_, cmd := m.handlePrefetchRequest(requestPrefetchMsg{server: domain.Server{ID: "1"}})
+	if cmd == nil {
+		t.Fatal("expected a prefetch command")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(serverAppModel)
+
+	// A fresh entry is not fetched again.
+	if _, cmd := m.handlePrefetchRequest(requestPrefetchMsg{server: domain.Server{ID: "1"}}); cmd != nil {
+		t.Error("expected a cached server not to be prefetched again")
+	}
+
+	updated, _ = m.switchToShow(domain.Server{ID: "1", Name: "web-1", Status: "off"})
+	if got := updated.(serverAppModel).show.server; got.Status != "running" || got.PublicIPv4 != "192.0.2.10" {
+		t.Errorf("expected the show view to open with the prefetched server, got %+v", got)
+	}
+	if provider.gets != 1 {
+		t.Errorf("expected one GetServer call, got %d", provider.gets)
+	}
+}
+
+func TestPrefetchCache_Expires(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	c := newPrefetchCache()
+	c.now = func() time.Time { return now }
+
+	c.Put(&domain.Server{ID: "1"})
+	if _, ok := c.Get("1"); !ok {
+		t.Fatal("expected a fresh entry")
+	}
+	now = now.Add(prefetchTTL)
+	if _, ok := c.Get("1"); ok {
+		t.Error("expected the entry to expire")
+	}
+
+	c.Put(&domain.Server{ID: "2"})
+	c.Forget("2")
+	if _, ok := c.Get("2"); ok {
+		t.Error("expected Forget to drop the entry")
+	}
+}
@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// loadProjects returns the projects with a stored token for providerName.
+// Tests replace it.
+var loadProjects = func(providerName string) []string {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.ProjectsFor(providerName)
+}
+
+// connectProject builds a provider that uses project's stored token, then
+// makes project the session's and the provider's active one. Tests
+// replace it.
+var connectProject = func(providerName, project string) (domain.Provider, error) {
+	store := auth.WithProject(auth.NewKeyringStore(auth.ServiceName), project)
+	provider, err := providers.Get(providerName, store)
+	if err != nil {
+		return nil, err
+	}
+	auth.UseProject(project)
+	if cfg, err := config.Load(); err == nil {
+		cfg.SetActiveProject(providerName, project)
+		_ = cfg.Save()
+	}
+	return provider, nil
+}
+
+// projectPicker lists the provider's projects so the session can switch
+// to another project's token.
+type projectPicker struct {
+	projects []string // "" is the token stored without a project
+	cursor   int
+	err      string
+}
+
+// projectName is how a picker entry is shown.
+func projectName(project string) string {
+	if project == "" {
+		return "default"
+	}
+	return project
+}
+
+// openProjectPicker shows the project picker over the list.
+func (m serverAppModel) openProjectPicker() (tea.Model, tea.Cmd) {
+	picker := &projectPicker{projects: append([]string{""}, loadProjects(m.providerName)...)}
+	for i, project := range picker.projects {
+		if project == m.project {
+			picker.cursor = i
+		}
+	}
+	m.projects = picker
+	return m, nil
+}
+
+func (m serverAppModel) updateProjectPicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	picker := *m.projects
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc", "p":
+		m.projects = nil
+		return m, nil
+
+	case "up", "k":
+		if picker.cursor > 0 {
+			picker.cursor--
+		}
+
+	case "down", "j":
+		if picker.cursor < len(picker.projects)-1 {
+			picker.cursor++
+		}
+
+	case "enter":
+		project := picker.projects[picker.cursor]
+		if project == m.project {
+			m.projects = nil
+			return m, nil
+		}
+		provider, err := connectProject(m.providerName, project)
+		if err != nil {
+			picker.err = fmt.Sprintf("Failed to switch to %s: %v", projectName(project), err)
+			break
+		}
+		m.projects = nil
+		m.project = project
+		m.provider = provider
+		m.overlay.provider = provider
+		m.metrics = newMetricsScheduler(provider)
+		return m.switchToList()
+	}
+
+	m.projects = &picker
+	return m, nil
+}
+
+// renderProjectPicker renders the project picker in place of the active view.
+func (m serverAppModel) renderProjectPicker() string {
+	picker := m.projects
+	header := components.Header(m.width, "project", m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "select"},
+		{Key: "esc", Desc: "close"},
+	})
+
+	lines := []string{styles.Title.Render("Switch project"), ""}
+	for i, project := range picker.projects {
+		prefix := "  "
+		name := projectName(project)
+		if i == picker.cursor {
+			prefix = styles.AccentText.Render("> ")
+			name = styles.Value.Render(name)
+		}
+		if project == m.project {
+			name += styles.MutedText.Render("  (current)")
+		}
+		lines = append(lines, prefix+name)
+	}
+	if picker.err != "" {
+		lines = append(lines, "", styles.ErrorText.Render(picker.err))
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
+
+// projectDivider renders the header's divider line with the session's
+// project at its right end.
+func projectDivider(width int, project string) string {
+	label := styles.AccentText.Render(" project: " + project + " ")
+	fill := width - lipgloss.Width(label) - 2
+	if fill < 1 {
+		return ""
+	}
+	line := lipgloss.NewStyle().Foreground(styles.DimGray)
+	return line.Render(strings.Repeat(styles.HLine(), fill)) + label + line.Render(strings.Repeat(styles.HLine(), 2))
+}
+
+// indexKey names the session's resources in the tag index; each project
+// is indexed separately.
+func (m serverAppModel) indexKey() string {
+	return auth.ProjectKey(m.providerName, m.project)
+}
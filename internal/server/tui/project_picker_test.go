@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func stubProjects(t *testing.T, projects []string, connect func(providerName, project string) (domain.Provider, error)) {
+	t.Helper()
+	origLoad, origConnect := loadProjects, connectProject
+	loadProjects = func(string) []string { return projects }
+	connectProject = connect
+	t.Cleanup(func() { loadProjects, connectProject = origLoad, origConnect })
+}
+
+func TestProjectPicker_SwitchesProject(t *testing.T) {
+	staging := &reauthProvider{name: "Staging"}
+	var connected string
+	stubProjects(t, []string{"prod", "staging"}, func(_, project string) (domain.Provider, error) {
+		connected = project
+		return staging, nil
+	})
+
+	m := newReauthTestApp()
+	m.list = newServerListModel(m.provider, m.providerName)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(serverAppModel)
+	if m.projects == nil {
+		t.Fatal("expected p to open the project picker")
+	}
+
+	// default, prod, staging: move to staging.
+	for range 2 {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = updated.(serverAppModel)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app := updated.(serverAppModel)
+
+	if connected != "staging" || app.project != "staging" {
+		t.Fatalf("expected to switch to staging, connected %q, project %q", connected, app.project)
+	}
+	if app.provider != domain.Provider(staging) {
+		t.Error("expected the session to use the staging provider")
+	}
+	if app.projects != nil || app.view != appViewList {
+		t.Error("expected the picker to close on the list")
+	}
+	if !strings.Contains(app.windowTitle(), "(staging)") {
+		t.Errorf("expected the window title to name the project, got %q", app.windowTitle())
+	}
+	if !strings.Contains(projectDivider(80, app.project), "project: staging") {
+		t.Error("expected the header divider to name the project")
+	}
+}
+
+func TestProjectPicker_ConnectErrorKeepsSession(t *testing.T) {
+	stubProjects(t, []string{"prod"}, func(string, string) (domain.Provider, error) {
+		return nil, errors.New("token not found")
+	})
+
+	m := newReauthTestApp()
+	m.list = newServerListModel(m.provider, m.providerName)
+	original := m.provider
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	updated, _ = updated.(serverAppModel).Update(tea.KeyMsg{Type: tea.KeyDown})
+	updated, _ = updated.(serverAppModel).Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app := updated.(serverAppModel)
+
+	if app.provider != original || app.project != "" {
+		t.Error("expected the session to keep its provider")
+	}
+	if app.projects == nil || !strings.Contains(app.projects.err, "token not found") {
+		t.Errorf("expected the picker to show the error, got %+v", app.projects)
+	}
+}
+
+func TestProjectPicker_NotOfferedWithoutProjects(t *testing.T) {
+	stubProjects(t, nil, nil)
+
+	m := newReauthTestApp()
+	m.list = newServerListModel(m.provider, m.providerName)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	if updated.(serverAppModel).projects != nil {
+		t.Error("expected no project picker without projects")
+	}
+}
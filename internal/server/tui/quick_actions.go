@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// quickAction is an entry in the action strip shown under the server list
+// for the selected row. Its number key runs it.
+type quickAction struct {
+	label string
+	run   func(m serverListModel, server domain.Server) (tea.Model, tea.Cmd)
+}
+
+// quickActions returns the actions offered for server, in strip order.
+// Actions the server cannot take right now (e.g. ssh while it is off) are
+// left out so the numbers always match what is shown.
+func (m serverListModel) quickActions(server domain.Server) []quickAction {
+	var actions []quickAction
+	if m.embedded && server.Status == "running" && (server.PublicIPv4 != "" || server.PublicIPv6 != "") {
+		actions = append(actions, quickAction{label: "ssh", run: func(m serverListModel, s domain.Server) (tea.Model, tea.Cmd) {
+			return m, func() tea.Msg { return navigateToSSHMsg{server: s} }
+		}})
+	}
+	switch server.Status {
+	case "running":
+		actions = append(actions, quickAction{label: "stop", run: runListKey("s")})
+	case "off", "stopped":
+		actions = append(actions, quickAction{label: "start", run: runListKey("s")})
+	}
+	actions = append(actions, quickAction{label: "metrics", run: runListKey("enter")})
+	if m.embedded && (server.PublicIPv4 != "" || server.PublicIPv6 != "") {
+		actions = append(actions, quickAction{label: "dns", run: func(m serverListModel, s domain.Server) (tea.Model, tea.Cmd) {
+			return m, func() tea.Msg { return navigateToDNSAttachMsg{server: s} }
+		}})
+	}
+	actions = append(actions, quickAction{label: "delete", run: runListKey("d")})
+	return actions
+}
+
+// runListKey returns a quick action that behaves like pressing key in the
+// list, so the strip and the single-letter bindings cannot drift apart.
+func runListKey(key string) func(serverListModel, domain.Server) (tea.Model, tea.Cmd) {
+	return func(m serverListModel, _ domain.Server) (tea.Model, tea.Cmd) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if key == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		return m.handleKey(msg)
+	}
+}
+
+// runQuickAction runs the selected row's action numbered key ("1" is the
+// first). It reports false when key is not a listed action.
+func (m serverListModel) runQuickAction(key string) (tea.Model, tea.Cmd, bool) {
+	n, err := strconv.Atoi(key)
+	if err != nil || len(m.servers) == 0 {
+		return m, nil, false
+	}
+	server := m.servers[m.cursor]
+	actions := m.quickActions(server)
+	if n < 1 || n > len(actions) {
+		return m, nil, false
+	}
+	updated, cmd := actions[n-1].run(m, server)
+	return updated, cmd, true
+}
+
+// renderQuickActions renders the action strip for the selected row, or ""
+// when no row is selectable.
+func (m serverListModel) renderQuickActions() string {
+	if m.loading || m.err != nil || len(m.servers) == 0 {
+		return ""
+	}
+	server := m.servers[m.cursor]
+	actions := m.quickActions(server)
+	subject := server.Name + ":"
+	if marked := m.markedServers(); len(marked) > 0 {
+		actions = m.bulkActions()
+		subject = i18n.T("%d marked:", len(marked))
+	}
+
+	parts := make([]string, len(actions))
+	for i, a := range actions {
+		parts[i] = styles.FormatKeyBinding(strconv.Itoa(i+1), i18n.T(a.label))
+	}
+	strip := styles.MutedText.Render(subject) + "  " + strings.Join(parts, styles.KeySepStyle.Render("  "))
+	return lipgloss.NewStyle().Width(m.width).Padding(0, 2).Render(strip)
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func quickActionList(servers ...domain.Server) serverListModel {
+	m := newServerListModel(&reauthProvider{name: "Mock"}, "mock")
+	m.embedded = true
+	m.loading = false
+	m.servers = servers
+	m.width, m.height = 100, 24
+	return m
+}
+
+func quickActionLabels(actions []quickAction) string {
+	labels := make([]string, len(actions))
+	for i, a := range actions {
+		labels[i] = a.label
+	}
+	return strings.Join(labels, ",")
+}
+
+func TestQuickActions_DependOnStatus(t *testing.T) {
+	m := quickActionList()
+
+	running := domain.Server{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"}
+	if got := quickActionLabels(m.quickActions(running)); got != "ssh,stop,metrics,dns,delete" {
+		t.Errorf("running actions = %q", got)
+	}
+	off := domain.Server{ID: "2", Name: "db-1", Status: "off", PublicIPv4: "192.0.2.2"}
+	if got := quickActionLabels(m.quickActions(off)); got != "start,metrics,dns,delete" {
+		t.Errorf("off actions = %q", got)
+	}
+}
+
+func TestQuickActions_NumberKeys(t *testing.T) {
+	server := domain.Server{ID: "1", Name: "web-1", Status: "running", PublicIPv4: "192.0.2.1"}
+	m := quickActionList(server)
+
+	tests := []struct {
+		key  string
+		want tea.Msg
+	}{
+		{"1", navigateToSSHMsg{server: server}},
+		{"2", requestToggleMsg{server: server}},
+		{"3", navigateToShowMsg{server: server}},
+		{"4", navigateToDNSAttachMsg{server: server}},
+		{"5", navigateToDeleteMsg{server: server}},
+	}
+	for _, tt := range tests {
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+		if cmd == nil {
+			t.Fatalf("key %s: expected a command", tt.key)
+		}
+		if got := cmd(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("key %s: got %#v, want %#v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestQuickActions_DNSNeedsPublicIP(t *testing.T) {
+	m := quickActionList()
+
+	private := domain.Server{ID: "3", Name: "internal-1", Status: "off", PrivateIPv4: "10.0.0.3"}
+	if got := quickActionLabels(m.quickActions(private)); got != "start,metrics,delete" {
+		t.Errorf("actions without a public IP = %q, want no dns", got)
+	}
+	m.embedded = false
+	public := domain.Server{ID: "4", Name: "web-2", Status: "off", PublicIPv4: "192.0.2.4"}
+	if got := quickActionLabels(m.quickActions(public)); got != "start,metrics,delete" {
+		t.Errorf("standalone actions = %q, want no dns", got)
+	}
+}
+
+func TestQuickActions_Rendered(t *testing.T) {
+	m := quickActionList(domain.Server{ID: "1", Name: "web-1", Status: "off"})
+
+	view := m.View()
+	if !strings.Contains(view, "web-1:") || !strings.Contains(view, "start") || !strings.Contains(view, "metrics") {
+		t.Errorf("expected the action strip in the list view, got:\n%s", view)
+	}
+}
+
+func TestServerList_InterruptibleBadge(t *testing.T) {
+	m := quickActionList(
+		domain.Server{ID: "1", Name: "web-1", Status: "running"},
+		domain.Server{ID: "2", Name: "batch-1", Status: "running", Interruptible: true},
+	)
+
+	view := m.View()
+	if got := strings.Count(view, interruptibleBadge); got != 1 {
+		t.Errorf("expected one %s badge, got %d:\n%s", interruptibleBadge, got, view)
+	}
+}
+func TestServerList_PickerSelectsAndDisablesActions(t *testing.T) {
+	m := serverListModel{
+		picker:  true,
+		servers: []domain.Server{{ID: "1", Name: "web-1", Status: "running"}},
+		width:   100,
+		height:  30,
+	}
+
+	updated, cmd := m.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	if cmd != nil || updated.(serverListModel).selectedServer != nil {
+		t.Fatalf("expected delete to be disabled in picker mode")
+	}
+
+	updated, cmd = m.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	got := updated.(serverListModel)
+	if got.selectedServer == nil || got.selectedServer.ID != "1" || got.action != "pick" {
+		t.Fatalf("expected server 1 picked, got %+v / %q", got.selectedServer, got.action)
+	}
+	if cmd == nil {
+		t.Error("expected the picker to quit after selection")
+	}
+	if view := m.View(); !strings.Contains(view, "select") || strings.Contains(view, "start/stop") {
+		t.Errorf("expected picker footer, got:\n%s", view)
+	}
+}
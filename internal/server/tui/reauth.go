@@ -0,0 +1,273 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reauthCheckTimeout bounds the request used to verify a new token.
+const reauthCheckTimeout = 15 * time.Second
+
+// reconnectProvider builds a provider that uses token instead of the
+// stored one. Tests replace it.
+var reconnectProvider = func(providerName, token string) (domain.Provider, error) {
+	return providers.Get(providerName, sessionStore{token: token})
+}
+
+// saveToken persists a verified token. Tests replace it.
+var saveToken = func(providerName, token string) error {
+	return auth.DefaultStore().SetToken(providerName, token)
+}
+
+// sessionStore is an auth.Store holding one unsaved token, so a
+// replacement can be verified before it overwrites the stored one.
+type sessionStore struct {
+	token string
+}
+
+func (s sessionStore) SetToken(string, string) error   { return nil }
+func (s sessionStore) GetToken(string) (string, error) { return s.token, nil }
+func (s sessionStore) DeleteToken(string) error        { return nil }
+
+// reauthResultMsg carries the outcome of verifying a pasted token.
+type reauthResultMsg struct {
+	provider domain.Provider
+	token    string
+	err      error
+}
+
+// reauthPrompt pauses the app after the provider rejected the session's
+// token and asks for a new one.
+type reauthPrompt struct {
+	input    textinput.Model
+	err      string
+	checking bool
+
+	// failed is the message that triggered the prompt. It is delivered
+	// unchanged if the user dismisses the prompt.
+	failed tea.Msg
+}
+
+// unauthorizedErr returns the error carried by msg when it reports a
+// rejected token, or nil.
+func unauthorizedErr(msg tea.Msg) error {
+	var err error
+	switch msg := msg.(type) {
+	case serversErrorMsg:
+		err = msg.err
+	case serverDetailErrorMsg:
+		err = msg.err
+	case metricsErrorMsg:
+		err = msg.err
+	case logsErrorMsg:
+		err = msg.err
+	case catalogErrorMsg:
+		err = msg.err
+	case deleteResultMsg:
+		err = msg.err
+	case createResultMsg:
+		err = msg.err
+	case opToggleErrorMsg:
+		err = msg.err
+	case opPollErrorMsg:
+		err = msg.err
+	case opDeleteResultMsg:
+		err = msg.err
+	}
+	if err != nil && errors.Is(err, domain.ErrUnauthorized) {
+		return err
+	}
+	return nil
+}
+
+// isOverlayMsg reports whether msg belongs to the operations overlay.
+// Those still go to the overlay so the operation is marked failed.
+func isOverlayMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case opToggleErrorMsg, opPollErrorMsg, opDeleteResultMsg:
+		return true
+	}
+	return false
+}
+
+// openReauth pauses the app with a re-auth prompt for the failed msg.
+func (m serverAppModel) openReauth(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if isOverlayMsg(msg) {
+		var updated tea.Model
+		updated, cmd = m.updateOverlay(msg)
+		m = updated.(serverAppModel)
+	}
+
+	input := textinput.New()
+	input.Placeholder = i18n.T("API token")
+	input.EchoMode = textinput.EchoPassword
+	input.EchoCharacter = styles.EchoRune()
+	input.CharLimit = 256
+	input.Width = 50
+
+	m.reauth = &reauthPrompt{input: input, failed: msg}
+	return m, tea.Batch(cmd, m.reauth.input.Focus())
+}
+
+func (m serverAppModel) updateReauth(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := *m.reauth
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		// Give up: show the original error and stop prompting for the
+		// rest of the session.
+		m.reauth = nil
+		m.reauthDismissed = true
+		if isOverlayMsg(prompt.failed) {
+			return m, nil
+		}
+		return m.Update(prompt.failed)
+
+	case "enter":
+		if prompt.checking {
+			return m, nil
+		}
+		token := strings.TrimSpace(prompt.input.Value())
+		if token == "" {
+			prompt.err = i18n.T("Token cannot be empty")
+			m.reauth = &prompt
+			return m, nil
+		}
+		prompt.checking = true
+		prompt.err = ""
+		m.reauth = &prompt
+		return m, verifyToken(m.providerName, token)
+	}
+
+	var cmd tea.Cmd
+	prompt.input, cmd = prompt.input.Update(msg)
+	prompt.err = ""
+	m.reauth = &prompt
+	return m, cmd
+}
+
+// verifyToken builds a provider with token and makes one request with it.
+func verifyToken(providerName, token string) tea.Cmd {
+	return func() tea.Msg {
+		provider, err := reconnectProvider(providerName, token)
+		if err != nil {
+			return reauthResultMsg{err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), reauthCheckTimeout)
+		defer cancel()
+		if _, err := provider.ListServers(ctx); err != nil {
+			return reauthResultMsg{err: err}
+		}
+		return reauthResultMsg{provider: provider, token: token}
+	}
+}
+
+func (m serverAppModel) handleReauthResult(msg reauthResultMsg) (tea.Model, tea.Cmd) {
+	if m.reauth == nil {
+		return m, nil
+	}
+	prompt := *m.reauth
+	prompt.checking = false
+	if msg.err != nil {
+		if errors.Is(msg.err, domain.ErrUnauthorized) {
+			prompt.err = i18n.T("Token rejected. Check it and try again.")
+		} else {
+			prompt.err = msg.err.Error()
+		}
+		m.reauth = &prompt
+		return m, nil
+	}
+
+	saveErr := saveToken(m.providerName, msg.token)
+
+	m.reauth = nil
+	m.provider = msg.provider
+	m.overlay.provider = msg.provider
+	m.metrics = newMetricsScheduler(msg.provider)
+
+	updated, cmd := m.retryAfterReauth(prompt.failed)
+	if saveErr != nil {
+		app := updated.(serverAppModel)
+		if app.view == appViewList {
+			app.list.persistentStatus = i18n.T("New token is used for this session but could not be saved: %v", saveErr)
+		}
+		updated = app
+	}
+	return updated, cmd
+}
+
+// retryAfterReauth re-runs the operation that failed with the old token:
+// the delete or create call, or otherwise the current view's fetch.
+func (m serverAppModel) retryAfterReauth(failed tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := failed.(type) {
+	case deleteResultMsg:
+		return m.startDeleteAction(msg.server)
+	case createResultMsg:
+		return m.startCreateAction(msg.opts)
+	}
+
+	switch m.view {
+	case appViewShow:
+		if m.show.server != nil {
+			return m.switchToShow(*m.show.server)
+		}
+	case appViewLogs:
+		return m.switchToLogs(*m.logs.server)
+	case appViewDelete:
+		if m.delete.server != nil {
+			return m.switchToDelete(*m.delete.server)
+		}
+	case appViewCreate:
+		return m.switchToCreate()
+	}
+	return m.switchToList()
+}
+
+// renderReauth renders the re-auth prompt in place of the active view.
+func (m serverAppModel) renderReauth() string {
+	prompt := m.reauth
+	header := components.Header(m.width, "re-authenticate", m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "enter", Desc: "verify & retry"},
+		{Key: "esc", Desc: "dismiss"},
+		{Key: "ctrl+c", Desc: "quit"},
+	})
+
+	title := styles.Title.Render(i18n.T("Session token rejected"))
+	hint := styles.MutedText.Render(i18n.T(
+		"%s rejected the API token (expired or revoked).\nPaste a new token to continue where you left off.",
+		m.provider.GetDisplayName()))
+
+	var statusLine string
+	switch {
+	case prompt.checking:
+		statusLine = styles.MutedText.Render(i18n.T("Verifying token..."))
+	case prompt.err != "":
+		statusLine = styles.ErrorText.Render(prompt.err)
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, title, hint, "", prompt.input.View(), statusLine)
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reauthProvider is a minimal provider for re-auth tests.
+type reauthProvider struct {
+	name    string
+	listErr error
+}
+
+func (p *reauthProvider) GetDisplayName() string { return p.name }
+func (p *reauthProvider) CreateServer(context.Context, domain.CreateServerOpts) (*domain.Server, error) {
+	return nil, nil
+}
+func (p *reauthProvider) DeleteServer(context.Context, string) error { return nil }
+func (p *reauthProvider) GetServer(context.Context, string) (*domain.Server, error) {
+	return nil, nil
+}
+func (p *reauthProvider) ListServers(context.Context) ([]domain.Server, error) {
+	return nil, p.listErr
+}
+func (p *reauthProvider) StartServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+func (p *reauthProvider) StopServer(context.Context, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func newReauthTestApp() serverAppModel {
+	provider := &reauthProvider{name: "Old"}
+	return serverAppModel{
+		provider:     provider,
+		providerName: "mock",
+		view:         appViewList,
+		list:         newServerListModel(provider, "mock"),
+		width:        80,
+		height:       24,
+	}
+}
+
+func unauthorizedListErr() tea.Msg {
+	return serversErrorMsg{err: fmt.Errorf("failed to list servers: %w", domain.ErrUnauthorized)}
+}
+
+func TestServerApp_UnauthorizedOpensReauthPrompt(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, _ := m.Update(unauthorizedListErr())
+	app := updated.(serverAppModel)
+
+	if app.reauth == nil {
+		t.Fatal("expected re-auth prompt to open")
+	}
+	if app.list.err != nil {
+		t.Errorf("expected the list not to show the error, got %v", app.list.err)
+	}
+}
+
+func TestServerApp_OtherErrorsDoNotOpenReauthPrompt(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, _ := m.Update(serversErrorMsg{err: errors.New("boom")})
+	if updated.(serverAppModel).reauth != nil {
+		t.Error("expected no re-auth prompt for a generic error")
+	}
+}
+
+func TestServerApp_ReauthSuccessSwapsProviderAndRetries(t *testing.T) {
+	var saved string
+	origSave := saveToken
+	saveToken = func(_, token string) error { saved = token; return nil }
+	t.Cleanup(func() { saveToken = origSave })
+
+	m := newReauthTestApp()
+	updated, _ := m.Update(unauthorizedListErr())
+
+	fresh := &reauthProvider{name: "New"}
+	updated, cmd := updated.(serverAppModel).Update(reauthResultMsg{provider: fresh, token: "new-token"})
+	app := updated.(serverAppModel)
+
+	if app.reauth != nil {
+		t.Error("expected prompt to close")
+	}
+	if app.provider != fresh || app.list.provider != fresh {
+		t.Error("expected the new provider to be used")
+	}
+	if saved != "new-token" {
+		t.Errorf("expected token to be saved, got %q", saved)
+	}
+	if cmd == nil || !app.list.loading {
+		t.Error("expected the list fetch to be retried")
+	}
+}
+
+func TestServerApp_ReauthRejectedKeepsPrompt(t *testing.T) {
+	m := newReauthTestApp()
+	updated, _ := m.Update(unauthorizedListErr())
+
+	updated, _ = updated.(serverAppModel).Update(reauthResultMsg{err: domain.ErrUnauthorized})
+	app := updated.(serverAppModel)
+
+	if app.reauth == nil || app.reauth.err == "" {
+		t.Fatalf("expected prompt to stay open with an error, got %+v", app.reauth)
+	}
+}
+
+func TestServerApp_ReauthDismissShowsOriginalError(t *testing.T) {
+	m := newReauthTestApp()
+	updated, _ := m.Update(unauthorizedListErr())
+
+	updated, _ = updated.(serverAppModel).Update(tea.KeyMsg{Type: tea.KeyEsc})
+	app := updated.(serverAppModel)
+
+	if app.reauth != nil {
+		t.Error("expected prompt to close")
+	}
+	if !errors.Is(app.list.err, domain.ErrUnauthorized) {
+		t.Errorf("expected list to show the original error, got %v", app.list.err)
+	}
+
+	updated, _ = app.Update(unauthorizedListErr())
+	if updated.(serverAppModel).reauth != nil {
+		t.Error("expected no new prompt after dismissal")
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	orig := reconnectProvider
+	t.Cleanup(func() { reconnectProvider = orig })
+
+	reconnectProvider = func(_, token string) (domain.Provider, error) {
+		if token == "bad" {
+			return &reauthProvider{listErr: domain.ErrUnauthorized}, nil
+		}
+		return &reauthProvider{}, nil
+	}
+
+	if msg := verifyToken("mock", "bad")().(reauthResultMsg); !errors.Is(msg.err, domain.ErrUnauthorized) {
+		t.Errorf("expected unauthorized error, got %v", msg.err)
+	}
+	if msg := verifyToken("mock", "good")().(reauthResultMsg); msg.err != nil || msg.provider == nil {
+		t.Errorf("expected verified provider, got %+v", msg)
+	}
+}
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchMaxResults bounds the number of results listed in the palette.
+const searchMaxResults = 10
+
+// searchPalette is the ctrl+f global search. It queries the local
+// resource index, so results from every provider appear without API
+// calls, and opens the selected resource's detail view.
+type searchPalette struct {
+	input   textinput.Model
+	results []tagindex.Resource
+	cursor  int
+	err     string
+}
+
+// openSearch shows the search palette over the active view.
+func (m serverAppModel) openSearch() (tea.Model, tea.Cmd) {
+	input := textinput.New()
+	input.Placeholder = "name, IP, label or record value"
+	input.CharLimit = 128
+	input.Width = 50
+
+	m.search = &searchPalette{input: input}
+	return m, m.search.input.Focus()
+}
+
+func (m serverAppModel) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	palette := *m.search
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc", "ctrl+f":
+		m.search = nil
+		return m, nil
+
+	case "up", "ctrl+p":
+		if palette.cursor > 0 {
+			palette.cursor--
+		}
+		m.search = &palette
+		return m, nil
+
+	case "down", "ctrl+n":
+		if palette.cursor < len(palette.results)-1 {
+			palette.cursor++
+		}
+		m.search = &palette
+		return m, nil
+
+	case "enter":
+		if len(palette.results) == 0 {
+			return m, nil
+		}
+		return m.openSearchResult(palette.results[palette.cursor])
+	}
+
+	var cmd tea.Cmd
+	palette.input, cmd = palette.input.Update(msg)
+	palette.err = ""
+	palette.results = m.searchIndex(palette.input.Value())
+	if palette.cursor >= len(palette.results) {
+		palette.cursor = max(0, len(palette.results)-1)
+	}
+	m.search = &palette
+	return m, cmd
+}
+
+// searchIndex returns the indexed resources matching text.
+func (m serverAppModel) searchIndex(text string) []tagindex.Resource {
+	if m.tags == nil || strings.TrimSpace(text) == "" {
+		return nil
+	}
+	results, err := m.tags.Search(text)
+	if err != nil {
+		return nil
+	}
+	if len(results) > searchMaxResults {
+		results = results[:searchMaxResults]
+	}
+	return results
+}
+
+// openSearchResult jumps to the detail view of res: a server's show view,
+// the show view of the server a volume is attached to, the backups view
+// of the server a backup belongs to, or the show view of the server a
+// DNS record points at. Only the session's provider can be opened; other
+// providers need their own session.
+func (m serverAppModel) openSearchResult(res tagindex.Resource) (tea.Model, tea.Cmd) {
+	if res.Kind == tagindex.KindDNSRecord {
+		if server, ok := m.indexedServerAt(res.Addresses); ok {
+			return m.openSearchResult(server)
+		}
+		return m.searchHint(fmt.Sprintf("%s does not point at a known server. Open its zone with: vpsm dns record list %s --domain %s",
+			res.Name, providerFlags(res.Provider), res.Parent))
+	}
+
+	serverID := res.ID
+	if res.Kind != tagindex.KindServer {
+		serverID = res.Parent
+	}
+	if res.Provider != m.indexKey() {
+		return m.searchHint(fmt.Sprintf("%s is on %s. Open it with: vpsm server show %s --id %s",
+			res.Name, res.Provider, providerFlags(res.Provider), serverID))
+	}
+	m.search = nil
+
+	// Prefer the list's copy of the server; an index entry only carries
+	// enough to fetch the rest.
+	server := domain.Server{ID: serverID, Provider: res.Provider}
+	known := false
+	for _, s := range m.list.servers {
+		if s.ID == serverID {
+			server, known = s, true
+			break
+		}
+	}
+	switch {
+	case !known && res.Kind == tagindex.KindServer:
+		server.Name, server.Labels = res.Name, res.Labels
+	case !known && res.Kind == tagindex.KindBackup:
+		server.Name = res.Content
+	}
+
+	if res.Kind == tagindex.KindBackup {
+		return m.switchToBackups(server)
+	}
+	if known {
+		return m.switchToShow(server)
+	}
+	m.view = appViewShow
+	m.show = newServerShowDirect(m.provider, m.providerName, &server, m.metrics)
+	m.show.width = m.width
+	m.show.height = m.height
+	m.show.loading = true
+	return m, tea.Batch(m.show.spinner.Tick, m.show.fetchServer())
+}
+
+// searchHint keeps the palette open and shows hint below the results.
+func (m serverAppModel) searchHint(hint string) (tea.Model, tea.Cmd) {
+	palette := *m.search
+	palette.err = hint
+	m.search = &palette
+	return m, nil
+}
+
+// indexedServerAt returns the indexed server that holds one of addrs, or
+// whose IPv6 network contains one.
+func (m serverAppModel) indexedServerAt(addrs []string) (tagindex.Resource, bool) {
+	if m.tags == nil || len(addrs) == 0 {
+		return tagindex.Resource{}, false
+	}
+	servers, err := m.tags.Find(tagindexsvc.Query{Kind: tagindex.KindServer})
+	if err != nil {
+		return tagindex.Resource{}, false
+	}
+	for _, addr := range addrs {
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			continue
+		}
+		for _, server := range servers {
+			for _, held := range server.Addresses {
+				if prefix, err := netip.ParsePrefix(held); err == nil && prefix.Masked().Contains(ip) {
+					return server, true
+				}
+				if held == addr {
+					return server, true
+				}
+			}
+		}
+	}
+	return tagindex.Resource{}, false
+}
+
+// providerFlags returns the flags selecting an index key's provider and
+// project, e.g. "--provider hetzner --project staging".
+func providerFlags(key string) string {
+	if provider, project, ok := strings.Cut(key, "/"); ok {
+		return "--provider " + provider + " --project " + project
+	}
+	return "--provider " + key
+}
+
+// renderSearch renders the search palette in place of the active view.
+func (m serverAppModel) renderSearch() string {
+	palette := m.search
+	header := components.Header(m.width, "search", m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: styles.Up() + "/" + styles.Down(), Desc: "select"},
+		{Key: "enter", Desc: "open"},
+		{Key: "esc", Desc: "close"},
+	})
+
+	lines := []string{styles.Title.Render("Search"), "", palette.input.View(), ""}
+	switch {
+	case m.tags == nil:
+		lines = append(lines, styles.MutedText.Render("The local resource index is unavailable."))
+	case strings.TrimSpace(palette.input.Value()) == "":
+		lines = append(lines, styles.MutedText.Render("Type to search resources from every provider."))
+	case len(palette.results) == 0:
+		lines = append(lines, styles.MutedText.Render("No matching resources."))
+	}
+	for i, res := range palette.results {
+		prefix := "  "
+		name := res.Name
+		if i == palette.cursor {
+			prefix = styles.AccentText.Render("> ")
+			name = styles.Value.Render(name)
+		}
+		detail := res.Kind + " " + styles.Middot() + " " + res.Provider
+		if len(res.Addresses) > 0 {
+			detail += " " + styles.Middot() + " " + res.Addresses[0]
+		} else if res.Content != "" {
+			detail += " " + styles.Middot() + " " + res.Content
+		}
+		lines = append(lines, prefix+name+"  "+styles.MutedText.Render(detail))
+	}
+	if palette.err != "" {
+		lines = append(lines, "", styles.ErrorText.Render(palette.err))
+	}
+
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newSearchTestApp(t *testing.T) serverAppModel {
+	t.Helper()
+	repo, err := tagindex.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	tags := tagindexsvc.NewService(repo)
+	t.Cleanup(func() { tags.Close() })
+	tags.RecordServers("mock", []domain.Server{{
+		ID: "1", Name: "web-1", PublicIPv4: "192.0.2.1", PublicIPv6: "2001:db8::/64",
+		Attached: []domain.AttachedResource{{Kind: domain.AttachedVolume, ID: "v1", Name: "uploads"}},
+	}})
+	tags.RecordServers("other", []domain.Server{{ID: "9", Name: "web-9"}})
+	tags.RecordBackups("mock", domain.Server{ID: "1", Name: "web-1"}, []domain.Backup{{ID: "b1", Description: "nightly"}})
+	tags.RecordZone("cloudflare", "example.com", []dnsdomain.Record{
+		{ID: "r1", Name: "shop", Type: dnsdomain.RecordAAAA, Value: "2001:db8::1"},
+		{ID: "r2", Name: "blog", Type: dnsdomain.RecordCNAME, Value: "hosted.example.net"},
+	})
+
+	m := newReauthTestApp()
+	m.tags = tags
+	return m
+}
+
+func typeSearch(t *testing.T, m serverAppModel, text string) serverAppModel {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlF})
+	m = updated.(serverAppModel)
+	if m.search == nil {
+		t.Fatal("expected ctrl+f to open the search palette")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	return updated.(serverAppModel)
+}
+
+func TestSearchPalette_OpensMatchingServer(t *testing.T) {
+	m := typeSearch(t, newSearchTestApp(t), "192.0.2")
+
+	if len(m.search.results) != 1 || m.search.results[0].ID != "1" {
+		t.Fatalf("expected web-1 to match by IP, got %+v", m.search.results)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app := updated.(serverAppModel)
+	if app.search != nil {
+		t.Error("expected the palette to close")
+	}
+	if app.view != appViewShow || app.show.serverID != "1" {
+		t.Errorf("expected the show view for server 1, got view %v server %q", app.view, app.show.serverID)
+	}
+	if cmd == nil {
+		t.Error("expected the detail view to fetch the server")
+	}
+}
+
+func TestSearchPalette_OtherProviderExplainsHowToOpen(t *testing.T) {
+	m := typeSearch(t, newSearchTestApp(t), "web-9")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app := updated.(serverAppModel)
+	if app.search == nil || !strings.Contains(app.search.err, "--provider other") {
+		t.Errorf("expected a hint to open the other provider, got %+v", app.search)
+	}
+	if app.view != appViewList {
+		t.Errorf("expected to stay on the list, got view %v", app.view)
+	}
+}
+
+func TestSearchPalette_OpensVolumeBackupAndDNSRecordViews(t *testing.T) {
+	tests := []struct {
+		text string
+		want appView
+	}{
+		{"uploads", appViewShow},
+		{"nightly", appViewBackups},
+		{"shop.example", appViewShow},
+	}
+	for _, tt := range tests {
+		m := typeSearch(t, newSearchTestApp(t), tt.text)
+		if len(m.search.results) != 1 {
+			t.Fatalf("%q: expected one result, got %+v", tt.text, m.search.results)
+		}
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		app := updated.(serverAppModel)
+		if app.search != nil || app.view != tt.want {
+			t.Errorf("%q: expected view %v, got %v (palette %+v)", tt.text, tt.want, app.view, app.search)
+			continue
+		}
+		switch app.view {
+		case appViewShow:
+			if app.show.serverID != "1" {
+				t.Errorf("%q: expected server 1, got %q", tt.text, app.show.serverID)
+			}
+		case appViewBackups:
+			if app.backups.server.ID != "1" {
+				t.Errorf("%q: expected the backups of server 1, got %q", tt.text, app.backups.server.ID)
+			}
+		}
+	}
+}
+
+func TestSearchPalette_DNSRecordElsewhereExplainsHowToOpen(t *testing.T) {
+	m := typeSearch(t, newSearchTestApp(t), "blog")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	app := updated.(serverAppModel)
+	if app.search == nil || !strings.Contains(app.search.err, "vpsm dns record list --provider cloudflare --domain example.com") {
+		t.Errorf("expected a hint to open the zone, got %+v", app.search)
+	}
+}
+
+func TestSearchPalette_EscCloses(t *testing.T) {
+	m := typeSearch(t, newSearchTestApp(t), "web")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if updated.(serverAppModel).search != nil {
+		t.Error("expected esc to close the palette")
+	}
+}
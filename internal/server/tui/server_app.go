@@ -3,23 +3,46 @@ package tui
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"strconv"
 	"strings"
-
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bastion"
+	"nathanbeddoewebdev/vpsm/internal/server/services/cost"
+	"nathanbeddoewebdev/vpsm/internal/server/services/metricsched"
+	"nathanbeddoewebdev/vpsm/internal/server/services/nativessh"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/server/services/status"
 	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	iphistorysvc "nathanbeddoewebdev/vpsm/internal/services/iphistory"
+	archivesvc "nathanbeddoewebdev/vpsm/internal/services/serverarchive"
 	prefssvc "nathanbeddoewebdev/vpsm/internal/services/serverprefs"
+	tagindexsvc "nathanbeddoewebdev/vpsm/internal/services/tagindex"
+	"nathanbeddoewebdev/vpsm/internal/sshkeys"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // --- Navigation messages ---
@@ -43,6 +66,34 @@ type navigateToSSHMsg struct {
 	server domain.Server
 }
 
+type navigateToLogsMsg struct {
+	server domain.Server
+}
+
+type navigateToResizeMsg struct {
+	server domain.Server
+}
+
+type navigateToBackupsMsg struct {
+	server domain.Server
+}
+
+type navigateToFloatingIPsMsg struct {
+	server domain.Server
+}
+
+type navigateToReverseDNSMsg struct {
+	server domain.Server
+}
+
+type navigateToLabelsMsg struct {
+	server domain.Server
+}
+
+type navigateToDNSAttachMsg struct {
+	server domain.Server
+}
+
 // navigateBackMsg asks the app to return to the previous view (or the list).
 type navigateBackMsg struct{}
 
@@ -55,6 +106,12 @@ type deleteConfirmedMsg struct {
 	server domain.Server
 }
 
+// stopThenDeleteMsg asks the app to stop a running server through the
+// operations overlay and delete it once it is off.
+type stopThenDeleteMsg struct {
+	server domain.Server
+}
+
 type createConfirmedMsg struct {
 	opts domain.CreateServerOpts
 }
@@ -67,6 +124,7 @@ type deleteResultMsg struct {
 }
 
 type createResultMsg struct {
+	opts   domain.CreateServerOpts
 	server *domain.Server
 	err    error
 }
@@ -75,9 +133,12 @@ type createResultMsg struct {
 
 // requestSSHMsg is emitted by the show model when the user confirms SSH.
 type requestSSHMsg struct {
-	server    domain.Server
-	username  string
-	ipAddress string
+	server     domain.Server
+	username   string
+	ipAddress  string
+	persistent bool   // attach to a remote tmux session
+	identity   string // private key file for -i, "" for ssh's defaults
+	port       int    // SSH port, 0 for the default
 }
 
 // sshErrKind categorizes SSH connection failures for appropriate error handling.
@@ -99,13 +160,65 @@ type sshFinishedMsg struct {
 	errDetail string // human-readable message extracted from SSH stderr
 }
 
+// sshSpawnedMsg reports that an SSH session was opened in tmux.
+type sshSpawnedMsg struct {
+	server domain.Server
+	mode   tmux.Mode
+	err    error
+}
+
+// runTmux runs a tmux command. Tests replace it.
+var runTmux = func(cmd *exec.Cmd) error {
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if detail := strings.TrimSpace(string(out)); detail != "" {
+			return fmt.Errorf("%s", detail)
+		}
+		return err
+	}
+	return nil
+}
+
 // clearHostKeyMsg requests removal of a stale SSH host key and connection retry.
 type clearHostKeyMsg struct {
-	server    domain.Server
-	username  string
-	ipAddress string
+	server     domain.Server
+	username   string
+	ipAddress  string
+	persistent bool
+	identity   string
+	port       int
+}
+
+// --- IP history messages ---
+
+// ipHistoryLoadedMsg carries the public IPs a server no longer uses, read
+// from the local IP history after recording its current ones.
+type ipHistoryLoadedMsg struct {
+	serverID string
+	previous []iphistory.IPRecord
 }
 
+// staleDNSMsg carries the DNS records still pointing at a server's
+// previous IPs.
+type staleDNSMsg struct {
+	serverID string
+	stale    *staleDNS
+	err      error
+}
+
+// --- Provider status messages ---
+
+// providerStatusMsg carries the result of a provider status page check.
+type providerStatusMsg struct {
+	status *status.Status
+	err    error
+}
+
+// providerStatusTickMsg triggers the next provider status check.
+type providerStatusTickMsg struct{}
+
+// providerStatusInterval is the delay between provider status page checks.
+const providerStatusInterval = 5 * time.Minute
+
 // --- App view ---
 
 type appView int
@@ -116,9 +229,39 @@ const (
 	appViewDelete
 	appViewCreate
 	appViewSSH
+	appViewLogs
+	appViewResize
+	appViewBackups
+	appViewFloatingIPs
+	appViewReverseDNS
+	appViewLabels
+	appViewDNSAttach
+	appViewTransfer
+	appViewMetrics
+	appViewGroup
 	appViewAction // performing an API call (delete/create)
 )
 
+// appViewNames names each view in crash reports.
+var appViewNames = map[appView]string{
+	appViewList:        "list",
+	appViewShow:        "show",
+	appViewDelete:      "delete",
+	appViewCreate:      "create",
+	appViewSSH:         "ssh",
+	appViewLogs:        "logs",
+	appViewResize:      "resize",
+	appViewBackups:     "backups",
+	appViewFloatingIPs: "floating-ips",
+	appViewReverseDNS:  "reverse-dns",
+	appViewLabels:      "labels",
+	appViewDNSAttach:   "dns-attach",
+	appViewTransfer:    "transfer",
+	appViewMetrics:     "metrics",
+	appViewGroup:       "group",
+	appViewAction:      "action",
+}
+
 // --- App model ---
 
 // serverAppModel is a top-level Bubbletea model that manages transitions
@@ -129,22 +272,99 @@ type serverAppModel struct {
 	provider     domain.Provider
 	providerName string
 
+	// project is the provider project whose token the session uses, or
+	// "" for the token stored without one.
+	project string
+
 	view appView
 
 	// Child models.
-	list   serverListModel
-	show   serverShowModel
-	delete serverDeleteModel
-	create serverCreateModel
-	ssh    serverSSHModel
+	list        serverListModel
+	show        serverShowModel
+	delete      serverDeleteModel
+	create      serverCreateModel
+	ssh         serverSSHModel
+	logs        serverLogsModel
+	resize      serverResizeModel
+	backups     serverBackupsModel
+	floatingIPs serverFloatingIPsModel
+	reverseDNS  serverReverseDNSModel
+	labels      serverLabelsModel
+	dnsAttach   serverDNSAttachModel
+	transfer    serverTransferModel
+	dashboard   serverMetricsModel
+	group       groupProgressModel
 
 	// overlay manages concurrent start/stop operations and renders a
 	// floating panel in the bottom-right corner of the screen.
 	overlay opsOverlay
 
+	// deletions tracks servers whose deletion was accepted, so lists show
+	// them as deleting until the provider drops them.
+	deletions *deletionTracker
+
+	// prefetched holds server details prefetched while the list cursor
+	// rested on a row, so the show view opens with fresh data.
+	prefetched *prefetchCache
+
 	// prefsSvc provides per-server user preference persistence.
 	prefsSvc *prefssvc.Service
 
+	// ipHistory records public IPs observed on servers so the detail view
+	// can surface addresses a server no longer uses.
+	ipHistory *iphistorysvc.Service
+
+	// tags indexes server labels so they can be searched across providers.
+	tags *tagindexsvc.Service
+
+	// statusBanner is a one-line warning shown under the header while the
+	// provider's status page reports an incident or maintenance.
+	statusBanner string
+
+	// reauth, when set, pauses the app to ask for a new API token after
+	// the provider rejected the current one. reauthDismissed stops
+	// further prompts once the user has declined.
+	reauth          *reauthPrompt
+	reauthDismissed bool
+
+	// search, when set, is the ctrl+f search palette shown over the view.
+	search *searchPalette
+
+	// projects, when set, is the project picker shown over the list.
+	projects *projectPicker
+
+	// groups, when set, is the group action picker shown over the list.
+	groups *groupPicker
+
+	// whatsNew, when set, lists catalog changes since the previous
+	// session. It is shown over the list until dismissed.
+	whatsNew *domain.CatalogChanges
+
+	// cost, when set, is the monthly cost overview shown over the list.
+	cost *costPanel
+
+	// toast offers to undo the latest reversible change for undoWindow.
+	// toastID tells a toast's expiry apart from a newer toast's.
+	toast   *undoToast
+	toastID int
+
+	// metrics batches and caches metrics requests for the provider, or
+	// is nil when the provider has no metrics.
+	metrics domain.MetricsProvider
+
+	// latency records how long provider calls took in each view;
+	// showLatency toggles the ctrl+g debug line that displays them.
+	latency     *latencyRecorder
+	showLatency bool
+
+	// title is the terminal window title last sent, so it is only
+	// rewritten when navigation changes it.
+	title string
+
+	// startCmd is the initial view's command when the app opens on a
+	// server instead of the list. Init runs it.
+	startCmd tea.Cmd
+
 	// Action state (appViewAction).
 	actionSpinner spinner.Model
 	actionLabel   string
@@ -162,11 +382,19 @@ type AppResult struct {
 	CreatedServer *domain.Server
 }
 
+// AppOptions adjusts how RunServerApp starts.
+type AppOptions struct {
+	// Server, when set, opens the app on its show view instead of the
+	// list. The list still loads in the background for when the user
+	// goes back.
+	Server *domain.Server
+}
+
 // RunServerApp starts the unified server management TUI. It stays open
 // until the user explicitly quits from the list view.
-func RunServerApp(provider domain.Provider, providerName string) (*AppResult, error) {
+func RunServerApp(provider domain.Provider, providerName string, opts AppOptions) (*AppResult, error) {
 	as := spinner.New()
-	as.Spinner = spinner.Dot
+	as.Spinner = styles.Spinner()
 	as.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	overlay, overlayInitCmd := newOpsOverlay(provider, providerName)
@@ -177,17 +405,42 @@ func RunServerApp(provider domain.Provider, providerName string) (*AppResult, er
 		prefsSvc = prefssvc.NewService(repo)
 	}
 
+	// Open IP history database (best-effort, continue if unavailable).
+	var ipHistory *iphistorysvc.Service
+	if repo, err := iphistory.Open(); err == nil {
+		ipHistory = iphistorysvc.NewService(repo)
+	}
+
+	// Open tag index database (best-effort, continue if unavailable).
+	var tags *tagindexsvc.Service
+	if repo, err := tagindex.Open(); err == nil {
+		tags = tagindexsvc.NewService(repo)
+	}
+
 	m := serverAppModel{
 		provider:      provider,
 		providerName:  providerName,
+		project:       auth.Project(),
 		view:          appViewList,
 		list:          newServerListModel(provider, providerName),
 		overlay:       overlay,
 		prefsSvc:      prefsSvc,
+		ipHistory:     ipHistory,
+		tags:          tags,
 		actionSpinner: as,
+		metrics:       newMetricsScheduler(provider),
+		deletions:     newDeletionTracker(),
+		prefetched:    newPrefetchCache(),
+		latency:       newLatencyRecorder(),
+	}
+	m.list.deletions = m.deletions
+	if opts.Server != nil {
+		updated, cmd := m.switchToShow(*opts.Server)
+		m = updated.(serverAppModel)
+		m.startCmd = cmd
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 
 	// Send overlay initialization command if available (loads pending actions).
 	if overlayInitCmd != nil {
@@ -210,15 +463,164 @@ func RunServerApp(provider domain.Provider, providerName string) (*AppResult, er
 	if final.prefsSvc != nil {
 		final.prefsSvc.Close()
 	}
+	if final.ipHistory != nil {
+		final.ipHistory.Close()
+	}
+	if final.tags != nil {
+		final.tags.Close()
+	}
 
 	return &AppResult{}, nil
 }
 
 func (m serverAppModel) Init() tea.Cmd {
-	return m.list.Init()
+	return tea.Batch(m.list.Init(), m.startCmd, m.checkProviderStatus(), m.checkCatalogChanges(), tea.SetWindowTitle(m.windowTitle()))
+}
+
+// checkProviderStatus fetches the provider's status page in the background.
+// Failures are silent: the banner is a convenience, not a dependency.
+func (m serverAppModel) checkProviderStatus() tea.Cmd {
+	providerName := m.providerName
+	return func() tea.Msg {
+		st, err := status.Check(context.Background(), nil, providerName)
+		return providerStatusMsg{status: st, err: err}
+	}
 }
 
+// Update handles msg and keeps the terminal title in step with the view
+// it leaves the app on.
 func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.update(msg)
+	app, ok := updated.(serverAppModel)
+	if !ok {
+		return updated, cmd
+	}
+	if title := app.windowTitle(); title != app.title {
+		app.title = title
+		cmd = tea.Batch(cmd, tea.SetWindowTitle(title))
+	}
+	return app, cmd
+}
+
+// windowTitle describes the current context, e.g. "vpsm: hetzner / web-1".
+func (m serverAppModel) windowTitle() string {
+	title := "vpsm: " + m.providerName
+	if m.project != "" {
+		title += " (" + m.project + ")"
+	}
+
+	var server *domain.Server
+	switch m.view {
+	case appViewShow:
+		server = m.show.server
+	case appViewDelete:
+		server = m.delete.server
+	case appViewSSH:
+		server = m.ssh.server
+	case appViewLogs:
+		server = m.logs.server
+	case appViewResize:
+		server = m.resize.server
+	case appViewBackups:
+		server = m.backups.server
+	case appViewFloatingIPs:
+		server = m.floatingIPs.server
+	case appViewReverseDNS:
+		server = m.reverseDNS.server
+	case appViewLabels:
+		server = m.labels.server
+	case appViewDNSAttach:
+		server = m.dnsAttach.server
+	case appViewTransfer:
+		server = m.transfer.server
+	case appViewMetrics:
+		server = m.dashboard.server
+	case appViewGroup:
+		return title + " / group " + m.group.groupName
+	case appViewCreate:
+		return title + " / new server"
+	}
+	if server != nil && server.Name != "" {
+		title += " / " + server.Name
+	}
+	return title
+}
+
+// serverTrail is the header breadcrumb trail for a view of server,
+// e.g. "servers › web-1 › logs". A nil server gives the trail of a view
+// that is not about one server, such as "servers › create".
+func serverTrail(server *domain.Server, view ...string) []string {
+	trail := []string{"servers"}
+	if server != nil && server.Name != "" {
+		trail = append(trail, server.Name)
+	}
+	return append(trail, view...)
+}
+
+func (m serverAppModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// A rejected token pauses the app behind a re-auth prompt instead of
+	// leaving the view on an error it cannot recover from.
+	if m.reauth != nil {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return m.updateReauth(msg)
+		case reauthResultMsg:
+			return m.handleReauthResult(msg)
+		}
+		if unauthorizedErr(msg) != nil {
+			// Further failures are retried along with the first one.
+			if isOverlayMsg(msg) {
+				return m.updateOverlay(msg)
+			}
+			return m, nil
+		}
+	} else if !m.reauthDismissed && unauthorizedErr(msg) != nil {
+		return m.openReauth(msg)
+	}
+
+	if msg, ok := msg.(timedMsg); ok {
+		m.latency.Record(m.view, msg.callTiming())
+	}
+
+	// The search palette takes keys while open; ctrl+f opens it from any
+	// view but an in-progress action.
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if m.search != nil {
+			return m.updateSearch(msg)
+		}
+		if m.projects != nil {
+			return m.updateProjectPicker(msg)
+		}
+		if m.groups != nil {
+			return m.updateGroupPicker(msg)
+		}
+		if m.whatsNew != nil && m.view == appViewList {
+			return m.updateWhatsNew(msg)
+		}
+		if m.cost != nil && m.view == appViewList {
+			return m.updateCostPanel(msg)
+		}
+		if msg.String() == "ctrl+f" && m.view != appViewAction {
+			return m.openSearch()
+		}
+		if msg.String() == "ctrl+g" {
+			m.showLatency = !m.showLatency
+			return m, nil
+		}
+		if msg.String() == "p" && m.view == appViewList && m.list.canSwitchProject {
+			return m.openProjectPicker()
+		}
+		if msg.String() == "o" && m.view == appViewList && !m.list.picker {
+			return m.openGroupPicker()
+		}
+		if msg.String() == "$" && m.view == appViewList && !m.list.picker && m.canShowCost() {
+			return m.openCostPanel()
+		}
+		if msg.String() == "u" && m.canUndo() {
+			return m.applyUndo()
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -243,6 +645,33 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case navigateToSSHMsg:
 		return m.switchToSSH(msg.server)
 
+	case navigateToLogsMsg:
+		return m.switchToLogs(msg.server)
+
+	case navigateToResizeMsg:
+		return m.switchToResize(msg.server)
+
+	case navigateToBackupsMsg:
+		return m.switchToBackups(msg.server)
+
+	case navigateToFloatingIPsMsg:
+		return m.switchToFloatingIPs(msg.server)
+
+	case navigateToReverseDNSMsg:
+		return m.switchToReverseDNS(msg.server)
+
+	case navigateToLabelsMsg:
+		return m.switchToLabels(msg.server)
+
+	case navigateToDNSAttachMsg:
+		return m.switchToDNSAttach(msg.server)
+
+	case navigateToTransferMsg:
+		return m.switchToTransfer(msg.server)
+
+	case navigateToMetricsMsg:
+		return m.switchToMetrics(msg.server)
+
 	case navigateBackMsg:
 		return m.switchToList()
 
@@ -251,6 +680,12 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case deleteConfirmedMsg:
 		return m.startDeleteAction(msg.server)
 
+	case stopThenDeleteMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartStopThenDelete(msg.server)
+		updated, listCmd := m.switchToList()
+		return updated, tea.Batch(cmd, listCmd)
+
 	case createConfirmedMsg:
 		return m.startCreateAction(msg.opts)
 
@@ -262,6 +697,87 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case createResultMsg:
 		return m.handleCreateResult(msg)
 
+	// --- Provider status ---
+
+	case providerStatusMsg:
+		if msg.err == nil {
+			m.statusBanner = ""
+			if msg.status != nil && msg.status.Degraded() {
+				m.statusBanner = fmt.Sprintf("%s status: %s", m.provider.GetDisplayName(), msg.status.Summary())
+			}
+		}
+		if msg.err == nil && (msg.status == nil || !msg.status.Available()) {
+			// No readable status page for this provider; stop polling.
+			return m, nil
+		}
+		return m, tea.Tick(providerStatusInterval, func(time.Time) tea.Msg { return providerStatusTickMsg{} })
+
+	case providerStatusTickMsg:
+		return m, m.checkProviderStatus()
+
+	case undoExpiredMsg:
+		if m.toast != nil && m.toast.id == msg.id {
+			m.toast = nil
+		}
+		return m, nil
+
+	case catalogChangesMsg:
+		if msg.err == nil && !msg.changes.Empty() {
+			m.whatsNew = &msg.changes
+		}
+		return m, nil
+
+	case costPricesMsg:
+		if m.cost != nil {
+			m.cost = &costPanel{
+				overview: cost.Estimate(m.providerName, m.list.servers, msg.prices, false),
+				err:      msg.err,
+			}
+		}
+		return m, nil
+
+	// --- IP history and tag index ---
+	// Record public IPs, labels and backups whenever they are fetched,
+	// then let the active child handle the message as usual.
+
+	case serversLoadedMsg:
+		if m.tags != nil {
+			_ = m.tags.RecordServers(m.indexKey(), msg.servers)
+		}
+		updated, cmd := m.updateChild(msg)
+		return updated, tea.Batch(cmd, m.recordIPs(msg.servers))
+
+	case backupsLoadedMsg:
+		if m.tags != nil {
+			_ = m.tags.RecordBackups(m.indexKey(), msg.server, msg.backups)
+		}
+		return m.updateChild(msg)
+
+	case serverDetailLoadedMsg:
+		updated, cmd := m.updateChildDirect(msg)
+		if msg.server != nil {
+			cmd = tea.Batch(cmd, m.loadIPHistory(*msg.server, true))
+		}
+		return updated, cmd
+
+	case ipHistoryLoadedMsg:
+		if m.view != appViewShow || m.show.server == nil || m.show.server.ID != msg.serverID {
+			return m, nil
+		}
+		m.show.previousIPs = msg.previous
+		if len(msg.previous) == 0 || m.show.staleDNSQueried {
+			return m, nil
+		}
+		m.show.staleDNSQueried = true
+		return m, m.findStaleDNS(msg.serverID, msg.previous)
+
+	case staleDNSMsg:
+		// A failed lookup keeps the generic warning.
+		if msg.err == nil && m.view == appViewShow && m.show.server != nil && m.show.server.ID == msg.serverID {
+			m.show.staleDNS = msg.stale
+		}
+		return m, nil
+
 	// --- Toggle overlay ---
 
 	case requestToggleMsg:
@@ -269,8 +785,74 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.overlay, cmd = m.overlay.StartToggle(msg.server)
 		return m, cmd
 
+	case requestRebootMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartReboot(msg.server)
+		return m, cmd
+
+	case requestResizeMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartResize(msg.server, msg.serverType)
+		updated, showCmd := m.switchToShow(msg.server)
+		return updated, tea.Batch(cmd, showCmd)
+
+	case requestBackupsToggleMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartBackups(msg.server, !msg.server.Backups)
+		return m, cmd
+
+	case requestRestoreMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartRestore(msg.server, msg.backup)
+		updated, showCmd := m.switchToShow(msg.server)
+		return updated, tea.Batch(cmd, showCmd)
+
+	case requestFloatingIPMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartFloatingIP(msg.server, msg.ip, msg.assign)
+		updated, showCmd := m.switchToShow(msg.server)
+		return updated, tea.Batch(cmd, showCmd)
+
+	case requestReverseDNSMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartReverseDNS(msg.server, msg.ip, msg.hostname, msg.previous)
+		updated, showCmd := m.switchToShow(msg.server)
+		return updated, tea.Batch(cmd, showCmd)
+
+	case requestLabelsMsg:
+		var cmd tea.Cmd
+		m.overlay, cmd = m.overlay.StartLabels(msg.server, msg.labels, msg.previous)
+		updated, showCmd := m.switchToShow(msg.server)
+		return updated, tea.Batch(cmd, showCmd)
+
+	case requestTransferMsg:
+		return m.startTransfer(msg)
+
+	case requestBulkMsg:
+		return m.startBulk(msg)
+
+	case groupResolvedMsg:
+		return m.handleGroupResolved(msg)
+
+	case requestPrefetchMsg:
+		return m.handlePrefetchRequest(msg)
+
+	case serverPrefetchedMsg:
+		m.prefetched.Put(msg.server)
+		return m, nil
+
+	case requestBroadcastMsg:
+		return m, openBroadcast(m.prefsSvc, m.providerName, msg.servers)
+
+	case broadcastReadyMsg:
+		return m.handleBroadcastReady(msg)
+
+	case broadcastFinishedMsg:
+		return m.handleBroadcastFinished(msg)
+
 	case opToggleInitiatedMsg, opToggleErrorMsg, opPollTickMsg,
-		opPollResultMsg, opPollErrorMsg, opDismissMsg:
+		opPollResultMsg, opPollErrorMsg, opDismissMsg, opDeleteResultMsg,
+		opTransferProgressMsg, opTransferResultMsg:
 		return m.updateOverlay(msg)
 
 	// --- SSH exec ---
@@ -281,6 +863,9 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case sshFinishedMsg:
 		return m.handleSSHFinished(msg)
 
+	case sshSpawnedMsg:
+		return m.handleSSHSpawned(msg)
+
 	case clearHostKeyMsg:
 		return m.handleClearHostKey(msg)
 
@@ -294,37 +879,26 @@ func (m serverAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m.updateChild(msg)
 }
 
-// updateOverlay delegates a message to the overlay and processes any
-// completed operations (e.g. refreshing the active child view).
+// updateOverlay delegates a message to the overlay and publishes an event
+// for each completed operation so subscribed views can refresh.
 func (m serverAppModel) updateOverlay(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var outcomes []opCompletedEvent
 	m.overlay, cmd, outcomes = m.overlay.Update(msg)
 
-	var cmds []tea.Cmd
-	if cmd != nil {
-		cmds = append(cmds, cmd)
-	}
-
-	for _, ev := range outcomes {
-		if ev.Success {
-			switch m.view {
-			case appViewList:
-				// Set loading state before triggering refresh to ensure
-				// footer renders correctly during the transition.
-				m.list.loading = true
-				m.list.err = nil
-				m.list.status = "" // Clear any previous status message
-				cmds = append(cmds, tea.Batch(m.list.spinner.Tick, m.list.fetchServers()))
-			case appViewShow:
-				if m.show.server != nil {
-					m.show.serverID = m.show.server.ID
-					m.show.loading = true
-					m.show.err = nil
-					m.show.status = "" // Clear any previous status message
-					cmds = append(cmds, tea.Batch(m.show.spinner.Tick, m.show.fetchServer()))
-				}
-			}
+	cmds := []tea.Cmd{cmd}
+	for _, outcome := range outcomes {
+		cmds = append(cmds, announce(outcomeSentence(outcome)))
+		if outcome.Success && !outcome.Unchanged {
+			ev := outcome.resourceEvent()
+			m.deletions.Observe(ev)
+			m.prefetched.Forget(ev.ID)
+			cmds = append(cmds, events.Publish(ev))
+		}
+		if outcome.Success && outcome.Undo != nil {
+			var cmd tea.Cmd
+			m, cmd = m.offerUndo(outcome)
+			cmds = append(cmds, cmd)
 		}
 	}
 
@@ -378,12 +952,57 @@ func (m serverAppModel) updateChildDirect(msg tea.Msg) (serverAppModel, tea.Cmd)
 		updated, cmd := m.ssh.Update(msg)
 		m.ssh = updated.(serverSSHModel)
 		return m, cmd
+	case appViewLogs:
+		updated, cmd := m.logs.Update(msg)
+		m.logs = updated.(serverLogsModel)
+		return m, cmd
+	case appViewResize:
+		updated, cmd := m.resize.Update(msg)
+		m.resize = updated.(serverResizeModel)
+		return m, cmd
+	case appViewBackups:
+		updated, cmd := m.backups.Update(msg)
+		m.backups = updated.(serverBackupsModel)
+		return m, cmd
+	case appViewFloatingIPs:
+		updated, cmd := m.floatingIPs.Update(msg)
+		m.floatingIPs = updated.(serverFloatingIPsModel)
+		return m, cmd
+	case appViewReverseDNS:
+		updated, cmd := m.reverseDNS.Update(msg)
+		m.reverseDNS = updated.(serverReverseDNSModel)
+		return m, cmd
+	case appViewLabels:
+		updated, cmd := m.labels.Update(msg)
+		m.labels = updated.(serverLabelsModel)
+		return m, cmd
+	case appViewDNSAttach:
+		updated, cmd := m.dnsAttach.Update(msg)
+		m.dnsAttach = updated.(serverDNSAttachModel)
+		return m, cmd
+	case appViewTransfer:
+		updated, cmd := m.transfer.Update(msg)
+		m.transfer = updated.(serverTransferModel)
+		return m, cmd
+	case appViewMetrics:
+		updated, cmd := m.dashboard.Update(msg)
+		m.dashboard = updated.(serverMetricsModel)
+		return m, cmd
+	case appViewGroup:
+		updated, cmd := m.group.Update(msg)
+		m.group = updated.(groupProgressModel)
+		return m, cmd
 	case appViewAction:
 		return m.updateActionDirect(msg)
 	}
 	return m, nil
 }
 
+// ViewName reports the current view for crash reports.
+func (m serverAppModel) ViewName() string {
+	return appViewNames[m.view]
+}
+
 // updateActionDirect handles messages for the action view and returns
 // the concrete serverAppModel type.
 func (m serverAppModel) updateActionDirect(msg tea.Msg) (serverAppModel, tea.Cmd) {
@@ -404,12 +1023,60 @@ func (m serverAppModel) View() string {
 		view = m.create.View()
 	case appViewSSH:
 		view = m.ssh.View()
+	case appViewLogs:
+		view = m.logs.View()
+	case appViewResize:
+		view = m.resize.View()
+	case appViewBackups:
+		view = m.backups.View()
+	case appViewFloatingIPs:
+		view = m.floatingIPs.View()
+	case appViewReverseDNS:
+		view = m.reverseDNS.View()
+	case appViewLabels:
+		view = m.labels.View()
+	case appViewDNSAttach:
+		view = m.dnsAttach.View()
+	case appViewTransfer:
+		view = m.transfer.View()
+	case appViewMetrics:
+		view = m.dashboard.View()
+	case appViewGroup:
+		view = m.group.View()
 	case appViewAction:
 		view = m.renderAction()
 	}
+	if m.search != nil {
+		view = m.renderSearch()
+	}
+	if m.projects != nil {
+		view = m.renderProjectPicker()
+	}
+	if m.groups != nil {
+		view = m.renderGroupPicker()
+	}
+	pickerOpen := m.search != nil || m.projects != nil || m.groups != nil
+	if m.whatsNew != nil && m.view == appViewList && !pickerOpen {
+		view = m.renderWhatsNew()
+	}
+	if m.cost != nil && m.view == appViewList && !pickerOpen {
+		view = m.renderCostPanel()
+	}
+	if m.reauth != nil {
+		view = m.renderReauth()
+	}
+
+	// Show the provider status banner in place of the header's divider,
+	// or else the session's project.
+	if m.statusBanner != "" {
+		view = composeBanner(view, components.Banner(m.width, m.statusBanner))
+	} else if m.project != "" {
+		view = composeBanner(view, projectDivider(m.width, m.project))
+	}
 
-	// Composite the operations overlay on top of the child view.
-	if m.overlay.HasAny() {
+	// Composite the operations overlay on top of the child view. The
+	// accessible renderer lists operations in the footer line instead.
+	if m.overlay.HasAny() && !styles.Accessible() {
 		overlayStr := m.overlay.View(m.width, m.height)
 		view = composeOverlay(view, overlayStr, m.width, m.height)
 	}
@@ -420,9 +1087,36 @@ func (m serverAppModel) View() string {
 	// from the prior frame.
 	view = padToHeight(view, m.width, m.height)
 
+	if m.overlay.HasAny() && styles.Accessible() {
+		view = composeFooterDivider(view, ansi.Truncate(m.overlay.PlainView(), m.width, ""))
+	}
+
+	// The debug line takes the place of the footer's top border.
+	if m.showLatency {
+		view = composeFooterDivider(view, latencyDivider(m.width, m.latency.Summary(m.view)))
+	}
+	// The undo toast is short-lived and takes the line over from it.
+	if m.canUndo() {
+		view = composeFooterDivider(view, undoDivider(m.width, m.toast))
+	}
+
 	return view
 }
 
+// composeBanner replaces the header's divider line (the second line of
+// the view) with the banner so the layout height is unchanged.
+func composeBanner(view, banner string) string {
+	if banner == "" {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	if len(lines) < 2 {
+		return view
+	}
+	lines[1] = banner
+	return strings.Join(lines, "\n")
+}
+
 // padToHeight ensures the view string has exactly `height` lines by
 // appending blank lines if necessary. This prevents ghost rendering
 // artifacts when the terminal's alt screen buffer retains content from
@@ -443,17 +1137,71 @@ func padToHeight(view string, width, height int) string {
 func (m serverAppModel) switchToList() (tea.Model, tea.Cmd) {
 	m.view = appViewList
 	m.list = newServerListModel(m.provider, m.providerName)
+	m.list.deletions = m.deletions
 	m.list.width = m.width
 	m.list.height = m.height
 	return m, m.list.Init()
 }
 
 func (m serverAppModel) switchToShow(server domain.Server) (tea.Model, tea.Cmd) {
+	if prefetched, ok := m.prefetched.Get(server.ID); ok {
+		server = *prefetched
+	}
 	m.view = appViewShow
-	m.show = newServerShowDirect(m.provider, m.providerName, &server)
+	m.show = newServerShowDirect(m.provider, m.providerName, &server, m.metrics)
 	m.show.width = m.width
 	m.show.height = m.height
-	return m, m.show.Init()
+	return m, tea.Batch(m.show.Init(), m.loadIPHistory(server, false))
+}
+
+// recordIPs records the servers' public IPs in the local IP history in
+// the background.
+func (m serverAppModel) recordIPs(servers []domain.Server) tea.Cmd {
+	ipHistory := m.ipHistory
+	if ipHistory == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		ipHistory.RecordServers(servers)
+		return nil
+	}
+}
+
+// loadIPHistory reads the server's previous IPs from the local IP
+// history, first recording its current ones when record is set.
+func (m serverAppModel) loadIPHistory(server domain.Server, record bool) tea.Cmd {
+	ipHistory := m.ipHistory
+	if ipHistory == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if record {
+			ipHistory.RecordServers([]domain.Server{server})
+		}
+		return ipHistoryLoadedMsg{serverID: server.ID, previous: ipHistory.PreviousIPs(server)}
+	}
+}
+
+// findStaleDNS looks for records at the DNS provider that still point at
+// the server's previous IPs.
+func (m serverAppModel) findStaleDNS(serverID string, previous []iphistory.IPRecord) tea.Cmd {
+	return func() tea.Msg {
+		dns, err := m.dnsProvider()
+		if err != nil {
+			return staleDNSMsg{serverID: serverID, err: err}
+		}
+		ips := make([]string, len(previous))
+		for i, rec := range previous {
+			ips[i] = rec.IP
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		records, err := attach.Pointing(ctx, dns, ips)
+		if err != nil {
+			return staleDNSMsg{serverID: serverID, err: err}
+		}
+		return staleDNSMsg{serverID: serverID, stale: &staleDNS{provider: dns.GetDisplayName(), records: records}}
+	}
 }
 
 func (m serverAppModel) switchToDelete(server domain.Server) (tea.Model, tea.Cmd) {
@@ -482,11 +1230,10 @@ func (m serverAppModel) switchToCreate() (tea.Model, tea.Cmd) {
 }
 
 func (m serverAppModel) switchToSSH(server domain.Server) (tea.Model, tea.Cmd) {
-	// Resolve IP address (IPv4 preferred, IPv6 fallback).
-	ipAddress := server.PublicIPv4
-	if ipAddress == "" {
-		ipAddress = server.PublicIPv6
-	}
+	// Resolve IP address (IPv4 preferred, IPv6 fallback; private IPv4
+	// when connecting through a bastion).
+	via := bastion.Lookup(m.prefsSvc, m.providerName, server)
+	ipAddress, _ := remote.HostVia(server, via)
 	if ipAddress == "" {
 		// No IP available — return to show with error.
 		m.view = appViewShow
@@ -503,11 +1250,210 @@ func (m serverAppModel) switchToSSH(server domain.Server) (tea.Model, tea.Cmd) {
 
 	m.view = appViewSSH
 	m.ssh = newServerSSHModel(&server, m.providerName, ipAddress, defaultUsername)
+	m.ssh.persistent = m.persistentSession(server)
+	m = m.restoreSSHChoices(server)
+	m.ssh.provider = m.provider
+	m.ssh.bastion = via
 	m.ssh.width = m.width
 	m.ssh.height = m.height
 	return m, m.ssh.Init()
 }
 
+// restoreSSHChoices fills the SSH view's port and identity file with the
+// ones last used for server, and offers the private keys found in ~/.ssh.
+func (m serverAppModel) restoreSSHChoices(server domain.Server) serverAppModel {
+	var identity string
+	var port int
+	if m.prefsSvc != nil {
+		identity = m.prefsSvc.GetSSHIdentity(m.providerName, server.ID)
+		port = m.prefsSvc.GetSSHPort(m.providerName, server.ID)
+	}
+	m.ssh.port = newSSHPortInput(port)
+	m.ssh = m.ssh.withIdentities(sshkeys.PrivateKeys(), identity)
+	return m
+}
+
+// persistentSession reports whether SSH logins to server are set to run
+// inside a remote tmux session.
+func (m serverAppModel) persistentSession(server domain.Server) bool {
+	return m.prefsSvc != nil && m.prefsSvc.GetPersistentSession(m.providerName, server.ID)
+}
+
+func (m serverAppModel) switchToLogs(server domain.Server) (tea.Model, tea.Cmd) {
+	// Logs are read over SSH with the same username the user last
+	// connected with.
+	conn := remote.Conn{Bastion: bastion.Lookup(m.prefsSvc, m.providerName, server)}
+	if m.prefsSvc != nil {
+		conn.Username = m.prefsSvc.GetSSHUser(m.providerName, server.ID)
+	}
+
+	m.view = appViewLogs
+	m.logs = newServerLogsModel(m.provider, m.providerName, &server, conn)
+	m.logs.width = m.width
+	m.logs.height = m.height
+	return m, m.logs.Init()
+}
+
+func (m serverAppModel) switchToResize(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewResize
+	m.resize = newServerResizeModel(m.provider, m.providerName, &server)
+	m.resize.width = m.width
+	m.resize.height = m.height
+	return m, m.resize.Init()
+}
+
+func (m serverAppModel) switchToBackups(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewBackups
+	m.backups = newServerBackupsModel(m.provider, m.providerName, &server)
+	m.backups.width = m.width
+	m.backups.height = m.height
+	return m, m.backups.Init()
+}
+
+func (m serverAppModel) switchToFloatingIPs(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewFloatingIPs
+	m.floatingIPs = newServerFloatingIPsModel(m.provider, m.providerName, &server)
+	m.floatingIPs.width = m.width
+	m.floatingIPs.height = m.height
+	return m, m.floatingIPs.Init()
+}
+
+func (m serverAppModel) switchToReverseDNS(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewReverseDNS
+	m.reverseDNS = newServerReverseDNSModel(m.provider, m.providerName, &server)
+	m.reverseDNS.width = m.width
+	m.reverseDNS.height = m.height
+	return m, m.reverseDNS.Init()
+}
+
+func (m serverAppModel) switchToLabels(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewLabels
+	m.labels = newServerLabelsModel(m.providerName, &server)
+	m.labels.width = m.width
+	m.labels.height = m.height
+	return m, m.labels.Init()
+}
+
+func (m serverAppModel) switchToDNSAttach(server domain.Server) (tea.Model, tea.Cmd) {
+	dns, err := m.dnsProvider()
+	m.view = appViewDNSAttach
+	m.dnsAttach = newServerDNSAttachModel(dns, err, m.providerName, &server)
+	m.dnsAttach.width = m.width
+	m.dnsAttach.height = m.height
+	return m, m.dnsAttach.Init()
+}
+
+// dnsProvider returns the DNS provider that hosts server hostnames: the
+// one set with 'vpsm config set dns-provider', or the session's provider.
+func (m serverAppModel) dnsProvider() (dnsdomain.Provider, error) {
+	return dnsProviderFor(m.providerName)
+}
+
+// dnsProviderFor returns the DNS provider that hosts the hostnames of
+// servers on serverProvider.
+func dnsProviderFor(serverProvider string) (dnsdomain.Provider, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	name := cfg.DNSProviderFor(serverProvider)
+	store := auth.DefaultStore()
+	if name != serverProvider {
+		store = auth.WithProject(auth.NewKeyringStore(auth.ServiceName), cfg.ActiveProject(name))
+	}
+	return dnsproviders.Get(name, store)
+}
+
+func (m serverAppModel) switchToTransfer(server domain.Server) (tea.Model, tea.Cmd) {
+	session, err := m.savedSession(server)
+	if err != nil {
+		m.view = appViewShow
+		m.show.status = err.Error()
+		m.show.statusIsError = true
+		return m, nil
+	}
+
+	m.view = appViewTransfer
+	m.transfer = newServerTransferModel(&server, m.providerName, session.User+"@"+session.Host)
+	m.transfer.width = m.width
+	m.transfer.height = m.height
+	return m, m.transfer.Init()
+}
+
+// switchToGroup shows the progress of a group action and starts it. The
+// operations overlay stays on top while the group runs.
+func (m serverAppModel) switchToGroup(msg groupResolvedMsg) (tea.Model, tea.Cmd) {
+	m.view = appViewGroup
+	m.group = newGroupProgressModel(m.provider, m.providerName, msg.group, msg.servers, msg.op, msg.opts)
+	m.group.embedded = true
+	m.group.width = m.width
+	m.group.height = m.height
+	m.group.syncViewport()
+	return m, m.group.Init()
+}
+
+func (m serverAppModel) switchToMetrics(server domain.Server) (tea.Model, tea.Cmd) {
+	m.view = appViewMetrics
+	m.dashboard = newServerMetricsModel(m.metrics, m.providerName, &server)
+	m.dashboard.width = m.width
+	m.dashboard.height = m.height
+	m.dashboard.syncViewport()
+	return m, m.dashboard.Init()
+}
+
+// startTransfer runs a confirmed file copy in the operations overlay and
+// returns to the server's detail view.
+func (m serverAppModel) startTransfer(msg requestTransferMsg) (tea.Model, tea.Cmd) {
+	session, err := m.savedSession(msg.server)
+	if err != nil {
+		m.transfer.local.SetErr(err.Error())
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.overlay, cmd = m.overlay.StartTransfer(msg.server, fileTransfer{
+		session:  session,
+		download: msg.download,
+		local:    msg.local,
+		remote:   msg.remote,
+	})
+	updated, showCmd := m.switchToShow(msg.server)
+	return updated, tea.Batch(cmd, showCmd)
+}
+
+// savedSession returns a built-in client session for server with the
+// username, key, port, ssh options and bastion saved for it.
+func (m serverAppModel) savedSession(server domain.Server) (*nativessh.Session, error) {
+	via := bastion.Lookup(m.prefsSvc, m.providerName, server)
+	host, err := remote.HostVia(server, via)
+	if err != nil {
+		return nil, err
+	}
+
+	username, identity, port, serverOptions := remote.DefaultUser, "", 0, ""
+	if m.prefsSvc != nil {
+		if saved := m.prefsSvc.GetSSHUser(m.providerName, server.ID); saved != "" {
+			username = saved
+		}
+		identity = m.prefsSvc.GetSSHIdentity(m.providerName, server.ID)
+		port = m.prefsSvc.GetSSHPort(m.providerName, server.ID)
+		serverOptions = m.prefsSvc.GetSSHOptions(m.providerName, server.ID)
+	}
+
+	session := &nativessh.Session{User: username, Host: host}
+	session.ApplyArgs(remote.UserArgs(serverOptions, loadSSHOptions()))
+	if identity != "" {
+		session.Identities = append([]string{identity}, session.Identities...)
+	}
+	if port != 0 {
+		session.Port = port
+	}
+	if session.Jump == "" {
+		session.Jump = via
+	}
+	return session, nil
+}
+
 // --- API actions ---
 
 func (m serverAppModel) startDeleteAction(server domain.Server) (tea.Model, tea.Cmd) {
@@ -516,9 +1462,13 @@ func (m serverAppModel) startDeleteAction(server domain.Server) (tea.Model, tea.
 	m.actionStatus = ""
 	m.actionIsError = false
 
-	provider := m.provider
+	provider, providerName := m.provider, m.providerName
 	return m, tea.Batch(m.actionSpinner.Tick, func() tea.Msg {
 		err := provider.DeleteServer(context.Background(), server.ID)
+		if err == nil {
+			dns, _ := dnsProviderFor(providerName)
+			archivesvc.ArchiveDeleted(context.Background(), providerName, server, dns)
+		}
 		return deleteResultMsg{server: server, err: err}
 	})
 }
@@ -529,10 +1479,13 @@ func (m serverAppModel) startCreateAction(opts domain.CreateServerOpts) (tea.Mod
 	m.actionStatus = ""
 	m.actionIsError = false
 
-	provider := m.provider
+	provider, providerName := m.provider, m.providerName
 	return m, tea.Batch(m.actionSpinner.Tick, func() tea.Msg {
 		server, err := provider.CreateServer(context.Background(), opts)
-		return createResultMsg{server: server, err: err}
+		if err == nil && server != nil {
+			archivesvc.RememberCreated(providerName, *server, opts)
+		}
+		return createResultMsg{opts: opts, server: server, err: err}
 	})
 }
 
@@ -546,8 +1499,11 @@ func (m serverAppModel) handleDeleteResult(msg deleteResultMsg) (tea.Model, tea.
 	}
 
 	// Go straight back to the list with a success status.
+	m.deletions.Mark(msg.server.ID)
+	m.prefetched.Forget(msg.server.ID)
 	m.view = appViewList
 	m.list = newServerListModel(m.provider, m.providerName)
+	m.list.deletions = m.deletions
 	m.list.width = m.width
 	m.list.height = m.height
 	m.list.persistentStatus = fmt.Sprintf("Server %q deleted successfully", msg.server.Name)
@@ -565,6 +1521,7 @@ func (m serverAppModel) handleCreateResult(msg createResultMsg) (tea.Model, tea.
 	// Go back to the list with a success status.
 	m.view = appViewList
 	m.list = newServerListModel(m.provider, m.providerName)
+	m.list.deletions = m.deletions
 	m.list.width = m.width
 	m.list.height = m.height
 
@@ -579,19 +1536,61 @@ func (m serverAppModel) handleCreateResult(msg createResultMsg) (tea.Model, tea.
 // --- SSH handlers ---
 
 func (m serverAppModel) handleSSHRequest(msg requestSSHMsg) (tea.Model, tea.Cmd) {
-	// Persist username for this server.
+	// Persist username and session choice for this server.
 	if m.prefsSvc != nil {
 		m.prefsSvc.SetSSHUser(m.providerName, msg.server.ID, msg.username)
+		m.prefsSvc.SetPersistentSession(m.providerName, msg.server.ID, msg.persistent)
+		m.prefsSvc.SetSSHIdentity(m.providerName, msg.server.ID, msg.identity)
+		m.prefsSvc.SetSSHPort(m.providerName, msg.server.ID, msg.port)
 	}
 
-	// Build SSH command with secure options.
-	sshCmd := exec.Command("ssh",
+	// User options come first: ssh keeps the first value it sees, so the
+	// server's options override the configured default, and both
+	// override the built-in secure options.
+	var serverOptions string
+	if m.prefsSvc != nil {
+		serverOptions = m.prefsSvc.GetSSHOptions(m.providerName, msg.server.ID)
+	}
+	userArgs := remote.UserArgs(serverOptions, loadSSHOptions())
+
+	// The built-in client always runs in the current terminal.
+	if nativessh.Configured() == nativessh.ModeNative {
+		return m, nativeSSH(msg, userArgs, bastion.Lookup(m.prefsSvc, m.providerName, msg.server))
+	}
+
+	// The key and port picked in the view come before everything else:
+	// they were chosen for this server just now.
+	var args []string
+	if msg.identity != "" {
+		args = append(args, "-i", msg.identity)
+	}
+	if msg.port != 0 {
+		args = append(args, "-p", strconv.Itoa(msg.port))
+	}
+	args = append(args, userArgs...)
+	args = append(args,
 		"-o", "StrictHostKeyChecking=accept-new",
 		"-o", "ConnectTimeout=10",
 		"-o", "ServerAliveInterval=60",
 		"-o", "ServerAliveCountMax=3",
-		fmt.Sprintf("%s@%s", msg.username, msg.ipAddress),
 	)
+	args = append(args, remote.RouteArgs(bastion.Lookup(m.prefsSvc, m.providerName, msg.server))...)
+	if msg.persistent {
+		// tmux needs a terminal on the remote side.
+		args = append(args, "-t")
+	}
+	args = append(args, fmt.Sprintf("%s@%s", msg.username, msg.ipAddress))
+	if msg.persistent {
+		args = append(args, remote.PersistentSession)
+	}
+
+	// Inside tmux the session can open beside the TUI instead of
+	// taking over its terminal.
+	if mode := loadSSHLaunch(); mode != tmux.ModeExec && tmux.Active() {
+		return m, spawnSSH(mode, msg.server, args)
+	}
+
+	sshCmd := exec.Command("ssh", args...)
 	sshCmd.Stdin = os.Stdin
 	sshCmd.Stdout = os.Stdout
 
@@ -643,17 +1642,51 @@ func (m serverAppModel) handleSSHRequest(msg requestSSHMsg) (tea.Model, tea.Cmd)
 	})
 }
 
+// nativeSSH hands the terminal to a login with the built-in SSH client.
+func nativeSSH(msg requestSSHMsg, userArgs []string, via string) tea.Cmd {
+	session := &nativessh.Session{User: msg.username, Host: msg.ipAddress}
+	session.ApplyArgs(userArgs)
+	if msg.identity != "" {
+		session.Identities = append([]string{msg.identity}, session.Identities...)
+	}
+	if msg.port != 0 {
+		session.Port = msg.port
+	}
+	// Like ssh, the first -J wins over the configured bastion.
+	if session.Jump == "" {
+		session.Jump = via
+	}
+	if msg.persistent {
+		session.Command = remote.PersistentSession
+	}
+
+	server := msg.server
+	return tea.Exec(session, func(err error) tea.Msg {
+		finished := sshFinishedMsg{server: server, username: msg.username, ipAddress: msg.ipAddress, err: err, errKind: sshErrNone}
+		switch {
+		case err == nil:
+		case errors.Is(err, nativessh.ErrHostKeyChanged):
+			finished.errKind = sshErrHostKeyConflict
+			finished.errDetail = "Host key has changed (IP may have been reused by a new server)"
+		default:
+			finished.errKind = sshErrGeneric
+			finished.errDetail = fmt.Sprintf("SSH connection failed: %v", err)
+		}
+		return finished
+	})
+}
+
 func (m serverAppModel) handleSSHFinished(msg sshFinishedMsg) (tea.Model, tea.Cmd) {
 	if msg.err == nil {
 		// SSH succeeded — navigate back to show view with refresh.
 		m.view = appViewShow
-		m.show = newServerShowDirect(m.provider, m.providerName, &msg.server)
+		m.show = newServerShowDirect(m.provider, m.providerName, &msg.server, m.metrics)
 		m.show.width = m.width
 		m.show.height = m.height
 		m.show.loading = true
 		m.show.serverID = msg.server.ID
 		m.show.err = nil
-		return m, tea.Batch(m.show.spinner.Tick, m.show.fetchServer())
+		return m, repaintAfterExec(tea.Batch(m.show.spinner.Tick, m.show.fetchServer()))
 	}
 
 	// SSH failed — branch on error kind.
@@ -669,14 +1702,17 @@ func (m serverAppModel) handleSSHFinished(msg sshFinishedMsg) (tea.Model, tea.Cm
 			msg.errDetail,
 			true, // hostKeyConflict
 		)
+		m.ssh.persistent = m.persistentSession(msg.server)
+		m = m.restoreSSHChoices(msg.server)
+		m.ssh.provider = m.provider
 		m.ssh.width = m.width
 		m.ssh.height = m.height
-		return m, m.ssh.Init()
+		return m, repaintAfterExec(m.ssh.Init())
 
 	default:
 		// Generic SSH error — navigate to show view with persistent error status.
 		m.view = appViewShow
-		m.show = newServerShowDirect(m.provider, m.providerName, &msg.server)
+		m.show = newServerShowDirect(m.provider, m.providerName, &msg.server, m.metrics)
 		m.show.width = m.width
 		m.show.height = m.height
 		m.show.persistentStatus = msg.errDetail
@@ -684,15 +1720,69 @@ func (m serverAppModel) handleSSHFinished(msg sshFinishedMsg) (tea.Model, tea.Cm
 		m.show.loading = true
 		m.show.serverID = msg.server.ID
 		m.show.err = nil
-		return m, tea.Batch(m.show.spinner.Tick, m.show.fetchServer())
+		return m, repaintAfterExec(tea.Batch(m.show.spinner.Tick, m.show.fetchServer()))
 	}
 }
 
+// spawnSSH opens ssh in a new tmux window or pane. tmux returns as soon
+// as the window exists, so the TUI stays in the foreground.
+func spawnSSH(mode tmux.Mode, server domain.Server, args []string) tea.Cmd {
+	return func() tea.Msg {
+		cmd, err := tmux.Command(mode, server.Name, append([]string{"ssh"}, args...)...)
+		if err == nil {
+			err = runTmux(cmd)
+		}
+		return sshSpawnedMsg{server: server, mode: mode, err: err}
+	}
+}
+
+func (m serverAppModel) handleSSHSpawned(msg sshSpawnedMsg) (tea.Model, tea.Cmd) {
+	m.view = appViewShow
+	m.show = newServerShowDirect(m.provider, m.providerName, &msg.server, m.metrics)
+	m.show.width = m.width
+	m.show.height = m.height
+	if msg.err != nil {
+		m.show.persistentStatus = fmt.Sprintf("Failed to open SSH session in tmux: %v", msg.err)
+		m.show.statusIsError = true
+	} else {
+		where := "window"
+		if msg.mode == tmux.ModePane {
+			where = "pane"
+		}
+		m.show.persistentStatus = fmt.Sprintf("Opened SSH session to %q in a new tmux %s", msg.server.Name, where)
+	}
+	m.show.loading = true
+	m.show.serverID = msg.server.ID
+	m.show.err = nil
+	return m, tea.Batch(m.show.spinner.Tick, m.show.fetchServer())
+}
+
+// repaintAfterExec re-measures and fully repaints the terminal once an
+// external process hands it back. Bubbletea ignores SIGWINCH while the
+// process runs, so the window may have been resized unnoticed, and the
+// screen no longer holds the renderer's last frame.
+func repaintAfterExec(cmd tea.Cmd) tea.Cmd {
+	return tea.Batch(cmd, tea.WindowSize(), tea.ClearScreen)
+}
+
 func (m serverAppModel) handleClearHostKey(msg clearHostKeyMsg) (tea.Model, tea.Cmd) {
-	// Remove the stale SSH host key for this IP address.
-	cmd := exec.Command("ssh-keygen", "-R", msg.ipAddress)
-	// Run synchronously (fast operation).
-	if err := cmd.Run(); err != nil {
+	// Remove the stale SSH host key for this IP address, from the file
+	// of whichever client connected. Runs synchronously (fast operation).
+	var err error
+	if nativessh.Configured() == nativessh.ModeNative {
+		var path string
+		if path, err = nativessh.KnownHostsPath(); err == nil {
+			err = nativessh.Forget(path, msg.ipAddress)
+		}
+	} else {
+		host := msg.ipAddress
+		if msg.port != 0 && msg.port != 22 {
+			// ssh records hosts on other ports as [host]:port.
+			host = fmt.Sprintf("[%s]:%d", msg.ipAddress, msg.port)
+		}
+		err = exec.Command("ssh-keygen", "-R", host).Run()
+	}
+	if err != nil {
 		// If ssh-keygen fails, show error and return to SSH view without retry.
 		m.view = appViewSSH
 		m.ssh = newServerSSHModelWithError(
@@ -703,6 +1793,9 @@ func (m serverAppModel) handleClearHostKey(msg clearHostKeyMsg) (tea.Model, tea.
 			fmt.Sprintf("Failed to clear host key: %v", err),
 			false, // not a host key conflict anymore, just an error
 		)
+		m.ssh.persistent = m.persistentSession(msg.server)
+		m = m.restoreSSHChoices(msg.server)
+		m.ssh.provider = m.provider
 		m.ssh.width = m.width
 		m.ssh.height = m.height
 		return m, m.ssh.Init()
@@ -711,9 +1804,12 @@ func (m serverAppModel) handleClearHostKey(msg clearHostKeyMsg) (tea.Model, tea.
 	// Host key cleared — immediately retry SSH connection.
 	return m, func() tea.Msg {
 		return requestSSHMsg{
-			server:    msg.server,
-			username:  msg.username,
-			ipAddress: msg.ipAddress,
+			server:     msg.server,
+			username:   msg.username,
+			ipAddress:  msg.ipAddress,
+			persistent: msg.persistent,
+			identity:   msg.identity,
+			port:       msg.port,
 		}
 	}
 }
@@ -747,6 +1843,52 @@ func (m serverAppModel) updateChild(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ssh = updated.(serverSSHModel)
 		return m, cmd
 
+	case appViewLogs:
+		updated, cmd := m.logs.Update(msg)
+		m.logs = updated.(serverLogsModel)
+		return m, cmd
+
+	case appViewResize:
+		updated, cmd := m.resize.Update(msg)
+		m.resize = updated.(serverResizeModel)
+		return m, cmd
+
+	case appViewBackups:
+		updated, cmd := m.backups.Update(msg)
+		m.backups = updated.(serverBackupsModel)
+		return m, cmd
+
+	case appViewFloatingIPs:
+		updated, cmd := m.floatingIPs.Update(msg)
+		m.floatingIPs = updated.(serverFloatingIPsModel)
+		return m, cmd
+	case appViewReverseDNS:
+		updated, cmd := m.reverseDNS.Update(msg)
+		m.reverseDNS = updated.(serverReverseDNSModel)
+		return m, cmd
+	case appViewLabels:
+		updated, cmd := m.labels.Update(msg)
+		m.labels = updated.(serverLabelsModel)
+		return m, cmd
+	case appViewDNSAttach:
+		updated, cmd := m.dnsAttach.Update(msg)
+		m.dnsAttach = updated.(serverDNSAttachModel)
+		return m, cmd
+
+	case appViewTransfer:
+		updated, cmd := m.transfer.Update(msg)
+		m.transfer = updated.(serverTransferModel)
+		return m, cmd
+
+	case appViewMetrics:
+		updated, cmd := m.dashboard.Update(msg)
+		m.dashboard = updated.(serverMetricsModel)
+		return m, cmd
+	case appViewGroup:
+		updated, cmd := m.group.Update(msg)
+		m.group = updated.(groupProgressModel)
+		return m, cmd
+
 	case appViewAction:
 		return m.updateAction(msg)
 	}
@@ -786,7 +1928,7 @@ func (m serverAppModel) renderAction() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "server", m.providerName)
+	header := components.HeaderTrail(m.width, serverTrail(nil), m.providerName)
 	footer := components.Footer(m.width, []components.KeyBinding{
 		{Key: "ctrl+c", Desc: "quit"},
 	})
@@ -822,21 +1964,33 @@ func (m serverAppModel) renderAction() string {
 
 func newServerListModel(provider domain.Provider, providerName string) serverListModel {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	return serverListModel{
-		provider:     provider,
-		providerName: providerName,
-		loading:      true,
-		spinner:      s,
-		embedded:     true,
+		provider:         provider,
+		providerName:     providerName,
+		loading:          true,
+		spinner:          s,
+		labelColumns:     loadLabelColumns(),
+		canSwitchProject: len(loadProjects(providerName)) > 0,
+		embedded:         true,
+	}
+}
+
+// newMetricsScheduler returns a metrics scheduler for provider, or nil if
+// it has no metrics.
+func newMetricsScheduler(provider domain.Provider) domain.MetricsProvider {
+	mp, ok := provider.(domain.MetricsProvider)
+	if !ok {
+		return nil
 	}
+	return metricsched.New(mp, metricsched.Options{})
 }
 
-func newServerShowDirect(provider domain.Provider, providerName string, server *domain.Server) serverShowModel {
+func newServerShowDirect(provider domain.Provider, providerName string, server *domain.Server, metrics domain.MetricsProvider) serverShowModel {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	vp := viewport.New(0, 0)
@@ -850,6 +2004,8 @@ func newServerShowDirect(provider domain.Provider, providerName string, server *
 		serverID:       server.ID,
 		loading:        false,
 		metricsLoading: true,
+		metricsSource:  metrics,
+		metricsRefresh: loadMetricsRefresh(),
 		spinner:        s,
 		embedded:       true,
 		viewport:       vp,
@@ -858,14 +2014,15 @@ func newServerShowDirect(provider domain.Provider, providerName string, server *
 
 func newServerDeleteModel(provider domain.Provider, providerName string, server *domain.Server) serverDeleteModel {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	m := serverDeleteModel{
-		provider:     provider,
-		providerName: providerName,
-		spinner:      s,
-		embedded:     true,
+		provider:       provider,
+		providerName:   providerName,
+		spinner:        s,
+		embedded:       true,
+		requireStopped: loadDeleteRequiresStopped(),
 	}
 
 	if server != nil {
@@ -883,77 +2040,44 @@ func newServerDeleteModel(provider domain.Provider, providerName string, server
 
 func newServerCreateModel(provider domain.CatalogProvider, providerName string, prefill domain.CreateServerOpts) serverCreateModel {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
-	ti := newCreateTextInput(prefill.Name)
-
 	return serverCreateModel{
 		provider:     provider,
 		providerName: providerName,
 		prefill:      prefill,
 		step:         stepLoading,
 		opts:         prefill,
-		nameInput:    ti,
+		name:         newServerNameInput(provider, prefill.Name),
 		loading:      true,
 		spinner:      s,
 		sshSelected:  make(map[int]struct{}),
+		labelInput:   newLabelInput(),
 		embedded:     true,
 	}
 }
 
 func newServerSSHModel(server *domain.Server, providerName string, ipAddress string, defaultUsername string) serverSSHModel {
-	ti := textinput.New()
-	ti.Placeholder = "root"
-	ti.Focus()
-	ti.CharLimit = 64
-	ti.Width = 40
-
-	if defaultUsername != "" {
-		ti.SetValue(defaultUsername)
-	}
-
 	return serverSSHModel{
-		server:        server,
-		providerName:  providerName,
-		ipAddress:     ipAddress,
-		usernameInput: ti,
-		embedded:      true,
+		server:       server,
+		providerName: providerName,
+		ipAddress:    ipAddress,
+		username:     newSSHUsernameInput(defaultUsername),
+		port:         newSSHPortInput(0),
+		embedded:     true,
 	}
 }
 
 func newServerSSHModelWithError(server *domain.Server, providerName string, ipAddress string, defaultUsername string, errorMsg string, hostKeyConflict bool) serverSSHModel {
-	ti := textinput.New()
-	ti.Placeholder = "root"
-	ti.Focus()
-	ti.CharLimit = 64
-	ti.Width = 40
-
-	if defaultUsername != "" {
-		ti.SetValue(defaultUsername)
-	}
-
 	return serverSSHModel{
 		server:          server,
 		providerName:    providerName,
 		ipAddress:       ipAddress,
-		usernameInput:   ti,
+		username:        newSSHUsernameInput(defaultUsername),
+		port:            newSSHPortInput(0),
 		errorMsg:        errorMsg,
 		hostKeyConflict: hostKeyConflict,
 		embedded:        true,
 	}
 }
-
-func newCreateTextInput(prefillName string) textinput.Model {
-	ti := textinput.New()
-	ti.Placeholder = "my-server"
-	ti.Focus()
-	ti.CharLimit = 63
-	ti.Width = 40
-
-	if prefillName != "" {
-		ti.SetValue(prefillName)
-	}
-
-	return ti
-}
@@ -0,0 +1,412 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/server/services/status"
+	iphistorysvc "nathanbeddoewebdev/vpsm/internal/services/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestServerApp_WindowTitle(t *testing.T) {
+	server := &domain.Server{ID: "1", Name: "web-1"}
+
+	m := newReauthTestApp()
+	if got, want := m.windowTitle(), "vpsm: mock"; got != want {
+		t.Errorf("list title = %q, want %q", got, want)
+	}
+
+	m.view = appViewShow
+	m.show.server = server
+	if got, want := m.windowTitle(), "vpsm: mock / web-1"; got != want {
+		t.Errorf("show title = %q, want %q", got, want)
+	}
+
+	m.view = appViewCreate
+	if got, want := m.windowTitle(), "vpsm: mock / new server"; got != want {
+		t.Errorf("create title = %q, want %q", got, want)
+	}
+}
+
+func TestServerApp_UpdateTracksWindowTitle(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, cmd := m.Update(navigateToDeleteMsg{server: domain.Server{ID: "1", Name: "web-1"}})
+	app := updated.(serverAppModel)
+	if app.title != "vpsm: mock / web-1" {
+		t.Errorf("expected title to follow navigation, got %q", app.title)
+	}
+	if cmd == nil {
+		t.Error("expected a command setting the window title")
+	}
+}
+
+func TestSpawnSSH_OpensTmuxWindow(t *testing.T) {
+	var got []string
+	orig := runTmux
+	runTmux = func(cmd *exec.Cmd) error { got = cmd.Args; return nil }
+	t.Cleanup(func() { runTmux = orig })
+
+	server := domain.Server{ID: "1", Name: "web-1"}
+	msg := spawnSSH(tmux.ModeWindow, server, []string{"root@192.0.2.1"})().(sshSpawnedMsg)
+
+	if msg.err != nil {
+		t.Fatalf("unexpected error: %v", msg.err)
+	}
+	want := "tmux new-window -n web-1 -- ssh root@192.0.2.1"
+	if strings.Join(got, " ") != want {
+		t.Errorf("tmux args = %q, want %q", strings.Join(got, " "), want)
+	}
+}
+
+func TestServerApp_UnavailableStatusPageStopsPolling(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, cmd := m.update(providerStatusMsg{status: status.Unavailable()})
+	app := updated.(serverAppModel)
+	if app.statusBanner != "" {
+		t.Errorf("banner = %q, want none", app.statusBanner)
+	}
+	if cmd != nil {
+		t.Error("expected no further status checks")
+	}
+}
+
+func TestServerApp_SSHSpawnedShowsStatus(t *testing.T) {
+	m := newReauthTestApp()
+	server := domain.Server{ID: "1", Name: "web-1"}
+
+	updated, _ := m.Update(sshSpawnedMsg{server: server, mode: tmux.ModePane})
+	app := updated.(serverAppModel)
+	if app.view != appViewShow {
+		t.Fatalf("expected show view, got %v", app.view)
+	}
+	if !strings.Contains(app.show.persistentStatus, "new tmux pane") || app.show.statusIsError {
+		t.Errorf("unexpected status %q (error=%v)", app.show.persistentStatus, app.show.statusIsError)
+	}
+
+	updated, _ = m.Update(sshSpawnedMsg{server: server, mode: tmux.ModeWindow, err: errors.New("no server running")})
+	app = updated.(serverAppModel)
+	if !app.show.statusIsError || !strings.Contains(app.show.persistentStatus, "no server running") {
+		t.Errorf("expected error status, got %q", app.show.persistentStatus)
+	}
+}
+
+func TestServerSSH_TabTogglesPersistentSession(t *testing.T) {
+	server := &domain.Server{ID: "1", Name: "web-1"}
+	m := newServerSSHModel(server, "mock", "192.0.2.1", "")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(serverSSHModel)
+	if !m.persistent {
+		t.Fatal("expected tab to turn the tmux session on")
+	}
+
+	_, cmd := m.Update(components.InputValidatedMsg{ID: sshUsernameInputID})
+	if cmd == nil {
+		t.Fatal("expected a connect command")
+	}
+	req, ok := cmd().(requestSSHMsg)
+	if !ok || !req.persistent {
+		t.Errorf("expected a persistent SSH request, got %+v", req)
+	}
+}
+
+func TestServerSSH_PortAndIdentityInRequest(t *testing.T) {
+	server := &domain.Server{ID: "1", Name: "web-1"}
+	m := newServerSSHModel(server, "mock", "192.0.2.1", "ubuntu")
+	m = m.withIdentities([]string{"/keys/deploy", "/keys/work"}, "")
+
+	var cmd tea.Cmd
+	keys := []tea.KeyMsg{
+		{Type: tea.KeyDown},
+		{Type: tea.KeyRunes, Runes: []rune("2222")},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyLeft},
+	}
+	for _, key := range keys {
+		updated, _ := m.Update(key)
+		m = updated.(serverSSHModel)
+	}
+	if m.identity != "/keys/work" {
+		t.Fatalf("expected left to wrap to the last key, got %q", m.identity)
+	}
+
+	// Enter validates the port, then the username, then connects.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for range 2 {
+		m = updated.(serverSSHModel)
+		if cmd == nil {
+			t.Fatal("expected a validation command")
+		}
+		updated, cmd = m.Update(cmd())
+	}
+	if cmd == nil {
+		t.Fatal("expected a connect command")
+	}
+	req, ok := cmd().(requestSSHMsg)
+	if !ok || req.identity != "/keys/work" || req.port != 2222 || req.username != "ubuntu" {
+		t.Errorf("expected request for ubuntu on port 2222 with /keys/work, got %+v", req)
+	}
+}
+
+func TestServerSSH_InvalidPortBlocksConnect(t *testing.T) {
+	server := &domain.Server{ID: "1", Name: "web-1"}
+	m := newServerSSHModel(server, "mock", "192.0.2.1", "")
+	m.port = newSSHPortInput(0)
+	m.port.Input.SetValue("70000")
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverSSHModel)
+	if m.port.Err() == "" || m.focus != sshFieldPort {
+		t.Errorf("expected a port error with the port focused, got err %q focus %d", m.port.Err(), m.focus)
+	}
+	for _, msg := range collectMsgs(cmd) {
+		if _, ok := msg.(components.InputValidatedMsg); ok {
+			t.Error("expected no validated input for an invalid port")
+		}
+	}
+}
+
+func TestServerSSH_RemembersIdentityOutsideSSHDir(t *testing.T) {
+	m := serverSSHModel{}.withIdentities([]string{"/keys/deploy"}, "/elsewhere/key")
+	if len(m.identities) != 2 || m.identities[0] != "/elsewhere/key" || m.identity != "/elsewhere/key" {
+		t.Errorf("expected the remembered key to stay selectable, got %v (selected %q)", m.identities, m.identity)
+	}
+
+	m = m.cycleIdentity(1)
+	m = m.cycleIdentity(1)
+	if m.identity != "" {
+		t.Errorf("expected cycling past the last key to select the default, got %q", m.identity)
+	}
+}
+
+func TestHandleSSHRequest_IdentityAndPortArgs(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	cfg := &config.Config{SSHLaunch: string(tmux.ModeWindow), SSHOptions: "-p 2200"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+
+	var got []string
+	orig := runTmux
+	runTmux = func(cmd *exec.Cmd) error { got = cmd.Args; return nil }
+	t.Cleanup(func() { runTmux = orig })
+
+	m := newReauthTestApp()
+	req := requestSSHMsg{server: domain.Server{ID: "1", Name: "web-1"}, username: "root", ipAddress: "192.0.2.1", identity: "/keys/deploy", port: 2222}
+	_, cmd := m.handleSSHRequest(req)
+	cmd()
+
+	args := strings.Join(got, " ")
+	if !strings.Contains(args, "-- ssh -i /keys/deploy -p 2222 -p 2200 ") {
+		t.Errorf("expected the picked key and port before configured options, got %q", args)
+	}
+}
+
+func TestHandleSSHRequest_PersistentSessionArgs(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	if err := (&config.Config{SSHLaunch: string(tmux.ModeWindow)}).Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+
+	var got []string
+	orig := runTmux
+	runTmux = func(cmd *exec.Cmd) error { got = cmd.Args; return nil }
+	t.Cleanup(func() { runTmux = orig })
+
+	m := newReauthTestApp()
+	server := domain.Server{ID: "1", Name: "web-1"}
+	_, cmd := m.handleSSHRequest(requestSSHMsg{server: server, username: "root", ipAddress: "192.0.2.1", persistent: true})
+	cmd()
+
+	args := strings.Join(got, " ")
+	if !strings.Contains(args, "-t root@192.0.2.1 "+remote.PersistentSession) {
+		t.Errorf("expected ssh to attach to the tmux session, got %q", args)
+	}
+}
+
+// collectMsgs runs cmd and any batched commands it returns.
+func collectMsgs(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		return []tea.Msg{msg}
+	}
+	var msgs []tea.Msg
+	for _, c := range batch {
+		msgs = append(msgs, collectMsgs(c)...)
+	}
+	return msgs
+}
+
+func TestServerApp_SSHFinishedRepaints(t *testing.T) {
+	m := newReauthTestApp()
+
+	_, cmd := m.Update(sshFinishedMsg{server: domain.Server{ID: "1", Name: "web-1"}})
+
+	want := map[string]bool{
+		fmt.Sprintf("%T", tea.WindowSize()()): false,
+		fmt.Sprintf("%T", tea.ClearScreen()):  false,
+	}
+	for _, msg := range collectMsgs(cmd) {
+		if _, ok := want[fmt.Sprintf("%T", msg)]; ok {
+			want[fmt.Sprintf("%T", msg)] = true
+		}
+	}
+	for typ, seen := range want {
+		if !seen {
+			t.Errorf("expected %s after SSH returns", typ)
+		}
+	}
+}
+
+func TestHandleSSHRequest_UserOptionsFirst(t *testing.T) {
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	cfg := &config.Config{SSHLaunch: string(tmux.ModeWindow), SSHOptions: "-o ForwardAgent=yes"}
+	if err := cfg.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+
+	var got []string
+	orig := runTmux
+	runTmux = func(cmd *exec.Cmd) error { got = cmd.Args; return nil }
+	t.Cleanup(func() { runTmux = orig })
+
+	m := newReauthTestApp()
+	_, cmd := m.handleSSHRequest(requestSSHMsg{server: domain.Server{ID: "1", Name: "web-1"}, username: "root", ipAddress: "192.0.2.1"})
+	cmd()
+
+	args := strings.Join(got, " ")
+	if !strings.Contains(args, "-- ssh -o ForwardAgent=yes -o StrictHostKeyChecking=accept-new") {
+		t.Errorf("expected configured options before the built-in ones, got %q", args)
+	}
+}
+
+func TestServerApp_ShowViewFollowsServerEvents(t *testing.T) {
+	m := newReauthTestApp()
+	updated, _ := m.Update(navigateToShowMsg{server: domain.Server{ID: "1", Name: "web-1"}})
+	m = updated.(serverAppModel)
+	m.show.server = &domain.Server{ID: "1", Name: "web-1"}
+	m.show.loading = false
+
+	updated, cmd := m.Update(events.Msg{Event: events.Event{Resource: events.ResourceServer, Kind: events.Changed, ID: "2"}})
+	if updated.(serverAppModel).show.loading || cmd != nil {
+		t.Error("expected an event for another server to be ignored")
+	}
+
+	updated, _ = m.Update(events.Msg{Event: events.Event{Resource: events.ResourceServer, Kind: events.Changed, ID: "1"}})
+	if !updated.(serverAppModel).show.loading {
+		t.Error("expected the shown server to be refetched after it changed")
+	}
+
+	_, cmd = m.Update(events.Msg{Event: events.Event{Resource: events.ResourceServer, Kind: events.Deleted, ID: "1"}})
+	if cmd == nil {
+		t.Fatal("expected a command after the shown server was deleted")
+	}
+	if _, ok := cmd().(navigateBackMsg); !ok {
+		t.Error("expected the show view to navigate back once its server is deleted")
+	}
+}
+
+func TestOpCompletedEvent_ResourceEvent(t *testing.T) {
+	ev := opCompletedEvent{Success: true, ServerID: "1", ServerName: "web-1", Verb: "deleted"}.resourceEvent()
+	if ev.Resource != events.ResourceServer || ev.Kind != events.Deleted || ev.ID != "1" {
+		t.Errorf("resourceEvent() = %+v, want deleted server 1", ev)
+	}
+	if got := (opCompletedEvent{Verb: "stopped"}).resourceEvent().Kind; got != events.Changed {
+		t.Errorf("stopped kind = %q, want changed", got)
+	}
+}
+
+// memIPRepo is an in-memory iphistory.Repository.
+type memIPRepo struct {
+	records  []iphistory.IPRecord
+	recorded int
+}
+
+func (r *memIPRepo) Record(provider, serverID string, ips []string, seenAt time.Time) error {
+	r.recorded++
+	for _, ip := range ips {
+		r.records = append(r.records, iphistory.IPRecord{Provider: provider, ServerID: serverID, IP: ip, LastSeen: seenAt})
+	}
+	return nil
+}
+
+func (r *memIPRepo) List(provider, serverID string) ([]iphistory.IPRecord, error) {
+	return r.records, nil
+}
+
+func (r *memIPRepo) Close() error { return nil }
+
+func TestServerApp_IPHistoryRecordedInBackground(t *testing.T) {
+	repo := &memIPRepo{records: []iphistory.IPRecord{{IP: "203.0.113.1", LastSeen: time.Now()}}}
+	server := domain.Server{ID: "1", Name: "web-1", Provider: "mock", PublicIPv4: "203.0.113.7"}
+	m := newReauthTestApp()
+	m.ipHistory = iphistorysvc.NewService(repo)
+	m.view = appViewShow
+	m.show = newServerShowDirect(nil, "mock", &server, nil)
+
+	m.update(serverDetailLoadedMsg{server: &server})
+	if repo.recorded != 0 {
+		t.Fatal("expected the IP history write to be left to a command")
+	}
+
+	msg := m.loadIPHistory(server, true)()
+	if repo.recorded != 1 {
+		t.Errorf("recorded %d time(s), want 1", repo.recorded)
+	}
+	updated, cmd := m.update(msg)
+	app := updated.(serverAppModel)
+	if len(app.show.previousIPs) != 1 || app.show.previousIPs[0].IP != "203.0.113.1" {
+		t.Errorf("previousIPs = %+v, want 203.0.113.1", app.show.previousIPs)
+	}
+	if cmd == nil || !app.show.staleDNSQueried {
+		t.Error("expected a lookup of the DNS records on the old address")
+	}
+}
+
+func TestServerApp_StaleDNSNamesRecords(t *testing.T) {
+	server := domain.Server{ID: "1", Name: "web-1"}
+	m := newReauthTestApp()
+	m.view = appViewShow
+	m.show = newServerShowDirect(nil, "mock", &server, nil)
+	if got := m.show.staleDNS.warning(); !strings.Contains(got, "check that DNS records") {
+		t.Errorf("warning before the lookup = %q, want the generic one", got)
+	}
+
+	stale := &staleDNS{provider: "Cloudflare", records: []attach.Pointer{
+		{Zone: "example.com", Record: dnsdomain.Record{Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.1"}},
+	}}
+	updated, _ := m.update(staleDNSMsg{serverID: "1", stale: stale})
+	app := updated.(serverAppModel)
+	got := app.show.staleDNS.warning()
+	if !strings.Contains(got, "1 record(s) at Cloudflare") || !strings.Contains(got, "www.example.com A 203.0.113.1") {
+		t.Errorf("warning = %q, want the record named", got)
+	}
+}
@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+type backupsLoadedMsg struct {
+	timing
+	server  domain.Server
+	backups []domain.Backup
+}
+
+type backupsErrorMsg struct {
+	err error
+}
+
+// requestBackupsToggleMsg is emitted by the show view when the user
+// presses "b" to turn a server's backups on or off.
+type requestBackupsToggleMsg struct {
+	server domain.Server
+}
+
+// requestRestoreMsg is emitted by the backups view once the user confirms
+// restoring a backup.
+type requestRestoreMsg struct {
+	server domain.Server
+	backup domain.Backup
+}
+
+// --- Server backups model ---
+
+// serverBackupsModel lists a server's backups, newest first, and restores
+// the selected one after confirmation.
+type serverBackupsModel struct {
+	provider     domain.Provider
+	providerName string
+	server       *domain.Server
+
+	backups    []domain.Backup
+	cursor     int
+	confirming bool
+	loading    bool
+	err        error
+	spinner    spinner.Model
+
+	width  int
+	height int
+}
+
+func newServerBackupsModel(provider domain.Provider, providerName string, server *domain.Server) serverBackupsModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	return serverBackupsModel{
+		provider:     provider,
+		providerName: providerName,
+		server:       server,
+		loading:      true,
+		spinner:      s,
+	}
+}
+
+func (m serverBackupsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchBackups())
+}
+
+func (m serverBackupsModel) fetchBackups() tea.Cmd {
+	backups, ok := m.provider.(domain.BackupProvider)
+	if !ok {
+		return func() tea.Msg {
+			return backupsErrorMsg{err: fmt.Errorf("%s does not support backups", m.provider.GetDisplayName())}
+		}
+	}
+	server := *m.server
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		list, err := backups.ListBackups(ctx, server.ID)
+		if err != nil {
+			return backupsErrorMsg{err: err}
+		}
+		return backupsLoadedMsg{timing: timed("backups", began), server: server, backups: list}
+	}
+}
+
+// --- Update ---
+
+func (m serverBackupsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case backupsLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.backups = msg.backups
+		if m.cursor >= len(m.backups) {
+			m.cursor = max(len(m.backups)-1, 0)
+		}
+		return m, nil
+
+	case backupsErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m serverBackupsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.confirming {
+		switch msg.String() {
+		case "y":
+			m.confirming = false
+			server := *m.server
+			backup := m.backups[m.cursor]
+			return m, func() tea.Msg { return requestRestoreMsg{server: server, backup: backup} }
+		case "n", "esc", "q":
+			m.confirming = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.backups)-1 {
+			m.cursor++
+		}
+
+	case "r":
+		if !m.loading {
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.fetchBackups())
+		}
+
+	case "enter":
+		if !m.loading && len(m.backups) > 0 {
+			m.confirming = true
+		}
+	}
+
+	return m, nil
+}
+
+// --- View ---
+
+func (m serverBackupsModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "backups"), m.providerName)
+
+	bindings := []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "restore"},
+		{Key: "r", Desc: "refresh"},
+		{Key: "esc", Desc: "back"},
+	}
+	if m.confirming {
+		bindings = []components.KeyBinding{
+			{Key: "y", Desc: "restore"},
+			{Key: "n", Desc: "cancel"},
+		}
+	}
+	footer := components.Footer(m.width, bindings)
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverBackupsModel) renderContent(height int) string {
+	if m.loading {
+		loadingText := m.spinner.View() + "  Fetching backups" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press r to retry or esc to go back.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			errText,
+		)
+	}
+
+	if len(m.backups) == 0 {
+		text := fmt.Sprintf("No backups for %q yet.", m.server.Name)
+		if !m.server.Backups {
+			text = fmt.Sprintf("Backups are disabled for %q. Press b in the server view to enable them.", m.server.Name)
+		}
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(text),
+		)
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Backups of %q", m.server.Name))
+
+	var note string
+	if m.confirming {
+		backup := m.backups[m.cursor]
+		note = styles.WarningText.Render(fmt.Sprintf("%s Restore the backup from %s? The server's disk is overwritten and changes since then are lost.",
+			styles.Warning(), backup.CreatedAt.Local().Format("2006-01-02 15:04")))
+	} else {
+		note = styles.MutedText.Render("Restoring overwrites the server's disk with the selected backup.")
+	}
+
+	combined := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		m.renderBackups(height-6),
+		"",
+		note,
+	)
+
+	return lipgloss.Place(
+		m.width, height,
+		lipgloss.Center, lipgloss.Center,
+		combined,
+	)
+}
+
+// renderBackups renders the window of backups around the cursor.
+func (m serverBackupsModel) renderBackups(maxVisible int) string {
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.backups))
+
+	rows := make([]string, 0, end-start+2)
+	if start > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more above", styles.Ellipsis(), start)))
+	}
+	for i := start; i < end; i++ {
+		label := backupLabel(m.backups[i])
+		if i == m.cursor {
+			rows = append(rows, styles.AccentText.Render("> ")+styles.Value.Bold(true).Render(label))
+		} else {
+			rows = append(rows, "  "+styles.MutedText.Render(label))
+		}
+	}
+	if remaining := len(m.backups) - end; remaining > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more below", styles.Ellipsis(), remaining)))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// backupLabel formats a backup as "2006-01-02 15:04 - 1.2 GB - description".
+func backupLabel(b domain.Backup) string {
+	parts := []string{b.CreatedAt.Local().Format("2006-01-02 15:04")}
+	if b.SizeGB > 0 {
+		parts = append(parts, fmt.Sprintf("%.1f GB", b.SizeGB))
+	}
+	if b.Description != "" {
+		parts = append(parts, b.Description)
+	}
+	return strings.Join(parts, " - ")
+}
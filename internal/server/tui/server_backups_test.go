@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// backupProvider is a provider that manages server backups.
+type backupProvider struct {
+	reauthProvider
+	enabled  string
+	restored string
+}
+
+func (p *backupProvider) EnableBackups(_ context.Context, id string) (*domain.ActionStatus, error) {
+	p.enabled = id
+	return &domain.ActionStatus{ID: "11", Status: domain.ActionStatusRunning}, nil
+}
+
+func (p *backupProvider) DisableBackups(context.Context, string) (*domain.ActionStatus, error) {
+	return &domain.ActionStatus{ID: "12", Status: domain.ActionStatusRunning}, nil
+}
+
+func (p *backupProvider) ListBackups(context.Context, string) ([]domain.Backup, error) {
+	return nil, nil
+}
+
+func (p *backupProvider) RestoreBackup(_ context.Context, _ string, backupID string) (*domain.ActionStatus, error) {
+	p.restored = backupID
+	return &domain.ActionStatus{ID: "13", Status: domain.ActionStatusRunning}, nil
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestServerShow_DisablingBackupsNeedsSecondPress(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", Backups: true}
+	m := newServerShowDirect(&backupProvider{}, "hetzner", server, nil)
+
+	updated, cmd := m.handleKey(runeKey('b'))
+	m = updated.(serverShowModel)
+	if cmd != nil || !m.confirmDisableBackups {
+		t.Fatal("expected the first b to ask for confirmation")
+	}
+	if !strings.Contains(m.status, "Existing backups will be deleted") {
+		t.Errorf("status = %q, want a deletion warning", m.status)
+	}
+
+	_, cmd = m.handleKey(runeKey('b'))
+	if cmd == nil {
+		t.Fatal("expected the second b to request the toggle")
+	}
+	if msg, ok := cmd().(requestBackupsToggleMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("expected requestBackupsToggleMsg for server 7, got %#v", msg)
+	}
+}
+
+func TestServerShow_EnablingBackupsIsImmediate(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "off"}
+	m := newServerShowDirect(&backupProvider{}, "hetzner", server, nil)
+
+	_, cmd := m.handleKey(runeKey('b'))
+	if cmd == nil {
+		t.Fatal("expected b to request the toggle")
+	}
+	if _, ok := cmd().(requestBackupsToggleMsg); !ok {
+		t.Error("expected requestBackupsToggleMsg")
+	}
+}
+
+func TestServerBackups_RestoreNeedsConfirmation(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", Backups: true}
+	m := newServerBackupsModel(&backupProvider{}, "hetzner", server)
+	updated, _ := m.Update(backupsLoadedMsg{backups: []domain.Backup{
+		{ID: "100", CreatedAt: time.Date(2026, 10, 15, 3, 0, 0, 0, time.UTC)},
+		{ID: "99", CreatedAt: time.Date(2026, 10, 14, 3, 0, 0, 0, time.UTC)},
+	}})
+	m = updated.(serverBackupsModel)
+
+	updated, _ = m.Update(runeKey('j'))
+	updated, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverBackupsModel)
+	if cmd != nil || !m.confirming {
+		t.Fatal("expected enter to ask for confirmation before restoring")
+	}
+
+	_, cmd = m.Update(runeKey('y'))
+	if cmd == nil {
+		t.Fatal("expected y to request the restore")
+	}
+	msg, ok := cmd().(requestRestoreMsg)
+	if !ok || msg.backup.ID != "99" || msg.server.ID != "7" {
+		t.Errorf("expected restore of backup 99 on server 7, got %#v", msg)
+	}
+}
+
+func TestOpsOverlay_StartRestoreTracksAction(t *testing.T) {
+	provider := &backupProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+
+	o, cmd := o.StartRestore(domain.Server{ID: "7", Name: "app", Status: "running"}, domain.Backup{ID: "99"})
+	if len(o.ops) != 1 || o.ops[0].verb != "restored" || o.ops[0].target != "running" {
+		t.Fatalf("expected one restore operation, got %+v", o.ops)
+	}
+	if inferCommand(o.ops[0].verb) != "restore_backup" {
+		t.Errorf("command = %q, want restore_backup", inferCommand(o.ops[0].verb))
+	}
+
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	if provider.restored != "99" {
+		t.Errorf("expected RestoreBackup(99), got %q", provider.restored)
+	}
+}
@@ -3,10 +3,15 @@ package tui
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 	"nathanbeddoewebdev/vpsm/internal/util"
 
@@ -23,10 +28,12 @@ type createStep int
 const (
 	stepLoading createStep = iota
 	stepName
+	stepNetwork
 	stepLocation
 	stepServerType
 	stepImage
 	stepSSHKeys
+	stepLabels
 	stepConfirm
 )
 
@@ -34,6 +41,8 @@ func (s createStep) label() string {
 	switch s {
 	case stepName:
 		return "Name"
+	case stepNetwork:
+		return "Network"
 	case stepLocation:
 		return "Location"
 	case stepServerType:
@@ -42,6 +51,8 @@ func (s createStep) label() string {
 		return "Image"
 	case stepSSHKeys:
 		return "SSH Keys"
+	case stepLabels:
+		return "Labels"
 	case stepConfirm:
 		return "Confirm"
 	default:
@@ -49,9 +60,43 @@ func (s createStep) label() string {
 	}
 }
 
+// imageSource is a tab of the image step.
+type imageSource int
+
+const (
+	imageSourceSystem imageSource = iota
+	imageSourceSnapshots
+	imageSourceBackups
+	imageSourceCount
+)
+
+func (s imageSource) label() string {
+	switch s {
+	case imageSourceSnapshots:
+		return "Snapshots"
+	case imageSourceBackups:
+		return "Backups"
+	default:
+		return "System"
+	}
+}
+
+// imageType is the domain.ImageSpec Type listed on the tab.
+func (s imageSource) imageType() string {
+	switch s {
+	case imageSourceSnapshots:
+		return "snapshot"
+	case imageSourceBackups:
+		return "backup"
+	default:
+		return "system"
+	}
+}
+
 // --- Messages ---
 
 type catalogLoadedMsg struct {
+	timing
 	data catalogData
 }
 
@@ -59,6 +104,58 @@ type catalogErrorMsg struct {
 	err error
 }
 
+// --- Name input ---
+
+// serverNameInputID identifies the wizard's name input in
+// components.InputValidatedMsg.
+const serverNameInputID = "server-name"
+
+// newServerNameInput returns the wizard's name field: a required, valid
+// hostname that no existing server of the provider already uses.
+func newServerNameInput(provider domain.Provider, prefillName string) components.ValidatedInput {
+	ti := textinput.New()
+	ti.Placeholder = "my-server"
+	ti.Focus()
+	ti.CharLimit = 63
+	ti.Width = 40
+
+	if prefillName != "" {
+		ti.SetValue(prefillName)
+	}
+
+	return components.NewValidatedInput(serverNameInputID, ti,
+		components.Required("Name is required"),
+		util.ValidateServerName,
+	).WithAsync(serverNameUnique(provider))
+}
+
+// serverNameUnique rejects names already used by one of the provider's
+// servers. A failed lookup lets the name through; the create call will
+// report a conflict if there is one.
+func serverNameUnique(provider domain.Provider) components.AsyncValidateFunc {
+	return func(ctx context.Context, name string) error {
+		servers, err := provider.ListServers(ctx)
+		if err != nil {
+			return nil
+		}
+		for _, s := range servers {
+			if strings.EqualFold(s.Name, name) {
+				return fmt.Errorf("a server named %q already exists", name)
+			}
+		}
+		return nil
+	}
+}
+
+// newLabelInput returns the wizard's key=value entry for the labels step.
+func newLabelInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "env=prod"
+	ti.CharLimit = 127
+	ti.Width = 40
+	return ti
+}
+
 // --- Create item for selection lists ---
 
 type createItem struct {
@@ -80,8 +177,12 @@ type serverCreateModel struct {
 	data catalogData
 
 	// Step: Name
-	nameInput textinput.Model
-	nameErr   string
+	name components.ValidatedInput
+
+	// Step: Network
+	networks     []createItem
+	networkIdx   int
+	networkStart int
 
 	// Step: Location
 	locations     []createItem
@@ -93,10 +194,12 @@ type serverCreateModel struct {
 	serverTypeIdx   int
 	serverTypeStart int
 
-	// Step: Image
-	images     []createItem
-	imageIdx   int
-	imageStart int
+	// Step: Image. images is the list on the selected tab.
+	images      []createItem
+	imageIdx    int
+	imageStart  int
+	imageSource imageSource
+	imageTabs   [imageSourceCount][]createItem
 
 	// Step: SSH Keys
 	sshKeys     []createItem
@@ -104,6 +207,11 @@ type serverCreateModel struct {
 	sshIdx      int
 	sshStart    int
 
+	// Step: Labels
+	labelInput textinput.Model
+	labelIdx   int // cursor into labelKeys()
+	labelErr   string
+
 	// Step: Confirm
 	confirmIdx int // 0 = create, 1 = cancel
 
@@ -125,32 +233,23 @@ type serverCreateModel struct {
 // RunServerCreate starts the full-window server creation wizard.
 func RunServerCreate(provider domain.CatalogProvider, providerName string, prefill domain.CreateServerOpts) (*domain.CreateServerOpts, error) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
-	ti := textinput.New()
-	ti.Placeholder = "my-server"
-	ti.Focus()
-	ti.CharLimit = 63
-	ti.Width = 40
-
-	if prefill.Name != "" {
-		ti.SetValue(prefill.Name)
-	}
-
 	m := serverCreateModel{
 		provider:     provider,
 		providerName: providerName,
 		prefill:      prefill,
 		step:         stepLoading,
 		opts:         prefill,
-		nameInput:    ti,
+		name:         newServerNameInput(provider, prefill.Name),
 		loading:      true,
 		spinner:      s,
 		sshSelected:  make(map[int]struct{}),
+		labelInput:   newLabelInput(),
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run server create: %w", err)
@@ -173,11 +272,12 @@ func (m serverCreateModel) Init() tea.Cmd {
 
 func (m serverCreateModel) fetchCatalog() tea.Cmd {
 	return func() tea.Msg {
+		began := time.Now()
 		data, err := fetchCatalog(context.Background(), m.provider)
 		if err != nil {
 			return catalogErrorMsg{err: err}
 		}
-		return catalogLoadedMsg{data: data}
+		return catalogLoadedMsg{timing: timed("catalog", began), data: data}
 	}
 }
 
@@ -204,6 +304,13 @@ func (m serverCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		return m, nil
 
+	case components.InputValidatedMsg:
+		if msg.ID == serverNameInputID && m.step == stepName {
+			m.opts.Name = msg.Value
+			m.step = m.stepAfterName()
+		}
+		return m, nil
+
 	case spinner.TickMsg:
 		if m.loading {
 			var cmd tea.Cmd
@@ -213,10 +320,10 @@ func (m serverCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Forward to text input when on name step.
+	// Forward to the name input (cursor blink, async validation results).
 	if m.step == stepName {
 		var cmd tea.Cmd
-		m.nameInput, cmd = m.nameInput.Update(msg)
+		m.name, cmd = m.name.Update(msg)
 		return m, cmd
 	}
 
@@ -224,27 +331,33 @@ func (m serverCreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *serverCreateModel) buildCatalogItems() {
-	// Locations.
-	m.locations = make([]createItem, 0, len(m.data.locations))
-	for _, loc := range m.data.locations {
-		value := valueOrID(loc.Name, loc.ID)
-		m.locations = append(m.locations, createItem{
-			name:  value,
-			label: locationLabel(loc),
+	// Networks. Only those reachable from at least one location are
+	// offered; the first item attaches none.
+	m.networks = nil
+	for _, n := range m.data.networks {
+		if len(filterLocationsByNetworks(m.data.locations, []domain.NetworkSpec{n})) == 0 {
+			continue
+		}
+		if m.networks == nil {
+			m.networks = []createItem{{name: "", label: "None (public network only)"}}
+		}
+		m.networks = append(m.networks, createItem{
+			name:  valueOrID(n.Name, n.ID),
+			label: networkLabel(n),
 		})
 	}
 
-	// Pre-select prefilled location.
-	if m.prefill.Location != "" {
-		for i, loc := range m.locations {
-			if strings.EqualFold(loc.name, m.prefill.Location) {
-				m.locationIdx = i
+	// Pre-select prefilled network.
+	if len(m.prefill.Networks) > 0 {
+		for i, n := range m.networks {
+			if i > 0 && strings.EqualFold(n.name, m.prefill.Networks[0]) {
+				m.networkIdx = i
 				break
 			}
 		}
 	}
 
-	m.rebuildServerTypes()
+	m.rebuildLocations()
 	m.rebuildImages()
 
 	// SSH keys.
@@ -267,6 +380,34 @@ func (m *serverCreateModel) buildCatalogItems() {
 	}
 }
 
+// rebuildLocations lists the locations the selected networks can be
+// attached from and re-selects the prefilled location if still offered.
+func (m *serverCreateModel) rebuildLocations() {
+	locations := filterLocationsByNetworks(m.data.locations, findNetworks(m.data.networks, m.opts.Networks))
+	m.locations = make([]createItem, 0, len(locations))
+	for _, loc := range locations {
+		value := valueOrID(loc.Name, loc.ID)
+		m.locations = append(m.locations, createItem{
+			name:  value,
+			label: locationLabel(loc),
+		})
+	}
+	m.locationIdx = 0
+	m.locationStart = 0
+
+	// Pre-select prefilled location.
+	if m.prefill.Location != "" {
+		for i, loc := range m.locations {
+			if strings.EqualFold(loc.name, m.prefill.Location) {
+				m.locationIdx = i
+				break
+			}
+		}
+	}
+
+	m.rebuildServerTypes()
+}
+
 func (m *serverCreateModel) rebuildServerTypes() {
 	location := ""
 	if m.locationIdx < len(m.locations) {
@@ -309,31 +450,93 @@ func (m *serverCreateModel) rebuildImages() {
 	}
 
 	filtered := filterImages(m.data.images, arch)
-	m.images = make([]createItem, 0, len(filtered))
+	system := make([]createItem, 0, len(filtered))
 	for _, img := range filtered {
 		value := valueOrID(img.Name, img.ID)
-		m.images = append(m.images, createItem{
+		system = append(system, createItem{
 			name:  value,
 			label: imageLabel(img),
 		})
 	}
-	m.imageIdx = 0
-	m.imageStart = 0
+	m.imageTabs[imageSourceSystem] = system
+
+	// Snapshots and backups have no names, so they are chosen by ID.
+	for _, source := range []imageSource{imageSourceSnapshots, imageSourceBackups} {
+		custom := filterCustomImages(m.data.customImages, source.imageType(), arch)
+		items := make([]createItem, 0, len(custom))
+		for _, img := range custom {
+			items = append(items, createItem{
+				name:  img.ID,
+				label: customImageLabel(img),
+			})
+		}
+		m.imageTabs[source] = items
+	}
+	m.setImageSource(imageSourceSystem)
 
 	// Re-select prefilled image if valid.
 	if m.prefill.Image != "" {
-		for i, img := range m.images {
-			if strings.EqualFold(img.name, m.prefill.Image) {
-				m.imageIdx = i
-				break
+		for source, items := range m.imageTabs {
+			for i, img := range items {
+				if strings.EqualFold(img.name, m.prefill.Image) {
+					m.setImageSource(imageSource(source))
+					m.imageIdx = i
+					return
+				}
 			}
 		}
 	}
 }
 
+// setImageSource switches the image step to a tab, moving the cursor to
+// its first image.
+func (m *serverCreateModel) setImageSource(source imageSource) {
+	m.imageSource = source
+	m.images = m.imageTabs[source]
+	m.imageIdx = 0
+	m.imageStart = 0
+}
+
+// hasImageTabs reports whether the image step offers snapshots and
+// backups alongside system images.
+func (m serverCreateModel) hasImageTabs() bool {
+	_, ok := m.provider.(domain.CustomImageProvider)
+	return ok
+}
+
+// stepAfterName returns the step following the name: the network step
+// when the provider has networks to offer, otherwise the location step.
+func (m serverCreateModel) stepAfterName() createStep {
+	if len(m.networks) > 0 {
+		return stepNetwork
+	}
+	return stepLocation
+}
+
+// selectionErr reports a location and network combination the provider
+// would reject.
+func (m serverCreateModel) selectionErr() error {
+	if m.opts.Location == "" {
+		return nil
+	}
+	for _, loc := range m.data.locations {
+		if !strings.EqualFold(valueOrID(loc.Name, loc.ID), m.opts.Location) {
+			continue
+		}
+		for _, n := range findNetworks(m.data.networks, m.opts.Networks) {
+			if err := domain.ValidateNetworkLocation(n, loc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // listCursor returns the current cursor index for the active list step.
 func (m serverCreateModel) listCursor() int {
 	switch m.step {
+	case stepNetwork:
+		return m.networkIdx
 	case stepLocation:
 		return m.locationIdx
 	case stepServerType:
@@ -348,6 +551,8 @@ func (m serverCreateModel) listCursor() int {
 // listItems returns the items for the active list step.
 func (m serverCreateModel) listItems() []createItem {
 	switch m.step {
+	case stepNetwork:
+		return m.networks
 	case stepLocation:
 		return m.locations
 	case stepServerType:
@@ -362,6 +567,8 @@ func (m serverCreateModel) listItems() []createItem {
 // setListCursor updates the cursor for the active list step.
 func (m *serverCreateModel) setListCursor(idx int) {
 	switch m.step {
+	case stepNetwork:
+		m.networkIdx = idx
 	case stepLocation:
 		m.locationIdx = idx
 	case stepServerType:
@@ -396,10 +603,12 @@ func (m serverCreateModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.step {
 	case stepName:
 		return m.handleNameKey(msg)
-	case stepLocation, stepServerType, stepImage:
+	case stepNetwork, stepLocation, stepServerType, stepImage:
 		return m.handleListKey(msg)
 	case stepSSHKeys:
 		return m.handleSSHKeysKey(msg)
+	case stepLabels:
+		return m.handleLabelsKey(msg)
 	case stepConfirm:
 		return m.handleConfirmKey(msg)
 	}
@@ -416,25 +625,14 @@ func (m serverCreateModel) handleNameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.quitting = true
 		return m, tea.Quit
 	case "enter":
-		name := strings.TrimSpace(m.nameInput.Value())
-		if name == "" {
-			m.nameErr = "Name is required"
-			return m, nil
-		}
-		if err := util.ValidateServerName(name); err != nil {
-			m.nameErr = err.Error()
-			return m, nil
-		}
-		m.nameErr = ""
-		m.opts.Name = name
-		m.step = stepLocation
-		return m, nil
+		// Advances to the next step on components.InputValidatedMsg.
+		var cmd tea.Cmd
+		m.name, cmd = m.name.Submit()
+		return m, cmd
 	}
 
-	// Forward to text input.
 	var cmd tea.Cmd
-	m.nameInput, cmd = m.nameInput.Update(msg)
-	m.nameErr = "" // Clear error on typing.
+	m.name, cmd = m.name.Update(msg)
 	return m, cmd
 }
 
@@ -445,20 +643,35 @@ func (m serverCreateModel) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Step navigation mapping.
 	var prevStep, nextStep createStep
 	switch m.step {
+	case stepNetwork:
+		prevStep, nextStep = stepName, stepLocation
 	case stepLocation:
 		prevStep, nextStep = stepName, stepServerType
+		if len(m.networks) > 0 {
+			prevStep = stepNetwork
+		}
 	case stepServerType:
 		prevStep, nextStep = stepLocation, stepImage
 	case stepImage:
 		prevStep, nextStep = stepServerType, stepSSHKeys
 	}
 
+	if m.step == stepImage && m.hasImageTabs() {
+		switch msg.String() {
+		case "tab", "right", "l":
+			m.setImageSource((m.imageSource + 1) % imageSourceCount)
+			return m, nil
+		case "shift+tab", "left", "h":
+			m.setImageSource((m.imageSource + imageSourceCount - 1) % imageSourceCount)
+			return m, nil
+		}
+	}
+
 	switch msg.String() {
 	case "esc":
 		m.step = prevStep
 		if prevStep == stepName {
-			m.nameInput.Focus()
-			return m, textinput.Blink
+			return m, m.name.Focus()
 		}
 		return m, nil
 	case "up", "k":
@@ -479,6 +692,13 @@ func (m serverCreateModel) handleListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if len(items) > 0 {
 			selected := items[cursor].name
 			switch m.step {
+			case stepNetwork:
+				m.opts.Networks = nil
+				if selected != "" {
+					m.opts.Networks = []string{selected}
+				}
+				m.networkIdx = cursor
+				m.rebuildLocations()
 			case stepLocation:
 				m.opts.Location = selected
 				m.locationIdx = cursor
@@ -526,23 +746,103 @@ func (m serverCreateModel) handleSSHKeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 				m.opts.SSHKeyIdentifiers = append(m.opts.SSHKeyIdentifiers, m.sshKeys[i].name)
 			}
 		}
-		m.confirmIdx = 0
-		m.step = stepConfirm
-		return m, nil
+		m.labelErr = ""
+		m.step = stepLabels
+		return m, m.labelInput.Focus()
 	}
 
 	return m, nil
 }
 
-func (m serverCreateModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// labelKeys returns the keys of the labels entered so far, sorted so the
+// list and its cursor stay stable as labels are added and removed.
+func (m serverCreateModel) labelKeys() []string {
+	keys := make([]string, 0, len(m.opts.Labels))
+	for key := range m.opts.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m serverCreateModel) handleLabelsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keys := m.labelKeys()
+
 	switch msg.String() {
 	case "esc":
+		m.labelInput.Blur()
+		m.labelErr = ""
 		if len(m.sshKeys) > 0 {
 			m.step = stepSSHKeys
 		} else {
 			m.step = stepImage
 		}
 		return m, nil
+	case "up":
+		if m.labelIdx > 0 {
+			m.labelIdx--
+		}
+		return m, nil
+	case "down":
+		if m.labelIdx < len(keys)-1 {
+			m.labelIdx++
+		}
+		return m, nil
+	case "ctrl+d", "delete":
+		if len(keys) == 0 {
+			return m, nil
+		}
+		// Copy before mutating: the prefill map is shared with the caller.
+		labels := maps.Clone(m.opts.Labels)
+		delete(labels, keys[m.labelIdx])
+		m.opts.Labels = labels
+		if m.labelIdx >= len(labels) && m.labelIdx > 0 {
+			m.labelIdx--
+		}
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.labelInput.Value())
+		if value == "" {
+			// An empty entry finishes the step.
+			m.labelInput.Blur()
+			m.labelErr = ""
+			m.confirmIdx = 0
+			m.step = stepConfirm
+			return m, nil
+		}
+		key, val, err := domain.ParseLabel(value)
+		if err != nil {
+			m.labelErr = err.Error()
+			return m, nil
+		}
+		labels := maps.Clone(m.opts.Labels)
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[key] = val
+		m.opts.Labels = labels
+		m.labelIdx = slices.Index(m.labelKeys(), key)
+		m.labelErr = ""
+		m.labelInput.Reset()
+		return m, nil
+	}
+
+	m.labelErr = ""
+	var cmd tea.Cmd
+	m.labelInput, cmd = m.labelInput.Update(msg)
+	return m, cmd
+}
+
+func (m serverCreateModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.labelErr = ""
+		m.step = stepLabels
+		return m, m.labelInput.Focus()
+	case "i":
+		if domain.SupportsInterruptible(m.provider) {
+			m.opts.Interruptible = !m.opts.Interruptible
+		}
 	case "left", "h":
 		if m.confirmIdx > 0 {
 			m.confirmIdx--
@@ -554,6 +854,9 @@ func (m serverCreateModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	case "enter":
 		if m.confirmIdx == 0 {
 			// Create!
+			if m.selectionErr() != nil {
+				return m, nil
+			}
 			opts := m.opts
 			opts.Name = strings.TrimSpace(opts.Name)
 			if len(opts.SSHKeyIdentifiers) == 0 {
@@ -572,6 +875,9 @@ func (m serverCreateModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		m.quitting = true
 		return m, tea.Quit
 	case "y":
+		if m.selectionErr() != nil {
+			return m, nil
+		}
 		opts := m.opts
 		opts.Name = strings.TrimSpace(opts.Name)
 		if len(opts.SSHKeyIdentifiers) == 0 {
@@ -598,7 +904,7 @@ func (m serverCreateModel) View() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "server create", m.providerName)
+	header := components.HeaderTrail(m.width, serverTrail(nil, "create"), m.providerName)
 
 	var footerBindings []components.KeyBinding
 	switch m.step {
@@ -615,31 +921,94 @@ func (m serverCreateModel) View() string {
 			{Key: "enter", Desc: "next"},
 			{Key: "esc", Desc: "back"},
 		}
+	case stepLabels:
+		footerBindings = []components.KeyBinding{
+			{Key: "enter", Desc: "add/next"},
+			{Key: "↑/↓", Desc: "select"},
+			{Key: "ctrl+d", Desc: "remove"},
+			{Key: "esc", Desc: "back"},
+		}
 	case stepConfirm:
 		footerBindings = []components.KeyBinding{
 			{Key: "y/n", Desc: "confirm"},
 			{Key: "enter", Desc: "select"},
-			{Key: "esc", Desc: "back"},
 		}
+		if domain.SupportsInterruptible(m.provider) {
+			footerBindings = append(footerBindings, components.KeyBinding{Key: "i", Desc: "interruptible"})
+		}
+		footerBindings = append(footerBindings, components.KeyBinding{Key: "esc", Desc: "back"})
 	default:
 		footerBindings = []components.KeyBinding{
 			{Key: "j/k", Desc: "navigate"},
 			{Key: "enter", Desc: "select"},
-			{Key: "esc", Desc: "back"},
 		}
+		if m.step == stepImage && m.hasImageTabs() {
+			footerBindings = append(footerBindings, components.KeyBinding{Key: "tab", Desc: "system/snapshots/backups"})
+		}
+		footerBindings = append(footerBindings, components.KeyBinding{Key: "esc", Desc: "back"})
 	}
 	footer := components.Footer(m.width, footerBindings)
+	cost := m.renderCostFooter()
 
 	headerH := lipgloss.Height(header)
 	footerH := lipgloss.Height(footer)
 	contentH := m.height - headerH - footerH
+	if cost != "" {
+		contentH -= lipgloss.Height(cost)
+	}
 	if contentH < 1 {
 		contentH = 1
 	}
 
 	content := m.renderContent(contentH)
 
-	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	if cost == "" {
+		return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, cost, footer)
+}
+
+// costEstimate returns the monthly cost of the current selection. Once the
+// server type step is reached it follows the cursors, so the estimate
+// updates live as the user moves through the type list.
+func (m serverCreateModel) costEstimate() (domain.CostEstimate, bool) {
+	if m.loading || m.err != nil || m.step < stepServerType || m.serverTypeIdx >= len(m.serverTypes) {
+		return domain.CostEstimate{}, false
+	}
+
+	opts := m.opts
+	if m.locationIdx < len(m.locations) {
+		opts.Location = m.locations[m.locationIdx].name
+	}
+	name := m.serverTypes[m.serverTypeIdx].name
+	for _, st := range m.data.serverTypes {
+		if strings.EqualFold(valueOrID(st.Name, st.ID), name) {
+			est := domain.EstimateMonthlyCost(st, opts, m.data.pricing)
+			return est, len(est.Lines) > 0
+		}
+	}
+	return domain.CostEstimate{}, false
+}
+
+// renderCostFooter renders the running monthly cost estimate shown above
+// the key bindings, or "" when nothing is priced yet.
+func (m serverCreateModel) renderCostFooter() string {
+	est, ok := m.costEstimate()
+	if !ok {
+		return ""
+	}
+
+	f := priceFormatter()
+	parts := make([]string, len(est.Lines))
+	for i, line := range est.Lines {
+		parts[i] = line.Label + " " + f.Format(line.Monthly)
+	}
+	text := styles.Label.Render("Estimated total: ") +
+		styles.Value.Render(f.FormatMonthly(est.Total))
+	if len(parts) > 1 {
+		text += styles.MutedText.Render("  (" + strings.Join(parts, " + ") + ")")
+	}
+	return lipgloss.NewStyle().Width(m.width).Align(lipgloss.Center).Render(text)
 }
 
 func (m serverCreateModel) renderContent(height int) string {
@@ -670,14 +1039,18 @@ func (m serverCreateModel) renderContent(height int) string {
 	switch m.step {
 	case stepName:
 		stepContent = m.renderNameStep()
+	case stepNetwork:
+		stepContent = m.renderListStep("Select a private network", m.networks, m.networkIdx, m.networkStart, height-6)
 	case stepLocation:
 		stepContent = m.renderListStep("Select a location", m.locations, m.locationIdx, m.locationStart, height-6)
 	case stepServerType:
 		stepContent = m.renderListStep("Select a server type", m.serverTypes, m.serverTypeIdx, m.serverTypeStart, height-6)
 	case stepImage:
-		stepContent = m.renderListStep("Select an image", m.images, m.imageIdx, m.imageStart, height-6)
+		stepContent = m.renderImageStep(height - 6)
 	case stepSSHKeys:
 		stepContent = m.renderSSHKeysStep(height - 6)
+	case stepLabels:
+		stepContent = m.renderLabelsStep()
 	case stepConfirm:
 		stepContent = m.renderConfirmStep()
 	}
@@ -692,11 +1065,16 @@ func (m serverCreateModel) renderContent(height int) string {
 }
 
 func (m serverCreateModel) renderProgress() string {
-	allSteps := []createStep{stepName, stepLocation, stepServerType, stepImage, stepSSHKeys, stepConfirm}
-	if len(m.sshKeys) == 0 {
-		// Remove SSH keys step if none available.
-		allSteps = []createStep{stepName, stepLocation, stepServerType, stepImage, stepConfirm}
+	allSteps := []createStep{stepName}
+	if len(m.networks) > 0 {
+		allSteps = append(allSteps, stepNetwork)
+	}
+	allSteps = append(allSteps, stepLocation, stepServerType, stepImage)
+	if len(m.sshKeys) > 0 {
+		// The SSH keys step is skipped when none are available.
+		allSteps = append(allSteps, stepSSHKeys)
 	}
+	allSteps = append(allSteps, stepLabels, stepConfirm)
 
 	parts := make([]string, len(allSteps))
 	for i, s := range allSteps {
@@ -718,19 +1096,11 @@ func (m serverCreateModel) renderNameStep() string {
 	title := styles.Title.Render("Server name")
 	hint := styles.MutedText.Render("Enter a valid hostname for your server")
 
-	inputView := m.nameInput.View()
-
-	var errLine string
-	if m.nameErr != "" {
-		errLine = "\n" + styles.ErrorText.Render(m.nameErr)
-	}
-
 	return lipgloss.JoinVertical(lipgloss.Left,
 		title,
 		hint,
 		"",
-		inputView,
-		errLine,
+		m.name.View(),
 	)
 }
 
@@ -790,6 +1160,36 @@ func (m serverCreateModel) renderListStep(title string, items []createItem, curs
 	)
 }
 
+// renderImageStep renders the image list, under a tab bar switching
+// between system images, snapshots and backups when the provider has
+// them.
+func (m serverCreateModel) renderImageStep(maxVisible int) string {
+	if !m.hasImageTabs() {
+		return m.renderListStep("Select an image", m.images, m.imageIdx, m.imageStart, maxVisible)
+	}
+
+	tabs := make([]string, imageSourceCount)
+	for source := range imageSourceCount {
+		tab := fmt.Sprintf("%s (%d)", source.label(), len(m.imageTabs[source]))
+		if source == m.imageSource {
+			tabs[source] = styles.AccentText.Bold(true).Render(tab)
+		} else {
+			tabs[source] = styles.MutedText.Render(tab)
+		}
+	}
+	tabBar := strings.Join(tabs, styles.MutedText.Render("  |  "))
+
+	list := m.renderListStep("Select an image", m.images, m.imageIdx, m.imageStart, maxVisible-2)
+	if len(m.images) == 0 {
+		list = lipgloss.JoinVertical(lipgloss.Left,
+			styles.Title.Render("Select an image"),
+			"",
+			styles.MutedText.Render(fmt.Sprintf("No %s found for this server type.", strings.ToLower(m.imageSource.label()))),
+		)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, tabBar, "", list)
+}
+
 func (m serverCreateModel) renderSSHKeysStep(maxVisible int) string {
 	if len(m.sshKeys) == 0 {
 		return lipgloss.JoinVertical(lipgloss.Left,
@@ -855,6 +1255,35 @@ func (m serverCreateModel) renderSSHKeysStep(maxVisible int) string {
 	)
 }
 
+func (m serverCreateModel) renderLabelsStep() string {
+	title := styles.Title.Render("Labels")
+	hint := styles.MutedText.Render("Add key=value labels, or press enter on an empty line to continue")
+
+	lines := []string{title, hint, "", m.labelInput.View()}
+	if m.labelErr != "" {
+		lines = append(lines, styles.ErrorText.Render(m.labelErr))
+	}
+
+	lines = append(lines, "")
+	keys := m.labelKeys()
+	if len(keys) == 0 {
+		lines = append(lines, styles.MutedText.Render("No labels added."))
+	}
+	for i, key := range keys {
+		prefix := "  "
+		label := key + "=" + m.opts.Labels[key]
+		if i == m.labelIdx {
+			prefix = styles.AccentText.Render("> ")
+			label = styles.Value.Render(label)
+		} else {
+			label = styles.MutedText.Render(label)
+		}
+		lines = append(lines, prefix+label)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
 func (m serverCreateModel) renderConfirmStep() string {
 	title := styles.Title.Render("Review & Confirm")
 
@@ -877,7 +1306,15 @@ func (m serverCreateModel) renderConfirmStep() string {
 		renderField("Name", m.opts.Name),
 		renderField("Location", m.findLabel(m.locations, location)),
 		renderField("Server type", m.findLabel(m.serverTypes, m.opts.ServerType)),
-		renderField("Image", m.findLabel(m.images, m.opts.Image)),
+		renderField("Image", m.findLabel(slices.Concat(m.imageTabs[:]...), m.opts.Image)),
+	}
+
+	if len(m.opts.Networks) > 0 {
+		networkLabels := make([]string, len(m.opts.Networks))
+		for i, n := range m.opts.Networks {
+			networkLabels[i] = m.findLabel(m.networks, n)
+		}
+		fields = append(fields, renderField("Network", strings.Join(networkLabels, ", ")))
 	}
 
 	if len(m.opts.SSHKeyIdentifiers) > 0 {
@@ -896,6 +1333,13 @@ func (m serverCreateModel) renderConfirmStep() string {
 	if m.opts.UserData != "" {
 		fields = append(fields, renderField("User data", fmt.Sprintf("%d bytes", len(m.opts.UserData))))
 	}
+	if domain.SupportsInterruptible(m.provider) {
+		if m.opts.Interruptible {
+			fields = append(fields, renderField("Interruptible", styles.WarningText.Render("yes - may be reclaimed at any time")))
+		} else {
+			fields = append(fields, renderField("Interruptible", "no (press i for a spot instance)"))
+		}
+	}
 
 	summaryContent := strings.Join(fields, "\n")
 	summary := styles.Card.Width(cardWidth).Render(summaryContent)
@@ -922,6 +1366,11 @@ func (m serverCreateModel) renderConfirmStep() string {
 
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, createBtn, "  ", cancelBtn)
 
+	if err := m.selectionErr(); err != nil {
+		buttons = styles.ErrorText.Render(err.Error()) + "\n" +
+			styles.MutedText.Render("Press esc to change the selection.")
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Center,
 		title,
 		"",
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -38,6 +40,11 @@ type serverDeleteModel struct {
 	server     *domain.Server
 	confirmIdx int // 0 = delete, 1 = cancel
 
+	// requireStopped turns deletion of a running server into a compound
+	// stop-then-delete operation.
+	requireStopped bool
+	stopFirst      bool
+
 	width  int
 	height int
 
@@ -57,19 +64,25 @@ type serverDeleteModel struct {
 type DeleteResult struct {
 	Server    *domain.Server
 	Confirmed bool
+	// StopFirst is set when the server is running and must be stopped
+	// before it is deleted.
+	StopFirst bool
 }
 
 // RunServerDelete starts the interactive server deletion TUI.
 // If serverToDelete is nil, it first shows a server selection list.
-func RunServerDelete(provider domain.Provider, providerName string, serverToDelete *domain.Server) (*DeleteResult, error) {
+// When requireStopped is set, confirming a running server asks the
+// caller to stop it first (see DeleteResult.StopFirst).
+func RunServerDelete(provider domain.Provider, providerName string, serverToDelete *domain.Server, requireStopped bool) (*DeleteResult, error) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	m := serverDeleteModel{
-		provider:     provider,
-		providerName: providerName,
-		spinner:      s,
+		provider:       provider,
+		providerName:   providerName,
+		spinner:        s,
+		requireStopped: requireStopped,
 	}
 
 	if serverToDelete != nil {
@@ -81,7 +94,7 @@ func RunServerDelete(provider domain.Provider, providerName string, serverToDele
 		m.loading = true
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run server delete: %w", err)
@@ -91,7 +104,28 @@ func RunServerDelete(provider domain.Provider, providerName string, serverToDele
 	if final.quitting || final.server == nil {
 		return nil, nil
 	}
-	return &DeleteResult{Server: final.server, Confirmed: final.confirmed}, nil
+	return &DeleteResult{Server: final.server, Confirmed: final.confirmed, StopFirst: final.stopFirst}, nil
+}
+
+// mustStopFirst reports whether the selected server has to be stopped
+// before it can be deleted.
+func (m serverDeleteModel) mustStopFirst() bool {
+	return m.requireStopped && m.server != nil && !m.server.IsStopped()
+}
+
+// confirm accepts the deletion. Embedded models hand the operation to the
+// app; standalone models quit with the result.
+func (m serverDeleteModel) confirm() (tea.Model, tea.Cmd) {
+	if m.embedded && m.server != nil {
+		server := *m.server
+		if m.mustStopFirst() {
+			return m, func() tea.Msg { return stopThenDeleteMsg{server: server} }
+		}
+		return m, func() tea.Msg { return deleteConfirmedMsg{server: server} }
+	}
+	m.confirmed = true
+	m.stopFirst = m.mustStopFirst()
+	return m, tea.Quit
 }
 
 func (m serverDeleteModel) Init() tea.Cmd {
@@ -103,11 +137,12 @@ func (m serverDeleteModel) Init() tea.Cmd {
 
 func (m serverDeleteModel) fetchServers() tea.Cmd {
 	return func() tea.Msg {
+		began := time.Now()
 		servers, err := m.provider.ListServers(context.Background())
 		if err != nil {
 			return serversErrorMsg{err: err}
 		}
-		return serversLoadedMsg{servers: servers}
+		return serversLoadedMsg{timing: timed("list fetch", began), servers: servers}
 	}
 }
 
@@ -234,12 +269,7 @@ func (m serverDeleteModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		}
 	case "enter":
 		if m.confirmIdx == 0 {
-			if m.embedded && m.server != nil {
-				server := *m.server
-				return m, func() tea.Msg { return deleteConfirmedMsg{server: server} }
-			}
-			m.confirmed = true
-			return m, tea.Quit
+			return m.confirm()
 		}
 		if m.embedded {
 			return m, func() tea.Msg { return navigateBackMsg{} }
@@ -247,12 +277,7 @@ func (m serverDeleteModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		m.quitting = true
 		return m, tea.Quit
 	case "y":
-		if m.embedded && m.server != nil {
-			server := *m.server
-			return m, func() tea.Msg { return deleteConfirmedMsg{server: server} }
-		}
-		m.confirmed = true
-		return m, tea.Quit
+		return m.confirm()
 	case "n":
 		if m.embedded {
 			return m, func() tea.Msg { return navigateBackMsg{} }
@@ -269,7 +294,7 @@ func (m serverDeleteModel) View() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "server delete", m.providerName)
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "delete"), m.providerName)
 
 	var footerBindings []components.KeyBinding
 	switch {
@@ -404,6 +429,9 @@ func (m serverDeleteModel) renderConfirmPhase(height int) string {
 
 	// Warning.
 	warning := styles.WarningText.Render("This action cannot be undone.")
+	if m.mustStopFirst() {
+		warning = styles.WarningText.Render("This server is running. It will be stopped, then deleted.\nThis action cannot be undone.")
+	}
 
 	// Server details.
 	fields := []string{
@@ -426,7 +454,7 @@ func (m serverDeleteModel) renderConfirmPhase(height int) string {
 
 	detailContent := strings.Join(fields, "\n")
 	detail := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(styles.Border).
 		BorderForeground(styles.Red).
 		Padding(1, 2).
 		Width(cardWidth).
@@ -434,6 +462,9 @@ func (m serverDeleteModel) renderConfirmPhase(height int) string {
 
 	// Buttons.
 	deleteBtn := "  Delete  "
+	if m.mustStopFirst() {
+		deleteBtn = "  Stop & Delete  "
+	}
 	cancelBtn := "  Cancel  "
 
 	if m.confirmIdx == 0 {
@@ -0,0 +1,471 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dnsAttachPollInterval is how often the propagation check asks the
+// nameservers again, and dnsAttachWait how long it keeps asking.
+const (
+	dnsAttachPollInterval = 5 * time.Second
+	dnsAttachWait         = 2 * time.Minute
+)
+
+// --- Messages ---
+
+type dnsAttachPlannedMsg struct {
+	changes     []dnsdomain.MirrorChange
+	nameservers []string
+}
+
+type dnsAttachAppliedMsg struct{}
+
+type dnsAttachCheckedMsg struct {
+	statuses []attach.Status
+}
+
+type dnsAttachPollMsg struct{}
+
+type dnsAttachErrorMsg struct {
+	err error
+}
+
+// --- Server DNS attach model ---
+
+// dnsAttachPhase is the step the DNS attach view is at.
+type dnsAttachPhase int
+
+const (
+	dnsAttachInput    dnsAttachPhase = iota // entering the zone and name
+	dnsAttachPlanning                       // reading the zone's records
+	dnsAttachConfirm                        // showing the changes
+	dnsAttachApplying                       // writing the records
+	dnsAttachChecking                       // asking the nameservers
+	dnsAttachDone
+)
+
+// dnsAttachField is the input the cursor is in.
+type dnsAttachField int
+
+const (
+	dnsAttachFieldDomain dnsAttachField = iota
+	dnsAttachFieldName
+	dnsAttachFieldCount
+)
+
+// serverDNSAttachModel points a name in a DNS zone at the server's
+// public addresses: it shows the A and AAAA changes, applies them once
+// confirmed, then waits for the zone's nameservers to answer with them.
+type serverDNSAttachModel struct {
+	dns          dnsdomain.Provider
+	dnsErr       error // set when no DNS provider could be built
+	lookup       attach.Lookup
+	providerName string
+	server       *domain.Server
+
+	domain components.ValidatedInput
+	name   components.ValidatedInput
+	focus  dnsAttachField
+
+	phase       dnsAttachPhase
+	zone        string
+	hostname    string
+	want        []string
+	changes     []dnsdomain.MirrorChange
+	nameservers []string
+	statuses    []attach.Status
+	deadline    time.Time
+	err         error
+	spinner     spinner.Model
+
+	width  int
+	height int
+}
+
+func newServerDNSAttachModel(dns dnsdomain.Provider, dnsErr error, providerName string, server *domain.Server) serverDNSAttachModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	zone := textinput.New()
+	zone.Placeholder = "example.com"
+	zone.CharLimit = 253
+	zone.Width = 40
+
+	name := textinput.New()
+	name.Placeholder = "@ for the zone apex"
+	name.CharLimit = 253
+	name.Width = 40
+
+	m := serverDNSAttachModel{
+		dns:          dns,
+		dnsErr:       dnsErr,
+		lookup:       attach.LookupAt,
+		providerName: providerName,
+		server:       server,
+		domain:       components.NewValidatedInput("dns-attach-domain", zone),
+		name:         components.NewValidatedInput("dns-attach-name", name),
+		spinner:      s,
+	}
+	m.domain.Focus()
+	return m
+}
+
+func (m serverDNSAttachModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// records returns the A and AAAA records the name should hold.
+func (m serverDNSAttachModel) records(name string) []dnsdomain.Record {
+	return attach.Records(name, m.server.PublicIPv4, m.server.ReachableIPv6(), 0)
+}
+
+// addresses returns the server's public addresses the records point at.
+func (m serverDNSAttachModel) addresses() []string {
+	records := m.records("@")
+	addrs := make([]string, len(records))
+	for i, r := range records {
+		addrs[i] = r.Value
+	}
+	return addrs
+}
+
+// plan reads the zone's records and nameservers and plans the changes.
+func (m serverDNSAttachModel) plan(zone string, records []dnsdomain.Record) tea.Cmd {
+	dns := m.dns
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		existing, err := dns.ListRecords(ctx, zone)
+		if err != nil {
+			return dnsAttachErrorMsg{err: fmt.Errorf("failed to list records of %s: %w", zone, err)}
+		}
+		changes, err := attach.Plan(records, existing, dns.Quirks())
+		if err != nil {
+			return dnsAttachErrorMsg{err: err}
+		}
+		zones, err := dns.ListZones(ctx)
+		if err != nil {
+			return dnsAttachErrorMsg{err: fmt.Errorf("failed to list zones: %w", err)}
+		}
+		var nameservers []string
+		for _, z := range zones {
+			if strings.EqualFold(strings.TrimSuffix(z.Name, "."), zone) {
+				nameservers = z.Nameservers
+			}
+		}
+		return dnsAttachPlannedMsg{changes: changes, nameservers: nameservers}
+	}
+}
+
+// apply writes the planned changes.
+func (m serverDNSAttachModel) apply() tea.Cmd {
+	dns, zone, changes := m.dns, m.zone, m.changes
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if _, err := dnsdomain.ApplyChanges(ctx, dns, zone, changes); err != nil {
+			return dnsAttachErrorMsg{err: err}
+		}
+		return dnsAttachAppliedMsg{}
+	}
+}
+
+// check asks the zone's nameservers for the name.
+func (m serverDNSAttachModel) check() tea.Cmd {
+	lookup, nameservers, hostname, want := m.lookup, m.nameservers, m.hostname, m.want
+	return func() tea.Msg {
+		return dnsAttachCheckedMsg{statuses: attach.Check(context.Background(), lookup, nameservers, hostname, want)}
+	}
+}
+
+// --- Update ---
+
+func (m serverDNSAttachModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case dnsAttachPlannedMsg:
+		m.changes = msg.changes
+		m.nameservers = msg.nameservers
+		if len(m.changes) == 0 {
+			return m.startCheck()
+		}
+		m.phase = dnsAttachConfirm
+		return m, nil
+
+	case dnsAttachAppliedMsg:
+		return m.startCheck()
+
+	case dnsAttachCheckedMsg:
+		m.statuses = msg.statuses
+		if attach.Propagated(m.statuses) || !time.Now().Add(dnsAttachPollInterval).Before(m.deadline) {
+			m.phase = dnsAttachDone
+			return m, nil
+		}
+		return m, tea.Tick(dnsAttachPollInterval, func(time.Time) tea.Msg { return dnsAttachPollMsg{} })
+
+	case dnsAttachPollMsg:
+		if m.phase == dnsAttachChecking {
+			return m, m.check()
+		}
+		return m, nil
+
+	case dnsAttachErrorMsg:
+		m.err = msg.err
+		m.phase = dnsAttachInput
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.busy() {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.phase == dnsAttachInput {
+		return m.updateInput(msg)
+	}
+	return m, nil
+}
+
+// busy reports whether the view is waiting on the provider or the
+// nameservers.
+func (m serverDNSAttachModel) busy() bool {
+	switch m.phase {
+	case dnsAttachPlanning, dnsAttachApplying, dnsAttachChecking:
+		return true
+	}
+	return false
+}
+
+// startCheck begins polling the nameservers.
+func (m serverDNSAttachModel) startCheck() (tea.Model, tea.Cmd) {
+	m.phase = dnsAttachChecking
+	m.deadline = time.Now().Add(dnsAttachWait)
+	return m, tea.Batch(m.spinner.Tick, m.check())
+}
+
+func (m serverDNSAttachModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.phase {
+	case dnsAttachInput:
+		switch msg.String() {
+		case "esc":
+			return m.back()
+		case "tab", "down":
+			return m.setFocus((m.focus + 1) % dnsAttachFieldCount)
+		case "shift+tab", "up":
+			return m.setFocus((m.focus + dnsAttachFieldCount - 1) % dnsAttachFieldCount)
+		case "enter":
+			return m.submit()
+		}
+		return m.updateInput(msg)
+
+	case dnsAttachConfirm:
+		switch msg.String() {
+		case "y", "enter":
+			m.phase = dnsAttachApplying
+			return m, tea.Batch(m.spinner.Tick, m.apply())
+		case "n", "esc":
+			m.phase = dnsAttachInput
+			return m, nil
+		}
+
+	case dnsAttachChecking, dnsAttachDone:
+		switch msg.String() {
+		case "esc", "q":
+			return m.back()
+		}
+	}
+	return m, nil
+}
+
+func (m serverDNSAttachModel) back() (tea.Model, tea.Cmd) {
+	server := *m.server
+	return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+}
+
+func (m serverDNSAttachModel) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.focus {
+	case dnsAttachFieldDomain:
+		m.domain, cmd = m.domain.Update(msg)
+	case dnsAttachFieldName:
+		m.name, cmd = m.name.Update(msg)
+	}
+	return m, cmd
+}
+
+// setFocus moves the cursor to field.
+func (m serverDNSAttachModel) setFocus(field dnsAttachField) (tea.Model, tea.Cmd) {
+	m.focus = field
+	m.domain.Input.Blur()
+	m.name.Input.Blur()
+	if field == dnsAttachFieldDomain {
+		return m, m.domain.Focus()
+	}
+	return m, m.name.Focus()
+}
+
+// submit checks the inputs and plans the changes.
+func (m serverDNSAttachModel) submit() (tea.Model, tea.Cmd) {
+	if m.dnsErr != nil {
+		return m, nil
+	}
+	m.err = nil
+
+	zone := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(m.domain.Value())), ".")
+	if err := dnsdomain.ValidateHostname(zone); err != nil || zone == "" {
+		m.domain.SetErr("enter the zone, e.g. example.com")
+		return m.setFocus(dnsAttachFieldDomain)
+	}
+	name := strings.TrimSpace(m.name.Value())
+	if name == "" {
+		name = "@"
+	}
+	if name != "@" {
+		if err := dnsdomain.ValidateHostname(name); err != nil {
+			m.name.SetErr(err.Error())
+			return m.setFocus(dnsAttachFieldName)
+		}
+	}
+
+	m.zone = zone
+	m.hostname = attach.Hostname(name, zone)
+	m.want = m.addresses()
+	m.phase = dnsAttachPlanning
+	return m, tea.Batch(m.spinner.Tick, m.plan(zone, m.records(name)))
+}
+
+// --- View ---
+
+func (m serverDNSAttachModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "DNS"), m.providerName)
+
+	var bindings []components.KeyBinding
+	switch m.phase {
+	case dnsAttachInput:
+		bindings = []components.KeyBinding{
+			{Key: "enter", Desc: "preview"},
+			{Key: "tab", Desc: "field"},
+			{Key: "esc", Desc: "back"},
+		}
+	case dnsAttachConfirm:
+		bindings = []components.KeyBinding{
+			{Key: "y", Desc: "apply"},
+			{Key: "n", Desc: "edit"},
+		}
+	case dnsAttachChecking, dnsAttachDone:
+		bindings = []components.KeyBinding{{Key: "esc", Desc: "back"}}
+	}
+	footer := components.Footer(m.width, bindings)
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverDNSAttachModel) renderContent(height int) string {
+	title := styles.Title.Render(fmt.Sprintf("Point a name at %q", m.server.Name))
+
+	var body string
+	switch {
+	case m.dnsErr != nil:
+		body = styles.ErrorText.Render("Error: "+m.dnsErr.Error()) + "\n\n" +
+			styles.MutedText.Render("Set one with 'vpsm config set dns-provider <name>'.")
+
+	case m.phase == dnsAttachInput:
+		fields := []string{
+			styles.Subtitle.Render("Zone"),
+			"",
+			m.domain.View(),
+			"",
+			styles.Subtitle.Render("Name"),
+			"",
+			m.name.View(),
+			"",
+			styles.MutedText.Render("A and AAAA records point at " + strings.Join(m.addresses(), ", ") + "."),
+		}
+		if m.err != nil {
+			fields = append(fields, "", styles.ErrorText.Render("Error: "+m.err.Error()))
+		}
+		body = styles.Card.Width(56).Render(strings.Join(fields, "\n"))
+
+	case m.phase == dnsAttachPlanning:
+		body = styles.MutedText.Render(m.spinner.View() + "  Reading " + m.zone + styles.Ellipsis())
+
+	case m.phase == dnsAttachConfirm:
+		lines := []string{styles.MutedText.Render(fmt.Sprintf("Changes to %s at %s:", m.zone, m.dns.GetDisplayName())), ""}
+		for _, c := range m.changes {
+			lines = append(lines, "  "+styles.Value.Render(c.String()))
+		}
+		lines = append(lines, "", styles.AccentText.Render("Apply these changes? (y/n)"))
+		body = strings.Join(lines, "\n")
+
+	case m.phase == dnsAttachApplying:
+		body = styles.MutedText.Render(m.spinner.View() + "  Saving records" + styles.Ellipsis())
+
+	default:
+		body = m.renderPropagation()
+	}
+
+	combined := lipgloss.JoinVertical(lipgloss.Center, title, "", body)
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, combined)
+}
+
+// renderPropagation lists what each nameserver answers for the name.
+func (m serverDNSAttachModel) renderPropagation() string {
+	var heading string
+	switch {
+	case m.phase == dnsAttachChecking:
+		heading = styles.MutedText.Render(m.spinner.View() + "  Waiting for the nameservers to answer with " + strings.Join(m.want, ", ") + styles.Ellipsis())
+	case attach.Propagated(m.statuses):
+		heading = styles.SuccessText.Render(fmt.Sprintf("%s resolves to %s.", m.hostname, strings.Join(m.want, ", ")))
+	default:
+		heading = styles.WarningText.Render("Not every nameserver answers with the new addresses yet.") + "\n" +
+			styles.MutedText.Render("The records are saved; the rest should follow within the zone's refresh time.")
+	}
+
+	lines := []string{heading, ""}
+	for _, s := range m.statuses {
+		if s.Propagated {
+			lines = append(lines, styles.SuccessText.Render("✓ ")+styles.Value.Render(s.String()))
+		} else {
+			lines = append(lines, styles.ErrorText.Render("✗ ")+styles.MutedText.Render(s.String()))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
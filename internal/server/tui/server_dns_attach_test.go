@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// attachDNSProvider is a DNS provider holding one zone's records.
+type attachDNSProvider struct {
+	records []dnsdomain.Record
+	created []dnsdomain.Record
+}
+
+func (p *attachDNSProvider) GetDisplayName() string   { return "Mock DNS" }
+func (p *attachDNSProvider) Quirks() dnsdomain.Quirks { return dnsdomain.Quirks{} }
+func (p *attachDNSProvider) ListZones(context.Context) ([]dnsdomain.Zone, error) {
+	return []dnsdomain.Zone{{Name: "example.com", Nameservers: []string{"ns1.example.net"}}}, nil
+}
+func (p *attachDNSProvider) ListRecords(context.Context, string) ([]dnsdomain.Record, error) {
+	return p.records, nil
+}
+func (p *attachDNSProvider) CreateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	p.created = append(p.created, r)
+	return &r, nil
+}
+func (p *attachDNSProvider) UpdateRecord(_ context.Context, _ string, r dnsdomain.Record) (*dnsdomain.Record, error) {
+	return &r, nil
+}
+func (p *attachDNSProvider) DeleteRecord(context.Context, string, string) error { return nil }
+
+func TestServerShow_DNSAttachKey(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "web", Status: "running", PublicIPv4: "203.0.113.7"}
+	m := newServerShowDirect(&reauthProvider{}, "hetzner", server, nil)
+
+	_, cmd := m.handleKey(runeKey('D'))
+	if cmd == nil {
+		t.Fatal("expected D to open the DNS view")
+	}
+	if msg, ok := cmd().(navigateToDNSAttachMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("expected navigateToDNSAttachMsg for server 7, got %#v", msg)
+	}
+
+	m = newServerShowDirect(&reauthProvider{}, "hetzner", &domain.Server{ID: "8", Name: "private"}, nil)
+	if _, cmd := m.handleKey(runeKey('D')); cmd != nil {
+		t.Error("expected D to do nothing without a public address")
+	}
+}
+
+func TestServerDNSAttach_ConfirmsThenChecks(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "web", Status: "running", PublicIPv4: "203.0.113.7"}
+	dns := &attachDNSProvider{}
+	m := newServerDNSAttachModel(dns, nil, "hetzner", server)
+	m.lookup = func(context.Context, string, string) ([]netip.Addr, error) {
+		return []netip.Addr{netip.MustParseAddr("203.0.113.7")}, nil
+	}
+
+	m.domain.Input.SetValue("Example.com.")
+	m.name.Input.SetValue("www")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverDNSAttachModel)
+	if m.phase != dnsAttachPlanning || m.hostname != "www.example.com" {
+		t.Fatalf("phase = %v, hostname = %q; want planning www.example.com", m.phase, m.hostname)
+	}
+
+	updated, _ = m.Update(m.plan(m.zone, m.records("www"))())
+	m = updated.(serverDNSAttachModel)
+	if m.phase != dnsAttachConfirm || len(m.changes) != 1 || m.changes[0].Action != dnsdomain.MirrorCreate {
+		t.Fatalf("phase = %v, changes = %v; want one create to confirm", m.phase, m.changes)
+	}
+	if len(dns.created) != 0 {
+		t.Fatal("records were written before confirming")
+	}
+
+	updated, _ = m.Update(runeKey('y'))
+	m = updated.(serverDNSAttachModel)
+	updated, _ = m.Update(m.apply()())
+	m = updated.(serverDNSAttachModel)
+	if len(dns.created) != 1 || m.phase != dnsAttachChecking {
+		t.Fatalf("created = %v, phase = %v; want the record created and checking", dns.created, m.phase)
+	}
+
+	updated, _ = m.Update(m.check()())
+	m = updated.(serverDNSAttachModel)
+	if m.phase != dnsAttachDone || len(m.statuses) != 1 || !m.statuses[0].Propagated {
+		t.Errorf("phase = %v, statuses = %v; want done and propagated", m.phase, m.statuses)
+	}
+}
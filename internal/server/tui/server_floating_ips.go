@@ -0,0 +1,348 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+type floatingIPsLoadedMsg struct {
+	timing
+	ips []domain.FloatingIP
+}
+
+type floatingIPsErrorMsg struct {
+	err error
+}
+
+// requestFloatingIPMsg is emitted by the floating IPs view when the user
+// assigns an address to the server (assign) or removes it (!assign).
+type requestFloatingIPMsg struct {
+	server domain.Server
+	ip     domain.FloatingIP
+	assign bool
+}
+
+// --- Server floating IPs model ---
+
+// serverFloatingIPsModel lists the floating IPs that can be assigned to a
+// server, i.e. those homed in its location, and assigns or unassigns the
+// selected one.
+type serverFloatingIPsModel struct {
+	provider     domain.Provider
+	providerName string
+	server       *domain.Server
+
+	ips []domain.FloatingIP
+	// confirming is set when the selected address is assigned to another
+	// server; a second enter moves it.
+	confirming bool
+	cursor     int
+	loading    bool
+	err        error
+	spinner    spinner.Model
+
+	width  int
+	height int
+}
+
+func newServerFloatingIPsModel(provider domain.Provider, providerName string, server *domain.Server) serverFloatingIPsModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	return serverFloatingIPsModel{
+		provider:     provider,
+		providerName: providerName,
+		server:       server,
+		loading:      true,
+		spinner:      s,
+	}
+}
+
+func (m serverFloatingIPsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchFloatingIPs())
+}
+
+func (m serverFloatingIPsModel) fetchFloatingIPs() tea.Cmd {
+	fp, ok := m.provider.(domain.FloatingIPProvider)
+	if !ok {
+		return func() tea.Msg {
+			return floatingIPsErrorMsg{err: fmt.Errorf("%s does not support floating IPs", m.provider.GetDisplayName())}
+		}
+	}
+	region := m.server.Region
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		list, err := fp.ListFloatingIPs(ctx)
+		if err != nil {
+			return floatingIPsErrorMsg{err: err}
+		}
+		// Only addresses homed in the server's location can be assigned.
+		ips := make([]domain.FloatingIP, 0, len(list))
+		for _, ip := range list {
+			if region == "" || ip.HomeLocation == region {
+				ips = append(ips, ip)
+			}
+		}
+		return floatingIPsLoadedMsg{timing: timed("floating IPs", began), ips: ips}
+	}
+}
+
+// --- Update ---
+
+func (m serverFloatingIPsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case floatingIPsLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.ips = msg.ips
+		if m.cursor >= len(m.ips) {
+			m.cursor = max(len(m.ips)-1, 0)
+		}
+		return m, nil
+
+	case floatingIPsErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m serverFloatingIPsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.confirming {
+		switch msg.String() {
+		case "y", "enter":
+			m.confirming = false
+			return m, m.request(true)
+		case "n", "esc", "q":
+			m.confirming = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q", "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.ips)-1 {
+			m.cursor++
+		}
+
+	case "r":
+		if !m.loading {
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.fetchFloatingIPs())
+		}
+
+	case "enter":
+		if m.loading || len(m.ips) == 0 {
+			return m, nil
+		}
+		switch ip := m.ips[m.cursor]; ip.ServerID {
+		case m.server.ID:
+			return m, nil
+		case "":
+			return m, m.request(true)
+		default:
+			m.confirming = true
+		}
+
+	case "u":
+		if !m.loading && len(m.ips) > 0 && m.ips[m.cursor].ServerID == m.server.ID {
+			return m, m.request(false)
+		}
+	}
+
+	return m, nil
+}
+
+// request emits a requestFloatingIPMsg for the selected address.
+func (m serverFloatingIPsModel) request(assign bool) tea.Cmd {
+	server := *m.server
+	ip := m.ips[m.cursor]
+	return func() tea.Msg { return requestFloatingIPMsg{server: server, ip: ip, assign: assign} }
+}
+
+// --- View ---
+
+func (m serverFloatingIPsModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "floating IPs"), m.providerName)
+
+	bindings := []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "assign"},
+	}
+	if len(m.ips) > 0 && m.ips[m.cursor].ServerID == m.server.ID {
+		bindings = append(bindings, components.KeyBinding{Key: "u", Desc: "unassign"})
+	}
+	bindings = append(bindings,
+		components.KeyBinding{Key: "r", Desc: "refresh"},
+		components.KeyBinding{Key: "esc", Desc: "back"},
+	)
+	if m.confirming {
+		bindings = []components.KeyBinding{
+			{Key: "y", Desc: "assign"},
+			{Key: "n", Desc: "cancel"},
+		}
+	}
+	footer := components.Footer(m.width, bindings)
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverFloatingIPsModel) renderContent(height int) string {
+	if m.loading {
+		loadingText := m.spinner.View() + "  Fetching floating IPs" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press r to retry or esc to go back.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			errText,
+		)
+	}
+
+	if len(m.ips) == 0 {
+		text := "No floating IPs found. Create one with 'vpsm ip create'."
+		if m.server.Region != "" {
+			text = fmt.Sprintf("No floating IPs in %s. Create one with 'vpsm ip create --location %s'.", m.server.Region, m.server.Region)
+		}
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(text),
+		)
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Floating IPs for %q", m.server.Name))
+
+	var note string
+	if m.confirming {
+		ip := m.ips[m.cursor]
+		note = styles.WarningText.Render(fmt.Sprintf("%s %s is assigned to server %s. Move it to %q?",
+			styles.Warning(), ip.IP, ip.ServerID, m.server.Name))
+	} else {
+		note = styles.MutedText.Render("The server must also configure an assigned address on a network interface.")
+	}
+
+	combined := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		m.renderFloatingIPs(height-6),
+		"",
+		note,
+	)
+
+	return lipgloss.Place(
+		m.width, height,
+		lipgloss.Center, lipgloss.Center,
+		combined,
+	)
+}
+
+// renderFloatingIPs renders the window of floating IPs around the cursor.
+func (m serverFloatingIPsModel) renderFloatingIPs(maxVisible int) string {
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.ips))
+
+	rows := make([]string, 0, end-start+2)
+	if start > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more above", styles.Ellipsis(), start)))
+	}
+	for i := start; i < end; i++ {
+		label := m.floatingIPLabel(m.ips[i])
+		if i == m.cursor {
+			rows = append(rows, styles.AccentText.Render("> ")+styles.Value.Bold(true).Render(label))
+		} else {
+			rows = append(rows, "  "+styles.MutedText.Render(label))
+		}
+	}
+	if remaining := len(m.ips) - end; remaining > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more below", styles.Ellipsis(), remaining)))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// floatingIPLabel formats an address as "203.0.113.7 (web-vip) - assigned here".
+func (m serverFloatingIPsModel) floatingIPLabel(ip domain.FloatingIP) string {
+	label := ip.IP
+	if ip.Name != "" {
+		label += " (" + ip.Name + ")"
+	}
+	switch ip.ServerID {
+	case "":
+		return label + " - unassigned"
+	case m.server.ID:
+		return label + " - assigned here"
+	default:
+		return label + " - assigned to server " + ip.ServerID
+	}
+}
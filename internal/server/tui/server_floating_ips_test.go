@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// floatingIPProvider is a provider that manages floating IPs.
+type floatingIPProvider struct {
+	reauthProvider
+	ips        []domain.FloatingIP
+	assigned   string
+	unassigned string
+}
+
+func (p *floatingIPProvider) ListFloatingIPs(context.Context) ([]domain.FloatingIP, error) {
+	return p.ips, nil
+}
+
+func (p *floatingIPProvider) CreateFloatingIP(context.Context, domain.CreateFloatingIPOpts) (*domain.FloatingIP, error) {
+	return nil, nil
+}
+
+func (p *floatingIPProvider) AssignFloatingIP(_ context.Context, floatingIPID, serverID string) (*domain.ActionStatus, error) {
+	p.assigned = floatingIPID + "->" + serverID
+	return &domain.ActionStatus{ID: "21", Status: domain.ActionStatusRunning}, nil
+}
+
+func (p *floatingIPProvider) UnassignFloatingIP(_ context.Context, floatingIPID string) (*domain.ActionStatus, error) {
+	p.unassigned = floatingIPID
+	return &domain.ActionStatus{ID: "22", Status: domain.ActionStatusRunning}, nil
+}
+
+func TestServerShow_FloatingIPsKey(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running"}
+	m := newServerShowDirect(&floatingIPProvider{}, "hetzner", server, nil)
+
+	_, cmd := m.handleKey(runeKey('f'))
+	if cmd == nil {
+		t.Fatal("expected f to open the floating IPs view")
+	}
+	if msg, ok := cmd().(navigateToFloatingIPsMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("expected navigateToFloatingIPsMsg for server 7, got %#v", msg)
+	}
+
+	m = newServerShowDirect(&reauthProvider{}, "hetzner", server, nil)
+	if _, cmd := m.handleKey(runeKey('f')); cmd != nil {
+		t.Error("expected f to do nothing without floating IP support")
+	}
+}
+
+func TestServerFloatingIPs_OnlyListsServerLocation(t *testing.T) {
+	provider := &floatingIPProvider{ips: []domain.FloatingIP{
+		{ID: "1", IP: "203.0.113.1", HomeLocation: "fsn1"},
+		{ID: "2", IP: "203.0.113.2", HomeLocation: "nbg1"},
+	}}
+	m := newServerFloatingIPsModel(provider, "hetzner", &domain.Server{ID: "7", Region: "fsn1"})
+
+	msg, ok := m.fetchFloatingIPs()().(floatingIPsLoadedMsg)
+	if !ok || len(msg.ips) != 1 || msg.ips[0].ID != "1" {
+		t.Errorf("expected only the fsn1 address, got %#v", msg)
+	}
+}
+
+func TestServerFloatingIPs_AssignAndUnassign(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", Region: "fsn1"}
+	m := newServerFloatingIPsModel(&floatingIPProvider{}, "hetzner", server)
+	updated, _ := m.Update(floatingIPsLoadedMsg{ips: []domain.FloatingIP{
+		{ID: "1", IP: "203.0.113.1", HomeLocation: "fsn1"},
+		{ID: "2", IP: "203.0.113.2", HomeLocation: "fsn1", ServerID: "7"},
+		{ID: "3", IP: "203.0.113.3", HomeLocation: "fsn1", ServerID: "8"},
+	}})
+	m = updated.(serverFloatingIPsModel)
+
+	// An unassigned address is assigned straight away.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(requestFloatingIPMsg); !ok || !msg.assign || msg.ip.ID != "1" || msg.server.ID != "7" {
+		t.Errorf("expected assign of 1 to server 7, got %#v", msg)
+	}
+
+	// The server's own address can only be unassigned.
+	updated, _ = m.Update(runeKey('j'))
+	m = updated.(serverFloatingIPsModel)
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected enter on an address assigned here to do nothing")
+	}
+	_, cmd = m.Update(runeKey('u'))
+	if msg, ok := cmd().(requestFloatingIPMsg); !ok || msg.assign || msg.ip.ID != "2" {
+		t.Errorf("expected unassign of 2, got %#v", msg)
+	}
+
+	// Moving an address away from another server needs confirmation.
+	updated, _ = m.Update(runeKey('j'))
+	updated, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverFloatingIPsModel)
+	if cmd != nil || !m.confirming {
+		t.Fatal("expected enter to ask before moving an address from another server")
+	}
+	if !strings.Contains(m.floatingIPLabel(m.ips[2]), "assigned to server 8") {
+		t.Errorf("label = %q", m.floatingIPLabel(m.ips[2]))
+	}
+	_, cmd = m.Update(runeKey('y'))
+	if msg, ok := cmd().(requestFloatingIPMsg); !ok || !msg.assign || msg.ip.ID != "3" {
+		t.Errorf("expected assign of 3, got %#v", msg)
+	}
+}
+
+func TestOpsOverlay_StartFloatingIPTracksAction(t *testing.T) {
+	provider := &floatingIPProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+
+	o, cmd := o.StartFloatingIP(domain.Server{ID: "7", Name: "app", Status: "off"}, domain.FloatingIP{ID: "1"}, true)
+	if len(o.ops) != 1 || o.ops[0].verb != "floating IP assigned" || o.ops[0].target != "off" {
+		t.Fatalf("expected one assign operation, got %+v", o.ops)
+	}
+	if inferCommand(o.ops[0].verb) != "assign_floating_ip" {
+		t.Errorf("command = %q, want assign_floating_ip", inferCommand(o.ops[0].verb))
+	}
+	if !strings.HasPrefix(o.ops[0].statusText, "Assigning a floating IP to") {
+		t.Errorf("status text = %q", o.ops[0].statusText)
+	}
+
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	if provider.assigned != "1->7" {
+		t.Errorf("expected AssignFloatingIP(1, 7), got %q", provider.assigned)
+	}
+}
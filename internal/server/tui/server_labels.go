@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+// requestLabelsMsg is emitted by the labels view when the user saves a
+// server's labels; previous are the labels it had, for undo.
+type requestLabelsMsg struct {
+	server   domain.Server
+	labels   map[string]string
+	previous map[string]string
+}
+
+// --- Server labels model ---
+
+// serverLabelsModel edits a server's labels as one comma-separated list
+// of key=value pairs.
+type serverLabelsModel struct {
+	providerName string
+	server       *domain.Server
+
+	input    textinput.Model
+	inputErr string
+
+	width  int
+	height int
+}
+
+func newServerLabelsModel(providerName string, server *domain.Server) serverLabelsModel {
+	input := textinput.New()
+	input.Placeholder = "env=prod, role=web"
+	input.CharLimit = 1024
+	input.Width = 60
+	input.SetValue(domain.FormatLabels(server.Labels))
+	input.CursorEnd()
+
+	return serverLabelsModel{
+		providerName: providerName,
+		server:       server,
+		input:        input,
+	}
+}
+
+func (m serverLabelsModel) Init() tea.Cmd {
+	return m.input.Focus()
+}
+
+// --- Update ---
+
+func (m serverLabelsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m serverLabelsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "enter":
+		labels, err := domain.ParseLabels(m.input.Value())
+		if err != nil {
+			m.inputErr = err.Error()
+			return m, nil
+		}
+		server := *m.server
+		if maps.Equal(labels, server.Labels) {
+			return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+		}
+		previous := maps.Clone(server.Labels)
+		if previous == nil {
+			previous = map[string]string{}
+		}
+		return m, func() tea.Msg {
+			return requestLabelsMsg{server: server, labels: labels, previous: previous}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.inputErr = ""
+	return m, cmd
+}
+
+// --- View ---
+
+func (m serverLabelsModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "labels"), m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "enter", Desc: "save"},
+		{Key: "esc", Desc: "cancel"},
+	})
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Labels of %q", m.server.Name))
+	hint := styles.MutedText.Render("Comma-separated key=value pairs; empty removes every label:")
+	body := lipgloss.JoinVertical(lipgloss.Left, title, "", hint, m.input.View())
+	if m.inputErr != "" {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, styles.ErrorText.Render(m.inputErr))
+	}
+
+	content := lipgloss.Place(
+		m.width, contentH,
+		lipgloss.Center, lipgloss.Center,
+		body,
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/go-cmp/cmp"
+)
+
+// labelProvider is a provider that edits server labels.
+type labelProvider struct {
+	reauthProvider
+	set []map[string]string
+}
+
+func (p *labelProvider) SetLabels(_ context.Context, _ string, labels map[string]string) error {
+	p.set = append(p.set, labels)
+	return nil
+}
+
+func TestServerLabels_EnterRequestsChange(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Labels: map[string]string{"env": "dev"}}
+	m := newServerLabelsModel("mock", server)
+	if got := m.input.Value(); got != "env=dev" {
+		t.Errorf("input = %q, want the current labels", got)
+	}
+
+	m.input.SetValue("env=prod, role")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverLabelsModel)
+	if m.inputErr == "" {
+		t.Fatal("expected an error for a label without a value")
+	}
+
+	m.input.SetValue("env=prod, role=web")
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(requestLabelsMsg)
+	if !ok {
+		t.Fatalf("expected a labels request, got %#v", cmd())
+	}
+	if diff := cmp.Diff(map[string]string{"env": "prod", "role": "web"}, msg.labels); diff != "" {
+		t.Errorf("labels mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"env": "dev"}, msg.previous); diff != "" {
+		t.Errorf("previous mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOpsOverlay_StartLabelsUndoRestoresPrevious(t *testing.T) {
+	provider := &labelProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+	server := domain.Server{ID: "7", Name: "app", Status: "running"}
+
+	o, cmd := o.StartLabels(server, map[string]string{"env": "prod"}, map[string]string{})
+	if len(o.ops) != 1 || o.ops[0].verb != "labels updated" || o.ops[0].undo == nil {
+		t.Fatalf("expected one undoable labels operation, got %+v", o.ops)
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+
+	o, cmd = o.ops[0].undo(o)
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	want := []map[string]string{{"env": "prod"}, {}}
+	if diff := cmp.Diff(want, provider.set); diff != "" {
+		t.Errorf("labels set mismatch (-want +got):\n%s", diff)
+	}
+	if o.ops[1].undo != nil {
+		t.Error("expected the undo itself not to be undoable")
+	}
+}
@@ -3,10 +3,18 @@ package tui
 import (
 	"context"
 	"fmt"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/platform/tmux"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/export"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -17,6 +25,7 @@ import (
 // --- Messages ---
 
 type serversLoadedMsg struct {
+	timing
 	servers []domain.Server
 }
 
@@ -24,8 +33,18 @@ type serversErrorMsg struct {
 	err error
 }
 
+// serversExportedMsg reports the outcome of a CSV export from the list view.
+type serversExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
 // --- Server list model ---
 
+// interruptibleBadge marks spot servers in the NAME column.
+const interruptibleBadge = "SPOT"
+
 type serverListModel struct {
 	provider     domain.Provider
 	providerName string
@@ -52,19 +71,97 @@ type serverListModel struct {
 
 	// Set when the user selects a server for detail/delete.
 	selectedServer *domain.Server
-	action         string // "show", "delete", or ""
+	action         string // "show", "delete", "pick", or ""
 	quitting       bool
 
+	// labelColumns lists label keys rendered as extra table columns,
+	// loaded from the label-columns config key.
+	labelColumns []string
+
+	// marked holds the IDs of servers selected for a bulk action.
+	marked map[string]bool
+	// confirmBulkDelete is set after the first press of the bulk delete
+	// key; a second press deletes.
+	confirmBulkDelete bool
+
+	// canSwitchProject is set when the provider has project tokens, so
+	// the app's project picker is offered.
+	canSwitchProject bool
+
 	// embedded is true when this model is managed by serverAppModel.
 	// When true, navigation actions emit messages instead of tea.Quit.
 	embedded bool
+
+	// picker is true for "vpsm server pick": enter selects the server and
+	// exits, and actions that change servers are disabled.
+	picker bool
+
+	// deletions marks servers whose deletion was accepted as deleting.
+	// deletionRefreshPending is set while a quiet refresh is scheduled
+	// to drop them once the provider has.
+	deletions              *deletionTracker
+	deletionRefreshPending bool
+
+	// hoverSeq numbers cursor moves so only the latest hover tick
+	// triggers a prefetch.
+	hoverSeq int
+}
+
+// loadLabelColumns returns the configured label columns, or nil if the
+// config cannot be read.
+func loadLabelColumns() []string {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	return cfg.LabelColumns
+}
+
+// loadDeleteRequiresStopped reports whether the delete-require-stopped
+// policy is on. An unreadable config leaves it off.
+func loadDeleteRequiresStopped() bool {
+	cfg, err := config.Load()
+	if err != nil {
+		return false
+	}
+	return cfg.DeleteRequiresStopped()
+}
+
+// loadSSHLaunch returns where SSH sessions open. An unreadable or
+// invalid config opens them in the current terminal.
+func loadSSHLaunch() tmux.Mode {
+	cfg, err := config.Load()
+	if err != nil {
+		return tmux.ModeExec
+	}
+	mode, err := tmux.ParseMode(cfg.SSHLaunch)
+	if err != nil {
+		return tmux.ModeExec
+	}
+	return mode
+}
+
+// loadSSHOptions returns the configured extra ssh arguments, or "" if the
+// config cannot be read.
+func loadSSHOptions() string {
+	cfg, err := config.Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.SSHOptions
+}
+
+// canReboot reports whether the provider can reboot servers.
+func (m serverListModel) canReboot() bool {
+	_, ok := m.provider.(domain.RebootProvider)
+	return ok
 }
 
 // RunServerList starts the full-window interactive server list TUI.
 // It returns the selected server (if any), the action to take, and any error.
 func RunServerList(provider domain.Provider, providerName string) (*domain.Server, string, error) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	m := serverListModel{
@@ -73,9 +170,10 @@ func RunServerList(provider domain.Provider, providerName string) (*domain.Serve
 		loading:      true,
 		spinner:      s,
 		poller:       newTogglePoller(provider),
+		labelColumns: loadLabelColumns(),
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to run server list: %w", err)
@@ -85,6 +183,35 @@ func RunServerList(provider domain.Provider, providerName string) (*domain.Serve
 	return final.selectedServer, final.action, nil
 }
 
+// RunServerPicker opens the server list for choosing a single server and
+// returns it, or nil if the user quit without choosing. The TUI is drawn
+// on stderr so stdout stays free for the caller's output, e.g. inside
+// $(vpsm server pick).
+func RunServerPicker(provider domain.Provider, providerName string) (*domain.Server, error) {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	m := serverListModel{
+		provider:     provider,
+		providerName: providerName,
+		loading:      true,
+		spinner:      s,
+		poller:       newTogglePoller(provider),
+		labelColumns: loadLabelColumns(),
+		picker:       true,
+	}
+
+	styles.RenderTo(os.Stderr)
+	p := crashguard.NewProgram(m, styles.ProgramOptions(tea.WithOutput(os.Stderr))...)
+	result, err := p.Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run server picker: %w", err)
+	}
+
+	return result.(serverListModel).selectedServer, nil
+}
+
 func (m serverListModel) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
@@ -94,11 +221,12 @@ func (m serverListModel) Init() tea.Cmd {
 
 func (m serverListModel) fetchServers() tea.Cmd {
 	return func() tea.Msg {
+		began := time.Now()
 		servers, err := m.provider.ListServers(context.Background())
 		if err != nil {
 			return serversErrorMsg{err: err}
 		}
-		return serversLoadedMsg{servers: servers}
+		return serversLoadedMsg{timing: timed("list fetch", began), servers: servers}
 	}
 }
 
@@ -112,11 +240,34 @@ func (m serverListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
-		return m.handleKey(msg)
+		prevID := m.selectedID()
+		updated, cmd := m.handleKey(msg)
+		list, ok := updated.(serverListModel)
+		if !ok {
+			return updated, cmd
+		}
+		list, hover := list.scheduleHoverPrefetch(prevID)
+		return list, tea.Batch(cmd, hover)
+
+	case hoverTickMsg:
+		if msg.seq != m.hoverSeq || msg.serverID != m.selectedID() {
+			return m, nil
+		}
+		server := m.servers[m.cursor]
+		return m, func() tea.Msg { return requestPrefetchMsg{server: server} }
+
+	case events.Msg:
+		if !events.Wants(m, msg.Event) {
+			return m, nil
+		}
+		m.loading = true
+		m.err = nil
+		m.status = ""
+		return m, tea.Batch(m.spinner.Tick, m.fetchServers())
 
 	case serversLoadedMsg:
 		m.loading = false
-		m.servers = msg.servers
+		m.servers = m.deletions.Apply(msg.servers)
 		m.err = nil
 		if m.persistentStatus != "" {
 			m.status = m.persistentStatus
@@ -129,8 +280,19 @@ func (m serverListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.status = fmt.Sprintf("%d server(s)", len(m.servers))
 			m.statusIsError = false
 		}
+		if m.hasDeleting() && !m.deletionRefreshPending {
+			m.deletionRefreshPending = true
+			return m, tea.Batch(m.spinner.Tick, scheduleDeletionRefresh())
+		}
 		return m, nil
 
+	case deletionRefreshTickMsg:
+		m.deletionRefreshPending = false
+		if m.loading || !m.hasDeleting() {
+			return m, nil
+		}
+		return m, m.fetchServers()
+
 	case serversErrorMsg:
 		m.loading = false
 		m.err = msg.err
@@ -138,6 +300,16 @@ func (m serverListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusIsError = false
 		return m, nil
 
+	case serversExportedMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Export failed: %v", msg.err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Exported %d server(s) to %s", msg.count, msg.path)
+		m.statusIsError = false
+		return m, nil
+
 	// --- Toggle lifecycle (delegated to togglePoller) ---
 
 	case serverToggleInitiatedMsg:
@@ -170,7 +342,7 @@ func (m serverListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.applyToggleOutcome(outcome, cmd)
 
 	case spinner.TickMsg:
-		needsSpinner := m.loading || (!m.embedded && m.poller.active)
+		needsSpinner := m.loading || (!m.embedded && m.poller.active) || m.hasDeleting()
 		if needsSpinner {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
@@ -182,6 +354,21 @@ func (m serverListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// hasDeleting reports whether any listed server is being deleted.
+func (m serverListModel) hasDeleting() bool {
+	for _, s := range m.servers {
+		if s.IsDeleting() {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscriptions refreshes the list when any server changes.
+func (m serverListModel) Subscriptions() []events.Topic {
+	return []events.Topic{{Resource: events.ResourceServer}}
+}
+
 // applyToggleOutcome interprets a toggleOutcome from the poller and updates
 // the model accordingly. When the outcome signals success, the server list
 // is refreshed. On error/timeout the status bar is updated.
@@ -196,13 +383,13 @@ func (m serverListModel) applyToggleOutcome(outcome *toggleOutcome, pollerCmd te
 	if outcome.Success {
 		m.loading = true
 		m.persistentStatus = fmt.Sprintf("Server %q %s successfully", outcome.ServerName, outcome.Verb)
-		return m, tea.Batch(m.spinner.Tick, m.fetchServers())
+		return m, tea.Batch(m.spinner.Tick, m.fetchServers(), announce(stateSentence(outcome.ServerName, outcome.Verb)))
 	}
 
 	// Error or timeout.
 	m.status = outcome.StatusText
 	m.statusIsError = outcome.IsError
-	return m, nil
+	return m, announce(sentence(outcome.StatusText))
 }
 
 // --- Key handling ---
@@ -220,11 +407,49 @@ func (m serverListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if m.picker {
+		switch msg.String() {
+		case " ", "d", "R", "s", "c", "e", "1", "2", "3", "4", "5":
+			return m, nil
+		}
+	}
+
+	// A server being deleted can only be looked at in the list.
+	if len(m.servers) > 0 && m.servers[m.cursor].IsDeleting() {
+		switch msg.String() {
+		case " ", "enter", "d", "R", "s", "1", "2", "3", "4", "5":
+			m.status = fmt.Sprintf("Server %q is being deleted", m.servers[m.cursor].Name)
+			m.statusIsError = true
+			return m, nil
+		}
+	}
+
+	// Any key other than a repeated number key cancels a pending bulk
+	// delete confirmation.
+	if _, err := strconv.Atoi(msg.String()); err != nil {
+		m.confirmBulkDelete = false
+	}
+
 	switch msg.String() {
-	case "ctrl+c", "q", "esc":
+	case "esc":
+		if len(m.marked) > 0 {
+			m.marked = nil
+			m.status = ""
+			m.statusIsError = false
+			return m, nil
+		}
 		m.quitting = true
 		return m, tea.Quit
 
+	case "ctrl+c", "q":
+		m.quitting = true
+		return m, tea.Quit
+
+	case " ":
+		if m.embedded {
+			m = m.toggleMark()
+		}
+
 	case "up", "k":
 		if m.cursor > 0 {
 			m.cursor--
@@ -251,6 +476,9 @@ func (m serverListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			m.selectedServer = &server
 			m.action = "show"
+			if m.picker {
+				m.action = "pick"
+			}
 			return m, tea.Quit
 		}
 
@@ -265,6 +493,17 @@ func (m serverListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		}
 
+	case "R":
+		if len(m.servers) > 0 && m.embedded && m.canReboot() {
+			server := m.servers[m.cursor]
+			if server.Status != "running" {
+				m.status = fmt.Sprintf("Cannot reboot server %q: status is %q", server.Name, server.Status)
+				m.statusIsError = true
+				return m, nil
+			}
+			return m, func() tea.Msg { return requestRebootMsg{server: server} }
+		}
+
 	case "s":
 		if len(m.servers) > 0 {
 			server := m.servers[m.cursor]
@@ -304,17 +543,52 @@ func (m serverListModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.action = "create"
 		return m, tea.Quit
 
+	case "e":
+		if len(m.servers) > 0 {
+			return m, m.exportServers()
+		}
+
 	case "r":
 		m.loading = true
 		m.err = nil
 		m.status = ""
 		m.statusIsError = false
 		return m, tea.Batch(m.spinner.Tick, m.fetchServers())
+
+	case "1", "2", "3", "4", "5":
+		if len(m.markedServers()) > 0 {
+			if updated, cmd, ok := m.runBulkAction(msg.String()); ok {
+				return updated, cmd
+			}
+			return m, nil
+		}
+		if updated, cmd, ok := m.runQuickAction(msg.String()); ok {
+			return updated, cmd
+		}
 	}
 
 	return m, nil
 }
 
+// exportServers writes the servers currently shown in the list to a
+// timestamped CSV file in the working directory. All columns are written,
+// including those hidden at the current terminal width.
+func (m serverListModel) exportServers() tea.Cmd {
+	servers := append([]domain.Server(nil), m.servers...)
+	providerName := m.providerName
+	return func() tea.Msg {
+		dir, err := os.Getwd()
+		if err != nil {
+			return serversExportedMsg{err: err}
+		}
+		path := export.DefaultCSVPath(dir, providerName, time.Now())
+		if err := export.WriteCSVFile(path, servers); err != nil {
+			return serversExportedMsg{err: err}
+		}
+		return serversExportedMsg{path: path, count: len(servers)}
+	}
+}
+
 // --- View ---
 
 func (m serverListModel) View() string {
@@ -322,24 +596,53 @@ func (m serverListModel) View() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "server list", m.providerName)
+	trail := serverTrail(nil)
+	if m.picker {
+		trail = []string{"server pick"}
+	}
+	header := components.HeaderTrail(m.width, trail, m.providerName)
 
 	var footerBindings []components.KeyBinding
 	showReduced := m.loading || (!m.embedded && m.poller.active)
-	if showReduced {
+	switch {
+	case showReduced:
 		footerBindings = []components.KeyBinding{
 			{Key: "ctrl+c", Desc: "quit"},
 		}
-	} else {
+	case m.picker:
+		footerBindings = []components.KeyBinding{
+			{Key: "j/k", Desc: "navigate"},
+			{Key: "enter", Desc: "select"},
+			{Key: "r", Desc: "refresh"},
+			{Key: "q", Desc: "quit"},
+		}
+	default:
 		footerBindings = []components.KeyBinding{
 			{Key: "j/k", Desc: "navigate"},
 			{Key: "enter", Desc: "show"},
 			{Key: "s", Desc: "start/stop"},
 			{Key: "d", Desc: "delete"},
 			{Key: "c", Desc: "create"},
+			{Key: "e", Desc: "export"},
 			{Key: "r", Desc: "refresh"},
-			{Key: "q", Desc: "quit"},
 		}
+		if m.embedded && m.canReboot() {
+			footerBindings = append(footerBindings, components.KeyBinding{Key: "R", Desc: "reboot"})
+		}
+		if m.embedded {
+			footerBindings = append(footerBindings,
+				components.KeyBinding{Key: "space", Desc: "mark"},
+				components.KeyBinding{Key: "o", Desc: "groups"},
+				components.KeyBinding{Key: "ctrl+f", Desc: "search"},
+			)
+		}
+		if _, ok := m.provider.(domain.CatalogProvider); ok && m.embedded {
+			footerBindings = append(footerBindings, components.KeyBinding{Key: "$", Desc: "cost"})
+		}
+		if m.canSwitchProject {
+			footerBindings = append(footerBindings, components.KeyBinding{Key: "p", Desc: "project"})
+		}
+		footerBindings = append(footerBindings, components.KeyBinding{Key: "q", Desc: "quit"})
 	}
 	footer := components.Footer(m.width, footerBindings)
 
@@ -350,11 +653,20 @@ func (m serverListModel) View() string {
 		statusBar = components.StatusBar(m.width, m.status, m.statusIsError)
 	}
 
+	actionsBar := ""
+	if !showReduced {
+		actionsBar = m.renderQuickActions()
+	}
+
 	// Calculate available height for content.
 	headerH := lipgloss.Height(header)
 	footerH := lipgloss.Height(footer)
 	statusH := lipgloss.Height(statusBar)
-	contentH := m.height - headerH - footerH - statusH
+	actionsH := 0
+	if actionsBar != "" {
+		actionsH = lipgloss.Height(actionsBar)
+	}
+	contentH := m.height - headerH - footerH - statusH - actionsH
 	if contentH < 1 {
 		contentH = 1
 	}
@@ -363,6 +675,9 @@ func (m serverListModel) View() string {
 
 	// Assemble the full layout.
 	sections := []string{header, content}
+	if actionsBar != "" {
+		sections = append(sections, actionsBar)
+	}
 	if statusBar != "" {
 		sections = append(sections, statusBar)
 	}
@@ -373,7 +688,7 @@ func (m serverListModel) View() string {
 
 func (m serverListModel) renderContent(height int) string {
 	if m.loading {
-		loadingText := m.spinner.View() + "  Fetching servers…"
+		loadingText := m.spinner.View() + "  Fetching servers" + styles.Ellipsis()
 		return lipgloss.Place(
 			m.width, height,
 			lipgloss.Center, lipgloss.Center,
@@ -400,14 +715,76 @@ func (m serverListModel) renderContent(height int) string {
 		)
 	}
 
+	if styles.Accessible() {
+		return m.renderLines(height)
+	}
 	return m.renderTable(height)
 }
 
+// renderLines lists the servers one per line for screen readers, which
+// read a table cell by cell without its column headings.
+func (m serverListModel) renderLines(height int) string {
+	start, end := visibleRange(m.cursor, len(m.servers), height-1)
+	lines := []string{fmt.Sprintf("%d servers", len(m.servers))}
+	for i := start; i < end; i++ {
+		s := m.servers[i]
+		details := []string{s.Status}
+		if s.Interruptible {
+			details = append(details, "interruptible")
+		}
+		for _, key := range m.labelColumns {
+			if value := s.Labels[key]; value != "" {
+				details = append(details, key+" "+value)
+			}
+		}
+		for _, field := range []struct{ name, value string }{
+			{"type", s.ServerType},
+			{"IPv4", s.PublicIPv4},
+			{"region", s.Region},
+			{"image", s.Image},
+		} {
+			if field.value != "" {
+				details = append(details, field.name+" "+field.value)
+			}
+		}
+		if m.marked[s.ID] {
+			details = append(details, "marked")
+		}
+
+		line := s.Name + ": " + strings.Join(details, ", ")
+		if i == m.cursor {
+			line = styles.TableSelectedRow.UnsetPadding().Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+	return lipgloss.NewStyle().Padding(0, 2).Render(strings.Join(lines, "\n"))
+}
+
+// visibleRange returns the slice [start, end) of n rows to show in a
+// window of size rows so that the cursor stays visible.
+func visibleRange(cursor, n, size int) (start, end int) {
+	if size < 1 {
+		size = 1
+	}
+	if cursor >= size {
+		start = cursor - size + 1
+	}
+	end = start + size
+	if end > n {
+		end = n
+		start = max(end-size, 0)
+	}
+	return start, end
+}
+
 func (m serverListModel) renderTable(height int) string {
 	// Define columns.
 	type column struct {
 		title string
 		width int
+		label string // label key for label columns, empty otherwise
 	}
 
 	// Calculate dynamic column widths based on terminal width.
@@ -417,12 +794,17 @@ func (m serverListModel) renderTable(height int) string {
 	// Minimum column widths.
 	cols := []column{
 		{title: "NAME", width: 18},
+	}
+	for _, key := range m.labelColumns {
+		cols = append(cols, column{title: strings.ToUpper(key), width: 10, label: key})
+	}
+	cols = append(cols, []column{
 		{title: "STATUS", width: 12},
 		{title: "TYPE", width: 10},
 		{title: "IPv4", width: 16},
 		{title: "REGION", width: 8},
 		{title: "IMAGE", width: 16},
-	}
+	}...)
 
 	// If terminal is wide enough, add ID column.
 	totalMin := 0
@@ -460,7 +842,7 @@ func (m serverListModel) renderTable(height int) string {
 	headerRow := lipgloss.JoinHorizontal(lipgloss.Top, headerCells...)
 
 	// Separator.
-	sep := styles.MutedText.Render(strings.Repeat("─", available))
+	sep := styles.MutedText.Render(strings.Repeat(styles.HLine(), available))
 
 	// Render data rows.
 	visibleRows := height - 3 // header + sep + bottom padding
@@ -469,33 +851,46 @@ func (m serverListModel) renderTable(height int) string {
 	}
 
 	// Scrolling: keep cursor visible.
-	startIdx := 0
-	if m.cursor >= visibleRows {
-		startIdx = m.cursor - visibleRows + 1
-	}
-	endIdx := startIdx + visibleRows
-	if endIdx > len(m.servers) {
-		endIdx = len(m.servers)
-		startIdx = endIdx - visibleRows
-		if startIdx < 0 {
-			startIdx = 0
-		}
-	}
+	startIdx, endIdx := visibleRange(m.cursor, len(m.servers), visibleRows)
 
 	rows := make([]string, 0, visibleRows)
 	for i := startIdx; i < endIdx; i++ {
 		s := m.servers[i]
 		isSelected := i == m.cursor
+		deleting := s.IsDeleting()
 
 		cells := make([]string, 0, len(cols))
 		for _, col := range cols {
 			var value string
-			switch col.title {
-			case "ID":
+			switch {
+			case col.label != "":
+				value = truncate(s.Labels[col.label], col.width-2)
+			case col.title == "ID":
 				value = truncate(s.ID, col.width-2)
-			case "NAME":
-				value = truncate(s.Name, col.width-2)
-			case "STATUS":
+			case col.title == "NAME":
+				marker := "  "
+				if m.marked[s.ID] {
+					marker = styles.Bullet() + " "
+				}
+				// Spot servers can vanish at any time, so flag them loudly.
+				badge := ""
+				if s.Interruptible {
+					badge = " " + interruptibleBadge
+				}
+				value = marker + truncate(s.Name, col.width-4-len(badge))
+				if badge != "" && !isSelected {
+					badge = styles.WarningText.Bold(true).Render(badge)
+				}
+				value += badge
+			case col.title == "STATUS" && deleting:
+				// Dot frames carry their own trailing space; the ASCII
+				// line spinner does not.
+				frame := m.spinner.View()
+				if lipgloss.Width(frame) < 2 {
+					frame += " "
+				}
+				value = frame + s.Status
+			case col.title == "STATUS":
 				if isSelected {
 					value = truncate(s.Status, col.width-2)
 				} else {
@@ -506,19 +901,23 @@ func (m serverListModel) renderTable(height int) string {
 						Render(s.Status))
 					continue
 				}
-			case "TYPE":
+			case col.title == "TYPE":
 				value = truncate(s.ServerType, col.width-2)
-			case "IPv4":
+			case col.title == "IPv4":
 				value = truncate(s.PublicIPv4, col.width-2)
-			case "REGION":
+			case col.title == "REGION":
 				value = truncate(s.Region, col.width-2)
-			case "IMAGE":
+			case col.title == "IMAGE":
 				value = truncate(s.Image, col.width-2)
 			}
 
 			cellStyle := styles.TableCell.Width(col.width)
-			if isSelected {
+			switch {
+			case isSelected:
 				cellStyle = styles.TableSelectedRow.Width(col.width)
+			case deleting:
+				// Grey out servers that are going away.
+				cellStyle = cellStyle.Foreground(styles.DimGray)
 			}
 			cells = append(cells, cellStyle.Render(value))
 		}
@@ -551,5 +950,8 @@ func truncate(s string, maxWidth int) string {
 	if maxWidth <= 3 {
 		return s[:maxWidth]
 	}
+	if styles.ASCII() {
+		return s[:maxWidth-3] + "..."
+	}
 	return s[:maxWidth-1] + "…"
 }
@@ -0,0 +1,334 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bootlog"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logsFollowInterval is the delay between refetches while follow mode is on.
+const logsFollowInterval = 5 * time.Second
+
+// --- Messages ---
+
+type logsLoadedMsg struct {
+	timing
+	log *bootlog.Log
+}
+
+type logsErrorMsg struct {
+	err error
+}
+
+// logsFollowTickMsg fires when follow mode is due to refetch the log.
+// gen is the followGen it was scheduled under, so ticks left over from
+// an earlier schedule are ignored.
+type logsFollowTickMsg struct {
+	gen int
+}
+
+// --- Server logs model ---
+
+// serverLogsModel displays a server's boot output (provider console output
+// or the cloud-init log fetched over SSH) in a scrollable viewport.
+type serverLogsModel struct {
+	provider     domain.Provider
+	providerName string
+	server       *domain.Server
+	conn         remote.Conn
+
+	log     *bootlog.Log
+	loading bool
+	err     error
+	spinner spinner.Model
+
+	// follow refetches the log periodically and keeps the viewport pinned
+	// to the bottom, like tail -f.
+	follow bool
+	// followGen counts the follow ticks scheduled; only the latest one
+	// refetches, so at most one polling chain runs.
+	followGen int
+	viewport  viewport.Model
+
+	width  int
+	height int
+
+	embedded bool
+}
+
+func newServerLogsModel(provider domain.Provider, providerName string, server *domain.Server, conn remote.Conn) serverLogsModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	vp := viewport.New(0, 0)
+	vp.KeyMap = detailViewportKeyMap()
+
+	return serverLogsModel{
+		provider:     provider,
+		providerName: providerName,
+		server:       server,
+		conn:         conn,
+		loading:      true,
+		spinner:      s,
+		viewport:     vp,
+		embedded:     true,
+	}
+}
+
+func (m serverLogsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchLogs())
+}
+
+func (m serverLogsModel) fetchLogs() tea.Cmd {
+	provider := m.provider
+	server := *m.server
+	conn := m.conn
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		log, err := bootlog.Fetch(ctx, provider, server, conn)
+		if err != nil {
+			return logsErrorMsg{err: err}
+		}
+		return logsLoadedMsg{timing: timed("console", began), log: log}
+	}
+}
+
+// followTick schedules the next refetch, superseding any tick still
+// pending.
+func (m *serverLogsModel) followTick() tea.Cmd {
+	m.followGen++
+	gen := m.followGen
+	return tea.Tick(logsFollowInterval, func(time.Time) tea.Msg { return logsFollowTickMsg{gen: gen} })
+}
+
+// --- Update ---
+
+func (m serverLogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.syncViewportSize()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		m.viewport, _ = m.viewport.Update(msg)
+		return m, nil
+
+	case logsLoadedMsg:
+		firstLoad := m.log == nil
+		m.loading = false
+		m.err = nil
+		m.log = msg.log
+		m.syncViewportSize()
+		m.viewport.SetContent(msg.log.Content)
+		if m.follow || firstLoad {
+			m.viewport.GotoBottom()
+		}
+		if m.follow {
+			return m, m.followTick()
+		}
+		return m, nil
+
+	case logsErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		if m.follow {
+			return m, m.followTick()
+		}
+		return m, nil
+
+	case logsFollowTickMsg:
+		if !m.follow || msg.gen != m.followGen {
+			return m, nil
+		}
+		return m, m.fetchLogs()
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m serverLogsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "q", "esc":
+		if m.embedded {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+		}
+		return m, tea.Quit
+
+	case "f":
+		m.follow = !m.follow
+		if m.follow {
+			m.viewport.GotoBottom()
+			return m, m.followTick()
+		}
+		// Drop the pending tick, so toggling back on starts afresh.
+		m.followGen++
+		return m, nil
+
+	case "r":
+		if !m.loading {
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.fetchLogs())
+		}
+		return m, nil
+
+	case "G":
+		m.viewport.GotoBottom()
+		return m, nil
+
+	case "g":
+		m.viewport.GotoTop()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// syncViewportSize sizes the viewport to the content area between the
+// header, status bar, and footer.
+func (m *serverLogsModel) syncViewportSize() {
+	header, statusBar, footer := m.chrome()
+	height := m.height - lipgloss.Height(header) - lipgloss.Height(statusBar) - lipgloss.Height(footer)
+	if height < 1 {
+		height = 1
+	}
+	width := m.width - 4
+	if width < 1 {
+		width = 1
+	}
+	m.viewport.Width = width
+	m.viewport.Height = height
+}
+
+// --- View ---
+
+func (m serverLogsModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header, statusBar, footer := m.chrome()
+
+	headerH := lipgloss.Height(header)
+	footerH := lipgloss.Height(footer)
+	statusH := lipgloss.Height(statusBar)
+	contentH := m.height - headerH - footerH - statusH
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	content := m.renderContent(contentH)
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, statusBar, footer)
+}
+
+// chrome renders the header, status bar, and footer around the log content.
+func (m serverLogsModel) chrome() (header, statusBar, footer string) {
+	header = components.HeaderTrail(m.width, serverTrail(m.server, "logs"), m.providerName)
+
+	followDesc := "follow"
+	if m.follow {
+		followDesc = "stop following"
+	}
+	footer = components.Footer(m.width, []components.KeyBinding{
+		{Key: "j/k", Desc: "scroll"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "f", Desc: followDesc},
+		{Key: "r", Desc: "refresh"},
+		{Key: "esc", Desc: "back"},
+	})
+
+	statusBar = components.StatusBar(m.width, m.statusText(), m.err != nil && m.log != nil)
+	return header, statusBar, footer
+}
+
+func (m serverLogsModel) statusText() string {
+	parts := []string{m.server.Name}
+	if m.log != nil {
+		switch m.log.Source {
+		case bootlog.SourceConsole:
+			parts = append(parts, "console output")
+		default:
+			parts = append(parts, bootlog.CloudInitLogPath)
+		}
+	}
+	if m.follow {
+		parts = append(parts, "following")
+	}
+	if m.err != nil && m.log != nil {
+		parts = append(parts, "refresh failed: "+m.err.Error())
+	}
+	return strings.Join(parts, " "+styles.Middot()+" ")
+}
+
+func (m serverLogsModel) renderContent(height int) string {
+	if m.loading && m.log == nil {
+		loadingText := m.spinner.View() + "  Fetching boot logs" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil && m.log == nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render(fmt.Sprintf("Logs are read over SSH as %q. Press r to retry or esc to go back.", m.sshUser()))
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			lipgloss.NewStyle().Width(m.width-8).Align(lipgloss.Center).Render(errText),
+		)
+	}
+
+	if m.log != nil && strings.TrimSpace(m.log.Content) == "" {
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render("Log is empty."),
+		)
+	}
+
+	return lipgloss.NewStyle().PaddingLeft(2).Height(height).Render(m.viewport.View())
+}
+
+func (m serverLogsModel) sshUser() string {
+	if m.conn.Username == "" {
+		return "root"
+	}
+	return m.conn.Username
+}
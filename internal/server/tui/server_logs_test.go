@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/bootlog"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+)
+
+func TestServerLogs_FollowToggleDropsPendingTick(t *testing.T) {
+	m := newServerLogsModel(nil, "hetzner", &domain.Server{ID: "7", Name: "app"}, remote.Conn{})
+
+	updated, _ := m.handleKey(runeKey('f'))
+	m = updated.(serverLogsModel)
+	stale := logsFollowTickMsg{gen: m.followGen}
+
+	// Off and on again before the first tick fires.
+	updated, _ = m.handleKey(runeKey('f'))
+	m = updated.(serverLogsModel)
+	updated, _ = m.handleKey(runeKey('f'))
+	m = updated.(serverLogsModel)
+
+	if _, cmd := m.Update(stale); cmd != nil {
+		t.Error("expected the superseded tick to be ignored")
+	}
+	if _, cmd := m.Update(logsFollowTickMsg{gen: m.followGen}); cmd == nil {
+		t.Error("expected the current tick to refetch")
+	}
+}
+
+func TestServerLogs_RefreshWhileFollowingKeepsOneChain(t *testing.T) {
+	m := newServerLogsModel(nil, "hetzner", &domain.Server{ID: "7", Name: "app"}, remote.Conn{})
+	updated, _ := m.handleKey(runeKey('f'))
+	m = updated.(serverLogsModel)
+	pending := logsFollowTickMsg{gen: m.followGen}
+
+	// A manual refresh lands while the follow tick is still pending.
+	updated, cmd := m.Update(logsLoadedMsg{log: &bootlog.Log{Content: "boot"}})
+	m = updated.(serverLogsModel)
+	if cmd == nil {
+		t.Fatal("expected the load to schedule the next tick")
+	}
+
+	if _, cmd := m.Update(pending); cmd != nil {
+		t.Error("expected the earlier tick to be ignored")
+	}
+	if _, cmd := m.Update(logsFollowTickMsg{gen: m.followGen}); cmd == nil {
+		t.Error("expected the rescheduled tick to refetch")
+	}
+}
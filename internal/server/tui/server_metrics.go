@@ -0,0 +1,366 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// metricsRange is a time range the dashboard can show.
+type metricsRange struct {
+	label string
+	span  time.Duration
+}
+
+// metricsRanges are the ranges the dashboard cycles through, selected with
+// the number keys in this order.
+var metricsRanges = []metricsRange{
+	{label: "1h", span: time.Hour},
+	{label: "6h", span: 6 * time.Hour},
+	{label: "24h", span: 24 * time.Hour},
+	{label: "7d", span: 7 * 24 * time.Hour},
+}
+
+// --- Messages ---
+
+// navigateToMetricsMsg opens the metrics dashboard for server.
+type navigateToMetricsMsg struct {
+	server domain.Server
+}
+
+// dashboardMetricsMsg carries the metrics fetched for a range, or the
+// error fetching them.
+type dashboardMetricsMsg struct {
+	timing
+	span    time.Duration
+	metrics *domain.ServerMetrics
+	err     error
+}
+
+// dashboardRefreshMsg fires when the dashboard is due for its periodic
+// refresh. since is when the metrics it replaces were fetched, so ticks
+// from before a range change or manual refresh are dropped.
+type dashboardRefreshMsg struct {
+	since time.Time
+}
+
+// --- Server metrics model ---
+
+// serverMetricsModel is a full-screen metrics dashboard for one server,
+// with a selectable time range, periodic refresh, and values shown either
+// abbreviated or exact.
+type serverMetricsModel struct {
+	source       domain.MetricsProvider
+	providerName string
+	server       *domain.Server
+
+	rangeIdx int
+	// raw shows exact values instead of abbreviated ones.
+	raw bool
+
+	metrics *domain.ServerMetrics
+	loading bool
+	err     error
+	spinner spinner.Model
+
+	// refresh is how often the dashboard refetches while open; zero turns
+	// it off. updated is when the shown metrics were fetched.
+	refresh time.Duration
+	updated time.Time
+
+	viewport viewport.Model
+
+	width  int
+	height int
+}
+
+func newServerMetricsModel(source domain.MetricsProvider, providerName string, server *domain.Server) serverMetricsModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	vp := viewport.New(0, 0)
+	vp.KeyMap = detailViewportKeyMap()
+
+	return serverMetricsModel{
+		source:       source,
+		providerName: providerName,
+		server:       server,
+		loading:      true,
+		spinner:      s,
+		refresh:      loadMetricsRefresh(),
+		viewport:     vp,
+	}
+}
+
+func (m serverMetricsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetch())
+}
+
+func (m serverMetricsModel) fetch() tea.Cmd {
+	source := m.source
+	serverID := m.server.ID
+	span := metricsRanges[m.rangeIdx].span
+	return func() tea.Msg {
+		if source == nil {
+			return dashboardMetricsMsg{span: span, err: errors.New("provider does not support metrics")}
+		}
+		end := time.Now()
+		began := time.Now()
+		metrics, err := source.GetServerMetrics(context.Background(), serverID, []domain.MetricType{
+			domain.MetricCPU,
+			domain.MetricDisk,
+			domain.MetricNetwork,
+			domain.MetricMemory,
+		}, end.Add(-span), end)
+		return dashboardMetricsMsg{timing: timed("metrics", began), span: span, metrics: metrics, err: err}
+	}
+}
+
+// scheduleRefresh arranges the next periodic refresh, if refreshing is on.
+func (m serverMetricsModel) scheduleRefresh() tea.Cmd {
+	if m.refresh <= 0 {
+		return nil
+	}
+	since := m.updated
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg {
+		return dashboardRefreshMsg{since: since}
+	})
+}
+
+// --- Update ---
+
+func (m serverMetricsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.syncViewport()
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case tea.MouseMsg:
+		m.viewport, _ = m.viewport.Update(msg)
+		return m, nil
+
+	case dashboardMetricsMsg:
+		if msg.span != metricsRanges[m.rangeIdx].span {
+			// Fetched for a range no longer selected.
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.metrics = msg.metrics
+		}
+		// A failed refresh keeps the previous metrics up; the status bar
+		// reports the error.
+		m.updated = time.Now()
+		m.syncViewport()
+		return m, m.scheduleRefresh()
+
+	case dashboardRefreshMsg:
+		if !msg.since.Equal(m.updated) || m.loading {
+			return m, nil
+		}
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.fetch())
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m serverMetricsModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "q", "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "1", "2", "3", "4":
+		return m.selectRange(int(key[0] - '1'))
+
+	case "tab":
+		return m.selectRange((m.rangeIdx + 1) % len(metricsRanges))
+
+	case "shift+tab":
+		return m.selectRange((m.rangeIdx + len(metricsRanges) - 1) % len(metricsRanges))
+
+	case "h":
+		m.raw = !m.raw
+		m.syncViewport()
+		return m, nil
+
+	case "r":
+		if !m.loading {
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.fetch())
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// selectRange switches to the range at idx and fetches its metrics. The
+// charts of the previous range stay up until they arrive.
+func (m serverMetricsModel) selectRange(idx int) (tea.Model, tea.Cmd) {
+	if idx == m.rangeIdx {
+		return m, nil
+	}
+	m.rangeIdx = idx
+	m.loading = true
+	m.err = nil
+	return m, tea.Batch(m.spinner.Tick, m.fetch())
+}
+
+// syncViewport sizes the viewport to the content area and renders the
+// charts into it.
+func (m *serverMetricsModel) syncViewport() {
+	header, statusBar, footer := m.chrome()
+	height := m.height - lipgloss.Height(header) - lipgloss.Height(statusBar) - lipgloss.Height(footer)
+	if height < 1 {
+		height = 1
+	}
+	width := m.width - 4
+	if width < 1 {
+		width = 1
+	}
+	m.viewport.Width = width
+	m.viewport.Height = height
+	m.viewport.SetContent(m.renderCharts(width))
+}
+
+// --- View ---
+
+func (m serverMetricsModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header, statusBar, footer := m.chrome()
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(statusBar) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), statusBar, footer)
+}
+
+// chrome renders the header, status bar, and footer around the charts.
+func (m serverMetricsModel) chrome() (header, statusBar, footer string) {
+	header = components.HeaderTrail(m.width, serverTrail(m.server, "metrics"), m.providerName)
+
+	unitsDesc := "exact values"
+	if m.raw {
+		unitsDesc = "abbreviated values"
+	}
+	footer = components.Footer(m.width, []components.KeyBinding{
+		{Key: "1-4/tab", Desc: "range"},
+		{Key: "h", Desc: unitsDesc},
+		{Key: "j/k", Desc: "scroll"},
+		{Key: "r", Desc: "refresh"},
+		{Key: "esc", Desc: "back"},
+	})
+
+	statusBar = components.StatusBar(m.width, m.statusText(), m.err != nil && m.metrics != nil)
+	return header, statusBar, footer
+}
+
+func (m serverMetricsModel) statusText() string {
+	parts := []string{m.server.Name, m.rangeTabs()}
+	switch {
+	case m.loading && m.metrics != nil:
+		parts = append(parts, m.spinner.View()+" refreshing")
+	case m.err != nil && m.metrics != nil:
+		parts = append(parts, "refresh failed: "+m.err.Error())
+	case !m.updated.IsZero():
+		parts = append(parts, "updated "+m.updated.Format("15:04:05"))
+	}
+	return strings.Join(parts, " "+styles.Middot()+" ")
+}
+
+// rangeTabs lists the ranges with the selected one bracketed, e.g.
+// "1h [6h] 24h 7d".
+func (m serverMetricsModel) rangeTabs() string {
+	tabs := make([]string, len(metricsRanges))
+	for i, r := range metricsRanges {
+		if i == m.rangeIdx {
+			tabs[i] = "[" + r.label + "]"
+		} else {
+			tabs[i] = r.label
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
+func (m serverMetricsModel) renderContent(height int) string {
+	if m.loading && m.metrics == nil {
+		loadingText := m.spinner.View() + "  Loading metrics" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil && m.metrics == nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press r to retry or esc to go back.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			lipgloss.NewStyle().Width(m.width-8).Align(lipgloss.Center).Render(errText),
+		)
+	}
+
+	return lipgloss.NewStyle().PaddingLeft(2).Height(height).Render(m.viewport.View())
+}
+
+// renderCharts renders a chart for every series the provider reported
+// over the selected range.
+func (m serverMetricsModel) renderCharts(width int) string {
+	if m.metrics == nil {
+		return ""
+	}
+	opts := components.ChartOptions{Span: metricsRanges[m.rangeIdx].span, Raw: m.raw}
+
+	var charts []string
+	if cpu := extractMetricValues(m.metrics, "cpu"); len(cpu) > 0 {
+		charts = append(charts, components.MetricsChartWith("CPU", cpu, width, "%", opts))
+	}
+	if mem := extractMetricValues(m.metrics, string(domain.MetricMemory)); len(mem) > 0 {
+		charts = append(charts, components.MetricsChartWith("Memory", mem, width, "%", opts))
+	} else {
+		charts = append(charts, styles.Label.Render("Memory")+"\n"+
+			styles.MutedText.Render("  Not reported by "+m.providerName))
+	}
+	for _, c := range []deviceChart{diskIOPSChart, diskThroughputChart, networkBandwidthChart, networkPacketsChart} {
+		charts = append(charts, renderDeviceCharts(m.metrics, c, width, opts)...)
+	}
+	return strings.Join(charts, "\n\n")
+}
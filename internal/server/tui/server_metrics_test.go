@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+)
+
+// rangeRecorder is a MetricsProvider recording the span of each request.
+type rangeRecorder struct {
+	domain.Provider
+
+	mu    sync.Mutex
+	spans []time.Duration
+}
+
+func (r *rangeRecorder) GetServerMetrics(_ context.Context, _ string, _ []domain.MetricType, start, end time.Time) (*domain.ServerMetrics, error) {
+	r.mu.Lock()
+	r.spans = append(r.spans, end.Sub(start).Round(time.Minute))
+	r.mu.Unlock()
+	return &domain.ServerMetrics{TimeSeries: map[string]domain.MetricsTimeSeries{
+		"cpu": {Name: "cpu", Values: []domain.MetricsPoint{{Value: 1250}, {Value: 1534.5}}},
+	}}, nil
+}
+
+func TestServerShow_MetricsKeyOpensDashboard(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running"}
+	m := newServerShowDirect(nil, "hetzner", server, &rangeRecorder{})
+
+	_, cmd := m.handleDetailKey(runeKey('m'))
+	if cmd == nil {
+		t.Fatal("expected m to open the metrics dashboard")
+	}
+	if msg, ok := cmd().(navigateToMetricsMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("got %+v, want navigateToMetricsMsg for server 7", msg)
+	}
+
+	m = newServerShowDirect(nil, "hetzner", server, nil)
+	if _, cmd := m.handleDetailKey(runeKey('m')); cmd != nil {
+		t.Error("expected no dashboard without a metrics provider")
+	}
+}
+
+func TestServerMetrics_SelectRangeFetchesSpan(t *testing.T) {
+	source := &rangeRecorder{}
+	m := newServerMetricsModel(source, "hetzner", &domain.Server{ID: "7", Name: "app"})
+	m.width, m.height = 100, 40
+
+	updated, _ := m.Update(m.fetch()())
+	m = updated.(serverMetricsModel)
+
+	updated, cmd := m.Update(runeKey('4'))
+	m = updated.(serverMetricsModel)
+	if m.rangeIdx != 3 || !m.loading || cmd == nil {
+		t.Fatalf("rangeIdx = %d, loading = %v; want 7d range loading", m.rangeIdx, m.loading)
+	}
+	if !strings.Contains(m.statusText(), "[7d]") {
+		t.Errorf("status = %q, want the 7d range selected", m.statusText())
+	}
+
+	// A result for the previously selected range is dropped.
+	stale := dashboardMetricsMsg{span: time.Hour, metrics: &domain.ServerMetrics{}}
+	updated, _ = m.Update(stale)
+	if !updated.(serverMetricsModel).loading {
+		t.Error("expected a stale result to be ignored")
+	}
+
+	updated, _ = m.Update(m.fetch()())
+	m = updated.(serverMetricsModel)
+	if m.loading || m.metrics == nil {
+		t.Fatal("expected the 7d metrics to load")
+	}
+	want := []time.Duration{time.Hour, 7 * 24 * time.Hour, 7 * 24 * time.Hour}
+	for i, span := range source.spans {
+		if span != want[i] {
+			t.Errorf("request %d span = %v, want %v", i, span, want[i])
+		}
+	}
+}
+
+func TestServerMetrics_ToggleRawValues(t *testing.T) {
+	m := newServerMetricsModel(&rangeRecorder{}, "hetzner", &domain.Server{ID: "7", Name: "app"})
+	m.width, m.height = 100, 40
+	updated, _ := m.Update(m.fetch()())
+	m = updated.(serverMetricsModel)
+
+	if got := m.renderCharts(80); !strings.Contains(got, "1.5K%") || !strings.Contains(got, "Not reported by hetzner") {
+		t.Errorf("expected abbreviated values and a memory note, got:\n%s", got)
+	}
+
+	updated, _ = m.Update(runeKey('h'))
+	m = updated.(serverMetricsModel)
+	if got := m.renderCharts(80); !strings.Contains(got, "1534.5%") {
+		t.Errorf("expected exact values, got:\n%s", got)
+	}
+}
+
+func TestServerMetrics_RefreshDropsStaleTicks(t *testing.T) {
+	m := newServerMetricsModel(&rangeRecorder{}, "hetzner", &domain.Server{ID: "7", Name: "app"})
+	m.refresh = 30 * time.Second
+	updated, cmd := m.Update(m.fetch()())
+	m = updated.(serverMetricsModel)
+	if cmd == nil {
+		t.Fatal("expected loaded metrics to schedule a refresh")
+	}
+
+	updated, cmd = m.Update(dashboardRefreshMsg{since: m.updated.Add(-time.Minute)})
+	if updated.(serverMetricsModel).loading || cmd != nil {
+		t.Error("expected a stale tick to be dropped")
+	}
+	updated, cmd = m.Update(dashboardRefreshMsg{since: m.updated})
+	if !updated.(serverMetricsModel).loading || cmd == nil {
+		t.Error("expected a refresh")
+	}
+}
+
+func TestRenderDeviceCharts_OneChartPerDevice(t *testing.T) {
+	point := []domain.MetricsPoint{{Value: 5}}
+	metrics := &domain.ServerMetrics{TimeSeries: map[string]domain.MetricsTimeSeries{
+		"disk.0.iops.read":       {Values: point},
+		"disk.10.iops.write":     {Values: point},
+		"disk.2.iops.read":       {Values: point},
+		"network.0.bandwidth.in": {Values: point},
+	}}
+
+	if got := metricDevices(metrics, "disk"); strings.Join(got, ",") != "0,2,10" {
+		t.Errorf("disk devices = %v, want [0 2 10]", got)
+	}
+	charts := renderDeviceCharts(metrics, diskIOPSChart, 80, components.ChartOptions{})
+	if len(charts) != 3 || !strings.Contains(charts[2], "Disk IOPS (disk 10)") {
+		t.Errorf("expected three labelled disk charts, got %d", len(charts))
+	}
+	charts = renderDeviceCharts(metrics, networkBandwidthChart, 80, components.ChartOptions{})
+	if len(charts) != 1 || strings.Contains(charts[0], "interface") {
+		t.Errorf("expected a single unlabelled network chart, got %q", charts)
+	}
+}
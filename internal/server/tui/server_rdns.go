@@ -0,0 +1,338 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+type reverseDNSLoadedMsg struct {
+	timing
+	records []domain.ReverseDNS
+}
+
+type reverseDNSErrorMsg struct {
+	err error
+}
+
+// requestReverseDNSMsg is emitted by the reverse DNS view when the user
+// saves a PTR record. An empty hostname resets it to the provider's
+// default; previous is the hostname it had, for undo.
+type requestReverseDNSMsg struct {
+	server   domain.Server
+	ip       string
+	hostname string
+	previous string
+}
+
+// --- Server reverse DNS model ---
+
+// serverReverseDNSModel lists the PTR records of a server's public
+// addresses and edits the selected one.
+type serverReverseDNSModel struct {
+	provider     domain.Provider
+	providerName string
+	server       *domain.Server
+
+	records []domain.ReverseDNS
+	cursor  int
+	// editing is set while the selected record's hostname is being typed.
+	editing  bool
+	input    textinput.Model
+	inputErr string
+	loading  bool
+	err      error
+	spinner  spinner.Model
+
+	width  int
+	height int
+}
+
+func newServerReverseDNSModel(provider domain.Provider, providerName string, server *domain.Server) serverReverseDNSModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	input := textinput.New()
+	input.Placeholder = "mail.example.com"
+	input.CharLimit = 253
+	input.Width = 50
+
+	return serverReverseDNSModel{
+		provider:     provider,
+		providerName: providerName,
+		server:       server,
+		input:        input,
+		loading:      true,
+		spinner:      s,
+	}
+}
+
+func (m serverReverseDNSModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchReverseDNS())
+}
+
+func (m serverReverseDNSModel) fetchReverseDNS() tea.Cmd {
+	rp, ok := m.provider.(domain.ReverseDNSProvider)
+	if !ok {
+		return func() tea.Msg {
+			return reverseDNSErrorMsg{err: fmt.Errorf("%s does not support reverse DNS", m.provider.GetDisplayName())}
+		}
+	}
+	serverID := m.server.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		records, err := rp.GetReverseDNS(ctx, serverID)
+		if err != nil {
+			return reverseDNSErrorMsg{err: err}
+		}
+		return reverseDNSLoadedMsg{timing: timed("reverse DNS", began), records: records}
+	}
+}
+
+// --- Update ---
+
+func (m serverReverseDNSModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.handleEditKey(msg)
+		}
+		return m.handleKey(msg)
+
+	case reverseDNSLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.records = msg.records
+		if m.cursor >= len(m.records) {
+			m.cursor = max(len(m.records)-1, 0)
+		}
+		return m, nil
+
+	case reverseDNSErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	if m.editing {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m serverReverseDNSModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "q", "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.records)-1 {
+			m.cursor++
+		}
+
+	case "r":
+		if !m.loading {
+			m.loading = true
+			m.err = nil
+			return m, tea.Batch(m.spinner.Tick, m.fetchReverseDNS())
+		}
+
+	case "enter", "e":
+		if m.loading || len(m.records) == 0 {
+			return m, nil
+		}
+		m.editing = true
+		m.inputErr = ""
+		m.input.SetValue(m.records[m.cursor].Hostname)
+		m.input.CursorEnd()
+		return m, m.input.Focus()
+	}
+
+	return m, nil
+}
+
+func (m serverReverseDNSModel) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		m.editing = false
+		m.input.Blur()
+		return m, nil
+
+	case "enter":
+		hostname := strings.TrimSpace(m.input.Value())
+		if hostname != "" {
+			if err := dnsdomain.ValidateHostname(hostname); err != nil {
+				m.inputErr = err.Error()
+				return m, nil
+			}
+		}
+		record := m.records[m.cursor]
+		m.editing = false
+		m.input.Blur()
+		if hostname == record.Hostname {
+			return m, nil
+		}
+		server := *m.server
+		return m, func() tea.Msg {
+			return requestReverseDNSMsg{server: server, ip: record.IP, hostname: hostname, previous: record.Hostname}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.inputErr = ""
+	return m, cmd
+}
+
+// --- View ---
+
+func (m serverReverseDNSModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "reverse DNS"), m.providerName)
+
+	bindings := []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "edit"},
+		{Key: "r", Desc: "refresh"},
+		{Key: "esc", Desc: "back"},
+	}
+	if m.editing {
+		bindings = []components.KeyBinding{
+			{Key: "enter", Desc: "save"},
+			{Key: "esc", Desc: "cancel"},
+		}
+	}
+	footer := components.Footer(m.width, bindings)
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverReverseDNSModel) renderContent(height int) string {
+	if m.loading {
+		loadingText := m.spinner.View() + "  Fetching reverse DNS" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press r to retry or esc to go back.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			errText,
+		)
+	}
+
+	if len(m.records) == 0 {
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render("Server has no public addresses."),
+		)
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Reverse DNS for %q", m.server.Name))
+
+	var bottom string
+	if m.editing {
+		hint := styles.MutedText.Render(fmt.Sprintf("Hostname for %s, empty to restore the default:", m.records[m.cursor].IP))
+		bottom = lipgloss.JoinVertical(lipgloss.Left, hint, m.input.View())
+		if m.inputErr != "" {
+			bottom = lipgloss.JoinVertical(lipgloss.Left, bottom, styles.ErrorText.Render(m.inputErr))
+		}
+	} else {
+		bottom = styles.MutedText.Render("The hostname's A or AAAA record should point back at the address.")
+	}
+
+	combined := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		m.renderRecords(),
+		"",
+		bottom,
+	)
+
+	return lipgloss.Place(
+		m.width, height,
+		lipgloss.Center, lipgloss.Center,
+		combined,
+	)
+}
+
+// renderRecords lists the addresses and their PTR hostnames.
+func (m serverReverseDNSModel) renderRecords() string {
+	ipWidth := 0
+	for _, r := range m.records {
+		ipWidth = max(ipWidth, lipgloss.Width(r.IP))
+	}
+
+	rows := make([]string, 0, len(m.records))
+	for i, r := range m.records {
+		hostname := r.Hostname
+		if hostname == "" {
+			hostname = "(provider default)"
+		}
+		label := fmt.Sprintf("%-*s  %s", ipWidth, r.IP, hostname)
+		if i == m.cursor {
+			rows = append(rows, styles.AccentText.Render("> ")+styles.Value.Bold(true).Render(label))
+		} else {
+			rows = append(rows, "  "+styles.MutedText.Render(label))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reverseDNSProvider is a provider that manages PTR records.
+type reverseDNSProvider struct {
+	reauthProvider
+	records []domain.ReverseDNS
+	set     string
+}
+
+func (p *reverseDNSProvider) GetReverseDNS(context.Context, string) ([]domain.ReverseDNS, error) {
+	return p.records, nil
+}
+
+func (p *reverseDNSProvider) SetReverseDNS(_ context.Context, serverID, ip, hostname string) (*domain.ActionStatus, error) {
+	p.set = serverID + " " + ip + "=" + hostname
+	return &domain.ActionStatus{ID: "31", Status: domain.ActionStatusRunning}, nil
+}
+
+func TestServerShow_ReverseDNSInNetworkCard(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "mail", Status: "running", PublicIPv4: "203.0.113.7"}
+	provider := &reverseDNSProvider{records: []domain.ReverseDNS{{IP: "203.0.113.7", Hostname: "mail.example.com"}}}
+	m := newServerShowDirect(provider, "hetzner", server, nil)
+
+	updated, _ := m.Update(m.fetchReverseDNS(server)())
+	m = updated.(serverShowModel)
+	if got := m.reverseDNSFor("203.0.113.7"); got != "mail.example.com" {
+		t.Errorf("reverseDNSFor = %q, want mail.example.com", got)
+	}
+
+	_, cmd := m.handleKey(runeKey('e'))
+	if cmd == nil {
+		t.Fatal("expected e to open the reverse DNS view")
+	}
+	if msg, ok := cmd().(navigateToReverseDNSMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("expected navigateToReverseDNSMsg for server 7, got %#v", msg)
+	}
+
+	m = newServerShowDirect(&reauthProvider{}, "hetzner", server, nil)
+	if _, cmd := m.handleKey(runeKey('e')); cmd != nil {
+		t.Error("expected e to do nothing without reverse DNS support")
+	}
+}
+
+func TestServerReverseDNS_EditsSelectedRecord(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "mail", Status: "running"}
+	m := newServerReverseDNSModel(&reverseDNSProvider{}, "hetzner", server)
+	updated, _ := m.Update(reverseDNSLoadedMsg{records: []domain.ReverseDNS{
+		{IP: "203.0.113.7", Hostname: "old.example.com"},
+		{IP: "2001:db8::1"},
+	}})
+	m = updated.(serverReverseDNSModel)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverReverseDNSModel)
+	if !m.editing || m.input.Value() != "old.example.com" {
+		t.Fatalf("expected to edit the current hostname, got editing=%v value=%q", m.editing, m.input.Value())
+	}
+
+	// An invalid hostname is refused.
+	m.input.SetValue("not a host")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverReverseDNSModel)
+	if cmd != nil || m.inputErr == "" {
+		t.Fatal("expected an invalid hostname to be refused")
+	}
+
+	m.input.SetValue("mail.example.com")
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverReverseDNSModel)
+	if m.editing || cmd == nil {
+		t.Fatal("expected enter to save the record")
+	}
+	msg, ok := cmd().(requestReverseDNSMsg)
+	if !ok || msg.ip != "203.0.113.7" || msg.hostname != "mail.example.com" || msg.previous != "old.example.com" {
+		t.Errorf("unexpected request %#v", msg)
+	}
+}
+
+func TestOpsOverlay_StartReverseDNSUndoRestoresPrevious(t *testing.T) {
+	provider := &reverseDNSProvider{}
+	o := opsOverlay{provider: provider, providerName: "mock"}
+
+	o, cmd := o.StartReverseDNS(domain.Server{ID: "7", Name: "mail", Status: "running"}, "203.0.113.7", "mail.example.com", "old.example.com")
+	if len(o.ops) != 1 || o.ops[0].verb != "reverse DNS updated" || o.ops[0].target != "running" {
+		t.Fatalf("expected one reverse DNS operation, got %+v", o.ops)
+	}
+	if !strings.HasPrefix(o.ops[0].statusText, "Updating reverse DNS of") {
+		t.Errorf("status text = %q", o.ops[0].statusText)
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	if provider.set != "7 203.0.113.7=mail.example.com" {
+		t.Errorf("expected SetReverseDNS(7, 203.0.113.7, mail.example.com), got %q", provider.set)
+	}
+
+	o, cmd = o.ops[0].undo(o)
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	if provider.set != "7 203.0.113.7=old.example.com" {
+		t.Errorf("expected undo to restore old.example.com, got %q", provider.set)
+	}
+}
@@ -0,0 +1,260 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+type resizeTypesLoadedMsg struct {
+	timing
+	types []domain.ServerTypeSpec
+}
+
+type resizeTypesErrorMsg struct {
+	err error
+}
+
+// requestResizeMsg is emitted by the resize view when the user picks a new
+// server type. The app hands it to the operations overlay.
+type requestResizeMsg struct {
+	server     domain.Server
+	serverType string
+}
+
+// --- Server resize model ---
+
+// serverResizeModel lists the server types a server can be resized to
+// without growing its disk, and asks the user to pick one.
+type serverResizeModel struct {
+	provider     domain.Provider
+	providerName string
+	server       *domain.Server
+
+	types   []domain.ServerTypeSpec
+	cursor  int
+	loading bool
+	err     error
+	spinner spinner.Model
+
+	width  int
+	height int
+}
+
+func newServerResizeModel(provider domain.Provider, providerName string, server *domain.Server) serverResizeModel {
+	s := spinner.New()
+	s.Spinner = styles.Spinner()
+	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
+
+	return serverResizeModel{
+		provider:     provider,
+		providerName: providerName,
+		server:       server,
+		loading:      true,
+		spinner:      s,
+	}
+}
+
+func (m serverResizeModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.fetchTypes())
+}
+
+func (m serverResizeModel) fetchTypes() tea.Cmd {
+	catalog, ok := m.provider.(domain.CatalogProvider)
+	if !ok {
+		return func() tea.Msg {
+			return resizeTypesErrorMsg{err: fmt.Errorf("%s does not list server types", m.provider.GetDisplayName())}
+		}
+	}
+	server := *m.server
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		types, err := catalog.ListServerTypes(ctx)
+		if err != nil {
+			return resizeTypesErrorMsg{err: err}
+		}
+		return resizeTypesLoadedMsg{timing: timed("server types", began), types: domain.ResizeTargets(server.ServerType, server.Region, types)}
+	}
+}
+
+// --- Update ---
+
+func (m serverResizeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case resizeTypesLoadedMsg:
+		m.loading = false
+		m.types = msg.types
+		return m, nil
+
+	case resizeTypesErrorMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m serverResizeModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "q", "esc":
+		server := *m.server
+		return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down", "j":
+		if m.cursor < len(m.types)-1 {
+			m.cursor++
+		}
+
+	case "enter":
+		if m.loading || len(m.types) == 0 {
+			return m, nil
+		}
+		server := *m.server
+		serverType := m.types[m.cursor].Name
+		return m, func() tea.Msg { return requestResizeMsg{server: server, serverType: serverType} }
+	}
+
+	return m, nil
+}
+
+// --- View ---
+
+func (m serverResizeModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "resize"), m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "resize"},
+		{Key: "esc", Desc: "back"},
+	})
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverResizeModel) renderContent(height int) string {
+	if m.loading {
+		loadingText := m.spinner.View() + "  Fetching server types" + styles.Ellipsis()
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(loadingText),
+		)
+	}
+
+	if m.err != nil {
+		errText := styles.ErrorText.Render("Error: "+m.err.Error()) + "\n\n" +
+			styles.MutedText.Render("Press esc to go back.")
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			errText,
+		)
+	}
+
+	if len(m.types) == 0 {
+		return lipgloss.Place(
+			m.width, height,
+			lipgloss.Center, lipgloss.Center,
+			styles.MutedText.Render(fmt.Sprintf("No server types in %s fit the disk of %q.", m.server.Region, m.server.Name)),
+		)
+	}
+
+	title := styles.Title.Render(fmt.Sprintf("Resize %q (currently %s)", m.server.Name, m.server.ServerType))
+
+	var notes []string
+	if !m.server.IsStopped() {
+		notes = append(notes, styles.WarningText.Render(styles.Warning()+" The server will be stopped, resized, and left off."))
+	}
+	notes = append(notes, styles.MutedText.Render("The disk keeps its size, so the server can be resized back down."))
+
+	maxVisible := height - len(notes) - 6
+	combined := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		m.renderTypes(maxVisible),
+		"",
+		strings.Join(notes, "\n"),
+	)
+
+	return lipgloss.Place(
+		m.width, height,
+		lipgloss.Center, lipgloss.Center,
+		combined,
+	)
+}
+
+// renderTypes renders the window of server types around the cursor.
+func (m serverResizeModel) renderTypes(maxVisible int) string {
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(m.types))
+
+	rows := make([]string, 0, end-start+2)
+	if start > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more above", styles.Ellipsis(), start)))
+	}
+	for i := start; i < end; i++ {
+		label := serverTypeLabel(m.types[i])
+		if i == m.cursor {
+			rows = append(rows, styles.AccentText.Render("> ")+styles.Value.Bold(true).Render(label))
+		} else {
+			rows = append(rows, "  "+styles.MutedText.Render(label))
+		}
+	}
+	if remaining := len(m.types) - end; remaining > 0 {
+		rows = append(rows, styles.MutedText.Render(fmt.Sprintf("  %s %d more below", styles.Ellipsis(), remaining)))
+	}
+	return strings.Join(rows, "\n")
+}
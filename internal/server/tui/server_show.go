@@ -1,13 +1,25 @@
 package tui
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/platform/browser"
+	"nathanbeddoewebdev/vpsm/internal/platform/ipv6"
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/console"
+	"nathanbeddoewebdev/vpsm/internal/server/services/idle"
+	"nathanbeddoewebdev/vpsm/internal/server/services/ping"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
+	"nathanbeddoewebdev/vpsm/internal/tui/events"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 	"nathanbeddoewebdev/vpsm/internal/util"
 
@@ -21,6 +33,7 @@ import (
 // --- Messages ---
 
 type serverDetailLoadedMsg struct {
+	timing
 	server *domain.Server
 }
 
@@ -29,13 +42,60 @@ type serverDetailErrorMsg struct {
 }
 
 type metricsLoadedMsg struct {
+	timing
 	metrics *domain.ServerMetrics
 }
 
+type idleReportMsg struct {
+	serverID string
+	report   *idle.Report
+}
+
 type metricsErrorMsg struct {
 	err error
 }
 
+// metricsRefreshMsg fires when the metrics card is due for its periodic
+// refresh. since is when the metrics it replaces were fetched, so ticks
+// left over from an earlier load are ignored.
+type metricsRefreshMsg struct {
+	serverID string
+	since    time.Time
+}
+
+// showNetworksLoadedMsg carries the private networks a server is attached
+// to, for their ranges.
+type showNetworksLoadedMsg struct {
+	timing
+	serverID string
+	networks []domain.NetworkSpec
+}
+
+// showReverseDNSLoadedMsg carries the PTR records of a server's public
+// addresses.
+type showReverseDNSLoadedMsg struct {
+	timing
+	serverID string
+	records  []domain.ReverseDNS
+}
+
+// pingResultMsg carries the reachability check of a server's public IPs.
+type pingResultMsg struct {
+	serverID string
+	results  []ping.Result
+	err      error
+}
+
+// consoleOpenedMsg reports a server's VNC console proxied to a local
+// address. stop shuts the proxy down.
+type consoleOpenedMsg struct {
+	serverID string
+	addr     string
+	password string
+	stop     context.CancelFunc
+	err      error
+}
+
 // --- Show result ---
 
 // ShowResult holds the outcome of the server show TUI.
@@ -53,6 +113,30 @@ const (
 	showPhaseDetail                  // displaying server details
 )
 
+// staleDNS lists the records at a DNS provider that still point at a
+// server's previous IPs.
+type staleDNS struct {
+	provider string
+	records  []attach.Pointer
+}
+
+// warning is the note shown under the previous IPs: the records still
+// pointing at them, or a generic reminder when the DNS provider has not
+// been checked.
+func (d *staleDNS) warning() string {
+	switch {
+	case d == nil:
+		return "IP changed — check that DNS records no longer point at these addresses."
+	case len(d.records) == 0:
+		return fmt.Sprintf("IP changed — no records at %s point at these addresses.", d.provider)
+	}
+	lines := []string{fmt.Sprintf("IP changed — %d record(s) at %s still point at these addresses:", len(d.records), d.provider)}
+	for _, r := range d.records {
+		lines = append(lines, "  "+r.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
 // --- Server show model ---
 
 type serverShowModel struct {
@@ -73,6 +157,10 @@ type serverShowModel struct {
 	// Whether we came from the select phase (enables going back).
 	fromSelect bool
 
+	// confirmDisableBackups is set after a first "b" on a server with
+	// backups; a second "b" disables them, deleting existing backups.
+	confirmDisableBackups bool
+
 	width  int
 	height int
 
@@ -94,11 +182,58 @@ type serverShowModel struct {
 	action   string
 	quitting bool
 
+	// metricsSource, when set, serves metrics instead of the provider so
+	// requests are batched and cached across views.
+	metricsSource domain.MetricsProvider
+
 	// Metrics state (loaded independently from server detail).
 	metrics        *domain.ServerMetrics
 	metricsLoading bool
 	metricsErr     error
 
+	// metricsRefresh is how often the metrics card refreshes itself while
+	// the view is open; zero turns it off. metricsUpdated is when the
+	// shown metrics were fetched.
+	metricsRefresh time.Duration
+	metricsUpdated time.Time
+	// metricsRefreshing is set while a periodic refresh is in flight; the
+	// card keeps showing the previous metrics meanwhile. If the refresh
+	// fails they stay up, marked with metricsRefreshFailed.
+	metricsRefreshing    bool
+	metricsRefreshFailed bool
+
+	// idleReport is the idle analysis over the configured metrics window,
+	// shown as a recommendation when the server looks unused.
+	idleReport *idle.Report
+
+	// networks holds the private networks the server is attached to. The
+	// server payload only names them, so their ranges are fetched
+	// separately.
+	networks []domain.NetworkSpec
+
+	// reverseDNS holds the PTR records of the server's public addresses,
+	// when the provider manages them.
+	reverseDNS []domain.ReverseDNS
+
+	// Reachability check state, started with P and shown as a card until
+	// the server is refreshed.
+	pinging     bool
+	pingResults []ping.Result
+	pingErr     error
+
+	// stopConsole shuts down the local proxy of the VNC console opened
+	// with v, if one is running.
+	stopConsole context.CancelFunc
+
+	// previousIPs lists public IPs the server no longer uses. Populated by
+	// serverAppModel from the local IP history.
+	previousIPs []iphistory.IPRecord
+
+	// staleDNS is what the DNS provider holds for previousIPs, once
+	// serverAppModel has looked; staleDNSQueried is set when it starts.
+	staleDNS        *staleDNS
+	staleDNSQueried bool
+
 	// Viewport for scrollable detail view.
 	viewport viewport.Model
 
@@ -129,11 +264,11 @@ func detailViewportKeyMap() viewport.KeyMap {
 		),
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
-			key.WithHelp("↑/k", "up"),
+			key.WithHelp(styles.Up()+"/k", "up"),
 		),
 		Down: key.NewBinding(
 			key.WithKeys("down", "j"),
-			key.WithHelp("↓/j", "down"),
+			key.WithHelp(styles.Down()+"/j", "down"),
 		),
 		Left: key.NewBinding(
 			key.WithDisabled(),
@@ -148,19 +283,20 @@ func detailViewportKeyMap() viewport.KeyMap {
 // If serverID is empty, the TUI first shows a server selection list.
 func RunServerShow(provider domain.Provider, providerName string, serverID string) (*ShowResult, error) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	vp := viewport.New(0, 0)
 	vp.KeyMap = detailViewportKeyMap()
 
 	m := serverShowModel{
-		provider:     provider,
-		providerName: providerName,
-		loading:      true,
-		spinner:      s,
-		poller:       newTogglePoller(provider),
-		viewport:     vp,
+		provider:       provider,
+		providerName:   providerName,
+		loading:        true,
+		spinner:        s,
+		poller:         newTogglePoller(provider),
+		viewport:       vp,
+		metricsRefresh: loadMetricsRefresh(),
 	}
 
 	if serverID != "" {
@@ -172,7 +308,7 @@ func RunServerShow(provider domain.Provider, providerName string, serverID strin
 		m.phase = showPhaseSelect
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run server show: %w", err)
@@ -188,7 +324,7 @@ func RunServerShow(provider domain.Provider, providerName string, serverID strin
 // RunServerShowDirect starts the detail view with an already-loaded server.
 func RunServerShowDirect(provider domain.Provider, providerName string, server *domain.Server) (*ShowResult, error) {
 	s := spinner.New()
-	s.Spinner = spinner.Dot
+	s.Spinner = styles.Spinner()
 	s.Style = lipgloss.NewStyle().Foreground(styles.Blue)
 
 	vp := viewport.New(0, 0)
@@ -202,12 +338,13 @@ func RunServerShowDirect(provider domain.Provider, providerName string, server *
 		serverID:       server.ID,
 		loading:        false,
 		metricsLoading: true,
+		metricsRefresh: loadMetricsRefresh(),
 		spinner:        s,
 		poller:         newTogglePoller(provider),
 		viewport:       vp,
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run server show: %w", err)
@@ -232,52 +369,156 @@ func (m serverShowModel) Init() tea.Cmd {
 
 	// When server is already loaded (RunServerShowDirect), kick off metrics.
 	if !m.loading && m.server != nil && m.metricsLoading {
-		return tea.Batch(m.spinner.Tick, m.fetchMetrics())
+		return tea.Batch(m.spinner.Tick, m.fetchMetrics(), m.fetchIdleReport(m.server), m.fetchNetworks(m.server), m.fetchReverseDNS(m.server))
 	}
 	return nil
 }
 
 func (m serverShowModel) fetchServers() tea.Cmd {
 	return func() tea.Msg {
+		began := time.Now()
 		servers, err := m.provider.ListServers(context.Background())
 		if err != nil {
 			return serversErrorMsg{err: err}
 		}
-		return serversLoadedMsg{servers: servers}
+		return serversLoadedMsg{timing: timed("list fetch", began), servers: servers}
 	}
 }
 
 func (m serverShowModel) fetchServer() tea.Cmd {
 	return func() tea.Msg {
+		began := time.Now()
 		server, err := m.provider.GetServer(context.Background(), m.serverID)
 		if err != nil {
 			return serverDetailErrorMsg{err: err}
 		}
-		return serverDetailLoadedMsg{server: server}
+		return serverDetailLoadedMsg{timing: timed("server", began), server: server}
 	}
 }
 
+// metricsProvider returns where to fetch metrics from, if anywhere.
+func (m serverShowModel) metricsProvider() (domain.MetricsProvider, bool) {
+	if m.metricsSource != nil {
+		return m.metricsSource, true
+	}
+	mp, ok := m.provider.(domain.MetricsProvider)
+	return mp, ok
+}
+
 func (m serverShowModel) fetchMetrics() tea.Cmd {
 	return func() tea.Msg {
-		mp, ok := m.provider.(domain.MetricsProvider)
+		mp, ok := m.metricsProvider()
 		if !ok {
 			return metricsErrorMsg{err: fmt.Errorf("provider does not support metrics")}
 		}
 
 		end := time.Now()
 		start := end.Add(-1 * time.Hour)
+		began := time.Now()
 		metrics, err := mp.GetServerMetrics(context.Background(), m.serverID, []domain.MetricType{
 			domain.MetricCPU,
 			domain.MetricDisk,
 			domain.MetricNetwork,
+			domain.MetricMemory,
 		}, start, end)
 		if err != nil {
 			return metricsErrorMsg{err: err}
 		}
-		return metricsLoadedMsg{metrics: metrics}
+		return metricsLoadedMsg{timing: timed("metrics", began), metrics: metrics}
+	}
+}
+
+// scheduleMetricsRefresh arranges the next periodic refresh of the
+// metrics card, if refreshing is on.
+func (m serverShowModel) scheduleMetricsRefresh() tea.Cmd {
+	if m.metricsRefresh <= 0 || m.server == nil {
+		return nil
+	}
+	serverID, since := m.server.ID, m.metricsUpdated
+	return tea.Tick(m.metricsRefresh, func(time.Time) tea.Msg {
+		return metricsRefreshMsg{serverID: serverID, since: since}
+	})
+}
+
+// loadMetricsRefresh returns the configured metrics refresh interval, or
+// the default if the config cannot be read.
+func loadMetricsRefresh() time.Duration {
+	cfg, err := config.Load()
+	if err != nil {
+		return config.DefaultMetricsRefresh
+	}
+	return cfg.MetricsRefreshInterval()
+}
+
+// fetchIdleReport analyses the configured idle window of metrics for a
+// running server. It returns nil when the check does not apply.
+func (m serverShowModel) fetchIdleReport(server *domain.Server) tea.Cmd {
+	mp, ok := m.metricsProvider()
+	if !ok || server == nil || server.Status != "running" {
+		return nil
+	}
+	serverID := server.ID
+	return func() tea.Msg {
+		window := idle.DefaultWindow
+		if cfg, err := config.Load(); err == nil {
+			window = idle.ResolveWindow(cfg.IdleWindow)
+		}
+		report, err := idle.Check(context.Background(), mp, serverID, window, idle.DefaultThresholds())
+		if err != nil {
+			return nil // best-effort: no recommendation
+		}
+		return idleReportMsg{serverID: serverID, report: report}
+	}
+}
+
+// fetchNetworks looks up the private networks server is attached to. It
+// returns nil when the server has none or the provider cannot list them.
+func (m serverShowModel) fetchNetworks(server *domain.Server) tea.Cmd {
+	np, ok := m.provider.(domain.NetworkProvider)
+	if !ok || server == nil || len(server.AttachedOfKind(domain.AttachedNetwork)) == 0 {
+		return nil
+	}
+	serverID := server.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		networks, err := np.ListNetworks(ctx)
+		if err != nil {
+			return nil // best-effort: networks are shown without ranges
+		}
+		return showNetworksLoadedMsg{timing: timed("networks", began), serverID: serverID, networks: networks}
+	}
+}
+
+// fetchReverseDNS looks up the PTR records of server's public addresses.
+// It returns nil when the provider does not manage them.
+func (m serverShowModel) fetchReverseDNS(server *domain.Server) tea.Cmd {
+	rp, ok := m.provider.(domain.ReverseDNSProvider)
+	if !ok || server == nil || (server.PublicIPv4 == "" && server.PublicIPv6 == "") {
+		return nil
+	}
+	serverID := server.ID
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		began := time.Now()
+		records, err := rp.GetReverseDNS(ctx, serverID)
+		if err != nil {
+			return nil // best-effort: addresses are shown without records
+		}
+		return showReverseDNSLoadedMsg{timing: timed("reverse DNS", began), serverID: serverID, records: records}
 	}
 }
 
+// Subscriptions refreshes the detail view when the shown server changes.
+func (m serverShowModel) Subscriptions() []events.Topic {
+	if m.server == nil {
+		return nil
+	}
+	return []events.Topic{{Resource: events.ResourceServer, ID: m.server.ID}}
+}
+
 // --- Update ---
 
 func (m serverShowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -289,6 +530,19 @@ func (m serverShowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// header/footer/status heights, so just store dimensions here.
 		return m, nil
 
+	case events.Msg:
+		if !events.Wants(m, msg.Event) {
+			return m, nil
+		}
+		if msg.Kind == events.Deleted && m.embedded {
+			return m, func() tea.Msg { return navigateBackMsg{} }
+		}
+		m.serverID = m.server.ID
+		m.loading = true
+		m.err = nil
+		m.status = ""
+		return m, tea.Batch(m.spinner.Tick, m.fetchServer())
+
 	case tea.KeyMsg:
 		model, cmd := m.handleKey(msg)
 		updated := model.(serverShowModel)
@@ -332,7 +586,8 @@ func (m serverShowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.metricsLoading = true
 		m.metrics = nil
 		m.metricsErr = nil
-		return m, tea.Batch(m.spinner.Tick, m.fetchMetrics())
+		m.idleReport = nil
+		return m, tea.Batch(m.spinner.Tick, m.fetchMetrics(), m.fetchIdleReport(msg.server), m.fetchNetworks(msg.server), m.fetchReverseDNS(msg.server))
 
 	case serverDetailErrorMsg:
 		m.loading = false
@@ -370,21 +625,78 @@ func (m serverShowModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.poller, cmd, outcome = m.poller.HandlePollError(msg)
 		return m.applyToggleOutcome(outcome, cmd)
 
+	case consoleOpenedMsg:
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			m.statusIsError = true
+			return m, nil
+		}
+		if m.server == nil || m.server.ID != msg.serverID {
+			msg.stop()
+			return m, nil
+		}
+		m.stopConsole = msg.stop
+		m.status = fmt.Sprintf("Console at %s, password %s. Press v to close it.", console.ViewerURL(msg.addr), msg.password)
+		m.statusIsError = false
+		return m, nil
+
+	case pingResultMsg:
+		if m.server != nil && m.server.ID == msg.serverID {
+			m.pinging = false
+			m.pingResults = msg.results
+			m.pingErr = msg.err
+		}
+		return m, nil
+
 	// --- Metrics lifecycle ---
 
 	case metricsLoadedMsg:
 		m.metricsLoading = false
+		m.metricsRefreshing = false
+		m.metricsRefreshFailed = false
 		m.metrics = msg.metrics
 		m.metricsErr = nil
-		return m, nil
+		m.metricsUpdated = time.Now()
+		return m, m.scheduleMetricsRefresh()
 
 	case metricsErrorMsg:
 		m.metricsLoading = false
+		if m.metricsRefreshing && m.metrics != nil {
+			m.metricsRefreshing = false
+			m.metricsRefreshFailed = true
+			return m, m.scheduleMetricsRefresh()
+		}
+		m.metricsRefreshing = false
 		m.metricsErr = msg.err
 		return m, nil
 
+	case metricsRefreshMsg:
+		if m.server == nil || m.server.ID != msg.serverID || !msg.since.Equal(m.metricsUpdated) || m.loading || m.metricsLoading {
+			return m, nil
+		}
+		m.metricsRefreshing = true
+		return m, m.fetchMetrics()
+
+	case idleReportMsg:
+		if m.server != nil && m.server.ID == msg.serverID {
+			m.idleReport = msg.report
+		}
+		return m, nil
+
+	case showNetworksLoadedMsg:
+		if m.server != nil && m.server.ID == msg.serverID {
+			m.networks = msg.networks
+		}
+		return m, nil
+
+	case showReverseDNSLoadedMsg:
+		if m.server != nil && m.server.ID == msg.serverID {
+			m.reverseDNS = msg.records
+		}
+		return m, nil
+
 	case spinner.TickMsg:
-		needsSpinner := m.loading || m.metricsLoading || (!m.embedded && m.poller.active)
+		needsSpinner := m.loading || m.metricsLoading || m.pinging || (!m.embedded && m.poller.active)
 		if needsSpinner {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
@@ -409,19 +721,20 @@ func (m serverShowModel) applyToggleOutcome(outcome *toggleOutcome, pollerCmd te
 
 	if outcome.Success {
 		m.persistentStatus = fmt.Sprintf("Server %q %s successfully", outcome.ServerName, outcome.Verb)
+		announcement := announce(stateSentence(outcome.ServerName, outcome.Verb))
 		if m.phase == showPhaseDetail && m.server != nil {
 			m.loading = true
 			m.err = nil
 			m.serverID = m.server.ID
-			return m, tea.Batch(m.spinner.Tick, m.fetchServer())
+			return m, tea.Batch(m.spinner.Tick, m.fetchServer(), announcement)
 		}
-		return m, nil
+		return m, announcement
 	}
 
 	// Error or timeout.
 	m.status = outcome.StatusText
 	m.statusIsError = outcome.IsError
-	return m, nil
+	return m, announce(sentence(outcome.StatusText))
 }
 
 // --- Key handling ---
@@ -493,8 +806,12 @@ func (m serverShowModel) handleSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m serverShowModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	confirmDisableBackups := m.confirmDisableBackups
+	m.confirmDisableBackups = false
+
 	switch msg.String() {
 	case "q", "esc":
+		m.closeConsole()
 		if m.fromSelect {
 			// Go back to the select phase.
 			m.phase = showPhaseSelect
@@ -506,6 +823,9 @@ func (m serverShowModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.metrics = nil
 			m.metricsLoading = false
 			m.metricsErr = nil
+			m.idleReport = nil
+			m.pingResults = nil
+			m.pingErr = nil
 			m.viewport.GotoTop()
 			return m, nil
 		}
@@ -567,10 +887,113 @@ func (m serverShowModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.metrics = nil
 			m.metricsLoading = false
 			m.metricsErr = nil
+			m.idleReport = nil
+			m.pingResults = nil
+			m.pingErr = nil
 			m.viewport.GotoTop()
 			return m, tea.Batch(m.spinner.Tick, m.fetchServer())
 		}
 
+	case "R":
+		if m.server != nil && m.embedded && m.canReboot() {
+			if m.server.Status != "running" {
+				m.status = fmt.Sprintf("Cannot reboot server %q: status is %q", m.server.Name, m.server.Status)
+				m.statusIsError = true
+				return m, nil
+			}
+			server := domain.Server{ID: m.server.ID, Name: m.server.Name, Status: m.server.Status}
+			return m, func() tea.Msg { return requestRebootMsg{server: server} }
+		}
+
+	case "b":
+		if m.server != nil && m.embedded && m.canBackup() {
+			if m.server.Backups && !confirmDisableBackups {
+				m.confirmDisableBackups = true
+				m.status = fmt.Sprintf("Press b again to disable backups for %q. Existing backups will be deleted.", m.server.Name)
+				m.statusIsError = true
+				return m, nil
+			}
+			m.status = ""
+			m.statusIsError = false
+			server := *m.server
+			return m, func() tea.Msg { return requestBackupsToggleMsg{server: server} }
+		}
+
+	case "B":
+		if m.server != nil && m.embedded && m.canBackup() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToBackupsMsg{server: server} }
+		}
+
+	case "f":
+		if m.server != nil && m.embedded && m.canFloatingIP() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToFloatingIPsMsg{server: server} }
+		}
+
+	case "e":
+		if m.server != nil && m.embedded && m.canReverseDNS() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToReverseDNSMsg{server: server} }
+		}
+
+	case "L":
+		if m.server != nil && m.embedded && m.canEditLabels() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToLabelsMsg{server: server} }
+		}
+
+	case "D":
+		if m.server != nil && m.embedded && m.hasPublicIP() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToDNSAttachMsg{server: server} }
+		}
+
+	case "z":
+		if m.server != nil && m.embedded && m.canResize() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToResizeMsg{server: server} }
+		}
+
+	case "m":
+		if m.server != nil && m.embedded && m.canViewMetrics() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToMetricsMsg{server: server} }
+		}
+
+	case "l":
+		if m.server != nil && m.embedded && m.canViewLogs() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToLogsMsg{server: server} }
+		}
+
+	case "P":
+		if m.server != nil && m.canPing() && !m.pinging {
+			m.pinging = true
+			m.pingResults = nil
+			m.pingErr = nil
+			return m, tea.Batch(m.spinner.Tick, pingServer(*m.server))
+		}
+
+	case "v":
+		if m.stopConsole != nil {
+			m.closeConsole()
+			m.status = "Console closed."
+			m.statusIsError = false
+			return m, nil
+		}
+		if m.canConsole() {
+			m.status = fmt.Sprintf("Opening console for %q%s", m.server.Name, styles.Ellipsis())
+			m.statusIsError = false
+			return m, openConsole(m.provider.(domain.ConsoleProvider), m.server.ID)
+		}
+
+	case "F":
+		if m.embedded && m.canTransfer() {
+			server := *m.server
+			return m, func() tea.Msg { return navigateToTransferMsg{server: server} }
+		}
+
 	case "c":
 		if m.server != nil && m.embedded && m.server.Status == "running" {
 			hasPublicIP := m.server.PublicIPv4 != "" || m.server.PublicIPv6 != ""
@@ -584,6 +1007,145 @@ func (m serverShowModel) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// canTransfer reports whether files can be copied to and from the
+// server, which has to be running and reachable over SSH.
+func (m serverShowModel) canTransfer() bool {
+	return m.server != nil && m.server.Status == "running" && (m.server.PublicIPv4 != "" || m.server.PublicIPv6 != "")
+}
+
+// canReboot reports whether the provider can reboot servers.
+func (m serverShowModel) canReboot() bool {
+	_, ok := m.provider.(domain.RebootProvider)
+	return ok
+}
+
+// canResize reports whether the provider can resize servers and list the
+// server types to choose from.
+func (m serverShowModel) canResize() bool {
+	_, resizer := m.provider.(domain.ResizeProvider)
+	_, catalog := m.provider.(domain.CatalogProvider)
+	return resizer && catalog
+}
+
+// canBackup reports whether the provider manages server backups.
+func (m serverShowModel) canBackup() bool {
+	_, ok := m.provider.(domain.BackupProvider)
+	return ok
+}
+
+// canFloatingIP reports whether the provider manages floating IPs.
+func (m serverShowModel) canFloatingIP() bool {
+	_, ok := m.provider.(domain.FloatingIPProvider)
+	return ok
+}
+
+// canReverseDNS reports whether the provider manages the PTR records of
+// the server, which needs a public address.
+func (m serverShowModel) canReverseDNS() bool {
+	_, ok := m.provider.(domain.ReverseDNSProvider)
+	return ok && m.server != nil && (m.server.PublicIPv4 != "" || m.server.PublicIPv6 != "")
+}
+
+// canEditLabels reports whether the provider can change server labels.
+func (m serverShowModel) canEditLabels() bool {
+	_, ok := m.provider.(domain.LabelProvider)
+	return ok
+}
+
+// hasPublicIP reports whether the server has a public address a DNS name
+// can point at.
+func (m serverShowModel) hasPublicIP() bool {
+	return m.server != nil && (m.server.PublicIPv4 != "" || m.server.PublicIPv6 != "")
+}
+
+// reverseDNSFor returns the PTR hostname of ip, or "" when it has none.
+func (m serverShowModel) reverseDNSFor(ip string) string {
+	for _, r := range m.reverseDNS {
+		if r.IP == ip {
+			return r.Hostname
+		}
+	}
+	return ""
+}
+
+// canPing reports whether the server has a public address to probe.
+func (m serverShowModel) canPing() bool {
+	return m.server != nil && len(ping.Addresses(*m.server)) > 0
+}
+
+// pingServer probes the server's public IPs with the default options.
+func pingServer(server domain.Server) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		results, err := ping.Server(ctx, server, ping.DefaultOptions())
+		return pingResultMsg{serverID: server.ID, results: results, err: err}
+	}
+}
+
+// canConsole reports whether the provider offers a console for the
+// server, which has to be running.
+func (m serverShowModel) canConsole() bool {
+	_, ok := m.provider.(domain.ConsoleProvider)
+	return ok && m.server != nil && m.server.Status == "running"
+}
+
+// closeConsole shuts down the console proxy, if one is running.
+func (m *serverShowModel) closeConsole() {
+	if m.stopConsole != nil {
+		m.stopConsole()
+		m.stopConsole = nil
+	}
+}
+
+// openConsole requests a VNC console for the server, proxies it to a
+// free local port and hands that to the system's VNC viewer. The proxy
+// runs until the returned stop function is called.
+func openConsole(provider domain.ConsoleProvider, serverID string) tea.Cmd {
+	return func() tea.Msg {
+		reqCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		c, err := provider.RequestConsole(reqCtx, serverID)
+		cancel()
+		if err != nil {
+			return consoleOpenedMsg{serverID: serverID, err: err}
+		}
+
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return consoleOpenedMsg{serverID: serverID, err: fmt.Errorf("failed to start console proxy: %w", err)}
+		}
+		var configuredProxy string
+		if cfg, err := config.Load(); err == nil {
+			configuredProxy = cfg.Proxy
+		}
+		ctx, stop := context.WithCancel(context.Background())
+		go console.Serve(ctx, ln, c, configuredProxy)
+
+		addr := ln.Addr().String()
+		browser.Open(console.ViewerURL(addr))
+		return consoleOpenedMsg{serverID: serverID, addr: addr, password: c.Password, stop: stop}
+	}
+}
+
+// canViewMetrics reports whether the provider serves metrics for the
+// dashboard.
+func (m serverShowModel) canViewMetrics() bool {
+	_, ok := m.metricsProvider()
+	return ok
+}
+
+// canViewLogs reports whether boot logs can be fetched for the server,
+// either from the provider's console output or over SSH.
+func (m serverShowModel) canViewLogs() bool {
+	if m.server == nil {
+		return false
+	}
+	if _, ok := m.provider.(domain.ConsoleOutputProvider); ok {
+		return true
+	}
+	return m.server.Status == "running" && (m.server.PublicIPv4 != "" || m.server.PublicIPv6 != "")
+}
+
 // --- View ---
 
 func (m serverShowModel) View() string {
@@ -591,7 +1153,7 @@ func (m serverShowModel) View() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "server show", m.providerName)
+	header := components.HeaderTrail(m.width, serverTrail(m.server), m.providerName)
 
 	var footerBindings []components.KeyBinding
 	showReduced := m.loading || (!m.embedded && m.poller.active)
@@ -616,6 +1178,49 @@ func (m serverShowModel) View() string {
 		if canSSH {
 			bindings = append(bindings, components.KeyBinding{Key: "c", Desc: "ssh"})
 		}
+		if m.embedded && m.canTransfer() {
+			bindings = append(bindings, components.KeyBinding{Key: "F", Desc: "transfer files"})
+		}
+		if m.embedded && m.canReboot() && m.server != nil && m.server.Status == "running" {
+			bindings = append(bindings, components.KeyBinding{Key: "R", Desc: "reboot"})
+		}
+		if m.embedded && m.canResize() {
+			bindings = append(bindings, components.KeyBinding{Key: "z", Desc: "resize"})
+		}
+		if m.embedded && m.canBackup() && m.server != nil {
+			toggle := "enable backups"
+			if m.server.Backups {
+				toggle = "disable backups"
+			}
+			bindings = append(bindings,
+				components.KeyBinding{Key: "b", Desc: toggle},
+				components.KeyBinding{Key: "B", Desc: "backups"},
+			)
+		}
+		if m.embedded && m.canFloatingIP() {
+			bindings = append(bindings, components.KeyBinding{Key: "f", Desc: "floating IPs"})
+		}
+		if m.embedded && m.canReverseDNS() {
+			bindings = append(bindings, components.KeyBinding{Key: "e", Desc: "reverse DNS"})
+		}
+		if m.embedded && m.canEditLabels() {
+			bindings = append(bindings, components.KeyBinding{Key: "L", Desc: "labels"})
+		}
+		if m.embedded && m.hasPublicIP() {
+			bindings = append(bindings, components.KeyBinding{Key: "D", Desc: "point DNS name"})
+		}
+		if m.embedded && m.canViewLogs() {
+			bindings = append(bindings, components.KeyBinding{Key: "l", Desc: "logs"})
+		}
+		if m.embedded && m.canViewMetrics() {
+			bindings = append(bindings, components.KeyBinding{Key: "m", Desc: "metrics"})
+		}
+		if m.canPing() {
+			bindings = append(bindings, components.KeyBinding{Key: "P", Desc: "ping"})
+		}
+		if m.canConsole() {
+			bindings = append(bindings, components.KeyBinding{Key: "v", Desc: "console"})
+		}
 		if m.fromSelect {
 			bindings = append(bindings, components.KeyBinding{Key: "esc", Desc: "back"})
 		}
@@ -656,9 +1261,9 @@ func (m serverShowModel) renderContent(height int) string {
 		var loadingLabel string
 		switch m.phase {
 		case showPhaseSelect:
-			loadingLabel = "Fetching servers…"
+			loadingLabel = "Fetching servers" + styles.Ellipsis()
 		default:
-			loadingLabel = "Fetching server details…"
+			loadingLabel = "Fetching server details" + styles.Ellipsis()
 		}
 		loadingText := m.spinner.View() + "  " + loadingLabel
 		return lipgloss.Place(
@@ -842,6 +1447,19 @@ func (m serverShowModel) renderDetail() string {
 	if !s.CreatedAt.IsZero() {
 		overviewFields = append(overviewFields, renderField("Created", s.CreatedAt.UTC().Format("2006-01-02 15:04:05 UTC")))
 	}
+	if s.Interruptible {
+		overviewFields = append(overviewFields, renderField("Interruptible", styles.WarningText.Render("spot - may be reclaimed")))
+	}
+	if m.canBackup() {
+		backups := "disabled"
+		if s.Backups {
+			backups = "enabled"
+		}
+		overviewFields = append(overviewFields, renderField("Backups", backups))
+	}
+	if labels := formatLabels(s.Labels); labels != "" {
+		overviewFields = append(overviewFields, renderField("Labels", labels))
+	}
 
 	overviewContent := strings.Join(overviewFields, "\n")
 
@@ -849,8 +1467,19 @@ func (m serverShowModel) renderDetail() string {
 	var networkFields []string
 	if s.PublicIPv4 != "" {
 		networkFields = append(networkFields, renderField("IPv4", s.PublicIPv4))
+		if ptr := m.reverseDNSFor(s.PublicIPv4); ptr != "" {
+			networkFields = append(networkFields, renderField("IPv4 rDNS", ptr))
+		}
 	}
-	if s.PublicIPv6 != "" {
+	if s.PublicIPv6Network != "" {
+		networkFields = append(networkFields, renderField("IPv6 subnet", s.PublicIPv6Network))
+		if addr, err := ipv6.DefaultAddress(s.PublicIPv6Network); err == nil {
+			networkFields = append(networkFields, renderField("IPv6 (::1)", addr.String()))
+			if ptr := m.reverseDNSFor(addr.String()); ptr != "" {
+				networkFields = append(networkFields, renderField("IPv6 rDNS", ptr))
+			}
+		}
+	} else if s.PublicIPv6 != "" {
 		networkFields = append(networkFields, renderField("IPv6", s.PublicIPv6))
 	}
 	if s.PrivateIPv4 != "" {
@@ -875,6 +1504,67 @@ func (m serverShowModel) renderDetail() string {
 		))
 	}
 
+	if networks := s.AttachedOfKind(domain.AttachedNetwork); len(networks) > 0 {
+		ranges := make(map[string]string, len(m.networks))
+		for _, n := range m.networks {
+			ranges[n.ID] = n.IPRange
+		}
+		var networkLines []string
+		for _, r := range networks {
+			name := r.Name
+			if name == "" {
+				name = "#" + r.ID
+			}
+			line := renderField(name, cmp.Or(r.Detail, "-"))
+			if ipRange := ranges[r.ID]; ipRange != "" {
+				line += "\n" + renderField("", styles.MutedText.Render("in "+ipRange))
+			}
+			networkLines = append(networkLines, line)
+		}
+		leftSections = append(leftSections, leftStyle.Render(
+			styles.Subtitle.Render("Private networks")+"\n\n"+strings.Join(networkLines, "\n"),
+		))
+	}
+
+	if m.pinging || m.pingResults != nil || m.pingErr != nil {
+		leftSections = append(leftSections, leftStyle.Render(
+			styles.Subtitle.Render("Reachability")+"\n\n"+m.renderPingResults(leftWidth-6),
+		))
+	}
+
+	var attachedFields []string
+	for _, r := range s.Attached {
+		if r.Kind == domain.AttachedNetwork {
+			continue // shown under "Private networks"
+		}
+		attachedFields = append(attachedFields, renderField(attachedKindLabel(r.Kind), attachedValue(r)))
+	}
+	if len(attachedFields) > 0 {
+		leftSections = append(leftSections, leftStyle.Render(
+			styles.Subtitle.Render("Attached")+"\n\n"+strings.Join(attachedFields, "\n"),
+		))
+	}
+
+	if len(m.previousIPs) > 0 {
+		var historyLines []string
+		for _, rec := range m.previousIPs {
+			historyLines = append(historyLines,
+				styles.Value.Render(rec.IP)+"\n"+styles.MutedText.Render("last seen "+rec.LastSeen.Local().Format("2006-01-02 15:04")))
+		}
+		warning := styles.WarningText.Width(leftWidth - 6).Render(m.staleDNS.warning())
+		leftSections = append(leftSections, leftStyle.Render(
+			styles.Subtitle.Render("Previous IPs")+"\n\n"+strings.Join(historyLines, "\n")+"\n\n"+warning,
+		))
+	}
+
+	if m.idleReport != nil && m.idleReport.Idle && s.Status == "running" {
+		text := styles.WarningText.Width(leftWidth - 6).Render(m.idleReport.Recommendation())
+		hint := styles.MutedText.Render("Press s to stop this server.")
+		leftSections = append(leftSections, leftStyle.Render(
+			styles.Subtitle.Render("Recommendation")+"\n\n"+text+"\n\n"+hint,
+		))
+	}
+
 	leftColumn := lipgloss.JoinVertical(lipgloss.Left, leftSections...)
 
 	// Build right column (metrics).
@@ -884,20 +1574,81 @@ func (m serverShowModel) renderDetail() string {
 	// Join columns horizontally with a gap.
 	gap := strings.Repeat(" ", columnGap)
 	var detail string
-	if rightColumn != "" {
-		detail = lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, gap, rightColumn)
-	} else {
+	switch {
+	case rightColumn == "":
 		detail = leftColumn
+	case styles.Accessible():
+		// Screen readers go line by line, across both columns at once.
+		detail = lipgloss.JoinVertical(lipgloss.Left, leftColumn, rightColumn)
+	default:
+		detail = lipgloss.JoinHorizontal(lipgloss.Top, leftColumn, gap, rightColumn)
 	}
 
 	// Pad left to align with header/footer.
 	return lipgloss.NewStyle().PaddingLeft(hPad).Render(detail)
 }
 
+// renderPingResults lists each probed address with its method and
+// outcome, or the in-progress/error state.
+func (m serverShowModel) renderPingResults(width int) string {
+	switch {
+	case m.pinging:
+		return m.spinner.View() + "  Pinging" + styles.Ellipsis()
+	case m.pingErr != nil:
+		return styles.ErrorText.Width(width).Render(m.pingErr.Error())
+	}
+	lines := make([]string, 0, len(m.pingResults))
+	for _, r := range m.pingResults {
+		status := styles.SuccessText
+		if !r.Reachable() {
+			status = styles.ErrorText
+		} else if r.Received < r.Sent {
+			status = styles.WarningText
+		}
+		head := styles.Value.Render(r.Address)
+		if label := r.MethodLabel(); label != "" {
+			head += styles.MutedText.Render("  " + label)
+		}
+		lines = append(lines, head+"\n"+status.Width(width).Render(r.Summary()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// attachedKindLabel returns the display label for an attached resource kind.
+func attachedKindLabel(kind string) string {
+	switch kind {
+	case domain.AttachedVolume:
+		return "Volume"
+	case domain.AttachedFloatingIP:
+		return "Floating IP"
+	case domain.AttachedFirewall:
+		return "Firewall"
+	case domain.AttachedLoadBalancer:
+		return "Load balancer"
+	case domain.AttachedNetwork:
+		return "Network"
+	default:
+		return kind
+	}
+}
+
+// attachedValue formats an attached resource as its name (or #ID when the
+// provider did not report one) followed by any detail in parentheses.
+func attachedValue(r domain.AttachedResource) string {
+	v := r.Name
+	if v == "" {
+		v = "#" + r.ID
+	}
+	if r.Detail != "" {
+		v += " (" + r.Detail + ")"
+	}
+	return v
+}
+
 // renderMetricsSection renders the metrics card with loading/error/chart states.
 func (m serverShowModel) renderMetricsSection(cardWidth int, sectionStyle lipgloss.Style) string {
 	if m.metricsLoading {
-		metricsContent := m.spinner.View() + "  Loading metrics…"
+		metricsContent := m.spinner.View() + "  Loading metrics" + styles.Ellipsis()
 		return sectionStyle.Render(
 			styles.Subtitle.Render("Metrics") + "\n\n" + styles.MutedText.Render(metricsContent),
 		)
@@ -928,25 +1679,15 @@ func (m serverShowModel) renderMetricsSection(cardWidth int, sectionStyle lipglo
 		charts = append(charts, components.MetricsChart("CPU", cpuData, chartWidth, "%"))
 	}
 
-	// Disk IOPS chart (dual: read + write). Blue/Yellow.
-	diskRead := extractMetricValues(m.metrics, "disk.0.iops.read")
-	diskWrite := extractMetricValues(m.metrics, "disk.0.iops.write")
-	if len(diskRead) > 0 || len(diskWrite) > 0 {
-		charts = append(charts, components.MetricsDualChart(
-			"Disk IOPS", diskRead, diskWrite, "read", "write", chartWidth, "",
-			components.DualChartColors{Color1: styles.Blue, Color2: styles.Yellow},
-		))
+	// Memory chart, for providers that report it.
+	memData := extractMetricValues(m.metrics, string(domain.MetricMemory))
+	if len(memData) > 0 {
+		charts = append(charts, components.MetricsChart("Memory", memData, chartWidth, "%"))
 	}
 
-	// Network bandwidth chart (dual: in + out). Green/Red.
-	netIn := extractMetricValues(m.metrics, "network.0.bandwidth.in")
-	netOut := extractMetricValues(m.metrics, "network.0.bandwidth.out")
-	if len(netIn) > 0 || len(netOut) > 0 {
-		charts = append(charts, components.MetricsDualChart(
-			"Network", netIn, netOut, "in", "out", chartWidth, "B/s",
-			components.DualChartColors{Color1: styles.Green, Color2: styles.Red},
-		))
-	}
+	// Disk IOPS and network bandwidth, one chart per disk and interface.
+	charts = append(charts, renderDeviceCharts(m.metrics, diskIOPSChart, chartWidth, components.ChartOptions{})...)
+	charts = append(charts, renderDeviceCharts(m.metrics, networkBandwidthChart, chartWidth, components.ChartOptions{})...)
 
 	if len(charts) == 0 {
 		return sectionStyle.Render(
@@ -956,10 +1697,23 @@ func (m serverShowModel) renderMetricsSection(cardWidth int, sectionStyle lipglo
 
 	metricsContent := strings.Join(charts, "\n\n")
 	return sectionStyle.Render(
-		styles.Subtitle.Render("Metrics (last hour)") + "\n\n" + metricsContent,
+		styles.Subtitle.Render("Metrics (last hour)") + m.metricsUpdatedLabel() + "\n\n" + metricsContent,
 	)
 }
 
+// metricsUpdatedLabel is the muted "last updated" note beside the metrics
+// title, flagging a failed refresh.
+func (m serverShowModel) metricsUpdatedLabel() string {
+	if m.metricsUpdated.IsZero() {
+		return ""
+	}
+	label := " · updated " + m.metricsUpdated.Format("15:04:05")
+	if m.metricsRefreshFailed {
+		label += ", refresh failed"
+	}
+	return styles.MutedText.Render(label)
+}
+
 // extractMetricValues extracts the float64 values from a named time series.
 func extractMetricValues(m *domain.ServerMetrics, key string) []float64 {
 	key = util.NormalizeKey(key)
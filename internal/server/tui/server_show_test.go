@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/ping"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderDetail_AttachedCard(t *testing.T) {
+	server := &domain.Server{
+		ID:     "7",
+		Name:   "app",
+		Status: "running",
+		Attached: []domain.AttachedResource{
+			{Kind: domain.AttachedVolume, ID: "31", Name: "data"},
+			{Kind: domain.AttachedFirewall, ID: "21", Detail: "applied"},
+		},
+	}
+	m := newServerShowDirect(nil, "hetzner", server, nil)
+	m.width = 120
+
+	out := m.renderDetail()
+	for _, want := range []string{"Attached", "Volume", "data", "Firewall", "#21 (applied)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("detail missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderDetail_NoAttachedCard(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app"}, nil)
+	m.width = 120
+
+	if strings.Contains(m.renderDetail(), "Attached") {
+		t.Error("expected no Attached card for a server without attached resources")
+	}
+}
+
+// networkShowProvider lists private networks for the show view.
+type networkShowProvider struct {
+	reauthProvider
+	networks []domain.NetworkSpec
+}
+
+func (p *networkShowProvider) ListNetworks(context.Context) ([]domain.NetworkSpec, error) {
+	return p.networks, nil
+}
+
+func (p *networkShowProvider) CreateNetwork(context.Context, domain.CreateNetworkOpts) (*domain.NetworkSpec, error) {
+	return nil, nil
+}
+
+func (p *networkShowProvider) DeleteNetwork(context.Context, string) error { return nil }
+
+func (p *networkShowProvider) AttachServer(context.Context, string, string, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func (p *networkShowProvider) DetachServer(context.Context, string, string) (*domain.ActionStatus, error) {
+	return nil, nil
+}
+
+func TestRenderDetail_PrivateNetworks(t *testing.T) {
+	server := &domain.Server{
+		ID:     "7",
+		Name:   "app",
+		Status: "running",
+		Attached: []domain.AttachedResource{
+			{Kind: domain.AttachedNetwork, ID: "4", Name: "internal", Detail: "10.0.0.2"},
+		},
+	}
+	provider := &networkShowProvider{networks: []domain.NetworkSpec{
+		{ID: "4", Name: "internal", IPRange: "10.0.0.0/16"},
+		{ID: "5", Name: "other", IPRange: "10.9.0.0/16"},
+	}}
+	m := newServerShowDirect(provider, "hetzner", server, nil)
+	m.width = 120
+
+	cmd := m.fetchNetworks(server)
+	if cmd == nil {
+		t.Fatal("expected networks to be fetched for a server in a network")
+	}
+	updated, _ := m.Update(cmd())
+	m = updated.(serverShowModel)
+
+	out := m.renderDetail()
+	for _, want := range []string{"Private networks", "internal", "10.0.0.2", "in 10.0.0.0/16"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("detail missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Attached") || strings.Contains(out, "10.9.0.0/16") {
+		t.Errorf("expected only the attached network, in its own card:\n%s", out)
+	}
+}
+
+func TestFetchNetworks_SkipsServersWithoutNetworks(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app"}
+	m := newServerShowDirect(&networkShowProvider{}, "hetzner", server, nil)
+	if m.fetchNetworks(server) != nil {
+		t.Error("expected no network lookup for a server without private networks")
+	}
+}
+
+func TestServerShow_PingShowsReachabilityCard(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", PublicIPv4: "203.0.113.5"}
+	m := newServerShowDirect(nil, "hetzner", server, nil)
+	m.width = 120
+
+	updated, cmd := m.handleDetailKey(runeKey('P'))
+	m = updated.(serverShowModel)
+	if !m.pinging || cmd == nil {
+		t.Fatal("expected P to start a reachability check")
+	}
+	if out := m.renderDetail(); !strings.Contains(out, "Reachability") || !strings.Contains(out, "Pinging") {
+		t.Errorf("expected an in-progress card:\n%s", out)
+	}
+
+	updated, _ = m.Update(pingResultMsg{serverID: "7", results: []ping.Result{
+		{Address: "203.0.113.5", Method: ping.MethodTCP, Port: 22, Sent: 4, Received: 3},
+	}})
+	m = updated.(serverShowModel)
+	out := m.renderDetail()
+	for _, want := range []string{"203.0.113.5", "tcp/22", "3/4 answered", "25% loss"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("detail missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestServerShow_PingNeedsPublicIP(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app"}, nil)
+
+	updated, cmd := m.handleDetailKey(runeKey('P'))
+	if updated.(serverShowModel).pinging || cmd != nil {
+		t.Error("expected no reachability check for a server without public IPs")
+	}
+}
+
+func TestServerShow_OverviewShowsLabels(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", Labels: map[string]string{"team": "web", "env": "prod"}}
+	m := newServerShowDirect(nil, "hetzner", server, nil)
+	m.width = 120
+
+	if out := m.renderDetail(); !strings.Contains(out, "env=prod, team=web") {
+		t.Errorf("expected the overview to list the labels:\n%s", out)
+	}
+}
+
+func TestServerShow_HeaderShowsBreadcrumbTrail(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app", Status: "running"}, nil)
+	m.width, m.height = 120, 40
+
+	if out := ansi.Strip(m.View()); !strings.Contains(out, "vpsm › servers › app") {
+		t.Errorf("expected the header trail to name the server:\n%s", out)
+	}
+}
+
+func TestServerShow_ConsoleStatusAndClose(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app", Status: "running"}, nil)
+
+	stopped := false
+	updated, _ := m.Update(consoleOpenedMsg{serverID: "7", addr: "127.0.0.1:5901", password: "s3cret", stop: func() { stopped = true }})
+	m = updated.(serverShowModel)
+	if !strings.Contains(m.status, "vnc://127.0.0.1:5901") || !strings.Contains(m.status, "s3cret") {
+		t.Errorf("expected the console address and password in the status, got %q", m.status)
+	}
+
+	updated, cmd := m.handleDetailKey(runeKey('v'))
+	m = updated.(serverShowModel)
+	if !stopped || m.stopConsole != nil || cmd != nil {
+		t.Errorf("expected v to close the running console (stopped=%v)", stopped)
+	}
+}
+
+func TestServerShow_PeriodicMetricsRefresh(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app", Status: "running"}, nil)
+	m.metricsRefresh = 30 * time.Second
+	first := &domain.ServerMetrics{}
+
+	updated, cmd := m.Update(metricsLoadedMsg{metrics: first})
+	m = updated.(serverShowModel)
+	if cmd == nil || m.metricsUpdated.IsZero() {
+		t.Fatal("expected loaded metrics to schedule a refresh")
+	}
+
+	// A tick from an earlier load is ignored.
+	updated, cmd = m.Update(metricsRefreshMsg{serverID: "7", since: m.metricsUpdated.Add(-time.Minute)})
+	if updated.(serverShowModel).metricsRefreshing || cmd != nil {
+		t.Error("expected a stale tick to be ignored")
+	}
+
+	updated, cmd = m.Update(metricsRefreshMsg{serverID: "7", since: m.metricsUpdated})
+	m = updated.(serverShowModel)
+	if !m.metricsRefreshing || m.metricsLoading || cmd == nil {
+		t.Fatalf("expected a background refresh, got refreshing=%v loading=%v", m.metricsRefreshing, m.metricsLoading)
+	}
+
+	// A failed refresh keeps the previous metrics and tries again later.
+	updated, cmd = m.Update(metricsErrorMsg{err: errors.New("timeout")})
+	m = updated.(serverShowModel)
+	if m.metrics != first || m.metricsErr != nil || !m.metricsRefreshFailed || cmd == nil {
+		t.Errorf("expected the previous metrics to stay up, got metrics=%v err=%v failed=%v", m.metrics, m.metricsErr, m.metricsRefreshFailed)
+	}
+	if !strings.Contains(m.metricsUpdatedLabel(), "refresh failed") {
+		t.Errorf("expected the label to flag the failed refresh, got %q", m.metricsUpdatedLabel())
+	}
+}
+
+func TestServerShow_MetricsRefreshOff(t *testing.T) {
+	m := newServerShowDirect(nil, "hetzner", &domain.Server{ID: "7", Name: "app", Status: "running"}, nil)
+	m.metricsRefresh = 0
+
+	if _, cmd := m.Update(metricsLoadedMsg{metrics: &domain.ServerMetrics{}}); cmd != nil {
+		t.Error("expected no refresh to be scheduled when refreshing is off")
+	}
+}
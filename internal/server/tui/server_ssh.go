@@ -1,10 +1,19 @@
 package tui
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/remote"
+	"nathanbeddoewebdev/vpsm/internal/sshkeys"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
@@ -16,6 +25,109 @@ import (
 // validUsernameRegex matches valid SSH usernames (alphanumeric, dot, underscore, hyphen).
 var validUsernameRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
 
+// sshUsernameInputID identifies the username input in
+// components.InputValidatedMsg.
+const sshUsernameInputID = "ssh-username"
+
+// validateSSHUsername accepts an empty value (meaning "root") or a name
+// made of valid SSH username characters.
+func validateSSHUsername(username string) error {
+	if username != "" && !validUsernameRegex.MatchString(username) {
+		return errors.New("username must contain only letters, numbers, dots, underscores, and hyphens")
+	}
+	return nil
+}
+
+// sshPortInputID identifies the port input in components.InputValidatedMsg.
+const sshPortInputID = "ssh-port"
+
+// validateSSHPort accepts an empty value (meaning the default port) or a
+// TCP port number.
+func validateSSHPort(value string) error {
+	if value == "" {
+		return nil
+	}
+	if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+		return errors.New("port must be a number between 1 and 65535")
+	}
+	return nil
+}
+
+// newSSHPortInput returns the SSH view's port field, prefilled with the
+// port last used for the server (0 leaves it empty).
+func newSSHPortInput(port int) components.ValidatedInput {
+	ti := textinput.New()
+	ti.Placeholder = "22"
+	ti.CharLimit = 5
+	ti.Width = 8
+
+	if port != 0 {
+		ti.SetValue(strconv.Itoa(port))
+	}
+
+	return components.NewValidatedInput(sshPortInputID, ti, validateSSHPort)
+}
+
+// sshField is the focused field of the SSH connect view.
+type sshField int
+
+const (
+	sshFieldUsername sshField = iota
+	sshFieldPort
+	sshFieldIdentity
+	sshFieldCount
+)
+
+// newSSHUsernameInput returns the SSH view's username field, prefilled
+// with the last username used for the server.
+func newSSHUsernameInput(defaultUsername string) components.ValidatedInput {
+	ti := textinput.New()
+	ti.Placeholder = "root"
+	ti.Focus()
+	ti.CharLimit = 64
+	ti.Width = 40
+
+	if defaultUsername != "" {
+		ti.SetValue(defaultUsername)
+	}
+
+	return components.NewValidatedInput(sshUsernameInputID, ti, validateSSHUsername)
+}
+
+// sshKeyCheckMsg carries the result of matching the provider's SSH keys
+// against keys available on this machine.
+type sshKeyCheckMsg struct {
+	serverID string
+	total    int      // number of keys uploaded to the provider
+	matched  []string // "name (source)" for each key available locally
+	err      error
+}
+
+// checkLocalKeys lists the provider's SSH keys and reports which of them
+// are loaded in ssh-agent or present in ~/.ssh. The provider does not
+// expose which keys a server was created with, so the account's keys are
+// used as a proxy for the server's authorized keys.
+func checkLocalKeys(provider domain.Provider, serverID string) tea.Cmd {
+	catalog, ok := provider.(domain.CatalogProvider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		keys, err := catalog.ListSSHKeys(context.Background())
+		if err != nil {
+			return sshKeyCheckMsg{serverID: serverID, err: err}
+		}
+		local := sshkeys.LocalKeys()
+		msg := sshKeyCheckMsg{serverID: serverID, total: len(keys)}
+		for _, k := range keys {
+			if found := sshkeys.FindByFingerprint(local, k.Fingerprint); found != nil {
+				msg.matched = append(msg.matched, fmt.Sprintf("%s (%s)", k.Name, found.Source))
+			}
+		}
+		return msg
+	}
+}
+
 // --- SSH connect model ---
 
 type serverSSHModel struct {
@@ -23,11 +135,19 @@ type serverSSHModel struct {
 	providerName string
 	ipAddress    string
 
-	usernameInput   textinput.Model
-	validationErr   string
+	username        components.ValidatedInput
+	port            components.ValidatedInput
+	identities      []string // private key files offered by the picker
+	identity        string   // chosen key file, "" for ssh's defaults
+	focus           sshField
+	persistent      bool   // run the login inside a remote tmux session
 	hostKeyConflict bool   // true when showing host key conflict error
 	errorMsg        string // error message to display
 
+	provider domain.Provider
+	bastion  string          // jump host, "" for a direct connection
+	keyCheck *sshKeyCheckMsg // nil until the local key check completes
+
 	width  int
 	height int
 
@@ -35,7 +155,7 @@ type serverSSHModel struct {
 }
 
 func (m serverSSHModel) Init() tea.Cmd {
-	return textinput.Blink
+	return tea.Batch(textinput.Blink, checkLocalKeys(m.provider, m.server.ID))
 }
 
 func (m serverSSHModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -45,16 +165,108 @@ func (m serverSSHModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 		return m, nil
 
+	case sshKeyCheckMsg:
+		if msg.serverID == m.server.ID {
+			m.keyCheck = &msg
+		}
+		return m, nil
+
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+
+	case components.InputValidatedMsg:
+		switch msg.ID {
+		case sshPortInputID:
+			// The port is checked first; the username completes the form.
+			var cmd tea.Cmd
+			m.username, cmd = m.username.Submit()
+			if m.username.Err() != "" {
+				return m.setFocus(sshFieldUsername)
+			}
+			return m, cmd
+		case sshUsernameInputID:
+			if m.embedded {
+				return m, func() tea.Msg {
+					return requestSSHMsg{
+						server:     *m.server,
+						username:   m.sshUsername(),
+						ipAddress:  m.ipAddress,
+						persistent: m.persistent,
+						identity:   m.identity,
+						port:       m.sshPort(),
+					}
+				}
+			}
+			return m, tea.Quit
+		}
+		return m, nil
 	}
 
-	// Forward to text input.
+	return m.updateFocused(msg)
+}
+
+// updateFocused forwards msg to the focused text input.
+func (m serverSSHModel) updateFocused(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	m.usernameInput, cmd = m.usernameInput.Update(msg)
+	switch m.focus {
+	case sshFieldUsername:
+		m.username, cmd = m.username.Update(msg)
+	case sshFieldPort:
+		m.port, cmd = m.port.Update(msg)
+	}
 	return m, cmd
 }
 
+// setFocus moves the cursor to field.
+func (m serverSSHModel) setFocus(field sshField) (tea.Model, tea.Cmd) {
+	m.focus = field
+	m.username.Input.Blur()
+	m.port.Input.Blur()
+	switch field {
+	case sshFieldUsername:
+		return m, m.username.Focus()
+	case sshFieldPort:
+		return m, m.port.Focus()
+	}
+	return m, nil
+}
+
+// withIdentities offers keys in the identity picker with identity
+// selected. A remembered key that is no longer in ~/.ssh stays selectable
+// so the choice is not silently dropped.
+func (m serverSSHModel) withIdentities(keys []string, identity string) serverSSHModel {
+	m.identities = keys
+	m.identity = identity
+	if identity != "" && !slices.Contains(keys, identity) {
+		m.identities = append([]string{identity}, keys...)
+	}
+	return m
+}
+
+// cycleIdentity selects the next (delta 1) or previous (delta -1) choice
+// in the picker, where the first choice is ssh's defaults.
+func (m serverSSHModel) cycleIdentity(delta int) serverSSHModel {
+	choices := append([]string{""}, m.identities...)
+	i := slices.Index(choices, m.identity)
+	i = (i + delta + len(choices)) % len(choices)
+	m.identity = choices[i]
+	return m
+}
+
+// sshPort returns the entered port, or 0 for the default.
+func (m serverSSHModel) sshPort() int {
+	port, _ := strconv.Atoi(m.port.Value())
+	return port
+}
+
+// sshUsername returns the entered username, defaulting to root.
+func (m serverSSHModel) sshUsername() string {
+	if username := m.username.Value(); username != "" {
+		return username
+	}
+	return "root"
+}
+
 func (m serverSSHModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "ctrl+c":
@@ -70,49 +282,53 @@ func (m serverSSHModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "k":
 		// 'k' key: clear host key and retry (only available when hostKeyConflict is true).
 		if m.hostKeyConflict && m.embedded {
-			username := strings.TrimSpace(m.usernameInput.Value())
-			if username == "" {
-				username = "root"
-			}
+			username := m.sshUsername()
 			return m, func() tea.Msg {
 				return clearHostKeyMsg{
-					server:    *m.server,
-					username:  username,
-					ipAddress: m.ipAddress,
+					server:     *m.server,
+					username:   username,
+					ipAddress:  m.ipAddress,
+					persistent: m.persistent,
+					identity:   m.identity,
+					port:       m.sshPort(),
 				}
 			}
 		}
 		// If not in conflict mode, fall through to default (textinput handles 'k').
-		var cmd tea.Cmd
-		m.usernameInput, cmd = m.usernameInput.Update(msg)
-		return m, cmd
+		return m.updateFocused(msg)
 
-	case "enter":
-		username := strings.TrimSpace(m.usernameInput.Value())
-		if username == "" {
-			username = "root"
-		}
-		// Validate username contains only valid SSH username characters.
-		if !validUsernameRegex.MatchString(username) {
-			m.validationErr = "Username must contain only letters, numbers, dots, underscores, and hyphens"
-			return m, nil
-		}
-		m.validationErr = ""
-		if m.embedded {
-			return m, func() tea.Msg {
-				return requestSSHMsg{
-					server:    *m.server,
-					username:  username,
-					ipAddress: m.ipAddress,
-				}
+	case "tab":
+		m.persistent = !m.persistent
+		return m, nil
+
+	case "down":
+		return m.setFocus((m.focus + 1) % sshFieldCount)
+
+	case "up":
+		return m.setFocus((m.focus + sshFieldCount - 1) % sshFieldCount)
+
+	case "left", "right":
+		if m.focus == sshFieldIdentity {
+			delta := 1
+			if msg.String() == "left" {
+				delta = -1
 			}
+			return m.cycleIdentity(delta), nil
 		}
-		return m, tea.Quit
+		return m.updateFocused(msg)
 
-	default:
+	case "enter":
+		// Connects on components.InputValidatedMsg, once the port and
+		// then the username pass validation.
 		var cmd tea.Cmd
-		m.usernameInput, cmd = m.usernameInput.Update(msg)
+		m.port, cmd = m.port.Submit()
+		if m.port.Err() != "" {
+			return m.setFocus(sshFieldPort)
+		}
 		return m, cmd
+
+	default:
+		return m.updateFocused(msg)
 	}
 }
 
@@ -121,10 +337,15 @@ func (m serverSSHModel) View() string {
 		return ""
 	}
 
-	header := components.Header(m.width, "ssh connect", m.providerName)
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "ssh"), m.providerName)
 
 	footerBindings := []components.KeyBinding{
 		{Key: "enter", Desc: "connect"},
+		{Key: "↑/↓", Desc: "field"},
+		{Key: "tab", Desc: "tmux session"},
+	}
+	if m.focus == sshFieldIdentity {
+		footerBindings = append(footerBindings, components.KeyBinding{Key: "←/→", Desc: "key"})
 	}
 	if m.hostKeyConflict {
 		footerBindings = append(footerBindings, components.KeyBinding{Key: "k", Desc: "clear key & retry"})
@@ -159,17 +380,41 @@ func (m serverSSHModel) renderContent(height int) string {
 	fields := []string{
 		renderField("Server", m.server.Name),
 		renderField("Target", m.ipAddress),
+	}
+	if m.bastion != "" {
+		fields = append(fields, renderField("Via", m.bastion))
+	}
+	// Key check failures are not surfaced: ssh itself will report
+	// authentication problems, so the check is purely advisory.
+	if kc := m.keyCheck; kc != nil && kc.err == nil {
+		switch {
+		case len(kc.matched) > 0:
+			fields = append(fields, renderField("Key", strings.Join(kc.matched, ", ")))
+		case kc.total > 0:
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+			fields = append(fields, "", warnStyle.Width(cardWidth-4).Render(
+				"None of your SSH keys on this provider are loaded in ssh-agent or found in ~/.ssh. Authentication may fail."))
+		}
+	}
+	fields = append(fields,
 		"",
 		styles.Subtitle.Render("Username"),
 		"",
-		m.usernameInput.View(),
-	}
+		m.username.View(),
+		"",
+		styles.Subtitle.Render("Port"),
+		"",
+		m.port.View(),
+		"",
+		styles.Subtitle.Render("Identity file"),
+		"",
+		m.renderIdentity(cardWidth-4),
+		"",
+		m.renderPersistent(),
+	)
 
-	// Show error messages (validation or SSH connection errors).
-	if m.validationErr != "" {
-		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
-		fields = append(fields, "", errStyle.Render(m.validationErr))
-	} else if m.errorMsg != "" {
+	// Show SSH connection errors unless a validation error is displayed.
+	if m.username.Err() == "" && m.port.Err() == "" && m.errorMsg != "" {
 		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
 		fields = append(fields, "", errStyle.Render(m.errorMsg))
 		if m.hostKeyConflict {
@@ -189,3 +434,42 @@ func (m serverSSHModel) renderContent(height int) string {
 		combined,
 	)
 }
+
+// renderIdentity renders the identity file picker, highlighted while it
+// has focus.
+func (m serverSSHModel) renderIdentity(width int) string {
+	choice := "default (ssh-agent and ~/.ssh/id_*)"
+	if m.identity != "" {
+		choice = tildePath(m.identity)
+	}
+	if len(m.identities) == 0 && m.identity == "" {
+		choice += styles.MutedText.Render(" — no keys in ~/.ssh")
+	}
+	if m.focus != sshFieldIdentity {
+		return "  " + choice
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(styles.SuccessText.Render("‹ ") + choice + styles.SuccessText.Render(" ›"))
+}
+
+// tildePath shortens a path under the home directory to ~/...
+func tildePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
+
+// renderPersistent renders the checkbox for running the login inside a
+// persistent remote tmux session.
+func (m serverSSHModel) renderPersistent() string {
+	check := "[ ]"
+	if m.persistent {
+		check = styles.SuccessText.Render("[x]")
+	}
+	hint := styles.MutedText.Render(remote.PersistentSession)
+	return check + " Resume in tmux session\n    " + hint
+}
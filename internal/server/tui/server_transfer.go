@@ -0,0 +1,310 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/nativessh"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- Messages ---
+
+// navigateToTransferMsg opens the file transfer view for server, set up
+// for an upload; tab switches it to a download.
+type navigateToTransferMsg struct {
+	server domain.Server
+}
+
+// requestTransferMsg is emitted by the transfer view when the user
+// confirms a copy. The app runs it in the operations overlay.
+type requestTransferMsg struct {
+	server   domain.Server
+	download bool
+	local    string
+	remote   string
+}
+
+// fileTransfer is one file copied between this machine and a server over
+// SCP, with the SSH login saved for the server.
+type fileTransfer struct {
+	session  *nativessh.Session
+	download bool
+	local    string
+	remote   string
+}
+
+// name is the file name shown while the transfer runs.
+func (t fileTransfer) name() string {
+	if t.download {
+		return path.Base(t.remote)
+	}
+	return filepath.Base(t.local)
+}
+
+// runTransfer copies the file of a transfer operation, reporting progress
+// as it goes. Tests replace it.
+var runTransfer = func(ctx context.Context, t fileTransfer, progress nativessh.Progress) error {
+	if t.download {
+		return t.session.Download(ctx, t.remote, t.local, progress)
+	}
+	return t.session.Upload(ctx, t.local, t.remote, progress)
+}
+
+// --- Server transfer model ---
+
+// transferField is the focused field of the transfer view.
+type transferField int
+
+const (
+	transferFieldLocal transferField = iota
+	transferFieldRemote
+	transferFieldCount
+)
+
+// serverTransferModel asks for the local and remote paths of a file to
+// upload to or download from a server.
+type serverTransferModel struct {
+	server       *domain.Server
+	providerName string
+	login        string // "user@host" the copy runs as
+
+	download bool
+	local    components.ValidatedInput
+	remote   components.ValidatedInput
+	focus    transferField
+
+	width  int
+	height int
+}
+
+func newServerTransferModel(server *domain.Server, providerName, login string) serverTransferModel {
+	local := textinput.New()
+	local.CharLimit = 1024
+	local.Width = 40
+
+	remote := textinput.New()
+	remote.CharLimit = 1024
+	remote.Width = 40
+
+	m := serverTransferModel{
+		server:       server,
+		providerName: providerName,
+		login:        login,
+		local:        components.NewValidatedInput("transfer-local", local),
+		remote:       components.NewValidatedInput("transfer-remote", remote),
+	}
+	m = m.withDirection(false)
+	m.local.Focus()
+	return m
+}
+
+// withDirection switches between upload and download, updating the
+// placeholders to match.
+func (m serverTransferModel) withDirection(download bool) serverTransferModel {
+	m.download = download
+	if download {
+		m.local.Input.Placeholder = "current directory"
+		m.remote.Input.Placeholder = "/var/log/syslog"
+	} else {
+		m.local.Input.Placeholder = "~/notes.txt"
+		m.remote.Input.Placeholder = "home directory"
+	}
+	m.local.SetErr("")
+	m.remote.SetErr("")
+	return m
+}
+
+func (m serverTransferModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m serverTransferModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			server := *m.server
+			return m, func() tea.Msg { return navigateToShowMsg{server: server} }
+		case "tab":
+			return m.withDirection(!m.download), nil
+		case "down":
+			return m.setFocus((m.focus + 1) % transferFieldCount)
+		case "up":
+			return m.setFocus((m.focus + transferFieldCount - 1) % transferFieldCount)
+		case "enter":
+			return m.submit()
+		}
+	}
+
+	var cmd tea.Cmd
+	switch m.focus {
+	case transferFieldLocal:
+		m.local, cmd = m.local.Update(msg)
+	case transferFieldRemote:
+		m.remote, cmd = m.remote.Update(msg)
+	}
+	return m, cmd
+}
+
+// setFocus moves the cursor to field.
+func (m serverTransferModel) setFocus(field transferField) (tea.Model, tea.Cmd) {
+	m.focus = field
+	m.local.Input.Blur()
+	m.remote.Input.Blur()
+	if field == transferFieldLocal {
+		return m, m.local.Focus()
+	}
+	return m, m.remote.Focus()
+}
+
+// submit checks both paths and asks the app to start the copy.
+func (m serverTransferModel) submit() (tea.Model, tea.Cmd) {
+	local := expandTilde(m.local.Value())
+	remote := m.remote.Value()
+
+	if err := checkLocalPath(local, m.download); err != nil {
+		m.local.SetErr(err.Error())
+		return m.setFocus(transferFieldLocal)
+	}
+	if m.download && remote == "" {
+		m.remote.SetErr("enter the path of the file to download")
+		return m.setFocus(transferFieldRemote)
+	}
+
+	req := requestTransferMsg{server: *m.server, download: m.download, local: local, remote: remote}
+	return m, func() tea.Msg { return req }
+}
+
+// checkLocalPath reports whether local can be uploaded, or downloaded
+// into: an upload needs an existing file, a download an existing
+// directory (or a new file in one). An empty download path means the
+// current directory.
+func checkLocalPath(local string, download bool) error {
+	if !download {
+		if local == "" {
+			return errors.New("enter the path of the file to upload")
+		}
+		info, err := os.Stat(local)
+		if err != nil {
+			return fmt.Errorf("cannot read %s", local)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a file", local)
+		}
+		return nil
+	}
+
+	if local == "" {
+		return nil
+	}
+	if info, err := os.Stat(local); err == nil && info.IsDir() {
+		return nil
+	}
+	if info, err := os.Stat(filepath.Dir(local)); err != nil || !info.IsDir() {
+		return fmt.Errorf("directory %s does not exist", filepath.Dir(local))
+	}
+	return nil
+}
+
+// expandTilde replaces a leading ~ with the home directory.
+func expandTilde(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
+
+func (m serverTransferModel) View() string {
+	if m.width == 0 || m.height == 0 {
+		return ""
+	}
+
+	header := components.HeaderTrail(m.width, serverTrail(m.server, "transfer"), m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "enter", Desc: "copy"},
+		{Key: "↑/↓", Desc: "field"},
+		{Key: "tab", Desc: "upload/download"},
+		{Key: "esc", Desc: "back"},
+	})
+
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.renderContent(contentH), footer)
+}
+
+func (m serverTransferModel) renderContent(height int) string {
+	cardWidth := 56
+	labelWidth := 10
+
+	renderField := func(label, value string) string {
+		return styles.Label.Width(labelWidth).Render(label) + styles.Value.Render(value)
+	}
+
+	title, from, to := "Upload File", "Local file", "Remote path"
+	localView, remoteView := m.local.View(), m.remote.View()
+	first, second := localView, remoteView
+	if m.download {
+		title, from, to = "Download File", "Remote file", "Local path"
+		first, second = remoteView, localView
+	}
+
+	fields := []string{
+		renderField("Server", m.server.Name),
+		renderField("Login", m.login),
+		"",
+		styles.Subtitle.Render(from),
+		"",
+		first,
+		"",
+		styles.Subtitle.Render(to),
+		"",
+		second,
+		"",
+		styles.MutedText.Render("Copied over SCP; progress shows in the operations panel."),
+	}
+
+	card := styles.Card.Width(cardWidth).Render(strings.Join(fields, "\n"))
+	combined := lipgloss.JoinVertical(lipgloss.Center, styles.Title.Render(title), "", card)
+
+	return lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, combined)
+}
+
+// formatBytes renders a byte count for progress lines, e.g. "1.5 MB".
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1024*1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(n)/(1024*1024*1024))
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/nativessh"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestServerShow_TransferKey(t *testing.T) {
+	server := &domain.Server{ID: "7", Name: "app", Status: "running", PublicIPv4: "203.0.113.7"}
+	m := newServerShowDirect(nil, "hetzner", server, nil)
+
+	_, cmd := m.handleDetailKey(runeKey('F'))
+	if cmd == nil {
+		t.Fatal("expected F to open the transfer view")
+	}
+	if msg, ok := cmd().(navigateToTransferMsg); !ok || msg.server.ID != "7" {
+		t.Errorf("got %+v, want the transfer view for server 7", msg)
+	}
+
+	// u is left to the undo toast.
+	if _, cmd := m.handleDetailKey(runeKey('u')); cmd != nil {
+		t.Error("expected u to do nothing in the detail view")
+	}
+}
+
+func TestServerTransfer_UploadNeedsExistingFile(t *testing.T) {
+	m := newServerTransferModel(&domain.Server{ID: "7", Name: "app"}, "hetzner", "root@203.0.113.7")
+	m.local.Input.SetValue(filepath.Join(t.TempDir(), "missing.txt"))
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverTransferModel)
+	if !strings.Contains(m.local.Err(), "cannot read") {
+		t.Errorf("local error = %q, want cannot read", m.local.Err())
+	}
+}
+
+func TestServerTransfer_SubmitsDownload(t *testing.T) {
+	dir := t.TempDir()
+	m := newServerTransferModel(&domain.Server{ID: "7", Name: "app"}, "hetzner", "root@203.0.113.7")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(serverTransferModel)
+	if !m.download {
+		t.Fatal("expected tab to switch to download")
+	}
+	m.local.Input.SetValue(dir)
+	m.remote.Input.SetValue("/var/log/syslog")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a transfer request")
+	}
+	msg, ok := cmd().(requestTransferMsg)
+	if !ok || !msg.download || msg.local != dir || msg.remote != "/var/log/syslog" {
+		t.Errorf("request = %+v", msg)
+	}
+}
+
+func TestOpsOverlay_TransferReportsProgress(t *testing.T) {
+	release := make(chan error)
+	orig := runTransfer
+	runTransfer = func(_ context.Context, _ fileTransfer, progress nativessh.Progress) error {
+		progress(512, 2048)
+		return <-release
+	}
+	t.Cleanup(func() { runTransfer = orig })
+
+	o := opsOverlay{providerName: "mock"}
+	o, cmd := o.StartTransfer(domain.Server{ID: "7", Name: "app"}, fileTransfer{local: "/tmp/app.tar.gz", remote: "~/"})
+
+	var progress opTransferProgressMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if m, ok := c().(opTransferProgressMsg); ok {
+			progress = m
+		}
+	}
+	o, cmd, _ = o.Update(progress)
+	if got := o.ops[0].statusText; got != `Uploading app.tar.gz to "app" (25%)` {
+		t.Errorf("status = %q", got)
+	}
+	if view := o.View(80, 20); !strings.Contains(view, "512 B/2.0 KB") {
+		t.Errorf("expected byte counts in the overlay:\n%s", view)
+	}
+
+	release <- nil
+	o, _, events := o.Update(cmd())
+	if o.ops[0].status != opStatusSucceeded {
+		t.Errorf("status = %q, want succeeded", o.ops[0].status)
+	}
+	if len(events) != 1 || !events[0].Success || !events[0].Unchanged || events[0].Verb != "app.tar.gz uploaded" {
+		t.Errorf("events = %+v", events)
+	}
+}
+
+func TestOpsOverlay_TransferFailure(t *testing.T) {
+	orig := runTransfer
+	runTransfer = func(context.Context, fileTransfer, nativessh.Progress) error {
+		return errors.New("scp: /srv: Permission denied")
+	}
+	t.Cleanup(func() { runTransfer = orig })
+
+	o := opsOverlay{providerName: "mock"}
+	o, cmd := o.StartTransfer(domain.Server{ID: "7", Name: "app"}, fileTransfer{download: true, local: os.TempDir(), remote: "/srv/db.sql"})
+
+	var result opTransferResultMsg
+	for _, c := range cmd().(tea.BatchMsg) {
+		if m, ok := c().(opTransferResultMsg); ok {
+			result = m
+		}
+	}
+	o, _, events := o.Update(result)
+	if o.ops[0].status != opStatusFailed {
+		t.Errorf("status = %q, want failed", o.ops[0].status)
+	}
+	if len(events) != 1 || !strings.Contains(events[0].ErrText, "Failed to copy db.sql: scp: /srv: Permission denied") {
+		t.Errorf("events = %+v", events)
+	}
+}
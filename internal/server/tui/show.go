@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/huh/spinner"
@@ -19,7 +20,7 @@ var ErrShowAborted = errors.New("server selection aborted by user")
 // to inspect. It fetches the current server list, presents a selection, and
 // returns the chosen server.
 func ShowServerForm(provider domain.Provider) (*domain.Server, error) {
-	accessible := os.Getenv("ACCESSIBLE") != ""
+	accessible := styles.Accessible()
 
 	// Fetch existing servers with a spinner.
 	var servers []domain.Server
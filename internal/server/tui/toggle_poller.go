@@ -385,6 +385,26 @@ func verbToInfinitive(verb string) string {
 		return "start"
 	case "stopped":
 		return "stop"
+	case "deleted":
+		return "delete"
+	case "rebooted":
+		return "reboot"
+	case "resized":
+		return "resize"
+	case "backups enabled":
+		return "enable backups for"
+	case "backups disabled":
+		return "disable backups for"
+	case "restored":
+		return "restore"
+	case "floating IP assigned":
+		return "assign a floating IP to"
+	case "floating IP unassigned":
+		return "unassign a floating IP from"
+	case "reverse DNS updated":
+		return "update reverse DNS of"
+	case "labels updated":
+		return "update labels of"
 	default:
 		return verb
 	}
@@ -398,6 +418,26 @@ func verbToGerund(verb string) string {
 		return "Starting"
 	case "stopped":
 		return "Stopping"
+	case "deleted":
+		return "Deleting"
+	case "rebooted":
+		return "Rebooting"
+	case "resized":
+		return "Resizing"
+	case "backups enabled":
+		return "Enabling backups for"
+	case "backups disabled":
+		return "Disabling backups for"
+	case "restored":
+		return "Restoring"
+	case "floating IP assigned":
+		return "Assigning a floating IP to"
+	case "floating IP unassigned":
+		return "Unassigning a floating IP from"
+	case "reverse DNS updated":
+		return "Updating reverse DNS of"
+	case "labels updated":
+		return "Updating labels of"
 	default:
 		return verb
 	}
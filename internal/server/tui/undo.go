@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// undoWindow is how long the toast offers to undo a change after it
+// completes.
+const undoWindow = 10 * time.Second
+
+// undoFunc applies the inverse of a change through the overlay.
+type undoFunc func(o opsOverlay) (opsOverlay, tea.Cmd)
+
+// undoToast offers to revert the most recent reversible change, such as
+// toggling backups or assigning a floating IP.
+type undoToast struct {
+	id      int
+	summary string // e.g. `"web-1" backups enabled`
+	undo    undoFunc
+}
+
+// undoExpiredMsg hides the toast with the given id once its window has
+// passed. A newer toast is left alone.
+type undoExpiredMsg struct {
+	id int
+}
+
+// offerUndo shows the toast for a change that just succeeded and schedules
+// its expiry.
+func (m serverAppModel) offerUndo(outcome opCompletedEvent) (serverAppModel, tea.Cmd) {
+	m.toastID++
+	id := m.toastID
+	m.toast = &undoToast{
+		id:      id,
+		summary: fmt.Sprintf("%q %s", outcome.ServerName, outcome.Verb),
+		undo:    outcome.Undo,
+	}
+	return m, tea.Tick(undoWindow, func(time.Time) tea.Msg { return undoExpiredMsg{id: id} })
+}
+
+// applyUndo reverts the change the toast offers. The inverse runs as a
+// regular overlay operation and cannot itself be undone.
+func (m serverAppModel) applyUndo() (tea.Model, tea.Cmd) {
+	toast := m.toast
+	m.toast = nil
+	var cmd tea.Cmd
+	m.overlay, cmd = toast.undo(m.overlay)
+	return m, cmd
+}
+
+// canUndo reports whether the toast is showing, so the u key reverts its
+// change. The list and detail views bind u to nothing else and have no
+// text input that u could be meant for; dialogs on top of them hide the
+// toast.
+func (m serverAppModel) canUndo() bool {
+	if m.toast == nil || m.search != nil || m.projects != nil || m.reauth != nil {
+		return false
+	}
+	return m.view == appViewShow || (m.view == appViewList && m.whatsNew == nil)
+}
+
+// undoDivider renders the toast in place of the footer's top border.
+func undoDivider(width int, toast *undoToast) string {
+	return components.UndoDivider(width, toast.summary)
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// completeOp reports operation opID as finished, as its final poll does.
+func completeOp(t *testing.T, m serverAppModel, opID int) serverAppModel {
+	t.Helper()
+	m.overlay.ops[opID].pollMode = opPollModeServer
+	updated, _ := m.Update(opPollResultMsg{opID: opID, action: &domain.ActionStatus{Status: domain.ActionStatusSuccess}})
+	return updated.(serverAppModel)
+}
+
+func newUndoTestApp() serverAppModel {
+	provider := &backupProvider{}
+	m := newReauthTestApp()
+	m.provider = provider
+	m.overlay = opsOverlay{provider: provider, providerName: "mock"}
+	return m
+}
+
+func TestServerApp_UndoToastRevertsBackupsToggle(t *testing.T) {
+	m := newUndoTestApp()
+	server := domain.Server{ID: "7", Name: "app", Status: "running"}
+	m.overlay, _ = m.overlay.StartBackups(server, true)
+	m = completeOp(t, m, 0)
+
+	if m.toast == nil {
+		t.Fatal("expected a toast after enabling backups")
+	}
+	view := ansi.Strip(m.View())
+	if !strings.Contains(view, `"app" backups enabled · u undo`) {
+		t.Errorf("expected the toast in the view, got:\n%s", view)
+	}
+
+	updated, _ := m.Update(runeKey('u'))
+	m = updated.(serverAppModel)
+	if m.toast != nil {
+		t.Error("expected undo to hide the toast")
+	}
+	if len(m.overlay.ops) != 2 || m.overlay.ops[1].verb != "backups disabled" {
+		t.Fatalf("expected undo to disable backups, got %+v", m.overlay.ops)
+	}
+
+	// The inverse cannot be undone in turn.
+	m = completeOp(t, m, 1)
+	if m.toast != nil {
+		t.Error("expected no toast after undoing")
+	}
+}
+
+func TestServerApp_UndoToastExpires(t *testing.T) {
+	m := newUndoTestApp()
+	server := domain.Server{ID: "7", Name: "app", Status: "running"}
+	m.overlay, _ = m.overlay.StartBackups(server, true)
+	m = completeOp(t, m, 0)
+	first := m.toast.id
+
+	m.overlay, _ = m.overlay.StartBackups(server, false)
+	m = completeOp(t, m, 1)
+
+	// The first toast's expiry leaves the newer one alone.
+	updated, _ := m.Update(undoExpiredMsg{id: first})
+	m = updated.(serverAppModel)
+	if m.toast == nil {
+		t.Fatal("expected the newer toast to stay")
+	}
+
+	updated, _ = m.Update(undoExpiredMsg{id: m.toast.id})
+	m = updated.(serverAppModel)
+	if m.toast != nil {
+		t.Error("expected the toast to expire")
+	}
+	if updated, _ = m.Update(runeKey('u')); len(updated.(serverAppModel).overlay.ops) != 2 {
+		t.Error("expected u to do nothing once the toast expired")
+	}
+}
+
+func TestOpsOverlay_FloatingIPUndo(t *testing.T) {
+	server := domain.Server{ID: "7", Name: "app"}
+	tests := []struct {
+		name     string
+		ip       domain.FloatingIP
+		assign   bool
+		undoable bool
+	}{
+		{"assign unassigned", domain.FloatingIP{ID: "1"}, true, true},
+		{"unassign", domain.FloatingIP{ID: "1", ServerID: "7"}, false, true},
+		{"take over from another server", domain.FloatingIP{ID: "1", ServerID: "9"}, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := opsOverlay{provider: &floatingIPProvider{}, providerName: "mock"}
+			o, _ = o.StartFloatingIP(server, tt.ip, tt.assign)
+			if got := o.ops[0].undo != nil; got != tt.undoable {
+				t.Errorf("undoable = %v, want %v", got, tt.undoable)
+			}
+		})
+	}
+}
+
+func TestServerApp_UndoToastOnTransferCapableDetailView(t *testing.T) {
+	m := newUndoTestApp()
+	server := domain.Server{ID: "7", Name: "app", Status: "running", PublicIPv4: "203.0.113.7"}
+	m.overlay, _ = m.overlay.StartBackups(server, true)
+	m = completeOp(t, m, 0)
+
+	// Files can be copied to the server, but not with u.
+	m.view = appViewShow
+	m.show = newServerShowDirect(m.provider, "mock", &server, nil)
+	updated, _ := m.Update(runeKey('u'))
+	m = updated.(serverAppModel)
+	if m.view != appViewShow || len(m.overlay.ops) != 2 || m.overlay.ops[1].verb != "backups disabled" {
+		t.Errorf("expected u to undo, got view %v and ops %+v", m.view, m.overlay.ops)
+	}
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"nathanbeddoewebdev/vpsm/internal/cache"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/services/catalogwatch"
+	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// whatsNewLimit caps how many entries of each kind the notice lists.
+const whatsNewLimit = 8
+
+// checkCatalog compares the provider's catalog with the one seen in the
+// previous session. Tests replace it.
+var checkCatalog = func(ctx context.Context, provider domain.CatalogProvider, providerName string) (domain.CatalogChanges, error) {
+	return catalogwatch.Check(ctx, cache.NewDefault(), provider, providerName)
+}
+
+// catalogChangesMsg carries the differences found by checkCatalog.
+type catalogChangesMsg struct {
+	changes domain.CatalogChanges
+	err     error
+}
+
+// checkCatalogChanges looks for catalog changes in the background.
+// Failures are silent, as for the provider status check.
+func (m serverAppModel) checkCatalogChanges() tea.Cmd {
+	catalog, ok := m.provider.(domain.CatalogProvider)
+	if !ok {
+		return nil
+	}
+	providerName := m.providerName
+	return func() tea.Msg {
+		changes, err := checkCatalog(context.Background(), catalog, providerName)
+		return catalogChangesMsg{changes: changes, err: err}
+	}
+}
+
+// updateWhatsNew dismisses the notice on enter or esc.
+func (m serverAppModel) updateWhatsNew(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "enter", "esc", "q":
+		m.whatsNew = nil
+	}
+	return m, nil
+}
+
+// renderWhatsNew renders the catalog changes notice in place of the list.
+func (m serverAppModel) renderWhatsNew() string {
+	changes := m.whatsNew
+	header := components.Header(m.width, "what's new", m.providerName)
+	footer := components.Footer(m.width, []components.KeyBinding{
+		{Key: "enter", Desc: "dismiss"},
+	})
+
+	f := priceFormatter()
+	lines := []string{styles.Title.Render("What's new at " + m.provider.GetDisplayName())}
+
+	section := func(title string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+		lines = append(lines, "", styles.Label.Render(title))
+		for i, entry := range entries {
+			if i == whatsNewLimit {
+				lines = append(lines, styles.MutedText.Render(fmt.Sprintf("  +%d more", len(entries)-i)))
+				break
+			}
+			lines = append(lines, "  "+entry)
+		}
+	}
+
+	var added []string
+	for _, st := range changes.AddedServerTypes {
+		entry := styles.Value.Render(st.Name)
+		if price := st.MonthlyPrice(); !price.IsZero() {
+			entry += "  " + f.FormatMonthly(price)
+		}
+		added = append(added, entry)
+	}
+	section("New server types", added)
+
+	var prices []string
+	for _, pc := range changes.PriceChanges {
+		prices = append(prices, styles.Value.Render(pc.ServerType)+"  "+f.Format(pc.Old)+" → "+f.FormatMonthly(pc.New))
+	}
+	section("Price changes", prices)
+
+	section("Retired server types", changes.RemovedServerTypes)
+
+	var locations []string
+	for _, loc := range changes.AddedLocations {
+		entry := styles.Value.Render(loc.Name)
+		if place := valueOrID(loc.City, loc.Description); place != "" {
+			entry += "  " + place
+		}
+		locations = append(locations, entry)
+	}
+	section("New locations", locations)
+
+	section("Removed locations", changes.RemovedLocations)
+
+	body := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	contentH := m.height - lipgloss.Height(header) - lipgloss.Height(footer)
+	if contentH < 1 {
+		contentH = 1
+	}
+	content := lipgloss.Place(m.width, contentH, lipgloss.Center, lipgloss.Center, body)
+	return lipgloss.JoinVertical(lipgloss.Left, header, content, footer)
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestServerApp_WhatsNewShownAndDismissed(t *testing.T) {
+	m := newReauthTestApp()
+
+	changes := domain.CatalogChanges{
+		AddedServerTypes: []domain.ServerTypeSpec{{Name: "cax11"}},
+		PriceChanges:     []domain.PriceChange{{ServerType: "cpx11"}},
+		AddedLocations:   []domain.Location{{Name: "sin", City: "Singapore"}},
+	}
+	updated, _ := m.Update(catalogChangesMsg{changes: changes})
+	m = updated.(serverAppModel)
+	if m.whatsNew == nil {
+		t.Fatal("expected catalog changes to open the notice")
+	}
+
+	view := m.View()
+	for _, want := range []string{"What's new at Old", "New server types", "cax11", "Price changes", "cpx11", "New locations", "Singapore"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in notice, got:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(serverAppModel)
+	if m.whatsNew != nil {
+		t.Error("expected enter to dismiss the notice")
+	}
+}
+
+func TestServerApp_WhatsNewIgnoresEmptyChanges(t *testing.T) {
+	m := newReauthTestApp()
+
+	updated, _ := m.Update(catalogChangesMsg{})
+	if updated.(serverAppModel).whatsNew != nil {
+		t.Error("expected no notice without changes")
+	}
+}
+
+func TestCheckCatalogChanges_SkipsProvidersWithoutCatalog(t *testing.T) {
+	orig := checkCatalog
+	t.Cleanup(func() { checkCatalog = orig })
+	checkCatalog = func(context.Context, domain.CatalogProvider, string) (domain.CatalogChanges, error) {
+		t.Fatal("unexpected catalog check")
+		return domain.CatalogChanges{}, nil
+	}
+
+	if cmd := newReauthTestApp().checkCatalogChanges(); cmd != nil {
+		t.Error("expected no command for a provider without a catalog")
+	}
+}
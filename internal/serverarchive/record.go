@@ -0,0 +1,42 @@
+package serverarchive
+
+import "time"
+
+// Config is a server's configuration as vpsm last knew it: what the
+// provider reports about the server, plus the options vpsm created it
+// with that the provider does not report back (user data, SSH keys).
+type Config struct {
+	ID         int64
+	Provider   string
+	ServerID   string
+	Name       string
+	Location   string
+	ServerType string
+	Image      string
+	Labels     map[string]string
+	UserData   string
+	SSHKeys    []string
+	Networks   []string
+	IPv4       bool
+	IPv6       bool
+	// DNSRecords are the records that pointed at the server's public
+	// addresses when it was deleted.
+	DNSRecords []DNSRecord
+	// ArchivedAt is when the server was deleted, or zero while it exists.
+	ArchivedAt time.Time
+	UpdatedAt  time.Time
+}
+
+// Archived reports whether the server has been deleted.
+func (c Config) Archived() bool {
+	return !c.ArchivedAt.IsZero()
+}
+
+// DNSRecord is an A or AAAA record that pointed a name at a server.
+type DNSRecord struct {
+	Zone  string `json:"zone"`
+	Name  string `json:"name"` // relative to the zone, "@" for the apex
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	TTL   int    `json:"ttl,omitempty"`
+}
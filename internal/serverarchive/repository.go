@@ -0,0 +1,267 @@
+// Package serverarchive keeps the configuration of servers so that an
+// equivalent server can be created after the original is deleted.
+//
+// A configuration is stored when vpsm creates a server (capturing the
+// user data and SSH keys the provider does not report back) and updated
+// from the server's final state when it is deleted, at which point it
+// becomes an archive entry.
+//
+// Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
+// (shared with actionstore and iphistory, separate table).
+package serverarchive
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for server configurations.
+type Repository interface {
+	// Save inserts or replaces the configuration of a server, keyed by
+	// provider and server ID. On insert, an ID is assigned to cfg.
+	Save(cfg *Config) error
+
+	// Get returns the configuration stored for a server, or nil if there
+	// is none.
+	Get(provider, serverID string) (*Config, error)
+
+	// ListArchived returns the configurations of deleted servers, most
+	// recently deleted first.
+	ListArchived() ([]Config, error)
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("serverarchive: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("serverarchive: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("serverarchive: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// migrations are the schema versions of the server_configs table, oldest
+// first. Add changes as new migrations; never edit one that has shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createConfigsTable},
+	{Version: 2, Up: addDNSRecords},
+}
+
+// migrate brings the server_configs table up to the latest schema.
+func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "serverarchive", migrations)
+}
+
+// createConfigsTable creates the server_configs table, which databases
+// from before schema versioning already hold.
+func createConfigsTable(tx *sql.Tx) error {
+	const ddl = `
+		CREATE TABLE IF NOT EXISTS server_configs (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider    TEXT NOT NULL,
+			server_id   TEXT NOT NULL,
+			name        TEXT NOT NULL DEFAULT '',
+			location    TEXT NOT NULL DEFAULT '',
+			server_type TEXT NOT NULL DEFAULT '',
+			image       TEXT NOT NULL DEFAULT '',
+			labels      TEXT NOT NULL DEFAULT '{}',
+			user_data   TEXT NOT NULL DEFAULT '',
+			ssh_keys    TEXT NOT NULL DEFAULT '[]',
+			networks    TEXT NOT NULL DEFAULT '[]',
+			ipv4        INTEGER NOT NULL DEFAULT 1,
+			ipv6        INTEGER NOT NULL DEFAULT 1,
+			archived_at TEXT NOT NULL DEFAULT '',
+			updated_at  TEXT NOT NULL,
+			UNIQUE(provider, server_id)
+		);
+	`
+	_, err := tx.Exec(ddl)
+	return err
+}
+
+// addDNSRecords adds the DNS records that pointed at a deleted server.
+func addDNSRecords(tx *sql.Tx) error {
+	_, err := tx.Exec(`ALTER TABLE server_configs ADD COLUMN dns_records TEXT NOT NULL DEFAULT '[]'`)
+	return err
+}
+
+// Save upserts cfg by provider and server ID.
+func (r *SQLiteRepository) Save(cfg *Config) error {
+	cfg.UpdatedAt = time.Now().UTC()
+
+	labels, err := json.Marshal(cfg.Labels)
+	if err != nil {
+		return fmt.Errorf("serverarchive: failed to encode labels: %w", err)
+	}
+	sshKeys, err := json.Marshal(nonNil(cfg.SSHKeys))
+	if err != nil {
+		return fmt.Errorf("serverarchive: failed to encode SSH keys: %w", err)
+	}
+	networks, err := json.Marshal(nonNil(cfg.Networks))
+	if err != nil {
+		return fmt.Errorf("serverarchive: failed to encode networks: %w", err)
+	}
+	dnsRecords := cfg.DNSRecords
+	if dnsRecords == nil {
+		dnsRecords = []DNSRecord{}
+	}
+	dns, err := json.Marshal(dnsRecords)
+	if err != nil {
+		return fmt.Errorf("serverarchive: failed to encode DNS records: %w", err)
+	}
+	archived := ""
+	if cfg.Archived() {
+		archived = cfg.ArchivedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	err = r.db.QueryRow(`
+		INSERT INTO server_configs (provider, server_id, name, location, server_type, image,
+		                            labels, user_data, ssh_keys, networks, ipv4, ipv6, dns_records, archived_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(provider, server_id) DO UPDATE SET
+			name = excluded.name, location = excluded.location,
+			server_type = excluded.server_type, image = excluded.image,
+			labels = excluded.labels, user_data = excluded.user_data,
+			ssh_keys = excluded.ssh_keys, networks = excluded.networks,
+			ipv4 = excluded.ipv4, ipv6 = excluded.ipv6,
+			dns_records = excluded.dns_records,
+			archived_at = excluded.archived_at, updated_at = excluded.updated_at
+		RETURNING id`,
+		cfg.Provider, cfg.ServerID, cfg.Name, cfg.Location, cfg.ServerType, cfg.Image,
+		string(labels), cfg.UserData, string(sshKeys), string(networks), cfg.IPv4, cfg.IPv6,
+		string(dns), archived, cfg.UpdatedAt.Format(time.RFC3339Nano),
+	).Scan(&cfg.ID)
+	if err != nil {
+		return fmt.Errorf("serverarchive: upsert failed: %w", err)
+	}
+	return nil
+}
+
+const selectColumns = `
+	SELECT id, provider, server_id, name, location, server_type, image,
+	       labels, user_data, ssh_keys, networks, ipv4, ipv6, dns_records, archived_at, updated_at
+	FROM server_configs`
+
+// Get returns the configuration stored for a server, or nil if none.
+func (r *SQLiteRepository) Get(provider, serverID string) (*Config, error) {
+	rows, err := r.db.Query(selectColumns+` WHERE provider = ? AND server_id = ?`, provider, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("serverarchive: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	configs, err := scanConfigs(rows)
+	if err != nil || len(configs) == 0 {
+		return nil, err
+	}
+	return &configs[0], nil
+}
+
+// ListArchived returns deleted servers' configurations, newest first.
+func (r *SQLiteRepository) ListArchived() ([]Config, error) {
+	rows, err := r.db.Query(selectColumns + ` WHERE archived_at != '' ORDER BY archived_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("serverarchive: query failed: %w", err)
+	}
+	defer rows.Close()
+	return scanConfigs(rows)
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+func scanConfigs(rows *sql.Rows) ([]Config, error) {
+	var configs []Config
+	for rows.Next() {
+		var cfg Config
+		var labels, sshKeys, networks, dns, archivedStr, updatedStr string
+		err := rows.Scan(&cfg.ID, &cfg.Provider, &cfg.ServerID, &cfg.Name, &cfg.Location, &cfg.ServerType, &cfg.Image,
+			&labels, &cfg.UserData, &sshKeys, &networks, &cfg.IPv4, &cfg.IPv6, &dns, &archivedStr, &updatedStr)
+		if err != nil {
+			return nil, fmt.Errorf("serverarchive: scan failed: %w", err)
+		}
+		_ = json.Unmarshal([]byte(labels), &cfg.Labels)
+		_ = json.Unmarshal([]byte(sshKeys), &cfg.SSHKeys)
+		_ = json.Unmarshal([]byte(networks), &cfg.Networks)
+		_ = json.Unmarshal([]byte(dns), &cfg.DNSRecords)
+		if archivedStr != "" {
+			cfg.ArchivedAt, _ = time.Parse(time.RFC3339Nano, archivedStr)
+		}
+		cfg.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedStr)
+		configs = append(configs, cfg)
+	}
+	return configs, rows.Err()
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,130 @@
+package serverarchive
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	r, err := OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestSaveAndGet(t *testing.T) {
+	r := tempRepo(t)
+
+	cfg := &Config{
+		Provider:   "hetzner",
+		ServerID:   "42",
+		Name:       "web-1",
+		Location:   "fsn1",
+		ServerType: "cpx11",
+		Image:      "ubuntu-24.04",
+		Labels:     map[string]string{"env": "prod"},
+		UserData:   "#cloud-config\n",
+		SSHKeys:    []string{"deploy"},
+		IPv4:       true,
+		IPv6:       true,
+		DNSRecords: []DNSRecord{{Zone: "example.com", Name: "www", Type: "A", Value: "203.0.113.7", TTL: 300}},
+	}
+	if err := r.Save(cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if cfg.ID == 0 {
+		t.Fatal("expected an ID to be assigned")
+	}
+
+	got, err := r.Get("hetzner", "42")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if diff := cmp.Diff(cfg, got, cmpopts.EquateApproxTime(time.Millisecond), cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("config mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, err := r.Get("hetzner", "43"); err != nil || got != nil {
+		t.Errorf("expected nil for an unknown server, got %+v (err %v)", got, err)
+	}
+}
+
+func TestSave_UpsertsAndListsArchived(t *testing.T) {
+	r := tempRepo(t)
+
+	first := &Config{Provider: "hetzner", ServerID: "1", Name: "web-1"}
+	second := &Config{Provider: "hetzner", ServerID: "2", Name: "web-2"}
+	for _, cfg := range []*Config{first, second} {
+		if err := r.Save(cfg); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if archived, _ := r.ListArchived(); len(archived) != 0 {
+		t.Fatalf("expected no archived configs yet, got %+v", archived)
+	}
+
+	id := first.ID
+	first.ArchivedAt = time.Now().Add(-time.Hour)
+	second.ArchivedAt = time.Now()
+	for _, cfg := range []*Config{first, second} {
+		if err := r.Save(cfg); err != nil {
+			t.Fatalf("Save failed: %v", err)
+		}
+	}
+	if first.ID != id {
+		t.Errorf("expected the upsert to keep ID %d, got %d", id, first.ID)
+	}
+
+	archived, err := r.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived failed: %v", err)
+	}
+	if len(archived) != 2 || archived[0].Name != "web-2" || archived[1].Name != "web-1" {
+		t.Errorf("expected web-2 then web-1, got %+v", archived)
+	}
+}
+
+func TestOpenAt_UpgradesUnversionedTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := createConfigsTable(tx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Exec(`INSERT INTO server_configs (provider, server_id, name, archived_at, updated_at)
+		VALUES ('hetzner', '42', 'web-1', '2026-01-02T03:04:05Z', '2026-01-02T03:04:05Z')`); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	defer r.Close()
+
+	got, err := r.Get("hetzner", "42")
+	if err != nil || got == nil {
+		t.Fatalf("Get() = %+v, %v", got, err)
+	}
+	if got.Name != "web-1" || len(got.DNSRecords) != 0 {
+		t.Errorf("unexpected config after upgrade: %+v", got)
+	}
+}
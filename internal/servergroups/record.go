@@ -0,0 +1,16 @@
+package servergroups
+
+import "time"
+
+// Group is a named set of servers. Membership is defined either by an
+// explicit list of server IDs, a label selector, or both (union).
+type Group struct {
+	ID        int64
+	Provider  string
+	Name      string
+	Selector  string   // label selector expression, e.g. "env=prod,role=web"
+	ServerIDs []string // explicit members
+	Bastion   string   // jump host for SSH to members, "" for none
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,252 @@
+// Package servergroups provides persistent storage for user-defined server
+// groups.
+//
+// Groups are keyed by (provider, name). Storage is backed by a SQLite
+// database at ~/.config/vpsm/vpsm.db (shared with actionstore and
+// serverprefs, separate table).
+package servergroups
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for server groups.
+type Repository interface {
+	// Get returns the group with the given name, or nil if not found.
+	Get(provider, name string) (*Group, error)
+
+	// List returns all groups for a provider, ordered by name.
+	List(provider string) ([]Group, error)
+
+	// Save upserts a group.
+	Save(group *Group) error
+
+	// Delete removes a group. It returns false if the group did not exist.
+	Delete(provider, name string) (bool, error)
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("servergroups: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("servergroups: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("servergroups: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// migrations are the schema versions of the server_groups table, oldest
+// first. Add changes as new migrations; never edit one that has shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createGroupsTable},
+}
+
+// migrate brings the server_groups table up to the latest schema.
+func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "servergroups", migrations)
+}
+
+// createGroupsTable creates the server_groups table. Databases from
+// before schema versioning may hold an older table, so it adds any
+// missing columns too.
+func createGroupsTable(tx *sql.Tx) error {
+	const ddl = `
+		CREATE TABLE IF NOT EXISTS server_groups (
+			id         INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider   TEXT NOT NULL,
+			name       TEXT NOT NULL,
+			selector   TEXT NOT NULL DEFAULT '',
+			server_ids TEXT NOT NULL DEFAULT '',
+			bastion    TEXT NOT NULL DEFAULT '',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL,
+			UNIQUE(provider, name)
+		);
+	`
+	if _, err := tx.Exec(ddl); err != nil {
+		return err
+	}
+
+	for _, column := range []string{
+		"bastion TEXT NOT NULL DEFAULT ''",
+	} {
+		_, err := tx.Exec(`ALTER TABLE server_groups ADD COLUMN ` + column)
+		if err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns the group with the given name, or nil if not found.
+func (r *SQLiteRepository) Get(provider, name string) (*Group, error) {
+	row := r.db.QueryRow(`
+		SELECT id, provider, name, selector, server_ids, bastion, created_at, updated_at
+		FROM server_groups WHERE provider = ? AND name = ?`,
+		provider, name)
+
+	g, err := scanGroup(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("servergroups: query failed: %w", err)
+	}
+	return g, nil
+}
+
+// List returns all groups for a provider, ordered by name.
+func (r *SQLiteRepository) List(provider string) ([]Group, error) {
+	rows, err := r.db.Query(`
+		SELECT id, provider, name, selector, server_ids, bastion, created_at, updated_at
+		FROM server_groups WHERE provider = ? ORDER BY name`,
+		provider)
+	if err != nil {
+		return nil, fmt.Errorf("servergroups: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []Group
+	for rows.Next() {
+		g, err := scanGroup(rows)
+		if err != nil {
+			return nil, fmt.Errorf("servergroups: scan failed: %w", err)
+		}
+		groups = append(groups, *g)
+	}
+	return groups, rows.Err()
+}
+
+// Save upserts a group.
+func (r *SQLiteRepository) Save(group *Group) error {
+	now := time.Now().UTC()
+	if group.CreatedAt.IsZero() {
+		group.CreatedAt = now
+	}
+	group.UpdatedAt = now
+
+	result, err := r.db.Exec(`
+		INSERT INTO server_groups (provider, name, selector, server_ids, bastion, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(provider, name) DO UPDATE SET
+			selector = excluded.selector,
+			server_ids = excluded.server_ids,
+			bastion = excluded.bastion,
+			updated_at = excluded.updated_at`,
+		group.Provider, group.Name, group.Selector, strings.Join(group.ServerIDs, ","), group.Bastion,
+		group.CreatedAt.Format(time.RFC3339Nano), group.UpdatedAt.Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("servergroups: upsert failed: %w", err)
+	}
+
+	if group.ID == 0 {
+		id, err := result.LastInsertId()
+		if err == nil {
+			group.ID = id
+		}
+	}
+	return nil
+}
+
+// Delete removes a group. It returns false if the group did not exist.
+func (r *SQLiteRepository) Delete(provider, name string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM server_groups WHERE provider = ? AND name = ?`, provider, name)
+	if err != nil {
+		return false, fmt.Errorf("servergroups: delete failed: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("servergroups: delete failed: %w", err)
+	}
+	return n > 0, nil
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanGroup(row rowScanner) (*Group, error) {
+	var g Group
+	var idsStr, createdStr, updatedStr string
+	if err := row.Scan(&g.ID, &g.Provider, &g.Name, &g.Selector, &idsStr, &g.Bastion, &createdStr, &updatedStr); err != nil {
+		return nil, err
+	}
+	if idsStr != "" {
+		g.ServerIDs = strings.Split(idsStr, ",")
+	}
+	g.CreatedAt, _ = time.Parse(time.RFC3339Nano, createdStr)
+	g.UpdatedAt, _ = time.Parse(time.RFC3339Nano, updatedStr)
+	return &g, nil
+}
@@ -0,0 +1,149 @@
+package servergroups
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestGet_NotFound(t *testing.T) {
+	r := tempRepo(t)
+
+	got, err := r.Get("hetzner", "web")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil for non-existent group, got %+v", got)
+	}
+}
+
+func TestSave_InsertAndGet(t *testing.T) {
+	r := tempRepo(t)
+
+	g := &Group{Provider: "hetzner", Name: "web", Selector: "role=web", ServerIDs: []string{"1", "2"}}
+	if err := r.Save(g); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if g.ID == 0 {
+		t.Error("expected ID to be assigned after insert")
+	}
+
+	got, err := r.Get("hetzner", "web")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected group, got nil")
+	}
+	if got.Selector != "role=web" {
+		t.Errorf("Selector = %q, want %q", got.Selector, "role=web")
+	}
+	if diff := cmp.Diff([]string{"1", "2"}, got.ServerIDs); diff != "" {
+		t.Errorf("ServerIDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSave_Upsert(t *testing.T) {
+	r := tempRepo(t)
+
+	r.Save(&Group{Provider: "hetzner", Name: "web", ServerIDs: []string{"1"}})
+	r.Save(&Group{Provider: "hetzner", Name: "web", Selector: "env=prod"})
+
+	groups, err := r.List("hetzner")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group after upsert, got %d", len(groups))
+	}
+	if groups[0].Selector != "env=prod" || len(groups[0].ServerIDs) != 0 {
+		t.Errorf("unexpected group after upsert: %+v", groups[0])
+	}
+}
+
+func TestList_ScopedByProviderAndSorted(t *testing.T) {
+	r := tempRepo(t)
+
+	r.Save(&Group{Provider: "hetzner", Name: "web"})
+	r.Save(&Group{Provider: "hetzner", Name: "db"})
+	r.Save(&Group{Provider: "other", Name: "cache"})
+
+	groups, err := r.List("hetzner")
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(groups) != 2 || groups[0].Name != "db" || groups[1].Name != "web" {
+		t.Errorf("unexpected groups: %+v", groups)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	r := tempRepo(t)
+
+	r.Save(&Group{Provider: "hetzner", Name: "web"})
+
+	deleted, err := r.Delete("hetzner", "web")
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if !deleted {
+		t.Error("expected Delete to report true for existing group")
+	}
+
+	deleted, err = r.Delete("hetzner", "web")
+	if err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if deleted {
+		t.Error("expected Delete to report false for missing group")
+	}
+}
+
+func TestSave_Bastion(t *testing.T) {
+	r := tempRepo(t)
+
+	if err := r.Save(&Group{Provider: "hetzner", Name: "private", Selector: "net=private", Bastion: "jump@203.0.113.10"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.Get("hetzner", "private")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Bastion != "jump@203.0.113.10" {
+		t.Errorf("Bastion = %q, want %q", got.Bastion, "jump@203.0.113.10")
+	}
+}
+
+func TestOpenAt_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	_, err = r.db.Exec(`UPDATE schema_versions SET version = version + 1 WHERE store = 'servergroups'`)
+	r.Close()
+	if err != nil {
+		t.Fatalf("failed to bump version: %v", err)
+	}
+
+	if _, err := OpenAt(path); !errors.Is(err, schema.ErrTooNew) {
+		t.Errorf("err = %v, want ErrTooNew", err)
+	}
+}
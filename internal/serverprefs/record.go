@@ -4,9 +4,27 @@ import "time"
 
 // ServerPrefs holds per-server user preferences.
 type ServerPrefs struct {
-	ID        int64
-	Provider  string
-	ServerID  string
-	SSHUser   string
+	ID       int64
+	Provider string
+	ServerID string
+	SSHUser  string
+	// Bastion is the jump host ("[user@]host[:port]") used to reach the
+	// server over SSH, or "" to connect directly.
+	Bastion string
+	// PersistentSession runs SSH logins inside a remote tmux session so a
+	// dropped connection can be resumed.
+	PersistentSession bool
+	// SSHOptions are extra ssh arguments for interactive logins (e.g.
+	// "-o ForwardAgent=yes -i ~/.ssh/deploy"), or "" for none.
+	SSHOptions string
+	// SSHIdentity is the private key file passed to ssh with -i, or ""
+	// to let ssh and the agent pick a key.
+	SSHIdentity string
+	// SSHPort is the port the server's SSH daemon listens on, or 0 for
+	// the default.
+	SSHPort   int
 	UpdatedAt time.Time
 }
+
+// AllServers is the ServerID under which provider-wide defaults are stored.
+const AllServers = "*"
@@ -1,7 +1,8 @@
 // Package serverprefs provides persistent storage for per-server user preferences.
 //
-// Preferences such as SSH usernames are stored keyed by (provider, server_id)
-// so that different servers can have different defaults.
+// Preferences such as SSH usernames and bastion hosts are stored keyed by
+// (provider, server_id) so that different servers can have different
+// defaults. Provider-wide defaults use the AllServers server ID.
 //
 // Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
 // (shared with actionstore, separate table).
@@ -12,8 +13,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -92,20 +96,51 @@ func OpenAt(path string) (*SQLiteRepository, error) {
 	return r, nil
 }
 
-// migrate creates the server_prefs table if it doesn't exist.
+// migrations are the schema versions of the server_prefs table, oldest
+// first. Add changes as new migrations; never edit one that has shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createPrefsTable},
+}
+
+// migrate brings the server_prefs table up to the latest schema.
 func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "serverprefs", migrations)
+}
+
+// createPrefsTable creates the server_prefs table. Databases from before
+// schema versioning may hold an older table, so it adds any missing
+// columns too.
+func createPrefsTable(tx *sql.Tx) error {
 	const ddl = `
 		CREATE TABLE IF NOT EXISTS server_prefs (
 			id         INTEGER PRIMARY KEY AUTOINCREMENT,
 			provider   TEXT NOT NULL,
 			server_id  TEXT NOT NULL,
 			ssh_user   TEXT NOT NULL DEFAULT '',
+			bastion    TEXT NOT NULL DEFAULT '',
+			persistent_session INTEGER NOT NULL DEFAULT 0,
+			ssh_options TEXT NOT NULL DEFAULT '',
+			ssh_identity TEXT NOT NULL DEFAULT '',
+			ssh_port   INTEGER NOT NULL DEFAULT 0,
 			updated_at TEXT NOT NULL DEFAULT (datetime('now')),
 			UNIQUE(provider, server_id)
 		);
 	`
-	if _, err := r.db.Exec(ddl); err != nil {
-		return fmt.Errorf("serverprefs: migration failed: %w", err)
+	if _, err := tx.Exec(ddl); err != nil {
+		return err
+	}
+
+	for _, column := range []string{
+		"bastion TEXT NOT NULL DEFAULT ''",
+		"persistent_session INTEGER NOT NULL DEFAULT 0",
+		"ssh_options TEXT NOT NULL DEFAULT ''",
+		"ssh_identity TEXT NOT NULL DEFAULT ''",
+		"ssh_port INTEGER NOT NULL DEFAULT 0",
+	} {
+		_, err := tx.Exec(`ALTER TABLE server_prefs ADD COLUMN ` + column)
+		if err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
 	}
 	return nil
 }
@@ -113,13 +148,13 @@ func (r *SQLiteRepository) migrate() error {
 // Get returns preferences for a (provider, serverID) pair, or nil if not found.
 func (r *SQLiteRepository) Get(provider, serverID string) (*ServerPrefs, error) {
 	row := r.db.QueryRow(`
-		SELECT id, provider, server_id, ssh_user, updated_at
+		SELECT id, provider, server_id, ssh_user, bastion, persistent_session, ssh_options, ssh_identity, ssh_port, updated_at
 		FROM server_prefs WHERE provider = ? AND server_id = ?`,
 		provider, serverID)
 
 	var prefs ServerPrefs
 	var updatedStr string
-	err := row.Scan(&prefs.ID, &prefs.Provider, &prefs.ServerID, &prefs.SSHUser, &updatedStr)
+	err := row.Scan(&prefs.ID, &prefs.Provider, &prefs.ServerID, &prefs.SSHUser, &prefs.Bastion, &prefs.PersistentSession, &prefs.SSHOptions, &prefs.SSHIdentity, &prefs.SSHPort, &updatedStr)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -135,12 +170,17 @@ func (r *SQLiteRepository) Save(prefs *ServerPrefs) error {
 	prefs.UpdatedAt = time.Now().UTC()
 
 	result, err := r.db.Exec(`
-		INSERT INTO server_prefs (provider, server_id, ssh_user, updated_at)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO server_prefs (provider, server_id, ssh_user, bastion, persistent_session, ssh_options, ssh_identity, ssh_port, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(provider, server_id) DO UPDATE SET
 			ssh_user = excluded.ssh_user,
+			bastion = excluded.bastion,
+			persistent_session = excluded.persistent_session,
+			ssh_options = excluded.ssh_options,
+			ssh_identity = excluded.ssh_identity,
+			ssh_port = excluded.ssh_port,
 			updated_at = excluded.updated_at`,
-		prefs.Provider, prefs.ServerID, prefs.SSHUser, prefs.UpdatedAt.Format(time.RFC3339Nano),
+		prefs.Provider, prefs.ServerID, prefs.SSHUser, prefs.Bastion, prefs.PersistentSession, prefs.SSHOptions, prefs.SSHIdentity, prefs.SSHPort, prefs.UpdatedAt.Format(time.RFC3339Nano),
 	)
 	if err != nil {
 		return fmt.Errorf("serverprefs: upsert failed: %w", err)
@@ -1,9 +1,12 @@
 package serverprefs
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
 )
 
 func tempRepo(t *testing.T) *SQLiteRepository {
@@ -186,3 +189,112 @@ func TestSQLiteRepository_CreatesDirectory(t *testing.T) {
 		t.Errorf("expected file to exist at %s, got error: %v", path, err)
 	}
 }
+
+func TestSave_Bastion(t *testing.T) {
+	r := tempRepo(t)
+
+	if err := r.Save(&ServerPrefs{Provider: "hetzner", ServerID: AllServers, Bastion: "jump@203.0.113.10"}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.Get("hetzner", AllServers)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil || got.Bastion != "jump@203.0.113.10" {
+		t.Errorf("expected bastion to round-trip, got %+v", got)
+	}
+}
+
+func TestOpenAt_AddsBastionColumnToExistingTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r1, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	// Simulate a database created before the bastion column existed,
+	// which predates schema versioning too.
+	if _, err := r1.db.Exec(`ALTER TABLE server_prefs DROP COLUMN bastion`); err != nil {
+		t.Fatalf("drop column failed: %v", err)
+	}
+	if _, err := r1.db.Exec(`DELETE FROM schema_versions`); err != nil {
+		t.Fatalf("clearing schema versions failed: %v", err)
+	}
+	r1.Close()
+
+	r2, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+	defer r2.Close()
+
+	if err := r2.Save(&ServerPrefs{Provider: "hetzner", ServerID: "1", Bastion: "jump"}); err != nil {
+		t.Fatalf("Save after migration failed: %v", err)
+	}
+}
+
+func TestSave_PersistentSession(t *testing.T) {
+	r := tempRepo(t)
+
+	if err := r.Save(&ServerPrefs{Provider: "hetzner", ServerID: "1", PersistentSession: true}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.Get("hetzner", "1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil || !got.PersistentSession {
+		t.Errorf("expected PersistentSession to round-trip, got %+v", got)
+	}
+}
+
+func TestSave_SSHOptions(t *testing.T) {
+	r := tempRepo(t)
+
+	opts := `-o ForwardAgent=yes -i "~/my keys/id"`
+	if err := r.Save(&ServerPrefs{Provider: "hetzner", ServerID: "1", SSHOptions: opts}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.Get("hetzner", "1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil || got.SSHOptions != opts {
+		t.Errorf("expected SSHOptions to round-trip, got %+v", got)
+	}
+}
+
+func TestSave_SSHIdentityAndPort(t *testing.T) {
+	r := tempRepo(t)
+
+	if err := r.Save(&ServerPrefs{Provider: "hetzner", ServerID: "1", SSHIdentity: "/home/me/.ssh/deploy", SSHPort: 2222}); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	got, err := r.Get("hetzner", "1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got == nil || got.SSHIdentity != "/home/me/.ssh/deploy" || got.SSHPort != 2222 {
+		t.Errorf("expected SSHIdentity and SSHPort to round-trip, got %+v", got)
+	}
+}
+
+func TestOpenAt_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	_, err = r.db.Exec(`UPDATE schema_versions SET version = version + 1 WHERE store = 'serverprefs'`)
+	r.Close()
+	if err != nil {
+		t.Fatalf("failed to bump version: %v", err)
+	}
+
+	if _, err := OpenAt(path); !errors.Is(err, schema.ErrTooNew) {
+		t.Errorf("err = %v, want ErrTooNew", err)
+	}
+}
@@ -16,9 +16,10 @@ type Store interface {
 	DeleteToken(provider string) error
 }
 
-// DefaultStore returns the standard auth store backed by the OS keychain.
+// DefaultStore returns the standard auth store backed by the OS keychain,
+// scoped to the project selected with UseProject.
 func DefaultStore() Store {
-	return NewKeyringStore(ServiceName)
+	return WithProject(NewKeyringStore(ServiceName), Project())
 }
 
 // NormalizeProvider normalizes a provider name for consistent key lookup.
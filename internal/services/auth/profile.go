@@ -0,0 +1,16 @@
+package auth
+
+import "strings"
+
+// A profile names one of a provider's tokens as "<provider>:<profile>",
+// e.g. "hetzner:work" and "hetzner:personal" for two accounts. Profiles
+// are stored like projects: "hetzner:work" is the "work" project's token
+// (see ProjectKey).
+
+// ParseProfile splits a profile name such as "hetzner:work" into its
+// provider and profile. A name without a colon is a provider and has no
+// profile.
+func ParseProfile(name string) (provider, profile string) {
+	provider, profile, _ = strings.Cut(name, ":")
+	return NormalizeProvider(provider), NormalizeProvider(profile)
+}
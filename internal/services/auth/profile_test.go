@@ -0,0 +1,30 @@
+package auth
+
+import "testing"
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name, provider, profile string
+	}{
+		{"hetzner:work", "hetzner", "work"},
+		{" Hetzner:Personal ", "hetzner", "personal"},
+		{"desec", "desec", ""},
+		{":work", "", "work"},
+	}
+	for _, tt := range tests {
+		provider, profile := ParseProfile(tt.name)
+		if provider != tt.provider || profile != tt.profile {
+			t.Errorf("ParseProfile(%q) = %q, %q; want %q, %q", tt.name, provider, profile, tt.provider, tt.profile)
+		}
+	}
+}
+
+func TestWithProject_RescopesProjectStore(t *testing.T) {
+	store := NewMockStore()
+	store.SetToken("hetzner/work", "work-token")
+
+	rescoped := WithProject(WithProject(store, "personal"), "work")
+	if got, _ := rescoped.GetToken("hetzner"); got != "work-token" {
+		t.Errorf("token = %q, want the work profile's token", got)
+	}
+}
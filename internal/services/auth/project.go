@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Tokens are scoped to a provider project (Hetzner tokens, for example,
+// belong to one project). A project's token is stored under ProjectKey;
+// the provider's own key holds the token used when no project is selected.
+
+var (
+	projectMu sync.RWMutex
+	project   string
+)
+
+// UseProject selects the project whose tokens DefaultStore returns for
+// the rest of the process. An empty project selects the unscoped tokens.
+func UseProject(name string) {
+	projectMu.Lock()
+	defer projectMu.Unlock()
+	project = NormalizeProvider(name)
+}
+
+// Project returns the project selected with UseProject.
+func Project() string {
+	projectMu.RLock()
+	defer projectMu.RUnlock()
+	return project
+}
+
+// ProjectKey returns the key a project's token is stored under.
+func ProjectKey(provider, project string) string {
+	provider = NormalizeProvider(provider)
+	if project == "" {
+		return provider
+	}
+	return provider + "/" + NormalizeProvider(project)
+}
+
+// WithProject returns a store that reads and writes project's tokens in
+// store. An empty project returns store unchanged. A store already scoped
+// to a project is rescoped to project rather than nested.
+func WithProject(store Store, project string) Store {
+	if project == "" {
+		return store
+	}
+	if scoped, ok := store.(projectStore); ok {
+		store = scoped.store
+	}
+	return projectStore{store: store, project: NormalizeProvider(project)}
+}
+
+type projectStore struct {
+	store   Store
+	project string
+}
+
+func (p projectStore) SetToken(provider string, token string) error {
+	return p.store.SetToken(ProjectKey(provider, p.project), token)
+}
+
+func (p projectStore) GetToken(provider string) (string, error) {
+	token, err := p.store.GetToken(ProjectKey(provider, p.project))
+	if err != nil {
+		return "", fmt.Errorf("project %s: %w", p.project, err)
+	}
+	return token, nil
+}
+
+func (p projectStore) DeleteToken(provider string) error {
+	return p.store.DeleteToken(ProjectKey(provider, p.project))
+}
@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithProject_ScopesTokens(t *testing.T) {
+	store := NewMockStore()
+	store.SetToken("hetzner", "default-token")
+
+	staging := WithProject(store, "Staging")
+	if err := staging.SetToken("hetzner", "staging-token"); err != nil {
+		t.Fatalf("SetToken failed: %v", err)
+	}
+
+	if got, _ := staging.GetToken("hetzner"); got != "staging-token" {
+		t.Errorf("project token = %q, want %q", got, "staging-token")
+	}
+	if got, _ := store.GetToken("hetzner"); got != "default-token" {
+		t.Errorf("default token = %q, want %q", got, "default-token")
+	}
+	if got, _ := store.GetToken("hetzner/staging"); got != "staging-token" {
+		t.Errorf("expected the project token under hetzner/staging, got %q", got)
+	}
+
+	_, err := WithProject(store, "prod").GetToken("hetzner")
+	if !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound for an unknown project, got %v", err)
+	}
+}
+
+func TestWithProject_EmptyIsUnscoped(t *testing.T) {
+	store := NewMockStore()
+	if WithProject(store, "") != Store(store) {
+		t.Error("expected an empty project to return the store unchanged")
+	}
+}
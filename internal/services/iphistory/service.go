@@ -0,0 +1,75 @@
+// Package iphistory provides a service layer for tracking server public IPs over time.
+package iphistory
+
+import (
+	"slices"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// Service wraps the iphistory repository with higher-level operations.
+type Service struct {
+	repo iphistory.Repository
+	now  func() time.Time
+}
+
+// NewService creates a new IP history service.
+func NewService(repo iphistory.Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Close releases repository resources.
+func (s *Service) Close() error {
+	if s.repo == nil {
+		return nil
+	}
+	return s.repo.Close()
+}
+
+// RecordServers records the current public IPs of each server (best-effort).
+func (s *Service) RecordServers(servers []domain.Server) {
+	if s.repo == nil {
+		return
+	}
+	seenAt := s.now()
+	for _, server := range servers {
+		ips := currentIPs(server)
+		if len(ips) == 0 {
+			continue
+		}
+		_ = s.repo.Record(server.Provider, server.ID, ips, seenAt)
+	}
+}
+
+// PreviousIPs returns IPs previously observed on the server that are no
+// longer assigned to it, most recently seen first.
+func (s *Service) PreviousIPs(server domain.Server) []iphistory.IPRecord {
+	if s.repo == nil {
+		return nil
+	}
+	records, err := s.repo.List(server.Provider, server.ID)
+	if err != nil {
+		return nil
+	}
+	current := currentIPs(server)
+	var previous []iphistory.IPRecord
+	for _, rec := range records {
+		if !slices.Contains(current, rec.IP) {
+			previous = append(previous, rec)
+		}
+	}
+	return previous
+}
+
+func currentIPs(server domain.Server) []string {
+	var ips []string
+	if server.PublicIPv4 != "" {
+		ips = append(ips, server.PublicIPv4)
+	}
+	if server.PublicIPv6 != "" {
+		ips = append(ips, server.PublicIPv6)
+	}
+	return ips
+}
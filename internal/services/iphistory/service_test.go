@@ -0,0 +1,63 @@
+package iphistory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/iphistory"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+func tempService(t *testing.T) *Service {
+	t.Helper()
+	repo, err := iphistory.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	svc := NewService(repo)
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestPreviousIPs_AfterIPChange(t *testing.T) {
+	svc := tempService(t)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	svc.now = func() time.Time { return base }
+	svc.RecordServers([]domain.Server{{ID: "1", Provider: "hetzner", PublicIPv4: "1.2.3.4"}})
+
+	rebuilt := domain.Server{ID: "1", Provider: "hetzner", PublicIPv4: "5.6.7.8"}
+	svc.now = func() time.Time { return base.Add(time.Hour) }
+	svc.RecordServers([]domain.Server{rebuilt})
+
+	prev := svc.PreviousIPs(rebuilt)
+	if len(prev) != 1 {
+		t.Fatalf("expected 1 previous IP, got %d", len(prev))
+	}
+	if prev[0].IP != "1.2.3.4" {
+		t.Errorf("expected previous IP 1.2.3.4, got %q", prev[0].IP)
+	}
+}
+
+func TestPreviousIPs_NoChange(t *testing.T) {
+	svc := tempService(t)
+	server := domain.Server{ID: "1", Provider: "hetzner", PublicIPv4: "1.2.3.4"}
+	svc.RecordServers([]domain.Server{server})
+	svc.RecordServers([]domain.Server{server})
+
+	if prev := svc.PreviousIPs(server); len(prev) != 0 {
+		t.Errorf("expected no previous IPs, got %+v", prev)
+	}
+}
+
+func TestService_NilRepo(t *testing.T) {
+	svc := NewService(nil)
+	svc.RecordServers([]domain.Server{{ID: "1", PublicIPv4: "1.2.3.4"}})
+	if prev := svc.PreviousIPs(domain.Server{ID: "1"}); prev != nil {
+		t.Errorf("expected nil, got %+v", prev)
+	}
+	if err := svc.Close(); err != nil {
+		t.Errorf("Close returned error: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package serverarchive provides a service layer for keeping the
+// configuration of servers vpsm creates and deletes.
+package serverarchive
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
+)
+
+// dnsLookupTimeout bounds the search for the DNS records pointing at a
+// deleted server, so an unreachable DNS provider cannot hold up a delete.
+const dnsLookupTimeout = 30 * time.Second
+
+// Service wraps the serverarchive repository with higher-level operations.
+type Service struct {
+	repo serverarchive.Repository
+	now  func() time.Time
+}
+
+// NewService creates a new server archive service.
+func NewService(repo serverarchive.Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Close releases repository resources.
+func (s *Service) Close() error {
+	if s.repo == nil {
+		return nil
+	}
+	return s.repo.Close()
+}
+
+// Remember stores the options server was created with (best-effort), so
+// its archive can include the user data and SSH keys providers do not
+// report back.
+func (s *Service) Remember(server domain.Server, opts domain.CreateServerOpts) {
+	if s.repo == nil {
+		return
+	}
+	cfg := fromServer(server)
+	if cfg.Location == "" {
+		cfg.Location = opts.Location
+	}
+	if cfg.Image == "" {
+		cfg.Image = opts.Image
+	}
+	cfg.UserData = opts.UserData
+	cfg.SSHKeys = opts.SSHKeyIdentifiers
+	if len(cfg.Networks) == 0 {
+		cfg.Networks = opts.Networks
+	}
+	_ = s.repo.Save(&cfg)
+}
+
+// Archive records server as deleted (best-effort), starting from its
+// current state and keeping what Remember stored when vpsm created it.
+// dns are the records that pointed at the server.
+func (s *Service) Archive(server domain.Server, dns []serverarchive.DNSRecord) {
+	if s.repo == nil {
+		return
+	}
+	cfg := fromServer(server)
+	if prev, err := s.repo.Get(cfg.Provider, cfg.ServerID); err == nil && prev != nil {
+		cfg.UserData = prev.UserData
+		cfg.SSHKeys = prev.SSHKeys
+		if len(cfg.Networks) == 0 {
+			cfg.Networks = prev.Networks
+		}
+		if cfg.Image == "" {
+			cfg.Image = prev.Image
+		}
+	}
+	cfg.DNSRecords = dns
+	cfg.ArchivedAt = s.now()
+	_ = s.repo.Save(&cfg)
+}
+
+// List returns the archived configurations, most recently deleted first.
+func (s *Service) List() ([]serverarchive.Config, error) {
+	if s.repo == nil {
+		return nil, fmt.Errorf("server archive unavailable")
+	}
+	return s.repo.ListArchived()
+}
+
+// Find returns the archived configuration matching ref, which is either
+// an archive ID or a server name. A name matches the most recently
+// deleted server of that name.
+func (s *Service) Find(ref string) (*serverarchive.Config, error) {
+	archived, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	id, idErr := strconv.ParseInt(ref, 10, 64)
+	for _, cfg := range archived {
+		if (idErr == nil && cfg.ID == id) || strings.EqualFold(cfg.Name, ref) {
+			return &cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("no archived server config %q", ref)
+}
+
+// CreateOpts returns options that recreate an equivalent server, for
+// prefilling the create wizard.
+func CreateOpts(cfg serverarchive.Config) domain.CreateServerOpts {
+	opts := domain.CreateServerOpts{
+		Name:              cfg.Name,
+		Image:             cfg.Image,
+		ServerType:        cfg.ServerType,
+		Location:          cfg.Location,
+		SSHKeyIdentifiers: cfg.SSHKeys,
+		Labels:            cfg.Labels,
+		UserData:          cfg.UserData,
+		Networks:          cfg.Networks,
+	}
+	if !cfg.IPv4 {
+		opts.EnableIPv4 = &cfg.IPv4
+	}
+	if !cfg.IPv6 {
+		opts.EnableIPv6 = &cfg.IPv6
+	}
+	return opts
+}
+
+// PointingRecords returns the A and AAAA records at dns that point at
+// server's public addresses, for archiving with it.
+func PointingRecords(ctx context.Context, dns dnsdomain.Provider, server domain.Server) ([]serverarchive.DNSRecord, error) {
+	ipv6 := server.PublicIPv6Network
+	if ipv6 == "" {
+		ipv6 = server.PublicIPv6
+	}
+	pointers, err := attach.Pointing(ctx, dns, []string{server.PublicIPv4, ipv6})
+	if err != nil {
+		return nil, err
+	}
+	var records []serverarchive.DNSRecord
+	for _, p := range pointers {
+		records = append(records, serverarchive.DNSRecord{
+			Zone:  p.Zone,
+			Name:  p.Record.Name,
+			Type:  string(p.Record.Type),
+			Value: p.Record.Value,
+			TTL:   p.Record.TTL,
+		})
+	}
+	return records, nil
+}
+
+// Repoint returns the updates that point the archived records of one
+// zone at a recreated server's ipv4 and ipv6 addresses, given the zone's
+// existing records. Only records still holding their archived value are
+// updated: one changed or removed since the delete was done on purpose.
+// The archived records left alone are returned as skipped.
+func Repoint(archived []serverarchive.DNSRecord, existing []dnsdomain.Record, ipv4, ipv6 string) (changes []dnsdomain.MirrorChange, skipped []serverarchive.DNSRecord) {
+	for _, rec := range archived {
+		value := ipv4
+		if dnsdomain.RecordType(rec.Type) == dnsdomain.RecordAAAA {
+			value = ipv6
+		}
+		i := slices.IndexFunc(existing, func(r dnsdomain.Record) bool {
+			return strings.EqualFold(r.Name, rec.Name) && string(r.Type) == rec.Type && r.Value == rec.Value
+		})
+		if value == "" || i < 0 {
+			skipped = append(skipped, rec)
+			continue
+		}
+		updated := existing[i]
+		updated.Value = value
+		changes = append(changes, dnsdomain.MirrorChange{Action: dnsdomain.MirrorUpdate, Record: updated})
+	}
+	return changes, skipped
+}
+
+func fromServer(server domain.Server) serverarchive.Config {
+	cfg := serverarchive.Config{
+		Provider:   server.Provider,
+		ServerID:   server.ID,
+		Name:       server.Name,
+		Location:   server.Region,
+		ServerType: server.ServerType,
+		Image:      server.Image,
+		Labels:     server.Labels,
+		IPv4:       server.PublicIPv4 != "",
+		IPv6:       server.PublicIPv6 != "" || server.PublicIPv6Network != "",
+	}
+	for _, res := range server.AttachedOfKind(domain.AttachedNetwork) {
+		if res.Name != "" {
+			cfg.Networks = append(cfg.Networks, res.Name)
+		}
+	}
+	return cfg
+}
+
+// RememberCreated opens the default repository and remembers the options
+// server was created with on providerName. Failures are ignored: the
+// archive is a convenience and must never block creating a server.
+func RememberCreated(providerName string, server domain.Server, opts domain.CreateServerOpts) {
+	if server.Provider == "" {
+		server.Provider = providerName
+	}
+	repo, err := serverarchive.Open()
+	if err != nil {
+		return
+	}
+	svc := NewService(repo)
+	defer svc.Close()
+	svc.Remember(server, opts)
+}
+
+// ArchiveDeleted opens the default repository and archives server, which
+// was deleted from providerName, along with the records at dns that
+// pointed at it. dns may be nil when there is no DNS provider. Failures
+// are ignored, as for RememberCreated.
+func ArchiveDeleted(ctx context.Context, providerName string, server domain.Server, dns dnsdomain.Provider) {
+	if server.Provider == "" {
+		server.Provider = providerName
+	}
+	var records []serverarchive.DNSRecord
+	if dns != nil {
+		ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+		records, _ = PointingRecords(ctx, dns, server)
+		cancel()
+	}
+	repo, err := serverarchive.Open()
+	if err != nil {
+		return
+	}
+	svc := NewService(repo)
+	defer svc.Close()
+	svc.Archive(server, records)
+}
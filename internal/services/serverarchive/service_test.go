@@ -0,0 +1,146 @@
+package serverarchive
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/serverarchive"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func tempService(t *testing.T) *Service {
+	t.Helper()
+	repo, err := serverarchive.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	svc := NewService(repo)
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestArchive_KeepsCreateOptions(t *testing.T) {
+	svc := tempService(t)
+
+	created := domain.Server{ID: "42", Provider: "hetzner", Name: "web-1", Region: "fsn1", ServerType: "cpx11", Image: "ubuntu-24.04", PublicIPv4: "203.0.113.5"}
+	svc.Remember(created, domain.CreateServerOpts{
+		Name:              "web-1",
+		SSHKeyIdentifiers: []string{"deploy"},
+		UserData:          "#cloud-config\n",
+	})
+
+	// By the time it is deleted the server was resized and relabelled.
+	deleted := created
+	deleted.ServerType = "cpx21"
+	deleted.Labels = map[string]string{"env": "prod"}
+	deleted.Attached = []domain.AttachedResource{{Kind: domain.AttachedNetwork, ID: "7", Name: "internal"}}
+	svc.now = func() time.Time { return time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC) }
+	svc.Archive(deleted, nil)
+
+	cfg, err := svc.Find("web-1")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if !cfg.Archived() {
+		t.Error("expected the config to be archived")
+	}
+
+	ipv6 := false
+	want := domain.CreateServerOpts{
+		Name:              "web-1",
+		Image:             "ubuntu-24.04",
+		ServerType:        "cpx21",
+		Location:          "fsn1",
+		SSHKeyIdentifiers: []string{"deploy"},
+		Labels:            map[string]string{"env": "prod"},
+		UserData:          "#cloud-config\n",
+		Networks:          []string{"internal"},
+		EnableIPv6:        &ipv6,
+	}
+	if diff := cmp.Diff(want, CreateOpts(*cfg)); diff != "" {
+		t.Errorf("create opts mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFind_ByIDOrName(t *testing.T) {
+	svc := tempService(t)
+
+	if _, err := svc.Find("web-1"); err == nil {
+		t.Fatal("expected an error with nothing archived")
+	}
+
+	svc.now = func() time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC) }
+	svc.Archive(domain.Server{ID: "1", Provider: "hetzner", Name: "web-1", ServerType: "cpx11"}, nil)
+	svc.now = func() time.Time { return time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC) }
+	svc.Archive(domain.Server{ID: "2", Provider: "hetzner", Name: "web-1", ServerType: "cpx21"}, nil)
+
+	cfg, err := svc.Find("WEB-1")
+	if err != nil {
+		t.Fatalf("Find by name failed: %v", err)
+	}
+	if cfg.ServerID != "2" {
+		t.Errorf("expected the most recently deleted web-1, got server %s", cfg.ServerID)
+	}
+
+	older, err := svc.Find("1")
+	if err != nil {
+		t.Fatalf("Find by ID failed: %v", err)
+	}
+	if older.ServerID != "1" {
+		t.Errorf("expected archive ID 1 to be server 1, got %s", older.ServerID)
+	}
+}
+
+func TestRepoint_UpdatesRecordsStillPointingAtTheOldServer(t *testing.T) {
+	archived := []serverarchive.DNSRecord{
+		{Zone: "example.com", Name: "www", Type: "A", Value: "203.0.113.7", TTL: 300},
+		{Zone: "example.com", Name: "www", Type: "AAAA", Value: "2001:db8::1"},
+		{Zone: "example.com", Name: "api", Type: "A", Value: "203.0.113.7"},
+	}
+	existing := []dnsdomain.Record{
+		{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "203.0.113.7", TTL: 300},
+		{ID: "2", Name: "www", Type: dnsdomain.RecordAAAA, Value: "2001:db8::1"},
+		// Pointed elsewhere since the delete.
+		{ID: "3", Name: "api", Type: dnsdomain.RecordA, Value: "198.51.100.9"},
+	}
+
+	changes, skipped := Repoint(archived, existing, "198.51.100.20", "")
+
+	wantChanges := []dnsdomain.MirrorChange{
+		{Action: dnsdomain.MirrorUpdate, Record: dnsdomain.Record{ID: "1", Name: "www", Type: dnsdomain.RecordA, Value: "198.51.100.20", TTL: 300}},
+	}
+	if diff := cmp.Diff(wantChanges, changes); diff != "" {
+		t.Errorf("changes mismatch (-want +got):\n%s", diff)
+	}
+	// The new server has no IPv6 address, and api was repointed.
+	if diff := cmp.Diff(archived[1:], skipped); diff != "" {
+		t.Errorf("skipped mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestArchive_StoresDNSRecords(t *testing.T) {
+	svc := tempService(t)
+	records := []serverarchive.DNSRecord{{Zone: "example.com", Name: "www", Type: "A", Value: "203.0.113.7"}}
+	svc.Archive(domain.Server{ID: "1", Provider: "hetzner", Name: "web-1", PublicIPv4: "203.0.113.7"}, records)
+
+	cfg, err := svc.Find("web-1")
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if diff := cmp.Diff(records, cfg.DNSRecords); diff != "" {
+		t.Errorf("DNS records mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNilRepository(t *testing.T) {
+	svc := NewService(nil)
+	svc.Remember(domain.Server{ID: "1"}, domain.CreateServerOpts{})
+	svc.Archive(domain.Server{ID: "1"}, nil)
+	if _, err := svc.List(); err == nil {
+		t.Error("expected an error without a repository")
+	}
+}
@@ -40,10 +40,137 @@ func (s *Service) SetSSHUser(provider, serverID, username string) {
 	if s.repo == nil {
 		return
 	}
-	prefs := &serverprefs.ServerPrefs{
-		Provider: provider,
-		ServerID: serverID,
-		SSHUser:  username,
+	prefs := s.load(provider, serverID)
+	prefs.SSHUser = username
+	_ = s.repo.Save(prefs)
+}
+
+// GetBastion returns the jump host stored for a server, or "" if not set.
+// Pass serverprefs.AllServers to read the provider-wide default.
+func (s *Service) GetBastion(provider, serverID string) string {
+	if s.repo == nil {
+		return ""
+	}
+	prefs, err := s.repo.Get(provider, serverID)
+	if err != nil || prefs == nil {
+		return ""
+	}
+	return prefs.Bastion
+}
+
+// SetBastion persists the jump host for a server, or the provider-wide
+// default when serverID is serverprefs.AllServers. An empty bastion
+// clears the setting.
+func (s *Service) SetBastion(provider, serverID, bastion string) error {
+	if s.repo == nil {
+		return nil
+	}
+	prefs := s.load(provider, serverID)
+	prefs.Bastion = bastion
+	return s.repo.Save(prefs)
+}
+
+// GetPersistentSession reports whether SSH logins to a server run inside
+// a persistent remote tmux session.
+func (s *Service) GetPersistentSession(provider, serverID string) bool {
+	if s.repo == nil {
+		return false
 	}
+	prefs, err := s.repo.Get(provider, serverID)
+	if err != nil || prefs == nil {
+		return false
+	}
+	return prefs.PersistentSession
+}
+
+// SetPersistentSession persists whether SSH logins to a server run inside
+// a persistent remote tmux session (best-effort).
+func (s *Service) SetPersistentSession(provider, serverID string, persistent bool) {
+	if s.repo == nil {
+		return
+	}
+	prefs := s.load(provider, serverID)
+	prefs.PersistentSession = persistent
 	_ = s.repo.Save(prefs)
 }
+
+// GetSSHOptions returns the extra ssh arguments stored for a server, or
+// "" if not set.
+func (s *Service) GetSSHOptions(provider, serverID string) string {
+	if s.repo == nil {
+		return ""
+	}
+	prefs, err := s.repo.Get(provider, serverID)
+	if err != nil || prefs == nil {
+		return ""
+	}
+	return prefs.SSHOptions
+}
+
+// SetSSHOptions persists the extra ssh arguments for a server. An empty
+// value clears the setting.
+func (s *Service) SetSSHOptions(provider, serverID, options string) error {
+	if s.repo == nil {
+		return nil
+	}
+	prefs := s.load(provider, serverID)
+	prefs.SSHOptions = options
+	return s.repo.Save(prefs)
+}
+
+// GetSSHIdentity returns the private key file chosen for a server, or ""
+// if none was.
+func (s *Service) GetSSHIdentity(provider, serverID string) string {
+	if s.repo == nil {
+		return ""
+	}
+	prefs, err := s.repo.Get(provider, serverID)
+	if err != nil || prefs == nil {
+		return ""
+	}
+	return prefs.SSHIdentity
+}
+
+// SetSSHIdentity persists the private key file for a server (best-effort).
+// An empty path clears the choice.
+func (s *Service) SetSSHIdentity(provider, serverID, identity string) {
+	if s.repo == nil {
+		return
+	}
+	prefs := s.load(provider, serverID)
+	prefs.SSHIdentity = identity
+	_ = s.repo.Save(prefs)
+}
+
+// GetSSHPort returns the SSH port stored for a server, or 0 for the
+// default.
+func (s *Service) GetSSHPort(provider, serverID string) int {
+	if s.repo == nil {
+		return 0
+	}
+	prefs, err := s.repo.Get(provider, serverID)
+	if err != nil || prefs == nil {
+		return 0
+	}
+	return prefs.SSHPort
+}
+
+// SetSSHPort persists the SSH port for a server (best-effort). Zero
+// clears the setting.
+func (s *Service) SetSSHPort(provider, serverID string, port int) {
+	if s.repo == nil {
+		return
+	}
+	prefs := s.load(provider, serverID)
+	prefs.SSHPort = port
+	_ = s.repo.Save(prefs)
+}
+
+// load returns the stored preferences for a server, or a fresh record so
+// that updating one field does not clobber the others.
+func (s *Service) load(provider, serverID string) *serverprefs.ServerPrefs {
+	if prefs, err := s.repo.Get(provider, serverID); err == nil && prefs != nil {
+		return prefs
+	}
+	return &serverprefs.ServerPrefs{Provider: provider, ServerID: serverID}
+}
@@ -0,0 +1,99 @@
+package serverprefs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/serverprefs"
+)
+
+func tempService(t *testing.T) *Service {
+	t.Helper()
+	repo, err := serverprefs.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	svc := NewService(repo)
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestSetSSHUser_PreservesBastion(t *testing.T) {
+	svc := tempService(t)
+	svc.SetBastion("hetzner", "1", "jump")
+	svc.SetSSHUser("hetzner", "1", "ubuntu")
+
+	if got := svc.GetBastion("hetzner", "1"); got != "jump" {
+		t.Errorf("GetBastion = %q, want %q", got, "jump")
+	}
+	if got := svc.GetSSHUser("hetzner", "1"); got != "ubuntu" {
+		t.Errorf("GetSSHUser = %q, want %q", got, "ubuntu")
+	}
+}
+
+func TestSetBastion_ClearKeepsSSHUser(t *testing.T) {
+	svc := tempService(t)
+	svc.SetSSHUser("hetzner", "1", "ubuntu")
+	svc.SetBastion("hetzner", "1", "jump")
+	if err := svc.SetBastion("hetzner", "1", ""); err != nil {
+		t.Fatalf("SetBastion failed: %v", err)
+	}
+
+	if got := svc.GetBastion("hetzner", "1"); got != "" {
+		t.Errorf("GetBastion = %q, want empty", got)
+	}
+	if got := svc.GetSSHUser("hetzner", "1"); got != "ubuntu" {
+		t.Errorf("GetSSHUser = %q, want %q", got, "ubuntu")
+	}
+}
+
+func TestSetPersistentSession_PreservesSSHUser(t *testing.T) {
+	svc := tempService(t)
+	svc.SetSSHUser("hetzner", "1", "ubuntu")
+	svc.SetPersistentSession("hetzner", "1", true)
+
+	if !svc.GetPersistentSession("hetzner", "1") {
+		t.Error("expected persistent session to be on")
+	}
+	if got := svc.GetSSHUser("hetzner", "1"); got != "ubuntu" {
+		t.Errorf("GetSSHUser = %q, want %q", got, "ubuntu")
+	}
+	if svc.GetPersistentSession("hetzner", "2") {
+		t.Error("expected persistent session to default to off")
+	}
+}
+
+func TestSetSSHOptions_PreservesSSHUser(t *testing.T) {
+	svc := tempService(t)
+	svc.SetSSHUser("hetzner", "1", "ubuntu")
+	if err := svc.SetSSHOptions("hetzner", "1", "-o ForwardAgent=yes"); err != nil {
+		t.Fatalf("SetSSHOptions failed: %v", err)
+	}
+
+	if got := svc.GetSSHOptions("hetzner", "1"); got != "-o ForwardAgent=yes" {
+		t.Errorf("GetSSHOptions = %q", got)
+	}
+	if got := svc.GetSSHUser("hetzner", "1"); got != "ubuntu" {
+		t.Errorf("GetSSHUser = %q, want %q", got, "ubuntu")
+	}
+}
+
+func TestSetSSHIdentityAndPort_PreserveSSHUser(t *testing.T) {
+	svc := tempService(t)
+	svc.SetSSHUser("hetzner", "1", "ubuntu")
+	svc.SetSSHIdentity("hetzner", "1", "/home/me/.ssh/deploy")
+	svc.SetSSHPort("hetzner", "1", 2222)
+
+	if got := svc.GetSSHIdentity("hetzner", "1"); got != "/home/me/.ssh/deploy" {
+		t.Errorf("GetSSHIdentity = %q", got)
+	}
+	if got := svc.GetSSHPort("hetzner", "1"); got != 2222 {
+		t.Errorf("GetSSHPort = %d, want 2222", got)
+	}
+	if got := svc.GetSSHUser("hetzner", "1"); got != "ubuntu" {
+		t.Errorf("GetSSHUser = %q, want %q", got, "ubuntu")
+	}
+	if got := svc.GetSSHPort("hetzner", "2"); got != 0 {
+		t.Errorf("expected port to default to 0, got %d", got)
+	}
+}
@@ -0,0 +1,265 @@
+// Package tagindex provides a service layer for finding provider resources
+// by tag across providers.
+package tagindex
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/dns/services/attach"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+)
+
+// Service wraps the tagindex repository with higher-level operations.
+type Service struct {
+	repo tagindex.Repository
+	now  func() time.Time
+}
+
+// NewService creates a new tag index service.
+func NewService(repo tagindex.Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Close releases repository resources.
+func (s *Service) Close() error {
+	if s.repo == nil {
+		return nil
+	}
+	return s.repo.Close()
+}
+
+// RecordServers replaces the indexed servers for provider with servers,
+// which must be the provider's complete server list, and the indexed
+// volumes with the ones attached to them.
+func (s *Service) RecordServers(provider string, servers []domain.Server) error {
+	if s.repo == nil {
+		return nil
+	}
+	seenAt := s.now()
+	resources := make([]tagindex.Resource, len(servers))
+	var volumes []tagindex.Resource
+	for i, server := range servers {
+		resources[i] = tagindex.Resource{
+			Provider:  provider,
+			Kind:      tagindex.KindServer,
+			ID:        server.ID,
+			Name:      server.Name,
+			Labels:    server.Labels,
+			Addresses: serverAddresses(server),
+			SeenAt:    seenAt,
+		}
+		for _, v := range server.AttachedOfKind(domain.AttachedVolume) {
+			name := v.Name
+			if name == "" {
+				name = v.ID
+			}
+			volumes = append(volumes, tagindex.Resource{
+				Provider: provider,
+				Kind:     tagindex.KindVolume,
+				ID:       v.ID,
+				Name:     name,
+				Parent:   server.ID,
+				Content:  v.Detail,
+				SeenAt:   seenAt,
+			})
+		}
+	}
+	if err := s.repo.Replace(provider, tagindex.KindServer, resources); err != nil {
+		return err
+	}
+	return s.repo.Replace(provider, tagindex.KindVolume, volumes)
+}
+
+// RecordBackups replaces the indexed backups of server with backups, the
+// server's complete backup list.
+func (s *Service) RecordBackups(provider string, server domain.Server, backups []domain.Backup) error {
+	if s.repo == nil {
+		return nil
+	}
+	seenAt := s.now()
+	resources := make([]tagindex.Resource, len(backups))
+	for i, b := range backups {
+		name := b.Description
+		if name == "" {
+			name = server.Name + " backup " + b.CreatedAt.UTC().Format(time.DateOnly)
+		}
+		resources[i] = tagindex.Resource{
+			Provider: provider,
+			Kind:     tagindex.KindBackup,
+			ID:       b.ID,
+			Name:     name,
+			Content:  server.Name,
+			SeenAt:   seenAt,
+		}
+	}
+	return s.repo.ReplaceIn(provider, tagindex.KindBackup, server.ID, resources)
+}
+
+// RecordZone replaces the indexed DNS records of zone with records, the
+// zone's complete record list. Records are named by their hostname, and
+// their value is searchable.
+func (s *Service) RecordZone(provider, zone string, records []dnsdomain.Record) error {
+	if s.repo == nil {
+		return nil
+	}
+	seenAt := s.now()
+	resources := make([]tagindex.Resource, len(records))
+	for i, r := range records {
+		resources[i] = tagindex.Resource{
+			Provider: provider,
+			Kind:     tagindex.KindDNSRecord,
+			// Record IDs are only unique within a zone at some providers.
+			ID:      zone + "/" + r.ID,
+			Name:    attach.Hostname(r.Name, zone),
+			Content: r.Value,
+			SeenAt:  seenAt,
+		}
+		if r.Type == dnsdomain.RecordA || r.Type == dnsdomain.RecordAAAA {
+			resources[i].Addresses = []string{r.Value}
+		}
+	}
+	return s.repo.ReplaceIn(provider, tagindex.KindDNSRecord, zone, resources)
+}
+
+// Find returns the indexed resources matching q.
+func (s *Service) Find(q Query) ([]tagindex.Resource, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	resources, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	var matched []tagindex.Resource
+	for _, res := range resources {
+		if q.Matches(res) {
+			matched = append(matched, res)
+		}
+	}
+	return matched, nil
+}
+
+// Search returns the indexed resources whose name, content, address or
+// labels contain text, ignoring case. Name matches come first.
+func (s *Service) Search(text string) ([]tagindex.Resource, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	resources, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	text = strings.ToLower(strings.TrimSpace(text))
+	var byName, byOther []tagindex.Resource
+	for _, res := range resources {
+		switch {
+		case strings.Contains(strings.ToLower(res.Name), text):
+			byName = append(byName, res)
+		case matchesContent(res, text):
+			byOther = append(byOther, res)
+		}
+	}
+	return append(byName, byOther...), nil
+}
+
+// matchesContent reports whether the content, any address, label key or
+// label value of res contains the lower-cased text.
+func matchesContent(res tagindex.Resource, text string) bool {
+	if strings.Contains(strings.ToLower(res.Content), text) {
+		return true
+	}
+	for _, addr := range res.Addresses {
+		if strings.Contains(strings.ToLower(addr), text) {
+			return true
+		}
+	}
+	for k, v := range res.Labels {
+		if strings.Contains(strings.ToLower(k+"="+v), text) {
+			return true
+		}
+	}
+	return false
+}
+
+func serverAddresses(server domain.Server) []string {
+	var addrs []string
+	for _, addr := range []string{server.PublicIPv4, server.PublicIPv6, server.PrivateIPv4} {
+		if addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// Query filters indexed resources. All set fields must match.
+type Query struct {
+	Selector domain.LabelSelector
+	Provider string
+	Kind     string
+	Name     string // case-insensitive substring of the resource name
+}
+
+// ParseQuery parses search terms such as "tag:env=prod", "provider:hetzner",
+// "kind:server" or a bare word matched against resource names. Tag terms
+// take label selector expressions and combine with each other.
+func ParseQuery(terms []string) (Query, error) {
+	var q Query
+	var names []string
+	for _, term := range terms {
+		field, value, ok := strings.Cut(term, ":")
+		if !ok {
+			names = append(names, term)
+			continue
+		}
+		switch field {
+		case "tag":
+			sel, err := domain.ParseLabelSelector(value)
+			if err != nil {
+				return Query{}, err
+			}
+			q.Selector = append(q.Selector, sel...)
+		case "provider":
+			q.Provider = value
+		case "kind":
+			q.Kind = value
+		case "name":
+			names = append(names, value)
+		default:
+			return Query{}, fmt.Errorf("unknown search field %q: expected tag, provider, kind or name", field)
+		}
+	}
+	q.Name = strings.Join(names, " ")
+	return q, nil
+}
+
+// Matches reports whether res satisfies the query.
+func (q Query) Matches(res tagindex.Resource) bool {
+	// Resources indexed per project ("hetzner/staging") also match
+	// their provider's name.
+	if q.Provider != "" && q.Provider != res.Provider && !strings.HasPrefix(res.Provider, q.Provider+"/") {
+		return false
+	}
+	if q.Kind != "" && q.Kind != res.Kind {
+		return false
+	}
+	if q.Name != "" && !strings.Contains(strings.ToLower(res.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	return q.Selector == nil || q.Selector.Matches(res.Labels)
+}
+
+// IndexZone opens the default repository and records the DNS records of
+// zone at provider. Failures are ignored: the index only feeds search.
+func IndexZone(provider, zone string, records []dnsdomain.Record) {
+	repo, err := tagindex.Open()
+	if err != nil {
+		return
+	}
+	svc := NewService(repo)
+	defer svc.Close()
+	_ = svc.RecordZone(provider, zone, records)
+}
@@ -0,0 +1,146 @@
+package tagindex
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dnsdomain "nathanbeddoewebdev/vpsm/internal/dns/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	"nathanbeddoewebdev/vpsm/internal/tagindex"
+)
+
+func tempService(t *testing.T) *Service {
+	t.Helper()
+	repo, err := tagindex.OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	svc := NewService(repo)
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+func TestFind_AcrossProviders(t *testing.T) {
+	svc := tempService(t)
+	svc.RecordServers("hetzner", []domain.Server{
+		{ID: "1", Name: "web-1", Labels: map[string]string{"env": "prod"}},
+		{ID: "2", Name: "web-2", Labels: map[string]string{"env": "staging"}},
+	})
+	svc.RecordServers("other", []domain.Server{
+		{ID: "a", Name: "db-1", Labels: map[string]string{"env": "prod", "role": "db"}},
+	})
+
+	q, err := ParseQuery([]string{"tag:env=prod"})
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	got, err := svc.Find(q)
+	if err != nil {
+		t.Fatalf("Find failed: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "a" {
+		t.Errorf("expected web-1 and db-1, got %+v", got)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery([]string{"tag:env=prod", "tag:role", "provider:hetzner", "kind:server", "WEB"})
+	if err != nil {
+		t.Fatalf("ParseQuery failed: %v", err)
+	}
+	if got := q.Selector.String(); got != "env=prod,role" {
+		t.Errorf("selector = %q, want %q", got, "env=prod,role")
+	}
+
+	match := tagindex.Resource{
+		Provider: "hetzner",
+		Kind:     tagindex.KindServer,
+		Name:     "web-1",
+		Labels:   map[string]string{"env": "prod", "role": "web"},
+	}
+	if !q.Matches(match) {
+		t.Error("expected resource to match")
+	}
+	match.Provider = "hetzner/staging"
+	if !q.Matches(match) {
+		t.Error("expected a project's resources to match their provider")
+	}
+	match.Provider = "other"
+	if q.Matches(match) {
+		t.Error("expected provider filter to exclude resource")
+	}
+
+	if _, err := ParseQuery([]string{"color:red"}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestSearch_NameAddressAndLabels(t *testing.T) {
+	svc := tempService(t)
+	svc.RecordServers("hetzner", []domain.Server{
+		{ID: "1", Name: "api", PublicIPv4: "192.0.2.10", Labels: map[string]string{"role": "web"}},
+		{ID: "2", Name: "web-1", PublicIPv4: "198.51.100.7"},
+	})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"WEB", []string{"2", "1"}}, // name match first, then label
+		{"192.0.2", []string{"1"}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		got, err := svc.Search(tt.text)
+		if err != nil {
+			t.Fatalf("Search(%q) failed: %v", tt.text, err)
+		}
+		var ids []string
+		for _, res := range got {
+			ids = append(ids, res.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Search(%q) = %v, want %v", tt.text, ids, tt.want)
+		}
+	}
+}
+
+func TestSearch_VolumesBackupsAndDNSRecords(t *testing.T) {
+	svc := tempService(t)
+	svc.RecordServers("hetzner", []domain.Server{{
+		ID: "1", Name: "db-1", PublicIPv4: "192.0.2.10",
+		Attached: []domain.AttachedResource{{Kind: domain.AttachedVolume, ID: "v7", Name: "pgdata"}},
+	}})
+	svc.RecordBackups("hetzner", domain.Server{ID: "1", Name: "db-1"}, []domain.Backup{{ID: "b3", Description: "nightly"}})
+	svc.RecordZone("cloudflare", "example.com", []dnsdomain.Record{
+		{ID: "r1", Name: "db", Type: dnsdomain.RecordA, Value: "192.0.2.10"},
+		{ID: "r2", Name: "@", Type: dnsdomain.RecordTXT, Value: "v=spf1 -all"},
+	})
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"pgdata", []string{"volume:v7@1"}},
+		{"nightly", []string{"backup:b3@1"}},
+		{"db.example", []string{"dns-record:example.com/r1@example.com"}},
+		{"spf1", []string{"dns-record:example.com/r2@example.com"}},
+		// By name first, then by content.
+		{"db", []string{"dns-record:example.com/r1@example.com", "server:1@", "backup:b3@1"}},
+		{"192.0.2.10", []string{"dns-record:example.com/r1@example.com", "server:1@"}},
+	}
+	for _, tt := range tests {
+		got, err := svc.Search(tt.text)
+		if err != nil {
+			t.Fatalf("Search(%q) failed: %v", tt.text, err)
+		}
+		var keys []string
+		for _, res := range got {
+			keys = append(keys, res.Kind+":"+res.ID+"@"+res.Parent)
+		}
+		if strings.Join(keys, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("Search(%q) = %v, want %v", tt.text, keys, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Package tokencheck verifies an API token with one lightweight read
+// request before it is stored, and reports what the token gives access to.
+package tokencheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dnsproviders "nathanbeddoewebdev/vpsm/internal/dns/providers"
+	shared "nathanbeddoewebdev/vpsm/internal/domain"
+	serverproviders "nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+// Timeout bounds the verification request.
+const Timeout = 15 * time.Second
+
+// maxNames is how many server or zone names Result.String lists.
+const maxNames = 5
+
+// ErrUnsupported is returned for token names no provider reads, so there
+// is nothing to verify the token against.
+var ErrUnsupported = errors.New("no provider to verify the token with")
+
+// dnsTokens maps tokens read only by a DNS provider to that provider.
+var dnsTokens = map[string]string{
+	"hetzner-dns": "hetzner",
+}
+
+// Result describes an accepted token. It says nothing of the token's
+// permissions: a read-only token passes the check as well.
+type Result struct {
+	// Provider is the display name of the provider that accepted it.
+	Provider string
+	// Kind is what the verification request listed: "servers" or "zones".
+	Kind string
+	// Names are the servers or zones visible to the token.
+	Names []string
+}
+
+// String summarises the result, e.g. "Hetzner: 2 servers (web, db)".
+func (r *Result) String() string {
+	if len(r.Names) == 0 {
+		return fmt.Sprintf("%s: no %s yet", r.Provider, r.Kind)
+	}
+	kind := r.Kind
+	if len(r.Names) == 1 {
+		kind = strings.TrimSuffix(kind, "s")
+	}
+	names := r.Names
+	more := ""
+	if len(names) > maxNames {
+		more = fmt.Sprintf(" and %d more", len(names)-maxNames)
+		names = names[:maxNames]
+	}
+	return fmt.Sprintf("%s: %d %s (%s%s)", r.Provider, len(r.Names), kind, strings.Join(names, ", "), more)
+}
+
+// tokenStore is an auth.Store returning one unsaved token for every key,
+// so a provider can be built with a token before it is stored.
+type tokenStore string
+
+func (s tokenStore) SetToken(string, string) error   { return nil }
+func (s tokenStore) GetToken(string) (string, error) { return string(s), nil }
+func (s tokenStore) DeleteToken(string) error        { return nil }
+
+// Verify builds the provider reading the token called name with token and
+// lists its servers (or, for DNS providers, its zones). Server providers
+// are tried first, so "hetzner" verifies a Cloud token.
+func Verify(ctx context.Context, name, token string) (*Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	name = auth.NormalizeProvider(name)
+	store := tokenStore(token)
+
+	if slices.Contains(serverproviders.List(), name) {
+		provider, err := serverproviders.Get(name, store)
+		if err != nil {
+			return nil, err
+		}
+		servers, err := provider.ListServers(ctx)
+		if err != nil {
+			return nil, rejected(provider.GetDisplayName(), err)
+		}
+		result := &Result{Provider: provider.GetDisplayName(), Kind: "servers"}
+		for _, s := range servers {
+			result.Names = append(result.Names, s.Name)
+		}
+		return result, nil
+	}
+
+	dnsName := name
+	if mapped, ok := dnsTokens[name]; ok {
+		dnsName = mapped
+	}
+	if slices.Contains(dnsproviders.List(), dnsName) {
+		provider, err := dnsproviders.Get(dnsName, store)
+		if err != nil {
+			return nil, err
+		}
+		zones, err := provider.ListZones(ctx)
+		if err != nil {
+			return nil, rejected(provider.GetDisplayName(), err)
+		}
+		result := &Result{Provider: provider.GetDisplayName(), Kind: "zones"}
+		for _, z := range zones {
+			result.Names = append(result.Names, z.Name)
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrUnsupported, name)
+}
+
+// rejected explains a failed verification request.
+func rejected(provider string, err error) error {
+	if errors.Is(err, shared.ErrUnauthorized) {
+		return fmt.Errorf("%s rejected the token (check that it is complete and not expired or revoked): %w", provider, err)
+	}
+	return fmt.Errorf("failed to verify token with %s: %w", provider, err)
+}
@@ -0,0 +1,92 @@
+package tokencheck
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	shared "nathanbeddoewebdev/vpsm/internal/domain"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+	serverproviders "nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// tokenProvider lists two servers for the token "good" and rejects any
+// other token.
+type tokenProvider struct {
+	domain.Provider
+	token string
+}
+
+func (p *tokenProvider) GetDisplayName() string { return "Mock" }
+func (p *tokenProvider) ListServers(context.Context) ([]domain.Server, error) {
+	if p.token != "good" {
+		return nil, shared.ErrUnauthorized
+	}
+	return []domain.Server{{Name: "web"}, {Name: "db"}}, nil
+}
+
+func registerTokenProvider(t *testing.T) {
+	t.Helper()
+	serverproviders.Reset()
+	t.Cleanup(serverproviders.Reset)
+	serverproviders.Register("mock", func(store auth.Store) (domain.Provider, error) {
+		token, err := store.GetToken("mock")
+		if err != nil {
+			return nil, err
+		}
+		return &tokenProvider{token: token}, nil
+	})
+}
+
+func TestVerify_AcceptedTokenListsServers(t *testing.T) {
+	registerTokenProvider(t)
+
+	result, err := Verify(context.Background(), "Mock", "good")
+	if err != nil {
+		t.Fatalf("Verify() error: %v", err)
+	}
+	want := &Result{Provider: "Mock", Kind: "servers", Names: []string{"web", "db"}}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("Verify() mismatch (-want +got):\n%s", diff)
+	}
+	if got := result.String(); got != "Mock: 2 servers (web, db)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestVerify_RejectedToken(t *testing.T) {
+	registerTokenProvider(t)
+
+	_, err := Verify(context.Background(), "mock", "bad")
+	if !errors.Is(err, shared.ErrUnauthorized) || !strings.Contains(err.Error(), "Mock rejected the token") {
+		t.Errorf("err = %v, want a rejection wrapping ErrUnauthorized", err)
+	}
+}
+
+func TestVerify_UnknownProvider(t *testing.T) {
+	registerTokenProvider(t)
+
+	if _, err := Verify(context.Background(), "nowhere", "good"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("err = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestResultString_ListsFirstNames(t *testing.T) {
+	tests := []struct {
+		result Result
+		want   string
+	}{
+		{Result{Provider: "deSEC", Kind: "zones"}, "deSEC: no zones yet"},
+		{Result{Provider: "deSEC", Kind: "zones", Names: []string{"example.com"}}, "deSEC: 1 zone (example.com)"},
+		{Result{Provider: "Hetzner", Kind: "servers", Names: []string{"a", "b", "c", "d", "e", "f", "g"}}, "Hetzner: 7 servers (a, b, c, d, e and 2 more)"},
+	}
+	for _, tt := range tests {
+		if got := tt.result.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -9,5 +9,6 @@ import (
 // Provider defines SSH key management operations for a cloud provider.
 type Provider interface {
 	GetDisplayName() string
+	ListSSHKeys(ctx context.Context) ([]platformsshkey.Spec, error)
 	CreateSSHKey(ctx context.Context, name, publicKey string) (*platformsshkey.Spec, error)
 }
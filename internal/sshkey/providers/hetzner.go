@@ -6,8 +6,6 @@ import (
 	serverproviders "nathanbeddoewebdev/vpsm/internal/server/providers"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
 	sshkeydomain "nathanbeddoewebdev/vpsm/internal/sshkey/domain"
-
-	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
 
 var _ sshkeydomain.Provider = (*serverproviders.HetznerProvider)(nil)
@@ -20,6 +18,10 @@ func RegisterHetzner() {
 			return nil, fmt.Errorf("hetzner auth: %w", err)
 		}
 
-		return serverproviders.NewHetznerProvider(hcloud.WithToken(token)), nil
+		opts, err := serverproviders.HetznerClientOptions(token)
+		if err != nil {
+			return nil, err
+		}
+		return serverproviders.NewHetznerProvider(opts...), nil
 	})
 }
@@ -0,0 +1,52 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/config"
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+)
+
+func TestRegisterHetzner_EndpointOverride(t *testing.T) {
+	var reached bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/gw/ssh_keys") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		reached = true
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ssh_keys": []interface{}{}})
+	}))
+	t.Cleanup(srv.Close)
+
+	config.SetPath(filepath.Join(t.TempDir(), "config.json"))
+	t.Cleanup(config.ResetPath)
+	cfg := &config.Config{}
+	cfg.SetEndpoint("hetzner", config.Endpoint{URL: srv.URL + "/gw"})
+	if err := cfg.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	Reset()
+	t.Cleanup(Reset)
+	RegisterHetzner()
+	store := auth.NewMockStore()
+	store.SetToken("hetzner", "token")
+
+	provider, err := Get("hetzner", store)
+	if err != nil {
+		t.Fatalf("expected no error from Get, got %v", err)
+	}
+	if _, err := provider.ListSSHKeys(context.Background()); err != nil {
+		t.Fatalf("ListSSHKeys() error: %v", err)
+	}
+	if !reached {
+		t.Error("expected the request to reach the overridden endpoint")
+	}
+}
@@ -39,8 +39,10 @@ func Register(name string, factory Factory) {
 }
 
 // Get resolves and constructs an SSH key provider by name.
+// A profile name such as "hetzner:work" uses that profile's token.
 func Get(name string, store auth.Store) (sshkeydomain.Provider, error) {
-	normalizedName := util.NormalizeKey(name)
+	baseName, profile := auth.ParseProfile(name)
+	normalizedName := util.NormalizeKey(baseName)
 
 	mu.RLock()
 	factory, ok := registry[normalizedName]
@@ -49,7 +51,7 @@ func Get(name string, store auth.Store) (sshkeydomain.Provider, error) {
 		return nil, fmt.Errorf("sshkey providers: unknown provider %q", name)
 	}
 
-	provider, err := factory(store)
+	provider, err := factory(auth.WithProject(store, profile))
 	if err != nil {
 		return nil, err
 	}
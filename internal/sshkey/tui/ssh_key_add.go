@@ -1,13 +1,16 @@
 package tui
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/sshkeys"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -25,12 +28,18 @@ type SSHKeySource int
 const (
 	SSHKeySourceFile SSHKeySource = iota
 	SSHKeySourcePaste
+	SSHKeySourceGitHub
 )
 
+// githubFetchTimeout bounds the download of a GitHub user's keys.
+const githubFetchTimeout = 15 * time.Second
+
 func (s SSHKeySource) label() string {
 	switch s {
 	case SSHKeySourcePaste:
 		return "Paste public key"
+	case SSHKeySourceGitHub:
+		return "Fetch from GitHub username"
 	default:
 		return "Use local .pub file"
 	}
@@ -38,24 +47,60 @@ func (s SSHKeySource) label() string {
 
 // SSHKeyAddPrefill provides initial values for the SSH key add flow.
 type SSHKeyAddPrefill struct {
-	Source    SSHKeySource
-	Path      string
-	PublicKey string
-	Name      string
+	Source     SSHKeySource
+	Path       string
+	PublicKey  string
+	GitHubUser string
+	Name       string
 }
 
-// SSHKeyAddResult contains the resolved SSH key name and public key.
-type SSHKeyAddResult struct {
+// SSHKeyUpload is a single public key to upload under Name.
+type SSHKeyUpload struct {
 	Name      string
 	PublicKey string
 }
 
+// SSHKeyAddResult contains the resolved keys to upload. File and paste
+// sources produce one key; a GitHub import may produce several.
+type SSHKeyAddResult struct {
+	Keys []SSHKeyUpload
+}
+
+// newSSHKeyAddResult names publicKeys after name, suffixing -1, -2, ...
+// when there is more than one.
+func newSSHKeyAddResult(name string, publicKeys []string) *SSHKeyAddResult {
+	names := sshkeys.GitHubKeyNames(name, len(publicKeys))
+	keys := make([]SSHKeyUpload, len(publicKeys))
+	for i, key := range publicKeys {
+		keys[i] = SSHKeyUpload{Name: names[i], PublicKey: key}
+	}
+	return &SSHKeyAddResult{Keys: keys}
+}
+
+// githubKeysMsg carries the result of fetching a GitHub user's keys.
+type githubKeysMsg struct {
+	username string
+	keys     []string
+	err      error
+}
+
+func fetchGitHubKeys(username string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), githubFetchTimeout)
+		defer cancel()
+		keys, err := sshkeys.FetchGitHubKeys(ctx, nil, username)
+		return githubKeysMsg{username: username, keys: keys, err: err}
+	}
+}
+
 type sshKeyAddStep int
 
 const (
 	sshStepSource sshKeyAddStep = iota
 	sshStepPath
 	sshStepPaste
+	sshStepGitHubUser
+	sshStepGitHubKeys
 	sshStepName
 	sshStepConfirm
 )
@@ -68,6 +113,10 @@ func (s sshKeyAddStep) label() string {
 		return "Key file"
 	case sshStepPaste:
 		return "Paste"
+	case sshStepGitHubUser:
+		return "GitHub user"
+	case sshStepGitHubKeys:
+		return "Keys"
 	case sshStepName:
 		return "Name"
 	case sshStepConfirm:
@@ -82,13 +131,21 @@ type sshKeyAddModel struct {
 	step         sshKeyAddStep
 	source       SSHKeySource
 
-	pathInput textinput.Model
-	keyInput  textinput.Model
-	nameInput textinput.Model
+	pathInput   textinput.Model
+	keyInput    textinput.Model
+	githubInput textinput.Model
+	nameInput   textinput.Model
 
 	keyPath   string
 	publicKey string
 
+	// GitHub import state.
+	githubUser     string
+	githubKeys     []string
+	githubSelected map[int]struct{}
+	githubIdx      int
+	fetching       bool
+
 	sourceIdx  int
 	confirmIdx int
 
@@ -120,6 +177,12 @@ func RunSSHKeyAdd(providerName string, prefill SSHKeyAddPrefill) (*SSHKeyAddResu
 	keyInput.Width = 70
 	keyInput.SetValue(prefill.PublicKey)
 
+	githubInput := textinput.New()
+	githubInput.Placeholder = "octocat"
+	githubInput.CharLimit = 39
+	githubInput.Width = 40
+	githubInput.SetValue(prefill.GitHubUser)
+
 	nameInput := textinput.New()
 	nameInput.Placeholder = sshkeys.DefaultKeyName()
 	nameInput.CharLimit = 64
@@ -134,13 +197,12 @@ func RunSSHKeyAdd(providerName string, prefill SSHKeyAddPrefill) (*SSHKeyAddResu
 		source:       source,
 		pathInput:    pathInput,
 		keyInput:     keyInput,
+		githubInput:  githubInput,
 		nameInput:    nameInput,
 	}
-	if source == SSHKeySourcePaste {
-		m.sourceIdx = 1
-	}
+	m.sourceIdx = int(source)
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run ssh key add: %w", err)
@@ -159,9 +221,13 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 	_ = providerName
 
 	sourceValue := "file"
-	if resolveSource(prefill) == SSHKeySourcePaste {
+	switch resolveSource(prefill) {
+	case SSHKeySourcePaste:
 		sourceValue = "paste"
+	case SSHKeySourceGitHub:
+		sourceValue = "github"
 	}
+	githubUser := strings.TrimSpace(prefill.GitHubUser)
 
 	keyPath := prefill.Path
 	if keyPath == "" {
@@ -171,9 +237,12 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 	publicKey := strings.TrimSpace(prefill.PublicKey)
 	name := strings.TrimSpace(prefill.Name)
 	if name == "" {
-		if sourceValue == "file" {
+		switch sourceValue {
+		case "file":
 			name = sshkeys.SuggestKeyName(keyPath)
-		} else {
+		case "github":
+			name = sshkeys.DefaultGitHubKeyName(githubUser)
+		default:
 			name = sshkeys.DefaultKeyName()
 		}
 	}
@@ -185,6 +254,7 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 				Options(
 					huh.NewOption("Use local .pub file", "file"),
 					huh.NewOption("Paste public key", "paste"),
+					huh.NewOption("Fetch from GitHub username", "github"),
 				).
 				Value(&sourceValue),
 			huh.NewInput().
@@ -222,8 +292,19 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 					_, err := sshkeys.ValidatePublicKey(s)
 					return err
 				}),
+			huh.NewInput().
+				Title("GitHub username").
+				Description("Used when source is github").
+				Value(&githubUser).
+				Validate(func(s string) error {
+					if sourceValue != "github" {
+						return nil
+					}
+					return sshkeys.ValidateGitHubUsername(strings.TrimSpace(s))
+				}),
 			huh.NewInput().
 				Title("Key name").
+				Description("Imported GitHub keys are suffixed -1, -2, ... when there are several").
 				Value(&name).
 				Validate(func(s string) error {
 					if strings.TrimSpace(s) == "" {
@@ -247,7 +328,8 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 	}
 
 	var err error
-	if sourceValue == "file" {
+	switch sourceValue {
+	case "file":
 		expanded, err := sshkeys.ExpandHomePath(strings.TrimSpace(keyPath))
 		if err != nil {
 			return nil, err
@@ -256,19 +338,69 @@ func RunSSHKeyAddAccessible(providerName string, prefill SSHKeyAddPrefill) (*SSH
 		if err != nil {
 			return nil, err
 		}
-	} else {
+	case "github":
+		keys, err := selectGitHubKeysAccessible(strings.TrimSpace(githubUser))
+		if err != nil {
+			return nil, err
+		}
+		return newSSHKeyAddResult(name, keys), nil
+	default:
 		publicKey, err = sshkeys.ValidatePublicKey(publicKey)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return &SSHKeyAddResult{Name: name, PublicKey: publicKey}, nil
+	return newSSHKeyAddResult(name, []string{publicKey}), nil
+}
+
+// selectGitHubKeysAccessible fetches a GitHub user's keys and asks which
+// of them to upload.
+func selectGitHubKeysAccessible(username string) ([]string, error) {
+	fmt.Fprintf(os.Stderr, "Fetching keys from %s...\n", sshkeys.GitHubKeysURL(username))
+	ctx, cancel := context.WithTimeout(context.Background(), githubFetchTimeout)
+	defer cancel()
+	keys, err := sshkeys.FetchGitHubKeys(ctx, nil, username)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make([]huh.Option[string], len(keys))
+	for i, key := range keys {
+		options[i] = huh.NewOption(truncateValue(key, 60), key).Selected(true)
+	}
+
+	var selected []string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Keys to upload").
+				Options(options...).
+				Value(&selected).
+				Validate(func(s []string) error {
+					if len(s) == 0 {
+						return fmt.Errorf("select at least one key")
+					}
+					return nil
+				}),
+		),
+	).WithAccessible(true)
+
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil, ErrAborted
+		}
+		return nil, err
+	}
+	return selected, nil
 }
 
 func resolveSource(prefill SSHKeyAddPrefill) SSHKeySource {
-	if prefill.Source == SSHKeySourcePaste {
-		return SSHKeySourcePaste
+	if prefill.Source == SSHKeySourcePaste || prefill.Source == SSHKeySourceGitHub {
+		return prefill.Source
+	}
+	if strings.TrimSpace(prefill.GitHubUser) != "" {
+		return SSHKeySourceGitHub
 	}
 	if strings.TrimSpace(prefill.PublicKey) != "" && strings.TrimSpace(prefill.Path) == "" {
 		return SSHKeySourcePaste
@@ -291,10 +423,13 @@ func (m sshKeyAddModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.pathInput.Width = minInt(60, available)
 		m.keyInput.Width = minInt(70, available)
+		m.githubInput.Width = minInt(40, available)
 		m.nameInput.Width = minInt(40, available)
 		return m, nil
 	case tea.KeyMsg:
 		return m.handleKey(msg)
+	case githubKeysMsg:
+		return m.handleGitHubKeys(msg)
 	}
 
 	return m, nil
@@ -313,6 +448,10 @@ func (m sshKeyAddModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handlePathKey(msg)
 	case sshStepPaste:
 		return m.handlePasteKey(msg)
+	case sshStepGitHubUser:
+		return m.handleGitHubUserKey(msg)
+	case sshStepGitHubKeys:
+		return m.handleGitHubKeysKey(msg)
 	case sshStepName:
 		return m.handleNameKey(msg)
 	case sshStepConfirm:
@@ -332,18 +471,21 @@ func (m sshKeyAddModel) handleSourceKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.sourceIdx--
 		}
 	case "down", "j", "right", "l":
-		if m.sourceIdx < 1 {
+		if m.sourceIdx < int(SSHKeySourceGitHub) {
 			m.sourceIdx++
 		}
 	case "enter":
-		if m.sourceIdx == 0 {
-			m.source = SSHKeySourceFile
-			m.step = sshStepPath
-			m.pathInput.Focus()
-		} else {
-			m.source = SSHKeySourcePaste
+		m.source = SSHKeySource(m.sourceIdx)
+		switch m.source {
+		case SSHKeySourcePaste:
 			m.step = sshStepPaste
 			m.keyInput.Focus()
+		case SSHKeySourceGitHub:
+			m.step = sshStepGitHubUser
+			m.githubInput.Focus()
+		default:
+			m.step = sshStepPath
+			m.pathInput.Focus()
 		}
 		m.err = ""
 		return m, textinput.Blink
@@ -418,12 +560,121 @@ func (m sshKeyAddModel) handlePasteKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m sshKeyAddModel) handleGitHubUserKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.fetching {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.step = sshStepSource
+		return m, nil
+	case "enter":
+		username := strings.TrimSpace(m.githubInput.Value())
+		if err := sshkeys.ValidateGitHubUsername(username); err != nil {
+			m.err = err.Error()
+			return m, nil
+		}
+		m.fetching = true
+		m.err = ""
+		return m, fetchGitHubKeys(username)
+	}
+
+	var cmd tea.Cmd
+	m.githubInput, cmd = m.githubInput.Update(msg)
+	m.err = ""
+	return m, cmd
+}
+
+func (m sshKeyAddModel) handleGitHubKeys(msg githubKeysMsg) (tea.Model, tea.Cmd) {
+	m.fetching = false
+	if msg.err != nil {
+		m.err = msg.err.Error()
+		return m, nil
+	}
+
+	if msg.username != m.githubUser {
+		// A different user: drop the previous selection and name suggestion.
+		if m.githubUser != "" && strings.TrimSpace(m.nameInput.Value()) == sshkeys.DefaultGitHubKeyName(m.githubUser) {
+			m.nameInput.SetValue("")
+		}
+		m.githubSelected = make(map[int]struct{}, len(msg.keys))
+		for i := range msg.keys {
+			m.githubSelected[i] = struct{}{}
+		}
+		m.githubIdx = 0
+	}
+	m.githubUser = msg.username
+	m.githubKeys = msg.keys
+	m.setNameDefaultIfEmpty(sshkeys.DefaultGitHubKeyName(msg.username))
+	m.step = sshStepGitHubKeys
+	return m, nil
+}
+
+func (m sshKeyAddModel) handleGitHubKeysKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.step = sshStepGitHubUser
+		m.err = ""
+		return m, textinput.Blink
+	case "up", "k":
+		if m.githubIdx > 0 {
+			m.githubIdx--
+		}
+	case "down", "j":
+		if m.githubIdx < len(m.githubKeys)-1 {
+			m.githubIdx++
+		}
+	case " ":
+		if _, ok := m.githubSelected[m.githubIdx]; ok {
+			delete(m.githubSelected, m.githubIdx)
+		} else {
+			m.githubSelected[m.githubIdx] = struct{}{}
+		}
+		m.err = ""
+	case "enter":
+		if len(m.githubSelected) == 0 {
+			m.err = "Select at least one key"
+			return m, nil
+		}
+		m.step = sshStepName
+		m.nameInput.Focus()
+		m.err = ""
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
+// selectedGitHubKeys returns the chosen GitHub keys in their listed order.
+func (m sshKeyAddModel) selectedGitHubKeys() []string {
+	keys := make([]string, 0, len(m.githubSelected))
+	for i, key := range m.githubKeys {
+		if _, ok := m.githubSelected[i]; ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// buildResult returns the keys the wizard will upload.
+func (m sshKeyAddModel) buildResult() *SSHKeyAddResult {
+	name := strings.TrimSpace(m.nameInput.Value())
+	if m.source == SSHKeySourceGitHub {
+		return newSSHKeyAddResult(name, m.selectedGitHubKeys())
+	}
+	return newSSHKeyAddResult(name, []string{m.publicKey})
+}
+
 func (m sshKeyAddModel) handleNameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
-		if m.source == SSHKeySourcePaste {
+		switch m.source {
+		case SSHKeySourcePaste:
 			m.step = sshStepPaste
-		} else {
+		case SSHKeySourceGitHub:
+			m.step = sshStepGitHubKeys
+		default:
 			m.step = sshStepPath
 		}
 		return m, nil
@@ -461,19 +712,13 @@ func (m sshKeyAddModel) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case "enter":
 		if m.confirmIdx == 0 {
-			m.result = &SSHKeyAddResult{
-				Name:      strings.TrimSpace(m.nameInput.Value()),
-				PublicKey: m.publicKey,
-			}
+			m.result = m.buildResult()
 			return m, tea.Quit
 		}
 		m.quitting = true
 		return m, tea.Quit
 	case "y":
-		m.result = &SSHKeyAddResult{
-			Name:      strings.TrimSpace(m.nameInput.Value()),
-			PublicKey: m.publicKey,
-		}
+		m.result = m.buildResult()
 		return m, tea.Quit
 	case "n":
 		m.quitting = true
@@ -498,6 +743,13 @@ func (m sshKeyAddModel) View() string {
 			{Key: "enter", Desc: "next"},
 			{Key: "esc", Desc: "cancel"},
 		}
+	case sshStepGitHubKeys:
+		footerBindings = []components.KeyBinding{
+			{Key: "j/k", Desc: "move"},
+			{Key: "space", Desc: "toggle"},
+			{Key: "enter", Desc: "next"},
+			{Key: "esc", Desc: "back"},
+		}
 	case sshStepConfirm:
 		footerBindings = []components.KeyBinding{
 			{Key: "y/n", Desc: "confirm"},
@@ -534,6 +786,10 @@ func (m sshKeyAddModel) renderContent(height int) string {
 		stepContent = m.renderPathStep()
 	case sshStepPaste:
 		stepContent = m.renderPasteStep()
+	case sshStepGitHubUser:
+		stepContent = m.renderGitHubUserStep()
+	case sshStepGitHubKeys:
+		stepContent = m.renderGitHubKeysStep()
 	case sshStepName:
 		stepContent = m.renderNameStep()
 	case sshStepConfirm:
@@ -550,9 +806,12 @@ func (m sshKeyAddModel) renderContent(height int) string {
 
 func (m sshKeyAddModel) renderProgress() string {
 	steps := []sshKeyAddStep{sshStepSource, sshStepName, sshStepConfirm}
-	if m.source == SSHKeySourcePaste {
+	switch m.source {
+	case SSHKeySourcePaste:
 		steps = []sshKeyAddStep{sshStepSource, sshStepPaste, sshStepName, sshStepConfirm}
-	} else if m.source == SSHKeySourceFile {
+	case SSHKeySourceGitHub:
+		steps = []sshKeyAddStep{sshStepSource, sshStepGitHubUser, sshStepGitHubKeys, sshStepName, sshStepConfirm}
+	case SSHKeySourceFile:
 		steps = []sshKeyAddStep{sshStepSource, sshStepPath, sshStepName, sshStepConfirm}
 	}
 
@@ -575,7 +834,7 @@ func (m sshKeyAddModel) renderSourceStep() string {
 	title := styles.Title.Render("Choose key source")
 	hint := styles.MutedText.Render("Select how you'd like to provide the public key")
 
-	options := []SSHKeySource{SSHKeySourceFile, SSHKeySourcePaste}
+	options := []SSHKeySource{SSHKeySourceFile, SSHKeySourcePaste, SSHKeySourceGitHub}
 	rows := make([]string, 0, len(options))
 	for i, option := range options {
 		prefix := "  "
@@ -637,9 +896,71 @@ func (m sshKeyAddModel) renderPasteStep() string {
 	)
 }
 
+func (m sshKeyAddModel) renderGitHubUserStep() string {
+	title := styles.Title.Render("GitHub username")
+	hint := styles.MutedText.Render("Keys are fetched from https://github.com/<username>.keys")
+
+	inputView := m.githubInput.View()
+
+	var statusLine string
+	switch {
+	case m.fetching:
+		statusLine = "\n" + styles.MutedText.Render("Fetching keys...")
+	case m.err != "":
+		statusLine = "\n" + styles.ErrorText.Render(m.err)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		"",
+		inputView,
+		statusLine,
+	)
+}
+
+func (m sshKeyAddModel) renderGitHubKeysStep() string {
+	title := styles.Title.Render(fmt.Sprintf("Keys for %s", m.githubUser))
+	hint := styles.MutedText.Render("Press space to toggle, enter to continue")
+
+	rows := make([]string, 0, len(m.githubKeys))
+	for i, key := range m.githubKeys {
+		check := "[ ]"
+		if _, ok := m.githubSelected[i]; ok {
+			check = styles.SuccessText.Render("[x]")
+		}
+
+		prefix := "  "
+		label := truncateValue(key, 56)
+		if i == m.githubIdx {
+			prefix = styles.AccentText.Render("> ")
+			label = styles.Value.Render(label)
+		} else {
+			label = styles.MutedText.Render(label)
+		}
+		rows = append(rows, prefix+check+" "+label)
+	}
+
+	var errLine string
+	if m.err != "" {
+		errLine = "\n" + styles.ErrorText.Render(m.err)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		hint,
+		"",
+		strings.Join(rows, "\n"),
+		errLine,
+	)
+}
+
 func (m sshKeyAddModel) renderNameStep() string {
 	title := styles.Title.Render("Key name")
 	hint := styles.MutedText.Render("Give this key a friendly name")
+	if m.source == SSHKeySourceGitHub && len(m.githubSelected) > 1 {
+		hint = styles.MutedText.Render("Each key is uploaded as <name>-1, <name>-2, ...")
+	}
 
 	inputView := m.nameInput.View()
 
@@ -668,21 +989,33 @@ func (m sshKeyAddModel) renderConfirmStep() string {
 	}
 
 	source := "File"
-	if m.source == SSHKeySourcePaste {
+	switch m.source {
+	case SSHKeySourcePaste:
 		source = "Paste"
+	case SSHKeySourceGitHub:
+		source = "GitHub"
 	}
 
 	fields := []string{
 		renderField("Source", source),
 	}
 
-	if m.source == SSHKeySourceFile {
+	switch m.source {
+	case SSHKeySourceFile:
 		fields = append(fields, renderField("Path", m.keyPath))
-	} else {
+	case SSHKeySourceGitHub:
+		fields = append(fields, renderField("User", m.githubUser))
+	default:
 		fields = append(fields, renderField("Key", truncateValue(m.publicKey, 48)))
 	}
 
-	fields = append(fields, renderField("Name", strings.TrimSpace(m.nameInput.Value())))
+	result := m.buildResult()
+	for _, key := range result.Keys {
+		fields = append(fields, renderField("Name", key.Name))
+	}
+	if len(result.Keys) > 1 {
+		fields = append(fields, renderField("Keys", fmt.Sprintf("%d", len(result.Keys))))
+	}
 
 	summary := styles.Card.Width(60).Render(strings.Join(fields, "\n"))
 
@@ -0,0 +1,110 @@
+package sshkeys
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// ssh-agent protocol message numbers (draft-miller-ssh-agent).
+const (
+	agentRequestIdentities = 11
+	agentIdentitiesAnswer  = 12
+	agentFailure           = 5
+)
+
+// maxAgentReply bounds the size of an agent response we are willing to read.
+const maxAgentReply = 256 * 1024
+
+// ErrNoAgent is returned when SSH_AUTH_SOCK is not set.
+var ErrNoAgent = errors.New("ssh-agent not available (SSH_AUTH_SOCK is not set)")
+
+// AgentKeys returns the public keys currently loaded in the ssh-agent
+// listening on SSH_AUTH_SOCK.
+func AgentKeys() ([]LocalKey, error) {
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, ErrNoAgent
+	}
+
+	conn, err := net.DialTimeout("unix", sock, 2*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh-agent: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return requestIdentities(conn)
+}
+
+// requestIdentities sends SSH_AGENTC_REQUEST_IDENTITIES and parses the reply.
+func requestIdentities(rw io.ReadWriter) ([]LocalKey, error) {
+	// uint32 length, byte type
+	if _, err := rw.Write([]byte{0, 0, 0, 1, agentRequestIdentities}); err != nil {
+		return nil, fmt.Errorf("failed to query ssh-agent: %w", err)
+	}
+
+	var length uint32
+	if err := binary.Read(rw, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("failed to read ssh-agent reply: %w", err)
+	}
+	if length == 0 || length > maxAgentReply {
+		return nil, fmt.Errorf("invalid ssh-agent reply length %d", length)
+	}
+	reply := make([]byte, length)
+	if _, err := io.ReadFull(rw, reply); err != nil {
+		return nil, fmt.Errorf("failed to read ssh-agent reply: %w", err)
+	}
+
+	switch reply[0] {
+	case agentIdentitiesAnswer:
+	case agentFailure:
+		return nil, fmt.Errorf("ssh-agent refused to list keys")
+	default:
+		return nil, fmt.Errorf("unexpected ssh-agent reply type %d", reply[0])
+	}
+
+	r := reply[1:]
+	count, r, ok := readUint32(r)
+	if !ok {
+		return nil, fmt.Errorf("malformed ssh-agent reply")
+	}
+
+	keys := make([]LocalKey, 0, count)
+	for range count {
+		var blob, comment []byte
+		if blob, r, ok = readString(r); !ok {
+			return nil, fmt.Errorf("malformed ssh-agent reply")
+		}
+		if comment, r, ok = readString(r); !ok {
+			return nil, fmt.Errorf("malformed ssh-agent reply")
+		}
+		keyType, _, _ := readString(blob)
+		keys = append(keys, LocalKey{
+			Type:    string(keyType),
+			Blob:    blob,
+			Comment: string(comment),
+			Source:  SourceAgent,
+		})
+	}
+	return keys, nil
+}
+
+func readUint32(b []byte) (uint32, []byte, bool) {
+	if len(b) < 4 {
+		return 0, b, false
+	}
+	return binary.BigEndian.Uint32(b), b[4:], true
+}
+
+func readString(b []byte) ([]byte, []byte, bool) {
+	n, rest, ok := readUint32(b)
+	if !ok || uint32(len(rest)) < n {
+		return nil, b, false
+	}
+	return rest[:n], rest[n:], true
+}
@@ -0,0 +1,122 @@
+package sshkeys
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// defaultGitHubBaseURL is where GitHub publishes users' public keys as
+// <base>/<username>.keys.
+const defaultGitHubBaseURL = "https://github.com"
+
+// githubBaseURL is the base used by GitHubKeysURL.
+// Use SetGitHubBaseURL / ResetGitHubBaseURL to manage.
+var githubBaseURL = defaultGitHubBaseURL
+
+// SetGitHubBaseURL points key downloads at another host. Intended for testing.
+func SetGitHubBaseURL(url string) { githubBaseURL = strings.TrimSuffix(url, "/") }
+
+// ResetGitHubBaseURL restores the default GitHub host. Intended for testing.
+func ResetGitHubBaseURL() { githubBaseURL = defaultGitHubBaseURL }
+
+// githubTimeout bounds the key download when the caller's context has no
+// deadline of its own.
+const githubTimeout = 15 * time.Second
+
+// maxGitHubKeysBody caps the size of a .keys response.
+const maxGitHubKeysBody = 1 << 20
+
+// validGitHubUsername matches GitHub's username rules: up to 39
+// alphanumerics or single hyphens, not starting or ending with a hyphen.
+var validGitHubUsername = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9]|-[a-zA-Z0-9]){0,38}$`)
+
+// GitHubKeysURL returns the URL of a GitHub user's public keys.
+func GitHubKeysURL(username string) string {
+	return fmt.Sprintf("%s/%s.keys", githubBaseURL, username)
+}
+
+// ValidateGitHubUsername checks that username is a syntactically valid
+// GitHub username.
+func ValidateGitHubUsername(username string) error {
+	if !validGitHubUsername.MatchString(username) {
+		return fmt.Errorf("invalid GitHub username %q", username)
+	}
+	return nil
+}
+
+// FetchGitHubKeys downloads the public SSH keys a GitHub user has added to
+// their account. Lines that are not valid public keys are skipped. A nil
+// client uses http.DefaultClient.
+func FetchGitHubKeys(ctx context.Context, client *http.Client, username string) ([]string, error) {
+	username = strings.TrimSpace(username)
+	if err := ValidateGitHubUsername(username); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, githubTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, GitHubKeysURL(username), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch GitHub keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("GitHub user %q not found", username)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("failed to fetch GitHub keys: unexpected status %s", resp.Status)
+	}
+
+	var keys []string
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxGitHubKeysBody))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxGitHubKeysBody)
+	for scanner.Scan() {
+		if key, err := ValidatePublicKey(scanner.Text()); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read GitHub keys: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("GitHub user %q has no public SSH keys", username)
+	}
+	return keys, nil
+}
+
+// GitHubKeyNames returns upload names for n keys imported under base:
+// base itself for a single key, otherwise base-1, base-2, ...
+func GitHubKeyNames(base string, n int) []string {
+	if n == 1 {
+		return []string{base}
+	}
+	names := make([]string, n)
+	for i := range names {
+		names[i] = fmt.Sprintf("%s-%d", base, i+1)
+	}
+	return names
+}
+
+// DefaultGitHubKeyName returns the suggested name base for keys imported
+// from a GitHub user.
+func DefaultGitHubKeyName(username string) string {
+	return "github-" + strings.ToLower(strings.TrimSpace(username))
+}
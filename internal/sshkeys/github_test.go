@@ -0,0 +1,78 @@
+package sshkeys
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func stubGitHub(t *testing.T, handler http.HandlerFunc) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	SetGitHubBaseURL(srv.URL)
+	t.Cleanup(ResetGitHubBaseURL)
+}
+
+func TestFetchGitHubKeys(t *testing.T) {
+	stubGitHub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/octocat.keys" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ssh-ed25519 AAAAC3Nza one\nnot a key\n\nssh-rsa AAAAB3Nza two\n"))
+	})
+
+	keys, err := FetchGitHubKeys(context.Background(), nil, "octocat")
+	if err != nil {
+		t.Fatalf("FetchGitHubKeys failed: %v", err)
+	}
+	want := []string{"ssh-ed25519 AAAAC3Nza one", "ssh-rsa AAAAB3Nza two"}
+	if diff := cmp.Diff(want, keys); diff != "" {
+		t.Errorf("keys mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchGitHubKeys_UserNotFound(t *testing.T) {
+	stubGitHub(t, http.NotFound)
+
+	_, err := FetchGitHubKeys(context.Background(), nil, "nobody")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestFetchGitHubKeys_NoKeys(t *testing.T) {
+	stubGitHub(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := FetchGitHubKeys(context.Background(), nil, "octocat")
+	if err == nil || !strings.Contains(err.Error(), "no public SSH keys") {
+		t.Errorf("expected no keys error, got %v", err)
+	}
+}
+
+func TestValidateGitHubUsername(t *testing.T) {
+	for _, name := range []string{"octocat", "a", "my-user-1"} {
+		if err := ValidateGitHubUsername(name); err != nil {
+			t.Errorf("ValidateGitHubUsername(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "-lead", "trail-", "dou--ble", "has/slash", strings.Repeat("a", 40)} {
+		if err := ValidateGitHubUsername(name); err == nil {
+			t.Errorf("ValidateGitHubUsername(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestGitHubKeyNames(t *testing.T) {
+	if diff := cmp.Diff([]string{"github-octocat"}, GitHubKeyNames("github-octocat", 1)); diff != "" {
+		t.Errorf("single key name mismatch: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"team-1", "team-2"}, GitHubKeyNames("team", 2)); diff != "" {
+		t.Errorf("multiple key names mismatch: %s", diff)
+	}
+}
@@ -0,0 +1,29 @@
+package tagindex
+
+import "time"
+
+// Kinds of resources held in the index.
+const (
+	KindServer    = "server"
+	KindVolume    = "volume"
+	KindBackup    = "backup"
+	KindDNSRecord = "dns-record"
+)
+
+// Resource is a provider resource and the labels it carried when last seen.
+type Resource struct {
+	Provider string
+	Kind     string
+	ID       string
+	Name     string
+	// Parent is what the resource belongs to: the ID of the server a
+	// volume is attached to or a backup was taken of, or a DNS record's
+	// zone. It is empty for servers.
+	Parent string
+	// Content is other searchable text, such as a DNS record's value.
+	Content string
+	Labels  map[string]string
+	// Addresses are the resource's IP addresses, if it has any.
+	Addresses []string
+	SeenAt    time.Time
+}
@@ -0,0 +1,241 @@
+// Package tagindex provides persistent storage for the labels attached to
+// provider resources, so resources can be found by tag across providers
+// without querying each one.
+//
+// Each time a provider's resources of one kind are fetched, the stored set
+// for that (provider, kind) is replaced, so deleted resources and removed
+// labels drop out of the index. Resources fetched per parent, such as a
+// server's backups or a zone's DNS records, replace only that parent's.
+//
+// Storage is backed by a SQLite database at ~/.config/vpsm/vpsm.db
+// (shared with actionstore and serverprefs, separate table).
+package tagindex
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for the tag index.
+type Repository interface {
+	// Replace stores resources as the complete set of the given kind for
+	// provider, removing any previously stored resources not among them.
+	Replace(provider, kind string, resources []Resource) error
+
+	// ReplaceIn is Replace for the resources of kind belonging to parent,
+	// leaving those of other parents in place.
+	ReplaceIn(provider, kind, parent string, resources []Resource) error
+
+	// List returns every indexed resource, ordered by provider, kind and name.
+	List() ([]Resource, error)
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("tagindex: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("tagindex: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("tagindex: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// migrations are the schema versions of the tagged_resources table,
+// oldest first. Add changes as new migrations; never edit one that has
+// shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createResourcesTable},
+}
+
+// migrate brings the tagged_resources table up to the latest schema.
+func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "tagindex", migrations)
+}
+
+// createResourcesTable creates the tagged_resources table. Databases
+// from before schema versioning may hold an older table, so it adds any
+// missing columns too.
+func createResourcesTable(tx *sql.Tx) error {
+	const ddl = `
+		CREATE TABLE IF NOT EXISTS tagged_resources (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider    TEXT NOT NULL,
+			kind        TEXT NOT NULL,
+			resource_id TEXT NOT NULL,
+			name        TEXT NOT NULL DEFAULT '',
+			labels      TEXT NOT NULL DEFAULT '{}',
+			addresses   TEXT NOT NULL DEFAULT '[]',
+			parent      TEXT NOT NULL DEFAULT '',
+			content     TEXT NOT NULL DEFAULT '',
+			seen_at     TEXT NOT NULL,
+			UNIQUE(provider, kind, resource_id)
+		);
+	`
+	if _, err := tx.Exec(ddl); err != nil {
+		return err
+	}
+
+	for _, column := range []string{
+		"addresses TEXT NOT NULL DEFAULT '[]'",
+		"parent TEXT NOT NULL DEFAULT ''",
+		"content TEXT NOT NULL DEFAULT ''",
+	} {
+		_, err := tx.Exec(`ALTER TABLE tagged_resources ADD COLUMN ` + column)
+		if err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace stores resources as the complete set of kind for provider.
+func (r *SQLiteRepository) Replace(provider, kind string, resources []Resource) error {
+	return r.replace(`DELETE FROM tagged_resources WHERE provider = ? AND kind = ?`,
+		[]any{provider, kind}, provider, kind, resources)
+}
+
+// ReplaceIn stores resources as the complete set of kind under parent.
+func (r *SQLiteRepository) ReplaceIn(provider, kind, parent string, resources []Resource) error {
+	for i := range resources {
+		resources[i].Parent = parent
+	}
+	return r.replace(`DELETE FROM tagged_resources WHERE provider = ? AND kind = ? AND parent = ?`,
+		[]any{provider, kind, parent}, provider, kind, resources)
+}
+
+// replace runs the delete statement and inserts resources in one
+// transaction.
+func (r *SQLiteRepository) replace(deleteStmt string, deleteArgs []any, provider, kind string, resources []Resource) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("tagindex: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(deleteStmt, deleteArgs...); err != nil {
+		return fmt.Errorf("tagindex: delete failed: %w", err)
+	}
+	for _, res := range resources {
+		labels, err := json.Marshal(res.Labels)
+		if err != nil {
+			return fmt.Errorf("tagindex: failed to encode labels: %w", err)
+		}
+		addresses, err := json.Marshal(res.Addresses)
+		if err != nil {
+			return fmt.Errorf("tagindex: failed to encode addresses: %w", err)
+		}
+		_, err = tx.Exec(`
+			INSERT INTO tagged_resources (provider, kind, resource_id, name, parent, content, labels, addresses, seen_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			provider, kind, res.ID, res.Name, res.Parent, res.Content, string(labels), string(addresses), res.SeenAt.UTC().Format(time.RFC3339Nano),
+		)
+		if err != nil {
+			return fmt.Errorf("tagindex: insert failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("tagindex: commit failed: %w", err)
+	}
+	return nil
+}
+
+// List returns every indexed resource.
+func (r *SQLiteRepository) List() ([]Resource, error) {
+	rows, err := r.db.Query(`
+		SELECT provider, kind, resource_id, name, parent, content, labels, addresses, seen_at
+		FROM tagged_resources
+		ORDER BY provider, kind, name, resource_id`)
+	if err != nil {
+		return nil, fmt.Errorf("tagindex: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var resources []Resource
+	for rows.Next() {
+		var res Resource
+		var labels, addresses, seenStr string
+		if err := rows.Scan(&res.Provider, &res.Kind, &res.ID, &res.Name, &res.Parent, &res.Content, &labels, &addresses, &seenStr); err != nil {
+			return nil, fmt.Errorf("tagindex: scan failed: %w", err)
+		}
+		if err := json.Unmarshal([]byte(labels), &res.Labels); err != nil {
+			return nil, fmt.Errorf("tagindex: failed to decode labels: %w", err)
+		}
+		if err := json.Unmarshal([]byte(addresses), &res.Addresses); err != nil {
+			return nil, fmt.Errorf("tagindex: failed to decode addresses: %w", err)
+		}
+		res.SeenAt, _ = time.Parse(time.RFC3339Nano, seenStr)
+		resources = append(resources, res)
+	}
+	return resources, rows.Err()
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,156 @@
+package tagindex
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	r, err := OpenAt(filepath.Join(t.TempDir(), "vpsm.db"))
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestReplace_RoundTrip(t *testing.T) {
+	r := tempRepo(t)
+	seen := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := r.Replace("hetzner", KindServer, []Resource{
+		{ID: "1", Name: "web-1", Labels: map[string]string{"env": "prod"}, Addresses: []string{"192.0.2.1"}, SeenAt: seen},
+	})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	got, err := r.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(got))
+	}
+	res := got[0]
+	if res.Provider != "hetzner" || res.Kind != KindServer || res.ID != "1" || res.Name != "web-1" {
+		t.Errorf("unexpected resource %+v", res)
+	}
+	if res.Labels["env"] != "prod" {
+		t.Errorf("expected env=prod label, got %v", res.Labels)
+	}
+	if len(res.Addresses) != 1 || res.Addresses[0] != "192.0.2.1" {
+		t.Errorf("expected address 192.0.2.1, got %v", res.Addresses)
+	}
+	if !res.SeenAt.Equal(seen) {
+		t.Errorf("SeenAt = %v, want %v", res.SeenAt, seen)
+	}
+}
+
+func TestReplace_DropsMissingResources(t *testing.T) {
+	r := tempRepo(t)
+	now := time.Now()
+
+	r.Replace("hetzner", KindServer, []Resource{{ID: "1", SeenAt: now}, {ID: "2", SeenAt: now}})
+	r.Replace("other", KindServer, []Resource{{ID: "9", SeenAt: now}})
+	if err := r.Replace("hetzner", KindServer, []Resource{{ID: "2", SeenAt: now}}); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	got, err := r.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 resources, got %+v", got)
+	}
+	if got[0].ID != "2" || got[1].Provider != "other" {
+		t.Errorf("expected server 2 and the other provider's server to remain, got %+v", got)
+	}
+}
+
+func TestReplaceIn_KeepsOtherParents(t *testing.T) {
+	r := tempRepo(t)
+	now := time.Now()
+
+	r.ReplaceIn("hetzner", KindBackup, "1", []Resource{{ID: "b1", SeenAt: now}, {ID: "b2", SeenAt: now}})
+	r.ReplaceIn("hetzner", KindBackup, "2", []Resource{{ID: "b3", SeenAt: now}})
+	if err := r.ReplaceIn("hetzner", KindBackup, "1", []Resource{{ID: "b2", SeenAt: now}}); err != nil {
+		t.Fatalf("ReplaceIn failed: %v", err)
+	}
+
+	got, err := r.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	var ids []string
+	for _, res := range got {
+		ids = append(ids, res.Parent+"/"+res.ID)
+	}
+	if strings.Join(ids, ",") != "1/b2,2/b3" {
+		t.Errorf("expected backups 1/b2 and 2/b3 to remain, got %v", ids)
+	}
+}
+
+func TestOpenAt_UpgradesUnversionedTable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE tagged_resources (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			provider    TEXT NOT NULL,
+			kind        TEXT NOT NULL,
+			resource_id TEXT NOT NULL,
+			name        TEXT NOT NULL DEFAULT '',
+			labels      TEXT NOT NULL DEFAULT '{}',
+			seen_at     TEXT NOT NULL,
+			UNIQUE(provider, kind, resource_id)
+		);
+		INSERT INTO tagged_resources (provider, kind, resource_id, name, labels, seen_at)
+		VALUES ('hetzner', 'server', '1', 'web-1', '{"env":"prod"}', '2026-01-02T03:04:05Z');`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	defer r.Close()
+
+	got, err := r.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "web-1" || got[0].Labels["env"] != "prod" {
+		t.Errorf("unexpected resources after upgrade: %+v", got)
+	}
+}
+
+func TestOpenAt_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	_, err = r.db.Exec(`UPDATE schema_versions SET version = version + 1 WHERE store = 'tagindex'`)
+	r.Close()
+	if err != nil {
+		t.Fatalf("failed to bump version: %v", err)
+	}
+
+	if _, err := OpenAt(path); !errors.Is(err, schema.ErrTooNew) {
+		t.Errorf("err = %v, want ErrTooNew", err)
+	}
+}
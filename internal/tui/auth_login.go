@@ -1,11 +1,15 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/services/tokencheck"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -15,6 +19,14 @@ import (
 
 // --- Messages ---
 
+// tokenVerifiedMsg carries the outcome of verifying the entered token.
+// A token no provider can verify arrives with a nil result and err.
+type tokenVerifiedMsg struct {
+	token  string
+	result *tokencheck.Result
+	err    error
+}
+
 type tokenSavedMsg struct{}
 
 type tokenSaveErrorMsg struct {
@@ -26,20 +38,26 @@ type tokenSaveErrorMsg struct {
 type authLoginModel struct {
 	provider string
 	store    auth.Store
+	verify   func(ctx context.Context, provider, token string) (*tokencheck.Result, error)
 
 	tokenInput textinput.Model
 
 	width  int
 	height int
 
-	err      error
-	saved    bool
-	quitting bool
+	err       error
+	verifying bool
+	verified  *tokencheck.Result
+	saved     bool
+	quitting  bool
 }
 
 // AuthLoginResult holds the outcome of the login TUI.
 type AuthLoginResult struct {
 	Saved bool
+	// Verified describes what the token gives access to. It is nil when
+	// no provider could verify the token.
+	Verified *tokencheck.Result
 }
 
 // RunAuthLogin starts the interactive auth login TUI.
@@ -48,16 +66,13 @@ func RunAuthLogin(provider string, store auth.Store) (*AuthLoginResult, error) {
 	ti.Placeholder = "paste your API token here"
 	ti.Focus()
 	ti.EchoMode = textinput.EchoPassword
-	ti.EchoCharacter = '*'
+	ti.EchoCharacter = styles.EchoRune()
 	ti.Width = 50
 
-	m := authLoginModel{
-		provider:   provider,
-		store:      store,
-		tokenInput: ti,
-	}
+	m := newAuthLoginModel(provider, store)
+	m.tokenInput = ti
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	result, err := p.Run()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run auth login: %w", err)
@@ -67,7 +82,16 @@ func RunAuthLogin(provider string, store auth.Store) (*AuthLoginResult, error) {
 	if final.quitting && !final.saved {
 		return nil, nil
 	}
-	return &AuthLoginResult{Saved: final.saved}, nil
+	return &AuthLoginResult{Saved: final.saved, Verified: final.verified}, nil
+}
+
+func newAuthLoginModel(provider string, store auth.Store) authLoginModel {
+	return authLoginModel{
+		provider:   provider,
+		store:      store,
+		verify:     tokencheck.Verify,
+		tokenInput: textinput.New(),
+	}
 }
 
 func (m authLoginModel) Init() tea.Cmd {
@@ -84,11 +108,22 @@ func (m authLoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		return m.handleKey(msg)
 
+	case tokenVerifiedMsg:
+		if msg.err != nil && !errors.Is(msg.err, tokencheck.ErrUnsupported) {
+			m.verifying = false
+			m.err = msg.err
+			return m, nil
+		}
+		m.verified = msg.result
+		return m, m.saveToken(msg.token)
+
 	case tokenSavedMsg:
+		m.verifying = false
 		m.saved = true
-		return m, tea.Quit
+		return m, nil
 
 	case tokenSaveErrorMsg:
+		m.verifying = false
 		m.err = msg.err
 		return m, nil
 	}
@@ -99,18 +134,30 @@ func (m authLoginModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m authLoginModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.saved {
+		// The result is on screen; any key closes it.
+		return m, tea.Quit
+	}
+
 	switch msg.String() {
 	case "ctrl+c", "esc":
 		m.quitting = true
 		return m, tea.Quit
 	case "enter":
+		if m.verifying {
+			return m, nil
+		}
 		token := strings.TrimSpace(m.tokenInput.Value())
 		if token == "" {
 			m.err = fmt.Errorf("token cannot be empty")
 			return m, nil
 		}
 		m.err = nil
-		return m, m.saveToken(token)
+		m.verifying = true
+		return m, m.verifyToken(token)
+	}
+	if m.verifying {
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -119,6 +166,14 @@ func (m authLoginModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// verifyToken makes one read request with the token before it is saved.
+func (m authLoginModel) verifyToken(token string) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.verify(context.Background(), m.provider, token)
+		return tokenVerifiedMsg{token: token, result: result, err: err}
+	}
+}
+
 func (m authLoginModel) saveToken(token string) tea.Cmd {
 	return func() tea.Msg {
 		if err := m.store.SetToken(m.provider, token); err != nil {
@@ -135,9 +190,12 @@ func (m authLoginModel) View() string {
 
 	header := components.Header(m.width, "auth login", m.provider)
 	footerBindings := []components.KeyBinding{
-		{Key: "enter", Desc: "save"},
+		{Key: "enter", Desc: "verify & save"},
 		{Key: "esc", Desc: "cancel"},
 	}
+	if m.saved {
+		footerBindings = []components.KeyBinding{{Key: "any key", Desc: "close"}}
+	}
 	footer := components.Footer(m.width, footerBindings)
 
 	headerH := lipgloss.Height(header)
@@ -153,27 +211,51 @@ func (m authLoginModel) View() string {
 }
 
 func (m authLoginModel) renderContent(height int) string {
-	title := styles.Title.Render("API Token")
-	hint := styles.MutedText.Render("Enter your " + m.provider + " API token")
-
-	inputView := m.tokenInput.View()
+	var card string
+	if m.saved {
+		card = m.renderSaved()
+	} else {
+		title := styles.Title.Render("API Token")
+		hint := styles.MutedText.Render("Enter your " + m.provider + " API token. It is checked with the provider before it is saved.")
+
+		inputView := m.tokenInput.View()
+
+		var statusLine string
+		switch {
+		case m.verifying:
+			statusLine = "\n" + styles.MutedText.Render("Verifying token"+styles.Ellipsis())
+		case m.err != nil:
+			statusLine = "\n" + styles.ErrorText.Render(m.err.Error())
+		}
 
-	var errLine string
-	if m.err != nil {
-		errLine = "\n" + styles.ErrorText.Render(m.err.Error())
+		card = lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			hint,
+			"",
+			inputView,
+			statusLine,
+		)
 	}
 
-	card := lipgloss.JoinVertical(lipgloss.Left,
-		title,
-		hint,
-		"",
-		inputView,
-		errLine,
-	)
-
 	return lipgloss.Place(
 		m.width, height,
 		lipgloss.Center, lipgloss.Center,
 		card,
 	)
 }
+
+// renderSaved reports what the saved token gives access to.
+func (m authLoginModel) renderSaved() string {
+	if m.verified == nil {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			styles.WarningText.Render(styles.Warning()+" Token saved without verification"),
+			styles.MutedText.Render("vpsm cannot check "+m.provider+" tokens; it is used as entered."),
+		)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left,
+		styles.SuccessText.Render(styles.Check()+" Token accepted and saved"),
+		"",
+		styles.Value.Render(m.verified.String()),
+		styles.MutedText.Render("Read access confirmed. Write access is checked on the first change."),
+	)
+}
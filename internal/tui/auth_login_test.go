@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"nathanbeddoewebdev/vpsm/internal/services/auth"
+	"nathanbeddoewebdev/vpsm/internal/services/tokencheck"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// submitToken types token into the login model, presses enter and runs
+// the verification and save commands that follow.
+func submitToken(t *testing.T, m authLoginModel, token string) authLoginModel {
+	t.Helper()
+	m.tokenInput.SetValue(token)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(authLoginModel)
+	if !m.verifying || cmd == nil {
+		t.Fatal("expected enter to start verifying the token")
+	}
+	for cmd != nil {
+		updated, cmd = m.Update(cmd())
+		m = updated.(authLoginModel)
+	}
+	return m
+}
+
+func TestAuthLogin_RejectedTokenIsNotSaved(t *testing.T) {
+	store := auth.NewMockStore()
+	m := newAuthLoginModel("hetzner", store)
+	m.verify = func(context.Context, string, string) (*tokencheck.Result, error) {
+		return nil, errors.New("Hetzner rejected the token")
+	}
+
+	m = submitToken(t, m, "bad")
+	if m.saved || m.verifying || m.err == nil {
+		t.Errorf("saved = %v, verifying = %v, err = %v; want the rejection shown", m.saved, m.verifying, m.err)
+	}
+	if _, err := store.GetToken("hetzner"); !errors.Is(err, auth.ErrTokenNotFound) {
+		t.Errorf("expected no stored token, got err %v", err)
+	}
+}
+
+func TestAuthLogin_VerifiedTokenIsSaved(t *testing.T) {
+	store := auth.NewMockStore()
+	m := newAuthLoginModel("hetzner", store)
+	verified := &tokencheck.Result{Provider: "Hetzner", Kind: "servers", Names: []string{"web"}}
+	m.verify = func(_ context.Context, provider, token string) (*tokencheck.Result, error) {
+		if provider != "hetzner" || token != "good" {
+			t.Errorf("verify(%q, %q)", provider, token)
+		}
+		return verified, nil
+	}
+
+	m = submitToken(t, m, "good")
+	if !m.saved || m.verified != verified {
+		t.Fatalf("saved = %v, verified = %v; want the token saved with its result", m.saved, m.verified)
+	}
+	if token, _ := store.GetToken("hetzner"); token != "good" {
+		t.Errorf("stored token = %q, want good", token)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	providernames "nathanbeddoewebdev/vpsm/internal/platform/providers/names"
 	"nathanbeddoewebdev/vpsm/internal/services/auth"
 	"nathanbeddoewebdev/vpsm/internal/tui/components"
+	"nathanbeddoewebdev/vpsm/internal/tui/crashguard"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -54,7 +55,7 @@ func RunAuthStatus(store auth.Store) error {
 		statuses: statuses,
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	p := crashguard.NewProgram(m, styles.ProgramOptions()...)
 	_, err := p.Run()
 	return err
 }
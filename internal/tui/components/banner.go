@@ -0,0 +1,23 @@
+package components
+
+import (
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Banner renders a single-line warning banner, truncated to fit the width.
+//
+//	⚠ Hetzner: Cloud API degraded
+func Banner(width int, message string) string {
+	if message == "" || width < 10 {
+		return ""
+	}
+
+	text := ansi.Truncate(styles.Warning()+" "+message, width-4, styles.Ellipsis())
+	return lipgloss.NewStyle().
+		Width(width).
+		Padding(0, 2).
+		Render(styles.WarningText.Render(text))
+}
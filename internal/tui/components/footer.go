@@ -3,6 +3,7 @@ package components
 import (
 	"strings"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/lipgloss"
@@ -23,7 +24,7 @@ func Footer(width int, bindings []KeyBinding) string {
 	sep := styles.KeySepStyle.Render("  ")
 	parts := make([]string, len(bindings))
 	for i, b := range bindings {
-		parts[i] = styles.FormatKeyBinding(b.Key, b.Desc)
+		parts[i] = styles.FormatKeyBinding(b.Key, i18n.T(b.Desc))
 	}
 
 	content := strings.Join(parts, sep)
@@ -31,7 +32,7 @@ func Footer(width int, bindings []KeyBinding) string {
 	bar := lipgloss.NewStyle().
 		Width(width).
 		Padding(0, 2).
-		BorderStyle(lipgloss.Border{Top: "─"}).
+		BorderStyle(lipgloss.Border{Top: styles.HLine()}).
 		BorderTop(true).
 		BorderForeground(styles.DimGray).
 		Render(content)
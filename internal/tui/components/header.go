@@ -6,6 +6,7 @@ package components
 import (
 	"strings"
 
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
 	"github.com/charmbracelet/lipgloss"
@@ -14,17 +15,26 @@ import (
 // Header renders the application header bar.
 //
 //	┌──────────────────────────────────────────┐
-//	│  vpsm > server list            Hetzner   │
+//	│  vpsm › server list            Hetzner   │
 //	└──────────────────────────────────────────┘
 func Header(width int, breadcrumb string, provider string) string {
-	if width < 10 {
-		return ""
+	if breadcrumb == "" {
+		return HeaderTrail(width, nil, provider)
 	}
+	return HeaderTrail(width, []string{breadcrumb}, provider)
+}
 
-	leftStyle := styles.Title.Foreground(styles.Blue)
-	left := leftStyle.Render("vpsm")
-	if breadcrumb != "" {
-		left += styles.MutedText.Render(" > ") + styles.Title.Render(breadcrumb)
+// HeaderTrail renders the header bar with a breadcrumb trail reflecting
+// where the user has navigated, ending with the current view:
+//
+//	│  vpsm › servers › web-1 › logs         Hetzner   │
+//
+// Segments are translated, so fixed view names should be passed in
+// English. When the trail does not fit, its leading segments collapse
+// into an ellipsis.
+func HeaderTrail(width int, trail []string, provider string) string {
+	if width < 10 {
+		return ""
 	}
 
 	right := ""
@@ -32,10 +42,15 @@ func Header(width int, breadcrumb string, provider string) string {
 		right = styles.Subtitle.Render(provider)
 	}
 
+	innerWidth := width - 4 // account for padding
+	left := renderTrail(trail, 0)
+	for skip := 1; skip < len(trail) && lipgloss.Width(left)+lipgloss.Width(right)+1 > innerWidth; skip++ {
+		left = renderTrail(trail, skip)
+	}
+
 	// Calculate spacing between left and right.
 	leftLen := lipgloss.Width(left)
 	rightLen := lipgloss.Width(right)
-	innerWidth := width - 4 // account for padding
 	gap := innerWidth - leftLen - rightLen
 	if gap < 1 {
 		gap = 1
@@ -46,10 +61,30 @@ func Header(width int, breadcrumb string, provider string) string {
 	bar := lipgloss.NewStyle().
 		Width(width).
 		Padding(0, 2).
-		BorderStyle(lipgloss.Border{Bottom: "─"}).
+		BorderStyle(lipgloss.Border{Bottom: styles.HLine()}).
 		BorderBottom(true).
 		BorderForeground(styles.DimGray).
 		Render(content)
 
 	return bar
 }
+
+// renderTrail renders "vpsm" followed by trail, replacing its first skip
+// segments with an ellipsis. The last segment is highlighted as the
+// current view.
+func renderTrail(trail []string, skip int) string {
+	sep := styles.MutedText.Render(" " + styles.Breadcrumb() + " ")
+	left := styles.Title.Foreground(styles.Blue).Render("vpsm")
+	if skip > 0 {
+		left += sep + styles.MutedText.Render(styles.Ellipsis())
+	}
+	for i := skip; i < len(trail); i++ {
+		segment := i18n.T(trail[i])
+		if i == len(trail)-1 {
+			left += sep + styles.Title.Render(segment)
+		} else {
+			left += sep + styles.MutedText.Render(segment)
+		}
+	}
+	return left
+}
@@ -0,0 +1,39 @@
+package components
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestHeaderTrail(t *testing.T) {
+	got := ansi.Strip(HeaderTrail(80, []string{"servers", "web-1", "logs"}, "Hetzner"))
+
+	if !strings.Contains(got, "vpsm › servers › web-1 › logs") {
+		t.Errorf("expected the full trail, got:\n%s", got)
+	}
+	if !strings.Contains(got, "Hetzner") {
+		t.Errorf("expected the provider, got:\n%s", got)
+	}
+}
+
+func TestHeaderTrail_CollapsesLeadingSegments(t *testing.T) {
+	trail := []string{"servers", "a-very-long-server-name-for-testing", "floating IPs"}
+	got := ansi.Strip(HeaderTrail(50, trail, "Hetzner"))
+	first := strings.Split(got, "\n")[0]
+
+	if strings.Contains(first, "servers") {
+		t.Errorf("expected the leading segment to collapse, got:\n%s", first)
+	}
+	if !strings.Contains(first, "vpsm › … › ") || !strings.Contains(first, "floating IPs") {
+		t.Errorf("expected an ellipsis and the current view, got:\n%s", first)
+	}
+}
+
+func TestHeader_SingleBreadcrumb(t *testing.T) {
+	got := ansi.Strip(Header(80, "config", ""))
+	if !strings.Contains(got, "vpsm › config") {
+		t.Errorf("expected the breadcrumb, got:\n%s", got)
+	}
+}
@@ -3,6 +3,9 @@ package components
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"nathanbeddoewebdev/vpsm/internal/tui/styles"
 
@@ -29,24 +32,72 @@ type DualChartColors struct {
 	Color2 lipgloss.AdaptiveColor
 }
 
+// ChartOptions adjusts how a metrics chart is drawn. The zero value draws
+// the last hour with abbreviated values.
+type ChartOptions struct {
+	// Span is the time range the X axis covers, ending now. Zero means one
+	// hour.
+	Span time.Duration
+	// Raw shows exact values (1534.5) on the Y axis and in the summaries
+	// instead of abbreviated ones (1.5K).
+	Raw bool
+}
+
+// span returns the X axis range, defaulting to one hour.
+func (o ChartOptions) span() time.Duration {
+	if o.Span <= 0 {
+		return time.Hour
+	}
+	return o.Span
+}
+
+// axisFormat returns the formatter for Y axis labels.
+func (o ChartOptions) axisFormat() func(float64, string) string {
+	if o.Raw {
+		return formatRaw
+	}
+	return formatCompact
+}
+
+// summaryFormat returns the formatter for summary values.
+func (o ChartOptions) summaryFormat() func(float64, string) string {
+	if o.Raw {
+		return formatRaw
+	}
+	return formatSummary
+}
+
 // --- Formatters ---
 
-// timeXFormatter returns an XLabelFormatter that maps X values (in minutes)
-// to human-readable time labels like "-60m", "-30m", "now".
-func timeXFormatter() func(int, float64) string {
+// timeUnit picks the unit the X axis of a span is labelled in: minutes up
+// to two hours, hours up to two days, days beyond.
+func timeUnit(span time.Duration) (time.Duration, string) {
+	switch {
+	case span <= 2*time.Hour:
+		return time.Minute, "m"
+	case span <= 48*time.Hour:
+		return time.Hour, "h"
+	default:
+		return 24 * time.Hour, "d"
+	}
+}
+
+// timeXFormatter returns an XLabelFormatter that maps X values (in the
+// given unit) to human-readable time labels like "-60m", "-6h", "now".
+func timeXFormatter(unit string) func(int, float64) string {
 	return func(_ int, v float64) string {
-		m := int(math.Round(v))
-		if m == 0 {
+		n := int(math.Round(v))
+		if n == 0 {
 			return "now"
 		}
-		return fmt.Sprintf("%dm", m)
+		return fmt.Sprintf("%d%s", n, unit)
 	}
 }
 
 // yFormatter returns a YLabelFormatter for axis tick labels.
-func yFormatter(suffix string) func(int, float64) string {
+func yFormatter(suffix string, format func(float64, string) string) func(int, float64) string {
 	return func(_ int, v float64) string {
-		return formatCompact(v, suffix)
+		return format(v, suffix)
 	}
 }
 
@@ -83,6 +134,13 @@ func formatSummary(v float64, suffix string) string {
 	}
 }
 
+// formatRaw renders a value unabbreviated, with at most two decimals.
+func formatRaw(v float64, suffix string) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	return s + suffix
+}
+
 // trimTrailingZero removes ".0" from formatted numbers (e.g., "3.0" -> "3").
 func trimTrailingZero(s string) string {
 	if len(s) >= 2 && s[len(s)-2:] == ".0" {
@@ -93,23 +151,23 @@ func trimTrailingZero(s string) string {
 
 // renderSummary builds a summary line with muted labels and white values.
 // e.g. "  cur: 1.2%  min: 0.5%  max: 3.8%"
-func renderSummary(cur, min, max float64, suffix string) string {
+func renderSummary(cur, min, max float64, suffix string, format func(float64, string) string) string {
 	muted := styles.MutedText
 	val := styles.Value
 	return "  " +
-		muted.Render("cur: ") + val.Render(formatSummary(cur, suffix)) + "  " +
-		muted.Render("min: ") + val.Render(formatSummary(min, suffix)) + "  " +
-		muted.Render("max: ") + val.Render(formatSummary(max, suffix))
+		muted.Render("cur: ") + val.Render(format(cur, suffix)) + "  " +
+		muted.Render("min: ") + val.Render(format(min, suffix)) + "  " +
+		muted.Render("max: ") + val.Render(format(max, suffix))
 }
 
 // renderLegendSummary builds a summary line with a colored legend prefix.
-func renderLegendSummary(legend string, legendStyle lipgloss.Style, cur, min, max float64, suffix string) string {
+func renderLegendSummary(legend string, legendStyle lipgloss.Style, cur, min, max float64, suffix string, format func(float64, string) string) string {
 	muted := styles.MutedText
 	val := styles.Value
 	return "  " + legendStyle.Render(legend) + "  " +
-		muted.Render("cur: ") + val.Render(formatSummary(cur, suffix)) + "  " +
-		muted.Render("min: ") + val.Render(formatSummary(min, suffix)) + "  " +
-		muted.Render("max: ") + val.Render(formatSummary(max, suffix))
+		muted.Render("cur: ") + val.Render(format(cur, suffix)) + "  " +
+		muted.Render("min: ") + val.Render(format(min, suffix)) + "  " +
+		muted.Render("max: ") + val.Render(format(max, suffix))
 }
 
 // padDataLeft prepends zeros so that len(data) >= graphWidth,
@@ -127,7 +185,7 @@ func padDataLeft(data []float64, graphWidth int) []float64 {
 // --- Chart constructors ---
 
 // newChart creates a single-series streamlinechart with axes and data.
-func newChart(width, height int, data []float64, suffix string, lineStyle lipgloss.Style) slc.Model {
+func newChart(width, height int, data []float64, suffix string, lineStyle lipgloss.Style, opts ChartOptions) slc.Model {
 	_, maxVal := minMax(data)
 	if maxVal == 0 {
 		maxVal = 1
@@ -136,16 +194,17 @@ func newChart(width, height int, data []float64, suffix string, lineStyle lipglo
 
 	axisStyle := lipgloss.NewStyle().Foreground(styles.DimGray)
 	labelStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	unit, unitLabel := timeUnit(opts.span())
 
 	chart := slc.New(width, height,
 		slc.WithYRange(0, yMax),
-		slc.WithXRange(-60, 0),
+		slc.WithXRange(-float64(opts.span()/unit), 0),
 		slc.WithXYSteps(xSteps, ySteps),
 		slc.WithStyles(runes.ArcLineStyle, lineStyle),
 		slc.WithAxesStyles(axisStyle, labelStyle),
 	)
-	chart.YLabelFormatter = yFormatter(suffix)
-	chart.XLabelFormatter = timeXFormatter()
+	chart.YLabelFormatter = yFormatter(suffix, opts.axisFormat())
+	chart.XLabelFormatter = timeXFormatter(unitLabel)
 	chart.UpdateGraphSizes()
 
 	// Pad data to fill the full graphing area so the line spans left to right.
@@ -161,7 +220,7 @@ func newChart(width, height int, data []float64, suffix string, lineStyle lipglo
 
 // newDualChart creates a streamlinechart with two named datasets.
 // Series1 uses ArcLineStyle, series2 uses ThinLineStyle for visual distinction.
-func newDualChart(width, height int, s1, s2 []float64, name1, name2 string, suffix string, style1, style2 lipgloss.Style) slc.Model {
+func newDualChart(width, height int, s1, s2 []float64, name1, name2 string, suffix string, style1, style2 lipgloss.Style, opts ChartOptions) slc.Model {
 	_, max1 := minMax(s1)
 	_, max2 := minMax(s2)
 	maxVal := math.Max(max1, max2)
@@ -172,18 +231,19 @@ func newDualChart(width, height int, s1, s2 []float64, name1, name2 string, suff
 
 	axisStyle := lipgloss.NewStyle().Foreground(styles.DimGray)
 	labelStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	unit, unitLabel := timeUnit(opts.span())
 
 	chart := slc.New(width, height,
 		slc.WithYRange(0, yMax),
-		slc.WithXRange(-60, 0),
+		slc.WithXRange(-float64(opts.span()/unit), 0),
 		slc.WithXYSteps(xSteps, ySteps),
 		slc.WithStyles(runes.ArcLineStyle, style1),
 		slc.WithAxesStyles(axisStyle, labelStyle),
 		slc.WithDataSetStyles(name1, runes.ArcLineStyle, style1),
 		slc.WithDataSetStyles(name2, runes.ThinLineStyle, style2),
 	)
-	chart.YLabelFormatter = yFormatter(suffix)
-	chart.XLabelFormatter = timeXFormatter()
+	chart.YLabelFormatter = yFormatter(suffix, opts.axisFormat())
+	chart.XLabelFormatter = timeXFormatter(unitLabel)
 	chart.UpdateGraphSizes()
 
 	// Pad both series to fill the full graphing area.
@@ -205,6 +265,11 @@ func newDualChart(width, height int, s1, s2 []float64, name1, name2 string, suff
 
 // MetricsChart renders a single-series line chart with axes and a label header.
 func MetricsChart(label string, data []float64, width int, suffix string) string {
+	return MetricsChartWith(label, data, width, suffix, ChartOptions{})
+}
+
+// MetricsChartWith is MetricsChart with a custom time span and value format.
+func MetricsChartWith(label string, data []float64, width int, suffix string, opts ChartOptions) string {
 	if len(data) == 0 {
 		return styles.MutedText.Render(label + ": no data")
 	}
@@ -214,20 +279,30 @@ func MetricsChart(label string, data []float64, width int, suffix string) string
 		chartWidth = 20
 	}
 
-	lineStyle := lipgloss.NewStyle().Foreground(styles.Blue)
-	chart := newChart(chartWidth, chartHeight, data, suffix, lineStyle)
-
 	current := data[len(data)-1]
 	min, max := minMax(data)
-	summary := renderSummary(current, min, max, suffix)
+	summary := renderSummary(current, min, max, suffix, opts.summaryFormat())
 
 	header := styles.Label.Render(label)
+	if styles.ASCII() {
+		// Line charts are drawn with box-drawing runes; keep to the numbers.
+		return lipgloss.JoinVertical(lipgloss.Left, header, summary)
+	}
+
+	lineStyle := lipgloss.NewStyle().Foreground(styles.Blue)
+	chart := newChart(chartWidth, chartHeight, data, suffix, lineStyle, opts)
 	return lipgloss.JoinVertical(lipgloss.Left, header, chart.View(), summary)
 }
 
 // MetricsDualChart renders two overlaid series on a single chart with shared
 // axes, per-series legends, and summaries. Colors are specified by the caller.
 func MetricsDualChart(label string, series1, series2 []float64, legend1, legend2 string, width int, suffix string, colors DualChartColors) string {
+	return MetricsDualChartWith(label, series1, series2, legend1, legend2, width, suffix, colors, ChartOptions{})
+}
+
+// MetricsDualChartWith is MetricsDualChart with a custom time span and
+// value format.
+func MetricsDualChartWith(label string, series1, series2 []float64, legend1, legend2 string, width int, suffix string, colors DualChartColors, opts ChartOptions) string {
 	if len(series1) == 0 && len(series2) == 0 {
 		return styles.MutedText.Render(label + ": no data")
 	}
@@ -251,7 +326,7 @@ func MetricsDualChart(label string, series1, series2 []float64, legend1, legend2
 
 	style1 := lipgloss.NewStyle().Foreground(colors.Color1)
 	style2 := lipgloss.NewStyle().Foreground(colors.Color2)
-	chart := newDualChart(chartWidth, dualChartHeight, series1, series2, legend1, legend2, suffix, style1, style2)
+	chart := newDualChart(chartWidth, dualChartHeight, series1, series2, legend1, legend2, suffix, style1, style2, opts)
 
 	// Per-series summary lines with colored legend labels.
 	legendStyle1 := lipgloss.NewStyle().Foreground(colors.Color1).Bold(true)
@@ -262,19 +337,22 @@ func MetricsDualChart(label string, series1, series2 []float64, legend1, legend2
 		cur1 := series1[len(series1)-1]
 		min1, max1 := minMax(series1)
 		summaryParts = append(summaryParts,
-			renderLegendSummary(legend1, legendStyle1, cur1, min1, max1, suffix),
+			renderLegendSummary(legend1, legendStyle1, cur1, min1, max1, suffix, opts.summaryFormat()),
 		)
 	}
 	if !orig2Empty {
 		cur2 := series2[len(series2)-1]
 		min2, max2 := minMax(series2)
 		summaryParts = append(summaryParts,
-			renderLegendSummary(legend2, legendStyle2, cur2, min2, max2, suffix),
+			renderLegendSummary(legend2, legendStyle2, cur2, min2, max2, suffix, opts.summaryFormat()),
 		)
 	}
 
 	header := styles.Label.Render(label)
-	sections := []string{header, chart.View()}
+	sections := []string{header}
+	if !styles.ASCII() {
+		sections = append(sections, chart.View())
+	}
 	sections = append(sections, summaryParts...)
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
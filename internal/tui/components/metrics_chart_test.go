@@ -0,0 +1,46 @@
+package components
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeUnit_PicksLabelUnitForSpan(t *testing.T) {
+	tests := []struct {
+		span time.Duration
+		unit time.Duration
+		want string
+	}{
+		{time.Hour, time.Minute, "-30m"},
+		{6 * time.Hour, time.Hour, "-3h"},
+		{24 * time.Hour, time.Hour, "-3h"},
+		{7 * 24 * time.Hour, 24 * time.Hour, "-3d"},
+	}
+	for _, tt := range tests {
+		unit, label := timeUnit(tt.span)
+		if unit != tt.unit {
+			t.Errorf("timeUnit(%v) = %v, want %v", tt.span, unit, tt.unit)
+		}
+		n := -30.0
+		if unit != time.Minute {
+			n = -3
+		}
+		if got := timeXFormatter(label)(0, n); got != tt.want {
+			t.Errorf("label for %v = %q, want %q", tt.span, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRaw(t *testing.T) {
+	tests := map[float64]string{
+		1534.5:    "1534.5B/s",
+		2_000_000: "2000000B/s",
+		0.25:      "0.25B/s",
+		0:         "0B/s",
+	}
+	for v, want := range tests {
+		if got := formatRaw(v, "B/s"); got != want {
+			t.Errorf("formatRaw(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
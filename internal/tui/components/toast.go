@@ -0,0 +1,25 @@
+package components
+
+import (
+	"strings"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/i18n"
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// UndoDivider renders an undo toast for summary (e.g. `"web-1" backups
+// enabled`) as a footer's top border, offering u to undo. It is empty
+// when the width cannot fit it.
+func UndoDivider(width int, summary string) string {
+	label := " " + styles.Value.Render(summary) + " " +
+		styles.MutedText.Render(styles.Middot()) + " " +
+		styles.AccentText.Render("u") + " " + styles.MutedText.Render(i18n.T("undo")) + " "
+	fill := width - lipgloss.Width(label) - 2
+	if fill < 1 {
+		return ""
+	}
+	line := lipgloss.NewStyle().Foreground(styles.DimGray)
+	return line.Render(strings.Repeat(styles.HLine(), 2)) + label + line.Render(strings.Repeat(styles.HLine(), fill))
+}
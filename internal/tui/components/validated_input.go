@@ -0,0 +1,170 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/tui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// asyncValidationTimeout bounds how long an async validator may run.
+const asyncValidationTimeout = 10 * time.Second
+
+// ValidateFunc checks a (trimmed) input value and returns a user-facing
+// error when it is invalid.
+type ValidateFunc func(value string) error
+
+// AsyncValidateFunc checks a value against something that needs I/O, such
+// as name uniqueness against the provider's servers. It runs in a tea.Cmd
+// after all synchronous validators pass.
+type AsyncValidateFunc func(ctx context.Context, value string) error
+
+// InputValidatedMsg is emitted once a submitted value passes every
+// validator. ID identifies the input that produced it.
+type InputValidatedMsg struct {
+	ID    string
+	Value string
+}
+
+// asyncValidationMsg carries the result of an async validator back to the
+// input that started it.
+type asyncValidationMsg struct {
+	id    string
+	seq   int
+	value string
+	err   error
+}
+
+// ValidatedInput wraps a textinput with a chain of validators and renders
+// the first failure inline below the field.
+//
+// Owners forward messages to Update, call Submit when the user confirms,
+// and advance when an InputValidatedMsg with their ID arrives.
+type ValidatedInput struct {
+	Input textinput.Model
+
+	id         string
+	validators []ValidateFunc
+	async      AsyncValidateFunc
+
+	err     string
+	pending bool
+	// seq increments on every edit so that results of async checks for
+	// an older value are discarded.
+	seq int
+}
+
+// NewValidatedInput returns a ValidatedInput around input. Validators run
+// in order on the trimmed value; the first error is shown.
+func NewValidatedInput(id string, input textinput.Model, validators ...ValidateFunc) ValidatedInput {
+	return ValidatedInput{Input: input, id: id, validators: validators}
+}
+
+// WithAsync sets a validator that runs after the synchronous ones pass.
+func (v ValidatedInput) WithAsync(fn AsyncValidateFunc) ValidatedInput {
+	v.async = fn
+	return v
+}
+
+// Value returns the trimmed input value.
+func (v ValidatedInput) Value() string {
+	return strings.TrimSpace(v.Input.Value())
+}
+
+// Err returns the current validation error message, or "".
+func (v ValidatedInput) Err() string { return v.err }
+
+// Pending reports whether an async validation is in flight.
+func (v ValidatedInput) Pending() bool { return v.pending }
+
+// SetErr shows msg as the input's error, e.g. for failures reported after
+// the value was accepted.
+func (v *ValidatedInput) SetErr(msg string) { v.err = msg }
+
+// Focus focuses the underlying text input.
+func (v *ValidatedInput) Focus() tea.Cmd { return v.Input.Focus() }
+
+// Submit validates the current value. Synchronous failures are shown
+// immediately; otherwise the returned command emits InputValidatedMsg,
+// after running the async validator if one is set.
+func (v ValidatedInput) Submit() (ValidatedInput, tea.Cmd) {
+	if v.pending {
+		return v, nil
+	}
+
+	value := v.Value()
+	for _, validate := range v.validators {
+		if err := validate(value); err != nil {
+			v.err = err.Error()
+			return v, nil
+		}
+	}
+	v.err = ""
+
+	id := v.id
+	if v.async == nil {
+		return v, func() tea.Msg { return InputValidatedMsg{ID: id, Value: value} }
+	}
+
+	v.pending = true
+	seq, async := v.seq, v.async
+	return v, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), asyncValidationTimeout)
+		defer cancel()
+		return asyncValidationMsg{id: id, seq: seq, value: value, err: async(ctx, value)}
+	}
+}
+
+// Update forwards msg to the text input and handles async results.
+// Editing the value clears any error and invalidates in-flight checks.
+func (v ValidatedInput) Update(msg tea.Msg) (ValidatedInput, tea.Cmd) {
+	if res, ok := msg.(asyncValidationMsg); ok {
+		if res.id != v.id || res.seq != v.seq {
+			return v, nil
+		}
+		v.pending = false
+		if res.err != nil {
+			v.err = res.err.Error()
+			return v, nil
+		}
+		id, value := v.id, res.value
+		return v, func() tea.Msg { return InputValidatedMsg{ID: id, Value: value} }
+	}
+
+	before := v.Input.Value()
+	var cmd tea.Cmd
+	v.Input, cmd = v.Input.Update(msg)
+	if v.Input.Value() != before {
+		v.err = ""
+		v.pending = false
+		v.seq++
+	}
+	return v, cmd
+}
+
+// View renders the input with its error or pending state below it.
+func (v ValidatedInput) View() string {
+	switch {
+	case v.err != "":
+		return lipgloss.JoinVertical(lipgloss.Left, v.Input.View(), styles.ErrorText.Render(v.err))
+	case v.pending:
+		return lipgloss.JoinVertical(lipgloss.Left, v.Input.View(), styles.MutedText.Render("Checking..."))
+	}
+	return v.Input.View()
+}
+
+// Required returns a validator that rejects empty values with msg.
+func Required(msg string) ValidateFunc {
+	return func(value string) error {
+		if value == "" {
+			return errors.New(msg)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,122 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newTestInput(value string, validators ...ValidateFunc) ValidatedInput {
+	ti := textinput.New()
+	ti.Focus()
+	ti.SetValue(value)
+	return NewValidatedInput("test", ti, validators...)
+}
+
+// run executes cmd and returns its message, or nil.
+func run(cmd tea.Cmd) tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+func TestValidatedInput_SyncFailureShowsError(t *testing.T) {
+	v := newTestInput("  ", Required("Name is required"))
+
+	v, cmd := v.Submit()
+
+	if cmd != nil {
+		t.Error("expected no command on validation failure")
+	}
+	if v.Err() != "Name is required" {
+		t.Errorf("Err = %q, want %q", v.Err(), "Name is required")
+	}
+}
+
+func TestValidatedInput_FirstFailingValidatorWins(t *testing.T) {
+	v := newTestInput("x",
+		func(string) error { return nil },
+		func(string) error { return errors.New("second") },
+		func(string) error { return errors.New("third") },
+	)
+
+	v, _ = v.Submit()
+
+	if v.Err() != "second" {
+		t.Errorf("Err = %q, want %q", v.Err(), "second")
+	}
+}
+
+func TestValidatedInput_SuccessEmitsTrimmedValue(t *testing.T) {
+	v := newTestInput("  web-1  ", Required("required"))
+
+	_, cmd := v.Submit()
+
+	msg, ok := run(cmd).(InputValidatedMsg)
+	if !ok {
+		t.Fatalf("expected InputValidatedMsg, got %T", run(cmd))
+	}
+	if msg.ID != "test" || msg.Value != "web-1" {
+		t.Errorf("unexpected message: %+v", msg)
+	}
+}
+
+func TestValidatedInput_TypingClearsError(t *testing.T) {
+	v := newTestInput("", Required("required"))
+	v, _ = v.Submit()
+
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+
+	if v.Err() != "" {
+		t.Errorf("expected error to clear on typing, got %q", v.Err())
+	}
+}
+
+func TestValidatedInput_AsyncFailure(t *testing.T) {
+	v := newTestInput("web-1").WithAsync(func(_ context.Context, value string) error {
+		return errors.New("taken")
+	})
+
+	v, cmd := v.Submit()
+	if !v.Pending() {
+		t.Fatal("expected pending async validation")
+	}
+
+	v, cmd = v.Update(run(cmd))
+
+	if v.Pending() || v.Err() != "taken" || cmd != nil {
+		t.Errorf("expected async error, got pending=%v err=%q", v.Pending(), v.Err())
+	}
+}
+
+func TestValidatedInput_AsyncSuccess(t *testing.T) {
+	v := newTestInput("web-1").WithAsync(func(context.Context, string) error { return nil })
+
+	v, cmd := v.Submit()
+	v, cmd = v.Update(run(cmd))
+
+	if _, ok := run(cmd).(InputValidatedMsg); !ok {
+		t.Errorf("expected InputValidatedMsg after async success")
+	}
+	if v.Pending() {
+		t.Error("expected pending to clear")
+	}
+}
+
+func TestValidatedInput_StaleAsyncResultIgnored(t *testing.T) {
+	v := newTestInput("web-1").WithAsync(func(context.Context, string) error { return errors.New("taken") })
+
+	v, cmd := v.Submit()
+	result := run(cmd)
+	// The user edits the value before the check returns.
+	v, _ = v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	v, cmd = v.Update(result)
+
+	if v.Err() != "" || cmd != nil {
+		t.Errorf("expected stale result to be ignored, got err=%q", v.Err())
+	}
+}
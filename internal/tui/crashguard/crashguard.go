@@ -0,0 +1,222 @@
+// Package crashguard runs Bubbletea programs so that a panic in a model
+// ends the session cleanly instead of leaving the terminal in alt-screen
+// raw mode.
+//
+// A recovered panic quits the program through Bubbletea's normal shutdown
+// path, which restores the terminal, and writes a crash report to the log
+// directory (~/.config/vpsm/logs on Linux). Program.Run then returns an
+// error that points the user at the report.
+package crashguard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recentMessages is how many of the latest messages a crash report lists.
+const recentMessages = 20
+
+// dirOverride replaces the log directory in tests.
+var dirOverride string
+
+// ViewNamer is implemented by models that can name the screen they are
+// showing. The name is included in crash reports.
+type ViewNamer interface {
+	ViewName() string
+}
+
+// CrashError is returned by Program.Run when the model panicked.
+type CrashError struct {
+	// ReportPath is where the crash report was written, or "" if it
+	// could not be saved.
+	ReportPath string
+	// Panic is the recovered panic value.
+	Panic any
+}
+
+func (e *CrashError) Error() string {
+	if e.ReportPath == "" {
+		return fmt.Sprintf("vpsm hit an unexpected error and had to close (%v); the crash report could not be saved", e.Panic)
+	}
+	return fmt.Sprintf("vpsm hit an unexpected error and had to close. A crash report was saved to %s", e.ReportPath)
+}
+
+// Program is a Bubbletea program whose model is guarded against panics.
+type Program struct {
+	*tea.Program
+	g *guard
+}
+
+// NewProgram wraps m in a guard and returns the program that runs it.
+func NewProgram(m tea.Model, opts ...tea.ProgramOption) *Program {
+	g := &guard{inner: m}
+	p := tea.NewProgram(g, opts...)
+	g.program = p
+	return &Program{Program: p, g: g}
+}
+
+// Run starts the program and blocks until it exits. It returns the final
+// model, unwrapped, or a *CrashError if the model panicked in Init, Update
+// or View.
+func (p *Program) Run() (tea.Model, error) {
+	g := p.g
+	_, err := p.Program.Run()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.crash != nil {
+		return nil, g.crash
+	}
+	return g.inner, err
+}
+
+// guard wraps a model, recovering panics from it and remembering the
+// latest messages it received.
+type guard struct {
+	program *tea.Program
+
+	mu     sync.Mutex
+	inner  tea.Model
+	recent []string
+	crash  *CrashError
+}
+
+func (g *guard) Init() (cmd tea.Cmd) {
+	defer func() {
+		if r := recover(); r != nil {
+			g.recordCrash(r, debug.Stack())
+			cmd = tea.Quit
+		}
+	}()
+	return g.inner.Init()
+}
+
+func (g *guard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if g.crashed() {
+		return g, nil
+	}
+
+	g.remember(msg)
+	defer func() {
+		if r := recover(); r != nil {
+			g.recordCrash(r, debug.Stack())
+			// Bubbletea keeps rendering the returned model until it quits.
+			model, cmd = g, tea.Quit
+		}
+	}()
+
+	updated, cmd := g.inner.Update(msg)
+	g.mu.Lock()
+	g.inner = updated
+	g.mu.Unlock()
+	return g, cmd
+}
+
+func (g *guard) View() (view string) {
+	if g.crashed() {
+		return ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			g.recordCrash(r, debug.Stack())
+			view = ""
+			// View cannot return a command, and Quit blocks until the
+			// event loop reads it, so send it from another goroutine.
+			if g.program != nil {
+				go g.program.Quit()
+			}
+		}
+	}()
+	return g.inner.View()
+}
+
+func (g *guard) crashed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.crash != nil
+}
+
+// remember records a short description of msg. Only the message type and,
+// for non-character keys, the key name are kept so typed text such as
+// API tokens never ends up in a report.
+func (g *guard) remember(msg tea.Msg) {
+	desc := fmt.Sprintf("%T", msg)
+	if key, ok := msg.(tea.KeyMsg); ok && key.Type != tea.KeyRunes {
+		desc += " " + key.String()
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.recent = append(g.recent, desc)
+	if len(g.recent) > recentMessages {
+		g.recent = g.recent[len(g.recent)-recentMessages:]
+	}
+}
+
+func (g *guard) recordCrash(r any, stack []byte) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.crash != nil {
+		return
+	}
+
+	path, _ := writeReport(g.report(r, stack))
+	g.crash = &CrashError{ReportPath: path, Panic: r}
+}
+
+// report formats the crash report. The caller must hold g.mu.
+func (g *guard) report(r any, stack []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "vpsm crash report\n")
+	fmt.Fprintf(&b, "time:  %s\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&b, "model: %T\n", g.inner)
+	if namer, ok := g.inner.(ViewNamer); ok {
+		fmt.Fprintf(&b, "view:  %s\n", namer.ViewName())
+	}
+	fmt.Fprintf(&b, "panic: %v\n", r)
+
+	fmt.Fprintf(&b, "\nlast messages (oldest first):\n")
+	for _, m := range g.recent {
+		fmt.Fprintf(&b, "  %s\n", m)
+	}
+
+	fmt.Fprintf(&b, "\nstack:\n%s", stack)
+	return b.String()
+}
+
+// Dir returns the directory crash reports are written to.
+func Dir() (string, error) {
+	if dirOverride != "" {
+		return dirOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("crashguard: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, "vpsm", "logs"), nil
+}
+
+func writeReport(report string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	name := "crash-" + time.Now().Format("20060102-150405") + ".log"
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(report), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write crash report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,74 @@
+package crashguard
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type panicModel struct {
+	panicOn string
+}
+
+func (m panicModel) Init() tea.Cmd { return nil }
+
+func (m panicModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok && key.String() == m.panicOn {
+		panic("boom")
+	}
+	return m, nil
+}
+
+func (m panicModel) View() string { return "ok" }
+
+func (m panicModel) ViewName() string { return "list" }
+
+func TestGuard_UpdatePanicWritesReportAndQuits(t *testing.T) {
+	dirOverride = t.TempDir()
+	t.Cleanup(func() { dirOverride = "" })
+
+	g := &guard{inner: panicModel{panicOn: "enter"}}
+	g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("secret")})
+	model, cmd := g.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if model != tea.Model(g) {
+		t.Fatalf("expected the guard as the model after a crash, got %#v", model)
+	}
+	if cmd == nil {
+		t.Fatal("expected a quit command")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Errorf("expected tea.QuitMsg, got %T", cmd())
+	}
+	if model.View() != "" {
+		t.Errorf("expected empty view after crash")
+	}
+
+	var crash *CrashError
+	if !errors.As(error(g.crash), &crash) || crash.ReportPath == "" {
+		t.Fatalf("expected crash with report path, got %+v", g.crash)
+	}
+	data, err := os.ReadFile(crash.ReportPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	report := string(data)
+	for _, want := range []string{"panic: boom", "view:  list", "tea.KeyMsg enter", "crashguard.panicModel"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+	if strings.Contains(report, "secret") {
+		t.Errorf("report must not contain typed text:\n%s", report)
+	}
+}
+
+func TestCrashError_MentionsReportPath(t *testing.T) {
+	err := &CrashError{ReportPath: "/tmp/crash.log", Panic: "boom"}
+	if !strings.Contains(err.Error(), "/tmp/crash.log") {
+		t.Errorf("expected report path in %q", err.Error())
+	}
+}
@@ -0,0 +1,80 @@
+// Package events is a small in-process event bus for TUI views.
+//
+// Code that changes a resource (the operations overlay, a delete or create
+// flow) publishes an Event as a Bubbletea message. The message travels the
+// normal Update path, so whichever view is active receives it and decides
+// from its subscriptions whether to refresh. New views plug in by
+// implementing Subscriber and handling Msg; the parent model does not need
+// to know which views care about which resources.
+package events
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Resource names a kind of resource that views display.
+type Resource string
+
+const (
+	ResourceServer    Resource = "server"
+	ResourceDNSRecord Resource = "dns_record"
+)
+
+// Kind describes what happened to a resource.
+type Kind string
+
+const (
+	Created Kind = "created"
+	Changed Kind = "changed"
+	Deleted Kind = "deleted"
+)
+
+// Event reports a change to a single resource.
+type Event struct {
+	Resource Resource
+	Kind     Kind
+	// ID identifies the resource within its kind, e.g. a server ID.
+	ID string
+	// Name is a human-readable name for status messages.
+	Name string
+}
+
+// Msg carries an Event through a Bubbletea program.
+type Msg struct {
+	Event
+}
+
+// Publish returns a command that delivers ev to the program.
+func Publish(ev Event) tea.Cmd {
+	return func() tea.Msg { return Msg{Event: ev} }
+}
+
+// Topic selects the events a subscriber is interested in. An empty ID
+// matches every resource of the given kind.
+type Topic struct {
+	Resource Resource
+	ID       string
+}
+
+// Matches reports whether ev falls under t.
+func (t Topic) Matches(ev Event) bool {
+	if t.Resource != ev.Resource {
+		return false
+	}
+	return t.ID == "" || t.ID == ev.ID
+}
+
+// Subscriber is implemented by views that refresh on resource changes.
+type Subscriber interface {
+	Subscriptions() []Topic
+}
+
+// Wants reports whether s subscribes to ev.
+func Wants(s Subscriber, ev Event) bool {
+	for _, t := range s.Subscriptions() {
+		if t.Matches(ev) {
+			return true
+		}
+	}
+	return false
+}
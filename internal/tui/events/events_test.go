@@ -0,0 +1,43 @@
+package events
+
+import "testing"
+
+type subscriber []Topic
+
+func (s subscriber) Subscriptions() []Topic { return s }
+
+func TestWants(t *testing.T) {
+	server42 := Event{Resource: ResourceServer, Kind: Changed, ID: "42"}
+	server7 := Event{Resource: ResourceServer, Kind: Deleted, ID: "7"}
+	record := Event{Resource: ResourceDNSRecord, Kind: Deleted, ID: "42"}
+
+	tests := []struct {
+		name string
+		sub  subscriber
+		ev   Event
+		want bool
+	}{
+		{"all servers", subscriber{{Resource: ResourceServer}}, server7, true},
+		{"same server", subscriber{{Resource: ResourceServer, ID: "42"}}, server42, true},
+		{"other server", subscriber{{Resource: ResourceServer, ID: "42"}}, server7, false},
+		{"other resource with the same ID", subscriber{{Resource: ResourceServer, ID: "42"}}, record, false},
+		{"any of several topics", subscriber{{Resource: ResourceServer, ID: "1"}, {Resource: ResourceDNSRecord}}, record, true},
+		{"no subscriptions", nil, server42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wants(tt.sub, tt.ev); got != tt.want {
+				t.Errorf("Wants(%v, %+v) = %v, want %v", tt.sub, tt.ev, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	ev := Event{Resource: ResourceServer, Kind: Changed, ID: "42", Name: "web"}
+	msg, ok := Publish(ev)().(Msg)
+	if !ok || msg.Event != ev {
+		t.Errorf("Publish delivered %#v, want Msg{%+v}", msg, ev)
+	}
+}
@@ -0,0 +1,185 @@
+package styles
+
+import (
+	"os"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// --- Output modes ---
+
+// ascii is set when output must stick to plain ASCII: no box-drawing
+// borders, braille spinners, line charts or unicode symbols. It suits CI
+// logs, dumb terminals and screen readers.
+var ascii bool
+
+// inline is set when full-window TUIs draw in the normal screen below
+// the prompt instead of taking over the alternate screen, for terminals
+// without one.
+var inline bool
+
+// accessible is set for screen readers. On top of ASCII output, borders
+// and rules are blank, spinners stand still and views lay their content
+// out linearly.
+var accessible bool
+
+// DisableColor turns colored output off. Styles keep their bold and
+// layout attributes; only colors are dropped.
+func DisableColor() {
+	colorDisabled = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
+
+// colorDisabled records DisableColor so RenderTo does not undo it.
+var colorDisabled bool
+
+// RenderTo detects color support from f instead of stdout, for TUIs that
+// draw on stderr while stdout is captured by the shell.
+func RenderTo(f *os.File) {
+	if colorDisabled {
+		return
+	}
+	out := termenv.NewOutput(f)
+	lipgloss.SetColorProfile(out.EnvColorProfile())
+	lipgloss.SetHasDarkBackground(out.HasDarkBackground())
+}
+
+// SetASCII switches ASCII-only output on or off. It must be called before
+// any TUI is built, since borders are baked into the shared styles.
+func SetASCII(enabled bool) {
+	ascii = enabled
+	applyBorder()
+}
+
+// SetAccessible switches the screen-reader friendly renderer on or off.
+// Turning it on implies ASCII output. Like SetASCII, it must be called
+// before any TUI is built.
+func SetAccessible(enabled bool) {
+	accessible = enabled
+	if enabled {
+		ascii = true
+	}
+	applyBorder()
+}
+
+// applyBorder sets the shared border for the current output mode.
+func applyBorder() {
+	switch {
+	case accessible:
+		Border = lipgloss.HiddenBorder()
+	case ascii:
+		Border = lipgloss.ASCIIBorder()
+	default:
+		Border = lipgloss.RoundedBorder()
+	}
+	Card = Card.Border(Border)
+	CardActive = CardActive.Border(Border)
+	InputFocused = InputFocused.Border(Border)
+	InputBlurred = InputBlurred.Border(Border)
+}
+
+// SetInline switches inline rendering on or off.
+func SetInline(enabled bool) {
+	inline = enabled
+}
+
+// Inline reports whether inline rendering is on.
+func Inline() bool {
+	return inline
+}
+
+// ProgramOptions returns the options for a full-window program: opts
+// plus the alternate screen, unless rendering is inline. The accessible
+// renderer draws inline too, so announcements stay in the terminal's
+// scrollback for screen readers.
+func ProgramOptions(opts ...tea.ProgramOption) []tea.ProgramOption {
+	if inline || accessible {
+		return opts
+	}
+	return append([]tea.ProgramOption{tea.WithAltScreen()}, opts...)
+}
+
+// ASCII reports whether ASCII-only output is on.
+func ASCII() bool {
+	return ascii
+}
+
+// Accessible reports whether the screen-reader friendly renderer is on.
+func Accessible() bool {
+	return accessible
+}
+
+// --- Glyphs ---
+
+// pick returns the unicode glyph, or its ASCII fallback in ASCII mode.
+func pick(unicode, fallback string) string {
+	if ascii {
+		return fallback
+	}
+	return unicode
+}
+
+// Ellipsis marks truncated or in-progress text.
+func Ellipsis() string { return pick("…", "...") }
+
+// Bullet is the dot used for status indicators and marks.
+func Bullet() string { return pick("●", "*") }
+
+// Check marks a successful item.
+func Check() string { return pick("✓", "+") }
+
+// Cross marks a failed item.
+func Cross() string { return pick("✗", "x") }
+
+// Warning prefixes warning banners.
+func Warning() string { return pick("⚠", "!") }
+
+// Breadcrumb separates segments of a header path.
+func Breadcrumb() string { return pick("›", ">") }
+
+// Middot separates inline details and marks pending items.
+func Middot() string { return pick("·", "-") }
+
+// HLine is a single horizontal rule segment. Rules are blank for screen
+// readers, which would otherwise read out every dash.
+func HLine() string {
+	if accessible {
+		return " "
+	}
+	return pick("─", "-")
+}
+
+// Up and Down name the arrow keys in key hints.
+func Up() string   { return pick("↑", "up") }
+func Down() string { return pick("↓", "down") }
+
+// BarFilled and BarEmpty are the cells of progress bars.
+func BarFilled() string { return pick("█", "#") }
+func BarEmpty() string  { return pick("░", "-") }
+
+// EchoRune masks secret input such as API tokens.
+func EchoRune() rune {
+	if ascii {
+		return '*'
+	}
+	return '•'
+}
+
+// Spinner returns the spinner animation to use. The default braille dots
+// become a plain rotating line in ASCII mode and a still "..." in
+// accessible mode.
+func Spinner() spinner.Spinner {
+	if accessible {
+		// A single frame never changes the view, so screen readers are
+		// not interrupted while something loads.
+		return spinner.Spinner{Frames: []string{"..."}, FPS: time.Second}
+	}
+	if ascii {
+		return spinner.Line
+	}
+	return spinner.Dot
+}
@@ -0,0 +1,62 @@
+package styles
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetASCII_SwapsGlyphsAndBorders(t *testing.T) {
+	SetASCII(true)
+	t.Cleanup(func() { SetASCII(false) })
+
+	for _, glyph := range []string{Ellipsis(), Bullet(), Check(), Cross(), Warning(), Breadcrumb(), Middot(), HLine(), Up(), Down(), string(EchoRune())} {
+		for _, r := range glyph {
+			if r > 127 {
+				t.Errorf("glyph %q is not ASCII", glyph)
+			}
+		}
+	}
+	for _, frame := range Spinner().Frames {
+		if strings.ContainsFunc(frame, func(r rune) bool { return r > 127 }) {
+			t.Errorf("spinner frame %q is not ASCII", frame)
+		}
+	}
+
+	card := Card.Render("x")
+	if strings.ContainsFunc(card, func(r rune) bool { return r > 127 }) {
+		t.Errorf("card border is not ASCII:\n%s", card)
+	}
+}
+
+func TestSetASCII_OffRestoresUnicode(t *testing.T) {
+	SetASCII(true)
+	SetASCII(false)
+
+	if Ellipsis() != "…" {
+		t.Errorf("Ellipsis() = %q, want unicode ellipsis", Ellipsis())
+	}
+	if !strings.Contains(Card.Render("x"), "╭") {
+		t.Errorf("expected rounded card border after turning ASCII off")
+	}
+}
+
+func TestSetAccessible_BlanksBordersAndRules(t *testing.T) {
+	SetAccessible(true)
+	t.Cleanup(func() {
+		SetAccessible(false)
+		SetASCII(false)
+	})
+
+	if !ASCII() {
+		t.Error("expected accessible mode to imply ASCII output")
+	}
+	if strings.Trim(Card.Render("x"), " \nx") != "" {
+		t.Errorf("expected a card without visible border:\n%q", Card.Render("x"))
+	}
+	if HLine() != " " {
+		t.Errorf("HLine() = %q, want a blank rule", HLine())
+	}
+	if frames := Spinner().Frames; len(frames) != 1 {
+		t.Errorf("expected a still spinner, got frames %q", frames)
+	}
+}
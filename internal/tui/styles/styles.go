@@ -68,7 +68,7 @@ func StatusStyle(status string) lipgloss.Style {
 // StatusIndicator returns a small dot + status text with appropriate color.
 func StatusIndicator(status string) string {
 	style := StatusStyle(status)
-	dot := style.Render("●")
+	dot := style.Render(Bullet())
 	text := style.Render(status)
 	return dot + " " + text
 }
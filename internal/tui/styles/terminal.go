@@ -0,0 +1,45 @@
+package styles
+
+import (
+	"runtime"
+	"strings"
+)
+
+// MinWidth and MinHeight are the smallest terminal the full-window TUIs
+// are laid out for. Below that their cards and tables overlap, so the
+// accessible renderer, which lays content out line by line, is used.
+const (
+	MinWidth  = 60
+	MinHeight = 16
+)
+
+// TerminalLimits describes what a terminal cannot do.
+type TerminalLimits struct {
+	// NoAltScreen is set for terminals without an alternate screen,
+	// where full-window TUIs must draw inline.
+	NoAltScreen bool
+	// TooSmall is set when the terminal is below MinWidth x MinHeight.
+	TooSmall bool
+}
+
+// DetectLimits inspects the TERM value and the terminal size. A size of
+// zero means it is unknown (output is not a terminal) and is not
+// considered too small.
+func DetectLimits(term string, width, height int) TerminalLimits {
+	var limits TerminalLimits
+	switch {
+	case term == "dumb", term == "linux",
+		strings.HasPrefix(term, "emacs"), strings.HasPrefix(term, "eterm"):
+		// The Linux console and Emacs' terminal modes have no alternate
+		// screen.
+		limits.NoAltScreen = true
+	case term == "" && runtime.GOOS != "windows":
+		// An unset TERM gives nothing to go on. Windows consoles do not
+		// set it but all support the alternate screen.
+		limits.NoAltScreen = true
+	}
+	if width > 0 && height > 0 && (width < MinWidth || height < MinHeight) {
+		limits.TooSmall = true
+	}
+	return limits
+}
@@ -0,0 +1,49 @@
+package styles
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestDetectLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		term          string
+		width, height int
+		want          TerminalLimits
+	}{
+		{"capable terminal", "xterm-256color", 120, 40, TerminalLimits{}},
+		{"dumb terminal", "dumb", 80, 24, TerminalLimits{NoAltScreen: true}},
+		{"linux console", "linux", 80, 24, TerminalLimits{NoAltScreen: true}},
+		{"emacs term mode", "eterm-color", 80, 24, TerminalLimits{NoAltScreen: true}},
+		{"narrow", "xterm", 40, 40, TerminalLimits{TooSmall: true}},
+		{"short", "xterm", 120, 10, TerminalLimits{TooSmall: true}},
+		{"size unknown", "xterm", 0, 0, TerminalLimits{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLimits(tt.term, tt.width, tt.height); got != tt.want {
+				t.Errorf("DetectLimits(%q, %d, %d) = %+v, want %+v", tt.term, tt.width, tt.height, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLimits_UnsetTerm(t *testing.T) {
+	want := runtime.GOOS != "windows"
+	if got := DetectLimits("", 80, 24).NoAltScreen; got != want {
+		t.Errorf("NoAltScreen for unset TERM = %v, want %v", got, want)
+	}
+}
+
+func TestProgramOptions_InlineSkipsAltScreen(t *testing.T) {
+	if got := len(ProgramOptions()); got != 1 {
+		t.Fatalf("expected the alternate screen option, got %d options", got)
+	}
+
+	SetInline(true)
+	t.Cleanup(func() { SetInline(false) })
+	if got := len(ProgramOptions()); got != 0 {
+		t.Errorf("expected no alternate screen when inline, got %d options", got)
+	}
+}
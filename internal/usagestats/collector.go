@@ -0,0 +1,116 @@
+package usagestats
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Events are buffered in memory while a command runs and written in one
+// batch by Flush, so timing a provider call never touches the database.
+var (
+	mu      sync.Mutex
+	pending []Event
+)
+
+// Observe buffers an event until the next Flush.
+func Observe(e Event) {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	mu.Lock()
+	pending = append(pending, e)
+	mu.Unlock()
+}
+
+// Drain returns and clears the buffered events.
+func Drain() []Event {
+	mu.Lock()
+	defer mu.Unlock()
+	events := pending
+	pending = nil
+	return events
+}
+
+// Flush writes buffered events to the local database. Failing to open
+// the database is reported, but the buffer is cleared either way.
+func Flush() error {
+	events := Drain()
+	if len(events) == 0 {
+		return nil
+	}
+	repo, err := Open()
+	if err != nil {
+		return err
+	}
+	defer repo.Close()
+	return repo.Record(events)
+}
+
+// idSegment matches path segments that identify a specific resource, so
+// calls like GET /v1/servers/123 and /v1/servers/456 aggregate together.
+var idSegment = regexp.MustCompile(`^[0-9]+$|^[0-9a-f-]{32,36}$`)
+
+// Transport wraps next so that every request is timed and buffered as a
+// KindProviderCall event for provider. A nil next uses
+// http.DefaultTransport.
+func Transport(provider string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &timingTransport{provider: provider, next: next}
+}
+
+type timingTransport struct {
+	provider string
+	next     http.RoundTripper
+}
+
+func (t *timingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	e := Event{
+		Kind:     KindProviderCall,
+		Provider: t.provider,
+		Name:     req.Method + " " + CallName(req.URL.Path),
+		Duration: time.Since(start),
+		Failed:   err != nil || resp.StatusCode >= 400,
+		At:       start,
+	}
+	if e.Failed && resp != nil {
+		e.RequestID = RequestID(resp.Header)
+	}
+	Observe(e)
+	return resp, err
+}
+
+// RequestIDHeaders are the response headers providers use to identify a
+// request to their support teams, in order of preference.
+var RequestIDHeaders = []string{
+	"X-Correlation-Id", // Hetzner
+	"X-Request-Id",     // DigitalOcean
+	"Cf-Ray",           // Cloudflare
+}
+
+// RequestID returns the first provider request ID found in h, or "".
+func RequestID(h http.Header) string {
+	for _, name := range RequestIDHeaders {
+		if id := h.Get(name); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// CallName normalises an API path by replacing resource IDs with ":id".
+func CallName(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if idSegment.MatchString(s) {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
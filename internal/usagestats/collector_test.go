@@ -0,0 +1,91 @@
+package usagestats
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestCallName(t *testing.T) {
+	tests := map[string]string{
+		"/v1/servers":                   "/v1/servers",
+		"/v1/servers/42":                "/v1/servers/:id",
+		"/v1/servers/42/actions/reboot": "/v1/servers/:id/actions/reboot",
+		"/v1/actions/7f1c2a4e-5b6d-4e8f-9a0b-1c2d3e4f5a6b": "/v1/actions/:id",
+	}
+	for path, want := range tests {
+		if got := CallName(path); got != want {
+			t.Errorf("CallName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestTransport_ObservesCalls(t *testing.T) {
+	Drain()
+	t.Cleanup(func() { Drain() })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-Id", "d5064a1f0bb9de4b")
+		if r.URL.Path == "/v1/servers/9" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Transport("hetzner", nil)}
+	for _, path := range []string{"/v1/servers", "/v1/servers/9"} {
+		resp, err := client.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	events := Drain()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Name != "GET /v1/servers" || events[0].Failed || events[0].RequestID != "" {
+		t.Errorf("unexpected first event: %+v", events[0])
+	}
+	if events[1].Name != "GET /v1/servers/:id" || !events[1].Failed || events[1].Provider != "hetzner" || events[1].RequestID != "d5064a1f0bb9de4b" {
+		t.Errorf("unexpected second event: %+v", events[1])
+	}
+}
+
+func TestRequestID_PrefersProviderHeaders(t *testing.T) {
+	h := http.Header{}
+	if got := RequestID(h); got != "" {
+		t.Errorf("expected no request ID, got %q", got)
+	}
+	h.Set("Cf-Ray", "8a1b2c3d4e5f6789-FRA")
+	if got := RequestID(h); got != "8a1b2c3d4e5f6789-FRA" {
+		t.Errorf("expected the Cloudflare ray ID, got %q", got)
+	}
+	h.Set("X-Request-Id", "req-1")
+	if got := RequestID(h); got != "req-1" {
+		t.Errorf("expected X-Request-Id to win over Cf-Ray, got %q", got)
+	}
+}
+
+func TestFlush_WritesBufferedEvents(t *testing.T) {
+	SetPath(filepath.Join(t.TempDir(), "vpsm.db"))
+	t.Cleanup(ResetPath)
+	Drain()
+
+	Observe(Event{Kind: KindCommand, Name: "server list"})
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	repo, err := Open()
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer repo.Close()
+	stats, _ := repo.Summary()
+	if len(stats) != 1 || stats[0].Name != "server list" {
+		t.Errorf("expected flushed command event, got %+v", stats)
+	}
+}
@@ -0,0 +1,41 @@
+package usagestats
+
+import "time"
+
+// Kind distinguishes what an Event measured.
+type Kind string
+
+const (
+	// KindCommand is one invocation of a vpsm command.
+	KindCommand Kind = "command"
+	// KindProviderCall is one HTTP request to a provider API.
+	KindProviderCall Kind = "provider_call"
+)
+
+// Event is a single timed observation.
+type Event struct {
+	Kind     Kind
+	Name     string // command path ("server list") or call ("GET /v1/servers")
+	Provider string // provider name, "" when not applicable
+	Duration time.Duration
+	Failed   bool
+	At       time.Time
+	// RequestID is the provider's ID for a failed call (see
+	// RequestIDHeaders), so it can be quoted in a support ticket.
+	RequestID string
+}
+
+// Stat aggregates all events sharing a kind, provider and name.
+type Stat struct {
+	Kind     Kind          `json:"kind"`
+	Provider string        `json:"provider,omitempty"`
+	Name     string        `json:"name"`
+	Count    int           `json:"count"`
+	Failures int           `json:"failures"`
+	Avg      time.Duration `json:"avg_ns"`
+	Max      time.Duration `json:"max_ns"`
+	LastSeen time.Time     `json:"last_seen"`
+	// LastFailedRequestID is the provider request ID of the most recent
+	// failed call, if the provider sent one.
+	LastFailedRequestID string `json:"last_failed_request_id,omitempty"`
+}
@@ -0,0 +1,224 @@
+// Package usagestats keeps purely local usage statistics: how often each
+// command runs and how long provider API calls take.
+//
+// Nothing is ever sent anywhere. Events are stored in the SQLite database
+// at ~/.config/vpsm/vpsm.db (shared with actionstore, separate table) so
+// users can spot slow providers and maintainers can ask for the numbers
+// when reproducing performance complaints.
+package usagestats
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	appDir = "vpsm"
+	dbFile = "vpsm.db"
+)
+
+// pathOverride, when non-empty, replaces the default database path.
+// Intended for testing. Use SetPath / ResetPath to manage.
+var pathOverride string
+
+// SetPath overrides the database path. Intended for testing.
+func SetPath(p string) { pathOverride = p }
+
+// ResetPath clears the path override, reverting to the default. Intended for testing.
+func ResetPath() { pathOverride = "" }
+
+// Repository defines the persistence interface for usage events.
+type Repository interface {
+	// Record stores a batch of events.
+	Record(events []Event) error
+
+	// Summary aggregates all stored events, ordered by kind, provider
+	// and name.
+	Summary() ([]Stat, error)
+
+	// Reset deletes all stored events.
+	Reset() error
+
+	// Close releases database resources.
+	Close() error
+}
+
+// SQLiteRepository implements Repository backed by a local SQLite database.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database path.
+func DefaultPath() (string, error) {
+	if pathOverride != "" {
+		return pathOverride, nil
+	}
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("usagestats: unable to determine config directory: %w", err)
+	}
+	return filepath.Join(base, appDir, dbFile), nil
+}
+
+// Open creates or opens the repository at the default path.
+func Open() (*SQLiteRepository, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return OpenAt(path)
+}
+
+// OpenAt creates or opens a SQLite database at the given path.
+// The parent directory is created if it does not exist.
+func OpenAt(path string) (*SQLiteRepository, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("usagestats: failed to create directory %s: %w", dir, err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("usagestats: failed to open database: %w", err)
+	}
+
+	r := &SQLiteRepository{db: db}
+	if err := r.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// migrations are the schema versions of the usage_events table, oldest
+// first. Add changes as new migrations; never edit one that has shipped.
+var migrations = []schema.Migration{
+	{Version: 1, Up: createEventsTable},
+}
+
+// migrate brings the usage_events table up to the latest schema.
+func (r *SQLiteRepository) migrate() error {
+	return schema.Migrate(r.db, "usagestats", migrations)
+}
+
+// createEventsTable creates the usage_events table. Databases from
+// before schema versioning may hold an older table, so it adds any
+// missing columns too.
+func createEventsTable(tx *sql.Tx) error {
+	const ddl = `
+		CREATE TABLE IF NOT EXISTS usage_events (
+			id          INTEGER PRIMARY KEY AUTOINCREMENT,
+			kind        TEXT NOT NULL,
+			provider    TEXT NOT NULL DEFAULT '',
+			name        TEXT NOT NULL,
+			duration_ms INTEGER NOT NULL,
+			failed      INTEGER NOT NULL DEFAULT 0,
+			request_id  TEXT NOT NULL DEFAULT '',
+			created_at  TEXT NOT NULL
+		);
+	`
+	if _, err := tx.Exec(ddl); err != nil {
+		return err
+	}
+
+	for _, column := range []string{
+		"request_id TEXT NOT NULL DEFAULT ''",
+	} {
+		_, err := tx.Exec(`ALTER TABLE usage_events ADD COLUMN ` + column)
+		if err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return err
+		}
+	}
+	return nil
+}
+
+// Record stores a batch of events in a single transaction.
+func (r *SQLiteRepository) Record(events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("usagestats: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, e := range events {
+		at := e.At
+		if at.IsZero() {
+			at = time.Now()
+		}
+		_, err := tx.Exec(`
+			INSERT INTO usage_events (kind, provider, name, duration_ms, failed, request_id, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			string(e.Kind), e.Provider, e.Name, e.Duration.Milliseconds(), e.Failed, e.RequestID,
+			at.UTC().Format(time.RFC3339Nano),
+		)
+		if err != nil {
+			return fmt.Errorf("usagestats: insert failed: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("usagestats: commit failed: %w", err)
+	}
+	return nil
+}
+
+// Summary aggregates all stored events, ordered by kind, provider and name.
+func (r *SQLiteRepository) Summary() ([]Stat, error) {
+	rows, err := r.db.Query(`
+		SELECT kind, provider, name, COUNT(*), SUM(failed),
+		       AVG(duration_ms), MAX(duration_ms), MAX(created_at),
+		       COALESCE((SELECT f.request_id FROM usage_events f
+		                 WHERE f.kind = e.kind AND f.provider = e.provider AND f.name = e.name
+		                   AND f.failed = 1 AND f.request_id != ''
+		                 ORDER BY f.created_at DESC LIMIT 1), '')
+		FROM usage_events e
+		GROUP BY kind, provider, name
+		ORDER BY kind, provider, name`)
+	if err != nil {
+		return nil, fmt.Errorf("usagestats: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []Stat
+	for rows.Next() {
+		var s Stat
+		var kind, lastStr string
+		var avgMS float64
+		var maxMS int64
+		if err := rows.Scan(&kind, &s.Provider, &s.Name, &s.Count, &s.Failures, &avgMS, &maxMS, &lastStr, &s.LastFailedRequestID); err != nil {
+			return nil, fmt.Errorf("usagestats: scan failed: %w", err)
+		}
+		s.Kind = Kind(kind)
+		s.Avg = time.Duration(avgMS * float64(time.Millisecond))
+		s.Max = time.Duration(maxMS) * time.Millisecond
+		s.LastSeen, _ = time.Parse(time.RFC3339Nano, lastStr)
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+// Reset deletes all stored events.
+func (r *SQLiteRepository) Reset() error {
+	if _, err := r.db.Exec(`DELETE FROM usage_events`); err != nil {
+		return fmt.Errorf("usagestats: reset failed: %w", err)
+	}
+	return nil
+}
+
+// Close releases database resources.
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,126 @@
+package usagestats
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nathanbeddoewebdev/vpsm/internal/platform/schema"
+)
+
+func tempRepo(t *testing.T) *SQLiteRepository {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return r
+}
+
+func TestSummary_Empty(t *testing.T) {
+	r := tempRepo(t)
+
+	stats, err := r.Summary()
+	if err != nil {
+		t.Fatalf("Summary failed: %v", err)
+	}
+	if len(stats) != 0 {
+		t.Errorf("expected no stats, got %d", len(stats))
+	}
+}
+
+func TestSummary_Aggregates(t *testing.T) {
+	r := tempRepo(t)
+
+	err := r.Record([]Event{
+		{Kind: KindProviderCall, Provider: "hetzner", Name: "GET /v1/servers", Duration: 100 * time.Millisecond},
+		{Kind: KindProviderCall, Provider: "hetzner", Name: "GET /v1/servers", Duration: 300 * time.Millisecond, Failed: true, RequestID: "d5064a1f0bb9de4b"},
+		{Kind: KindCommand, Name: "server list", Duration: 2 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+
+	stats, err := r.Summary()
+	if err != nil {
+		t.Fatalf("Summary failed: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 stats, got %d: %+v", len(stats), stats)
+	}
+
+	cmd, call := stats[0], stats[1]
+	if cmd.Kind != KindCommand || cmd.Name != "server list" || cmd.Count != 1 {
+		t.Errorf("unexpected command stat: %+v", cmd)
+	}
+	if call.Count != 2 || call.Failures != 1 {
+		t.Errorf("expected 2 calls with 1 failure, got %+v", call)
+	}
+	if call.Avg != 200*time.Millisecond || call.Max != 300*time.Millisecond {
+		t.Errorf("expected avg 200ms max 300ms, got avg %v max %v", call.Avg, call.Max)
+	}
+	if call.LastFailedRequestID != "d5064a1f0bb9de4b" {
+		t.Errorf("expected the failed call's request ID, got %q", call.LastFailedRequestID)
+	}
+}
+
+func TestOpenAt_AddsRequestIDColumn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r1, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	// Simulate a database created before the request_id column existed,
+	// which predates schema versioning too.
+	if _, err := r1.db.Exec(`ALTER TABLE usage_events DROP COLUMN request_id`); err != nil {
+		t.Fatalf("failed to simulate an old schema: %v", err)
+	}
+	if _, err := r1.db.Exec(`DELETE FROM schema_versions`); err != nil {
+		t.Fatalf("clearing schema versions failed: %v", err)
+	}
+	r1.Close()
+
+	r2, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("reopening an old database failed: %v", err)
+	}
+	defer r2.Close()
+	if err := r2.Record([]Event{{Kind: KindProviderCall, Name: "GET /v1/servers", Failed: true, RequestID: "abc"}}); err != nil {
+		t.Fatalf("Record after migration failed: %v", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	r := tempRepo(t)
+	r.Record([]Event{{Kind: KindCommand, Name: "server list"}})
+
+	if err := r.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+
+	stats, _ := r.Summary()
+	if len(stats) != 0 {
+		t.Errorf("expected no stats after reset, got %d", len(stats))
+	}
+}
+
+func TestOpenAt_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vpsm.db")
+	r, err := OpenAt(path)
+	if err != nil {
+		t.Fatalf("OpenAt failed: %v", err)
+	}
+	_, err = r.db.Exec(`UPDATE schema_versions SET version = version + 1 WHERE store = 'usagestats'`)
+	r.Close()
+	if err != nil {
+		t.Fatalf("failed to bump version: %v", err)
+	}
+
+	if _, err := OpenAt(path); !errors.Is(err, schema.ErrTooNew) {
+		t.Errorf("err = %v, want ErrTooNew", err)
+	}
+}
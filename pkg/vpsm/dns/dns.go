@@ -0,0 +1,82 @@
+// Package dns exposes vpsm's provider-agnostic DNS record types,
+// validation and provider interface for use from other Go programs.
+package dns
+
+import (
+	"io"
+
+	"nathanbeddoewebdev/vpsm/internal/dns/domain"
+)
+
+// Provider is implemented by DNS providers (Hetzner DNS, deSEC,
+// Cloudflare).
+type Provider = domain.Provider
+
+// DNSSECProvider extends Provider with zone signing (Cloudflare).
+type DNSSECProvider = domain.DNSSECProvider
+
+// DNSSEC is the signing state of a zone and the records its registrar
+// needs.
+type DNSSEC = domain.DNSSEC
+
+// DSRecord is a delegation signer record to publish at the registrar.
+type DSRecord = domain.DSRecord
+
+// DNSKEY is a zone's public signing key.
+type DNSKEY = domain.DNSKEY
+
+// Zone is a DNS zone hosted by a provider.
+type Zone = domain.Zone
+
+// Quirks describes what a DNS provider accepts.
+type Quirks = domain.Quirks
+
+// Record is a single DNS record within a zone.
+type Record = domain.Record
+
+// RecordType is a DNS resource record type such as "A" or "MX".
+type RecordType = domain.RecordType
+
+// Supported record types.
+const (
+	RecordA     = domain.RecordA
+	RecordAAAA  = domain.RecordAAAA
+	RecordCNAME = domain.RecordCNAME
+	RecordMX    = domain.RecordMX
+	RecordTXT   = domain.RecordTXT
+	RecordNS    = domain.RecordNS
+	RecordSRV   = domain.RecordSRV
+	RecordCAA   = domain.RecordCAA
+)
+
+// ParseRecordType normalises a record type name (e.g. "aaaa" -> AAAA).
+func ParseRecordType(s string) RecordType {
+	return domain.ParseRecordType(s)
+}
+
+// ValidateRecord checks that r is well-formed for its type.
+func ValidateRecord(r Record) error {
+	return domain.ValidateRecord(r)
+}
+
+// Template is a named set of records for a common hosting or verification
+// setup, rendered with Template.Render.
+type Template = domain.Template
+
+// TemplateVar is a value supplied when rendering a Template.
+type TemplateVar = domain.TemplateVar
+
+// LookupTemplate returns the built-in template called name, or nil.
+func LookupTemplate(name string) *Template {
+	return domain.LookupTemplate(name)
+}
+
+// ParseZoneFile reads the records of zone from a BIND zone file.
+func ParseZoneFile(r io.Reader, zone string) ([]Record, error) {
+	return domain.ParseZoneFile(r, zone)
+}
+
+// FormatZoneFile renders records as a BIND zone file for zone.
+func FormatZoneFile(zone string, records []Record) string {
+	return domain.FormatZoneFile(zone, records)
+}
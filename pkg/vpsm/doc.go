@@ -0,0 +1,23 @@
+// Package vpsm is the importable Go SDK for vpsm's multi-provider server
+// abstraction.
+//
+// It exposes the same Provider interfaces the CLI and TUI are built on, so
+// other Go programs can manage servers across providers without shelling
+// out to the vpsm binary:
+//
+//	p, err := vpsm.New("hetzner", os.Getenv("HCLOUD_TOKEN"))
+//	if err != nil {
+//		return err
+//	}
+//	servers, err := p.ListServers(ctx)
+//
+// Optional capabilities are discovered with type assertions against the
+// extension interfaces (CatalogProvider, ActionPoller, MetricsProvider, ...).
+// Errors from every provider wrap the sentinels in this package, so callers
+// can classify failures with errors.Is.
+//
+// The types are aliases of vpsm's internal domain types; values can be
+// passed between this package and the rest of vpsm without conversion.
+// Everything exported here follows semantic versioning; the internal
+// packages do not.
+package vpsm
@@ -0,0 +1,44 @@
+package vpsm
+
+import (
+	"fmt"
+	"sort"
+
+	"nathanbeddoewebdev/vpsm/internal/server/providers"
+	"nathanbeddoewebdev/vpsm/internal/util"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// builtin maps provider names to constructors taking an API token.
+var builtin = map[string]func(token string) Provider{
+	"hetzner": func(token string) Provider {
+		return providers.NewHetznerProvider(hcloud.WithToken(token))
+	},
+	"digitalocean": func(token string) Provider {
+		return providers.NewDigitalOceanProvider(token)
+	},
+}
+
+// New returns the named provider authenticated with token. Provider names
+// are case-insensitive; see Providers for the supported set.
+func New(provider, token string) (Provider, error) {
+	newProvider, ok := builtin[util.NormalizeKey(provider)]
+	if !ok {
+		return nil, fmt.Errorf("vpsm: unknown provider %q", provider)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("vpsm: %s: %w", provider, ErrUnauthorized)
+	}
+	return newProvider(token), nil
+}
+
+// Providers returns the names accepted by New, sorted.
+func Providers() []string {
+	names := make([]string, 0, len(builtin))
+	for name := range builtin {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
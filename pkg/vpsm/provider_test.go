@@ -0,0 +1,39 @@
+package vpsm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNew_Hetzner(t *testing.T) {
+	p, err := New("Hetzner", "token")
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if p.GetDisplayName() != "Hetzner" {
+		t.Errorf("display name = %q, want Hetzner", p.GetDisplayName())
+	}
+	if _, ok := p.(CatalogProvider); !ok {
+		t.Error("expected the Hetzner provider to implement CatalogProvider")
+	}
+}
+
+func TestNew_UnknownProvider(t *testing.T) {
+	if _, err := New("nope", "token"); err == nil {
+		t.Fatal("expected an error for an unknown provider")
+	}
+}
+
+func TestNew_EmptyToken(t *testing.T) {
+	_, err := New("hetzner", "")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestProviders(t *testing.T) {
+	if got := Providers(); !slices.Contains(got, "hetzner") {
+		t.Errorf("Providers() = %v, want it to include hetzner", got)
+	}
+}
@@ -0,0 +1,131 @@
+package vpsm
+
+import (
+	"context"
+
+	shared "nathanbeddoewebdev/vpsm/internal/domain"
+	"nathanbeddoewebdev/vpsm/internal/platform/money"
+	"nathanbeddoewebdev/vpsm/internal/server/domain"
+)
+
+// Provider defines the core operations every VPS provider supports.
+type Provider = domain.Provider
+
+// Extension interfaces implemented by providers that support the
+// corresponding capability. Use a type assertion to check for them.
+type (
+	CatalogProvider       = domain.CatalogProvider
+	CustomImageProvider   = domain.CustomImageProvider
+	NetworkProvider       = domain.NetworkProvider
+	PricingProvider       = domain.PricingProvider
+	InterruptibleProvider = domain.InterruptibleProvider
+	UserDataLimiter       = domain.UserDataLimiter
+	RebootProvider        = domain.RebootProvider
+	ResizeProvider        = domain.ResizeProvider
+	BackupProvider        = domain.BackupProvider
+	FloatingIPProvider    = domain.FloatingIPProvider
+	ReverseDNSProvider    = domain.ReverseDNSProvider
+	SSHKeyManager         = domain.SSHKeyManager
+	ActionPoller          = domain.ActionPoller
+	MetricsProvider       = domain.MetricsProvider
+	ConsoleOutputProvider = domain.ConsoleOutputProvider
+	ConsoleProvider       = domain.ConsoleProvider
+	ServerFilterProvider  = domain.ServerFilterProvider
+)
+
+// Server and catalog types returned by providers.
+type (
+	Server            = domain.Server
+	AttachedResource  = domain.AttachedResource
+	CreateServerOpts  = domain.CreateServerOpts
+	ActionStatus      = domain.ActionStatus
+	Backup            = domain.Backup
+	FloatingIP        = domain.FloatingIP
+	ReverseDNS        = domain.ReverseDNS
+	Console           = domain.Console
+	CreateNetworkOpts = domain.CreateNetworkOpts
+	Location          = domain.Location
+	ServerTypeSpec    = domain.ServerTypeSpec
+	ImageSpec         = domain.ImageSpec
+	SSHKeySpec        = domain.SSHKeySpec
+	NetworkSpec       = domain.NetworkSpec
+	AddonPricing      = domain.AddonPricing
+	CostEstimate      = domain.CostEstimate
+	CostLine          = domain.CostLine
+	Money             = money.Money
+)
+
+// Metrics types returned by MetricsProvider.
+type (
+	MetricType        = domain.MetricType
+	MetricsPoint      = domain.MetricsPoint
+	MetricsTimeSeries = domain.MetricsTimeSeries
+	ServerMetrics     = domain.ServerMetrics
+)
+
+// Label selectors filter servers by their provider labels.
+type (
+	LabelSelector    = domain.LabelSelector
+	LabelRequirement = domain.LabelRequirement
+)
+
+// Metric types accepted by MetricsProvider.GetServerMetrics.
+const (
+	MetricCPU     = domain.MetricCPU
+	MetricDisk    = domain.MetricDisk
+	MetricNetwork = domain.MetricNetwork
+	MetricMemory  = domain.MetricMemory
+)
+
+// Kinds reported in AttachedResource.Kind.
+const (
+	AttachedVolume       = domain.AttachedVolume
+	AttachedFloatingIP   = domain.AttachedFloatingIP
+	AttachedFirewall     = domain.AttachedFirewall
+	AttachedLoadBalancer = domain.AttachedLoadBalancer
+	AttachedNetwork      = domain.AttachedNetwork
+)
+
+// Action status values reported in ActionStatus.Status.
+const (
+	ActionStatusRunning = domain.ActionStatusRunning
+	ActionStatusSuccess = domain.ActionStatusSuccess
+	ActionStatusError   = domain.ActionStatusError
+)
+
+// Sentinel errors wrapped by every provider. Match them with errors.Is.
+var (
+	ErrNotFound     = shared.ErrNotFound
+	ErrUnauthorized = shared.ErrUnauthorized
+	ErrRateLimited  = shared.ErrRateLimited
+	ErrConflict     = shared.ErrConflict
+)
+
+// RequestID returns the provider's ID for the failed request behind err
+// (for example Hetzner's correlation ID), or "" if the provider sent none.
+func RequestID(err error) string {
+	return shared.RequestID(err)
+}
+
+// ParseLabelSelector parses a selector such as "env=prod,tier!=db".
+func ParseLabelSelector(expr string) (LabelSelector, error) {
+	return domain.ParseLabelSelector(expr)
+}
+
+// ListServersMatching returns p's servers whose labels satisfy selector,
+// filtered by the provider's API when it implements ServerFilterProvider.
+func ListServersMatching(ctx context.Context, p Provider, selector LabelSelector) ([]Server, error) {
+	return domain.ListServersMatching(ctx, p, selector)
+}
+
+// ValidateNetworkLocation reports an error when network cannot be attached
+// to a server in loc.
+func ValidateNetworkLocation(network NetworkSpec, loc Location) error {
+	return domain.ValidateNetworkLocation(network, loc)
+}
+
+// ValidateImageArchitecture reports an error when image targets another
+// CPU architecture than serverType, according to the provider's catalog.
+func ValidateImageArchitecture(image, serverType string, types []ServerTypeSpec, images []ImageSpec) error {
+	return domain.ValidateImageArchitecture(image, serverType, types, images)
+}